@@ -5,13 +5,11 @@
 
 import (
 	cfg "github.com/ChainSafe/gossamer/config"
-
-	"github.com/adrg/xdg"
 )
 
 var (
 	// defaultBasePath is the default base directory path for kusama node
-	defaultBasePath = xdg.DataHome + "/gossamer/kusama"
+	defaultBasePath = cfg.ChainBasePath(cfg.DefaultDataDir, cfg.KusamaChain.String())
 	// defaultChainSpec is the default chain-spec json path
 	defaultChainSpec = "./chain/kusama/chain-spec-raw.json"
 )
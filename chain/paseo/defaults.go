@@ -5,12 +5,11 @@
 
 import (
 	cfg "github.com/ChainSafe/gossamer/config"
-	"github.com/adrg/xdg"
 )
 
 var (
 	// defaultBasePath is the default directory base path for paseo node
-	defaultBasePath = xdg.DataHome + "/gossamer/paseo"
+	defaultBasePath = cfg.ChainBasePath(cfg.DefaultDataDir, cfg.PaseoChain.String())
 	// defaultChainSpec is the default chain spec configuration path
 	defaultChainSpec = "./chain/paseo/chain-spec-raw.json"
 )
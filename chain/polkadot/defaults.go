@@ -5,12 +5,11 @@
 
 import (
 	cfg "github.com/ChainSafe/gossamer/config"
-	"github.com/adrg/xdg"
 )
 
 var (
 	// defaultBasePath is default base directory path for polkadot node
-	defaultBasePath = xdg.DataHome + "/gossamer/polkadot"
+	defaultBasePath = cfg.ChainBasePath(cfg.DefaultDataDir, cfg.PolkadotChain.String())
 	// defaultChainSpec is the default chain spec configuration path
 	defaultChainSpec = "./chain/polkadot/chain-spec-raw.json"
 )
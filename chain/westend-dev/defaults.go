@@ -5,12 +5,11 @@
 
 import (
 	cfg "github.com/ChainSafe/gossamer/config"
-	"github.com/adrg/xdg"
 )
 
 var (
 	// defaultBasePath is the default basepath for the westend dev node
-	defaultBasePath = xdg.DataHome + "/gossamer/westend-dev"
+	defaultBasePath = cfg.ChainBasePath(cfg.DefaultDataDir, cfg.WestendDevChain.String())
 	// defaultChainSpec is the default chain spec for the westend dev node
 	defaultChainSpec = "./chain/westend-dev/westend-dev-spec-raw.json"
 )
@@ -4,25 +4,30 @@
 package westendlocal
 
 import (
+	"path/filepath"
+
 	cfg "github.com/ChainSafe/gossamer/config"
-	"github.com/adrg/xdg"
 )
 
 var (
 	// defaultChainSpec is the default chain spec for the westend local node
 	defaultChainSpec = "./chain/westend-local/westend-local-spec-raw.json"
 
+	// defaultBasePath is the directory holding the base path of each westend local node
+	defaultBasePath = cfg.ChainBasePath(cfg.DefaultDataDir, cfg.WestendLocalChain.String())
+
 	// defaultBasePathAlice is the default basepath for the westend local alice node
-	defaultBasePathAlice = xdg.DataHome + "/gossamer/westend-local/alice"
+	defaultBasePathAlice = filepath.Join(defaultBasePath, "alice")
 	// defaultBasePathBob is the default basepath for the westend local bob node
-	defaultBasePathBob = xdg.DataHome + "/gossamer/westend-local/bob"
+	defaultBasePathBob = filepath.Join(defaultBasePath, "bob")
 	// defaultBasePathCharlie is the default basepath for the westend local charlie node
-	defaultBasePathCharlie = xdg.DataHome + "/gossamer/westend-local/charlie"
+	defaultBasePathCharlie = filepath.Join(defaultBasePath, "charlie")
 )
 
 // DefaultConfig returns a westend local node configuration
 func DefaultConfig() *cfg.Config {
 	config := cfg.DefaultConfig()
+	config.BasePath = defaultBasePath
 	config.ChainSpec = defaultChainSpec
 	config.Network.NoMDNS = false
 	config.RPC.RPCExternal = true
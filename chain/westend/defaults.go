@@ -5,12 +5,11 @@
 
 import (
 	cfg "github.com/ChainSafe/gossamer/config"
-	"github.com/adrg/xdg"
 )
 
 var (
 	// defaultBasePath is the default base directory path for westend node
-	defaultBasePath = xdg.DataHome + "/gossamer/westend"
+	defaultBasePath = cfg.ChainBasePath(cfg.DefaultDataDir, cfg.WestendChain.String())
 	// defaultChainSpec is the default chain specification path
 	defaultChainSpec = "./chain/westend/chain-spec-raw.json"
 )
@@ -4,11 +4,20 @@
 package commands
 
 import (
+	"bytes"
+	"encoding/hex"
 	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
 
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
 	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/os"
 	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/ChainSafe/gossamer/pkg/extrinsic"
+	"github.com/ChainSafe/gossamer/pkg/scale"
 	"github.com/spf13/cobra"
 )
 
@@ -16,7 +25,32 @@ func init() {
 	AccountCmd.Flags().String("keystore-path", "", "path to keystore")
 	AccountCmd.Flags().String("keystore-file", "", "name of keystore file to import")
 	AccountCmd.Flags().String("password", "", "password used to encrypt the keystore. Used with --generate or --unlock")
+	AccountCmd.Flags().String("password-file", "", "file containing the password used to encrypt the keystore")
+	AccountCmd.Flags().Bool("password-interactive", false, "prompt for the passwords")
+	AccountCmd.Flags().String("new-password", "", "new password of the keystore file. Used with change-password")
+	AccountCmd.Flags().String("new-password-file", "", "file containing the new password of the keystore file")
 	AccountCmd.Flags().String("scheme", crypto.Sr25519Type, "keyring scheme (sr25519, ed25519, secp256k1)")
+	AccountCmd.Flags().String("kdf", keystore.DefaultKDF,
+		"key derivation function of the keystore password (scrypt, argon2id)")
+	AccountCmd.Flags().String("network", "substrate",
+		"network of the SS58 addresses, either polkadot, kusama, westend, substrate or a prefix number")
+	AccountCmd.Flags().String("signatories", "",
+		"comma separated SS58 addresses or hex account ids of the multisig signatories")
+	AccountCmd.Flags().Uint16("threshold", 0, "number of approvals of the multisig operations")
+	AccountCmd.Flags().String("spawner", "", "SS58 address or hex account id of the creator of the pure proxy")
+	AccountCmd.Flags().Uint8("proxy-type", 0, "index of the proxy type in the ProxyType enum of the runtime")
+	AccountCmd.Flags().Uint16("index", 0, "disambiguation index of the pure proxy")
+	AccountCmd.Flags().Uint32("height", 0, "number of the block the pure proxy is created at")
+	AccountCmd.Flags().Uint32("extrinsic-index", 0, "index in its block of the extrinsic creating the pure proxy")
+	AccountCmd.Flags().String("metadata", "",
+		"file of the runtime metadata, hex encoded as returned by the state_getMetadata RPC method")
+	AccountCmd.Flags().String("call", "", "hex encoded call to wrap")
+	AccountCmd.Flags().String("sender", "", "SS58 address or hex account id of the signatory sending the approval")
+	AccountCmd.Flags().String("timepoint", "",
+		"block number and extrinsic index of the first approval of the multisig operation, as <height>:<index>")
+	AccountCmd.Flags().Uint64("max-ref-time", 0, "maximum computation time of the call dispatched by the multisig")
+	AccountCmd.Flags().Uint64("max-proof-size", 0, "maximum proof size of the call dispatched by the multisig")
+	AccountCmd.Flags().String("real", "", "SS58 address or hex account id of the account the proxy acts for")
 }
 
 // AccountCmd is the command to manage the gossamer keystore
@@ -34,7 +68,19 @@ func init() {
 	gossamer account import --keystore-path=path/to/location --keystore-file=keystore.json
 To import a raw key:
 	gossamer account import-raw --keystore-path=path/to/location --keystore-file=keystore.json
-To list keys: gossamer account list --keystore-path=path/to/location`,
+To list keys: gossamer account list --keystore-path=path/to/location
+To change the password of a keystore file, re-encrypting it with the given key derivation function:
+	gossamer account change-password --keystore-file=path/to/location/keystore/key.key --kdf=argon2id \
+		--password-interactive
+To compute the address of a multisig account:
+	gossamer account multisig --signatories=<address>,<address>,<address> --threshold=2 --network=polkadot
+To compute the address of a pure proxy created with create_pure:
+	gossamer account pure-proxy --spawner=<address> --proxy-type=0 --height=<block number> --extrinsic-index=1
+To wrap a hex encoded call in a multisig approval or a proxy call, with the metadata of the runtime:
+	gossamer account as-multi --metadata=metadata.hex --call=0x0400... --threshold=2 \
+		--signatories=<address>,<address>,<address> --sender=<address> --max-ref-time=1000000000 \
+		--max-proof-size=10000
+	gossamer account proxy --metadata=metadata.hex --call=0x0400... --real=<address> --proxy-type=3`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) == 0 {
 			logger.Errorf("account command cannot be empty")
@@ -58,6 +104,26 @@ func init() {
 			if err := listKeys(cmd); err != nil {
 				return err
 			}
+		case "change-password":
+			if err := changePassword(cmd); err != nil {
+				return err
+			}
+		case "multisig":
+			if err := multisigAccount(cmd); err != nil {
+				return err
+			}
+		case "pure-proxy":
+			if err := pureProxyAccount(cmd); err != nil {
+				return err
+			}
+		case "as-multi":
+			if err := asMultiCall(cmd); err != nil {
+				return err
+			}
+		case "proxy":
+			if err := proxyCall(cmd); err != nil {
+				return err
+			}
 		default:
 			logger.Errorf("invalid account command: %s", args[0])
 			return fmt.Errorf("invalid account command: %s", args[0])
@@ -85,14 +151,19 @@ func generateKeyPair(cmd *cobra.Command) error {
 		return fmt.Errorf("invalid scheme: %s", scheme)
 	}
 
-	password, err := cmd.Flags().GetString("password")
+	password, err := accountPassword(cmd, "password", "password-file", "Enter password to encrypt the keystore:")
+	if err != nil {
+		return err
+	}
+
+	kdf, err := cmd.Flags().GetString("kdf")
 	if err != nil {
-		return fmt.Errorf("failed to get password: %s", err)
+		return fmt.Errorf("failed to get kdf: %s", err)
 	}
 
 	logger.Info("Generating keypair")
 
-	file, err := keystore.GenerateKeypair(scheme, nil, keystorePath, []byte(password))
+	file, err := keystore.GenerateKeypair(scheme, nil, keystorePath, password, kdf)
 	if err != nil {
 		logger.Errorf("failed to generate keypair: %s", err)
 		return err
@@ -156,12 +227,17 @@ func importRawKey(cmd *cobra.Command) error {
 		return fmt.Errorf("invalid scheme: %s", scheme)
 	}
 
-	password, err := cmd.Flags().GetString("password")
+	password, err := accountPassword(cmd, "password", "password-file", "Enter password to encrypt the keystore:")
 	if err != nil {
-		return fmt.Errorf("failed to get password: %s", err)
+		return err
+	}
+
+	kdf, err := cmd.Flags().GetString("kdf")
+	if err != nil {
+		return fmt.Errorf("failed to get kdf: %s", err)
 	}
 
-	file, err := keystore.ImportRawPrivateKey(keystoreFile, scheme, keystorePath, []byte(password))
+	file, err := keystore.ImportRawPrivateKey(keystoreFile, scheme, keystorePath, password, kdf)
 	if err != nil {
 		logger.Errorf("failed to import private key: %s", err)
 		return err
@@ -190,3 +266,381 @@ func listKeys(cmd *cobra.Command) error {
 
 	return nil
 }
+
+// changePassword re-encrypts a keystore file with a new password
+func changePassword(cmd *cobra.Command) error {
+	keystoreFile, err := cmd.Flags().GetString("keystore-file")
+	if err != nil {
+		return fmt.Errorf("failed to get keystore-file: %s", err)
+	}
+	if keystoreFile == "" {
+		return fmt.Errorf("keystore-file cannot be empty")
+	}
+
+	kdf, err := cmd.Flags().GetString("kdf")
+	if err != nil {
+		return fmt.Errorf("failed to get kdf: %s", err)
+	}
+
+	password, err := accountPassword(cmd, "password", "password-file", "Enter current password of the keystore:")
+	if err != nil {
+		return err
+	}
+
+	newPassword, err := accountPassword(cmd, "new-password", "new-password-file", "Enter new password of the keystore:")
+	if err != nil {
+		return err
+	}
+
+	err = keystore.ChangePassword(keystoreFile, password, newPassword, kdf)
+	if err != nil {
+		logger.Errorf("failed to change password: %s", err)
+		return err
+	}
+
+	logger.Infof("keystore file %s encrypted with the new password", keystoreFile)
+
+	return nil
+}
+
+// accountPassword returns the password given by the given password flag, the first line of the
+// file given by the password file flag, or prompted with the message if --password-interactive is set
+func accountPassword(cmd *cobra.Command, passwordFlag, passwordFileFlag, prompt string) ([]byte, error) {
+	password, passwordFile, interactive, err := passwordFlags(cmd, passwordFlag, passwordFileFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case interactive:
+		return getPassword(prompt), nil
+	case passwordFile != "":
+		passwords, err := readPasswordFile(passwordFile)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(passwords[0]), nil
+	default:
+		return []byte(password), nil
+	}
+}
+
+// multisigAccount prints the account id and the SS58 address of the multisig of the
+// signatories and threshold given
+func multisigAccount(cmd *cobra.Command) error {
+	prefix, err := accountNetwork(cmd)
+	if err != nil {
+		return err
+	}
+
+	signatories, err := accountIDsFlag(cmd, "signatories")
+	if err != nil {
+		return err
+	}
+
+	threshold, err := cmd.Flags().GetUint16("threshold")
+	if err != nil {
+		return fmt.Errorf("failed to get threshold: %s", err)
+	}
+
+	accountID, err := extrinsic.NewMultisigAccountID(signatories, threshold)
+	if err != nil {
+		return fmt.Errorf("failed to compute multisig account: %s", err)
+	}
+
+	return printAccountID(accountID, prefix)
+}
+
+// pureProxyAccount prints the account id and the SS58 address of the pure proxy created by
+// the spawner given
+func pureProxyAccount(cmd *cobra.Command) error {
+	prefix, err := accountNetwork(cmd)
+	if err != nil {
+		return err
+	}
+
+	spawner, err := accountIDFlag(cmd, "spawner")
+	if err != nil {
+		return err
+	}
+
+	proxyType, err := cmd.Flags().GetUint8("proxy-type")
+	if err != nil {
+		return fmt.Errorf("failed to get proxy-type: %s", err)
+	}
+
+	index, err := cmd.Flags().GetUint16("index")
+	if err != nil {
+		return fmt.Errorf("failed to get index: %s", err)
+	}
+
+	height, err := cmd.Flags().GetUint32("height")
+	if err != nil {
+		return fmt.Errorf("failed to get height: %s", err)
+	}
+
+	extrinsicIndex, err := cmd.Flags().GetUint32("extrinsic-index")
+	if err != nil {
+		return fmt.Errorf("failed to get extrinsic-index: %s", err)
+	}
+
+	accountID := extrinsic.NewPureProxyAccountID(spawner, proxyType, index, height, extrinsicIndex)
+	return printAccountID(accountID, prefix)
+}
+
+// asMultiCall prints the multisig approval of the call given, and the hash of the call
+// approved with approve_as_multi by the other signatories
+func asMultiCall(cmd *cobra.Command) error {
+	builder, call, err := accountCallBuilder(cmd)
+	if err != nil {
+		return err
+	}
+
+	signatories, err := accountIDsFlag(cmd, "signatories")
+	if err != nil {
+		return err
+	}
+
+	threshold, err := cmd.Flags().GetUint16("threshold")
+	if err != nil {
+		return fmt.Errorf("failed to get threshold: %s", err)
+	}
+
+	sender, err := accountIDFlag(cmd, "sender")
+	if err != nil {
+		return err
+	}
+
+	timepoint, err := timepointFlag(cmd)
+	if err != nil {
+		return err
+	}
+
+	var maxWeight extrinsic.Weight
+	maxWeight.RefTime, err = cmd.Flags().GetUint64("max-ref-time")
+	if err != nil {
+		return fmt.Errorf("failed to get max-ref-time: %s", err)
+	}
+	maxWeight.ProofSize, err = cmd.Flags().GetUint64("max-proof-size")
+	if err != nil {
+		return fmt.Errorf("failed to get max-proof-size: %s", err)
+	}
+
+	wrapped, err := builder.NewAsMultiCall(threshold, signatories, sender, timepoint, call, maxWeight)
+	if err != nil {
+		return fmt.Errorf("failed to build as_multi call: %s", err)
+	}
+
+	err = printCall(wrapped)
+	if err != nil {
+		return err
+	}
+
+	// the other signatories approve the call by its hash with approve_as_multi
+	encodedCall, err := call.MarshalSCALE()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Call hash: %s\n", common.MustBlake2bHash(encodedCall))
+	return nil
+}
+
+// proxyCall prints the proxy call dispatching the call given on behalf of the real account
+func proxyCall(cmd *cobra.Command) error {
+	builder, call, err := accountCallBuilder(cmd)
+	if err != nil {
+		return err
+	}
+
+	realAccount, err := accountIDFlag(cmd, "real")
+	if err != nil {
+		return err
+	}
+
+	// any proxy type allowed to dispatch the call is used if the proxy type is not forced
+	var forceProxyType *uint8
+	if cmd.Flags().Changed("proxy-type") {
+		proxyType, err := cmd.Flags().GetUint8("proxy-type")
+		if err != nil {
+			return fmt.Errorf("failed to get proxy-type: %s", err)
+		}
+		forceProxyType = &proxyType
+	}
+
+	wrapped, err := builder.NewProxyCall(realAccount, forceProxyType, call)
+	if err != nil {
+		return fmt.Errorf("failed to build proxy call: %s", err)
+	}
+
+	return printCall(wrapped)
+}
+
+// accountNetwork returns the SS58 prefix of the network flag
+func accountNetwork(cmd *cobra.Command) (uint16, error) {
+	network, err := cmd.Flags().GetString("network")
+	if err != nil {
+		return 0, fmt.Errorf("failed to get network: %s", err)
+	}
+
+	prefix, err := crypto.ParseSS58Network(network)
+	if err != nil {
+		return 0, fmt.Errorf("invalid network: %s", err)
+	}
+	return prefix, nil
+}
+
+// accountIDFlag returns the account id given by the flag of the name given
+func accountIDFlag(cmd *cobra.Command, name string) (extrinsic.AccountID, error) {
+	value, err := cmd.Flags().GetString(name)
+	if err != nil {
+		return extrinsic.AccountID{}, fmt.Errorf("failed to get %s: %s", name, err)
+	}
+	if value == "" {
+		return extrinsic.AccountID{}, fmt.Errorf("%s cannot be empty", name)
+	}
+
+	accountID, err := parseAccountID(value)
+	if err != nil {
+		return extrinsic.AccountID{}, fmt.Errorf("invalid %s: %s", name, err)
+	}
+	return accountID, nil
+}
+
+// accountIDsFlag returns the comma separated account ids given by the flag of the name given
+func accountIDsFlag(cmd *cobra.Command, name string) ([]extrinsic.AccountID, error) {
+	value, err := cmd.Flags().GetString(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %s", name, err)
+	}
+	if value == "" {
+		return nil, fmt.Errorf("%s cannot be empty", name)
+	}
+
+	values := strings.Split(value, ",")
+	accountIDs := make([]extrinsic.AccountID, len(values))
+	for i, value := range values {
+		accountIDs[i], err = parseAccountID(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %s", name, err)
+		}
+	}
+	return accountIDs, nil
+}
+
+// parseAccountID returns the account id of the SS58 address or of the hex encoded account id
+// given
+func parseAccountID(input string) (extrinsic.AccountID, error) {
+	var accountID []byte
+	var err error
+	if strings.HasPrefix(input, "0x") {
+		accountID, err = common.HexToBytes(input)
+	} else {
+		_, accountID, err = crypto.DecodeSS58(common.Address(input))
+	}
+	if err != nil {
+		return extrinsic.AccountID{}, err
+	}
+
+	if len(accountID) != len(extrinsic.AccountID{}) {
+		return extrinsic.AccountID{}, fmt.Errorf("account id %s is %d bytes long instead of %d",
+			input, len(accountID), len(extrinsic.AccountID{}))
+	}
+	return extrinsic.AccountID(accountID), nil
+}
+
+// timepointFlag returns the timepoint given by the timepoint flag, or nil if it is empty
+func timepointFlag(cmd *cobra.Command) (*extrinsic.Timepoint, error) {
+	value, err := cmd.Flags().GetString("timepoint")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get timepoint: %s", err)
+	}
+	if value == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	height, index, ok := strings.Cut(value, ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid timepoint %s: not in the <height>:<index> format", value)
+	}
+	parsedHeight, err := strconv.ParseUint(height, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timepoint height: %s", err)
+	}
+	parsedIndex, err := strconv.ParseUint(index, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timepoint index: %s", err)
+	}
+	return &extrinsic.Timepoint{Height: uint32(parsedHeight), Index: uint32(parsedIndex)}, nil
+}
+
+// accountCallBuilder returns the extrinsic builder of the runtime metadata of the metadata
+// flag, and the call of the call flag
+func accountCallBuilder(cmd *cobra.Command) (*extrinsic.Builder, extrinsic.Call, error) {
+	metadataFile, err := cmd.Flags().GetString("metadata")
+	if err != nil {
+		return nil, extrinsic.Call{}, fmt.Errorf("failed to get metadata: %s", err)
+	}
+	if metadataFile == "" {
+		return nil, extrinsic.Call{}, fmt.Errorf("metadata cannot be empty")
+	}
+
+	callHex, err := cmd.Flags().GetString("call")
+	if err != nil {
+		return nil, extrinsic.Call{}, fmt.Errorf("failed to get call: %s", err)
+	}
+	encodedCall, err := common.HexToBytes(callHex)
+	if err != nil {
+		return nil, extrinsic.Call{}, fmt.Errorf("invalid call: %s", err)
+	}
+	call, err := extrinsic.DecodeCall(encodedCall)
+	if err != nil {
+		return nil, extrinsic.Call{}, err
+	}
+
+	content, err := os.ReadFile(filepath.Clean(metadataFile))
+	if err != nil {
+		return nil, extrinsic.Call{}, fmt.Errorf("failed to read metadata: %s", err)
+	}
+
+	// the metadata is either raw or hex encoded
+	metadata := content
+	if !bytes.HasPrefix(content, []byte("meta")) {
+		metadata, err = hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(content)), "0x"))
+		if err != nil {
+			return nil, extrinsic.Call{}, fmt.Errorf("failed to decode metadata: %s", err)
+		}
+	}
+
+	registry, err := scale.NewRegistry(metadata)
+	if err != nil {
+		return nil, extrinsic.Call{}, fmt.Errorf("failed to decode metadata: %s", err)
+	}
+	builder, err := extrinsic.NewBuilder(registry)
+	if err != nil {
+		return nil, extrinsic.Call{}, fmt.Errorf("failed to create extrinsic builder: %s", err)
+	}
+	return builder, call, nil
+}
+
+// printAccountID prints the account id and its SS58 address on the network of the prefix given
+func printAccountID(accountID extrinsic.AccountID, prefix uint16) error {
+	address, err := crypto.EncodeSS58(prefix, accountID[:])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Account ID:   0x%x\n", accountID)
+	fmt.Printf("SS58 Address: %s\n", address)
+	return nil
+}
+
+// printCall prints the hex encoded call given
+func printCall(call extrinsic.Call) error {
+	encoded, err := call.MarshalSCALE()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Call:      0x%x\n", encoded)
+	return nil
+}
@@ -5,8 +5,12 @@
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/utils"
 	"github.com/stretchr/testify/require"
 )
 
@@ -132,3 +136,98 @@ func TestAccountList(t *testing.T) {
 	err = rootCmd.Execute()
 	require.NoError(t, err)
 }
+
+// TestAccountChangePassword test "gossamer account change-password --password --new-password --kdf"
+func TestAccountChangePassword(t *testing.T) {
+	testDir := t.TempDir()
+
+	keyFile, err := keystore.GenerateKeypair("", nil, testDir, []byte("VerySecurePassword"), "")
+	require.NoError(t, err)
+
+	rootCmd, err := NewRootCommand()
+	require.NoError(t, err)
+	rootCmd.AddCommand(AccountCmd)
+
+	rootCmd.SetArgs([]string{"account",
+		"change-password",
+		fmt.Sprintf("--keystore-file=%s", keyFile),
+		"--password=VerySecurePassword",
+		"--new-password=EvenMoreSecurePassword",
+		"--kdf=argon2id"})
+
+	err = rootCmd.Execute()
+	require.NoError(t, err)
+
+	keyFiles, err := utils.KeystoreFilepaths(testDir)
+	require.NoError(t, err)
+	require.Len(t, keyFiles, 1)
+
+	_, err = keystore.ReadFromFileAndDecrypt(keyFile, []byte("EvenMoreSecurePassword"))
+	require.NoError(t, err)
+}
+
+// TestAccountMultisigAndProxy test "gossamer account multisig|pure-proxy|as-multi|proxy"
+func TestAccountMultisigAndProxy(t *testing.T) {
+	const (
+		alice   = "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"
+		bob     = "5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty"
+		charlie = "0x90b5ab205c6974c9ea841be688864633dc9ca8a357843eeacf2314649965fe22"
+	)
+
+	metadataFile := filepath.Join(t.TempDir(), "metadata.hex")
+	err := os.WriteFile(metadataFile, []byte("0x00"), 0600)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		args   []string
+		errMsg string
+	}{
+		"multisig": {
+			args: []string{"multisig", "--signatories", alice + "," + bob + "," + charlie, "--threshold=2",
+				"--network=polkadot"},
+		},
+		"multisig_invalid_threshold": {
+			args:   []string{"multisig", "--signatories", alice + "," + bob, "--threshold=3"},
+			errMsg: "failed to compute multisig account: invalid multisig threshold: 3 of 2 signatories",
+		},
+		"multisig_invalid_signatory": {
+			args:   []string{"multisig", "--signatories", alice + ",0x1234", "--threshold=1"},
+			errMsg: "invalid signatories: account id 0x1234 is 2 bytes long instead of 32",
+		},
+		"pure_proxy": {
+			args: []string{"pure-proxy", "--spawner", alice, "--proxy-type=0", "--height=10", "--extrinsic-index=1"},
+		},
+		"pure_proxy_no_spawner": {
+			args:   []string{"pure-proxy", "--spawner="},
+			errMsg: "spawner cannot be empty",
+		},
+		"as_multi_no_metadata": {
+			args:   []string{"as-multi", "--metadata=", "--call=0x0000"},
+			errMsg: "metadata cannot be empty",
+		},
+		"proxy_invalid_call": {
+			args:   []string{"proxy", "--metadata", metadataFile, "--call=0x00", "--real", alice},
+			errMsg: "invalid call: 1 bytes are too short for a call",
+		},
+		"proxy_invalid_metadata": {
+			args:   []string{"proxy", "--metadata", metadataFile, "--call=0x0000", "--real", alice},
+			errMsg: "failed to decode metadata: metadata does not start with the magic number",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rootCmd, err := NewRootCommand()
+			require.NoError(t, err)
+			rootCmd.AddCommand(AccountCmd)
+
+			rootCmd.SetArgs(append([]string{"account"}, testCase.args...))
+			err = rootCmd.Execute()
+			if testCase.errMsg == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, testCase.errMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	BenchmarkStorageCmd.Flags().Int("keys", 1000,
+		"Number of storage keys of the best block state sampled, all of them if 0")
+	BenchmarkMachineCmd.Flags().Duration("duration", 5*time.Second,
+		"Duration of each of the CPU and memory benchmarks")
+	BenchmarkBlockCmd.Flags().Uint("from", 1, "Number of the first block to execute")
+	BenchmarkBlockCmd.Flags().Uint("to", 1, "Number of the last block to execute")
+	BenchmarkBlockCmd.Flags().Uint("repeat", 10, "Number of times each block is executed")
+
+	BenchmarkCmd.AddCommand(BenchmarkStorageCmd, BenchmarkMachineCmd, BenchmarkBlockCmd)
+}
+
+// BenchmarkCmd is the command grouping the node benchmarks
+var BenchmarkCmd = &cobra.Command{
+	Use:   "benchmark",
+	Short: "Benchmark the storage, machine and block execution performance of the node",
+	Long: `The benchmark command measures the performance of the node, giving results
+comparable to those of the substrate benchmark command to calibrate runtime weights.
+Examples:
+	gossamer benchmark storage --base-path ~/.gossamer/westend --keys 5000
+	gossamer benchmark machine --base-path ~/.gossamer/westend
+	gossamer benchmark block --base-path ~/.gossamer/westend --from 100 --to 200`,
+}
+
+// BenchmarkStorageCmd is the command to benchmark the storage reads and writes of the node database
+var BenchmarkStorageCmd = &cobra.Command{
+	Use:   "storage",
+	Short: "Benchmark the latency of storage reads and writes against the node database",
+	Long: `The storage benchmark reads storage keys of the best block state from the node
+database, bypassing the in memory tries, and writes values of the same sizes to a scratch
+table of the database, deleted afterwards. It prints the read and write latencies along
+with the corresponding RocksDbWeight values.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execBenchmarkStorage(cmd)
+	},
+}
+
+// BenchmarkMachineCmd is the command to benchmark the hardware of the machine
+var BenchmarkMachineCmd = &cobra.Command{
+	Use:   "machine",
+	Short: "Benchmark the CPU, memory and disk of the machine against the reference hardware",
+	Long: `The machine benchmark measures the BLAKE2-256 hashing, SR25519 verification, memory copy
+and disk write throughput of the machine, and compares them with the reference hardware of
+Polkadot validators. The disk benchmarks write to the base path directory.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execBenchmarkMachine(cmd)
+	},
+}
+
+// BenchmarkBlockCmd is the command to benchmark the re-execution of historical blocks
+var BenchmarkBlockCmd = &cobra.Command{
+	Use:   "block",
+	Short: "Benchmark the re-execution of historical blocks stored in the node database",
+	Long: `The block benchmark executes each block of the given range on the state of its
+parent read from the node database, without persisting anything, and prints the duration
+of the block executions.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execBenchmarkBlock(cmd)
+	},
+}
+
+// benchmarkBasePath returns the expanded base path of the node, set either with the
+// base-path flag or in the configuration.
+func benchmarkBasePath() (string, error) {
+	if basePath == "" {
+		basePath = config.BasePath
+	}
+
+	if basePath == "" {
+		return "", fmt.Errorf("basepath must be specified")
+	}
+
+	return utils.ExpandDir(basePath), nil
+}
+
+func execBenchmarkStorage(cmd *cobra.Command) error {
+	keys, err := cmd.Flags().GetInt("keys")
+	if err != nil {
+		return fmt.Errorf("failed to get keys: %s", err)
+	}
+	if keys < 0 {
+		return fmt.Errorf("keys must be positive")
+	}
+
+	benchmarkPath, err := benchmarkBasePath()
+	if err != nil {
+		return err
+	}
+
+	result, err := dot.BenchmarkStorage(dot.BenchmarkStorageConfig{
+		BasePath: benchmarkPath,
+		Keys:     keys,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("benchmarked %d keys at state root %s\n", result.Read.Count, result.StateRoot)
+	fmt.Printf("Read time summary [ns]:\n%s\n\n", result.Read)
+	fmt.Printf("Write time summary [ns]:\n%s\n\n", result.Write)
+	fmt.Printf("RocksDbWeight:\n%s\n", result.Weight())
+
+	return nil
+}
+
+func execBenchmarkMachine(cmd *cobra.Command) error {
+	duration, err := cmd.Flags().GetDuration("duration")
+	if err != nil {
+		return fmt.Errorf("failed to get duration: %s", err)
+	}
+	if duration <= 0 {
+		return fmt.Errorf("duration must be positive")
+	}
+
+	benchmarkPath, err := benchmarkBasePath()
+	if err != nil {
+		return err
+	}
+
+	results, err := dot.BenchmarkMachine(dot.BenchmarkMachineConfig{
+		Dir:      benchmarkPath,
+		Duration: duration,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("| Function       |              Score |            Minimum |   Ratio | Result |")
+	fmt.Println("|----------------|--------------------|--------------------|---------|--------|")
+	passed := true
+	for _, result := range results {
+		fmt.Println(result)
+		passed = passed && result.Passed()
+	}
+
+	if !passed {
+		fmt.Println("the hardware does not meet the requirements of a Polkadot validator")
+	}
+
+	return nil
+}
+
+func execBenchmarkBlock(cmd *cobra.Command) error {
+	from, err := cmd.Flags().GetUint("from")
+	if err != nil {
+		return fmt.Errorf("failed to get from: %s", err)
+	}
+
+	to, err := cmd.Flags().GetUint("to")
+	if err != nil {
+		return fmt.Errorf("failed to get to: %s", err)
+	}
+
+	repeat, err := cmd.Flags().GetUint("repeat")
+	if err != nil {
+		return fmt.Errorf("failed to get repeat: %s", err)
+	}
+
+	if from == 0 || from > to {
+		return fmt.Errorf("invalid block range: from %d to %d", from, to)
+	}
+
+	benchmarkPath, err := benchmarkBasePath()
+	if err != nil {
+		return err
+	}
+
+	results, err := dot.BenchmarkBlock(dot.BenchmarkBlockConfig{
+		BasePath: benchmarkPath,
+		From:     from,
+		To:       to,
+		Repeat:   repeat,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		fmt.Printf("block %d (%s) with %d extrinsics executed %d times\n",
+			result.Number, result.Hash, result.Extrinsics, result.Stats.Count)
+		fmt.Printf("Execution time summary [ns]:\n%s\n\n", result.Stats)
+	}
+
+	return nil
+}
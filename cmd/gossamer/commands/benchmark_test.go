@@ -0,0 +1,36 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBenchmarkBlockInvalidRange(t *testing.T) {
+	rootCmd, err := NewRootCommand()
+	require.NoError(t, err)
+	rootCmd.AddCommand(BenchmarkCmd)
+
+	rootCmd.SetArgs([]string{BenchmarkCmd.Name(), BenchmarkBlockCmd.Name(),
+		"--from", "10",
+		"--to", "5",
+	})
+	err = rootCmd.Execute()
+	assert.ErrorContains(t, err, "invalid block range: from 10 to 5")
+}
+
+func TestBenchmarkMachineInvalidDuration(t *testing.T) {
+	rootCmd, err := NewRootCommand()
+	require.NoError(t, err)
+	rootCmd.AddCommand(BenchmarkCmd)
+
+	rootCmd.SetArgs([]string{BenchmarkCmd.Name(), BenchmarkMachineCmd.Name(),
+		"--duration", "0s",
+	})
+	err = rootCmd.Execute()
+	assert.ErrorContains(t, err, "duration must be positive")
+}
@@ -0,0 +1,64 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"fmt"
+
+	cfg "github.com/ChainSafe/gossamer/config"
+	"github.com/ChainSafe/gossamer/dot"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	ChainCmd.AddCommand(ChainListCmd)
+}
+
+// ChainCmd is the command grouping the management of the chains of the data directory
+var ChainCmd = &cobra.Command{
+	Use:   "chain",
+	Short: "Manage the chains of the data directory",
+	Long: `The chain command manages the chains of the data directory, each of them
+having its own base path, holding its chain spec, keystore and database.
+Examples:
+	gossamer chain list
+	gossamer chain list --data-dir ~/gossamer`,
+}
+
+// ChainListCmd is the command to list the chains of the data directory
+var ChainListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the chains of the data directory",
+	Long: `The chain list command lists the chains of the data directory, set with
+the --data-dir flag and defaulting to $XDG_DATA_HOME/gossamer, with the base path,
+name and chain spec id of each of them.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execChainList()
+	},
+}
+
+// execChainList executes the chain list command
+func execChainList() error {
+	chainsDir := cfg.DefaultDataDir
+	if dataDir != "" {
+		chainsDir = utils.ExpandDir(dataDir)
+	}
+
+	chains, err := dot.ListChains(chainsDir)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%d chain(s) in %s\n", len(chains), chainsDir)
+	for _, chain := range chains {
+		status := "not initialised"
+		if chain.Initialised {
+			status = "initialised"
+		}
+		fmt.Printf("%s\t%s (%s)\t%s\n", chain.Path, chain.Name, chain.ID, status)
+	}
+
+	return nil
+}
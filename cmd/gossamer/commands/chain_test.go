@@ -0,0 +1,21 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainListEmptyDataDir(t *testing.T) {
+	rootCmd, err := NewRootCommand()
+	require.NoError(t, err)
+	rootCmd.AddCommand(ChainCmd)
+
+	rootCmd.SetArgs([]string{ChainCmd.Name(), ChainListCmd.Name(), "--data-dir", t.TempDir()})
+	err = rootCmd.Execute()
+	assert.NoError(t, err)
+}
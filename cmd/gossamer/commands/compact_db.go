@@ -0,0 +1,58 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/spf13/cobra"
+)
+
+// CompactDBCmd is the command to compact the databases of the node
+var CompactDBCmd = &cobra.Command{
+	Use:   "compact-db",
+	Short: "Compact the state database and the libp2p datastore to reclaim disk space",
+	Long: `The compact-db command compacts the state database and the libp2p badger datastore
+of the base path, reclaiming the disk space of their deleted and overwritten entries.
+The value log files of the datastore holding more than --badger-gc-discard-ratio of
+stale data are rewritten. The node must be stopped, the databases of a running node
+being compacted with the dev_compactDatabase RPC method.
+Examples:
+	gossamer compact-db --base-path ~/.local/share/gossamer/westend`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execCompactDB(cmd)
+	},
+}
+
+// execCompactDB executes the compact-db command
+func execCompactDB(cmd *cobra.Command) error {
+	discardRatio, err := cmd.Flags().GetFloat64("badger-gc-discard-ratio")
+	if err != nil {
+		return fmt.Errorf("failed to get badger-gc-discard-ratio: %s", err)
+	}
+
+	if discardRatio <= 0 || discardRatio >= 1 {
+		return fmt.Errorf("badger-gc-discard-ratio must be between 0 and 1")
+	}
+
+	if basePath == "" {
+		basePath = config.BasePath
+	}
+
+	if basePath == "" {
+		return fmt.Errorf("basepath must be specified")
+	}
+
+	basePath = utils.ExpandDir(basePath)
+
+	err = dot.CompactDatabases(basePath, discardRatio)
+	if err != nil {
+		return fmt.Errorf("failed to compact databases: %s", err)
+	}
+
+	fmt.Printf("databases of %s compacted\n", basePath)
+	return nil
+}
@@ -0,0 +1,21 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactDBFlags(t *testing.T) {
+	rootCmd, err := NewRootCommand()
+	require.NoError(t, err)
+	rootCmd.AddCommand(CompactDBCmd)
+
+	rootCmd.SetArgs([]string{CompactDBCmd.Name(), "--badger-gc-discard-ratio", "1"})
+	err = rootCmd.Execute()
+	assert.ErrorContains(t, err, "badger-gc-discard-ratio must be between 0 and 1")
+}
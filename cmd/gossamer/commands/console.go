@@ -0,0 +1,94 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ChainSafe/gossamer/internal/console"
+	"github.com/spf13/cobra"
+	terminal "golang.org/x/term"
+)
+
+func init() {
+	ConsoleCmd.Flags().String("uri", "ws://127.0.0.1:8546", "Websocket RPC endpoint of the node")
+}
+
+// ConsoleCmd is the command to start an interactive console connected to a node
+var ConsoleCmd = &cobra.Command{
+	Use:   "console",
+	Short: "Start an interactive console connected to a node",
+	Long: `The console command connects to the websocket RPC endpoint of a node and reads commands to
+query its storage decoded with the runtime metadata, submit calls signed by the development
+accounts, and print the new blocks with their events. The pallets, storage items, calls and
+development accounts are completed with the tab key. The commands are read from the standard
+input without completion if it is not a terminal.
+Examples:
+	gossamer console --uri ws://127.0.0.1:8546
+	echo "storage System Account alice" | gossamer console`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execConsole(cmd)
+	},
+}
+
+func execConsole(cmd *cobra.Command) error {
+	uri, err := cmd.Flags().GetString("uri")
+	if err != nil {
+		return fmt.Errorf("failed to get uri: %s", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	connectCtx, cancelConnect := context.WithTimeout(ctx, 30*time.Second)
+	defer cancelConnect()
+	c, err := console.New(connectCtx, uri, cmd.OutOrStdout())
+	if err != nil {
+		return fmt.Errorf("failed to start console: %w", err)
+	}
+	defer c.Close()
+
+	fd := int(os.Stdin.Fd()) //nolint:gosec
+	if !terminal.IsTerminal(fd) {
+		return c.Run(ctx, cmd.InOrStdin())
+	}
+
+	state, err := terminal.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("failed to set terminal raw mode: %s", err)
+	}
+	defer func() {
+		_ = terminal.Restore(fd, state)
+	}()
+
+	term := terminal.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{os.Stdin, os.Stdout}, "gossamer> ")
+	term.AutoCompleteCallback = c.AutoComplete
+	c.SetOutput(term)
+
+	for {
+		line, err := term.ReadLine()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to read command: %s", err)
+		}
+
+		err = c.Execute(ctx, line)
+		if errors.Is(err, console.ErrExit) {
+			return nil
+		} else if err != nil {
+			_, _ = fmt.Fprintf(term, "error: %s\n", err)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsoleUnreachableNode(t *testing.T) {
+	rootCmd, err := NewRootCommand()
+	require.NoError(t, err)
+	rootCmd.AddCommand(ConsoleCmd)
+
+	rootCmd.SetArgs([]string{ConsoleCmd.Name(), "--uri", "ws://127.0.0.1:1"})
+	err = rootCmd.Execute()
+	assert.ErrorContains(t, err, "failed to start console")
+}
@@ -0,0 +1,219 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	cfg "github.com/ChainSafe/gossamer/config"
+	"github.com/ChainSafe/gossamer/dot"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/genesis"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	ForkOffCmd.Flags().String("uri", "", "HTTP RPC endpoint of the node to fetch the state from")
+	ForkOffCmd.Flags().String("at", "", "Hash of the block whose state is forked, the last finalised block if empty")
+	ForkOffCmd.Flags().String("output", "fork.json", "Path to write the forked raw chain spec to")
+	ForkOffCmd.Flags().String("sudo", "", "Hex encoded account ID set as sudo key, Alice if empty")
+	ForkOffCmd.Flags().StringArray("patch", nil,
+		"Hex encoded key=value to set in the forked state, an empty value deleting the key. Can be repeated")
+	ForkOffCmd.Flags().StringSlice("consensus-pallets", dot.DefaultForkOffConsensusPallets,
+		"Pallets whose storage is kept from the development chain spec")
+	ForkOffCmd.Flags().Bool("start", false, "Start a local node authored by Alice from the forked chain spec")
+}
+
+// ForkOffCmd is the command to fork the state of a live chain into a local development chain
+var ForkOffCmd = &cobra.Command{
+	Use:   "fork-off",
+	Short: "Fork the state of a live chain into a local development chain",
+	Long: `The fork-off command fetches the state of a live chain from a remote node and writes a raw
+chain spec using it as genesis state. The storage of the consensus pallets is kept from the
+development chain given with --chain (westend-dev by default), so the forked chain is authored
+and finalised by its development authorities.
+Examples:
+	gossamer fork-off --uri http://localhost:9933 --output fork.json
+	gossamer fork-off --chain westend-dev --uri http://localhost:9933 --patch 0x1234=0x01 --start`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execForkOff(cmd)
+	},
+}
+
+func execForkOff(cmd *cobra.Command) error {
+	uri, err := cmd.Flags().GetString("uri")
+	if err != nil {
+		return fmt.Errorf("failed to get uri: %s", err)
+	}
+	if uri == "" {
+		return fmt.Errorf("uri must be specified")
+	}
+
+	at, err := cmd.Flags().GetString("at")
+	if err != nil {
+		return fmt.Errorf("failed to get at: %s", err)
+	}
+	var block *common.Hash
+	if at != "" {
+		blockHash, err := common.HexToHash(at)
+		if err != nil {
+			return fmt.Errorf("invalid block hash: %s", err)
+		}
+		block = &blockHash
+	}
+
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return fmt.Errorf("failed to get output: %s", err)
+	}
+
+	sudo, err := parseSudo(cmd)
+	if err != nil {
+		return err
+	}
+
+	patchFlags, err := cmd.Flags().GetStringArray("patch")
+	if err != nil {
+		return fmt.Errorf("failed to get patch: %s", err)
+	}
+	patches := make(map[string]string, len(patchFlags))
+	for _, patch := range patchFlags {
+		key, value, ok := strings.Cut(patch, "=")
+		if !ok {
+			return fmt.Errorf("invalid patch %q: expected key=value", patch)
+		}
+		patches[key] = value
+	}
+
+	consensusPallets, err := cmd.Flags().GetStringSlice("consensus-pallets")
+	if err != nil {
+		return fmt.Errorf("failed to get consensus-pallets: %s", err)
+	}
+
+	start, err := cmd.Flags().GetBool("start")
+	if err != nil {
+		return fmt.Errorf("failed to get start: %s", err)
+	}
+
+	devChain := chain
+	if devChain == "" {
+		devChain = cfg.WestendDevChain.String()
+	}
+	if err := parseChainSpec(devChain); err != nil {
+		return fmt.Errorf("failed to parse chain-spec: %s", err)
+	}
+	if config.ChainSpec == "" {
+		return fmt.Errorf("unknown chain %s", devChain)
+	}
+
+	spec, err := dot.ForkOff(dot.ForkOffConfig{
+		URI:              uri,
+		Block:            block,
+		DevChainSpec:     config.ChainSpec,
+		ConsensusPallets: consensusPallets,
+		Sudo:             sudo,
+		Patches:          patches,
+	})
+	if err != nil {
+		return err
+	}
+
+	jsonSpec, err := json.MarshalIndent(spec, "", "\t")
+	if err != nil {
+		return fmt.Errorf("failed to encode chain spec: %s", err)
+	}
+
+	if err := os.WriteFile(filepath.Clean(output), jsonSpec, 0o600); err != nil {
+		return fmt.Errorf("failed to write chain spec: %s", err)
+	}
+	logger.Infof("forked chain spec written to %s", output)
+
+	if !start {
+		return nil
+	}
+
+	return startForkedNode(output)
+}
+
+// parseSudo returns the account ID of the sudo flag, or the account ID of Alice if it is empty.
+func parseSudo(cmd *cobra.Command) ([]byte, error) {
+	sudo, err := cmd.Flags().GetString("sudo")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sudo: %s", err)
+	}
+
+	if sudo != "" {
+		accountID, err := common.HexToBytes(sudo)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sudo account ID: %s", err)
+		}
+		return accountID, nil
+	}
+
+	keyring, err := keystore.NewSr25519Keyring()
+	if err != nil {
+		return nil, fmt.Errorf("error creating sr22519 keyring: %s", err)
+	}
+	return keyring.Alice().Public().Encode(), nil
+}
+
+// startForkedNode initialises and starts a single authority node using Alice's keys
+// from the forked chain spec given.
+func startForkedNode(specPath string) error {
+	spec, err := genesis.NewGenesisFromJSONRaw(specPath)
+	if err != nil {
+		return fmt.Errorf("failed to load chain spec: %s", err)
+	}
+
+	config = cfg.DefaultConfigFromSpec(spec)
+	config.ChainSpec = specPath
+	if basePath != "" {
+		config.BasePath = basePath
+	}
+	config.BasePath = utils.ExpandDir(config.BasePath)
+	config.Account.Key = "alice"
+	config.Core.Role = common.AuthorityRole
+	config.Network.NoBootstrap = true
+	config.Network.NoMDNS = true
+	config.RPC.UnsafeRPC = true
+
+	if err := cfg.EnsureRoot(config.BasePath); err != nil {
+		return fmt.Errorf("failed to ensure root: %s", err)
+	}
+
+	ks := keystore.NewGlobalKeystore()
+	if err := loadBuiltInTestKeys(config.Account.Key, *ks); err != nil {
+		return fmt.Errorf("error loading built-in test keys: %s", err)
+	}
+
+	isInitialised, err := dot.IsNodeInitialised(config.BasePath)
+	if err != nil {
+		return fmt.Errorf("failed to check is not is initialised: %w", err)
+	}
+
+	if !isInitialised {
+		if err := dot.InitNode(config); err != nil {
+			return fmt.Errorf("failed to initialise node: %s", err)
+		}
+	}
+
+	node, err := dot.NewNode(config, ks)
+	if err != nil {
+		return fmt.Errorf("failed to create node services: %s", err)
+	}
+
+	logger.Info("starting forked node " + node.Name + "...")
+
+	if err := node.Start(); err != nil {
+		return fmt.Errorf("failed to start node: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForkOffMissingURI(t *testing.T) {
+	rootCmd, err := NewRootCommand()
+	require.NoError(t, err)
+	rootCmd.AddCommand(ForkOffCmd)
+
+	rootCmd.SetArgs([]string{ForkOffCmd.Name()})
+	err = rootCmd.Execute()
+	assert.ErrorContains(t, err, "uri must be specified")
+}
+
+func TestForkOffInvalidPatch(t *testing.T) {
+	rootCmd, err := NewRootCommand()
+	require.NoError(t, err)
+	rootCmd.AddCommand(ForkOffCmd)
+
+	rootCmd.SetArgs([]string{ForkOffCmd.Name(),
+		"--uri", "http://localhost:9933",
+		"--patch", "0x01",
+	})
+	err = rootCmd.Execute()
+	assert.ErrorContains(t, err, "invalid patch \"0x01\": expected key=value")
+}
@@ -0,0 +1,54 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/ChainSafe/gossamer/lib/os"
+	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+	"github.com/spf13/cobra"
+)
+
+// InspectRuntimeCmd is the command to list the host functions imported by a runtime
+var InspectRuntimeCmd = &cobra.Command{
+	Use:   "inspect-runtime <runtime.wasm>",
+	Short: "List the host functions imported by a WASM runtime which gossamer does and doesn't implement",
+	Long: `The inspect-runtime command lists the host functions imported by the given WASM runtime,
+split between the ones implemented by gossamer and the missing ones. A runtime importing missing
+host functions can still be run with the --stub-missing-host-functions flag, failing the runtime
+calls using them.
+Example:
+	gossamer inspect-runtime runtime.wasm`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execInspectRuntime(args[0])
+	},
+}
+
+// execInspectRuntime executes the inspect-runtime command
+func execInspectRuntime(runtimeFile string) error {
+	code, err := os.ReadFile(filepath.Clean(runtimeFile))
+	if err != nil {
+		return fmt.Errorf("reading runtime: %w", err)
+	}
+
+	implemented, missing, err := wazero_runtime.HostFunctionImports(code)
+	if err != nil {
+		return fmt.Errorf("inspecting runtime: %w", err)
+	}
+
+	fmt.Printf("Implemented host functions (%d):\n", len(implemented))
+	for _, name := range implemented {
+		fmt.Printf("\t%s\n", name)
+	}
+
+	fmt.Printf("Missing host functions (%d):\n", len(missing))
+	for _, name := range missing {
+		fmt.Printf("\t%s\n", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInspectRuntimeMissingRuntime(t *testing.T) {
+	rootCmd, err := NewRootCommand()
+	require.NoError(t, err)
+	rootCmd.AddCommand(InspectRuntimeCmd)
+
+	rootCmd.SetArgs([]string{InspectRuntimeCmd.Name()})
+	err = rootCmd.Execute()
+	assert.ErrorContains(t, err, "accepts 1 arg(s), received 0")
+}
+
+func TestInspectRuntimeInvalidRuntime(t *testing.T) {
+	rootCmd, err := NewRootCommand()
+	require.NoError(t, err)
+	rootCmd.AddCommand(InspectRuntimeCmd)
+
+	rootCmd.SetArgs([]string{InspectRuntimeCmd.Name(), "does-not-exist.wasm"})
+	err = rootCmd.Execute()
+	assert.ErrorContains(t, err, "reading runtime")
+}
@@ -0,0 +1,533 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/crypto/secp256k1"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/blake2b"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+func init() {
+	for _, cmd := range []*cobra.Command{KeyGenerateCmd, KeyInspectCmd, KeySignCmd, KeyVerifyCmd, KeyVanityCmd} {
+		cmd.Flags().String("scheme", crypto.Sr25519Type, "Cryptography scheme of the key (sr25519, ed25519, secp256k1)")
+	}
+	for _, cmd := range []*cobra.Command{KeyGenerateCmd, KeyInspectCmd, KeyVanityCmd} {
+		cmd.Flags().String("network", "substrate",
+			"Network of the SS58 addresses, either polkadot, kusama, westend, substrate or a prefix number")
+	}
+	for _, cmd := range []*cobra.Command{KeySignCmd, KeyVerifyCmd} {
+		cmd.Flags().String("message", "", "Message to sign or verify, read from the standard input if empty")
+		cmd.Flags().Bool("hex", false, "Decode the message as a hex string")
+	}
+
+	KeyGenerateCmd.Flags().Int("words", 12, "Number of words of the secret phrase (12, 15, 18, 21 or 24)")
+	KeyGenerateCmd.Flags().String("derivation", "", "Derivation path of the key, such as //hard/soft")
+	KeyGenerateCmd.Flags().String("password", "", "Password of the secret phrase")
+	KeyInspectCmd.Flags().Bool("public", false, "Inspect a hex encoded public key or a SS58 address")
+	KeySignCmd.Flags().String("suri", "", "Secret URI of the key to sign with")
+	KeyVanityCmd.Flags().String("pattern", "", "Pattern the SS58 address must contain")
+	KeyGenerateNodeKeyCmd.Flags().String("file", "",
+		"File to save the node key to, in the format of the node.key file of the base path")
+
+	KeyCmd.AddCommand(KeyGenerateCmd, KeyInspectCmd, KeySignCmd, KeyVerifyCmd, KeyVanityCmd, KeyGenerateNodeKeyCmd)
+}
+
+// KeyCmd is the command grouping the key utilities
+var KeyCmd = &cobra.Command{
+	Use:   "key",
+	Short: "Generate, inspect and use keys without a running node",
+	Long: `The key command generates and inspects sr25519, ed25519 and secp256k1 keys, given
+by secret URIs of the form <secret phrase or 0x seed>//hard/soft///password as in substrate,
+signs and verifies messages with them and generates node keys.
+Examples:
+	gossamer key generate --scheme ed25519 --words 24
+	gossamer key inspect "//Alice" --network polkadot
+	gossamer key inspect --public 5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY --network kusama
+	gossamer key sign --suri "//Alice" --message hello
+	gossamer key verify <signature> <public key or address> --message hello
+	gossamer key vanity --pattern abc
+	gossamer key generate-node-key --file ~/.gossamer/westend/node.key`,
+}
+
+// KeyGenerateCmd is the command to generate a key with a new secret phrase
+var KeyGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate a key with a new secret phrase",
+	Long: `The key generate command generates a new BIP39 secret phrase and prints the key
+derived from it along the optional derivation path.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execKeyGenerate(cmd)
+	},
+}
+
+// KeyInspectCmd is the command to inspect a key given by its secret URI or public key
+var KeyInspectCmd = &cobra.Command{
+	Use:   "inspect <secret URI | public key | address>",
+	Short: "Inspect a key given by its secret URI, public key or SS58 address",
+	Long: `The key inspect command prints the public key, account id and SS58 address on the
+given network of the key of the secret URI given, or of the public key or SS58 address given
+with the --public flag.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execKeyInspect(cmd, args[0])
+	},
+}
+
+// KeySignCmd is the command to sign a message
+var KeySignCmd = &cobra.Command{
+	Use:   "sign",
+	Short: "Sign a message with the key of a secret URI",
+	Long: `The key sign command signs the message with the key of the secret URI and prints
+the hex encoded signature. The secp256k1 keys sign the blake2b hash of the message.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execKeySign(cmd)
+	},
+}
+
+// KeyVerifyCmd is the command to verify the signature of a message
+var KeyVerifyCmd = &cobra.Command{
+	Use:   "verify <signature> <public key | address>",
+	Short: "Verify the signature of a message with a public key or SS58 address",
+	Long: `The key verify command verifies the hex encoded signature of the message with the
+hex encoded public key or SS58 address given, the secp256k1 keys requiring a public key.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execKeyVerify(cmd, args[0], args[1])
+	},
+}
+
+// KeyVanityCmd is the command to generate a key with an SS58 address containing a pattern
+var KeyVanityCmd = &cobra.Command{
+	Use:   "vanity",
+	Short: "Generate a key with a SS58 address containing a pattern",
+	Long: `The key vanity command generates secret phrases until the SS58 address of their key
+on the given network contains the pattern, and prints the key. The time it takes grows
+exponentially with the length of the pattern.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execKeyVanity(cmd)
+	},
+}
+
+// KeyGenerateNodeKeyCmd is the command to generate a node key for the p2p identity of the node
+var KeyGenerateNodeKeyCmd = &cobra.Command{
+	Use:   "generate-node-key",
+	Short: "Generate a node key for the p2p identity of the node",
+	Long: `The key generate-node-key command generates a random ed25519 node key and prints
+its peer id and its seed, to use with the --node-key flag, or saves it to the given file.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execKeyGenerateNodeKey(cmd)
+	},
+}
+
+// execKeyGenerate executes the key generate command
+func execKeyGenerate(cmd *cobra.Command) error {
+	scheme, prefix, err := keySchemeAndNetwork(cmd)
+	if err != nil {
+		return err
+	}
+
+	words, err := cmd.Flags().GetInt("words")
+	if err != nil {
+		return fmt.Errorf("failed to get words: %s", err)
+	}
+
+	derivation, err := cmd.Flags().GetString("derivation")
+	if err != nil {
+		return fmt.Errorf("failed to get derivation: %s", err)
+	}
+
+	password, err := cmd.Flags().GetString("password")
+	if err != nil {
+		return fmt.Errorf("failed to get password: %s", err)
+	}
+
+	phrase, err := crypto.NewBIP39MnemonicOfLength(words)
+	if err != nil {
+		return fmt.Errorf("failed to generate secret phrase: %s", err)
+	}
+
+	uri := phrase + derivation
+	if password != "" {
+		uri += "///" + password
+	}
+
+	return printSecretURIKey(cmd.OutOrStdout(), uri, scheme, prefix)
+}
+
+// execKeyInspect executes the key inspect command
+func execKeyInspect(cmd *cobra.Command, input string) error {
+	scheme, prefix, err := keySchemeAndNetwork(cmd)
+	if err != nil {
+		return err
+	}
+
+	public, err := cmd.Flags().GetBool("public")
+	if err != nil {
+		return fmt.Errorf("failed to get public: %s", err)
+	}
+
+	if !public {
+		return printSecretURIKey(cmd.OutOrStdout(), input, scheme, prefix)
+	}
+
+	if !strings.HasPrefix(input, "0x") {
+		// SS58 addresses only hold the account id
+		addressPrefix, addressAccountID, err := crypto.DecodeSS58(common.Address(input))
+		if err != nil {
+			return fmt.Errorf("invalid public key or address: %s", err)
+		}
+
+		address, err := crypto.EncodeSS58(prefix, addressAccountID)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Address `%s` is account:\n", input)
+		fmt.Fprintf(cmd.OutOrStdout(), "  Address network:   %d\n", addressPrefix)
+		fmt.Fprintf(cmd.OutOrStdout(), "  Network ID:        %d\n", prefix)
+		fmt.Fprintf(cmd.OutOrStdout(), "  Account ID:        0x%x\n", addressAccountID)
+		fmt.Fprintf(cmd.OutOrStdout(), "  SS58 Address:      %s\n", address)
+		return nil
+	}
+
+	pub, err := decodePublicKey(input, scheme)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Public key `%s` is account:\n", input)
+	return printPublicKey(cmd.OutOrStdout(), pub, scheme, prefix)
+}
+
+// execKeySign executes the key sign command
+func execKeySign(cmd *cobra.Command) error {
+	scheme, err := keyScheme(cmd)
+	if err != nil {
+		return err
+	}
+
+	uri, err := cmd.Flags().GetString("suri")
+	if err != nil {
+		return fmt.Errorf("failed to get suri: %s", err)
+	}
+	if uri == "" {
+		return fmt.Errorf("suri must be specified")
+	}
+
+	message, err := keyMessage(cmd)
+	if err != nil {
+		return err
+	}
+
+	kp, err := keystore.DecodeKeyPairFromSecretURI(uri, scheme)
+	if err != nil {
+		return fmt.Errorf("invalid secret URI: %s", err)
+	}
+
+	if scheme == crypto.Secp256k1Type {
+		hash := blake2b.Sum256(message)
+		message = hash[:]
+	}
+
+	signature, err := kp.Sign(message)
+	if err != nil {
+		return fmt.Errorf("failed to sign message: %s", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "0x%x\n", signature)
+	return nil
+}
+
+// execKeyVerify executes the key verify command
+func execKeyVerify(cmd *cobra.Command, signatureHex, publicKey string) error {
+	scheme, err := keyScheme(cmd)
+	if err != nil {
+		return err
+	}
+
+	signature, err := common.HexToBytes(signatureHex)
+	if err != nil {
+		return fmt.Errorf("invalid signature: %s", err)
+	}
+
+	message, err := keyMessage(cmd)
+	if err != nil {
+		return err
+	}
+
+	pub, err := decodePublicKey(publicKey, scheme)
+	if err != nil {
+		return err
+	}
+
+	if scheme == crypto.Secp256k1Type {
+		hash := blake2b.Sum256(message)
+		message = hash[:]
+		// the recovery id is not needed to verify the signature
+		if len(signature) == secp256k1.SignatureLengthRecovery {
+			signature = signature[:secp256k1.SignatureLength]
+		}
+	}
+
+	ok, err := pub.Verify(message, signature)
+	if err != nil {
+		return fmt.Errorf("failed to verify signature: %s", err)
+	}
+	if !ok {
+		return errors.New("signature is invalid")
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), "Signature verifies correctly.")
+	return nil
+}
+
+// execKeyVanity executes the key vanity command
+func execKeyVanity(cmd *cobra.Command) error {
+	scheme, prefix, err := keySchemeAndNetwork(cmd)
+	if err != nil {
+		return err
+	}
+
+	pattern, err := cmd.Flags().GetString("pattern")
+	if err != nil {
+		return fmt.Errorf("failed to get pattern: %s", err)
+	}
+	if pattern == "" {
+		return fmt.Errorf("pattern must be specified")
+	}
+	for _, char := range pattern {
+		if !strings.ContainsRune(base58Alphabet, char) {
+			return fmt.Errorf("pattern contains the character %q which is not in the base58 alphabet", char)
+		}
+	}
+
+	for attempts := 1; ; attempts++ {
+		phrase, err := crypto.NewBIP39Mnemonic()
+		if err != nil {
+			return fmt.Errorf("failed to generate secret phrase: %s", err)
+		}
+
+		kp, err := keystore.DecodeKeyPairFromSecretURI(phrase, scheme)
+		if err != nil {
+			return err
+		}
+
+		address, err := crypto.EncodeSS58(prefix, accountID(kp.Public(), scheme))
+		if err != nil {
+			return err
+		}
+
+		if strings.Contains(string(address), pattern) {
+			logger.Infof("found address containing %s after %d attempts", pattern, attempts)
+			return printSecretURIKey(cmd.OutOrStdout(), phrase, scheme, prefix)
+		}
+	}
+}
+
+// execKeyGenerateNodeKey executes the key generate-node-key command
+func execKeyGenerateNodeKey(cmd *cobra.Command) error {
+	file, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return fmt.Errorf("failed to get file: %s", err)
+	}
+
+	seed := make([]byte, ed25519.SeedLength)
+	_, err = io.ReadFull(rand.Reader, seed)
+	if err != nil {
+		return fmt.Errorf("failed to generate node key: %s", err)
+	}
+
+	privateKey, peerID, err := network.NodeKeyFromSeed(seed)
+	if err != nil {
+		return fmt.Errorf("failed to generate node key: %s", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Peer ID:  %s\n", peerID)
+	if file == "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "Node key: %s\n", hex.EncodeToString(seed))
+		return nil
+	}
+
+	err = network.SaveNodeKeyFile(privateKey, file)
+	if err != nil {
+		return fmt.Errorf("failed to save node key: %s", err)
+	}
+
+	logger.Infof("node key saved to %s", file)
+	return nil
+}
+
+// keyScheme returns the cryptography scheme given by the scheme flag
+func keyScheme(cmd *cobra.Command) (crypto.KeyType, error) {
+	scheme, err := cmd.Flags().GetString("scheme")
+	if err != nil {
+		return "", fmt.Errorf("failed to get scheme: %s", err)
+	}
+	if !(scheme == crypto.Ed25519Type || scheme == crypto.Sr25519Type || scheme == crypto.Secp256k1Type) {
+		return "", fmt.Errorf("invalid scheme: %s", scheme)
+	}
+
+	return scheme, nil
+}
+
+// keySchemeAndNetwork returns the cryptography scheme and the SS58 prefix given by the
+// scheme and network flags
+func keySchemeAndNetwork(cmd *cobra.Command) (crypto.KeyType, uint16, error) {
+	scheme, err := keyScheme(cmd)
+	if err != nil {
+		return "", 0, err
+	}
+
+	networkName, err := cmd.Flags().GetString("network")
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to get network: %s", err)
+	}
+
+	prefix, err := crypto.ParseSS58Network(networkName)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid network: %s", err)
+	}
+
+	return scheme, prefix, nil
+}
+
+// keyMessage returns the message given by the message flag, or read from the standard input
+func keyMessage(cmd *cobra.Command) ([]byte, error) {
+	message, err := cmd.Flags().GetString("message")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message: %s", err)
+	}
+
+	isHex, err := cmd.Flags().GetBool("hex")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hex: %s", err)
+	}
+
+	if message == "" {
+		input, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read message: %s", err)
+		}
+		message = string(input)
+	}
+
+	if !isHex {
+		return []byte(message), nil
+	}
+
+	decoded, err := common.HexToBytes(strings.TrimSpace(message))
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex message: %s", err)
+	}
+	return decoded, nil
+}
+
+// decodePublicKey decodes the hex encoded public key or the SS58 address of the given scheme
+func decodePublicKey(input string, scheme crypto.KeyType) (crypto.PublicKey, error) {
+	var encoded []byte
+	var err error
+	if strings.HasPrefix(input, "0x") {
+		encoded, err = common.HexToBytes(input)
+	} else if scheme == crypto.Secp256k1Type {
+		// the account id of the secp256k1 keys is the hash of their public key
+		err = errors.New("secp256k1 public keys must be hex encoded")
+	} else {
+		_, encoded, err = crypto.DecodeSS58(common.Address(input))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key or address: %s", err)
+	}
+
+	var pub crypto.PublicKey
+	switch scheme {
+	case crypto.Sr25519Type:
+		pub, err = sr25519.NewPublicKey(encoded)
+	case crypto.Ed25519Type:
+		pub, err = ed25519.NewPublicKey(encoded)
+	default:
+		pub = new(secp256k1.PublicKey)
+		err = pub.Decode(encoded)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %s", err)
+	}
+
+	return pub, nil
+}
+
+// accountID returns the account id of the public key, which is the blake2b hash of the
+// public key for the secp256k1 keys and the public key itself otherwise
+func accountID(pub crypto.PublicKey, scheme crypto.KeyType) []byte {
+	if scheme == crypto.Secp256k1Type {
+		hash := blake2b.Sum256(pub.Encode())
+		return hash[:]
+	}
+	return pub.Encode()
+}
+
+// printSecretURIKey prints the key of the secret URI given
+func printSecretURIKey(out io.Writer, uri string, scheme crypto.KeyType, prefix uint16) error {
+	secretURI, err := crypto.ParseSecretURI(uri)
+	if err != nil {
+		return err
+	}
+
+	kp, err := keystore.DecodeKeyPairFromSecretURI(uri, scheme)
+	if err != nil {
+		return fmt.Errorf("invalid secret URI: %s", err)
+	}
+
+	if strings.HasPrefix(secretURI.Phrase, "0x") {
+		fmt.Fprintf(out, "Secret Key URI `%s` is account:\n", uri)
+	} else {
+		fmt.Fprintf(out, "Secret phrase:       %s\n", secretURI.Phrase)
+	}
+
+	// the seed of derived keys is not printed, soft derived keys not having one
+	if len(secretURI.Junctions) == 0 {
+		seed, err := secretURI.Seed()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "  Secret seed:       0x%x\n", seed)
+	}
+
+	return printPublicKey(out, kp.Public(), scheme, prefix)
+}
+
+// printPublicKey prints the public key, account id and SS58 address of the public key given
+func printPublicKey(out io.Writer, pub crypto.PublicKey, scheme crypto.KeyType, prefix uint16) error {
+	publicSS58, err := crypto.EncodeSS58(prefix, pub.Encode())
+	if err != nil {
+		return err
+	}
+
+	address, err := crypto.EncodeSS58(prefix, accountID(pub, scheme))
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "  Network ID:        %d\n", prefix)
+	fmt.Fprintf(out, "  Public key (hex):  %s\n", pub.Hex())
+	fmt.Fprintf(out, "  Account ID:        0x%x\n", accountID(pub, scheme))
+	fmt.Fprintf(out, "  Public key (SS58): %s\n", publicSS58)
+	fmt.Fprintf(out, "  SS58 Address:      %s\n", address)
+	return nil
+}
@@ -0,0 +1,187 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+func executeKeyCommand(t *testing.T, args ...string) (output string, err error) {
+	t.Helper()
+
+	rootCmd, err := NewRootCommand()
+	require.NoError(t, err)
+	rootCmd.AddCommand(KeyCmd)
+
+	buffer := bytes.NewBuffer(nil)
+	rootCmd.SetOut(buffer)
+	rootCmd.SetArgs(append([]string{KeyCmd.Name()}, args...))
+	err = rootCmd.Execute()
+	return buffer.String(), err
+}
+
+func TestKeyGenerateAndInspect(t *testing.T) {
+	output, err := executeKeyCommand(t, "generate", "--scheme", "ed25519", "--words", "24", "--derivation", "//stash")
+	require.NoError(t, err)
+	phrase := strings.TrimSpace(strings.TrimPrefix(strings.Split(output, "\n")[0], "Secret phrase:"))
+	assert.Len(t, strings.Fields(phrase), 24)
+
+	// the generated key is the key of its secret phrase and derivation
+	kp, err := keystore.DecodeKeyPairFromSecretURI(phrase+"//stash", crypto.Ed25519Type)
+	require.NoError(t, err)
+	assert.Contains(t, output, "  Public key (hex):  "+kp.Public().Hex()+"\n")
+
+	// vectors of the subkey inspect command
+	const devPhrase = "bottom drive obey lake curtain smoke basket hold race lonely fit walk"
+	testCases := map[string]struct {
+		args           []string
+		expectedOutput string
+	}{
+		"sr25519_dev_phrase": {
+			args: []string{"inspect", devPhrase, "--scheme", "sr25519", "--network", "substrate", "--public=false"},
+			expectedOutput: "Secret phrase:       " + devPhrase + "\n" +
+				"  Secret seed:       0xfac7959dbfe72f052e5a0c3c8d6530f202b02fd8f9f5ca3580ec8deb7797479e\n" +
+				"  Network ID:        42\n" +
+				"  Public key (hex):  0x46ebddef8cd9bb167dc30878d7113b7e168e6f0646beffd77d69d39bad76b47a\n" +
+				"  Account ID:        0x46ebddef8cd9bb167dc30878d7113b7e168e6f0646beffd77d69d39bad76b47a\n" +
+				"  Public key (SS58): 5DfhGyQdFobKM8NsWvEeAKk5EQQgYe9AydgJ7rMB6E1EqRzV\n" +
+				"  SS58 Address:      5DfhGyQdFobKM8NsWvEeAKk5EQQgYe9AydgJ7rMB6E1EqRzV\n",
+		},
+		"ed25519_dev_phrase": {
+			args: []string{"inspect", devPhrase, "--scheme", "ed25519", "--network", "substrate", "--public=false"},
+			expectedOutput: "Secret phrase:       " + devPhrase + "\n" +
+				"  Secret seed:       0xfac7959dbfe72f052e5a0c3c8d6530f202b02fd8f9f5ca3580ec8deb7797479e\n" +
+				"  Network ID:        42\n" +
+				"  Public key (hex):  0x345071da55e5dccefaaa440339415ef9f2663338a38f7da0df21be5ab4e055ef\n" +
+				"  Account ID:        0x345071da55e5dccefaaa440339415ef9f2663338a38f7da0df21be5ab4e055ef\n" +
+				"  Public key (SS58): 5DFJF7tY4bpbpcKPJcBTQaKuCDEPCpiz8TRjpmLeTtweqmXL\n" +
+				"  SS58 Address:      5DFJF7tY4bpbpcKPJcBTQaKuCDEPCpiz8TRjpmLeTtweqmXL\n",
+		},
+		"sr25519_alice_polkadot": {
+			args: []string{"inspect", "//Alice", "--scheme", "sr25519", "--network", "polkadot", "--public=false"},
+			expectedOutput: "Secret phrase:       " + devPhrase + "\n" +
+				"  Network ID:        0\n" +
+				"  Public key (hex):  0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d\n" +
+				"  Account ID:        0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d\n" +
+				"  Public key (SS58): 15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5\n" +
+				"  SS58 Address:      15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5\n",
+		},
+		"ed25519_alice": {
+			args: []string{"inspect", "//Alice", "--scheme", "ed25519", "--network", "substrate", "--public=false"},
+			expectedOutput: "Secret phrase:       " + devPhrase + "\n" +
+				"  Network ID:        42\n" +
+				"  Public key (hex):  0x88dc3417d5058ec4b4503e0c12ea1a0a89be200fe98922423d4334014fa6b0ee\n" +
+				"  Account ID:        0x88dc3417d5058ec4b4503e0c12ea1a0a89be200fe98922423d4334014fa6b0ee\n" +
+				"  Public key (SS58): 5FA9nQDVg267DEd8m1ZypXLBnvN7SFxYwV7ndqSYGiN9TTpu\n" +
+				"  SS58 Address:      5FA9nQDVg267DEd8m1ZypXLBnvN7SFxYwV7ndqSYGiN9TTpu\n",
+		},
+		"alice_address_kusama": {
+			args: []string{"inspect", "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY",
+				"--scheme", "sr25519", "--network", "kusama", "--public"},
+			expectedOutput: "Address `5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY` is account:\n" +
+				"  Address network:   42\n" +
+				"  Network ID:        2\n" +
+				"  Account ID:        0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d\n" +
+				"  SS58 Address:      HNZata7iMYWmk5RvZRTiAsSDhV8366zq2YGb3tLH5Upf74F\n",
+		},
+		"alice_public_key": {
+			args: []string{"inspect", "0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d",
+				"--scheme", "sr25519", "--network", "substrate", "--public"},
+			expectedOutput: "Public key `0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d` is account:\n" +
+				"  Network ID:        42\n" +
+				"  Public key (hex):  0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d\n" +
+				"  Account ID:        0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d\n" +
+				"  Public key (SS58): 5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY\n" +
+				"  SS58 Address:      5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY\n",
+		},
+	}
+
+	for name, testCase := range testCases {
+		output, err := executeKeyCommand(t, testCase.args...)
+		require.NoError(t, err, name)
+		assert.Equal(t, testCase.expectedOutput, output, name)
+	}
+}
+
+func TestKeySignAndVerify(t *testing.T) {
+	for _, scheme := range []crypto.KeyType{crypto.Sr25519Type, crypto.Ed25519Type, crypto.Secp256k1Type} {
+		output, err := executeKeyCommand(t, "sign", "--suri", "//Alice", "--scheme", scheme, "--message", "hello")
+		require.NoError(t, err)
+		printedSignature, err := common.HexToBytes(strings.TrimSpace(output))
+		require.NoError(t, err)
+
+		kp, err := keystore.DecodeKeyPairFromSecretURI("//Alice", scheme)
+		require.NoError(t, err)
+
+		message := []byte("hello")
+		if scheme == crypto.Secp256k1Type {
+			hash := blake2b.Sum256(message)
+			message = hash[:]
+		}
+		signature, err := kp.Sign(message)
+		require.NoError(t, err)
+		if scheme == crypto.Ed25519Type {
+			// ed25519 signatures are deterministic
+			assert.Equal(t, signature, printedSignature)
+		}
+
+		// the printed signature is verified by the public key of the secret URI
+		ok, err := kp.Public().Verify(message, printedSignature[:min(len(printedSignature), 64)])
+		require.NoError(t, err)
+		assert.True(t, ok, scheme)
+
+		output, err = executeKeyCommand(t, "verify", fmt.Sprintf("0x%x", signature), kp.Public().Hex(),
+			"--scheme", scheme, "--message", "hello")
+		assert.NoError(t, err, scheme)
+		assert.Equal(t, "Signature verifies correctly.\n", output)
+
+		_, err = executeKeyCommand(t, "verify", fmt.Sprintf("0x%x", signature), kp.Public().Hex(),
+			"--scheme", scheme, "--message", "bye")
+		assert.EqualError(t, err, "signature is invalid", scheme)
+	}
+}
+
+func TestKeyVanity(t *testing.T) {
+	// every substrate address starts with 5
+	output, err := executeKeyCommand(t, "vanity", "--pattern", "5", "--network", "substrate", "--scheme", "sr25519")
+	assert.NoError(t, err)
+	assert.Contains(t, output, "  SS58 Address:      5")
+
+	_, err = executeKeyCommand(t, "vanity", "--pattern", "0l", "--network", "substrate", "--scheme", "sr25519")
+	assert.EqualError(t, err, "pattern contains the character '0' which is not in the base58 alphabet")
+}
+
+func TestKeyGenerateNodeKey(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "node.key")
+
+	output, err := executeKeyCommand(t, "generate-node-key", "--file", file)
+	require.NoError(t, err)
+	assert.FileExists(t, file)
+	assert.True(t, strings.HasPrefix(output, "Peer ID:  12D3KooW"), output)
+}
+
+func TestKeyFlags(t *testing.T) {
+	_, err := executeKeyCommand(t, "generate", "--scheme", "rsa")
+	assert.EqualError(t, err, "invalid scheme: rsa")
+
+	_, err = executeKeyCommand(t, "generate", "--scheme", "sr25519", "--network", "unknown")
+	assert.ErrorContains(t, err, "invalid network")
+
+	_, err = executeKeyCommand(t, "generate", "--network", "substrate", "--words", "13")
+	assert.EqualError(t, err, "failed to generate secret phrase: invalid number of words: 13")
+
+	_, err = executeKeyCommand(t, "sign", "--suri", "", "--scheme", "sr25519", "--message", "hello")
+	assert.EqualError(t, err, "suri must be specified")
+}
@@ -0,0 +1,99 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ChainSafe/gossamer/dot"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	ReplayBlockCmd.Flags().Bool("trace", false,
+		"Print the runtime calls and the storage accesses made while replaying the block")
+}
+
+// ReplayBlockCmd is the command to replay a block stored in the node database
+var ReplayBlockCmd = &cobra.Command{
+	Use:   "replay-block <block hash>",
+	Short: "Re-execute a stored block and compare its computed roots to its header",
+	Long: `The replay-block command re-executes the block with the given hash stored in the node
+database on the state of its parent, without persisting anything, to diagnose the blocks failing
+to import with a state root mismatch. It prints the result of each extrinsic, the storage entries
+modified by the block and the state and extrinsics roots computed, compared to the ones of the
+stored header. The --trace flag prints each runtime call and storage access made by the runtime.
+Examples:
+	gossamer replay-block --base-path ~/.gossamer/westend <block hash>
+	gossamer replay-block --base-path ~/.gossamer/westend --trace <block hash>`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execReplayBlock(cmd, args[0])
+	},
+}
+
+// execReplayBlock executes the replay-block command
+func execReplayBlock(cmd *cobra.Command, blockHash string) error {
+	hash, err := common.HexToHash(blockHash)
+	if err != nil {
+		return fmt.Errorf("invalid block hash: %s", err)
+	}
+
+	traceEnabled, err := cmd.Flags().GetBool("trace")
+	if err != nil {
+		return fmt.Errorf("failed to get trace: %s", err)
+	}
+
+	var trace io.Writer
+	if traceEnabled {
+		trace = os.Stdout
+	}
+
+	if basePath == "" {
+		basePath = config.BasePath
+	}
+
+	if basePath == "" {
+		return fmt.Errorf("basepath must be specified")
+	}
+
+	result, err := dot.ReplayBlock(dot.ReplayBlockConfig{
+		BasePath: utils.ExpandDir(basePath),
+		Hash:     hash,
+		Trace:    trace,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("replayed block %d (%s) with %d extrinsics\n", result.Number, result.Hash, len(result.Extrinsics))
+	for _, extrinsic := range result.Extrinsics {
+		fmt.Printf("extrinsic %d: 0x%x\n", extrinsic.Index, extrinsic.Result)
+	}
+
+	fmt.Printf("%d storage entries changed\n", len(result.Diffs))
+	for _, diff := range result.Diffs {
+		switch {
+		case diff.Before == nil:
+			fmt.Printf("+ 0x%x: 0x%x\n", diff.Key, diff.After)
+		case diff.After == nil:
+			fmt.Printf("- 0x%x: 0x%x\n", diff.Key, diff.Before)
+		default:
+			fmt.Printf("~ 0x%x: 0x%x -> 0x%x\n", diff.Key, diff.Before, diff.After)
+		}
+	}
+
+	fmt.Printf("state root: stored %s, computed %s\n", result.StoredStateRoot, result.ComputedStateRoot)
+	fmt.Printf("extrinsics root: stored %s, computed %s\n",
+		result.StoredExtrinsicsRoot, result.ComputedExtrinsicsRoot)
+
+	if !result.StateRootMatches() || !result.ExtrinsicsRootMatches() {
+		return fmt.Errorf("computed roots do not match the stored header of block %s", result.Hash)
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayBlockInvalidHash(t *testing.T) {
+	rootCmd, err := NewRootCommand()
+	require.NoError(t, err)
+	rootCmd.AddCommand(ReplayBlockCmd)
+
+	rootCmd.SetArgs([]string{ReplayBlockCmd.Name(), "wrong"})
+	err = rootCmd.Execute()
+	assert.ErrorContains(t, err, "invalid block hash")
+}
@@ -0,0 +1,81 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	RevertCmd.Flags().Uint("blocks", 0, "Number of blocks to revert the chain by")
+	RevertCmd.Flags().String("to", "", "Hash of the block to revert the chain to, instead of a number of blocks")
+}
+
+// RevertCmd is the command to revert the chain
+var RevertCmd = &cobra.Command{
+	Use:   "revert",
+	Short: "Revert the chain by a number of blocks or to a given block",
+	Long: `The revert command reverts the chain stored in the database of the base path,
+by the number of blocks given with --blocks or to the block given with --to, to recover
+from importing a bad fork without a full resync. The headers, bodies and justifications
+of the reverted blocks are deleted, and the state trie nodes left unused can be removed
+with the prune-state command. The node must be stopped.
+Examples:
+	gossamer revert --base-path ~/.local/share/gossamer/westend --blocks 10
+	gossamer revert --base-path ~/.local/share/gossamer/westend --to <block hash>`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execRevert(cmd)
+	},
+}
+
+// execRevert executes the revert command
+func execRevert(cmd *cobra.Command) error {
+	blocks, err := cmd.Flags().GetUint("blocks")
+	if err != nil {
+		return fmt.Errorf("failed to get blocks: %s", err)
+	}
+
+	toHex, err := cmd.Flags().GetString("to")
+	if err != nil {
+		return fmt.Errorf("failed to get to: %s", err)
+	}
+
+	var to *common.Hash
+	if toHex != "" {
+		if blocks != 0 {
+			return fmt.Errorf("only one of blocks and to must be specified")
+		}
+
+		hash, err := common.HexToHash(toHex)
+		if err != nil {
+			return fmt.Errorf("invalid block hash: %s", err)
+		}
+		to = &hash
+	} else if blocks == 0 {
+		return fmt.Errorf("blocks or to must be specified")
+	}
+
+	if basePath == "" {
+		basePath = config.BasePath
+	}
+
+	if basePath == "" {
+		return fmt.Errorf("basepath must be specified")
+	}
+
+	basePath = utils.ExpandDir(basePath)
+
+	header, err := dot.RevertChain(basePath, blocks, to)
+	if err != nil {
+		return fmt.Errorf("failed to revert chain: %s", err)
+	}
+
+	fmt.Printf("chain reverted to block %d with hash %s\n", header.Number, header.Hash())
+	return nil
+}
@@ -0,0 +1,47 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevertFlags(t *testing.T) {
+	testCases := map[string]struct {
+		args   []string
+		errMsg string
+	}{
+		"no_blocks_nor_to": {
+			errMsg: "blocks or to must be specified",
+		},
+		"blocks_and_to": {
+			args:   []string{"--blocks", "1", "--to", "0x01"},
+			errMsg: "only one of blocks and to must be specified",
+		},
+		"invalid_to": {
+			args:   []string{"--to", "wrong"},
+			errMsg: "invalid block hash",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			// reset the flags set by the previous test cases
+			require.NoError(t, RevertCmd.Flags().Set("blocks", "0"))
+			require.NoError(t, RevertCmd.Flags().Set("to", ""))
+
+			rootCmd, err := NewRootCommand()
+			require.NoError(t, err)
+			rootCmd.AddCommand(RevertCmd)
+
+			rootCmd.SetArgs(append([]string{RevertCmd.Name()}, testCase.args...))
+			err = rootCmd.Execute()
+			assert.ErrorContains(t, err, testCase.errMsg)
+		})
+	}
+}
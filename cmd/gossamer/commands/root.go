@@ -16,6 +16,7 @@
 	"github.com/ChainSafe/gossamer/internal/log"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 // Package level variables
@@ -47,6 +48,12 @@
 	// RPC Config
 	// RPC modules to enable
 	rpcModules string
+	// RPC CORS origins to allow
+	rpcCors string
+
+	// Pprof Config
+	// pprof when set, enables the pprof and diagnostics server
+	pprof bool
 )
 
 // Flag values for persistent flags
@@ -59,12 +66,13 @@
 	// Initialization flags for node
 	chain    string
 	basePath string
+	dataDir  string
 )
 
 // Default values
 const (
 	// DefaultHomeEnv is the default environment variable for the base path
-	DefaultHomeEnv = "GSSMRHOME"
+	DefaultHomeEnv = "GOSSAMER_HOME"
 )
 
 // NewRootCommand creates the root command
@@ -98,7 +106,7 @@ func NewRootCommand() (*cobra.Command, error) {
 
 			parseAccount()
 
-			if err := parseRole(); err != nil {
+			if err := parseRole(cmd); err != nil {
 				return fmt.Errorf("failed to parse role: %s", err)
 			}
 
@@ -108,6 +116,8 @@ func NewRootCommand() (*cobra.Command, error) {
 
 			parseRPC()
 
+			parsePprof()
+
 			// If no chain-spec is provided, it should already exist in the base-path
 			// If a chain-spec is provided, it should be copied to the base-path
 			if config.ChainSpec == "" {
@@ -158,11 +168,16 @@ func addRootFlags(cmd *cobra.Command) error {
 	cmd.PersistentFlags().StringVar(&basePath,
 		"base-path",
 		"",
-		"The base path for the node. Defaults to $GSSMRHOME if set")
+		"The base path for the node. Defaults to $GOSSAMER_HOME if set")
 	cmd.PersistentFlags().StringVar(&chain,
 		"chain",
 		"",
-		"The default chain configuration to load. Example: --chain kusama")
+		"The default chain configuration to load, by name, chain spec id or chain spec path. Example: --chain kusama")
+	cmd.PersistentFlags().StringVar(&dataDir,
+		"data-dir",
+		"",
+		"The directory holding the base path of each chain, used if the base path is not set. "+
+			"Defaults to $XDG_DATA_HOME/gossamer")
 
 	// Base Config
 	if err := addBaseConfigFlags(cmd); err != nil {
@@ -209,6 +224,11 @@ func addRootFlags(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to add pprof flags: %s", err)
 	}
 
+	// Collator Config
+	if err := addCollatorFlags(cmd); err != nil {
+		return fmt.Errorf("failed to add collator flags: %s", err)
+	}
+
 	return nil
 }
 
@@ -249,6 +269,42 @@ func addBaseConfigFlags(cmd *cobra.Command) error {
 		"prometheus-external"); err != nil {
 		return fmt.Errorf("failed to add --prometheus-external flag: %s", err)
 	}
+	if err := addStringFlagBindViper(cmd,
+		"traces-file",
+		config.BaseConfig.TracesFile,
+		"File the OpenTelemetry trace spans are exported to as JSON, tracing is disabled if empty",
+		"traces-file"); err != nil {
+		return fmt.Errorf("failed to add --traces-file flag: %s", err)
+	}
+	if err := addStringFlagBindViper(cmd,
+		"otlp-endpoint",
+		config.BaseConfig.OTLPEndpoint,
+		"Address of the OpenTelemetry collector the trace spans are exported to with OTLP over gRPC, "+
+			"e.g. localhost:4317",
+		"otlp-endpoint"); err != nil {
+		return fmt.Errorf("failed to add --otlp-endpoint flag: %s", err)
+	}
+	if err := addBoolFlagBindViper(cmd,
+		"otlp-insecure",
+		config.BaseConfig.OTLPInsecure,
+		"Disable the TLS of the connection to the OpenTelemetry collector",
+		"otlp-insecure"); err != nil {
+		return fmt.Errorf("failed to add --otlp-insecure flag: %s", err)
+	}
+	if err := addStringFlagBindViper(cmd,
+		"block-hook-url",
+		config.BaseConfig.BlockHookURL,
+		"Webhook URL the blocks imported and finalised are posted to as JSON, for external indexers",
+		"block-hook-url"); err != nil {
+		return fmt.Errorf("failed to add --block-hook-url flag: %s", err)
+	}
+	if err := addStringFlagBindViper(cmd,
+		"block-hook-exec",
+		config.BaseConfig.BlockHookExec,
+		"Command run for each block imported and finalised, with the block written as JSON to its standard input",
+		"block-hook-exec"); err != nil {
+		return fmt.Errorf("failed to add --block-hook-exec flag: %s", err)
+	}
 	cmd.Flags().StringVar(&telemetryURLs,
 		"telemetry-url",
 		"",
@@ -258,6 +314,18 @@ func addBaseConfigFlags(cmd *cobra.Command) error {
 Expected format is 'URL VERBOSITY', e.g. ''--telemetry-url wss://foo/bar:0, wss://baz/quz:1
 `)
 
+	// development flag injecting faults, hidden from the help
+	if err := addStringFlagBindViper(cmd,
+		"chaos",
+		config.BaseConfig.Chaos,
+		"Inject faults seeded for reproducibility, e.g. seed=42,drop=0.1,finality-delay=2s,disk-failure=0.01",
+		"chaos"); err != nil {
+		return fmt.Errorf("failed to add --chaos flag: %s", err)
+	}
+	if err := cmd.PersistentFlags().MarkHidden("chaos"); err != nil {
+		return fmt.Errorf("failed to hide --chaos flag: %s", err)
+	}
+
 	return nil
 }
 
@@ -294,6 +362,14 @@ func addAccountFlags(cmd *cobra.Command) error {
 		"password",
 		"",
 		"Password used to encrypt the keystore")
+	cmd.Flags().String(
+		"password-file",
+		"",
+		"File containing the passwords of the accounts to unlock, one per line")
+	cmd.Flags().Bool(
+		"password-interactive",
+		false,
+		"Prompt for the password of each account to unlock")
 
 	return nil
 }
@@ -387,6 +463,14 @@ func addNetworkFlags(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to add --public-dns flag: %s", err)
 	}
 
+	if err := addStringSliceFlagBindViper(cmd,
+		"public-addr",
+		config.Network.PublicAddresses,
+		"Comma separated IPv4, IPv6 or DNS multiaddrs advertised to other peers",
+		"network.public-addr"); err != nil {
+		return fmt.Errorf("failed to add --public-addr flag: %s", err)
+	}
+
 	if err := addStringFlagBindViper(cmd,
 		"node-key",
 		config.Network.NodeKey,
@@ -395,14 +479,118 @@ func addNetworkFlags(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to add --node-key flag: %s", err)
 	}
 
-	if err := addStringFlagBindViper(cmd,
+	if err := addStringSliceFlagBindViper(cmd,
 		"listen-addr",
-		config.Network.ListenAddress,
-		"Overrides the listen address used for peer to peer networking",
+		config.Network.ListenAddresses,
+		"Comma separated multiaddrs to listen on for peer to peer networking",
 		"network.listen-addr"); err != nil {
 		return fmt.Errorf("failed to add --listen-addr flag: %s", err)
 	}
 
+	if err := addBoolFlagBindViper(cmd,
+		"force-tx-propagation",
+		config.Network.ForceTxPropagation,
+		"Relays transactions to peers even if the node is not an authority",
+		"network.force-tx-propagation"); err != nil {
+		return fmt.Errorf("failed to add --force-tx-propagation flag: %s", err)
+	}
+
+	if err := addStringFlagBindViper(cmd,
+		"compression",
+		config.Network.Compression,
+		"Compression offered to peers for network payloads. One of 'none', 'zstd' or 'snappy'",
+		"network.compression"); err != nil {
+		return fmt.Errorf("failed to add --compression flag: %s", err)
+	}
+
+	if err := addUintFlagBindViper(cmd,
+		"max-bandwidth",
+		config.Network.MaxBandwidth,
+		"Soft limit of the outbound bandwidth in bytes per second, deferring transactions gossip when exceeded",
+		"network.max-bandwidth"); err != nil {
+		return fmt.Errorf("failed to add --max-bandwidth flag: %s", err)
+	}
+
+	if err := addBoolFlagBindViper(cmd,
+		"no-private-ipv4",
+		config.Network.NoPrivateIPv4,
+		"Prevents dialing the peers on private IPv4 addresses, for public nodes",
+		"network.no-private-ipv4"); err != nil {
+		return fmt.Errorf("failed to add --no-private-ipv4 flag: %s", err)
+	}
+
+	if err := addUintFlagBindViper(cmd,
+		"max-streams",
+		config.Network.MaxStreams,
+		"Maximum number of libp2p streams open at a time (0 for the default scaled to the machine)",
+		"network.max-streams"); err != nil {
+		return fmt.Errorf("failed to add --max-streams flag: %s", err)
+	}
+
+	if err := addUintFlagBindViper(cmd,
+		"max-streams-per-peer",
+		config.Network.MaxStreamsPerPeer,
+		"Maximum number of libp2p streams open with a peer at a time (0 for the default)",
+		"network.max-streams-per-peer"); err != nil {
+		return fmt.Errorf("failed to add --max-streams-per-peer flag: %s", err)
+	}
+
+	if err := addUintFlagBindViper(cmd,
+		"max-network-memory",
+		config.Network.MaxNetworkMemory,
+		"Maximum memory in bytes reserved by the libp2p connections and streams (0 for the default)",
+		"network.max-network-memory"); err != nil {
+		return fmt.Errorf("failed to add --max-network-memory flag: %s", err)
+	}
+
+	if err := addUintFlagBindViper(cmd,
+		"max-conns-per-peer",
+		config.Network.MaxConnsPerPeer,
+		"Maximum number of connections with a peer (0 for the default)",
+		"network.max-conns-per-peer"); err != nil {
+		return fmt.Errorf("failed to add --max-conns-per-peer flag: %s", err)
+	}
+
+	if err := addUintFlagBindViper(cmd,
+		"max-conns-per-ip",
+		config.Network.MaxConnsPerIP,
+		"Maximum number of connections with the peers of an IPv4 address or /56 IPv6 subnet (0 for 8)",
+		"network.max-conns-per-ip"); err != nil {
+		return fmt.Errorf("failed to add --max-conns-per-ip flag: %s", err)
+	}
+
+	if err := addUintFlagBindViper(cmd,
+		"max-conns-per-subnet",
+		config.Network.MaxConnsPerSubnet,
+		"Maximum number of connections with the peers of a /24 IPv4 or /48 IPv6 subnet (0 for the default)",
+		"network.max-conns-per-subnet"); err != nil {
+		return fmt.Errorf("failed to add --max-conns-per-subnet flag: %s", err)
+	}
+
+	if err := addDurationFlagBindViper(cmd,
+		"badger-gc-interval",
+		config.Network.BadgerGCInterval,
+		"Interval of the value log garbage collection of the libp2p badger datastore. 0 disables it",
+		"network.badger-gc-interval"); err != nil {
+		return fmt.Errorf("failed to add --badger-gc-interval flag: %s", err)
+	}
+
+	if err := addFloat64FlagBindViper(cmd,
+		"badger-gc-discard-ratio",
+		config.Network.BadgerGCDiscardRatio,
+		"Minimum ratio of stale data for a badger value log file to be rewritten by the garbage collection",
+		"network.badger-gc-discard-ratio"); err != nil {
+		return fmt.Errorf("failed to add --badger-gc-discard-ratio flag: %s", err)
+	}
+
+	if err := addBoolFlagBindViper(cmd,
+		"badger-gc-pause-during-sync",
+		config.Network.BadgerGCPauseDuringSync,
+		"Skips the scheduled badger garbage collections while the node is syncing",
+		"network.badger-gc-pause-during-sync"); err != nil {
+		return fmt.Errorf("failed to add --badger-gc-pause-during-sync flag: %s", err)
+	}
+
 	return nil
 }
 
@@ -477,10 +665,82 @@ func addRPCFlags(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to add --ws-unsafe-external flag: %s", err)
 	}
 
-	// dummy flag to conform with the substrate cli
-	cmd.Flags().String("rpc-cors",
+	if err := addBoolFlagBindViper(cmd,
+		"no-health-endpoint",
+		config.RPC.NoHealthEndpoint,
+		"Disable the /health and /health/readiness endpoints of the HTTP-RPC server",
+		"rpc.no-health-endpoint"); err != nil {
+		return fmt.Errorf("failed to add --no-health-endpoint flag: %s", err)
+	}
+
+	if err := addUint32FlagBindViper(cmd,
+		"grpc-port",
+		config.RPC.GRPCPort,
+		"gRPC server listening port, 0 disabling the gRPC server",
+		"rpc.grpc-port"); err != nil {
+		return fmt.Errorf("failed to add --grpc-port flag: %s", err)
+	}
+
+	if err := addBoolFlagBindViper(cmd,
+		"grpc-external",
+		config.RPC.GRPCExternal,
+		"Enable external gRPC connections",
+		"rpc.grpc-external"); err != nil {
+		return fmt.Errorf("failed to add --grpc-external flag: %s", err)
+	}
+
+	cmd.PersistentFlags().StringVar(&rpcCors,
+		"rpc-cors",
 		"",
-		"dummy flag to conform with the substrate cli")
+		"Origins of the browser requests allowed by the RPC servers, comma separated list, 'all' or 'none'")
+
+	if err := addStringFlagBindViper(cmd,
+		"rpc-tls-cert",
+		config.RPC.TLSCert,
+		"Certificate file of the TLS termination of the RPC servers",
+		"rpc.tls-cert"); err != nil {
+		return fmt.Errorf("failed to add --rpc-tls-cert flag: %s", err)
+	}
+
+	if err := addStringFlagBindViper(cmd,
+		"rpc-tls-key",
+		config.RPC.TLSKey,
+		"Key file of the TLS termination of the RPC servers",
+		"rpc.tls-key"); err != nil {
+		return fmt.Errorf("failed to add --rpc-tls-key flag: %s", err)
+	}
+
+	if err := addStringFlagBindViper(cmd,
+		"rpc-unix-socket",
+		config.RPC.UnixSocket,
+		"Path of the unix domain socket the HTTP-RPC server also listens on",
+		"rpc.unix-socket"); err != nil {
+		return fmt.Errorf("failed to add --rpc-unix-socket flag: %s", err)
+	}
+
+	if err := addStringFlagBindViper(cmd,
+		"ws-unix-socket",
+		config.RPC.WSUnixSocket,
+		"Path of the unix domain socket the websocket server also listens on",
+		"rpc.ws-unix-socket"); err != nil {
+		return fmt.Errorf("failed to add --ws-unix-socket flag: %s", err)
+	}
+
+	if err := addDurationFlagBindViper(cmd,
+		"ws-resume-window",
+		config.RPC.WSResumeWindow,
+		"Duration the websocket subscriptions of a closed connection can be resumed during, 0 disabling it",
+		"rpc.ws-resume-window"); err != nil {
+		return fmt.Errorf("failed to add --ws-resume-window flag: %s", err)
+	}
+
+	if err := addUint32FlagBindViper(cmd,
+		"ws-resume-buffer",
+		config.RPC.WSResumeBuffer,
+		"Maximum number of notifications buffered for a websocket subscription to resume",
+		"rpc.ws-resume-buffer"); err != nil {
+		return fmt.Errorf("failed to add --ws-resume-buffer flag: %s", err)
+	}
 
 	return nil
 }
@@ -529,6 +789,171 @@ func addCoreFlags(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to add --grandpa-interval flag: %s", err)
 	}
 
+	if err := addStringFlagBindViper(cmd,
+		"grandpa-pause",
+		config.Core.GrandpaPause,
+		"Pause the GRANDPA voting at a block for a number of blocks, formatted as <block>,<delay>",
+		"core.grandpa-pause"); err != nil {
+		return fmt.Errorf("failed to add --grandpa-pause flag: %s", err)
+	}
+
+	if err := addStringFlagBindViper(cmd,
+		"dev-seal",
+		config.Core.DevSeal,
+		"Development block authoring mode replacing BABE slots. One of 'instant' or 'manual'",
+		"core.dev-seal"); err != nil {
+		return fmt.Errorf("failed to add --dev-seal flag: %s", err)
+	}
+
+	if err := addBoolFlagBindViper(cmd,
+		"stub-missing-host-functions",
+		config.Core.StubMissingHostFunctions,
+		"Link the host functions imported by the runtime and not implemented to failing stubs",
+		"core.stub-missing-host-functions"); err != nil {
+		return fmt.Errorf("failed to add --stub-missing-host-functions flag: %s", err)
+	}
+
+	if err := addUint32FlagBindViper(cmd,
+		"max-heap-pages",
+		config.Core.MaxHeapPages,
+		"Maximum number of pages the runtime memory can grow to when allocating (0 for 65536 pages)",
+		"core.max-heap-pages"); err != nil {
+		return fmt.Errorf("failed to add --max-heap-pages flag: %s", err)
+	}
+
+	if err := addStringFlagBindViper(cmd,
+		"wasm-cache-dir",
+		config.Core.WasmCacheDir,
+		"Directory persisting the compiled WASM runtimes (default \"<base-path>/wasm-cache\")",
+		"core.wasm-cache-dir"); err != nil {
+		return fmt.Errorf("failed to add --wasm-cache-dir flag: %s", err)
+	}
+
+	if err := addBoolFlagBindViper(cmd,
+		"wasm-metering",
+		config.Core.WasmMetering,
+		"Instrument the runtime code to count the fuel consumed by each runtime call",
+		"core.wasm-metering"); err != nil {
+		return fmt.Errorf("failed to add --wasm-metering flag: %s", err)
+	}
+
+	if err := addDurationFlagBindViper(cmd,
+		"max-clock-drift",
+		config.Core.MaxClockDrift,
+		"Maximum drift of the local clock from the network time before warning and pausing block authoring, "+
+			"0 to disable the check",
+		"core.max-clock-drift"); err != nil {
+		return fmt.Errorf("failed to add --max-clock-drift flag: %s", err)
+	}
+
+	if err := addStringFlagBindViper(cmd,
+		"ntp-server",
+		config.Core.NTPServer,
+		"NTP server to measure the drift of the local clock against",
+		"core.ntp-server"); err != nil {
+		return fmt.Errorf("failed to add --ntp-server flag: %s", err)
+	}
+
+	if err := addBoolFlagBindViper(cmd,
+		"authoring-dry-run",
+		config.Core.AuthoringDryRun,
+		"Build the blocks of the BABE slots won without importing nor broadcasting them",
+		"core.authoring-dry-run"); err != nil {
+		return fmt.Errorf("failed to add --authoring-dry-run flag: %s", err)
+	}
+
+	if err := addBoolFlagBindViper(cmd,
+		"standby",
+		config.Core.Standby,
+		"Start the authority node in the full node role, until switched to the authority role",
+		"core.standby"); err != nil {
+		return fmt.Errorf("failed to add --standby flag: %s", err)
+	}
+
+	if err := addStringFlagBindViper(cmd,
+		"failover-lease",
+		config.Core.FailoverLease,
+		"Path of the lease file shared with the nodes holding the same authority keys, "+
+			"the node authoring while holding the lease",
+		"core.failover-lease"); err != nil {
+		return fmt.Errorf("failed to add --failover-lease flag: %s", err)
+	}
+
+	if err := addDurationFlagBindViper(cmd,
+		"failover-lease-ttl",
+		config.Core.FailoverLeaseTTL,
+		"Duration of the authoring lease of the failover",
+		"core.failover-lease-ttl"); err != nil {
+		return fmt.Errorf("failed to add --failover-lease-ttl flag: %s", err)
+	}
+
+	if err := addStringFlagBindViper(cmd,
+		"misbehaviour-report",
+		config.Core.MisbehaviourReport,
+		"File the misbehaviours of the block authors are appended to as JSON lines",
+		"core.misbehaviour-report"); err != nil {
+		return fmt.Errorf("failed to add --misbehaviour-report flag: %s", err)
+	}
+
+	if err := addUintFlagBindViper(cmd,
+		"pool-limit",
+		config.Core.PoolLimit,
+		"Maximum number of transactions in the transaction pool, dropping the lowest priority ones (0 to disable)",
+		"core.pool-limit"); err != nil {
+		return fmt.Errorf("failed to add --pool-limit flag: %s", err)
+	}
+
+	if err := addUintFlagBindViper(cmd,
+		"pool-kbytes",
+		config.Core.PoolKbytes,
+		"Maximum size in kilobytes of the transactions in the transaction pool, "+
+			"dropping the lowest priority ones (0 to disable)",
+		"core.pool-kbytes"); err != nil {
+		return fmt.Errorf("failed to add --pool-kbytes flag: %s", err)
+	}
+
+	if err := addUintFlagBindViper(cmd,
+		"sync-kbytes",
+		config.Core.SyncKbytes,
+		"Memory budget in kilobytes of the blocks buffered between their download, verification "+
+			"and execution while syncing",
+		"core.sync-kbytes"); err != nil {
+		return fmt.Errorf("failed to add --sync-kbytes flag: %s", err)
+	}
+
+	if err := addDurationFlagBindViper(cmd,
+		"tx-ban-duration",
+		config.Core.TxBanDuration,
+		"Duration the extrinsics repeatedly failing validation or block building are banned from "+
+			"the transaction pool for (0 to disable)",
+		"core.tx-ban-duration"); err != nil {
+		return fmt.Errorf("failed to add --tx-ban-duration flag: %s", err)
+	}
+
+	if err := addDurationFlagBindViper(cmd,
+		"watchdog-block-production",
+		config.Core.WatchdogBlockProduction,
+		"Window the BABE slot loop must handle a slot within before being restarted (0 to disable)",
+		"core.watchdog-block-production"); err != nil {
+		return fmt.Errorf("failed to add --watchdog-block-production flag: %s", err)
+	}
+
+	if err := addDurationFlagBindViper(cmd,
+		"watchdog-finality",
+		config.Core.WatchdogFinality,
+		"Window the GRANDPA voter must start a round within before being restarted (0 to disable)",
+		"core.watchdog-finality"); err != nil {
+		return fmt.Errorf("failed to add --watchdog-finality flag: %s", err)
+	}
+
+	if err := addDurationFlagBindViper(cmd,
+		"watchdog-sync",
+		config.Core.WatchdogSync,
+		"Window the sync engine must run its strategy within before being restarted (0 to disable)",
+		"core.watchdog-sync"); err != nil {
+		return fmt.Errorf("failed to add --watchdog-sync flag: %s", err)
+	}
+
 	return nil
 }
 
@@ -541,11 +966,83 @@ func addStateFlags(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to add --rewind flag: %s", err)
 	}
 
+	if err := addBoolFlagBindViper(cmd,
+		"repair", config.State.Repair,
+		"Roll back to the last consistent finalised block if the database is inconsistent on startup",
+		"state.repair"); err != nil {
+		return fmt.Errorf("failed to add --repair flag: %s", err)
+	}
+
+	if err := addUintFlagBindViper(cmd,
+		"trie-cache-size", config.State.TrieCacheSize,
+		"Size in bytes of the trie cache, 0 to disable it",
+		"state.trie-cache-size"); err != nil {
+		return fmt.Errorf("failed to add --trie-cache-size flag: %s", err)
+	}
+
+	if err := addUintFlagBindViper(cmd,
+		"warmup-blocks", config.State.WarmupBlocks,
+		"Number of most recent blocks replayed in the background on startup to warm the trie and runtime caches "+
+			"(0 to disable)",
+		"state.warmup-blocks"); err != nil {
+		return fmt.Errorf("failed to add --warmup-blocks flag: %s", err)
+	}
+
+	if err := addBoolFlagBindViper(cmd,
+		"state-snapshot", config.State.Snapshot,
+		"Maintain a flat snapshot of the state of the best block, read by the block executions",
+		"state.snapshot"); err != nil {
+		return fmt.Errorf("failed to add --state-snapshot flag: %s", err)
+	}
+
+	if err := addBoolFlagBindViper(cmd,
+		"prune-justifications", config.State.PruneJustifications,
+		"Only keep the justifications of the authority set changes and of the highest block justified",
+		"state.prune-justifications"); err != nil {
+		return fmt.Errorf("failed to add --prune-justifications flag: %s", err)
+	}
+
+	if err := addBoolFlagBindViper(cmd,
+		"prune-epochs", config.State.PruneEpochs,
+		"Prune the BABE epoch data of the epochs older than two epochs before the finalised epoch",
+		"state.prune-epochs"); err != nil {
+		return fmt.Errorf("failed to add --prune-epochs flag: %s", err)
+	}
+
+	if err := addStringFlagBindViper(cmd,
+		"blocks-pruning", config.State.BlocksPruning,
+		"Block bodies pruning, \"archive\" or the number of the highest finalised blocks whose bodies are kept",
+		"state.blocks-pruning"); err != nil {
+		return fmt.Errorf("failed to add --blocks-pruning flag: %s", err)
+	}
+
+	if err := addStringSliceFlagBindViper(cmd,
+		"storage-watch", config.State.StorageWatch,
+		"Comma separated storage prefixes, hex encoded or formatted as <pallet>.<entry>, whose changes at "+
+			"each block finalised are appended to the storage changefeed file",
+		"state.storage-watch"); err != nil {
+		return fmt.Errorf("failed to add --storage-watch flag: %s", err)
+	}
+
+	if err := addStringFlagBindViper(cmd,
+		"storage-watch-file", config.State.StorageWatchFile,
+		"Storage changefeed file the changes of the watched storage prefixes are appended to "+
+			"(default storage-changefeed.jsonl in the base path)",
+		"state.storage-watch-file"); err != nil {
+		return fmt.Errorf("failed to add --storage-watch-file flag: %s", err)
+	}
+
 	return nil
 }
 
 // addPprofFlags adds pprof flags and binds to viper
 func addPprofFlags(cmd *cobra.Command) error {
+	cmd.PersistentFlags().BoolVar(&pprof,
+		"pprof",
+		false,
+		"Enable the pprof and diagnostics server, serving the profiles at /debug/pprof/ "+
+			"and the service goroutines, database, trie cache and resource manager stats at /debug/gossamer/")
+
 	if err := addBoolFlagBindViper(cmd,
 		"pprof.enabled", false,
 		"--pprof.enabled", "pprof.enabled"); err != nil {
@@ -577,11 +1074,56 @@ func addPprofFlags(cmd *cobra.Command) error {
 	return nil
 }
 
+// addCollatorFlags adds collator flags and binds to viper
+func addCollatorFlags(cmd *cobra.Command) error {
+	if err := addBoolFlagBindViper(cmd,
+		"collator",
+		config.Collator.Enabled,
+		"Collate the parachain blocks against the relay chain followed by an embedded relay chain node",
+		"collator.enabled"); err != nil {
+		return fmt.Errorf("failed to add --collator flag: %s", err)
+	}
+
+	if err := addUint32FlagBindViper(cmd,
+		"para-id",
+		config.Collator.ParaID,
+		"Identifier of the parachain on the relay chain",
+		"collator.para-id"); err != nil {
+		return fmt.Errorf("failed to add --para-id flag: %s", err)
+	}
+
+	if err := addStringFlagBindViper(cmd,
+		"relay-chain-spec",
+		config.Collator.RelayChainSpec,
+		"Path to the chain-spec file of the relay chain",
+		"collator.relay-chain-spec"); err != nil {
+		return fmt.Errorf("failed to add --relay-chain-spec flag: %s", err)
+	}
+
+	if err := addUint16FlagBindViper(cmd,
+		"relay-port",
+		config.Collator.RelayPort,
+		"Network port of the embedded relay chain node",
+		"collator.relay-port"); err != nil {
+		return fmt.Errorf("failed to add --relay-port flag: %s", err)
+	}
+
+	if err := addStringSliceFlagBindViper(cmd,
+		"relay-bootnodes",
+		config.Collator.RelayBootnodes,
+		"Comma separated node URLs for the network discovery bootstrap of the embedded relay chain node",
+		"collator.relay-bootnodes"); err != nil {
+		return fmt.Errorf("failed to add --relay-bootnodes flag: %s", err)
+	}
+
+	return nil
+}
+
 // execRoot executes the root command
 func execRoot(cmd *cobra.Command) error {
-	password, err := cmd.Flags().GetString("password")
+	passwords, err := unlockPasswords(cmd, config.Account.Unlock)
 	if err != nil {
-		return fmt.Errorf("failed to get password: %s", err)
+		return fmt.Errorf("failed to get passwords: %s", err)
 	}
 
 	ks := keystore.NewGlobalKeystore()
@@ -592,15 +1134,15 @@ func execRoot(cmd *cobra.Command) error {
 	}
 
 	// load user keys if specified
-	if err := unlockKeystore(ks.Acco, config.BasePath, config.Account.Unlock, password); err != nil {
+	if err := unlockKeystore(ks.Acco, config.BasePath, config.Account.Unlock, passwords); err != nil {
 		return fmt.Errorf("failed to unlock keystore: %s", err)
 	}
 
-	if err := unlockKeystore(ks.Babe, config.BasePath, config.Account.Unlock, password); err != nil {
+	if err := unlockKeystore(ks.Babe, config.BasePath, config.Account.Unlock, passwords); err != nil {
 		return fmt.Errorf("failed to unlock keystore: %s", err)
 	}
 
-	if err := unlockKeystore(ks.Gran, config.BasePath, config.Account.Unlock, password); err != nil {
+	if err := unlockKeystore(ks.Gran, config.BasePath, config.Account.Unlock, passwords); err != nil {
 		return fmt.Errorf("failed to unlock keystore: %s", err)
 	}
 
@@ -630,6 +1172,8 @@ func execRoot(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to create node services: %s", err)
 	}
 
+	node.ConfigLoader = reloadConfig
+
 	logger.Info("starting node " + node.Name + "...")
 
 	// start node
@@ -639,3 +1183,22 @@ func execRoot(cmd *cobra.Command) error {
 
 	return nil
 }
+
+// reloadConfig reads the config file again and returns the node config with the
+// config file, environment variables and command line flags applied, in that order.
+func reloadConfig() (*cfg.Config, error) {
+	if err := viper.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read config file: %s", err)
+	}
+
+	reloaded := cfg.Copy(config)
+	if err := viper.Unmarshal(&reloaded); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %s", err)
+	}
+
+	if err := reloaded.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("error in config file: %v", err)
+	}
+
+	return &reloaded, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/ChainSafe/gossamer/internal/spawn"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	SpawnCmd.Flags().String("network", "", "Path of the TOML network description, or of a zombienet network definition")
+	SpawnCmd.Flags().String("command", "",
+		"Gossamer binary running the nodes not given a command, the running binary if empty")
+	SpawnCmd.Flags().Duration("ready-timeout", 2*time.Minute, "Time to wait for the RPC server of every node to be up")
+	SpawnCmd.Flags().Bool("dry-run", false, "Print the command of every node without starting them")
+}
+
+// SpawnCmd is the command to launch a local multi-node network
+var SpawnCmd = &cobra.Command{
+	Use:   "spawn",
+	Short: "Launch a local multi-node network from a TOML network description",
+	Long: `The spawn command launches a local network of gossamer nodes, each node being a child
+process with its own directory in the base path. The ports, node keys and bootnodes of the nodes
+are wired automatically, and the built-in key named after a node is used if the node has no key.
+Once every node is ready, the nodes and their endpoints are listed in network.json in the base
+path. The nodes are stopped when the command is interrupted or when a node exits.
+The network description can also be a zombienet network definition using the native provider,
+its relay chain nodes being run by gossamer.
+Examples:
+	gossamer spawn --network network.toml --base-path /tmp/gossamer-network
+	gossamer spawn --network zombienet_tests/functional/0001-basic-network.toml`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return execSpawn(cmd)
+	},
+}
+
+func execSpawn(cmd *cobra.Command) error {
+	networkPath, err := cmd.Flags().GetString("network")
+	if err != nil {
+		return fmt.Errorf("failed to get network: %s", err)
+	}
+	if networkPath == "" {
+		return fmt.Errorf("network must be specified")
+	}
+
+	command, err := cmd.Flags().GetString("command")
+	if err != nil {
+		return fmt.Errorf("failed to get command: %s", err)
+	}
+	if command == "" {
+		command, err = os.Executable()
+		if err != nil {
+			return fmt.Errorf("failed to get gossamer binary: %s", err)
+		}
+	}
+
+	readyTimeout, err := cmd.Flags().GetDuration("ready-timeout")
+	if err != nil {
+		return fmt.Errorf("failed to get ready-timeout: %s", err)
+	}
+
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return fmt.Errorf("failed to get dry-run: %s", err)
+	}
+
+	networkBasePath := basePath
+	if networkBasePath == "" {
+		networkBasePath = filepath.Join(os.TempDir(), "gossamer-spawn")
+	}
+
+	networkConfig, err := spawn.ParseConfig(networkPath)
+	if err != nil {
+		return fmt.Errorf("failed to parse network: %w", err)
+	}
+
+	nodes, err := spawn.Plan(networkConfig, networkBasePath, command)
+	if err != nil {
+		return fmt.Errorf("failed to plan network: %w", err)
+	}
+
+	if dryRun {
+		for _, node := range nodes {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s: %s %q\n", node.Name, node.Command, node.Args)
+		}
+		return nil
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	network, err := spawn.Launch(ctx, nodes, networkBasePath, readyTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to launch network: %w", err)
+	}
+	defer network.Stop()
+
+	logger.Infof("network of %d nodes ready, listed in %s", len(nodes),
+		filepath.Join(networkBasePath, spawn.NetworkFile))
+	return network.Wait(ctx)
+}
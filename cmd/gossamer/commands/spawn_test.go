@@ -0,0 +1,57 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpawnMissingNetwork(t *testing.T) {
+	rootCmd, err := NewRootCommand()
+	require.NoError(t, err)
+	rootCmd.AddCommand(SpawnCmd)
+
+	rootCmd.SetArgs([]string{SpawnCmd.Name()})
+	err = rootCmd.Execute()
+	assert.ErrorContains(t, err, "network must be specified")
+}
+
+func TestSpawnDryRun(t *testing.T) {
+	networkPath := filepath.Join(t.TempDir(), "network.toml")
+	err := os.WriteFile(networkPath, []byte(`
+chain = "westend-local"
+
+[[nodes]]
+name = "alice"
+validator = true
+
+[[nodes]]
+name = "bob"
+validator = true
+`), 0o600)
+	require.NoError(t, err)
+
+	rootCmd, err := NewRootCommand()
+	require.NoError(t, err)
+	rootCmd.AddCommand(SpawnCmd)
+
+	output := bytes.NewBuffer(nil)
+	rootCmd.SetOut(output)
+	rootCmd.SetArgs([]string{SpawnCmd.Name(),
+		"--network", networkPath,
+		"--command", "gossamer",
+		"--dry-run",
+	})
+	err = rootCmd.Execute()
+	require.NoError(t, err)
+	assert.Contains(t, output.String(), "alice: gossamer")
+	assert.Contains(t, output.String(), "bob: gossamer")
+	assert.Contains(t, output.String(), `"--bootnodes"`)
+}
@@ -0,0 +1,147 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/spf13/cobra"
+)
+
+var upgradeChecks = map[string]dot.UpgradeCheckSelect{
+	"none":         dot.UpgradeCheckNone,
+	"all":          dot.UpgradeCheckAll,
+	"pre-and-post": dot.UpgradeCheckPreAndPost,
+	"try-state":    dot.UpgradeCheckTryState,
+}
+
+// TryRuntimeCmd is the command to try a runtime against the state of a live chain
+var TryRuntimeCmd = newTryRuntimeCmd()
+
+// newTryRuntimeCmd returns a try-runtime command with its flags
+func newTryRuntimeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "try-runtime",
+		Short: "Execute a runtime against the state of a live chain and report the storage changes",
+		Long: `The try-runtime command executes the given WASM runtime against the state of a live chain,
+fetched from a remote node or read from the local database, without persisting anything.
+The on-runtime-upgrade mode runs TryRuntime_on_runtime_upgrade on the state of the block, and
+the execute-block mode runs Core_execute_block for the block on the state of its parent.
+Examples:
+	gossamer try-runtime --runtime runtime.wasm --uri http://localhost:9933 --at <block hash>
+	gossamer try-runtime --runtime runtime.wasm --base-path ~/.gossamer/westend --mode execute-block`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return execTryRuntime(cmd)
+		},
+	}
+
+	cmd.Flags().String("runtime", "", "Path to the WASM runtime to try")
+	cmd.Flags().String("mode", string(dot.TryRuntimeOnRuntimeUpgrade),
+		"Runtime function to execute: on-runtime-upgrade or execute-block")
+	cmd.Flags().String("checks", "all",
+		"Checks run by on-runtime-upgrade: none, all, pre-and-post or try-state")
+	cmd.Flags().String("uri", "",
+		"HTTP RPC endpoint of the node to fetch the state from, the local database is used if empty")
+	cmd.Flags().String("at", "", "Hash of the block to run the runtime at, the best block if empty")
+	cmd.Flags().Uint8("state-version",
+		uint8(trie.DefaultStateVersion),
+		"State version of the state fetched from the remote node",
+	)
+	return cmd
+}
+
+func execTryRuntime(cmd *cobra.Command) error {
+	runtimeFile, err := cmd.Flags().GetString("runtime")
+	if err != nil {
+		return fmt.Errorf("failed to get runtime: %s", err)
+	}
+	if runtimeFile == "" {
+		return fmt.Errorf("runtime must be specified")
+	}
+
+	mode, err := cmd.Flags().GetString("mode")
+	if err != nil {
+		return fmt.Errorf("failed to get mode: %s", err)
+	}
+
+	checksName, err := cmd.Flags().GetString("checks")
+	if err != nil {
+		return fmt.Errorf("failed to get checks: %s", err)
+	}
+	checks, ok := upgradeChecks[checksName]
+	if !ok {
+		return fmt.Errorf("invalid checks: %s", checksName)
+	}
+
+	uri, err := cmd.Flags().GetString("uri")
+	if err != nil {
+		return fmt.Errorf("failed to get uri: %s", err)
+	}
+
+	at, err := cmd.Flags().GetString("at")
+	if err != nil {
+		return fmt.Errorf("failed to get at: %s", err)
+	}
+	var block *common.Hash
+	if at != "" {
+		blockHash, err := common.HexToHash(at)
+		if err != nil {
+			return fmt.Errorf("invalid block hash: %s", err)
+		}
+		block = &blockHash
+	}
+
+	stateVersion, err := cmd.Flags().GetUint8("state-version")
+	if err != nil {
+		return fmt.Errorf("failed to get state-version: %s", err)
+	}
+	stateTrieVersion, err := trie.ParseVersion(stateVersion)
+	if err != nil {
+		return fmt.Errorf("invalid state version")
+	}
+
+	if uri == "" {
+		if basePath == "" {
+			basePath = config.BasePath
+		}
+
+		if basePath == "" {
+			return fmt.Errorf("basepath or uri must be specified")
+		}
+		basePath = utils.ExpandDir(basePath)
+	}
+
+	result, err := dot.TryRuntime(dot.TryRuntimeConfig{
+		RuntimeFile:  runtimeFile,
+		Mode:         dot.TryRuntimeMode(mode),
+		Checks:       checks,
+		URI:          uri,
+		BasePath:     basePath,
+		Block:        block,
+		StateVersion: stateTrieVersion,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("executed %s at block %s\n", mode, result.Block)
+	fmt.Printf("output: 0x%x\n", result.Output)
+	fmt.Printf("%d storage entries changed\n", len(result.Diffs))
+	for _, diff := range result.Diffs {
+		switch {
+		case diff.Before == nil:
+			fmt.Printf("+ 0x%x: 0x%x\n", diff.Key, diff.After)
+		case diff.After == nil:
+			fmt.Printf("- 0x%x: 0x%x\n", diff.Key, diff.Before)
+		default:
+			fmt.Printf("~ 0x%x: 0x%x -> 0x%x\n", diff.Key, diff.Before, diff.After)
+		}
+	}
+
+	return nil
+}
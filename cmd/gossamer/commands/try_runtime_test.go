@@ -0,0 +1,50 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTryRuntimeMissingRuntime(t *testing.T) {
+	rootCmd, err := NewRootCommand()
+	require.NoError(t, err)
+	tryRuntimeCmd := newTryRuntimeCmd()
+	rootCmd.AddCommand(tryRuntimeCmd)
+
+	rootCmd.SetArgs([]string{tryRuntimeCmd.Name()})
+	err = rootCmd.Execute()
+	assert.ErrorContains(t, err, "runtime must be specified")
+}
+
+func TestTryRuntimeInvalidChecks(t *testing.T) {
+	rootCmd, err := NewRootCommand()
+	require.NoError(t, err)
+	tryRuntimeCmd := newTryRuntimeCmd()
+	rootCmd.AddCommand(tryRuntimeCmd)
+
+	rootCmd.SetArgs([]string{tryRuntimeCmd.Name(),
+		"--runtime", "runtime.wasm",
+		"--checks", "wrong",
+	})
+	err = rootCmd.Execute()
+	assert.ErrorContains(t, err, "invalid checks: wrong")
+}
+
+func TestTryRuntimeInvalidBlockHash(t *testing.T) {
+	rootCmd, err := NewRootCommand()
+	require.NoError(t, err)
+	tryRuntimeCmd := newTryRuntimeCmd()
+	rootCmd.AddCommand(tryRuntimeCmd)
+
+	rootCmd.SetArgs([]string{tryRuntimeCmd.Name(),
+		"--runtime", "runtime.wasm",
+		"--at", "wrong",
+	})
+	err = rootCmd.Execute()
+	assert.ErrorContains(t, err, "invalid block hash")
+}
@@ -4,7 +4,6 @@
 package commands
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -121,6 +120,18 @@ func addDurationFlagBindViper(
 	return viper.BindPFlag(viperBindName, cmd.PersistentFlags().Lookup(name))
 }
 
+// addFloat64FlagBindViper adds a float64 flag to the given command and binds it to the given viper name
+func addFloat64FlagBindViper(
+	cmd *cobra.Command,
+	name string,
+	defaultValue float64,
+	usage string,
+	viperBindName string,
+) error {
+	cmd.PersistentFlags().Float64(name, defaultValue, usage)
+	return viper.BindPFlag(viperBindName, cmd.PersistentFlags().Lookup(name))
+}
+
 // addStringSliceFlagBindViper adds a string slice flag to the given command and binds it to the given viper name
 func addStringSliceFlagBindViper(
 	cmd *cobra.Command,
@@ -155,6 +166,11 @@ func loadBuiltInTestKeys(accountKey string, ks keystore.GlobalKeystore) (err err
 		return fmt.Errorf("error loading babe keystore: %w", err)
 	}
 
+	err = keystore.LoadKeystore(accountKey, ks.Aura, sr25519keyRing)
+	if err != nil {
+		return fmt.Errorf("error loading aura keystore: %w", err)
+	}
+
 	err = keystore.LoadKeystore(accountKey, ks.Gran, ed25519keyRing)
 	if err != nil {
 		return fmt.Errorf("error loading grandpa keystore: %w", err)
@@ -168,34 +184,103 @@ type KeypairInserter interface {
 	Insert(kp keystore.KeyPair) error
 }
 
-// unlockKeystore compares the length of passwords to the length of accounts,
-// prompts the user for a password if no password is provided, and then unlocks
-// the accounts within the provided keystore
-func unlockKeystore(ks KeypairInserter, basepath, unlock, password string) error {
-	var passwords []string
+// unlockKeystore unlocks the accounts within the provided keystore, each with its password
+func unlockKeystore(ks KeypairInserter, basepath, unlock string, passwords []string) error {
+	err := keystore.UnlockKeysWithPasswords(ks, basepath, unlock, passwords)
+	if err != nil {
+		return fmt.Errorf("failed to unlock keys: %s", err)
+	}
 
-	if password != "" {
-		passwords = strings.Split(password, ",")
+	return nil
+}
 
-		// compare length of passwords to length of accounts to unlock (if password provided)
-		if len(passwords) != len(unlock) {
-			return fmt.Errorf("passwords length does not match unlock length")
-		}
+// unlockPasswords returns the passwords of the accounts to unlock, one per account, given by
+// --password separated by commas or by --password-file one per line. The user is prompted for
+// the password of each account if --password-interactive is set or no password is provided.
+func unlockPasswords(cmd *cobra.Command, unlock string) ([]string, error) {
+	if unlock == "" {
+		return nil, nil
+	}
 
-	} else {
-		// compare length of passwords to length of accounts to unlock (if password not provided)
-		if len(passwords) != len(unlock) {
-			bytes := getPassword("Enter password to unlock keystore:")
-			password = string(bytes)
-		}
+	indices, err := common.StringToInts(unlock)
+	if err != nil {
+		return nil, fmt.Errorf("invalid unlock: %s", err)
+	}
+
+	password, passwordFile, interactive, err := passwordFlags(cmd, "password", "password-file")
+	if err != nil {
+		return nil, err
+	}
 
-		err := keystore.UnlockKeys(ks, basepath, unlock, password)
+	var passwords []string
+	switch {
+	case interactive || (password == "" && passwordFile == ""):
+		for _, index := range indices {
+			password := getPassword(fmt.Sprintf("Enter password to unlock account %d:", index))
+			passwords = append(passwords, string(password))
+		}
+	case passwordFile != "":
+		passwords, err = readPasswordFile(passwordFile)
 		if err != nil {
-			return fmt.Errorf("failed to unlock keys: %s", err)
+			return nil, err
 		}
+	default:
+		passwords = strings.Split(password, ",")
 	}
 
-	return nil
+	if len(passwords) != len(indices) {
+		return nil, fmt.Errorf("passwords length does not match unlock length")
+	}
+
+	return passwords, nil
+}
+
+// passwordFlags returns the values of the given password and password file flags and of
+// --password-interactive, at most one of them being set.
+func passwordFlags(cmd *cobra.Command, passwordFlag, passwordFileFlag string) (
+	password, passwordFile string, interactive bool, err error) {
+	password, err = cmd.Flags().GetString(passwordFlag)
+	if err != nil {
+		return "", "", false, fmt.Errorf("failed to get %s: %s", passwordFlag, err)
+	}
+
+	passwordFile, err = cmd.Flags().GetString(passwordFileFlag)
+	if err != nil {
+		return "", "", false, fmt.Errorf("failed to get %s: %s", passwordFileFlag, err)
+	}
+
+	interactive, err = cmd.Flags().GetBool("password-interactive")
+	if err != nil {
+		return "", "", false, fmt.Errorf("failed to get password-interactive: %s", err)
+	}
+
+	set := 0
+	for _, isSet := range []bool{password != "", passwordFile != "", interactive} {
+		if isSet {
+			set++
+		}
+	}
+	if set > 1 {
+		return "", "", false, fmt.Errorf("only one of %s, %s and password-interactive must be specified",
+			passwordFlag, passwordFileFlag)
+	}
+
+	return password, passwordFile, interactive, nil
+}
+
+// readPasswordFile reads the passwords of the given file, one per line
+func readPasswordFile(path string) ([]string, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read password file: %s", err)
+	}
+
+	content := strings.TrimSuffix(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	if content == "" {
+		return nil, fmt.Errorf("password file %s is empty", path)
+	}
+
+	return strings.Split(content, "\n"), nil
 }
 
 // getPassword prompts user to enter password
@@ -238,7 +323,8 @@ func parseChainSpec(chain string) error {
 		config = cfg.DefaultConfigFromSpec(spec)
 		config.ChainSpec = chain
 	} else {
-		switch cfg.Chain(chain) {
+		defaultChain, _ := cfg.ParseChain(chain)
+		switch defaultChain {
 		case cfg.PolkadotChain:
 			config = polkadot.DefaultConfig()
 		case cfg.KusamaChain:
@@ -307,20 +393,25 @@ func ParseConfig() error {
 
 // parseBasePath parses the base path from the command line flags
 func parseBasePath() error {
-	var home string
-	// For the base path, prefer the environment variable over the flag
+	// For the base path, prefer the flag over the environment variable
 	// If neither are set, use the default base path from the config
-	if os.Getenv(DefaultHomeEnv) != "" {
+	home := basePath
+	if home == "" {
 		home = os.Getenv(DefaultHomeEnv)
-	} else {
-		home = basePath
 	}
 	if config.BasePath == "" && home == "" {
 		return fmt.Errorf("--base-path cannot be empty")
 	}
-	// If the base path is set, use it
+	// If the base path is set, use it, otherwise keep the chain
+	// base path in the data directory if it is set.
 	if home != "" {
 		config.BasePath = home
+	} else if dataDir != "" {
+		chainPath, err := filepath.Rel(cfg.DefaultDataDir, config.BasePath)
+		if err != nil || strings.HasPrefix(chainPath, "..") {
+			return fmt.Errorf("base path %s is not in the default data directory %s", config.BasePath, cfg.DefaultDataDir)
+		}
+		config.BasePath = cfg.ChainBasePath(utils.ExpandDir(dataDir), chainPath)
 	}
 	config.BasePath = utils.ExpandDir(config.BasePath)
 	// bind it to viper so that it can be used during the config parsing
@@ -359,13 +450,22 @@ func parseAccount() {
 
 // parseRPC parses the rpc config from the command line flags
 func parseRPC() {
-	// if rpc modules is not set, set it to the default
+	parseRPCModules()
+	parseRPCCors()
+}
+
+// parseRPCModules parses the rpc modules from the --rpc-methods flag
+func parseRPCModules() {
+	// if rpc modules is not set, keep the modules of the config file or chain defaults
 	// if rpc modules is set to unsafe, set it to all modules
 	//TODO: refactor this to follow the same pattern as substrate
 	// Substrate accepts `unsafe`,`safe` and `auto` for --rpc-methods
-	if rpcModules == "unsafe" || rpcModules == "" {
+	switch rpcModules {
+	case "":
+		return
+	case "unsafe":
 		config.RPC.Modules = cfg.DefaultRPCModules
-	} else {
+	default:
 		config.RPC.Modules = strings.Split(rpcModules, ",")
 	}
 
@@ -373,6 +473,36 @@ func parseRPC() {
 	viper.Set("rpc.modules", config.RPC.Modules)
 }
 
+// parseRPCCors parses the rpc cors origins from the --rpc-cors flag
+func parseRPCCors() {
+	// if rpc cors is not set, keep the origins of the config file
+	// if rpc cors is set to all, allow any origin
+	switch rpcCors {
+	case "":
+		return
+	case "all":
+		config.RPC.Cors = []string{"*"}
+	case "none":
+		config.RPC.Cors = []string{}
+	default:
+		config.RPC.Cors = strings.Split(rpcCors, ",")
+	}
+
+	// bind it to viper so that it can be used during the config parsing
+	viper.Set("rpc.cors", config.RPC.Cors)
+}
+
+// parsePprof enables the pprof server if the --pprof flag is set
+func parsePprof() {
+	if !pprof {
+		return
+	}
+
+	config.Pprof.Enabled = true
+	// bind it to viper so that it can be used during the config parsing
+	viper.Set("pprof.enabled", true)
+}
+
 // copyChainSpec copies the chain-spec file to the base path
 func copyChainSpec(source, destination string) error {
 	if err := gssmros.CopyFile(source, destination); err != nil {
@@ -384,12 +514,16 @@ func copyChainSpec(source, destination string) error {
 	return nil
 }
 
-// parseRole parses the role from the command line flags
-func parseRole() error {
+// parseRole parses the role from the command line flags.
+// The role of the config file or chain defaults is kept if no role flag is set.
+func parseRole(cmd *cobra.Command) error {
 	var selectedRole common.NetworkRole
-	if validator {
+	switch {
+	case validator:
 		selectedRole = common.AuthorityRole
-	} else {
+	case !cmd.Flags().Changed("role"):
+		return nil
+	default:
 		switch role {
 		case cfg.FullNode.String():
 			selectedRole = common.FullNodeRole
@@ -500,54 +634,51 @@ func setViperDefault(config *cfg.Config) {
 	}
 }
 
+// parseLogLevel parses the log levels from the command line flag.
+// Only the levels of the modules given are set, so the levels of the
+// other modules are taken from the config file or chain defaults.
 func parseLogLevel() error {
-	// set default log level from config
-	moduleToLogLevel := map[string]string{
-		"global":  config.LogLevel,
-		"core":    config.Log.Core,
-		"digest":  config.Log.Digest,
-		"sync":    config.Log.Sync,
-		"network": config.Log.Network,
-		"rpc":     config.Log.RPC,
-		"state":   config.Log.State,
-		"runtime": config.Log.Runtime,
-		"babe":    config.Log.Babe,
-		"grandpa": config.Log.Grandpa,
-		"wasmer":  config.Log.Wasmer,
-	}
-
-	if logLevel != "" {
-		logConfigurations := strings.Split(logLevel, ",")
-		for _, config := range logConfigurations {
-			parts := strings.SplitN(config, "=", 2)
-			if len(parts) != 2 {
-				return fmt.Errorf("invalid log configuration: %s", config)
-			}
+	moduleToLogLevel := map[string]*string{
+		"global":  &config.LogLevel,
+		"core":    &config.Log.Core,
+		"digest":  &config.Log.Digest,
+		"sync":    &config.Log.Sync,
+		"network": &config.Log.Network,
+		"rpc":     &config.Log.RPC,
+		"state":   &config.Log.State,
+		"runtime": &config.Log.Runtime,
+		"babe":    &config.Log.Babe,
+		"grandpa": &config.Log.Grandpa,
+		"wasmer":  &config.Log.Wasmer,
+	}
 
-			module := strings.TrimSpace(parts[0])
-			logLevel := strings.TrimSpace(parts[1])
+	if logLevel == "" {
+		return nil
+	}
 
-			if _, ok := moduleToLogLevel[module]; !ok {
-				return fmt.Errorf("invalid module: %s", module)
-			}
-			moduleToLogLevel[module] = logLevel
+	logConfigurations := strings.Split(logLevel, ",")
+	for _, logConfiguration := range logConfigurations {
+		parts := strings.SplitN(logConfiguration, "=", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid log configuration: %s", logConfiguration)
 		}
-	}
 
-	// set global log level
-	config.LogLevel = moduleToLogLevel["global"]
-	viper.Set("log-level", config.LogLevel)
+		module := strings.TrimSpace(parts[0])
+		level := strings.TrimSpace(parts[1])
 
-	// set config.Log
-	jsonData, err := json.Marshal(moduleToLogLevel)
-	if err != nil {
-		return fmt.Errorf("error marshalling logs: %s", err)
-	}
-	err = json.Unmarshal(jsonData, &config.Log)
-	if err != nil {
-		return fmt.Errorf("error unmarshalling logs: %s", err)
+		moduleLogLevel, ok := moduleToLogLevel[module]
+		if !ok {
+			return fmt.Errorf("invalid module: %s", module)
+		}
+		*moduleLogLevel = level
+
+		// bind it to viper so that it takes precedence over the config file
+		if module == "global" {
+			viper.Set("log-level", level)
+		} else {
+			viper.Set("log."+module, level)
+		}
 	}
-	viper.Set("log", config.Log)
 
 	return nil
 }
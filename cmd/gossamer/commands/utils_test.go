@@ -4,6 +4,8 @@
 package commands
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -268,3 +270,42 @@ func TestBindToViper(t *testing.T) {
 		})
 	}
 }
+
+func TestParseLogLevel(t *testing.T) {
+	viper.Reset()
+	t.Cleanup(func() { logLevel = "" })
+
+	config = westend.DefaultConfig()
+	config.Log.Sync = "warn"
+	logLevel = "global=debug,core=trace"
+
+	err := parseLogLevel()
+	require.NoError(t, err)
+
+	require.Equal(t, "debug", config.LogLevel)
+	require.Equal(t, "trace", config.Log.Core)
+	require.Equal(t, "warn", config.Log.Sync)
+	require.Equal(t, "debug", viper.Get("log-level"))
+	require.Equal(t, "trace", viper.Get("log.core"))
+	// the levels of the modules not given are left to the config file
+	require.False(t, viper.IsSet("log.sync"))
+
+	logLevel = "unknown=debug"
+	err = parseLogLevel()
+	require.EqualError(t, err, "invalid module: unknown")
+}
+
+func TestReadPasswordFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "passwords")
+	require.NoError(t, os.WriteFile(path, []byte("first\r\nsecond,with,commas\n"), 0600))
+
+	passwords, err := readPasswordFile(path)
+	require.NoError(t, err)
+	require.Equal(t, []string{"first", "second,with,commas"}, passwords)
+
+	require.NoError(t, os.WriteFile(path, []byte("\n"), 0600))
+	_, err = readPasswordFile(path)
+	require.EqualError(t, err, "password file "+path+" is empty")
+}
@@ -0,0 +1,93 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/spf13/cobra"
+)
+
+// ValidateSpecCmd is the command to validate a chain spec
+var ValidateSpecCmd = newValidateSpecCmd()
+
+// newValidateSpecCmd returns a validate-spec command with its flags
+func newValidateSpecCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate-spec <chain-spec.json>",
+		Short: "Validate a plain or raw chain spec and report its issues",
+		Long: `The validate-spec command parses the given plain or raw chain spec and checks the
+consistency of its genesis storage: the BABE and GRANDPA authorities must be decodable,
+the runtime code must be a valid WASM runtime, and the genesis block hash must match the
+given one, if any. Each issue is reported with the field or storage key it is found at.
+Examples:
+	gossamer validate-spec chain-spec-raw.json
+	gossamer validate-spec chain-spec-raw.json --genesis-hash <genesis hash>`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return execValidateSpec(cmd, args[0])
+		},
+	}
+
+	cmd.Flags().String("genesis-hash", "",
+		"Expected genesis block hash of the chain, not checked if empty")
+	return cmd
+}
+
+// execValidateSpec executes the validate-spec command
+func execValidateSpec(cmd *cobra.Command, file string) error {
+	genesisHashHex, err := cmd.Flags().GetString("genesis-hash")
+	if err != nil {
+		return fmt.Errorf("failed to get genesis-hash: %s", err)
+	}
+
+	var genesisHash *common.Hash
+	if genesisHashHex != "" {
+		hash, err := common.HexToHash(genesisHashHex)
+		if err != nil {
+			return fmt.Errorf("invalid genesis hash: %s", err)
+		}
+		genesisHash = &hash
+	}
+
+	report, err := dot.ValidateSpec(dot.ValidateSpecConfig{
+		File:        file,
+		GenesisHash: genesisHash,
+	})
+	if err != nil {
+		return err
+	}
+
+	kind := "plain"
+	if report.Raw {
+		kind = "raw"
+	}
+	fmt.Printf("%s chain spec %q with id %q and %d genesis storage entries\n",
+		kind, report.Name, report.ID, report.Entries)
+	if report.RuntimeVersion != nil {
+		fmt.Printf("runtime %s version %d with state version %d\n", report.RuntimeVersion.SpecName,
+			report.RuntimeVersion.SpecVersion, report.RuntimeVersion.StateVersion)
+	}
+	if report.Valid() {
+		fmt.Printf("genesis state root %s\n", report.StateRoot)
+		fmt.Printf("genesis hash %s\n", report.GenesisHash)
+	}
+
+	errorCount := 0
+	for _, issue := range report.Issues {
+		fmt.Println(issue)
+		if issue.Severity == dot.SpecIssueError {
+			errorCount++
+		}
+	}
+
+	if errorCount > 0 {
+		return fmt.Errorf("chain spec is invalid: %d error(s) found", errorCount)
+	}
+
+	fmt.Println("chain spec is valid")
+	return nil
+}
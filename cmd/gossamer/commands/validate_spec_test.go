@@ -0,0 +1,39 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSpecInvalidGenesisHash(t *testing.T) {
+	rootCmd, err := NewRootCommand()
+	require.NoError(t, err)
+	validateSpecCmd := newValidateSpecCmd()
+	rootCmd.AddCommand(validateSpecCmd)
+
+	rootCmd.SetArgs([]string{validateSpecCmd.Name(), "chain-spec.json", "--genesis-hash", "wrong"})
+	err = rootCmd.Execute()
+	assert.ErrorContains(t, err, "invalid genesis hash")
+}
+
+func TestValidateSpecInvalidSpec(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "chain-spec.json")
+	err := os.WriteFile(file, []byte(`{"name": "test"`), os.ModePerm)
+	require.NoError(t, err)
+
+	rootCmd, err := NewRootCommand()
+	require.NoError(t, err)
+	validateSpecCmd := newValidateSpecCmd()
+	rootCmd.AddCommand(validateSpecCmd)
+
+	rootCmd.SetArgs([]string{validateSpecCmd.Name(), file})
+	err = rootCmd.Execute()
+	assert.ErrorContains(t, err, "chain spec is invalid: 1 error(s) found")
+}
@@ -24,32 +24,45 @@ func main() {
 		commands.ImportRuntimeCmd,
 		commands.BuildSpecCmd,
 		commands.PruneStateCmd,
+		commands.CompactDBCmd,
 		commands.ImportStateCmd,
+		commands.TryRuntimeCmd,
+		commands.ForkOffCmd,
+		commands.InspectRuntimeCmd,
+		commands.BenchmarkCmd,
+		commands.ValidateSpecCmd,
+		commands.RevertCmd,
+		commands.ReplayBlockCmd,
+		commands.SpawnCmd,
+		commands.ConsoleCmd,
+		commands.KeyCmd,
+		commands.ChainCmd,
 		commands.VersionCmd,
 	)
-	configureCobraCmd("GSSMR")
+	configureCobraCmd("GOSSAMER", "GSSMR")
 	if err := rootCmd.Execute(); err != nil {
 		log.Errorf("failed to execute root command: %s", err)
 		panic(err)
 	}
 }
 
-// configureCobraCmd configures the cobra command with the given environment prefix and default base path.
-func configureCobraCmd(envPrefix string) {
+// configureCobraCmd configures the cobra command to read the environment variables
+// with the given prefix, falling back on the ones with the legacy prefix.
+func configureCobraCmd(envPrefix, legacyEnvPrefix string) {
 	cobra.OnInitialize(func() {
-		if err := initEnv(envPrefix); err != nil {
+		if err := initEnv(envPrefix, legacyEnvPrefix); err != nil {
 			return
 		}
 	})
 }
 
 // initEnv sets to use ENV variables if set.
-func initEnv(prefix string) error {
-	if err := copyEnvVars(prefix); err != nil {
+func initEnv(prefix, legacyPrefix string) error {
+	if err := copyEnvVars(prefix, legacyPrefix); err != nil {
 		return err
 	}
 
-	// env variables with GSSMR prefix (eg. GSSMR_ROOT)
+	// env variables with GOSSAMER prefix (eg. GOSSAMER_LOG_LEVEL or GOSSAMER_RPC_PORT)
 	viper.SetEnvPrefix(prefix)
 	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_"))
 	viper.AutomaticEnv()
@@ -57,21 +70,24 @@ func initEnv(prefix string) error {
 	return nil
 }
 
-// copyEnvVars copies all envs like GSSMRROOT to GSSMR_ROOT,
-// so we can support both formats.
-func copyEnvVars(prefix string) error {
-	prefix = strings.ToUpper(prefix)
-	ps := prefix + "_"
+// copyEnvVars copies all envs like GSSMRROOT or GSSMR_ROOT to GOSSAMER_ROOT,
+// unless GOSSAMER_ROOT is already set, so we can support the legacy formats.
+func copyEnvVars(prefix, legacyPrefix string) error {
+	ps := strings.ToUpper(prefix) + "_"
+	legacyPrefix = strings.ToUpper(legacyPrefix)
 	for _, e := range os.Environ() {
 		kv := strings.SplitN(e, "=", 2)
-		if len(kv) == 2 {
-			k, v := kv[0], kv[1]
-			if strings.HasPrefix(k, prefix) && !strings.HasPrefix(k, ps) {
-				k2 := strings.Replace(k, prefix, ps, 1)
-				if err := os.Setenv(k2, v); err != nil {
-					return err
-				}
-			}
+		if len(kv) != 2 || !strings.HasPrefix(kv[0], legacyPrefix) {
+			continue
+		}
+
+		k, v := kv[0], kv[1]
+		k2 := ps + strings.TrimPrefix(strings.TrimPrefix(k, legacyPrefix), "_")
+		if _, ok := os.LookupEnv(k2); ok {
+			continue
+		}
+		if err := os.Setenv(k2, v); err != nil {
+			return err
 		}
 	}
 
@@ -5,10 +5,15 @@
 
 import (
 	"fmt"
+	"net/url"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/ChainSafe/gossamer/dot/changefeed"
 	"github.com/ChainSafe/gossamer/dot/state/pruner"
+	"github.com/ChainSafe/gossamer/internal/chaos"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/genesis"
 	"github.com/ChainSafe/gossamer/lib/os"
@@ -29,6 +34,10 @@
 	DefaultRetainBlocks = uint32(512)
 	// DefaultPruning is the default pruning strategy
 	DefaultPruning = pruner.Archive
+	// DefaultTrieCacheSize is the default size in bytes of the trie cache
+	DefaultTrieCacheSize = uint(64 * 1024 * 1024)
+	// DefaultBlocksPruning is the default block bodies pruning, keeping the bodies of all the blocks
+	DefaultBlocksPruning = string(pruner.Archive)
 
 	// defaultAccount is the default account key
 	defaultAccount = "alice"
@@ -37,6 +46,18 @@
 	DefaultRole = common.AuthorityRole
 	// DefaultWasmInterpreter is the default wasm interpreter
 	DefaultWasmInterpreter = wazero.Name
+	// DefaultMaxClockDrift is the default maximum drift of the local clock from the network time
+	DefaultMaxClockDrift = time.Second
+	// DefaultPoolLimit is the default maximum number of transactions in the transaction pool
+	DefaultPoolLimit = uint(8192)
+	// DefaultPoolKbytes is the default maximum size in kilobytes of the transactions in the transaction pool
+	DefaultPoolKbytes = uint(20480)
+	// DefaultSyncKbytes is the default memory budget in kilobytes of the blocks buffered while syncing
+	DefaultSyncKbytes = uint(262144)
+	// DefaultTxBanDuration is the default duration the extrinsics failing repeatedly are banned for
+	DefaultTxBanDuration = 30 * time.Minute
+	// DefaultFailoverLeaseTTL is the default duration of the authoring lease of the failover
+	DefaultFailoverLeaseTTL = 18 * time.Second
 
 	// DefaultNetworkPort is the default network port
 	DefaultNetworkPort = uint16(7001)
@@ -46,6 +67,10 @@
 	DefaultMinPeers = 5
 	// DefaultMaxPeers is the default maximum number of peers
 	DefaultMaxPeers = 50
+	// DefaultBadgerGCInterval is the default interval of the value log garbage collection of the libp2p datastore
+	DefaultBadgerGCInterval = 15 * time.Minute
+	// DefaultBadgerGCDiscardRatio is the default ratio of stale data for a value log file to be garbage collected
+	DefaultBadgerGCDiscardRatio = 0.2
 
 	// DefaultRPCPort is the default RPC port
 	DefaultRPCPort = uint32(8545)
@@ -53,16 +78,25 @@
 	DefaultRPCHost = "localhost"
 	// DefaultWSPort is the default WS port
 	DefaultWSPort = uint32(8546)
+	// DefaultWSResumeBuffer is the default maximum number of notifications buffered for a
+	// websocket subscription to resume
+	DefaultWSResumeBuffer = uint32(256)
 
 	// DefaultPprofListenAddress is the default pprof listen address
 	DefaultPprofListenAddress = "localhost:6060"
 
+	// DefaultRelayChainPort is the default network port of the relay chain node embedded by a collator
+	DefaultRelayChainPort = uint16(7002)
+
 	// DefaultSystemName is the default system name
 	DefaultSystemName = "Gossamer"
 	// DefaultSystemVersion is the default system version
 	DefaultSystemVersion = "0.0.0"
 )
 
+// DefaultDataDir is the default directory holding the base path of each chain
+var DefaultDataDir = filepath.Join(xdg.DataHome, "gossamer")
+
 // DefaultRPCModules the default RPC modules
 var DefaultRPCModules = []string{
 	"system",
@@ -80,13 +114,14 @@
 // Config defines the configuration for the gossamer node
 type Config struct {
 	BaseConfig `mapstructure:",squash"`
-	Log        *LogConfig     `mapstructure:"log"`
-	Account    *AccountConfig `mapstructure:"account"`
-	Core       *CoreConfig    `mapstructure:"core"`
-	Network    *NetworkConfig `mapstructure:"network"`
-	State      *StateConfig   `mapstructure:"state"`
-	RPC        *RPCConfig     `mapstructure:"rpc"`
-	Pprof      *PprofConfig   `mapstructure:"pprof"`
+	Log        *LogConfig      `mapstructure:"log"`
+	Account    *AccountConfig  `mapstructure:"account"`
+	Core       *CoreConfig     `mapstructure:"core"`
+	Network    *NetworkConfig  `mapstructure:"network"`
+	State      *StateConfig    `mapstructure:"state"`
+	RPC        *RPCConfig      `mapstructure:"rpc"`
+	Pprof      *PprofConfig    `mapstructure:"pprof"`
+	Collator   *CollatorConfig `mapstructure:"collator"`
 
 	// System holds the system information
 	// Do not export this field, as it is not part of the config file
@@ -120,6 +155,9 @@ func (cfg *Config) ValidateBasic() error {
 	if err := cfg.Pprof.ValidateBasic(); err != nil {
 		return fmt.Errorf("pprof config: %w", err)
 	}
+	if err := cfg.Collator.ValidateBasic(); err != nil {
+		return fmt.Errorf("collator config: %w", err)
+	}
 	return nil
 }
 
@@ -136,6 +174,22 @@ type BaseConfig struct {
 	PrometheusExternal bool                        `mapstructure:"prometheus-external,omitempty"`
 	NoTelemetry        bool                        `mapstructure:"no-telemetry"`
 	TelemetryURLs      []genesis.TelemetryEndpoint `mapstructure:"telemetry-urls,omitempty"`
+	// TracesFile is the file the OpenTelemetry trace spans are exported to as JSON,
+	// tracing is disabled if it is empty
+	TracesFile string `mapstructure:"traces-file,omitempty"`
+	// OTLPEndpoint is the address of the OpenTelemetry collector the trace spans are exported to
+	// with OTLP over gRPC, the spans are not exported to a collector if it is empty
+	OTLPEndpoint string `mapstructure:"otlp-endpoint,omitempty"`
+	// OTLPInsecure disables the TLS of the connection to the OpenTelemetry collector
+	OTLPInsecure bool `mapstructure:"otlp-insecure,omitempty"`
+	// BlockHookURL is the webhook URL the blocks imported and finalised are posted to as JSON,
+	// for external indexers, no webhook is notified if it is empty
+	BlockHookURL string `mapstructure:"block-hook-url,omitempty"`
+	// BlockHookExec is the command run with each block imported and finalised written as JSON
+	// to its standard input, no command is run if it is empty
+	BlockHookExec string `mapstructure:"block-hook-exec,omitempty"`
+	// Chaos is the comma separated list of faults injected for testing, see chaos.ParseConfig
+	Chaos string `mapstructure:"chaos,omitempty"`
 }
 
 // SystemConfig represents the system configuration
@@ -166,46 +220,113 @@ type AccountConfig struct {
 
 // NetworkConfig is to marshal/unmarshal toml network config vars
 type NetworkConfig struct {
-	Port              uint16        `mapstructure:"port"`
-	Bootnodes         []string      `mapstructure:"bootnodes"`
-	ProtocolID        string        `mapstructure:"protocol"`
-	NoBootstrap       bool          `mapstructure:"no-bootstrap"`
-	NoMDNS            bool          `mapstructure:"no-mdns"`
-	MinPeers          int           `mapstructure:"min-peers"`
-	MaxPeers          int           `mapstructure:"max-peers"`
-	PersistentPeers   []string      `mapstructure:"persistent-peers"`
-	DiscoveryInterval time.Duration `mapstructure:"discovery-interval"`
-	PublicIP          string        `mapstructure:"public-ip"`
-	PublicDNS         string        `mapstructure:"public-dns"`
-	NodeKey           string        `mapstructure:"node-key"`
-	ListenAddress     string        `mapstructure:"listen-addr"`
+	Port               uint16        `mapstructure:"port"`
+	Bootnodes          []string      `mapstructure:"bootnodes"`
+	ProtocolID         string        `mapstructure:"protocol"`
+	NoBootstrap        bool          `mapstructure:"no-bootstrap"`
+	NoMDNS             bool          `mapstructure:"no-mdns"`
+	MinPeers           int           `mapstructure:"min-peers"`
+	MaxPeers           int           `mapstructure:"max-peers"`
+	PersistentPeers    []string      `mapstructure:"persistent-peers"`
+	DiscoveryInterval  time.Duration `mapstructure:"discovery-interval"`
+	PublicIP           string        `mapstructure:"public-ip"`
+	PublicDNS          string        `mapstructure:"public-dns"`
+	PublicAddresses    []string      `mapstructure:"public-addr"`
+	NodeKey            string        `mapstructure:"node-key"`
+	ListenAddresses    []string      `mapstructure:"listen-addr"`
+	ForceTxPropagation bool          `mapstructure:"force-tx-propagation"`
+	Compression        string        `mapstructure:"compression,omitempty"`
+	MaxBandwidth       uint          `mapstructure:"max-bandwidth,omitempty"`
+	NoPrivateIPv4      bool          `mapstructure:"no-private-ipv4,omitempty"`
+	// MaxStreams to MaxConnsPerSubnet of 0 keep the default limits of the libp2p resource manager
+	MaxStreams        uint `mapstructure:"max-streams,omitempty"`
+	MaxStreamsPerPeer uint `mapstructure:"max-streams-per-peer,omitempty"`
+	MaxNetworkMemory  uint `mapstructure:"max-network-memory,omitempty"`
+	MaxConnsPerPeer   uint `mapstructure:"max-conns-per-peer,omitempty"`
+	MaxConnsPerIP     uint `mapstructure:"max-conns-per-ip,omitempty"`
+	MaxConnsPerSubnet uint `mapstructure:"max-conns-per-subnet,omitempty"`
+	// BadgerGCInterval of 0 disables the value log garbage collection of the libp2p datastore
+	BadgerGCInterval        time.Duration `mapstructure:"badger-gc-interval"`
+	BadgerGCDiscardRatio    float64       `mapstructure:"badger-gc-discard-ratio"`
+	BadgerGCPauseDuringSync bool          `mapstructure:"badger-gc-pause-during-sync"`
 }
 
 // CoreConfig is to marshal/unmarshal toml core config vars
 type CoreConfig struct {
-	Role             common.NetworkRole `mapstructure:"role,omitempty"`
-	BabeAuthority    bool               `mapstructure:"babe-authority"`
-	GrandpaAuthority bool               `mapstructure:"grandpa-authority"`
-	WasmInterpreter  string             `mapstructure:"wasm-interpreter,omitempty"`
-	GrandpaInterval  time.Duration      `mapstructure:"grandpa-interval,omitempty"`
+	Role                     common.NetworkRole `mapstructure:"role,omitempty"`
+	BabeAuthority            bool               `mapstructure:"babe-authority"`
+	GrandpaAuthority         bool               `mapstructure:"grandpa-authority"`
+	WasmInterpreter          string             `mapstructure:"wasm-interpreter,omitempty"`
+	GrandpaInterval          time.Duration      `mapstructure:"grandpa-interval,omitempty"`
+	GrandpaPause             string             `mapstructure:"grandpa-pause,omitempty"`
+	DevSeal                  string             `mapstructure:"dev-seal,omitempty"`
+	StubMissingHostFunctions bool               `mapstructure:"stub-missing-host-functions,omitempty"`
+	MaxHeapPages             uint32             `mapstructure:"max-heap-pages,omitempty"`
+	WasmCacheDir             string             `mapstructure:"wasm-cache-dir,omitempty"`
+	WasmMetering             bool               `mapstructure:"wasm-metering,omitempty"`
+	MaxClockDrift            time.Duration      `mapstructure:"max-clock-drift,omitempty"`
+	NTPServer                string             `mapstructure:"ntp-server,omitempty"`
+	AuthoringDryRun          bool               `mapstructure:"authoring-dry-run,omitempty"`
+	Standby                  bool               `mapstructure:"standby,omitempty"`
+	FailoverLease            string             `mapstructure:"failover-lease,omitempty"`
+	FailoverLeaseTTL         time.Duration      `mapstructure:"failover-lease-ttl"`
+	MisbehaviourReport       string             `mapstructure:"misbehaviour-report,omitempty"`
+	PoolLimit                uint               `mapstructure:"pool-limit"`
+	PoolKbytes               uint               `mapstructure:"pool-kbytes"`
+	SyncKbytes               uint               `mapstructure:"sync-kbytes"`
+	TxBanDuration            time.Duration      `mapstructure:"tx-ban-duration"`
+	WatchdogBlockProduction  time.Duration      `mapstructure:"watchdog-block-production,omitempty"`
+	WatchdogFinality         time.Duration      `mapstructure:"watchdog-finality,omitempty"`
+	WatchdogSync             time.Duration      `mapstructure:"watchdog-sync,omitempty"`
 }
 
 // StateConfig contains the configuration for the state.
 type StateConfig struct {
-	Rewind uint `mapstructure:"rewind,omitempty"`
+	Rewind        uint `mapstructure:"rewind,omitempty"`
+	Repair        bool `mapstructure:"repair,omitempty"`
+	TrieCacheSize uint `mapstructure:"trie-cache-size"`
+	Snapshot      bool `mapstructure:"snapshot"`
+	// PruneJustifications only keeps the justifications of the authority set changes and
+	// the justification of the highest block justified
+	PruneJustifications bool `mapstructure:"prune-justifications"`
+	// PruneEpochs prunes the BABE epoch data of the epochs older than two epochs before the
+	// epoch of the highest finalised block
+	PruneEpochs bool `mapstructure:"prune-epochs"`
+	// BlocksPruning is "archive" to keep the bodies of all the blocks, or the number of the
+	// highest finalised blocks whose bodies are kept, the older bodies being pruned
+	BlocksPruning string `mapstructure:"blocks-pruning"`
+	// StorageWatch are the storage prefixes, hex encoded or formatted as <pallet>.<entry>, whose
+	// changes at each block finalised are appended to the storage changefeed file
+	StorageWatch []string `mapstructure:"storage-watch,omitempty"`
+	// StorageWatchFile is the storage changefeed file, storage-changefeed.jsonl in the base path
+	// if it is empty
+	StorageWatchFile string `mapstructure:"storage-watch-file,omitempty"`
+	// WarmupBlocks is the number of most recent blocks replayed in the background on startup to
+	// warm the trie and runtime caches, 0 disabling the warmup
+	WarmupBlocks uint `mapstructure:"warmup-blocks"`
 }
 
 // RPCConfig is to marshal/unmarshal toml RPC config vars
 type RPCConfig struct {
-	RPCExternal       bool     `mapstructure:"rpc-external,omitempty"`
-	UnsafeRPC         bool     `mapstructure:"unsafe-rpc,omitempty"`
-	UnsafeRPCExternal bool     `mapstructure:"unsafe-rpc-external,omitempty"`
-	Port              uint32   `mapstructure:"port,omitempty"`
-	Host              string   `mapstructure:"host,omitempty"`
-	Modules           []string `mapstructure:"modules,omitempty"`
-	WSPort            uint32   `mapstructure:"ws-port,omitempty"`
-	WSExternal        bool     `mapstructure:"ws-external,omitempty"`
-	UnsafeWSExternal  bool     `mapstructure:"unsafe-ws-external,omitempty"`
+	RPCExternal       bool          `mapstructure:"rpc-external,omitempty"`
+	UnsafeRPC         bool          `mapstructure:"unsafe-rpc,omitempty"`
+	UnsafeRPCExternal bool          `mapstructure:"unsafe-rpc-external,omitempty"`
+	Port              uint32        `mapstructure:"port,omitempty"`
+	Host              string        `mapstructure:"host,omitempty"`
+	Modules           []string      `mapstructure:"modules,omitempty"`
+	WSPort            uint32        `mapstructure:"ws-port,omitempty"`
+	WSExternal        bool          `mapstructure:"ws-external,omitempty"`
+	UnsafeWSExternal  bool          `mapstructure:"unsafe-ws-external,omitempty"`
+	NoHealthEndpoint  bool          `mapstructure:"no-health-endpoint,omitempty"`
+	GRPCPort          uint32        `mapstructure:"grpc-port,omitempty"`
+	GRPCExternal      bool          `mapstructure:"grpc-external,omitempty"`
+	Cors              []string      `mapstructure:"cors,omitempty"`
+	TLSCert           string        `mapstructure:"tls-cert,omitempty"`
+	TLSKey            string        `mapstructure:"tls-key,omitempty"`
+	UnixSocket        string        `mapstructure:"unix-socket,omitempty"`
+	WSUnixSocket      string        `mapstructure:"ws-unix-socket,omitempty"`
+	WSResumeWindow    time.Duration `mapstructure:"ws-resume-window"`
+	WSResumeBuffer    uint32        `mapstructure:"ws-resume-buffer"`
 }
 
 // PprofConfig contains the configuration for Pprof.
@@ -216,6 +337,16 @@ type PprofConfig struct {
 	MutexProfileRate int    `mapstructure:"mutex-profile-rate,omitempty"`
 }
 
+// CollatorConfig contains the configuration of the collator of a parachain node,
+// collating against the relay chain followed by an embedded relay chain node.
+type CollatorConfig struct {
+	Enabled        bool     `mapstructure:"enabled,omitempty"`
+	ParaID         uint32   `mapstructure:"para-id,omitempty"`
+	RelayChainSpec string   `mapstructure:"relay-chain-spec,omitempty"`
+	RelayPort      uint16   `mapstructure:"relay-port,omitempty"`
+	RelayBootnodes []string `mapstructure:"relay-bootnodes,omitempty"`
+}
+
 // ValidateBasic does the basic validation on BaseConfig
 func (b *BaseConfig) ValidateBasic() error {
 	if b.Name == "" {
@@ -239,6 +370,18 @@ func (b *BaseConfig) ValidateBasic() error {
 			uint32Max,
 		)
 	}
+	if _, err := chaos.ParseConfig(b.Chaos); err != nil {
+		return fmt.Errorf("chaos is invalid: %w", err)
+	}
+	if b.BlockHookURL != "" {
+		hookURL, err := url.Parse(b.BlockHookURL)
+		if err != nil {
+			return fmt.Errorf("block-hook-url is invalid: %w", err)
+		}
+		if hookURL.Scheme != "http" && hookURL.Scheme != "https" {
+			return fmt.Errorf("block-hook-url scheme must be http or https, got %q", hookURL.Scheme)
+		}
+	}
 
 	return nil
 }
@@ -268,6 +411,14 @@ func (n *NetworkConfig) ValidateBasic() error {
 	if n.DiscoveryInterval == 0 {
 		return fmt.Errorf("discovery-interval cannot be empty")
 	}
+	switch n.Compression {
+	case "", "none", "zstd", "snappy":
+	default:
+		return fmt.Errorf("compression must be one of 'none', 'zstd' or 'snappy'")
+	}
+	if n.BadgerGCDiscardRatio < 0 || n.BadgerGCDiscardRatio >= 1 {
+		return fmt.Errorf("badger-gc-discard-ratio must be between 0 and 1")
+	}
 
 	return nil
 }
@@ -280,15 +431,92 @@ func (c *CoreConfig) ValidateBasic() error {
 	if c.WasmInterpreter != wazero.Name {
 		return fmt.Errorf("wasm-interpreter is invalid")
 	}
+	if c.DevSeal != "" && c.DevSeal != "instant" && c.DevSeal != "manual" {
+		return fmt.Errorf("dev-seal must be one of 'instant' or 'manual'")
+	}
+	if c.MaxClockDrift < 0 {
+		return fmt.Errorf("max-clock-drift cannot be negative")
+	}
+	if c.WatchdogBlockProduction < 0 || c.WatchdogFinality < 0 || c.WatchdogSync < 0 {
+		return fmt.Errorf("watchdog windows cannot be negative")
+	}
+	if c.AuthoringDryRun && !c.BabeAuthority {
+		return fmt.Errorf("authoring-dry-run requires babe-authority")
+	}
+	if c.Standby && !c.BabeAuthority && !c.GrandpaAuthority {
+		return fmt.Errorf("standby requires babe-authority or grandpa-authority")
+	}
+	if c.FailoverLease != "" {
+		if !c.BabeAuthority && !c.GrandpaAuthority {
+			return fmt.Errorf("failover-lease requires babe-authority or grandpa-authority")
+		}
+		if c.FailoverLeaseTTL <= 0 {
+			return fmt.Errorf("failover-lease-ttl must be positive")
+		}
+	}
+	if c.GrandpaPause != "" {
+		if _, _, err := ParseGrandpaPause(c.GrandpaPause); err != nil {
+			return fmt.Errorf("grandpa-pause is invalid: %w", err)
+		}
+	}
 
 	return nil
 }
 
+// ParseGrandpaPause parses the grandpa-pause value formatted as "<block>,<delay>", the GRANDPA
+// votes not going past the block given until the best block is the delay given past it.
+func ParseGrandpaPause(value string) (block, delay uint32, err error) {
+	blockString, delayString, ok := strings.Cut(value, ",")
+	if !ok {
+		return 0, 0, fmt.Errorf("%q is not formatted as <block>,<delay>", value)
+	}
+
+	parsedBlock, err := strconv.ParseUint(strings.TrimSpace(blockString), 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("parsing block: %w", err)
+	}
+
+	parsedDelay, err := strconv.ParseUint(strings.TrimSpace(delayString), 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("parsing delay: %w", err)
+	}
+
+	return uint32(parsedBlock), uint32(parsedDelay), nil
+}
+
 // ValidateBasic does the basic validation on StateConfig
 func (s *StateConfig) ValidateBasic() error {
+	if _, _, err := ParseBlocksPruning(s.BlocksPruning); err != nil {
+		return fmt.Errorf("blocks-pruning is invalid: %w", err)
+	}
+	for _, prefix := range s.StorageWatch {
+		if _, err := changefeed.ParsePrefix(prefix); err != nil {
+			return fmt.Errorf("storage-watch is invalid: %w", err)
+		}
+	}
+
 	return nil
 }
 
+// ParseBlocksPruning parses the blocks-pruning value, either "archive" to keep the bodies of all
+// the blocks or the number of the highest finalised blocks whose bodies are kept. An empty value
+// is handled as "archive".
+func ParseBlocksPruning(value string) (prune bool, retained uint, err error) {
+	if value == "" || value == string(pruner.Archive) {
+		return false, 0, nil
+	}
+
+	parsed, err := strconv.ParseUint(strings.TrimSpace(value), 10, 32)
+	if err != nil {
+		return false, 0, fmt.Errorf("%q is neither %q nor a number of blocks", value, pruner.Archive)
+	}
+	if parsed == 0 {
+		return false, 0, fmt.Errorf("the number of blocks whose bodies are kept cannot be 0")
+	}
+
+	return true, uint(parsed), nil
+}
+
 // ValidateBasic does the basic validation on RPCConfig
 func (r *RPCConfig) ValidateBasic() error {
 	if r.IsRPCEnabled() {
@@ -302,6 +530,9 @@ func (r *RPCConfig) ValidateBasic() error {
 	if r.IsWSEnabled() && r.WSPort == 0 {
 		return fmt.Errorf("ws port cannot be empty")
 	}
+	if (r.TLSCert == "") != (r.TLSKey == "") {
+		return fmt.Errorf("tls cert and tls key must be set together")
+	}
 
 	return nil
 }
@@ -315,14 +546,29 @@ func (p *PprofConfig) ValidateBasic() error {
 	return nil
 }
 
+// ValidateBasic does the basic validation on CollatorConfig
+func (c *CollatorConfig) ValidateBasic() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.RelayChainSpec == "" {
+		return fmt.Errorf("relay chain spec cannot be empty")
+	}
+	if c.RelayPort == 0 {
+		return fmt.Errorf("relay port cannot be empty")
+	}
+
+	return nil
+}
+
 // IsRPCEnabled returns true if RPC is enabled.
 func (r *RPCConfig) IsRPCEnabled() bool {
-	return r.UnsafeRPCExternal || r.RPCExternal || r.UnsafeRPC
+	return r.UnsafeRPCExternal || r.RPCExternal || r.UnsafeRPC || r.UnixSocket != ""
 }
 
 // IsWSEnabled returns true if WS is enabled.
 func (r *RPCConfig) IsWSEnabled() bool {
-	return r.WSExternal || r.UnsafeWSExternal
+	return r.WSExternal || r.UnsafeWSExternal || r.WSUnixSocket != ""
 }
 
 // DefaultConfig returns the default configuration.
@@ -331,7 +577,7 @@ func DefaultConfig() *Config {
 		BaseConfig: BaseConfig{
 			Name:               "Gossamer",
 			ID:                 "gssmr",
-			BasePath:           xdg.DataHome + "gossamer",
+			BasePath:           DefaultDataDir,
 			ChainSpec:          "",
 			LogLevel:           DefaultLogLevel,
 			PrometheusPort:     DefaultPrometheusPort,
@@ -363,6 +609,12 @@ func DefaultConfig() *Config {
 			GrandpaAuthority: true,
 			WasmInterpreter:  DefaultWasmInterpreter,
 			GrandpaInterval:  DefaultDiscoveryInterval,
+			MaxClockDrift:    DefaultMaxClockDrift,
+			PoolLimit:        DefaultPoolLimit,
+			PoolKbytes:       DefaultPoolKbytes,
+			SyncKbytes:       DefaultSyncKbytes,
+			TxBanDuration:    DefaultTxBanDuration,
+			FailoverLeaseTTL: DefaultFailoverLeaseTTL,
 		},
 		Network: &NetworkConfig{
 			Port:              DefaultNetworkPort,
@@ -377,10 +629,19 @@ func DefaultConfig() *Config {
 			PublicIP:          "",
 			PublicDNS:         "",
 			NodeKey:           "",
-			ListenAddress:     "",
+			ListenAddresses:   nil,
+
+			BadgerGCInterval:     DefaultBadgerGCInterval,
+			BadgerGCDiscardRatio: DefaultBadgerGCDiscardRatio,
 		},
 		State: &StateConfig{
-			Rewind: 0,
+			Rewind:              0,
+			Repair:              false,
+			TrieCacheSize:       DefaultTrieCacheSize,
+			Snapshot:            false,
+			PruneJustifications: false,
+			PruneEpochs:         false,
+			BlocksPruning:       DefaultBlocksPruning,
 		},
 		RPC: &RPCConfig{
 			RPCExternal:       false,
@@ -392,6 +653,16 @@ func DefaultConfig() *Config {
 			WSPort:            DefaultWSPort,
 			WSExternal:        false,
 			UnsafeWSExternal:  false,
+			NoHealthEndpoint:  false,
+			GRPCPort:          0,
+			GRPCExternal:      false,
+			Cors:              nil,
+			TLSCert:           "",
+			TLSKey:            "",
+			UnixSocket:        "",
+			WSUnixSocket:      "",
+			WSResumeWindow:    0,
+			WSResumeBuffer:    DefaultWSResumeBuffer,
 		},
 		Pprof: &PprofConfig{
 			Enabled:          false,
@@ -399,6 +670,13 @@ func DefaultConfig() *Config {
 			BlockProfileRate: 0,
 			MutexProfileRate: 0,
 		},
+		Collator: &CollatorConfig{
+			Enabled:        false,
+			ParaID:         0,
+			RelayChainSpec: "",
+			RelayPort:      DefaultRelayChainPort,
+			RelayBootnodes: nil,
+		},
 		System: &SystemConfig{
 			SystemName:    DefaultSystemName,
 			SystemVersion: GetFullVersion(),
@@ -412,7 +690,7 @@ func DefaultConfigFromSpec(nodeSpec *genesis.Genesis) *Config {
 		BaseConfig: BaseConfig{
 			Name:               nodeSpec.Name,
 			ID:                 nodeSpec.ID,
-			BasePath:           xdg.DataHome + "gossamer",
+			BasePath:           ChainBasePath(DefaultDataDir, nodeSpec.ID),
 			ChainSpec:          "",
 			LogLevel:           DefaultLogLevel,
 			PrometheusPort:     uint32(9876),
@@ -444,6 +722,12 @@ func DefaultConfigFromSpec(nodeSpec *genesis.Genesis) *Config {
 			GrandpaAuthority: true,
 			WasmInterpreter:  DefaultWasmInterpreter,
 			GrandpaInterval:  DefaultDiscoveryInterval,
+			MaxClockDrift:    DefaultMaxClockDrift,
+			PoolLimit:        DefaultPoolLimit,
+			PoolKbytes:       DefaultPoolKbytes,
+			SyncKbytes:       DefaultSyncKbytes,
+			TxBanDuration:    DefaultTxBanDuration,
+			FailoverLeaseTTL: DefaultFailoverLeaseTTL,
 		},
 		Network: &NetworkConfig{
 			Port:              DefaultNetworkPort,
@@ -458,10 +742,19 @@ func DefaultConfigFromSpec(nodeSpec *genesis.Genesis) *Config {
 			PublicIP:          "",
 			PublicDNS:         "",
 			NodeKey:           "",
-			ListenAddress:     "",
+			ListenAddresses:   nil,
+
+			BadgerGCInterval:     DefaultBadgerGCInterval,
+			BadgerGCDiscardRatio: DefaultBadgerGCDiscardRatio,
 		},
 		State: &StateConfig{
-			Rewind: 0,
+			Rewind:              0,
+			Repair:              false,
+			TrieCacheSize:       DefaultTrieCacheSize,
+			Snapshot:            false,
+			PruneJustifications: false,
+			PruneEpochs:         false,
+			BlocksPruning:       DefaultBlocksPruning,
 		},
 		RPC: &RPCConfig{
 			RPCExternal:       false,
@@ -473,6 +766,16 @@ func DefaultConfigFromSpec(nodeSpec *genesis.Genesis) *Config {
 			WSPort:            DefaultWSPort,
 			WSExternal:        false,
 			UnsafeWSExternal:  false,
+			NoHealthEndpoint:  false,
+			GRPCPort:          0,
+			GRPCExternal:      false,
+			Cors:              nil,
+			TLSCert:           "",
+			TLSKey:            "",
+			UnixSocket:        "",
+			WSUnixSocket:      "",
+			WSResumeWindow:    0,
+			WSResumeBuffer:    DefaultWSResumeBuffer,
 		},
 		Pprof: &PprofConfig{
 			Enabled:          false,
@@ -480,6 +783,13 @@ func DefaultConfigFromSpec(nodeSpec *genesis.Genesis) *Config {
 			BlockProfileRate: 0,
 			MutexProfileRate: 0,
 		},
+		Collator: &CollatorConfig{
+			Enabled:        false,
+			ParaID:         0,
+			RelayChainSpec: "",
+			RelayPort:      DefaultRelayChainPort,
+			RelayBootnodes: nil,
+		},
 		System: &SystemConfig{
 			SystemName:    DefaultSystemName,
 			SystemVersion: GetFullVersion(),
@@ -502,6 +812,12 @@ func Copy(c *Config) Config {
 			PrometheusExternal: c.PrometheusExternal,
 			NoTelemetry:        c.NoTelemetry,
 			TelemetryURLs:      c.TelemetryURLs,
+			TracesFile:         c.TracesFile,
+			OTLPEndpoint:       c.OTLPEndpoint,
+			OTLPInsecure:       c.OTLPInsecure,
+			BlockHookURL:       c.BlockHookURL,
+			BlockHookExec:      c.BlockHookExec,
+			Chaos:              c.Chaos,
 		},
 		Log: &LogConfig{
 			Core:    c.Log.Core,
@@ -520,29 +836,73 @@ func Copy(c *Config) Config {
 			Unlock: c.Account.Unlock,
 		},
 		Core: &CoreConfig{
-			Role:             c.Core.Role,
-			BabeAuthority:    c.Core.BabeAuthority,
-			GrandpaAuthority: c.Core.GrandpaAuthority,
-			WasmInterpreter:  c.Core.WasmInterpreter,
-			GrandpaInterval:  c.Core.GrandpaInterval,
+			Role:                     c.Core.Role,
+			BabeAuthority:            c.Core.BabeAuthority,
+			GrandpaAuthority:         c.Core.GrandpaAuthority,
+			WasmInterpreter:          c.Core.WasmInterpreter,
+			GrandpaInterval:          c.Core.GrandpaInterval,
+			GrandpaPause:             c.Core.GrandpaPause,
+			DevSeal:                  c.Core.DevSeal,
+			StubMissingHostFunctions: c.Core.StubMissingHostFunctions,
+			MaxHeapPages:             c.Core.MaxHeapPages,
+			WasmCacheDir:             c.Core.WasmCacheDir,
+			WasmMetering:             c.Core.WasmMetering,
+			MaxClockDrift:            c.Core.MaxClockDrift,
+			NTPServer:                c.Core.NTPServer,
+			AuthoringDryRun:          c.Core.AuthoringDryRun,
+			Standby:                  c.Core.Standby,
+			FailoverLease:            c.Core.FailoverLease,
+			FailoverLeaseTTL:         c.Core.FailoverLeaseTTL,
+			MisbehaviourReport:       c.Core.MisbehaviourReport,
+			PoolLimit:                c.Core.PoolLimit,
+			PoolKbytes:               c.Core.PoolKbytes,
+			SyncKbytes:               c.Core.SyncKbytes,
+			TxBanDuration:            c.Core.TxBanDuration,
+			WatchdogBlockProduction:  c.Core.WatchdogBlockProduction,
+			WatchdogFinality:         c.Core.WatchdogFinality,
+			WatchdogSync:             c.Core.WatchdogSync,
 		},
 		Network: &NetworkConfig{
-			Port:              c.Network.Port,
-			Bootnodes:         c.Network.Bootnodes,
-			ProtocolID:        c.Network.ProtocolID,
-			NoBootstrap:       c.Network.NoBootstrap,
-			NoMDNS:            c.Network.NoMDNS,
-			MinPeers:          c.Network.MinPeers,
-			MaxPeers:          c.Network.MaxPeers,
-			PersistentPeers:   c.Network.PersistentPeers,
-			DiscoveryInterval: c.Network.DiscoveryInterval,
-			PublicIP:          c.Network.PublicIP,
-			PublicDNS:         c.Network.PublicDNS,
-			NodeKey:           c.Network.NodeKey,
-			ListenAddress:     c.Network.ListenAddress,
+			Port:               c.Network.Port,
+			Bootnodes:          c.Network.Bootnodes,
+			ProtocolID:         c.Network.ProtocolID,
+			NoBootstrap:        c.Network.NoBootstrap,
+			NoMDNS:             c.Network.NoMDNS,
+			MinPeers:           c.Network.MinPeers,
+			MaxPeers:           c.Network.MaxPeers,
+			PersistentPeers:    c.Network.PersistentPeers,
+			DiscoveryInterval:  c.Network.DiscoveryInterval,
+			PublicIP:           c.Network.PublicIP,
+			PublicDNS:          c.Network.PublicDNS,
+			PublicAddresses:    c.Network.PublicAddresses,
+			NodeKey:            c.Network.NodeKey,
+			ListenAddresses:    c.Network.ListenAddresses,
+			ForceTxPropagation: c.Network.ForceTxPropagation,
+			Compression:        c.Network.Compression,
+			MaxBandwidth:       c.Network.MaxBandwidth,
+			NoPrivateIPv4:      c.Network.NoPrivateIPv4,
+			MaxStreams:         c.Network.MaxStreams,
+			MaxStreamsPerPeer:  c.Network.MaxStreamsPerPeer,
+			MaxNetworkMemory:   c.Network.MaxNetworkMemory,
+			MaxConnsPerPeer:    c.Network.MaxConnsPerPeer,
+			MaxConnsPerIP:      c.Network.MaxConnsPerIP,
+			MaxConnsPerSubnet:  c.Network.MaxConnsPerSubnet,
+
+			BadgerGCInterval:        c.Network.BadgerGCInterval,
+			BadgerGCDiscardRatio:    c.Network.BadgerGCDiscardRatio,
+			BadgerGCPauseDuringSync: c.Network.BadgerGCPauseDuringSync,
 		},
 		State: &StateConfig{
-			Rewind: c.State.Rewind,
+			Rewind:              c.State.Rewind,
+			Repair:              c.State.Repair,
+			TrieCacheSize:       c.State.TrieCacheSize,
+			Snapshot:            c.State.Snapshot,
+			PruneJustifications: c.State.PruneJustifications,
+			PruneEpochs:         c.State.PruneEpochs,
+			BlocksPruning:       c.State.BlocksPruning,
+			StorageWatch:        c.State.StorageWatch,
+			StorageWatchFile:    c.State.StorageWatchFile,
+			WarmupBlocks:        c.State.WarmupBlocks,
 		},
 		RPC: &RPCConfig{
 			UnsafeRPC:         c.RPC.UnsafeRPC,
@@ -554,6 +914,16 @@ func Copy(c *Config) Config {
 			WSPort:            c.RPC.WSPort,
 			WSExternal:        c.RPC.WSExternal,
 			UnsafeWSExternal:  c.RPC.UnsafeWSExternal,
+			NoHealthEndpoint:  c.RPC.NoHealthEndpoint,
+			GRPCPort:          c.RPC.GRPCPort,
+			GRPCExternal:      c.RPC.GRPCExternal,
+			Cors:              c.RPC.Cors,
+			TLSCert:           c.RPC.TLSCert,
+			TLSKey:            c.RPC.TLSKey,
+			UnixSocket:        c.RPC.UnixSocket,
+			WSUnixSocket:      c.RPC.WSUnixSocket,
+			WSResumeWindow:    c.RPC.WSResumeWindow,
+			WSResumeBuffer:    c.RPC.WSResumeBuffer,
 		},
 		Pprof: &PprofConfig{
 			Enabled:          c.Pprof.Enabled,
@@ -561,6 +931,13 @@ func Copy(c *Config) Config {
 			BlockProfileRate: c.Pprof.BlockProfileRate,
 			MutexProfileRate: c.Pprof.MutexProfileRate,
 		},
+		Collator: &CollatorConfig{
+			Enabled:        c.Collator.Enabled,
+			ParaID:         c.Collator.ParaID,
+			RelayChainSpec: c.Collator.RelayChainSpec,
+			RelayPort:      c.Collator.RelayPort,
+			RelayBootnodes: c.Collator.RelayBootnodes,
+		},
 		System: &SystemConfig{
 			SystemName:    c.System.SystemName,
 			SystemVersion: c.System.SystemVersion,
@@ -604,6 +981,31 @@ func (c Chain) String() string {
 	return string(c)
 }
 
+// chainAliases maps the ids of the chain specs of the default chains to their chain
+var chainAliases = map[string]Chain{
+	"ksmcc3":                KusamaChain,
+	"westend2":              WestendChain,
+	"westend_dev":           WestendDevChain,
+	"westend_local_testnet": WestendLocalChain,
+}
+
+// ParseChain returns the default chain with the given name or chain spec id alias,
+// and false if there is no such default chain.
+func ParseChain(name string) (chain Chain, ok bool) {
+	switch chain = Chain(name); chain {
+	case PolkadotChain, KusamaChain, WestendChain, WestendDevChain, WestendLocalChain, PaseoChain:
+		return chain, true
+	}
+
+	chain, ok = chainAliases[name]
+	return chain, ok
+}
+
+// ChainBasePath returns the base path of the chain with the given name in the data directory.
+func ChainBasePath(dataDir, name string) string {
+	return filepath.Join(dataDir, name)
+}
+
 // NetworkRole is a string representing a network role
 type NetworkRole string
 
@@ -56,7 +56,7 @@ func WriteConfigFile(basePath string, config *Config) error {
 # NOTE: Any path below can be absolute (e.g. "/var/gossamer/data") or
 # relative to the home directory (e.g. "data"). The home directory is
 # "$HOME/.local/share/gossamer" by default, but could be changed via
-# $GSSMRHOME env variable or --home cmd flag.
+# $GOSSAMER_HOME env variable or --base-path cmd flag.
 
 #######################################################################
 ###                   Main Base Config Options                      ###
@@ -113,6 +113,30 @@ func WriteConfigFile(basePath string, config *Config) error {
 # Defaults to false
 prometheus-external = {{ .BaseConfig.PrometheusExternal }}
 
+# File the OpenTelemetry trace spans, such as the phases of the block production, are exported to
+# Tracing is disabled if empty
+# Defaults to ""
+traces-file = "{{ .BaseConfig.TracesFile }}"
+
+# Address of the OpenTelemetry collector the trace spans are exported to with OTLP over gRPC,
+# such as "localhost:4317", to be viewed with Jaeger or Tempo
+# Defaults to ""
+otlp-endpoint = "{{ .BaseConfig.OTLPEndpoint }}"
+
+# Disable the TLS of the connection to the OpenTelemetry collector
+# Defaults to false
+otlp-insecure = {{ .BaseConfig.OTLPInsecure }}
+
+# Webhook URL the blocks imported and finalised, with their extrinsics and decoded events,
+# are posted to as JSON for external indexers
+# Defaults to ""
+block-hook-url = "{{ .BaseConfig.BlockHookURL }}"
+
+# Command run for each block imported and finalised, with the block written as JSON to its
+# standard input, such as "nats pub blocks" to publish the blocks to a message queue
+# Defaults to ""
+block-hook-exec = "{{ .BaseConfig.BlockHookExec }}"
+
 #######################################################################
 ###                 Advanced Configuration Options                  ###
 #######################################################################
@@ -211,11 +235,70 @@ func WriteConfigFile(basePath string, config *Config) error {
 # Overrides the public DNS used for peer to peer networking"
 public-dns = "{{ .Network.PublicDNS }}"
 
+# Comma separated IPv4, IPv6 or DNS multiaddrs advertised to other peers,
+# e.g. "/ip6/2001:db8::1/tcp/7001,/dns/node.example.com/tcp/7001"
+public-addr = "{{ StringsJoin .Network.PublicAddresses "," }}"
+
 # Overrides the secret Ed25519 key to use for libp2p networking
 node-key = "{{ .Network.NodeKey }}"
 
-# Multiaddress to listen on
-listen-addr = "{{ .Network.ListenAddress }}"
+# Comma separated multiaddresses to listen on, e.g. "/ip4/0.0.0.0/tcp/7001,/ip6/::/tcp/7001"
+listen-addr = "{{ StringsJoin .Network.ListenAddresses "," }}"
+
+# Relays transactions to peers even if the node is not an authority
+# Defaults to false
+force-tx-propagation = {{ .Network.ForceTxPropagation }}
+
+# Compression offered to peers for the notification and request-response payloads,
+# one of 'none', 'zstd' or 'snappy'. Compressed payloads are always accepted.
+# Defaults to none
+compression = "{{ .Network.Compression }}"
+
+# Soft limit of the outbound bandwidth in bytes per second, the transactions
+# gossip being deferred while it is exceeded. 0 disables the limit.
+# Defaults to 0
+max-bandwidth = {{ .Network.MaxBandwidth }}
+
+# Prevents dialing the peers on private IPv4 addresses, for public nodes
+# Defaults to false
+no-private-ipv4 = {{ .Network.NoPrivateIPv4 }}
+
+# Limits of the libp2p resource manager. 0 keeps the default limit, scaled to the
+# memory and file descriptors of the machine.
+# Maximum number of streams open at a time
+max-streams = {{ .Network.MaxStreams }}
+
+# Maximum number of streams open with a peer at a time
+max-streams-per-peer = {{ .Network.MaxStreamsPerPeer }}
+
+# Maximum memory in bytes reserved by the libp2p connections and streams
+max-network-memory = {{ .Network.MaxNetworkMemory }}
+
+# Maximum number of connections with a peer
+max-conns-per-peer = {{ .Network.MaxConnsPerPeer }}
+
+# Maximum number of connections with the peers of an IPv4 address or of a /56 IPv6 subnet
+# Defaults to 8 when 0
+max-conns-per-ip = {{ .Network.MaxConnsPerIP }}
+
+# Maximum number of connections with the peers of a /24 IPv4 subnet or of a /48 IPv6 subnet.
+# 0 does not limit the /24 IPv4 subnets and limits the /48 IPv6 subnets to 64 connections.
+max-conns-per-subnet = {{ .Network.MaxConnsPerSubnet }}
+
+# Interval of the value log garbage collection of the badger datastore persisting
+# the peerstore. "0s" disables the garbage collection.
+# Format: "10s", "1m", "1h"
+# Defaults to "15m0s"
+badger-gc-interval = "{{ .Network.BadgerGCInterval }}"
+
+# Minimum ratio of stale data for a value log file of the badger datastore to be
+# rewritten by the garbage collection, between 0 and 1
+# Defaults to 0.2
+badger-gc-discard-ratio = {{ .Network.BadgerGCDiscardRatio }}
+
+# Skips the scheduled badger garbage collections while the node is syncing
+# Defaults to false
+badger-gc-pause-during-sync = {{ .Network.BadgerGCPauseDuringSync }}
 
 #######################################################
 ###             Core Configuration Options          ###
@@ -242,6 +325,109 @@ func WriteConfigFile(basePath string, config *Config) error {
 # Grandpa interval
 grandpa-interval = "{{ .Core.GrandpaInterval }}"
 
+# Pause of the GRANDPA voting formatted as "<block>,<delay>": the votes do not go past
+# the block given until the best block is the delay given past it
+# Defaults to "" (no pause)
+grandpa-pause = "{{ .Core.GrandpaPause }}"
+
+# Development block authoring mode replacing BABE slots
+# One of: "instant" (a block per transaction), "manual" (blocks authored with engine_createBlock)
+# Defaults to "" (BABE slots)
+dev-seal = "{{ .Core.DevSeal }}"
+
+# Link the host functions imported by the runtime and not implemented to stubs
+# failing the runtime calls using them, instead of failing to load the runtime
+# Defaults to false
+stub-missing-host-functions = {{ .Core.StubMissingHostFunctions }}
+
+# Maximum number of 64KiB pages the memory of the runtime can grow to when allocating,
+# runtime calls exhausting it fail with an out of memory error
+# Defaults to 0 (65536 pages, the 4GiB limit of the WASM memory)
+max-heap-pages = {{ .Core.MaxHeapPages }}
+
+# Directory persisting the compiled WASM runtimes across restarts, in a gossamer-wazero-<version>
+# subdirectory replacing the one of the previous gossamer version
+# Defaults to "" (the wasm-cache directory in the base path)
+wasm-cache-dir = "{{ .Core.WasmCacheDir }}"
+
+# Instrument the runtime code to count the fuel consumed by each runtime call, one unit per
+# WASM instruction executed, logged at the debug level and exported as the gossamer_runtime_fuel metric
+# Defaults to false
+wasm-metering = {{ .Core.WasmMetering }}
+
+# Maximum drift of the local clock from the network time, estimated from the arrival
+# of the network blocks, above which a warning is logged and no block is authored
+# while the local clock is ahead, 0 disabling the check
+# Defaults to "1s"
+max-clock-drift = "{{ .Core.MaxClockDrift }}"
+
+# NTP server the drift of the local clock is measured against, in addition to the arrival
+# of the network blocks, such as "pool.ntp.org"
+# Defaults to "" (no NTP server)
+ntp-server = "{{ .Core.NTPServer }}"
+
+# Run the BABE slot lottery and build the blocks of the slots won without importing
+# nor broadcasting them, logging the blocks which would have been authored
+# Defaults to false
+authoring-dry-run = {{ .Core.AuthoringDryRun }}
+
+# Start the authority node in the full node role, authoring no block and casting no
+# GRANDPA vote until switched to the authority role with the dev_setNodeRole RPC method
+# or by reloading the configuration with standby set to false
+# Defaults to false
+standby = {{ .Core.Standby }}
+
+# Path of the lease file, on a filesystem shared with the other nodes holding the same
+# authority keys, the node in the authority role while holding the lease and in the full
+# node role otherwise, so a single node authors blocks and votes at a time
+# Defaults to "", no failover coordination
+failover-lease = "{{ .Core.FailoverLease }}"
+
+# Duration of the authoring lease, the node holding it switching to the full node role
+# when it could not renew it for two thirds of the duration
+# Defaults to 18s
+failover-lease-ttl = "{{ .Core.FailoverLeaseTTL }}"
+
+# File the misbehaviours of the block authors, such as bad seals and invalid state roots,
+# are appended to as JSON lines, for an external service to report them
+# Defaults to "", the misbehaviours being only logged and exported as metrics and telemetry
+misbehaviour-report = "{{ .Core.MisbehaviourReport }}"
+
+# Maximum number of transactions in the transaction pool, the transactions of lowest
+# priority being dropped when exceeded, 0 disabling the limit
+# Defaults to 8192
+pool-limit = {{ .Core.PoolLimit }}
+
+# Maximum size in kilobytes of the transactions in the transaction pool, the transactions
+# of lowest priority being dropped when exceeded, 0 disabling the limit
+# Defaults to 20480
+pool-kbytes = {{ .Core.PoolKbytes }}
+
+# Memory budget in kilobytes of the blocks buffered between their download, verification
+# and execution while syncing, no more blocks being requested once exceeded
+# Defaults to 262144
+sync-kbytes = {{ .Core.SyncKbytes }}
+
+# Duration the extrinsics repeatedly failing validation or block building are banned from
+# the transaction pool for, 0 disabling banning
+# Defaults to 30m0s
+tx-ban-duration = "{{ .Core.TxBanDuration }}"
+
+# Window the BABE slot loop must handle a slot within, the loop being restarted after
+# logging its goroutines and its last error otherwise, 0 disabling the watch
+# Defaults to 0s
+watchdog-block-production = "{{ .Core.WatchdogBlockProduction }}"
+
+# Window the GRANDPA voter must start a round within, the voter being restarted from the
+# next round after logging its goroutines and its last error otherwise, 0 disabling the watch
+# Defaults to 0s
+watchdog-finality = "{{ .Core.WatchdogFinality }}"
+
+# Window the sync engine must run its strategy within, the engine being restarted after
+# logging its goroutines and its last error otherwise, 0 disabling the watch
+# Defaults to 0s
+watchdog-sync = "{{ .Core.WatchdogSync }}"
+
 #######################################################
 ###            State Configuration Options          ###
 #######################################################
@@ -250,6 +436,51 @@ func WriteConfigFile(basePath string, config *Config) error {
 # Defaults to 0
 rewind = {{ .State.Rewind }}
 
+# Roll back to the last consistent finalised block if the database is inconsistent on startup
+# Defaults to false
+repair = {{ .State.Repair }}
+
+# Size in bytes of the cache of the trie nodes and storage values read from the database
+# Set to 0 to disable the cache
+# Defaults to 67108864
+trie-cache-size = {{ .State.TrieCacheSize }}
+
+# Number of most recent blocks replayed in the background on startup, loading the state of the
+# best block and executing the blocks again to warm the trie and runtime caches
+# Set to 0 to disable the warmup
+# Defaults to 0
+warmup-blocks = {{ .State.WarmupBlocks }}
+
+# Maintain a flat snapshot of the state of the best block, serving the storage reads of the
+# block executions without traversing the state trie
+# Defaults to false
+snapshot = {{ .State.Snapshot }}
+
+# Only keep the GRANDPA justifications of the blocks changing the authority set and the
+# justification of the highest block justified, pruning the intermediate justifications
+# Defaults to false
+prune-justifications = {{ .State.PruneJustifications }}
+
+# Prune the BABE epoch data of the epochs older than two epochs before the epoch of the
+# highest finalised block
+# Defaults to false
+prune-epochs = {{ .State.PruneEpochs }}
+
+# Block bodies pruning, either "archive" to keep the bodies of all the blocks or the number
+# of the highest finalised blocks whose bodies are kept, the headers being always kept
+# Defaults to "archive"
+blocks-pruning = "{{ .State.BlocksPruning }}"
+
+# Comma separated storage prefixes, hex encoded or formatted as <pallet>.<entry> such as
+# "Session.Validators,Sudo.Key", whose changes at each block finalised are appended to the
+# storage changefeed file as JSON lines
+# Defaults to ""
+storage-watch = "{{ StringsJoin .State.StorageWatch "," }}"
+
+# Storage changefeed file the changes of the watched storage prefixes are appended to
+# Defaults to "storage-changefeed.jsonl" in the base path
+storage-watch-file = "{{ .State.StorageWatchFile }}"
+
 #######################################################
 ###              RPC Configuration Options          ###
 #######################################################
@@ -291,6 +522,44 @@ func WriteConfigFile(basePath string, config *Config) error {
 # Defaults to false
 unsafe-ws-external = {{ .RPC.UnsafeWSExternal }}
 
+# Disable the /health and /health/readiness endpoints of the HTTP-RPC server
+# Defaults to false
+no-health-endpoint = {{ .RPC.NoHealthEndpoint }}
+
+# gRPC server listening port, 0 disabling the gRPC server
+# Defaults to 0
+grpc-port = {{ .RPC.GRPCPort }}
+
+# Enable external gRPC connections
+# Defaults to false
+grpc-external = {{ .RPC.GRPCExternal }}
+
+# Origins of the browser requests allowed by the HTTP-RPC and websocket servers, "*" allowing any origin
+# Defaults to [], the CORS headers not being set
+cors = [{{ range .RPC.Cors }}"{{ . }}", {{ end }}]
+
+# Certificate and key files of the TLS termination of the HTTP-RPC and websocket servers
+# Defaults to "", the servers being served without TLS
+tls-cert = "{{ .RPC.TLSCert }}"
+tls-key = "{{ .RPC.TLSKey }}"
+
+# Path of the unix domain socket the HTTP-RPC server also listens on
+# Defaults to "", no unix socket being listened on
+unix-socket = "{{ .RPC.UnixSocket }}"
+
+# Path of the unix domain socket the websocket server also listens on
+# Defaults to "", no unix socket being listened on
+ws-unix-socket = "{{ .RPC.WSUnixSocket }}"
+
+# Duration the finalised heads and storage subscriptions of a closed websocket connection can be
+# resumed during with their resume token, receiving the notifications missed
+# Defaults to "0s", the subscriptions not being resumable
+ws-resume-window = "{{ .RPC.WSResumeWindow }}"
+
+# Maximum number of notifications buffered for a websocket subscription to resume
+# Defaults to 256
+ws-resume-buffer = {{ .RPC.WSResumeBuffer }}
+
 #######################################################
 ###            PPROF Configuration Options          ###
 #######################################################
@@ -311,4 +580,29 @@ func WriteConfigFile(basePath string, config *Config) error {
 # The frequency at which the Go runtime samples the state of mutexes to generate mutex profile information.
 # Defaults to 0
 mutex-profile-rate = {{ .Pprof.MutexProfileRate }}
+
+#######################################################
+###          Collator Configuration Options         ###
+#######################################################
+[collator]
+
+# Collate the blocks of the parachain against the relay chain followed by an embedded relay chain node
+# Defaults to false
+enabled = {{ .Collator.Enabled }}
+
+# Identifier of the parachain on the relay chain
+# Defaults to 0
+para-id = {{ .Collator.ParaID }}
+
+# Path to the chain-spec file of the relay chain
+# Defaults to ""
+relay-chain-spec = "{{ .Collator.RelayChainSpec }}"
+
+# Network port of the embedded relay chain node
+# Defaults to 7002
+relay-port = {{ .Collator.RelayPort }}
+
+# Comma separated node URLs for the network discovery bootstrap of the embedded relay chain node
+# Defaults to the boot nodes of the relay chain-spec
+relay-bootnodes = "{{ StringsJoin .Collator.RelayBootnodes "," }}"
 `
@@ -0,0 +1,525 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math"
+	mrand "math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+)
+
+// benchmarkWritePrefix is the prefix of the scratch database table written to
+// by the storage benchmark, deleted once the benchmark is done.
+const benchmarkWritePrefix = "benchmark"
+
+var (
+	errNoStorageKeys       = errors.New("no storage keys in state")
+	errInvalidBlockRange   = errors.New("invalid block range")
+	errUnsupportedTrieType = errors.New("unsupported trie type")
+)
+
+// BenchmarkStats summarises the durations measured by a benchmark
+type BenchmarkStats struct {
+	Count   int
+	Total   time.Duration
+	Min     time.Duration
+	Max     time.Duration
+	Average time.Duration
+	Median  time.Duration
+	Stddev  time.Duration
+	P99     time.Duration
+	P95     time.Duration
+	P75     time.Duration
+}
+
+func newBenchmarkStats(durations []time.Duration) (stats BenchmarkStats) {
+	if len(durations) == 0 {
+		return stats
+	}
+
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+
+	stats.Count = len(sorted)
+	for _, duration := range sorted {
+		stats.Total += duration
+	}
+	stats.Min = sorted[0]
+	stats.Max = sorted[len(sorted)-1]
+	stats.Average = stats.Total / time.Duration(len(sorted))
+	stats.Median = percentile(sorted, 50)
+	stats.P99 = percentile(sorted, 99)
+	stats.P95 = percentile(sorted, 95)
+	stats.P75 = percentile(sorted, 75)
+
+	var variance float64
+	for _, duration := range sorted {
+		deviation := float64(duration - stats.Average)
+		variance += deviation * deviation
+	}
+	stats.Stddev = time.Duration(math.Sqrt(variance / float64(len(sorted))))
+
+	return stats
+}
+
+// percentile returns the nearest-rank percentile of the sorted durations given.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// String returns the statistics in nanoseconds, in the format used by `substrate benchmark`.
+func (s BenchmarkStats) String() string {
+	return fmt.Sprintf("Total: %d\nMin: %d, Max: %d\nAverage: %d, Median: %d, Stddev: %d\n"+
+		"Percentiles 99th, 95th, 75th: %d, %d, %d",
+		s.Total.Nanoseconds(), s.Min.Nanoseconds(), s.Max.Nanoseconds(),
+		s.Average.Nanoseconds(), s.Median.Nanoseconds(), s.Stddev.Nanoseconds(),
+		s.P99.Nanoseconds(), s.P95.Nanoseconds(), s.P75.Nanoseconds())
+}
+
+// BenchmarkStorageConfig is the configuration of a storage benchmark
+type BenchmarkStorageConfig struct {
+	BasePath string
+	// Keys is the number of storage keys of the best block state sampled, all of them if zero
+	Keys int
+}
+
+// BenchmarkStorageResult is the result of a storage benchmark
+type BenchmarkStorageResult struct {
+	StateRoot common.Hash
+	Read      BenchmarkStats
+	Write     BenchmarkStats
+}
+
+// Weight returns the read and write weights in the format of the `RocksDbWeight`
+// constant generated by `substrate benchmark storage`, based on the average durations.
+func (r *BenchmarkStorageResult) Weight() string {
+	return fmt.Sprintf("read: %d * constants::WEIGHT_REF_TIME_PER_NANOS,\n"+
+		"write: %d * constants::WEIGHT_REF_TIME_PER_NANOS,",
+		r.Read.Average.Nanoseconds(), r.Write.Average.Nanoseconds())
+}
+
+// BenchmarkStorage measures the latency of reading the storage values of the best block
+// state from the node database, bypassing the tries held in memory, and the latency of
+// writing values of the same sizes to the node database.
+func BenchmarkStorage(config BenchmarkStorageConfig) (*BenchmarkStorageResult, error) {
+	stateSrvc, err := newOfflineStateService(config.BasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer stopOfflineStateService(stateSrvc)
+
+	stateRoot, err := stateSrvc.Block.BestBlockStateRoot()
+	if err != nil {
+		return nil, fmt.Errorf("getting best block state root: %w", err)
+	}
+
+	entries, err := stateSrvc.Storage.Entries(&stateRoot)
+	if err != nil {
+		return nil, fmt.Errorf("getting state entries: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%w: at state root %s", errNoStorageKeys, stateRoot)
+	}
+
+	keys := make([][]byte, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, []byte(key))
+	}
+	mrand.Shuffle(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+	if config.Keys > 0 && config.Keys < len(keys) {
+		keys = keys[:config.Keys]
+	}
+
+	logger.Infof("reading %d keys at state root %s", len(keys), stateRoot)
+	readDurations := make([]time.Duration, len(keys))
+	for i, key := range keys {
+		start := time.Now()
+		_, err = stateSrvc.Storage.GetStorageFromDB(stateRoot, key)
+		readDurations[i] = time.Since(start)
+		if err != nil {
+			return nil, fmt.Errorf("reading key 0x%x: %w", key, err)
+		}
+	}
+
+	logger.Infof("writing %d keys", len(keys))
+	writeDurations, err := benchmarkWrites(stateSrvc.DB(), keys, entries)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BenchmarkStorageResult{
+		StateRoot: stateRoot,
+		Read:      newBenchmarkStats(readDurations),
+		Write:     newBenchmarkStats(writeDurations),
+	}, nil
+}
+
+// benchmarkWrites writes random values of the sizes of the values of the keys given to a
+// scratch table of the database, and deletes them once done.
+func benchmarkWrites(db database.Database, keys [][]byte, entries map[string][]byte) (
+	durations []time.Duration, err error) {
+	table := database.NewTable(db, benchmarkWritePrefix)
+	defer func() {
+		for _, key := range keys {
+			delErr := table.Del(key)
+			if delErr != nil {
+				logger.Errorf("deleting benchmark key 0x%x: %s", key, delErr)
+			}
+		}
+	}()
+
+	durations = make([]time.Duration, len(keys))
+	for i, key := range keys {
+		value := make([]byte, len(entries[string(key)]))
+		_, err = rand.Read(value)
+		if err != nil {
+			return nil, fmt.Errorf("generating value: %w", err)
+		}
+
+		start := time.Now()
+		err = table.Put(key, value)
+		durations[i] = time.Since(start)
+		if err != nil {
+			return nil, fmt.Errorf("writing key 0x%x: %w", key, err)
+		}
+	}
+
+	return durations, nil
+}
+
+// BenchmarkBlockConfig is the configuration of a block re-execution benchmark
+type BenchmarkBlockConfig struct {
+	BasePath string
+	From     uint
+	To       uint
+	// Repeat is the number of times each block is executed
+	Repeat uint
+}
+
+// BenchmarkBlockResult is the result of the re-execution of a block
+type BenchmarkBlockResult struct {
+	Number     uint
+	Hash       common.Hash
+	Extrinsics int
+	Stats      BenchmarkStats
+}
+
+// BenchmarkBlock re-executes the blocks of the given range stored in the node database
+// on the state of their parent, and measures the duration of Core_execute_block.
+func BenchmarkBlock(config BenchmarkBlockConfig) ([]BenchmarkBlockResult, error) {
+	if config.From == 0 || config.From > config.To {
+		return nil, fmt.Errorf("%w: from %d to %d", errInvalidBlockRange, config.From, config.To)
+	}
+
+	repeat := config.Repeat
+	if repeat == 0 {
+		repeat = 1
+	}
+
+	stateSrvc, err := newOfflineStateService(config.BasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer stopOfflineStateService(stateSrvc)
+
+	instances := make(map[common.Hash]*wazero_runtime.Instance)
+	defer func() {
+		for _, instance := range instances {
+			instance.Stop()
+		}
+	}()
+
+	results := make([]BenchmarkBlockResult, 0, config.To-config.From+1)
+	for number := config.From; number <= config.To; number++ {
+		block, err := stateSrvc.Block.GetBlockByNumber(number)
+		if err != nil {
+			return nil, fmt.Errorf("getting block %d: %w", number, err)
+		}
+
+		parent, err := stateSrvc.Block.GetHeader(block.Header.ParentHash)
+		if err != nil {
+			return nil, fmt.Errorf("getting parent of block %d: %w", number, err)
+		}
+
+		durations, err := benchmarkBlockExecution(stateSrvc, instances, block, parent, repeat)
+		if err != nil {
+			return nil, fmt.Errorf("executing block %d: %w", number, err)
+		}
+
+		results = append(results, BenchmarkBlockResult{
+			Number:     number,
+			Hash:       block.Header.Hash(),
+			Extrinsics: len(block.Body),
+			Stats:      newBenchmarkStats(durations),
+		})
+	}
+
+	return results, nil
+}
+
+// benchmarkBlockExecution executes the block given repeat times on copies of the parent state,
+// reusing the runtime instance of the parent state code if one is already in instances.
+func benchmarkBlockExecution(stateSrvc *state.Service, instances map[common.Hash]*wazero_runtime.Instance,
+	block *types.Block, parent *types.Header, repeat uint) (durations []time.Duration, err error) {
+	parentState, err := stateSrvc.Storage.TrieState(&parent.StateRoot)
+	if err != nil {
+		return nil, fmt.Errorf("getting parent state: %w", err)
+	}
+
+	parentTrie, ok := parentState.Trie().(*inmemory_trie.InMemoryTrie)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", errUnsupportedTrieType, parentState.Trie())
+	}
+
+	code := parentState.LoadCode()
+	codeHash, err := common.Blake2bHash(code)
+	if err != nil {
+		return nil, fmt.Errorf("hashing runtime code: %w", err)
+	}
+
+	instance, ok := instances[codeHash]
+	if !ok {
+		instance, err = newTryRuntimeInstance(code, parentState)
+		if err != nil {
+			return nil, fmt.Errorf("creating runtime instance: %w", err)
+		}
+		instances[codeHash] = instance
+	}
+
+	durations = make([]time.Duration, repeat)
+	for i := range durations {
+		instance.SetContextStorage(rtstorage.NewTrieState(parentTrie.Snapshot()))
+
+		start := time.Now()
+		_, err = instance.ExecuteBlock(block)
+		durations[i] = time.Since(start)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return durations, nil
+}
+
+// Reference hardware requirements of Polkadot validators, as checked by `substrate benchmark machine`
+const (
+	referenceBlake2256    = 783.27   // MiB/s
+	referenceSr25519      = 0.560677 // MiB/s
+	referenceMemCopy      = 11768.3  // MiB/s
+	referenceDiskSeqWrite = 950      // MiB/s
+	referenceDiskRndWrite = 420      // MiB/s
+
+	// machineBenchmarkTolerance is the fraction of a reference throughput a
+	// machine can be below of while still passing the benchmark.
+	machineBenchmarkTolerance = 0.1
+
+	mebibyte = 1 << 20
+)
+
+// MachineBenchmark is the throughput measured by a machine benchmark
+type MachineBenchmark struct {
+	Name string
+	// Throughput is the measured throughput in MiB/s
+	Throughput float64
+	// Reference is the minimum throughput in MiB/s of the reference hardware
+	Reference float64
+}
+
+// Passed returns true if the throughput is at least the reference throughput within the tolerance.
+func (m MachineBenchmark) Passed() bool {
+	return m.Throughput >= m.Reference*(1-machineBenchmarkTolerance)
+}
+
+// String returns the benchmark result as a row of the table printed by `gossamer benchmark machine`
+func (m MachineBenchmark) String() string {
+	result := "✅ Pass"
+	if !m.Passed() {
+		result = "❌ Fail"
+	}
+	return fmt.Sprintf("| %-14s | %12.2f MiB/s | %12.2f MiB/s | %6.1f%% | %s |",
+		m.Name, m.Throughput, m.Reference, 100*m.Throughput/m.Reference, result)
+}
+
+// BenchmarkMachineConfig is the configuration of a machine benchmark
+type BenchmarkMachineConfig struct {
+	// Dir is the directory the disk benchmarks write to
+	Dir string
+	// Duration is the duration of each of the CPU and memory benchmarks
+	Duration time.Duration
+}
+
+// BenchmarkMachine measures the CPU, memory and disk throughput of the machine and
+// compares them with the reference hardware of Polkadot validators.
+func BenchmarkMachine(config BenchmarkMachineConfig) ([]MachineBenchmark, error) {
+	blake2Throughput, err := benchmarkBlake2256(config.Duration)
+	if err != nil {
+		return nil, fmt.Errorf("benchmarking BLAKE2-256: %w", err)
+	}
+
+	sr25519Throughput, err := benchmarkSr25519Verify(config.Duration)
+	if err != nil {
+		return nil, fmt.Errorf("benchmarking SR25519 verification: %w", err)
+	}
+
+	memCopyThroughput := benchmarkMemCopy(config.Duration)
+
+	diskSeqThroughput, err := benchmarkDiskWrite(config.Dir, false)
+	if err != nil {
+		return nil, fmt.Errorf("benchmarking sequential disk writes: %w", err)
+	}
+
+	diskRndThroughput, err := benchmarkDiskWrite(config.Dir, true)
+	if err != nil {
+		return nil, fmt.Errorf("benchmarking random disk writes: %w", err)
+	}
+
+	return []MachineBenchmark{
+		{Name: "BLAKE2-256", Throughput: blake2Throughput, Reference: referenceBlake2256},
+		{Name: "SR25519-Verify", Throughput: sr25519Throughput, Reference: referenceSr25519},
+		{Name: "Copy", Throughput: memCopyThroughput, Reference: referenceMemCopy},
+		{Name: "Seq Write", Throughput: diskSeqThroughput, Reference: referenceDiskSeqWrite},
+		{Name: "Rnd Write", Throughput: diskRndThroughput, Reference: referenceDiskRndWrite},
+	}, nil
+}
+
+// throughput runs the function given until the duration is elapsed and returns
+// the throughput in MiB/s, given the number of bytes processed by each run.
+func throughput(duration time.Duration, size int, run func() error) (float64, error) {
+	var runs int
+	start := time.Now()
+	for time.Since(start) < duration || runs == 0 {
+		err := run()
+		if err != nil {
+			return 0, err
+		}
+		runs++
+	}
+	elapsed := time.Since(start)
+
+	return float64(runs*size) / mebibyte / elapsed.Seconds(), nil
+}
+
+func benchmarkBlake2256(duration time.Duration) (float64, error) {
+	input := make([]byte, 32*1024)
+	_, err := rand.Read(input)
+	if err != nil {
+		return 0, err
+	}
+
+	return throughput(duration, len(input), func() error {
+		_, err := common.Blake2bHash(input)
+		return err
+	})
+}
+
+func benchmarkSr25519Verify(duration time.Duration) (float64, error) {
+	keypair, err := sr25519.GenerateKeypair()
+	if err != nil {
+		return 0, err
+	}
+
+	message := make([]byte, 32)
+	_, err = rand.Read(message)
+	if err != nil {
+		return 0, err
+	}
+
+	signature, err := keypair.Sign(message)
+	if err != nil {
+		return 0, err
+	}
+
+	publicKey := keypair.Public()
+	return throughput(duration, len(message), func() error {
+		ok, err := publicKey.Verify(message, signature)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.New("invalid signature")
+		}
+		return nil
+	})
+}
+
+func benchmarkMemCopy(duration time.Duration) float64 {
+	src := make([]byte, 64*mebibyte)
+	dst := make([]byte, len(src))
+
+	// copy cannot fail, so neither can throughput.
+	memCopyThroughput, _ := throughput(duration, len(src), func() error {
+		copy(dst, src)
+		return nil
+	})
+	return memCopyThroughput
+}
+
+// benchmarkDiskWrite writes 64MiB to a file in the directory given, either sequentially or
+// in 64KiB chunks at random offsets, syncs it to disk and returns the throughput in MiB/s.
+func benchmarkDiskWrite(dir string, random bool) (float64, error) {
+	const size = 64 * mebibyte
+	const chunkSize = 64 * 1024
+
+	data := make([]byte, size)
+	_, err := rand.Read(data)
+	if err != nil {
+		return 0, err
+	}
+
+	file, err := os.CreateTemp(dir, "gossamer-benchmark-*")
+	if err != nil {
+		return 0, fmt.Errorf("creating file: %w", err)
+	}
+	path := file.Name()
+	defer func() {
+		_ = file.Close()
+		removeErr := os.Remove(path)
+		if removeErr != nil {
+			logger.Errorf("removing benchmark file: %s", removeErr)
+		}
+	}()
+
+	start := time.Now()
+	if random {
+		for _, chunk := range mrand.Perm(size / chunkSize) {
+			offset := chunk * chunkSize
+			_, err = file.WriteAt(data[offset:offset+chunkSize], int64(offset))
+			if err != nil {
+				return 0, fmt.Errorf("writing to %s: %w", filepath.Base(path), err)
+			}
+		}
+	} else {
+		_, err = file.Write(data)
+		if err != nil {
+			return 0, fmt.Errorf("writing to %s: %w", filepath.Base(path), err)
+		}
+	}
+
+	err = file.Sync()
+	if err != nil {
+		return 0, fmt.Errorf("syncing %s: %w", filepath.Base(path), err)
+	}
+	elapsed := time.Since(start)
+
+	return size / mebibyte / elapsed.Seconds(), nil
+}
@@ -0,0 +1,41 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newBenchmarkStats(t *testing.T) {
+	t.Parallel()
+
+	durations := []time.Duration{4, 1, 3, 2}
+
+	expected := BenchmarkStats{
+		Count:   4,
+		Total:   10,
+		Min:     1,
+		Max:     4,
+		Average: 2,
+		Median:  2,
+		Stddev:  1,
+		P99:     4,
+		P95:     4,
+		P75:     3,
+	}
+	assert.Equal(t, expected, newBenchmarkStats(durations))
+	assert.Equal(t, []time.Duration{4, 1, 3, 2}, durations)
+	assert.Equal(t, BenchmarkStats{}, newBenchmarkStats(nil))
+}
+
+func Test_MachineBenchmark_Passed(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, MachineBenchmark{Throughput: 100, Reference: 100}.Passed())
+	assert.True(t, MachineBenchmark{Throughput: 90, Reference: 100}.Passed())
+	assert.False(t, MachineBenchmark{Throughput: 89, Reference: 100}.Passed())
+}
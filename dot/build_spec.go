@@ -15,6 +15,7 @@
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/genesis"
+	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
 	"github.com/ChainSafe/gossamer/lib/utils"
 )
 
@@ -33,7 +34,8 @@ func (b *BuildSpec) ToJSON() ([]byte, error) {
 		ProtocolID: b.genesis.ProtocolID,
 		Properties: b.genesis.Properties,
 		Genesis: genesis.Fields{
-			Runtime: b.genesis.GenesisFields().Runtime,
+			Runtime:        b.genesis.GenesisFields().Runtime,
+			RuntimeGenesis: b.genesis.GenesisFields().RuntimeGenesis,
 		},
 	}
 	return json.MarshalIndent(tmpGen, "", "    ")
@@ -61,6 +63,12 @@ func BuildFromGenesis(path string, authCount int) (*BuildSpec, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	err = wazero_runtime.GenesisToRaw(gen)
+	if err != nil {
+		return nil, fmt.Errorf("building runtime genesis: %w", err)
+	}
+
 	bs := &BuildSpec{
 		genesis: gen,
 	}
@@ -0,0 +1,82 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	cfg "github.com/ChainSafe/gossamer/config"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/genesis"
+)
+
+// ChainDir is the base path of a chain in a data directory
+type ChainDir struct {
+	// Path is the base path of the chain relative to the data directory
+	Path string
+	Name string
+	ID   string
+	// Initialised is true if the chain database was initialised
+	Initialised bool
+}
+
+// ListChains returns the chains of the given data directory, which are its
+// directories holding a chain spec, sorted by path.
+func ListChains(dataDir string) (chains []ChainDir, err error) {
+	_, err = os.Stat(dataDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	err = filepath.WalkDir(dataDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+
+		chainSpec := cfg.GetChainSpec(path)
+		_, err = os.Stat(chainSpec)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		spec, err := genesis.NewGenesisFromJSONRaw(chainSpec)
+		if err != nil {
+			return fmt.Errorf("loading chain spec %s: %w", chainSpec, err)
+		}
+
+		relativePath, err := filepath.Rel(dataDir, path)
+		if err != nil {
+			return err
+		}
+
+		entries, err := os.ReadDir(filepath.Join(path, database.DefaultDatabaseDir))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+
+		chains = append(chains, ChainDir{
+			Path:        relativePath,
+			Name:        spec.Name,
+			ID:          spec.ID,
+			Initialised: len(entries) > 0,
+		})
+
+		// the directories of a chain base path are not chain base paths
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing chains of %s: %w", dataDir, err)
+	}
+
+	return chains, nil
+}
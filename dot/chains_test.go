@@ -0,0 +1,48 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	cfg "github.com/ChainSafe/gossamer/config"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListChains(t *testing.T) {
+	t.Parallel()
+
+	dataDir := t.TempDir()
+	writeChainSpec := func(path, spec string) {
+		basePath := filepath.Join(dataDir, path)
+		err := os.MkdirAll(basePath, os.ModePerm)
+		require.NoError(t, err)
+		err = os.WriteFile(cfg.GetChainSpec(basePath), []byte(spec), os.ModePerm)
+		require.NoError(t, err)
+	}
+
+	writeChainSpec("polkadot", `{"name": "Polkadot", "id": "polkadot"}`)
+	writeChainSpec(filepath.Join("westend-local", "alice"), `{"name": "Local Testnet", "id": "local_testnet"}`)
+	err := os.MkdirAll(filepath.Join(dataDir, "empty"), os.ModePerm)
+	require.NoError(t, err)
+
+	databaseDir := filepath.Join(dataDir, "polkadot", database.DefaultDatabaseDir)
+	err = os.MkdirAll(databaseDir, os.ModePerm)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(databaseDir, "CURRENT"), nil, os.ModePerm)
+	require.NoError(t, err)
+
+	chains, err := ListChains(dataDir)
+	require.NoError(t, err)
+
+	expected := []ChainDir{
+		{Path: "polkadot", Name: "Polkadot", ID: "polkadot", Initialised: true},
+		{Path: filepath.Join("westend-local", "alice"), Name: "Local Testnet", ID: "local_testnet"},
+	}
+	assert.Equal(t, expected, chains)
+}
@@ -0,0 +1,281 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package changefeed appends the changes of watched storage entries at each block finalised to
+// an on-disk changefeed, one JSON object per line, as an audit trail of specific chain state
+// such as the validator set or the sudo key.
+package changefeed
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/services"
+)
+
+var logger = log.NewFromGlobal(log.AddContext("pkg", "changefeed"))
+
+var errEmptyPrefix = errors.New("empty storage prefix")
+
+// Change is the change of the value of a watched storage entry at a block
+type Change struct {
+	BlockHash   common.Hash `json:"blockHash"`
+	BlockNumber uint        `json:"blockNumber"`
+	// Key is the hex encoded key of the storage entry
+	Key string `json:"key"`
+	// Value is the hex encoded value of the storage entry at the block, nil if the entry
+	// was deleted by the block
+	Value *string `json:"value"`
+}
+
+// ParsePrefix parses a watched storage prefix, either hex encoded or formatted as
+// <pallet>.<entry>, such as Sudo.Key, for the prefix of the storage entry of a pallet.
+// A storage key being a prefix of itself, a storage value is watched with its key.
+func ParsePrefix(value string) (prefix []byte, err error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, errEmptyPrefix
+	}
+
+	if strings.HasPrefix(value, "0x") {
+		prefix, err = common.HexToBytes(value)
+		if err != nil {
+			return nil, fmt.Errorf("decoding hex storage prefix: %w", err)
+		}
+		if len(prefix) == 0 {
+			return nil, errEmptyPrefix
+		}
+		return prefix, nil
+	}
+
+	pallet, entry, ok := strings.Cut(value, ".")
+	if !ok || pallet == "" || entry == "" {
+		return nil, fmt.Errorf("storage prefix %q is neither hex encoded nor formatted as <pallet>.<entry>", value)
+	}
+	palletHash, err := common.Twox128Hash([]byte(pallet))
+	if err != nil {
+		return nil, fmt.Errorf("hashing pallet name %s: %w", pallet, err)
+	}
+	entryHash, err := common.Twox128Hash([]byte(entry))
+	if err != nil {
+		return nil, fmt.Errorf("hashing storage entry name %s: %w", entry, err)
+	}
+	return append(palletHash, entryHash...), nil
+}
+
+// Service appends the changes of the storage entries whose keys start with the watched
+// prefixes to the changefeed file, for each block finalised from the highest block finalised
+// when the service starts. The changes of a block are the differences between the state of
+// the block and the state of its parent.
+type Service struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	blockState BlockState
+	storage    StorageState
+	prefixes   [][]byte
+	path       string
+
+	finalised chan *types.FinalisationInfo
+	file      *os.File
+	// last is the hash of the last block finalised whose changes were appended
+	last common.Hash
+}
+
+// NewService returns a service appending the changes of the storage entries with the prefixes
+// given to the changefeed file of the path given, created if it does not exist.
+func NewService(blockState BlockState, storage StorageState, prefixes [][]byte, path string) *Service {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Service{
+		ctx:        ctx,
+		cancel:     cancel,
+		blockState: blockState,
+		storage:    storage,
+		prefixes:   prefixes,
+		path:       path,
+	}
+}
+
+// Start opens the changefeed file and starts appending the changes of the blocks finalised
+func (s *Service) Start() (err error) {
+	finalised, err := s.blockState.GetHighestFinalisedHeader()
+	if err != nil {
+		return fmt.Errorf("getting highest finalised header: %w", err)
+	}
+	s.last = finalised.Hash()
+
+	s.file, err = os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("opening changefeed file: %w", err)
+	}
+
+	s.finalised = s.blockState.GetFinalisedNotifierChannel()
+	s.wg.Add(1)
+	services.Go(s, func() {
+		defer s.wg.Done()
+		s.run()
+	})
+	return nil
+}
+
+// Stop stops appending the changes and closes the changefeed file
+func (s *Service) Stop() error {
+	s.cancel()
+	s.wg.Wait()
+	if s.finalised != nil {
+		s.blockState.FreeFinalisedNotifierChannel(s.finalised)
+	}
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	if err != nil {
+		return fmt.Errorf("closing changefeed file: %w", err)
+	}
+	return nil
+}
+
+// run appends the changes of the blocks finalised until the service is stopped.
+func (s *Service) run() {
+	for {
+		select {
+		case info := <-s.finalised:
+			if info == nil {
+				continue
+			}
+			err := s.handleFinalisation(info.Header.Hash())
+			if err != nil {
+				logger.Errorf("appending storage changes up to finalised block #%d (%s): %s",
+					info.Header.Number, info.Header.Hash(), err)
+			}
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// handleFinalisation appends the changes of each block finalised since the last block whose
+// changes were appended, up to the block finalised of the hash given, so no block is missed
+// when several blocks are finalised at once.
+func (s *Service) handleFinalisation(finalised common.Hash) error {
+	hashes, err := s.blockState.Range(s.last, finalised)
+	if err != nil {
+		return fmt.Errorf("getting finalised blocks: %w", err)
+	}
+
+	// the first hash is the last block whose changes were appended
+	for _, hash := range hashes[1:] {
+		err = s.appendChanges(hash)
+		if err != nil {
+			return fmt.Errorf("block %s: %w", hash, err)
+		}
+		s.last = hash
+	}
+	return nil
+}
+
+// appendChanges appends the changes of the watched storage entries at the block of the hash
+// given to the changefeed file.
+func (s *Service) appendChanges(hash common.Hash) error {
+	header, err := s.blockState.GetHeader(hash)
+	if err != nil {
+		return fmt.Errorf("getting header: %w", err)
+	}
+	parent, err := s.blockState.GetHeader(header.ParentHash)
+	if err != nil {
+		return fmt.Errorf("getting parent header: %w", err)
+	}
+
+	var lines []byte
+	for _, prefix := range s.prefixes {
+		changes, err := s.changes(prefix, &parent.StateRoot, &header.StateRoot)
+		if err != nil {
+			return fmt.Errorf("prefix 0x%x: %w", prefix, err)
+		}
+
+		for _, change := range changes {
+			change.BlockHash = hash
+			change.BlockNumber = header.Number
+			line, err := json.Marshal(change)
+			if err != nil {
+				return fmt.Errorf("encoding change: %w", err)
+			}
+			lines = append(lines, line...)
+			lines = append(lines, '\n')
+		}
+	}
+
+	if len(lines) == 0 {
+		return nil
+	}
+	// the changes of a block are written at once for the changefeed to hold all the changes
+	// of a block or none of them
+	_, err = s.file.Write(lines)
+	if err != nil {
+		return fmt.Errorf("writing changefeed file: %w", err)
+	}
+	return nil
+}
+
+// changes returns the changes, ordered by key, of the storage entries with the prefix given
+// between the states of the parent root and of the root given.
+func (s *Service) changes(prefix []byte, parentRoot, root *common.Hash) ([]Change, error) {
+	before, err := s.entries(prefix, parentRoot)
+	if err != nil {
+		return nil, fmt.Errorf("reading parent state: %w", err)
+	}
+	after, err := s.entries(prefix, root)
+	if err != nil {
+		return nil, fmt.Errorf("reading state: %w", err)
+	}
+
+	var changes []Change
+	for key, value := range after {
+		previous, existed := before[key]
+		if existed && bytes.Equal(previous, value) {
+			continue
+		}
+		hexValue := common.BytesToHex(value)
+		changes = append(changes, Change{Key: common.BytesToHex([]byte(key)), Value: &hexValue})
+	}
+	for key := range before {
+		if _, exists := after[key]; !exists {
+			changes = append(changes, Change{Key: common.BytesToHex([]byte(key))})
+		}
+	}
+
+	slices.SortFunc(changes, func(a, b Change) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+	return changes, nil
+}
+
+// entries returns the values of the storage entries with the prefix given in the state of
+// the root given, by key.
+func (s *Service) entries(prefix []byte, root *common.Hash) (map[string][]byte, error) {
+	keys, err := s.storage.GetKeysWithPrefix(root, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("getting keys: %w", err)
+	}
+
+	entries := make(map[string][]byte, len(keys))
+	for _, key := range keys {
+		value, err := s.storage.GetStorage(root, key)
+		if err != nil {
+			return nil, fmt.Errorf("getting value of key 0x%x: %w", key, err)
+		}
+		entries[string(key)] = value
+	}
+	return entries, nil
+}
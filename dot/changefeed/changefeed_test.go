@@ -0,0 +1,213 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package changefeed
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testBlockState is a block state of a chain of headers set by the test
+type testBlockState struct {
+	headers   map[common.Hash]*types.Header
+	finalised chan *types.FinalisationInfo
+	highest   common.Hash
+}
+
+func (bs *testBlockState) GetHighestFinalisedHeader() (*types.Header, error) {
+	return bs.GetHeader(bs.highest)
+}
+
+func (bs *testBlockState) GetHeader(hash common.Hash) (*types.Header, error) {
+	header, ok := bs.headers[hash]
+	if !ok {
+		return nil, errors.New("header not found")
+	}
+	return header, nil
+}
+
+func (bs *testBlockState) Range(startHash, endHash common.Hash) ([]common.Hash, error) {
+	hashes := []common.Hash{endHash}
+	for hash := endHash; hash != startHash; {
+		header, err := bs.GetHeader(hash)
+		if err != nil {
+			return nil, err
+		}
+		hash = header.ParentHash
+		hashes = append([]common.Hash{hash}, hashes...)
+	}
+	return hashes, nil
+}
+
+func (bs *testBlockState) GetFinalisedNotifierChannel() chan *types.FinalisationInfo {
+	return bs.finalised
+}
+
+func (*testBlockState) FreeFinalisedNotifierChannel(chan *types.FinalisationInfo) {}
+
+// testStorageState holds the storage entries of each state root
+type testStorageState map[common.Hash]map[string][]byte
+
+func (s testStorageState) GetKeysWithPrefix(root *common.Hash, prefix []byte) ([][]byte, error) {
+	entries, ok := s[*root]
+	if !ok {
+		return nil, errors.New("state not found")
+	}
+	var keys [][]byte
+	for key := range entries {
+		if strings.HasPrefix(key, string(prefix)) {
+			keys = append(keys, []byte(key))
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return string(keys[i]) < string(keys[j]) })
+	return keys, nil
+}
+
+func (s testStorageState) GetStorage(root *common.Hash, key []byte) ([]byte, error) {
+	return s[*root][string(key)], nil
+}
+
+// newTestChain returns the block state of a chain whose blocks have the states given, the
+// first state being the state of the genesis block
+func newTestChain(states ...common.Hash) (*testBlockState, []common.Hash) {
+	blockState := &testBlockState{
+		headers:   make(map[common.Hash]*types.Header),
+		finalised: make(chan *types.FinalisationInfo),
+	}
+	hashes := make([]common.Hash, len(states))
+	var parentHash common.Hash
+	for i, stateRoot := range states {
+		header := &types.Header{
+			ParentHash: parentHash,
+			Number:     uint(i),
+			StateRoot:  stateRoot,
+			Digest:     types.NewDigest(),
+		}
+		hashes[i] = header.Hash()
+		blockState.headers[hashes[i]] = header
+		parentHash = hashes[i]
+	}
+	return blockState, hashes
+}
+
+func TestParsePrefix(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		value          string
+		expectedPrefix string
+		expectedErr    string
+	}{
+		"hex": {
+			value:          " 0x3a636f6465 ",
+			expectedPrefix: "0x3a636f6465",
+		},
+		"pallet_entry": {
+			value:          "Sudo.Key",
+			expectedPrefix: "0x5c0d1176a568c1f92944340dbfed9e9c530ebca703c85910e7164cb7d1c9e47b",
+		},
+		"empty": {
+			value:       " ",
+			expectedErr: "empty storage prefix",
+		},
+		"empty_hex": {
+			value:       "0x",
+			expectedErr: "empty storage prefix",
+		},
+		"invalid_hex": {
+			value:       "0xzz",
+			expectedErr: "decoding hex storage prefix: encoding/hex: invalid byte: U+007A 'z': 0xzz",
+		},
+		"invalid_format": {
+			value:       "Sudo",
+			expectedErr: `storage prefix "Sudo" is neither hex encoded nor formatted as <pallet>.<entry>`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			prefix, err := ParsePrefix(testCase.value)
+			if testCase.expectedErr != "" {
+				assert.EqualError(t, err, testCase.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedPrefix, common.BytesToHex(prefix))
+		})
+	}
+}
+
+func TestService(t *testing.T) {
+	t.Parallel()
+
+	storage := testStorageState{
+		{0}: {"validators": {1}, "key": {1}, "other": {1}},
+		{1}: {"validators": {1}, "key": {2}, "other": {2}},
+		{2}: {"validators": {1, 2}, "other": {3}},
+		{3}: {"validators": {1, 2}, "other": {4}},
+	}
+	blockState, hashes := newTestChain(common.Hash{0}, common.Hash{1}, common.Hash{2}, common.Hash{3})
+	blockState.highest = hashes[0]
+	path := filepath.Join(t.TempDir(), "changefeed.jsonl")
+	s := NewService(blockState, storage, [][]byte{[]byte("validators"), []byte("key")}, path)
+
+	err := s.Start()
+	require.NoError(t, err)
+
+	// the changes of the blocks finalised at once are all appended
+	blockState.finalised <- &types.FinalisationInfo{Header: *blockState.headers[hashes[2]]}
+	blockState.finalised <- &types.FinalisationInfo{Header: *blockState.headers[hashes[3]]}
+	// the notifications are handled one at a time
+	blockState.finalised <- nil
+
+	err = s.Stop()
+	require.NoError(t, err)
+
+	changefeed, err := os.ReadFile(path)
+	require.NoError(t, err)
+	expected := `{"blockHash":"` + hashes[1].String() + `","blockNumber":1,"key":"0x6b6579","value":"0x02"}
+{"blockHash":"` + hashes[2].String() + `","blockNumber":2,"key":"0x76616c696461746f7273","value":"0x0102"}
+{"blockHash":"` + hashes[2].String() + `","blockNumber":2,"key":"0x6b6579","value":null}
+`
+	assert.Equal(t, expected, string(changefeed))
+}
+
+func TestService_handleFinalisation(t *testing.T) {
+	t.Parallel()
+
+	storage := testStorageState{
+		{0}: {"key": {1}},
+		{2}: {"key": {2}},
+	}
+	// the state of the block 1 is missing
+	blockState, hashes := newTestChain(common.Hash{0}, common.Hash{1}, common.Hash{2})
+	file, err := os.Create(filepath.Join(t.TempDir(), "changefeed.jsonl"))
+	require.NoError(t, err)
+	defer file.Close()
+	s := &Service{
+		blockState: blockState,
+		storage:    storage,
+		prefixes:   [][]byte{[]byte("key")},
+		file:       file,
+		last:       hashes[0],
+	}
+
+	err = s.handleFinalisation(hashes[2])
+	assert.EqualError(t, err, "block "+hashes[1].String()+": prefix 0x6b6579: reading state: "+
+		"getting keys: state not found")
+	// the changes of the block are appended again at the next finalisation
+	assert.Equal(t, hashes[0], s.last)
+}
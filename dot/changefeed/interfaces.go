@@ -0,0 +1,24 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package changefeed
+
+import (
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+// BlockState is the interface for the block state methods
+type BlockState interface {
+	GetHighestFinalisedHeader() (*types.Header, error)
+	GetHeader(hash common.Hash) (*types.Header, error)
+	Range(startHash, endHash common.Hash) (hashes []common.Hash, err error)
+	GetFinalisedNotifierChannel() chan *types.FinalisationInfo
+	FreeFinalisedNotifierChannel(ch chan *types.FinalisationInfo)
+}
+
+// StorageState is the interface for the storage state methods
+type StorageState interface {
+	GetKeysWithPrefix(root *common.Hash, prefix []byte) ([][]byte, error)
+	GetStorage(root *common.Hash, key []byte) ([]byte, error)
+}
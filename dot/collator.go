@@ -0,0 +1,130 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	cfg "github.com/ChainSafe/gossamer/config"
+	"github.com/ChainSafe/gossamer/dot/core"
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/aura"
+	"github.com/ChainSafe/gossamer/lib/collator"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/genesis"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// relayChainDir is the directory of the base path holding the embedded relay chain node
+const relayChainDir = "relay-chain"
+
+var errCollatorNotAura = errors.New("collating requires a parachain selecting Aura in its chain spec")
+
+// relayChainNode is the relay chain full node embedded by a collator, its
+// services being started and stopped with the services of the parachain node.
+type relayChainNode struct {
+	node *Node
+}
+
+// Start starts the services of the relay chain node
+func (r *relayChainNode) Start() error {
+	r.node.ServiceRegistry.StartAll()
+	return nil
+}
+
+// Stop stops the services of the relay chain node
+func (r *relayChainNode) Stop() error {
+	r.node.ServiceRegistry.StopAll()
+	return nil
+}
+
+// relayChainConfig returns the configuration of the relay chain full node embedded by the collator
+// of the parachain node configuration given, stored in the relay chain directory of its base path.
+func relayChainConfig(config *cfg.Config) (*cfg.Config, error) {
+	relaySpec, err := genesis.NewGenesisFromJSONRaw(config.Collator.RelayChainSpec)
+	if err != nil {
+		return nil, fmt.Errorf("loading relay chain spec: %w", err)
+	}
+
+	relayConfig := cfg.DefaultConfigFromSpec(relaySpec)
+	relayConfig.BasePath = filepath.Join(config.BasePath, relayChainDir)
+	relayConfig.ChainSpec = config.Collator.RelayChainSpec
+	relayConfig.LogLevel = config.LogLevel
+	relayConfig.NoTelemetry = config.NoTelemetry
+	logConfig := *config.Log
+	relayConfig.Log = &logConfig
+	relayConfig.Core.Role = common.FullNodeRole
+	relayConfig.Core.BabeAuthority = false
+	relayConfig.Core.GrandpaAuthority = false
+	relayConfig.Network.Port = config.Collator.RelayPort
+	if len(config.Collator.RelayBootnodes) > 0 {
+		relayConfig.Network.Bootnodes = config.Collator.RelayBootnodes
+	}
+
+	return relayConfig, nil
+}
+
+// createCollatorService creates the embedded relay chain node and the collator building the parachain
+// blocks with the Aura service given against the relay chain it follows, distributing their collations
+// to the relay chain validators over its network.
+func createCollatorService(config *cfg.Config, st *state.Service, cs *core.Service,
+	auraSrvc *aura.Service) (*collator.Service, *relayChainNode, error) {
+	if auraSrvc == nil {
+		return nil, nil, errCollatorNotAura
+	}
+
+	logger.Infof("creating collator service for para id %d...", config.Collator.ParaID)
+
+	relayConfig, err := relayChainConfig(config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	relayNode, err := NewNode(relayConfig, keystore.NewGlobalKeystore())
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating relay chain node: %w", err)
+	}
+
+	localPeer, err := peer.Decode(relayNode.network.NetworkState().PeerID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decoding relay chain peer id: %w", err)
+	}
+
+	// the collator key only authenticates the collations, so it is generated on each start
+	key, err := sr25519.GenerateKeypair()
+	if err != nil {
+		return nil, nil, fmt.Errorf("generating collator key: %w", err)
+	}
+
+	paraID := collator.ParaID(config.Collator.ParaID)
+	distributor, err := collator.NewDistributor(relayNode.network, relayNode.state.Block.GenesisHash(),
+		localPeer, key, paraID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating collation distributor: %w", err)
+	}
+
+	collatorLogLevel, err := log.ParseLevel(config.LogLevel)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse collator log level: %w", err)
+	}
+
+	collatorSrvc := collator.NewService(&collator.Config{
+		LogLvl:             collatorLogLevel,
+		ParaID:             paraID,
+		Key:                key,
+		RelayChain:         collator.NewRelayChain(relayNode.state.Block, relayNode.state.Storage),
+		BlockState:         st.Block,
+		StorageState:       st.Storage,
+		Proposer:           auraSrvc,
+		BlockImportHandler: cs,
+		Distributor:        distributor,
+	})
+
+	return collatorSrvc, &relayChainNode{node: relayNode}, nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_relayChainConfig(t *testing.T) {
+	t.Parallel()
+
+	config := DefaultTestWestendDevConfig(t)
+	config.LogLevel = "debug"
+	config.Log.Network = "trace"
+	config.Collator.Enabled = true
+	config.Collator.RelayChainSpec = utils.GetWestendDevRawGenesisPath(t)
+	config.Collator.RelayPort = 7010
+
+	relayConfig, err := relayChainConfig(config)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(config.BasePath, relayChainDir), relayConfig.BasePath)
+	assert.Equal(t, config.Collator.RelayChainSpec, relayConfig.ChainSpec)
+	assert.Equal(t, "debug", relayConfig.LogLevel)
+	assert.Equal(t, "trace", relayConfig.Log.Network)
+	assert.NotSame(t, config.Log, relayConfig.Log)
+	assert.Equal(t, common.FullNodeRole, relayConfig.Core.Role)
+	assert.False(t, relayConfig.Core.BabeAuthority)
+	assert.False(t, relayConfig.Core.GrandpaAuthority)
+	assert.Equal(t, uint16(7010), relayConfig.Network.Port)
+	assert.False(t, relayConfig.RPC.IsRPCEnabled())
+	assert.False(t, relayConfig.Pprof.Enabled)
+	assert.False(t, relayConfig.Collator.Enabled)
+
+	config.Collator.RelayBootnodes = []string{"/ip4/127.0.0.1/tcp/30333/p2p/12D3KooWRelay"}
+	relayConfig, err = relayChainConfig(config)
+	require.NoError(t, err)
+	assert.Equal(t, config.Collator.RelayBootnodes, relayConfig.Network.Bootnodes)
+
+	config.Collator.RelayChainSpec = filepath.Join(t.TempDir(), "missing.json")
+	_, err = relayChainConfig(config)
+	assert.ErrorContains(t, err, "loading relay chain spec")
+}
+
+func Test_createCollatorService_notAura(t *testing.T) {
+	t.Parallel()
+
+	config := DefaultTestWestendDevConfig(t)
+	config.Collator.Enabled = true
+
+	_, _, err := createCollatorService(config, nil, nil, nil)
+	assert.True(t, errors.Is(err, errCollatorNotAura), err)
+}
@@ -0,0 +1,63 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/internal/database"
+)
+
+// compacter is a database which can be compacted
+type compacter interface {
+	Compact() error
+}
+
+// CompactDatabases compacts the state database and the libp2p datastore of the given base path,
+// reclaiming the disk space of their deleted and overwritten entries. The value log files of the
+// datastore holding more than the given ratio of stale data are rewritten. The node must be stopped.
+func CompactDatabases(basePath string, badgerGCDiscardRatio float64) (err error) {
+	db, err := database.NewPebble(filepath.Join(basePath, database.DefaultDatabaseDir), false)
+	if err != nil {
+		return fmt.Errorf("opening database: %w", err)
+	}
+	defer func() {
+		closeErr := db.Close()
+		if closeErr != nil {
+			err = errors.Join(err, fmt.Errorf("closing database: %w", closeErr))
+		}
+	}()
+
+	err = db.Compact()
+	if err != nil {
+		return err
+	}
+
+	return network.CompactDatastore(basePath, badgerGCDiscardRatio)
+}
+
+// databaseCompacter compacts the databases of the running node, the network service
+// being nil if it is disabled.
+type databaseCompacter struct {
+	db      database.Database
+	network *network.Service
+}
+
+// Compact compacts the state database and the libp2p datastore.
+func (d databaseCompacter) Compact() error {
+	if db, ok := d.db.(compacter); ok {
+		err := db.Compact()
+		if err != nil {
+			return err
+		}
+	}
+
+	if d.network == nil {
+		return nil
+	}
+	return d.network.CompactDatastore()
+}
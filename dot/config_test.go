@@ -127,6 +127,7 @@ func Test_networkServiceEnabled(t *testing.T) {
 				State:      &cfg.StateConfig{},
 				RPC:        &cfg.RPCConfig{},
 				Pprof:      &cfg.PprofConfig{},
+				Collator:   &cfg.CollatorConfig{},
 				System:     &cfg.SystemConfig{},
 			},
 			want: false,
@@ -15,5 +15,17 @@
 	// ErrEmptyRuntimeCode is returned when the storage :code is empty
 	ErrEmptyRuntimeCode = errors.New("new :code is empty")
 
+	// ErrTransactionPoolFull is returned when a submitted transaction is dropped right away, the
+	// transaction pool being full of transactions of higher priority
+	ErrTransactionPoolFull = errors.New("transaction pool is full")
+
+	// ErrTransactionBanned is returned when a submitted transaction is banned from the
+	// transaction pool, having failed validation or block building repeatedly
+	ErrTransactionBanned = errors.New("transaction is temporarily banned")
+
+	// ErrTransactionExpired is returned when the mortality window of a transaction has
+	// expired at the best block
+	ErrTransactionExpired = errors.New("transaction mortality window has expired")
+
 	errInvalidTransactionQueueVersion = errors.New("invalid transaction queue version")
 )
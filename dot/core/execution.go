@@ -0,0 +1,186 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package core
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// blockExecutionCacheCapacity is the maximum number of block executions kept in the
+// block execution cache, the oldest executions being evicted first.
+const blockExecutionCacheCapacity = 32
+
+// blockExecution holds the artifacts of the execution of a block on top of its parent state,
+// so the RPC calls needing them do not execute the block again.
+type blockExecution struct {
+	// events are the SCALE encoded events deposited by the block
+	events []byte
+	// witness is the storage proof of the trie nodes accessed by the block on top of the
+	// parent state root, nil if the block was executed without recording the trie nodes
+	// accessed, as when imported
+	witness         [][]byte
+	parentStateRoot common.Hash
+}
+
+// newBlockExecution returns the execution artifacts of the trie state given, resulting
+// from the execution of a block.
+func newBlockExecution(ts *rtstorage.TrieState) *blockExecution {
+	return &blockExecution{
+		events: ts.Trie().Get(common.SystemEventsKey),
+	}
+}
+
+// blockExecutionCache caches the executions of the recent blocks by block hash, filled
+// when the blocks are imported. Its zero value is ready to use.
+type blockExecutionCache struct {
+	mutex      sync.RWMutex
+	executions map[common.Hash]*blockExecution
+	// hashes holds the hashes of the blocks cached, from the oldest to the newest
+	hashes []common.Hash
+}
+
+func (bc *blockExecutionCache) get(blockHash common.Hash) (execution *blockExecution, ok bool) {
+	bc.mutex.RLock()
+	defer bc.mutex.RUnlock()
+	execution, ok = bc.executions[blockHash]
+	return execution, ok
+}
+
+// put caches the execution of the block given, replacing its execution already cached.
+func (bc *blockExecutionCache) put(blockHash common.Hash, execution *blockExecution) {
+	bc.mutex.Lock()
+	defer bc.mutex.Unlock()
+
+	if bc.executions == nil {
+		bc.executions = make(map[common.Hash]*blockExecution)
+	}
+	if _, ok := bc.executions[blockHash]; ok {
+		bc.executions[blockHash] = execution
+		return
+	}
+
+	if len(bc.hashes) == blockExecutionCacheCapacity {
+		delete(bc.executions, bc.hashes[0])
+		bc.hashes = bc.hashes[1:]
+	}
+
+	bc.executions[blockHash] = execution
+	bc.hashes = append(bc.hashes, blockHash)
+}
+
+// BlockStats holds the statistics of the execution of a block
+type BlockStats struct {
+	// WitnessLen is the length of the storage proof of the trie nodes accessed by the block
+	WitnessLen uint64
+	// BlockLen is the length of the SCALE encoded block
+	BlockLen uint64
+	// NumExtrinsics is the number of extrinsics of the block
+	NumExtrinsics uint64
+}
+
+// CachedBlockEvents returns the SCALE encoded events deposited by the block with the
+// given hash if its execution is cached, without reading the block state.
+func (s *Service) CachedBlockEvents(blockHash common.Hash) (events []byte, ok bool) {
+	execution, ok := s.blockExecutions.get(blockHash)
+	if !ok {
+		return nil, false
+	}
+	return execution.events, true
+}
+
+// GetBlockStats returns the statistics of the execution of the block with the given hash,
+// executing the block again if its witness is not cached.
+func (s *Service) GetBlockStats(blockHash common.Hash) (*BlockStats, error) {
+	block, err := s.getBlock(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	execution, err := s.witnessedBlockExecution(blockHash, block)
+	if err != nil {
+		return nil, err
+	}
+
+	encodedBlock, err := scale.Marshal(*block)
+	if err != nil {
+		return nil, fmt.Errorf("encoding block: %w", err)
+	}
+
+	stats := &BlockStats{
+		BlockLen:      uint64(len(encodedBlock)),
+		NumExtrinsics: uint64(len(block.Body)),
+	}
+	for _, encodedNode := range execution.witness {
+		stats.WitnessLen += uint64(len(encodedNode))
+	}
+	return stats, nil
+}
+
+// getBlock returns the block with the given hash.
+func (s *Service) getBlock(blockHash common.Hash) (*types.Block, error) {
+	header, err := s.blockState.GetHeader(blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting header: %w", err)
+	}
+
+	body, err := s.blockState.GetBlockBody(blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting block body: %w", err)
+	}
+
+	return &types.Block{Header: *header, Body: *body}, nil
+}
+
+// witnessedBlockExecution returns the execution of the block given with its witness, from
+// the block execution cache or executing the block again recording the trie nodes accessed.
+func (s *Service) witnessedBlockExecution(blockHash common.Hash, block *types.Block) (
+	*blockExecution, error) {
+	execution, ok := s.blockExecutions.get(blockHash)
+	if ok && execution.witness != nil {
+		return execution, nil
+	}
+
+	parent, err := s.blockState.GetHeader(block.Header.ParentHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting parent header: %w", err)
+	}
+
+	s.storageState.Lock()
+	defer s.storageState.Unlock()
+
+	ts, err := s.storageState.TrieState(&parent.StateRoot)
+	if err != nil {
+		return nil, fmt.Errorf("getting trie state: %w", err)
+	}
+	ts.EnableProofRecording()
+
+	rt, err := s.blockState.GetRuntime(parent.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("getting runtime: %w", err)
+	}
+
+	rt.SetContextStorage(ts)
+
+	_, err = rt.ExecuteBlock(block)
+	if err != nil {
+		return nil, fmt.Errorf("executing block %d: %w", block.Header.Number, err)
+	}
+
+	witness, err := s.storageState.GenerateWitness(parent.StateRoot, ts.RecordedKeys(), ts.RecordedChildKeys())
+	if err != nil {
+		return nil, fmt.Errorf("generating witness: %w", err)
+	}
+
+	execution = newBlockExecution(ts)
+	execution.witness = witness
+	execution.parentStateRoot = parent.StateRoot
+	s.blockExecutions.put(blockHash, execution)
+	return execution, nil
+}
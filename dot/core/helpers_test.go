@@ -24,7 +24,6 @@
 	"github.com/ChainSafe/gossamer/pkg/scale"
 	"github.com/ChainSafe/gossamer/pkg/trie"
 	"github.com/ChainSafe/gossamer/tests/utils/config"
-	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
@@ -119,8 +118,10 @@ func createTestService(t *testing.T, genesisFilePath string,
 
 	// Hash of encrypted centrifuge extrinsic
 	testCallArguments := []byte{0xab, 0xcd}
+	keyRing, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
 	extHex := runtime.NewTestExtrinsic(t, cfgRuntime, genesisHeader.Hash(), cfgBlockState.BestBlockHash(),
-		0, signature.TestKeyringPairAlice, "System.remark", testCallArguments)
+		0, keyRing.Alice(), "System.remark", testCallArguments)
 	encodedExtrinsic = common.MustHexToBytes(extHex)
 
 	cfgCodeSubstitutes := make(map[common.Hash]string)
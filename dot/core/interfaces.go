@@ -30,6 +30,7 @@ type BlockState interface {
 	BestBlockHeader() (*types.Header, error)
 	AddBlock(*types.Block) error
 	GetHeader(bhash common.Hash) (*types.Header, error)
+	GetHighestFinalisedHeader() (*types.Header, error)
 	GetBlockStateRoot(bhash common.Hash) (common.Hash, error)
 	RangeInMemory(start, end common.Hash) ([]common.Hash, error)
 	GetBlockBody(hash common.Hash) (*types.Body, error)
@@ -45,6 +46,7 @@ type StorageState interface {
 	StoreTrie(*rtstorage.TrieState, *types.Header) error
 	GetStateRootFromBlock(bhash *common.Hash) (*common.Hash, error)
 	GenerateTrieProof(stateRoot common.Hash, keys [][]byte) ([][]byte, error)
+	GenerateWitness(stateRoot common.Hash, keys [][]byte, childKeys map[string][][]byte) ([][]byte, error)
 	sync.Locker
 }
 
@@ -73,6 +75,9 @@ type TransactionState interface {
 	RemoveExtrinsicFromPool(ext types.Extrinsic)
 	PendingInPool() []*transaction.ValidTransaction
 	Exists(ext types.Extrinsic) bool
+	IsBanned(ext types.Extrinsic) bool
+	ReportInvalid(ext types.Extrinsic) (banned bool)
+	RemoveExpired(bestNumber uint) (removed []common.Hash)
 }
 
 // Network is the interface for the network service
@@ -80,6 +85,8 @@ type Network interface {
 	GossipMessage(network.NotificationsMessage)
 	IsSynced() bool
 	ReportPeer(change peerset.ReputationChange, p peer.ID)
+	ReadRemoteStorage(blockHash, stateRoot common.Hash, key []byte) (
+		value []byte, encodedProofNodes [][]byte, err error)
 }
 
 // CodeSubstitutedState interface to handle storage of code substitute state
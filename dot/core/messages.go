@@ -4,6 +4,8 @@
 package core
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
 	"github.com/ChainSafe/gossamer/dot/network"
@@ -17,6 +19,11 @@
 
 func (s *Service) validateTransaction(head *types.Header, rt runtime.Instance,
 	tx types.Extrinsic) (validity *transaction.Validity, err error) {
+	era, err := decodeEra(tx)
+	if err != nil {
+		return nil, err
+	}
+
 	s.storageState.Lock()
 
 	ts, err := s.storageState.TrieState(&head.StateRoot)
@@ -25,6 +32,11 @@ func (s *Service) validateTransaction(head *types.Header, rt runtime.Instance,
 		return nil, fmt.Errorf("cannot get trie state from storage for root %s: %w", head.StateRoot, err)
 	}
 
+	err = checkMortality(era, head, ts)
+	if err != nil {
+		return nil, err
+	}
+
 	rt.SetContextStorage(ts)
 
 	// validate each transaction
@@ -33,13 +45,16 @@ func (s *Service) validateTransaction(head *types.Header, rt runtime.Instance,
 		return nil, fmt.Errorf("building external transaction: %w", err)
 	}
 
-	validity, err = rt.ValidateTransaction(externalExt)
+	validity, err = validateTransactionWithTimeout(rt, externalExt)
 	if err != nil {
 		logger.Debugf("failed to validate transaction: %s", err)
 		return nil, err
 	}
 
-	vtx := transaction.NewValidTransaction(tx, validity)
+	vtx, err := newValidTransaction(tx, era, validity, head.Number)
+	if err != nil {
+		return nil, err
+	}
 
 	// push to the transaction queue of BABE session
 	hash := s.transactionState.AddToPool(vtx)
@@ -76,18 +91,42 @@ func (s *Service) HandleTransactionMessage(peerID peer.ID, msg *network.Transact
 
 	allTxnsAreValid := true
 	for _, tx := range txs {
+		if s.transactionState.IsBanned(tx) {
+			allTxnsAreValid = false
+			s.net.ReportPeer(peerset.ReputationChange{
+				Value:  peerset.BannedTransactionValue,
+				Reason: peerset.BannedTransactionReason,
+			}, peerID)
+			continue
+		}
+
 		validity, err := s.validateTransaction(head, rt, tx)
 		if err != nil {
 			allTxnsAreValid = false
 			switch err.(type) {
 			case runtime.InvalidTransaction:
+				s.transactionState.ReportInvalid(tx)
 				s.net.ReportPeer(peerset.ReputationChange{
 					Value:  peerset.BadTransactionValue,
 					Reason: peerset.BadTransactionReason,
 				}, peerID)
 			case runtime.UnknownTransaction:
 			default:
-				return false, fmt.Errorf("validating transaction from peerID %s: %w", peerID, err)
+				switch {
+				case errors.Is(err, ErrTransactionExpired):
+				case errors.Is(err, transaction.ErrInvalidEra):
+					s.net.ReportPeer(peerset.ReputationChange{
+						Value:  peerset.BadTransactionValue,
+						Reason: peerset.BadTransactionReason,
+					}, peerID)
+				case errors.Is(err, context.DeadlineExceeded):
+					s.net.ReportPeer(peerset.ReputationChange{
+						Value:  peerset.TransactionValidationTimeoutValue,
+						Reason: peerset.TransactionValidationTimeoutReason,
+					}, peerID)
+				default:
+					return false, fmt.Errorf("validating transaction from peerID %s: %w", peerID, err)
+				}
 			}
 			continue
 		}
@@ -113,3 +152,17 @@ func (s *Service) HandleTransactionMessage(peerID peer.ID, msg *network.Transact
 func (s *Service) TransactionsCount() int {
 	return len(s.transactionState.PendingInPool())
 }
+
+// PendingTransactions returns the extrinsics of the pending transactions in pool
+// which are allowed to be propagated
+func (s *Service) PendingTransactions() []types.Extrinsic {
+	pending := s.transactionState.PendingInPool()
+	extrinsics := make([]types.Extrinsic, 0, len(pending))
+	for _, tx := range pending {
+		if tx.Validity != nil && !tx.Validity.Propagate {
+			continue
+		}
+		extrinsics = append(extrinsics, tx.Extrinsic)
+	}
+	return extrinsics
+}
@@ -5,7 +5,9 @@
 
 import (
 	"bytes"
+	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/ChainSafe/gossamer/dot/network"
@@ -57,8 +59,11 @@ type mockStorageState struct {
 }
 
 type mockTxnState struct {
-	input *transaction.ValidTransaction
-	hash  common.Hash
+	checked       []types.Extrinsic
+	banned        bool
+	reportInvalid types.Extrinsic
+	input         *transaction.ValidTransaction
+	hash          common.Hash
 }
 
 type mockSetContextStorage struct {
@@ -120,6 +125,7 @@ func TestServiceHandleTransactionMessage(t *testing.T) {
 	runtimeMock := NewMockInstance(ctrl)
 	runtimeMock2 := NewMockInstance(ctrl)
 	runtimeMock3 := NewMockInstance(ctrl)
+	runtimeMock4 := NewMockInstance(ctrl)
 
 	invalidTransaction := runtime.NewInvalidTransaction()
 	err := invalidTransaction.SetValue(runtime.Future{})
@@ -225,6 +231,9 @@ type args struct {
 				input: &common.Hash{},
 				err:   errDummyErr,
 			},
+			mockTxnState: &mockTxnState{
+				checked: []types.Extrinsic{{1, 2, 3}},
+			},
 			args: args{
 				peerID: peer.ID("jimbo"),
 				msg: &network.TransactionMessage{
@@ -260,6 +269,10 @@ type args struct {
 				input:     &common.Hash{},
 				trieState: &storage.TrieState{},
 			},
+			mockTxnState: &mockTxnState{
+				checked:       []types.Extrinsic{{1, 2, 3}},
+				reportInvalid: types.Extrinsic{1, 2, 3},
+			},
 			mockRuntime: &mockRuntime{
 				runtime:           runtimeMock2,
 				setContextStorage: &mockSetContextStorage{trieState: &storage.TrieState{}},
@@ -279,6 +292,84 @@ type args struct {
 				},
 			},
 		},
+		{
+			name: "validate_transaction_timeout",
+			mockNetwork: &mockNetwork{
+				IsSynced: true,
+				ReportPeer: &mockReportPeer{
+					change: peerset.ReputationChange{
+						Value:  peerset.TransactionValidationTimeoutValue,
+						Reason: peerset.TransactionValidationTimeoutReason,
+					},
+					id: peer.ID("jimbo"),
+				},
+			},
+			mockBlockState: &mockBlockState{
+				bestHeader: &mockBestHeader{
+					header: testEmptyHeader,
+				},
+				getRuntime: &mockGetRuntime{
+					runtime: runtimeMock4,
+				},
+				callsBestBlockHash: true,
+			},
+			mockStorageState: &mockStorageState{
+				input:     &common.Hash{},
+				trieState: &storage.TrieState{},
+			},
+			mockTxnState: &mockTxnState{
+				checked: []types.Extrinsic{{1, 2, 3}},
+			},
+			mockRuntime: &mockRuntime{
+				runtime:           runtimeMock4,
+				setContextStorage: &mockSetContextStorage{trieState: &storage.TrieState{}},
+				validateTxn: &mockValidateTxn{
+					input: types.Extrinsic(bytes.Join([][]byte{
+						{byte(types.TxnExternal)},
+						testExtrinsic[0],
+						testEmptyHeader.StateRoot.ToBytes(),
+					}, nil)),
+					err: fmt.Errorf("running runtime function: %w", context.DeadlineExceeded),
+				},
+			},
+			args: args{
+				peerID: peer.ID("jimbo"),
+				msg: &network.TransactionMessage{
+					Extrinsics: []types.Extrinsic{{1, 2, 3}},
+				},
+			},
+		},
+		{
+			name: "banned_transaction",
+			mockNetwork: &mockNetwork{
+				IsSynced: true,
+				ReportPeer: &mockReportPeer{
+					change: peerset.ReputationChange{
+						Value:  peerset.BannedTransactionValue,
+						Reason: peerset.BannedTransactionReason,
+					},
+					id: peer.ID("jimbo"),
+				},
+			},
+			mockBlockState: &mockBlockState{
+				bestHeader: &mockBestHeader{
+					header: testEmptyHeader,
+				},
+				getRuntime: &mockGetRuntime{
+					runtime: runtimeMock,
+				},
+			},
+			mockTxnState: &mockTxnState{
+				checked: []types.Extrinsic{{1, 2, 3}},
+				banned:  true,
+			},
+			args: args{
+				peerID: peer.ID("jimbo"),
+				msg: &network.TransactionMessage{
+					Extrinsics: []types.Extrinsic{{1, 2, 3}},
+				},
+			},
+		},
 		{
 			name: "validTransaction",
 			mockNetwork: &mockNetwork{
@@ -305,6 +396,7 @@ type args struct {
 				trieState: &storage.TrieState{},
 			},
 			mockTxnState: &mockTxnState{
+				checked: []types.Extrinsic{{1, 2, 3}},
 				input: transaction.NewValidTransaction(
 					types.Extrinsic{1, 2, 3},
 					&transaction.Validity{
@@ -372,13 +464,21 @@ type args struct {
 			}
 			if tt.mockTxnState != nil {
 				txnState := NewMockTransactionState(ctrl)
-				txnState.EXPECT().AddToPool(tt.mockTxnState.input).Return(tt.mockTxnState.hash)
+				for _, ext := range tt.mockTxnState.checked {
+					txnState.EXPECT().IsBanned(ext).Return(tt.mockTxnState.banned)
+				}
+				if tt.mockTxnState.reportInvalid != nil {
+					txnState.EXPECT().ReportInvalid(tt.mockTxnState.reportInvalid)
+				}
+				if tt.mockTxnState.input != nil {
+					txnState.EXPECT().AddToPool(tt.mockTxnState.input).Return(tt.mockTxnState.hash)
+				}
 				s.transactionState = txnState
 			}
 			if tt.mockRuntime != nil {
 				rt := tt.mockRuntime.runtime
 				rt.EXPECT().SetContextStorage(tt.mockRuntime.setContextStorage.trieState)
-				rt.EXPECT().ValidateTransaction(tt.mockRuntime.validateTxn.input).
+				rt.EXPECT().ValidateTransaction(gomock.Any(), tt.mockRuntime.validateTxn.input).
 					Return(tt.mockRuntime.validateTxn.validity, tt.mockRuntime.validateTxn.err)
 				rt.EXPECT().Version().Return(runtime.Version{
 					SpecName:         []byte("polkadot"),
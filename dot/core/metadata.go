@@ -0,0 +1,204 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package core
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+)
+
+// metadataCacheCapacity is the maximum number of metadata kept in the metadata cache,
+// the oldest metadata being evicted first.
+const metadataCacheCapacity = 16
+
+// metadataKey identifies the metadata of a runtime code at a metadata version, the
+// version zero standing for the metadata returned by Metadata_metadata.
+type metadataKey struct {
+	codeHash common.Hash
+	version  uint32
+}
+
+// metadataCache caches the metadata returned by the runtimes by runtime code hash,
+// since the metadata only changes with the runtime code. Its zero value is ready to use.
+type metadataCache struct {
+	mutex    sync.RWMutex
+	metadata map[metadataKey][]byte
+	// keys holds the keys of the metadata cached, from the oldest to the newest
+	keys []metadataKey
+}
+
+func (mc *metadataCache) get(key metadataKey) (metadata []byte, ok bool) {
+	mc.mutex.RLock()
+	defer mc.mutex.RUnlock()
+	metadata, ok = mc.metadata[key]
+	return metadata, ok
+}
+
+func (mc *metadataCache) put(key metadataKey, metadata []byte) {
+	mc.mutex.Lock()
+	defer mc.mutex.Unlock()
+
+	if mc.metadata == nil {
+		mc.metadata = make(map[metadataKey][]byte)
+	}
+	if _, ok := mc.metadata[key]; ok {
+		return
+	}
+
+	if len(mc.keys) == metadataCacheCapacity {
+		delete(mc.metadata, mc.keys[0])
+		mc.keys = mc.keys[1:]
+	}
+
+	mc.metadata[key] = metadata
+	mc.keys = append(mc.keys, key)
+}
+
+// GetMetadata calls runtime Metadata_metadata function, using the runtime of the block
+// of the hash given, or of the best block.
+func (s *Service) GetMetadata(bhash *common.Hash) (metadata []byte, err error) {
+	return s.getMetadata(bhash, 0, runtime.Instance.Metadata)
+}
+
+// GetMetadataAtVersion calls runtime Metadata_metadata_at_version function, using the
+// runtime of the block of the hash given, or of the best block. It returns
+// runtime.ErrMetadataVersionNotSupported if the runtime does not provide the metadata
+// at the version given.
+func (s *Service) GetMetadataAtVersion(bhash *common.Hash, version uint32) (metadata []byte, err error) {
+	return s.getMetadata(bhash, version, func(instance runtime.Instance) ([]byte, error) {
+		return instance.MetadataAtVersion(version)
+	})
+}
+
+// getMetadata returns the metadata at the version given of the runtime of the block of
+// the hash given, from the metadata cache or calling the runtime using the function given.
+func (s *Service) getMetadata(bhash *common.Hash, version uint32,
+	call func(runtime.Instance) ([]byte, error)) (metadata []byte, err error) {
+	blockHash, trieState, err := s.blockTrieState(bhash)
+	if err != nil {
+		return nil, err
+	}
+
+	codeHash, err := trieState.LoadCodeHash()
+	if err != nil {
+		return nil, fmt.Errorf("loading code hash: %w", err)
+	}
+
+	key := metadataKey{codeHash: codeHash, version: version}
+	metadata, ok := s.metadata.get(key)
+	if ok {
+		return metadata, nil
+	}
+
+	instance, release, err := s.runtimeAt(blockHash, trieState)
+	if err != nil {
+		return nil, fmt.Errorf("setting up runtime: %w", err)
+	}
+	defer release()
+
+	metadata, err = call(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	s.metadata.put(key, metadata)
+	return metadata, nil
+}
+
+// blockTrieState returns the hash given, or the best block hash if it is nil, with the
+// trie state of this block.
+func (s *Service) blockTrieState(bhash *common.Hash) (
+	blockHash common.Hash, trieState *rtstorage.TrieState, err error) {
+	if bhash != nil {
+		blockHash = *bhash
+	} else {
+		blockHash = s.blockState.BestBlockHash()
+	}
+
+	stateRoot, err := s.storageState.GetStateRootFromBlock(&blockHash)
+	if err != nil {
+		return blockHash, nil, fmt.Errorf("getting state root from block hash: %w", err)
+	}
+
+	trieState, err = s.storageState.TrieState(stateRoot)
+	if err != nil {
+		return blockHash, nil, fmt.Errorf("getting trie state: %w", err)
+	}
+
+	return blockHash, trieState, nil
+}
+
+// runtimeAt returns a runtime instance running the code of the block of the hash given,
+// with the trie state given as storage, and a function to call once done with it.
+// The runtimes of the blocks in the block tree are kept in the block tree, but the ones
+// of the blocks finalised before the highest finalised block are instantiated from the
+// code of their state, and stopped by the release function.
+func (s *Service) runtimeAt(blockHash common.Hash, trieState *rtstorage.TrieState) (
+	instance runtime.Instance, release func(), err error) {
+	header, err := s.blockState.GetHeader(blockHash)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting header: %w", err)
+	}
+
+	finalised, err := s.blockState.GetHighestFinalisedHeader()
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting highest finalised header: %w", err)
+	}
+
+	if header.Number >= finalised.Number {
+		instance, err = s.blockState.GetRuntime(blockHash)
+		if err != nil {
+			return nil, nil, fmt.Errorf("getting runtime: %w", err)
+		}
+
+		instance.SetContextStorage(trieState)
+		return instance, func() {}, nil
+	}
+
+	code := trieState.LoadCode()
+	if len(code) == 0 {
+		return nil, nil, fmt.Errorf("%w: for block %s", ErrEmptyRuntimeCode, blockHash)
+	}
+
+	finalisedRuntime, err := s.blockState.GetRuntime(finalised.Hash())
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting runtime of highest finalised block: %w", err)
+	}
+
+	cfg := runtimeConfig(finalisedRuntime, trieState)
+	cfg.CodeHash, err = common.Blake2bHash(code)
+	if err != nil {
+		return nil, nil, fmt.Errorf("hashing code: %w", err)
+	}
+
+	historical, err := wazero_runtime.NewInstance(code, cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating runtime instance for block %s: %w", blockHash, err)
+	}
+
+	return historical, historical.Stop, nil
+}
+
+// runtimeConfig returns the configuration of a new runtime instance using the trie
+// state given as storage, and sharing the other settings of the runtime instance given.
+func runtimeConfig(instance runtime.Instance, trieState *rtstorage.TrieState) wazero_runtime.Config {
+	cfg := wazero_runtime.Config{
+		Storage:        trieState,
+		Keystore:       instance.Keystore(),
+		NodeStorage:    instance.NodeStorage(),
+		Network:        instance.NetworkService(),
+		InstanceConfig: instance.Config(),
+	}
+
+	if instance.Validator() {
+		cfg.Role = 4
+	}
+
+	return cfg
+}
@@ -149,6 +149,21 @@ func (mr *MockBlockStateMockRecorder) GetHeader(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeader", reflect.TypeOf((*MockBlockState)(nil).GetHeader), arg0)
 }
 
+// GetHighestFinalisedHeader mocks base method.
+func (m *MockBlockState) GetHighestFinalisedHeader() (*types.Header, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHighestFinalisedHeader")
+	ret0, _ := ret[0].(*types.Header)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHighestFinalisedHeader indicates an expected call of GetHighestFinalisedHeader.
+func (mr *MockBlockStateMockRecorder) GetHighestFinalisedHeader() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHighestFinalisedHeader", reflect.TypeOf((*MockBlockState)(nil).GetHighestFinalisedHeader))
+}
+
 // GetRuntime mocks base method.
 func (m *MockBlockState) GetRuntime(arg0 common.Hash) (runtime.Instance, error) {
 	m.ctrl.T.Helper()
@@ -258,6 +273,21 @@ func (mr *MockStorageStateMockRecorder) GenerateTrieProof(arg0, arg1 any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateTrieProof", reflect.TypeOf((*MockStorageState)(nil).GenerateTrieProof), arg0, arg1)
 }
 
+// GenerateWitness mocks base method.
+func (m *MockStorageState) GenerateWitness(arg0 common.Hash, arg1 [][]byte, arg2 map[string][][]byte) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateWitness", arg0, arg1, arg2)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateWitness indicates an expected call of GenerateWitness.
+func (mr *MockStorageStateMockRecorder) GenerateWitness(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateWitness", reflect.TypeOf((*MockStorageState)(nil).GenerateWitness), arg0, arg1, arg2)
+}
+
 // GetStateRootFromBlock mocks base method.
 func (m *MockStorageState) GetStateRootFromBlock(arg0 *common.Hash) (*common.Hash, error) {
 	m.ctrl.T.Helper()
@@ -377,6 +407,20 @@ func (mr *MockTransactionStateMockRecorder) Exists(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockTransactionState)(nil).Exists), arg0)
 }
 
+// IsBanned mocks base method.
+func (m *MockTransactionState) IsBanned(arg0 types.Extrinsic) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsBanned", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsBanned indicates an expected call of IsBanned.
+func (mr *MockTransactionStateMockRecorder) IsBanned(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBanned", reflect.TypeOf((*MockTransactionState)(nil).IsBanned), arg0)
+}
+
 // PendingInPool mocks base method.
 func (m *MockTransactionState) PendingInPool() []*transaction.ValidTransaction {
 	m.ctrl.T.Helper()
@@ -406,6 +450,20 @@ func (mr *MockTransactionStateMockRecorder) Push(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockTransactionState)(nil).Push), arg0)
 }
 
+// RemoveExpired mocks base method.
+func (m *MockTransactionState) RemoveExpired(arg0 uint) []common.Hash {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveExpired", arg0)
+	ret0, _ := ret[0].([]common.Hash)
+	return ret0
+}
+
+// RemoveExpired indicates an expected call of RemoveExpired.
+func (mr *MockTransactionStateMockRecorder) RemoveExpired(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveExpired", reflect.TypeOf((*MockTransactionState)(nil).RemoveExpired), arg0)
+}
+
 // RemoveExtrinsic mocks base method.
 func (m *MockTransactionState) RemoveExtrinsic(arg0 types.Extrinsic) {
 	m.ctrl.T.Helper()
@@ -430,6 +488,20 @@ func (mr *MockTransactionStateMockRecorder) RemoveExtrinsicFromPool(arg0 any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveExtrinsicFromPool", reflect.TypeOf((*MockTransactionState)(nil).RemoveExtrinsicFromPool), arg0)
 }
 
+// ReportInvalid mocks base method.
+func (m *MockTransactionState) ReportInvalid(arg0 types.Extrinsic) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportInvalid", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// ReportInvalid indicates an expected call of ReportInvalid.
+func (mr *MockTransactionStateMockRecorder) ReportInvalid(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportInvalid", reflect.TypeOf((*MockTransactionState)(nil).ReportInvalid), arg0)
+}
+
 // MockNetwork is a mock of Network interface.
 type MockNetwork struct {
 	ctrl     *gomock.Controller
@@ -479,6 +551,22 @@ func (mr *MockNetworkMockRecorder) IsSynced() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSynced", reflect.TypeOf((*MockNetwork)(nil).IsSynced))
 }
 
+// ReadRemoteStorage mocks base method.
+func (m *MockNetwork) ReadRemoteStorage(arg0, arg1 common.Hash, arg2 []byte) ([]byte, [][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadRemoteStorage", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].([][]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReadRemoteStorage indicates an expected call of ReadRemoteStorage.
+func (mr *MockNetworkMockRecorder) ReadRemoteStorage(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadRemoteStorage", reflect.TypeOf((*MockNetwork)(nil).ReadRemoteStorage), arg0, arg1, arg2)
+}
+
 // ReportPeer mocks base method.
 func (m *MockNetwork) ReportPeer(arg0 peerset.ReputationChange, arg1 peer.ID) {
 	m.ctrl.T.Helper()
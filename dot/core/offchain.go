@@ -0,0 +1,55 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package core
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+// runOffchainWorker runs the offchain worker of the runtime on the imported block if it is the
+// best block and the node is a synced validator, as the offchain workers of the runtime
+// submit the unsigned transactions expected from the validators, such as the im-online
+// heartbeats without which a validator not authoring blocks is reported offline.
+// The transactions submitted are handled as the extrinsics submitted to the node.
+func (s *Service) runOffchainWorker(block *types.Block, bestBlockHash common.Hash) error {
+	hash := block.Header.Hash()
+	if hash != bestBlockHash || s.net == nil || !s.net.IsSynced() {
+		return nil
+	}
+
+	rt, err := s.blockState.GetRuntime(hash)
+	if err != nil {
+		return fmt.Errorf("getting runtime: %w", err)
+	}
+
+	if !rt.Validator() {
+		return nil
+	}
+
+	ts, err := s.storageState.TrieState(&block.Header.StateRoot)
+	if err != nil {
+		return fmt.Errorf("getting trie state: %w", err)
+	}
+
+	rt.SetContextStorage(ts)
+	extrinsics, err := rt.OffchainWorker(&block.Header)
+	if err != nil {
+		return fmt.Errorf("running offchain worker: %w", err)
+	}
+
+	for _, ext := range extrinsics {
+		err = s.HandleSubmittedExtrinsic(ext)
+		if err != nil {
+			logger.Warnf("failed to submit transaction %s of offchain worker at block %s: %s", ext, hash, err)
+			continue
+		}
+
+		logger.Debugf("submitted transaction %s of offchain worker at block %s", ext, hash)
+	}
+
+	return nil
+}
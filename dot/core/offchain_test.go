@@ -0,0 +1,124 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package core
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Service_runOffchainWorker(t *testing.T) {
+	t.Parallel()
+
+	block := types.NewBlock(types.Header{
+		Number:    1,
+		StateRoot: common.Hash{2},
+	}, *types.NewBody(nil))
+	blockHash := block.Header.Hash()
+	heartbeat := types.Extrinsic{1, 2, 3}
+
+	testCases := map[string]struct {
+		bestBlockHash  common.Hash
+		serviceBuilder func(ctrl *gomock.Controller) *Service
+		errWrapped     error
+		errMessage     string
+	}{
+		"not_best_block": {
+			bestBlockHash: common.Hash{1},
+			serviceBuilder: func(_ *gomock.Controller) *Service {
+				return &Service{}
+			},
+		},
+		"not_synced": {
+			bestBlockHash: blockHash,
+			serviceBuilder: func(ctrl *gomock.Controller) *Service {
+				network := NewMockNetwork(ctrl)
+				network.EXPECT().IsSynced().Return(false)
+				return &Service{net: network}
+			},
+		},
+		"not_validator": {
+			bestBlockHash: blockHash,
+			serviceBuilder: func(ctrl *gomock.Controller) *Service {
+				network := NewMockNetwork(ctrl)
+				network.EXPECT().IsSynced().Return(true)
+				runtimeInstance := NewMockInstance(ctrl)
+				runtimeInstance.EXPECT().Validator().Return(false)
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetRuntime(blockHash).Return(runtimeInstance, nil)
+				return &Service{
+					net:        network,
+					blockState: blockState,
+				}
+			},
+		},
+		"offchain_worker_error": {
+			bestBlockHash: blockHash,
+			serviceBuilder: func(ctrl *gomock.Controller) *Service {
+				network := NewMockNetwork(ctrl)
+				network.EXPECT().IsSynced().Return(true)
+				runtimeInstance := NewMockInstance(ctrl)
+				runtimeInstance.EXPECT().Validator().Return(true)
+				runtimeInstance.EXPECT().SetContextStorage(&rtstorage.TrieState{})
+				runtimeInstance.EXPECT().OffchainWorker(&block.Header).Return(nil, errTestDummyError)
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetRuntime(blockHash).Return(runtimeInstance, nil)
+				storageState := NewMockStorageState(ctrl)
+				storageState.EXPECT().TrieState(&common.Hash{2}).Return(&rtstorage.TrieState{}, nil)
+				return &Service{
+					net:          network,
+					blockState:   blockState,
+					storageState: storageState,
+				}
+			},
+			errWrapped: errTestDummyError,
+			errMessage: "running offchain worker: test dummy error",
+		},
+		"submitted_transaction_handled": {
+			bestBlockHash: blockHash,
+			serviceBuilder: func(ctrl *gomock.Controller) *Service {
+				network := NewMockNetwork(ctrl)
+				network.EXPECT().IsSynced().Return(true)
+				runtimeInstance := NewMockInstance(ctrl)
+				runtimeInstance.EXPECT().Validator().Return(true)
+				runtimeInstance.EXPECT().SetContextStorage(&rtstorage.TrieState{})
+				runtimeInstance.EXPECT().OffchainWorker(&block.Header).
+					Return([]types.Extrinsic{heartbeat}, nil)
+				blockState := NewMockBlockState(ctrl)
+				blockState.EXPECT().GetRuntime(blockHash).Return(runtimeInstance, nil)
+				storageState := NewMockStorageState(ctrl)
+				storageState.EXPECT().TrieState(&common.Hash{2}).Return(&rtstorage.TrieState{}, nil)
+				transactionState := NewMockTransactionState(ctrl)
+				transactionState.EXPECT().Exists(heartbeat).Return(true)
+				return &Service{
+					net:              network,
+					blockState:       blockState,
+					storageState:     storageState,
+					transactionState: transactionState,
+				}
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			service := testCase.serviceBuilder(ctrl)
+			err := service.runOffchainWorker(&block, testCase.bestBlockHash)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+		})
+	}
+}
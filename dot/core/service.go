@@ -9,6 +9,7 @@
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/ChainSafe/gossamer/dot/network"
 	"github.com/ChainSafe/gossamer/dot/types"
@@ -19,8 +20,11 @@
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+	"github.com/ChainSafe/gossamer/lib/services"
 	"github.com/ChainSafe/gossamer/lib/transaction"
+	"github.com/ChainSafe/gossamer/pkg/scale"
 	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
 
 	cscale "github.com/centrifuge/go-substrate-rpc-client/v4/scale"
 	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
@@ -30,6 +34,15 @@
 	logger = log.NewFromGlobal(log.AddContext("pkg", "core"))
 )
 
+// systemBlockHashPrefix is the storage key prefix of the hashes of the recent blocks kept by
+// the System pallet: Twox128Hash("System") + Twox128Hash("BlockHash")
+var systemBlockHashPrefix = common.MustHexToBytes(
+	"0x26aa394eea5630e07c48ae0c9558cef7a44704b568d21667356a5a050c118746")
+
+// validateTransactionTimeout is the maximum duration of the runtime call validating
+// a transaction, after which the call is aborted and the transaction rejected.
+const validateTransactionTimeout = 2 * time.Second
+
 // QueryKeyValueChanges represents the key-value data inside a block storage
 type QueryKeyValueChanges map[string]string
 
@@ -57,6 +70,12 @@ type Service struct {
 	// Keystore
 	keys          *keystore.GlobalKeystore
 	onBlockImport BlockImportDigestHandler
+
+	// metadata returned by the runtimes, by runtime code hash
+	metadata metadataCache
+
+	// executions of the recent blocks, by block hash
+	blockExecutions blockExecutionCache
 }
 
 // Config holds the configuration for the core Service.
@@ -106,7 +125,7 @@ func NewService(cfg *Config) (*Service, error) {
 
 // Start starts the core service
 func (s *Service) Start() error {
-	go s.handleBlocksAsync()
+	services.Go(s, s.handleBlocksAsync)
 	return nil
 }
 
@@ -152,37 +171,12 @@ func (s *Service) StorageRoot() (common.Hash, error) {
 
 // HandleBlockImport handles a block that was imported via the network
 func (s *Service) HandleBlockImport(block *types.Block, state *rtstorage.TrieState, announce bool) error {
-	parentHash := block.Header.ParentHash
-	if parentHash != s.blockState.GenesisHash() {
-		parentHeader, err := s.blockState.GetHeader(parentHash)
-		if err != nil {
-			return fmt.Errorf("getting parent header: %w", err)
-		}
-
-		parentEpoch, err := s.epochState.GetEpochForBlock(parentHeader)
-		if err != nil {
-			return fmt.Errorf("getting epoch for parent block: %w", err)
-		}
-
-		currentBlockEpoch, err := s.epochState.GetEpochForBlock(&block.Header)
-		if err != nil {
-			return fmt.Errorf("getting epoch for current block: %w", err)
-		}
-
-		// if epoch was skipped then we should change the current
-		// epoch descriptor mapping to use the actual epoch,since
-		// was expected to have a block on `parentEpoch + 1` but
-		// the descendant is more than one epoch forward
-		if currentBlockEpoch > (parentEpoch + 1) {
-			err := s.epochState.UpdateSkippedEpochDefinitions(parentEpoch+1,
-				currentBlockEpoch, &block.Header)
-			if err != nil {
-				return fmt.Errorf("updating skipped epoch data raw: %w", err)
-			}
-		}
+	err := s.updateSkippedEpochs(&block.Header)
+	if err != nil {
+		return err
 	}
 
-	err := s.handleBlock(block, state)
+	err = s.handleBlock(block, state)
 	if err != nil {
 		return fmt.Errorf("handling block: %w", err)
 	}
@@ -203,6 +197,133 @@ func (s *Service) HandleBlockImport(block *types.Block, state *rtstorage.TrieSta
 	return nil
 }
 
+// HandleHeaderImport handles a block header that was imported via the network by a light node.
+// The block is not executed, its header is stored and its digests are handled, the runtime
+// code being read from the peers if the header signals the runtime environment was updated.
+func (s *Service) HandleHeaderImport(header *types.Header) error {
+	err := s.updateSkippedEpochs(header)
+	if err != nil {
+		return err
+	}
+
+	err = s.blockState.AddBlock(&types.Block{Header: *header, Body: types.Body{}})
+	if err != nil && !errors.Is(err, blocktree.ErrBlockExists) {
+		return fmt.Errorf("adding block: %w", err)
+	}
+
+	err = s.onBlockImport.HandleDigests(header)
+	if err != nil {
+		return fmt.Errorf("on block import handle: %w", err)
+	}
+
+	err = s.grandpaState.ApplyForcedChanges(header)
+	if err != nil {
+		return fmt.Errorf("applying forced changes: %w", err)
+	}
+
+	err = s.handleRuntimeEnvironmentUpdate(header)
+	if err != nil {
+		return fmt.Errorf("handling runtime environment update: %w", err)
+	}
+
+	logger.Debugf("imported header %s", header.Hash())
+	return nil
+}
+
+// handleRuntimeEnvironmentUpdate reads the runtime code of the block given from the
+// peers if its header signals the runtime environment was updated, and upgrades the
+// runtime of the block with it. Not executing the blocks, a light node does not
+// otherwise know the runtime code changed.
+func (s *Service) handleRuntimeEnvironmentUpdate(header *types.Header) error {
+	if !hasRuntimeEnvironmentUpdated(header.Digest) {
+		return nil
+	}
+
+	blockHash := header.Hash()
+	code, _, err := s.net.ReadRemoteStorage(blockHash, header.StateRoot, common.CodeKey)
+	if err != nil {
+		return fmt.Errorf("reading runtime code: %w", err)
+	}
+
+	codeTrie := inmemory.NewEmptyTrie()
+	err = codeTrie.Put(common.CodeKey, code)
+	if err != nil {
+		return fmt.Errorf("putting runtime code in trie: %w", err)
+	}
+	codeState := rtstorage.NewTrieState(codeTrie)
+
+	parentRuntime, err := s.blockState.GetRuntime(header.ParentHash)
+	if err != nil {
+		return fmt.Errorf("getting parent runtime: %w", err)
+	}
+
+	err = s.blockState.HandleRuntimeChanges(codeState, parentRuntime, blockHash)
+	if err != nil {
+		return fmt.Errorf("handling runtime changes: %w", err)
+	}
+
+	err = s.storageState.StoreTrie(codeState, header)
+	if err != nil {
+		return fmt.Errorf("storing runtime code: %w", err)
+	}
+
+	return nil
+}
+
+// hasRuntimeEnvironmentUpdated returns true if the digest given contains
+// the item signalling the runtime code or heap pages were updated.
+func hasRuntimeEnvironmentUpdated(digest types.Digest) bool {
+	for _, item := range digest {
+		value, err := item.Value()
+		if err != nil {
+			continue
+		}
+
+		if _, ok := value.(types.RuntimeEnvironmentUpdated); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// updateSkippedEpochs updates the epoch definitions if the epochs
+// between the parent block and the given block were skipped
+func (s *Service) updateSkippedEpochs(header *types.Header) error {
+	parentHash := header.ParentHash
+	if parentHash == s.blockState.GenesisHash() {
+		return nil
+	}
+
+	parentHeader, err := s.blockState.GetHeader(parentHash)
+	if err != nil {
+		return fmt.Errorf("getting parent header: %w", err)
+	}
+
+	parentEpoch, err := s.epochState.GetEpochForBlock(parentHeader)
+	if err != nil {
+		return fmt.Errorf("getting epoch for parent block: %w", err)
+	}
+
+	currentBlockEpoch, err := s.epochState.GetEpochForBlock(header)
+	if err != nil {
+		return fmt.Errorf("getting epoch for current block: %w", err)
+	}
+
+	// if epoch was skipped then we should change the current
+	// epoch descriptor mapping to use the actual epoch,since
+	// was expected to have a block on `parentEpoch + 1` but
+	// the descendant is more than one epoch forward
+	if currentBlockEpoch > (parentEpoch + 1) {
+		err := s.epochState.UpdateSkippedEpochDefinitions(parentEpoch+1,
+			currentBlockEpoch, header)
+		if err != nil {
+			return fmt.Errorf("updating skipped epoch data raw: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // HandleBlockProduced handles a block that was produced by us
 // It is handled the same as an imported block in terms of state updates; the only difference
 // is we send a BlockAnnounceMessage to our peers.
@@ -258,6 +379,8 @@ func (s *Service) handleBlock(block *types.Block, state *rtstorage.TrieState) er
 		return err
 	}
 
+	s.blockExecutions.put(block.Header.Hash(), newBlockExecution(state))
+
 	// store block in database
 	if err = s.blockState.AddBlock(block); err != nil {
 		if errors.Is(err, blocktree.ErrParentNotFound) && block.Header.Number != 0 {
@@ -334,18 +457,7 @@ func (s *Service) handleCodeSubstitution(hash common.Hash,
 
 	// this needs to create a new runtime instance, otherwise it will update
 	// the blocks that reference the current runtime version to use the code substition
-	cfg := wazero_runtime.Config{
-		Storage:     state,
-		Keystore:    rt.Keystore(),
-		NodeStorage: rt.NodeStorage(),
-		Network:     rt.NetworkService(),
-	}
-
-	if rt.Validator() {
-		cfg.Role = 4
-	}
-
-	next, err := wazero_runtime.NewInstance(code, cfg)
+	next, err := wazero_runtime.NewInstance(code, runtimeConfig(rt, state))
 	if err != nil {
 		return fmt.Errorf("creating new runtime instance: %w", err)
 	}
@@ -383,6 +495,10 @@ func (s *Service) handleBlocksAsync() {
 				// TODO remove once gossamer is in stable state
 				panic(fmt.Errorf("failed to maintain txn pool after re-org: %s", err))
 			}
+
+			if err := s.runOffchainWorker(block, bestBlockHash); err != nil {
+				logger.Warnf("failed to run offchain worker for block %s: %s", block.Header.Hash(), err)
+			}
 		case <-s.ctx.Done():
 			return
 		}
@@ -452,7 +568,7 @@ func (s *Service) handleChainReorg(best, curr common.Hash) error {
 				return fmt.Errorf("building external transaction: %s", err)
 			}
 
-			transactionValidity, err := rt.ValidateTransaction(externalExt)
+			transactionValidity, err := validateTransactionWithTimeout(rt, externalExt)
 			if err != nil {
 				logger.Debugf("failed to validate transaction for extrinsic %s: %s skipping in chain reorg", ext, err)
 				s.transactionState.RemoveExtrinsic(ext)
@@ -476,6 +592,11 @@ func (s *Service) maintainTransactionPool(block *types.Block, bestBlockHash comm
 		s.transactionState.RemoveExtrinsic(ext)
 	}
 
+	// remove the transactions expired at the new best block
+	if block.Header.Hash() == bestBlockHash {
+		s.transactionState.RemoveExpired(block.Header.Number)
+	}
+
 	stateRoot, err := s.storageState.GetStateRootFromBlock(&bestBlockHash)
 	if err != nil {
 		logger.Errorf("could not get state root from block %s: %w", bestBlockHash, err)
@@ -503,14 +624,19 @@ func (s *Service) maintainTransactionPool(block *types.Block, bestBlockHash comm
 			return fmt.Errorf("building external transaction: %s", err)
 		}
 
-		txnValidity, err := rt.ValidateTransaction(externalExt)
+		txnValidity, err := validateTransactionWithTimeout(rt, externalExt)
 		if err != nil {
 			logger.Debugf("failed to validate transaction for extrinsic %s: %s", tx.Extrinsic, err)
 			s.transactionState.RemoveExtrinsic(tx.Extrinsic)
+			if _, ok := err.(runtime.InvalidTransaction); ok {
+				s.transactionState.ReportInvalid(tx.Extrinsic)
+			}
 			continue
 		}
 
+		validTill := tx.ValidTill
 		tx = transaction.NewValidTransaction(tx.Extrinsic, txnValidity)
+		tx.ValidTill = validTill
 
 		// Err is only thrown if tx is already in pool, in which case it still gets removed
 		h, _ := s.transactionState.Push(tx)
@@ -573,6 +699,15 @@ func (s *Service) HandleSubmittedExtrinsic(ext types.Extrinsic) error {
 		return nil
 	}
 
+	if s.transactionState.IsBanned(ext) {
+		return ErrTransactionBanned
+	}
+
+	era, err := decodeEra(ext)
+	if err != nil {
+		return err
+	}
+
 	bestBlockHash := s.blockState.BestBlockHash()
 
 	stateRoot, err := s.storageState.GetStateRootFromBlock(&bestBlockHash)
@@ -585,6 +720,20 @@ func (s *Service) HandleSubmittedExtrinsic(ext types.Extrinsic) error {
 		return err
 	}
 
+	var bestBlockNumber uint
+	if !era.Immortal() {
+		bestBlockHeader, err := s.blockState.GetHeader(bestBlockHash)
+		if err != nil {
+			return fmt.Errorf("getting best block header: %w", err)
+		}
+		bestBlockNumber = bestBlockHeader.Number
+
+		err = checkMortality(era, bestBlockHeader, ts)
+		if err != nil {
+			return err
+		}
+	}
+
 	rt, err := s.blockState.GetRuntime(bestBlockHash)
 	if err != nil {
 		logger.Critical("failed to get runtime")
@@ -598,14 +747,24 @@ func (s *Service) HandleSubmittedExtrinsic(ext types.Extrinsic) error {
 		return fmt.Errorf("building external transaction: %w", err)
 	}
 
-	transactionValidity, err := rt.ValidateTransaction(externalExt)
+	transactionValidity, err := validateTransactionWithTimeout(rt, externalExt)
+	if err != nil {
+		if _, ok := err.(runtime.InvalidTransaction); ok {
+			s.transactionState.ReportInvalid(ext)
+		}
+		return err
+	}
+
+	vtx, err := newValidTransaction(ext, era, transactionValidity, bestBlockNumber)
 	if err != nil {
 		return err
 	}
 
 	// add transaction to pool
-	vtx := transaction.NewValidTransaction(ext, transactionValidity)
 	s.transactionState.AddToPool(vtx)
+	if !s.transactionState.Exists(ext) {
+		return ErrTransactionPoolFull
+	}
 
 	// broadcast transaction
 	msg := &network.TransactionMessage{Extrinsics: []types.Extrinsic{ext}}
@@ -613,15 +772,6 @@ func (s *Service) HandleSubmittedExtrinsic(ext types.Extrinsic) error {
 	return nil
 }
 
-// GetMetadata calls runtime Metadata_metadata function
-func (s *Service) GetMetadata(bhash *common.Hash) (metadata []byte, err error) {
-	rt, err := prepareRuntime(bhash, s.storageState, s.blockState)
-	if err != nil {
-		return nil, fmt.Errorf("setting up runtime: %w", err)
-	}
-	return rt.Metadata()
-}
-
 // GetReadProofAt will return an array with the proofs for the keys passed as params
 // based on the block hash passed as param as well, if block hash is nil then the current state will take place
 func (s *Service) GetReadProofAt(block common.Hash, keys [][]byte) (
@@ -643,6 +793,115 @@ func (s *Service) GetReadProofAt(block common.Hash, keys [][]byte) (
 	return block, proofForKeys, nil
 }
 
+// GetBlockWitness executes again the block with the given hash on top of its parent state,
+// recording the state trie keys accessed during the execution. It returns the state root of
+// the parent block with the storage proof of all the trie nodes touched by the execution,
+// which is enough to execute the block without the parent state. The witness of the recent
+// blocks witnessed is cached, so the block is only executed again once.
+func (s *Service) GetBlockWitness(blockHash common.Hash) (
+	parentStateRoot common.Hash, witness [][]byte, err error) {
+	execution, ok := s.blockExecutions.get(blockHash)
+	if !ok || execution.witness == nil {
+		var block *types.Block
+		block, err = s.getBlock(blockHash)
+		if err != nil {
+			return parentStateRoot, nil, err
+		}
+
+		execution, err = s.witnessedBlockExecution(blockHash, block)
+		if err != nil {
+			return parentStateRoot, nil, err
+		}
+	}
+
+	return execution.parentStateRoot, execution.witness, nil
+}
+
+// validateTransactionWithTimeout validates the external transaction given with the runtime
+// given, aborting the runtime call once validateTransactionTimeout elapsed.
+func validateTransactionWithTimeout(rt runtime.Instance, externalExt types.Extrinsic) (
+	*transaction.Validity, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), validateTransactionTimeout)
+	defer cancel()
+	return rt.ValidateTransaction(ctx, externalExt)
+}
+
+// decodeEra decodes the era of the extrinsic given, returning the immortal era if the extrinsic
+// format is not understood, the runtime validating it regardless.
+func decodeEra(ext types.Extrinsic) (era transaction.Era, err error) {
+	era, err = transaction.DecodeEra(ext)
+	switch {
+	case errors.Is(err, transaction.ErrInvalidEra):
+		return era, err
+	case err != nil:
+		logger.Tracef("cannot decode era of extrinsic %s: %s", ext, err)
+		return transaction.Era{}, nil
+	default:
+		return era, nil
+	}
+}
+
+// checkMortality returns ErrTransactionExpired if the mortal extrinsic of the era given cannot be
+// valid on top of the best block given, checking it the same way as the CheckMortality signed
+// extension. The extrinsic being signed with the hash of the block its mortality window starts
+// at, the birth block has to be imported, and its hash kept by the System pallet in the state
+// of the best block given, the hashes of the older blocks being pruned by the runtime.
+func checkMortality(era transaction.Era, best *types.Header, state *rtstorage.TrieState) error {
+	if era.Immortal() {
+		return nil
+	}
+
+	// the extrinsic is validated for the block built on top of the best block
+	bestNumber := uint64(best.Number)
+	birth := era.Birth(bestNumber + 1)
+	switch {
+	case birth > bestNumber:
+		return fmt.Errorf("%w: birth block %d is not imported at best block %d",
+			ErrTransactionExpired, birth, bestNumber)
+	case birth == bestNumber:
+		// the hash of the best block is only stored in the state of the next block
+		return nil
+	}
+
+	encodedBirth := scale.MustMarshal(uint32(birth))
+	hashedBirth, err := common.Twox64(encodedBirth)
+	if err != nil {
+		return fmt.Errorf("hashing birth block number: %w", err)
+	}
+
+	key := append(bytes.Clone(systemBlockHashPrefix), hashedBirth...)
+	if state.Get(append(key, encodedBirth...)) == nil {
+		return fmt.Errorf("%w: hash of birth block %d is pruned at best block %d",
+			ErrTransactionExpired, birth, bestNumber)
+	}
+
+	return nil
+}
+
+// newValidTransaction returns the transaction of the extrinsic given, validated at the best
+// block number given, expiring at the end of its mortality window or earlier if the runtime
+// gave it a shorter longevity. It returns ErrTransactionExpired if the runtime gave it no
+// longevity left at the best block.
+func newValidTransaction(ext types.Extrinsic, era transaction.Era, validity *transaction.Validity,
+	bestBlockNumber uint) (*transaction.ValidTransaction, error) {
+	vtx := transaction.NewValidTransaction(ext, validity)
+	if era.Immortal() {
+		return vtx, nil
+	}
+
+	best := uint64(bestBlockNumber)
+	validTill := era.Death(best)
+	if validity != nil && validity.Longevity < validTill-best {
+		validTill = best + validity.Longevity
+	}
+	if validTill <= best {
+		return nil, fmt.Errorf("%w: at block %d", ErrTransactionExpired, validTill)
+	}
+
+	vtx.ValidTill = uint(validTill)
+	return vtx, nil
+}
+
 // buildExternalTransaction builds an external transaction based on the current transaction queue API version
 // See https://github.com/paritytech/substrate/blob/polkadot-v0.9.25/primitives/transaction-pool/src/runtime_api.rs#L25-L55
 func (s *Service) buildExternalTransaction(rt runtime.Instance, ext types.Extrinsic) (types.Extrinsic, error) {
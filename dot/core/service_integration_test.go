@@ -321,7 +321,7 @@ func BuildBlock(t *testing.T, instance runtime.Instance, parent *types.Header, e
 		var ret []byte
 
 		externalExt := types.Extrinsic(append([]byte{byte(types.TxnExternal)}, ext...))
-		_, err = instance.ValidateTransaction(externalExt)
+		_, err = instance.ValidateTransaction(context.Background(), externalExt)
 		require.NoError(t, err)
 
 		ret, err = instance.ApplyExtrinsic(ext)
@@ -386,7 +386,7 @@ func TestHandleChainReorg_WithReorg_Transactions(t *testing.T) {
 	rt, err := s.blockState.GetRuntime(bestBlockHash)
 	require.NoError(t, err)
 
-	validity, err := rt.ValidateTransaction(tx)
+	validity, err := rt.ValidateTransaction(context.Background(), tx)
 	require.NoError(t, err)
 
 	// get common ancestor
@@ -261,6 +261,7 @@ func Test_Service_handleCodeSubstitution(t *testing.T) {
 				storedRuntime.EXPECT().Keystore().Return(nil)
 				storedRuntime.EXPECT().NodeStorage().Return(runtime.NodeStorage{})
 				storedRuntime.EXPECT().NetworkService().Return(nil)
+				storedRuntime.EXPECT().Config().Return(runtime.InstanceConfig{})
 				storedRuntime.EXPECT().Validator().Return(false)
 
 				blockState := NewMockBlockState(ctrl)
@@ -287,6 +288,7 @@ func Test_Service_handleCodeSubstitution(t *testing.T) {
 				storedRuntime.EXPECT().Keystore().Return(nil)
 				storedRuntime.EXPECT().NodeStorage().Return(runtime.NodeStorage{})
 				storedRuntime.EXPECT().NetworkService().Return(nil)
+				storedRuntime.EXPECT().Config().Return(runtime.InstanceConfig{})
 				storedRuntime.EXPECT().Validator().Return(true)
 
 				blockState := NewMockBlockState(ctrl)
@@ -316,6 +318,7 @@ func Test_Service_handleCodeSubstitution(t *testing.T) {
 				storedRuntime.EXPECT().Keystore().Return(nil)
 				storedRuntime.EXPECT().NodeStorage().Return(runtime.NodeStorage{})
 				storedRuntime.EXPECT().NetworkService().Return(nil)
+				storedRuntime.EXPECT().Config().Return(runtime.InstanceConfig{})
 				storedRuntime.EXPECT().Validator().Return(true)
 
 				blockState := NewMockBlockState(ctrl)
@@ -623,7 +626,7 @@ func Test_Service_maintainTransactionPool(t *testing.T) {
 
 		ctrl := gomock.NewController(t)
 		runtimeMock := NewMockInstance(ctrl)
-		runtimeMock.EXPECT().ValidateTransaction(externalExt).Return(nil, errTestDummyError)
+		runtimeMock.EXPECT().ValidateTransaction(gomock.Any(), externalExt).Return(nil, errTestDummyError)
 		runtimeMock.EXPECT().Version().Return(runtime.Version{
 			SpecName:         []byte("polkadot"),
 			ImplName:         []byte("parity-polkadot"),
@@ -688,7 +691,8 @@ func Test_Service_maintainTransactionPool(t *testing.T) {
 
 		ctrl := gomock.NewController(t)
 		runtimeMock := NewMockInstance(ctrl)
-		runtimeMock.EXPECT().ValidateTransaction(externalExt).Return(&transaction.Validity{Propagate: true}, nil)
+		runtimeMock.EXPECT().ValidateTransaction(gomock.Any(), externalExt).
+			Return(&transaction.Validity{Propagate: true}, nil)
 		runtimeMock.EXPECT().Version().Return(runtime.Version{
 			SpecName:         []byte("polkadot"),
 			ImplName:         []byte("parity-polkadot"),
@@ -729,6 +733,154 @@ func Test_Service_maintainTransactionPool(t *testing.T) {
 	})
 }
 
+func Test_Service_maintainTransactionPool_expired(t *testing.T) {
+	t.Parallel()
+
+	block := types.NewBlock(*types.NewEmptyHeader(), *types.NewBody([]types.Extrinsic{{21}}))
+	block.Header.Number = 21
+	bestBlockHash := block.Header.Hash()
+
+	ctrl := gomock.NewController(t)
+	mockTxnState := NewMockTransactionState(ctrl)
+	mockTxnState.EXPECT().RemoveExtrinsic(types.Extrinsic{21})
+	mockTxnState.EXPECT().RemoveExpired(uint(21))
+	mockStorageState := NewMockStorageState(ctrl)
+	mockStorageState.EXPECT().GetStateRootFromBlock(&bestBlockHash).Return(nil, errDummyErr)
+
+	service := &Service{
+		transactionState: mockTxnState,
+		storageState:     mockStorageState,
+	}
+	err := service.maintainTransactionPool(&block, bestBlockHash)
+	require.ErrorIs(t, err, errDummyErr)
+}
+
+func Test_newValidTransaction(t *testing.T) {
+	t.Parallel()
+
+	ext := types.Extrinsic{1}
+	mortal := transaction.Era{Period: 64, Phase: 42}
+
+	testCases := map[string]struct {
+		era             transaction.Era
+		validity        *transaction.Validity
+		bestBlockNumber uint
+		validTill       uint
+		errWrapped      error
+		errMessage      string
+	}{
+		"immortal": {
+			validity:        &transaction.Validity{},
+			bestBlockNumber: 100,
+		},
+		"mortal": {
+			era:             mortal,
+			validity:        &transaction.Validity{Longevity: 1000},
+			bestBlockNumber: 100,
+			validTill:       106,
+		},
+		"shorter_longevity": {
+			era:             mortal,
+			validity:        &transaction.Validity{Longevity: 2},
+			bestBlockNumber: 100,
+			validTill:       102,
+		},
+		"no_longevity_left": {
+			era:             mortal,
+			validity:        &transaction.Validity{Longevity: 0},
+			bestBlockNumber: 100,
+			errWrapped:      ErrTransactionExpired,
+			errMessage:      "transaction mortality window has expired: at block 100",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			vtx, err := newValidTransaction(ext, testCase.era, testCase.validity, testCase.bestBlockNumber)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+				assert.Nil(t, vtx)
+				return
+			}
+			expected := transaction.NewValidTransaction(ext, testCase.validity)
+			expected.ValidTill = testCase.validTill
+			assert.Equal(t, expected, vtx)
+		})
+	}
+}
+
+func Test_checkMortality(t *testing.T) {
+	t.Parallel()
+
+	// the hash of block 100 is kept in the state of the best block 110
+	birthKey := append(common.MustHexToBytes(
+		"0x26aa394eea5630e07c48ae0c9558cef7a44704b568d21667356a5a050c118746"),
+		common.MustHexToBytes("0x4213c2713e48b45264000000")...)
+	state := rtstorage.NewTrieState(inmemory_trie.NewEmptyTrie())
+	err := state.Put(birthKey, common.Hash{1}.ToBytes())
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		era        transaction.Era
+		bestNumber uint
+		errWrapped error
+		errMessage string
+	}{
+		"immortal": {
+			bestNumber: 110,
+		},
+		"birth_hash_kept": {
+			era:        transaction.Era{Period: 64, Phase: 36},
+			bestNumber: 110,
+		},
+		"birth_at_best_block": {
+			era:        transaction.Era{Period: 64, Phase: 46},
+			bestNumber: 110,
+		},
+		"birth_after_best_block": {
+			era:        transaction.Era{Period: 64, Phase: 42},
+			bestNumber: 10,
+			errWrapped: ErrTransactionExpired,
+			errMessage: "transaction mortality window has expired: " +
+				"birth block 42 is not imported at best block 10",
+		},
+		"birth_at_next_block": {
+			era:        transaction.Era{Period: 64, Phase: 47},
+			bestNumber: 110,
+			errWrapped: ErrTransactionExpired,
+			errMessage: "transaction mortality window has expired: " +
+				"birth block 111 is not imported at best block 110",
+		},
+		"stale_era": {
+			era:        transaction.Era{Period: 64, Phase: 37},
+			bestNumber: 110,
+			errWrapped: ErrTransactionExpired,
+			errMessage: "transaction mortality window has expired: " +
+				"hash of birth block 101 is pruned at best block 110",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			best := &types.Header{Number: testCase.bestNumber}
+			err := checkMortality(testCase.era, best, state)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+		})
+	}
+}
+
 func Test_Service_handleBlocksAsync(t *testing.T) {
 	t.Parallel()
 	t.Run("cancelled_context", func(t *testing.T) {
@@ -904,7 +1056,7 @@ func TestService_handleChainReorg(t *testing.T) {
 		ctrl := gomock.NewController(t)
 
 		runtimeMockErr := NewMockInstance(ctrl)
-		runtimeMockErr.EXPECT().ValidateTransaction(externExt).Return(nil, errTestDummyError)
+		runtimeMockErr.EXPECT().ValidateTransaction(gomock.Any(), externExt).Return(nil, errTestDummyError)
 		runtimeMockErr.EXPECT().Version().Return(runtime.Version{
 			SpecName:         []byte("polkadot"),
 			ImplName:         []byte("parity-polkadot"),
@@ -943,7 +1095,7 @@ func TestService_handleChainReorg(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
 		runtimeMockOk := NewMockInstance(ctrl)
-		runtimeMockOk.EXPECT().ValidateTransaction(externExt).Return(testValidity, nil)
+		runtimeMockOk.EXPECT().ValidateTransaction(gomock.Any(), externExt).Return(testValidity, nil)
 		runtimeMockOk.EXPECT().Version().Return(runtime.Version{
 			SpecName:         []byte("polkadot"),
 			ImplName:         []byte("parity-polkadot"),
@@ -1243,6 +1395,19 @@ func TestServiceHandleSubmittedExtrinsic(t *testing.T) {
 		execTest(t, service, nil, nil)
 	})
 
+	t.Run("banned", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockTxnState := NewMockTransactionState(ctrl)
+		mockTxnState.EXPECT().Exists(types.Extrinsic{}).Return(false)
+		mockTxnState.EXPECT().IsBanned(types.Extrinsic{}).Return(true)
+		service := &Service{
+			transactionState: mockTxnState,
+			net:              NewMockNetwork(ctrl),
+		}
+		execTest(t, service, types.Extrinsic{}, ErrTransactionBanned)
+	})
+
 	t.Run("trie_state_err", func(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
@@ -1254,6 +1419,7 @@ func TestServiceHandleSubmittedExtrinsic(t *testing.T) {
 		mockBlockState.EXPECT().BestBlockHash().Return(common.Hash{})
 		mockTxnState := NewMockTransactionState(ctrl)
 		mockTxnState.EXPECT().Exists(nil)
+		mockTxnState.EXPECT().IsBanned(nil)
 		service := &Service{
 			blockState:       mockBlockState,
 			storageState:     mockStorageState,
@@ -1277,6 +1443,7 @@ func TestServiceHandleSubmittedExtrinsic(t *testing.T) {
 
 		mockTxnState := NewMockTransactionState(ctrl)
 		mockTxnState.EXPECT().Exists(nil).MaxTimes(2)
+		mockTxnState.EXPECT().IsBanned(nil)
 		service := &Service{
 			storageState:     mockStorageState,
 			transactionState: mockTxnState,
@@ -1301,8 +1468,9 @@ func TestServiceHandleSubmittedExtrinsic(t *testing.T) {
 
 		mockTxnState := NewMockTransactionState(ctrl)
 		mockTxnState.EXPECT().Exists(types.Extrinsic{})
+		mockTxnState.EXPECT().IsBanned(types.Extrinsic{})
 
-		runtimeMockErr.EXPECT().ValidateTransaction(externalExt).Return(nil, errDummyErr)
+		runtimeMockErr.EXPECT().ValidateTransaction(gomock.Any(), externalExt).Return(nil, errDummyErr)
 		runtimeMockErr.EXPECT().Version().Return(runtime.Version{
 			SpecName:         []byte("polkadot"),
 			ImplName:         []byte("parity-polkadot"),
@@ -1336,7 +1504,8 @@ func TestServiceHandleSubmittedExtrinsic(t *testing.T) {
 		mockBlockState.EXPECT().GetRuntime(common.Hash{}).Return(runtimeMock, nil).MaxTimes(2)
 		mockBlockState.EXPECT().BestBlockHash().Return(common.Hash{})
 
-		runtimeMock.EXPECT().ValidateTransaction(externalExt).Return(&transaction.Validity{Propagate: true}, nil)
+		runtimeMock.EXPECT().ValidateTransaction(gomock.Any(), externalExt).
+			Return(&transaction.Validity{Propagate: true}, nil)
 		runtimeMock.EXPECT().Version().Return(runtime.Version{
 			SpecName:         []byte("polkadot"),
 			ImplName:         []byte("parity-polkadot"),
@@ -1357,8 +1526,10 @@ func TestServiceHandleSubmittedExtrinsic(t *testing.T) {
 		mockStorageState.EXPECT().GetStateRootFromBlock(&common.Hash{}).Return(&common.Hash{}, nil)
 
 		mockTxnState := NewMockTransactionState(ctrl)
-		mockTxnState.EXPECT().Exists(types.Extrinsic{}).MaxTimes(2)
+		mockTxnState.EXPECT().Exists(types.Extrinsic{}).Return(false)
+		mockTxnState.EXPECT().IsBanned(types.Extrinsic{}).Return(false)
 		mockTxnState.EXPECT().AddToPool(transaction.NewValidTransaction(ext, &transaction.Validity{Propagate: true}))
+		mockTxnState.EXPECT().Exists(types.Extrinsic{}).Return(true)
 		mockNetState := NewMockNetwork(ctrl)
 		mockNetState.EXPECT().GossipMessage(&network.TransactionMessage{Extrinsics: []types.Extrinsic{ext}})
 		service := &Service{
@@ -1383,6 +1554,14 @@ func TestServiceGetMetadata(t *testing.T) {
 		assert.Equal(t, exp, res)
 	}
 
+	newTrieState := func(t *testing.T, code []byte) *rtstorage.TrieState {
+		t.Helper()
+		trieState := rtstorage.NewTrieState(inmemory_trie.NewEmptyTrie())
+		err := trieState.Put(common.CodeKey, code)
+		require.NoError(t, err)
+		return trieState
+	}
+
 	t.Run("get_state_root_error", func(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
@@ -1391,30 +1570,37 @@ func TestServiceGetMetadata(t *testing.T) {
 		service := &Service{
 			storageState: mockStorageState,
 		}
-		const expectedErrMessage = "setting up runtime: getting state root from block hash: dummy error for testing"
+		const expectedErrMessage = "getting state root from block hash: dummy error for testing"
 		execTest(t, service, &common.Hash{}, nil, errDummyErr, expectedErrMessage)
 	})
 
 	t.Run("trie_state_error", func(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().BestBlockHash().Return(common.Hash{1})
 		mockStorageState := NewMockStorageState(ctrl)
-		mockStorageState.EXPECT().TrieState(nil).Return(nil, errDummyErr)
+		mockStorageState.EXPECT().GetStateRootFromBlock(&common.Hash{1}).Return(&common.Hash{2}, nil)
+		mockStorageState.EXPECT().TrieState(&common.Hash{2}).Return(nil, errDummyErr)
 		service := &Service{
 			storageState: mockStorageState,
+			blockState:   mockBlockState,
 		}
-		const expectedErrMessage = "setting up runtime: getting trie state: dummy error for testing"
+		const expectedErrMessage = "getting trie state: dummy error for testing"
 		execTest(t, service, nil, nil, errDummyErr, expectedErrMessage)
 	})
 
 	t.Run("get_runtime_error", func(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
-		mockStorageState := NewMockStorageState(ctrl)
-		mockStorageState.EXPECT().TrieState(nil).Return(&rtstorage.TrieState{}, nil)
 		mockBlockState := NewMockBlockState(ctrl)
 		mockBlockState.EXPECT().BestBlockHash().Return(common.Hash{1})
+		mockBlockState.EXPECT().GetHeader(common.Hash{1}).Return(&types.Header{Number: 2}, nil)
+		mockBlockState.EXPECT().GetHighestFinalisedHeader().Return(&types.Header{Number: 1}, nil)
 		mockBlockState.EXPECT().GetRuntime(common.Hash{1}).Return(nil, errDummyErr)
+		mockStorageState := NewMockStorageState(ctrl)
+		mockStorageState.EXPECT().GetStateRootFromBlock(&common.Hash{1}).Return(&common.Hash{2}, nil)
+		mockStorageState.EXPECT().TrieState(&common.Hash{2}).Return(newTrieState(t, []byte{1}), nil)
 		service := &Service{
 			storageState: mockStorageState,
 			blockState:   mockBlockState,
@@ -1423,26 +1609,104 @@ func TestServiceGetMetadata(t *testing.T) {
 		execTest(t, service, nil, nil, errDummyErr, expectedErrMessage)
 	})
 
-	t.Run("happy_path", func(t *testing.T) {
+	t.Run("historical_block_without_code", func(t *testing.T) {
 		t.Parallel()
 		ctrl := gomock.NewController(t)
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().GetHeader(common.Hash{1}).Return(&types.Header{Number: 1}, nil)
+		mockBlockState.EXPECT().GetHighestFinalisedHeader().Return(&types.Header{Number: 2}, nil)
 		mockStorageState := NewMockStorageState(ctrl)
-		mockStorageState.EXPECT().TrieState(nil).Return(&rtstorage.TrieState{}, nil)
-		runtimeMockOk := NewMockInstance(ctrl)
+		mockStorageState.EXPECT().GetStateRootFromBlock(&common.Hash{1}).Return(&common.Hash{2}, nil)
+		mockStorageState.EXPECT().TrieState(&common.Hash{2}).Return(newTrieState(t, nil), nil)
+		service := &Service{
+			storageState: mockStorageState,
+			blockState:   mockBlockState,
+		}
+		const expectedErrMessage = "setting up runtime: new :code is empty: for block " +
+			"0x0100000000000000000000000000000000000000000000000000000000000000"
+		execTest(t, service, &common.Hash{1}, nil, ErrEmptyRuntimeCode, expectedErrMessage)
+	})
+
+	t.Run("happy_path", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		trieState := newTrieState(t, []byte{1})
 		mockBlockState := NewMockBlockState(ctrl)
-		mockBlockState.EXPECT().BestBlockHash().Return(common.Hash{1})
+		mockBlockState.EXPECT().BestBlockHash().Return(common.Hash{1}).Times(2)
+		mockBlockState.EXPECT().GetHeader(common.Hash{1}).Return(&types.Header{Number: 2}, nil)
+		mockBlockState.EXPECT().GetHighestFinalisedHeader().Return(&types.Header{Number: 1}, nil)
+		runtimeMockOk := NewMockInstance(ctrl)
 		mockBlockState.EXPECT().GetRuntime(common.Hash{1}).Return(runtimeMockOk, nil)
-		runtimeMockOk.EXPECT().SetContextStorage(&rtstorage.TrieState{})
+		runtimeMockOk.EXPECT().SetContextStorage(trieState)
 		runtimeMockOk.EXPECT().Metadata().Return([]byte{1, 2, 3}, nil)
+		mockStorageState := NewMockStorageState(ctrl)
+		mockStorageState.EXPECT().GetStateRootFromBlock(&common.Hash{1}).Return(&common.Hash{2}, nil).Times(2)
+		mockStorageState.EXPECT().TrieState(&common.Hash{2}).Return(trieState, nil).Times(2)
 		service := &Service{
 			storageState: mockStorageState,
 			blockState:   mockBlockState,
 		}
-		const expectedErrMessage = "setting up runtime: getting state root from block hash: dummy error for testing"
-		execTest(t, service, nil, []byte{1, 2, 3}, nil, expectedErrMessage)
+		execTest(t, service, nil, []byte{1, 2, 3}, nil, "")
+
+		// the metadata is cached for the runtime code
+		execTest(t, service, nil, []byte{1, 2, 3}, nil, "")
 	})
 }
 
+func TestServiceGetMetadataAtVersion(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	trieState := rtstorage.NewTrieState(inmemory_trie.NewEmptyTrie())
+	err := trieState.Put(common.CodeKey, []byte{1})
+	require.NoError(t, err)
+
+	mockBlockState := NewMockBlockState(ctrl)
+	mockBlockState.EXPECT().GetHeader(common.Hash{1}).Return(&types.Header{Number: 2}, nil).Times(2)
+	mockBlockState.EXPECT().GetHighestFinalisedHeader().Return(&types.Header{Number: 2}, nil).Times(2)
+	runtimeMock := NewMockInstance(ctrl)
+	mockBlockState.EXPECT().GetRuntime(common.Hash{1}).Return(runtimeMock, nil).Times(2)
+	runtimeMock.EXPECT().SetContextStorage(trieState).Times(2)
+	runtimeMock.EXPECT().MetadataAtVersion(uint32(15)).Return([]byte{15}, nil)
+	runtimeMock.EXPECT().MetadataAtVersion(uint32(16)).
+		Return(nil, runtime.ErrMetadataVersionNotSupported)
+	mockStorageState := NewMockStorageState(ctrl)
+	mockStorageState.EXPECT().GetStateRootFromBlock(&common.Hash{1}).Return(&common.Hash{2}, nil).Times(2)
+	mockStorageState.EXPECT().TrieState(&common.Hash{2}).Return(trieState, nil).Times(2)
+	service := &Service{
+		storageState: mockStorageState,
+		blockState:   mockBlockState,
+	}
+
+	metadata, err := service.GetMetadataAtVersion(&common.Hash{1}, 15)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{15}, metadata)
+
+	metadata, err = service.GetMetadataAtVersion(&common.Hash{1}, 16)
+	assert.ErrorIs(t, err, runtime.ErrMetadataVersionNotSupported)
+	assert.Nil(t, metadata)
+}
+
+func Test_metadataCache(t *testing.T) {
+	t.Parallel()
+
+	var cache metadataCache
+	for i := 0; i <= metadataCacheCapacity; i++ {
+		cache.put(metadataKey{codeHash: common.Hash{byte(i)}}, []byte{byte(i)})
+	}
+
+	_, ok := cache.get(metadataKey{codeHash: common.Hash{0}})
+	assert.False(t, ok)
+
+	metadata, ok := cache.get(metadataKey{codeHash: common.Hash{1}})
+	assert.True(t, ok)
+	assert.Equal(t, []byte{1}, metadata)
+
+	_, ok = cache.get(metadataKey{codeHash: common.Hash{1}, version: 15})
+	assert.False(t, ok)
+	assert.Len(t, cache.metadata, metadataCacheCapacity)
+}
+
 func TestService_GetReadProofAt(t *testing.T) {
 	t.Parallel()
 	execTest := func(t *testing.T, s *Service, block common.Hash, keys [][]byte,
@@ -1500,3 +1764,368 @@ func TestService_GetReadProofAt(t *testing.T) {
 		execTest(t, service, common.Hash{}, [][]byte{{1}}, common.Hash{2}, [][]byte{{2}}, nil)
 	})
 }
+
+func TestService_GetBlockWitness(t *testing.T) {
+	t.Parallel()
+
+	parentHeader := &types.Header{
+		Number:    1,
+		StateRoot: common.Hash{3},
+	}
+	header := &types.Header{
+		ParentHash: parentHeader.Hash(),
+		Number:     2,
+	}
+	blockHash := header.Hash()
+	body := &types.Body{{1, 2, 3}}
+
+	t.Run("get_header_error", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().GetHeader(blockHash).Return(nil, errDummyErr)
+		service := &Service{
+			blockState: mockBlockState,
+		}
+
+		_, witness, err := service.GetBlockWitness(blockHash)
+		assert.ErrorIs(t, err, errDummyErr)
+		assert.EqualError(t, err, "getting header: dummy error for testing")
+		assert.Nil(t, witness)
+	})
+
+	t.Run("execute_block_error", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().GetHeader(blockHash).Return(header, nil)
+		mockBlockState.EXPECT().GetBlockBody(blockHash).Return(body, nil)
+		mockBlockState.EXPECT().GetHeader(header.ParentHash).Return(parentHeader, nil)
+		trieState := rtstorage.NewTrieState(inmemory_trie.NewEmptyTrie())
+		mockStorageState := NewMockStorageState(ctrl)
+		mockStorageState.EXPECT().Lock()
+		mockStorageState.EXPECT().TrieState(&parentHeader.StateRoot).Return(trieState, nil)
+		mockStorageState.EXPECT().Unlock()
+		runtimeMock := NewMockInstance(ctrl)
+		mockBlockState.EXPECT().GetRuntime(header.ParentHash).Return(runtimeMock, nil)
+		runtimeMock.EXPECT().SetContextStorage(trieState)
+		runtimeMock.EXPECT().ExecuteBlock(&types.Block{Header: *header, Body: *body}).
+			Return(nil, errDummyErr)
+		service := &Service{
+			blockState:   mockBlockState,
+			storageState: mockStorageState,
+		}
+
+		_, witness, err := service.GetBlockWitness(blockHash)
+		assert.ErrorIs(t, err, errDummyErr)
+		assert.EqualError(t, err, "executing block 2: dummy error for testing")
+		assert.Nil(t, witness)
+	})
+
+	t.Run("happy_path", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().GetHeader(blockHash).Return(header, nil)
+		mockBlockState.EXPECT().GetBlockBody(blockHash).Return(body, nil)
+		mockBlockState.EXPECT().GetHeader(header.ParentHash).Return(parentHeader, nil)
+		trieState := rtstorage.NewTrieState(inmemory_trie.NewEmptyTrie())
+		mockStorageState := NewMockStorageState(ctrl)
+		mockStorageState.EXPECT().Lock()
+		mockStorageState.EXPECT().TrieState(&parentHeader.StateRoot).Return(trieState, nil)
+		mockStorageState.EXPECT().Unlock()
+		runtimeMock := NewMockInstance(ctrl)
+		mockBlockState.EXPECT().GetRuntime(header.ParentHash).Return(runtimeMock, nil)
+		runtimeMock.EXPECT().SetContextStorage(trieState)
+		runtimeMock.EXPECT().ExecuteBlock(&types.Block{Header: *header, Body: *body}).
+			DoAndReturn(func(*types.Block) ([]byte, error) {
+				trieState.Get([]byte("read"))
+				err := trieState.Put([]byte("written"), []byte{1})
+				if err != nil {
+					return nil, err
+				}
+				err = trieState.SetChildStorage([]byte("child"), []byte("child_written"), []byte{1})
+				return nil, err
+			})
+		mockStorageState.EXPECT().GenerateWitness(parentHeader.StateRoot,
+			[][]byte{[]byte(":child_storage:default:child"), []byte("read"), []byte("written")},
+			map[string][][]byte{"child": {[]byte("child_written")}}).
+			Return([][]byte{{4}}, nil)
+		service := &Service{
+			blockState:   mockBlockState,
+			storageState: mockStorageState,
+		}
+
+		parentStateRoot, witness, err := service.GetBlockWitness(blockHash)
+		require.NoError(t, err)
+		assert.Equal(t, parentHeader.StateRoot, parentStateRoot)
+		assert.Equal(t, [][]byte{{4}}, witness)
+
+		// the witness is cached, so the block is not executed again
+		parentStateRoot, witness, err = service.GetBlockWitness(blockHash)
+		require.NoError(t, err)
+		assert.Equal(t, parentHeader.StateRoot, parentStateRoot)
+		assert.Equal(t, [][]byte{{4}}, witness)
+	})
+}
+
+func TestService_GetBlockStats(t *testing.T) {
+	t.Parallel()
+
+	parentHeader := &types.Header{
+		Number:    1,
+		StateRoot: common.Hash{3},
+	}
+	header := &types.Header{
+		ParentHash: parentHeader.Hash(),
+		Number:     2,
+	}
+	blockHash := header.Hash()
+	body := &types.Body{{1, 2, 3}, {4}}
+	block := &types.Block{Header: *header, Body: *body}
+
+	t.Run("get_body_error", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().GetHeader(blockHash).Return(header, nil)
+		mockBlockState.EXPECT().GetBlockBody(blockHash).Return(nil, errDummyErr)
+		service := &Service{
+			blockState: mockBlockState,
+		}
+
+		stats, err := service.GetBlockStats(blockHash)
+		assert.ErrorIs(t, err, errDummyErr)
+		assert.EqualError(t, err, "getting block body: dummy error for testing")
+		assert.Nil(t, stats)
+	})
+
+	t.Run("cached_witness", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().GetHeader(blockHash).Return(header, nil)
+		mockBlockState.EXPECT().GetBlockBody(blockHash).Return(body, nil)
+		service := &Service{
+			blockState: mockBlockState,
+		}
+		service.blockExecutions.put(blockHash, &blockExecution{
+			witness:         [][]byte{{1, 2}, {3}},
+			parentStateRoot: parentHeader.StateRoot,
+		})
+
+		stats, err := service.GetBlockStats(blockHash)
+		require.NoError(t, err)
+		expected := &BlockStats{
+			WitnessLen:    3,
+			BlockLen:      uint64(len(scale.MustMarshal(*block))),
+			NumExtrinsics: 2,
+		}
+		assert.Equal(t, expected, stats)
+	})
+
+	t.Run("imported_block_executed_again", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().GetHeader(blockHash).Return(header, nil)
+		mockBlockState.EXPECT().GetBlockBody(blockHash).Return(body, nil)
+		mockBlockState.EXPECT().GetHeader(header.ParentHash).Return(parentHeader, nil)
+		trieState := rtstorage.NewTrieState(inmemory_trie.NewEmptyTrie())
+		mockStorageState := NewMockStorageState(ctrl)
+		mockStorageState.EXPECT().Lock()
+		mockStorageState.EXPECT().TrieState(&parentHeader.StateRoot).Return(trieState, nil)
+		mockStorageState.EXPECT().Unlock()
+		runtimeMock := NewMockInstance(ctrl)
+		mockBlockState.EXPECT().GetRuntime(header.ParentHash).Return(runtimeMock, nil)
+		runtimeMock.EXPECT().SetContextStorage(trieState)
+		runtimeMock.EXPECT().ExecuteBlock(block).
+			DoAndReturn(func(*types.Block) ([]byte, error) {
+				return nil, trieState.Put(common.SystemEventsKey, []byte{0})
+			})
+		mockStorageState.EXPECT().GenerateWitness(parentHeader.StateRoot,
+			[][]byte{common.SystemEventsKey}, map[string][][]byte{}).
+			Return([][]byte{{4, 5, 6, 7}}, nil)
+		service := &Service{
+			blockState:   mockBlockState,
+			storageState: mockStorageState,
+		}
+		// the blocks imported are cached without their witness
+		service.blockExecutions.put(blockHash, &blockExecution{events: []byte{0}})
+
+		stats, err := service.GetBlockStats(blockHash)
+		require.NoError(t, err)
+		expected := &BlockStats{
+			WitnessLen:    4,
+			BlockLen:      uint64(len(scale.MustMarshal(*block))),
+			NumExtrinsics: 2,
+		}
+		assert.Equal(t, expected, stats)
+
+		events, ok := service.CachedBlockEvents(blockHash)
+		assert.True(t, ok)
+		assert.Equal(t, []byte{0}, events)
+	})
+}
+
+func TestService_CachedBlockEvents(t *testing.T) {
+	t.Parallel()
+
+	trieState := rtstorage.NewTrieState(inmemory_trie.NewEmptyTrie())
+	require.NoError(t, trieState.Put(common.SystemEventsKey, []byte{1, 2}))
+
+	service := &Service{}
+	service.blockExecutions.put(common.Hash{1}, newBlockExecution(trieState))
+
+	events, ok := service.CachedBlockEvents(common.Hash{1})
+	assert.True(t, ok)
+	assert.Equal(t, []byte{1, 2}, events)
+
+	_, ok = service.CachedBlockEvents(common.Hash{2})
+	assert.False(t, ok)
+}
+
+func Test_blockExecutionCache(t *testing.T) {
+	t.Parallel()
+
+	var cache blockExecutionCache
+	for i := 0; i <= blockExecutionCacheCapacity; i++ {
+		cache.put(common.Hash{byte(i)}, &blockExecution{events: []byte{byte(i)}})
+	}
+
+	_, ok := cache.get(common.Hash{0})
+	assert.False(t, ok)
+
+	execution, ok := cache.get(common.Hash{1})
+	assert.True(t, ok)
+	assert.Equal(t, &blockExecution{events: []byte{1}}, execution)
+
+	// the execution witnessed replaces the execution cached
+	witnessed := &blockExecution{events: []byte{1}, witness: [][]byte{{1}}}
+	cache.put(common.Hash{1}, witnessed)
+	execution, ok = cache.get(common.Hash{1})
+	assert.True(t, ok)
+	assert.Same(t, witnessed, execution)
+	assert.Len(t, cache.executions, blockExecutionCacheCapacity)
+	assert.Len(t, cache.hashes, blockExecutionCacheCapacity)
+}
+
+func TestService_HandleHeaderImport(t *testing.T) {
+	t.Parallel()
+
+	genesisHash := common.Hash{1}
+	header := &types.Header{
+		ParentHash: genesisHash,
+		Number:     1,
+	}
+	// the header hash is cached before copying the header, so that the headers
+	// shared by the parallel subtests are not modified when they are hashed
+	header.Hash()
+	block := &types.Block{Header: *header, Body: types.Body{}}
+
+	t.Run("add_block_error", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().GenesisHash().Return(genesisHash)
+		mockBlockState.EXPECT().AddBlock(block).Return(errDummyErr)
+		service := &Service{
+			blockState: mockBlockState,
+		}
+
+		err := service.HandleHeaderImport(header)
+		assert.ErrorIs(t, err, errDummyErr)
+		assert.EqualError(t, err, "adding block: dummy error for testing")
+	})
+
+	t.Run("block_exists", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().GenesisHash().Return(genesisHash)
+		mockBlockState.EXPECT().AddBlock(block).Return(blocktree.ErrBlockExists)
+		mockDigestHandler := NewMockBlockImportDigestHandler(ctrl)
+		mockDigestHandler.EXPECT().HandleDigests(header).Return(nil)
+		mockGrandpaState := NewMockGrandpaState(ctrl)
+		mockGrandpaState.EXPECT().ApplyForcedChanges(header).Return(nil)
+		service := &Service{
+			blockState:    mockBlockState,
+			onBlockImport: mockDigestHandler,
+			grandpaState:  mockGrandpaState,
+		}
+
+		err := service.HandleHeaderImport(header)
+		require.NoError(t, err)
+	})
+
+	upgradeHeader := &types.Header{
+		ParentHash: genesisHash,
+		Number:     1,
+		StateRoot:  common.Hash{2},
+		Digest:     types.NewDigest(),
+	}
+	require.NoError(t, upgradeHeader.Digest.Add(types.RuntimeEnvironmentUpdated{}))
+	upgradeHash := upgradeHeader.Hash()
+	upgradeBlock := &types.Block{Header: *upgradeHeader, Body: types.Body{}}
+
+	t.Run("runtime_code_read_error", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().GenesisHash().Return(genesisHash)
+		mockBlockState.EXPECT().AddBlock(upgradeBlock).Return(nil)
+		mockDigestHandler := NewMockBlockImportDigestHandler(ctrl)
+		mockDigestHandler.EXPECT().HandleDigests(upgradeHeader).Return(nil)
+		mockGrandpaState := NewMockGrandpaState(ctrl)
+		mockGrandpaState.EXPECT().ApplyForcedChanges(upgradeHeader).Return(nil)
+		mockNetwork := NewMockNetwork(ctrl)
+		mockNetwork.EXPECT().ReadRemoteStorage(upgradeHash, upgradeHeader.StateRoot, common.CodeKey).
+			Return(nil, nil, errDummyErr)
+		service := &Service{
+			blockState:    mockBlockState,
+			onBlockImport: mockDigestHandler,
+			grandpaState:  mockGrandpaState,
+			net:           mockNetwork,
+		}
+
+		err := service.HandleHeaderImport(upgradeHeader)
+		assert.ErrorIs(t, err, errDummyErr)
+		assert.EqualError(t, err, "handling runtime environment update: "+
+			"reading runtime code: dummy error for testing")
+	})
+
+	t.Run("runtime_environment_updated", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		code := []byte{1, 2, 3}
+		parentRuntime := NewMockInstance(ctrl)
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().GenesisHash().Return(genesisHash)
+		mockBlockState.EXPECT().AddBlock(upgradeBlock).Return(nil)
+		mockBlockState.EXPECT().GetRuntime(genesisHash).Return(parentRuntime, nil)
+		mockBlockState.EXPECT().HandleRuntimeChanges(gomock.Any(), parentRuntime, upgradeHash).
+			DoAndReturn(func(codeState *rtstorage.TrieState, _ runtime.Instance, _ common.Hash) error {
+				assert.Equal(t, code, codeState.LoadCode())
+				return nil
+			})
+		mockStorageState := NewMockStorageState(ctrl)
+		mockStorageState.EXPECT().StoreTrie(gomock.Any(), upgradeHeader).Return(nil)
+		mockDigestHandler := NewMockBlockImportDigestHandler(ctrl)
+		mockDigestHandler.EXPECT().HandleDigests(upgradeHeader).Return(nil)
+		mockGrandpaState := NewMockGrandpaState(ctrl)
+		mockGrandpaState.EXPECT().ApplyForcedChanges(upgradeHeader).Return(nil)
+		mockNetwork := NewMockNetwork(ctrl)
+		mockNetwork.EXPECT().ReadRemoteStorage(upgradeHash, upgradeHeader.StateRoot, common.CodeKey).
+			Return(code, [][]byte{{1}}, nil)
+		service := &Service{
+			blockState:    mockBlockState,
+			storageState:  mockStorageState,
+			onBlockImport: mockDigestHandler,
+			grandpaState:  mockGrandpaState,
+			net:           mockNetwork,
+		}
+
+		err := service.HandleHeaderImport(upgradeHeader)
+		require.NoError(t, err)
+	})
+}
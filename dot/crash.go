@@ -0,0 +1,149 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/telemetry"
+	"github.com/ChainSafe/gossamer/lib/services"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// maxCrashRestarts is the number of times a service is restarted after a panic before the
+// node is shut down instead
+const maxCrashRestarts = 3
+
+const (
+	crashActionRestart  = "restart"
+	crashActionShutdown = "shutdown"
+)
+
+var servicePanics = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "gossamer_services",
+	Name:      "panics_total",
+	Help:      "number of panics recovered in the goroutines of the node services",
+}, []string{"service"})
+
+// restartableService is a service which can be restarted in-process, such as after a panic
+type restartableService interface {
+	Restart() error
+}
+
+// crashReporter handles the panics recovered in the goroutines of the node services: it
+// writes a crash report file, counts the panic in the metrics, sends it to telemetry, and
+// restarts the service if it can be restarted, or shuts down the node cleanly otherwise.
+type crashReporter struct {
+	dir       string
+	telemetry Telemetry
+	// services are the services which can be restarted, by name
+	services map[string]restartableService
+	// shutdown receives the reason of the node shutdown
+	shutdown chan<- string
+	now      func() time.Time
+
+	mutex sync.Mutex
+	// restarts are the numbers of restarts of the services after a panic, by name
+	restarts map[string]int
+}
+
+// newCrashReporter returns a crash reporter writing the crash reports to the directory
+// given, restarting the services given which can be restarted and sending the reason of
+// the node shutdown on the channel given otherwise.
+func newCrashReporter(dir string, telemetryMailer Telemetry, shutdown chan<- string,
+	srvcs []service) *crashReporter {
+	restartable := make(map[string]restartableService)
+	for _, srvc := range srvcs {
+		if r, ok := srvc.(restartableService); ok {
+			restartable[services.Name(srvc)] = r
+		}
+	}
+
+	return &crashReporter{
+		dir:       dir,
+		telemetry: telemetryMailer,
+		services:  restartable,
+		shutdown:  shutdown,
+		now:       time.Now,
+		restarts:  make(map[string]int),
+	}
+}
+
+// HandleCrash reports the panic recovered in a goroutine of the service of the name given,
+// and restarts the service or shuts down the node.
+func (c *crashReporter) HandleCrash(name string, recovered any, stack []byte) {
+	logger.Criticalf("service %s panicked: %v\n%s", name, recovered, stack)
+	servicePanics.WithLabelValues(name).Inc()
+
+	c.mutex.Lock()
+	srvc, restartable := c.services[name]
+	restart := restartable && c.restarts[name] < maxCrashRestarts
+	if restart {
+		c.restarts[name]++
+	}
+	c.mutex.Unlock()
+
+	action := crashActionShutdown
+	if restart {
+		action = crashActionRestart
+	}
+
+	path, err := c.writeReport(name, recovered, stack, action)
+	if err != nil {
+		logger.Errorf("cannot write crash report: %s", err)
+	} else {
+		logger.Criticalf("crash report of %s written to %s", name, path)
+	}
+	c.telemetry.SendMessage(telemetry.NewSystemServiceCrash(name, fmt.Sprint(recovered), action))
+
+	if !restart {
+		c.shutdownNode(fmt.Sprintf("service %s panicked", name))
+		return
+	}
+
+	// the service is restarted once the goroutine which panicked returned
+	go func() {
+		err := srvc.Restart()
+		if err != nil {
+			logger.Criticalf("cannot restart %s after panic: %s", name, err)
+			c.shutdownNode(fmt.Sprintf("service %s could not be restarted after a panic", name))
+			return
+		}
+		logger.Infof("restarted %s after panic", name)
+	}()
+}
+
+// shutdownNode requests the shutdown of the node for the reason given, unless a shutdown
+// was already requested.
+func (c *crashReporter) shutdownNode(reason string) {
+	select {
+	case c.shutdown <- reason:
+	default:
+	}
+}
+
+// writeReport writes the crash report of the panic given to a new file of the crash
+// reports directory, and returns the path of the file.
+func (c *crashReporter) writeReport(name string, recovered any, stack []byte, action string) (
+	path string, err error) {
+	err = os.MkdirAll(c.dir, os.ModePerm)
+	if err != nil {
+		return "", fmt.Errorf("creating crash reports directory: %w", err)
+	}
+
+	now := c.now()
+	path = filepath.Join(c.dir, fmt.Sprintf("%s-%s.txt", now.UTC().Format("20060102T150405.000000000Z"), name))
+	report := fmt.Sprintf("service: %s\ntime: %s\naction: %s\npanic: %v\n\n%s",
+		name, now.Format(time.RFC3339Nano), action, recovered, stack)
+	err = os.WriteFile(path, []byte(report), 0o600)
+	if err != nil {
+		return "", fmt.Errorf("writing crash report: %w", err)
+	}
+	return path, nil
+}
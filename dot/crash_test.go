@@ -0,0 +1,116 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/telemetry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// restartService is a service sending on its channel the error returned by each restart
+type restartService struct {
+	err       error
+	restarted chan error
+}
+
+func (*restartService) Start() error { return nil }
+func (*restartService) Stop() error  { return nil }
+
+func (s *restartService) Restart() error {
+	s.restarted <- s.err
+	return s.err
+}
+
+func Test_crashReporter_HandleCrash(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		restartErr       error
+		previousRestarts int
+		expectedAction   string
+		expectedShutdown string
+	}{
+		"restart": {
+			expectedAction: crashActionRestart,
+		},
+		"restart_error": {
+			restartErr:       errors.New("test error"),
+			expectedAction:   crashActionRestart,
+			expectedShutdown: "service dot.restartService could not be restarted after a panic",
+		},
+		"too_many_restarts": {
+			previousRestarts: maxCrashRestarts,
+			expectedAction:   crashActionShutdown,
+			expectedShutdown: "service dot.restartService panicked",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			dir := filepath.Join(t.TempDir(), "crash-reports")
+			telemetryMailer := NewMockTelemetry(ctrl)
+			telemetryMailer.EXPECT().SendMessage(
+				telemetry.NewSystemServiceCrash("dot.restartService", "test panic", testCase.expectedAction))
+			shutdown := make(chan string, 1)
+			srvc := &restartService{err: testCase.restartErr, restarted: make(chan error, 1)}
+			reporter := newCrashReporter(dir, telemetryMailer, shutdown, []service{srvc})
+			reporter.now = func() time.Time { return time.Unix(1_000_000, 0) }
+			reporter.restarts["dot.restartService"] = testCase.previousRestarts
+
+			reporter.HandleCrash("dot.restartService", "test panic", []byte("test stack"))
+
+			if testCase.expectedAction == crashActionRestart {
+				<-srvc.restarted
+			}
+			if testCase.expectedShutdown != "" {
+				assert.Equal(t, testCase.expectedShutdown, <-shutdown)
+			} else {
+				assert.Empty(t, shutdown)
+			}
+
+			entries, err := os.ReadDir(dir)
+			require.NoError(t, err)
+			require.Len(t, entries, 1)
+			report, err := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+			require.NoError(t, err)
+			assert.Contains(t, string(report), "service: dot.restartService\n")
+			assert.Contains(t, string(report), "action: "+testCase.expectedAction+"\n")
+			assert.Contains(t, string(report), "panic: test panic\n")
+			assert.Contains(t, string(report), "test stack")
+		})
+	}
+}
+
+func Test_crashReporter_HandleCrash_notRestartable(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	telemetryMailer := NewMockTelemetry(ctrl)
+	telemetryMailer.EXPECT().SendMessage(
+		telemetry.NewSystemServiceCrash("state.Service", "test panic", crashActionShutdown))
+	shutdown := make(chan string, 1)
+	reporter := newCrashReporter(t.TempDir(), telemetryMailer, shutdown, nil)
+
+	reporter.HandleCrash("state.Service", "test panic", nil)
+	assert.Equal(t, "service state.Service panicked", <-shutdown)
+
+	// a single shutdown is requested
+	telemetryMailer.EXPECT().SendMessage(
+		telemetry.NewSystemServiceCrash("state.Service", "test panic", crashActionShutdown))
+	shutdown <- "previous shutdown"
+	reporter.HandleCrash("state.Service", "test panic", nil)
+	assert.Equal(t, "previous shutdown", <-shutdown)
+}
@@ -10,21 +10,22 @@
 	"github.com/ChainSafe/gossamer/pkg/scale"
 )
 
+// BlockImportHandler dispatches the consensus digests of the imported blocks to the
+// engine subscribers of the registry.
 type BlockImportHandler struct {
-	epochState   EpochState
-	grandpaState GrandpaState
+	registry *EngineRegistry
 }
 
-func NewBlockImportHandler(epochState EpochState, grandpaState GrandpaState) *BlockImportHandler {
+// NewBlockImportHandler returns a new BlockImportHandler
+func NewBlockImportHandler(registry *EngineRegistry) *BlockImportHandler {
 	return &BlockImportHandler{
-		epochState:   epochState,
-		grandpaState: grandpaState,
+		registry: registry,
 	}
 }
 
 // HandleDigests handles consensus digests for an imported block
 func (h *BlockImportHandler) HandleDigests(header *types.Header) error {
-	consensusDigests := toConsensusDigests(header.Digest)
+	consensusDigests := toConsensusDigests(header.Digest, h.registry)
 	consensusDigests, err := checkForGRANDPAForcedChanges(consensusDigests)
 	if err != nil {
 		return fmt.Errorf("failed while checking GRANDPA digests: %w", err)
@@ -45,38 +46,12 @@ func (h *BlockImportHandler) HandleDigests(header *types.Header) error {
 }
 
 func (h *BlockImportHandler) handleConsensusDigest(d *types.ConsensusDigest, header *types.Header) error {
-	switch d.ConsensusEngineID {
-	case types.GrandpaEngineID:
-		data := types.NewGrandpaConsensusDigest()
-		err := scale.Unmarshal(d.Data, &data)
-		if err != nil {
-			return fmt.Errorf("unmarshaling grandpa consensus digest: %w", err)
-		}
-
-		err = h.grandpaState.HandleGRANDPADigest(header, data)
-		if err != nil {
-			return fmt.Errorf("handling grandpa digest: %w", err)
-		}
-	case types.BabeEngineID:
-		data := types.NewBabeConsensusDigest()
-		err := scale.Unmarshal(d.Data, &data)
-		if err != nil {
-			return fmt.Errorf("unmarshaling babe consensus digest: %w", err)
-		}
-
-		err = h.epochState.HandleBABEDigest(header, data)
-		if err != nil {
-			return fmt.Errorf("handling babe digest: %w", err)
-		}
-	default:
-		return fmt.Errorf("%w: 0x%x", ErrUnknownConsensusEngineID, d.ConsensusEngineID.ToBytes())
-	}
-
-	return nil
+	return h.registry.handleDigest(header, *d)
 }
 
-// toConsensusDigests converts a slice of scale.VaryingDataType to a slice of types.ConsensusDigest.
-func toConsensusDigests(scaleVaryingTypes types.Digest) []types.ConsensusDigest {
+// toConsensusDigests converts a slice of scale.VaryingDataType to a slice of types.ConsensusDigest,
+// keeping only the consensus digests of the engines registered in the registry.
+func toConsensusDigests(scaleVaryingTypes types.Digest, registry *EngineRegistry) []types.ConsensusDigest {
 	consensusDigests := make([]types.ConsensusDigest, 0, len(scaleVaryingTypes))
 
 	for _, d := range scaleVaryingTypes {
@@ -90,8 +65,7 @@ func toConsensusDigests(scaleVaryingTypes types.Digest) []types.ConsensusDigest
 			continue
 		}
 
-		switch digest.ConsensusEngineID {
-		case types.GrandpaEngineID, types.BabeEngineID:
+		if registry.IsRegistered(digest.ConsensusEngineID) {
 			consensusDigests = append(consensusDigests, digest)
 		}
 	}
@@ -278,7 +278,8 @@ func TestBlockImportHandle(t *testing.T) {
 			epochStateMock := tt.setupEpochState(t, ctrl, importedHeader, consensusDigests[:2])
 			grandpaStateMock := tt.setupGrandpaState(t, ctrl, importedHeader, consensusDigests[2:])
 
-			onBlockImportDigestHandler := NewBlockImportHandler(epochStateMock, grandpaStateMock)
+			registry := NewDefaultEngineRegistry(epochStateMock, grandpaStateMock)
+			onBlockImportDigestHandler := NewBlockImportHandler(registry)
 			err := onBlockImportDigestHandler.HandleDigests(importedHeader)
 			require.ErrorIs(t, err, tt.wantErr)
 			if tt.errString != "" {
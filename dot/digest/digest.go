@@ -9,6 +9,7 @@
 
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/services"
 )
 
 var logger = log.NewFromGlobal(log.AddContext("pkg", "digest"))
@@ -17,41 +18,41 @@
 	ErrUnknownConsensusEngineID = errors.New("unknown consensus engine ID")
 )
 
-// Handler is used to handle consensus messages and relevant authority updates to BABE and GRANDPA
+// Handler notifies the consensus engines registered in its EngineRegistry of the finalised blocks
 type Handler struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 
 	// interfaces
-	blockState   BlockState
-	epochState   EpochState
-	grandpaState GrandpaState
+	blockState BlockState
+	registry   *EngineRegistry
 
 	// block notification channels
 	imported  chan *types.Block
 	finalised chan *types.FinalisationInfo
 }
 
-// NewHandler returns a new Handler
-func NewHandler(blockState BlockState, epochState EpochState, grandpaState GrandpaState) (*Handler, error) {
+// NewHandler returns a new Handler notifying the subscribers of the registry of the finalised blocks
+func NewHandler(blockState BlockState, registry *EngineRegistry) (*Handler, error) {
 	imported := blockState.GetImportedBlockNotifierChannel()
 	finalised := blockState.GetFinalisedNotifierChannel()
 
 	ctx, cancel := context.WithCancel(context.Background())
 	return &Handler{
-		ctx:          ctx,
-		cancel:       cancel,
-		blockState:   blockState,
-		epochState:   epochState,
-		grandpaState: grandpaState,
-		imported:     imported,
-		finalised:    finalised,
+		ctx:        ctx,
+		cancel:     cancel,
+		blockState: blockState,
+		registry:   registry,
+		imported:   imported,
+		finalised:  finalised,
 	}, nil
 }
 
 // Start starts the Handler
 func (h *Handler) Start() error {
-	go h.handleBlockFinalisation(h.ctx)
+	services.Go(h, func() {
+		h.handleBlockFinalisation(h.ctx)
+	})
 	return nil
 }
 
@@ -71,20 +72,7 @@ func (h *Handler) handleBlockFinalisation(ctx context.Context) {
 				continue
 			}
 
-			err := h.epochState.FinalizeBABENextEpochData(&info.Header)
-			if err != nil {
-				logger.Errorf("failed to persist babe next epoch data: %s", err)
-			}
-
-			err = h.epochState.FinalizeBABENextConfigData(&info.Header)
-			if err != nil {
-				logger.Errorf("failed to persist babe next epoch config: %s", err)
-			}
-
-			err = h.grandpaState.ApplyScheduledChanges(&info.Header)
-			if err != nil {
-				logger.Errorf("failed to apply scheduled change: %s", err)
-			}
+			h.registry.handleFinalisation(&info.Header)
 		case <-ctx.Done():
 			return
 		}
@@ -49,15 +49,16 @@ func newTestHandler(t *testing.T) (*Handler, *BlockImportHandler, *state.Service
 	err = stateSrvc.Start()
 	require.NoError(t, err)
 
-	dh, err := NewHandler(stateSrvc.Block, stateSrvc.Epoch, stateSrvc.Grandpa)
+	registry := NewDefaultEngineRegistry(stateSrvc.Epoch, stateSrvc.Grandpa)
+	dh, err := NewHandler(stateSrvc.Block, registry)
 	require.NoError(t, err)
 
-	blockImportHandler := NewBlockImportHandler(stateSrvc.Epoch, stateSrvc.Grandpa)
+	blockImportHandler := NewBlockImportHandler(registry)
 	return dh, blockImportHandler, stateSrvc
 }
 
 func TestHandler_GrandpaScheduledChange(t *testing.T) {
-	handler, blockImportHandler, _ := newTestHandler(t)
+	handler, blockImportHandler, stateSrvc := newTestHandler(t)
 	handler.Start()
 	defer handler.Stop()
 
@@ -99,17 +100,109 @@ func TestHandler_GrandpaScheduledChange(t *testing.T) {
 	require.NoError(t, err)
 
 	time.Sleep(time.Millisecond * 500)
-	setID, err := handler.grandpaState.(*state.GrandpaState).GetCurrentSetID()
+	setID, err := stateSrvc.Grandpa.GetCurrentSetID()
 	require.NoError(t, err)
 	require.Equal(t, uint64(1), setID)
 
-	auths, err := handler.grandpaState.(*state.GrandpaState).GetAuthorities(setID)
+	auths, err := stateSrvc.Grandpa.GetAuthorities(setID)
 	require.NoError(t, err)
 	expected, err := types.NewGrandpaVotersFromAuthoritiesRaw(sc.Auths)
 	require.NoError(t, err)
 	require.Equal(t, expected, auths)
 }
 
+func TestHandler_GrandpaForcedChangeOnNonBestFork(t *testing.T) {
+	_, blockImportHandler, stateSrvc := newTestHandler(t)
+
+	/*
+	* 1 -> 2 -> 3 -> 4 (best chain)
+	*  \ -> 2' -> 3' -> 4' -> 5' (fork with a forced change announced at 2')
+	 */
+	headers, _ := state.AddBlocksToState(t, stateSrvc.Block, 4, false)
+
+	kr, err := keystore.NewEd25519Keyring()
+	require.NoError(t, err)
+
+	fc := types.GrandpaForcedChange{
+		Auths: []types.GrandpaAuthoritiesRaw{
+			{Key: kr.Bob().Public().(*ed25519.PublicKey).AsBytes(), ID: 0},
+		},
+		Delay:              1,
+		BestFinalizedBlock: 1,
+	}
+
+	grandpaDigest := types.NewGrandpaConsensusDigest()
+	err = grandpaDigest.SetValue(fc)
+	require.NoError(t, err)
+
+	data, err := scale.Marshal(grandpaDigest)
+	require.NoError(t, err)
+
+	// the fork blocks need a BABE pre-digest to be added to the block state
+	newForkDigest := func(number uint) types.Digest {
+		t.Helper()
+		preDigest, err := types.NewBabePrimaryPreDigest(0, uint64(100+number), [32]byte{}, [64]byte{}).
+			ToPreRuntimeDigest()
+		require.NoError(t, err)
+
+		digest := types.NewDigest()
+		err = digest.Add(*preDigest)
+		require.NoError(t, err)
+		return digest
+	}
+
+	digest := newForkDigest(2)
+	err = digest.Add(types.ConsensusDigest{
+		ConsensusEngineID: types.GrandpaEngineID,
+		Data:              data,
+	})
+	require.NoError(t, err)
+
+	fork := []*types.Header{state.AddBlockToState(t, stateSrvc.Block, 2, digest, headers[0].Hash())}
+	err = blockImportHandler.HandleDigests(fork[0])
+	require.NoError(t, err)
+
+	importForkBlock := func() {
+		t.Helper()
+		parent := fork[len(fork)-1]
+		header := state.AddBlockToState(t, stateSrvc.Block, parent.Number+1,
+			newForkDigest(parent.Number+1), parent.Hash())
+		fork = append(fork, header)
+
+		err := blockImportHandler.HandleDigests(header)
+		require.NoError(t, err)
+		err = stateSrvc.Grandpa.ApplyForcedChanges(header)
+		require.NoError(t, err)
+	}
+
+	// the fork reaches the effective block 3' of the forced change but is not the best chain
+	importForkBlock()
+	require.NotEqual(t, fork[1].Hash(), stateSrvc.Block.BestBlockHash())
+
+	setID, err := stateSrvc.Grandpa.GetCurrentSetID()
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), setID)
+
+	// the fork becomes the best chain past the effective block
+	importForkBlock()
+	importForkBlock()
+	require.Equal(t, fork[3].Hash(), stateSrvc.Block.BestBlockHash())
+
+	setID, err = stateSrvc.Grandpa.GetCurrentSetID()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), setID)
+
+	auths, err := stateSrvc.Grandpa.GetAuthorities(setID)
+	require.NoError(t, err)
+	expected, err := types.NewGrandpaVotersFromAuthoritiesRaw(fc.Auths)
+	require.NoError(t, err)
+	require.Equal(t, expected, auths)
+
+	changeNumber, err := stateSrvc.Grandpa.GetSetIDChange(setID)
+	require.NoError(t, err)
+	require.Equal(t, uint(fc.BestFinalizedBlock), changeNumber)
+}
+
 func TestMultipleGRANDPADigests_ShouldIncludeJustForcedChanges(t *testing.T) {
 	tests := map[string]struct {
 		digestsTypes    []any
@@ -186,7 +279,6 @@ func TestMultipleGRANDPADigests_ShouldIncludeJustForcedChanges(t *testing.T) {
 				Digest: digests,
 			}
 
-			_, blockImportHandler, _ := newTestHandler(t)
 			ctrl := gomock.NewController(t)
 			grandpaState := NewMockGrandpaState(ctrl)
 
@@ -203,7 +295,9 @@ func TestMultipleGRANDPADigests_ShouldIncludeJustForcedChanges(t *testing.T) {
 				grandpaState.EXPECT().HandleGRANDPADigest(header, expected).Return(nil)
 			}
 
-			blockImportHandler.grandpaState = grandpaState
+			registry := NewEngineRegistry()
+			registry.Register(types.GrandpaEngineID, NewGRANDPASubscriber(grandpaState))
+			blockImportHandler := NewBlockImportHandler(registry)
 			err := blockImportHandler.HandleDigests(header)
 			require.NoError(t, err)
 		})
@@ -318,7 +412,7 @@ func TestHandler_HandleNextEpochData(t *testing.T) {
 
 	handler.handleBlockFinalisation(ctx)
 
-	stored, err := handler.epochState.(*state.EpochState).GetEpochDataRaw(targetEpoch, nil)
+	stored, err := stateSrv.Epoch.GetEpochDataRaw(targetEpoch, nil)
 	require.NoError(t, err)
 
 	digestValue, err := digest.Value()
@@ -397,7 +491,7 @@ func TestHandler_HandleNextConfigData(t *testing.T) {
 		t.Fatal()
 	}
 
-	stored, err := handler.epochState.(*state.EpochState).GetConfigData(targetEpoch, nil)
+	stored, err := stateSrv.Epoch.GetConfigData(targetEpoch, nil)
 	require.NoError(t, err)
 	require.Equal(t, decodedNextConfigDataV1.ToConfigData(), stored)
 }
@@ -0,0 +1,190 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package digest
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// EngineSubscriber is implemented by the consensus engines interested in the consensus
+// digests of an engine ID, it is called back on block import and on block finalisation.
+type EngineSubscriber interface {
+	// HandleDigest handles a consensus digest of the engine found in an imported block.
+	HandleDigest(header *types.Header, digest types.ConsensusDigest) error
+	// HandleFinalisation is called for each finalised block.
+	HandleFinalisation(finalizedHeader *types.Header) error
+}
+
+type engineSubscription struct {
+	engineID   types.ConsensusEngineID
+	subscriber EngineSubscriber
+}
+
+// EngineRegistry dispatches the consensus digests of the imported blocks and the finalised
+// blocks to the engine subscribers registered for the consensus engine IDs.
+type EngineRegistry struct {
+	mutex         sync.RWMutex
+	subscriptions []engineSubscription
+}
+
+// NewEngineRegistry returns a new EngineRegistry without any subscriber.
+func NewEngineRegistry() *EngineRegistry {
+	return &EngineRegistry{}
+}
+
+// NewDefaultEngineRegistry returns a new EngineRegistry with the BABE and GRANDPA subscribers
+// registered for their engine IDs.
+func NewDefaultEngineRegistry(epochState EpochState, grandpaState GrandpaState) *EngineRegistry {
+	registry := NewEngineRegistry()
+	registry.Register(types.BabeEngineID, NewBABESubscriber(epochState))
+	registry.Register(types.GrandpaEngineID, NewGRANDPASubscriber(grandpaState))
+	return registry
+}
+
+// Register registers the subscriber for the consensus digests of the engine ID, the
+// subscribers of an engine ID being called in their registration order.
+func (r *EngineRegistry) Register(engineID types.ConsensusEngineID, subscriber EngineSubscriber) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.subscriptions = append(r.subscriptions, engineSubscription{
+		engineID:   engineID,
+		subscriber: subscriber,
+	})
+}
+
+// IsRegistered returns true if at least one subscriber is registered for the engine ID.
+func (r *EngineRegistry) IsRegistered(engineID types.ConsensusEngineID) bool {
+	return len(r.subscribersFor(engineID)) > 0
+}
+
+func (r *EngineRegistry) subscribersFor(engineID types.ConsensusEngineID) []EngineSubscriber {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	var subscribers []EngineSubscriber
+	for _, subscription := range r.subscriptions {
+		if subscription.engineID == engineID {
+			subscribers = append(subscribers, subscription.subscriber)
+		}
+	}
+	return subscribers
+}
+
+func (r *EngineRegistry) allSubscriptions() []engineSubscription {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	subscriptions := make([]engineSubscription, len(r.subscriptions))
+	copy(subscriptions, r.subscriptions)
+	return subscriptions
+}
+
+// handleDigest dispatches the consensus digest to the subscribers of its engine ID.
+func (r *EngineRegistry) handleDigest(header *types.Header, digest types.ConsensusDigest) error {
+	subscribers := r.subscribersFor(digest.ConsensusEngineID)
+	if len(subscribers) == 0 {
+		return fmt.Errorf("%w: 0x%x", ErrUnknownConsensusEngineID, digest.ConsensusEngineID.ToBytes())
+	}
+
+	for _, subscriber := range subscribers {
+		err := subscriber.HandleDigest(header, digest)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handleFinalisation calls back all the subscribers with the finalised header, logging
+// the errors so a failing engine does not prevent the others from being notified.
+func (r *EngineRegistry) handleFinalisation(finalizedHeader *types.Header) {
+	for _, subscription := range r.allSubscriptions() {
+		err := subscription.subscriber.HandleFinalisation(finalizedHeader)
+		if err != nil {
+			logger.Errorf("failed to handle finalisation of block #%d for engine %s: %s",
+				finalizedHeader.Number, subscription.engineID, err)
+		}
+	}
+}
+
+// BABESubscriber handles the BABE consensus digests using the epoch state.
+type BABESubscriber struct {
+	epochState EpochState
+}
+
+// NewBABESubscriber returns a new BABESubscriber
+func NewBABESubscriber(epochState EpochState) *BABESubscriber {
+	return &BABESubscriber{epochState: epochState}
+}
+
+// HandleDigest handles a BABE consensus digest
+func (s *BABESubscriber) HandleDigest(header *types.Header, digest types.ConsensusDigest) error {
+	data := types.NewBabeConsensusDigest()
+	err := scale.Unmarshal(digest.Data, &data)
+	if err != nil {
+		return fmt.Errorf("unmarshaling babe consensus digest: %w", err)
+	}
+
+	err = s.epochState.HandleBABEDigest(header, data)
+	if err != nil {
+		return fmt.Errorf("handling babe digest: %w", err)
+	}
+	return nil
+}
+
+// HandleFinalisation persists the next epoch data and config data announced on the finalised chain
+func (s *BABESubscriber) HandleFinalisation(finalizedHeader *types.Header) error {
+	var epochDataErr, configDataErr error
+	err := s.epochState.FinalizeBABENextEpochData(finalizedHeader)
+	if err != nil {
+		epochDataErr = fmt.Errorf("persisting babe next epoch data: %w", err)
+	}
+
+	err = s.epochState.FinalizeBABENextConfigData(finalizedHeader)
+	if err != nil {
+		configDataErr = fmt.Errorf("persisting babe next epoch config: %w", err)
+	}
+
+	return errors.Join(epochDataErr, configDataErr)
+}
+
+// GRANDPASubscriber handles the GRANDPA consensus digests using the grandpa state.
+type GRANDPASubscriber struct {
+	grandpaState GrandpaState
+}
+
+// NewGRANDPASubscriber returns a new GRANDPASubscriber
+func NewGRANDPASubscriber(grandpaState GrandpaState) *GRANDPASubscriber {
+	return &GRANDPASubscriber{grandpaState: grandpaState}
+}
+
+// HandleDigest handles a GRANDPA consensus digest
+func (s *GRANDPASubscriber) HandleDigest(header *types.Header, digest types.ConsensusDigest) error {
+	data := types.NewGrandpaConsensusDigest()
+	err := scale.Unmarshal(digest.Data, &data)
+	if err != nil {
+		return fmt.Errorf("unmarshaling grandpa consensus digest: %w", err)
+	}
+
+	err = s.grandpaState.HandleGRANDPADigest(header, data)
+	if err != nil {
+		return fmt.Errorf("handling grandpa digest: %w", err)
+	}
+	return nil
+}
+
+// HandleFinalisation applies the scheduled changes of the finalised chain
+func (s *GRANDPASubscriber) HandleFinalisation(finalizedHeader *types.Header) error {
+	err := s.grandpaState.ApplyScheduledChanges(finalizedHeader)
+	if err != nil {
+		return fmt.Errorf("applying scheduled change: %w", err)
+	}
+	return nil
+}
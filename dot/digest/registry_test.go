@@ -0,0 +1,119 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package digest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestEngineRegistry_handleDigest(t *testing.T) {
+	t.Parallel()
+
+	header := &types.Header{Number: 1}
+	beefyDigest := types.ConsensusDigest{
+		ConsensusEngineID: types.BeefyEngineID,
+		Data:              []byte{1, 2, 3},
+	}
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		setupRegistry func(ctrl *gomock.Controller) *EngineRegistry
+		errWrapped    error
+		errMessage    string
+	}{
+		"unregistered_engine": {
+			setupRegistry: func(ctrl *gomock.Controller) *EngineRegistry {
+				registry := NewEngineRegistry()
+				registry.Register(types.BabeEngineID, NewMockEngineSubscriber(ctrl))
+				return registry
+			},
+			errWrapped: ErrUnknownConsensusEngineID,
+			errMessage: "unknown consensus engine ID: 0x42454546",
+		},
+		"subscribers_called_in_registration_order": {
+			setupRegistry: func(ctrl *gomock.Controller) *EngineRegistry {
+				first := NewMockEngineSubscriber(ctrl)
+				second := NewMockEngineSubscriber(ctrl)
+				gomock.InOrder(
+					first.EXPECT().HandleDigest(header, beefyDigest).Return(nil),
+					second.EXPECT().HandleDigest(header, beefyDigest).Return(nil),
+				)
+
+				registry := NewEngineRegistry()
+				registry.Register(types.BeefyEngineID, first)
+				registry.Register(types.BabeEngineID, NewMockEngineSubscriber(ctrl))
+				registry.Register(types.BeefyEngineID, second)
+				return registry
+			},
+		},
+		"subscriber_error": {
+			setupRegistry: func(ctrl *gomock.Controller) *EngineRegistry {
+				first := NewMockEngineSubscriber(ctrl)
+				first.EXPECT().HandleDigest(header, beefyDigest).Return(errTest)
+
+				registry := NewEngineRegistry()
+				registry.Register(types.BeefyEngineID, first)
+				registry.Register(types.BeefyEngineID, NewMockEngineSubscriber(ctrl))
+				return registry
+			},
+			errWrapped: errTest,
+			errMessage: "test error",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			registry := testCase.setupRegistry(ctrl)
+			err := registry.handleDigest(header, beefyDigest)
+			require.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+		})
+	}
+}
+
+func TestEngineRegistry_handleFinalisation(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	header := &types.Header{Number: 1}
+
+	babe := NewMockEngineSubscriber(ctrl)
+	grandpa := NewMockEngineSubscriber(ctrl)
+	gomock.InOrder(
+		babe.EXPECT().HandleFinalisation(header).Return(errors.New("test error")),
+		grandpa.EXPECT().HandleFinalisation(header).Return(nil),
+	)
+
+	registry := NewEngineRegistry()
+	registry.Register(types.BabeEngineID, babe)
+	registry.Register(types.GrandpaEngineID, grandpa)
+
+	registry.handleFinalisation(header)
+}
+
+func TestBABESubscriber_HandleFinalisation(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	header := &types.Header{Number: 1}
+	epochState := NewMockEpochState(ctrl)
+	epochState.EXPECT().FinalizeBABENextEpochData(header).Return(errors.New("epoch data error"))
+	epochState.EXPECT().FinalizeBABENextConfigData(header).Return(errors.New("config data error"))
+
+	err := NewBABESubscriber(epochState).HandleFinalisation(header)
+	assert.EqualError(t, err, "persisting babe next epoch data: epoch data error\n"+
+		"persisting babe next epoch config: config data error")
+}
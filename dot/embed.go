@@ -0,0 +1,74 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"errors"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+// ErrNodeStopped is returned when submitting an extrinsic to a node which is stopped.
+var ErrNodeStopped = errors.New("node is stopped")
+
+// FinalizedBlocks returns a channel receiving the finalisation information of each block
+// finalised from now on, for a Go program embedding the node. The channel is closed when
+// the node stops. Notifications are dropped if the channel is not drained fast enough.
+func (n *Node) FinalizedBlocks() <-chan *types.FinalisationInfo {
+	blockState := n.state.Block
+	in := blockState.GetFinalisedNotifierChannel()
+	out := make(chan *types.FinalisationInfo, cap(in))
+	go forwardNotifications(in, out, n.stopping, blockState.FreeFinalisedNotifierChannel)
+	return out
+}
+
+// ImportedBlocks returns a channel receiving each block imported from now on, on any fork,
+// for a Go program embedding the node. The channel is closed when the node stops.
+// Notifications are dropped if the channel is not drained fast enough.
+func (n *Node) ImportedBlocks() <-chan *types.Block {
+	blockState := n.state.Block
+	in := blockState.GetImportedBlockNotifierChannel()
+	out := make(chan *types.Block, cap(in))
+	go forwardNotifications(in, out, n.stopping, blockState.FreeImportedBlockNotifierChannel)
+	return out
+}
+
+// SubmitExtrinsic validates the extrinsic given, adds it to the transaction pool and
+// propagates it to the peers, as the author_submitExtrinsic RPC method does.
+// It returns the hash of the extrinsic.
+func (n *Node) SubmitExtrinsic(ext types.Extrinsic) (common.Hash, error) {
+	select {
+	case <-n.stopping:
+		return common.Hash{}, ErrNodeStopped
+	default:
+	}
+
+	err := n.core.HandleSubmittedExtrinsic(ext)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return ext.Hash(), nil
+}
+
+// forwardNotifications forwards the notifications received on the channel in to the
+// channel out until the stopping channel is closed, then frees the channel in and
+// closes the channel out.
+func forwardNotifications[T any](in chan T, out chan<- T, stopping <-chan struct{}, free func(chan T)) {
+	defer close(out)
+	defer free(in)
+
+	for {
+		select {
+		case notification := <-in:
+			select {
+			case out <- notification:
+			case <-stopping:
+				return
+			}
+		case <-stopping:
+			return
+		}
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_forwardNotifications(t *testing.T) {
+	t.Parallel()
+
+	in := make(chan int, 1)
+	out := make(chan int, 1)
+	stopping := make(chan struct{})
+	freed := make(chan chan int, 1)
+	free := func(ch chan int) { freed <- ch }
+
+	go forwardNotifications(in, out, stopping, free)
+
+	in <- 1
+	assert.Equal(t, 1, <-out)
+	in <- 2
+	assert.Equal(t, 2, <-out)
+
+	close(stopping)
+
+	_, ok := <-out
+	assert.False(t, ok)
+	assert.Equal(t, in, <-freed)
+}
+
+func TestNode_SubmitExtrinsic_stopped(t *testing.T) {
+	t.Parallel()
+
+	stopping := make(chan struct{})
+	close(stopping)
+	node := &Node{stopping: stopping}
+
+	hash, err := node.SubmitExtrinsic(types.Extrinsic{1})
+
+	assert.ErrorIs(t, err, ErrNodeStopped)
+	assert.Equal(t, common.Hash{}, hash)
+}
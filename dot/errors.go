@@ -14,3 +14,5 @@
 var ErrInvalidKeystoreType = errors.New("invalid keystore type")
 
 var ErrWasmInterpreterName = errors.New("unknown wasm interpreter name")
+
+var errSassafrasNotSupported = errors.New("sassafras block import and production are not supported yet")
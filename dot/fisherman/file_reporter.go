@@ -0,0 +1,52 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package fisherman
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// FileReporter appends the misbehaviours reported to a file, one JSON object per line, for
+// an external service to report them.
+type FileReporter struct {
+	path  string
+	mutex sync.Mutex
+}
+
+// NewFileReporter returns a reporter appending the misbehaviours to the file of the path given,
+// created if it does not exist.
+func NewFileReporter(path string) *FileReporter {
+	return &FileReporter{path: path}
+}
+
+// Report appends the misbehaviour given to the file.
+func (r *FileReporter) Report(misbehaviour Misbehaviour) (err error) {
+	line, err := json.Marshal(misbehaviour)
+	if err != nil {
+		return fmt.Errorf("encoding misbehaviour: %w", err)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	file, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("opening report file: %w", err)
+	}
+	defer func() {
+		closeErr := file.Close()
+		if err == nil && closeErr != nil {
+			err = fmt.Errorf("closing report file: %w", closeErr)
+		}
+	}()
+
+	_, err = file.Write(append(line, '\n'))
+	if err != nil {
+		return fmt.Errorf("writing report file: %w", err)
+	}
+	return nil
+}
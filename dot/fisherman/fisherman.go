@@ -0,0 +1,197 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package fisherman records the blocks failing the verification because of a misbehaviour of
+// their author, such as a bad seal or an invalid state root. The misbehaviours are attributed
+// to the authority which authored the block, exported as metrics and telemetry messages, and
+// reported to pluggable reporters, as groundwork for reporting the offences on chain.
+package fisherman
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/telemetry"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var logger = log.NewFromGlobal(log.AddContext("pkg", "fisherman"))
+
+var misbehavioursCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "gossamer_fisherman",
+	Name:      "misbehaviours_total",
+	Help:      "number of blocks failing verification because of their author, by kind and authority index",
+}, []string{"kind", "authority"})
+
+// Kind is the kind of misbehaviour of a block author
+type Kind string
+
+const (
+	// BadSeal is a block whose seal is not signed by its author
+	BadSeal Kind = "bad_seal"
+	// InvalidSlotClaim is a block whose author did not win the slot it claimed
+	InvalidSlotClaim Kind = "invalid_slot_claim"
+	// Equivocation is a block authored for a slot its author already authored a block for
+	Equivocation Kind = "equivocation"
+	// InvalidStateRoot is a block whose state root does not match the state after its execution
+	InvalidStateRoot Kind = "invalid_state_root"
+)
+
+// Misbehaviour is a block verification failure attributed to the block author
+type Misbehaviour struct {
+	Kind        Kind
+	BlockHash   common.Hash
+	BlockNumber uint
+	// Slot and AuthorityIndex are the slot and the index of the author in the authority set
+	// claimed by the BABE pre-runtime digest of the block, AuthorityIndex being nil if the
+	// block has no BABE pre-runtime digest
+	Slot           uint64
+	AuthorityIndex *uint32
+	// Err is the verification error
+	Err  error
+	Time time.Time
+}
+
+// MarshalJSON encodes the misbehaviour with its error message.
+func (m Misbehaviour) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind           Kind        `json:"kind"`
+		BlockHash      common.Hash `json:"blockHash"`
+		BlockNumber    uint        `json:"blockNumber"`
+		Slot           uint64      `json:"slot"`
+		AuthorityIndex *uint32     `json:"authorityIndex"`
+		Error          string      `json:"error"`
+		Time           time.Time   `json:"time"`
+	}{
+		Kind:           m.Kind,
+		BlockHash:      m.BlockHash,
+		BlockNumber:    m.BlockNumber,
+		Slot:           m.Slot,
+		AuthorityIndex: m.AuthorityIndex,
+		Error:          m.Err.Error(),
+		Time:           m.Time,
+	})
+}
+
+// Reporter reports the misbehaviours recorded, for example to an external slashing service
+type Reporter interface {
+	Report(misbehaviour Misbehaviour) error
+}
+
+// Classifier returns the kind of misbehaviour of a block verification error, and false if the
+// error is not caused by a misbehaviour of the block author.
+type Classifier func(err error) (kind Kind, ok bool)
+
+// Telemetry is the telemetry client to send telemetry messages.
+type Telemetry interface {
+	SendMessage(msg json.Marshaler)
+}
+
+// Fisherman records the misbehaviours of the block authors
+type Fisherman struct {
+	classify  Classifier
+	telemetry Telemetry
+	reporters []Reporter
+	now       func() time.Time
+}
+
+// New returns a fisherman recording the block verification errors classified as misbehaviours
+// by the classifier given, and reporting them to the reporters given.
+func New(classify Classifier, telemetry Telemetry, reporters ...Reporter) *Fisherman {
+	return &Fisherman{
+		classify:  classify,
+		telemetry: telemetry,
+		reporters: reporters,
+		now:       time.Now,
+	}
+}
+
+// RecordBlockFailure records the verification error of the block of the header given if it is
+// caused by a misbehaviour of the block author, and is a no-op otherwise.
+func (f *Fisherman) RecordBlockFailure(header *types.Header, err error) {
+	kind, ok := f.classify(err)
+	if !ok {
+		return
+	}
+
+	misbehaviour := Misbehaviour{
+		Kind:        kind,
+		BlockHash:   header.Hash(),
+		BlockNumber: header.Number,
+		Err:         err,
+		Time:        f.now(),
+	}
+
+	authority := "unknown"
+	authorityIndex, slot, ok := blockAuthor(header)
+	if ok {
+		misbehaviour.Slot = slot
+		misbehaviour.AuthorityIndex = &authorityIndex
+		authority = strconv.FormatUint(uint64(authorityIndex), 10)
+	}
+
+	logger.Warnf("block #%d (%s) authored by authority %s at slot %d failed verification: %s: %s",
+		header.Number, misbehaviour.BlockHash, authority, slot, kind, err)
+	misbehavioursCounter.WithLabelValues(string(kind), authority).Inc()
+
+	if f.telemetry != nil {
+		f.telemetry.SendMessage(telemetry.NewBlockMisbehaviour(
+			misbehaviour.BlockHash, header.Number, string(kind), misbehaviour.AuthorityIndex, err.Error()))
+	}
+
+	for _, reporter := range f.reporters {
+		err := reporter.Report(misbehaviour)
+		if err != nil {
+			logger.Errorf("reporting %s of block #%d (%s): %s", kind, header.Number, misbehaviour.BlockHash, err)
+		}
+	}
+}
+
+// blockAuthor returns the index of the author in the authority set and the slot claimed by
+// the BABE pre-runtime digest of the header given, and false if the header has none.
+func blockAuthor(header *types.Header) (authorityIndex uint32, slot uint64, ok bool) {
+	for _, item := range header.Digest {
+		value, err := item.Value()
+		if err != nil {
+			continue
+		}
+		preDigest, isPreDigest := value.(types.PreRuntimeDigest)
+		if !isPreDigest || preDigest.ConsensusEngineID != types.BabeEngineID {
+			continue
+		}
+
+		babePreDigest, err := types.DecodeBabePreDigest(preDigest.Data)
+		if err != nil {
+			return 0, 0, false
+		}
+
+		switch d := babePreDigest.(type) {
+		case types.BabePrimaryPreDigest:
+			return d.AuthorityIndex, d.SlotNumber, true
+		case types.BabeSecondaryVRFPreDigest:
+			return d.AuthorityIndex, d.SlotNumber, true
+		case types.BabeSecondaryPlainPreDigest:
+			return d.AuthorityIndex, d.SlotNumber, true
+		}
+	}
+	return 0, 0, false
+}
+
+// ErrorIs returns a classifier classifying the errors wrapping one of the target errors
+// given as the kind of misbehaviour they are mapped to.
+func ErrorIs(kinds map[error]Kind) Classifier {
+	return func(err error) (kind Kind, ok bool) {
+		for target, kind := range kinds {
+			if errors.Is(err, target) {
+				return kind, true
+			}
+		}
+		return "", false
+	}
+}
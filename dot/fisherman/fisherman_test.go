@@ -0,0 +1,144 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package fisherman
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/telemetry"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	errBadSignature = errors.New("could not verify signature")
+	errMissingEpoch = errors.New("missing epoch data")
+)
+
+func newTestHeader(t *testing.T, authorityIndex uint32, slot uint64) *types.Header {
+	t.Helper()
+
+	preDigest, err := types.NewBabeSecondaryPlainPreDigest(authorityIndex, slot).ToPreRuntimeDigest()
+	require.NoError(t, err)
+	digest := types.NewDigest()
+	err = digest.Add(*preDigest)
+	require.NoError(t, err)
+	return types.NewHeader(common.Hash{1}, common.Hash{2}, common.Hash{3}, 10, digest)
+}
+
+func TestFisherman_RecordBlockFailure(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	header := newTestHeader(t, 3, 42)
+	now := time.Unix(1_700_000_000, 0)
+	err := fmt.Errorf("verifying block: %w", errBadSignature)
+
+	telemetryClient := NewMockTelemetry(ctrl)
+	authorityIndex := uint32(3)
+	telemetryClient.EXPECT().SendMessage(telemetry.NewBlockMisbehaviour(
+		header.Hash(), 10, "bad_seal", &authorityIndex, err.Error()))
+
+	errTest := errors.New("test error")
+	reporter := NewMockReporter(ctrl)
+	reporter.EXPECT().Report(Misbehaviour{
+		Kind:           BadSeal,
+		BlockHash:      header.Hash(),
+		BlockNumber:    10,
+		Slot:           42,
+		AuthorityIndex: &authorityIndex,
+		Err:            err,
+		Time:           now,
+	}).Return(errTest)
+	// the reporters following a failing reporter are still called
+	otherReporter := NewMockReporter(ctrl)
+	otherReporter.EXPECT().Report(gomock.Any()).Return(nil)
+
+	classify := ErrorIs(map[error]Kind{errBadSignature: BadSeal})
+	fisherman := New(classify, telemetryClient, reporter, otherReporter)
+	fisherman.now = func() time.Time { return now }
+
+	fisherman.RecordBlockFailure(header, err)
+
+	// the failures not caused by the block author are not recorded
+	fisherman.RecordBlockFailure(header, errMissingEpoch)
+}
+
+func TestFisherman_RecordBlockFailure_unknownAuthor(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	header := types.NewHeader(common.Hash{1}, common.Hash{2}, common.Hash{3}, 10, types.NewDigest())
+
+	reporter := NewMockReporter(ctrl)
+	reporter.EXPECT().Report(gomock.Any()).DoAndReturn(func(misbehaviour Misbehaviour) error {
+		assert.Equal(t, InvalidStateRoot, misbehaviour.Kind)
+		assert.Nil(t, misbehaviour.AuthorityIndex)
+		return nil
+	})
+
+	classify := func(error) (Kind, bool) { return InvalidStateRoot, true }
+	fisherman := New(classify, nil, reporter)
+
+	fisherman.RecordBlockFailure(header, errMissingEpoch)
+}
+
+func TestFileReporter_Report(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "misbehaviours.jsonl")
+	reporter := NewFileReporter(path)
+	authorityIndex := uint32(3)
+	misbehaviours := []Misbehaviour{{
+		Kind:           BadSeal,
+		BlockNumber:    10,
+		Slot:           42,
+		AuthorityIndex: &authorityIndex,
+		Err:            errBadSignature,
+		Time:           time.Unix(1_700_000_000, 0).UTC(),
+	}, {
+		Kind:        InvalidStateRoot,
+		BlockNumber: 11,
+		Err:         errMissingEpoch,
+		Time:        time.Unix(1_700_000_006, 0).UTC(),
+	}}
+
+	for _, misbehaviour := range misbehaviours {
+		err := reporter.Report(misbehaviour)
+		require.NoError(t, err)
+	}
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	require.Len(t, lines, 2)
+
+	var decoded map[string]any
+	err = json.Unmarshal([]byte(lines[0]), &decoded)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"kind":           "bad_seal",
+		"blockHash":      common.Hash{}.String(),
+		"blockNumber":    float64(10),
+		"slot":           float64(42),
+		"authorityIndex": float64(3),
+		"error":          "could not verify signature",
+		"time":           "2023-11-14T22:13:20Z",
+	}, decoded)
+
+	err = json.Unmarshal([]byte(lines[1]), &decoded)
+	require.NoError(t, err)
+	assert.Equal(t, "invalid_state_root", decoded["kind"])
+	assert.Nil(t, decoded["authorityIndex"])
+}
@@ -0,0 +1,178 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/genesis"
+	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+)
+
+// DefaultForkOffConsensusPallets are the pallets whose storage is kept from the
+// development chain spec when forking off a live chain, so the forked chain is
+// produced and finalised by the authorities of the development chain.
+var DefaultForkOffConsensusPallets = []string{
+	"Babe",
+	"Grandpa",
+	"Session",
+	"Authorship",
+	"ImOnline",
+	"AuthorityDiscovery",
+	"Beefy",
+	"BeefyMmrLeaf",
+	"ParasShared",
+	"ParaSessionInfo",
+}
+
+// ForkOffConfig is the configuration to fork off a live chain into a development chain spec
+type ForkOffConfig struct {
+	// URI is the HTTP RPC endpoint of the node to fetch the state from
+	URI string
+	// Block is the block whose state is forked, the last finalised block if nil
+	Block *common.Hash
+	// DevChainSpec is the path to the development chain spec providing the consensus state
+	DevChainSpec string
+	// ConsensusPallets are the pallets whose storage is kept from the development chain spec
+	ConsensusPallets []string
+	// Sudo is the account ID set as sudo key, if not nil
+	Sudo []byte
+	// Patches are the hex encoded values set at hex encoded keys in the forked state,
+	// an empty value deleting the key.
+	Patches map[string]string
+}
+
+// ForkOff returns a raw chain spec whose genesis state is the state of a live chain,
+// except for the storage of the consensus pallets which is kept from the development
+// chain spec given, so the forked chain can be run locally by its development authorities.
+func ForkOff(config ForkOffConfig) (*genesis.Genesis, error) {
+	spec, err := genesis.NewGenesisFromJSONRaw(config.DevChainSpec)
+	if err != nil {
+		return nil, fmt.Errorf("loading development chain spec: %w", err)
+	}
+
+	if !spec.IsRaw() {
+		err = wazero_runtime.GenesisToRaw(spec)
+		if err != nil {
+			return nil, fmt.Errorf("converting development chain spec to raw: %w", err)
+		}
+	}
+
+	node := &remoteNode{uri: config.URI}
+	blockHash := config.Block
+	if blockHash == nil {
+		finalisedHead, err := node.finalisedHead()
+		if err != nil {
+			return nil, fmt.Errorf("getting finalised head: %w", err)
+		}
+		blockHash = &finalisedHead
+	}
+
+	forkedEntries, err := node.storageEntries(*blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("fetching state at block %s: %w", *blockHash, err)
+	}
+
+	consensusPrefixes, err := palletPrefixes(config.ConsensusPallets)
+	if err != nil {
+		return nil, err
+	}
+
+	top := forkOffTop(spec.Genesis.Raw["top"], forkedEntries, consensusPrefixes)
+
+	if config.Sudo != nil {
+		sudoKey, err := palletStorageKey("Sudo", "Key")
+		if err != nil {
+			return nil, err
+		}
+		top[sudoKey] = common.BytesToHex(config.Sudo)
+	}
+
+	for key, value := range config.Patches {
+		key = strings.ToLower(key)
+		if value == "" {
+			delete(top, key)
+			continue
+		}
+		top[key] = value
+	}
+
+	spec.Name += " fork"
+	spec.ID += "_fork"
+	spec.Bootnodes = nil
+	spec.CodeSubstitutes = nil
+	spec.Genesis = genesis.Fields{
+		Raw: map[string]map[string]string{"top": top},
+	}
+
+	logger.Infof("forked %d storage entries at block %s", len(top), *blockHash)
+	return spec, nil
+}
+
+// forkOffTop returns the forked top trie entries, made of the development entries under
+// the consensus prefixes given and of the other entries of the live chain. The child
+// trie roots are left out since the content of the child tries is not fetched.
+func forkOffTop(devTop, forkedTop map[string]string, consensusPrefixes []string) map[string]string {
+	isConsensusKey := func(key string) bool {
+		key = strings.ToLower(key)
+		if strings.HasPrefix(key, common.BytesToHex([]byte(":grandpa"))) {
+			return true
+		}
+		for _, prefix := range consensusPrefixes {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+
+	childStoragePrefix := common.BytesToHex([]byte(inmemory.ChildStorageKeyPrefix))
+
+	top := make(map[string]string, len(forkedTop))
+	for key, value := range devTop {
+		if isConsensusKey(key) {
+			top[strings.ToLower(key)] = value
+		}
+	}
+
+	for key, value := range forkedTop {
+		if isConsensusKey(key) || strings.HasPrefix(strings.ToLower(key), childStoragePrefix) {
+			continue
+		}
+		top[strings.ToLower(key)] = value
+	}
+
+	return top
+}
+
+// palletPrefixes returns the hex encoded storage prefixes of the pallets given.
+func palletPrefixes(pallets []string) (prefixes []string, err error) {
+	prefixes = make([]string, len(pallets))
+	for i, pallet := range pallets {
+		hash, err := common.Twox128Hash([]byte(pallet))
+		if err != nil {
+			return nil, fmt.Errorf("hashing pallet name %s: %w", pallet, err)
+		}
+		prefixes[i] = common.BytesToHex(hash)
+	}
+	return prefixes, nil
+}
+
+// palletStorageKey returns the hex encoded key of a storage value of a pallet.
+func palletStorageKey(pallet, item string) (string, error) {
+	palletHash, err := common.Twox128Hash([]byte(pallet))
+	if err != nil {
+		return "", fmt.Errorf("hashing pallet name %s: %w", pallet, err)
+	}
+
+	itemHash, err := common.Twox128Hash([]byte(item))
+	if err != nil {
+		return "", fmt.Errorf("hashing storage item name %s: %w", item, err)
+	}
+
+	return common.BytesToHex(append(palletHash, itemHash...)), nil
+}
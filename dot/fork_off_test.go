@@ -0,0 +1,53 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_forkOffTop(t *testing.T) {
+	t.Parallel()
+
+	prefixes, err := palletPrefixes([]string{"Babe"})
+	require.NoError(t, err)
+	babeKey := prefixes[0] + "01"
+	grandpaKey := common.BytesToHex([]byte(":grandpa_authorities"))
+	childKey := common.BytesToHex([]byte(":child_storage:default:child"))
+	codeKey := common.BytesToHex([]byte(":code"))
+
+	devTop := map[string]string{
+		babeKey:    "0x01",
+		grandpaKey: "0x02",
+		codeKey:    "0x03",
+		"0xaa":     "0x04",
+	}
+	forkedTop := map[string]string{
+		babeKey:    "0x11",
+		grandpaKey: "0x12",
+		codeKey:    "0x13",
+		childKey:   "0x14",
+		"0xBB":     "0x15",
+	}
+
+	expected := map[string]string{
+		babeKey:    "0x01",
+		grandpaKey: "0x02",
+		codeKey:    "0x13",
+		"0xbb":     "0x15",
+	}
+	assert.Equal(t, expected, forkOffTop(devTop, forkedTop, prefixes))
+}
+
+func Test_palletStorageKey(t *testing.T) {
+	t.Parallel()
+
+	key, err := palletStorageKey("Sudo", "Key")
+	require.NoError(t, err)
+	assert.Equal(t, "0x5c0d1176a568c1f92944340dbfed9e9c530ebca703c85910e7164cb7d1c9e47b", key)
+}
@@ -0,0 +1,50 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+var errEmptyCommand = errors.New("empty command")
+
+// ExecSink runs a command for each block notification, writing the notification to its
+// standard input. The command can for example forward the notifications to a message queue
+// such as NATS or Kafka with their command line clients.
+type ExecSink struct {
+	name string
+	args []string
+}
+
+// NewExecSink returns a sink running the command given for each block notification, the
+// command being its executable followed by its arguments separated by spaces.
+func NewExecSink(command string) (*ExecSink, error) {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return nil, errEmptyCommand
+	}
+	return &ExecSink{name: fields[0], args: fields[1:]}, nil
+}
+
+// Send runs the command with the JSON encoded notification given, followed by a newline,
+// written to its standard input, failing if the command exits with an error.
+func (e *ExecSink) Send(ctx context.Context, notification []byte) error {
+	cmd := exec.CommandContext(ctx, e.name, e.args...) //nolint:gosec
+	cmd.Stdin = io.MultiReader(bytes.NewReader(notification), strings.NewReader("\n"))
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("running %s: %w: %s", e.name, err, bytes.TrimSpace(output))
+	}
+	return nil
+}
+
+func (e *ExecSink) String() string {
+	return "exec"
+}
@@ -0,0 +1,47 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package hooks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewExecSink(t *testing.T) {
+	t.Parallel()
+
+	sink, err := NewExecSink(" nats  pub blocks ")
+	require.NoError(t, err)
+	assert.Equal(t, &ExecSink{name: "nats", args: []string{"pub", "blocks"}}, sink)
+
+	_, err = NewExecSink("  ")
+	assert.ErrorIs(t, err, errEmptyCommand)
+}
+
+func TestExecSink_Send(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "notifications")
+	sink, err := NewExecSink("tee -a " + path)
+	require.NoError(t, err)
+
+	err = sink.Send(context.Background(), []byte(`{"event":"block_imported"}`))
+	require.NoError(t, err)
+	err = sink.Send(context.Background(), []byte(`{"event":"block_finalised"}`))
+	require.NoError(t, err)
+
+	written, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "{\"event\":\"block_imported\"}\n{\"event\":\"block_finalised\"}\n", string(written))
+
+	sink, err = NewExecSink("false")
+	require.NoError(t, err)
+	err = sink.Send(context.Background(), nil)
+	assert.EqualError(t, err, "running false: exit status 1: ")
+}
@@ -0,0 +1,277 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package hooks pushes the blocks imported and finalised by the node to external indexers,
+// with their header, extrinsics and decoded events, through pluggable sinks such as a
+// webhook or a command run for each block.
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/services"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var logger = log.NewFromGlobal(log.AddContext("pkg", "hooks"))
+
+var (
+	notificationsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gossamer_hooks",
+		Name:      "notifications_total",
+		Help:      "number of block notifications delivered to the hooks, by sink and result",
+	}, []string{"sink", "result"})
+	droppedCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "gossamer_hooks",
+		Name:      "notifications_dropped_total",
+		Help:      "number of block notifications dropped because the hooks did not keep up",
+	})
+)
+
+const (
+	// queueSize is the number of block notifications queued for the sinks before the
+	// notifications are dropped
+	queueSize = 1024
+	// sendTimeout is the duration after which the delivery of a notification to a sink fails
+	sendTimeout = 10 * time.Second
+)
+
+// Event is the block event notified to the hooks
+type Event string
+
+const (
+	// BlockImported is notified for each block imported, on any fork
+	BlockImported Event = "block_imported"
+	// BlockFinalised is notified for each block finalised
+	BlockFinalised Event = "block_finalised"
+)
+
+// Notification is the block notification sent to the sinks, encoded as JSON
+type Notification struct {
+	Event      Event               `json:"event"`
+	Hash       common.Hash         `json:"hash"`
+	Header     Header              `json:"header"`
+	Extrinsics []string            `json:"extrinsics"`
+	Events     []scale.EventRecord `json:"events"`
+	// Round and SetID are the GRANDPA round and set id of the finalisation of the block, for the
+	// BlockFinalised event
+	Round uint64 `json:"round,omitempty"`
+	SetID uint64 `json:"setId,omitempty"`
+}
+
+// Header is the header of the block notified, its hashes and digest logs being hex encoded
+type Header struct {
+	ParentHash     common.Hash `json:"parentHash"`
+	Number         uint        `json:"number"`
+	StateRoot      common.Hash `json:"stateRoot"`
+	ExtrinsicsRoot common.Hash `json:"extrinsicsRoot"`
+	Digest         []string    `json:"digest"`
+}
+
+// Sink delivers the JSON encoded block notifications to an external service
+type Sink interface {
+	Send(ctx context.Context, notification []byte) error
+	// String returns the name of the sink, used in the logs and metrics
+	String() string
+}
+
+// Service notifies the sinks of the blocks imported and finalised, one block at a time in the
+// order the blocks are received. The notifications are dropped if the sinks do not keep up.
+type Service struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	blockState BlockState
+	events     EventsDecoder
+	sinks      []Sink
+
+	// block notification channels
+	imported  chan *types.Block
+	finalised chan *types.FinalisationInfo
+	queue     chan block
+}
+
+// NewService returns a service notifying the sinks given of the blocks imported and finalised,
+// with their events decoded by the decoder given.
+func NewService(blockState BlockState, events EventsDecoder, sinks ...Sink) *Service {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Service{
+		ctx:        ctx,
+		cancel:     cancel,
+		blockState: blockState,
+		events:     events,
+		sinks:      sinks,
+		imported:   blockState.GetImportedBlockNotifierChannel(),
+		finalised:  blockState.GetFinalisedNotifierChannel(),
+		queue:      make(chan block, queueSize),
+	}
+}
+
+// Start starts notifying the sinks
+func (s *Service) Start() error {
+	s.wg.Add(2)
+	services.Go(s, func() {
+		defer s.wg.Done()
+		s.receive()
+	})
+	services.Go(s, func() {
+		defer s.wg.Done()
+		s.deliver()
+	})
+	return nil
+}
+
+// Stop stops notifying the sinks, dropping the notifications queued
+func (s *Service) Stop() error {
+	s.cancel()
+	s.wg.Wait()
+	s.blockState.FreeImportedBlockNotifierChannel(s.imported)
+	s.blockState.FreeFinalisedNotifierChannel(s.finalised)
+	return nil
+}
+
+// block is a block imported or finalised, queued to be notified to the sinks
+type block struct {
+	event  Event
+	header types.Header
+	// body is the body of the block imported, nil for a block finalised
+	body         *types.Body
+	round, setID uint64
+}
+
+// receive queues the blocks imported and finalised until the service is stopped.
+func (s *Service) receive() {
+	for {
+		var queued block
+		select {
+		case imported := <-s.imported:
+			if imported == nil {
+				continue
+			}
+			queued = block{event: BlockImported, header: imported.Header, body: &imported.Body}
+		case info := <-s.finalised:
+			if info == nil {
+				continue
+			}
+			queued = block{event: BlockFinalised, header: info.Header, round: info.Round, setID: info.SetID}
+		case <-s.ctx.Done():
+			return
+		}
+
+		select {
+		case s.queue <- queued:
+		default:
+			droppedCounter.Inc()
+			logger.Warnf("dropping %s notification of block #%d (%s): hooks are not keeping up",
+				queued.event, queued.header.Number, queued.header.Hash())
+		}
+	}
+}
+
+// deliver notifies the sinks of the blocks queued until the service is stopped.
+func (s *Service) deliver() {
+	for {
+		select {
+		case queued := <-s.queue:
+			err := s.notify(queued)
+			if err != nil {
+				logger.Errorf("notifying %s of block #%d (%s): %s",
+					queued.event, queued.header.Number, queued.header.Hash(), err)
+			}
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// notify sends the notification of the block given, with its extrinsics and events, to each
+// sink. The failures of the sinks are logged, only the errors building the notification are
+// returned.
+func (s *Service) notify(queued block) error {
+	hash := queued.header.Hash()
+	header, err := headerToJSON(queued.header)
+	if err != nil {
+		return err
+	}
+
+	body := queued.body
+	if body == nil {
+		body, err = s.blockState.GetBlockBody(hash)
+		if err != nil {
+			return fmt.Errorf("getting block body: %w", err)
+		}
+	}
+
+	// the notification is sent without events if they cannot be decoded, such as for a
+	// runtime whose metadata is not supported
+	events, err := s.events(hash)
+	if err != nil {
+		logger.Warnf("decoding events of block #%d (%s): %s", queued.header.Number, hash, err)
+	}
+
+	encoded, err := json.Marshal(Notification{
+		Event:      queued.event,
+		Hash:       hash,
+		Header:     header,
+		Extrinsics: extrinsicsToHex(*body),
+		Events:     events,
+		Round:      queued.round,
+		SetID:      queued.setID,
+	})
+	if err != nil {
+		return fmt.Errorf("encoding notification: %w", err)
+	}
+
+	for _, sink := range s.sinks {
+		ctx, cancel := context.WithTimeout(s.ctx, sendTimeout)
+		err := sink.Send(ctx, encoded)
+		cancel()
+		if err != nil {
+			notificationsCounter.WithLabelValues(sink.String(), "error").Inc()
+			logger.Errorf("sending %s notification of block #%d (%s) to %s: %s",
+				queued.event, queued.header.Number, hash, sink, err)
+			continue
+		}
+		notificationsCounter.WithLabelValues(sink.String(), "success").Inc()
+	}
+	return nil
+}
+
+// headerToJSON returns the JSON representation of the header given.
+func headerToJSON(header types.Header) (Header, error) {
+	digest := make([]string, 0, len(header.Digest))
+	for _, item := range header.Digest {
+		encoded, err := scale.Marshal(item)
+		if err != nil {
+			return Header{}, fmt.Errorf("encoding digest item: %w", err)
+		}
+		digest = append(digest, common.BytesToHex(encoded))
+	}
+
+	return Header{
+		ParentHash:     header.ParentHash,
+		Number:         header.Number,
+		StateRoot:      header.StateRoot,
+		ExtrinsicsRoot: header.ExtrinsicsRoot,
+		Digest:         digest,
+	}, nil
+}
+
+// extrinsicsToHex returns the hex encoded extrinsics of the block body given.
+func extrinsicsToHex(body types.Body) []string {
+	extrinsics := make([]string, len(body))
+	for i, extrinsic := range body {
+		extrinsics[i] = common.BytesToHex(extrinsic)
+	}
+	return extrinsics
+}
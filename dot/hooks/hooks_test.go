@@ -0,0 +1,141 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testBlockState is a block state whose notifier channels and block bodies are set by the test
+type testBlockState struct {
+	imported  chan *types.Block
+	finalised chan *types.FinalisationInfo
+	bodies    map[common.Hash]*types.Body
+	freed     int
+}
+
+func (bs *testBlockState) GetImportedBlockNotifierChannel() chan *types.Block { return bs.imported }
+func (bs *testBlockState) FreeImportedBlockNotifierChannel(chan *types.Block) { bs.freed++ }
+func (bs *testBlockState) GetFinalisedNotifierChannel() chan *types.FinalisationInfo {
+	return bs.finalised
+}
+func (bs *testBlockState) FreeFinalisedNotifierChannel(chan *types.FinalisationInfo) { bs.freed++ }
+
+func (bs *testBlockState) GetBlockBody(hash common.Hash) (*types.Body, error) {
+	body, ok := bs.bodies[hash]
+	if !ok {
+		return nil, errors.New("body not found")
+	}
+	return body, nil
+}
+
+// testSink sends the notifications it receives on its channel
+type testSink struct {
+	notifications chan Notification
+	err           error
+}
+
+func (s *testSink) Send(_ context.Context, notification []byte) error {
+	var decoded Notification
+	err := json.Unmarshal(notification, &decoded)
+	if err != nil {
+		return err
+	}
+	s.notifications <- decoded
+	return s.err
+}
+
+func (*testSink) String() string { return "test" }
+
+func TestService(t *testing.T) {
+	t.Parallel()
+
+	header := types.Header{
+		ParentHash: common.Hash{1},
+		Number:     2,
+		StateRoot:  common.Hash{3},
+		Digest:     types.NewDigest(),
+	}
+	body := types.Body{{1, 2}, {3}}
+	blockState := &testBlockState{
+		imported:  make(chan *types.Block),
+		finalised: make(chan *types.FinalisationInfo),
+		bodies:    map[common.Hash]*types.Body{header.Hash(): &body},
+	}
+	events := func(blockHash common.Hash) ([]scale.EventRecord, error) {
+		assert.Equal(t, header.Hash(), blockHash)
+		return []scale.EventRecord{{Pallet: "System", Name: "ExtrinsicSuccess"}}, nil
+	}
+	failingSink := &testSink{notifications: make(chan Notification, 2), err: errors.New("test error")}
+	sink := &testSink{notifications: make(chan Notification, 2)}
+
+	s := NewService(blockState, events, failingSink, sink)
+	err := s.Start()
+	require.NoError(t, err)
+
+	blockState.imported <- &types.Block{Header: header, Body: body}
+	blockState.finalised <- &types.FinalisationInfo{Header: header, Round: 4, SetID: 5}
+
+	expected := Notification{
+		Event: BlockImported,
+		Hash:  header.Hash(),
+		Header: Header{
+			ParentHash: common.Hash{1},
+			Number:     2,
+			StateRoot:  common.Hash{3},
+			Digest:     []string{},
+		},
+		Extrinsics: []string{"0x0102", "0x03"},
+		Events:     []scale.EventRecord{{Pallet: "System", Name: "ExtrinsicSuccess", Topics: nil}},
+	}
+	assert.Equal(t, expected, <-failingSink.notifications)
+	assert.Equal(t, expected, <-sink.notifications)
+
+	// the sinks are notified even if a previous sink failed
+	expected.Event = BlockFinalised
+	expected.Round = 4
+	expected.SetID = 5
+	assert.Equal(t, expected, <-failingSink.notifications)
+	assert.Equal(t, expected, <-sink.notifications)
+
+	err = s.Stop()
+	require.NoError(t, err)
+	assert.Equal(t, 2, blockState.freed)
+}
+
+func TestService_notify(t *testing.T) {
+	t.Parallel()
+
+	header := types.Header{Number: 1, Digest: types.NewDigest()}
+	blockState := &testBlockState{
+		imported:  make(chan *types.Block),
+		finalised: make(chan *types.FinalisationInfo),
+	}
+	events := func(common.Hash) ([]scale.EventRecord, error) {
+		return nil, errors.New("test error")
+	}
+	sink := &testSink{notifications: make(chan Notification, 1)}
+	s := NewService(blockState, events, sink)
+
+	// the body of a block finalised is read from the block state
+	err := s.notify(block{event: BlockFinalised, header: header})
+	assert.EqualError(t, err, "getting block body: body not found")
+	assert.Empty(t, sink.notifications)
+
+	// the notification is sent without events if they cannot be decoded
+	err = s.notify(block{event: BlockImported, header: header, body: &types.Body{}})
+	require.NoError(t, err)
+	notification := <-sink.notifications
+	assert.Equal(t, []string{}, notification.Extrinsics)
+	assert.Nil(t, notification.Events)
+}
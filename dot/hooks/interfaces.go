@@ -0,0 +1,23 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package hooks
+
+import (
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// BlockState is the interface for the block state methods
+type BlockState interface {
+	GetImportedBlockNotifierChannel() chan *types.Block
+	FreeImportedBlockNotifierChannel(ch chan *types.Block)
+	GetFinalisedNotifierChannel() chan *types.FinalisationInfo
+	FreeFinalisedNotifierChannel(ch chan *types.FinalisationInfo)
+	GetBlockBody(hash common.Hash) (*types.Body, error)
+}
+
+// EventsDecoder returns the events of the block of the hash given, decoded with the runtime
+// metadata at the block
+type EventsDecoder func(blockHash common.Hash) ([]scale.EventRecord, error)
@@ -0,0 +1,53 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// WebhookSink posts the block notifications to a webhook URL
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink returns a sink posting the block notifications to the URL given.
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{
+		url:    url,
+		client: &http.Client{},
+	}
+}
+
+// Send posts the JSON encoded notification given to the webhook, failing if the response
+// status code is not 2xx.
+func (w *WebhookSink) Send(ctx context.Context, notification []byte) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(notification))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := w.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("posting notification: %w", err)
+	}
+	defer response.Body.Close()
+	// the body is drained for the connection to be reused
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %s", response.Status)
+	}
+	return nil
+}
+
+func (w *WebhookSink) String() string {
+	return "webhook"
+}
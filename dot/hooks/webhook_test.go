@@ -0,0 +1,41 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package hooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookSink_Send(t *testing.T) {
+	t.Parallel()
+
+	status := http.StatusOK
+	received := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		received <- body
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL)
+	err := sink.Send(context.Background(), []byte(`{"event":"block_imported"}`))
+	require.NoError(t, err)
+	assert.Equal(t, `{"event":"block_imported"}`, string(<-received))
+
+	status = http.StatusInternalServerError
+	err = sink.Send(context.Background(), []byte(`{}`))
+	assert.EqualError(t, err, "webhook responded with status 500 Internal Server Error")
+	<-received
+}
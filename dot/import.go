@@ -79,8 +79,13 @@ func newHeaderFromFile(filename string) (*types.Header, error) {
 		return nil, err
 	}
 
+	return newHeaderFromJSON(data)
+}
+
+// newHeaderFromJSON decodes a header in the JSON format returned by the chain_getHeader RPC method.
+func newHeaderFromJSON(data []byte) (*types.Header, error) {
 	jsonHeader := make(map[string]interface{})
-	err = json.Unmarshal(data, &jsonHeader)
+	err := json.Unmarshal(data, &jsonHeader)
 	if err != nil {
 		return nil, err
 	}
@@ -16,10 +16,11 @@ type service interface {
 	Stop() error
 }
 
-// ServiceRegisterer can register a service interface, start or stop all services,
-// and get a particular service.
+// ServiceRegisterer can register a service interface and its dependencies,
+// start or stop all services, and get a particular service.
 type ServiceRegisterer interface {
 	RegisterService(service services.Service)
+	DependsOn(service services.Service, dependencies ...services.Service)
 	StartAll()
 	StopAll()
 	Get(srvc interface{}) services.Service
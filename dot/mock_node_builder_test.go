@@ -21,6 +21,8 @@
 	sync "github.com/ChainSafe/gossamer/dot/sync"
 	system "github.com/ChainSafe/gossamer/dot/system"
 	types "github.com/ChainSafe/gossamer/dot/types"
+	clock "github.com/ChainSafe/gossamer/internal/clock"
+	aura "github.com/ChainSafe/gossamer/lib/aura"
 	babe "github.com/ChainSafe/gossamer/lib/babe"
 	grandpa "github.com/ChainSafe/gossamer/lib/grandpa"
 	keystore "github.com/ChainSafe/gossamer/lib/keystore"
@@ -51,6 +53,36 @@ func (m *MocknodeBuilderIface) EXPECT() *MocknodeBuilderIfaceMockRecorder {
 	return m.recorder
 }
 
+// createAuraService mocks base method.
+func (m *MocknodeBuilderIface) createAuraService(config *config.Config, st *state.Service, ks KeyStore, cs *core.Service, telemetryMailer Telemetry) (*aura.Service, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createAuraService", config, st, ks, cs, telemetryMailer)
+	ret0, _ := ret[0].(*aura.Service)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// createAuraService indicates an expected call of createAuraService.
+func (mr *MocknodeBuilderIfaceMockRecorder) createAuraService(config, st, ks, cs, telemetryMailer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createAuraService", reflect.TypeOf((*MocknodeBuilderIface)(nil).createAuraService), config, st, ks, cs, telemetryMailer)
+}
+
+// createAuraVerifier mocks base method.
+func (m *MocknodeBuilderIface) createAuraVerifier(st *state.Service) (*aura.Verifier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createAuraVerifier", st)
+	ret0, _ := ret[0].(*aura.Verifier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// createAuraVerifier indicates an expected call of createAuraVerifier.
+func (mr *MocknodeBuilderIfaceMockRecorder) createAuraVerifier(st any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createAuraVerifier", reflect.TypeOf((*MocknodeBuilderIface)(nil).createAuraVerifier), st)
+}
+
 // createBABEService mocks base method.
 func (m *MocknodeBuilderIface) createBABEService(config *config.Config, st *state.Service, ks KeyStore, cs *core.Service, telemetryMailer Telemetry) (*babe.Service, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +112,21 @@ func (mr *MocknodeBuilderIfaceMockRecorder) createBlockVerifier(st any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createBlockVerifier", reflect.TypeOf((*MocknodeBuilderIface)(nil).createBlockVerifier), st)
 }
 
+// createClockGuard mocks base method.
+func (m *MocknodeBuilderIface) createClockGuard(config *config.Config, st *state.Service) (*clock.Guard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "createClockGuard", config, st)
+	ret0, _ := ret[0].(*clock.Guard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// createClockGuard indicates an expected call of createClockGuard.
+func (mr *MocknodeBuilderIfaceMockRecorder) createClockGuard(config, st any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "createClockGuard", reflect.TypeOf((*MocknodeBuilderIface)(nil).createClockGuard), config, st)
+}
+
 // createCoreService mocks base method.
 func (m *MocknodeBuilderIface) createCoreService(config *config.Config, ks *keystore.GlobalKeystore, st *state.Service, net *network.Service) (*core.Service, error) {
 	m.ctrl.T.Helper()
@@ -229,7 +276,7 @@ func (mr *MocknodeBuilderIfaceMockRecorder) loadRuntime(config, ns, stateSrvc, k
 }
 
 // newSyncService mocks base method.
-func (m *MocknodeBuilderIface) newSyncService(config *config.Config, st *state.Service, finalityGadget sync.FinalityGadget, verifier *babe.VerificationManager, cs *core.Service, net *network.Service, telemetryMailer Telemetry) (network.Syncer, error) {
+func (m *MocknodeBuilderIface) newSyncService(config *config.Config, st *state.Service, finalityGadget sync.FinalityGadget, verifier sync.BabeVerifier, cs *core.Service, net *network.Service, telemetryMailer Telemetry) (network.Syncer, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "newSyncService", config, st, finalityGadget, verifier, cs, net, telemetryMailer)
 	ret0, _ := ret[0].(network.Syncer)
@@ -7,3 +7,4 @@
 //go:generate mockgen -destination=mock_block_state_test.go -package $GOPACKAGE github.com/ChainSafe/gossamer/dot/network BlockState
 //go:generate mockgen -source=node.go -destination=mock_node_builder_test.go -package=$GOPACKAGE
 //go:generate mockgen -destination=mock_service_builder_test.go -package $GOPACKAGE . ServiceBuilder
+//go:generate mockgen -source=role.go -destination=mock_role_test.go -package=$GOPACKAGE
@@ -39,6 +39,23 @@ func (m *MockServiceRegisterer) EXPECT() *MockServiceRegistererMockRecorder {
 	return m.recorder
 }
 
+// DependsOn mocks base method.
+func (m *MockServiceRegisterer) DependsOn(arg0 services.Service, arg1 ...services.Service) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "DependsOn", varargs...)
+}
+
+// DependsOn indicates an expected call of DependsOn.
+func (mr *MockServiceRegistererMockRecorder) DependsOn(arg0 any, arg1 ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DependsOn", reflect.TypeOf((*MockServiceRegisterer)(nil).DependsOn), varargs...)
+}
+
 // Get mocks base method.
 func (m *MockServiceRegisterer) Get(arg0 any) services.Service {
 	m.ctrl.T.Helper()
@@ -0,0 +1,150 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	ma "github.com/multiformats/go-multiaddr"
+)
+
+var (
+	errInvalidListenAddress = errors.New("invalid listen address")
+	errInvalidPublicAddress = errors.New("invalid public address")
+	errMissingTCPPort       = errors.New("missing tcp port")
+)
+
+// buildAddresses parses and validates the listen addresses and the public addresses
+// of the configuration, defaulting to listen on all the IPv4 interfaces.
+func (c *Config) buildAddresses() (err error) {
+	listenAddresses := c.ListenAddresses
+	if len(listenAddresses) == 0 {
+		listenAddresses = []string{fmt.Sprintf("/ip4/0.0.0.0/tcp/%d", c.Port)}
+	}
+
+	c.listenAddrs = make([]ma.Multiaddr, len(listenAddresses))
+	for i, listenAddress := range listenAddresses {
+		c.listenAddrs[i], err = parseListenAddress(listenAddress)
+		if err != nil {
+			return err
+		}
+	}
+
+	port, err := tcpPort(c.listenAddrs[0])
+	if err != nil {
+		return err
+	}
+
+	c.externalAddrs = nil
+	for _, publicAddress := range c.PublicAddresses {
+		addr, err := parsePublicAddress(publicAddress)
+		if err != nil {
+			return err
+		}
+		c.externalAddrs = append(c.externalAddrs, addr)
+	}
+
+	switch {
+	case strings.TrimSpace(c.PublicIP) != "":
+		addr, err := publicIPAddress(c.PublicIP, port)
+		if err != nil {
+			return err
+		}
+		c.externalAddrs = append(c.externalAddrs, addr)
+	case strings.TrimSpace(c.PublicDNS) != "":
+		addr, err := parsePublicAddress(fmt.Sprintf("/dns/%s/tcp/%d", c.PublicDNS, port))
+		if err != nil {
+			return err
+		}
+		c.externalAddrs = append(c.externalAddrs, addr)
+	}
+
+	return nil
+}
+
+// parseListenAddress parses a listen multiaddr, which must be an IPv4, IPv6 or DNS address with a tcp port
+func parseListenAddress(s string) (ma.Multiaddr, error) {
+	addr, err := ma.NewMultiaddr(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", errInvalidListenAddress, s, err)
+	}
+
+	err = validateHostAndPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", errInvalidListenAddress, s, err)
+	}
+
+	return addr, nil
+}
+
+// parsePublicAddress parses a public multiaddr, which must be an IPv4, IPv6 or DNS address with a
+// tcp port. Unspecified IP addresses such as 0.0.0.0 cannot be advertised and are rejected.
+func parsePublicAddress(s string) (ma.Multiaddr, error) {
+	addr, err := ma.NewMultiaddr(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", errInvalidPublicAddress, s, err)
+	}
+
+	err = validateHostAndPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", errInvalidPublicAddress, s, err)
+	}
+
+	for _, code := range []int{ma.P_IP4, ma.P_IP6} {
+		value, err := addr.ValueForProtocol(code)
+		if err == nil && net.ParseIP(value).IsUnspecified() {
+			return nil, fmt.Errorf("%w: %s: unspecified ip address", errInvalidPublicAddress, s)
+		}
+	}
+
+	return addr, nil
+}
+
+// publicIPAddress returns the tcp multiaddr of the IPv4 or IPv6 public ip address
+func publicIPAddress(publicIP string, port uint64) (ma.Multiaddr, error) {
+	ip := net.ParseIP(strings.TrimSpace(publicIP))
+	if ip == nil {
+		return nil, fmt.Errorf("%w: %s: invalid ip address", errInvalidPublicAddress, publicIP)
+	}
+
+	if ip.To4() != nil {
+		return parsePublicAddress(fmt.Sprintf("/ip4/%s/tcp/%d", ip, port))
+	}
+	return parsePublicAddress(fmt.Sprintf("/ip6/%s/tcp/%d", ip, port))
+}
+
+// validateHostAndPort checks the multiaddr starts with an ip4, ip6, dns, dns4 or dns6
+// component followed by a tcp component.
+func validateHostAndPort(addr ma.Multiaddr) error {
+	protocols := addr.Protocols()
+	if len(protocols) < 2 {
+		return errMissingTCPPort
+	}
+
+	switch protocols[0].Code {
+	case ma.P_IP4, ma.P_IP6, ma.P_DNS, ma.P_DNS4, ma.P_DNS6:
+	default:
+		return fmt.Errorf("unsupported host protocol %s", protocols[0].Name)
+	}
+
+	if protocols[1].Code != ma.P_TCP {
+		return errMissingTCPPort
+	}
+
+	return nil
+}
+
+// tcpPort returns the tcp port of the multiaddr
+func tcpPort(addr ma.Multiaddr) (uint64, error) {
+	portString, err := addr.ValueForProtocol(ma.P_TCP)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", errMissingTCPPort, addr)
+	}
+
+	return strconv.ParseUint(portString, 10, 16)
+}
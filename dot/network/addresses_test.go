@@ -0,0 +1,116 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"testing"
+
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Config_buildAddresses(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		config        Config
+		listenAddrs   []string
+		externalAddrs []string
+		errWrapped    error
+		errMessage    string
+	}{
+		"default_listen_address": {
+			config:      Config{Port: 7001},
+			listenAddrs: []string{"/ip4/0.0.0.0/tcp/7001"},
+		},
+		"ipv4_and_ipv6_listen_addresses": {
+			config: Config{
+				ListenAddresses: []string{"/ip4/0.0.0.0/tcp/7001", "/ip6/::/tcp/7002"},
+			},
+			listenAddrs: []string{"/ip4/0.0.0.0/tcp/7001", "/ip6/::/tcp/7002"},
+		},
+		"invalid_listen_address": {
+			config: Config{
+				ListenAddresses: []string{"/ip4/0.0.0.0/tcp/7001", "/ip4/256.0.0.1/tcp/7001"},
+			},
+			errWrapped: errInvalidListenAddress,
+			errMessage: "invalid listen address: /ip4/256.0.0.1/tcp/7001: " +
+				"failed to parse multiaddr \"/ip4/256.0.0.1/tcp/7001\": " +
+				"invalid value \"256.0.0.1\" for protocol ip4: failed to parse ip4 addr: 256.0.0.1",
+		},
+		"listen_address_without_tcp_port": {
+			config: Config{
+				ListenAddresses: []string{"/ip4/0.0.0.0/udp/7001"},
+			},
+			errWrapped: errMissingTCPPort,
+			errMessage: "invalid listen address: /ip4/0.0.0.0/udp/7001: missing tcp port",
+		},
+		"public_addresses_and_ipv6_public_ip": {
+			config: Config{
+				Port: 7001,
+				PublicAddresses: []string{
+					"/dns/node.example.com/tcp/30333",
+					"/ip6/2001:db8::1/tcp/30333",
+				},
+				PublicIP: "2001:db8::2",
+			},
+			listenAddrs: []string{"/ip4/0.0.0.0/tcp/7001"},
+			externalAddrs: []string{
+				"/dns/node.example.com/tcp/30333",
+				"/ip6/2001:db8::1/tcp/30333",
+				"/ip6/2001:db8::2/tcp/7001",
+			},
+		},
+		"public_dns_uses_listen_port": {
+			config: Config{
+				ListenAddresses: []string{"/ip6/::/tcp/7002"},
+				PublicDNS:       "alice",
+			},
+			listenAddrs:   []string{"/ip6/::/tcp/7002"},
+			externalAddrs: []string{"/dns/alice/tcp/7002"},
+		},
+		"unspecified_public_address": {
+			config: Config{
+				PublicAddresses: []string{"/ip4/0.0.0.0/tcp/7001"},
+			},
+			errWrapped: errInvalidPublicAddress,
+			errMessage: "invalid public address: /ip4/0.0.0.0/tcp/7001: unspecified ip address",
+		},
+		"invalid_public_ip": {
+			config: Config{
+				PublicIP: "alice",
+			},
+			errWrapped: errInvalidPublicAddress,
+			errMessage: "invalid public address: alice: invalid ip address",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			config := testCase.config
+			err := config.buildAddresses()
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errMessage != "" {
+				require.EqualError(t, err, testCase.errMessage)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.listenAddrs, multiaddrsToStrings(config.listenAddrs))
+			assert.Equal(t, testCase.externalAddrs, multiaddrsToStrings(config.externalAddrs))
+		})
+	}
+}
+
+func multiaddrsToStrings(addrs []ma.Multiaddr) (strings []string) {
+	for _, addr := range addrs {
+		strings = append(strings, addr.String())
+	}
+	return strings
+}
@@ -0,0 +1,83 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	protocolTrafficGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "gossamer_network_traffic",
+		Name:      "protocol_bytes_total",
+		Help:      "total number of bytes received and sent per sub-protocol",
+	}, []string{"protocol", "direction"})
+	peerTrafficGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "gossamer_network_traffic",
+		Name:      "peer_bytes_total",
+		Help:      "total number of bytes received and sent per connected peer",
+	}, []string{"peer", "direction"})
+)
+
+const (
+	directionInbound  = "in"
+	directionOutbound = "out"
+)
+
+// NetworkTraffic returns the bytes received and sent in total, per connected peer and per sub-protocol
+func (s *Service) NetworkTraffic() common.NetworkTraffic {
+	totals := s.host.bwc.GetBandwidthTotals()
+	traffic := common.NetworkTraffic{
+		TotalBytesInbound:  uint64(totals.TotalIn),  //nolint:gosec
+		TotalBytesOutbound: uint64(totals.TotalOut), //nolint:gosec
+		Peers:              make(map[string]common.Traffic),
+		Protocols:          make(map[string]common.Traffic),
+	}
+
+	for _, p := range s.host.peers() {
+		stats := s.host.bwc.GetBandwidthForPeer(p)
+		traffic.Peers[p.String()] = common.Traffic{
+			BytesInbound:  uint64(stats.TotalIn),  //nolint:gosec
+			BytesOutbound: uint64(stats.TotalOut), //nolint:gosec
+		}
+	}
+
+	for protocolID, stats := range s.host.bwc.GetBandwidthByProtocol() {
+		traffic.Protocols[string(protocolID)] = common.Traffic{
+			BytesInbound:  uint64(stats.TotalIn),  //nolint:gosec
+			BytesOutbound: uint64(stats.TotalOut), //nolint:gosec
+		}
+	}
+
+	return traffic
+}
+
+// updateTrafficMetrics sets the per-protocol and per-peer traffic gauges, the disconnected
+// peers being removed from the gauges.
+func (s *Service) updateTrafficMetrics() {
+	traffic := s.NetworkTraffic()
+
+	for protocolID, protocolTraffic := range traffic.Protocols {
+		protocolTrafficGauge.WithLabelValues(protocolID, directionInbound).Set(float64(protocolTraffic.BytesInbound))
+		protocolTrafficGauge.WithLabelValues(protocolID, directionOutbound).Set(float64(protocolTraffic.BytesOutbound))
+	}
+
+	peerTrafficGauge.Reset()
+	for peerID, peerTraffic := range traffic.Peers {
+		peerTrafficGauge.WithLabelValues(peerID, directionInbound).Set(float64(peerTraffic.BytesInbound))
+		peerTrafficGauge.WithLabelValues(peerID, directionOutbound).Set(float64(peerTraffic.BytesOutbound))
+	}
+}
+
+// bandwidthExceeded returns true if the outbound bandwidth is above the configured soft limit
+func (s *Service) bandwidthExceeded() bool {
+	if s.cfg.MaxBandwidth == 0 {
+		return false
+	}
+
+	rateOut := s.host.bwc.GetBandwidthTotals().RateOut
+	return rateOut > float64(s.cfg.MaxBandwidth)
+}
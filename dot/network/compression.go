@@ -0,0 +1,179 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Compression is the algorithm used to compress the payloads of the notification and
+// request-response sub-protocols. The algorithm is negotiated when opening a stream by
+// suffixing the sub-protocol ID with the algorithm name, peers not supporting it falling
+// back on the uncompressed sub-protocol.
+type Compression string
+
+const (
+	// NoCompression sends the payloads uncompressed
+	NoCompression Compression = "none"
+	// ZstdCompression compresses the payloads using zstd
+	ZstdCompression Compression = "zstd"
+	// SnappyCompression compresses the payloads using snappy
+	SnappyCompression Compression = "snappy"
+)
+
+var (
+	errUnknownCompression     = errors.New("unknown compression algorithm")
+	errDecompressedSizeTooBig = errors.New("decompressed message size greater than max size")
+)
+
+// supportedCompressions are the compression algorithms accepted on inbound streams
+var supportedCompressions = []Compression{ZstdCompression, SnappyCompression}
+
+var (
+	compressionBytesCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gossamer_network_compression",
+		Name:      "compressed_bytes_total",
+		Help:      "total number of compressed payload bytes sent or received",
+	}, []string{"algorithm", "direction"})
+	uncompressedBytesCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gossamer_network_compression",
+		Name:      "uncompressed_bytes_total",
+		Help:      "total number of payload bytes sent or received before compression",
+	}, []string{"algorithm", "direction"})
+	savedBytesCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "gossamer_network_compression",
+		Name:      "saved_bytes_total",
+		Help:      "total number of bytes saved on the wire by compressing payloads",
+	}, []string{"algorithm", "direction"})
+)
+
+const (
+	directionSent     = "sent"
+	directionReceived = "received"
+)
+
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault))
+	zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderMaxMemory(MaxBlockResponseSize))
+)
+
+// ParseCompression parses the compression algorithm name, an empty name meaning no compression.
+func ParseCompression(name string) (Compression, error) {
+	switch Compression(strings.ToLower(name)) {
+	case "", NoCompression:
+		return NoCompression, nil
+	case ZstdCompression:
+		return ZstdCompression, nil
+	case SnappyCompression:
+		return SnappyCompression, nil
+	default:
+		return "", fmt.Errorf("%w: %s", errUnknownCompression, name)
+	}
+}
+
+// compressedProtocolID returns the protocol ID negotiating the given compression for the sub-protocol
+func compressedProtocolID(pid protocol.ID, compression Compression) protocol.ID {
+	if compression == NoCompression || compression == "" {
+		return pid
+	}
+	return pid + "/" + protocol.ID(compression)
+}
+
+// splitCompression returns the sub-protocol ID and the compression negotiated by the protocol ID
+func splitCompression(pid protocol.ID) (protocol.ID, Compression) {
+	for _, compression := range supportedCompressions {
+		suffix := "/" + protocol.ID(compression)
+		if strings.HasSuffix(string(pid), string(suffix)) {
+			return pid[:len(pid)-len(suffix)], compression
+		}
+	}
+	return pid, NoCompression
+}
+
+// compress compresses the payload using the given algorithm, empty payloads being left as is
+// since a zero length message is valid on the wire.
+func compress(compression Compression, payload []byte) ([]byte, error) {
+	if len(payload) == 0 {
+		return payload, nil
+	}
+
+	var compressed []byte
+	switch compression {
+	case NoCompression:
+		return payload, nil
+	case ZstdCompression:
+		compressed = zstdEncoder.EncodeAll(payload, nil)
+	case SnappyCompression:
+		compressed = snappy.Encode(nil, payload)
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownCompression, compression)
+	}
+
+	recordCompression(compression, directionSent, len(compressed), len(payload))
+	return compressed, nil
+}
+
+// decompress decompresses the payload using the given algorithm, failing if the
+// decompressed payload is larger than maxSize
+func decompress(compression Compression, payload []byte, maxSize uint64) ([]byte, error) {
+	var decompressed []byte
+	switch compression {
+	case NoCompression:
+		return payload, nil
+	case ZstdCompression:
+		var header zstd.Header
+		if err := header.Decode(payload); err != nil {
+			return nil, fmt.Errorf("decoding zstd frame header: %w", err)
+		}
+		if header.HasFCS && header.FrameContentSize > maxSize {
+			return nil, fmt.Errorf("%w: max %d, got %d",
+				errDecompressedSizeTooBig, maxSize, header.FrameContentSize)
+		}
+
+		var err error
+		decompressed, err = zstdDecoder.DecodeAll(payload, nil)
+		if err != nil {
+			return nil, fmt.Errorf("decompressing zstd payload: %w", err)
+		}
+	case SnappyCompression:
+		decodedLength, err := snappy.DecodedLen(payload)
+		if err != nil {
+			return nil, fmt.Errorf("decoding snappy length: %w", err)
+		}
+		if uint64(decodedLength) > maxSize {
+			return nil, fmt.Errorf("%w: max %d, got %d", errDecompressedSizeTooBig, maxSize, decodedLength)
+		}
+
+		decompressed, err = snappy.Decode(nil, payload)
+		if err != nil {
+			return nil, fmt.Errorf("decompressing snappy payload: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownCompression, compression)
+	}
+
+	if uint64(len(decompressed)) > maxSize {
+		return nil, fmt.Errorf("%w: max %d, got %d", errDecompressedSizeTooBig, maxSize, len(decompressed))
+	}
+
+	recordCompression(compression, directionReceived, len(payload), len(decompressed))
+	return decompressed, nil
+}
+
+func recordCompression(compression Compression, direction string, compressedSize, uncompressedSize int) {
+	algorithm := string(compression)
+	compressionBytesCounter.WithLabelValues(algorithm, direction).Add(float64(compressedSize))
+	uncompressedBytesCounter.WithLabelValues(algorithm, direction).Add(float64(uncompressedSize))
+	if uncompressedSize > compressedSize {
+		savedBytesCounter.WithLabelValues(algorithm, direction).Add(float64(uncompressedSize - compressedSize))
+	}
+}
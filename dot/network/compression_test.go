@@ -0,0 +1,182 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/network/messages"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_ParseCompression(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		name        string
+		compression Compression
+		errWrapped  error
+		errMessage  string
+	}{
+		"empty": {
+			compression: NoCompression,
+		},
+		"none": {
+			name:        "none",
+			compression: NoCompression,
+		},
+		"zstd_upper_case": {
+			name:        "ZSTD",
+			compression: ZstdCompression,
+		},
+		"snappy": {
+			name:        "snappy",
+			compression: SnappyCompression,
+		},
+		"unknown": {
+			name:       "gzip",
+			errWrapped: errUnknownCompression,
+			errMessage: "unknown compression algorithm: gzip",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			compression, err := ParseCompression(testCase.name)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.compression, compression)
+		})
+	}
+}
+
+func Test_splitCompression(t *testing.T) {
+	t.Parallel()
+
+	const pid = protocol.ID("/dot/sync/2")
+
+	for _, compression := range []Compression{NoCompression, ZstdCompression, SnappyCompression} {
+		protocolID, negotiated := splitCompression(compressedProtocolID(pid, compression))
+		assert.Equal(t, pid, protocolID)
+		assert.Equal(t, compression, negotiated)
+	}
+}
+
+func Test_compress_decompress(t *testing.T) {
+	t.Parallel()
+
+	payload := bytes.Repeat([]byte{1, 2, 3, 4}, 1024)
+
+	for _, compression := range supportedCompressions {
+		compression := compression
+		t.Run(string(compression), func(t *testing.T) {
+			t.Parallel()
+
+			compressed, err := compress(compression, payload)
+			require.NoError(t, err)
+			assert.Less(t, len(compressed), len(payload))
+
+			decompressed, err := decompress(compression, compressed, uint64(len(payload)))
+			require.NoError(t, err)
+			assert.Equal(t, payload, decompressed)
+
+			_, err = decompress(compression, compressed, uint64(len(payload)-1))
+			assert.ErrorIs(t, err, errDecompressedSizeTooBig)
+		})
+	}
+}
+
+// Test_compress_blockResponse measures the bandwidth saved by compressing the block
+// responses received during a full sync.
+func Test_compress_blockResponse(t *testing.T) {
+	t.Parallel()
+
+	response := &messages.BlockResponseMessage{}
+	parentHash := common.Hash{}
+	for number := uint(1); number <= 128; number++ {
+		header := types.NewHeader(parentHash, common.Hash{1}, common.Hash{2}, number, nil)
+		body := types.NewBody([]types.Extrinsic{
+			{0x28, 0x04, 0x03, 0x00, 0x0b, 0x70, 0x1b, 0x4f, 0x8c, 0x8e, 0x01},
+		})
+		response.BlockData = append(response.BlockData, &types.BlockData{
+			Hash:   header.Hash(),
+			Header: header,
+			Body:   body,
+		})
+		parentHash = header.Hash()
+	}
+
+	encoded, err := response.Encode()
+	require.NoError(t, err)
+
+	for _, compression := range supportedCompressions {
+		compressed, err := compress(compression, encoded)
+		require.NoError(t, err)
+		assert.Less(t, len(compressed), len(encoded))
+
+		t.Logf("%s: block response of %d bytes compressed to %d bytes, saving %.1f%%",
+			compression, len(encoded), len(compressed),
+			100*float64(len(encoded)-len(compressed))/float64(len(encoded)))
+	}
+}
+
+func Test_readMessage(t *testing.T) {
+	t.Parallel()
+
+	payload := bytes.Repeat([]byte{9}, 64)
+	compressed, err := compress(ZstdCompression, payload)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		protocolID protocol.ID
+		data       []byte
+		expected   []byte
+	}{
+		"uncompressed": {
+			protocolID: "/dot/sync/2",
+			data:       payload,
+			expected:   payload,
+		},
+		"zstd_compressed": {
+			protocolID: "/dot/sync/2/zstd",
+			data:       compressed,
+			expected:   payload,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			streamBuf := bytes.NewBuffer(Uint64ToLEB128(uint64(len(testCase.data))))
+			streamBuf.Write(testCase.data)
+
+			stream := NewMockStream(ctrl)
+			stream.EXPECT().Read(gomock.Any()).
+				DoAndReturn(func(buf []byte) (int, error) {
+					return streamBuf.Read(buf)
+				}).AnyTimes()
+			stream.EXPECT().Protocol().Return(testCase.protocolID).AnyTimes()
+
+			buf := make([]byte, 1)
+			n, err := readMessage(stream, &buf, 1024)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, buf[:n])
+		})
+	}
+}
@@ -12,8 +12,10 @@
 
 	"github.com/adrg/xdg"
 	"github.com/libp2p/go-libp2p/core/crypto"
+	ma "github.com/multiformats/go-multiaddr"
 
 	"github.com/ChainSafe/gossamer/dot/network/ratelimiters"
+	"github.com/ChainSafe/gossamer/internal/chaos"
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/internal/metrics"
 	"github.com/ChainSafe/gossamer/lib/common"
@@ -47,6 +49,9 @@
 	// DefaultDiscoveryInterval is the default interval for searching for DHT peers
 	DefaultDiscoveryInterval = time.Minute * 5
 
+	// DefaultBadgerGCDiscardRatio is the default value for Config.BadgerGCDiscardRatio
+	DefaultBadgerGCDiscardRatio = 0.2
+
 	defaultTxnBatchSize = 100
 )
 
@@ -68,13 +73,17 @@ type Config struct {
 	BlockState         BlockState
 	Syncer             Syncer
 	WarpSyncProvider   WarpSyncProvider
+	StateSyncProvider  StateSyncProvider
 	TransactionHandler TransactionHandler
 
-	// Used to specify the address broadcasted to other peers, and avoids using pubip.Get
+	// Used to specify the IPv4 or IPv6 address broadcasted to other peers, and avoids using pubip.Get
 	PublicIP string
 	// Used to specify the dns broadcasted to other peers, and avoids using pubip.Get.
 	// Only PublicIP or PublicDNS will be used
 	PublicDNS string
+	// PublicAddresses are the IPv4, IPv6 or DNS multiaddrs broadcasted to other peers in addition
+	// to PublicIP or PublicDNS, and avoid using pubip.Get
+	PublicAddresses []string
 	// Port the network port used for listening
 	Port uint16
 	// RandSeed the seed used to generate the network p2p identity (0 = non-deterministic random seed)
@@ -87,8 +96,10 @@ type Config struct {
 	NoBootstrap bool
 	// NoMDNS disables MDNS discovery
 	NoMDNS bool
-	// ListenAddress is the multiaddress to listen on
-	ListenAddress string
+	// ListenAddresses are the multiaddresses to listen on, defaulting to all the IPv4 interfaces on Port
+	ListenAddresses []string
+	// Compression is the compression offered when opening notification and request-response streams
+	Compression Compression
 
 	MinPeers int
 	MaxPeers int
@@ -98,12 +109,40 @@ type Config struct {
 	// PersistentPeers is a list of multiaddrs which the node should remain connected to
 	PersistentPeers []string
 
+	// ForceTxPropagation relays transactions to peers even if the node is not an authority
+	ForceTxPropagation bool
+
+	// MaxBandwidth is the soft limit of the outbound bandwidth in bytes per second, the transactions
+	// gossip being deferred while it is exceeded. 0 disables the limit.
+	MaxBandwidth uint64
+
+	// ResourceLimits are the limits of the libp2p resource manager
+	ResourceLimits ResourceLimits
+	// NoPrivateIPv4 prevents dialing the peers on private IPv4 addresses
+	NoPrivateIPv4 bool
+
+	// BadgerGCInterval is the interval of the value log garbage collection of the badger
+	// datastore persisting the peerstore. 0 disables the garbage collection.
+	BadgerGCInterval time.Duration
+	// BadgerGCDiscardRatio is the minimum ratio of stale data for a value log file to be
+	// rewritten by the garbage collection, defaulting to DefaultBadgerGCDiscardRatio
+	BadgerGCDiscardRatio float64
+	// BadgerGCPauseDuringSync skips the scheduled garbage collections while the node is syncing
+	BadgerGCPauseDuringSync bool
+
+	// Chaos drops some of the notifications received if it is set, for testing
+	Chaos *chaos.Injector
+
 	// NodeKey is the private hex encoded Ed25519 key to build the p2p identity
 	NodeKey string
 
 	// privateKey the private key for the network p2p identity
 	privateKey crypto.PrivKey
 
+	// listenAddrs and externalAddrs are the parsed listen and public addresses
+	listenAddrs   []ma.Multiaddr
+	externalAddrs []ma.Multiaddr
+
 	// telemetryInterval how often to send telemetry metrics
 	telemetryInterval time.Duration
 
@@ -148,6 +187,20 @@ func (c *Config) build() error {
 		return err
 	}
 
+	// build listen and public addresses configuration
+	err = c.buildAddresses()
+	if err != nil {
+		return err
+	}
+
+	if c.Compression == "" {
+		c.Compression = NoCompression
+	}
+
+	if c.BadgerGCDiscardRatio == 0 {
+		c.BadgerGCDiscardRatio = DefaultBadgerGCDiscardRatio
+	}
+
 	// check bootnoode configuration
 	if !c.NoBootstrap && len(c.Bootnodes) == 0 {
 		c.logger.Warn("Bootstrap is enabled but no bootstrap nodes are defined")
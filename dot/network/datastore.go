@@ -0,0 +1,128 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"runtime"
+	"time"
+
+	badger "github.com/ipfs/go-ds-badger4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// datastoreDir is the directory of the base path holding the libp2p datastore
+const datastoreDir = "libp2p-datastore"
+
+var (
+	datastoreLSMSizeGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "gossamer_network_datastore",
+		Name:      "lsm_size_bytes",
+		Help:      "size in bytes of the LSM tree of the badger datastore persisting the peerstore",
+	})
+
+	datastoreVlogSizeGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "gossamer_network_datastore",
+		Name:      "vlog_size_bytes",
+		Help:      "size in bytes of the value log of the badger datastore persisting the peerstore",
+	})
+
+	datastoreGCCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "gossamer_network_datastore",
+		Name:      "gc_runs_total",
+		Help:      "number of value log garbage collections of the badger datastore persisting the peerstore",
+	})
+)
+
+// newDatastore opens the badger datastore of the base path. Its value log garbage
+// collection is not run by the datastore itself, but scheduled by the network service.
+func newDatastore(basePath string, gcDiscardRatio float64) (*badger.Datastore, error) {
+	options := badger.DefaultOptions
+	options.GcInterval = 0
+	if gcDiscardRatio > 0 {
+		options.GcDiscardRatio = gcDiscardRatio
+	}
+	return badger.NewDatastore(path.Join(basePath, datastoreDir), &options)
+}
+
+// compactDatastore compacts the LSM tree of the datastore into its last level,
+// and rewrites the value log files holding stale data.
+func compactDatastore(ctx context.Context, ds *badger.Datastore) error {
+	err := ds.DB.Flatten(runtime.NumCPU())
+	if err != nil {
+		return fmt.Errorf("flattening LSM tree: %w", err)
+	}
+
+	err = ds.CollectGarbage(ctx)
+	if err != nil {
+		return fmt.Errorf("collecting value log garbage: %w", err)
+	}
+	datastoreGCCounter.Inc()
+	setDatastoreMetrics(ds)
+	return nil
+}
+
+// CompactDatastore compacts the libp2p datastore of the base path, for a stopped node.
+// The value log files holding more than the given ratio of stale data are rewritten.
+func CompactDatastore(basePath string, gcDiscardRatio float64) (err error) {
+	ds, err := newDatastore(basePath, gcDiscardRatio)
+	if err != nil {
+		return fmt.Errorf("opening libp2p datastore: %w", err)
+	}
+	defer func() {
+		closeErr := ds.Close()
+		if err == nil && closeErr != nil {
+			err = fmt.Errorf("closing libp2p datastore: %w", closeErr)
+		}
+	}()
+
+	return compactDatastore(context.Background(), ds)
+}
+
+// setDatastoreMetrics sets the sizes of the LSM tree and of the value log of the datastore.
+func setDatastoreMetrics(ds *badger.Datastore) {
+	lsm, vlog := ds.DB.Size()
+	datastoreLSMSizeGauge.Set(float64(lsm))
+	datastoreVlogSizeGauge.Set(float64(vlog))
+}
+
+// CompactDatastore compacts the libp2p datastore persisting the peerstore, and
+// rewrites its value log files holding stale data.
+func (s *Service) CompactDatastore() error {
+	return compactDatastore(s.ctx, s.host.ds)
+}
+
+// collectDatastoreGarbage runs the value log garbage collection of the datastore
+// every BadgerGCInterval, skipping it while the node is syncing if
+// BadgerGCPauseDuringSync is set, until the service is stopped.
+func (s *Service) collectDatastoreGarbage() {
+	ticker := time.NewTicker(s.cfg.BadgerGCInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if s.cfg.BadgerGCPauseDuringSync && !s.syncer.IsSynced() {
+			logger.Debug("skipping libp2p datastore garbage collection while syncing")
+			continue
+		}
+
+		start := time.Now()
+		err := s.host.ds.CollectGarbage(s.ctx)
+		if err != nil {
+			logger.Warnf("failed to collect libp2p datastore garbage: %s", err)
+			continue
+		}
+		datastoreGCCounter.Inc()
+		setDatastoreMetrics(s.host.ds)
+		logger.Debugf("collected libp2p datastore garbage in %s", time.Since(start))
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"context"
+	"testing"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactDatastore(t *testing.T) {
+	t.Parallel()
+
+	basePath := t.TempDir()
+	datastore, err := newDatastore(basePath, DefaultBadgerGCDiscardRatio)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	for _, key := range []string{"a", "b", "c"} {
+		err = datastore.Put(ctx, ds.NewKey(key), []byte("value"))
+		require.NoError(t, err)
+	}
+	err = datastore.Delete(ctx, ds.NewKey("b"))
+	require.NoError(t, err)
+	err = datastore.Close()
+	require.NoError(t, err)
+
+	err = CompactDatastore(basePath, DefaultBadgerGCDiscardRatio)
+	require.NoError(t, err)
+
+	datastore, err = newDatastore(basePath, DefaultBadgerGCDiscardRatio)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err := datastore.Close()
+		require.NoError(t, err)
+	})
+
+	value, err := datastore.Get(ctx, ds.NewKey("c"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+	_, err = datastore.Get(ctx, ds.NewKey("b"))
+	require.ErrorIs(t, err, ds.ErrNotFound)
+}
@@ -88,7 +88,7 @@ func (s *testStreamHandler) readStream(stream libp2pnetwork.Stream,
 	}()
 
 	for {
-		tot, err := readStream(stream, &msgBytes, MaxBlockResponseSize)
+		tot, err := readMessage(stream, &msgBytes, MaxBlockResponseSize)
 		if errors.Is(err, io.EOF) {
 			return
 		} else if err != nil {
@@ -231,6 +231,7 @@ func createTestService(t *testing.T, cfg *Config) (srvc *Service) {
 			Return(true, nil).AnyTimes()
 
 		th.EXPECT().TransactionsCount().Return(0).AnyTimes()
+		th.EXPECT().PendingTransactions().Return(nil).AnyTimes()
 		cfg.TransactionHandler = th
 	}
 
@@ -254,7 +255,7 @@ func createTestService(t *testing.T, cfg *Config) (srvc *Service) {
 			Return(nil).AnyTimes()
 
 		syncer.EXPECT().
-			CreateBlockResponse(gomock.Any(), gomock.Any()).
+			CreateBlockResponse(gomock.Any(), gomock.Any(), gomock.Any()).
 			Return(newTestBlockResponseMessage(t), nil).AnyTimes()
 
 		syncer.EXPECT().
@@ -8,9 +8,7 @@
 	"fmt"
 	"log"
 	"net"
-	"path"
-	"strconv"
-	"strings"
+	"slices"
 	"sync"
 	"time"
 
@@ -26,14 +24,13 @@
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/peerstore"
 	"github.com/libp2p/go-libp2p/core/protocol"
-	mempstore "github.com/libp2p/go-libp2p/p2p/host/peerstore/pstoremem"
-	rm "github.com/libp2p/go-libp2p/p2p/host/resource-manager"
+	"github.com/libp2p/go-libp2p/p2p/host/peerstore/pstoreds"
 	ma "github.com/multiformats/go-multiaddr"
-	"github.com/prometheus/client_golang/prometheus"
 )
 
-func newPrivateIPFilters() (privateIPs *ma.Filters, err error) {
-	privateCIDRs := []string{
+var (
+	// privateIPv4CIDRs are the private and reserved IPv4 ranges
+	privateIPv4CIDRs = []string{
 		"10.0.0.0/8",
 		"172.16.0.0/12",
 		"100.64.0.0/10",
@@ -41,19 +38,33 @@ func newPrivateIPFilters() (privateIPs *ma.Filters, err error) {
 		"192.168.0.0/16",
 		"169.254.0.0/16",
 	}
-	privateIPs = ma.NewFilters()
-	for _, cidr := range privateCIDRs {
+	// privateIPv6CIDRs are the unique local and link local IPv6 ranges
+	privateIPv6CIDRs = []string{
+		"fc00::/7",
+		"fe80::/10",
+	}
+)
+
+func newPrivateIPFilters() (privateIPs *ma.Filters, err error) {
+	return newDenyFilters(append(slices.Clone(privateIPv4CIDRs), privateIPv6CIDRs...))
+}
+
+// newDenyFilters returns the filters denying the addresses of the CIDRs given
+func newDenyFilters(cidrs []string) (filters *ma.Filters, err error) {
+	filters = ma.NewFilters()
+	for _, cidr := range cidrs {
 		_, ipnet, err := net.ParseCIDR(cidr)
 		if err != nil {
-			return privateIPs, err
+			return filters, err
 		}
-		privateIPs.AddFilter(*ipnet, ma.ActionDeny)
+		filters.AddFilter(*ipnet, ma.ActionDeny)
 	}
-	return
+	return filters, nil
 }
 
 var (
-	privateIPs *ma.Filters
+	privateIPs   *ma.Filters
+	privateIPv4s *ma.Filters
 )
 
 func init() {
@@ -62,6 +73,10 @@ func init() {
 	if err != nil {
 		log.Panic(err)
 	}
+	privateIPv4s, err = newDenyFilters(privateIPv4CIDRs)
+	if err != nil {
+		log.Panic(err)
+	}
 }
 
 const (
@@ -82,58 +97,29 @@ type host struct {
 	messageCache    *messageCache
 	bwc             *metrics.BandwidthCounter
 	closeSync       sync.Once
-	externalAddr    ma.Multiaddr
+	externalAddrs   []ma.Multiaddr
+	compression     Compression
 }
 
 func newHost(ctx context.Context, cfg *Config) (*host, error) {
-	// create multiaddress (without p2p identity)
-	listenAddress := fmt.Sprintf("/ip4/0.0.0.0/tcp/%d", cfg.Port)
-	if cfg.ListenAddress != "" {
-		listenAddress = cfg.ListenAddress
-	}
-	addr, err := ma.NewMultiaddr(listenAddress)
-	if err != nil {
-		return nil, err
-	}
-
-	portString, err := addr.ValueForProtocol(ma.P_TCP)
-	if err != nil {
-		return nil, err
-	}
-
-	port, err := strconv.ParseUint(portString, 10, 64)
-	if err != nil {
-		return nil, err
-	}
-	var externalAddr ma.Multiaddr
-
-	switch {
-	case strings.TrimSpace(cfg.PublicIP) != "":
-		ip := net.ParseIP(cfg.PublicIP)
-		if ip == nil {
-			return nil, fmt.Errorf("invalid public ip: %s", cfg.PublicIP)
-		}
-		logger.Debugf("using config PublicIP: %s", ip)
-		externalAddr, err = ma.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d", ip, port))
-		if err != nil {
-			return nil, err
-		}
-	case strings.TrimSpace(cfg.PublicDNS) != "":
-		logger.Debugf("using config PublicDNS: %s", cfg.PublicDNS)
-		externalAddr, err = ma.NewMultiaddr(fmt.Sprintf("/dns/%s/tcp/%d", cfg.PublicDNS, port))
-		if err != nil {
-			return nil, err
-		}
-	default:
+	externalAddrs := cfg.externalAddrs
+	if len(externalAddrs) > 0 {
+		logger.Debugf("using config public addresses: %s", externalAddrs)
+	} else {
 		ip, err := pubip.Get()
 		if err != nil {
 			logger.Errorf("failed to get public IP error: %v", err)
 		} else {
 			logger.Debugf("got public IP address %s", ip)
-			externalAddr, err = ma.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d", ip, port))
+			port, err := tcpPort(cfg.listenAddrs[0])
 			if err != nil {
 				return nil, err
 			}
+			externalAddr, err := publicIPAddress(ip.String(), port)
+			if err != nil {
+				return nil, err
+			}
+			externalAddrs = []ma.Multiaddr{externalAddr}
 		}
 	}
 
@@ -177,38 +163,27 @@ func newHost(ctx context.Context, cfg *Config) (*host, error) {
 	// format protocol id
 	pid := protocol.ID(cfg.ProtocolID)
 
-	ds, err := badger.NewDatastore(path.Join(cfg.BasePath, "libp2p-datastore"), &badger.DefaultOptions)
+	ds, err := newDatastore(cfg.BasePath, cfg.BadgerGCDiscardRatio)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create libp2p datastore: %w", err)
 	}
 
-	ps, err := mempstore.NewPeerstore()
+	// the peerstore is persisted in the libp2p datastore so the known peers
+	// survive restarts and we do not solely rely on the bootnodes
+	ps, err := pstoreds.NewPeerstore(ctx, ds, pstoreds.DefaultOpts())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create peerstore: %w", err)
 	}
 
-	limiter := rm.NewFixedLimiter(rm.DefaultLimits.AutoScale())
-	var managerOptions []rm.Option
-
-	if cfg.Metrics.Publish {
-		rm.MustRegisterWith(prometheus.DefaultRegisterer)
-		reporter, err := rm.NewStatsTraceReporter()
-		if err != nil {
-			return nil, fmt.Errorf("while creating resource manager stats trace reporter: %w", err)
-		}
-
-		managerOptions = append(managerOptions, rm.WithTraceReporter(reporter))
-	}
-
-	manager, err := rm.NewResourceManager(limiter, managerOptions...)
+	manager, err := newResourceManager(cfg.ResourceLimits, cfg.Metrics.Publish)
 	if err != nil {
-		return nil, fmt.Errorf("while creating the resource manager: %w", err)
+		return nil, err
 	}
 
 	// set libp2p host options
 	opts := []libp2p.Option{
 		libp2p.ResourceManager(manager),
-		libp2p.ListenAddrs(addr),
+		libp2p.ListenAddrs(cfg.listenAddrs...),
 		libp2p.DisableRelay(),
 		libp2p.Identity(cfg.privateKey),
 		libp2p.NATPortMap(),
@@ -221,13 +196,14 @@ func newHost(ctx context.Context, cfg *Config) (*host, error) {
 					addrs = append(addrs, addr)
 				}
 			}
-			if externalAddr == nil {
-				return addrs
-			}
-			return append(addrs, externalAddr)
+			return append(addrs, externalAddrs...)
 		}),
 	}
 
+	if cfg.NoPrivateIPv4 {
+		opts = append(opts, libp2p.ConnectionGater(newPrivateIPv4Gater()))
+	}
+
 	// create libp2p host instance
 	h, err := libp2p.New(opts...)
 	if err != nil {
@@ -262,7 +238,8 @@ func newHost(ctx context.Context, cfg *Config) (*host, error) {
 		persistentPeers: pps,
 		messageCache:    msgCache,
 		bwc:             bwc,
-		externalAddr:    externalAddr,
+		externalAddrs:   externalAddrs,
+		compression:     cfg.Compression,
 	}
 
 	cm.host = host
@@ -306,6 +283,33 @@ func (h *host) registerStreamHandler(pid protocol.ID, handler func(network.Strea
 	h.p2pHost.SetStreamHandler(pid, handler)
 }
 
+// registerCompressibleStreamHandler registers the stream handler for the given protocol ids
+// and for their compressed variants, so peers can negotiate any supported compression.
+func (h *host) registerCompressibleStreamHandler(handler func(network.Stream), pids ...protocol.ID) {
+	for _, pid := range pids {
+		h.registerStreamHandler(pid, handler)
+		for _, compression := range supportedCompressions {
+			h.registerStreamHandler(compressedProtocolID(pid, compression), handler)
+		}
+	}
+}
+
+// newStream opens a stream with the peer for the given protocol id, offering the
+// configured compression first and falling back on the uncompressed protocol.
+// The fallback protocol ids are offered in order after the protocol id, for peers
+// not supporting it.
+func (h *host) newStream(ctx context.Context, p peer.ID, pid protocol.ID,
+	fallbackPIDs ...protocol.ID) (network.Stream, error) {
+	pids := make([]protocol.ID, 0, 2*(1+len(fallbackPIDs)))
+	for _, id := range append([]protocol.ID{pid}, fallbackPIDs...) {
+		if h.compression != NoCompression && h.compression != "" {
+			pids = append(pids, compressedProtocolID(id, h.compression))
+		}
+		pids = append(pids, id)
+	}
+	return h.p2pHost.NewStream(ctx, p, pids...)
+}
+
 // connect connects the host to a specific peer address
 func (h *host) connect(p peer.AddrInfo) (err error) {
 	h.p2pHost.Peerstore().AddAddrs(p.ID, p.Addrs, peerstore.PermanentAddrTTL)
@@ -327,13 +331,17 @@ func (h *host) bootstrap() {
 		h.p2pHost.Peerstore().AddAddrs(addrInfo.ID, addrInfo.Addrs, peerstore.PermanentAddrTTL)
 		h.cm.peerSetHandler.AddPeer(0, addrInfo.ID)
 	}
+
+	h.restorePersistedPeers()
+	go h.rotateBootnodes()
 }
 
 // send creates a new outbound stream with the given peer and writes the message. It also returns
 // the newly created stream.
-func (h *host) send(p peer.ID, pid protocol.ID, msg messages.P2PMessage) (network.Stream, error) {
+func (h *host) send(p peer.ID, pid protocol.ID, msg messages.P2PMessage,
+	fallbackPIDs ...protocol.ID) (network.Stream, error) {
 	// open outbound stream with host protocol id
-	stream, err := h.p2pHost.NewStream(h.ctx, p, pid)
+	stream, err := h.newStream(h.ctx, p, pid, fallbackPIDs...)
 	if err != nil {
 		logger.Tracef("failed to open new stream with peer %s using protocol %s: %s", p, pid, err)
 		return nil, err
@@ -341,7 +349,7 @@ func (h *host) send(p peer.ID, pid protocol.ID, msg messages.P2PMessage) (networ
 
 	logger.Tracef(
 		"Opened stream with host %s, peer %s and protocol %s",
-		h.id(), p, pid)
+		h.id(), p, stream.Protocol())
 
 	err = h.writeToStream(stream, msg)
 	if err != nil {
@@ -350,7 +358,7 @@ func (h *host) send(p peer.ID, pid protocol.ID, msg messages.P2PMessage) (networ
 
 	logger.Tracef(
 		"Sent message %s to peer %s using protocol %s and host %s",
-		msg, p, pid, h.id())
+		msg, p, stream.Protocol(), h.id())
 
 	return stream, nil
 }
@@ -361,6 +369,18 @@ func (h *host) writeToStream(s network.Stream, msg messages.P2PMessage) error {
 		return err
 	}
 
+	return h.writeEncodedToStream(s, encMsg)
+}
+
+// writeEncodedToStream writes the already encoded message to the stream, prefixed with its length.
+// The message is compressed beforehand if a compression was negotiated for the stream protocol.
+func (h *host) writeEncodedToStream(s network.Stream, encMsg []byte) error {
+	_, compression := splitCompression(s.Protocol())
+	encMsg, err := compress(compression, encMsg)
+	if err != nil {
+		return err
+	}
+
 	msgLen := uint64(len(encMsg))
 	lenBytes := Uint64ToLEB128(msgLen)
 	encMsg = append(lenBytes, encMsg...)
@@ -374,11 +394,25 @@ func (h *host) writeToStream(s network.Stream, msg messages.P2PMessage) error {
 		logger.Errorf("full message not sent: sent %d, message size %d", sent, len(encMsg))
 	}
 
-	h.bwc.LogSentMessage(int64(sent))
+	h.logSent(s, sent)
 
 	return nil
 }
 
+// logSent accounts the bytes sent on the stream to its peer and sub-protocol
+func (h *host) logSent(s network.Stream, size int) {
+	protocolID, _ := splitCompression(s.Protocol())
+	h.bwc.LogSentMessage(int64(size))
+	h.bwc.LogSentMessageStream(int64(size), protocolID, s.Conn().RemotePeer())
+}
+
+// logReceived accounts the bytes received on the stream to its peer and sub-protocol
+func (h *host) logReceived(s network.Stream, size int) {
+	protocolID, _ := splitCompression(s.Protocol())
+	h.bwc.LogRecvMessage(int64(size))
+	h.bwc.LogRecvMessageStream(int64(size), protocolID, s.Conn().RemotePeer())
+}
+
 // id returns the host id
 func (h *host) id() peer.ID {
 	return h.p2pHost.ID()
@@ -424,8 +458,8 @@ func (h *host) removeReservedPeers(ids ...string) error {
 
 // supportsProtocol checks if the protocol is supported by peerID
 // returns an error if could not get peer protocols
-func (h *host) supportsProtocol(peerID peer.ID, protocol protocol.ID) (bool, error) {
-	peerProtocols, err := h.p2pHost.Peerstore().SupportsProtocols(peerID, protocol)
+func (h *host) supportsProtocol(peerID peer.ID, protocols ...protocol.ID) (bool, error) {
+	peerProtocols, err := h.p2pHost.Peerstore().SupportsProtocols(peerID, protocols...)
 	if err != nil {
 		return false, err
 	}
@@ -467,16 +501,16 @@ func (h *host) closePeer(peer peer.ID) error {
 	return h.p2pHost.Network().ClosePeer(peer)
 }
 
-func (h *host) closeProtocolStream(pID protocol.ID, p peer.ID) {
+func (h *host) closeProtocolStream(p peer.ID, pIDs ...protocol.ID) {
 	connToPeer := h.p2pHost.Network().ConnsToPeer(p)
 	for _, c := range connToPeer {
 		for _, st := range c.GetStreams() {
-			if st.Protocol() != pID {
+			if protocolID, _ := splitCompression(st.Protocol()); !slices.Contains(pIDs, protocolID) {
 				continue
 			}
 			err := st.Close()
 			if err != nil {
-				logger.Tracef("Failed to close stream for protocol %s: %s", pID, err)
+				logger.Tracef("Failed to close stream for protocol %s: %s", st.Protocol(), err)
 			}
 		}
 	}
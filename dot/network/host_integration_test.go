@@ -230,6 +230,195 @@ func TestSend(t *testing.T) {
 	require.Equal(t, testBlockReqMessage, msg[0])
 }
 
+func TestSendCompression(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		registerHandler func(h *host, handler *testStreamHandler)
+		protocolID      protocol.ID
+	}{
+		"compression_negotiated": {
+			registerHandler: func(h *host, handler *testStreamHandler) {
+				h.registerCompressibleStreamHandler(handler.handleStream, h.protocolID)
+			},
+			protocolID: TestProtocolID + "/zstd",
+		},
+		"fallback_on_uncompressed_protocol": {
+			registerHandler: func(h *host, handler *testStreamHandler) {
+				h.registerStreamHandler(h.protocolID, handler.handleStream)
+			},
+			protocolID: TestProtocolID,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			configA := &Config{
+				BasePath:    t.TempDir(),
+				Port:        availablePort(t),
+				NoBootstrap: true,
+				NoMDNS:      true,
+				Compression: ZstdCompression,
+			}
+
+			nodeA := createTestService(t, configA)
+			nodeA.noGossip = true
+
+			configB := &Config{
+				BasePath:    t.TempDir(),
+				Port:        availablePort(t),
+				NoBootstrap: true,
+				NoMDNS:      true,
+			}
+
+			nodeB := createTestService(t, configB)
+			nodeB.noGossip = true
+			handler := newTestStreamHandler(testBlockRequestMessageDecoder)
+			testCase.registerHandler(nodeB.host, handler)
+
+			addrInfoB := addrInfo(nodeB.host)
+			err := nodeA.host.connect(addrInfoB)
+			// retry connect if "failed to dial" error
+			if failedToDial(err) {
+				time.Sleep(TestBackoffTimeout)
+				err = nodeA.host.connect(addrInfoB)
+			}
+			require.NoError(t, err)
+
+			testBlockReqMessage := newTestBlockRequestMessage(t)
+			stream, err := nodeA.host.send(addrInfoB.ID, nodeB.host.protocolID, testBlockReqMessage)
+			require.NoError(t, err)
+			require.Equal(t, testCase.protocolID, stream.Protocol())
+
+			time.Sleep(TestMessageTimeout)
+
+			msg, ok := handler.messages[nodeA.host.id()]
+			require.True(t, ok)
+			require.Equal(t, 1, len(msg))
+			require.Equal(t, testBlockReqMessage, msg[0])
+		})
+	}
+}
+
+func TestSendFallbackProtocol(t *testing.T) {
+	t.Parallel()
+
+	const legacyProtocolID = protocol.ID("/legacy/sync/2")
+
+	testCases := map[string]struct {
+		protocolIDs []protocol.ID
+		protocolID  protocol.ID
+	}{
+		"protocol_negotiated": {
+			protocolIDs: []protocol.ID{TestProtocolID, legacyProtocolID},
+			protocolID:  TestProtocolID,
+		},
+		"fallback_on_legacy_protocol": {
+			protocolIDs: []protocol.ID{legacyProtocolID},
+			protocolID:  legacyProtocolID,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			configA := &Config{
+				BasePath:    t.TempDir(),
+				Port:        availablePort(t),
+				NoBootstrap: true,
+				NoMDNS:      true,
+			}
+
+			nodeA := createTestService(t, configA)
+			nodeA.noGossip = true
+
+			configB := &Config{
+				BasePath:    t.TempDir(),
+				Port:        availablePort(t),
+				NoBootstrap: true,
+				NoMDNS:      true,
+			}
+
+			nodeB := createTestService(t, configB)
+			nodeB.noGossip = true
+			handler := newTestStreamHandler(testBlockRequestMessageDecoder)
+			nodeB.host.registerCompressibleStreamHandler(handler.handleStream, testCase.protocolIDs...)
+
+			addrInfoB := addrInfo(nodeB.host)
+			err := nodeA.host.connect(addrInfoB)
+			// retry connect if "failed to dial" error
+			if failedToDial(err) {
+				time.Sleep(TestBackoffTimeout)
+				err = nodeA.host.connect(addrInfoB)
+			}
+			require.NoError(t, err)
+
+			testBlockReqMessage := newTestBlockRequestMessage(t)
+			stream, err := nodeA.host.send(addrInfoB.ID, TestProtocolID, testBlockReqMessage, legacyProtocolID)
+			require.NoError(t, err)
+			require.Equal(t, testCase.protocolID, stream.Protocol())
+
+			time.Sleep(TestMessageTimeout)
+
+			msg, ok := handler.messages[nodeA.host.id()]
+			require.True(t, ok)
+			require.Equal(t, 1, len(msg))
+			require.Equal(t, testBlockReqMessage, msg[0])
+		})
+	}
+}
+
+func TestNetworkTraffic(t *testing.T) {
+	t.Parallel()
+
+	configA := &Config{
+		BasePath:    t.TempDir(),
+		Port:        availablePort(t),
+		NoBootstrap: true,
+		NoMDNS:      true,
+	}
+
+	nodeA := createTestService(t, configA)
+	nodeA.noGossip = true
+
+	configB := &Config{
+		BasePath:    t.TempDir(),
+		Port:        availablePort(t),
+		NoBootstrap: true,
+		NoMDNS:      true,
+	}
+
+	nodeB := createTestService(t, configB)
+	nodeB.noGossip = true
+	handler := newTestStreamHandler(testBlockRequestMessageDecoder)
+	nodeB.host.registerStreamHandler(nodeB.host.protocolID, handler.handleStream)
+
+	addrInfoB := addrInfo(nodeB.host)
+	err := nodeA.host.connect(addrInfoB)
+	// retry connect if "failed to dial" error
+	if failedToDial(err) {
+		time.Sleep(TestBackoffTimeout)
+		err = nodeA.host.connect(addrInfoB)
+	}
+	require.NoError(t, err)
+
+	_, err = nodeA.host.send(addrInfoB.ID, nodeB.host.protocolID, newTestBlockRequestMessage(t))
+	require.NoError(t, err)
+
+	// the bandwidth counter totals are updated asynchronously
+	require.Eventually(t, func() bool {
+		traffic := nodeA.NetworkTraffic()
+		return traffic.Protocols[TestProtocolID].BytesOutbound > 0 &&
+			traffic.Peers[nodeB.host.id().String()].BytesOutbound > 0 &&
+			traffic.TotalBytesOutbound >= traffic.Protocols[TestProtocolID].BytesOutbound
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
 // test host send method with existing stream
 func TestExistingStream(t *testing.T) {
 	t.Parallel()
@@ -694,3 +883,53 @@ func TestPeerReputation(t *testing.T) {
 	require.NoError(t, err)
 	require.Greater(t, rep, int32(0))
 }
+
+func TestPersistedPeers(t *testing.T) {
+	t.Parallel()
+
+	configA := &Config{
+		BasePath:    t.TempDir(),
+		Port:        availablePort(t),
+		NoBootstrap: true,
+		NoMDNS:      true,
+		MinPeers:    1,
+		MaxPeers:    3,
+	}
+
+	nodeA := createTestService(t, configA)
+	nodeA.noGossip = true
+
+	configB := &Config{
+		BasePath:    t.TempDir(),
+		Port:        availablePort(t),
+		NoBootstrap: true,
+		NoMDNS:      true,
+		MinPeers:    1,
+		MaxPeers:    3,
+	}
+
+	nodeB := createTestService(t, configB)
+	nodeB.noGossip = true
+
+	addrInfoB := addrInfo(nodeB.host)
+	nodeA.host.p2pHost.Peerstore().AddAddrs(addrInfoB.ID, addrInfoB.Addrs, peerstore.PermanentAddrTTL)
+	nodeA.host.cm.peerSetHandler.AddPeer(0, addrInfoB.ID)
+
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, 1, nodeA.host.peerCount())
+
+	nodeA.host.cm.peerSetHandler.ReportPeer(peerset.ReputationChange{
+		Value:  peerset.GoodTransactionValue,
+		Reason: peerset.GoodTransactionReason,
+	}, addrInfoB.ID)
+
+	time.Sleep(100 * time.Millisecond)
+
+	nodeA.host.persistConnectedPeers()
+
+	persisted := nodeA.host.persistedPeers()
+	require.Len(t, persisted, 1)
+	require.Equal(t, addrInfoB.ID, persisted[0].id)
+	require.Greater(t, persisted[0].reputation, peerset.Reputation(0))
+	require.WithinDuration(t, time.Now(), persisted[0].lastSeen, time.Minute)
+}
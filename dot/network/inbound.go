@@ -4,6 +4,8 @@
 package network
 
 import (
+	"slices"
+
 	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
 )
 
@@ -20,7 +22,7 @@ func (s *Service) readStream(stream libp2pnetwork.Stream, decoder messageDecoder
 	defer s.bufPool.Put(buffer)
 
 	for {
-		n, err := readStream(stream, buffer, maxSize)
+		n, err := readMessage(stream, buffer, maxSize)
 		if err != nil {
 			logger.Tracef(
 				"failed to read from stream id %s of peer %s using protocol %s: %s",
@@ -49,19 +51,19 @@ func (s *Service) readStream(stream libp2pnetwork.Stream, decoder messageDecoder
 			return
 		}
 
-		s.host.bwc.LogRecvMessage(int64(n))
+		s.host.logReceived(stream, n)
 	}
 }
 
 func (s *Service) resetInboundStream(stream libp2pnetwork.Stream) {
-	protocolID := stream.Protocol()
+	protocolID, _ := splitCompression(stream.Protocol())
 	peerID := stream.Conn().RemotePeer()
 
 	s.notificationsMu.Lock()
 	defer s.notificationsMu.Unlock()
 
 	for _, prtl := range s.notificationsProtocols {
-		if prtl.protocolID != protocolID {
+		if !slices.Contains(prtl.protocolIDs(), protocolID) {
 			continue
 		}
 
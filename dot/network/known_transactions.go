@@ -0,0 +1,67 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	lrucache "github.com/ChainSafe/gossamer/lib/utils/lru-cache"
+)
+
+// maxKnownTransactionsPerPeer is the maximum number of transaction hashes
+// remembered for each connected peer.
+const maxKnownTransactionsPerPeer = 10240
+
+// knownTransactions keeps track, for each peer, of the transaction hashes
+// the peer is known to have, either because it sent them to us or because
+// we already sent them to it.
+type knownTransactions struct {
+	sync.RWMutex
+	capacity uint
+	peers    map[peer.ID]*lrucache.LRUCache[common.Hash, bool]
+}
+
+func newKnownTransactions(capacity uint) *knownTransactions {
+	return &knownTransactions{
+		capacity: capacity,
+		peers:    make(map[peer.ID]*lrucache.LRUCache[common.Hash, bool]),
+	}
+}
+
+// add marks the given transaction hashes as known by the peer.
+func (k *knownTransactions) add(peerID peer.ID, hashes ...common.Hash) {
+	k.Lock()
+	known, ok := k.peers[peerID]
+	if !ok {
+		known = lrucache.NewLRUCache[common.Hash, bool](k.capacity)
+		k.peers[peerID] = known
+	}
+	k.Unlock()
+
+	for _, hash := range hashes {
+		known.Put(hash, true)
+	}
+}
+
+// has returns true if the peer is known to have the given transaction.
+func (k *knownTransactions) has(peerID peer.ID, hash common.Hash) bool {
+	k.RLock()
+	known, ok := k.peers[peerID]
+	k.RUnlock()
+	if !ok {
+		return false
+	}
+
+	return known.Get(hash)
+}
+
+// remove forgets all the transactions known by the peer.
+func (k *knownTransactions) remove(peerID peer.ID) {
+	k.Lock()
+	defer k.Unlock()
+	delete(k.peers, peerID)
+}
@@ -0,0 +1,41 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+func Test_knownTransactions(t *testing.T) {
+	t.Parallel()
+
+	const (
+		peerA = peer.ID("a")
+		peerB = peer.ID("b")
+	)
+	hashA := common.Hash{1}
+	hashB := common.Hash{2}
+	hashC := common.Hash{3}
+
+	known := newKnownTransactions(2)
+	require.False(t, known.has(peerA, hashA))
+
+	known.add(peerA, hashA, hashB)
+	require.True(t, known.has(peerA, hashA))
+	require.True(t, known.has(peerA, hashB))
+	require.False(t, known.has(peerB, hashA))
+
+	// the least recently used hash is evicted once the capacity is reached
+	known.add(peerA, hashC)
+	require.False(t, known.has(peerA, hashA))
+	require.True(t, known.has(peerA, hashC))
+
+	known.remove(peerA)
+	require.False(t, known.has(peerA, hashC))
+}
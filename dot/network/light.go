@@ -4,17 +4,30 @@
 package network
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ChainSafe/gossamer/dot/network/messages"
+	"github.com/ChainSafe/gossamer/dot/peerset"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory/proof"
 
 	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
+// remoteReadTimeout is the timeout of the remote read requests sent to the peers
+const remoteReadTimeout = 20 * time.Second
+
+var (
+	ErrNoRemoteReadResponse = errors.New("no peer responded with a valid storage proof")
+
+	errNoRemoteReadProof = errors.New("remote read response has no proof")
+)
+
 // handleLightStream handles streams with the <protocol-id>/light/2 protocol ID
 func (s *Service) handleLightStream(stream libp2pnetwork.Stream) {
 	s.readStream(stream, s.decodeLightMessage, s.handleLightMsg, MaxBlockResponseSize)
@@ -462,3 +475,64 @@ func remoteReadChildResp(_ *RemoteReadChildRequest) (*RemoteReadResponse, error)
 func remoteReadResp(_ *RemoteReadRequest) (*RemoteReadResponse, error) {
 	return &RemoteReadResponse{}, nil
 }
+
+// ReadRemoteStorage returns the value at the given key in the state of the block given, and
+// the encoded trie nodes proving it against the given state root of the block. The value is
+// read from the connected peers using the remote read request of the light protocol, the
+// peers answering with an invalid proof being reported. A nil value is returned if the proof
+// shows the key is absent from the state.
+func (s *Service) ReadRemoteStorage(blockHash, stateRoot common.Hash, key []byte) (
+	value []byte, encodedProofNodes [][]byte, err error) {
+	s.remoteReadsOnce.Do(func() {
+		s.remoteReads = s.GetRequestResponseProtocol(LightID, remoteReadTimeout, MaxBlockResponseSize)
+	})
+
+	request := &messages.RemoteReadRequest{
+		Block: blockHash,
+		Keys:  [][]byte{key},
+	}
+
+	for _, peerID := range s.AllConnectedPeersIDs() {
+		response := new(messages.RemoteReadResponse)
+		err := s.remoteReads.Do(peerID, request, response)
+		if err != nil {
+			logger.Debugf("requesting storage proof from peer %s: %s", peerID, err)
+			continue
+		}
+
+		value, encodedProofNodes, err := readStorageProof(stateRoot, key, response)
+		if err != nil {
+			logger.Debugf("invalid storage proof from peer %s: %s", peerID, err)
+			s.ReportPeer(peerset.ReputationChange{
+				Value:  peerset.BadMessageValue,
+				Reason: peerset.BadMessageReason,
+			}, peerID)
+			continue
+		}
+
+		return value, encodedProofNodes, nil
+	}
+
+	return nil, nil, fmt.Errorf("%w: for key 0x%x at block %s", ErrNoRemoteReadResponse, key, blockHash)
+}
+
+// readStorageProof decodes the proof of the remote read response given and reads
+// the value at the given key from it, verifying it against the state root given.
+func readStorageProof(stateRoot common.Hash, key []byte, response *messages.RemoteReadResponse) (
+	value []byte, encodedProofNodes [][]byte, err error) {
+	if response.Proof == nil {
+		return nil, nil, errNoRemoteReadProof
+	}
+
+	err = scale.Unmarshal(response.Proof, &encodedProofNodes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decoding proof: %w", err)
+	}
+
+	value, err = proof.Read(encodedProofNodes, stateRoot.ToBytes(), key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading proof: %w", err)
+	}
+
+	return value, encodedProofNodes, nil
+}
@@ -115,7 +115,7 @@ func TestHandleLightMessage_Response(t *testing.T) {
 	}
 	require.NoError(t, err)
 
-	stream, err := s.host.p2pHost.NewStream(s.ctx, b.host.id(), s.host.protocolID+lightID)
+	stream, err := s.host.p2pHost.NewStream(s.ctx, b.host.id(), s.host.protocolID+LightID)
 	require.NoError(t, err)
 
 	// Testing empty request
@@ -0,0 +1,60 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/network/messages"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory/proof"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_readStorageProof(t *testing.T) {
+	t.Parallel()
+
+	tr := inmemory.NewEmptyTrie()
+	tr.Put([]byte("cat"), []byte("meow"))
+	tr.Put([]byte("dog"), []byte("woof"))
+
+	stateRoot, err := trie.V0.Hash(tr)
+	require.NoError(t, err)
+
+	db, err := database.NewPebble("", true)
+	require.NoError(t, err)
+	err = tr.WriteDirty(db)
+	require.NoError(t, err)
+
+	keys := [][]byte{[]byte("cat"), []byte("cow")}
+	witness, err := proof.GenerateWitness(stateRoot.ToBytes(), keys, nil, db)
+	require.NoError(t, err)
+
+	encodedProof, err := scale.Marshal(witness)
+	require.NoError(t, err)
+	response := &messages.RemoteReadResponse{Proof: encodedProof}
+
+	value, encodedProofNodes, err := readStorageProof(stateRoot, []byte("cat"), response)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("meow"), value)
+	assert.Equal(t, witness, encodedProofNodes)
+
+	value, _, err = readStorageProof(stateRoot, []byte("cow"), response)
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	_, _, err = readStorageProof(common.Hash{1}, []byte("cat"), response)
+	assert.ErrorIs(t, err, proof.ErrRootNodeNotFound)
+
+	_, _, err = readStorageProof(stateRoot, []byte("cat"), &messages.RemoteReadResponse{Proof: []byte{1}})
+	assert.ErrorContains(t, err, "decoding proof")
+
+	_, _, err = readStorageProof(stateRoot, []byte("cat"), &messages.RemoteReadResponse{})
+	assert.ErrorIs(t, err, errNoRemoteReadProof)
+}
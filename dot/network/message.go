@@ -17,6 +17,8 @@
 	blockAnnounceMsgType MessageType = iota + 3
 	transactionMsgType
 	ConsensusMsgType
+	// CollationMsgType is the type of the messages of the collation protocol of the parachain collators
+	CollationMsgType
 )
 
 // NotificationsMessage must be implemented by all messages sent over a notifications protocol
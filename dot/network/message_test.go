@@ -314,6 +314,45 @@ func TestDecodeConsensusMessage(t *testing.T) {
 	require.Equal(t, encMsg, encodedMessage)
 }
 
+func TestRemoteReadMessages(t *testing.T) {
+	t.Parallel()
+
+	request := &messages.RemoteReadRequest{
+		Block: common.Hash{1},
+		Keys:  [][]byte{[]byte("cat")},
+	}
+	encodedRequest, err := request.Encode()
+	require.NoError(t, err)
+
+	// remote_read_request with its block and keys fields
+	expectedRequest := append([]byte{0x12, 0x27, 0x12, 0x20}, common.Hash{1}.ToBytes()...)
+	expectedRequest = append(expectedRequest, 0x1a, 0x03, 'c', 'a', 't')
+	require.Equal(t, expectedRequest, encodedRequest)
+
+	decodedRequest := new(messages.RemoteReadRequest)
+	err = decodedRequest.Decode(encodedRequest)
+	require.NoError(t, err)
+	require.Equal(t, request, decodedRequest)
+
+	response := &messages.RemoteReadResponse{Proof: []byte{4, 1, 2}}
+	encodedResponse, err := response.Encode()
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x12, 0x05, 0x12, 0x03, 4, 1, 2}, encodedResponse)
+
+	decodedResponse := new(messages.RemoteReadResponse)
+	err = decodedResponse.Decode(encodedResponse)
+	require.NoError(t, err)
+	require.Equal(t, response, decodedResponse)
+
+	// a peer unable to answer sends a remote read response without proof
+	err = decodedResponse.Decode([]byte{0x12, 0x00})
+	require.NoError(t, err)
+	require.Nil(t, decodedResponse.Proof)
+
+	err = decodedResponse.Decode(nil)
+	require.ErrorIs(t, err, messages.ErrNotRemoteReadResponse)
+}
+
 func TestAscendingBlockRequest(t *testing.T) {
 	one := uint32(1)
 	three := uint32(3)
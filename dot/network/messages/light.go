@@ -0,0 +1,107 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package messages
+
+import (
+	"errors"
+	"fmt"
+
+	pb "github.com/ChainSafe/gossamer/dot/network/proto"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	_ P2PMessage = (*RemoteReadRequest)(nil)
+	_ P2PMessage = (*RemoteReadResponse)(nil)
+)
+
+var (
+	ErrNotRemoteReadRequest  = errors.New("light request is not a remote read request")
+	ErrNotRemoteReadResponse = errors.New("light response is not a remote read response")
+)
+
+// RemoteReadRequest is the light client request of the storage values at the given
+// keys in the state of the given block, proven by the trie nodes of the response.
+type RemoteReadRequest struct {
+	Block common.Hash
+	Keys  [][]byte
+}
+
+func (r *RemoteReadRequest) String() string {
+	return fmt.Sprintf("RemoteReadRequest Block=%s Keys=%#x", r.Block, r.Keys)
+}
+
+func (r *RemoteReadRequest) Encode() ([]byte, error) {
+	message := &pb.Request{
+		Request: &pb.Request_RemoteReadRequest{
+			RemoteReadRequest: &pb.RemoteReadRequest{
+				Block: r.Block.ToBytes(),
+				Keys:  make([][]byte, len(r.Keys)),
+			},
+		},
+	}
+	copy(message.GetRemoteReadRequest().Keys, r.Keys)
+	return proto.Marshal(message)
+}
+
+func (r *RemoteReadRequest) Decode(in []byte) error {
+	message := &pb.Request{}
+	err := proto.Unmarshal(in, message)
+	if err != nil {
+		return err
+	}
+
+	request := message.GetRemoteReadRequest()
+	if request == nil {
+		return ErrNotRemoteReadRequest
+	}
+
+	r.Block = common.BytesToHash(request.Block)
+	r.Keys = make([][]byte, len(request.Keys))
+	copy(r.Keys, request.Keys)
+	return nil
+}
+
+// RemoteReadResponse contains the SCALE encoded trie nodes proving the storage values
+// requested by a RemoteReadRequest. The proof is nil if the peer could not answer,
+// for example because the state of the block is pruned.
+type RemoteReadResponse struct {
+	Proof []byte
+}
+
+func (r *RemoteReadResponse) String() string {
+	return fmt.Sprintf("RemoteReadResponse Proof=%d bytes", len(r.Proof))
+}
+
+func (r *RemoteReadResponse) Encode() ([]byte, error) {
+	message := &pb.Response{
+		Response: &pb.Response_RemoteReadResponse{
+			RemoteReadResponse: &pb.RemoteReadResponse{
+				Proof: r.Proof,
+			},
+		},
+	}
+	return proto.Marshal(message)
+}
+
+func (r *RemoteReadResponse) Decode(in []byte) error {
+	message := &pb.Response{}
+	err := proto.Unmarshal(in, message)
+	if err != nil {
+		return err
+	}
+
+	response := message.GetRemoteReadResponse()
+	if response == nil {
+		return ErrNotRemoteReadResponse
+	}
+
+	r.Proof = nil
+	if response.Proof != nil {
+		r.Proof = make([]byte, len(response.Proof))
+		copy(r.Proof, response.Proof)
+	}
+	return nil
+}
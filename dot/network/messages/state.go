@@ -12,7 +12,10 @@
 	"google.golang.org/protobuf/proto"
 )
 
-var _ P2PMessage = (*StateRequest)(nil)
+var (
+	_ P2PMessage = (*StateRequest)(nil)
+	_ P2PMessage = (*StateResponse)(nil)
+)
 
 // StateRequest defines the parameters to request the state keys
 // and values from another peer
@@ -23,9 +26,9 @@ type StateRequest struct {
 }
 
 func (s *StateRequest) String() string {
-	return fmt.Sprintf("StateRequest Block=%s Start=[0x%x, 0x%x] NoProof=%v",
+	return fmt.Sprintf("StateRequest Block=%s Start=%#x NoProof=%v",
 		s.Block.String(),
-		s.Start[0], s.Start[1],
+		s.Start,
 		s.NoProof,
 	)
 }
@@ -54,17 +57,55 @@ func (s *StateRequest) Decode(in []byte) error {
 	return nil
 }
 
+// StateResponse contains the state entries, and optionally the proof,
+// answering a StateRequest
 type StateResponse struct {
 	Entries []KeyValueStateEntry
 	Proof   []byte
 }
 
+// KeyValueStateEntry contains the entries of a trie, the top trie having
+// an empty state root and the child tries having their own root
 type KeyValueStateEntry struct {
 	StateRoot    common.Hash
 	StateEntries trie.Entries
 	Complete     bool
 }
 
+func (s *StateResponse) String() string {
+	return fmt.Sprintf("StateResponse Entries=%d Proof=%d bytes", len(s.Entries), len(s.Proof))
+}
+
+func (s *StateResponse) Encode() ([]byte, error) {
+	message := &pb.StateResponse{
+		Entries: make([]*pb.KeyValueStateEntry, len(s.Entries)),
+		Proof:   s.Proof,
+	}
+
+	for idx, entry := range s.Entries {
+		encodedEntry := &pb.KeyValueStateEntry{
+			Entries:  make([]*pb.StateEntry, len(entry.StateEntries)),
+			Complete: entry.Complete,
+		}
+
+		// the top trie is identified by an empty state root
+		if entry.StateRoot != (common.Hash{}) {
+			encodedEntry.StateRoot = entry.StateRoot.ToBytes()
+		}
+
+		for stateEntryIdx, stateEntry := range entry.StateEntries {
+			encodedEntry.Entries[stateEntryIdx] = &pb.StateEntry{
+				Key:   stateEntry.Key,
+				Value: stateEntry.Value,
+			}
+		}
+
+		message.Entries[idx] = encodedEntry
+	}
+
+	return proto.Marshal(message)
+}
+
 func (s *StateResponse) Decode(in []byte) error {
 	decodedResponse := &pb.StateResponse{}
 	err := proto.Unmarshal(in, decodedResponse)
@@ -10,6 +10,7 @@
 package network
 
 import (
+	context "context"
 	reflect "reflect"
 
 	messages "github.com/ChainSafe/gossamer/dot/network/messages"
@@ -41,18 +42,18 @@ func (m *MockSyncer) EXPECT() *MockSyncerMockRecorder {
 }
 
 // CreateBlockResponse mocks base method.
-func (m *MockSyncer) CreateBlockResponse(arg0 peer.ID, arg1 *messages.BlockRequestMessage) (*messages.BlockResponseMessage, error) {
+func (m *MockSyncer) CreateBlockResponse(arg0 context.Context, arg1 peer.ID, arg2 *messages.BlockRequestMessage) (*messages.BlockResponseMessage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateBlockResponse", arg0, arg1)
+	ret := m.ctrl.Call(m, "CreateBlockResponse", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*messages.BlockResponseMessage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateBlockResponse indicates an expected call of CreateBlockResponse.
-func (mr *MockSyncerMockRecorder) CreateBlockResponse(arg0, arg1 any) *gomock.Call {
+func (mr *MockSyncerMockRecorder) CreateBlockResponse(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBlockResponse", reflect.TypeOf((*MockSyncer)(nil).CreateBlockResponse), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBlockResponse", reflect.TypeOf((*MockSyncer)(nil).CreateBlockResponse), arg0, arg1, arg2)
 }
 
 // HandleBlockAnnounce mocks base method.
@@ -12,6 +12,7 @@
 import (
 	reflect "reflect"
 
+	types "github.com/ChainSafe/gossamer/dot/types"
 	peer "github.com/libp2p/go-libp2p/core/peer"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -54,6 +55,20 @@ func (mr *MockTransactionHandlerMockRecorder) HandleTransactionMessage(arg0, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleTransactionMessage", reflect.TypeOf((*MockTransactionHandler)(nil).HandleTransactionMessage), arg0, arg1)
 }
 
+// PendingTransactions mocks base method.
+func (m *MockTransactionHandler) PendingTransactions() []types.Extrinsic {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingTransactions")
+	ret0, _ := ret[0].([]types.Extrinsic)
+	return ret0
+}
+
+// PendingTransactions indicates an expected call of PendingTransactions.
+func (mr *MockTransactionHandlerMockRecorder) PendingTransactions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingTransactions", reflect.TypeOf((*MockTransactionHandler)(nil).PendingTransactions))
+}
+
 // TransactionsCount mocks base method.
 func (m *MockTransactionHandler) TransactionsCount() int {
 	m.ctrl.T.Helper()
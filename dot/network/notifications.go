@@ -57,26 +57,34 @@ type handshakeReader struct {
 }
 
 type notificationsProtocol struct {
-	protocolID         protocol.ID
-	getHandshake       HandshakeGetter
-	handshakeDecoder   HandshakeDecoder
-	handshakeValidator HandshakeValidator
-	peersData          *peersData
-	maxSize            uint64
+	protocolID          protocol.ID
+	fallbackProtocolIDs []protocol.ID
+	getHandshake        HandshakeGetter
+	handshakeDecoder    HandshakeDecoder
+	handshakeValidator  HandshakeValidator
+	peersData           *peersData
+	maxSize             uint64
 }
 
-func newNotificationsProtocol(protocolID protocol.ID, handshakeGetter HandshakeGetter,
-	handshakeDecoder HandshakeDecoder, handshakeValidator HandshakeValidator, maxSize uint64) *notificationsProtocol {
+func newNotificationsProtocol(protocolID protocol.ID, fallbackProtocolIDs []protocol.ID,
+	handshakeGetter HandshakeGetter, handshakeDecoder HandshakeDecoder,
+	handshakeValidator HandshakeValidator, maxSize uint64) *notificationsProtocol {
 	return &notificationsProtocol{
-		protocolID:         protocolID,
-		getHandshake:       handshakeGetter,
-		handshakeValidator: handshakeValidator,
-		handshakeDecoder:   handshakeDecoder,
-		peersData:          newPeersData(),
-		maxSize:            maxSize,
+		protocolID:          protocolID,
+		fallbackProtocolIDs: fallbackProtocolIDs,
+		getHandshake:        handshakeGetter,
+		handshakeValidator:  handshakeValidator,
+		handshakeDecoder:    handshakeDecoder,
+		peersData:           newPeersData(),
+		maxSize:             maxSize,
 	}
 }
 
+// protocolIDs returns the protocol id followed by the fallback protocol ids of the protocol
+func (n *notificationsProtocol) protocolIDs() []protocol.ID {
+	return append([]protocol.ID{n.protocolID}, n.fallbackProtocolIDs...)
+}
+
 type handshakeData struct {
 	received  bool
 	validated bool
@@ -150,6 +158,12 @@ func (s *Service) createNotificationsMessageHandler(
 			return fmt.Errorf("%w: expected %T but got %T", errMessageTypeNotValid, (NotificationsMessage)(nil), msg)
 		}
 
+		if s.chaos.DropMessage() {
+			logger.Tracef("chaos: dropping message on notifications sub-protocol %s from peer %s",
+				info.protocolID, peer)
+			return nil
+		}
+
 		hasSeen, err := s.gossip.hasSeen(msg)
 		if err != nil {
 			return fmt.Errorf("could not check if message was seen before: %w", err)
@@ -251,7 +265,7 @@ func (s *Service) sendData(peer peer.ID, hs Handshake, info *notificationsProtoc
 		return
 	}
 
-	support, err := s.host.supportsProtocol(peer, info.protocolID)
+	support, err := s.host.supportsProtocol(peer, info.protocolIDs()...)
 	if err != nil {
 		logger.Errorf("could not check if protocol %s is supported by peer %s: %s", info.protocolID, peer, err)
 		return
@@ -342,7 +356,7 @@ func (s *Service) sendHandshake(peer peer.ID, hs Handshake, info *notificationsP
 
 	logger.Tracef("sending outbound handshake to peer %s on protocol %s, message: %s",
 		peer, info.protocolID, hs)
-	stream, err := s.host.send(peer, info.protocolID, hs)
+	stream, err := s.host.send(peer, info.protocolID, hs, info.fallbackProtocolIDs...)
 	if err != nil {
 		logger.Tracef("failed to send handshake to peer %s: %s", peer, err)
 		// don't need to close the stream here, as it's nil!
@@ -433,12 +447,14 @@ func (s *Service) readHandshake(stream network.Stream, decoder HandshakeDecoder,
 		buffer := s.bufPool.Get().(*[]byte)
 		defer s.bufPool.Put(buffer)
 
-		tot, err := readStream(stream, buffer, maxSize)
+		tot, err := readMessage(stream, buffer, maxSize)
 		if err != nil {
 			hsC <- &handshakeReader{hs: nil, err: err}
 			return
 		}
 
+		s.host.logReceived(stream, tot)
+
 		msgBytes := *buffer
 		hs, err := decoder(msgBytes[:tot])
 		if err != nil {
@@ -0,0 +1,180 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// lastSeenMetadataKey is the peerstore metadata key of the unix time
+	// a peer was last connected to us
+	lastSeenMetadataKey = "gossamer/last-seen"
+	// reputationMetadataKey is the peerstore metadata key of the reputation
+	// of a peer when it was last connected to us
+	reputationMetadataKey = "gossamer/reputation"
+
+	// peerPersistInterval is how often the connected peers are persisted, so their last
+	// seen time and reputation are not lost if the node is not stopped gracefully
+	peerPersistInterval = 5 * time.Minute
+	// persistedPeerMaxAge is the maximum age of the last connection to a
+	// persisted peer for it to be restored on start
+	persistedPeerMaxAge = 7 * 24 * time.Hour
+
+	// restoredReputationReason is the reason of the reputation change
+	// restoring the persisted reputation of a peer
+	restoredReputationReason = "Restored reputation"
+
+	// bootnodeDialInitialBackoff is the time waited before dialing a bootnode when
+	// the host is not connected to any peer, doubling after each failed dial
+	bootnodeDialInitialBackoff = connectTimeout
+	// bootnodeDialMaxBackoff is the maximum time waited between two bootnode dials
+	bootnodeDialMaxBackoff = 5 * time.Minute
+)
+
+// persistedPeer is a peer stored in the peerstore by a previous run of the node
+type persistedPeer struct {
+	id         peer.ID
+	lastSeen   time.Time
+	reputation peerset.Reputation
+}
+
+// persistPeer stores the current time and the reputation of the peer in the
+// peerstore metadata, which is persisted in the libp2p datastore.
+func (h *host) persistPeer(p peer.ID) {
+	ps := h.p2pHost.Peerstore()
+	err := ps.Put(p, lastSeenMetadataKey, time.Now().Unix())
+	if err != nil {
+		logger.Debugf("storing last seen time of peer %s: %s", p, err)
+		return
+	}
+
+	reputation, err := h.cm.peerSetHandler.PeerReputation(p)
+	if err != nil {
+		// the peer is not part of the peer set
+		return
+	}
+
+	err = ps.Put(p, reputationMetadataKey, int32(reputation))
+	if err != nil {
+		logger.Debugf("storing reputation of peer %s: %s", p, err)
+	}
+}
+
+// persistConnectedPeers persists the peers connected to the host
+func (h *host) persistConnectedPeers() {
+	for _, p := range h.peers() {
+		h.persistPeer(p)
+	}
+}
+
+// persistedPeers returns the peers of the peerstore with known addresses which were
+// connected to us recently, most recently seen first.
+func (h *host) persistedPeers() []persistedPeer {
+	ps := h.p2pHost.Peerstore()
+	now := time.Now()
+
+	peers := make([]persistedPeer, 0)
+	for _, p := range ps.PeersWithAddrs() {
+		if p == h.id() {
+			continue
+		}
+
+		lastSeenValue, err := ps.Get(p, lastSeenMetadataKey)
+		if err != nil {
+			continue
+		}
+
+		lastSeenUnix, ok := lastSeenValue.(int64)
+		if !ok {
+			continue
+		}
+
+		lastSeen := time.Unix(lastSeenUnix, 0)
+		if now.Sub(lastSeen) > persistedPeerMaxAge {
+			continue
+		}
+
+		persisted := persistedPeer{id: p, lastSeen: lastSeen}
+		reputationValue, err := ps.Get(p, reputationMetadataKey)
+		if err == nil {
+			reputation, ok := reputationValue.(int32)
+			if ok {
+				persisted.reputation = peerset.Reputation(reputation)
+			}
+		}
+
+		peers = append(peers, persisted)
+	}
+
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].lastSeen.After(peers[j].lastSeen)
+	})
+
+	return peers
+}
+
+// restorePersistedPeers adds the recently seen peers of the persisted peerstore
+// to the peer set, restoring their reputation.
+func (h *host) restorePersistedPeers() {
+	peers := h.persistedPeers()
+	if len(peers) > h.cm.maxPeers {
+		peers = peers[:h.cm.maxPeers]
+	}
+
+	for _, p := range peers {
+		h.cm.peerSetHandler.AddPeer(0, p.id)
+		if p.reputation != 0 {
+			h.cm.peerSetHandler.ReportPeer(peerset.ReputationChange{
+				Value:  p.reputation,
+				Reason: restoredReputationReason,
+			}, p.id)
+		}
+	}
+
+	logger.Debugf("restored %d peers from the peerstore", len(peers))
+}
+
+// rotateBootnodes dials the bootnodes one after the other whenever the host is not
+// connected to any peer, waiting an exponentially increasing time between two failed
+// dials, until the host is closed.
+func (h *host) rotateBootnodes() {
+	if len(h.bootnodes) == 0 {
+		return
+	}
+
+	backoff := bootnodeDialInitialBackoff
+	timer := time.NewTimer(backoff)
+	defer timer.Stop()
+
+	for idx := 0; ; {
+		select {
+		case <-h.ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		if h.peerCount() > 0 {
+			backoff = bootnodeDialInitialBackoff
+			timer.Reset(backoff)
+			continue
+		}
+
+		bootnode := h.bootnodes[idx]
+		idx = (idx + 1) % len(h.bootnodes)
+		err := h.connect(bootnode)
+		if err == nil {
+			logger.Infof("connected to bootnode %s", bootnode.ID)
+			backoff = bootnodeDialInitialBackoff
+		} else {
+			backoff = min(2*backoff, bootnodeDialMaxBackoff)
+			logger.Warnf("failed to dial bootnode %s, dialing next bootnode in %s: %s", bootnode.ID, backoff, err)
+		}
+		timer.Reset(backoff)
+	}
+}
@@ -0,0 +1,713 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Schema definition for light client messages.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v4.24.4
+// source: light.v1.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Enumerate all possible light client request messages.
+type Request struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Request:
+	//	*Request_RemoteCallRequest
+	//	*Request_RemoteReadRequest
+	//	*Request_RemoteReadChildRequest
+	Request isRequest_Request `protobuf_oneof:"request"`
+}
+
+func (x *Request) Reset() {
+	*x = Request{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_light_v1_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Request) ProtoMessage() {}
+
+func (x *Request) ProtoReflect() protoreflect.Message {
+	mi := &file_light_v1_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Request.ProtoReflect.Descriptor instead.
+func (*Request) Descriptor() ([]byte, []int) {
+	return file_light_v1_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *Request) GetRequest() isRequest_Request {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (x *Request) GetRemoteCallRequest() *RemoteCallRequest {
+	if x, ok := x.GetRequest().(*Request_RemoteCallRequest); ok {
+		return x.RemoteCallRequest
+	}
+	return nil
+}
+
+func (x *Request) GetRemoteReadRequest() *RemoteReadRequest {
+	if x, ok := x.GetRequest().(*Request_RemoteReadRequest); ok {
+		return x.RemoteReadRequest
+	}
+	return nil
+}
+
+func (x *Request) GetRemoteReadChildRequest() *RemoteReadChildRequest {
+	if x, ok := x.GetRequest().(*Request_RemoteReadChildRequest); ok {
+		return x.RemoteReadChildRequest
+	}
+	return nil
+}
+
+type isRequest_Request interface {
+	isRequest_Request()
+}
+
+type Request_RemoteCallRequest struct {
+	RemoteCallRequest *RemoteCallRequest `protobuf:"bytes,1,opt,name=remote_call_request,json=remoteCallRequest,oneof"`
+}
+
+type Request_RemoteReadRequest struct {
+	RemoteReadRequest *RemoteReadRequest `protobuf:"bytes,2,opt,name=remote_read_request,json=remoteReadRequest,oneof"`
+}
+
+type Request_RemoteReadChildRequest struct {
+	RemoteReadChildRequest *RemoteReadChildRequest `protobuf:"bytes,4,opt,name=remote_read_child_request,json=remoteReadChildRequest,oneof"` // Note: ids 3 and 5 were used in the past. It would be preferable to not re-use them.
+}
+
+func (*Request_RemoteCallRequest) isRequest_Request() {}
+
+func (*Request_RemoteReadRequest) isRequest_Request() {}
+
+func (*Request_RemoteReadChildRequest) isRequest_Request() {}
+
+// Enumerate all possible light client response messages.
+type Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Response:
+	//	*Response_RemoteCallResponse
+	//	*Response_RemoteReadResponse
+	Response isResponse_Response `protobuf_oneof:"response"`
+}
+
+func (x *Response) Reset() {
+	*x = Response{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_light_v1_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Response) ProtoMessage() {}
+
+func (x *Response) ProtoReflect() protoreflect.Message {
+	mi := &file_light_v1_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Response.ProtoReflect.Descriptor instead.
+func (*Response) Descriptor() ([]byte, []int) {
+	return file_light_v1_proto_rawDescGZIP(), []int{1}
+}
+
+func (m *Response) GetResponse() isResponse_Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (x *Response) GetRemoteCallResponse() *RemoteCallResponse {
+	if x, ok := x.GetResponse().(*Response_RemoteCallResponse); ok {
+		return x.RemoteCallResponse
+	}
+	return nil
+}
+
+func (x *Response) GetRemoteReadResponse() *RemoteReadResponse {
+	if x, ok := x.GetResponse().(*Response_RemoteReadResponse); ok {
+		return x.RemoteReadResponse
+	}
+	return nil
+}
+
+type isResponse_Response interface {
+	isResponse_Response()
+}
+
+type Response_RemoteCallResponse struct {
+	RemoteCallResponse *RemoteCallResponse `protobuf:"bytes,1,opt,name=remote_call_response,json=remoteCallResponse,oneof"`
+}
+
+type Response_RemoteReadResponse struct {
+	RemoteReadResponse *RemoteReadResponse `protobuf:"bytes,2,opt,name=remote_read_response,json=remoteReadResponse,oneof"` // Note: ids 3 and 4 were used in the past. It would be preferable to not re-use them.
+}
+
+func (*Response_RemoteCallResponse) isResponse_Response() {}
+
+func (*Response_RemoteReadResponse) isResponse_Response() {}
+
+// Remote call request.
+type RemoteCallRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Block at which to perform call.
+	Block []byte `protobuf:"bytes,2,req,name=block" json:"block,omitempty"`
+	// Method name.
+	Method *string `protobuf:"bytes,3,req,name=method" json:"method,omitempty"`
+	// Call data.
+	Data []byte `protobuf:"bytes,4,req,name=data" json:"data,omitempty"`
+}
+
+func (x *RemoteCallRequest) Reset() {
+	*x = RemoteCallRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_light_v1_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoteCallRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteCallRequest) ProtoMessage() {}
+
+func (x *RemoteCallRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_light_v1_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteCallRequest.ProtoReflect.Descriptor instead.
+func (*RemoteCallRequest) Descriptor() ([]byte, []int) {
+	return file_light_v1_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RemoteCallRequest) GetBlock() []byte {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+func (x *RemoteCallRequest) GetMethod() string {
+	if x != nil && x.Method != nil {
+		return *x.Method
+	}
+	return ""
+}
+
+func (x *RemoteCallRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// Remote call response.
+type RemoteCallResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Execution proof. If missing, indicates that the remote couldn't answer, for example because
+	// the block is pruned.
+	Proof []byte `protobuf:"bytes,2,opt,name=proof" json:"proof,omitempty"`
+}
+
+func (x *RemoteCallResponse) Reset() {
+	*x = RemoteCallResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_light_v1_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoteCallResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteCallResponse) ProtoMessage() {}
+
+func (x *RemoteCallResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_light_v1_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteCallResponse.ProtoReflect.Descriptor instead.
+func (*RemoteCallResponse) Descriptor() ([]byte, []int) {
+	return file_light_v1_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RemoteCallResponse) GetProof() []byte {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+// Remote storage read request.
+type RemoteReadRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Block at which to perform call.
+	Block []byte `protobuf:"bytes,2,req,name=block" json:"block,omitempty"`
+	// Storage keys.
+	Keys [][]byte `protobuf:"bytes,3,rep,name=keys" json:"keys,omitempty"`
+}
+
+func (x *RemoteReadRequest) Reset() {
+	*x = RemoteReadRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_light_v1_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoteReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteReadRequest) ProtoMessage() {}
+
+func (x *RemoteReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_light_v1_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteReadRequest.ProtoReflect.Descriptor instead.
+func (*RemoteReadRequest) Descriptor() ([]byte, []int) {
+	return file_light_v1_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RemoteReadRequest) GetBlock() []byte {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+func (x *RemoteReadRequest) GetKeys() [][]byte {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+// Remote read response.
+type RemoteReadResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Read proof. If missing, indicates that the remote couldn't answer, for example because
+	// the block is pruned.
+	Proof []byte `protobuf:"bytes,2,opt,name=proof" json:"proof,omitempty"`
+}
+
+func (x *RemoteReadResponse) Reset() {
+	*x = RemoteReadResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_light_v1_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoteReadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteReadResponse) ProtoMessage() {}
+
+func (x *RemoteReadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_light_v1_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteReadResponse.ProtoReflect.Descriptor instead.
+func (*RemoteReadResponse) Descriptor() ([]byte, []int) {
+	return file_light_v1_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RemoteReadResponse) GetProof() []byte {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+// Remote storage read child request.
+type RemoteReadChildRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Block at which to perform call.
+	Block []byte `protobuf:"bytes,2,req,name=block" json:"block,omitempty"`
+	// Child Storage key, this is relative
+	// to the child type storage location.
+	StorageKey []byte `protobuf:"bytes,3,req,name=storage_key,json=storageKey" json:"storage_key,omitempty"`
+	// Storage keys.
+	Keys [][]byte `protobuf:"bytes,6,rep,name=keys" json:"keys,omitempty"`
+}
+
+func (x *RemoteReadChildRequest) Reset() {
+	*x = RemoteReadChildRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_light_v1_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoteReadChildRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoteReadChildRequest) ProtoMessage() {}
+
+func (x *RemoteReadChildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_light_v1_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoteReadChildRequest.ProtoReflect.Descriptor instead.
+func (*RemoteReadChildRequest) Descriptor() ([]byte, []int) {
+	return file_light_v1_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RemoteReadChildRequest) GetBlock() []byte {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+func (x *RemoteReadChildRequest) GetStorageKey() []byte {
+	if x != nil {
+		return x.StorageKey
+	}
+	return nil
+}
+
+func (x *RemoteReadChildRequest) GetKeys() [][]byte {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+var File_light_v1_proto protoreflect.FileDescriptor
+
+var file_light_v1_proto_rawDesc = []byte{
+	0x0a, 0x0e, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x0c, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x22, 0x9d,
+	0x02, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x51, 0x0a, 0x13, 0x72, 0x65,
+	0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x63, 0x61, 0x6c, 0x6c, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x61, 0x6c,
+	0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x11, 0x72, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x51, 0x0a,
+	0x13, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65,
+	0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x11, 0x72,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x61, 0x0a, 0x19, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x5f,
+	0x63, 0x68, 0x69, 0x6c, 0x64, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x6c, 0x69, 0x67,
+	0x68, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x61, 0x64, 0x43, 0x68, 0x69,
+	0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x16, 0x72, 0x65, 0x6d,
+	0x6f, 0x74, 0x65, 0x52, 0x65, 0x61, 0x64, 0x43, 0x68, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x42, 0x09, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xc2,
+	0x01, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x54, 0x0a, 0x14, 0x72,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x63, 0x61, 0x6c, 0x6c, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43,
+	0x61, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x12, 0x72,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x54, 0x0a, 0x14, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x72, 0x65, 0x61, 0x64,
+	0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x20, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x2e, 0x52,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x48, 0x00, 0x52, 0x12, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x61, 0x64, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x55, 0x0a, 0x11, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x61, 0x6c,
+	0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x18, 0x02, 0x20, 0x02, 0x28, 0x0c, 0x52, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x16,
+	0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x03, 0x20, 0x02, 0x28, 0x09, 0x52, 0x06,
+	0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04,
+	0x20, 0x02, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x2a, 0x0a, 0x12, 0x52, 0x65,
+	0x6d, 0x6f, 0x74, 0x65, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x3d, 0x0a, 0x11, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65,
+	0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x02, 0x28, 0x0c, 0x52, 0x05, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52,
+	0x04, 0x6b, 0x65, 0x79, 0x73, 0x22, 0x2a, 0x0a, 0x12, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x52,
+	0x65, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70,
+	0x72, 0x6f, 0x6f, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x6f,
+	0x66, 0x22, 0x63, 0x0a, 0x16, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x61, 0x64, 0x43,
+	0x68, 0x69, 0x6c, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x02, 0x28, 0x0c, 0x52, 0x05, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x6b, 0x65, 0x79,
+	0x18, 0x03, 0x20, 0x02, 0x28, 0x0c, 0x52, 0x0a, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x4b,
+	0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x53, 0x61, 0x66, 0x65, 0x2f, 0x67,
+	0x6f, 0x73, 0x73, 0x61, 0x6d, 0x65, 0x72, 0x2f, 0x64, 0x6f, 0x74, 0x2f, 0x6e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x32,
+}
+
+var (
+	file_light_v1_proto_rawDescOnce sync.Once
+	file_light_v1_proto_rawDescData = file_light_v1_proto_rawDesc
+)
+
+func file_light_v1_proto_rawDescGZIP() []byte {
+	file_light_v1_proto_rawDescOnce.Do(func() {
+		file_light_v1_proto_rawDescData = protoimpl.X.CompressGZIP(file_light_v1_proto_rawDescData)
+	})
+	return file_light_v1_proto_rawDescData
+}
+
+var file_light_v1_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_light_v1_proto_goTypes = []interface{}{
+	(*Request)(nil),                // 0: api.v1.light.Request
+	(*Response)(nil),               // 1: api.v1.light.Response
+	(*RemoteCallRequest)(nil),      // 2: api.v1.light.RemoteCallRequest
+	(*RemoteCallResponse)(nil),     // 3: api.v1.light.RemoteCallResponse
+	(*RemoteReadRequest)(nil),      // 4: api.v1.light.RemoteReadRequest
+	(*RemoteReadResponse)(nil),     // 5: api.v1.light.RemoteReadResponse
+	(*RemoteReadChildRequest)(nil), // 6: api.v1.light.RemoteReadChildRequest
+}
+var file_light_v1_proto_depIdxs = []int32{
+	2, // 0: api.v1.light.Request.remote_call_request:type_name -> api.v1.light.RemoteCallRequest
+	4, // 1: api.v1.light.Request.remote_read_request:type_name -> api.v1.light.RemoteReadRequest
+	6, // 2: api.v1.light.Request.remote_read_child_request:type_name -> api.v1.light.RemoteReadChildRequest
+	3, // 3: api.v1.light.Response.remote_call_response:type_name -> api.v1.light.RemoteCallResponse
+	5, // 4: api.v1.light.Response.remote_read_response:type_name -> api.v1.light.RemoteReadResponse
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_light_v1_proto_init() }
+func file_light_v1_proto_init() {
+	if File_light_v1_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_light_v1_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Request); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_light_v1_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_light_v1_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoteCallRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_light_v1_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoteCallResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_light_v1_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoteReadRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_light_v1_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoteReadResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_light_v1_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoteReadChildRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_light_v1_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*Request_RemoteCallRequest)(nil),
+		(*Request_RemoteReadRequest)(nil),
+		(*Request_RemoteReadChildRequest)(nil),
+	}
+	file_light_v1_proto_msgTypes[1].OneofWrappers = []interface{}{
+		(*Response_RemoteCallResponse)(nil),
+		(*Response_RemoteReadResponse)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_light_v1_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_light_v1_proto_goTypes,
+		DependencyIndexes: file_light_v1_proto_depIdxs,
+		MessageInfos:      file_light_v1_proto_msgTypes,
+	}.Build()
+	File_light_v1_proto = out.File
+	file_light_v1_proto_rawDesc = nil
+	file_light_v1_proto_goTypes = nil
+	file_light_v1_proto_depIdxs = nil
+}
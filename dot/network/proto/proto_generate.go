@@ -4,4 +4,4 @@
 // Package proto contains protobuf generated Go structures.
 package proto
 
-//go:generate protoc --go_out=. --go_opt=paths=source_relative api.v1.proto
+//go:generate protoc --go_out=. --go_opt=paths=source_relative api.v1.proto light.v1.proto
@@ -5,17 +5,190 @@
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/network/messages"
 	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/internal/tracing"
 	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const tracerName = "github.com/ChainSafe/gossamer/dot/network"
+
+const (
+	// defaultRequestTimeout is the default time allowed to answer an inbound request
+	defaultRequestTimeout = time.Second * 20
+
+	// defaultInboundQueueSize is the default maximum number of inbound requests
+	// being handled at the same time for a request-response protocol
+	defaultInboundQueueSize = 64
+)
+
+var (
+	errInboundQueueFull      = errors.New("inbound request queue is full")
+	errRequestTimeout        = errors.New("request handling timed out")
+	errResponseTooLarge      = errors.New("response is larger than maximum size")
+	errProtocolAlreadyExists = errors.New("request-response protocol already registered")
+)
+
+// the following are used for RegisterRequestResponseProtocol
+type (
+	// RequestDecoder decodes an inbound request
+	RequestDecoder = func([]byte) (messages.P2PMessage, error)
+
+	// RequestHandler builds the response to an inbound request received from the given peer,
+	// giving up once the context given is done
+	RequestHandler = func(ctx context.Context, from peer.ID, req messages.P2PMessage) (messages.P2PMessage, error)
+)
+
+// RequestResponseConfig describes a request-response sub-protocol served by the node
+type RequestResponseConfig struct {
+	// ProtocolID is the full protocol ID of the sub-protocol
+	ProtocolID protocol.ID
+	// FallbackProtocolIDs are the legacy protocol IDs of the sub-protocol, also served
+	// for the peers not supporting the protocol ID
+	FallbackProtocolIDs []protocol.ID
+	// MaxRequestSize is the maximum size of an inbound request
+	MaxRequestSize uint64
+	// MaxResponseSize is the maximum size of a response sent back to the requester
+	MaxResponseSize uint64
+	// RequestTimeout is the time allowed to build the response, defaults to 20 seconds
+	RequestTimeout time.Duration
+	// InboundQueueSize is the maximum number of inbound requests handled at the same time,
+	// requests received when the queue is full are rejected. Defaults to 64.
+	InboundQueueSize uint
+	// DecodeRequest decodes the inbound requests
+	DecodeRequest RequestDecoder
+	// HandleRequest builds the response to an inbound request
+	HandleRequest RequestHandler
+}
+
+// requestResponseServer answers the inbound requests of a request-response sub-protocol
+type requestResponseServer struct {
+	cfg     RequestResponseConfig
+	inbound chan struct{}
+}
+
+func newRequestResponseServer(cfg RequestResponseConfig) *requestResponseServer {
+	if cfg.RequestTimeout == 0 {
+		cfg.RequestTimeout = defaultRequestTimeout
+	}
+
+	if cfg.InboundQueueSize == 0 {
+		cfg.InboundQueueSize = defaultInboundQueueSize
+	}
+
+	return &requestResponseServer{
+		cfg:     cfg,
+		inbound: make(chan struct{}, cfg.InboundQueueSize),
+	}
+}
+
+// RegisterRequestResponseProtocol registers a request-response protocol with the network service,
+// the inbound requests are decoded and answered using the given configuration.
+func (s *Service) RegisterRequestResponseProtocol(cfg RequestResponseConfig) error {
+	s.requestResponseMu.Lock()
+	defer s.requestResponseMu.Unlock()
+
+	if _, has := s.requestResponseProtocols[cfg.ProtocolID]; has {
+		return fmt.Errorf("%w: %s", errProtocolAlreadyExists, cfg.ProtocolID)
+	}
+
+	server := newRequestResponseServer(cfg)
+	s.requestResponseProtocols[cfg.ProtocolID] = server
+
+	handler := func(stream libp2pnetwork.Stream) {
+		if stream == nil {
+			return
+		}
+		s.handleRequestResponseStream(server, stream)
+	}
+	s.host.registerCompressibleStreamHandler(handler,
+		append([]protocol.ID{cfg.ProtocolID}, cfg.FallbackProtocolIDs...)...)
+
+	logger.Infof("registered request-response sub-protocol %s", cfg.ProtocolID)
+	return nil
+}
+
+func (s *Service) handleRequestResponseStream(server *requestResponseServer, stream libp2pnetwork.Stream) {
+	select {
+	case server.inbound <- struct{}{}:
+		defer func() { <-server.inbound }()
+	default:
+		logger.Debugf("%s for protocol %s, rejecting request from peer %s",
+			errInboundQueueFull, server.cfg.ProtocolID, stream.Conn().RemotePeer())
+		_ = stream.Reset()
+		return
+	}
+
+	decoder := func(in []byte, _ peer.ID, _ bool) (messages.P2PMessage, error) {
+		return server.cfg.DecodeRequest(in)
+	}
+
+	handler := func(stream libp2pnetwork.Stream, msg messages.P2PMessage) error {
+		if msg == nil {
+			return nil
+		}
+
+		defer func() {
+			err := stream.Close()
+			if err != nil && err.Error() != ErrStreamReset.Error() {
+				logger.Warnf("failed to close stream: %s", err)
+			}
+		}()
+
+		from := stream.Conn().RemotePeer()
+		resp, err := server.handle(s.ctx, from, msg)
+		if err != nil {
+			logger.Debugf("cannot create response for request from peer %s using protocol %s: %s",
+				from, server.cfg.ProtocolID, err)
+			return nil
+		}
+
+		encResp, err := resp.Encode()
+		if err != nil {
+			return fmt.Errorf("encoding response: %w", err)
+		}
+
+		if uint64(len(encResp)) > server.cfg.MaxResponseSize {
+			logger.Warnf("%s: protocol %s, max %d, got %d",
+				errResponseTooLarge, server.cfg.ProtocolID, server.cfg.MaxResponseSize, len(encResp))
+			return nil
+		}
+
+		if err = s.host.writeEncodedToStream(stream, encResp); err != nil {
+			logger.Debugf("failed to send response to peer %s using protocol %s: %s",
+				from, server.cfg.ProtocolID, err)
+			return err
+		}
+
+		return nil
+	}
+
+	s.readStream(stream, decoder, handler, server.cfg.MaxRequestSize)
+}
+
+// handle builds the response to the given request, the handler being given a context
+// cancelled once the request timeout is reached
+func (rrs *requestResponseServer) handle(ctx context.Context, from peer.ID,
+	req messages.P2PMessage) (messages.P2PMessage, error) {
+	ctx, cancel := context.WithTimeout(ctx, rrs.cfg.RequestTimeout)
+	defer cancel()
+
+	resp, err := rrs.cfg.HandleRequest(ctx, from, req)
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("%w: %w", errRequestTimeout, ctx.Err())
+	}
+	return resp, err
+}
+
 type RequestMaker interface {
 	Do(to peer.ID, req, res messages.P2PMessage) error
 }
@@ -26,21 +199,37 @@ type RequestResponseProtocol struct {
 	requestTimeout  time.Duration
 	maxResponseSize uint64
 	protocolID      protocol.ID
-	responseBufMu   sync.Mutex
-	responseBuf     []byte
+	// fallbackProtocolIDs are offered in order to the peers not supporting protocolID
+	fallbackProtocolIDs []protocol.ID
+	responseBufMu       sync.Mutex
+	responseBuf         []byte
+	// tracer traces the requests sent and their responses
+	tracer trace.Tracer
 }
 
-func (rrp *RequestResponseProtocol) Do(to peer.ID, req, res messages.P2PMessage) error {
+func (rrp *RequestResponseProtocol) Do(to peer.ID, req, res messages.P2PMessage) (err error) {
 	rrp.host.p2pHost.ConnManager().Protect(to, "")
 	defer rrp.host.p2pHost.ConnManager().Unprotect(to, "")
 
 	ctx, cancel := context.WithTimeout(rrp.ctx, rrp.requestTimeout)
 	defer cancel()
 
-	stream, err := rrp.host.p2pHost.NewStream(ctx, to, rrp.protocolID)
+	ctx, span := rrp.tracer.Start(ctx, "request", trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("protocol", string(rrp.protocolID)),
+			attribute.String("peer", to.String()),
+		))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	stream, err := rrp.host.newStream(ctx, to, rrp.protocolID, rrp.fallbackProtocolIDs...)
 	if err != nil {
 		return err
 	}
+	span.AddEvent("stream opened", trace.WithAttributes(
+		attribute.String("negotiated_protocol", string(stream.Protocol())),
+	))
 
 	defer func() {
 		err := stream.Close()
@@ -49,9 +238,15 @@ func (rrp *RequestResponseProtocol) Do(to peer.ID, req, res messages.P2PMessage)
 		}
 	}()
 
+	// the request timeout covers the whole exchange, not only the stream opening
+	if err = stream.SetDeadline(time.Now().Add(rrp.requestTimeout)); err != nil {
+		logger.Debugf("failed to set stream deadline: %s", err)
+	}
+
 	if err = rrp.host.writeToStream(stream, req); err != nil {
 		return err
 	}
+	span.AddEvent("request sent")
 
 	return rrp.receiveResponse(stream, res)
 }
@@ -65,7 +260,7 @@ func (rrp *RequestResponseProtocol) receiveResponse(stream libp2pnetwork.Stream,
 
 	buf := rrp.responseBuf
 
-	n, err := readStream(stream, &buf, rrp.maxResponseSize)
+	n, err := readMessage(stream, &buf, rrp.maxResponseSize)
 	if err != nil {
 		return fmt.Errorf("read stream error: %w", err)
 	}
@@ -74,6 +269,8 @@ func (rrp *RequestResponseProtocol) receiveResponse(stream libp2pnetwork.Stream,
 		return ErrReceivedEmptyMessage
 	}
 
+	rrp.host.logReceived(stream, n)
+
 	err = msg.Decode(buf[:n])
 	if err != nil {
 		rrp.host.cm.peerSetHandler.ReportPeer(peerset.ReputationChange{
@@ -0,0 +1,87 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/network/messages"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newRequestResponseServer(t *testing.T) {
+	t.Parallel()
+
+	server := newRequestResponseServer(RequestResponseConfig{ProtocolID: "/test/1"})
+	assert.Equal(t, defaultRequestTimeout, server.cfg.RequestTimeout)
+	assert.Equal(t, uint(defaultInboundQueueSize), server.cfg.InboundQueueSize)
+	assert.Equal(t, defaultInboundQueueSize, cap(server.inbound))
+
+	server = newRequestResponseServer(RequestResponseConfig{
+		ProtocolID:       "/test/1",
+		RequestTimeout:   time.Second,
+		InboundQueueSize: 2,
+	})
+	assert.Equal(t, time.Second, server.cfg.RequestTimeout)
+	assert.Equal(t, 2, cap(server.inbound))
+}
+
+func Test_requestResponseServer_handle(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+	request := &messages.BlockRequestMessage{}
+	response := &messages.BlockResponseMessage{}
+
+	testCases := map[string]struct {
+		handler    RequestHandler
+		timeout    time.Duration
+		response   messages.P2PMessage
+		errWrapped error
+	}{
+		"response": {
+			handler: func(context.Context, peer.ID, messages.P2PMessage) (messages.P2PMessage, error) {
+				return response, nil
+			},
+			timeout:  time.Second,
+			response: response,
+		},
+		"handler_error": {
+			handler: func(context.Context, peer.ID, messages.P2PMessage) (messages.P2PMessage, error) {
+				return nil, errTest
+			},
+			timeout:    time.Second,
+			errWrapped: errTest,
+		},
+		"timeout": {
+			handler: func(ctx context.Context, _ peer.ID, _ messages.P2PMessage) (messages.P2PMessage, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			},
+			timeout:    time.Millisecond,
+			errWrapped: errRequestTimeout,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := newRequestResponseServer(RequestResponseConfig{
+				RequestTimeout: testCase.timeout,
+				HandleRequest:  testCase.handler,
+			})
+
+			resp, err := server.handle(context.Background(), peer.ID("peer"), request)
+			require.ErrorIs(t, err, testCase.errWrapped)
+			assert.Equal(t, testCase.response, resp)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"fmt"
+
+	"github.com/libp2p/go-libp2p/core/connmgr"
+	"github.com/libp2p/go-libp2p/core/control"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	rm "github.com/libp2p/go-libp2p/p2p/host/resource-manager"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// defaultMaxConnsPerIP is the default maximum number of connections with the peers of an
+	// IP address, matching the default of the libp2p resource manager
+	defaultMaxConnsPerIP = 8
+	// defaultMaxConnsPerIPv6Subnet is the default maximum number of connections with the peers
+	// of a /48 IPv6 subnet, matching the default of the libp2p resource manager
+	defaultMaxConnsPerIPv6Subnet = 8 * defaultMaxConnsPerIP
+)
+
+// ResourceLimits are the limits of the libp2p resource manager protecting the node against
+// resource exhaustion. A limit of 0 keeps the default limit of the resource manager, scaled
+// to the memory and file descriptors of the machine.
+type ResourceLimits struct {
+	// MaxStreams is the maximum number of streams open at a time
+	MaxStreams int
+	// MaxStreamsPerPeer is the maximum number of streams open with a peer at a time
+	MaxStreamsPerPeer int
+	// MaxMemory is the maximum memory in bytes reserved by the connections and streams
+	MaxMemory int64
+	// MaxConnsPerPeer is the maximum number of connections with a peer
+	MaxConnsPerPeer int
+	// MaxConnsPerIP is the maximum number of connections with the peers of an IPv4 address
+	// or of a /56 IPv6 subnet
+	MaxConnsPerIP int
+	// MaxConnsPerSubnet is the maximum number of connections with the peers of a /24 IPv4
+	// subnet or of a /48 IPv6 subnet. 0 does not limit the connections per /24 IPv4 subnet.
+	MaxConnsPerSubnet int
+}
+
+// limitConfig returns the limits of the resource manager, the limits of 0 being scaled
+// to the memory and file descriptors of the machine.
+func (l ResourceLimits) limitConfig() rm.ConcreteLimitConfig {
+	partial := rm.PartialLimitConfig{
+		System: rm.ResourceLimits{
+			Streams: rm.LimitVal(l.MaxStreams),
+			Memory:  rm.LimitVal64(l.MaxMemory),
+		},
+		PeerDefault: rm.ResourceLimits{
+			Streams: rm.LimitVal(l.MaxStreamsPerPeer),
+			Conns:   rm.LimitVal(l.MaxConnsPerPeer),
+		},
+	}
+	return partial.Build(rm.DefaultLimits.AutoScale())
+}
+
+// subnetLimits returns the limits of the connections per IPv4 and IPv6 subnet, which are
+// nil if the default limits of the resource manager are kept.
+func (l ResourceLimits) subnetLimits() (ipv4, ipv6 []rm.ConnLimitPerSubnet) {
+	if l.MaxConnsPerIP == 0 && l.MaxConnsPerSubnet == 0 {
+		return nil, nil
+	}
+
+	perIP := l.MaxConnsPerIP
+	if perIP == 0 {
+		perIP = defaultMaxConnsPerIP
+	}
+	perIPv6Subnet := l.MaxConnsPerSubnet
+	if perIPv6Subnet == 0 {
+		perIPv6Subnet = defaultMaxConnsPerIPv6Subnet
+	}
+
+	ipv4 = []rm.ConnLimitPerSubnet{{PrefixLength: 32, ConnCount: perIP}}
+	if l.MaxConnsPerSubnet != 0 {
+		ipv4 = append(ipv4, rm.ConnLimitPerSubnet{PrefixLength: 24, ConnCount: l.MaxConnsPerSubnet})
+	}
+	ipv6 = []rm.ConnLimitPerSubnet{
+		{PrefixLength: 56, ConnCount: perIP},
+		{PrefixLength: 48, ConnCount: perIPv6Subnet},
+	}
+	return ipv4, ipv6
+}
+
+// newResourceManager returns the resource manager of the libp2p host enforcing the limits
+// given, reporting its statistics to prometheus if publishMetrics is true.
+func newResourceManager(limits ResourceLimits, publishMetrics bool) (network.ResourceManager, error) {
+	limiter := rm.NewFixedLimiter(limits.limitConfig())
+	var managerOptions []rm.Option
+
+	ipv4Limits, ipv6Limits := limits.subnetLimits()
+	if ipv4Limits != nil {
+		managerOptions = append(managerOptions, rm.WithLimitPerSubnet(ipv4Limits, ipv6Limits))
+	}
+
+	if publishMetrics {
+		rm.MustRegisterWith(prometheus.DefaultRegisterer)
+		reporter, err := rm.NewStatsTraceReporter()
+		if err != nil {
+			return nil, fmt.Errorf("while creating resource manager stats trace reporter: %w", err)
+		}
+
+		managerOptions = append(managerOptions, rm.WithTraceReporter(reporter))
+	}
+
+	manager, err := rm.NewResourceManager(limiter, managerOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("while creating the resource manager: %w", err)
+	}
+	return manager, nil
+}
+
+// privateIPv4Gater is a connection gater preventing the host from dialing the private IPv4
+// addresses, so a public node does not probe the private networks advertised by its peers.
+type privateIPv4Gater struct {
+	filters *ma.Filters
+}
+
+var _ connmgr.ConnectionGater = (*privateIPv4Gater)(nil)
+
+func newPrivateIPv4Gater() *privateIPv4Gater {
+	return &privateIPv4Gater{filters: privateIPv4s}
+}
+
+// InterceptPeerDial allows dialing any peer
+func (*privateIPv4Gater) InterceptPeerDial(peer.ID) (allow bool) {
+	return true
+}
+
+// InterceptAddrDial prevents dialing the private IPv4 addresses
+func (g *privateIPv4Gater) InterceptAddrDial(_ peer.ID, addr ma.Multiaddr) (allow bool) {
+	return !g.filters.AddrBlocked(addr)
+}
+
+// InterceptAccept allows accepting any connection
+func (*privateIPv4Gater) InterceptAccept(network.ConnMultiaddrs) (allow bool) {
+	return true
+}
+
+// InterceptSecured allows any secured connection
+func (*privateIPv4Gater) InterceptSecured(network.Direction, peer.ID, network.ConnMultiaddrs) (allow bool) {
+	return true
+}
+
+// InterceptUpgraded allows any upgraded connection
+func (*privateIPv4Gater) InterceptUpgraded(network.Conn) (allow bool, reason control.DisconnectReason) {
+	return true, 0
+}
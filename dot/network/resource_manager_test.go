@@ -0,0 +1,123 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"testing"
+
+	rm "github.com/libp2p/go-libp2p/p2p/host/resource-manager"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ResourceLimits_limitConfig(t *testing.T) {
+	t.Parallel()
+
+	defaults := rm.DefaultLimits.AutoScale()
+	limits := ResourceLimits{
+		MaxStreams:        1000,
+		MaxStreamsPerPeer: 100,
+		MaxMemory:         256 << 20,
+		MaxConnsPerPeer:   2,
+	}
+
+	config := limits.limitConfig().ToPartialLimitConfig()
+
+	assert.Equal(t, rm.LimitVal(1000), config.System.Streams)
+	assert.Equal(t, rm.LimitVal64(256<<20), config.System.Memory)
+	assert.Equal(t, rm.LimitVal(100), config.PeerDefault.Streams)
+	assert.Equal(t, rm.LimitVal(2), config.PeerDefault.Conns)
+	// the limits left to 0 are scaled to the machine
+	assert.Equal(t, defaults.ToPartialLimitConfig().System.Conns, config.System.Conns)
+	assert.Equal(t, defaults.ToPartialLimitConfig().PeerDefault.Memory, config.PeerDefault.Memory)
+}
+
+func Test_ResourceLimits_subnetLimits(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		limits ResourceLimits
+		ipv4   []rm.ConnLimitPerSubnet
+		ipv6   []rm.ConnLimitPerSubnet
+	}{
+		"default_limits": {},
+		"per_ip_limit": {
+			limits: ResourceLimits{MaxConnsPerIP: 2},
+			ipv4:   []rm.ConnLimitPerSubnet{{PrefixLength: 32, ConnCount: 2}},
+			ipv6: []rm.ConnLimitPerSubnet{
+				{PrefixLength: 56, ConnCount: 2},
+				{PrefixLength: 48, ConnCount: 64},
+			},
+		},
+		"per_subnet_limit": {
+			limits: ResourceLimits{MaxConnsPerSubnet: 16},
+			ipv4: []rm.ConnLimitPerSubnet{
+				{PrefixLength: 32, ConnCount: 8},
+				{PrefixLength: 24, ConnCount: 16},
+			},
+			ipv6: []rm.ConnLimitPerSubnet{
+				{PrefixLength: 56, ConnCount: 8},
+				{PrefixLength: 48, ConnCount: 16},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ipv4, ipv6 := testCase.limits.subnetLimits()
+			assert.Equal(t, testCase.ipv4, ipv4)
+			assert.Equal(t, testCase.ipv6, ipv6)
+		})
+	}
+}
+
+func Test_newResourceManager(t *testing.T) {
+	t.Parallel()
+
+	manager, err := newResourceManager(ResourceLimits{MaxConnsPerIP: 1}, false)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, manager.Close())
+	})
+
+	const outbound = 2
+	addr := ma.StringCast("/ip4/1.2.3.4/tcp/7001")
+	scope, err := manager.OpenConnection(outbound, true, addr)
+	require.NoError(t, err)
+	defer scope.Done()
+
+	_, err = manager.OpenConnection(outbound, true, addr)
+	assert.EqualError(t, err, "connections per ip limit exceeded for /ip4/1.2.3.4/tcp/7001")
+}
+
+func Test_privateIPv4Gater_InterceptAddrDial(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		addr  string
+		allow bool
+	}{
+		"public_ipv4":   {addr: "/ip4/1.2.3.4/tcp/7001", allow: true},
+		"private_ipv4":  {addr: "/ip4/192.168.1.10/tcp/7001"},
+		"shared_ipv4":   {addr: "/ip4/100.64.0.1/tcp/7001"},
+		"loopback_ipv4": {addr: "/ip4/127.0.0.1/tcp/7001", allow: true},
+		"private_ipv6":  {addr: "/ip6/fd00::1/tcp/7001", allow: true},
+		"dns":           {addr: "/dns/node.example.com/tcp/7001", allow: true},
+	}
+
+	gater := newPrivateIPv4Gater()
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			allow := gater.InterceptAddrDial("", ma.StringCast(testCase.addr))
+			assert.Equal(t, testCase.allow, allow)
+		})
+	}
+}
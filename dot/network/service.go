@@ -5,6 +5,7 @@
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/big"
@@ -16,6 +17,7 @@
 	"github.com/ChainSafe/gossamer/dot/peerset"
 	"github.com/ChainSafe/gossamer/dot/telemetry"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/chaos"
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/internal/metrics"
 	"github.com/ChainSafe/gossamer/lib/common"
@@ -23,8 +25,10 @@
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
 	"github.com/libp2p/go-libp2p/p2p/discovery/mdns"
+	rm "github.com/libp2p/go-libp2p/p2p/host/resource-manager"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel"
 )
 
 const (
@@ -34,7 +38,8 @@
 	// the following are sub-protocols used by the node
 	SyncID          = "/sync/2"
 	WarpSyncID      = "/sync/warp"
-	lightID         = "/light/2"
+	StateSyncID     = "/state/2"
+	LightID         = "/light/2"
 	blockAnnounceID = "/block-announces/1"
 	transactionsID  = "/transactions/1"
 
@@ -126,11 +131,22 @@ type Service struct {
 	lightRequest   map[peer.ID]struct{} // set if we have sent a light request message to the given peer
 	lightRequestMu sync.RWMutex
 
+	// remoteReads sends the remote read requests of the light protocol,
+	// it is created on the first remote read
+	remoteReads     RequestMaker
+	remoteReadsOnce sync.Once
+
+	knownTransactions *knownTransactions
+
+	requestResponseProtocols map[protocol.ID]*requestResponseServer
+	requestResponseMu        sync.Mutex
+
 	// Service interfaces
 	blockState         BlockState
 	syncer             Syncer
 	transactionHandler TransactionHandler
 	warpSyncProvider   WarpSyncProvider
+	stateSyncProvider  StateSyncProvider
 
 	// Configuration options
 	noBootstrap bool
@@ -138,6 +154,9 @@ type Service struct {
 	noMDNS      bool
 	noGossip    bool // internal option
 
+	// chaos drops some of the notifications received, for testing
+	chaos *chaos.Injector
+
 	Metrics metrics.IntervalConfig
 
 	// telemetry
@@ -209,27 +228,30 @@ func NewService(cfg *Config) (*Service, error) {
 	mdnsService := mdns.NewMdnsService(host.p2pHost, serviceTag, notifee)
 
 	network := &Service{
-		ctx:                    ctx,
-		cancel:                 cancel,
-		cfg:                    cfg,
-		host:                   host,
-		mdns:                   mdnsService,
-		gossip:                 newGossip(),
-		blockState:             cfg.BlockState,
-		transactionHandler:     cfg.TransactionHandler,
-		noBootstrap:            cfg.NoBootstrap,
-		noMDNS:                 cfg.NoMDNS,
-		syncer:                 cfg.Syncer,
-		warpSyncProvider:       cfg.WarpSyncProvider,
-		notificationsProtocols: make(map[MessageType]*notificationsProtocol),
-		lightRequest:           make(map[peer.ID]struct{}),
-		telemetryInterval:      cfg.telemetryInterval,
-		closeCh:                make(chan struct{}),
-		bufPool:                bufPool,
-		streamManager:          newStreamManager(ctx),
-		telemetry:              cfg.Telemetry,
-		Metrics:                cfg.Metrics,
-		warpSyncSpamLimiter:    cfg.warpSyncSpamLimiter,
+		ctx:                      ctx,
+		cancel:                   cancel,
+		cfg:                      cfg,
+		host:                     host,
+		mdns:                     mdnsService,
+		gossip:                   newGossip(),
+		blockState:               cfg.BlockState,
+		transactionHandler:       cfg.TransactionHandler,
+		noBootstrap:              cfg.NoBootstrap,
+		noMDNS:                   cfg.NoMDNS,
+		syncer:                   cfg.Syncer,
+		warpSyncProvider:         cfg.WarpSyncProvider,
+		notificationsProtocols:   make(map[MessageType]*notificationsProtocol),
+		lightRequest:             make(map[peer.ID]struct{}),
+		knownTransactions:        newKnownTransactions(maxKnownTransactionsPerPeer),
+		requestResponseProtocols: make(map[protocol.ID]*requestResponseServer),
+		telemetryInterval:        cfg.telemetryInterval,
+		closeCh:                  make(chan struct{}),
+		bufPool:                  bufPool,
+		streamManager:            newStreamManager(ctx),
+		telemetry:                cfg.Telemetry,
+		Metrics:                  cfg.Metrics,
+		warpSyncSpamLimiter:      cfg.warpSyncSpamLimiter,
+		chaos:                    cfg.Chaos,
 	}
 
 	return network, nil
@@ -259,15 +281,35 @@ func (s *Service) Start() error {
 		s.ctx, s.cancel = context.WithCancel(context.Background())
 	}
 
-	genesisHashProtocolId := protocol.ID(s.cfg.BlockState.GenesisHash().String())
+	lightProtocolID, lightFallbackProtocolIDs := s.protocolIDs(LightID)
+	for _, pid := range append([]protocol.ID{lightProtocolID}, lightFallbackProtocolIDs...) {
+		s.host.registerStreamHandler(pid, s.handleLightStream)
+	}
+
+	warpSyncProtocolID, warpSyncFallbackProtocolIDs := s.protocolIDs(WarpSyncID)
+	for _, pid := range append([]protocol.ID{warpSyncProtocolID}, warpSyncFallbackProtocolIDs...) {
+		s.host.registerStreamHandler(pid, s.handleWarpSyncStream)
+	}
+
+	// register block request protocol
+	err := s.RegisterRequestResponseProtocol(s.blockRequestResponseConfig())
+	if err != nil {
+		logger.Warnf("failed to register request-response protocol with sync id %s: %s", SyncID, err)
+	}
 
-	s.host.registerStreamHandler(s.host.protocolID+SyncID, s.handleSyncStream)
-	s.host.registerStreamHandler(s.host.protocolID+lightID, s.handleLightStream)
-	s.host.registerStreamHandler(genesisHashProtocolId+WarpSyncID, s.handleWarpSyncStream)
+	// register state request protocol, only if we are able to serve state
+	if s.stateSyncProvider != nil {
+		err = s.RegisterRequestResponseProtocol(s.stateRequestResponseConfig())
+		if err != nil {
+			logger.Warnf("failed to register request-response protocol with state id %s: %s", StateSyncID, err)
+		}
+	}
 
 	// register block announce protocol
-	err := s.RegisterNotificationsProtocol(
-		s.host.protocolID+blockAnnounceID,
+	blockAnnounceProtocolID, blockAnnounceFallbackProtocolIDs := s.protocolIDs(blockAnnounceID)
+	err = s.RegisterNotificationsProtocol(
+		blockAnnounceProtocolID,
+		blockAnnounceFallbackProtocolIDs,
 		blockAnnounceMsgType,
 		s.getBlockAnnounceHandshake,
 		decodeBlockAnnounceHandshake,
@@ -286,8 +328,10 @@ func (s *Service) Start() error {
 	txnBatchHandler := s.createBatchMessageHandler(txnBatch)
 
 	// register transactions protocol
+	transactionsProtocolID, transactionsFallbackProtocolIDs := s.protocolIDs(transactionsID)
 	err = s.RegisterNotificationsProtocol(
-		s.host.protocolID+transactionsID,
+		transactionsProtocolID,
+		transactionsFallbackProtocolIDs,
 		transactionMsgType,
 		s.getTransactionHandshake,
 		decodeTransactionHandshake,
@@ -314,12 +358,16 @@ func (s *Service) Start() error {
 	}
 
 	// when a peer gets disconnected, we should clear all handshake data we have for it.
+	// The peer is persisted on disconnection rather than on connection, the peerstore writes
+	// to the datastore slowing down the connection of the peer otherwise.
 	s.host.cm.disconnectHandler = func(peerID peer.ID) {
 		for _, prtl := range s.notificationsProtocols {
 			prtl.peersData.deleteMutex(peerID)
 			prtl.peersData.deleteInboundHandshakeData(peerID)
 			prtl.peersData.deleteOutboundHandshakeData(peerID)
 		}
+		s.knownTransactions.remove(peerID)
+		s.host.persistPeer(peerID)
 	}
 
 	// log listening addresses to console
@@ -355,9 +403,15 @@ func (s *Service) Start() error {
 		go s.updateMetrics()
 	}
 
+	if s.cfg.BadgerGCInterval > 0 {
+		go s.collectDatastoreGarbage()
+	}
+
 	go s.logPeerCount()
+	go s.persistPeersPeriodically()
 	go s.publishNetworkTelemetry(s.closeCh)
 	go s.sentBlockIntervalTelemetry()
+	go s.startTransactionPropagation()
 	s.streamManager.start()
 
 	return nil
@@ -383,6 +437,8 @@ func (s *Service) updateMetrics() {
 			outboundGrandpaStreamsGauge.Set(float64(s.getNumStreams(ConsensusMsgType, false)))
 			inboundStreamsGauge.Set(float64(s.getTotalStreams(true)))
 			outboundStreamsGauge.Set(float64(s.getTotalStreams(false)))
+			s.updateTrafficMetrics()
+			setDatastoreMetrics(s.host.ds)
 		}
 	}
 }
@@ -425,6 +481,22 @@ func (s *Service) logPeerCount() {
 	}
 }
 
+// persistPeersPeriodically persists the connected peers every peer persist interval,
+// peers being otherwise only persisted when they disconnect or the service stops.
+func (s *Service) persistPeersPeriodically() {
+	ticker := time.NewTicker(peerPersistInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.host.persistConnectedPeers()
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
 func (s *Service) publishNetworkTelemetry(done <-chan struct{}) {
 	ticker := time.NewTicker(s.telemetryInterval)
 	defer ticker.Stop()
@@ -486,6 +558,9 @@ func (s *Service) Stop() error {
 		logger.Errorf("Failed to close mDNS discovery service: %s", err)
 	}
 
+	// persist the reputation of the connected peers before closing the peerstore
+	s.host.persistConnectedPeers()
+
 	// close host and host services
 	err = s.host.close()
 	if err != nil {
@@ -510,8 +585,11 @@ func (s *Service) Stop() error {
 
 // RegisterNotificationsProtocol registers a protocol with the network service with the given handler
 // messageID is a user-defined message ID for the message passed over this protocol.
+// The fallback protocol IDs are the legacy names of the protocol, served as well and negotiated
+// with the peers not supporting the protocol ID.
 func (s *Service) RegisterNotificationsProtocol(
 	protocolID protocol.ID,
+	fallbackProtocolIDs []protocol.ID,
 	messageID MessageType,
 	handshakeGetter HandshakeGetter,
 	handshakeDecoder HandshakeDecoder,
@@ -528,15 +606,16 @@ func (s *Service) RegisterNotificationsProtocol(
 		return errors.New("notifications protocol with message type already exists")
 	}
 
-	np := newNotificationsProtocol(protocolID, handshakeGetter, handshakeDecoder, handshakeValidator, maxSize)
+	np := newNotificationsProtocol(protocolID, fallbackProtocolIDs, handshakeGetter, handshakeDecoder,
+		handshakeValidator, maxSize)
 	s.notificationsProtocols[messageID] = np
 	decoder := createDecoder(np, handshakeDecoder, messageDecoder)
 	handlerWithValidate := s.createNotificationsMessageHandler(np, messageHandler, batchHandler)
 
-	s.host.registerStreamHandler(protocolID, func(stream libp2pnetwork.Stream) {
-		logger.Tracef("received stream using sub-protocol %s", protocolID)
+	s.host.registerCompressibleStreamHandler(func(stream libp2pnetwork.Stream) {
+		logger.Tracef("received stream using sub-protocol %s", stream.Protocol())
 		s.readStream(stream, decoder, handlerWithValidate, maxSize)
-	})
+	}, np.protocolIDs()...)
 
 	logger.Infof("registered notifications sub-protocol %s", protocolID)
 	return nil
@@ -547,12 +626,19 @@ func (s *Service) IsStopped() bool {
 	return s.ctx.Err() != nil
 }
 
-// GossipMessage gossips a notifications protocol message to our peers
+// GossipMessage gossips a notifications protocol message to our peers.
+// Transactions submitted locally are sent to every connected peer that does
+// not know about them yet, regardless of the node role.
 func (s *Service) GossipMessage(msg NotificationsMessage) {
 	if s.host == nil || msg == nil || s.IsStopped() {
 		return
 	}
 
+	if txMsg, ok := msg.(*TransactionMessage); ok {
+		s.sendTransactions(s.host.peers(), txMsg.Extrinsics)
+		return
+	}
+
 	logger.Debugf("gossiping from host %s message of type %d: %s",
 		s.host.id(), msg.Type(), msg)
 
@@ -622,18 +708,33 @@ func (s *Service) SendMessage(to peer.ID, msg NotificationsMessage) error {
 func (s *Service) GetRequestResponseProtocol(subprotocol string, requestTimeout time.Duration,
 	maxResponseSize uint64) *RequestResponseProtocol {
 
-	protocolID := s.host.protocolID + protocol.ID(subprotocol)
+	protocolID, fallbackProtocolIDs := s.protocolIDs(protocol.ID(subprotocol))
 	return &RequestResponseProtocol{
-		ctx:             s.ctx,
-		host:            s.host,
-		requestTimeout:  requestTimeout,
-		maxResponseSize: maxResponseSize,
-		protocolID:      protocolID,
-		responseBuf:     make([]byte, maxResponseSize),
-		responseBufMu:   sync.Mutex{},
+		ctx:                 s.ctx,
+		host:                s.host,
+		requestTimeout:      requestTimeout,
+		maxResponseSize:     maxResponseSize,
+		protocolID:          protocolID,
+		fallbackProtocolIDs: fallbackProtocolIDs,
+		responseBuf:         make([]byte, maxResponseSize),
+		responseBufMu:       sync.Mutex{},
+		tracer:              otel.Tracer(tracerName),
 	}
 }
 
+// protocolIDs returns the protocol ID of the sub-protocol derived from the genesis hash, as
+// named by Substrate since the chain specification protocolId was deprecated, along with
+// the legacy protocol ID derived from the configured protocol ID, used as fallback.
+func (s *Service) protocolIDs(subprotocol protocol.ID) (protocolID protocol.ID, fallbackProtocolIDs []protocol.ID) {
+	return genesisProtocolID(s.blockState.GenesisHash(), subprotocol), []protocol.ID{s.host.protocolID + subprotocol}
+}
+
+// genesisProtocolID returns the /<genesis-hash>/<sub-protocol> protocol ID, where the
+// genesis hash is hex encoded without the 0x prefix.
+func genesisProtocolID(genesisHash common.Hash, subprotocol protocol.ID) protocol.ID {
+	return protocol.ID("/"+hex.EncodeToString(genesisHash[:])) + subprotocol
+}
+
 // Health returns information about host needed for the rpc server
 func (s *Service) Health() common.Health {
 	return common.Health{
@@ -814,3 +915,13 @@ func (s *Service) BlockAnnounceHandshake(header *types.Header) error {
 	s.broadcastExcluding(protocol, peer.ID(""), msg)
 	return nil
 }
+
+// ResourceManagerStat returns a snapshot of the resources used by the libp2p host,
+// for the system, transient, service, protocol and peer scopes.
+func (s *Service) ResourceManagerStat() (stat rm.ResourceManagerStat, err error) {
+	state, ok := s.host.p2pHost.Network().ResourceManager().(rm.ResourceManagerState)
+	if !ok {
+		return stat, errNoResourceManagerState
+	}
+	return state.Stat(), nil
+}
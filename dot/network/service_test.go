@@ -0,0 +1,23 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_genesisProtocolID(t *testing.T) {
+	t.Parallel()
+
+	genesisHash := common.MustHexToHash("0x91b171bb158e2d3848fa23a9f1c25182fb8e20313b2c1eb49219da7a70ce90c3")
+
+	protocolID := genesisProtocolID(genesisHash, blockAnnounceID)
+
+	const expected = protocol.ID("/91b171bb158e2d3848fa23a9f1c25182fb8e20313b2c1eb49219da7a70ce90c3/block-announces/1")
+	assert.Equal(t, expected, protocolID)
+}
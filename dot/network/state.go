@@ -32,8 +32,9 @@ type Syncer interface {
 	// IsSynced exposes the internal synced state
 	IsSynced() bool
 
-	// CreateBlockResponse is called upon receipt of a BlockRequestMessage to create the response
-	CreateBlockResponse(peer.ID, *messages.BlockRequestMessage) (*messages.BlockResponseMessage, error)
+	// CreateBlockResponse is called upon receipt of a BlockRequestMessage to create the response,
+	// giving up once the context is done
+	CreateBlockResponse(context.Context, peer.ID, *messages.BlockRequestMessage) (*messages.BlockResponseMessage, error)
 
 	// OnConnectionClosed should be trigged whenever Gossamer closes a connection with another
 	// peer, normally used when the peer reputation is too low.
@@ -44,6 +45,8 @@ type Syncer interface {
 type TransactionHandler interface {
 	HandleTransactionMessage(peer.ID, *TransactionMessage) (bool, error)
 	TransactionsCount() int
+	// PendingTransactions returns the pending transactions that should be propagated to peers
+	PendingTransactions() []types.Extrinsic
 }
 
 // PeerSetHandler is the interface used by the connection manager to handle peerset.
@@ -71,4 +74,5 @@ type PeerRemove interface {
 type Peer interface {
 	SortedPeers(idx int) chan peer.IDSlice
 	Messages() chan peerset.Message
+	PeerReputation(peer.ID) (peerset.Reputation, error)
 }
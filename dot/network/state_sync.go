@@ -0,0 +1,50 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/network/messages"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// StateSyncProvider is used to answer the state requests of peers fast syncing from us
+type StateSyncProvider interface {
+	// CreateStateResponse creates the state response answering the given state request,
+	// giving up once the context is done
+	CreateStateResponse(ctx context.Context, from peer.ID, req *messages.StateRequest) (*messages.StateResponse, error)
+}
+
+// stateRequestResponseConfig returns the request-response configuration of the
+// /<genesis-hash>/state/2 protocol, answering the inbound state requests
+func (s *Service) stateRequestResponseConfig() RequestResponseConfig {
+	protocolID, fallbackProtocolIDs := s.protocolIDs(StateSyncID)
+	return RequestResponseConfig{
+		ProtocolID:          protocolID,
+		FallbackProtocolIDs: fallbackProtocolIDs,
+		MaxRequestSize:      maxStateRequestSize,
+		MaxResponseSize:     MaxStateResponseSize,
+		DecodeRequest:       decodeStateRequest,
+		HandleRequest:       s.handleStateRequest,
+	}
+}
+
+func decodeStateRequest(in []byte) (messages.P2PMessage, error) {
+	msg := new(messages.StateRequest)
+	err := msg.Decode(in)
+	return msg, err
+}
+
+func (s *Service) handleStateRequest(ctx context.Context, from peer.ID, msg messages.P2PMessage) (
+	messages.P2PMessage, error) {
+	req, ok := msg.(*messages.StateRequest)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected %T but got %T",
+			errMessageTypeNotValid, (*messages.StateRequest)(nil), msg)
+	}
+
+	return s.stateSyncProvider.CreateStateResponse(ctx, from, req)
+}
@@ -4,52 +4,42 @@
 package network
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/ChainSafe/gossamer/dot/network/messages"
-	libp2pnetwork "github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
-// handleSyncStream handles streams with the <protocol-id>/sync/2 protocol ID
-func (s *Service) handleSyncStream(stream libp2pnetwork.Stream) {
-	if stream == nil {
-		return
+// blockRequestResponseConfig returns the request-response configuration of the
+// /<genesis-hash>/sync/2 protocol, answering the inbound block requests
+func (s *Service) blockRequestResponseConfig() RequestResponseConfig {
+	protocolID, fallbackProtocolIDs := s.protocolIDs(SyncID)
+	return RequestResponseConfig{
+		ProtocolID:          protocolID,
+		FallbackProtocolIDs: fallbackProtocolIDs,
+		MaxRequestSize:      maxBlockRequestSize,
+		MaxResponseSize:     MaxBlockResponseSize,
+		DecodeRequest:       decodeSyncMessage,
+		HandleRequest:       s.handleSyncMessage,
 	}
-
-	s.readStream(stream, decodeSyncMessage, s.handleSyncMessage, MaxBlockResponseSize)
 }
 
-func decodeSyncMessage(in []byte, _ peer.ID, _ bool) (messages.P2PMessage, error) {
+func decodeSyncMessage(in []byte) (messages.P2PMessage, error) {
 	msg := new(messages.BlockRequestMessage)
 	err := msg.Decode(in)
 	return msg, err
 }
 
-// handleSyncMessage handles inbound sync streams
+// handleSyncMessage handles inbound sync requests
 // the only messages we should receive over an inbound stream are BlockRequestMessages, so we only need to handle those
-func (s *Service) handleSyncMessage(stream libp2pnetwork.Stream, msg messages.P2PMessage) error {
-	if msg == nil {
-		return nil
-	}
-
-	defer func() {
-		err := stream.Close()
-		if err != nil && err.Error() != ErrStreamReset.Error() {
-			logger.Warnf("failed to close stream: %s", err)
-		}
-	}()
-
-	if req, ok := msg.(*messages.BlockRequestMessage); ok {
-		resp, err := s.syncer.CreateBlockResponse(stream.Conn().RemotePeer(), req)
-		if err != nil {
-			logger.Debugf("cannot create response for request: %s", err)
-			return nil
-		}
-
-		if err = s.host.writeToStream(stream, resp); err != nil {
-			logger.Debugf("failed to send BlockResponse message to peer %s: %s", stream.Conn().RemotePeer(), err)
-			return err
-		}
+func (s *Service) handleSyncMessage(ctx context.Context, from peer.ID, msg messages.P2PMessage) (
+	messages.P2PMessage, error) {
+	req, ok := msg.(*messages.BlockRequestMessage)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected %T but got %T",
+			errMessageTypeNotValid, (*messages.BlockRequestMessage)(nil), msg)
 	}
 
-	return nil
+	return s.syncer.CreateBlockResponse(ctx, from, req)
 }
@@ -7,7 +7,6 @@
 	"testing"
 
 	"github.com/ChainSafe/gossamer/dot/network/messages"
-	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/require"
 )
 
@@ -15,11 +14,10 @@ func TestDecodeSyncMessage(t *testing.T) {
 	t.Parallel()
 	testBlockReqMessage := newTestBlockRequestMessage(t)
 
-	testPeer := peer.ID("noot")
 	reqEnc, err := testBlockReqMessage.Encode()
 	require.NoError(t, err)
 
-	msg, err := decodeSyncMessage(reqEnc, testPeer, true)
+	msg, err := decodeSyncMessage(reqEnc)
 	require.NoError(t, err)
 
 	req, ok := msg.(*messages.BlockRequestMessage)
@@ -6,9 +6,12 @@
 import (
 	"errors"
 	"fmt"
+	"math"
+	"math/rand"
 	"time"
 
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
 
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
@@ -20,8 +23,13 @@
 	_ Handshake            = (*transactionHandshake)(nil)
 )
 
-// txnBatchChTimeout is the timeout for adding a transaction to the batch processing channel
-const txnBatchChTimeout = time.Millisecond * 200
+const (
+	// txnBatchChTimeout is the timeout for adding a transaction to the batch processing channel
+	txnBatchChTimeout = time.Millisecond * 200
+
+	// txnPropagateInterval is the interval between two propagations of the pending transactions
+	txnPropagateInterval = time.Millisecond * 2900
+)
 
 // TransactionMessage is a network message that is sent to notify of new transactions entering the network
 type TransactionMessage struct {
@@ -88,7 +96,8 @@ func decodeTransactionHandshake(_ []byte) (Handshake, error) {
 }
 
 func (s *Service) startTxnBatchProcessing(txnBatchCh chan *batchMessage, slotDuration time.Duration) {
-	protocolID := s.host.protocolID + transactionsID
+	protocolID, fallbackProtocolIDs := s.protocolIDs(transactionsID)
+	protocolIDs := append([]protocol.ID{protocolID}, fallbackProtocolIDs...)
 	ticker := time.NewTicker(slotDuration)
 	defer ticker.Stop()
 
@@ -107,26 +116,15 @@ func (s *Service) startTxnBatchProcessing(txnBatchCh chan *batchMessage, slotDur
 					propagate, err := s.handleTransactionMessage(txnMsg.peer, txnMsg.msg)
 					if err != nil {
 						logger.Warnf("could not handle transaction message: %s", err)
-						s.host.closeProtocolStream(protocolID, txnMsg.peer)
+						s.host.closeProtocolStream(txnMsg.peer, protocolIDs...)
 						continue
 					}
 
-					if s.noGossip || !propagate {
+					if !propagate {
 						continue
 					}
 
-					// TODO: Check if s.gossip.hasSeen should be moved before handleTransactionMessage. #2445
-					// That we could avoid handling the transactions again, which we would have already seen.
-
-					hasSeen, err := s.gossip.hasSeen(txnMsg.msg)
-					if err != nil {
-						s.host.closeProtocolStream(protocolID, txnMsg.peer)
-						logger.Debugf("could not check if message was seen before: %s", err)
-						continue
-					}
-					if !hasSeen {
-						s.broadcastExcluding(s.notificationsProtocols[transactionMsgType], txnMsg.peer, txnMsg.msg)
-					}
+					s.propagateTransactions(txnMsg.peer, txnMsg.msg.(*TransactionMessage).Extrinsics)
 				}
 			}
 		}
@@ -169,5 +167,120 @@ func (s *Service) handleTransactionMessage(peerID peer.ID, msg NotificationsMess
 		return false, errors.New("invalid transaction type")
 	}
 
+	// the peer sent us these transactions, so there is no need to send them back
+	hashes := make([]common.Hash, len(txMsg.Extrinsics))
+	for i, ext := range txMsg.Extrinsics {
+		hashes[i] = ext.Hash()
+	}
+	s.knownTransactions.add(peerID, hashes...)
+
 	return s.transactionHandler.HandleTransactionMessage(peerID, txMsg)
 }
+
+// shouldPropagateTransactions returns true if the node relays transactions to its peers,
+// which is only the case for authorities, unless transaction propagation is forced.
+func (s *Service) shouldPropagateTransactions() bool {
+	if s.noGossip {
+		return false
+	}
+
+	return s.cfg.Roles&common.AuthorityRole != 0 || s.cfg.ForceTxPropagation
+}
+
+// startTransactionPropagation periodically propagates the transactions pending in
+// the pool to the peers that do not know about them yet.
+func (s *Service) startTransactionPropagation() {
+	ticker := time.NewTicker(txnPropagateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			if !s.shouldPropagateTransactions() {
+				continue
+			}
+
+			s.propagateTransactions(peer.ID(""), s.transactionHandler.PendingTransactions())
+		}
+	}
+}
+
+// propagateTransactions relays the given transactions to the peers selected by
+// transactionPropagationPeers, if the node role allows it. The propagation is skipped
+// while the bandwidth soft limit is exceeded, the pending transactions being propagated
+// again by startTransactionPropagation.
+func (s *Service) propagateTransactions(excluding peer.ID, extrinsics []types.Extrinsic) {
+	if !s.shouldPropagateTransactions() {
+		return
+	}
+
+	if s.bandwidthExceeded() {
+		logger.Debugf("bandwidth soft limit of %d bytes/s exceeded, deferring propagation of %d transactions",
+			s.cfg.MaxBandwidth, len(extrinsics))
+		return
+	}
+
+	s.sendTransactions(s.transactionPropagationPeers(excluding), extrinsics)
+}
+
+// transactionPropagationPeers returns a random subset of the connected peers, the
+// size of the subset being the square root of the number of connected peers.
+func (s *Service) transactionPropagationPeers(excluding peer.ID) []peer.ID {
+	connected := s.host.peers()
+	peers := make([]peer.ID, 0, len(connected))
+	for _, p := range connected {
+		if p != excluding {
+			peers = append(peers, p)
+		}
+	}
+
+	rand.Shuffle(len(peers), func(i, j int) {
+		peers[i], peers[j] = peers[j], peers[i]
+	})
+
+	size := int(math.Ceil(math.Sqrt(float64(len(peers)))))
+	return peers[:size]
+}
+
+// sendTransactions sends to each of the given peers the transactions it does not know about yet.
+func (s *Service) sendTransactions(peers []peer.ID, extrinsics []types.Extrinsic) {
+	if len(peers) == 0 || len(extrinsics) == 0 {
+		return
+	}
+
+	s.notificationsMu.RLock()
+	info, ok := s.notificationsProtocols[transactionMsgType]
+	s.notificationsMu.RUnlock()
+	if !ok {
+		logger.Errorf("transactions notifications protocol is not registered")
+		return
+	}
+
+	hs, err := info.getHandshake()
+	if err != nil {
+		logger.Errorf("failed to get handshake using protocol %s: %s", info.protocolID, err)
+		return
+	}
+
+	for _, p := range peers {
+		var unknown []types.Extrinsic
+		var unknownHashes []common.Hash
+		for _, ext := range extrinsics {
+			hash := ext.Hash()
+			if s.knownTransactions.has(p, hash) {
+				continue
+			}
+			unknown = append(unknown, ext)
+			unknownHashes = append(unknownHashes, hash)
+		}
+
+		if len(unknown) == 0 {
+			continue
+		}
+
+		s.knownTransactions.add(p, unknownHashes...)
+		go s.sendData(p, hs, info, &TransactionMessage{Extrinsics: unknown})
+	}
+}
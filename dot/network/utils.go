@@ -4,6 +4,7 @@
 package network
 
 import (
+	"crypto/ed25519"
 	crand "crypto/rand"
 	"encoding/hex"
 	"fmt"
@@ -20,8 +21,11 @@
 )
 
 const (
-	// maxBlockRequestSize              uint64 = 1024 * 1024      // 1mb
+	maxBlockRequestSize  uint64 = 1024 * 1024      // 1mb
 	MaxBlockResponseSize uint64 = 1024 * 1024 * 16 // 16mb
+	maxStateRequestSize  uint64 = 1024 * 1024      // 1mb
+	// MaxStateResponseSize is the maximum size of a state response message.
+	MaxStateResponseSize uint64 = 1024 * 1024 * 16 // 16mb
 	// MaxGrandpaNotificationSize is maximum size for a grandpa notification message.
 	MaxGrandpaNotificationSize       uint64 = 1024 * 1024      // 1mb
 	maxTransactionsNotificationSize  uint64 = 1024 * 1024 * 16 // 16mb
@@ -116,7 +120,12 @@ func makeDir(fp string) error {
 
 // saveKey attempts to save a private key to the provided filepath
 func saveKey(priv crypto.PrivKey, fp string) (err error) {
-	pth := path.Join(filepath.Clean(fp), DefaultKeyFile)
+	return SaveNodeKeyFile(priv, path.Join(filepath.Clean(fp), DefaultKeyFile))
+}
+
+// SaveNodeKeyFile saves the private key of the p2p identity to the given file, in the
+// format of the key file loaded from the base path
+func SaveNodeKeyFile(priv crypto.PrivKey, pth string) (err error) {
 	f, err := os.Create(filepath.Clean(pth))
 	if err != nil {
 		return err
@@ -133,6 +142,26 @@ func saveKey(priv crypto.PrivKey, fp string) (err error) {
 	return f.Close()
 }
 
+// NodeKeyFromSeed returns the private key of the p2p identity built from the given ed25519
+// seed, as given with the node key configuration, along with its peer id
+func NodeKeyFromSeed(seed []byte) (crypto.PrivKey, peer.ID, error) {
+	if len(seed) != ed25519.SeedSize {
+		return nil, "", fmt.Errorf("node key seed is %d bytes long instead of %d", len(seed), ed25519.SeedSize)
+	}
+
+	privateKey, err := crypto.UnmarshalEd25519PrivateKey(ed25519.NewKeyFromSeed(seed))
+	if err != nil {
+		return nil, "", fmt.Errorf("decoding ed25519 bytes: %w", err)
+	}
+
+	peerID, err := peer.IDFromPrivateKey(privateKey)
+	if err != nil {
+		return nil, "", fmt.Errorf("getting peer id: %w", err)
+	}
+
+	return privateKey, peerID, nil
+}
+
 func Uint64ToLEB128(in uint64) []byte {
 	var out []byte
 	for {
@@ -182,6 +211,31 @@ func ReadLEB128ToUint64(r io.Reader) (uint64, int, error) {
 	return out, bytesRead, nil
 }
 
+// readMessage reads a message from the stream into the given buffer like readStream, decompressing
+// it if a compression was negotiated for the stream protocol. It returns the size of the message.
+func readMessage(stream libp2pnetwork.Stream, bufPointer *[]byte, maxSize uint64) (int, error) {
+	tot, err := readStream(stream, bufPointer, maxSize)
+	if err != nil || tot == 0 {
+		return tot, err
+	}
+
+	_, compression := splitCompression(stream.Protocol())
+	if compression == NoCompression {
+		return tot, nil
+	}
+
+	decompressed, err := decompress(compression, (*bufPointer)[:tot], maxSize)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(decompressed) > len(*bufPointer) {
+		*bufPointer = append(*bufPointer, make([]byte, len(decompressed)-len(*bufPointer))...)
+	}
+
+	return copy(*bufPointer, decompressed), nil
+}
+
 // readStream reads from the stream into the given buffer, returning the number of bytes read
 func readStream(stream libp2pnetwork.Stream, bufPointer *[]byte, maxSize uint64) (tot int, err error) {
 	if stream == nil {
@@ -57,7 +57,9 @@ func createServiceWithWarpSyncHelper(t *testing.T, warpSyncProvider WarpSyncProv
 
 	srvc := createTestService(t, config)
 	srvc.noGossip = true
-	handler := newTestStreamHandler(decodeSyncMessage)
+	handler := newTestStreamHandler(func(in []byte, _ peer.ID, _ bool) (messages.P2PMessage, error) {
+		return decodeSyncMessage(in)
+	})
 	srvc.host.registerStreamHandler(srvc.host.protocolID, handler.handleStream)
 
 	return srvc
@@ -19,21 +19,30 @@
 	"github.com/ChainSafe/gossamer/dot/digest"
 	"github.com/ChainSafe/gossamer/dot/network"
 	"github.com/ChainSafe/gossamer/dot/rpc"
+	"github.com/ChainSafe/gossamer/dot/rpc/grpc"
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/state/pruner"
 	dotsync "github.com/ChainSafe/gossamer/dot/sync"
 	"github.com/ChainSafe/gossamer/dot/system"
 	"github.com/ChainSafe/gossamer/dot/telemetry"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/clock"
 	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/internal/failover"
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/internal/metrics"
+	"github.com/ChainSafe/gossamer/internal/pprof"
+	"github.com/ChainSafe/gossamer/internal/tracing"
+	"github.com/ChainSafe/gossamer/lib/aura"
 	"github.com/ChainSafe/gossamer/lib/babe"
+	"github.com/ChainSafe/gossamer/lib/collator"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/genesis"
 	"github.com/ChainSafe/gossamer/lib/grandpa"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+	"github.com/ChainSafe/gossamer/lib/sassafras"
 	"github.com/ChainSafe/gossamer/lib/services"
 )
 
@@ -46,6 +55,26 @@ type Node struct {
 	wg              sync.WaitGroup
 	started         chan struct{}
 	metricsServer   *metrics.Server
+	tracesExporter  *tracing.Exporter
+	// ConfigLoader loads the configuration reloaded on SIGHUP, the configuration
+	// is not reloaded if it is nil.
+	ConfigLoader ConfigLoader
+
+	reloadMutex sync.Mutex
+	config      *cfg.Config
+	genesisData *genesis.Data
+	telemetry   Telemetry
+	network     *network.Service
+	state       *state.Service
+	core        *core.Service
+	role        nodeRole
+	// stopping is closed when the node stops, closing the channels returned by
+	// FinalizedBlocks and ImportedBlocks
+	stopping chan struct{}
+	// crashReporter handles the panics of the goroutines of the services, sending the
+	// reason of the node shutdown on crashed when a service cannot be restarted
+	crashReporter *crashReporter
+	crashed       chan string
 }
 
 type nodeBuilderIface interface {
@@ -57,16 +86,20 @@ type nodeBuilderIface interface {
 	loadRuntime(config *cfg.Config, ns *runtime.NodeStorage, stateSrvc *state.Service, ks *keystore.GlobalKeystore,
 		net *network.Service) error
 	createBlockVerifier(st *state.Service) *babe.VerificationManager
+	createClockGuard(config *cfg.Config, st *state.Service) (*clock.Guard, error)
 	createDigestHandler(st *state.Service) (*digest.Handler, error)
 	createCoreService(config *cfg.Config, ks *keystore.GlobalKeystore, st *state.Service, net *network.Service,
 	) (*core.Service, error)
 	createGRANDPAService(config *cfg.Config, st *state.Service, ks KeyStore,
 		net *network.Service, telemetryMailer Telemetry) (*grandpa.Service, error)
 	newSyncService(config *cfg.Config, st *state.Service, finalityGadget dotsync.FinalityGadget,
-		verifier *babe.VerificationManager, cs *core.Service, net *network.Service,
+		verifier dotsync.BabeVerifier, cs *core.Service, net *network.Service,
 		telemetryMailer Telemetry) (network.Syncer, error)
 	createBABEService(config *cfg.Config, st *state.Service, ks KeyStore, cs *core.Service,
 		telemetryMailer Telemetry) (service *babe.Service, err error)
+	createAuraVerifier(st *state.Service) (*aura.Verifier, error)
+	createAuraService(config *cfg.Config, st *state.Service, ks KeyStore, cs *core.Service,
+		telemetryMailer Telemetry) (*aura.Service, error)
 	createSystemService(cfg *types.SystemInfo, stateSrvc *state.Service) (*system.Service, error)
 	createRPCService(params rpcServiceSettings) (*rpc.HTTPServer, error)
 }
@@ -118,6 +151,16 @@ func IsNodeInitialised(basepath string) (bool, error) {
 	return true, nil
 }
 
+// checkConsensusEngine returns an error if the consensus engine selected by the chain spec
+// cannot be run. Only the primitives of Sassafras are implemented, in lib/sassafras, so the
+// chains selecting it cannot be run yet.
+func checkConsensusEngine(consensusEngine string) error {
+	if consensusEngine != sassafras.EngineName {
+		return nil
+	}
+	return errSassafrasNotSupported
+}
+
 // InitNode initialise the node with the given Config
 func InitNode(config *cfg.Config) error {
 	nodeInstance := nodeBuilder{}
@@ -142,9 +185,14 @@ func (nodeBuilder) initNode(config *cfg.Config) error {
 		return fmt.Errorf("failed to load genesis from file: %w", err)
 	}
 
+	err = checkConsensusEngine(gen.ConsensusEngine)
+	if err != nil {
+		return err
+	}
+
 	if !gen.IsRaw() {
-		// genesis is human-readable, convert to raw
-		err = gen.ToRaw()
+		// genesis is human-readable or built by the runtime, convert to raw
+		err = wazero_runtime.GenesisToRaw(gen)
 		if err != nil {
 			return fmt.Errorf("failed to convert genesis-spec to raw genesis: %w", err)
 		}
@@ -273,10 +321,16 @@ func newNode(config *cfg.Config,
 	var (
 		nodeSrvcs   []service
 		networkSrvc *network.Service
+		pprofSrvc   *pprof.Service
 	)
 
 	if config.Pprof.Enabled {
-		nodeSrvcs = append(nodeSrvcs, createPprofService(*config.Pprof))
+		pprofSrvc = createPprofService(*config.Pprof)
+		nodeSrvcs = append(nodeSrvcs, pprofSrvc)
+	}
+
+	if config.Chaos != "" {
+		logger.Warnf("🐒 injecting faults for testing: %s", config.Chaos)
 	}
 
 	stateSrvc, err := builder.createStateService(config)
@@ -289,6 +343,11 @@ func newNode(config *cfg.Config,
 		return nil, fmt.Errorf("cannot load genesis data: %w", err)
 	}
 
+	err = checkConsensusEngine(gd.ConsensusEngine)
+	if err != nil {
+		return nil, err
+	}
+
 	telemetryMailer, err := setupTelemetry(config, gd)
 	if err != nil {
 		return nil, fmt.Errorf("cannot setup telemetry mailer: %w", err)
@@ -353,7 +412,30 @@ func newNode(config *cfg.Config,
 		return nil, err
 	}
 
-	ver := builder.createBlockVerifier(stateSrvc)
+	isAura := gd.ConsensusEngine == aura.EngineName
+
+	var clockGuard *clock.Guard
+	if !isAura && config.Core.MaxClockDrift > 0 {
+		clockGuard, err = builder.createClockGuard(config, stateSrvc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create clock guard: %s", err)
+		}
+		nodeSrvcs = append(nodeSrvcs, clockGuard)
+	}
+
+	var ver dotsync.BabeVerifier
+	if isAura {
+		ver, err = builder.createAuraVerifier(stateSrvc)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		verificationManager := builder.createBlockVerifier(stateSrvc)
+		if clockGuard != nil {
+			verificationManager.SetClockGuard(clockGuard)
+		}
+		ver = verificationManager
+	}
 
 	dh, err := builder.createDigestHandler(stateSrvc)
 	if err != nil {
@@ -384,15 +466,90 @@ func newNode(config *cfg.Config,
 	}
 	nodeSrvcs = append(nodeSrvcs, syncer.(service))
 
-	bp, err := builder.createBABEService(config, stateSrvc, ks.Babe, coreSrvc, telemetryMailer)
+	var bp BlockProducer
+	if isAura {
+		bp, err = builder.createAuraService(config, stateSrvc, ks.Aura, coreSrvc, telemetryMailer)
+	} else {
+		var bs *babe.Service
+		bs, err = builder.createBABEService(config, stateSrvc, ks.Babe, coreSrvc, telemetryMailer)
+		if err == nil && clockGuard != nil {
+			bs.SetClockGuard(clockGuard)
+		}
+		bp = bs
+	}
 	if err != nil {
 		return nil, err
 	}
 	nodeSrvcs = append(nodeSrvcs, bp)
 
+	// the collator builds the parachain blocks with the Aura service, against the relay
+	// chain followed by the embedded relay chain node started before it
+	var (
+		collatorSrvc *collator.Service
+		relayNode    *relayChainNode
+	)
+	if config.Collator.Enabled {
+		auraSrvc, _ := bp.(*aura.Service)
+		collatorSrvc, relayNode, err = createCollatorService(config, stateSrvc, coreSrvc, auraSrvc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create collator service: %w", err)
+		}
+		nodeSrvcs = append(nodeSrvcs, relayNode, collatorSrvc)
+	}
+
+	roleSwitcher := newNodeRoleSwitcher(config, bp, fg)
+	var role nodeRole = roleSwitcher
+	if config.Core.Standby || config.Core.FailoverLease != "" {
+		// the node coordinating its failover stays in the full node role until it holds the lease
+		logger.Info("starting in the full node role")
+		err = roleSwitcher.SetAuthoring(false)
+		if err != nil {
+			return nil, fmt.Errorf("starting in the full node role: %w", err)
+		}
+	}
+
+	// the failover coordinator is started after the block producer and the GRANDPA
+	// service, so they are started paused before being resumed by the coordinator
+	var coordinator *failover.Coordinator
+	if config.Core.FailoverLease != "" {
+		coordinator, err = createFailoverCoordinator(config, roleSwitcher)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create failover coordinator: %w", err)
+		}
+		nodeSrvcs = append(nodeSrvcs, coordinator)
+		role = coordinator
+	}
+
+	// the watchdog is started after the services it watches
+	nodeWatchdog := createWatchdog(config, bp, fg, syncer.(service))
+	if nodeWatchdog != nil {
+		nodeSrvcs = append(nodeSrvcs, nodeWatchdog)
+	}
+
+	blockHooks, err := createBlockHooks(config, stateSrvc, coreSrvc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create block hooks: %w", err)
+	}
+	if blockHooks != nil {
+		nodeSrvcs = append(nodeSrvcs, blockHooks)
+	}
+
+	storageChangefeed, err := createChangefeed(config, stateSrvc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create storage changefeed: %w", err)
+	}
+	if storageChangefeed != nil {
+		nodeSrvcs = append(nodeSrvcs, storageChangefeed)
+	}
+
+	cacheWarmup := createWarmup(config, stateSrvc)
+	if cacheWarmup != nil {
+		nodeSrvcs = append(nodeSrvcs, cacheWarmup)
+	}
+
 	// check if rpc service is enabled
+	var rpcSrvc *rpc.HTTPServer
 	if enabled := config.RPC.IsRPCEnabled() || config.RPC.IsWSEnabled(); enabled {
-		var rpcSrvc *rpc.HTTPServer
 		cRPCParams := rpcServiceSettings{
 			config:        config,
 			nodeStorage:   ns,
@@ -403,6 +560,7 @@ func newNode(config *cfg.Config,
 			system:        sysSrvc,
 			blockFinality: fg,
 			syncer:        syncer.(rpc.SyncAPI),
+			role:          role,
 		}
 		rpcSrvc, err = builder.createRPCService(cRPCParams)
 		if err != nil {
@@ -413,19 +571,81 @@ func newNode(config *cfg.Config,
 		logger.Debug("rpc service disabled by default")
 	}
 
+	var grpcSrvc *grpc.Server
+	if config.RPC.GRPCPort != 0 {
+		grpcSrvc, err = createGRPCService(config, stateSrvc, coreSrvc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create grpc service: %s", err)
+		}
+		nodeSrvcs = append(nodeSrvcs, grpcSrvc)
+	}
+
 	// close state service last
 	nodeSrvcs = append(nodeSrvcs, stateSrvc)
 
+	if pprofSrvc != nil {
+		setPprofDiagnostics(pprofSrvc, stateSrvc, networkSrvc)
+	}
+
 	node := &Node{
 		Name:            config.Name,
 		ServiceRegistry: serviceRegistry,
 		started:         make(chan struct{}),
-	}
+		config:          config,
+		genesisData:     gd,
+		telemetry:       telemetryMailer,
+		network:         networkSrvc,
+		state:           stateSrvc,
+		core:            coreSrvc,
+		role:            role,
+		stopping:        make(chan struct{}),
+		crashed:         make(chan string, 1),
+	}
+	node.crashReporter = newCrashReporter(filepath.Join(config.BasePath, "crash-reports"), telemetryMailer,
+		node.crashed, nodeSrvcs)
 
 	for _, srvc := range nodeSrvcs {
 		node.ServiceRegistry.RegisterService(srvc)
 	}
 
+	// services are stopped before the services they depend on: the RPC servers first,
+	// then the network service to stop receiving messages, the consensus services,
+	// and the state service last to flush the database before closing it.
+	syncSrvc := syncer.(service)
+	if rpcSrvc != nil {
+		node.ServiceRegistry.DependsOn(rpcSrvc, networkSrvc, coreSrvc, bp, fg, coordinator, syncSrvc, sysSrvc,
+			stateSrvc)
+	}
+	if grpcSrvc != nil {
+		node.ServiceRegistry.DependsOn(grpcSrvc, coreSrvc, stateSrvc)
+	}
+	if networkSrvc != nil {
+		node.ServiceRegistry.DependsOn(networkSrvc, syncSrvc, coreSrvc, fg, stateSrvc)
+	}
+	node.ServiceRegistry.DependsOn(coordinator, bp, fg)
+	if nodeWatchdog != nil {
+		node.ServiceRegistry.DependsOn(nodeWatchdog, bp, fg, syncSrvc)
+	}
+	if blockHooks != nil {
+		node.ServiceRegistry.DependsOn(blockHooks, coreSrvc, stateSrvc)
+	}
+	if storageChangefeed != nil {
+		node.ServiceRegistry.DependsOn(storageChangefeed, stateSrvc)
+	}
+	if cacheWarmup != nil {
+		node.ServiceRegistry.DependsOn(cacheWarmup, stateSrvc)
+	}
+	if collatorSrvc != nil {
+		node.ServiceRegistry.DependsOn(collatorSrvc, relayNode, bp, coreSrvc, stateSrvc)
+	}
+	node.ServiceRegistry.DependsOn(bp, coreSrvc, stateSrvc)
+	node.ServiceRegistry.DependsOn(fg, stateSrvc)
+	node.ServiceRegistry.DependsOn(syncSrvc, coreSrvc, stateSrvc)
+	// the digest handler handles the digests of the blocks imported by the core service
+	node.ServiceRegistry.DependsOn(coreSrvc, dh, stateSrvc)
+	node.ServiceRegistry.DependsOn(dh, stateSrvc)
+	node.ServiceRegistry.DependsOn(sysSrvc, stateSrvc)
+
 	if config.PrometheusExternal {
 		address := fmt.Sprintf(":%d", config.PrometheusPort)
 		node.metricsServer = metrics.NewServer(address)
@@ -435,6 +655,18 @@ func newNode(config *cfg.Config,
 		}
 	}
 
+	tracingSettings := tracing.Settings{
+		File:         config.TracesFile,
+		OTLPEndpoint: config.OTLPEndpoint,
+		OTLPInsecure: config.OTLPInsecure,
+	}
+	if tracingSettings.Enabled() {
+		node.tracesExporter, err = tracing.NewExporter(tracingSettings)
+		if err != nil {
+			return nil, fmt.Errorf("cannot start traces exporter: %w", err)
+		}
+	}
+
 	return node, nil
 }
 
@@ -443,19 +675,23 @@ func setupTelemetry(config *cfg.Config, genesisData *genesis.Data) (mailer Telem
 		return telemetry.NewNoopMailer(), nil
 	}
 
-	var telemetryEndpoints []*genesis.TelemetryEndpoint
+	telemetryLogger := log.NewFromGlobal(log.AddContext("pkg", "telemetry"))
+	return telemetry.BootstrapMailer(context.TODO(),
+		telemetryEndpoints(config, genesisData), telemetryLogger)
+}
+
+// telemetryEndpoints returns the telemetry endpoints of the configuration,
+// defaulting to the ones of the genesis data if none is configured.
+func telemetryEndpoints(config *cfg.Config, genesisData *genesis.Data) (endpoints []*genesis.TelemetryEndpoint) {
 	if len(config.TelemetryURLs) == 0 && genesisData != nil {
-		telemetryEndpoints = append(telemetryEndpoints, genesisData.TelemetryEndpoints...)
-	} else {
-		telemetryURLs := config.TelemetryURLs
-		for i := range telemetryURLs {
-			telemetryEndpoints = append(telemetryEndpoints, &telemetryURLs[i])
-		}
+		return append(endpoints, genesisData.TelemetryEndpoints...)
 	}
 
-	telemetryLogger := log.NewFromGlobal(log.AddContext("pkg", "telemetry"))
-	return telemetry.BootstrapMailer(context.TODO(),
-		telemetryEndpoints, telemetryLogger)
+	telemetryURLs := config.TelemetryURLs
+	for i := range telemetryURLs {
+		endpoints = append(endpoints, &telemetryURLs[i])
+	}
+	return endpoints
 }
 
 // stores the global node name to reuse
@@ -487,6 +723,13 @@ func storeGlobalNodeName(name, basepath string) (err error) {
 func (n *Node) Start() error {
 	logger.Info("🕸️ starting node services...")
 
+	// the panics of the goroutines of the services are reported, and the services restarted
+	// or the node shut down, instead of crashing the process
+	if n.crashReporter != nil {
+		services.SetCrashHandler(n.crashReporter)
+		defer services.SetCrashHandler(nil)
+	}
+
 	// start all dot node services
 	n.ServiceRegistry.StartAll()
 
@@ -495,19 +738,63 @@ func (n *Node) Start() error {
 		sigc := make(chan os.Signal, 1)
 		signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
 		defer signal.Stop(sigc)
-		<-sigc
-		logger.Info("signal interrupt, shutting down...")
+		select {
+		case <-sigc:
+			logger.Info("signal interrupt, shutting down...")
+		case reason := <-n.crashed:
+			logger.Criticalf("%s, shutting down...", reason)
+		}
 		n.Stop()
 	}()
 
+	stopped := make(chan struct{})
+	if n.ConfigLoader != nil {
+		hangup := make(chan os.Signal, 1)
+		signal.Notify(hangup, syscall.SIGHUP)
+		go n.reloadOnHangup(hangup, stopped)
+	}
+
 	close(n.started)
 	n.wg.Wait()
+	close(stopped)
 	return nil
 }
 
+// Started returns a channel closed once the node services are started.
+func (n *Node) Started() <-chan struct{} {
+	return n.started
+}
+
+// reloadOnHangup reloads the configuration of the node each time a SIGHUP signal is received.
+func (n *Node) reloadOnHangup(hangup chan os.Signal, stopped <-chan struct{}) {
+	defer signal.Stop(hangup)
+
+	for {
+		select {
+		case <-hangup:
+			logger.Info("signal hangup, reloading configuration...")
+			config, err := n.ConfigLoader()
+			if err != nil {
+				logger.Errorf("cannot load configuration: %s", err)
+				continue
+			}
+
+			err = n.Reload(config)
+			if err != nil {
+				logger.Errorf("cannot reload configuration: %s", err)
+			}
+		case <-stopped:
+			return
+		}
+	}
+}
+
 // Stop stops all dot node services
 func (n *Node) Stop() {
 	// stop all node services
+	if n.stopping != nil {
+		close(n.stopping)
+	}
 	n.ServiceRegistry.StopAll()
 	n.wg.Done()
 	if n.metricsServer != nil {
@@ -516,6 +803,12 @@ func (n *Node) Stop() {
 			log.Errorf("cannot stop metrics server: %s", err)
 		}
 	}
+	if n.tracesExporter != nil {
+		err := n.tracesExporter.Stop()
+		if err != nil {
+			log.Errorf("cannot stop traces exporter: %s", err)
+		}
+	}
 }
 
 func (nodeBuilder) loadRuntime(config *cfg.Config, ns *runtime.NodeStorage,
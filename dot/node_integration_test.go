@@ -13,6 +13,7 @@
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/ChainSafe/gossamer/chain/westend"
 	"github.com/ChainSafe/gossamer/tests/utils/config"
@@ -26,6 +27,7 @@
 	system "github.com/ChainSafe/gossamer/dot/system"
 	"github.com/ChainSafe/gossamer/dot/telemetry"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/clock"
 	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/babe"
@@ -88,7 +90,8 @@ func TestNewNode(t *testing.T) {
 	assert.NoError(t, err)
 
 	mockServiceRegistry := NewMockServiceRegisterer(ctrl)
-	mockServiceRegistry.EXPECT().RegisterService(gomock.Any()).Times(8)
+	mockServiceRegistry.EXPECT().RegisterService(gomock.Any()).Times(9)
+	mockServiceRegistry.EXPECT().DependsOn(gomock.Any(), gomock.Any()).AnyTimes()
 
 	m := NewMocknodeBuilderIface(ctrl)
 	m.EXPECT().createStateService(initConfig).DoAndReturn(func(config *cfg.Config) (*state.Service, error) {
@@ -125,6 +128,8 @@ func TestNewNode(t *testing.T) {
 		NodeStorage{}, nil)
 	m.EXPECT().loadRuntime(initConfig, &runtime.NodeStorage{}, gomock.AssignableToTypeOf(&state.Service{}),
 		ks, gomock.AssignableToTypeOf(&network.Service{})).Return(nil)
+	m.EXPECT().createClockGuard(initConfig, gomock.AssignableToTypeOf(&state.Service{})).
+		Return(clock.NewGuard(time.Second, initConfig.Core.MaxClockDrift, ""), nil)
 	m.EXPECT().createBlockVerifier(gomock.AssignableToTypeOf(&state.Service{})).
 		Return(&babe.VerificationManager{})
 	m.EXPECT().createDigestHandler(gomock.AssignableToTypeOf(&state.Service{})).
@@ -137,7 +142,7 @@ func TestNewNode(t *testing.T) {
 		gomock.AssignableToTypeOf(&telemetry.Mailer{})).
 		Return(&grandpa.Service{}, nil)
 	m.EXPECT().newSyncService(initConfig, gomock.AssignableToTypeOf(&state.Service{}), &grandpa.Service{},
-		&babe.VerificationManager{}, &core.Service{}, gomock.AssignableToTypeOf(&network.Service{}),
+		gomock.AssignableToTypeOf(&babe.VerificationManager{}), &core.Service{}, gomock.AssignableToTypeOf(&network.Service{}),
 		gomock.AssignableToTypeOf(&telemetry.Mailer{})).
 		Return(&sync.SyncService{}, nil)
 	m.EXPECT().createBABEService(initConfig, gomock.AssignableToTypeOf(&state.Service{}), ks.Babe,
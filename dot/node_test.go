@@ -258,3 +258,30 @@ type fields struct {
 		})
 	}
 }
+
+func TestCheckConsensusEngine(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		consensusEngine string
+		errWrapped      error
+	}{
+		"babe": {
+			consensusEngine: "",
+		},
+		"sassafras": {
+			consensusEngine: "sassafras",
+			errWrapped:      errSassafrasNotSupported,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkConsensusEngine(testCase.consensusEngine)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/aura"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+)
+
+// newOfflineStateService starts a state service on the database of the given base path,
+// for the commands reading the database of a stopped node.
+func newOfflineStateService(basePath string) (*state.Service, error) {
+	babeConfig, err := offlineGenesisBABEConfig(basePath)
+	if err != nil {
+		return nil, fmt.Errorf("getting genesis BABE configuration: %w", err)
+	}
+
+	stateSrvc := state.NewService(state.Config{
+		Path:              basePath,
+		LogLevel:          log.Info,
+		GenesisBABEConfig: babeConfig,
+	})
+
+	err = stateSrvc.SetupBase()
+	if err != nil {
+		return nil, fmt.Errorf("setting up state database: %w", err)
+	}
+
+	err = stateSrvc.Start()
+	if err != nil {
+		return nil, fmt.Errorf("starting state service: %w", err)
+	}
+
+	return stateSrvc, nil
+}
+
+func stopOfflineStateService(stateSrvc *state.Service) {
+	err := stateSrvc.Stop()
+	if err != nil {
+		logger.Errorf("stopping state service: %s", err)
+	}
+}
+
+// offlineGenesisBABEConfig returns the BABE configuration of the genesis runtime stored
+// in the database of the given base path, which the epoch state is created with.
+func offlineGenesisBABEConfig(basePath string) (config *types.BabeConfiguration, err error) {
+	db, err := database.LoadDatabase(basePath, false)
+	if err != nil {
+		return nil, fmt.Errorf("loading database: %w", err)
+	}
+	defer func() {
+		closeErr := db.Close()
+		if closeErr != nil {
+			err = errors.Join(err, fmt.Errorf("closing database: %w", closeErr))
+		}
+	}()
+
+	genesisData, err := state.NewBaseState(db).LoadGenesisData()
+	if err != nil {
+		return nil, fmt.Errorf("loading genesis data: %w", err)
+	}
+
+	tries := state.NewTries()
+	blockState, err := state.NewBlockState(db, tries, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating block state: %w", err)
+	}
+
+	genesisHeader, err := blockState.GetHeader(blockState.GenesisHash())
+	if err != nil {
+		return nil, fmt.Errorf("getting genesis header: %w", err)
+	}
+
+	storageState, err := state.NewStorageState(db, blockState, tries, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating storage state: %w", err)
+	}
+
+	genesisTrie, err := storageState.LoadFromDB(genesisHeader.StateRoot)
+	if err != nil {
+		return nil, fmt.Errorf("loading genesis state: %w", err)
+	}
+
+	genesisRuntime, err := wazero_runtime.NewRuntimeFromGenesis(wazero_runtime.Config{
+		LogLvl:  log.Critical,
+		Storage: rtstorage.NewTrieState(genesisTrie),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("instantiating genesis runtime: %w", err)
+	}
+	defer genesisRuntime.Stop()
+
+	if genesisData.ConsensusEngine == aura.EngineName {
+		return aura.GenesisConfiguration(genesisRuntime)
+	}
+	return genesisRuntime.BabeConfiguration()
+}
@@ -48,6 +48,16 @@
 	// BadTransactionReason when transaction import was not performed.
 	BadTransactionReason = "Bad Transaction"
 
+	// BannedTransactionValue used when a peer gossips a transaction banned for failing repeatedly.
+	BannedTransactionValue Reputation = -(1 << 12)
+	// BannedTransactionReason used when a peer gossips a transaction banned for failing repeatedly.
+	BannedTransactionReason = "Banned transaction"
+
+	// TransactionValidationTimeoutValue used when the runtime times out validating a transaction.
+	TransactionValidationTimeoutValue Reputation = -(1 << 12)
+	// TransactionValidationTimeoutReason used when the runtime times out validating a transaction.
+	TransactionValidationTimeoutReason = "Transaction validation timeout"
+
 	// BadBlockAnnouncementValue is used when peer announces invalid block.
 	BadBlockAnnouncementValue Reputation = -(1 << 12)
 	// BadBlockAnnouncementReason is used when peer announces invalid block.
@@ -0,0 +1,160 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	cfg "github.com/ChainSafe/gossamer/config"
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/genesis"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// ConfigLoader loads the configuration of the node, to reload it while the node is running.
+type ConfigLoader func() (*cfg.Config, error)
+
+// telemetryEndpointsSetter is implemented by the telemetry mailers
+// able to change their endpoints while the node is running.
+type telemetryEndpointsSetter interface {
+	SetEndpoints(ctx context.Context, endpoints []*genesis.TelemetryEndpoint) error
+}
+
+// Reload applies the dynamic settings of the configuration given to the running node:
+// the log levels, the telemetry endpoints, the persistent peers and the standby role.
+// Any other setting change requires a restart of the node to be applied.
+func (n *Node) Reload(config *cfg.Config) error {
+	n.reloadMutex.Lock()
+	defer n.reloadMutex.Unlock()
+
+	err := reloadLogLevels(config)
+	if err != nil {
+		return fmt.Errorf("reloading log levels: %w", err)
+	}
+
+	if setter, ok := n.telemetry.(telemetryEndpointsSetter); ok && !config.NoTelemetry {
+		endpoints := telemetryEndpoints(config, n.genesisData)
+		err = setter.SetEndpoints(context.TODO(), endpoints)
+		if err != nil {
+			return fmt.Errorf("reloading telemetry endpoints: %w", err)
+		}
+	}
+
+	if n.network != nil {
+		err = n.reloadPersistentPeers(config.Network.PersistentPeers)
+		if err != nil {
+			return fmt.Errorf("reloading persistent peers: %w", err)
+		}
+	}
+
+	if n.role != nil && config.Core.Standby != n.config.Core.Standby {
+		err = n.role.SetAuthoring(!config.Core.Standby)
+		if err != nil {
+			return fmt.Errorf("switching node role: %w", err)
+		}
+	}
+
+	n.config = config
+	logger.Info("configuration reloaded")
+	return nil
+}
+
+// reloadLogLevels sets the log levels of the configuration given to the loggers
+// of each package, matched by their package context.
+func reloadLogLevels(config *cfg.Config) error {
+	packageToLogLevel := map[string]string{
+		"dot":     config.LogLevel,
+		"core":    config.Log.Core,
+		"digest":  config.Log.Digest,
+		"sync":    config.Log.Sync,
+		"network": config.Log.Network,
+		"rpc":     config.Log.RPC,
+		"state":   config.Log.State,
+		"runtime": config.Log.Wasmer,
+		"babe":    config.Log.Babe,
+		"aura":    config.Log.Babe,
+		"grandpa": config.Log.Grandpa,
+	}
+
+	for pkg, logLevel := range packageToLogLevel {
+		if logLevel == "" {
+			continue
+		}
+
+		level, err := log.ParseLevel(logLevel)
+		if err != nil {
+			return fmt.Errorf("parsing %s log level: %w", pkg, err)
+		}
+
+		log.PatchContext("pkg", pkg, log.SetLevel(level))
+	}
+
+	return nil
+}
+
+// reloadPersistentPeers reserves the persistent peers added to the configuration
+// and releases the ones removed from it.
+func (n *Node) reloadPersistentPeers(persistentPeers []string) error {
+	added, removed, err := diffPersistentPeers(n.config.Network.PersistentPeers, persistentPeers)
+	if err != nil {
+		return err
+	}
+
+	if len(added) > 0 {
+		err = n.network.AddReservedPeers(added...)
+		if err != nil {
+			return fmt.Errorf("adding reserved peers: %w", err)
+		}
+	}
+
+	if len(removed) > 0 {
+		err = n.network.RemoveReservedPeers(removed...)
+		if err != nil {
+			return fmt.Errorf("removing reserved peers: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// diffPersistentPeers returns the multiaddresses of the peers added and the
+// peer IDs of the peers removed between the previous and current persistent peers.
+func diffPersistentPeers(previous, current []string) (added, removed []string, err error) {
+	previousIDs := make([]peer.ID, len(previous))
+	previousIDsSet := make(map[peer.ID]struct{}, len(previous))
+	for i, addr := range previous {
+		addr = strings.TrimSpace(addr)
+		addrInfo, err := peer.AddrInfoFromString(addr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parsing persistent peer %s: %w", addr, err)
+		}
+		previousIDs[i] = addrInfo.ID
+		previousIDsSet[addrInfo.ID] = struct{}{}
+	}
+
+	currentIDs := make(map[peer.ID]struct{}, len(current))
+	for _, addr := range current {
+		// the persistent peers of the config file are separated with ", "
+		addr = strings.TrimSpace(addr)
+		addrInfo, err := peer.AddrInfoFromString(addr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parsing persistent peer %s: %w", addr, err)
+		}
+		currentIDs[addrInfo.ID] = struct{}{}
+
+		if _, ok := previousIDsSet[addrInfo.ID]; !ok {
+			added = append(added, addr)
+		}
+	}
+
+	for _, id := range previousIDs {
+		if _, ok := currentIDs[id]; !ok {
+			removed = append(removed, id.String())
+		}
+	}
+
+	return added, removed, nil
+}
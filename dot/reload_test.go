@@ -0,0 +1,61 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_diffPersistentPeers(t *testing.T) {
+	t.Parallel()
+
+	const (
+		peerA = "/ip4/127.0.0.1/tcp/7001/p2p/12D3KooWARREmJv5sDF3TFsnUsRrwPUQaXC5BaGGACHLGutNSGeV"
+		peerB = "/ip4/127.0.0.1/tcp/7001/p2p/12D3KooWCYyh5xoAc5oRyiGU4d9ktcqFQ23JjitNFR6bEcbw7YdN"
+		peerC = "/ip4/127.0.0.1/tcp/7001/p2p/12D3KooWHHzSeKaY8xuZVzkLbKFfvNgPPeKhFBGrMbNzbm5akpqu"
+	)
+
+	testCases := map[string]struct {
+		previous        []string
+		current         []string
+		expectedAdded   []string
+		expectedRemoved []string
+		errMessage      string
+	}{
+		"unchanged": {
+			previous: []string{peerA, peerB},
+			current:  []string{peerB, peerA},
+		},
+		"added_and_removed": {
+			previous:        []string{peerA, peerB},
+			current:         []string{peerB, peerC},
+			expectedAdded:   []string{peerC},
+			expectedRemoved: []string{"12D3KooWARREmJv5sDF3TFsnUsRrwPUQaXC5BaGGACHLGutNSGeV"},
+		},
+		"invalid_peer": {
+			previous:   []string{peerA},
+			current:    []string{"/ip4/127.0.0.1/tcp/7001"},
+			errMessage: "parsing persistent peer /ip4/127.0.0.1/tcp/7001: invalid p2p multiaddr",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			added, removed, err := diffPersistentPeers(testCase.previous, testCase.current)
+			if testCase.errMessage != "" {
+				require.EqualError(t, err, testCase.errMessage)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedAdded, added)
+			assert.Equal(t, testCase.expectedRemoved, removed)
+		})
+	}
+}
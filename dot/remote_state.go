@@ -0,0 +1,169 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+// remoteKeysPageSize is the number of keys fetched per state_getKeysPaged call
+const remoteKeysPageSize = 1000
+
+// remoteNode fetches blocks and states from a node using its HTTP RPC endpoint
+type remoteNode struct {
+	uri string
+}
+
+// finalisedHead returns the hash of the last block finalised by the remote node.
+func (n *remoteNode) finalisedHead() (common.Hash, error) {
+	var hash string
+	err := n.call("chain_getFinalizedHead", []interface{}{}, &hash)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return common.HexToHash(hash)
+}
+
+// block returns the block with the given hash, or the best block if the hash is nil.
+func (n *remoteNode) block(hash *common.Hash) (*types.Block, error) {
+	params := []interface{}{}
+	if hash != nil {
+		params = append(params, hash.String())
+	}
+
+	var response struct {
+		Block struct {
+			Header     json.RawMessage `json:"header"`
+			Extrinsics []string        `json:"extrinsics"`
+		} `json:"block"`
+	}
+	err := n.call("chain_getBlock", params, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := newHeaderFromJSON(response.Block.Header)
+	if err != nil {
+		return nil, fmt.Errorf("decoding header: %w", err)
+	}
+
+	body, err := types.NewBodyFromExtrinsicStrings(response.Block.Extrinsics)
+	if err != nil {
+		return nil, fmt.Errorf("decoding body: %w", err)
+	}
+
+	block := types.NewBlock(*header, *body)
+	return &block, nil
+}
+
+// storageEntries returns the hex encoded keys and values of the top trie at the given block.
+func (n *remoteNode) storageEntries(blockHash common.Hash) (map[string]string, error) {
+	entries := make(map[string]string)
+
+	// the start key is omitted from the first page request
+	var startKey interface{}
+	for {
+		var keys []string
+		err := n.call("state_getKeysPaged", []interface{}{"0x", remoteKeysPageSize, startKey, blockHash.String()}, &keys)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(keys) == 0 {
+			break
+		}
+
+		var changeSets []struct {
+			Changes [][2]*string `json:"changes"`
+		}
+		err = n.call("state_queryStorageAt", []interface{}{keys, blockHash.String()}, &changeSets)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, changeSet := range changeSets {
+			for _, change := range changeSet.Changes {
+				if change[0] == nil || change[1] == nil {
+					continue
+				}
+				entries[*change[0]] = *change[1]
+			}
+		}
+
+		logger.Infof("fetched %d storage entries at block %s", len(entries), blockHash)
+
+		if len(keys) < remoteKeysPageSize {
+			break
+		}
+		startKey = keys[len(keys)-1]
+	}
+
+	return entries, nil
+}
+
+// call calls the RPC method given on the remote node and decodes its result in the result given.
+func (n *remoteNode) call(method string, params []interface{}, result interface{}) error {
+	requestBody, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return fmt.Errorf("encoding %s request: %w", method, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, n.uri, bytes.NewReader(requestBody))
+	if err != nil {
+		return fmt.Errorf("creating %s request: %w", method, err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("calling %s: %w", method, err)
+	}
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("reading %s response: %w", method, err)
+	}
+
+	var rpcResponse struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	err = json.Unmarshal(responseBody, &rpcResponse)
+	if err != nil {
+		return fmt.Errorf("decoding %s response: %w", method, err)
+	}
+
+	if rpcResponse.Error != nil {
+		return fmt.Errorf("%s returned error %d: %s", method, rpcResponse.Error.Code, rpcResponse.Error.Message)
+	}
+
+	err = json.Unmarshal(rpcResponse.Result, result)
+	if err != nil {
+		return fmt.Errorf("decoding %s result: %w", method, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,251 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+)
+
+// ReplayBlockConfig is the configuration of a block replay
+type ReplayBlockConfig struct {
+	BasePath string
+	Hash     common.Hash
+	// Trace is written the runtime calls and storage accesses of the replay, if it is not nil
+	Trace io.Writer
+}
+
+// ReplayExtrinsicResult is the result of the application of an extrinsic of a replayed block
+type ReplayExtrinsicResult struct {
+	Index int
+	// Result is the encoded ApplyExtrinsicResult returned by the runtime
+	Result []byte
+}
+
+// ReplayBlockResult is the result of a block replay
+type ReplayBlockResult struct {
+	Number                 uint
+	Hash                   common.Hash
+	StoredStateRoot        common.Hash
+	ComputedStateRoot      common.Hash
+	StoredExtrinsicsRoot   common.Hash
+	ComputedExtrinsicsRoot common.Hash
+	Extrinsics             []ReplayExtrinsicResult
+	Diffs                  []StorageDiff
+}
+
+// StateRootMatches returns true if the state root computed matches the one of the stored header
+func (r *ReplayBlockResult) StateRootMatches() bool {
+	return r.ComputedStateRoot == r.StoredStateRoot
+}
+
+// ExtrinsicsRootMatches returns true if the extrinsics root computed matches the one of the stored header
+func (r *ReplayBlockResult) ExtrinsicsRootMatches() bool {
+	return r.ComputedExtrinsicsRoot == r.StoredExtrinsicsRoot
+}
+
+// ReplayBlock re-executes the block stored in the node database on the state of its parent,
+// without persisting anything, and returns the state and extrinsics roots it computes along
+// with the storage entries it modifies. The block is applied extrinsic by extrinsic with
+// Core_initialize_block, BlockBuilder_apply_extrinsic and BlockBuilder_finalize_block instead
+// of Core_execute_block, so the roots are computed even if they do not match the stored header.
+func ReplayBlock(config ReplayBlockConfig) (*ReplayBlockResult, error) {
+	stateSrvc, err := newOfflineStateService(config.BasePath)
+	if err != nil {
+		return nil, err
+	}
+	defer stopOfflineStateService(stateSrvc)
+
+	block, err := stateSrvc.Block.GetBlockByHash(config.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("getting block: %w", err)
+	}
+
+	parent, err := stateSrvc.Block.GetHeader(block.Header.ParentHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting parent header: %w", err)
+	}
+
+	parentState, err := stateSrvc.Storage.TrieState(&parent.StateRoot)
+	if err != nil {
+		return nil, fmt.Errorf("getting parent state: %w", err)
+	}
+
+	trieState := parentState
+	before := trieState.Trie().Entries()
+
+	var storage runtime.Storage = trieState
+	if config.Trace != nil {
+		storage = &tracingStorage{Storage: trieState, writer: config.Trace}
+	}
+
+	instance, err := newTryRuntimeInstance(trieState.LoadCode(), storage)
+	if err != nil {
+		return nil, fmt.Errorf("creating runtime instance: %w", err)
+	}
+	defer instance.Stop()
+
+	header, err := withoutSealDigest(&block.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	writeTrace(config.Trace, "%s for block %d", runtime.CoreInitializeBlock, header.Number)
+	err = instance.InitializeBlock(header)
+	if err != nil {
+		return nil, fmt.Errorf("initialising block: %w", err)
+	}
+
+	result := &ReplayBlockResult{
+		Number:               block.Header.Number,
+		Hash:                 config.Hash,
+		StoredStateRoot:      block.Header.StateRoot,
+		StoredExtrinsicsRoot: block.Header.ExtrinsicsRoot,
+		Extrinsics:           make([]ReplayExtrinsicResult, len(block.Body)),
+	}
+
+	for i, extrinsic := range block.Body {
+		writeTrace(config.Trace, "%s for extrinsic %d", runtime.BlockBuilderApplyExtrinsic, i)
+		output, err := instance.ApplyExtrinsic(extrinsic)
+		if err != nil {
+			return nil, fmt.Errorf("applying extrinsic %d: %w", i, err)
+		}
+		writeTrace(config.Trace, "extrinsic %d result: 0x%x", i, output)
+		result.Extrinsics[i] = ReplayExtrinsicResult{Index: i, Result: output}
+	}
+
+	writeTrace(config.Trace, "%s", runtime.BlockBuilderFinalizeBlock)
+	computedHeader, err := instance.FinalizeBlock()
+	if err != nil {
+		return nil, fmt.Errorf("finalising block: %w", err)
+	}
+	result.ComputedStateRoot = computedHeader.StateRoot
+	result.ComputedExtrinsicsRoot = computedHeader.ExtrinsicsRoot
+
+	// commit the changes of the block before reading the state entries
+	_, err = trieState.Root()
+	if err != nil {
+		return nil, fmt.Errorf("computing state root: %w", err)
+	}
+	result.Diffs = storageDiffs(before, trieState.Trie().Entries())
+
+	return result, nil
+}
+
+// withoutSealDigest returns a copy of the header given without its seal digest,
+// as the runtime initialises blocks with.
+func withoutSealDigest(header *types.Header) (*types.Header, error) {
+	headerCopy, err := header.DeepCopy()
+	if err != nil {
+		return nil, fmt.Errorf("copying header: %w", err)
+	}
+
+	headerCopy.Digest = types.NewDigest()
+	for _, digest := range header.Digest {
+		digestValue, err := digest.Value()
+		if err != nil {
+			return nil, fmt.Errorf("getting digest type value: %w", err)
+		}
+
+		if _, ok := digestValue.(types.SealDigest); ok {
+			continue
+		}
+
+		err = headerCopy.Digest.Add(digestValue)
+		if err != nil {
+			return nil, fmt.Errorf("adding digest: %w", err)
+		}
+	}
+
+	return headerCopy, nil
+}
+
+func writeTrace(writer io.Writer, format string, args ...any) {
+	if writer == nil {
+		return
+	}
+	_, _ = fmt.Fprintf(writer, format+"\n", args...)
+}
+
+// tracingStorage writes the storage accesses made by the runtime through
+// the storage host functions to its writer.
+type tracingStorage struct {
+	runtime.Storage
+	writer io.Writer
+}
+
+func (s *tracingStorage) Get(key []byte) []byte {
+	value := s.Storage.Get(key)
+	writeTrace(s.writer, "  storage get 0x%x -> 0x%x", key, value)
+	return value
+}
+
+func (s *tracingStorage) Put(key []byte, value []byte) error {
+	writeTrace(s.writer, "  storage put 0x%x = 0x%x", key, value)
+	return s.Storage.Put(key, value)
+}
+
+func (s *tracingStorage) Delete(key []byte) error {
+	writeTrace(s.writer, "  storage delete 0x%x", key)
+	return s.Storage.Delete(key)
+}
+
+func (s *tracingStorage) NextKey(key []byte) []byte {
+	next := s.Storage.NextKey(key)
+	writeTrace(s.writer, "  storage next key 0x%x -> 0x%x", key, next)
+	return next
+}
+
+func (s *tracingStorage) ClearPrefix(prefix []byte) error {
+	writeTrace(s.writer, "  storage clear prefix 0x%x", prefix)
+	return s.Storage.ClearPrefix(prefix)
+}
+
+func (s *tracingStorage) ClearPrefixLimit(prefix []byte, limit uint32) (
+	deleted uint32, allDeleted bool, err error) {
+	deleted, allDeleted, err = s.Storage.ClearPrefixLimit(prefix, limit)
+	writeTrace(s.writer, "  storage clear prefix 0x%x limit %d -> %d deleted, all deleted %t",
+		prefix, limit, deleted, allDeleted)
+	return deleted, allDeleted, err
+}
+
+func (s *tracingStorage) ClearPrefixFrom(prefix []byte, limit *uint32, cursor []byte) (
+	results rtstorage.RemovalResults, err error) {
+	results, err = s.Storage.ClearPrefixFrom(prefix, limit, cursor)
+	writeTrace(s.writer, "  storage clear prefix 0x%x from 0x%x -> %d deleted, cursor 0x%x",
+		prefix, cursor, results.Unique, results.Cursor)
+	return results, err
+}
+
+func (s *tracingStorage) Root() (common.Hash, error) {
+	root, err := s.Storage.Root()
+	writeTrace(s.writer, "  storage root -> %s", root)
+	return root, err
+}
+
+func (s *tracingStorage) GetChildStorage(keyToChild, key []byte) ([]byte, error) {
+	value, err := s.Storage.GetChildStorage(keyToChild, key)
+	writeTrace(s.writer, "  child storage 0x%x get 0x%x -> 0x%x", keyToChild, key, value)
+	return value, err
+}
+
+func (s *tracingStorage) SetChildStorage(keyToChild, key, value []byte) error {
+	writeTrace(s.writer, "  child storage 0x%x put 0x%x = 0x%x", keyToChild, key, value)
+	return s.Storage.SetChildStorage(keyToChild, key, value)
+}
+
+func (s *tracingStorage) ClearChildStorage(keyToChild, key []byte) error {
+	writeTrace(s.writer, "  child storage 0x%x delete 0x%x", keyToChild, key)
+	return s.Storage.ClearChildStorage(keyToChild, key)
+}
+
+func (s *tracingStorage) DeleteChild(keyToChild []byte) error {
+	writeTrace(s.writer, "  child storage 0x%x delete child", keyToChild)
+	return s.Storage.DeleteChild(keyToChild)
+}
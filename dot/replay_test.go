@@ -0,0 +1,56 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_withoutSealDigest(t *testing.T) {
+	t.Parallel()
+
+	preRuntimeDigest := types.NewBABEPreRuntimeDigest([]byte{1})
+	sealDigest := types.SealDigest{ConsensusEngineID: types.BabeEngineID, Data: []byte{2}}
+
+	digest := types.NewDigest()
+	require.NoError(t, digest.Add(*preRuntimeDigest, sealDigest))
+	header := types.NewHeader(common.Hash{1}, common.Hash{2}, common.Hash{3}, 1, digest)
+
+	headerWithoutSeal, err := withoutSealDigest(header)
+	require.NoError(t, err)
+
+	expectedDigest := types.NewDigest()
+	require.NoError(t, expectedDigest.Add(*preRuntimeDigest))
+	expected := types.NewHeader(common.Hash{1}, common.Hash{2}, common.Hash{3}, 1, expectedDigest)
+	assert.Equal(t, expected.Hash(), headerWithoutSeal.Hash())
+	// the header given is not modified
+	assert.Len(t, header.Digest, 2)
+}
+
+func Test_tracingStorage(t *testing.T) {
+	t.Parallel()
+
+	trace := bytes.NewBuffer(nil)
+	storage := &tracingStorage{
+		Storage: rtstorage.NewTrieState(inmemory_trie.NewEmptyTrie()),
+		writer:  trace,
+	}
+
+	require.NoError(t, storage.Put([]byte{1}, []byte{2}))
+	assert.Equal(t, []byte{2}, storage.Get([]byte{1}))
+	require.NoError(t, storage.Delete([]byte{1}))
+
+	expected := "  storage put 0x01 = 0x02\n" +
+		"  storage get 0x01 -> 0x02\n" +
+		"  storage delete 0x01\n"
+	assert.Equal(t, expected, trace.String())
+}
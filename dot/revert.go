@@ -0,0 +1,40 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+// RevertChain reverts the chain stored in the database of the given base path,
+// either by the given number of blocks or to the block with the given hash if it is not nil.
+// It returns the header of the new head of the chain.
+func RevertChain(basePath string, blocks uint, to *common.Hash) (header *types.Header, err error) {
+	stateSrvc := state.NewService(state.Config{
+		Path:     basePath,
+		LogLevel: log.Info,
+	})
+
+	err = stateSrvc.SetupBase()
+	if err != nil {
+		return nil, fmt.Errorf("cannot setup base: %w", err)
+	}
+	defer func() {
+		closeErr := stateSrvc.DB().Close()
+		if closeErr != nil {
+			err = errors.Join(err, fmt.Errorf("closing database: %w", closeErr))
+		}
+	}()
+
+	if to != nil {
+		return stateSrvc.RevertTo(*to)
+	}
+	return stateSrvc.RevertBlocks(blocks)
+}
@@ -0,0 +1,122 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	cfg "github.com/ChainSafe/gossamer/config"
+	"github.com/ChainSafe/gossamer/lib/grandpa"
+)
+
+var errNotAuthority = errors.New("node is not started as a BABE nor GRANDPA authority")
+
+// nodeRole switches the node between the authority role and the full node role
+type nodeRole interface {
+	SetAuthoring(authoring bool) error
+	IsAuthoring() bool
+}
+
+// pausableBlockProducer is a block producer whose block authoring can be paused and resumed
+type pausableBlockProducer interface {
+	Pause() error
+	Resume() error
+}
+
+// finalityVoter is a finality gadget whose voting can be paused and resumed
+type finalityVoter interface {
+	PauseVoting()
+	ResumeVoting()
+}
+
+// roleSwitcher switches the node between the authority role, authoring blocks and voting
+// for their finality, and the full node role doing neither, without restarting the node,
+// so a backup validator takes over from its primary validator.
+type roleSwitcher struct {
+	mutex     sync.Mutex
+	authoring bool
+	// blockProducer is nil if the node is not a block producing authority
+	blockProducer pausableBlockProducer
+	// voter is nil if the node is not a GRANDPA authority
+	voter finalityVoter
+}
+
+// newRoleSwitcher returns a role switcher of the node in the authority role, switching the
+// block authoring of the block producer given and the voting of the voter given, either of
+// them being nil if the node is not an authority of its consensus.
+func newRoleSwitcher(blockProducer pausableBlockProducer, voter finalityVoter) *roleSwitcher {
+	return &roleSwitcher{
+		authoring:     true,
+		blockProducer: blockProducer,
+		voter:         voter,
+	}
+}
+
+// newNodeRoleSwitcher returns the role switcher of the node of the configuration given,
+// switching the block producer if the node is a BABE authority and the GRANDPA service
+// if the node is a GRANDPA authority.
+func newNodeRoleSwitcher(config *cfg.Config, bp BlockProducer, fg *grandpa.Service) *roleSwitcher {
+	var blockProducer pausableBlockProducer
+	if config.Core.BabeAuthority {
+		blockProducer = bp
+	}
+
+	var voter finalityVoter
+	if config.Core.GrandpaAuthority {
+		voter = fg
+	}
+
+	return newRoleSwitcher(blockProducer, voter)
+}
+
+// SetAuthoring switches the node to the authority role if authoring is true, and to the
+// full node role otherwise.
+func (r *roleSwitcher) SetAuthoring(authoring bool) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.blockProducer == nil && r.voter == nil {
+		return errNotAuthority
+	}
+
+	if authoring == r.authoring {
+		return nil
+	}
+
+	if authoring {
+		if r.blockProducer != nil {
+			err := r.blockProducer.Resume()
+			if err != nil {
+				return fmt.Errorf("resuming block production: %w", err)
+			}
+		}
+		if r.voter != nil {
+			r.voter.ResumeVoting()
+		}
+		logger.Info("switched to the authority role")
+	} else {
+		if r.blockProducer != nil {
+			err := r.blockProducer.Pause()
+			if err != nil {
+				return fmt.Errorf("pausing block production: %w", err)
+			}
+		}
+		if r.voter != nil {
+			r.voter.PauseVoting()
+		}
+		logger.Info("switched to the full node role")
+	}
+
+	r.authoring = authoring
+	return nil
+}
+
+// IsAuthoring returns true if the node is in the authority role.
+func (r *roleSwitcher) IsAuthoring() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.authoring && (r.blockProducer != nil || r.voter != nil)
+}
@@ -0,0 +1,69 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_roleSwitcher_SetAuthoring(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	blockProducer := NewMockpausableBlockProducer(ctrl)
+	voter := NewMockfinalityVoter(ctrl)
+	role := newRoleSwitcher(blockProducer, voter)
+	assert.True(t, role.IsAuthoring())
+
+	// the role is unchanged when switched to the current role
+	err := role.SetAuthoring(true)
+	require.NoError(t, err)
+
+	blockProducer.EXPECT().Pause().Return(nil)
+	voter.EXPECT().PauseVoting()
+	err = role.SetAuthoring(false)
+	require.NoError(t, err)
+	assert.False(t, role.IsAuthoring())
+
+	errTest := errors.New("test error")
+	blockProducer.EXPECT().Resume().Return(errTest)
+	err = role.SetAuthoring(true)
+	assert.ErrorIs(t, err, errTest)
+	assert.EqualError(t, err, "resuming block production: test error")
+	assert.False(t, role.IsAuthoring())
+
+	blockProducer.EXPECT().Resume().Return(nil)
+	voter.EXPECT().ResumeVoting()
+	err = role.SetAuthoring(true)
+	require.NoError(t, err)
+	assert.True(t, role.IsAuthoring())
+}
+
+func Test_roleSwitcher_SetAuthoring_voterOnly(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	voter := NewMockfinalityVoter(ctrl)
+	role := newRoleSwitcher(nil, voter)
+
+	voter.EXPECT().PauseVoting()
+	err := role.SetAuthoring(false)
+	require.NoError(t, err)
+	assert.False(t, role.IsAuthoring())
+}
+
+func Test_roleSwitcher_SetAuthoring_notAuthority(t *testing.T) {
+	t.Parallel()
+
+	role := newRoleSwitcher(nil, nil)
+	assert.False(t, role.IsAuthoring())
+
+	err := role.SetAuthoring(false)
+	assert.ErrorIs(t, err, errNotAuthority)
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package rpc
+
+import (
+	"net/http"
+)
+
+// allOrigins is the CORS origin allowing the requests from any origin
+const allOrigins = "*"
+
+// originFilter filters the origins of the browser requests by an allow list of origins.
+type originFilter struct {
+	allowAll bool
+	origins  map[string]struct{}
+}
+
+func newOriginFilter(origins []string) *originFilter {
+	filter := &originFilter{origins: make(map[string]struct{}, len(origins))}
+	for _, origin := range origins {
+		if origin == allOrigins {
+			filter.allowAll = true
+		}
+		filter.origins[origin] = struct{}{}
+	}
+	return filter
+}
+
+// allowed returns true if the requests from the origin given are allowed. Requests without
+// origin are not made by a browser and are always allowed.
+func (f *originFilter) allowed(origin string) bool {
+	if origin == "" || f.allowAll {
+		return true
+	}
+	_, ok := f.origins[origin]
+	return ok
+}
+
+// corsHandler is an HTTP handler answering the CORS preflight requests and setting the CORS
+// headers of the responses to the requests from the allowed origins, the requests from the
+// other origins being refused.
+type corsHandler struct {
+	handler http.Handler
+	filter  *originFilter
+}
+
+func newCorsHandler(handler http.Handler, origins []string) *corsHandler {
+	return &corsHandler{
+		handler: handler,
+		filter:  newOriginFilter(origins),
+	}
+}
+
+func (c *corsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		c.handler.ServeHTTP(w, r)
+		return
+	}
+
+	w.Header().Add("Vary", "Origin")
+	if !c.filter.allowed(origin) {
+		http.Error(w, "origin not allowed", http.StatusForbidden)
+		return
+	}
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+
+	if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.Header().Set("Access-Control-Max-Age", "3600")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	c.handler.ServeHTTP(w, r)
+}
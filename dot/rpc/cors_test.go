@@ -0,0 +1,91 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package rpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_originFilter_allowed(t *testing.T) {
+	t.Parallel()
+
+	filter := newOriginFilter([]string{"https://polkadot.js.org"})
+	assert.True(t, filter.allowed(""))
+	assert.True(t, filter.allowed("https://polkadot.js.org"))
+	assert.False(t, filter.allowed("https://example.com"))
+
+	filter = newOriginFilter([]string{allOrigins})
+	assert.True(t, filter.allowed("https://example.com"))
+}
+
+func Test_corsHandler_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	const allowedOrigin = "https://polkadot.js.org"
+
+	testCases := map[string]struct {
+		method        string
+		origin        string
+		preflight     bool
+		status        int
+		allowedOrigin string
+		handled       bool
+	}{
+		"no_origin": {
+			method:  http.MethodPost,
+			status:  http.StatusOK,
+			handled: true,
+		},
+		"allowed_origin": {
+			method:        http.MethodPost,
+			origin:        allowedOrigin,
+			status:        http.StatusOK,
+			allowedOrigin: allowedOrigin,
+			handled:       true,
+		},
+		"origin_not_allowed": {
+			method: http.MethodPost,
+			origin: "https://example.com",
+			status: http.StatusForbidden,
+		},
+		"preflight": {
+			method:        http.MethodOptions,
+			origin:        allowedOrigin,
+			preflight:     true,
+			status:        http.StatusNoContent,
+			allowedOrigin: allowedOrigin,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			handled := false
+			handler := newCorsHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				handled = true
+			}), []string{allowedOrigin})
+
+			request := httptest.NewRequest(testCase.method, "/", nil)
+			if testCase.origin != "" {
+				request.Header.Set("Origin", testCase.origin)
+			}
+			if testCase.preflight {
+				request.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			}
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, request)
+
+			assert.Equal(t, testCase.status, recorder.Code)
+			assert.Equal(t, testCase.allowedOrigin, recorder.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, testCase.handled, handled)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ChainSafe/gossamer/dot/core"
+	"github.com/ChainSafe/gossamer/dot/rpc/grpc/proto"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/transaction"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// authorService implements the author gRPC service
+type authorService struct {
+	proto.UnimplementedAuthorServer
+	coreAPI             CoreAPI
+	transactionStateAPI TransactionStateAPI
+}
+
+// SubmitExtrinsic validates the extrinsic given and adds it to the transaction pool.
+func (as *authorService) SubmitExtrinsic(_ context.Context, request *proto.SubmitExtrinsicRequest) (
+	*proto.ExtrinsicHash, error) {
+	ext := types.Extrinsic(request.Extrinsic)
+
+	err := as.coreAPI.HandleSubmittedExtrinsic(ext)
+	if err != nil {
+		return nil, submitStatus(err)
+	}
+
+	return &proto.ExtrinsicHash{Hash: ext.Hash().ToBytes()}, nil
+}
+
+// PendingExtrinsics returns the extrinsics pending in the transaction pool.
+func (as *authorService) PendingExtrinsics(context.Context, *proto.PendingExtrinsicsRequest) (
+	*proto.PendingExtrinsicsResponse, error) {
+	pending := as.transactionStateAPI.Pending()
+
+	extrinsics := make([][]byte, len(pending))
+	for i, validTransaction := range pending {
+		extrinsics[i] = validTransaction.Extrinsic
+	}
+
+	return &proto.PendingExtrinsicsResponse{Extrinsics: extrinsics}, nil
+}
+
+// submitStatus converts the error of an extrinsic submission to a gRPC status error.
+func submitStatus(err error) error {
+	var invalid runtime.InvalidTransaction
+	switch {
+	case errors.As(err, &invalid),
+		errors.Is(err, transaction.ErrInvalidEra),
+		errors.Is(err, core.ErrTransactionExpired):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, core.ErrTransactionBanned):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, core.ErrTransactionPoolFull):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/rpc/grpc/proto"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	gogrpc "google.golang.org/grpc"
+)
+
+// chainService implements the chain gRPC service
+type chainService struct {
+	proto.UnimplementedChainServer
+	blockAPI BlockAPI
+}
+
+// GetHeader returns the header of the block of the hash given, or of the best block.
+func (cs *chainService) GetHeader(_ context.Context, request *proto.BlockRequest) (*proto.Header, error) {
+	hash, err := decodeHash(request.Hash)
+	if err != nil {
+		return nil, err
+	}
+	if hash.IsEmpty() {
+		hash = cs.blockAPI.BestBlockHash()
+	}
+
+	header, err := cs.blockAPI.GetHeader(hash)
+	if err != nil {
+		return nil, toStatus(fmt.Errorf("getting header: %w", err))
+	}
+
+	return newHeader(header)
+}
+
+// GetBlock returns the block of the hash given, or the best block.
+func (cs *chainService) GetBlock(_ context.Context, request *proto.BlockRequest) (*proto.Block, error) {
+	hash, err := decodeHash(request.Hash)
+	if err != nil {
+		return nil, err
+	}
+	if hash.IsEmpty() {
+		hash = cs.blockAPI.BestBlockHash()
+	}
+
+	block, err := cs.blockAPI.GetBlockByHash(hash)
+	if err != nil {
+		return nil, toStatus(fmt.Errorf("getting block: %w", err))
+	}
+
+	header, err := newHeader(&block.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	extrinsics := make([][]byte, len(block.Body))
+	for i, extrinsic := range block.Body {
+		extrinsics[i] = extrinsic
+	}
+
+	return &proto.Block{
+		Header:     header,
+		Extrinsics: extrinsics,
+	}, nil
+}
+
+// GetBlockHash returns the hash of the block of the canonical chain at the number given,
+// or the hash of the best block.
+func (cs *chainService) GetBlockHash(_ context.Context, request *proto.BlockHashRequest) (*proto.BlockHash, error) {
+	if request.Number == nil {
+		hash := cs.blockAPI.BestBlockHash()
+		return &proto.BlockHash{Hash: hash.ToBytes()}, nil
+	}
+
+	hash, err := cs.blockAPI.GetHashByNumber(uint(*request.Number))
+	if err != nil {
+		return nil, toStatus(fmt.Errorf("getting hash of block %d: %w", *request.Number, err))
+	}
+
+	return &proto.BlockHash{Hash: hash.ToBytes()}, nil
+}
+
+// GetFinalizedHead returns the hash of the highest finalised block.
+func (cs *chainService) GetFinalizedHead(context.Context, *proto.FinalizedHeadRequest) (*proto.BlockHash, error) {
+	hash, err := cs.blockAPI.GetHighestFinalisedHash()
+	if err != nil {
+		return nil, toStatus(fmt.Errorf("getting highest finalised hash: %w", err))
+	}
+
+	return &proto.BlockHash{Hash: hash.ToBytes()}, nil
+}
+
+// SubscribeNewHeads streams the header of each block imported, until the client cancels
+// the stream or the server stops.
+func (cs *chainService) SubscribeNewHeads(_ *proto.SubscribeHeadsRequest,
+	stream gogrpc.ServerStreamingServer[proto.Header]) error {
+	blocks := cs.blockAPI.GetImportedBlockNotifierChannel()
+	defer cs.blockAPI.FreeImportedBlockNotifierChannel(blocks)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case block := <-blocks:
+			err := sendHeader(stream, &block.Header)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// SubscribeFinalizedHeads streams the header of each block finalised, until the client
+// cancels the stream or the server stops.
+func (cs *chainService) SubscribeFinalizedHeads(_ *proto.SubscribeHeadsRequest,
+	stream gogrpc.ServerStreamingServer[proto.Header]) error {
+	finalised := cs.blockAPI.GetFinalisedNotifierChannel()
+	defer cs.blockAPI.FreeFinalisedNotifierChannel(finalised)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case info := <-finalised:
+			err := sendHeader(stream, &info.Header)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func sendHeader(stream gogrpc.ServerStreamingServer[proto.Header], header *types.Header) error {
+	message, err := newHeader(header)
+	if err != nil {
+		return err
+	}
+
+	err = stream.Send(message)
+	if err != nil {
+		return fmt.Errorf("sending header: %w", err)
+	}
+	return nil
+}
+
+func newHeader(header *types.Header) (*proto.Header, error) {
+	digest, err := scale.Marshal(header.Digest)
+	if err != nil {
+		return nil, toStatus(fmt.Errorf("encoding digest: %w", err))
+	}
+
+	return &proto.Header{
+		Hash:           header.Hash().ToBytes(),
+		ParentHash:     header.ParentHash.ToBytes(),
+		Number:         uint64(header.Number),
+		StateRoot:      header.StateRoot.ToBytes(),
+		ExtrinsicsRoot: header.ExtrinsicsRoot.ToBytes(),
+		Digest:         digest,
+	}, nil
+}
@@ -0,0 +1,43 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grpc
+
+import (
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/transaction"
+)
+
+//go:generate mockgen -source=interfaces.go -destination=mocks_test.go -package=$GOPACKAGE
+
+// BlockAPI is the interface for the block state
+type BlockAPI interface {
+	GetHeader(hash common.Hash) (*types.Header, error)
+	GetBlockByHash(hash common.Hash) (*types.Block, error)
+	GetHashByNumber(blockNumber uint) (common.Hash, error)
+	BestBlockHash() common.Hash
+	GetHighestFinalisedHash() (common.Hash, error)
+	GetImportedBlockNotifierChannel() chan *types.Block
+	FreeImportedBlockNotifierChannel(ch chan *types.Block)
+	GetFinalisedNotifierChannel() chan *types.FinalisationInfo
+	FreeFinalisedNotifierChannel(ch chan *types.FinalisationInfo)
+}
+
+// StorageAPI is the interface for the storage state
+type StorageAPI interface {
+	GetStorageByBlockHash(bhash *common.Hash, key []byte) ([]byte, error)
+	RegisterStorageObserver(observer state.Observer)
+	UnregisterStorageObserver(observer state.Observer)
+}
+
+// CoreAPI is the interface for the core methods
+type CoreAPI interface {
+	HandleSubmittedExtrinsic(ext types.Extrinsic) error
+}
+
+// TransactionStateAPI is the interface for the transaction state
+type TransactionStateAPI interface {
+	Pending() []*transaction.ValidTransaction
+}
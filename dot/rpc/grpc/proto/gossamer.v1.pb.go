@@ -0,0 +1,1037 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Schema definition of the gRPC services of the node, mirroring the chain, state
+// and author JSON-RPC methods.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.2
+// 	protoc        (unknown)
+// source: gossamer.v1.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Block header.
+type Header struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Hash of the block.
+	Hash []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// Hash of the parent block.
+	ParentHash []byte `protobuf:"bytes,2,opt,name=parent_hash,json=parentHash,proto3" json:"parent_hash,omitempty"`
+	// Number of the block.
+	Number uint64 `protobuf:"varint,3,opt,name=number,proto3" json:"number,omitempty"`
+	// Root of the state trie after the execution of the block.
+	StateRoot []byte `protobuf:"bytes,4,opt,name=state_root,json=stateRoot,proto3" json:"state_root,omitempty"`
+	// Root of the trie of the block extrinsics.
+	ExtrinsicsRoot []byte `protobuf:"bytes,5,opt,name=extrinsics_root,json=extrinsicsRoot,proto3" json:"extrinsics_root,omitempty"`
+	// SCALE encoded digest of the block.
+	Digest []byte `protobuf:"bytes,6,opt,name=digest,proto3" json:"digest,omitempty"`
+}
+
+func (x *Header) Reset() {
+	*x = Header{}
+	mi := &file_gossamer_v1_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Header) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Header) ProtoMessage() {}
+
+func (x *Header) ProtoReflect() protoreflect.Message {
+	mi := &file_gossamer_v1_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Header.ProtoReflect.Descriptor instead.
+func (*Header) Descriptor() ([]byte, []int) {
+	return file_gossamer_v1_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Header) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *Header) GetParentHash() []byte {
+	if x != nil {
+		return x.ParentHash
+	}
+	return nil
+}
+
+func (x *Header) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Header) GetStateRoot() []byte {
+	if x != nil {
+		return x.StateRoot
+	}
+	return nil
+}
+
+func (x *Header) GetExtrinsicsRoot() []byte {
+	if x != nil {
+		return x.ExtrinsicsRoot
+	}
+	return nil
+}
+
+func (x *Header) GetDigest() []byte {
+	if x != nil {
+		return x.Digest
+	}
+	return nil
+}
+
+// Block header and body.
+type Block struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Header *Header `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// SCALE encoded extrinsics of the block.
+	Extrinsics [][]byte `protobuf:"bytes,2,rep,name=extrinsics,proto3" json:"extrinsics,omitempty"`
+}
+
+func (x *Block) Reset() {
+	*x = Block{}
+	mi := &file_gossamer_v1_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Block) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Block) ProtoMessage() {}
+
+func (x *Block) ProtoReflect() protoreflect.Message {
+	mi := &file_gossamer_v1_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Block.ProtoReflect.Descriptor instead.
+func (*Block) Descriptor() ([]byte, []int) {
+	return file_gossamer_v1_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Block) GetHeader() *Header {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *Block) GetExtrinsics() [][]byte {
+	if x != nil {
+		return x.Extrinsics
+	}
+	return nil
+}
+
+// Request of a block header or block.
+type BlockRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Hash of the block, the best block if it is empty.
+	Hash []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+}
+
+func (x *BlockRequest) Reset() {
+	*x = BlockRequest{}
+	mi := &file_gossamer_v1_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockRequest) ProtoMessage() {}
+
+func (x *BlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gossamer_v1_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockRequest.ProtoReflect.Descriptor instead.
+func (*BlockRequest) Descriptor() ([]byte, []int) {
+	return file_gossamer_v1_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BlockRequest) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+// Request of the hash of the block of the canonical chain at a number.
+type BlockHashRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Number of the block, the best block if it is not set.
+	Number *uint64 `protobuf:"varint,1,opt,name=number,proto3,oneof" json:"number,omitempty"`
+}
+
+func (x *BlockHashRequest) Reset() {
+	*x = BlockHashRequest{}
+	mi := &file_gossamer_v1_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockHashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockHashRequest) ProtoMessage() {}
+
+func (x *BlockHashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gossamer_v1_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockHashRequest.ProtoReflect.Descriptor instead.
+func (*BlockHashRequest) Descriptor() ([]byte, []int) {
+	return file_gossamer_v1_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BlockHashRequest) GetNumber() uint64 {
+	if x != nil && x.Number != nil {
+		return *x.Number
+	}
+	return 0
+}
+
+// Hash of a block.
+type BlockHash struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hash []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+}
+
+func (x *BlockHash) Reset() {
+	*x = BlockHash{}
+	mi := &file_gossamer_v1_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockHash) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockHash) ProtoMessage() {}
+
+func (x *BlockHash) ProtoReflect() protoreflect.Message {
+	mi := &file_gossamer_v1_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockHash.ProtoReflect.Descriptor instead.
+func (*BlockHash) Descriptor() ([]byte, []int) {
+	return file_gossamer_v1_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BlockHash) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+// Request of the hash of the highest finalised block.
+type FinalizedHeadRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *FinalizedHeadRequest) Reset() {
+	*x = FinalizedHeadRequest{}
+	mi := &file_gossamer_v1_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinalizedHeadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinalizedHeadRequest) ProtoMessage() {}
+
+func (x *FinalizedHeadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gossamer_v1_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinalizedHeadRequest.ProtoReflect.Descriptor instead.
+func (*FinalizedHeadRequest) Descriptor() ([]byte, []int) {
+	return file_gossamer_v1_proto_rawDescGZIP(), []int{5}
+}
+
+// Request of a stream of block headers.
+type SubscribeHeadsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SubscribeHeadsRequest) Reset() {
+	*x = SubscribeHeadsRequest{}
+	mi := &file_gossamer_v1_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeHeadsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeHeadsRequest) ProtoMessage() {}
+
+func (x *SubscribeHeadsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gossamer_v1_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeHeadsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeHeadsRequest) Descriptor() ([]byte, []int) {
+	return file_gossamer_v1_proto_rawDescGZIP(), []int{6}
+}
+
+// Request of a storage value.
+type StorageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Storage key.
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Hash of the block to read the storage at, the best block if it is empty.
+	BlockHash []byte `protobuf:"bytes,2,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+}
+
+func (x *StorageRequest) Reset() {
+	*x = StorageRequest{}
+	mi := &file_gossamer_v1_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StorageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageRequest) ProtoMessage() {}
+
+func (x *StorageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gossamer_v1_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageRequest.ProtoReflect.Descriptor instead.
+func (*StorageRequest) Descriptor() ([]byte, []int) {
+	return file_gossamer_v1_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StorageRequest) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *StorageRequest) GetBlockHash() []byte {
+	if x != nil {
+		return x.BlockHash
+	}
+	return nil
+}
+
+// Storage value.
+type StorageValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Value of the key, not set if the key has no value.
+	Value []byte `protobuf:"bytes,1,opt,name=value,proto3,oneof" json:"value,omitempty"`
+}
+
+func (x *StorageValue) Reset() {
+	*x = StorageValue{}
+	mi := &file_gossamer_v1_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StorageValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageValue) ProtoMessage() {}
+
+func (x *StorageValue) ProtoReflect() protoreflect.Message {
+	mi := &file_gossamer_v1_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageValue.ProtoReflect.Descriptor instead.
+func (*StorageValue) Descriptor() ([]byte, []int) {
+	return file_gossamer_v1_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *StorageValue) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// Request of a stream of storage changes.
+type SubscribeStorageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Storage keys to watch.
+	Keys [][]byte `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+}
+
+func (x *SubscribeStorageRequest) Reset() {
+	*x = SubscribeStorageRequest{}
+	mi := &file_gossamer_v1_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeStorageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeStorageRequest) ProtoMessage() {}
+
+func (x *SubscribeStorageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gossamer_v1_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeStorageRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeStorageRequest) Descriptor() ([]byte, []int) {
+	return file_gossamer_v1_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SubscribeStorageRequest) GetKeys() [][]byte {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+// Change of a storage key.
+type StorageChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// New value of the key, not set if the key was deleted.
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3,oneof" json:"value,omitempty"`
+}
+
+func (x *StorageChange) Reset() {
+	*x = StorageChange{}
+	mi := &file_gossamer_v1_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StorageChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageChange) ProtoMessage() {}
+
+func (x *StorageChange) ProtoReflect() protoreflect.Message {
+	mi := &file_gossamer_v1_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageChange.ProtoReflect.Descriptor instead.
+func (*StorageChange) Descriptor() ([]byte, []int) {
+	return file_gossamer_v1_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *StorageChange) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *StorageChange) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// Changes of the storage keys watched at a block.
+type StorageChangeSet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// State root of the block the changes were made at.
+	StateRoot []byte           `protobuf:"bytes,1,opt,name=state_root,json=stateRoot,proto3" json:"state_root,omitempty"`
+	Changes   []*StorageChange `protobuf:"bytes,2,rep,name=changes,proto3" json:"changes,omitempty"`
+}
+
+func (x *StorageChangeSet) Reset() {
+	*x = StorageChangeSet{}
+	mi := &file_gossamer_v1_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StorageChangeSet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StorageChangeSet) ProtoMessage() {}
+
+func (x *StorageChangeSet) ProtoReflect() protoreflect.Message {
+	mi := &file_gossamer_v1_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StorageChangeSet.ProtoReflect.Descriptor instead.
+func (*StorageChangeSet) Descriptor() ([]byte, []int) {
+	return file_gossamer_v1_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *StorageChangeSet) GetStateRoot() []byte {
+	if x != nil {
+		return x.StateRoot
+	}
+	return nil
+}
+
+func (x *StorageChangeSet) GetChanges() []*StorageChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// Request to submit an extrinsic.
+type SubmitExtrinsicRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// SCALE encoded extrinsic.
+	Extrinsic []byte `protobuf:"bytes,1,opt,name=extrinsic,proto3" json:"extrinsic,omitempty"`
+}
+
+func (x *SubmitExtrinsicRequest) Reset() {
+	*x = SubmitExtrinsicRequest{}
+	mi := &file_gossamer_v1_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitExtrinsicRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitExtrinsicRequest) ProtoMessage() {}
+
+func (x *SubmitExtrinsicRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gossamer_v1_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitExtrinsicRequest.ProtoReflect.Descriptor instead.
+func (*SubmitExtrinsicRequest) Descriptor() ([]byte, []int) {
+	return file_gossamer_v1_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SubmitExtrinsicRequest) GetExtrinsic() []byte {
+	if x != nil {
+		return x.Extrinsic
+	}
+	return nil
+}
+
+// Hash of a submitted extrinsic.
+type ExtrinsicHash struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hash []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+}
+
+func (x *ExtrinsicHash) Reset() {
+	*x = ExtrinsicHash{}
+	mi := &file_gossamer_v1_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExtrinsicHash) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExtrinsicHash) ProtoMessage() {}
+
+func (x *ExtrinsicHash) ProtoReflect() protoreflect.Message {
+	mi := &file_gossamer_v1_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExtrinsicHash.ProtoReflect.Descriptor instead.
+func (*ExtrinsicHash) Descriptor() ([]byte, []int) {
+	return file_gossamer_v1_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ExtrinsicHash) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+// Request of the extrinsics pending in the transaction pool.
+type PendingExtrinsicsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *PendingExtrinsicsRequest) Reset() {
+	*x = PendingExtrinsicsRequest{}
+	mi := &file_gossamer_v1_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PendingExtrinsicsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingExtrinsicsRequest) ProtoMessage() {}
+
+func (x *PendingExtrinsicsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_gossamer_v1_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingExtrinsicsRequest.ProtoReflect.Descriptor instead.
+func (*PendingExtrinsicsRequest) Descriptor() ([]byte, []int) {
+	return file_gossamer_v1_proto_rawDescGZIP(), []int{14}
+}
+
+// Extrinsics pending in the transaction pool.
+type PendingExtrinsicsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// SCALE encoded extrinsics.
+	Extrinsics [][]byte `protobuf:"bytes,1,rep,name=extrinsics,proto3" json:"extrinsics,omitempty"`
+}
+
+func (x *PendingExtrinsicsResponse) Reset() {
+	*x = PendingExtrinsicsResponse{}
+	mi := &file_gossamer_v1_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PendingExtrinsicsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingExtrinsicsResponse) ProtoMessage() {}
+
+func (x *PendingExtrinsicsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_gossamer_v1_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingExtrinsicsResponse.ProtoReflect.Descriptor instead.
+func (*PendingExtrinsicsResponse) Descriptor() ([]byte, []int) {
+	return file_gossamer_v1_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PendingExtrinsicsResponse) GetExtrinsics() [][]byte {
+	if x != nil {
+		return x.Extrinsics
+	}
+	return nil
+}
+
+var File_gossamer_v1_proto protoreflect.FileDescriptor
+
+var file_gossamer_v1_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x67, 0x6f, 0x73, 0x73, 0x61, 0x6d, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x67, 0x6f, 0x73, 0x73, 0x61, 0x6d, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x22, 0xb5, 0x01, 0x0a, 0x06, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x68,
+	0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12,
+	0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68,
+	0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x78, 0x74, 0x72, 0x69,
+	0x6e, 0x73, 0x69, 0x63, 0x73, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0e, 0x65, 0x78, 0x74, 0x72, 0x69, 0x6e, 0x73, 0x69, 0x63, 0x73, 0x52, 0x6f, 0x6f, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x54, 0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x12, 0x2b, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x67, 0x6f, 0x73, 0x73, 0x61, 0x6d, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x1e,
+	0x0a, 0x0a, 0x65, 0x78, 0x74, 0x72, 0x69, 0x6e, 0x73, 0x69, 0x63, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0c, 0x52, 0x0a, 0x65, 0x78, 0x74, 0x72, 0x69, 0x6e, 0x73, 0x69, 0x63, 0x73, 0x22, 0x22,
+	0x0a, 0x0c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x61,
+	0x73, 0x68, 0x22, 0x3a, 0x0a, 0x10, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x1f,
+	0x0a, 0x09, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x68,
+	0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x22,
+	0x16, 0x0a, 0x14, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x48, 0x65, 0x61, 0x64,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x17, 0x0a, 0x15, 0x53, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x62, 0x65, 0x48, 0x65, 0x61, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x41, 0x0a, 0x0e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x61,
+	0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48,
+	0x61, 0x73, 0x68, 0x22, 0x33, 0x0a, 0x0c, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x12, 0x19, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x48, 0x00, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x88, 0x01, 0x01, 0x42, 0x08,
+	0x0a, 0x06, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x2d, 0x0a, 0x17, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0c, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x22, 0x46, 0x0a, 0x0d, 0x53, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x19, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22,
+	0x67, 0x0a, 0x10, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x53, 0x65, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x72, 0x6f, 0x6f,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x65, 0x52, 0x6f,
+	0x6f, 0x74, 0x12, 0x34, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x73, 0x73, 0x61, 0x6d, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52,
+	0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0x36, 0x0a, 0x16, 0x53, 0x75, 0x62, 0x6d,
+	0x69, 0x74, 0x45, 0x78, 0x74, 0x72, 0x69, 0x6e, 0x73, 0x69, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x78, 0x74, 0x72, 0x69, 0x6e, 0x73, 0x69, 0x63, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x65, 0x78, 0x74, 0x72, 0x69, 0x6e, 0x73, 0x69, 0x63,
+	0x22, 0x23, 0x0a, 0x0d, 0x45, 0x78, 0x74, 0x72, 0x69, 0x6e, 0x73, 0x69, 0x63, 0x48, 0x61, 0x73,
+	0x68, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x04, 0x68, 0x61, 0x73, 0x68, 0x22, 0x1a, 0x0a, 0x18, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x45, 0x78, 0x74, 0x72, 0x69, 0x6e, 0x73, 0x69, 0x63, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x3b, 0x0a, 0x19, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x45, 0x78, 0x74, 0x72,
+	0x69, 0x6e, 0x73, 0x69, 0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e,
+	0x0a, 0x0a, 0x65, 0x78, 0x74, 0x72, 0x69, 0x6e, 0x73, 0x69, 0x63, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0c, 0x52, 0x0a, 0x65, 0x78, 0x74, 0x72, 0x69, 0x6e, 0x73, 0x69, 0x63, 0x73, 0x32, 0xbb,
+	0x03, 0x0a, 0x05, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x3b, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x48,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x19, 0x2e, 0x67, 0x6f, 0x73, 0x73, 0x61, 0x6d, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x13, 0x2e, 0x67, 0x6f, 0x73, 0x73, 0x61, 0x6d, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x48,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x39, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x12, 0x19, 0x2e, 0x67, 0x6f, 0x73, 0x73, 0x61, 0x6d, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x67,
+	0x6f, 0x73, 0x73, 0x61, 0x6d, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x12, 0x45, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68,
+	0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x73, 0x73, 0x61, 0x6d, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x16, 0x2e, 0x67, 0x6f, 0x73, 0x73, 0x61, 0x6d, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x4d, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x46, 0x69,
+	0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x48, 0x65, 0x61, 0x64, 0x12, 0x21, 0x2e, 0x67, 0x6f,
+	0x73, 0x73, 0x61, 0x6d, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69,
+	0x7a, 0x65, 0x64, 0x48, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
+	0x2e, 0x67, 0x6f, 0x73, 0x73, 0x61, 0x6d, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x4e, 0x0a, 0x11, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x62, 0x65, 0x4e, 0x65, 0x77, 0x48, 0x65, 0x61, 0x64, 0x73, 0x12, 0x22, 0x2e, 0x67, 0x6f,
+	0x73, 0x73, 0x61, 0x6d, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x62, 0x65, 0x48, 0x65, 0x61, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x13, 0x2e, 0x67, 0x6f, 0x73, 0x73, 0x61, 0x6d, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x30, 0x01, 0x12, 0x54, 0x0a, 0x17, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x62, 0x65, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x48, 0x65, 0x61, 0x64,
+	0x73, 0x12, 0x22, 0x2e, 0x67, 0x6f, 0x73, 0x73, 0x61, 0x6d, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x48, 0x65, 0x61, 0x64, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x67, 0x6f, 0x73, 0x73, 0x61, 0x6d, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x30, 0x01, 0x32, 0xa8, 0x01, 0x0a,
+	0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x44, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x53, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x2e, 0x67, 0x6f, 0x73, 0x73, 0x61, 0x6d, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x19, 0x2e, 0x67, 0x6f, 0x73, 0x73, 0x61, 0x6d, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x59, 0x0a, 0x10,
+	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x12, 0x24, 0x2e, 0x67, 0x6f, 0x73, 0x73, 0x61, 0x6d, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x67, 0x6f, 0x73, 0x73, 0x61, 0x6d, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x53, 0x65, 0x74, 0x30, 0x01, 0x32, 0xc0, 0x01, 0x0a, 0x06, 0x41, 0x75, 0x74, 0x68,
+	0x6f, 0x72, 0x12, 0x52, 0x0a, 0x0f, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x45, 0x78, 0x74, 0x72,
+	0x69, 0x6e, 0x73, 0x69, 0x63, 0x12, 0x23, 0x2e, 0x67, 0x6f, 0x73, 0x73, 0x61, 0x6d, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x45, 0x78, 0x74, 0x72, 0x69, 0x6e,
+	0x73, 0x69, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x6f, 0x73,
+	0x73, 0x61, 0x6d, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x74, 0x72, 0x69, 0x6e, 0x73,
+	0x69, 0x63, 0x48, 0x61, 0x73, 0x68, 0x12, 0x62, 0x0a, 0x11, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x45, 0x78, 0x74, 0x72, 0x69, 0x6e, 0x73, 0x69, 0x63, 0x73, 0x12, 0x25, 0x2e, 0x67, 0x6f,
+	0x73, 0x73, 0x61, 0x6d, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x45, 0x78, 0x74, 0x72, 0x69, 0x6e, 0x73, 0x69, 0x63, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x26, 0x2e, 0x67, 0x6f, 0x73, 0x73, 0x61, 0x6d, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x45, 0x78, 0x74, 0x72, 0x69, 0x6e, 0x73, 0x69,
+	0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x32, 0x5a, 0x30, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x53, 0x61,
+	0x66, 0x65, 0x2f, 0x67, 0x6f, 0x73, 0x73, 0x61, 0x6d, 0x65, 0x72, 0x2f, 0x64, 0x6f, 0x74, 0x2f,
+	0x72, 0x70, 0x63, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_gossamer_v1_proto_rawDescOnce sync.Once
+	file_gossamer_v1_proto_rawDescData = file_gossamer_v1_proto_rawDesc
+)
+
+func file_gossamer_v1_proto_rawDescGZIP() []byte {
+	file_gossamer_v1_proto_rawDescOnce.Do(func() {
+		file_gossamer_v1_proto_rawDescData = protoimpl.X.CompressGZIP(file_gossamer_v1_proto_rawDescData)
+	})
+	return file_gossamer_v1_proto_rawDescData
+}
+
+var file_gossamer_v1_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_gossamer_v1_proto_goTypes = []any{
+	(*Header)(nil),                    // 0: gossamer.v1.Header
+	(*Block)(nil),                     // 1: gossamer.v1.Block
+	(*BlockRequest)(nil),              // 2: gossamer.v1.BlockRequest
+	(*BlockHashRequest)(nil),          // 3: gossamer.v1.BlockHashRequest
+	(*BlockHash)(nil),                 // 4: gossamer.v1.BlockHash
+	(*FinalizedHeadRequest)(nil),      // 5: gossamer.v1.FinalizedHeadRequest
+	(*SubscribeHeadsRequest)(nil),     // 6: gossamer.v1.SubscribeHeadsRequest
+	(*StorageRequest)(nil),            // 7: gossamer.v1.StorageRequest
+	(*StorageValue)(nil),              // 8: gossamer.v1.StorageValue
+	(*SubscribeStorageRequest)(nil),   // 9: gossamer.v1.SubscribeStorageRequest
+	(*StorageChange)(nil),             // 10: gossamer.v1.StorageChange
+	(*StorageChangeSet)(nil),          // 11: gossamer.v1.StorageChangeSet
+	(*SubmitExtrinsicRequest)(nil),    // 12: gossamer.v1.SubmitExtrinsicRequest
+	(*ExtrinsicHash)(nil),             // 13: gossamer.v1.ExtrinsicHash
+	(*PendingExtrinsicsRequest)(nil),  // 14: gossamer.v1.PendingExtrinsicsRequest
+	(*PendingExtrinsicsResponse)(nil), // 15: gossamer.v1.PendingExtrinsicsResponse
+}
+var file_gossamer_v1_proto_depIdxs = []int32{
+	0,  // 0: gossamer.v1.Block.header:type_name -> gossamer.v1.Header
+	10, // 1: gossamer.v1.StorageChangeSet.changes:type_name -> gossamer.v1.StorageChange
+	2,  // 2: gossamer.v1.Chain.GetHeader:input_type -> gossamer.v1.BlockRequest
+	2,  // 3: gossamer.v1.Chain.GetBlock:input_type -> gossamer.v1.BlockRequest
+	3,  // 4: gossamer.v1.Chain.GetBlockHash:input_type -> gossamer.v1.BlockHashRequest
+	5,  // 5: gossamer.v1.Chain.GetFinalizedHead:input_type -> gossamer.v1.FinalizedHeadRequest
+	6,  // 6: gossamer.v1.Chain.SubscribeNewHeads:input_type -> gossamer.v1.SubscribeHeadsRequest
+	6,  // 7: gossamer.v1.Chain.SubscribeFinalizedHeads:input_type -> gossamer.v1.SubscribeHeadsRequest
+	7,  // 8: gossamer.v1.State.GetStorage:input_type -> gossamer.v1.StorageRequest
+	9,  // 9: gossamer.v1.State.SubscribeStorage:input_type -> gossamer.v1.SubscribeStorageRequest
+	12, // 10: gossamer.v1.Author.SubmitExtrinsic:input_type -> gossamer.v1.SubmitExtrinsicRequest
+	14, // 11: gossamer.v1.Author.PendingExtrinsics:input_type -> gossamer.v1.PendingExtrinsicsRequest
+	0,  // 12: gossamer.v1.Chain.GetHeader:output_type -> gossamer.v1.Header
+	1,  // 13: gossamer.v1.Chain.GetBlock:output_type -> gossamer.v1.Block
+	4,  // 14: gossamer.v1.Chain.GetBlockHash:output_type -> gossamer.v1.BlockHash
+	4,  // 15: gossamer.v1.Chain.GetFinalizedHead:output_type -> gossamer.v1.BlockHash
+	0,  // 16: gossamer.v1.Chain.SubscribeNewHeads:output_type -> gossamer.v1.Header
+	0,  // 17: gossamer.v1.Chain.SubscribeFinalizedHeads:output_type -> gossamer.v1.Header
+	8,  // 18: gossamer.v1.State.GetStorage:output_type -> gossamer.v1.StorageValue
+	11, // 19: gossamer.v1.State.SubscribeStorage:output_type -> gossamer.v1.StorageChangeSet
+	13, // 20: gossamer.v1.Author.SubmitExtrinsic:output_type -> gossamer.v1.ExtrinsicHash
+	15, // 21: gossamer.v1.Author.PendingExtrinsics:output_type -> gossamer.v1.PendingExtrinsicsResponse
+	12, // [12:22] is the sub-list for method output_type
+	2,  // [2:12] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_gossamer_v1_proto_init() }
+func file_gossamer_v1_proto_init() {
+	if File_gossamer_v1_proto != nil {
+		return
+	}
+	file_gossamer_v1_proto_msgTypes[3].OneofWrappers = []any{}
+	file_gossamer_v1_proto_msgTypes[8].OneofWrappers = []any{}
+	file_gossamer_v1_proto_msgTypes[10].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_gossamer_v1_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   3,
+		},
+		GoTypes:           file_gossamer_v1_proto_goTypes,
+		DependencyIndexes: file_gossamer_v1_proto_depIdxs,
+		MessageInfos:      file_gossamer_v1_proto_msgTypes,
+	}.Build()
+	File_gossamer_v1_proto = out.File
+	file_gossamer_v1_proto_rawDesc = nil
+	file_gossamer_v1_proto_goTypes = nil
+	file_gossamer_v1_proto_depIdxs = nil
+}
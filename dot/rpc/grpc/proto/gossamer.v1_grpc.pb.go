@@ -0,0 +1,640 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Schema definition of the gRPC services of the node, mirroring the chain, state
+// and author JSON-RPC methods.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: gossamer.v1.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Chain_GetHeader_FullMethodName               = "/gossamer.v1.Chain/GetHeader"
+	Chain_GetBlock_FullMethodName                = "/gossamer.v1.Chain/GetBlock"
+	Chain_GetBlockHash_FullMethodName            = "/gossamer.v1.Chain/GetBlockHash"
+	Chain_GetFinalizedHead_FullMethodName        = "/gossamer.v1.Chain/GetFinalizedHead"
+	Chain_SubscribeNewHeads_FullMethodName       = "/gossamer.v1.Chain/SubscribeNewHeads"
+	Chain_SubscribeFinalizedHeads_FullMethodName = "/gossamer.v1.Chain/SubscribeFinalizedHeads"
+)
+
+// ChainClient is the client API for Chain service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Chain service, mirroring the chain JSON-RPC methods.
+type ChainClient interface {
+	// Returns the header of a block.
+	GetHeader(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*Header, error)
+	// Returns a block.
+	GetBlock(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*Block, error)
+	// Returns the hash of the block of the canonical chain at a number.
+	GetBlockHash(ctx context.Context, in *BlockHashRequest, opts ...grpc.CallOption) (*BlockHash, error)
+	// Returns the hash of the highest finalised block.
+	GetFinalizedHead(ctx context.Context, in *FinalizedHeadRequest, opts ...grpc.CallOption) (*BlockHash, error)
+	// Streams the header of each block imported.
+	SubscribeNewHeads(ctx context.Context, in *SubscribeHeadsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Header], error)
+	// Streams the header of each block finalised.
+	SubscribeFinalizedHeads(ctx context.Context, in *SubscribeHeadsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Header], error)
+}
+
+type chainClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewChainClient(cc grpc.ClientConnInterface) ChainClient {
+	return &chainClient{cc}
+}
+
+func (c *chainClient) GetHeader(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*Header, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Header)
+	err := c.cc.Invoke(ctx, Chain_GetHeader_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chainClient) GetBlock(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*Block, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Block)
+	err := c.cc.Invoke(ctx, Chain_GetBlock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chainClient) GetBlockHash(ctx context.Context, in *BlockHashRequest, opts ...grpc.CallOption) (*BlockHash, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BlockHash)
+	err := c.cc.Invoke(ctx, Chain_GetBlockHash_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chainClient) GetFinalizedHead(ctx context.Context, in *FinalizedHeadRequest, opts ...grpc.CallOption) (*BlockHash, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BlockHash)
+	err := c.cc.Invoke(ctx, Chain_GetFinalizedHead_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chainClient) SubscribeNewHeads(ctx context.Context, in *SubscribeHeadsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Header], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Chain_ServiceDesc.Streams[0], Chain_SubscribeNewHeads_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeHeadsRequest, Header]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Chain_SubscribeNewHeadsClient = grpc.ServerStreamingClient[Header]
+
+func (c *chainClient) SubscribeFinalizedHeads(ctx context.Context, in *SubscribeHeadsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Header], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Chain_ServiceDesc.Streams[1], Chain_SubscribeFinalizedHeads_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeHeadsRequest, Header]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Chain_SubscribeFinalizedHeadsClient = grpc.ServerStreamingClient[Header]
+
+// ChainServer is the server API for Chain service.
+// All implementations must embed UnimplementedChainServer
+// for forward compatibility.
+//
+// Chain service, mirroring the chain JSON-RPC methods.
+type ChainServer interface {
+	// Returns the header of a block.
+	GetHeader(context.Context, *BlockRequest) (*Header, error)
+	// Returns a block.
+	GetBlock(context.Context, *BlockRequest) (*Block, error)
+	// Returns the hash of the block of the canonical chain at a number.
+	GetBlockHash(context.Context, *BlockHashRequest) (*BlockHash, error)
+	// Returns the hash of the highest finalised block.
+	GetFinalizedHead(context.Context, *FinalizedHeadRequest) (*BlockHash, error)
+	// Streams the header of each block imported.
+	SubscribeNewHeads(*SubscribeHeadsRequest, grpc.ServerStreamingServer[Header]) error
+	// Streams the header of each block finalised.
+	SubscribeFinalizedHeads(*SubscribeHeadsRequest, grpc.ServerStreamingServer[Header]) error
+	mustEmbedUnimplementedChainServer()
+}
+
+// UnimplementedChainServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedChainServer struct{}
+
+func (UnimplementedChainServer) GetHeader(context.Context, *BlockRequest) (*Header, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHeader not implemented")
+}
+func (UnimplementedChainServer) GetBlock(context.Context, *BlockRequest) (*Block, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlock not implemented")
+}
+func (UnimplementedChainServer) GetBlockHash(context.Context, *BlockHashRequest) (*BlockHash, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockHash not implemented")
+}
+func (UnimplementedChainServer) GetFinalizedHead(context.Context, *FinalizedHeadRequest) (*BlockHash, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFinalizedHead not implemented")
+}
+func (UnimplementedChainServer) SubscribeNewHeads(*SubscribeHeadsRequest, grpc.ServerStreamingServer[Header]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeNewHeads not implemented")
+}
+func (UnimplementedChainServer) SubscribeFinalizedHeads(*SubscribeHeadsRequest, grpc.ServerStreamingServer[Header]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeFinalizedHeads not implemented")
+}
+func (UnimplementedChainServer) mustEmbedUnimplementedChainServer() {}
+func (UnimplementedChainServer) testEmbeddedByValue()               {}
+
+// UnsafeChainServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ChainServer will
+// result in compilation errors.
+type UnsafeChainServer interface {
+	mustEmbedUnimplementedChainServer()
+}
+
+func RegisterChainServer(s grpc.ServiceRegistrar, srv ChainServer) {
+	// If the following call pancis, it indicates UnimplementedChainServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Chain_ServiceDesc, srv)
+}
+
+func _Chain_GetHeader_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChainServer).GetHeader(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chain_GetHeader_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChainServer).GetHeader(ctx, req.(*BlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chain_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChainServer).GetBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chain_GetBlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChainServer).GetBlock(ctx, req.(*BlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chain_GetBlockHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockHashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChainServer).GetBlockHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chain_GetBlockHash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChainServer).GetBlockHash(ctx, req.(*BlockHashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chain_GetFinalizedHead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinalizedHeadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChainServer).GetFinalizedHead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Chain_GetFinalizedHead_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChainServer).GetFinalizedHead(ctx, req.(*FinalizedHeadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Chain_SubscribeNewHeads_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeHeadsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ChainServer).SubscribeNewHeads(m, &grpc.GenericServerStream[SubscribeHeadsRequest, Header]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Chain_SubscribeNewHeadsServer = grpc.ServerStreamingServer[Header]
+
+func _Chain_SubscribeFinalizedHeads_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeHeadsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ChainServer).SubscribeFinalizedHeads(m, &grpc.GenericServerStream[SubscribeHeadsRequest, Header]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Chain_SubscribeFinalizedHeadsServer = grpc.ServerStreamingServer[Header]
+
+// Chain_ServiceDesc is the grpc.ServiceDesc for Chain service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Chain_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gossamer.v1.Chain",
+	HandlerType: (*ChainServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetHeader",
+			Handler:    _Chain_GetHeader_Handler,
+		},
+		{
+			MethodName: "GetBlock",
+			Handler:    _Chain_GetBlock_Handler,
+		},
+		{
+			MethodName: "GetBlockHash",
+			Handler:    _Chain_GetBlockHash_Handler,
+		},
+		{
+			MethodName: "GetFinalizedHead",
+			Handler:    _Chain_GetFinalizedHead_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeNewHeads",
+			Handler:       _Chain_SubscribeNewHeads_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeFinalizedHeads",
+			Handler:       _Chain_SubscribeFinalizedHeads_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "gossamer.v1.proto",
+}
+
+const (
+	State_GetStorage_FullMethodName       = "/gossamer.v1.State/GetStorage"
+	State_SubscribeStorage_FullMethodName = "/gossamer.v1.State/SubscribeStorage"
+)
+
+// StateClient is the client API for State service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// State service, mirroring the state JSON-RPC methods.
+type StateClient interface {
+	// Returns the value of a storage key.
+	GetStorage(ctx context.Context, in *StorageRequest, opts ...grpc.CallOption) (*StorageValue, error)
+	// Streams the changes of storage keys, starting with their values at the best block.
+	SubscribeStorage(ctx context.Context, in *SubscribeStorageRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StorageChangeSet], error)
+}
+
+type stateClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStateClient(cc grpc.ClientConnInterface) StateClient {
+	return &stateClient{cc}
+}
+
+func (c *stateClient) GetStorage(ctx context.Context, in *StorageRequest, opts ...grpc.CallOption) (*StorageValue, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StorageValue)
+	err := c.cc.Invoke(ctx, State_GetStorage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stateClient) SubscribeStorage(ctx context.Context, in *SubscribeStorageRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StorageChangeSet], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &State_ServiceDesc.Streams[0], State_SubscribeStorage_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeStorageRequest, StorageChangeSet]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type State_SubscribeStorageClient = grpc.ServerStreamingClient[StorageChangeSet]
+
+// StateServer is the server API for State service.
+// All implementations must embed UnimplementedStateServer
+// for forward compatibility.
+//
+// State service, mirroring the state JSON-RPC methods.
+type StateServer interface {
+	// Returns the value of a storage key.
+	GetStorage(context.Context, *StorageRequest) (*StorageValue, error)
+	// Streams the changes of storage keys, starting with their values at the best block.
+	SubscribeStorage(*SubscribeStorageRequest, grpc.ServerStreamingServer[StorageChangeSet]) error
+	mustEmbedUnimplementedStateServer()
+}
+
+// UnimplementedStateServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStateServer struct{}
+
+func (UnimplementedStateServer) GetStorage(context.Context, *StorageRequest) (*StorageValue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStorage not implemented")
+}
+func (UnimplementedStateServer) SubscribeStorage(*SubscribeStorageRequest, grpc.ServerStreamingServer[StorageChangeSet]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeStorage not implemented")
+}
+func (UnimplementedStateServer) mustEmbedUnimplementedStateServer() {}
+func (UnimplementedStateServer) testEmbeddedByValue()               {}
+
+// UnsafeStateServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StateServer will
+// result in compilation errors.
+type UnsafeStateServer interface {
+	mustEmbedUnimplementedStateServer()
+}
+
+func RegisterStateServer(s grpc.ServiceRegistrar, srv StateServer) {
+	// If the following call pancis, it indicates UnimplementedStateServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&State_ServiceDesc, srv)
+}
+
+func _State_GetStorage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StorageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateServer).GetStorage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: State_GetStorage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateServer).GetStorage(ctx, req.(*StorageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _State_SubscribeStorage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeStorageRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StateServer).SubscribeStorage(m, &grpc.GenericServerStream[SubscribeStorageRequest, StorageChangeSet]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type State_SubscribeStorageServer = grpc.ServerStreamingServer[StorageChangeSet]
+
+// State_ServiceDesc is the grpc.ServiceDesc for State service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var State_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gossamer.v1.State",
+	HandlerType: (*StateServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetStorage",
+			Handler:    _State_GetStorage_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeStorage",
+			Handler:       _State_SubscribeStorage_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "gossamer.v1.proto",
+}
+
+const (
+	Author_SubmitExtrinsic_FullMethodName   = "/gossamer.v1.Author/SubmitExtrinsic"
+	Author_PendingExtrinsics_FullMethodName = "/gossamer.v1.Author/PendingExtrinsics"
+)
+
+// AuthorClient is the client API for Author service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Author service, mirroring the author JSON-RPC methods.
+type AuthorClient interface {
+	// Submits an extrinsic to the transaction pool.
+	SubmitExtrinsic(ctx context.Context, in *SubmitExtrinsicRequest, opts ...grpc.CallOption) (*ExtrinsicHash, error)
+	// Returns the extrinsics pending in the transaction pool.
+	PendingExtrinsics(ctx context.Context, in *PendingExtrinsicsRequest, opts ...grpc.CallOption) (*PendingExtrinsicsResponse, error)
+}
+
+type authorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAuthorClient(cc grpc.ClientConnInterface) AuthorClient {
+	return &authorClient{cc}
+}
+
+func (c *authorClient) SubmitExtrinsic(ctx context.Context, in *SubmitExtrinsicRequest, opts ...grpc.CallOption) (*ExtrinsicHash, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExtrinsicHash)
+	err := c.cc.Invoke(ctx, Author_SubmitExtrinsic_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authorClient) PendingExtrinsics(ctx context.Context, in *PendingExtrinsicsRequest, opts ...grpc.CallOption) (*PendingExtrinsicsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PendingExtrinsicsResponse)
+	err := c.cc.Invoke(ctx, Author_PendingExtrinsics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuthorServer is the server API for Author service.
+// All implementations must embed UnimplementedAuthorServer
+// for forward compatibility.
+//
+// Author service, mirroring the author JSON-RPC methods.
+type AuthorServer interface {
+	// Submits an extrinsic to the transaction pool.
+	SubmitExtrinsic(context.Context, *SubmitExtrinsicRequest) (*ExtrinsicHash, error)
+	// Returns the extrinsics pending in the transaction pool.
+	PendingExtrinsics(context.Context, *PendingExtrinsicsRequest) (*PendingExtrinsicsResponse, error)
+	mustEmbedUnimplementedAuthorServer()
+}
+
+// UnimplementedAuthorServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAuthorServer struct{}
+
+func (UnimplementedAuthorServer) SubmitExtrinsic(context.Context, *SubmitExtrinsicRequest) (*ExtrinsicHash, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitExtrinsic not implemented")
+}
+func (UnimplementedAuthorServer) PendingExtrinsics(context.Context, *PendingExtrinsicsRequest) (*PendingExtrinsicsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PendingExtrinsics not implemented")
+}
+func (UnimplementedAuthorServer) mustEmbedUnimplementedAuthorServer() {}
+func (UnimplementedAuthorServer) testEmbeddedByValue()                {}
+
+// UnsafeAuthorServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuthorServer will
+// result in compilation errors.
+type UnsafeAuthorServer interface {
+	mustEmbedUnimplementedAuthorServer()
+}
+
+func RegisterAuthorServer(s grpc.ServiceRegistrar, srv AuthorServer) {
+	// If the following call pancis, it indicates UnimplementedAuthorServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Author_ServiceDesc, srv)
+}
+
+func _Author_SubmitExtrinsic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitExtrinsicRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorServer).SubmitExtrinsic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Author_SubmitExtrinsic_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorServer).SubmitExtrinsic(ctx, req.(*SubmitExtrinsicRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Author_PendingExtrinsics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PendingExtrinsicsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthorServer).PendingExtrinsics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Author_PendingExtrinsics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthorServer).PendingExtrinsics(ctx, req.(*PendingExtrinsicsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Author_ServiceDesc is the grpc.ServiceDesc for Author service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Author_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gossamer.v1.Author",
+	HandlerType: (*AuthorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SubmitExtrinsic",
+			Handler:    _Author_SubmitExtrinsic_Handler,
+		},
+		{
+			MethodName: "PendingExtrinsics",
+			Handler:    _Author_PendingExtrinsics_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "gossamer.v1.proto",
+}
@@ -0,0 +1,122 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/ChainSafe/gossamer/dot/rpc/grpc/proto"
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/blocktree"
+	"github.com/ChainSafe/gossamer/lib/common"
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var logger = log.NewFromGlobal(log.AddContext("pkg", "grpc"))
+
+// Config configures the gRPC server
+type Config struct {
+	LogLvl log.Level
+	// Port is the port the server listens on
+	Port uint32
+	// External is set to accept connections from other hosts than the local host
+	External            bool
+	BlockAPI            BlockAPI
+	StorageAPI          StorageAPI
+	CoreAPI             CoreAPI
+	TransactionStateAPI TransactionStateAPI
+}
+
+// Server serves the chain, state and author gRPC services, mirroring the
+// corresponding JSON-RPC methods.
+type Server struct {
+	config Config
+	server *gogrpc.Server
+}
+
+// NewServer creates a gRPC server with the configuration given.
+func NewServer(config Config) *Server {
+	logger.Patch(log.SetLevel(config.LogLvl))
+
+	server := gogrpc.NewServer()
+	proto.RegisterChainServer(server, &chainService{blockAPI: config.BlockAPI})
+	proto.RegisterStateServer(server, &stateService{
+		blockAPI:   config.BlockAPI,
+		storageAPI: config.StorageAPI,
+	})
+	proto.RegisterAuthorServer(server, &authorService{
+		coreAPI:             config.CoreAPI,
+		transactionStateAPI: config.TransactionStateAPI,
+	})
+
+	return &Server{
+		config: config,
+		server: server,
+	}
+}
+
+// Start starts listening and serving the gRPC services.
+func (s *Server) Start() error {
+	host := "localhost"
+	if s.config.External {
+		host = ""
+	}
+	address := net.JoinHostPort(host, fmt.Sprint(s.config.Port))
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", address, err)
+	}
+
+	logger.Infof("Starting gRPC server on %s...", listener.Addr())
+	go s.serve(listener)
+	return nil
+}
+
+func (s *Server) serve(listener net.Listener) {
+	err := s.server.Serve(listener)
+	if err != nil {
+		logger.Errorf("grpc error: %s", err)
+	}
+}
+
+// Stop stops the server, closing the connections and the streams in progress.
+func (s *Server) Stop() error {
+	s.server.Stop()
+	return nil
+}
+
+// toStatus converts the error given to a gRPC status error.
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, database.ErrNotFound),
+		errors.Is(err, blocktree.ErrNodeNotFound),
+		errors.Is(err, blocktree.ErrNumGreaterThanHighest):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, state.ErrBlockBodyPruned):
+		// the block is known but its body is older than the block bodies kept
+		return status.Error(codes.OutOfRange, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// decodeHash decodes the block hash given, returning the zero hash if it is empty.
+func decodeHash(hash []byte) (common.Hash, error) {
+	switch len(hash) {
+	case 0:
+		return common.Hash{}, nil
+	case common.HashLength:
+		return common.NewHash(hash), nil
+	default:
+		return common.Hash{}, status.Errorf(codes.InvalidArgument,
+			"block hash must be %d bytes long, got %d bytes", common.HashLength, len(hash))
+	}
+}
@@ -0,0 +1,276 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/core"
+	"github.com/ChainSafe/gossamer/dot/rpc/grpc/proto"
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/transaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type mocks struct {
+	block       *MockBlockAPI
+	storage     *MockStorageAPI
+	core        *MockCoreAPI
+	transaction *MockTransactionStateAPI
+}
+
+// newTestClient serves the gRPC services backed by mocks in memory, and returns a
+// connection to the server.
+func newTestClient(t *testing.T) (*gogrpc.ClientConn, mocks) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	m := mocks{
+		block:       NewMockBlockAPI(ctrl),
+		storage:     NewMockStorageAPI(ctrl),
+		core:        NewMockCoreAPI(ctrl),
+		transaction: NewMockTransactionStateAPI(ctrl),
+	}
+
+	server := NewServer(Config{
+		LogLvl:              log.Critical,
+		BlockAPI:            m.block,
+		StorageAPI:          m.storage,
+		CoreAPI:             m.core,
+		TransactionStateAPI: m.transaction,
+	})
+
+	listener := bufconn.Listen(1 << 20)
+	go server.serve(listener)
+	t.Cleanup(func() {
+		err := server.Stop()
+		assert.NoError(t, err)
+	})
+
+	dialer := func(ctx context.Context, _ string) (net.Conn, error) {
+		return listener.DialContext(ctx)
+	}
+	conn, err := gogrpc.NewClient("passthrough:///bufconn",
+		gogrpc.WithContextDialer(dialer),
+		gogrpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err := conn.Close()
+		assert.NoError(t, err)
+	})
+
+	return conn, m
+}
+
+func testHeader(number uint) *types.Header {
+	return types.NewHeader(common.Hash{byte(number)}, common.Hash{2}, common.Hash{3}, number, types.NewDigest())
+}
+
+func Test_chainService_GetHeader(t *testing.T) {
+	t.Parallel()
+
+	conn, m := newTestClient(t)
+	client := proto.NewChainClient(conn)
+	ctx := context.Background()
+
+	header := testHeader(5)
+	m.block.EXPECT().BestBlockHash().Return(common.Hash{9})
+	m.block.EXPECT().GetHeader(common.Hash{9}).Return(header, nil)
+
+	response, err := client.GetHeader(ctx, &proto.BlockRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, header.Hash().ToBytes(), response.Hash)
+	assert.Equal(t, header.ParentHash.ToBytes(), response.ParentHash)
+	assert.Equal(t, uint64(5), response.Number)
+	assert.Equal(t, header.StateRoot.ToBytes(), response.StateRoot)
+	assert.Equal(t, header.ExtrinsicsRoot.ToBytes(), response.ExtrinsicsRoot)
+	assert.Equal(t, []byte{0}, response.Digest)
+
+	m.block.EXPECT().GetHeader(common.Hash{1}).Return(nil, database.ErrNotFound)
+	_, err = client.GetHeader(ctx, &proto.BlockRequest{Hash: common.Hash{1}.ToBytes()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.GetHeader(ctx, &proto.BlockRequest{Hash: []byte{1}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func Test_chainService_GetBlock(t *testing.T) {
+	t.Parallel()
+
+	conn, m := newTestClient(t)
+	client := proto.NewChainClient(conn)
+
+	block := &types.Block{
+		Header: *testHeader(5),
+		Body:   types.Body{{1, 2}, {3}},
+	}
+	m.block.EXPECT().GetBlockByHash(common.Hash{1}).Return(block, nil)
+
+	response, err := client.GetBlock(context.Background(), &proto.BlockRequest{Hash: common.Hash{1}.ToBytes()})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), response.Header.Number)
+	assert.Equal(t, [][]byte{{1, 2}, {3}}, response.Extrinsics)
+
+	m.block.EXPECT().GetBlockByHash(common.Hash{2}).Return(nil, state.ErrBlockBodyPruned)
+	_, err = client.GetBlock(context.Background(), &proto.BlockRequest{Hash: common.Hash{2}.ToBytes()})
+	assert.Equal(t, codes.OutOfRange, status.Code(err))
+}
+
+func Test_chainService_GetBlockHash(t *testing.T) {
+	t.Parallel()
+
+	conn, m := newTestClient(t)
+	client := proto.NewChainClient(conn)
+	ctx := context.Background()
+
+	m.block.EXPECT().BestBlockHash().Return(common.Hash{9})
+	response, err := client.GetBlockHash(ctx, &proto.BlockHashRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, common.Hash{9}.ToBytes(), response.Hash)
+
+	number := uint64(3)
+	m.block.EXPECT().GetHashByNumber(uint(3)).Return(common.Hash{3}, nil)
+	response, err = client.GetBlockHash(ctx, &proto.BlockHashRequest{Number: &number})
+	require.NoError(t, err)
+	assert.Equal(t, common.Hash{3}.ToBytes(), response.Hash)
+
+	m.block.EXPECT().GetHashByNumber(uint(3)).Return(common.Hash{}, errors.New("test error"))
+	_, err = client.GetBlockHash(ctx, &proto.BlockHashRequest{Number: &number})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.ErrorContains(t, err, "getting hash of block 3: test error")
+}
+
+func Test_chainService_SubscribeFinalizedHeads(t *testing.T) {
+	t.Parallel()
+
+	conn, m := newTestClient(t)
+	client := proto.NewChainClient(conn)
+
+	finalised := make(chan *types.FinalisationInfo, 1)
+	m.block.EXPECT().GetFinalisedNotifierChannel().Return(finalised)
+	freed := make(chan struct{})
+	m.block.EXPECT().FreeFinalisedNotifierChannel(finalised).Do(func(chan *types.FinalisationInfo) {
+		close(freed)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := client.SubscribeFinalizedHeads(ctx, &proto.SubscribeHeadsRequest{})
+	require.NoError(t, err)
+
+	finalised <- &types.FinalisationInfo{Header: *testHeader(7)}
+	header, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), header.Number)
+
+	cancel()
+	<-freed
+}
+
+func Test_stateService_GetStorage(t *testing.T) {
+	t.Parallel()
+
+	conn, m := newTestClient(t)
+	client := proto.NewStateClient(conn)
+	ctx := context.Background()
+
+	m.storage.EXPECT().GetStorageByBlockHash(&common.Hash{1}, []byte{1}).Return([]byte{2}, nil)
+	response, err := client.GetStorage(ctx, &proto.StorageRequest{Key: []byte{1}, BlockHash: common.Hash{1}.ToBytes()})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{2}, response.Value)
+
+	m.block.EXPECT().BestBlockHash().Return(common.Hash{9})
+	m.storage.EXPECT().GetStorageByBlockHash(&common.Hash{9}, []byte{1}).Return(nil, nil)
+	response, err = client.GetStorage(ctx, &proto.StorageRequest{Key: []byte{1}})
+	require.NoError(t, err)
+	assert.Nil(t, response.Value)
+}
+
+func Test_stateService_SubscribeStorage(t *testing.T) {
+	t.Parallel()
+
+	conn, m := newTestClient(t)
+	client := proto.NewStateClient(conn)
+
+	registered := make(chan state.Observer, 1)
+	m.storage.EXPECT().RegisterStorageObserver(gomock.Any()).Do(func(observer state.Observer) {
+		registered <- observer
+	})
+	unregistered := make(chan struct{})
+	m.storage.EXPECT().UnregisterStorageObserver(gomock.Any()).Do(func(state.Observer) {
+		close(unregistered)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := client.SubscribeStorage(ctx, &proto.SubscribeStorageRequest{Keys: [][]byte{{1}, {2}}})
+	require.NoError(t, err)
+
+	observer := <-registered
+	assert.Equal(t, map[string][]byte{"0x01": nil, "0x02": nil}, observer.GetFilter())
+	assert.Greater(t, observer.GetID(), uint(observerIDOffset))
+
+	go observer.Update(&state.SubscriptionResult{
+		Hash:    common.Hash{5},
+		Changes: []state.KeyValue{{Key: []byte{1}, Value: []byte{3}}, {Key: []byte{2}}},
+	})
+
+	changeSet, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, common.Hash{5}.ToBytes(), changeSet.StateRoot)
+	require.Len(t, changeSet.Changes, 2)
+	assert.Equal(t, []byte{1}, changeSet.Changes[0].Key)
+	assert.Equal(t, []byte{3}, changeSet.Changes[0].Value)
+	assert.Equal(t, []byte{2}, changeSet.Changes[1].Key)
+	assert.Nil(t, changeSet.Changes[1].Value)
+
+	cancel()
+	<-unregistered
+}
+
+func Test_authorService_SubmitExtrinsic(t *testing.T) {
+	t.Parallel()
+
+	conn, m := newTestClient(t)
+	client := proto.NewAuthorClient(conn)
+	ctx := context.Background()
+
+	ext := types.Extrinsic{1, 2, 3}
+	m.core.EXPECT().HandleSubmittedExtrinsic(ext).Return(nil)
+	response, err := client.SubmitExtrinsic(ctx, &proto.SubmitExtrinsicRequest{Extrinsic: ext})
+	require.NoError(t, err)
+	assert.Equal(t, ext.Hash().ToBytes(), response.Hash)
+
+	m.core.EXPECT().HandleSubmittedExtrinsic(ext).Return(core.ErrTransactionBanned)
+	_, err = client.SubmitExtrinsic(ctx, &proto.SubmitExtrinsicRequest{Extrinsic: ext})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func Test_authorService_PendingExtrinsics(t *testing.T) {
+	t.Parallel()
+
+	conn, m := newTestClient(t)
+	client := proto.NewAuthorClient(conn)
+
+	m.transaction.EXPECT().Pending().Return([]*transaction.ValidTransaction{
+		{Extrinsic: types.Extrinsic{1}},
+		{Extrinsic: types.Extrinsic{2}},
+	})
+
+	response, err := client.PendingExtrinsics(context.Background(), &proto.PendingExtrinsicsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{{1}, {2}}, response.Extrinsics)
+}
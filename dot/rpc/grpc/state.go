@@ -0,0 +1,123 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/ChainSafe/gossamer/dot/rpc/grpc/proto"
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/lib/common"
+	gogrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// observerIDOffset is added to the identifiers of the storage observers of the
+// gRPC streams, so they do not collide with the identifiers of the storage
+// observers of the websocket subscriptions, counted from 1.
+const observerIDOffset = 1 << 31
+
+// stateService implements the state gRPC service
+type stateService struct {
+	proto.UnimplementedStateServer
+	blockAPI   BlockAPI
+	storageAPI StorageAPI
+	observers  atomic.Uint64
+}
+
+// GetStorage returns the value of the storage key given at the block given, or at the best block.
+func (ss *stateService) GetStorage(_ context.Context, request *proto.StorageRequest) (*proto.StorageValue, error) {
+	hash, err := decodeHash(request.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+	if hash.IsEmpty() {
+		hash = ss.blockAPI.BestBlockHash()
+	}
+
+	value, err := ss.storageAPI.GetStorageByBlockHash(&hash, request.Key)
+	if err != nil {
+		return nil, toStatus(fmt.Errorf("getting storage: %w", err))
+	}
+
+	return &proto.StorageValue{Value: value}, nil
+}
+
+// SubscribeStorage streams the changes of the storage keys given, starting with their
+// values at the best block, until the client cancels the stream or the server stops.
+func (ss *stateService) SubscribeStorage(request *proto.SubscribeStorageRequest,
+	stream gogrpc.ServerStreamingServer[proto.StorageChangeSet]) error {
+	if len(request.Keys) == 0 {
+		return status.Error(codes.InvalidArgument, "no storage key to watch")
+	}
+
+	observer := &storageObserver{
+		id:      observerIDOffset + uint(ss.observers.Add(1)),
+		filter:  make(map[string][]byte, len(request.Keys)),
+		results: make(chan *state.SubscriptionResult),
+		done:    stream.Context().Done(),
+	}
+	for _, key := range request.Keys {
+		observer.filter[common.BytesToHex(key)] = nil
+	}
+
+	ss.storageAPI.RegisterStorageObserver(observer)
+	defer ss.storageAPI.UnregisterStorageObserver(observer)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case result := <-observer.results:
+			changeSet := &proto.StorageChangeSet{
+				StateRoot: result.Hash.ToBytes(),
+				Changes:   make([]*proto.StorageChange, len(result.Changes)),
+			}
+			for i, change := range result.Changes {
+				changeSet.Changes[i] = &proto.StorageChange{
+					Key:   change.Key,
+					Value: change.Value,
+				}
+			}
+
+			err := stream.Send(changeSet)
+			if err != nil {
+				return fmt.Errorf("sending storage changes: %w", err)
+			}
+		}
+	}
+}
+
+// storageObserver forwards the storage changes of the keys of its filter to a gRPC stream
+type storageObserver struct {
+	id      uint
+	filter  map[string][]byte
+	results chan *state.SubscriptionResult
+	done    <-chan struct{}
+}
+
+// Update forwards the storage changes given, unless the stream is done.
+func (so *storageObserver) Update(result *state.SubscriptionResult) {
+	if result == nil {
+		return
+	}
+
+	select {
+	case so.results <- result:
+	case <-so.done:
+	}
+}
+
+// GetID returns the identifier of the observer
+func (so *storageObserver) GetID() uint {
+	return so.id
+}
+
+// GetFilter returns the values of the storage keys watched, last notified
+func (so *storageObserver) GetFilter() map[string][]byte {
+	return so.filter
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// HealthResponse is the JSON body of the health endpoints
+type HealthResponse struct {
+	IsSyncing       bool `json:"isSyncing"`
+	Peers           int  `json:"peers"`
+	ShouldHavePeers bool `json:"shouldHavePeers"`
+	BestBlock       uint `json:"bestBlock"`
+	FinalisedBlock  uint `json:"finalisedBlock"`
+	// FinalityLag is the number of blocks between the best block and the highest finalised block
+	FinalityLag uint `json:"finalityLag"`
+	// Ready is true if the node is synced and connected to peers, if it should have any
+	Ready bool `json:"ready"`
+}
+
+// health returns the health of the node, from its network and block states.
+func (h *HTTPServer) health() (response HealthResponse, err error) {
+	networkHealth := h.serverConfig.NetworkAPI.Health()
+	response = HealthResponse{
+		IsSyncing:       networkHealth.IsSyncing,
+		Peers:           networkHealth.Peers,
+		ShouldHavePeers: networkHealth.ShouldHavePeers,
+	}
+	response.Ready = !response.IsSyncing && (response.Peers > 0 || !response.ShouldHavePeers)
+
+	blockAPI := h.serverConfig.BlockAPI
+	bestHeader, err := blockAPI.GetHeader(blockAPI.BestBlockHash())
+	if err != nil {
+		return response, fmt.Errorf("getting best block header: %w", err)
+	}
+	response.BestBlock = bestHeader.Number
+
+	finalisedHash, err := blockAPI.GetHighestFinalisedHash()
+	if err != nil {
+		return response, fmt.Errorf("getting highest finalised hash: %w", err)
+	}
+
+	finalisedHeader, err := blockAPI.GetHeader(finalisedHash)
+	if err != nil {
+		return response, fmt.Errorf("getting highest finalised header: %w", err)
+	}
+	response.FinalisedBlock = finalisedHeader.Number
+
+	if response.BestBlock > response.FinalisedBlock {
+		response.FinalityLag = response.BestBlock - response.FinalisedBlock
+	}
+
+	return response, nil
+}
+
+// serveHealth responds with the health of the node and a 200 status code,
+// as long as the node is running, to be used as a liveness probe.
+func (h *HTTPServer) serveHealth(w http.ResponseWriter, _ *http.Request) {
+	response, err := h.health()
+	if err != nil {
+		h.logger.Errorf("cannot get node health: %s", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.writeHealth(w, http.StatusOK, response)
+}
+
+// serveReadiness responds with the health of the node and a 200 status code if
+// the node is ready to serve requests, or a 503 status code otherwise,
+// to be used as a readiness probe.
+func (h *HTTPServer) serveReadiness(w http.ResponseWriter, _ *http.Request) {
+	response, err := h.health()
+	if err != nil {
+		h.logger.Errorf("cannot get node health: %s", err)
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	status := http.StatusOK
+	if !response.Ready {
+		status = http.StatusServiceUnavailable
+	}
+	h.writeHealth(w, status, response)
+}
+
+func (h *HTTPServer) writeHealth(w http.ResponseWriter, status int, response HealthResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	err := json.NewEncoder(w).Encode(response)
+	if err != nil {
+		h.logger.Debugf("cannot write health response: %s", err)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package rpc
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/rpc/modules/mocks"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_HTTPServer_serveReadiness(t *testing.T) {
+	t.Parallel()
+
+	bestHash := common.Hash{1}
+	finalisedHash := common.Hash{2}
+
+	testCases := map[string]struct {
+		health           common.Health
+		expectedStatus   int
+		expectedResponse HealthResponse
+	}{
+		"ready": {
+			health:         common.Health{Peers: 3, ShouldHavePeers: true},
+			expectedStatus: http.StatusOK,
+			expectedResponse: HealthResponse{
+				Peers:           3,
+				ShouldHavePeers: true,
+				BestBlock:       10,
+				FinalisedBlock:  8,
+				FinalityLag:     2,
+				Ready:           true,
+			},
+		},
+		"syncing": {
+			health:         common.Health{Peers: 3, IsSyncing: true, ShouldHavePeers: true},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedResponse: HealthResponse{
+				IsSyncing:       true,
+				Peers:           3,
+				ShouldHavePeers: true,
+				BestBlock:       10,
+				FinalisedBlock:  8,
+				FinalityLag:     2,
+			},
+		},
+		"no_peers": {
+			health:         common.Health{ShouldHavePeers: true},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedResponse: HealthResponse{
+				ShouldHavePeers: true,
+				BestBlock:       10,
+				FinalisedBlock:  8,
+				FinalityLag:     2,
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			networkAPI := mocks.NewMockNetworkAPI(ctrl)
+			networkAPI.EXPECT().Health().Return(testCase.health)
+
+			blockAPI := mocks.NewMockBlockAPI(ctrl)
+			blockAPI.EXPECT().BestBlockHash().Return(bestHash)
+			blockAPI.EXPECT().GetHeader(bestHash).Return(&types.Header{Number: 10}, nil)
+			blockAPI.EXPECT().GetHighestFinalisedHash().Return(finalisedHash, nil)
+			blockAPI.EXPECT().GetHeader(finalisedHash).Return(&types.Header{Number: 8}, nil)
+
+			server := &HTTPServer{
+				logger: log.New(log.SetWriter(io.Discard)),
+				serverConfig: &HTTPServerConfig{
+					NetworkAPI: networkAPI,
+					BlockAPI:   blockAPI,
+				},
+			}
+
+			recorder := httptest.NewRecorder()
+			server.serveReadiness(recorder, httptest.NewRequest(http.MethodGet, "/health/readiness", nil))
+
+			assert.Equal(t, testCase.expectedStatus, recorder.Code)
+			assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+			var response HealthResponse
+			err := json.Unmarshal(recorder.Body.Bytes(), &response)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedResponse, response)
+		})
+	}
+}
@@ -7,6 +7,7 @@
 	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"strings"
 
 	"github.com/ChainSafe/gossamer/dot/rpc/modules"
@@ -25,6 +26,10 @@ func LocalhostFilter() *ipfilter.IPFilter {
 
 // LocalRequestOnly HTTP handler to restrict to only local connections
 func LocalRequestOnly(r *rpc.RequestInfo, i interface{}) error {
+	if isUnixSocketRequest(r.Request) {
+		return nil
+	}
+
 	ip, _, err := net.SplitHostPort(r.Request.RemoteAddr)
 
 	if err != nil {
@@ -37,6 +42,13 @@ func LocalRequestOnly(r *rpc.RequestInfo, i interface{}) error {
 	return errors.New("external HTTP request refused")
 }
 
+// isUnixSocketRequest returns true if the request given was received on a unix domain
+// socket listener, whose connections are local.
+func isUnixSocketRequest(r *http.Request) bool {
+	localAddr, ok := r.Context().Value(http.LocalAddrContextKey).(net.Addr)
+	return ok && localAddr.Network() == "unix"
+}
+
 func snakeCaseFormat(method string) (string, error) {
 	parts := strings.Split(method, ".")
 	if len(parts) < 2 {
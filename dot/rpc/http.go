@@ -4,9 +4,11 @@
 package rpc
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
+	"os"
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/rpc/modules"
@@ -18,6 +20,8 @@
 	"github.com/gorilla/mux"
 	"github.com/gorilla/rpc/v2"
 	"github.com/gorilla/websocket"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // HTTPServer gateway for RPC server
@@ -26,6 +30,10 @@ type HTTPServer struct {
 	rpcServer    *rpc.Server // Actual RPC call handler
 	serverConfig *HTTPServerConfig
 	wsConns      []*subscription.WSConn
+	// resumptions holds the resumable subscriptions, nil if the resumption is disabled
+	resumptions *subscription.ResumptionStore
+	// tracer traces the RPC calls
+	tracer trace.Tracer
 }
 
 // HTTPServerConfig configures the HTTPServer
@@ -37,11 +45,15 @@ type HTTPServerConfig struct {
 	CoreAPI             CoreAPI
 	BlockProducerAPI    BlockProducerAPI
 	BlockFinalityAPI    BlockFinalityAPI
+	BlockFinaliserAPI   BlockFinaliserAPI
 	TransactionQueueAPI TransactionStateAPI
 	RPCAPI              API
 	SystemAPI           SystemAPI
 	SyncStateAPI        SyncStateAPI
 	SyncAPI             SyncAPI
+	DatabaseAPI         DatabaseAPI
+	RoleAPI             RoleAPI
+	EpochAPI            EpochAPI
 	NodeStorage         *runtime.NodeStorage
 	RPCUnsafe           bool
 	RPCExternal         bool
@@ -52,6 +64,23 @@ type HTTPServerConfig struct {
 	WSUnsafeExternal    bool
 	WSPort              uint32
 	Modules             []string
+	NoHealthEndpoint    bool
+	// CorsOrigins are the origins of the browser requests allowed, "*" allowing any
+	// origin. The CORS headers are not set if empty.
+	CorsOrigins []string
+	// TLSCertFile and TLSKeyFile are the certificate and key files of the TLS termination
+	// of the HTTP and websocket servers, served without TLS if empty.
+	TLSCertFile string
+	TLSKeyFile  string
+	// UnixSocket and WSUnixSocket are the paths of the unix domain sockets the HTTP and
+	// websocket servers also listen on, if not empty. Their connections are local.
+	UnixSocket   string
+	WSUnixSocket string
+	// WSResumeWindow is the duration the finalised heads and storage subscriptions of a closed
+	// websocket connection can be resumed during, buffering at most WSResumeBufferSize
+	// notifications for each. The subscriptions are not resumable if zero.
+	WSResumeWindow     time.Duration
+	WSResumeBufferSize uint32
 }
 
 func (h *HTTPServerConfig) rpcUnsafeEnabled() bool {
@@ -66,12 +95,22 @@ func (h *HTTPServerConfig) exposeWS() bool {
 	return h.WSExternal || h.WSUnsafeExternal
 }
 
+func (h *HTTPServerConfig) wsEnabled() bool {
+	return h.exposeWS() || h.WSUnixSocket != ""
+}
+
 func (h *HTTPServerConfig) exposeRPC() bool {
 	return h.RPCExternal || h.RPCUnsafeExternal
 }
 
+func (h *HTTPServerConfig) tlsEnabled() bool {
+	return h.TLSCertFile != "" && h.TLSKeyFile != ""
+}
+
 var logger *log.Logger
 
+var errNotUnixSocket = errors.New("file is not a unix socket")
+
 // NewHTTPServer creates a new http server and registers an associated rpc server
 func NewHTTPServer(cfg *HTTPServerConfig) *HTTPServer {
 	logger = log.NewFromGlobal(log.AddContext("pkg", "rpc"))
@@ -81,6 +120,10 @@ func NewHTTPServer(cfg *HTTPServerConfig) *HTTPServer {
 		logger:       logger,
 		rpcServer:    rpc.NewServer(),
 		serverConfig: cfg,
+		tracer:       otel.Tracer(tracerName),
+	}
+	if cfg.WSResumeWindow > 0 {
+		server.resumptions = subscription.NewResumptionStore(cfg.WSResumeWindow, int(cfg.WSResumeBufferSize))
 	}
 
 	server.RegisterModules(cfg.Modules)
@@ -109,7 +152,12 @@ func (h *HTTPServer) RegisterModules(mods []string) {
 		case "rpc":
 			srvc = modules.NewRPCModule(h.serverConfig.RPCAPI)
 		case "dev":
-			srvc = modules.NewDevModule(h.serverConfig.BlockProducerAPI, h.serverConfig.NetworkAPI)
+			srvc = modules.NewDevModule(h.serverConfig.BlockProducerAPI, h.serverConfig.NetworkAPI,
+				h.serverConfig.CoreAPI, h.serverConfig.StorageAPI, h.serverConfig.DatabaseAPI,
+				h.serverConfig.RoleAPI, h.serverConfig.BlockAPI, h.serverConfig.EpochAPI)
+		case "engine":
+			srvc = modules.NewEngineModule(h.serverConfig.BlockAPI, h.serverConfig.BlockProducerAPI,
+				h.serverConfig.BlockFinaliserAPI, h.serverConfig.TransactionQueueAPI)
 		case "offchain":
 			srvc = modules.NewOffchainModule(h.serverConfig.NodeStorage)
 		case "childstate":
@@ -143,27 +191,30 @@ func (h *HTTPServer) Start() error {
 	h.logger.Infof("Starting HTTP Server on host %s and port %d...", h.serverConfig.Host, h.serverConfig.RPCPort)
 	r := mux.NewRouter()
 	r.Handle("/", h.rpcServer)
+	if !h.serverConfig.NoHealthEndpoint {
+		r.HandleFunc("/health", h.serveHealth).Methods(http.MethodGet)
+		r.HandleFunc("/health/readiness", h.serveReadiness).Methods(http.MethodGet)
+	}
 
 	validate := validator.New()
 	// Add custom validator for `common.Hash`
 	validate.RegisterCustomTypeFunc(common.HashValidator, common.Hash{})
 
 	h.rpcServer.RegisterValidateRequestFunc(rpcValidator(h.serverConfig, validate))
+	h.rpcServer.RegisterInterceptFunc(startCallSpan(h.tracer))
+	h.rpcServer.RegisterAfterFunc(endCallSpan)
 
-	go func() {
-		server := &http.Server{
-			Addr:              fmt.Sprintf(":%d", h.serverConfig.RPCPort),
-			ReadHeaderTimeout: 5 * time.Second,
-			Handler:           r,
-		}
+	var handler http.Handler = r
+	if len(h.serverConfig.CorsOrigins) > 0 {
+		handler = newCorsHandler(r, h.serverConfig.CorsOrigins)
+	}
 
-		err := server.ListenAndServe()
-		if err != nil {
-			h.logger.Errorf("http error: %s", err)
-		}
-	}()
+	err := h.serve(h.serverConfig.RPCPort, h.serverConfig.UnixSocket, handler)
+	if err != nil {
+		return fmt.Errorf("starting HTTP server: %w", err)
+	}
 
-	if !h.serverConfig.exposeWS() {
+	if !h.serverConfig.wsEnabled() {
 		return nil
 	}
 
@@ -171,14 +222,51 @@ func (h *HTTPServer) Start() error {
 		h.serverConfig.Host, h.serverConfig.WSPort)
 	ws := mux.NewRouter()
 	ws.Handle("/", h)
-	go func() {
-		wsServer := &http.Server{
-			Addr:              fmt.Sprintf(":%d", h.serverConfig.WSPort),
-			ReadHeaderTimeout: 5 * time.Second,
-			Handler:           ws,
+	err = h.serve(h.serverConfig.WSPort, h.serverConfig.WSUnixSocket, ws)
+	if err != nil {
+		return fmt.Errorf("starting WebSocket server: %w", err)
+	}
+
+	return nil
+}
+
+// serve serves the handler given on the port given, with TLS if configured, and on the
+// unix domain socket of the path given if not empty. A stale socket file left at the path
+// is removed, so the socket can be listened on again after the node stopped.
+func (h *HTTPServer) serve(port uint32, socketPath string, handler http.Handler) error {
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		ReadHeaderTimeout: 5 * time.Second,
+		Handler:           handler,
+	}
+
+	if socketPath != "" {
+		err := removeStaleSocket(socketPath)
+		if err != nil {
+			return err
+		}
+
+		listener, err := net.Listen("unix", socketPath)
+		if err != nil {
+			return fmt.Errorf("listening on unix socket: %w", err)
 		}
 
-		err := wsServer.ListenAndServe()
+		h.logger.Infof("Listening on unix socket %s...", socketPath)
+		go func() {
+			err := server.Serve(listener)
+			if err != nil {
+				h.logger.Errorf("http error on unix socket %s: %s", socketPath, err)
+			}
+		}()
+	}
+
+	go func() {
+		var err error
+		if h.serverConfig.tlsEnabled() {
+			err = server.ListenAndServeTLS(h.serverConfig.TLSCertFile, h.serverConfig.TLSKeyFile)
+		} else {
+			err = server.ListenAndServe()
+		}
 		if err != nil {
 			h.logger.Errorf("http error: %s", err)
 		}
@@ -187,9 +275,30 @@ func (h *HTTPServer) Start() error {
 	return nil
 }
 
+// removeStaleSocket removes the unix domain socket file at the path given, if any. It
+// returns an error if another kind of file exists at the path.
+func removeStaleSocket(socketPath string) error {
+	info, err := os.Lstat(socketPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("checking unix socket file: %w", err)
+	}
+
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%w: %s", errNotUnixSocket, socketPath)
+	}
+
+	err = os.Remove(socketPath)
+	if err != nil {
+		return fmt.Errorf("removing stale unix socket file: %w", err)
+	}
+	return nil
+}
+
 // Stop stops the server
 func (h *HTTPServer) Stop() error {
-	if h.serverConfig.exposeWS() {
+	if h.serverConfig.wsEnabled() {
 		// close all channels and websocket connections
 		for _, conn := range h.wsConns {
 			for _, sub := range conn.Subscriptions {
@@ -214,7 +323,13 @@ func (h *HTTPServer) Stop() error {
 func (h *HTTPServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	var upg = websocket.Upgrader{
 		CheckOrigin: func(r *http.Request) bool {
-			if !h.serverConfig.exposeWS() {
+			if len(h.serverConfig.CorsOrigins) > 0 &&
+				!newOriginFilter(h.serverConfig.CorsOrigins).allowed(r.Header.Get("Origin")) {
+				logger.Debug("websocket request from a not allowed origin refused")
+				return false
+			}
+
+			if !h.serverConfig.exposeWS() && !isUnixSocketRequest(r) {
 				ip, _, err := net.SplitHostPort(r.RemoteAddr)
 				if err != nil {
 					logger.Errorf("unable to parse remote address %s: %s", ip, err)
@@ -241,6 +356,7 @@ func (h *HTTPServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 	// create wsConn
 	wsc := NewWSConn(ws, h.serverConfig)
+	wsc.Resumptions = h.resumptions
 	h.wsConns = append(h.wsConns, wsc)
 
 	go wsc.HandleConn()
@@ -5,11 +5,14 @@
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -58,7 +61,7 @@ func TestRegisterModules(t *testing.T) {
 
 func TestUnsafeRPCProtection(t *testing.T) {
 	cfg := &HTTPServerConfig{
-		Modules:           []string{"system", "author", "chain", "state", "rpc", "grandpa", "dev", "syncstate"},
+		Modules:           []string{"system", "author", "chain", "state", "rpc", "grandpa", "dev", "syncstate", "engine"},
 		RPCPort:           7878,
 		RPCAPI:            NewService(),
 		RPCUnsafeExternal: false,
@@ -238,6 +241,65 @@ func TestRPCExternalEnable_UnsafeExternalNotEnabled(t *testing.T) {
 	require.Equal(t, expected, string(resBody))
 }
 
+func TestUnsafeRPCOnUnixSocket(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	const peerAddress = "/ip4/198.51.100.19/tcp/30333/p2p/QmSk5HQbn6LhUwDiNMseVUjuRYhEtYj4aUZ6WfWoGURpdV"
+	data := []byte(fmt.Sprintf(
+		`{"jsonrpc":"2.0","method":"system_addReservedPeer","params":["%s"],"id":1}`, peerAddress))
+
+	netmock := mocks.NewMockNetworkAPI(ctrl)
+	netmock.EXPECT().AddReservedPeers(peerAddress).Return(nil)
+
+	socketPath := filepath.Join(t.TempDir(), "rpc.sock")
+	// a stale socket file left by a previous node is removed
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+	listener.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, listener.Close())
+
+	cfg := &HTTPServerConfig{
+		Modules:           []string{"system"},
+		RPCPort:           7881,
+		RPCAPI:            NewService(),
+		RPCUnsafe:         true,
+		RPCUnsafeExternal: false,
+		NetworkAPI:        netmock,
+		UnixSocket:        socketPath,
+	}
+
+	s := NewHTTPServer(cfg)
+	err = s.Start()
+	require.NoError(t, err)
+	defer s.Stop()
+
+	// the connections of the unix socket are local, so unsafe methods can be called
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return new(net.Dialer).DialContext(ctx, "unix", socketPath)
+		},
+	}}
+	res, err := client.Post("http://unix/", "application/json", bytes.NewReader(data))
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.Equal(t, `{"jsonrpc":"2.0","result":null,"id":1}`+"\n", string(resBody))
+}
+
+func Test_removeStaleSocket(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, removeStaleSocket(path))
+
+	require.NoError(t, os.WriteFile(path, []byte{1}, 0o600))
+	err := removeStaleSocket(path)
+	require.ErrorIs(t, err, errNotUnixSocket)
+	require.FileExists(t, path)
+}
+
 func PostRequest(t *testing.T, url string, data io.Reader) (int, []byte) {
 	t.Helper()
 
@@ -104,6 +104,18 @@ func (mr *MockTransactionStateAPIMockRecorder) AddToPool(arg0 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToPool", reflect.TypeOf((*MockTransactionStateAPI)(nil).AddToPool), arg0)
 }
 
+// BanExtrinsic mocks base method.
+func (m *MockTransactionStateAPI) BanExtrinsic(arg0 types.Extrinsic) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "BanExtrinsic", arg0)
+}
+
+// BanExtrinsic indicates an expected call of BanExtrinsic.
+func (mr *MockTransactionStateAPIMockRecorder) BanExtrinsic(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BanExtrinsic", reflect.TypeOf((*MockTransactionStateAPI)(nil).BanExtrinsic), arg0)
+}
+
 // FreeStatusNotifierChannel mocks base method.
 func (m *MockTransactionStateAPI) FreeStatusNotifierChannel(arg0 chan transaction.Status) {
 	m.ctrl.T.Helper()
@@ -143,3 +155,43 @@ func (mr *MockTransactionStateAPIMockRecorder) Pending() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pending", reflect.TypeOf((*MockTransactionStateAPI)(nil).Pending))
 }
+
+// PendingInPool mocks base method.
+func (m *MockTransactionStateAPI) PendingInPool() []*transaction.ValidTransaction {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingInPool")
+	ret0, _ := ret[0].([]*transaction.ValidTransaction)
+	return ret0
+}
+
+// PendingInPool indicates an expected call of PendingInPool.
+func (mr *MockTransactionStateAPIMockRecorder) PendingInPool() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingInPool", reflect.TypeOf((*MockTransactionStateAPI)(nil).PendingInPool))
+}
+
+// PendingInQueue mocks base method.
+func (m *MockTransactionStateAPI) PendingInQueue() []*transaction.ValidTransaction {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingInQueue")
+	ret0, _ := ret[0].([]*transaction.ValidTransaction)
+	return ret0
+}
+
+// PendingInQueue indicates an expected call of PendingInQueue.
+func (mr *MockTransactionStateAPIMockRecorder) PendingInQueue() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingInQueue", reflect.TypeOf((*MockTransactionStateAPI)(nil).PendingInQueue))
+}
+
+// RemoveExtrinsic mocks base method.
+func (m *MockTransactionStateAPI) RemoveExtrinsic(arg0 types.Extrinsic) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemoveExtrinsic", arg0)
+}
+
+// RemoveExtrinsic indicates an expected call of RemoveExtrinsic.
+func (mr *MockTransactionStateAPIMockRecorder) RemoveExtrinsic(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveExtrinsic", reflect.TypeOf((*MockTransactionStateAPI)(nil).RemoveExtrinsic), arg0)
+}
@@ -4,6 +4,8 @@
 package modules
 
 import (
+	"time"
+
 	"github.com/ChainSafe/gossamer/dot/core"
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
@@ -22,9 +24,11 @@ type StorageAPI interface {
 	GetStorageChild(root *common.Hash, keyToChild []byte) (trie.Trie, error)
 	GetStorageFromChild(root *common.Hash, keyToChild, key []byte) ([]byte, error)
 	GetStorageByBlockHash(bhash *common.Hash, key []byte) ([]byte, error)
+	GetChangedBlocks(key []byte, start, end uint) (hashes []common.Hash, indexed bool, err error)
 	Entries(root *common.Hash) (map[string][]byte, error)
 	GetStateRootFromBlock(bhash *common.Hash) (*common.Hash, error)
 	GetKeysWithPrefix(root *common.Hash, prefix []byte) ([][]byte, error)
+	GetKeysPaged(root *common.Hash, prefix, startKey []byte, limit uint32) ([][]byte, error)
 	RegisterStorageObserver(observer state.Observer)
 	UnregisterStorageObserver(observer state.Observer)
 }
@@ -53,6 +57,7 @@ type BlockAPI interface {
 type NetworkAPI interface {
 	Health() common.Health
 	NetworkState() common.NetworkState
+	NetworkTraffic() common.NetworkTraffic
 	Peers() []common.PeerInfo
 	NodeRoles() common.NetworkRole
 	Stop() error
@@ -68,11 +73,22 @@ type BlockProducerAPI interface {
 	Resume() error
 	EpochLength() uint64
 	SlotDuration() uint64
+	SealBlock() (*types.Block, error)
+}
+
+// BlockFinaliserAPI is the interface to finalise blocks outside of GRANDPA
+type BlockFinaliserAPI interface {
+	GetHighestRoundAndSetID() (uint64, uint64, error)
+	SetFinalisedHash(hash common.Hash, round, setID uint64) error
 }
 
 // TransactionStateAPI ...
 type TransactionStateAPI interface {
 	Pending() []*transaction.ValidTransaction
+	PendingInQueue() []*transaction.ValidTransaction
+	PendingInPool() []*transaction.ValidTransaction
+	RemoveExtrinsic(ext types.Extrinsic)
+	BanExtrinsic(ext types.Extrinsic)
 }
 
 // CoreAPI is the interface for the core methods
@@ -82,8 +98,12 @@ type CoreAPI interface {
 	GetRuntimeVersion(bhash *common.Hash) (runtime.Version, error)
 	HandleSubmittedExtrinsic(types.Extrinsic) error
 	GetMetadata(bhash *common.Hash) ([]byte, error)
+	GetMetadataAtVersion(bhash *common.Hash, version uint32) ([]byte, error)
 	DecodeSessionKeys(enc []byte) ([]byte, error)
 	GetReadProofAt(block common.Hash, keys [][]byte) (common.Hash, [][]byte, error)
+	GetBlockWitness(blockHash common.Hash) (common.Hash, [][]byte, error)
+	GetBlockStats(blockHash common.Hash) (*core.BlockStats, error)
+	CachedBlockEvents(blockHash common.Hash) ([]byte, bool)
 }
 
 // RPCAPI is the interface for methods related to RPC service
@@ -107,6 +127,8 @@ type BlockFinalityAPI interface {
 	GetVoters() grandpa.Voters
 	PreVotes() []ed25519.PublicKeyBytes
 	PreCommits() []ed25519.PublicKeyBytes
+	PauseVoting()
+	ResumeVoting()
 }
 
 // RuntimeStorageAPI is the interface to interacts with the node storage
@@ -122,7 +144,33 @@ type SyncStateAPI interface {
 	GenSyncSpec(raw bool) (*genesis.Genesis, error)
 }
 
+// LightSyncStateAPI is the interface to get the light sync state of the highest finalised block.
+type LightSyncStateAPI interface {
+	LightSyncState() (*genesis.LightSyncState, error)
+}
+
 // SyncAPI is the interface to interact with the sync service
 type SyncAPI interface {
 	HighestBlock() uint
+	SyncProgress() common.SyncProgress
+}
+
+// DatabaseAPI is the interface to compact the databases of the node
+type DatabaseAPI interface {
+	Compact() error
+}
+
+// EpochAPI is the interface to the BABE epochs
+type EpochAPI interface {
+	GetCurrentEpoch() (uint64, error)
+	GetEpochDataRaw(epoch uint64, header *types.Header) (*types.EpochDataRaw, error)
+	GetEpochForBlock(header *types.Header) (uint64, error)
+	GetStartSlotForEpoch(epoch uint64, bestBlockHash common.Hash) (uint64, error)
+	GetSlotDuration() (time.Duration, error)
+}
+
+// RoleAPI is the interface to switch the node between the authority and full node roles
+type RoleAPI interface {
+	SetAuthoring(authoring bool) error
+	IsAuthoring() bool
 }
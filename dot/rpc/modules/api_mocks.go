@@ -20,10 +20,13 @@ func NewMockAnyStorageAPI(ctrl *gomock.Controller) *modulesmocks.MockStorageAPI
 	m.EXPECT().Entries(gomock.Any()).Return(nil, nil).AnyTimes()
 	m.EXPECT().GetStorageByBlockHash(gomock.Any(), gomock.Any()).
 		Return(nil, nil).AnyTimes()
+	m.EXPECT().GetChangedBlocks(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, false, nil).AnyTimes()
 	m.EXPECT().RegisterStorageObserver(gomock.Any()).AnyTimes()
 	m.EXPECT().UnregisterStorageObserver(gomock.Any()).AnyTimes()
 	m.EXPECT().GetStateRootFromBlock(gomock.Any()).Return(nil, nil).AnyTimes()
 	m.EXPECT().GetKeysWithPrefix(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	m.EXPECT().GetKeysPaged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
 	return m
 }
 
@@ -5,13 +5,16 @@
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/transaction"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 )
 
@@ -41,10 +44,10 @@ type Extrinsic struct {
 	Data string
 }
 
-// ExtrinsicOrHash is a type for Hash and Extrinsic array of bytes
+// ExtrinsicOrHash identifies an extrinsic either by its hash or by its hex encoded bytes
 type ExtrinsicOrHash struct {
 	Hash      common.Hash
-	Extrinsic []byte
+	Extrinsic string
 }
 
 // ExtrinsicOrHashRequest is a array of ExtrinsicOrHash
@@ -59,6 +62,24 @@ type ExtrinsicOrHash struct {
 // RemoveExtrinsicsResponse is a array of hash used to Remove extrinsics
 type RemoveExtrinsicsResponse []common.Hash
 
+// PoolTransaction is a transaction of the transaction pool with its validity
+type PoolTransaction struct {
+	Hash      common.Hash `json:"hash"`
+	Extrinsic string      `json:"extrinsic"`
+	Priority  uint64      `json:"priority"`
+	Longevity uint64      `json:"longevity"`
+	Requires  []string    `json:"requires"`
+	Provides  []string    `json:"provides"`
+	Propagate bool        `json:"propagate"`
+}
+
+// InspectPoolResponse holds the transactions of the ready queue and of the future pool, by
+// decreasing priority
+type InspectPoolResponse struct {
+	Ready  []PoolTransaction `json:"ready"`
+	Future []PoolTransaction `json:"future"`
+}
+
 // KeyRotateResponse is a byte array used to rotate
 type KeyRotateResponse []byte
 
@@ -147,12 +168,8 @@ func (am *AuthorModule) HasSessionKeys(r *http.Request, req *HasSessionKeyReques
 func (am *AuthorModule) InsertKey(r *http.Request, req *KeyInsertRequest, _ *KeyInsertResponse) error {
 	keyReq := *req
 
-	keyBytes, err := common.HexToBytes(req.Seed)
-	if err != nil {
-		return err
-	}
-
-	keyPair, err := keystore.DecodeKeyPairFromHex(keyBytes, keystore.DetermineKeyType(keyReq.Type))
+	// the seed is a secret URI, either a hex encoded seed or a mnemonic, with an optional derivation path
+	keyPair, err := keystore.DecodeKeyPairFromSecretURI(keyReq.Seed, keystore.DetermineKeyType(keyReq.Type))
 	if err != nil {
 		return err
 	}
@@ -191,8 +208,77 @@ func (am *AuthorModule) PendingExtrinsics(r *http.Request, req *EmptyRequest, re
 	return nil
 }
 
-// RemoveExtrinsic Remove given extrinsic from the pool and temporarily ban it to prevent reimporting
-func (am *AuthorModule) RemoveExtrinsic(r *http.Request, _ *ExtrinsicOrHashRequest, _ *RemoveExtrinsicsResponse) error {
+// InspectPool returns the transactions of the ready queue and of the future pool with their
+// priority, longevity and tags
+func (am *AuthorModule) InspectPool(r *http.Request, req *EmptyRequest, res *InspectPoolResponse) error {
+	*res = InspectPoolResponse{
+		Ready:  newPoolTransactions(am.txStateAPI.PendingInQueue()),
+		Future: newPoolTransactions(am.txStateAPI.PendingInPool()),
+	}
+	return nil
+}
+
+func newPoolTransactions(txs []*transaction.ValidTransaction) []PoolTransaction {
+	poolTxs := make([]PoolTransaction, len(txs))
+	for i, tx := range txs {
+		poolTxs[i] = PoolTransaction{
+			Hash:      tx.Extrinsic.Hash(),
+			Extrinsic: common.BytesToHex(tx.Extrinsic),
+			Requires:  []string{},
+			Provides:  []string{},
+		}
+		if tx.Validity == nil {
+			continue
+		}
+
+		poolTxs[i].Priority = tx.Validity.Priority
+		poolTxs[i].Longevity = tx.Validity.Longevity
+		poolTxs[i].Propagate = tx.Validity.Propagate
+		for _, tag := range tx.Validity.Requires {
+			poolTxs[i].Requires = append(poolTxs[i].Requires, common.BytesToHex(tag))
+		}
+		for _, tag := range tx.Validity.Provides {
+			poolTxs[i].Provides = append(poolTxs[i].Provides, common.BytesToHex(tag))
+		}
+	}
+
+	sort.SliceStable(poolTxs, func(i, j int) bool {
+		return poolTxs[i].Priority > poolTxs[j].Priority
+	})
+	return poolTxs
+}
+
+// RemoveExtrinsic Remove given extrinsics from the pool, identified by their hash or bytes, and
+// returns the hashes of the extrinsics removed. The extrinsics removed are temporarily banned
+// from re-entering the pool.
+func (am *AuthorModule) RemoveExtrinsic(r *http.Request, req *ExtrinsicOrHashRequest,
+	res *RemoveExtrinsicsResponse) error {
+	hashes := make(map[common.Hash]struct{}, len(*req))
+	for _, extOrHash := range *req {
+		hash := extOrHash.Hash
+		if extOrHash.Extrinsic != "" {
+			extBytes, err := common.HexToBytes(extOrHash.Extrinsic)
+			if err != nil {
+				return fmt.Errorf("decoding extrinsic: %w", err)
+			}
+			hash = types.Extrinsic(extBytes).Hash()
+		}
+		hashes[hash] = struct{}{}
+	}
+
+	removed := RemoveExtrinsicsResponse{}
+	for _, tx := range am.txStateAPI.Pending() {
+		hash := tx.Extrinsic.Hash()
+		if _, ok := hashes[hash]; !ok {
+			continue
+		}
+
+		am.txStateAPI.RemoveExtrinsic(tx.Extrinsic)
+		am.txStateAPI.BanExtrinsic(tx.Extrinsic)
+		removed = append(removed, hash)
+	}
+
+	*res = removed
 	return nil
 }
 
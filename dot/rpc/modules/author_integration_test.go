@@ -161,6 +161,9 @@ func TestAuthorModule_Pending_Integration(t *testing.T) {
 
 func TestAuthorModule_SubmitExtrinsic_Integration(t *testing.T) {
 	t.Parallel()
+	keyRing, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+
 	integrationTestController := setupStateAndPopulateTrieState(t, t.TempDir(), useInstanceFromGenesis)
 
 	ctrl := gomock.NewController(t)
@@ -177,7 +180,7 @@ func TestAuthorModule_SubmitExtrinsic_Integration(t *testing.T) {
 	// creating an extrisinc to the System.remark call using a sample argument
 	extHex := runtime.NewTestExtrinsic(t,
 		integrationTestController.runtime, genesisHash, genesisHash, 0,
-		signature.TestKeyringPairAlice, "System.remark", []byte{0xab, 0xcd})
+		keyRing.Alice(), "System.remark", []byte{0xab, 0xcd})
 
 	extBytes := common.MustHexToBytes(extHex)
 
@@ -189,7 +192,7 @@ func TestAuthorModule_SubmitExtrinsic_Integration(t *testing.T) {
 	auth := newAuthorModule(t, integrationTestController)
 
 	res := new(ExtrinsicHashResponse)
-	err := auth.SubmitExtrinsic(nil, &Extrinsic{extHex}, res)
+	err = auth.SubmitExtrinsic(nil, &Extrinsic{extHex}, res)
 	require.NoError(t, err)
 
 	expectedExtrinsic := types.NewExtrinsic(extBytes)
@@ -215,15 +218,29 @@ func TestAuthorModule_SubmitExtrinsic_Integration(t *testing.T) {
 	require.Equal(t, expectedHash, *res)
 }
 
+// invalidPublicKeySigner signs with its keypair but advertises another public key, so the
+// signatures of its extrinsics are invalid
+type invalidPublicKeySigner struct {
+	*sr25519.Keypair
+	publicKey crypto.PublicKey
+}
+
+func (s invalidPublicKeySigner) Public() crypto.PublicKey {
+	return s.publicKey
+}
+
 func TestAuthorModule_SubmitExtrinsic_bad_proof(t *testing.T) {
 	t.Parallel()
-	testInvalidKeyringPairAlice := signature.KeyringPair{
-		URI: "//Alice",
-		PublicKey: []byte{0xd5, 0x36, 0x13, 0xc7, 0x15, 0xfd, 0xd3,
-			0x1c, 0x61, 0x14, 0x1a, 0xb4, 0x4, 0xa9, 0x9f, 0xd6, 0x82,
-			0x2c, 0x85, 0x58, 0x85, 0x2c, 0xcd, 0xe3, 0x9a, 0x56, 0x84,
-			0xe7, 0xa5, 0x6d, 0x12, 0x7d},
-		Address: "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY",
+	keyRing, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+	invalidPublicKey, err := sr25519.NewPublicKey([]byte{0xd5, 0x36, 0x13, 0xc7, 0x15, 0xfd, 0xd3,
+		0x1c, 0x61, 0x14, 0x1a, 0xb4, 0x4, 0xa9, 0x9f, 0xd6, 0x82,
+		0x2c, 0x85, 0x58, 0x85, 0x2c, 0xcd, 0xe3, 0x9a, 0x56, 0x84,
+		0xe7, 0xa5, 0x6d, 0x12, 0x7d})
+	require.NoError(t, err)
+	testInvalidSignerAlice := invalidPublicKeySigner{
+		Keypair:   keyRing.KeyAlice,
+		publicKey: invalidPublicKey,
 	}
 
 	integrationTestController := setupStateAndRuntime(t, t.TempDir(), useInstanceFromGenesis)
@@ -233,7 +250,7 @@ func TestAuthorModule_SubmitExtrinsic_bad_proof(t *testing.T) {
 	// creating an extrisinc to the System.remark call using a sample argument
 	extHex := runtime.NewTestExtrinsic(t,
 		integrationTestController.runtime, genesisHash, genesisHash, 0,
-		testInvalidKeyringPairAlice, "System.remark", []byte{0xab, 0xcd})
+		testInvalidSignerAlice, "System.remark", []byte{0xab, 0xcd})
 
 	ctrl := gomock.NewController(t)
 	net2test := NewMockNetwork(ctrl)
@@ -244,7 +261,7 @@ func TestAuthorModule_SubmitExtrinsic_bad_proof(t *testing.T) {
 	auth := newAuthorModule(t, integrationTestController)
 
 	res := new(ExtrinsicHashResponse)
-	err := auth.SubmitExtrinsic(nil, &Extrinsic{extHex}, res)
+	err = auth.SubmitExtrinsic(nil, &Extrinsic{extHex}, res)
 	require.EqualError(t, err, "bad proof")
 
 	txOnPool := integrationTestController.stateSrv.Transaction.PendingInPool()
@@ -266,6 +283,9 @@ func TestAuthorModule_SubmitExtrinsic_invalid_input(t *testing.T) {
 
 func TestAuthorModule_SubmitExtrinsic_AlreadyInPool(t *testing.T) {
 	t.Parallel()
+	keyRing, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+
 	integrationTestController := setupStateAndRuntime(t, t.TempDir(), useInstanceFromGenesis)
 
 	ctrl := gomock.NewController(t)
@@ -282,7 +302,7 @@ func TestAuthorModule_SubmitExtrinsic_AlreadyInPool(t *testing.T) {
 	// creating an extrisinc to the System.remark call using a sample argument
 	extHex := runtime.NewTestExtrinsic(t,
 		integrationTestController.runtime, genesisHash, genesisHash, 0,
-		signature.TestKeyringPairAlice, "System.remark", []byte{})
+		keyRing.Alice(), "System.remark", []byte{})
 	extBytes := common.MustHexToBytes(extHex)
 
 	integrationTestController.network = NewMockNetwork(nil)
@@ -308,7 +328,7 @@ func TestAuthorModule_SubmitExtrinsic_AlreadyInPool(t *testing.T) {
 
 	integrationTestController.stateSrv.Transaction.AddToPool(expected)
 
-	err := auth.SubmitExtrinsic(nil, &Extrinsic{extHex}, res)
+	err = auth.SubmitExtrinsic(nil, &Extrinsic{extHex}, res)
 	require.NoError(t, err)
 }
 
@@ -625,6 +645,8 @@ type coreNetwork interface {
 	GossipMessage(network.NotificationsMessage)
 	IsSynced() bool
 	ReportPeer(change peerset.ReputationChange, p peer.ID)
+	ReadRemoteStorage(blockHash, stateRoot common.Hash, key []byte) (
+		value []byte, encodedProofNodes [][]byte, err error)
 }
 
 type coreStorageState interface {
@@ -299,6 +299,103 @@ type args struct {
 	}
 }
 
+func TestAuthorModule_RemoveExtrinsic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	extA := types.NewExtrinsic([]byte("someExtrinsic"))
+	extB := types.NewExtrinsic([]byte("someExtrinsic1"))
+	extC := types.NewExtrinsic([]byte("someExtrinsic2"))
+
+	mockTransactionStateAPI := mocks.NewMockTransactionStateAPI(ctrl)
+	mockTransactionStateAPI.EXPECT().Pending().Return([]*transaction.ValidTransaction{
+		{Extrinsic: extA},
+		{Extrinsic: extB},
+		{Extrinsic: extC},
+	})
+	mockTransactionStateAPI.EXPECT().RemoveExtrinsic(extA)
+	mockTransactionStateAPI.EXPECT().BanExtrinsic(extA)
+	mockTransactionStateAPI.EXPECT().RemoveExtrinsic(extC)
+	mockTransactionStateAPI.EXPECT().BanExtrinsic(extC)
+
+	authorModule := &AuthorModule{
+		logger:     log.New(log.SetWriter(io.Discard)),
+		txStateAPI: mockTransactionStateAPI,
+	}
+
+	req := ExtrinsicOrHashRequest{
+		{Hash: extA.Hash()},
+		{Extrinsic: common.BytesToHex(extC)},
+		{Hash: common.Hash{1}},
+	}
+	var res RemoveExtrinsicsResponse
+	err := authorModule.RemoveExtrinsic(nil, &req, &res)
+	require.NoError(t, err)
+	assert.Equal(t, RemoveExtrinsicsResponse{extA.Hash(), extC.Hash()}, res)
+}
+
+func TestAuthorModule_InspectPool(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	extA := types.NewExtrinsic([]byte("someExtrinsic"))
+	extB := types.NewExtrinsic([]byte("someExtrinsic1"))
+	extC := types.NewExtrinsic([]byte("someExtrinsic2"))
+
+	mockTransactionStateAPI := mocks.NewMockTransactionStateAPI(ctrl)
+	mockTransactionStateAPI.EXPECT().PendingInQueue().Return([]*transaction.ValidTransaction{
+		{
+			Extrinsic: extA,
+			Validity:  transaction.NewValidity(1, nil, [][]byte{{1}}, 64, true),
+		},
+		{
+			Extrinsic: extB,
+			Validity:  transaction.NewValidity(2, [][]byte{{1}}, [][]byte{{2}}, 32, false),
+		},
+	})
+	mockTransactionStateAPI.EXPECT().PendingInPool().Return([]*transaction.ValidTransaction{
+		{Extrinsic: extC},
+	})
+
+	authorModule := &AuthorModule{
+		logger:     log.New(log.SetWriter(io.Discard)),
+		txStateAPI: mockTransactionStateAPI,
+	}
+
+	var res InspectPoolResponse
+	err := authorModule.InspectPool(nil, nil, &res)
+	require.NoError(t, err)
+
+	expected := InspectPoolResponse{
+		Ready: []PoolTransaction{
+			{
+				Hash:      extB.Hash(),
+				Extrinsic: common.BytesToHex(extB),
+				Priority:  2,
+				Longevity: 32,
+				Requires:  []string{"0x01"},
+				Provides:  []string{"0x02"},
+			},
+			{
+				Hash:      extA.Hash(),
+				Extrinsic: common.BytesToHex(extA),
+				Priority:  1,
+				Longevity: 64,
+				Requires:  []string{},
+				Provides:  []string{"0x01"},
+				Propagate: true,
+			},
+		},
+		Future: []PoolTransaction{
+			{
+				Hash:      extC.Hash(),
+				Extrinsic: common.BytesToHex(extC),
+				Requires:  []string{},
+				Provides:  []string{},
+			},
+		},
+	}
+	assert.Equal(t, expected, res)
+}
+
 func TestAuthorModule_InsertKey(t *testing.T) {
 	kp1, err := sr25519.NewKeypairFromSeed(
 		common.MustHexToBytes("0x6246ddf254e0b4b4e7dffefc8adf69d212b98ac2b579c362b473fec8c40b4c0a"))
@@ -316,11 +413,18 @@ func TestAuthorModule_InsertKey(t *testing.T) {
 	require.NoError(t, err)
 	_ = kp3.Public().Hex()
 
+	kr, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+	_ = kr.Alice().Public().Hex()
+
 	ctrl := gomock.NewController(t)
 
 	mockCoreAPIHappyBabe := mocks.NewMockCoreAPI(ctrl)
 	mockCoreAPIHappyBabe.EXPECT().InsertKey(kp1, "babe").Return(nil)
 
+	mockCoreAPIHappyURI := mocks.NewMockCoreAPI(ctrl)
+	mockCoreAPIHappyURI.EXPECT().InsertKey(kr.Alice(), "babe").Return(nil)
+
 	mockCoreAPIHappyGran := mocks.NewMockCoreAPI(ctrl)
 	mockCoreAPIHappyGran.EXPECT().InsertKey(kp2, "gran").Return(nil)
 
@@ -367,6 +471,20 @@ type args struct {
 				},
 			},
 		},
+		{
+			name: "happy_path,_secret_uri",
+			fields: fields{
+				logger:  log.New(log.SetWriter(io.Discard)),
+				coreAPI: mockCoreAPIHappyURI,
+			},
+			args: args{
+				req: &KeyInsertRequest{
+					"babe",
+					"//Alice",
+					"0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d",
+				},
+			},
+		},
 		{
 			name: "invalid_key",
 			fields: fields{
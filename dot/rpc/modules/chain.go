@@ -14,6 +14,9 @@
 	"github.com/ChainSafe/gossamer/pkg/scale"
 )
 
+// maxBlocksInRange is the maximum number of blocks returned by a chain_getBlocksInRange call
+const maxBlocksInRange = 100
+
 // ChainHashRequest Hash as a string
 type ChainHashRequest struct {
 	Bhash *common.Hash
@@ -24,6 +27,12 @@ type ChainBlockNumberRequest struct {
 	Block interface{}
 }
 
+// ChainBlockRangeRequest holds the numbers of the first and last blocks of a range, inclusive
+type ChainBlockRangeRequest struct {
+	Start uint
+	End   uint
+}
+
 // ChainFinalizedHeadRequest ...
 type ChainFinalizedHeadRequest struct {
 	Round uint64
@@ -55,6 +64,9 @@ type ChainBlockResponse struct {
 	Block ChainBlock `json:"block"`
 }
 
+// ChainBlocksResponse holds the blocks of a range of the canonical chain
+type ChainBlocksResponse []ChainBlock
+
 // ChainHashResponse interface to handle response
 type ChainHashResponse interface{}
 
@@ -79,20 +91,52 @@ func (cm *ChainModule) GetBlock(r *http.Request, req *ChainHashRequest, res *Cha
 		return err
 	}
 
-	res.Block.Header, err = HeaderToJSON(block.Header)
+	res.Block, err = blockToJSON(block)
+	return err
+}
+
+// GetBlocksInRange returns the blocks of the canonical chain numbered from the start to the
+// end block numbers given, both included. The range is truncated at the best block, and ranges
+// of more than 100 blocks are rejected, longer ranges having to be requested in several calls.
+func (cm *ChainModule) GetBlocksInRange(_ *http.Request, req *ChainBlockRangeRequest,
+	res *ChainBlocksResponse) error {
+	if req.Start > req.End {
+		return fmt.Errorf("%w: %d > %d", ErrInvalidBlockRange, req.Start, req.End)
+	}
+	if req.End-req.Start >= maxBlocksInRange {
+		return fmt.Errorf("%w: %d blocks requested, maximum is %d",
+			ErrBlockRangeTooLarge, req.End-req.Start+1, maxBlocksInRange)
+	}
+
+	bestHeader, err := cm.blockAPI.GetHeader(cm.blockAPI.BestBlockHash())
 	if err != nil {
-		return err
+		return fmt.Errorf("getting best block header: %w", err)
+	}
+	end := req.End
+	if end > bestHeader.Number {
+		end = bestHeader.Number
 	}
 
-	if block.Body != nil {
-		ext, err := block.Body.AsEncodedExtrinsics()
+	blocks := make(ChainBlocksResponse, 0, maxBlocksInRange)
+	for number := req.Start; number <= end; number++ {
+		hash, err := cm.blockAPI.GetHashByNumber(number)
 		if err != nil {
-			return err
+			return fmt.Errorf("getting hash of block %d: %w", number, err)
 		}
-		for _, e := range ext {
-			res.Block.Body = append(res.Block.Body, e.String())
+
+		block, err := cm.blockAPI.GetBlockByHash(hash)
+		if err != nil {
+			return fmt.Errorf("getting block %s: %w", hash, err)
 		}
+
+		chainBlock, err := blockToJSON(block)
+		if err != nil {
+			return err
+		}
+		blocks = append(blocks, chainBlock)
 	}
+
+	*res = blocks
 	return nil
 }
 
@@ -232,6 +276,26 @@ func (cm *ChainModule) lookupHashByInterface(i interface{}) (string, error) {
 	return h.String(), nil
 }
 
+// blockToJSON converts types.Block to ChainBlock
+func blockToJSON(block *types.Block) (ChainBlock, error) {
+	header, err := HeaderToJSON(block.Header)
+	if err != nil {
+		return ChainBlock{}, err
+	}
+
+	chainBlock := ChainBlock{Header: header}
+	if block.Body != nil {
+		ext, err := block.Body.AsEncodedExtrinsics()
+		if err != nil {
+			return ChainBlock{}, err
+		}
+		for _, e := range ext {
+			chainBlock.Body = append(chainBlock.Body, e.String())
+		}
+	}
+	return chainBlock, nil
+}
+
 // HeaderToJSON converts types.Header to ChainBlockHeaderResponse
 func HeaderToJSON(header types.Header) (ChainBlockHeaderResponse, error) {
 	res := ChainBlockHeaderResponse{
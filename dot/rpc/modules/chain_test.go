@@ -117,6 +117,112 @@ type args struct {
 	}
 }
 
+func TestChainModule_GetBlocksInRange(t *testing.T) {
+	bestHash := common.Hash{2}
+	bestHeader := &types.Header{Number: 2}
+	block1 := &types.Block{Header: types.Header{Number: 1}, Body: types.BytesArrayToExtrinsics([][]byte{{1}})}
+	block2 := &types.Block{Header: types.Header{Number: 2}}
+	zeroHash := "0x0000000000000000000000000000000000000000000000000000000000000000"
+
+	tests := map[string]struct {
+		blockAPIBuilder func(ctrl *gomock.Controller) BlockAPI
+		req             ChainBlockRangeRequest
+		expErr          error
+		errMessage      string
+		exp             ChainBlocksResponse
+	}{
+		"truncated_at_best_block": {
+			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
+				mockBlockAPI := mocks.NewMockBlockAPI(ctrl)
+				mockBlockAPI.EXPECT().BestBlockHash().Return(bestHash)
+				mockBlockAPI.EXPECT().GetHeader(bestHash).Return(bestHeader, nil)
+				mockBlockAPI.EXPECT().GetHashByNumber(uint(1)).Return(common.Hash{1}, nil)
+				mockBlockAPI.EXPECT().GetBlockByHash(common.Hash{1}).Return(block1, nil)
+				mockBlockAPI.EXPECT().GetHashByNumber(uint(2)).Return(bestHash, nil)
+				mockBlockAPI.EXPECT().GetBlockByHash(bestHash).Return(block2, nil)
+				return mockBlockAPI
+			},
+			req: ChainBlockRangeRequest{Start: 1, End: 10},
+			exp: ChainBlocksResponse{
+				{
+					Header: ChainBlockHeaderResponse{
+						ParentHash:     zeroHash,
+						Number:         "0x01",
+						StateRoot:      zeroHash,
+						ExtrinsicsRoot: zeroHash,
+						Digest:         ChainBlockHeaderDigest{},
+					},
+					Body: []string{"0x0401"},
+				},
+				{
+					Header: ChainBlockHeaderResponse{
+						ParentHash:     zeroHash,
+						Number:         "0x02",
+						StateRoot:      zeroHash,
+						ExtrinsicsRoot: zeroHash,
+						Digest:         ChainBlockHeaderDigest{},
+					},
+				},
+			},
+		},
+		"start_after_best_block": {
+			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
+				mockBlockAPI := mocks.NewMockBlockAPI(ctrl)
+				mockBlockAPI.EXPECT().BestBlockHash().Return(bestHash)
+				mockBlockAPI.EXPECT().GetHeader(bestHash).Return(bestHeader, nil)
+				return mockBlockAPI
+			},
+			req: ChainBlockRangeRequest{Start: 3, End: 4},
+			exp: ChainBlocksResponse{},
+		},
+		"invalid_range": {
+			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
+				return mocks.NewMockBlockAPI(ctrl)
+			},
+			req:        ChainBlockRangeRequest{Start: 2, End: 1},
+			expErr:     ErrInvalidBlockRange,
+			errMessage: "the start block number cannot be greater than the end block number: 2 > 1",
+		},
+		"range_too_large": {
+			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
+				return mocks.NewMockBlockAPI(ctrl)
+			},
+			req:        ChainBlockRangeRequest{Start: 1, End: 101},
+			expErr:     ErrBlockRangeTooLarge,
+			errMessage: "block range too large: 101 blocks requested, maximum is 100",
+		},
+		"GetHashByNumber_Err": {
+			blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
+				mockBlockAPI := mocks.NewMockBlockAPI(ctrl)
+				mockBlockAPI.EXPECT().BestBlockHash().Return(bestHash)
+				mockBlockAPI.EXPECT().GetHeader(bestHash).Return(bestHeader, nil)
+				mockBlockAPI.EXPECT().GetHashByNumber(uint(1)).
+					Return(common.Hash{}, errors.New("GetHashByNumber Error"))
+				return mockBlockAPI
+			},
+			req:        ChainBlockRangeRequest{Start: 1, End: 2},
+			errMessage: "getting hash of block 1: GetHashByNumber Error",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			cm := NewChainModule(tt.blockAPIBuilder(ctrl))
+			res := ChainBlocksResponse(nil)
+			err := cm.GetBlocksInRange(nil, &tt.req, &res)
+			if tt.expErr != nil {
+				assert.ErrorIs(t, err, tt.expErr)
+			}
+			if tt.errMessage != "" {
+				assert.EqualError(t, err, tt.errMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.exp, res)
+		})
+	}
+}
+
 func TestChainModule_GetBlockHash(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
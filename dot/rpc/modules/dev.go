@@ -6,27 +6,138 @@
 import (
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"net/http"
+	"sync"
+	"time"
 
+	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
 )
 
 var blockProducerStoppedMsg = "babe service stopped"
 var blockProducerStartedMsg = "babe service started"
 var networkStoppedMsg = "network service stopped"
 var networkStartedMsg = "network service started"
+var databaseCompactedMsg = "database compacted"
+
+// Node roles of the dev_setNodeRole and dev_nodeRole RPC methods
+const (
+	authorityNodeRole = "authority"
+	fullNodeRole      = "full"
+)
+
+var errInvalidNodeRole = errors.New("invalid node role")
+
+// DevBlockWitnessRequest holds the hash of the block to generate the witness for
+type DevBlockWitnessRequest struct {
+	Hash common.Hash `json:"hash"`
+}
+
+// DevBlockWitnessResponse holds the storage proof of the state accessed by a block
+type DevBlockWitnessResponse struct {
+	At              common.Hash `json:"at"`
+	ParentStateRoot common.Hash `json:"parentStateRoot"`
+	Proof           []string    `json:"proof"`
+}
+
+// DevBlockStatsRequest holds the hash of the block to return the statistics of
+type DevBlockStatsRequest struct {
+	Hash common.Hash `json:"hash"`
+}
+
+// DevBlockStatsResponse holds the statistics of the execution of a block
+type DevBlockStatsResponse struct {
+	WitnessLen    uint64 `json:"witnessLen"`
+	BlockLen      uint64 `json:"blockLen"`
+	NumExtrinsics uint64 `json:"numExtrinsics"`
+}
+
+// DevEventsRequest holds the hash of the block to decode the events of
+type DevEventsRequest struct {
+	Hash common.Hash `json:"hash"`
+}
+
+// DevEventsResponse holds the events of a block decoded with the runtime metadata
+type DevEventsResponse struct {
+	At     common.Hash         `json:"at"`
+	Events []scale.EventRecord `json:"events"`
+}
+
+// DevEpochRandomnessRequest holds the epoch to return the randomness of, the current epoch
+// if it is nil
+type DevEpochRandomnessRequest struct {
+	Epoch *uint64 `json:"epoch"`
+}
+
+// DevEpochRandomnessResponse holds the BABE randomness of an epoch, used as input of the VRF
+// of its slot claims, along with the current epoch and slot
+type DevEpochRandomnessResponse struct {
+	Epoch        uint64 `json:"epoch"`
+	StartSlot    uint64 `json:"startSlot"`
+	Randomness   string `json:"randomness"`
+	CurrentEpoch uint64 `json:"currentEpoch"`
+	CurrentSlot  uint64 `json:"currentSlot"`
+}
+
+// DevBlockVRFRequest holds the hash of the block to return the slot claim of
+type DevBlockVRFRequest struct {
+	Hash common.Hash `json:"hash"`
+}
+
+// DevBlockVRFResponse holds the BABE slot claim of a block, with the VRF output and proof
+// of its author
+type DevBlockVRFResponse struct {
+	At             common.Hash `json:"at"`
+	Epoch          uint64      `json:"epoch"`
+	Slot           uint64      `json:"slot"`
+	AuthorityIndex uint32      `json:"authorityIndex"`
+	// SlotClaim is the kind of slot claim, primary, secondaryVRF or secondaryPlain
+	SlotClaim string `json:"slotClaim"`
+	// VRFOutput and VRFProof are empty for the secondary plain slot claims, which have no VRF
+	VRFOutput string `json:"vrfOutput,omitempty"`
+	VRFProof  string `json:"vrfProof,omitempty"`
+}
+
+// Kinds of BABE slot claims of the dev_blockVrf RPC method
+const (
+	primarySlotClaim        = "primary"
+	secondaryVRFSlotClaim   = "secondaryVRF"
+	secondaryPlainSlotClaim = "secondaryPlain"
+)
+
+var errNoBabePreDigest = errors.New("block has no BABE pre-runtime digest")
+
+// DevNodeRoleRequest holds the role to switch the node to, authority or full
+type DevNodeRoleRequest struct {
+	Role string `json:"role"`
+}
 
 // DevModule is an RPC module that provides developer endpoints
 type DevModule struct {
 	networkAPI       NetworkAPI
 	blockProducerAPI BlockProducerAPI
+	coreAPI          CoreAPI
+	storageAPI       StorageAPI
+	databaseAPI      DatabaseAPI
+	roleAPI          RoleAPI
+	blockAPI         BlockAPI
+	epochAPI         EpochAPI
 }
 
 // NewDevModule creates a new Dev module.
-func NewDevModule(bp BlockProducerAPI, net NetworkAPI) *DevModule {
+func NewDevModule(bp BlockProducerAPI, net NetworkAPI, core CoreAPI, storage StorageAPI,
+	database DatabaseAPI, role RoleAPI, block BlockAPI, epoch EpochAPI) *DevModule {
 	return &DevModule{
 		networkAPI:       net,
 		blockProducerAPI: bp,
+		coreAPI:          core,
+		storageAPI:       storage,
+		databaseAPI:      database,
+		roleAPI:          role,
+		blockAPI:         block,
+		epochAPI:         epoch,
 	}
 }
 
@@ -76,6 +187,331 @@ func (m *DevModule) EpochLength(r *http.Request, req *EmptyRequest, res *string)
 	return err
 }
 
+// GetBlockWitness Dev RPC to return the storage proof of all the state trie nodes
+// accessed when executing the given block on top of its parent state
+func (m *DevModule) GetBlockWitness(_ *http.Request, req *DevBlockWitnessRequest,
+	res *DevBlockWitnessResponse) error {
+	if m.coreAPI == nil {
+		return errors.New("core API is not available")
+	}
+
+	parentStateRoot, witness, err := m.coreAPI.GetBlockWitness(req.Hash)
+	if err != nil {
+		return err
+	}
+
+	proof := make([]string, len(witness))
+	for i, encodedNode := range witness {
+		proof[i] = common.BytesToHex(encodedNode)
+	}
+
+	*res = DevBlockWitnessResponse{
+		At:              req.Hash,
+		ParentStateRoot: parentStateRoot,
+		Proof:           proof,
+	}
+	return nil
+}
+
+// GetBlockStats Dev RPC to return the length of the witness of the given block, that is
+// the storage proof of the state trie nodes accessed by its execution, along with the
+// length and the number of extrinsics of the block
+func (m *DevModule) GetBlockStats(_ *http.Request, req *DevBlockStatsRequest,
+	res *DevBlockStatsResponse) error {
+	if m.coreAPI == nil {
+		return errors.New("core API is not available")
+	}
+
+	stats, err := m.coreAPI.GetBlockStats(req.Hash)
+	if err != nil {
+		return err
+	}
+
+	*res = DevBlockStatsResponse{
+		WitnessLen:    stats.WitnessLen,
+		BlockLen:      stats.BlockLen,
+		NumExtrinsics: stats.NumExtrinsics,
+	}
+	return nil
+}
+
+// GetEvents Dev RPC to return the events of the given block, decoded with the type
+// registry of the runtime metadata at the block, from the metadata version 14
+func (m *DevModule) GetEvents(_ *http.Request, req *DevEventsRequest, res *DevEventsResponse) error {
+	if m.coreAPI == nil || m.storageAPI == nil {
+		return errors.New("core or storage API is not available")
+	}
+
+	events, err := BlockEvents(m.coreAPI, m.storageAPI, req.Hash)
+	if err != nil {
+		return err
+	}
+
+	*res = DevEventsResponse{
+		At:     req.Hash,
+		Events: events,
+	}
+	return nil
+}
+
+// EpochRandomness Dev RPC to return the BABE randomness of the given epoch, or of the current
+// epoch if no epoch is given, along with the current epoch and slot, for the applications
+// building on the BABE randomness
+func (m *DevModule) EpochRandomness(_ *http.Request, req *DevEpochRandomnessRequest,
+	res *DevEpochRandomnessResponse) error {
+	if m.epochAPI == nil || m.blockAPI == nil {
+		return errors.New("epoch or block API is not available")
+	}
+
+	currentEpoch, err := m.epochAPI.GetCurrentEpoch()
+	if err != nil {
+		return fmt.Errorf("getting current epoch: %w", err)
+	}
+	epoch := currentEpoch
+	if req.Epoch != nil {
+		epoch = *req.Epoch
+	}
+
+	// the data of the epochs not finalised yet are looked up on the fork of the best block
+	bestBlockHash := m.blockAPI.BestBlockHash()
+	bestHeader, err := m.blockAPI.GetHeader(bestBlockHash)
+	if err != nil {
+		return fmt.Errorf("getting best block header: %w", err)
+	}
+	epochData, err := m.epochAPI.GetEpochDataRaw(epoch, bestHeader)
+	if err != nil {
+		return fmt.Errorf("getting data of epoch %d: %w", epoch, err)
+	}
+	if epochData == nil {
+		return fmt.Errorf("no data for epoch %d", epoch)
+	}
+
+	startSlot, err := m.epochAPI.GetStartSlotForEpoch(epoch, bestBlockHash)
+	if err != nil {
+		return fmt.Errorf("getting start slot of epoch %d: %w", epoch, err)
+	}
+	slotDuration, err := m.epochAPI.GetSlotDuration()
+	if err != nil {
+		return fmt.Errorf("getting slot duration: %w", err)
+	}
+	if slotDuration <= 0 {
+		return fmt.Errorf("invalid slot duration %s", slotDuration)
+	}
+
+	*res = DevEpochRandomnessResponse{
+		Epoch:        epoch,
+		StartSlot:    startSlot,
+		Randomness:   common.BytesToHex(epochData.Randomness[:]),
+		CurrentEpoch: currentEpoch,
+		CurrentSlot:  uint64(time.Now().UnixNano()) / uint64(slotDuration.Nanoseconds()), //nolint:gosec
+	}
+	return nil
+}
+
+// BlockVRF Dev RPC to return the BABE slot claim of the given block, with the VRF output and
+// proof of its author for the primary and secondary VRF slot claims
+func (m *DevModule) BlockVRF(_ *http.Request, req *DevBlockVRFRequest, res *DevBlockVRFResponse) error {
+	if m.epochAPI == nil || m.blockAPI == nil {
+		return errors.New("epoch or block API is not available")
+	}
+
+	header, err := m.blockAPI.GetHeader(req.Hash)
+	if err != nil {
+		return fmt.Errorf("getting header: %w", err)
+	}
+	preDigest, err := babePreDigest(header)
+	if err != nil {
+		return err
+	}
+	epoch, err := m.epochAPI.GetEpochForBlock(header)
+	if err != nil {
+		return fmt.Errorf("getting epoch of block: %w", err)
+	}
+
+	*res = DevBlockVRFResponse{
+		At:    req.Hash,
+		Epoch: epoch,
+	}
+	switch d := preDigest.(type) {
+	case types.BabePrimaryPreDigest:
+		res.Slot = d.SlotNumber
+		res.AuthorityIndex = d.AuthorityIndex
+		res.SlotClaim = primarySlotClaim
+		res.VRFOutput = common.BytesToHex(d.VRFOutput[:])
+		res.VRFProof = common.BytesToHex(d.VRFProof[:])
+	case types.BabeSecondaryVRFPreDigest:
+		res.Slot = d.SlotNumber
+		res.AuthorityIndex = d.AuthorityIndex
+		res.SlotClaim = secondaryVRFSlotClaim
+		res.VRFOutput = common.BytesToHex(d.VrfOutput[:])
+		res.VRFProof = common.BytesToHex(d.VrfProof[:])
+	case types.BabeSecondaryPlainPreDigest:
+		res.Slot = d.SlotNumber
+		res.AuthorityIndex = d.AuthorityIndex
+		res.SlotClaim = secondaryPlainSlotClaim
+	default:
+		return fmt.Errorf("unexpected BABE pre-runtime digest %T", preDigest)
+	}
+	return nil
+}
+
+// babePreDigest returns the decoded BABE pre-runtime digest of the header given.
+func babePreDigest(header *types.Header) (any, error) {
+	for _, item := range header.Digest {
+		value, err := item.Value()
+		if err != nil {
+			return nil, fmt.Errorf("getting digest item: %w", err)
+		}
+		preDigest, ok := value.(types.PreRuntimeDigest)
+		if !ok || preDigest.ConsensusEngineID != types.BabeEngineID {
+			continue
+		}
+
+		babePreDigest, err := types.DecodeBabePreDigest(preDigest.Data)
+		if err != nil {
+			return nil, fmt.Errorf("decoding BABE pre-runtime digest: %w", err)
+		}
+		return babePreDigest, nil
+	}
+	return nil, errNoBabePreDigest
+}
+
+// CompactDatabase Dev RPC to compact the state database and the libp2p datastore, reclaiming
+// the disk space of their deleted and overwritten entries. It returns once the compaction is done.
+func (m *DevModule) CompactDatabase(_ *http.Request, _ *EmptyRequest, res *string) error {
+	if m.databaseAPI == nil {
+		return errors.New("database API is not available")
+	}
+
+	err := m.databaseAPI.Compact()
+	if err != nil {
+		return err
+	}
+
+	*res = databaseCompactedMsg
+	return nil
+}
+
+// SetNodeRole Dev RPC to switch the node to the authority role, authoring blocks and voting
+// for their finality, or to the full node role doing neither, without restarting the node
+func (m *DevModule) SetNodeRole(_ *http.Request, req *DevNodeRoleRequest, res *string) error {
+	if m.roleAPI == nil {
+		return errors.New("role API is not available")
+	}
+
+	var authoring bool
+	switch req.Role {
+	case authorityNodeRole:
+		authoring = true
+	case fullNodeRole:
+	default:
+		return fmt.Errorf("%w: %q, expected %q or %q", errInvalidNodeRole, req.Role, authorityNodeRole, fullNodeRole)
+	}
+
+	err := m.roleAPI.SetAuthoring(authoring)
+	if err != nil {
+		return err
+	}
+
+	*res = "node role set to " + req.Role
+	return nil
+}
+
+// NodeRole Dev RPC to return the role of the node, authority or full
+func (m *DevModule) NodeRole(_ *http.Request, _ *EmptyRequest, res *string) error {
+	if m.roleAPI == nil {
+		return errors.New("role API is not available")
+	}
+
+	*res = fullNodeRole
+	if m.roleAPI.IsAuthoring() {
+		*res = authorityNodeRole
+	}
+	return nil
+}
+
+// eventsGetter gets the runtime metadata at a block and the events of the blocks
+// whose execution is cached
+type eventsGetter interface {
+	GetMetadata(bhash *common.Hash) ([]byte, error)
+	CachedBlockEvents(blockHash common.Hash) ([]byte, bool)
+}
+
+// storageGetter gets a storage value at a block
+type storageGetter interface {
+	GetStorageByBlockHash(bhash *common.Hash, key []byte) ([]byte, error)
+}
+
+// eventsRegistry caches the type registry of the last runtime metadata the events
+// were decoded with, since the metadata rarely changes between blocks
+var eventsRegistry = &registryCache{}
+
+// registryCache holds the type registry of a runtime metadata
+type registryCache struct {
+	mu           sync.Mutex
+	metadataHash common.Hash
+	registry     *scale.Registry
+}
+
+// get returns the type registry of the SCALE encoded runtime metadata given.
+func (rc *registryCache) get(encodedMetadata []byte) (*scale.Registry, error) {
+	metadataHash, err := common.Blake2bHash(encodedMetadata)
+	if err != nil {
+		return nil, fmt.Errorf("hashing metadata: %w", err)
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if rc.registry != nil && rc.metadataHash == metadataHash {
+		return rc.registry, nil
+	}
+
+	var metadata []byte
+	err = scale.Unmarshal(encodedMetadata, &metadata)
+	if err != nil {
+		return nil, fmt.Errorf("decoding metadata: %w", err)
+	}
+
+	registry, err := scale.NewRegistry(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("parsing metadata: %w", err)
+	}
+
+	rc.metadataHash = metadataHash
+	rc.registry = registry
+	return registry, nil
+}
+
+// BlockEvents returns the events of the given block, decoded with the type registry of
+// the runtime metadata at the block. The events are read from the block state unless
+// the execution of the block is cached.
+func BlockEvents(core eventsGetter, storage storageGetter, blockHash common.Hash) (
+	[]scale.EventRecord, error) {
+	encodedMetadata, err := core.GetMetadata(&blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting metadata: %w", err)
+	}
+
+	registry, err := eventsRegistry.get(encodedMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	encodedEvents, ok := core.CachedBlockEvents(blockHash)
+	if !ok {
+		encodedEvents, err = storage.GetStorageByBlockHash(&blockHash, common.SystemEventsKey)
+		if err != nil {
+			return nil, fmt.Errorf("getting events: %w", err)
+		}
+	}
+
+	if len(encodedEvents) == 0 {
+		return []scale.EventRecord{}, nil
+	}
+	return registry.DecodeEvents(encodedEvents)
+}
+
 // uint64ToHex converts a uint64 to a hexed string
 func uint64ToHex(input uint64) string {
 	buffer := make([]byte, 8)
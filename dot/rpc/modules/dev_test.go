@@ -7,13 +7,31 @@
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
+	"github.com/ChainSafe/gossamer/dot/core"
 	"github.com/ChainSafe/gossamer/dot/rpc/modules/mocks"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
 	"go.uber.org/mock/gomock"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+// testMetadataV15 is a SCALE encoded runtime metadata V15 with a System pallet, whose
+// events are ExtrinsicSuccess and Remarked { sender: u32 }
+const testMetadataV15 = "0x" +
+	"6d6574610f2400000005050410646f6373040c306672616d655f73797374656d1870616c6c6574144576656e74000108" +
+	"4045787472696e736963537563636573730000002052656d61726b656404011873656e64657200000001000410646f63" +
+	"7308043052756e74696d654576656e740001041853797374656d040004000000000410646f63730c08306672616d655f" +
+	"73797374656d145068617365000108384170706c7945787472696e736963040000000000003046696e616c697a617469" +
+	"6f6e0001000410646f63731000000320000000140410646f637314000005030410646f637318000002100410646f6373" +
+	"1c08306672616d655f73797374656d2c4576656e745265636f726400000c011470686173650c000001146576656e7408" +
+	"00000118746f706963731800000410646f6373200000021c0410646f6373041853797374656d011853797374656d0418" +
+	"4576656e747301002004000000010400000000040008000000"
+
 func Test_uint64ToHex(t *testing.T) {
 	type args struct {
 		input uint64
@@ -58,7 +76,7 @@ func TestDevModule_EpochLength(t *testing.T) {
 
 	mockBlockProducerAPI := mocks.NewMockBlockProducerAPI(ctrl)
 	mockBlockProducerAPI.EXPECT().EpochLength().Return(uint64(23))
-	devModule := NewDevModule(mockBlockProducerAPI, nil)
+	devModule := NewDevModule(mockBlockProducerAPI, nil, nil, nil, nil, nil, nil, nil)
 
 	type fields struct {
 		networkAPI       NetworkAPI
@@ -312,3 +330,509 @@ type args struct {
 		})
 	}
 }
+
+func TestDevModule_GetBlockWitness(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	blockHash := common.Hash{1}
+	parentStateRoot := common.Hash{2}
+
+	mockCoreAPI := mocks.NewMockCoreAPI(ctrl)
+	mockCoreAPI.EXPECT().GetBlockWitness(blockHash).
+		Return(parentStateRoot, [][]byte{{1, 2}, {3}}, nil)
+
+	mockCoreAPIErr := mocks.NewMockCoreAPI(ctrl)
+	mockCoreAPIErr.EXPECT().GetBlockWitness(blockHash).
+		Return(common.Hash{}, nil, errors.New("GetBlockWitness Error"))
+
+	tests := map[string]struct {
+		coreAPI CoreAPI
+		expErr  error
+		exp     DevBlockWitnessResponse
+	}{
+		"OK": {
+			coreAPI: mockCoreAPI,
+			exp: DevBlockWitnessResponse{
+				At:              blockHash,
+				ParentStateRoot: parentStateRoot,
+				Proof:           []string{"0x0102", "0x03"},
+			},
+		},
+		"GetBlockWitness Error": {
+			coreAPI: mockCoreAPIErr,
+			expErr:  errors.New("GetBlockWitness Error"),
+		},
+		"no core API": {
+			expErr: errors.New("core API is not available"),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := NewDevModule(nil, nil, tt.coreAPI, nil, nil, nil, nil, nil)
+			res := DevBlockWitnessResponse{}
+			err := m.GetBlockWitness(nil, &DevBlockWitnessRequest{Hash: blockHash}, &res)
+			if tt.expErr != nil {
+				assert.EqualError(t, err, tt.expErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.exp, res)
+		})
+	}
+}
+
+func TestDevModule_GetBlockStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	blockHash := common.Hash{1}
+
+	mockCoreAPI := mocks.NewMockCoreAPI(ctrl)
+	mockCoreAPI.EXPECT().GetBlockStats(blockHash).
+		Return(&core.BlockStats{WitnessLen: 100, BlockLen: 20, NumExtrinsics: 2}, nil)
+
+	mockCoreAPIErr := mocks.NewMockCoreAPI(ctrl)
+	mockCoreAPIErr.EXPECT().GetBlockStats(blockHash).
+		Return(nil, errors.New("GetBlockStats Error"))
+
+	tests := map[string]struct {
+		coreAPI CoreAPI
+		expErr  error
+		exp     DevBlockStatsResponse
+	}{
+		"OK": {
+			coreAPI: mockCoreAPI,
+			exp: DevBlockStatsResponse{
+				WitnessLen:    100,
+				BlockLen:      20,
+				NumExtrinsics: 2,
+			},
+		},
+		"GetBlockStats Error": {
+			coreAPI: mockCoreAPIErr,
+			expErr:  errors.New("GetBlockStats Error"),
+		},
+		"no core API": {
+			expErr: errors.New("core API is not available"),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := NewDevModule(nil, nil, tt.coreAPI, nil, nil, nil, nil, nil)
+			res := DevBlockStatsResponse{}
+			err := m.GetBlockStats(nil, &DevBlockStatsRequest{Hash: blockHash}, &res)
+			if tt.expErr != nil {
+				assert.EqualError(t, err, tt.expErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.exp, res)
+		})
+	}
+}
+
+func TestDevModule_GetEvents(t *testing.T) {
+	blockHash := common.Hash{1}
+	encodedMetadata, err := scale.Marshal(common.MustHexToBytes(testMetadataV15))
+	require.NoError(t, err)
+	encodedEvents := []byte{
+		1 << 2,
+		// phase ApplyExtrinsic(0)
+		0, 0, 0, 0, 0,
+		// event System Remarked { sender: 7 }
+		0, 1, 7, 0, 0, 0,
+		// topics
+		0,
+	}
+
+	tests := map[string]struct {
+		coreAPIBuilder    func(ctrl *gomock.Controller) CoreAPI
+		storageAPIBuilder func(ctrl *gomock.Controller) StorageAPI
+		expErr            error
+		exp               DevEventsResponse
+	}{
+		"OK": {
+			coreAPIBuilder: func(ctrl *gomock.Controller) CoreAPI {
+				mockCoreAPI := mocks.NewMockCoreAPI(ctrl)
+				mockCoreAPI.EXPECT().GetMetadata(&blockHash).Return(encodedMetadata, nil)
+				mockCoreAPI.EXPECT().CachedBlockEvents(blockHash).Return(nil, false)
+				return mockCoreAPI
+			},
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+				mockStorageAPI := mocks.NewMockStorageAPI(ctrl)
+				mockStorageAPI.EXPECT().GetStorageByBlockHash(&blockHash, common.SystemEventsKey).
+					Return(encodedEvents, nil)
+				return mockStorageAPI
+			},
+			exp: DevEventsResponse{
+				At: blockHash,
+				Events: []scale.EventRecord{{
+					Phase:  scale.Variant{Name: "ApplyExtrinsic", Index: 0, Value: uint32(0)},
+					Pallet: "System",
+					Name:   "Remarked",
+					Fields: map[string]any{"sender": uint32(7)},
+					Topics: []any{},
+				}},
+			},
+		},
+		"cached events": {
+			coreAPIBuilder: func(ctrl *gomock.Controller) CoreAPI {
+				mockCoreAPI := mocks.NewMockCoreAPI(ctrl)
+				mockCoreAPI.EXPECT().GetMetadata(&blockHash).Return(encodedMetadata, nil)
+				mockCoreAPI.EXPECT().CachedBlockEvents(blockHash).Return(encodedEvents, true)
+				return mockCoreAPI
+			},
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+				return mocks.NewMockStorageAPI(ctrl)
+			},
+			exp: DevEventsResponse{
+				At: blockHash,
+				Events: []scale.EventRecord{{
+					Phase:  scale.Variant{Name: "ApplyExtrinsic", Index: 0, Value: uint32(0)},
+					Pallet: "System",
+					Name:   "Remarked",
+					Fields: map[string]any{"sender": uint32(7)},
+					Topics: []any{},
+				}},
+			},
+		},
+		"no events": {
+			coreAPIBuilder: func(ctrl *gomock.Controller) CoreAPI {
+				mockCoreAPI := mocks.NewMockCoreAPI(ctrl)
+				mockCoreAPI.EXPECT().GetMetadata(&blockHash).Return(encodedMetadata, nil)
+				mockCoreAPI.EXPECT().CachedBlockEvents(blockHash).Return(nil, false)
+				return mockCoreAPI
+			},
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+				mockStorageAPI := mocks.NewMockStorageAPI(ctrl)
+				mockStorageAPI.EXPECT().GetStorageByBlockHash(&blockHash, common.SystemEventsKey).
+					Return(nil, nil)
+				return mockStorageAPI
+			},
+			exp: DevEventsResponse{
+				At:     blockHash,
+				Events: []scale.EventRecord{},
+			},
+		},
+		"GetMetadata Error": {
+			coreAPIBuilder: func(ctrl *gomock.Controller) CoreAPI {
+				mockCoreAPI := mocks.NewMockCoreAPI(ctrl)
+				mockCoreAPI.EXPECT().GetMetadata(&blockHash).Return(nil, errors.New("GetMetadata Error"))
+				return mockCoreAPI
+			},
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+				return mocks.NewMockStorageAPI(ctrl)
+			},
+			expErr: errors.New("getting metadata: GetMetadata Error"),
+		},
+		"GetStorageByBlockHash Error": {
+			coreAPIBuilder: func(ctrl *gomock.Controller) CoreAPI {
+				mockCoreAPI := mocks.NewMockCoreAPI(ctrl)
+				mockCoreAPI.EXPECT().GetMetadata(&blockHash).Return(encodedMetadata, nil)
+				mockCoreAPI.EXPECT().CachedBlockEvents(blockHash).Return(nil, false)
+				return mockCoreAPI
+			},
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+				mockStorageAPI := mocks.NewMockStorageAPI(ctrl)
+				mockStorageAPI.EXPECT().GetStorageByBlockHash(&blockHash, common.SystemEventsKey).
+					Return(nil, errors.New("GetStorageByBlockHash Error"))
+				return mockStorageAPI
+			},
+			expErr: errors.New("getting events: GetStorageByBlockHash Error"),
+		},
+		"no core API": {
+			coreAPIBuilder: func(ctrl *gomock.Controller) CoreAPI {
+				return nil
+			},
+			storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+				return mocks.NewMockStorageAPI(ctrl)
+			},
+			expErr: errors.New("core or storage API is not available"),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := NewDevModule(nil, nil, tt.coreAPIBuilder(ctrl), tt.storageAPIBuilder(ctrl), nil, nil, nil, nil)
+			res := DevEventsResponse{}
+			err := m.GetEvents(nil, &DevEventsRequest{Hash: blockHash}, &res)
+			if tt.expErr != nil {
+				assert.EqualError(t, err, tt.expErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.exp, res)
+		})
+	}
+}
+
+func TestDevModule_CompactDatabase(t *testing.T) {
+	tests := map[string]struct {
+		databaseAPIBuilder func(ctrl *gomock.Controller) DatabaseAPI
+		exp                string
+		expErr             error
+	}{
+		"happy path": {
+			databaseAPIBuilder: func(ctrl *gomock.Controller) DatabaseAPI {
+				mockDatabaseAPI := NewMockDatabaseAPI(ctrl)
+				mockDatabaseAPI.EXPECT().Compact().Return(nil)
+				return mockDatabaseAPI
+			},
+			exp: databaseCompactedMsg,
+		},
+		"Compact Error": {
+			databaseAPIBuilder: func(ctrl *gomock.Controller) DatabaseAPI {
+				mockDatabaseAPI := NewMockDatabaseAPI(ctrl)
+				mockDatabaseAPI.EXPECT().Compact().Return(errors.New("Compact Error"))
+				return mockDatabaseAPI
+			},
+			expErr: errors.New("Compact Error"),
+		},
+		"no database API": {
+			databaseAPIBuilder: func(ctrl *gomock.Controller) DatabaseAPI {
+				return nil
+			},
+			expErr: errors.New("database API is not available"),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := NewDevModule(nil, nil, nil, nil, tt.databaseAPIBuilder(ctrl), nil, nil, nil)
+			var res string
+			err := m.CompactDatabase(nil, &EmptyRequest{}, &res)
+			if tt.expErr != nil {
+				assert.EqualError(t, err, tt.expErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.exp, res)
+		})
+	}
+}
+
+func TestDevModule_SetNodeRole(t *testing.T) {
+	tests := map[string]struct {
+		roleAPIBuilder func(ctrl *gomock.Controller) RoleAPI
+		role           string
+		exp            string
+		expErr         error
+	}{
+		"authority": {
+			roleAPIBuilder: func(ctrl *gomock.Controller) RoleAPI {
+				mockRoleAPI := NewMockRoleAPI(ctrl)
+				mockRoleAPI.EXPECT().SetAuthoring(true).Return(nil)
+				return mockRoleAPI
+			},
+			role: "authority",
+			exp:  "node role set to authority",
+		},
+		"full": {
+			roleAPIBuilder: func(ctrl *gomock.Controller) RoleAPI {
+				mockRoleAPI := NewMockRoleAPI(ctrl)
+				mockRoleAPI.EXPECT().SetAuthoring(false).Return(nil)
+				return mockRoleAPI
+			},
+			role: "full",
+			exp:  "node role set to full",
+		},
+		"SetAuthoring Error": {
+			roleAPIBuilder: func(ctrl *gomock.Controller) RoleAPI {
+				mockRoleAPI := NewMockRoleAPI(ctrl)
+				mockRoleAPI.EXPECT().SetAuthoring(true).Return(errors.New("SetAuthoring Error"))
+				return mockRoleAPI
+			},
+			role:   "authority",
+			expErr: errors.New("SetAuthoring Error"),
+		},
+		"invalid role": {
+			roleAPIBuilder: func(ctrl *gomock.Controller) RoleAPI {
+				return NewMockRoleAPI(ctrl)
+			},
+			role:   "light",
+			expErr: errors.New(`invalid node role: "light", expected "authority" or "full"`),
+		},
+		"no role API": {
+			roleAPIBuilder: func(ctrl *gomock.Controller) RoleAPI {
+				return nil
+			},
+			role:   "authority",
+			expErr: errors.New("role API is not available"),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := NewDevModule(nil, nil, nil, nil, nil, tt.roleAPIBuilder(ctrl), nil, nil)
+			var res string
+			err := m.SetNodeRole(nil, &DevNodeRoleRequest{Role: tt.role}, &res)
+			if tt.expErr != nil {
+				assert.EqualError(t, err, tt.expErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.exp, res)
+		})
+	}
+}
+
+func TestDevModule_NodeRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRoleAPI := NewMockRoleAPI(ctrl)
+	m := NewDevModule(nil, nil, nil, nil, nil, mockRoleAPI, nil, nil)
+
+	var res string
+	mockRoleAPI.EXPECT().IsAuthoring().Return(true)
+	err := m.NodeRole(nil, &EmptyRequest{}, &res)
+	require.NoError(t, err)
+	assert.Equal(t, "authority", res)
+
+	mockRoleAPI.EXPECT().IsAuthoring().Return(false)
+	err = m.NodeRole(nil, &EmptyRequest{}, &res)
+	require.NoError(t, err)
+	assert.Equal(t, "full", res)
+}
+
+func TestDevModule_EpochRandomness(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	blockAPI := NewMockBlockAPI(ctrl)
+	epochAPI := NewMockEpochAPI(ctrl)
+	m := NewDevModule(nil, nil, nil, nil, nil, nil, blockAPI, epochAPI)
+
+	bestHeader := &types.Header{Number: 10, Digest: types.NewDigest()}
+	blockAPI.EXPECT().BestBlockHash().Return(common.Hash{1}).Times(2)
+	blockAPI.EXPECT().GetHeader(common.Hash{1}).Return(bestHeader, nil).Times(2)
+	epochAPI.EXPECT().GetCurrentEpoch().Return(uint64(3), nil).Times(2)
+	epochAPI.EXPECT().GetSlotDuration().Return(6*time.Second, nil).Times(2)
+	epochAPI.EXPECT().GetEpochDataRaw(uint64(3), bestHeader).
+		Return(&types.EpochDataRaw{Randomness: [32]byte{3}}, nil)
+	epochAPI.EXPECT().GetStartSlotForEpoch(uint64(3), common.Hash{1}).Return(uint64(300), nil)
+	epochAPI.EXPECT().GetEpochDataRaw(uint64(1), bestHeader).
+		Return(&types.EpochDataRaw{Randomness: [32]byte{1}}, nil)
+	epochAPI.EXPECT().GetStartSlotForEpoch(uint64(1), common.Hash{1}).Return(uint64(100), nil)
+
+	before := uint64(time.Now().Unix() / 6)
+	var res DevEpochRandomnessResponse
+	err := m.EpochRandomness(nil, &DevEpochRandomnessRequest{}, &res)
+	require.NoError(t, err)
+	after := uint64(time.Now().Unix() / 6)
+	assert.Equal(t, uint64(3), res.Epoch)
+	assert.Equal(t, uint64(300), res.StartSlot)
+	assert.Equal(t, "0x0300000000000000000000000000000000000000000000000000000000000000", res.Randomness)
+	assert.Equal(t, uint64(3), res.CurrentEpoch)
+	assert.GreaterOrEqual(t, res.CurrentSlot, before)
+	assert.LessOrEqual(t, res.CurrentSlot, after)
+
+	epoch := uint64(1)
+	err = m.EpochRandomness(nil, &DevEpochRandomnessRequest{Epoch: &epoch}, &res)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), res.Epoch)
+	assert.Equal(t, uint64(100), res.StartSlot)
+	assert.Equal(t, "0x0100000000000000000000000000000000000000000000000000000000000000", res.Randomness)
+	assert.Equal(t, uint64(3), res.CurrentEpoch)
+
+	m = NewDevModule(nil, nil, nil, nil, nil, nil, nil, nil)
+	err = m.EpochRandomness(nil, &DevEpochRandomnessRequest{}, &res)
+	assert.EqualError(t, err, "epoch or block API is not available")
+}
+
+func TestDevModule_BlockVRF(t *testing.T) {
+	newHeader := func(t *testing.T, preDigest interface {
+		ToPreRuntimeDigest() (*types.PreRuntimeDigest, error)
+	}) *types.Header {
+		digest := types.NewDigest()
+		if preDigest != nil {
+			preRuntimeDigest, err := preDigest.ToPreRuntimeDigest()
+			require.NoError(t, err)
+			err = digest.Add(*preRuntimeDigest)
+			require.NoError(t, err)
+		}
+		return &types.Header{Number: 5, Digest: digest}
+	}
+
+	tests := map[string]struct {
+		preDigest interface {
+			ToPreRuntimeDigest() (*types.PreRuntimeDigest, error)
+		}
+		exp    DevBlockVRFResponse
+		expErr error
+	}{
+		"primary": {
+			preDigest: types.NewBabePrimaryPreDigest(2, 51, [32]byte{1}, [64]byte{2}),
+			exp: DevBlockVRFResponse{
+				At:             common.Hash{5},
+				Epoch:          4,
+				Slot:           51,
+				AuthorityIndex: 2,
+				SlotClaim:      "primary",
+				VRFOutput:      common.BytesToHex(append([]byte{1}, make([]byte, 31)...)),
+				VRFProof:       common.BytesToHex(append([]byte{2}, make([]byte, 63)...)),
+			},
+		},
+		"secondary VRF": {
+			preDigest: types.NewBabeSecondaryVRFPreDigest(1, 52, [32]byte{3}, [64]byte{4}),
+			exp: DevBlockVRFResponse{
+				At:             common.Hash{5},
+				Epoch:          4,
+				Slot:           52,
+				AuthorityIndex: 1,
+				SlotClaim:      "secondaryVRF",
+				VRFOutput:      common.BytesToHex(append([]byte{3}, make([]byte, 31)...)),
+				VRFProof:       common.BytesToHex(append([]byte{4}, make([]byte, 63)...)),
+			},
+		},
+		"secondary plain": {
+			preDigest: types.NewBabeSecondaryPlainPreDigest(0, 53),
+			exp: DevBlockVRFResponse{
+				At:        common.Hash{5},
+				Epoch:     4,
+				Slot:      53,
+				SlotClaim: "secondaryPlain",
+			},
+		},
+		"no BABE pre-runtime digest": {
+			expErr: errors.New("block has no BABE pre-runtime digest"),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			blockAPI := NewMockBlockAPI(ctrl)
+			epochAPI := NewMockEpochAPI(ctrl)
+			m := NewDevModule(nil, nil, nil, nil, nil, nil, blockAPI, epochAPI)
+
+			header := newHeader(t, tt.preDigest)
+			blockAPI.EXPECT().GetHeader(common.Hash{5}).Return(header, nil)
+			if tt.expErr == nil {
+				epochAPI.EXPECT().GetEpochForBlock(header).Return(uint64(4), nil)
+			}
+
+			var res DevBlockVRFResponse
+			err := m.BlockVRF(nil, &DevBlockVRFRequest{Hash: common.Hash{5}}, &res)
+			if tt.expErr != nil {
+				assert.EqualError(t, err, tt.expErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.exp, res)
+		})
+	}
+}
+
+func Test_registryCache(t *testing.T) {
+	encodedMetadata, err := scale.Marshal(common.MustHexToBytes(testMetadataV15))
+	require.NoError(t, err)
+
+	cache := &registryCache{}
+	registry, err := cache.get(encodedMetadata)
+	require.NoError(t, err)
+
+	cached, err := cache.get(encodedMetadata)
+	require.NoError(t, err)
+	assert.Same(t, registry, cached)
+
+	encodedMetadata, err = scale.Marshal([]byte("meta\x0d"))
+	require.NoError(t, err)
+	_, err = cache.get(encodedMetadata)
+	assert.ErrorIs(t, err, scale.ErrMetadataVersion)
+	assert.Same(t, registry, cache.registry)
+}
@@ -0,0 +1,121 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package modules
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+var (
+	errNotBlockProducer      = errors.New("not a block producer")
+	errParentNotBestBlock    = errors.New("parent is not the best block")
+	errNoPendingTransactions = errors.New("no pending transactions to include in the block")
+)
+
+// EngineCreateBlockRequest holds the parameters of the engine_createBlock call
+type EngineCreateBlockRequest struct {
+	// CreateEmpty allows to create a block without any pending transaction
+	CreateEmpty bool `json:"createEmpty"`
+	// Finalise finalises the created block
+	Finalise bool `json:"finalize"`
+	// ParentHash must be the best block hash if it is set
+	ParentHash *common.Hash `json:"parentHash"`
+}
+
+// EngineCreateBlockResponse holds the hash of the created block
+type EngineCreateBlockResponse struct {
+	Hash common.Hash `json:"hash"`
+}
+
+// EngineFinalizeBlockRequest holds the hash of the block to finalise
+type EngineFinalizeBlockRequest struct {
+	Hash common.Hash `json:"hash"`
+}
+
+// EngineModule is an RPC module to author and finalise blocks on demand,
+// used with the manual seal authoring mode of development nodes.
+type EngineModule struct {
+	blockAPI            BlockAPI
+	blockProducerAPI    BlockProducerAPI
+	blockFinaliserAPI   BlockFinaliserAPI
+	transactionStateAPI TransactionStateAPI
+}
+
+// NewEngineModule creates a new Engine module.
+func NewEngineModule(blockAPI BlockAPI, bp BlockProducerAPI, finaliser BlockFinaliserAPI,
+	txStateAPI TransactionStateAPI) *EngineModule {
+	return &EngineModule{
+		blockAPI:            blockAPI,
+		blockProducerAPI:    bp,
+		blockFinaliserAPI:   finaliser,
+		transactionStateAPI: txStateAPI,
+	}
+}
+
+// CreateBlock seals a new block on top of the best block with the pending transactions
+// and optionally finalises it. It returns the hash of the block created.
+func (em *EngineModule) CreateBlock(_ *http.Request, req *EngineCreateBlockRequest,
+	res *EngineCreateBlockResponse) error {
+	if em.blockProducerAPI == nil {
+		return errNotBlockProducer
+	}
+
+	if req.ParentHash != nil {
+		bestBlockHash := em.blockAPI.BestBlockHash()
+		if *req.ParentHash != bestBlockHash {
+			return fmt.Errorf("%w: parent %s, best block %s", errParentNotBestBlock, *req.ParentHash, bestBlockHash)
+		}
+	}
+
+	if !req.CreateEmpty && len(em.transactionStateAPI.Pending()) == 0 {
+		return errNoPendingTransactions
+	}
+
+	block, err := em.blockProducerAPI.SealBlock()
+	if err != nil {
+		return fmt.Errorf("sealing block: %w", err)
+	}
+	blockHash := block.Header.Hash()
+
+	if req.Finalise {
+		err = em.finalise(blockHash)
+		if err != nil {
+			return err
+		}
+	}
+
+	*res = EngineCreateBlockResponse{Hash: blockHash}
+	return nil
+}
+
+// FinalizeBlock finalises the block with the hash given and its ancestors.
+func (em *EngineModule) FinalizeBlock(_ *http.Request, req *EngineFinalizeBlockRequest, res *bool) error {
+	err := em.finalise(req.Hash)
+	if err != nil {
+		return err
+	}
+
+	*res = true
+	return nil
+}
+
+// finalise finalises the block at the round following the highest finalised round,
+// so the finalised block notifications are sent to the subscribers.
+func (em *EngineModule) finalise(blockHash common.Hash) error {
+	round, setID, err := em.blockFinaliserAPI.GetHighestRoundAndSetID()
+	if err != nil {
+		return fmt.Errorf("getting highest round and set id: %w", err)
+	}
+
+	err = em.blockFinaliserAPI.SetFinalisedHash(blockHash, round+1, setID)
+	if err != nil {
+		return fmt.Errorf("finalising block %s: %w", blockHash, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package modules
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/rpc/modules/mocks"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/transaction"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestEngineModule_CreateBlock(t *testing.T) {
+	t.Parallel()
+
+	block := &types.Block{Header: types.Header{Number: 1}}
+	blockHash := block.Header.Hash()
+	bestBlockHash := common.Hash{1}
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		engineModuleBuilder func(ctrl *gomock.Controller) *EngineModule
+		request             *EngineCreateBlockRequest
+		expectedResponse    EngineCreateBlockResponse
+		errWrapped          error
+		errMessage          string
+	}{
+		"not_block_producer": {
+			engineModuleBuilder: func(ctrl *gomock.Controller) *EngineModule {
+				return NewEngineModule(nil, nil, nil, nil)
+			},
+			request:    &EngineCreateBlockRequest{},
+			errWrapped: errNotBlockProducer,
+			errMessage: "not a block producer",
+		},
+		"parent_not_best_block": {
+			engineModuleBuilder: func(ctrl *gomock.Controller) *EngineModule {
+				blockAPI := mocks.NewMockBlockAPI(ctrl)
+				blockAPI.EXPECT().BestBlockHash().Return(bestBlockHash)
+				return NewEngineModule(blockAPI, mocks.NewMockBlockProducerAPI(ctrl), nil, nil)
+			},
+			request:    &EngineCreateBlockRequest{ParentHash: &common.Hash{2}},
+			errWrapped: errParentNotBestBlock,
+			errMessage: "parent is not the best block: " +
+				"parent 0x0200000000000000000000000000000000000000000000000000000000000000, " +
+				"best block 0x0100000000000000000000000000000000000000000000000000000000000000",
+		},
+		"no_pending_transactions": {
+			engineModuleBuilder: func(ctrl *gomock.Controller) *EngineModule {
+				transactionStateAPI := mocks.NewMockTransactionStateAPI(ctrl)
+				transactionStateAPI.EXPECT().Pending().Return(nil)
+				return NewEngineModule(nil, mocks.NewMockBlockProducerAPI(ctrl), nil, transactionStateAPI)
+			},
+			request:    &EngineCreateBlockRequest{},
+			errWrapped: errNoPendingTransactions,
+			errMessage: "no pending transactions to include in the block",
+		},
+		"seal_block_error": {
+			engineModuleBuilder: func(ctrl *gomock.Controller) *EngineModule {
+				blockProducerAPI := mocks.NewMockBlockProducerAPI(ctrl)
+				blockProducerAPI.EXPECT().SealBlock().Return(nil, errTest)
+				return NewEngineModule(nil, blockProducerAPI, nil, nil)
+			},
+			request:    &EngineCreateBlockRequest{CreateEmpty: true},
+			errWrapped: errTest,
+			errMessage: "sealing block: test error",
+		},
+		"pending_transactions": {
+			engineModuleBuilder: func(ctrl *gomock.Controller) *EngineModule {
+				blockAPI := mocks.NewMockBlockAPI(ctrl)
+				blockAPI.EXPECT().BestBlockHash().Return(bestBlockHash)
+				transactionStateAPI := mocks.NewMockTransactionStateAPI(ctrl)
+				transactionStateAPI.EXPECT().Pending().Return([]*transaction.ValidTransaction{{}})
+				blockProducerAPI := mocks.NewMockBlockProducerAPI(ctrl)
+				blockProducerAPI.EXPECT().SealBlock().Return(block, nil)
+				return NewEngineModule(blockAPI, blockProducerAPI, nil, transactionStateAPI)
+			},
+			request:          &EngineCreateBlockRequest{ParentHash: &bestBlockHash},
+			expectedResponse: EngineCreateBlockResponse{Hash: blockHash},
+		},
+		"finalise_error": {
+			engineModuleBuilder: func(ctrl *gomock.Controller) *EngineModule {
+				blockProducerAPI := mocks.NewMockBlockProducerAPI(ctrl)
+				blockProducerAPI.EXPECT().SealBlock().Return(block, nil)
+				blockFinaliserAPI := mocks.NewMockBlockFinaliserAPI(ctrl)
+				blockFinaliserAPI.EXPECT().GetHighestRoundAndSetID().Return(uint64(0), uint64(0), errTest)
+				return NewEngineModule(nil, blockProducerAPI, blockFinaliserAPI, nil)
+			},
+			request:    &EngineCreateBlockRequest{CreateEmpty: true, Finalise: true},
+			errWrapped: errTest,
+			errMessage: "getting highest round and set id: test error",
+		},
+		"create_empty_and_finalise": {
+			engineModuleBuilder: func(ctrl *gomock.Controller) *EngineModule {
+				blockProducerAPI := mocks.NewMockBlockProducerAPI(ctrl)
+				blockProducerAPI.EXPECT().SealBlock().Return(block, nil)
+				blockFinaliserAPI := mocks.NewMockBlockFinaliserAPI(ctrl)
+				blockFinaliserAPI.EXPECT().GetHighestRoundAndSetID().Return(uint64(3), uint64(1), nil)
+				blockFinaliserAPI.EXPECT().SetFinalisedHash(blockHash, uint64(4), uint64(1)).Return(nil)
+				return NewEngineModule(nil, blockProducerAPI, blockFinaliserAPI, nil)
+			},
+			request:          &EngineCreateBlockRequest{CreateEmpty: true, Finalise: true},
+			expectedResponse: EngineCreateBlockResponse{Hash: blockHash},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			engineModule := testCase.engineModuleBuilder(ctrl)
+
+			var response EngineCreateBlockResponse
+			err := engineModule.CreateBlock(nil, testCase.request, &response)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.expectedResponse, response)
+		})
+	}
+}
+
+func TestEngineModule_FinalizeBlock(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+
+	blockHash := common.Hash{1}
+	errTest := errors.New("test error")
+
+	blockFinaliserAPI := mocks.NewMockBlockFinaliserAPI(ctrl)
+	blockFinaliserAPI.EXPECT().GetHighestRoundAndSetID().Return(uint64(0), uint64(0), nil).Times(2)
+	blockFinaliserAPI.EXPECT().SetFinalisedHash(blockHash, uint64(1), uint64(0)).Return(errTest)
+	blockFinaliserAPI.EXPECT().SetFinalisedHash(blockHash, uint64(1), uint64(0)).Return(nil)
+	engineModule := NewEngineModule(nil, nil, blockFinaliserAPI, nil)
+
+	var response bool
+	err := engineModule.FinalizeBlock(nil, &EngineFinalizeBlockRequest{Hash: blockHash}, &response)
+	assert.ErrorIs(t, err, errTest)
+	assert.False(t, response)
+
+	err = engineModule.FinalizeBlock(nil, &EngineFinalizeBlockRequest{Hash: blockHash}, &response)
+	assert.NoError(t, err)
+	assert.True(t, response)
+}
@@ -8,4 +8,6 @@
 var (
 	ErrSubscriptionTransport = errors.New("subscriptions are not available on this transport")
 	ErrStartBlockHashEmpty   = errors.New("the start block hash cannot be an empty value")
+	ErrInvalidBlockRange     = errors.New("the start block number cannot be greater than the end block number")
+	ErrBlockRangeTooLarge    = errors.New("block range too large")
 )
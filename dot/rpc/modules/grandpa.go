@@ -55,6 +55,11 @@ type ProveFinalityRequest struct {
 // ProveFinalityResponse is an optional SCALE encoded proof array
 type ProveFinalityResponse []string
 
+// PauseVotingRequest request struct
+type PauseVotingRequest struct {
+	Paused bool
+}
+
 // ProveFinality for the provided block number, the Justification for the last block in the set is written to the
 // response. The response is a SCALE encoded proof array.  The proof array is empty if the block number is
 // not finalized.
@@ -126,6 +131,17 @@ func (gm *GrandpaModule) RoundState(r *http.Request, req *EmptyRequest, res *Rou
 	return nil
 }
 
+// PauseVoting pauses the GRANDPA voting of the node if paused is true and resumes it otherwise.
+// While paused the node does not vote, and the rounds are finalised by the other voters only.
+func (gm *GrandpaModule) PauseVoting(r *http.Request, req *PauseVotingRequest, res *[]byte) error {
+	if req.Paused {
+		gm.blockFinalityAPI.PauseVoting()
+	} else {
+		gm.blockFinalityAPI.ResumeVoting()
+	}
+	return nil
+}
+
 func thresholdWeight(totalWeight uint32) uint32 {
 	return totalWeight * 2 / 3
 }
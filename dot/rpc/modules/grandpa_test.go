@@ -214,3 +214,18 @@ type args struct {
 		})
 	}
 }
+
+func TestGrandpaModule_PauseVoting(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mockBlockFinalityAPI := mocks.NewMockBlockFinalityAPI(ctrl)
+	gm := NewGrandpaModule(nil, mockBlockFinalityAPI)
+
+	mockBlockFinalityAPI.EXPECT().PauseVoting()
+	err := gm.PauseVoting(nil, &PauseVotingRequest{Paused: true}, nil)
+	assert.NoError(t, err)
+
+	mockBlockFinalityAPI.EXPECT().ResumeVoting()
+	err = gm.PauseVoting(nil, &PauseVotingRequest{Paused: false}, nil)
+	assert.NoError(t, err)
+}
@@ -9,6 +9,7 @@
 
 	network "github.com/ChainSafe/gossamer/dot/network"
 	peerset "github.com/ChainSafe/gossamer/dot/peerset"
+	common "github.com/ChainSafe/gossamer/lib/common"
 	gomock "go.uber.org/mock/gomock"
 	peer "github.com/libp2p/go-libp2p/core/peer"
 )
@@ -62,6 +63,22 @@ func (mr *MockNetworkMockRecorder) IsSynced() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSynced", reflect.TypeOf((*MockNetwork)(nil).IsSynced))
 }
 
+// ReadRemoteStorage mocks base method.
+func (m *MockNetwork) ReadRemoteStorage(arg0, arg1 common.Hash, arg2 []byte) ([]byte, [][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadRemoteStorage", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].([][]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReadRemoteStorage indicates an expected call of ReadRemoteStorage.
+func (mr *MockNetworkMockRecorder) ReadRemoteStorage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadRemoteStorage", reflect.TypeOf((*MockNetwork)(nil).ReadRemoteStorage), arg0, arg1, arg2)
+}
+
 // ReportPeer mocks base method.
 func (m *MockNetwork) ReportPeer(arg0 peerset.ReputationChange, arg1 peer.ID) {
 	m.ctrl.T.Helper()
@@ -12,6 +12,7 @@
 import (
 	reflect "reflect"
 
+	common "github.com/ChainSafe/gossamer/lib/common"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -51,3 +52,17 @@ func (mr *MockSyncAPIMockRecorder) HighestBlock() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HighestBlock", reflect.TypeOf((*MockSyncAPI)(nil).HighestBlock))
 }
+
+// SyncProgress mocks base method.
+func (m *MockSyncAPI) SyncProgress() common.SyncProgress {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncProgress")
+	ret0, _ := ret[0].(common.SyncProgress)
+	return ret0
+}
+
+// SyncProgress indicates an expected call of SyncProgress.
+func (mr *MockSyncAPIMockRecorder) SyncProgress() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncProgress", reflect.TypeOf((*MockSyncAPI)(nil).SyncProgress))
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/ChainSafe/gossamer/dot/rpc/modules (interfaces: StorageAPI,BlockAPI,NetworkAPI,BlockProducerAPI,TransactionStateAPI,CoreAPI,SystemAPI,BlockFinalityAPI,RuntimeStorageAPI,SyncStateAPI)
+// Source: github.com/ChainSafe/gossamer/dot/rpc/modules (interfaces: StorageAPI,BlockAPI,NetworkAPI,BlockProducerAPI,TransactionStateAPI,CoreAPI,SystemAPI,BlockFinalityAPI,RuntimeStorageAPI,SyncStateAPI,LightSyncStateAPI,BlockFinaliserAPI)
 //
 // Generated by this command:
 //
-//	mockgen -destination=mocks/mocks.go -package mocks . StorageAPI,BlockAPI,NetworkAPI,BlockProducerAPI,TransactionStateAPI,CoreAPI,SystemAPI,BlockFinalityAPI,RuntimeStorageAPI,SyncStateAPI
+//	mockgen -destination=mocks/mocks.go -package mocks . StorageAPI,BlockAPI,NetworkAPI,BlockProducerAPI,TransactionStateAPI,CoreAPI,SystemAPI,BlockFinalityAPI,RuntimeStorageAPI,SyncStateAPI,LightSyncStateAPI,BlockFinaliserAPI
 //
 
 // Package mocks is a generated GoMock package.
@@ -62,6 +62,37 @@ func (mr *MockStorageAPIMockRecorder) Entries(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Entries", reflect.TypeOf((*MockStorageAPI)(nil).Entries), arg0)
 }
 
+// GetChangedBlocks mocks base method.
+func (m *MockStorageAPI) GetChangedBlocks(arg0 []byte, arg1 uint, arg2 uint) ([]common.Hash, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChangedBlocks", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]common.Hash)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetChangedBlocks indicates an expected call of GetChangedBlocks.
+func (mr *MockStorageAPIMockRecorder) GetChangedBlocks(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangedBlocks", reflect.TypeOf((*MockStorageAPI)(nil).GetChangedBlocks), arg0, arg1, arg2)
+}
+
+// GetKeysPaged mocks base method.
+func (m *MockStorageAPI) GetKeysPaged(arg0 *common.Hash, arg1, arg2 []byte, arg3 uint32) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeysPaged", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKeysPaged indicates an expected call of GetKeysPaged.
+func (mr *MockStorageAPIMockRecorder) GetKeysPaged(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeysPaged", reflect.TypeOf((*MockStorageAPI)(nil).GetKeysPaged), arg0, arg1, arg2, arg3)
+}
+
 // GetKeysWithPrefix mocks base method.
 func (m *MockStorageAPI) GetKeysWithPrefix(arg0 *common.Hash, arg1 []byte) ([][]byte, error) {
 	m.ctrl.T.Helper()
@@ -498,6 +529,20 @@ func (mr *MockNetworkAPIMockRecorder) NetworkState() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkState", reflect.TypeOf((*MockNetworkAPI)(nil).NetworkState))
 }
 
+// NetworkTraffic mocks base method.
+func (m *MockNetworkAPI) NetworkTraffic() common.NetworkTraffic {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetworkTraffic")
+	ret0, _ := ret[0].(common.NetworkTraffic)
+	return ret0
+}
+
+// NetworkTraffic indicates an expected call of NetworkTraffic.
+func (mr *MockNetworkAPIMockRecorder) NetworkTraffic() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkTraffic", reflect.TypeOf((*MockNetworkAPI)(nil).NetworkTraffic))
+}
+
 // NodeRoles mocks base method.
 func (m *MockNetworkAPI) NodeRoles() common.NetworkRole {
 	m.ctrl.T.Helper()
@@ -651,6 +696,21 @@ func (mr *MockBlockProducerAPIMockRecorder) Resume() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resume", reflect.TypeOf((*MockBlockProducerAPI)(nil).Resume))
 }
 
+// SealBlock mocks base method.
+func (m *MockBlockProducerAPI) SealBlock() (*types.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SealBlock")
+	ret0, _ := ret[0].(*types.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SealBlock indicates an expected call of SealBlock.
+func (mr *MockBlockProducerAPIMockRecorder) SealBlock() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SealBlock", reflect.TypeOf((*MockBlockProducerAPI)(nil).SealBlock))
+}
+
 // SlotDuration mocks base method.
 func (m *MockBlockProducerAPI) SlotDuration() uint64 {
 	m.ctrl.T.Helper()
@@ -688,6 +748,18 @@ func (m *MockTransactionStateAPI) EXPECT() *MockTransactionStateAPIMockRecorder
 	return m.recorder
 }
 
+// BanExtrinsic mocks base method.
+func (m *MockTransactionStateAPI) BanExtrinsic(arg0 types.Extrinsic) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "BanExtrinsic", arg0)
+}
+
+// BanExtrinsic indicates an expected call of BanExtrinsic.
+func (mr *MockTransactionStateAPIMockRecorder) BanExtrinsic(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BanExtrinsic", reflect.TypeOf((*MockTransactionStateAPI)(nil).BanExtrinsic), arg0)
+}
+
 // Pending mocks base method.
 func (m *MockTransactionStateAPI) Pending() []*transaction.ValidTransaction {
 	m.ctrl.T.Helper()
@@ -702,6 +774,46 @@ func (mr *MockTransactionStateAPIMockRecorder) Pending() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pending", reflect.TypeOf((*MockTransactionStateAPI)(nil).Pending))
 }
 
+// PendingInPool mocks base method.
+func (m *MockTransactionStateAPI) PendingInPool() []*transaction.ValidTransaction {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingInPool")
+	ret0, _ := ret[0].([]*transaction.ValidTransaction)
+	return ret0
+}
+
+// PendingInPool indicates an expected call of PendingInPool.
+func (mr *MockTransactionStateAPIMockRecorder) PendingInPool() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingInPool", reflect.TypeOf((*MockTransactionStateAPI)(nil).PendingInPool))
+}
+
+// PendingInQueue mocks base method.
+func (m *MockTransactionStateAPI) PendingInQueue() []*transaction.ValidTransaction {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingInQueue")
+	ret0, _ := ret[0].([]*transaction.ValidTransaction)
+	return ret0
+}
+
+// PendingInQueue indicates an expected call of PendingInQueue.
+func (mr *MockTransactionStateAPIMockRecorder) PendingInQueue() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingInQueue", reflect.TypeOf((*MockTransactionStateAPI)(nil).PendingInQueue))
+}
+
+// RemoveExtrinsic mocks base method.
+func (m *MockTransactionStateAPI) RemoveExtrinsic(arg0 types.Extrinsic) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemoveExtrinsic", arg0)
+}
+
+// RemoveExtrinsic indicates an expected call of RemoveExtrinsic.
+func (mr *MockTransactionStateAPIMockRecorder) RemoveExtrinsic(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveExtrinsic", reflect.TypeOf((*MockTransactionStateAPI)(nil).RemoveExtrinsic), arg0)
+}
+
 // MockCoreAPI is a mock of CoreAPI interface.
 type MockCoreAPI struct {
 	ctrl     *gomock.Controller
@@ -725,6 +837,21 @@ func (m *MockCoreAPI) EXPECT() *MockCoreAPIMockRecorder {
 	return m.recorder
 }
 
+// CachedBlockEvents mocks base method.
+func (m *MockCoreAPI) CachedBlockEvents(arg0 common.Hash) ([]byte, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CachedBlockEvents", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// CachedBlockEvents indicates an expected call of CachedBlockEvents.
+func (mr *MockCoreAPIMockRecorder) CachedBlockEvents(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CachedBlockEvents", reflect.TypeOf((*MockCoreAPI)(nil).CachedBlockEvents), arg0)
+}
+
 // DecodeSessionKeys mocks base method.
 func (m *MockCoreAPI) DecodeSessionKeys(arg0 []byte) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -740,6 +867,37 @@ func (mr *MockCoreAPIMockRecorder) DecodeSessionKeys(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecodeSessionKeys", reflect.TypeOf((*MockCoreAPI)(nil).DecodeSessionKeys), arg0)
 }
 
+// GetBlockStats mocks base method.
+func (m *MockCoreAPI) GetBlockStats(arg0 common.Hash) (*core.BlockStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockStats", arg0)
+	ret0, _ := ret[0].(*core.BlockStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockStats indicates an expected call of GetBlockStats.
+func (mr *MockCoreAPIMockRecorder) GetBlockStats(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockStats", reflect.TypeOf((*MockCoreAPI)(nil).GetBlockStats), arg0)
+}
+
+// GetBlockWitness mocks base method.
+func (m *MockCoreAPI) GetBlockWitness(arg0 common.Hash) (common.Hash, [][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockWitness", arg0)
+	ret0, _ := ret[0].(common.Hash)
+	ret1, _ := ret[1].([][]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetBlockWitness indicates an expected call of GetBlockWitness.
+func (mr *MockCoreAPIMockRecorder) GetBlockWitness(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockWitness", reflect.TypeOf((*MockCoreAPI)(nil).GetBlockWitness), arg0)
+}
+
 // GetMetadata mocks base method.
 func (m *MockCoreAPI) GetMetadata(arg0 *common.Hash) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -755,6 +913,21 @@ func (mr *MockCoreAPIMockRecorder) GetMetadata(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetadata", reflect.TypeOf((*MockCoreAPI)(nil).GetMetadata), arg0)
 }
 
+// GetMetadataAtVersion mocks base method.
+func (m *MockCoreAPI) GetMetadataAtVersion(arg0 *common.Hash, arg1 uint32) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetadataAtVersion", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMetadataAtVersion indicates an expected call of GetMetadataAtVersion.
+func (mr *MockCoreAPIMockRecorder) GetMetadataAtVersion(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetadataAtVersion", reflect.TypeOf((*MockCoreAPI)(nil).GetMetadataAtVersion), arg0, arg1)
+}
+
 // GetReadProofAt mocks base method.
 func (m *MockCoreAPI) GetReadProofAt(arg0 common.Hash, arg1 [][]byte) (common.Hash, [][]byte, error) {
 	m.ctrl.T.Helper()
@@ -987,6 +1160,18 @@ func (mr *MockBlockFinalityAPIMockRecorder) GetVoters() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVoters", reflect.TypeOf((*MockBlockFinalityAPI)(nil).GetVoters))
 }
 
+// PauseVoting mocks base method.
+func (m *MockBlockFinalityAPI) PauseVoting() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PauseVoting")
+}
+
+// PauseVoting indicates an expected call of PauseVoting.
+func (mr *MockBlockFinalityAPIMockRecorder) PauseVoting() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseVoting", reflect.TypeOf((*MockBlockFinalityAPI)(nil).PauseVoting))
+}
+
 // PreCommits mocks base method.
 func (m *MockBlockFinalityAPI) PreCommits() []ed25519.PublicKeyBytes {
 	m.ctrl.T.Helper()
@@ -1015,6 +1200,18 @@ func (mr *MockBlockFinalityAPIMockRecorder) PreVotes() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreVotes", reflect.TypeOf((*MockBlockFinalityAPI)(nil).PreVotes))
 }
 
+// ResumeVoting mocks base method.
+func (m *MockBlockFinalityAPI) ResumeVoting() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ResumeVoting")
+}
+
+// ResumeVoting indicates an expected call of ResumeVoting.
+func (mr *MockBlockFinalityAPIMockRecorder) ResumeVoting() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeVoting", reflect.TypeOf((*MockBlockFinalityAPI)(nil).ResumeVoting))
+}
+
 // MockRuntimeStorageAPI is a mock of RuntimeStorageAPI interface.
 type MockRuntimeStorageAPI struct {
 	ctrl     *gomock.Controller
@@ -1133,3 +1330,94 @@ func (mr *MockSyncStateAPIMockRecorder) GenSyncSpec(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenSyncSpec", reflect.TypeOf((*MockSyncStateAPI)(nil).GenSyncSpec), arg0)
 }
+
+// MockLightSyncStateAPI is a mock of LightSyncStateAPI interface.
+type MockLightSyncStateAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockLightSyncStateAPIMockRecorder
+}
+
+// MockLightSyncStateAPIMockRecorder is the mock recorder for MockLightSyncStateAPI.
+type MockLightSyncStateAPIMockRecorder struct {
+	mock *MockLightSyncStateAPI
+}
+
+// NewMockLightSyncStateAPI creates a new mock instance.
+func NewMockLightSyncStateAPI(ctrl *gomock.Controller) *MockLightSyncStateAPI {
+	mock := &MockLightSyncStateAPI{ctrl: ctrl}
+	mock.recorder = &MockLightSyncStateAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLightSyncStateAPI) EXPECT() *MockLightSyncStateAPIMockRecorder {
+	return m.recorder
+}
+
+// LightSyncState mocks base method.
+func (m *MockLightSyncStateAPI) LightSyncState() (*genesis.LightSyncState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LightSyncState")
+	ret0, _ := ret[0].(*genesis.LightSyncState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LightSyncState indicates an expected call of LightSyncState.
+func (mr *MockLightSyncStateAPIMockRecorder) LightSyncState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LightSyncState", reflect.TypeOf((*MockLightSyncStateAPI)(nil).LightSyncState))
+}
+
+// MockBlockFinaliserAPI is a mock of BlockFinaliserAPI interface.
+type MockBlockFinaliserAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlockFinaliserAPIMockRecorder
+}
+
+// MockBlockFinaliserAPIMockRecorder is the mock recorder for MockBlockFinaliserAPI.
+type MockBlockFinaliserAPIMockRecorder struct {
+	mock *MockBlockFinaliserAPI
+}
+
+// NewMockBlockFinaliserAPI creates a new mock instance.
+func NewMockBlockFinaliserAPI(ctrl *gomock.Controller) *MockBlockFinaliserAPI {
+	mock := &MockBlockFinaliserAPI{ctrl: ctrl}
+	mock.recorder = &MockBlockFinaliserAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlockFinaliserAPI) EXPECT() *MockBlockFinaliserAPIMockRecorder {
+	return m.recorder
+}
+
+// GetHighestRoundAndSetID mocks base method.
+func (m *MockBlockFinaliserAPI) GetHighestRoundAndSetID() (uint64, uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHighestRoundAndSetID")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetHighestRoundAndSetID indicates an expected call of GetHighestRoundAndSetID.
+func (mr *MockBlockFinaliserAPIMockRecorder) GetHighestRoundAndSetID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHighestRoundAndSetID", reflect.TypeOf((*MockBlockFinaliserAPI)(nil).GetHighestRoundAndSetID))
+}
+
+// SetFinalisedHash mocks base method.
+func (m *MockBlockFinaliserAPI) SetFinalisedHash(arg0 common.Hash, arg1, arg2 uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFinalisedHash", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetFinalisedHash indicates an expected call of SetFinalisedHash.
+func (mr *MockBlockFinaliserAPIMockRecorder) SetFinalisedHash(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFinalisedHash", reflect.TypeOf((*MockBlockFinaliserAPI)(nil).SetFinalisedHash), arg0, arg1, arg2)
+}
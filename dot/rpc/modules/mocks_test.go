@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/ChainSafe/gossamer/dot/rpc/modules (interfaces: StorageAPI,BlockAPI,Telemetry)
+// Source: github.com/ChainSafe/gossamer/dot/rpc/modules (interfaces: StorageAPI,BlockAPI,Telemetry,DatabaseAPI,RoleAPI,EpochAPI)
 //
 // Generated by this command:
 //
-//	mockgen -destination=mocks_test.go -package=modules . StorageAPI,BlockAPI,Telemetry
+//	mockgen -destination=mocks_test.go -package=modules . StorageAPI,BlockAPI,Telemetry,DatabaseAPI,RoleAPI,EpochAPI
 //
 
 // Package modules is a generated GoMock package.
@@ -12,6 +12,7 @@
 import (
 	json "encoding/json"
 	reflect "reflect"
+	time "time"
 
 	state "github.com/ChainSafe/gossamer/dot/state"
 	types "github.com/ChainSafe/gossamer/dot/types"
@@ -59,6 +60,37 @@ func (mr *MockStorageAPIMockRecorder) Entries(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Entries", reflect.TypeOf((*MockStorageAPI)(nil).Entries), arg0)
 }
 
+// GetChangedBlocks mocks base method.
+func (m *MockStorageAPI) GetChangedBlocks(arg0 []byte, arg1 uint, arg2 uint) ([]common.Hash, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChangedBlocks", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]common.Hash)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetChangedBlocks indicates an expected call of GetChangedBlocks.
+func (mr *MockStorageAPIMockRecorder) GetChangedBlocks(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangedBlocks", reflect.TypeOf((*MockStorageAPI)(nil).GetChangedBlocks), arg0, arg1, arg2)
+}
+
+// GetKeysPaged mocks base method.
+func (m *MockStorageAPI) GetKeysPaged(arg0 *common.Hash, arg1, arg2 []byte, arg3 uint32) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKeysPaged", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKeysPaged indicates an expected call of GetKeysPaged.
+func (mr *MockStorageAPIMockRecorder) GetKeysPaged(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKeysPaged", reflect.TypeOf((*MockStorageAPI)(nil).GetKeysPaged), arg0, arg1, arg2, arg3)
+}
+
 // GetKeysWithPrefix mocks base method.
 func (m *MockStorageAPI) GetKeysWithPrefix(arg0 *common.Hash, arg1 []byte) ([][]byte, error) {
 	m.ctrl.T.Helper()
@@ -460,3 +492,189 @@ func (mr *MockTelemetryMockRecorder) SendMessage(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMessage", reflect.TypeOf((*MockTelemetry)(nil).SendMessage), arg0)
 }
+
+// MockDatabaseAPI is a mock of DatabaseAPI interface.
+type MockDatabaseAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockDatabaseAPIMockRecorder
+}
+
+// MockDatabaseAPIMockRecorder is the mock recorder for MockDatabaseAPI.
+type MockDatabaseAPIMockRecorder struct {
+	mock *MockDatabaseAPI
+}
+
+// NewMockDatabaseAPI creates a new mock instance.
+func NewMockDatabaseAPI(ctrl *gomock.Controller) *MockDatabaseAPI {
+	mock := &MockDatabaseAPI{ctrl: ctrl}
+	mock.recorder = &MockDatabaseAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDatabaseAPI) EXPECT() *MockDatabaseAPIMockRecorder {
+	return m.recorder
+}
+
+// Compact mocks base method.
+func (m *MockDatabaseAPI) Compact() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Compact")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Compact indicates an expected call of Compact.
+func (mr *MockDatabaseAPIMockRecorder) Compact() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compact", reflect.TypeOf((*MockDatabaseAPI)(nil).Compact))
+}
+
+// MockRoleAPI is a mock of RoleAPI interface.
+type MockRoleAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockRoleAPIMockRecorder
+}
+
+// MockRoleAPIMockRecorder is the mock recorder for MockRoleAPI.
+type MockRoleAPIMockRecorder struct {
+	mock *MockRoleAPI
+}
+
+// NewMockRoleAPI creates a new mock instance.
+func NewMockRoleAPI(ctrl *gomock.Controller) *MockRoleAPI {
+	mock := &MockRoleAPI{ctrl: ctrl}
+	mock.recorder = &MockRoleAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRoleAPI) EXPECT() *MockRoleAPIMockRecorder {
+	return m.recorder
+}
+
+// IsAuthoring mocks base method.
+func (m *MockRoleAPI) IsAuthoring() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAuthoring")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsAuthoring indicates an expected call of IsAuthoring.
+func (mr *MockRoleAPIMockRecorder) IsAuthoring() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAuthoring", reflect.TypeOf((*MockRoleAPI)(nil).IsAuthoring))
+}
+
+// SetAuthoring mocks base method.
+func (m *MockRoleAPI) SetAuthoring(arg0 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAuthoring", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAuthoring indicates an expected call of SetAuthoring.
+func (mr *MockRoleAPIMockRecorder) SetAuthoring(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAuthoring", reflect.TypeOf((*MockRoleAPI)(nil).SetAuthoring), arg0)
+}
+
+// MockEpochAPI is a mock of EpochAPI interface.
+type MockEpochAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockEpochAPIMockRecorder
+}
+
+// MockEpochAPIMockRecorder is the mock recorder for MockEpochAPI.
+type MockEpochAPIMockRecorder struct {
+	mock *MockEpochAPI
+}
+
+// NewMockEpochAPI creates a new mock instance.
+func NewMockEpochAPI(ctrl *gomock.Controller) *MockEpochAPI {
+	mock := &MockEpochAPI{ctrl: ctrl}
+	mock.recorder = &MockEpochAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEpochAPI) EXPECT() *MockEpochAPIMockRecorder {
+	return m.recorder
+}
+
+// GetCurrentEpoch mocks base method.
+func (m *MockEpochAPI) GetCurrentEpoch() (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentEpoch")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentEpoch indicates an expected call of GetCurrentEpoch.
+func (mr *MockEpochAPIMockRecorder) GetCurrentEpoch() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentEpoch", reflect.TypeOf((*MockEpochAPI)(nil).GetCurrentEpoch))
+}
+
+// GetEpochDataRaw mocks base method.
+func (m *MockEpochAPI) GetEpochDataRaw(arg0 uint64, arg1 *types.Header) (*types.EpochDataRaw, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEpochDataRaw", arg0, arg1)
+	ret0, _ := ret[0].(*types.EpochDataRaw)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEpochDataRaw indicates an expected call of GetEpochDataRaw.
+func (mr *MockEpochAPIMockRecorder) GetEpochDataRaw(arg0 any, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEpochDataRaw", reflect.TypeOf((*MockEpochAPI)(nil).GetEpochDataRaw), arg0, arg1)
+}
+
+// GetEpochForBlock mocks base method.
+func (m *MockEpochAPI) GetEpochForBlock(arg0 *types.Header) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEpochForBlock", arg0)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEpochForBlock indicates an expected call of GetEpochForBlock.
+func (mr *MockEpochAPIMockRecorder) GetEpochForBlock(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEpochForBlock", reflect.TypeOf((*MockEpochAPI)(nil).GetEpochForBlock), arg0)
+}
+
+// GetSlotDuration mocks base method.
+func (m *MockEpochAPI) GetSlotDuration() (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSlotDuration")
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSlotDuration indicates an expected call of GetSlotDuration.
+func (mr *MockEpochAPIMockRecorder) GetSlotDuration() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSlotDuration", reflect.TypeOf((*MockEpochAPI)(nil).GetSlotDuration))
+}
+
+// GetStartSlotForEpoch mocks base method.
+func (m *MockEpochAPI) GetStartSlotForEpoch(arg0 uint64, arg1 common.Hash) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStartSlotForEpoch", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStartSlotForEpoch indicates an expected call of GetStartSlotForEpoch.
+func (mr *MockEpochAPIMockRecorder) GetStartSlotForEpoch(arg0 any, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStartSlotForEpoch", reflect.TypeOf((*MockEpochAPI)(nil).GetStartSlotForEpoch), arg0, arg1)
+}
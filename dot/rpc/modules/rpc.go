@@ -19,6 +19,11 @@
 		"state_getPairs",
 		"state_getKeysPaged",
 		"state_queryStorage",
+		"engine_createBlock",
+		"engine_finalizeBlock",
+		"grandpa_pauseVoting",
+		"dev_compactDatabase",
+		"dev_setNodeRole",
 	}
 
 	// AliasesMethods is a map that links the original methods to their aliases
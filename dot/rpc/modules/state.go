@@ -7,7 +7,6 @@
 	"encoding/hex"
 	"fmt"
 	"net/http"
-	"strings"
 
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/runtime"
@@ -35,9 +34,10 @@ type StateStorageKeyRequest struct {
 	Block    *common.Hash `json:"block"`
 }
 
-// StateRuntimeMetadataQuery is a hash value
+// StateRuntimeMetadataQuery is a hash value, with an optional metadata version
 type StateRuntimeMetadataQuery struct {
-	Bhash *common.Hash
+	Bhash   *common.Hash
+	Version *uint32
 }
 
 // StateRuntimeVersionRequest is hash value
@@ -282,29 +282,36 @@ func (sm *StateModule) GetKeysPaged(_ *http.Request, req *StateStorageKeyRequest
 	if err != nil {
 		return err
 	}
-	keys, err := sm.storageAPI.GetKeysWithPrefix(req.Block, hPrefix)
+
+	var afterKey []byte
+	if req.AfterKey != "" {
+		afterKey, err = common.HexToBytes(req.AfterKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	keys, err := sm.storageAPI.GetKeysPaged(req.Block, hPrefix, afterKey, req.Qty)
 	if err != nil {
 		return fmt.Errorf("cannot get keys with prefix %s: %w", hPrefix, err)
 	}
-	resCount := uint32(0)
+
 	for _, k := range keys {
-		fKey := fmt.Sprintf("0x%x", k)
-		if strings.Compare(fKey, req.AfterKey) == 1 {
-			// sm.storageAPI.Keys sorts keys in lexicographical order, so we know that keys where strings.Compare = 1
-			//  are after the requested after key.
-			if resCount >= req.Qty {
-				break
-			}
-			*res = append(*res, fKey)
-			resCount++
-		}
+		*res = append(*res, fmt.Sprintf("0x%x", k))
 	}
-	return err
+	return nil
 }
 
-// GetMetadata calls runtime Metadata_metadata function
+// GetMetadata calls runtime Metadata_metadata function, or runtime Metadata_metadata_at_version
+// function if a metadata version is given, using the runtime of the block given.
 func (sm *StateModule) GetMetadata(_ *http.Request, req *StateRuntimeMetadataQuery, res *StateMetadataResponse) error {
-	metadata, err := sm.coreAPI.GetMetadata(req.Bhash)
+	var metadata []byte
+	var err error
+	if req.Version == nil {
+		metadata, err = sm.coreAPI.GetMetadata(req.Bhash)
+	} else {
+		metadata, err = sm.coreAPI.GetMetadataAtVersion(req.Bhash, *req.Version)
+	}
 	if err != nil {
 		return err
 	}
@@ -476,6 +483,27 @@ func (sm *StateModule) QueryStorage(
 	}
 	endBlockNumber := endBlock.Header.Number
 
+	// changedAt holds for each key the blocks at which its value may have changed, nil if
+	// the changes of the blocks queried are not indexed. The storage of a key is only read
+	// at the start block and at the blocks it may have changed at.
+	changedAt := make([]map[common.Hash]struct{}, len(req.Keys))
+	if startBlockNumber < endBlockNumber {
+		for j, key := range req.Keys {
+			hashes, indexed, err := sm.storageAPI.GetChangedBlocks(
+				common.MustHexToBytes(key), startBlockNumber+1, endBlockNumber)
+			if err != nil {
+				return fmt.Errorf("getting changed blocks: %w", err)
+			}
+			if !indexed {
+				continue
+			}
+			changedAt[j] = make(map[common.Hash]struct{}, len(hashes))
+			for _, hash := range hashes {
+				changedAt[j][hash] = struct{}{}
+			}
+		}
+	}
+
 	response := make([]StorageChangeSetResponse, 0, endBlockNumber-startBlockNumber)
 	lastValue := make([]*string, len(req.Keys))
 
@@ -487,6 +515,12 @@ func (sm *StateModule) QueryStorage(
 		changes := make([][2]*string, 0, len(req.Keys))
 
 		for j, key := range req.Keys {
+			if i != startBlockNumber && changedAt[j] != nil {
+				if _, changed := changedAt[j][blockHash]; !changed {
+					continue
+				}
+			}
+
 			value, err := sm.storageAPI.GetStorageByBlockHash(&blockHash, common.MustHexToBytes(key))
 			if err != nil {
 				return fmt.Errorf("getting value by block hash: %w", err)
@@ -193,16 +193,19 @@ func TestStateModuleGetKeysPaged(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
 	mockStorageAPI := mocks.NewMockStorageAPI(ctrl)
-	mockStorageAPI.EXPECT().GetKeysWithPrefix((*common.Hash)(nil), common.MustHexToBytes("0x")).
-		Return([][]byte{{1}, {2}}, nil)
+	mockStorageAPI.EXPECT().GetKeysPaged((*common.Hash)(nil), common.MustHexToBytes("0x"),
+		common.MustHexToBytes("0x01"), uint32(0)).
+		Return([][]byte{}, nil)
 
 	mockStorageAPI2 := mocks.NewMockStorageAPI(ctrl)
-	mockStorageAPI2.EXPECT().GetKeysWithPrefix((*common.Hash)(nil), common.MustHexToBytes("0x")).
-		Return([][]byte{{1, 1, 1}, {1, 1, 1}}, nil)
+	mockStorageAPI2.EXPECT().GetKeysPaged((*common.Hash)(nil), common.MustHexToBytes("0x"),
+		common.MustHexToBytes("0x01"), uint32(1)).
+		Return([][]byte{{1, 1, 1}}, nil)
 
 	mockStorageAPIErr := mocks.NewMockStorageAPI(ctrl)
-	mockStorageAPIErr.EXPECT().GetKeysWithPrefix((*common.Hash)(nil), common.MustHexToBytes("0x")).
-		Return(nil, errors.New("GetKeysWithPrefix Err"))
+	mockStorageAPIErr.EXPECT().GetKeysPaged((*common.Hash)(nil), common.MustHexToBytes("0x"),
+		common.MustHexToBytes("0x01"), uint32(0)).
+		Return(nil, errors.New("GetKeysPaged Err"))
 
 	type fields struct {
 		networkAPI NetworkAPI
@@ -231,7 +234,7 @@ type args struct {
 			exp: StateStorageKeysResponse(nil),
 		},
 		{
-			name:   "ResCount break",
+			name:   "Qty",
 			fields: fields{nil, mockStorageAPI2, nil},
 			args: args{
 				req: &StateStorageKeyRequest{
@@ -242,14 +245,14 @@ type args struct {
 			exp: StateStorageKeysResponse{"0x010101"},
 		},
 		{
-			name:   "GetKeysWithPrefix Error",
+			name:   "GetKeysPaged Error",
 			fields: fields{nil, mockStorageAPIErr, nil},
 			args: args{
 				req: &StateStorageKeyRequest{
 					AfterKey: "0x01",
 				},
 			},
-			expErr: errors.New("cannot get keys with prefix : GetKeysWithPrefix Err"),
+			expErr: errors.New("cannot get keys with prefix : GetKeysPaged Err"),
 		},
 		{
 			name:   "Request Prefix Error",
@@ -315,6 +318,10 @@ func TestStateModuleGetMetadata(t *testing.T) {
 
 	mockCoreAPI := mocks.NewMockCoreAPI(ctrl)
 	mockCoreAPI.EXPECT().GetMetadata(&hash).Return(common.MustHexToBytes(testdata.NewTestMetadata()), nil)
+	mockCoreAPI.EXPECT().GetMetadataAtVersion(&hash, uint32(15)).
+		Return(common.MustHexToBytes(testdata.NewTestMetadata()), nil)
+	mockCoreAPI.EXPECT().GetMetadataAtVersion(&hash, uint32(16)).
+		Return(nil, runtime.ErrMetadataVersionNotSupported)
 
 	mockCoreAPIErr := mocks.NewMockCoreAPI(ctrl)
 	mockCoreAPIErr.EXPECT().GetMetadata(&hash).Return(nil, errors.New("GetMetadata Error"))
@@ -324,6 +331,7 @@ func TestStateModuleGetMetadata(t *testing.T) {
 	var expRes []byte
 	err := scale.Unmarshal(common.MustHexToBytes(testdata.NewTestMetadata()), &expRes)
 	assert.NoError(t, err)
+	version15, version16 := uint32(15), uint32(16)
 	type fields struct {
 		networkAPI NetworkAPI
 		storageAPI StorageAPI
@@ -348,6 +356,22 @@ type args struct {
 			},
 			exp: StateMetadataResponse(common.BytesToHex(expRes)),
 		},
+		{
+			name:   "OK Case at version",
+			fields: fields{nil, nil, mockCoreAPI},
+			args: args{
+				req: &StateRuntimeMetadataQuery{Bhash: &hash, Version: &version15},
+			},
+			exp: StateMetadataResponse(common.BytesToHex(expRes)),
+		},
+		{
+			name:   "version not supported",
+			fields: fields{nil, nil, mockCoreAPI},
+			args: args{
+				req: &StateRuntimeMetadataQuery{Bhash: &hash, Version: &version16},
+			},
+			expErr: runtime.ErrMetadataVersionNotSupported,
+		},
 		{
 			name:   "GetMetadata Error",
 			fields: fields{nil, nil, mockStateModule.coreAPI},
@@ -893,6 +917,10 @@ type args struct {
 			fields: fields{
 				storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
 					mockStorageAPI := NewMockStorageAPI(ctrl)
+					mockStorageAPI.EXPECT().GetChangedBlocks([]byte{1, 2, 4}, uint(2), uint(3)).
+						Return(nil, false, nil)
+					mockStorageAPI.EXPECT().GetChangedBlocks([]byte{9, 9, 9}, uint(2), uint(3)).
+						Return(nil, false, nil)
 					mockStorageAPI.EXPECT().GetStorageByBlockHash(&common.Hash{2}, []byte{1, 2, 4}).
 						Return([]byte{1, 1, 1}, nil)
 					mockStorageAPI.EXPECT().GetStorageByBlockHash(&common.Hash{2}, []byte{9, 9, 9}).
@@ -951,6 +979,8 @@ type args struct {
 			fields: fields{
 				storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
 					mockStorageAPI := NewMockStorageAPI(ctrl)
+					mockStorageAPI.EXPECT().GetChangedBlocks([]byte{1, 2, 4}, uint(1), uint(3)).
+						Return(nil, false, nil)
 					mockStorageAPI.EXPECT().GetStorageByBlockHash(&common.Hash{1}, []byte{1, 2, 4}).
 						Return([]byte{1, 1, 1}, nil)
 					mockStorageAPI.EXPECT().GetStorageByBlockHash(&common.Hash{2}, []byte{1, 2, 4}).
@@ -1011,6 +1041,8 @@ type args struct {
 			fields: fields{
 				storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
 					mockStorageAPI := NewMockStorageAPI(ctrl)
+					mockStorageAPI.EXPECT().GetChangedBlocks([]byte{1, 2, 4}, uint(2), uint(2)).
+						Return(nil, false, nil)
 					mockStorageAPI.EXPECT().GetStorageByBlockHash(&common.Hash{2}, []byte{1, 2, 4}).
 						Return([]byte{1, 1, 1}, nil)
 					mockStorageAPI.EXPECT().GetStorageByBlockHash(&common.Hash{3}, []byte{1, 2, 4}).
@@ -1047,6 +1079,86 @@ type args struct {
 				},
 			},
 		},
+		"start_block/end_block/indexed_changes": {
+			fields: fields{
+				storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+					mockStorageAPI := NewMockStorageAPI(ctrl)
+					mockStorageAPI.EXPECT().GetChangedBlocks([]byte{1, 2, 4}, uint(2), uint(4)).
+						Return([]common.Hash{{4}, {9}}, true, nil)
+					mockStorageAPI.EXPECT().GetStorageByBlockHash(&common.Hash{2}, []byte{1, 2, 4}).
+						Return([]byte{1, 1, 1}, nil)
+					mockStorageAPI.EXPECT().GetStorageByBlockHash(&common.Hash{4}, []byte{1, 2, 4}).
+						Return([]byte{2, 2, 2}, nil)
+					return mockStorageAPI
+				},
+				blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
+					mockBlockAPI := NewMockBlockAPI(ctrl)
+					mockBlockAPI.EXPECT().GetBlockByHash(common.Hash{2}).
+						Return(&types.Block{Header: types.Header{Number: 1}}, nil)
+					mockBlockAPI.EXPECT().GetBlockByHash(common.Hash{5}).
+						Return(&types.Block{Header: types.Header{Number: 4}}, nil)
+					mockBlockAPI.EXPECT().GetHashByNumber(uint(1)).Return(common.Hash{2}, nil)
+					mockBlockAPI.EXPECT().GetHashByNumber(uint(2)).Return(common.Hash{3}, nil)
+					mockBlockAPI.EXPECT().GetHashByNumber(uint(3)).Return(common.Hash{4}, nil)
+					mockBlockAPI.EXPECT().GetHashByNumber(uint(4)).Return(common.Hash{5}, nil)
+					return mockBlockAPI
+				}},
+			args: args{
+				req: &StateStorageQueryRangeRequest{
+					Keys:       []string{"0x010204"},
+					StartBlock: common.Hash{2},
+					EndBlock:   common.Hash{5},
+				},
+			},
+			exp: []StorageChangeSetResponse{
+				{
+					Block: &common.Hash{2},
+					Changes: [][2]*string{
+						makeChange("0x010204", "0x010101"),
+					},
+				},
+				{
+					Block:   &common.Hash{3},
+					Changes: [][2]*string{},
+				},
+				{
+					Block: &common.Hash{4},
+					Changes: [][2]*string{
+						makeChange("0x010204", "0x020202"),
+					},
+				},
+				{
+					Block:   &common.Hash{5},
+					Changes: [][2]*string{},
+				},
+			},
+		},
+		"start_block/end_block/error_get_changed_blocks": {
+			fields: fields{
+				storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
+					mockStorageAPI := NewMockStorageAPI(ctrl)
+					mockStorageAPI.EXPECT().GetChangedBlocks([]byte{1, 2, 4}, uint(2), uint(2)).
+						Return(nil, false, errTest)
+					return mockStorageAPI
+				},
+				blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
+					mockBlockAPI := NewMockBlockAPI(ctrl)
+					mockBlockAPI.EXPECT().GetBlockByHash(common.Hash{2}).
+						Return(&types.Block{Header: types.Header{Number: 1}}, nil)
+					mockBlockAPI.EXPECT().GetBlockByHash(common.Hash{3}).
+						Return(&types.Block{Header: types.Header{Number: 2}}, nil)
+					return mockBlockAPI
+				}},
+			args: args{
+				req: &StateStorageQueryRangeRequest{
+					Keys:       []string{"0x010204"},
+					StartBlock: common.Hash{2},
+					EndBlock:   common.Hash{3},
+				},
+			},
+			exp:       []StorageChangeSetResponse{},
+			errRegexp: "getting changed blocks: test error",
+		},
 		"start_block/end_block/error_end_hash": {
 			fields: fields{
 				storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
@@ -1074,6 +1186,8 @@ type args struct {
 			fields: fields{
 				storageAPIBuilder: func(ctrl *gomock.Controller) StorageAPI {
 					mockStorageAPI := NewMockStorageAPI(ctrl)
+					mockStorageAPI.EXPECT().GetChangedBlocks([]byte{1, 2, 4}, uint(2), uint(2)).
+						Return(nil, false, nil)
 					return mockStorageAPI
 				},
 				blockAPIBuilder: func(ctrl *gomock.Controller) BlockAPI {
@@ -1098,6 +1212,8 @@ type args struct {
 		"start_block/end_block/error_get_storage_by_block_hash": {
 			fields: fields{func(ctrl *gomock.Controller) StorageAPI {
 				mockStorageAPI := NewMockStorageAPI(ctrl)
+				mockStorageAPI.EXPECT().GetChangedBlocks([]byte{1, 2, 4}, uint(2), uint(2)).
+					Return(nil, false, nil)
 				mockStorageAPI.EXPECT().GetStorageByBlockHash(&common.Hash{2}, []byte{1, 2, 4}).Return(nil, errTest)
 				return mockStorageAPI
 			},
@@ -4,6 +4,7 @@
 package modules
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/ChainSafe/gossamer/lib/common"
@@ -40,10 +41,12 @@ func (ss *SyncStateModule) GenSyncSpec(_ *http.Request, req *GenSyncSpecRequest,
 // syncState implements SyncStateAPI.
 type syncState struct {
 	chainSpecification *genesis.Genesis
+	lightSyncStateAPI  LightSyncStateAPI
 }
 
 // NewStateSync creates an instance of SyncStateAPI given a chain specification.
-func NewStateSync(gData *genesis.Data, storageAPI StorageAPI) (SyncStateAPI, error) {
+func NewStateSync(gData *genesis.Data, storageAPI StorageAPI,
+	lightSyncStateAPI LightSyncStateAPI) (SyncStateAPI, error) {
 	tmpGen := &genesis.Genesis{
 		Name:       "",
 		ID:         "",
@@ -71,11 +74,11 @@ func NewStateSync(gData *genesis.Data, storageAPI StorageAPI) (SyncStateAPI, err
 	tmpGen.ID = gData.ID
 	tmpGen.Bootnodes = common.BytesToStringArray(gData.Bootnodes)
 	tmpGen.ProtocolID = gData.ProtocolID
-	return syncState{chainSpecification: tmpGen}, nil
+	return syncState{chainSpecification: tmpGen, lightSyncStateAPI: lightSyncStateAPI}, nil
 }
 
 // GenSyncSpec returns the JSON serialised chain specification running the node
-// (i.e. the current state), with a sync state.
+// (i.e. the current state), with the light sync state of the highest finalised block.
 func (s syncState) GenSyncSpec(raw bool) (*genesis.Genesis, error) {
 	if raw {
 		err := s.chainSpecification.ToRaw()
@@ -84,5 +87,12 @@ func (s syncState) GenSyncSpec(raw bool) (*genesis.Genesis, error) {
 		}
 	}
 
-	return s.chainSpecification, nil
+	lightSyncState, err := s.lightSyncStateAPI.LightSyncState()
+	if err != nil {
+		return nil, fmt.Errorf("getting light sync state: %w", err)
+	}
+
+	chainSpecification := *s.chainSpecification
+	chainSpecification.LightSyncState = lightSyncState
+	return &chainSpecification, nil
 }
@@ -11,9 +11,11 @@
 	"path/filepath"
 	"testing"
 
+	"github.com/ChainSafe/gossamer/dot/rpc/modules/mocks"
 	"github.com/ChainSafe/gossamer/lib/genesis"
 	"github.com/ChainSafe/gossamer/lib/utils"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 )
 
 func TestSyncStateModule(t *testing.T) {
@@ -26,7 +28,11 @@ func TestSyncStateModule(t *testing.T) {
 	err = json.Unmarshal(data, g)
 	require.NoError(t, err)
 
-	module := NewSyncStateModule(syncState{chainSpecification: g})
+	ctrl := gomock.NewController(t)
+	lightSyncStateAPI := mocks.NewMockLightSyncStateAPI(ctrl)
+	lightSyncStateAPI.EXPECT().LightSyncState().Return(&genesis.LightSyncState{}, nil)
+
+	module := NewSyncStateModule(syncState{chainSpecification: g, lightSyncStateAPI: lightSyncStateAPI})
 
 	req := GenSyncSpecRequest{
 		Raw: true,
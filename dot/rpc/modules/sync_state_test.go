@@ -95,9 +95,12 @@ func TestNewStateSync(t *testing.T) {
 	mockStorageAPIErr := mocks.NewMockStorageAPI(ctrl)
 	mockStorageAPIErr.EXPECT().Entries((*common.Hash)(nil)).Return(nil, errors.New("entries error"))
 
+	mockLightSyncStateAPI := mocks.NewMockLightSyncStateAPI(ctrl)
+
 	type args struct {
-		gData      *genesis.Data
-		storageAPI StorageAPI
+		gData             *genesis.Data
+		storageAPI        StorageAPI
+		lightSyncStateAPI LightSyncStateAPI
 	}
 	tests := []struct {
 		name   string
@@ -108,8 +111,9 @@ type args struct {
 		{
 			name: "OK_Case",
 			args: args{
-				gData:      g1.GenesisData(),
-				storageAPI: mockStorageAPI,
+				gData:             g1.GenesisData(),
+				storageAPI:        mockStorageAPI,
+				lightSyncStateAPI: mockLightSyncStateAPI,
 			},
 			exp: syncState{chainSpecification: &genesis.Genesis{
 				Name:       "",
@@ -121,20 +125,22 @@ type args struct {
 					Runtime: new(genesis.Runtime),
 				},
 			},
+				lightSyncStateAPI: mockLightSyncStateAPI,
 			},
 		},
 		{
 			name: "Err_Case",
 			args: args{
-				gData:      g2.GenesisData(),
-				storageAPI: mockStorageAPIErr,
+				gData:             g2.GenesisData(),
+				storageAPI:        mockStorageAPIErr,
+				lightSyncStateAPI: mockLightSyncStateAPI,
 			},
 			expErr: errors.New("entries error"),
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			res, err := NewStateSync(tt.args.gData, tt.args.storageAPI)
+			res, err := NewStateSync(tt.args.gData, tt.args.storageAPI, tt.args.lightSyncStateAPI)
 			if tt.expErr != nil {
 				assert.EqualError(t, err, tt.expErr.Error())
 			} else {
@@ -146,6 +152,13 @@ type args struct {
 }
 
 func Test_syncState_GenSyncSpec(t *testing.T) {
+	lightSyncState := &genesis.LightSyncState{
+		FinalizedBlockHeader:     "0x01",
+		BabeEpochChanges:         "0x02",
+		BabeFinalizedBlockWeight: 0,
+		GrandpaAuthoritySet:      "0x03",
+	}
+
 	type fields struct {
 		chainSpecification genesis.Genesis
 	}
@@ -153,16 +166,22 @@ type args struct {
 		raw bool
 	}
 	tests := []struct {
-		name   string
-		fields fields
-		args   args
-		expErr error
-		exp    genesis.Genesis
+		name                     string
+		fields                   fields
+		args                     args
+		lightSyncStateAPIBuilder func(ctrl *gomock.Controller) LightSyncStateAPI
+		expErr                   error
+		exp                      genesis.Genesis
 	}{
 		{
 			name:   "GenSyncSpec False",
 			fields: fields{genesis.Genesis{}},
-			exp:    genesis.Genesis{},
+			lightSyncStateAPIBuilder: func(ctrl *gomock.Controller) LightSyncStateAPI {
+				mock := mocks.NewMockLightSyncStateAPI(ctrl)
+				mock.EXPECT().LightSyncState().Return(lightSyncState, nil)
+				return mock
+			},
+			exp: genesis.Genesis{LightSyncState: lightSyncState},
 		},
 		{
 			name:   "GenSyncSpec True",
@@ -170,20 +189,37 @@ type args struct {
 			args: args{
 				raw: true,
 			},
-			exp: genesis.Genesis{},
+			lightSyncStateAPIBuilder: func(ctrl *gomock.Controller) LightSyncStateAPI {
+				mock := mocks.NewMockLightSyncStateAPI(ctrl)
+				mock.EXPECT().LightSyncState().Return(lightSyncState, nil)
+				return mock
+			},
+			exp: genesis.Genesis{LightSyncState: lightSyncState},
+		},
+		{
+			name:   "LightSyncState Err",
+			fields: fields{genesis.Genesis{}},
+			lightSyncStateAPIBuilder: func(ctrl *gomock.Controller) LightSyncStateAPI {
+				mock := mocks.NewMockLightSyncStateAPI(ctrl)
+				mock.EXPECT().LightSyncState().Return(nil, errors.New("light sync state error"))
+				return mock
+			},
+			expErr: errors.New("getting light sync state: light sync state error"),
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
 			s := syncState{
 				chainSpecification: &tt.fields.chainSpecification,
+				lightSyncStateAPI:  tt.lightSyncStateAPIBuilder(ctrl),
 			}
 			res, err := s.GenSyncSpec(tt.args.raw)
 			if tt.expErr != nil {
 				assert.EqualError(t, err, tt.expErr.Error())
-			} else {
-				assert.NoError(t, err)
+				return
 			}
+			assert.NoError(t, err)
 			assert.Equal(t, tt.exp, *res)
 		})
 	}
@@ -40,8 +40,12 @@ type EmptyRequest struct{
 
 // NetworkStateString Network State represented as string so JSON encode/decoding works
 type NetworkStateString struct {
-	PeerID     string
-	Multiaddrs []string
+	PeerID             string
+	Multiaddrs         []string
+	TotalBytesInbound  uint64
+	TotalBytesOutbound uint64
+	PeerTraffic        map[string]common.Traffic
+	ProtocolTraffic    map[string]common.Traffic
 }
 
 // SystemNetworkStateResponse struct to marshal json
@@ -67,6 +71,24 @@ type SyncStateResponse struct {
 	StartingBlock uint32 `json:"startingBlock"`
 }
 
+// SyncProgressResponse is the struct to return on the system_syncProgress rpc call
+type SyncProgressResponse struct {
+	BestBlock                uint                           `json:"bestBlock"`
+	TargetBlock              uint                           `json:"targetBlock"`
+	BlocksPerSecond          float64                        `json:"blocksPerSecond"`
+	AverageBlocksPerSecond   float64                        `json:"averageBlocksPerSecond"`
+	BytesPerSecond           float64                        `json:"bytesPerSecond"`
+	EstimatedSecondsToTarget float64                        `json:"estimatedSecondsToTarget"`
+	Peers                    []PeerSyncContributionResponse `json:"peers"`
+}
+
+// PeerSyncContributionResponse is the amount of block data downloaded from a peer
+type PeerSyncContributionResponse struct {
+	PeerID string `json:"peerId"`
+	Blocks uint64 `json:"blocks"`
+	Bytes  uint64 `json:"bytes"`
+}
+
 // NewSystemModule creates a new API instance
 func NewSystemModule(net NetworkAPI, sys SystemAPI, core CoreAPI,
 	storage StorageAPI, txAPI TransactionStateAPI, blockAPI BlockAPI,
@@ -119,13 +141,20 @@ func (sm *SystemModule) Health(r *http.Request, req *EmptyRequest, res *SystemHe
 	return nil
 }
 
-// NetworkState returns the network state (basic information about the host)
+// NetworkState returns the network state (basic information about the host and its
+// traffic per connected peer and per sub-protocol)
 func (sm *SystemModule) NetworkState(r *http.Request, req *EmptyRequest, res *SystemNetworkStateResponse) error {
 	networkState := sm.networkAPI.NetworkState()
 	res.NetworkState.PeerID = networkState.PeerID
 	for _, v := range networkState.Multiaddrs {
 		res.NetworkState.Multiaddrs = append(res.NetworkState.Multiaddrs, v.String())
 	}
+
+	traffic := sm.networkAPI.NetworkTraffic()
+	res.NetworkState.TotalBytesInbound = traffic.TotalBytesInbound
+	res.NetworkState.TotalBytesOutbound = traffic.TotalBytesOutbound
+	res.NetworkState.PeerTraffic = traffic.Peers
+	res.NetworkState.ProtocolTraffic = traffic.Protocols
 	return nil
 }
 
@@ -243,6 +272,31 @@ func (sm *SystemModule) SyncState(r *http.Request, req *EmptyRequest, res *SyncS
 	return nil
 }
 
+// SyncProgress Returns the sync rates, the estimated time to reach the target
+// block and the block data downloaded from each peer.
+func (sm *SystemModule) SyncProgress(r *http.Request, req *EmptyRequest, res *SyncProgressResponse) error {
+	progress := sm.syncAPI.SyncProgress()
+
+	*res = SyncProgressResponse{
+		BestBlock:                progress.BestBlock,
+		TargetBlock:              progress.TargetBlock,
+		BlocksPerSecond:          progress.BlocksPerSecond,
+		AverageBlocksPerSecond:   progress.AverageBlocksPerSecond,
+		BytesPerSecond:           progress.BytesPerSecond,
+		EstimatedSecondsToTarget: progress.EstimatedTimeToTarget.Seconds(),
+		Peers:                    make([]PeerSyncContributionResponse, len(progress.Peers)),
+	}
+
+	for i, contribution := range progress.Peers {
+		res.Peers[i] = PeerSyncContributionResponse{
+			PeerID: contribution.PeerID,
+			Blocks: contribution.Blocks,
+			Bytes:  contribution.Bytes,
+		}
+	}
+	return nil
+}
+
 // LocalListenAddresses Returns the libp2p multiaddresses that the local node is listening on
 func (sm *SystemModule) LocalListenAddresses(r *http.Request, req *EmptyRequest, res *[]string) error {
 	netstate := sm.networkAPI.NetworkState()
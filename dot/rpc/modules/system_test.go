@@ -7,6 +7,7 @@
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/ChainSafe/gossamer/dot/rpc/modules/mocks"
 	testdata "github.com/ChainSafe/gossamer/dot/rpc/modules/test_data"
@@ -125,7 +126,17 @@ func TestSystemModule_NetworkStateTest(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
 	mockNetworkAPI := mocks.NewMockNetworkAPI(ctrl)
-	mockNetworkAPI.EXPECT().NetworkState().Return(common.NetworkState{})
+	mockNetworkAPI.EXPECT().NetworkState().Return(common.NetworkState{PeerID: "peer id"})
+	mockNetworkAPI.EXPECT().NetworkTraffic().Return(common.NetworkTraffic{
+		TotalBytesInbound:  3,
+		TotalBytesOutbound: 4,
+		Peers: map[string]common.Traffic{
+			"peer": {BytesInbound: 1, BytesOutbound: 2},
+		},
+		Protocols: map[string]common.Traffic{
+			"/dot/sync/2": {BytesInbound: 3, BytesOutbound: 4},
+		},
+	})
 	sm := &SystemModule{
 		networkAPI: mockNetworkAPI,
 	}
@@ -134,7 +145,20 @@ func TestSystemModule_NetworkStateTest(t *testing.T) {
 	var networkStateRes SystemNetworkStateResponse
 	err := sm.NetworkState(nil, req, &networkStateRes)
 	require.NoError(t, err)
-	require.Equal(t, SystemNetworkStateResponse{}, networkStateRes)
+	expected := SystemNetworkStateResponse{
+		NetworkState: NetworkStateString{
+			PeerID:             "peer id",
+			TotalBytesInbound:  3,
+			TotalBytesOutbound: 4,
+			PeerTraffic: map[string]common.Traffic{
+				"peer": {BytesInbound: 1, BytesOutbound: 2},
+			},
+			ProtocolTraffic: map[string]common.Traffic{
+				"/dot/sync/2": {BytesInbound: 3, BytesOutbound: 4},
+			},
+		},
+	}
+	require.Equal(t, expected, networkStateRes)
 }
 
 func TestSystemModule_PeersTest(t *testing.T) {
@@ -403,6 +427,44 @@ type args struct {
 	}
 }
 
+func TestSystemModule_SyncProgress(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mockSyncAPI := NewMockSyncAPI(ctrl)
+	mockSyncAPI.EXPECT().SyncProgress().Return(common.SyncProgress{
+		BestBlock:              100,
+		TargetBlock:            400,
+		BlocksPerSecond:        10,
+		AverageBlocksPerSecond: 8,
+		BytesPerSecond:         2048,
+		EstimatedTimeToTarget:  30 * time.Second,
+		Peers: []common.PeerSyncContribution{
+			{PeerID: "alice", Blocks: 60, Bytes: 6000},
+			{PeerID: "bob", Blocks: 40, Bytes: 4000},
+		},
+	})
+
+	sm := NewSystemModule(nil, nil, nil, nil, nil, nil, mockSyncAPI)
+
+	res := SyncProgressResponse{}
+	err := sm.SyncProgress(nil, &EmptyRequest{}, &res)
+	require.NoError(t, err)
+
+	expected := SyncProgressResponse{
+		BestBlock:                100,
+		TargetBlock:              400,
+		BlocksPerSecond:          10,
+		AverageBlocksPerSecond:   8,
+		BytesPerSecond:           2048,
+		EstimatedSecondsToTarget: 30,
+		Peers: []PeerSyncContributionResponse{
+			{PeerID: "alice", Blocks: 60, Bytes: 6000},
+			{PeerID: "bob", Blocks: 40, Bytes: 4000},
+		},
+	}
+	assert.Equal(t, expected, res)
+}
+
 func TestSystemModule_LocalListenAddresses(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
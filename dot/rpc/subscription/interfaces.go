@@ -13,6 +13,7 @@
 
 // StorageAPI is the interface for the storage state
 type StorageAPI interface {
+	GetStorageByBlockHash(bhash *common.Hash, key []byte) ([]byte, error)
 	RegisterStorageObserver(observer state.Observer)
 	UnregisterStorageObserver(observer state.Observer)
 }
@@ -35,6 +36,8 @@ type TransactionStateAPI interface {
 
 // CoreAPI is the interface for the core methods
 type CoreAPI interface {
+	GetMetadata(bhash *common.Hash) ([]byte, error)
 	GetRuntimeVersion(bhash *common.Hash) (runtime.Version, error)
 	HandleSubmittedExtrinsic(types.Extrinsic) error
+	CachedBlockEvents(blockHash common.Hash) ([]byte, bool)
 }
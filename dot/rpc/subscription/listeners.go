@@ -16,6 +16,7 @@
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	"github.com/ChainSafe/gossamer/lib/transaction"
+	"github.com/ChainSafe/gossamer/pkg/scale"
 )
 
 const (
@@ -26,6 +27,7 @@
 	chainNewHeadMethod           = "chain_newHead"
 	chainAllHeadMethod           = "chain_allHead"
 	stateStorageMethod           = "state_storage"
+	stateEventsMethod            = "state_events"
 )
 
 var (
@@ -60,6 +62,8 @@ type StorageObserver struct {
 	id     uint32
 	filter map[string][]byte
 	wsconn *WSConn
+	// resumable is the resumable subscription the notifications are sent through, if any
+	resumable *resumableSubscription
 }
 
 // Update is called to notify observer of new value
@@ -80,6 +84,10 @@ func (s *StorageObserver) Update(change *state.SubscriptionResult) {
 	res.Method = stateStorageMethod
 	res.Params.Result = changeResult
 	res.Params.SubscriptionID = s.id
+	if s.resumable != nil {
+		s.resumable.send(res)
+		return
+	}
 	s.wsconn.safeSend(res)
 }
 
@@ -171,6 +179,8 @@ type BlockFinalizedListener struct {
 	done          chan struct{}
 	cancel        chan struct{}
 	cancelTimeout time.Duration
+	// resumable is the resumable subscription the notifications are sent through, if any
+	resumable *resumableSubscription
 }
 
 // Listen implementation of Listen interface to listen for importedChan changes
@@ -201,6 +211,10 @@ func (l *BlockFinalizedListener) Listen() {
 				res.Method = chainFinalizedHeadMethod
 				res.Params.Result = head
 				res.Params.SubscriptionID = l.subID
+				if l.resumable != nil {
+					l.resumable.send(res)
+					continue
+				}
 				l.wsconn.safeSend(res)
 			}
 		}
@@ -481,6 +495,83 @@ func (g *GrandpaJustificationListener) Stop() error {
 	return cancelWithTimeout(g.cancel, g.done, g.cancelTimeout)
 }
 
+// EventsResult holds the events of a block decoded with the runtime metadata
+type EventsResult struct {
+	Block  string              `json:"block"`
+	Events []scale.EventRecord `json:"events"`
+}
+
+// EventsListener to handle listening for the decoded events of the imported or
+// finalised blocks
+type EventsListener struct {
+	wsconn *WSConn
+	subID  uint32
+	// importedChan is set to listen for imported blocks, and finalisedChan for
+	// finalised blocks
+	importedChan  chan *types.Block
+	finalisedChan chan *types.FinalisationInfo
+	done          chan struct{}
+	cancel        chan struct{}
+	cancelTimeout time.Duration
+}
+
+// Listen implementation of Listen interface to listen for imported or finalised
+// blocks and send their decoded events
+func (l *EventsListener) Listen() {
+	go func() {
+		defer func() {
+			if l.importedChan != nil {
+				l.wsconn.BlockAPI.FreeImportedBlockNotifierChannel(l.importedChan)
+			}
+			if l.finalisedChan != nil {
+				l.wsconn.BlockAPI.FreeFinalisedNotifierChannel(l.finalisedChan)
+			}
+			close(l.done)
+		}()
+
+		for {
+			var header *types.Header
+			select {
+			case <-l.cancel:
+				return
+			case block, ok := <-l.importedChan:
+				if !ok {
+					return
+				}
+				if block == nil {
+					continue
+				}
+				header = &block.Header
+			case info, ok := <-l.finalisedChan:
+				if !ok {
+					return
+				}
+				if info == nil {
+					continue
+				}
+				header = &info.Header
+			}
+
+			blockHash := header.Hash()
+			events, err := modules.BlockEvents(l.wsconn.CoreAPI, l.wsconn.StorageAPI, blockHash)
+			if err != nil {
+				logger.Errorf("failed to decode events of block %s: %s", blockHash, err)
+				continue
+			}
+
+			l.wsconn.safeSend(newSubscriptionResponse(stateEventsMethod, l.subID, EventsResult{
+				Block:  blockHash.String(),
+				Events: events,
+			}))
+		}
+	}()
+}
+
+// Stop to cancel the running goroutines to this listener
+func (l *EventsListener) Stop() error {
+	return cancelWithTimeout(l.cancel, l.done, l.cancelTimeout)
+}
+
 func cancelWithTimeout(cancel, done chan struct{}, t time.Duration) error {
 	close(cancel)
 
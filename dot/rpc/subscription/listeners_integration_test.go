@@ -364,3 +364,74 @@ func TestRuntimeChannelListener_Listen(t *testing.T) {
 	time.Sleep(time.Millisecond * 10)
 	require.Equal(t, expectedUpdateResponse, mockConnection.lastMessage)
 }
+
+func TestEventsListener_Listen(t *testing.T) {
+	// runtime metadata V15 without types nor pallets
+	metadata := []byte("meta\x0f\x00\x00\x04\x00\x00\x00\x00\x00")
+	encodedMetadata, err := scale.Marshal(metadata)
+	require.NoError(t, err)
+
+	header := types.NewEmptyHeader()
+	header.Number = 1
+	blockHash := header.Hash()
+
+	tests := map[string]struct {
+		finalised bool
+	}{
+		"imported_blocks":  {},
+		"finalised_blocks": {finalised: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			wsconn, ws, cancel := setupWSConn(t)
+			defer cancel()
+
+			coreAPI := mocks.NewMockCoreAPI(ctrl)
+			coreAPI.EXPECT().GetMetadata(&blockHash).Return(encodedMetadata, nil)
+			coreAPI.EXPECT().CachedBlockEvents(blockHash).Return(nil, false)
+			wsconn.CoreAPI = coreAPI
+
+			storageAPI := mocks.NewMockStorageAPI(ctrl)
+			storageAPI.EXPECT().GetStorageByBlockHash(&blockHash, common.SystemEventsKey).Return(nil, nil)
+			wsconn.StorageAPI = storageAPI
+
+			blockAPI := mocks.NewMockBlockAPI(ctrl)
+			wsconn.BlockAPI = blockAPI
+
+			listener := &EventsListener{
+				wsconn:        wsconn,
+				subID:         5,
+				cancel:        make(chan struct{}, 1),
+				done:          make(chan struct{}, 1),
+				cancelTimeout: time.Second * 5,
+			}
+			importedChan := make(chan *types.Block)
+			finalisedChan := make(chan *types.FinalisationInfo)
+			if tt.finalised {
+				listener.finalisedChan = finalisedChan
+				blockAPI.EXPECT().FreeFinalisedNotifierChannel(finalisedChan)
+			} else {
+				listener.importedChan = importedChan
+				blockAPI.EXPECT().FreeImportedBlockNotifierChannel(importedChan)
+			}
+
+			listener.Listen()
+			if tt.finalised {
+				finalisedChan <- &types.FinalisationInfo{Header: *header}
+			} else {
+				importedChan <- &types.Block{Header: *header}
+			}
+
+			_, msg, err := ws.ReadMessage()
+			require.NoError(t, err)
+
+			expected := `{"jsonrpc":"2.0","method":"state_events","params":` +
+				`{"result":{"block":"%s","events":[]},"subscription":5}}` + "\n"
+			require.Equal(t, fmt.Sprintf(expected, blockHash), string(msg))
+			require.NoError(t, listener.Stop())
+		})
+	}
+}
@@ -69,3 +69,18 @@ func newBooleanResponseJSON(value bool, reqID float64) BooleanResponse {
 		ID:      reqID,
 	}
 }
+
+// StringResponse for responses that return string values
+type StringResponse struct {
+	JSONRPC string  `json:"jsonrpc"`
+	Result  string  `json:"result"`
+	ID      float64 `json:"id"`
+}
+
+func newStringResponseJSON(value string, reqID float64) StringResponse {
+	return StringResponse{
+		JSONRPC: "2.0",
+		Result:  value,
+		ID:      reqID,
+	}
+}
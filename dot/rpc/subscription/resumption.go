@@ -0,0 +1,301 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package subscription
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+// Resumption RPC methods
+const (
+	subscriptionGetResumeToken string = "subscription_getResumeToken"
+	subscriptionResume         string = "subscription_resume"
+)
+
+var (
+	errResumptionDisabled     = errors.New("subscription resumption is disabled")
+	errNotResumable           = errors.New("subscription is not resumable")
+	errUnknownResumeToken     = errors.New("unknown or expired resume token")
+	errSubscriptionAttached   = errors.New("subscription is still attached to a connection")
+	errMissedNotificationsCap = errors.New("too many notifications missed, subscribe again")
+)
+
+// ResumptionStore holds the resumable subscriptions, so a client reconnecting after a short
+// disconnection resumes its subscriptions with their resume token and receives the
+// notifications missed while disconnected. The subscriptions detached from their closed
+// connection are stopped once the resumption window elapsed.
+type ResumptionStore struct {
+	window     time.Duration
+	bufferSize int
+
+	mu            sync.Mutex
+	subscriptions map[string]*resumableSubscription
+}
+
+// NewResumptionStore returns a resumption store keeping the subscriptions detached from their
+// connection during the window given, buffering at most bufferSize notifications for each.
+func NewResumptionStore(window time.Duration, bufferSize int) *ResumptionStore {
+	return &ResumptionStore{
+		window:        window,
+		bufferSize:    bufferSize,
+		subscriptions: make(map[string]*resumableSubscription),
+	}
+}
+
+// add makes resumable the subscription given of the connection given.
+func (rs *ResumptionStore) add(conn *WSConn, subID uint32, listener Listener) (
+	*resumableSubscription, error) {
+	tokenBytes := make([]byte, 16)
+	_, err := rand.Read(tokenBytes)
+	if err != nil {
+		return nil, fmt.Errorf("generating resume token: %w", err)
+	}
+
+	sub := &resumableSubscription{
+		token:    common.BytesToHex(tokenBytes),
+		store:    rs,
+		listener: listener,
+		conn:     conn,
+		subID:    subID,
+	}
+
+	rs.mu.Lock()
+	rs.subscriptions[sub.token] = sub
+	rs.mu.Unlock()
+	return sub, nil
+}
+
+// remove removes the resumable subscription of the token given.
+func (rs *ResumptionStore) remove(token string) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	delete(rs.subscriptions, token)
+}
+
+// get returns the resumable subscription of the token given.
+func (rs *ResumptionStore) get(token string) (sub *resumableSubscription, ok bool) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	sub, ok = rs.subscriptions[token]
+	return sub, ok
+}
+
+// resumableSubscription sends the notifications of a subscription to its connection, or buffers
+// them while the subscription is detached from its closed connection.
+type resumableSubscription struct {
+	token    string
+	store    *ResumptionStore
+	listener Listener
+
+	mu sync.Mutex
+	// conn is the connection the notifications are sent to, nil while detached
+	conn *WSConn
+	// subID is the subscription ID of the subscription on its connection
+	subID uint32
+	// missed holds the notifications missed while detached
+	missed     []BaseResponseJSON
+	overflowed bool
+	expiry     *time.Timer
+}
+
+// send sends the notification given to the connection of the subscription, with the
+// subscription ID on the connection, or buffers it if the subscription is detached.
+func (s *resumableSubscription) send(res BaseResponseJSON) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn != nil {
+		res.Params.SubscriptionID = s.subID
+		s.conn.safeSend(res)
+		return
+	}
+
+	if len(s.missed) >= s.store.bufferSize {
+		s.overflowed = true
+		return
+	}
+	s.missed = append(s.missed, res)
+}
+
+// detach detaches the subscription from its closed connection, the subscription being
+// stopped unless resumed within the resumption window.
+func (s *resumableSubscription) detach() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.conn = nil
+	s.expiry = time.AfterFunc(s.store.window, s.expire)
+}
+
+// expire stops the subscription not resumed within the resumption window.
+func (s *resumableSubscription) expire() {
+	s.store.remove(s.token)
+	err := s.listener.Stop()
+	if err != nil {
+		logger.Warnf("failed to stop expired subscription: %s", err)
+	}
+}
+
+// resume attaches the detached subscription to the connection given with the subscription
+// ID given, answering the resume request of the ID given before sending the notifications
+// missed, so the notifications keep their order.
+func (s *resumableSubscription) resume(conn *WSConn, subID uint32, reqID float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn != nil {
+		return errSubscriptionAttached
+	}
+	if !s.expiry.Stop() {
+		return errUnknownResumeToken
+	}
+	if s.overflowed {
+		s.expiry.Reset(0)
+		return fmt.Errorf("%w: more than %d notifications", errMissedNotificationsCap, s.store.bufferSize)
+	}
+
+	conn.safeSend(NewSubscriptionResponseJSON(subID, reqID))
+	for _, res := range s.missed {
+		res.Params.SubscriptionID = subID
+		conn.safeSend(res)
+	}
+
+	s.conn = conn
+	s.subID = subID
+	s.missed = nil
+	return nil
+}
+
+// makeResumable makes resumable the subscription of the listener given, if the resumption
+// of the subscriptions is enabled, returning nil otherwise.
+func (c *WSConn) makeResumable(subID uint32, listener Listener) *resumableSubscription {
+	if c.Resumptions == nil {
+		return nil
+	}
+
+	sub, err := c.Resumptions.add(c, subID, listener)
+	if err != nil {
+		logger.Warnf("cannot make subscription %d resumable: %s", subID, err)
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.resumable == nil {
+		c.resumable = make(map[uint32]*resumableSubscription)
+	}
+	c.resumable[subID] = sub
+	return sub
+}
+
+// detachResumableSubscriptions detaches the resumable subscriptions of the closed connection.
+func (c *WSConn) detachResumableSubscriptions() {
+	c.mu.Lock()
+	resumable := c.resumable
+	c.resumable = nil
+	c.mu.Unlock()
+
+	for _, sub := range resumable {
+		sub.detach()
+	}
+}
+
+// removeResumable removes the resumable subscription of the subscription ID given, once the
+// subscription is unsubscribed.
+func (c *WSConn) removeResumable(subID uint32) {
+	c.mu.Lock()
+	sub, ok := c.resumable[subID]
+	delete(c.resumable, subID)
+	c.mu.Unlock()
+
+	if ok {
+		c.Resumptions.remove(sub.token)
+	}
+}
+
+// sendResumeToken answers the request of the ID given with the resume token of the
+// subscription of the params given.
+func (c *WSConn) sendResumeToken(reqID float64, params interface{}) {
+	if c.Resumptions == nil {
+		c.safeSendError(reqID, nil, errResumptionDisabled.Error())
+		return
+	}
+
+	subID, err := parseSubscribeID(params)
+	if err != nil {
+		c.safeSendError(reqID, big.NewInt(InvalidRequestCode), InvalidRequestMessage)
+		return
+	}
+
+	c.mu.Lock()
+	sub, ok := c.resumable[subID]
+	c.mu.Unlock()
+	if !ok {
+		c.safeSendError(reqID, nil, fmt.Sprintf("subscriber id %d: %s", subID, errNotResumable))
+		return
+	}
+
+	c.safeSend(newStringResponseJSON(sub.token, reqID))
+}
+
+// resumeSubscription resumes on the connection the subscription of the resume token of the
+// params given, answering with its new subscription ID followed by the notifications missed.
+func (c *WSConn) resumeSubscription(reqID float64, params interface{}) {
+	if c.Resumptions == nil {
+		c.safeSendError(reqID, nil, errResumptionDisabled.Error())
+		return
+	}
+
+	token, err := parseResumeToken(params)
+	if err != nil {
+		c.safeSendError(reqID, big.NewInt(InvalidRequestCode), InvalidRequestMessage)
+		return
+	}
+
+	sub, ok := c.Resumptions.get(token)
+	if !ok {
+		c.safeSendError(reqID, nil, errUnknownResumeToken.Error())
+		return
+	}
+
+	c.mu.Lock()
+	subID := atomic.AddUint32(&c.qtyListeners, 1)
+	c.mu.Unlock()
+
+	err = sub.resume(c, subID, reqID)
+	if err != nil {
+		logger.Debugf("cannot resume subscription: %s", err)
+		c.safeSendError(reqID, nil, err.Error())
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Subscriptions[subID] = sub.listener
+	if c.resumable == nil {
+		c.resumable = make(map[uint32]*resumableSubscription)
+	}
+	c.resumable[subID] = sub
+}
+
+func parseResumeToken(params interface{}) (token string, err error) {
+	p, ok := params.([]interface{})
+	if !ok || len(p) != 1 {
+		return "", fmt.Errorf("%w: expected 1 param", errUnexpectedParamLen)
+	}
+
+	token, ok = p[0].(string)
+	if !ok {
+		return "", fmt.Errorf("%w: %T, expected type string", errUnexpectedType, p[0])
+	}
+	return token, nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package subscription
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testListener struct {
+	stopped chan struct{}
+}
+
+func (*testListener) Listen() {}
+
+func (l *testListener) Stop() error {
+	close(l.stopped)
+	return nil
+}
+
+func Test_resumableSubscription_send(t *testing.T) {
+	t.Parallel()
+
+	store := NewResumptionStore(time.Minute, 2)
+	sub := &resumableSubscription{store: store}
+
+	sub.send(newSubscriptionResponse(chainFinalizedHeadMethod, 1, "first"))
+	sub.send(newSubscriptionResponse(chainFinalizedHeadMethod, 1, "second"))
+	assert.Len(t, sub.missed, 2)
+	assert.False(t, sub.overflowed)
+
+	sub.send(newSubscriptionResponse(chainFinalizedHeadMethod, 1, "third"))
+	assert.Len(t, sub.missed, 2)
+	assert.True(t, sub.overflowed)
+}
+
+func Test_resumableSubscription_expire(t *testing.T) {
+	t.Parallel()
+
+	store := NewResumptionStore(time.Millisecond, 2)
+	listener := &testListener{stopped: make(chan struct{})}
+	sub, err := store.add(nil, 1, listener)
+	require.NoError(t, err)
+
+	sub.detach()
+
+	select {
+	case <-listener.stopped:
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for the expired subscription to stop")
+	}
+	_, ok := store.get(sub.token)
+	assert.False(t, ok)
+}
+
+func TestWSConn_resumeSubscription(t *testing.T) {
+	t.Parallel()
+
+	store := NewResumptionStore(time.Minute, 4)
+	listener := &testListener{stopped: make(chan struct{})}
+
+	oldConn, _, oldCancel := setupWSConn(t)
+	oldConn.Resumptions = store
+	sub := oldConn.makeResumable(1, listener)
+	require.NotNil(t, sub)
+	oldCancel()
+	oldConn.detachResumableSubscriptions()
+
+	sub.send(newSubscriptionResponse(chainFinalizedHeadMethod, 1, "missed"))
+
+	wsconn, ws, cancel := setupWSConn(t)
+	t.Cleanup(cancel)
+	wsconn.Resumptions = store
+	wsconn.Subscriptions = make(map[uint32]Listener)
+
+	wsconn.resumeSubscription(2, []interface{}{sub.token})
+
+	_, msg, err := ws.ReadMessage()
+	require.NoError(t, err)
+	assert.Equal(t, `{"jsonrpc":"2.0","result":1,"id":2}`+"\n", string(msg))
+
+	_, msg, err = ws.ReadMessage()
+	require.NoError(t, err)
+	expected := `{"jsonrpc":"2.0","method":"chain_finalizedHead",` +
+		`"params":{"result":"missed","subscription":1}}` + "\n"
+	assert.Equal(t, expected, string(msg))
+	assert.Equal(t, listener, wsconn.Subscriptions[1])
+
+	// the resumed subscription cannot be resumed again while attached
+	wsconn.resumeSubscription(3, []interface{}{sub.token})
+	_, msg, err = ws.ReadMessage()
+	require.NoError(t, err)
+	expected = `{"jsonrpc":"2.0","error":{"code":null,"message":"subscription is still attached to a connection"},` +
+		`"id":3}` + "\n"
+	assert.Equal(t, expected, string(msg))
+
+	wsconn.resumeSubscription(4, []interface{}{"0x00"})
+	_, msg, err = ws.ReadMessage()
+	require.NoError(t, err)
+	expected = `{"jsonrpc":"2.0","error":{"code":null,"message":"unknown or expired resume token"},"id":4}` + "\n"
+	assert.Equal(t, expected, string(msg))
+}
+
+func Test_parseResumeToken(t *testing.T) {
+	t.Parallel()
+
+	token, err := parseResumeToken([]interface{}{"0x01"})
+	require.NoError(t, err)
+	assert.Equal(t, "0x01", token)
+
+	_, err = parseResumeToken([]interface{}{})
+	assert.ErrorIs(t, err, errUnexpectedParamLen)
+
+	_, err = parseResumeToken([]interface{}{1})
+	assert.ErrorIs(t, err, errUnexpectedType)
+}
@@ -18,6 +18,7 @@
 	chainSubscribeAllHeads         string = "chain_subscribeAllHeads"
 	stateSubscribeStorage          string = "state_subscribeStorage"
 	stateSubscribeRuntimeVersion   string = "state_subscribeRuntimeVersion"
+	stateSubscribeEvents           string = "state_subscribeEvents"
 	grandpaSubscribeJustifications string = "grandpa_subscribeJustifications"
 )
 
@@ -44,6 +45,8 @@ func (c *WSConn) getSetupListener(method string) setupListener {
 		return c.initAllBlocksListerner
 	case stateSubscribeRuntimeVersion:
 		return c.initRuntimeVersionListener
+	case stateSubscribeEvents:
+		return c.initEventsListener
 	case grandpaSubscribeJustifications:
 		return c.initGrandpaJustificationListener
 	default:
@@ -38,6 +38,8 @@ type httpclient interface {
 	errEmptyMethod             = errors.New("empty method")
 	errStorageNotSet           = errors.New("error StorageAPI not set")
 	errBlockAPINotSet          = errors.New("error BlockAPI not set")
+	errCoreAPINotSet           = errors.New("error CoreAPI not set")
+	errUnexpectedParam         = errors.New("unexpected param")
 )
 
 var logger = log.NewFromGlobal(log.AddContext("pkg", "rpc/subscription"))
@@ -55,6 +57,10 @@ type WSConn struct {
 	TxStateAPI    TransactionStateAPI
 	RPCHost       string
 	HTTP          httpclient
+	// Resumptions holds the resumable subscriptions, nil if the resumption is disabled
+	Resumptions *ResumptionStore
+	// resumable holds the resumable subscriptions of the connection by subscription ID
+	resumable map[uint32]*resumableSubscription
 }
 
 // readWebsocketMessage will read and parse the message data to a string->interface{} data
@@ -84,6 +90,7 @@ func (c *WSConn) HandleConn() {
 		if err != nil {
 			logger.Debugf("websocket failed to read message: %s", err)
 			if errors.Is(err, errCannotReadFromWebsocket) {
+				c.detachResumableSubscriptions()
 				return
 			}
 
@@ -94,6 +101,15 @@ func (c *WSConn) HandleConn() {
 		logger.Tracef("websocket message received: %s", string(rawBytes))
 		logger.Debugf("ws method %s called with params %v", wsMessage.Method, wsMessage.Params)
 
+		switch wsMessage.Method {
+		case subscriptionGetResumeToken:
+			c.sendResumeToken(wsMessage.ID, wsMessage.Params)
+			continue
+		case subscriptionResume:
+			c.resumeSubscription(wsMessage.ID, wsMessage.Params)
+			continue
+		}
+
 		if !strings.Contains(wsMessage.Method, "_unsubscribe") && !strings.Contains(wsMessage.Method, "_unwatch") {
 			setupListener := c.getSetupListener(wsMessage.Method)
 
@@ -128,6 +144,9 @@ func (c *WSConn) HandleConn() {
 		}
 
 		err = listener.Stop()
+		if subscribeID, parseErr := parseSubscribeID(wsMessage.Params); parseErr == nil {
+			c.removeResumable(subscribeID)
+		}
 		if err != nil {
 			logger.Warnf("failed to stop listener goroutine (method=%s): %s", wsMessage.Method, err)
 			c.safeSend(newBooleanResponseJSON(false, wsMessage.ID))
@@ -204,6 +223,7 @@ func (c *WSConn) initStorageChangeListener(reqID float64, params interface{}) (L
 
 	c.mu.Unlock()
 
+	stgobs.resumable = c.makeResumable(stgobs.id, stgobs)
 	c.StorageAPI.RegisterStorageObserver(stgobs)
 	initRes := NewSubscriptionResponseJSON(stgobs.id, reqID)
 	c.safeSend(initRes)
@@ -255,6 +275,8 @@ func (c *WSConn) initBlockFinalizedListener(reqID float64, _ interface{}) (Liste
 
 	c.mu.Unlock()
 
+	blockFinalizedListener.resumable = c.makeResumable(blockFinalizedListener.subID, blockFinalizedListener)
+
 	initRes := NewSubscriptionResponseJSON(blockFinalizedListener.subID, reqID)
 	c.safeSend(initRes)
 
@@ -408,6 +430,85 @@ func (c *WSConn) initGrandpaJustificationListener(reqID float64, _ interface{})
 	return jl, nil
 }
 
+// initEventsListener subscribes to the decoded events of the imported blocks, or of the
+// finalised blocks if the "finalized" parameter is given
+func (c *WSConn) initEventsListener(reqID float64, params interface{}) (Listener, error) {
+	if c.BlockAPI == nil {
+		c.safeSendError(reqID, nil, errBlockAPINotSet.Error())
+		return nil, errBlockAPINotSet
+	}
+	if c.StorageAPI == nil {
+		c.safeSendError(reqID, nil, errStorageNotSet.Error())
+		return nil, errStorageNotSet
+	}
+	if c.CoreAPI == nil {
+		c.safeSendError(reqID, nil, errCoreAPINotSet.Error())
+		return nil, errCoreAPINotSet
+	}
+
+	finalised, err := parseEventsParams(params)
+	if err != nil {
+		c.safeSendError(reqID, nil, err.Error())
+		return nil, err
+	}
+
+	el := &EventsListener{
+		wsconn:        c,
+		cancel:        make(chan struct{}, 1),
+		done:          make(chan struct{}, 1),
+		cancelTimeout: defaultCancelTimeout,
+	}
+	if finalised {
+		el.finalisedChan = c.BlockAPI.GetFinalisedNotifierChannel()
+	} else {
+		el.importedChan = c.BlockAPI.GetImportedBlockNotifierChannel()
+	}
+
+	c.mu.Lock()
+
+	el.subID = atomic.AddUint32(&c.qtyListeners, 1)
+	c.Subscriptions[el.subID] = el
+
+	c.mu.Unlock()
+
+	c.safeSend(NewSubscriptionResponseJSON(el.subID, reqID))
+
+	return el, nil
+}
+
+// parseEventsParams returns true if the events of the finalised blocks are requested,
+// with the "finalized" parameter, rather than the ones of the imported blocks, with the
+// "best" parameter or no parameter.
+func parseEventsParams(params interface{}) (finalised bool, err error) {
+	var blocks string
+	switch p := params.(type) {
+	case nil:
+	case []interface{}:
+		switch len(p) {
+		case 0:
+		case 1:
+			var ok bool
+			blocks, ok = p[0].(string)
+			if !ok {
+				return false, fmt.Errorf("%w: %T, expected type string", errUnexpectedType, p[0])
+			}
+		default:
+			return false, fmt.Errorf("%w: expected at most 1 param, got: %d", errUnexpectedParamLen, len(p))
+		}
+	default:
+		return false, fmt.Errorf("%w: %T, expected type []interface{}", errUnexpectedType, params)
+	}
+
+	switch blocks {
+	case "", "best":
+		return false, nil
+	case "finalized":
+		return true, nil
+	default:
+		return false, fmt.Errorf("%w: %q, expected \"best\" or \"finalized\"", errUnexpectedParam, blocks)
+	}
+}
+
 func (c *WSConn) safeSend(msg interface{}) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -728,3 +728,28 @@ func TestWSConn_CheckWebsocketInvalidData(t *testing.T) {
 		require.Equal(t, tt.expected, msg)
 	}
 }
+
+func Test_parseEventsParams(t *testing.T) {
+	tests := map[string]struct {
+		params     interface{}
+		finalised  bool
+		errWrapped error
+	}{
+		"no_params":       {},
+		"empty_params":    {params: []interface{}{}},
+		"best":            {params: []interface{}{"best"}},
+		"finalized":       {params: []interface{}{"finalized"}, finalised: true},
+		"unknown_blocks":  {params: []interface{}{"pending"}, errWrapped: errUnexpectedParam},
+		"not_a_string":    {params: []interface{}{1.0}, errWrapped: errUnexpectedType},
+		"too_many_params": {params: []interface{}{"best", "finalized"}, errWrapped: errUnexpectedParamLen},
+		"unexpected_type": {params: "finalized", errWrapped: errUnexpectedType},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			finalised, err := parseEventsParams(tt.params)
+			require.ErrorIs(t, err, tt.errWrapped)
+			require.Equal(t, tt.finalised, finalised)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package rpc
+
+import (
+	"net/http"
+
+	"github.com/ChainSafe/gossamer/internal/tracing"
+	"github.com/gorilla/rpc/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/ChainSafe/gossamer/dot/rpc"
+
+// startCallSpan returns the intercept function of the RPC server starting the span of each
+// RPC call, the span is carried by the context of the request given to the RPC method.
+func startCallSpan(tracer trace.Tracer) func(info *rpc.RequestInfo) *http.Request {
+	return func(info *rpc.RequestInfo) *http.Request {
+		method, err := snakeCaseFormat(info.Method)
+		if err != nil {
+			method = info.Method
+		}
+
+		ctx, _ := tracer.Start(info.Request.Context(), method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("rpc.system", "jsonrpc"),
+				attribute.String("rpc.method", method),
+			))
+		return info.Request.WithContext(ctx)
+	}
+}
+
+// endCallSpan is the after function of the RPC server ending the span started by startCallSpan.
+func endCallSpan(info *rpc.RequestInfo) {
+	span := trace.SpanFromContext(info.Request.Context())
+	tracing.EndSpan(span, info.Error)
+}
@@ -0,0 +1,46 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package rpc
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/rpc/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func Test_callSpan(t *testing.T) {
+	t.Parallel()
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer(tracerName)
+
+	request := httptest.NewRequest("POST", "/", nil)
+	request = startCallSpan(tracer)(&rpc.RequestInfo{
+		Request: request,
+		Method:  "chain.GetBlock",
+	})
+	assert.True(t, trace.SpanFromContext(request.Context()).IsRecording())
+
+	endCallSpan(&rpc.RequestInfo{
+		Request: request,
+		Method:  "chain.GetBlock",
+		Error:   errors.New("test error"),
+	})
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "chain_getBlock", spans[0].Name())
+	assert.Equal(t, trace.SpanKindServer, spans[0].SpanKind())
+	assert.Contains(t, spans[0].Attributes(), attribute.String("rpc.method", "chain_getBlock"))
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
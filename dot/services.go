@@ -4,26 +4,39 @@
 package dot
 
 import (
+	"crypto/rand"
 	"errors"
 	"fmt"
+	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	cfg "github.com/ChainSafe/gossamer/config"
 
+	"github.com/ChainSafe/gossamer/dot/changefeed"
 	"github.com/ChainSafe/gossamer/dot/core"
 	"github.com/ChainSafe/gossamer/dot/digest"
+	"github.com/ChainSafe/gossamer/dot/fisherman"
+	"github.com/ChainSafe/gossamer/dot/hooks"
 	"github.com/ChainSafe/gossamer/dot/network"
 	"github.com/ChainSafe/gossamer/dot/rpc"
+	"github.com/ChainSafe/gossamer/dot/rpc/grpc"
 	"github.com/ChainSafe/gossamer/dot/rpc/modules"
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/sync"
 	"github.com/ChainSafe/gossamer/dot/system"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/dot/warmup"
+	"github.com/ChainSafe/gossamer/internal/chaos"
+	"github.com/ChainSafe/gossamer/internal/clock"
 	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/internal/failover"
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/internal/metrics"
 	"github.com/ChainSafe/gossamer/internal/pprof"
+	"github.com/ChainSafe/gossamer/internal/watchdog"
+	"github.com/ChainSafe/gossamer/lib/aura"
 	"github.com/ChainSafe/gossamer/lib/babe"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
@@ -35,16 +48,20 @@
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+	"github.com/ChainSafe/gossamer/lib/services"
+	"github.com/ChainSafe/gossamer/pkg/scale"
 )
 
 const blockRequestTimeout = 20 * time.Second
 
 // BlockProducer to produce blocks
 type BlockProducer interface {
+	service
 	Pause() error
 	Resume() error
 	EpochLength() uint64
 	SlotDuration() uint64
+	SealBlock() (*types.Block, error)
 }
 
 type rpcServiceSettings struct {
@@ -57,12 +74,23 @@ type rpcServiceSettings struct {
 	system        *system.Service
 	blockFinality *grandpa.Service
 	syncer        rpc.SyncAPI
+	role          nodeRole
 }
 
 func newInMemoryDB() (database.Database, error) {
 	return database.LoadDatabase("", true)
 }
 
+// newChaosInjector returns the injector of the faults set with --chaos, which is nil if
+// no fault is set. Each service gets its own injector, seeded the same.
+func newChaosInjector(config *cfg.Config) (*chaos.Injector, error) {
+	chaosConfig, err := chaos.ParseConfig(config.Chaos)
+	if err != nil {
+		return nil, fmt.Errorf("parsing chaos: %w", err)
+	}
+	return chaos.New(chaosConfig), nil
+}
+
 // createStateService creates the state service and initialise state database
 func (nodeBuilder) createStateService(config *cfg.Config) (*state.Service, error) {
 	logger.Debug("creating state service...")
@@ -93,9 +121,17 @@ func (nodeBuilder) createStateService(config *cfg.Config) (*state.Service, error
 	}
 	defer genesisRuntime.Stop()
 
-	babeCfg, err := genesisRuntime.BabeConfiguration()
-	if err != nil {
-		return nil, fmt.Errorf("getting babe configuration: %w", err)
+	var babeCfg *types.BabeConfiguration
+	if gen.ConsensusEngine == aura.EngineName {
+		babeCfg, err = aura.GenesisConfiguration(genesisRuntime)
+		if err != nil {
+			return nil, fmt.Errorf("getting aura configuration: %w", err)
+		}
+	} else {
+		babeCfg, err = genesisRuntime.BabeConfiguration()
+		if err != nil {
+			return nil, fmt.Errorf("getting babe configuration: %w", err)
+		}
 	}
 
 	stateLogLevel, err := log.ParseLevel(config.Log.State)
@@ -103,11 +139,34 @@ func (nodeBuilder) createStateService(config *cfg.Config) (*state.Service, error
 		return nil, err
 	}
 
+	chaosInjector, err := newChaosInjector(config)
+	if err != nil {
+		return nil, err
+	}
+
+	pruneBlockBodies, retainedBlockBodies, err := cfg.ParseBlocksPruning(config.State.BlocksPruning)
+	if err != nil {
+		return nil, fmt.Errorf("parsing blocks pruning: %w", err)
+	}
+
 	stateConfig := state.Config{
-		Path:              config.BasePath,
-		LogLevel:          stateLogLevel,
-		Metrics:           metrics.NewIntervalConfig(config.PrometheusExternal),
-		GenesisBABEConfig: babeCfg,
+		Path:                config.BasePath,
+		LogLevel:            stateLogLevel,
+		Metrics:             metrics.NewIntervalConfig(config.PrometheusExternal),
+		GenesisBABEConfig:   babeCfg,
+		TrieCacheSize:       config.State.TrieCacheSize,
+		Snapshot:            config.State.Snapshot,
+		PruneJustifications: config.State.PruneJustifications,
+		PruneEpochs:         config.State.PruneEpochs,
+		PruneBlockBodies:    pruneBlockBodies,
+		RetainedBlockBodies: retainedBlockBodies,
+		Light:               config.Core.Role == common.LightClientRole,
+		Chaos:               chaosInjector,
+		TransactionPoolLimits: state.TransactionPoolLimits{
+			Count: config.Core.PoolLimit,
+			Bytes: config.Core.PoolKbytes * 1024,
+		},
+		TransactionBanDuration: config.Core.TxBanDuration,
 	}
 
 	stateSrvc := state.NewService(stateConfig)
@@ -122,8 +181,19 @@ func (nodeBuilder) createStateService(config *cfg.Config) (*state.Service, error
 func startStateService(config cfg.StateConfig, stateSrvc *state.Service) error {
 	logger.Debug("starting state service...")
 
+	err := stateSrvc.CheckConsistency()
+	if errors.Is(err, state.ErrInconsistentDatabase) && config.Repair {
+		logger.Warnf("repairing database: %s", err)
+		_, err = stateSrvc.Repair()
+	}
+	if errors.Is(err, state.ErrInconsistentDatabase) {
+		return fmt.Errorf("%w, restart the node with --repair to roll back to a consistent block", err)
+	} else if err != nil {
+		return fmt.Errorf("checking database consistency: %w", err)
+	}
+
 	// start state service (initialise state database)
-	err := stateSrvc.Start()
+	err = stateSrvc.Start()
 	if err != nil {
 		return fmt.Errorf("failed to start state service: %w", err)
 	}
@@ -184,8 +254,18 @@ func createRuntime(config *cfg.Config, ns runtime.NodeStorage, st *state.Service
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse wasmer log level: %w", err)
 	}
+	wasmCacheDir := config.Core.WasmCacheDir
+	if wasmCacheDir == "" {
+		wasmCacheDir = filepath.Join(config.BasePath, "wasm-cache")
+	}
+
 	switch config.Core.WasmInterpreter {
 	case wazero_runtime.Name:
+		compilationCacheDir, err := wazero_runtime.PrepareCompilationCacheDir(wasmCacheDir, cfg.GetFullVersion())
+		if err != nil {
+			return nil, fmt.Errorf("preparing wasm cache directory: %w", err)
+		}
+
 		rtCfg := wazero_runtime.Config{
 			Storage:     ts,
 			Keystore:    ks,
@@ -195,6 +275,12 @@ func createRuntime(config *cfg.Config, ns runtime.NodeStorage, st *state.Service
 			Transaction: st.Transaction,
 			Role:        config.Core.Role,
 			CodeHash:    codeHash,
+			InstanceConfig: runtime.InstanceConfig{
+				StubMissingHostFunctions: config.Core.StubMissingHostFunctions,
+				MaxHeapPages:             config.Core.MaxHeapPages,
+				CompilationCacheDir:      compilationCacheDir,
+				Metering:                 config.Core.WasmMetering,
+			},
 		}
 
 		// create runtime executor
@@ -265,11 +351,18 @@ func (nodeBuilder) createBABEServiceWithBuilder(config *cfg.Config, st *state.Se
 		BlockImportHandler: cs,
 		Authority:          config.Core.BabeAuthority,
 		IsDev:              config.ID == "dev",
+		SealMode:           babe.SealMode(config.Core.DevSeal),
+		AuthoringDryRun:    config.Core.AuthoringDryRun,
 		Telemetry:          telemetryMailer,
 	}
 
+	if config.Core.AuthoringDryRun {
+		logger.Warn("BABE authoring dry run: the blocks built are not imported nor broadcast")
+	}
+
 	if config.Core.BabeAuthority {
 		bcfg.Keypair = kps[0].(*sr25519.Keypair)
+		bcfg.Keystore = ks
 	}
 
 	bs, err := newBabeService.NewServiceIFace(bcfg)
@@ -280,6 +373,62 @@ func (nodeBuilder) createBABEServiceWithBuilder(config *cfg.Config, st *state.Se
 	return bs, nil
 }
 
+func (nodeBuilder) createAuraVerifier(st *state.Service) (*aura.Verifier, error) {
+	slotDuration, err := st.Epoch.GetSlotDuration()
+	if err != nil {
+		return nil, fmt.Errorf("getting slot duration: %w", err)
+	}
+
+	return aura.NewVerifier(st.Block, st.Storage, slotDuration), nil
+}
+
+// createAuraService creates the Aura block production service of chains selecting Aura in their chain spec.
+// The Aura authority is configured the same way as the BABE authority.
+func (nodeBuilder) createAuraService(config *cfg.Config, st *state.Service, ks KeyStore,
+	cs *core.Service, telemetryMailer Telemetry) (*aura.Service, error) {
+	logger.Info("creating Aura service" +
+		asAuthority(config.Core.BabeAuthority) + "...")
+
+	if ks.Name() != keystore.AuraName || ks.Type() != crypto.Sr25519Type {
+		return nil, ErrInvalidKeystoreType
+	}
+
+	// the blocks of a collator are built by the collator service against the relay chain,
+	// so the Aura service of a collator seals them without running its own slots
+	collating := config.Collator.Enabled
+	kps := ks.Keypairs()
+	if len(kps) == 0 && (config.Core.BabeAuthority || collating) {
+		return nil, ErrNoKeysProvided
+	}
+
+	auraLogLevel, err := log.ParseLevel(config.Log.Babe)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse babe log level: %w", err)
+	}
+
+	slotDuration, err := st.Epoch.GetSlotDuration()
+	if err != nil {
+		return nil, fmt.Errorf("getting slot duration: %w", err)
+	}
+
+	auraConfig := &aura.ServiceConfig{
+		LogLvl:             auraLogLevel,
+		BlockState:         st.Block,
+		StorageState:       st.Storage,
+		TransactionState:   st.Transaction,
+		BlockImportHandler: cs,
+		Authority:          config.Core.BabeAuthority && !collating,
+		SlotDuration:       slotDuration,
+		Telemetry:          telemetryMailer,
+	}
+
+	if config.Core.BabeAuthority || collating {
+		auraConfig.Keypair = kps[0].(*sr25519.Keypair)
+	}
+
+	return aura.NewService(auraConfig)
+}
+
 // Core Service
 
 // createCoreService creates the core service from the provided core configuration
@@ -316,7 +465,7 @@ func (nodeBuilder) createCoreService(config *cfg.Config, ks *keystore.GlobalKeys
 		EpochState:           st.Epoch,
 		CodeSubstitutes:      codeSubs,
 		CodeSubstitutedState: st.Base,
-		OnBlockImport:        digest.NewBlockImportHandler(st.Epoch, st.Grandpa),
+		OnBlockImport:        digest.NewBlockImportHandler(newDigestEngineRegistry(st)),
 	}
 
 	// create new core service
@@ -349,33 +498,62 @@ func (nodeBuilder) createNetworkService(config *cfg.Config, stateSrvc *state.Ser
 		return nil, fmt.Errorf("failed to parse network log level: %w", err)
 	}
 
+	compression, err := network.ParseCompression(config.Network.Compression)
+	if err != nil {
+		return nil, fmt.Errorf("parsing network compression: %w", err)
+	}
+
+	chaosInjector, err := newChaosInjector(config)
+	if err != nil {
+		return nil, err
+	}
+
 	warpSyncProvider := grandpa.NewWarpSyncProofProvider(
 		stateSrvc.Block, stateSrvc.Grandpa,
 	)
 
 	// network service configuation
 	networkConfig := network.Config{
-		LogLvl:            networkLogLevel,
-		BlockState:        stateSrvc.Block,
-		BasePath:          config.BasePath,
-		Roles:             config.Core.Role,
-		Port:              config.Network.Port,
-		Bootnodes:         config.Network.Bootnodes,
-		ProtocolID:        config.Network.ProtocolID,
-		NoBootstrap:       config.Network.NoBootstrap,
-		NoMDNS:            config.Network.NoMDNS,
-		MinPeers:          config.Network.MinPeers,
-		MaxPeers:          config.Network.MaxPeers,
-		PersistentPeers:   config.Network.PersistentPeers,
-		DiscoveryInterval: config.Network.DiscoveryInterval,
-		SlotDuration:      slotDuration,
-		PublicIP:          config.Network.PublicIP,
-		Telemetry:         telemetryMailer,
-		PublicDNS:         config.Network.PublicDNS,
-		Metrics:           metrics.NewIntervalConfig(config.PrometheusExternal),
-		NodeKey:           config.Network.NodeKey,
-		ListenAddress:     config.Network.ListenAddress,
-		WarpSyncProvider:  warpSyncProvider,
+		LogLvl:             networkLogLevel,
+		BlockState:         stateSrvc.Block,
+		BasePath:           config.BasePath,
+		Roles:              config.Core.Role,
+		Port:               config.Network.Port,
+		Bootnodes:          config.Network.Bootnodes,
+		ProtocolID:         config.Network.ProtocolID,
+		NoBootstrap:        config.Network.NoBootstrap,
+		NoMDNS:             config.Network.NoMDNS,
+		MinPeers:           config.Network.MinPeers,
+		MaxPeers:           config.Network.MaxPeers,
+		PersistentPeers:    config.Network.PersistentPeers,
+		DiscoveryInterval:  config.Network.DiscoveryInterval,
+		SlotDuration:       slotDuration,
+		PublicIP:           config.Network.PublicIP,
+		Telemetry:          telemetryMailer,
+		PublicDNS:          config.Network.PublicDNS,
+		PublicAddresses:    config.Network.PublicAddresses,
+		Metrics:            metrics.NewIntervalConfig(config.PrometheusExternal),
+		NodeKey:            config.Network.NodeKey,
+		ListenAddresses:    config.Network.ListenAddresses,
+		WarpSyncProvider:   warpSyncProvider,
+		StateSyncProvider:  sync.NewStateResponseProvider(stateSrvc.Block, stateSrvc.Storage),
+		ForceTxPropagation: config.Network.ForceTxPropagation,
+		Compression:        compression,
+		MaxBandwidth:       uint64(config.Network.MaxBandwidth),
+		NoPrivateIPv4:      config.Network.NoPrivateIPv4,
+		ResourceLimits: network.ResourceLimits{
+			MaxStreams:        int(config.Network.MaxStreams),         //nolint:gosec
+			MaxStreamsPerPeer: int(config.Network.MaxStreamsPerPeer),  //nolint:gosec
+			MaxMemory:         int64(config.Network.MaxNetworkMemory), //nolint:gosec
+			MaxConnsPerPeer:   int(config.Network.MaxConnsPerPeer),    //nolint:gosec
+			MaxConnsPerIP:     int(config.Network.MaxConnsPerIP),      //nolint:gosec
+			MaxConnsPerSubnet: int(config.Network.MaxConnsPerSubnet),  //nolint:gosec
+		},
+		Chaos: chaosInjector,
+
+		BadgerGCInterval:        config.Network.BadgerGCInterval,
+		BadgerGCDiscardRatio:    config.Network.BadgerGCDiscardRatio,
+		BadgerGCPauseDuringSync: config.Network.BadgerGCPauseDuringSync,
 	}
 
 	networkSrvc, err := network.NewService(&networkConfig)
@@ -407,7 +585,7 @@ func (nodeBuilder) createRPCService(params rpcServiceSettings) (*rpc.HTTPServer,
 		return nil, fmt.Errorf("failed to load genesis data: %s", err)
 	}
 
-	syncStateSrvc, err := modules.NewStateSync(genesisData, params.state.Storage)
+	syncStateSrvc, err := modules.NewStateSync(genesisData, params.state.Storage, params.state)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create sync state service: %s", err)
 	}
@@ -416,6 +594,12 @@ func (nodeBuilder) createRPCService(params rpcServiceSettings) (*rpc.HTTPServer,
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse rpc log level: %w", err)
 	}
+	rpcModules := params.config.RPC.Modules
+	if params.config.Core.DevSeal == string(babe.ManualSeal) && !slices.Contains(rpcModules, "engine") {
+		// blocks are only authored through the engine module in manual seal mode
+		rpcModules = append(slices.Clone(rpcModules), "engine")
+	}
+
 	rpcConfig := &rpc.HTTPServerConfig{
 		LogLvl:              rpcLogLevel,
 		BlockAPI:            params.state.Block,
@@ -425,10 +609,13 @@ func (nodeBuilder) createRPCService(params rpcServiceSettings) (*rpc.HTTPServer,
 		NodeStorage:         params.nodeStorage,
 		BlockProducerAPI:    params.blockProducer,
 		BlockFinalityAPI:    params.blockFinality,
+		BlockFinaliserAPI:   params.state.Block,
 		TransactionQueueAPI: params.state.Transaction,
 		RPCAPI:              rpcService,
 		SyncStateAPI:        syncStateSrvc,
 		SyncAPI:             params.syncer,
+		DatabaseAPI:         databaseCompacter{db: params.state.DB(), network: params.network},
+		EpochAPI:            params.state.Epoch,
 		SystemAPI:           params.system,
 		RPCUnsafe:           params.config.RPC.UnsafeRPC,
 		RPCExternal:         params.config.RPC.RPCExternal,
@@ -438,12 +625,139 @@ func (nodeBuilder) createRPCService(params rpcServiceSettings) (*rpc.HTTPServer,
 		WSExternal:          params.config.RPC.WSExternal,
 		WSUnsafeExternal:    params.config.RPC.UnsafeWSExternal,
 		WSPort:              params.config.RPC.WSPort,
-		Modules:             params.config.RPC.Modules,
+		Modules:             rpcModules,
+		NoHealthEndpoint:    params.config.RPC.NoHealthEndpoint,
+		CorsOrigins:         params.config.RPC.Cors,
+		TLSCertFile:         params.config.RPC.TLSCert,
+		TLSKeyFile:          params.config.RPC.TLSKey,
+		UnixSocket:          params.config.RPC.UnixSocket,
+		WSUnixSocket:        params.config.RPC.WSUnixSocket,
+		WSResumeWindow:      params.config.RPC.WSResumeWindow,
+		WSResumeBufferSize:  params.config.RPC.WSResumeBuffer,
+	}
+	if params.role != nil {
+		rpcConfig.RoleAPI = params.role
 	}
 
 	return rpc.NewHTTPServer(rpcConfig), nil
 }
 
+// createFailoverCoordinator creates the coordinator of the failover between the nodes holding
+// the same authority keys, switching the role given while the node holds the lease file.
+func createFailoverCoordinator(config *cfg.Config, role *roleSwitcher) (*failover.Coordinator, error) {
+	holderID := make([]byte, 8)
+	_, err := rand.Read(holderID)
+	if err != nil {
+		return nil, fmt.Errorf("generating lease holder id: %w", err)
+	}
+
+	// the holder is unique to the process, a restarted node waiting for its lease to expire
+	holder := fmt.Sprintf("%s-%x", config.Name, holderID)
+	lease := failover.NewFileLease(config.Core.FailoverLease)
+	return failover.NewCoordinator(lease, holder, config.Core.FailoverLeaseTTL, role, config.Core.Standby), nil
+}
+
+// createWatchdog creates the watchdog restarting the block producer, the GRANDPA voter and the
+// sync engine when they make no progress within their configured window. It returns nil if
+// no service is watched.
+func createWatchdog(config *cfg.Config, bp BlockProducer, fg *grandpa.Service, syncer service) *watchdog.Watchdog {
+	var targets []watchdog.Target
+	addTarget := func(srvc service, window time.Duration) {
+		watched, ok := srvc.(watchdog.Service)
+		if window == 0 || !ok {
+			return
+		}
+		// the name is the value of the pprof label of the goroutines started by the service
+		targets = append(targets, watchdog.Target{Name: services.Name(srvc), Service: watched, Window: window})
+	}
+
+	addTarget(bp, config.Core.WatchdogBlockProduction)
+	addTarget(fg, config.Core.WatchdogFinality)
+	addTarget(syncer, config.Core.WatchdogSync)
+	if len(targets) == 0 {
+		return nil
+	}
+	return watchdog.NewWatchdog(targets...)
+}
+
+// createChangefeed creates the service appending the changes of the watched storage prefixes
+// at each block finalised to the storage changefeed file. It returns nil if no storage prefix
+// is watched.
+func createChangefeed(config *cfg.Config, st *state.Service) (*changefeed.Service, error) {
+	if len(config.State.StorageWatch) == 0 {
+		return nil, nil
+	}
+
+	prefixes := make([][]byte, len(config.State.StorageWatch))
+	for i, value := range config.State.StorageWatch {
+		prefix, err := changefeed.ParsePrefix(value)
+		if err != nil {
+			return nil, fmt.Errorf("parsing storage watch: %w", err)
+		}
+		prefixes[i] = prefix
+	}
+
+	path := config.State.StorageWatchFile
+	if path == "" {
+		path = filepath.Join(config.BasePath, "storage-changefeed.jsonl")
+	}
+	return changefeed.NewService(st.Block, st.Storage, prefixes, path), nil
+}
+
+// createWarmup creates the service warming the caches on startup, or returns nil if the warmup
+// is disabled. Light clients only store the runtime code, so they have no state to warm.
+func createWarmup(config *cfg.Config, st *state.Service) *warmup.Service {
+	if config.State.WarmupBlocks == 0 || config.Core.Role == common.LightClientRole {
+		return nil
+	}
+	return warmup.NewService(st.Block, st.Storage, config.State.WarmupBlocks)
+}
+
+// createBlockHooks creates the service notifying the configured webhook and command of the
+// blocks imported and finalised, with their events decoded with the runtime metadata. It
+// returns nil if no hook is configured.
+func createBlockHooks(config *cfg.Config, st *state.Service, cs *core.Service) (*hooks.Service, error) {
+	var sinks []hooks.Sink
+	if config.BlockHookURL != "" {
+		sinks = append(sinks, hooks.NewWebhookSink(config.BlockHookURL))
+	}
+	if config.BlockHookExec != "" {
+		sink, err := hooks.NewExecSink(config.BlockHookExec)
+		if err != nil {
+			return nil, fmt.Errorf("creating exec hook: %w", err)
+		}
+		sinks = append(sinks, sink)
+	}
+	if len(sinks) == 0 {
+		return nil, nil
+	}
+
+	events := func(blockHash common.Hash) ([]scale.EventRecord, error) {
+		return modules.BlockEvents(cs, st.Storage, blockHash)
+	}
+	return hooks.NewService(st.Block, events, sinks...), nil
+}
+
+// createGRPCService creates the gRPC server serving the chain, state and author services
+func createGRPCService(config *cfg.Config, stateSrvc *state.Service, coreSrvc *core.Service) (*grpc.Server, error) {
+	logger.Infof("creating grpc service with port %d and external=%t", config.RPC.GRPCPort, config.RPC.GRPCExternal)
+
+	rpcLogLevel, err := log.ParseLevel(config.Log.RPC)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse rpc log level: %w", err)
+	}
+
+	return grpc.NewServer(grpc.Config{
+		LogLvl:              rpcLogLevel,
+		Port:                config.RPC.GRPCPort,
+		External:            config.RPC.GRPCExternal,
+		BlockAPI:            stateSrvc.Block,
+		StorageAPI:          stateSrvc.Storage,
+		CoreAPI:             coreSrvc,
+		TransactionStateAPI: stateSrvc.Transaction,
+	}), nil
+}
+
 // createSystemService creates a systemService for providing system related information
 func (nodeBuilder) createSystemService(cfg *types.SystemInfo, stateSrvc *state.Service) (*system.Service, error) {
 	genesisData, err := stateSrvc.Base.LoadGenesisData()
@@ -483,6 +797,12 @@ func (nodeBuilder) createGRANDPAService(config *cfg.Config, st *state.Service, k
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse grandpa log level: %w", err)
 	}
+
+	chaosInjector, err := newChaosInjector(config)
+	if err != nil {
+		return nil, err
+	}
+
 	gsCfg := &grandpa.Config{
 		LogLvl:       grandpaLogLevel,
 		BlockState:   st.Block,
@@ -492,10 +812,21 @@ func (nodeBuilder) createGRANDPAService(config *cfg.Config, st *state.Service, k
 		Network:      net,
 		Interval:     config.Core.GrandpaInterval,
 		Telemetry:    telemetryMailer,
+		Chaos:        chaosInjector,
 	}
 
 	if config.Core.GrandpaAuthority {
 		gsCfg.Keypair = keys[0].(*ed25519.Keypair)
+		gsCfg.Keystore = ks
+	}
+
+	if config.Core.GrandpaPause != "" {
+		block, delay, err := cfg.ParseGrandpaPause(config.Core.GrandpaPause)
+		if err != nil {
+			return nil, fmt.Errorf("parsing grandpa pause: %w", err)
+		}
+		gsCfg.VotingPause = &grandpa.VotingPause{Block: block, Delay: delay}
+		logger.Warnf("GRANDPA votes will not go past block #%d until block #%d", block, block+delay)
 	}
 
 	return grandpa.NewService(gsCfg)
@@ -505,8 +836,41 @@ func (nodeBuilder) createBlockVerifier(st *state.Service) *babe.VerificationMana
 	return babe.NewVerificationManager(st.Block, st.Slot, st.Epoch)
 }
 
+// createClockGuard creates the guard of the local clock against the network time,
+// estimating the drift of the local clock from the slots of the network blocks
+func (nodeBuilder) createClockGuard(config *cfg.Config, st *state.Service) (*clock.Guard, error) {
+	slotDuration, err := st.Epoch.GetSlotDuration()
+	if err != nil {
+		return nil, fmt.Errorf("getting slot duration: %w", err)
+	}
+
+	return clock.NewGuard(slotDuration, config.Core.MaxClockDrift, config.Core.NTPServer), nil
+}
+
+// blockMisbehaviours maps the block verification errors caused by the block author
+// to the kind of misbehaviour recorded by the fisherman
+var blockMisbehaviours = map[error]fisherman.Kind{
+	babe.ErrBadSignature:           fisherman.BadSeal,
+	aura.ErrBadSignature:           fisherman.BadSeal,
+	babe.ErrBadSlotClaim:           fisherman.InvalidSlotClaim,
+	babe.ErrBadSecondarySlotClaim:  fisherman.InvalidSlotClaim,
+	babe.ErrVRFOutputOverThreshold: fisherman.InvalidSlotClaim,
+	babe.ErrProducerEquivocated:    fisherman.Equivocation,
+	sync.ErrInvalidStateRoot:       fisherman.InvalidStateRoot,
+}
+
+// newFisherman returns the fisherman recording the misbehaviours of the block authors,
+// appending them to the misbehaviour report file if configured.
+func newFisherman(config *cfg.Config, telemetryMailer Telemetry) *fisherman.Fisherman {
+	var reporters []fisherman.Reporter
+	if config.Core.MisbehaviourReport != "" {
+		reporters = append(reporters, fisherman.NewFileReporter(config.Core.MisbehaviourReport))
+	}
+	return fisherman.New(fisherman.ErrorIs(blockMisbehaviours), telemetryMailer, reporters...)
+}
+
 func (nodeBuilder) newSyncService(config *cfg.Config, st *state.Service, fg sync.FinalityGadget,
-	verifier *babe.VerificationManager, cs *core.Service, net *network.Service, telemetryMailer Telemetry) (
+	verifier sync.BabeVerifier, cs *core.Service, net *network.Service, telemetryMailer Telemetry) (
 	network.Syncer, error) {
 	slotDuration, err := st.Epoch.GetSlotDuration()
 	if err != nil {
@@ -531,6 +895,12 @@ func (nodeBuilder) newSyncService(config *cfg.Config, st *state.Service, fg sync
 		Telemetry:          telemetryMailer,
 		BadBlocks:          genesisData.BadBlocks,
 		RequestMaker:       requestMaker,
+		// light nodes only import the block headers, without executing the blocks
+		Light:               config.Core.Role == common.LightClientRole,
+		HeaderImportHandler: cs,
+		MemoryBudget:        uint64(config.Core.SyncKbytes) * 1024,
+
+		MisbehaviourRecorder: newFisherman(config, telemetryMailer),
 	}
 	fullSync := sync.NewFullSyncStrategy(syncCfg)
 
@@ -544,10 +914,42 @@ func (nodeBuilder) newSyncService(config *cfg.Config, st *state.Service, fg sync
 }
 
 func (nodeBuilder) createDigestHandler(st *state.Service) (*digest.Handler, error) {
-	return digest.NewHandler(st.Block, st.Epoch, st.Grandpa)
+	return digest.NewHandler(st.Block, newDigestEngineRegistry(st))
+}
+
+// newDigestEngineRegistry returns the registry of the consensus engines handling
+// the consensus digests of the imported and finalised blocks.
+func newDigestEngineRegistry(st *state.Service) *digest.EngineRegistry {
+	return digest.NewDefaultEngineRegistry(st.Epoch, st.Grandpa)
 }
 
 func createPprofService(config cfg.PprofConfig) (service *pprof.Service) {
 	pprofLogger := log.NewFromGlobal(log.AddContext("pkg", "pprof"))
 	return pprof.NewService(config, pprofLogger)
 }
+
+var errTrieCacheDisabled = errors.New("trie cache is disabled")
+
+// setPprofDiagnostics sets the database, trie cache and libp2p resource manager
+// diagnostics served by the pprof server, the network service being nil if disabled.
+func setPprofDiagnostics(pprofSrvc *pprof.Service, stateSrvc *state.Service, networkSrvc *network.Service) {
+	if db, ok := stateSrvc.DB().(interface{ Stats() database.Stats }); ok {
+		pprofSrvc.SetDiagnostic("database", func() (any, error) {
+			return db.Stats(), nil
+		})
+	}
+
+	pprofSrvc.SetDiagnostic("trie-cache", func() (any, error) {
+		stats, enabled := stateSrvc.Storage.TrieCacheStats()
+		if !enabled {
+			return nil, errTrieCacheDisabled
+		}
+		return stats, nil
+	})
+
+	if networkSrvc != nil {
+		pprofSrvc.SetDiagnostic("resource-manager", func() (any, error) {
+			return networkSrvc.ResourceManagerStat()
+		})
+	}
+}
@@ -5,10 +5,15 @@
 
 import (
 	"testing"
+	"time"
 
 	"github.com/ChainSafe/gossamer/dot/state"
+	dotsync "github.com/ChainSafe/gossamer/dot/sync"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/babe"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/grandpa"
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
@@ -173,3 +178,57 @@ func newStateService(t *testing.T, ctrl *gomock.Controller) *state.Service {
 
 	return stateSrvc
 }
+
+func Test_createWatchdog(t *testing.T) {
+	t.Parallel()
+
+	config := DefaultTestWestendDevConfig(t)
+	bp := &babe.Service{}
+	fg := &grandpa.Service{}
+	syncer := dotsync.NewSyncService()
+
+	assert.Nil(t, createWatchdog(config, bp, fg, syncer))
+
+	config.Core.WatchdogSync = time.Minute
+	assert.NotNil(t, createWatchdog(config, bp, fg, syncer))
+}
+
+func Test_createBlockHooks(t *testing.T) {
+	t.Parallel()
+
+	config := DefaultTestWestendDevConfig(t)
+	blockHooks, err := createBlockHooks(config, nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, blockHooks)
+
+	config.BlockHookExec = " "
+	_, err = createBlockHooks(config, nil, nil)
+	assert.EqualError(t, err, "creating exec hook: empty command")
+}
+
+func Test_createChangefeed(t *testing.T) {
+	t.Parallel()
+
+	config := DefaultTestWestendDevConfig(t)
+	storageChangefeed, err := createChangefeed(config, nil)
+	require.NoError(t, err)
+	assert.Nil(t, storageChangefeed)
+
+	config.State.StorageWatch = []string{"Sudo.Key", "Sudo"}
+	_, err = createChangefeed(config, nil)
+	assert.EqualError(t, err, `parsing storage watch: storage prefix "Sudo" is neither hex encoded `+
+		"nor formatted as <pallet>.<entry>")
+}
+
+func Test_createWarmup(t *testing.T) {
+	t.Parallel()
+
+	config := DefaultTestWestendDevConfig(t)
+	assert.Nil(t, createWarmup(config, &state.Service{}))
+
+	config.State.WarmupBlocks = 8
+	assert.NotNil(t, createWarmup(config, &state.Service{}))
+
+	config.Core.Role = common.LightClientRole
+	assert.Nil(t, createWarmup(config, &state.Service{}))
+}
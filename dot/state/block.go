@@ -9,6 +9,7 @@
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/types"
@@ -38,6 +39,11 @@
 	justificationPrefix = []byte("jcp") // justificationPrefix + hash -> justification
 	firstSlotNumberKey  = []byte("fsn") // firstSlotNumberKey -> First slot number
 
+	// justificationAncestryPrefix + hash -> compact vote ancestry of the justification
+	justificationAncestryPrefix = []byte("jca")
+	// latestJustificationKey -> hash of the highest block justified
+	latestJustificationKey = []byte("ljh")
+
 	errNilBlockTree = errors.New("blocktree is nil")
 	errNilBlockBody = errors.New("block body is nil")
 
@@ -75,6 +81,27 @@ type BlockState struct {
 	runtimeUpdateSubscriptionsLock sync.RWMutex
 	runtimeUpdateSubscriptions     map[uint32]chan<- runtime.Version
 
+	// runtimes compiled in the background by the hash of the block changing the runtime code
+	pendingRuntimesLock sync.Mutex
+	pendingRuntimes     map[common.Hash]*pendingRuntime
+
+	// runtime of the best block, and its version, last notified to the runtime updated channels
+	bestRuntimeLock    sync.Mutex
+	bestRuntime        runtime.Instance
+	bestRuntimeVersion runtime.Version
+
+	// justificationLock serialises the justification writes, and pruneJustifications is set
+	// to only keep the justifications of the authority set changes and the latest justification
+	justificationLock   sync.Mutex
+	pruneJustifications bool
+
+	// blockBodiesPruning is set to prune the bodies of the finalised blocks older than the
+	// retainedBlockBodies highest finalised blocks, the bodies of the blocks numbered below
+	// prunedBodiesHorizon being pruned
+	blockBodiesPruning  bool
+	retainedBlockBodies uint
+	prunedBodiesHorizon atomic.Uint64
+
 	telemetry Telemetry
 }
 
@@ -89,6 +116,7 @@ func NewBlockState(db database.Database, trs *Tries, telemetry Telemetry) (*Bloc
 		imported:                   make(map[chan *types.Block]struct{}),
 		finalised:                  make(map[chan *types.FinalisationInfo]struct{}),
 		runtimeUpdateSubscriptions: make(map[uint32]chan<- runtime.Version),
+		pendingRuntimes:            make(map[common.Hash]*pendingRuntime),
 		telemetry:                  telemetry,
 		pause:                      make(chan struct{}),
 	}
@@ -107,6 +135,11 @@ func NewBlockState(db database.Database, trs *Tries, telemetry Telemetry) (*Bloc
 	bs.genesisHash = genesisHash
 	bs.lastFinalised = header.Hash()
 	bs.bt = blocktree.NewBlockTreeFromRoot(header)
+
+	err = bs.loadPrunedBodiesHorizon()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load pruned block bodies horizon: %w", err)
+	}
 	return bs, nil
 }
 
@@ -123,6 +156,7 @@ func NewBlockStateFromGenesis(db database.Database, trs *Tries, header *types.He
 		imported:                   make(map[chan *types.Block]struct{}),
 		finalised:                  make(map[chan *types.FinalisationInfo]struct{}),
 		runtimeUpdateSubscriptions: make(map[uint32]chan<- runtime.Version),
+		pendingRuntimes:            make(map[common.Hash]*pendingRuntime),
 		genesisHash:                header.Hash(),
 		lastFinalised:              header.Hash(),
 		telemetry:                  telemetryMailer,
@@ -463,7 +497,12 @@ func (bs *BlockState) GetBlockBody(hash common.Hash) (body *types.Body, err erro
 	}
 
 	data, err := bs.db.Get(blockBodyKey(hash))
-	if err != nil {
+	if errors.Is(err, database.ErrNotFound) {
+		if prunedErr := bs.checkBodyPruned(hash); prunedErr != nil {
+			return nil, prunedErr
+		}
+		return nil, err
+	} else if err != nil {
 		return nil, err
 	}
 
@@ -850,8 +889,12 @@ func (bs *BlockState) setArrivalTime(hash common.Hash, arrivalTime time.Time) er
 }
 
 // HandleRuntimeChanges handles the update in runtime.
+// The new runtime is compiled in the background, the runtime of the block waiting for it.
+// The runtime updated channels are notified once the runtime of the best block changes.
 func (bs *BlockState) HandleRuntimeChanges(newState *rtstorage.TrieState,
 	parentRuntimeInstance runtime.Instance, bHash common.Hash) error {
+	defer func() { go bs.notifyBestRuntimeUpdated() }()
+
 	currCodeHash, err := newState.LoadCodeHash()
 	if err != nil {
 		return err
@@ -899,39 +942,34 @@ func (bs *BlockState) HandleRuntimeChanges(newState *rtstorage.TrieState,
 	}
 
 	rtCfg := wazero_runtime.Config{
-		Storage:     newState,
-		Keystore:    parentRuntimeInstance.Keystore(),
-		NodeStorage: parentRuntimeInstance.NodeStorage(),
-		Network:     parentRuntimeInstance.NetworkService(),
-		CodeHash:    currCodeHash,
+		Storage:        newState,
+		Keystore:       parentRuntimeInstance.Keystore(),
+		NodeStorage:    parentRuntimeInstance.NodeStorage(),
+		Network:        parentRuntimeInstance.NetworkService(),
+		CodeHash:       currCodeHash,
+		InstanceConfig: parentRuntimeInstance.Config(),
 	}
 
 	if parentRuntimeInstance.Validator() {
 		rtCfg.Role = 4
 	}
 
-	instance, err := wazero_runtime.NewInstance(code, rtCfg)
-	if err != nil {
-		return err
-	}
-
-	bs.StoreRuntime(bHash, instance)
-
 	err = bs.baseState.StoreCodeSubstitutedBlockHash(common.Hash{})
 	if err != nil {
 		return fmt.Errorf("failed to update code substituted block hash: %w", err)
 	}
 
-	newVersion, err := instance.Version()
-	if err != nil {
-		return err
-	}
-	go bs.notifyRuntimeUpdated(newVersion)
+	bs.compileRuntime(bHash, code, rtCfg)
 	return nil
 }
 
 // GetRuntime gets the runtime instance pointer for the block hash given.
 func (bs *BlockState) GetRuntime(blockHash common.Hash) (instance runtime.Instance, err error) {
+	err = bs.waitPendingRuntimes(blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("while getting runtime: %w", err)
+	}
+
 	// we search primarily in the blocktree so we ensure the
 	// fork aware property while searching for a runtime, however
 	// if there is no runtimes in that fork then we look for the
@@ -61,28 +61,3 @@ func (bs *BlockState) GetMessageQueue(hash common.Hash) ([]byte, error) {
 
 	return data, nil
 }
-
-// HasJustification returns if the db contains a Justification at the given hash
-func (bs *BlockState) HasJustification(hash common.Hash) (bool, error) {
-	return bs.db.Has(prefixKey(hash, justificationPrefix))
-}
-
-// SetJustification sets a Justification in the database
-func (bs *BlockState) SetJustification(hash common.Hash, data []byte) error {
-	err := bs.db.Put(prefixKey(hash, justificationPrefix), data)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-// GetJustification retrieves a Justification from the database
-func (bs *BlockState) GetJustification(hash common.Hash) ([]byte, error) {
-	data, err := bs.db.Get(prefixKey(hash, justificationPrefix))
-	if err != nil {
-		return nil, err
-	}
-
-	return data, nil
-}
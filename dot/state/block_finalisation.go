@@ -171,6 +171,13 @@ func (bs *BlockState) SetFinalisedHash(hash common.Hash, round, setID uint64) er
 		return fmt.Errorf("failed to get finalised header, hash: %s, error: %s", hash, err)
 	}
 
+	if bs.blockBodiesPruning {
+		err = bs.pruneBlockBodies(header.Number)
+		if err != nil {
+			return fmt.Errorf("failed to prune block bodies: %w", err)
+		}
+	}
+
 	bs.telemetry.SendMessage(
 		telemetry.NewNotifyFinalized(
 			header.Hash(),
@@ -5,6 +5,8 @@
 
 import (
 	"errors"
+	"fmt"
+	"reflect"
 	"sync"
 
 	"github.com/ChainSafe/gossamer/dot/types"
@@ -121,8 +123,63 @@ func (bs *BlockState) notifyRuntimeUpdated(version runtime.Version) {
 	wg.Wait()
 }
 
+// notifyBestRuntimeUpdated notifies the runtime updated channels of the version of the
+// runtime of the best block, if it changed since the last notification. The runtime
+// changes of the blocks out of the best chain are not notified.
+func (bs *BlockState) notifyBestRuntimeUpdated() {
+	bs.bestRuntimeLock.Lock()
+	defer bs.bestRuntimeLock.Unlock()
+
+	previous, previousVersion := bs.bestRuntime, bs.bestRuntimeVersion
+	err := bs.updateBestRuntime()
+	if err != nil {
+		logger.Warnf("failed to update runtime of best block: %s", err)
+		return
+	}
+
+	if previous == nil || previous == bs.bestRuntime ||
+		reflect.DeepEqual(previousVersion, bs.bestRuntimeVersion) {
+		return
+	}
+	bs.notifyRuntimeUpdated(bs.bestRuntimeVersion)
+}
+
+// updateBestRuntime sets the runtime of the best block and its version.
+// The best runtime lock must be held when calling it.
+func (bs *BlockState) updateBestRuntime() error {
+	instance, err := bs.GetRuntime(bs.BestBlockHash())
+	if err != nil {
+		return fmt.Errorf("getting runtime: %w", err)
+	}
+	if instance == nil {
+		return errors.New("no runtime for best block")
+	}
+	if instance == bs.bestRuntime {
+		return nil
+	}
+
+	version, err := instance.Version()
+	if err != nil {
+		return fmt.Errorf("getting runtime version: %w", err)
+	}
+
+	bs.bestRuntime = instance
+	bs.bestRuntimeVersion = version
+	return nil
+}
+
 // RegisterRuntimeUpdatedChannel function to register chan that is notified when runtime version changes
 func (bs *BlockState) RegisterRuntimeUpdatedChannel(ch chan<- runtime.Version) (uint32, error) {
+	// the runtime changes are notified from the runtime of the best block at the registration
+	bs.bestRuntimeLock.Lock()
+	if bs.bestRuntime == nil {
+		err := bs.updateBestRuntime()
+		if err != nil {
+			logger.Debugf("failed to update runtime of best block: %s", err)
+		}
+	}
+	bs.bestRuntimeLock.Unlock()
+
 	bs.runtimeUpdateSubscriptionsLock.Lock()
 	defer bs.runtimeUpdateSubscriptionsLock.Unlock()
 
@@ -11,6 +11,7 @@
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 )
 
 var testMessageTimeout = time.Second * 3
@@ -167,3 +168,43 @@ func TestService_RegisterUnRegisterConcurrentCalls(t *testing.T) {
 		}()
 	}
 }
+
+func TestBlockState_notifyBestRuntimeUpdated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	bs := newTestBlockState(t, newTriesEmpty())
+
+	genesisRuntime := NewMockInstance(ctrl)
+	genesisRuntime.EXPECT().Version().Return(runtime.Version{SpecVersion: 1}, nil)
+	bs.StoreRuntime(bs.GenesisHash(), genesisRuntime)
+
+	ch := make(chan runtime.Version, 1)
+	chID, err := bs.RegisterRuntimeUpdatedChannel(ch)
+	require.NoError(t, err)
+	defer bs.UnregisterRuntimeUpdatedChannel(chID)
+
+	chain, _ := AddBlocksToState(t, bs, 2, false)
+	fork := AddBlockToState(t, bs, 1, createPrimaryBABEDigest(t), bs.GenesisHash())
+	require.Equal(t, chain[1].Hash(), bs.BestBlockHash())
+
+	// the runtime changes out of the best chain are not notified
+	forkRuntime := NewMockInstance(ctrl)
+	bs.StoreRuntime(fork.Hash(), forkRuntime)
+	bs.notifyBestRuntimeUpdated()
+	require.Empty(t, ch)
+
+	// the runtimes of the same version are not notified
+	sameVersionRuntime := NewMockInstance(ctrl)
+	sameVersionRuntime.EXPECT().Version().Return(runtime.Version{SpecVersion: 1}, nil)
+	bs.StoreRuntime(chain[0].Hash(), sameVersionRuntime)
+	bs.notifyBestRuntimeUpdated()
+	require.Empty(t, ch)
+
+	bestRuntime := NewMockInstance(ctrl)
+	bestRuntime.EXPECT().Version().Return(runtime.Version{SpecVersion: 2}, nil)
+	bs.StoreRuntime(chain[1].Hash(), bestRuntime)
+	bs.notifyBestRuntimeUpdated()
+	require.Equal(t, runtime.Version{SpecVersion: 2}, <-ch)
+
+	bs.notifyBestRuntimeUpdated()
+	require.Empty(t, ch)
+}
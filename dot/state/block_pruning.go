@@ -0,0 +1,119 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+// ErrBlockBodyPruned is returned when getting the body of a finalised block pruned since it is
+// older than the finalised blocks whose bodies are kept.
+var ErrBlockBodyPruned = errors.New("block body pruned")
+
+// prunedBodiesHorizonKey -> number of the lowest block whose body is kept, the bodies of the
+// finalised blocks below it, genesis excluded, being pruned
+var prunedBodiesHorizonKey = []byte("pbh")
+
+// maxPrunedBodiesPerBatch is the maximum number of block bodies deleted in a database batch.
+const maxPrunedBodiesPerBatch = 1024
+
+// loadPrunedBodiesHorizon loads the number of the lowest block whose body is kept, left to 0
+// if no block body was ever pruned.
+func (bs *BlockState) loadPrunedBodiesHorizon() error {
+	data, err := bs.db.Get(prunedBodiesHorizonKey)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	bs.prunedBodiesHorizon.Store(binary.LittleEndian.Uint64(data))
+	return nil
+}
+
+// pruneBlockBodies deletes the bodies of the finalised blocks older than the retained block
+// bodies before the highest finalised block of the number given. The headers of these blocks
+// are kept, as well as the genesis block body.
+func (bs *BlockState) pruneBlockBodies(finalisedNumber uint) error {
+	if finalisedNumber < bs.retainedBlockBodies {
+		return nil
+	}
+	horizon := finalisedNumber - bs.retainedBlockBodies + 1
+	start := uint(bs.prunedBodiesHorizon.Load())
+	if horizon <= start {
+		return nil
+	}
+	if start == 0 {
+		start = 1
+	}
+
+	batch := bs.db.NewBatch()
+	defer batch.Close()
+
+	pruned := 0
+	for number := start; number < horizon; number++ {
+		hash, err := bs.GetHashByNumber(number)
+		if errors.Is(err, database.ErrNotFound) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("getting hash of block %d: %w", number, err)
+		}
+
+		err = batch.Del(blockBodyKey(hash))
+		if err != nil {
+			return err
+		}
+		pruned++
+
+		if pruned%maxPrunedBodiesPerBatch == 0 {
+			err = batch.Flush()
+			if err != nil {
+				return fmt.Errorf("deleting block bodies: %w", err)
+			}
+			batch.Reset()
+		}
+	}
+
+	encodedHorizon := make([]byte, 8)
+	binary.LittleEndian.PutUint64(encodedHorizon, uint64(horizon))
+	err := batch.Put(prunedBodiesHorizonKey, encodedHorizon)
+	if err != nil {
+		return err
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return fmt.Errorf("deleting block bodies: %w", err)
+	}
+
+	bs.prunedBodiesHorizon.Store(uint64(horizon))
+	logger.Debugf("pruned the bodies of %d blocks before block %d", pruned, horizon)
+	return nil
+}
+
+// checkBodyPruned returns an error wrapping ErrBlockBodyPruned if the body of the block of the
+// hash given, not found in the database, was pruned.
+func (bs *BlockState) checkBodyPruned(hash common.Hash) error {
+	horizon := uint(bs.prunedBodiesHorizon.Load())
+	if horizon == 0 {
+		return nil
+	}
+
+	header, err := bs.GetHeader(hash)
+	if err != nil {
+		// the block itself is unknown
+		return nil //nolint:nilerr
+	}
+
+	if header.Number == 0 || header.Number >= horizon {
+		return nil
+	}
+
+	return fmt.Errorf("%w: the bodies of the blocks below block #%d are pruned, block #%d (%s) requested",
+		ErrBlockBodyPruned, horizon, header.Number, hash)
+}
@@ -0,0 +1,75 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBlockState_pruneBlockBodies(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	telemetryMock := NewMockTelemetry(ctrl)
+	telemetryMock.EXPECT().SendMessage(gomock.Any()).AnyTimes()
+
+	db := NewInMemoryDB(t)
+	bs, err := NewBlockStateFromGenesis(db, newTriesEmpty(), testGenesisHeader, telemetryMock)
+	require.NoError(t, err)
+	bs.blockBodiesPruning = true
+	bs.retainedBlockBodies = 2
+
+	var headers []*types.Header
+	parentHash := testGenesisHeader.Hash()
+	for number := uint(1); number <= 4; number++ {
+		header := AddBlockToState(t, bs, number, createPrimaryBABEDigest(t), parentHash)
+		headers = append(headers, header)
+		parentHash = header.Hash()
+	}
+
+	err = bs.SetFinalisedHash(headers[1].Hash(), 1, 0)
+	require.NoError(t, err)
+	// the bodies of the blocks 1 and 2 are kept as the 2 highest finalised blocks
+	for _, header := range headers[:2] {
+		_, err = bs.GetBlockBody(header.Hash())
+		require.NoError(t, err)
+	}
+
+	err = bs.SetFinalisedHash(headers[3].Hash(), 2, 0)
+	require.NoError(t, err)
+
+	for _, header := range headers[:2] {
+		_, err = bs.GetBlockBody(header.Hash())
+		assert.ErrorIsf(t, err, ErrBlockBodyPruned, "body of block %d", header.Number)
+
+		_, err = bs.GetBlockByHash(header.Hash())
+		assert.ErrorIsf(t, err, ErrBlockBodyPruned, "block %d", header.Number)
+
+		has, err := bs.HasHeader(header.Hash())
+		require.NoError(t, err)
+		assert.Truef(t, has, "header of block %d", header.Number)
+	}
+
+	for _, header := range append([]*types.Header{testGenesisHeader}, headers[2:]...) {
+		_, err = bs.GetBlockBody(header.Hash())
+		assert.NoErrorf(t, err, "body of block %d", header.Number)
+	}
+
+	_, err = bs.GetBlockBody(common.Hash{9})
+	assert.ErrorIs(t, err, database.ErrNotFound)
+
+	// the pruned bodies are still reported as pruned once the block state is reloaded
+	reloaded, err := NewBlockState(db, newTriesEmpty(), telemetryMock)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), reloaded.prunedBodiesHorizon.Load())
+	_, err = reloaded.GetBlockBody(headers[0].Hash())
+	assert.ErrorIs(t, err, ErrBlockBodyPruned)
+}
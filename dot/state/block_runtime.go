@@ -0,0 +1,80 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+)
+
+// pendingRuntime is a runtime compiled in the background for the block changing the runtime code.
+type pendingRuntime struct {
+	done chan struct{}
+	// err is the error of the compilation, set once done is closed
+	err error
+}
+
+// compileRuntime compiles the runtime code given in the background and stores the runtime
+// for the block given once compiled. The runtime of the block and its descendants waits
+// for the compilation, so the import of the block changing the runtime code does not.
+func (bs *BlockState) compileRuntime(hash common.Hash, code []byte, cfg wazero_runtime.Config) {
+	pending := &pendingRuntime{done: make(chan struct{})}
+
+	bs.pendingRuntimesLock.Lock()
+	bs.pendingRuntimes[hash] = pending
+	bs.pendingRuntimesLock.Unlock()
+
+	go func() {
+		defer close(pending.done)
+
+		instance, err := wazero_runtime.NewInstance(code, cfg)
+		if err != nil {
+			logger.Criticalf("failed to compile runtime code of block %s: %s", hash, err)
+			pending.err = fmt.Errorf("compiling runtime code of block %s: %w", hash, err)
+			return
+		}
+
+		bs.StoreRuntime(hash, instance)
+
+		bs.pendingRuntimesLock.Lock()
+		delete(bs.pendingRuntimes, hash)
+		bs.pendingRuntimesLock.Unlock()
+		logger.Infof("🔄 compiled runtime code of block %s", hash)
+	}()
+}
+
+// waitPendingRuntimes waits for the runtimes compiled in the background for the block
+// given and its ancestors, returning the error of a compilation failing.
+func (bs *BlockState) waitPendingRuntimes(blockHash common.Hash) error {
+	var waiting []*pendingRuntime
+
+	bs.pendingRuntimesLock.Lock()
+	for hash, pending := range bs.pendingRuntimes {
+		isAncestor, err := bs.bt.IsDescendantOf(hash, blockHash)
+		if err != nil {
+			// the block of the runtime is pruned or finalised, a runtime
+			// still compiling is waited for as it may be the one of the block
+			select {
+			case <-pending.done:
+				delete(bs.pendingRuntimes, hash)
+				continue
+			default:
+			}
+		} else if !isAncestor {
+			continue
+		}
+		waiting = append(waiting, pending)
+	}
+	bs.pendingRuntimesLock.Unlock()
+
+	for _, pending := range waiting {
+		<-pending.done
+		if pending.err != nil {
+			return pending.err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBlockState_GetRuntime_pendingRuntime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	bs := newTestBlockState(t, newTriesEmpty())
+	genesisRuntime := NewMockInstance(ctrl)
+	bs.StoreRuntime(bs.GenesisHash(), genesisRuntime)
+
+	chain, _ := AddBlocksToState(t, bs, 2, false)
+	fork := AddBlockToState(t, bs, 1, createPrimaryBABEDigest(t), bs.GenesisHash())
+
+	pending := &pendingRuntime{done: make(chan struct{})}
+	bs.pendingRuntimes[chain[0].Hash()] = pending
+
+	// the runtime of the blocks out of the fork of the pending runtime is not waited for
+	instance, err := bs.GetRuntime(fork.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, genesisRuntime, instance)
+
+	upgradedRuntime := NewMockInstance(ctrl)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		bs.StoreRuntime(chain[0].Hash(), upgradedRuntime)
+		close(pending.done)
+	}()
+
+	instance, err = bs.GetRuntime(chain[1].Hash())
+	require.NoError(t, err)
+	assert.Equal(t, upgradedRuntime, instance)
+
+	// the error of a failed compilation is returned for the descendant blocks
+	errTest := errors.New("test error")
+	bs.pendingRuntimes[chain[1].Hash()] = &pendingRuntime{done: pending.done, err: errTest}
+	_, err = bs.GetRuntime(chain[1].Hash())
+	assert.ErrorIs(t, err, errTest)
+}
@@ -0,0 +1,194 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+)
+
+// ErrInconsistentDatabase is returned when the database is left inconsistent,
+// usually after an unclean shutdown of the node.
+var ErrInconsistentDatabase = errors.New("database is inconsistent")
+
+// CheckConsistency checks the highest finalised block of the database is consistent:
+// its header and body are stored, it is the block stored for its number and
+// its state trie root node is stored. It must be called before Start.
+func (s *Service) CheckConsistency() error {
+	if s.isMemDB {
+		return nil
+	}
+
+	bs := s.newConsistencyBlockState()
+	hash, err := bs.GetHighestFinalisedHash()
+	if err != nil {
+		return fmt.Errorf("%w: cannot get highest finalised hash: %s", ErrInconsistentDatabase, err)
+	}
+
+	_, err = s.checkBlockConsistency(bs, hash)
+	return err
+}
+
+// Repair rolls the highest finalised block back to the highest finalised block
+// which is consistent, and returns its header. It must be called before Start.
+func (s *Service) Repair() (header *types.Header, err error) {
+	bs := s.newConsistencyBlockState()
+
+	highestNumber, err := highestCanonicalNumber(bs.db)
+	if err != nil {
+		return nil, err
+	}
+
+	number := highestNumber
+	for {
+		hashBytes, err := bs.db.Get(headerHashKey(number))
+		if err != nil {
+			return nil, fmt.Errorf("getting hash of block %d: %w", number, err)
+		}
+
+		header, err = s.checkBlockConsistency(bs, common.NewHash(hashBytes))
+		if err == nil {
+			break
+		} else if !errors.Is(err, ErrInconsistentDatabase) {
+			return nil, err
+		}
+		logger.Warnf("block %d is not consistent: %s", number, err)
+
+		if number == 0 {
+			return nil, fmt.Errorf("%w: no consistent finalised block found", ErrInconsistentDatabase)
+		}
+		number--
+	}
+
+	round, setID, err := bs.GetHighestRoundAndSetID()
+	if err != nil {
+		logger.Warnf("cannot get highest round and set id, resetting them: %s", err)
+		round, setID = 0, 0
+	}
+
+	batch := bs.db.NewBatch()
+	for n := number + 1; n <= highestNumber; n++ {
+		err = batch.Del(headerHashKey(n))
+		if err != nil {
+			return nil, fmt.Errorf("deleting hash of block %d: %w", n, err)
+		}
+	}
+
+	err = batch.Put(finalisedHashKey(round, setID), header.Hash().ToBytes())
+	if err != nil {
+		return nil, fmt.Errorf("setting finalised hash: %w", err)
+	}
+
+	err = batch.Put(highestRoundAndSetIDKey, roundAndSetIDToBytes(round, setID))
+	if err != nil {
+		return nil, fmt.Errorf("setting highest round and set id: %w", err)
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return nil, fmt.Errorf("writing repaired finalised block: %w", err)
+	}
+
+	logger.Infof("rolled back highest finalised block from number %d to number %d and hash %s",
+		highestNumber, header.Number, header.Hash())
+	return header, nil
+}
+
+// newConsistencyBlockState returns a block state reading the database only,
+// since the block state of the node cannot be created from an inconsistent database.
+func (s *Service) newConsistencyBlockState() *BlockState {
+	return &BlockState{
+		db:                database.NewTable(s.db, blockPrefix),
+		unfinalisedBlocks: newHashToBlockMap(),
+	}
+}
+
+// checkBlockConsistency checks the finalised block with the given hash is fully stored
+// in the database, and returns its header.
+func (s *Service) checkBlockConsistency(bs *BlockState, hash common.Hash) (*types.Header, error) {
+	header, err := bs.GetHeader(hash)
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot get header of block %s: %s", ErrInconsistentDatabase, hash, err)
+	}
+
+	canonicalHash, err := bs.db.Get(headerHashKey(uint64(header.Number)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot get hash of block %d: %s", ErrInconsistentDatabase, header.Number, err)
+	}
+	if !bytes.Equal(canonicalHash, hash.ToBytes()) {
+		return nil, fmt.Errorf("%w: hash of block %d is %s instead of %s",
+			ErrInconsistentDatabase, header.Number, common.NewHash(canonicalHash), hash)
+	}
+
+	hasBody, err := bs.HasBlockBody(hash)
+	if err != nil {
+		return nil, fmt.Errorf("checking body of block %s: %w", hash, err)
+	}
+	if !hasBody {
+		return nil, fmt.Errorf("%w: body of block %s is missing", ErrInconsistentDatabase, hash)
+	}
+
+	// a light node only stores the genesis state
+	if header.StateRoot != trie.EmptyHash && (!s.light || header.Number == 0) {
+		hasStateRoot, err := database.NewTable(s.db, storagePrefix).Has(header.StateRoot.ToBytes())
+		if err != nil {
+			return nil, fmt.Errorf("checking state root of block %s: %w", hash, err)
+		}
+		if !hasStateRoot {
+			return nil, fmt.Errorf("%w: state root %s of block %s is missing",
+				ErrInconsistentDatabase, header.StateRoot, hash)
+		}
+	}
+
+	return header, nil
+}
+
+// highestCanonicalNumber returns the highest block number stored with its hash
+// in the database, the numbers from 0 to it being all stored.
+func highestCanonicalNumber(db database.Reader) (number uint64, err error) {
+	has := func(number uint64) (bool, error) {
+		return db.Has(headerHashKey(number))
+	}
+
+	ok, err := has(0)
+	if err != nil {
+		return 0, fmt.Errorf("checking hash of genesis block: %w", err)
+	} else if !ok {
+		return 0, fmt.Errorf("%w: hash of genesis block is missing", ErrInconsistentDatabase)
+	}
+
+	// find a number not stored by doubling, then binary search
+	// the highest number stored between it and the previous number.
+	low, high := uint64(0), uint64(1)
+	for {
+		ok, err = has(high)
+		if err != nil {
+			return 0, fmt.Errorf("checking hash of block %d: %w", high, err)
+		} else if !ok {
+			break
+		}
+		low, high = high, high*2
+	}
+
+	for high-low > 1 {
+		middle := low + (high-low)/2
+		ok, err = has(middle)
+		if err != nil {
+			return 0, fmt.Errorf("checking hash of block %d: %w", middle, err)
+		}
+		if ok {
+			low = middle
+		} else {
+			high = middle
+		}
+	}
+
+	return low, nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_highestCanonicalNumber(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		storedNumbers uint64
+		number        uint64
+		errWrapped    error
+	}{
+		"no_block_stored": {
+			errWrapped: ErrInconsistentDatabase,
+		},
+		"genesis_block_only": {
+			storedNumbers: 1,
+		},
+		"power_of_two": {
+			storedNumbers: 9,
+			number:        8,
+		},
+		"between_powers_of_two": {
+			storedNumbers: 1001,
+			number:        1000,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			db := database.NewTable(NewInMemoryDB(t), blockPrefix)
+			for number := uint64(0); number < testCase.storedNumbers; number++ {
+				err := db.Put(headerHashKey(number), []byte{1})
+				require.NoError(t, err)
+			}
+
+			number, err := highestCanonicalNumber(db)
+
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			assert.Equal(t, testCase.number, number)
+		})
+	}
+}
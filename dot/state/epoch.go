@@ -37,6 +37,7 @@
 	skipToKey            = []byte("skipto")
 	nextEpochDataPrefix  = []byte("nextepochdata")
 	nextConfigDataPrefix = []byte("nextconfigdata")
+	slotClaimsKey        = []byte("slotclaims")
 )
 
 func epochDataKey(epoch uint64) []byte {
@@ -86,6 +87,11 @@ type EpochState struct {
 	nextConfigData nextEpochMap[types.NextConfigDataV1]
 
 	genesisEpochDescriptor *GenesisEpochDescriptor
+
+	// pruneEpochs is set to prune the epoch data and config data of the epochs older than
+	// retainedEpochs epochs before the finalised epoch, up to prunedEpochsHorizon so far
+	pruneEpochs         bool
+	prunedEpochsHorizon uint64
 }
 
 // NewEpochStateFromGenesis returns a new EpochState given information for the first epoch, fetched from the runtime
@@ -268,6 +274,33 @@ func (s *EpochState) GetCurrentEpoch() (uint64, error) {
 	return binary.LittleEndian.Uint64(b), nil
 }
 
+// StoreSlotClaims persists the slot claims of the local authority for an epoch,
+// replacing the slot claims of the previous epoch
+func (s *EpochState) StoreSlotClaims(claims *types.EpochSlotClaims) error {
+	enc, err := scale.Marshal(*claims)
+	if err != nil {
+		return fmt.Errorf("encoding slot claims: %w", err)
+	}
+
+	return s.db.Put(slotClaimsKey, enc)
+}
+
+// GetSlotClaims returns the last slot claims persisted by the local authority
+func (s *EpochState) GetSlotClaims() (*types.EpochSlotClaims, error) {
+	enc, err := s.db.Get(slotClaimsKey)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := new(types.EpochSlotClaims)
+	err = scale.Unmarshal(enc, claims)
+	if err != nil {
+		return nil, fmt.Errorf("decoding slot claims: %w", err)
+	}
+
+	return claims, nil
+}
+
 // GetEpochForBlock checks the pre-runtime digest to determine what epoch the block was formed in.
 func (s *EpochState) GetEpochForBlock(header *types.Header) (uint64, error) {
 	if header == nil {
@@ -685,10 +718,8 @@ func (s *EpochState) HandleBABEDigest(header *types.Header, digest types.BabeCon
 		}
 
 		nextEpoch := currEpoch + 1
-		s.storeBABENextEpochData(nextEpoch, headerHash, val)
-
-		if err = s.setBABENextEpochDataInDB(nextEpoch, headerHash, val); err != nil {
-			return fmt.Errorf("setting next epoch data in db: %w", err)
+		if err = s.storeBABENextEpochData(nextEpoch, headerHash, val); err != nil {
+			return fmt.Errorf("storing next epoch data: %w", err)
 		}
 
 		logger.Debugf("stored BABENextEpochData data: %v for hash: %s to epoch: %d", digest, headerHash, nextEpoch)
@@ -705,15 +736,17 @@ func (s *EpochState) HandleBABEDigest(header *types.Header, digest types.BabeCon
 
 		switch nextConfigData := nextConfigDataVersion.(type) {
 		case types.NextConfigDataV1:
+			if types.AllowedSlots(nextConfigData.SecondarySlots) > types.PrimaryAndSecondaryVRFSlots {
+				return fmt.Errorf("%w: %d", types.ErrInvalidAllowedSlots, nextConfigData.SecondarySlots)
+			}
+
 			currEpoch, err := s.GetEpochForBlock(header)
 			if err != nil {
 				return fmt.Errorf("getting epoch for block %d (%s): %w", header.Number, headerHash, err)
 			}
 			nextEpoch := currEpoch + 1
-			s.storeBABENextConfigData(nextEpoch, headerHash, nextConfigData)
-
-			if err := s.setBABENextConfigData(nextEpoch, headerHash, nextConfigData); err != nil {
-				return fmt.Errorf("setting next config data in db: %w", err)
+			if err := s.storeBABENextConfigData(nextEpoch, headerHash, nextConfigData); err != nil {
+				return fmt.Errorf("storing next config data: %w", err)
 			}
 
 			logger.Debugf("stored BABENextConfigData data: %v for hash: %s to epoch: %d", digest, headerHash, nextEpoch)
@@ -911,16 +944,30 @@ func (s *EpochState) SkipVerify(header *types.Header) (bool, error) {
 	return false, nil
 }
 
-// StoreBABENextEpochData stores the types.NextEpochData under epoch and hash keys
-func (s *EpochState) storeBABENextEpochData(epoch uint64, hash common.Hash, nextEpochData types.NextEpochData) {
+// storeBABENextEpochData stores the types.NextEpochData under epoch and hash keys, in memory
+// and in the database, unless the data of maxNextEpochForks forks is already kept for the epoch
+func (s *EpochState) storeBABENextEpochData(epoch uint64, hash common.Hash,
+	nextEpochData types.NextEpochData) error {
 	s.nextEpochDataLock.Lock()
 	defer s.nextEpochDataLock.Unlock()
 
-	_, has := s.nextEpochData[epoch]
-	if !has {
-		s.nextEpochData[epoch] = make(map[common.Hash]types.NextEpochData)
+	removed, added, err := s.nextEpochData.addFork(s.blockState, epoch, hash, nextEpochData)
+	if err != nil {
+		return err
+	}
+	for _, forkHash := range removed {
+		err = s.db.Del(nextEpochDataKey(epoch, forkHash))
+		if err != nil {
+			return fmt.Errorf("deleting next epoch data of pruned fork: %w", err)
+		}
+	}
+	if !added {
+		logger.Warnf("dropping next epoch data of block %s for epoch %d: data of %d forks already kept",
+			hash, epoch, maxNextEpochForks)
+		return nil
 	}
-	s.nextEpochData[epoch][hash] = nextEpochData
+
+	return s.setBABENextEpochDataInDB(epoch, hash, nextEpochData)
 }
 
 // setBABENextEpochDataInDB stores the types.NextEpochData under epoch and hash keys
@@ -936,18 +983,30 @@ func (s *EpochState) setBABENextEpochDataInDB(epoch uint64, forkHash common.Hash
 	return s.db.Put(key, encodedEpochData)
 }
 
-// StoreBABENextConfigData stores the types.NextConfigData under epoch and hash keys
-func (s *EpochState) storeBABENextConfigData(epoch uint64, hash common.Hash, nextConfigData types.NextConfigDataV1) {
+// storeBABENextConfigData stores the types.NextConfigData under epoch and hash keys, in memory
+// and in the database, unless the data of maxNextEpochForks forks is already kept for the epoch
+func (s *EpochState) storeBABENextConfigData(epoch uint64, hash common.Hash,
+	nextConfigData types.NextConfigDataV1) error {
 	s.nextConfigDataLock.Lock()
 	defer s.nextConfigDataLock.Unlock()
 
-	fmt.Printf("storing next config data for epoch %d, hash: %s\n", epoch, hash.String())
-
-	_, has := s.nextConfigData[epoch]
-	if !has {
-		s.nextConfigData[epoch] = make(map[common.Hash]types.NextConfigDataV1)
+	removed, added, err := s.nextConfigData.addFork(s.blockState, epoch, hash, nextConfigData)
+	if err != nil {
+		return err
+	}
+	for _, forkHash := range removed {
+		err = s.db.Del(nextConfigDataKey(epoch, forkHash))
+		if err != nil {
+			return fmt.Errorf("deleting next config data of pruned fork: %w", err)
+		}
+	}
+	if !added {
+		logger.Warnf("dropping next config data of block %s for epoch %d: data of %d forks already kept",
+			hash, epoch, maxNextEpochForks)
+		return nil
 	}
-	s.nextConfigData[epoch][hash] = nextConfigData
+
+	return s.setBABENextConfigData(epoch, hash, nextConfigData)
 }
 
 // setBABENextConfigData stores the types.NextConfigData under epoch and hash keys
@@ -995,28 +1054,29 @@ func (s *EpochState) FinalizeBABENextEpochData(finalizedHeader *types.Header) er
 	}
 
 	// epoch data already defined we don't need to lookup in the map
-	if epochRawInDatabase != nil {
-		return nil
-	}
+	if epochRawInDatabase == nil {
+		finalizedNextEpochData, err := findFinalizedHeaderForEpoch(s.nextEpochData, s, nextEpoch)
+		if err != nil {
+			return fmt.Errorf("cannot find next epoch data: %w", err)
+		}
 
-	finalizedNextEpochData, err := findFinalizedHeaderForEpoch(s.nextEpochData, s, nextEpoch)
-	if err != nil {
-		return fmt.Errorf("cannot find next epoch data: %w", err)
+		err = s.SetEpochDataRaw(nextEpoch, finalizedNextEpochData.ToEpochDataRaw())
+		if err != nil {
+			return fmt.Errorf("cannot set epoch data: %w", err)
+		}
 	}
 
-	err = s.SetEpochDataRaw(nextEpoch, finalizedNextEpochData.ToEpochDataRaw())
+	// remove previous epochs from the memory and from the database, the data announced by
+	// the forks for these epochs being no longer needed once the epoch data is defined
+	err = s.nextEpochData.deleteEpochsUpTo(s.db, nextEpochDataPrefix, nextEpoch)
 	if err != nil {
-		return fmt.Errorf("cannot set epoch data: %w", err)
+		return fmt.Errorf("cannot delete next epoch data: %w", err)
 	}
 
-	// remove previous epochs from the memory
-	for e := range s.nextEpochData {
-		if e <= nextEpoch {
-			delete(s.nextEpochData, e)
-			// remove the epoch data from the database
-			if err = deleteDataFromDisk[types.NextEpochData](s.db, e, nextEpochDataPrefix); err != nil {
-				return fmt.Errorf("cannot delete next epoch data from the database: %w", err)
-			}
+	if s.pruneEpochs {
+		err = s.pruneEpochDefinitions(nextEpoch - 1)
+		if err != nil {
+			return fmt.Errorf("pruning epoch definitions: %w", err)
 		}
 	}
 
@@ -1053,7 +1113,8 @@ func getDataKeysFromDisk[T types.NextEpochData | types.NextConfigDataV1](
 	[]string, error) {
 
 	var dataKeys []string
-	currentEpochPrefix := fmt.Sprintf("%s%d", prefix, currentEpoch)
+	// the separator prevents matching the keys of the epochs starting with the same digits
+	currentEpochPrefix := fmt.Sprintf("%s%d:", prefix, currentEpoch)
 
 	iter, err := db.NewPrefixIterator([]byte(currentEpochPrefix))
 	if err != nil {
@@ -1097,7 +1158,7 @@ func (s *EpochState) FinalizeBABENextConfigData(finalizedHeader *types.Header) e
 	}
 
 	configInDatabase, err := getEpochDefinitionFromDatabase[types.ConfigData](
-		s.db, nextEpoch, epochDataKey)
+		s.db, nextEpoch, configDataKey)
 
 	// if an error occurs and the error is database.ErrNotFound we ignore
 	// since this error is what we will handle in the next lines
@@ -1107,14 +1168,14 @@ func (s *EpochState) FinalizeBABENextConfigData(finalizedHeader *types.Header) e
 
 	// config data already defined we don't need to lookup in the map
 	if configInDatabase != nil {
-		return nil
+		return s.nextConfigData.deleteEpochsUpTo(s.db, nextConfigDataPrefix, nextEpoch)
 	}
 
 	// not every epoch will have `ConfigData`
 	finalizedNextConfigData, err := findFinalizedHeaderForEpoch(s.nextConfigData, s, nextEpoch)
 	if errors.Is(err, ErrEpochNotInMemory) {
 		logger.Debugf("config data for epoch %d not found in memory", nextEpoch)
-		return nil
+		return s.nextConfigData.deleteEpochsUpTo(s.db, nextConfigDataPrefix, nextEpoch)
 	} else if err != nil {
 		return fmt.Errorf("cannot find next config data: %w", err)
 	}
@@ -1125,15 +1186,10 @@ func (s *EpochState) FinalizeBABENextConfigData(finalizedHeader *types.Header) e
 		return fmt.Errorf("cannot set config data: %w", err)
 	}
 
-	// remove previous epochs from the memory
-	for e := range s.nextConfigData {
-		if e <= nextEpoch {
-			delete(s.nextConfigData, e)
-			// remove the config data from the database
-			if err = deleteDataFromDisk[types.NextConfigDataV1](s.db, e, nextConfigDataPrefix); err != nil {
-				return fmt.Errorf("cannot delete next config data from the database: %w", err)
-			}
-		}
+	// remove previous epochs from the memory and from the database
+	err = s.nextConfigData.deleteEpochsUpTo(s.db, nextConfigDataPrefix, nextEpoch)
+	if err != nil {
+		return fmt.Errorf("cannot delete next config data: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,156 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+// retainedEpochs is the number of epochs preceding the epoch of the highest finalised block
+// whose data and config data are kept when pruning the epochs, so the blocks of these epochs
+// can still be verified, for example after reverting the most recent blocks.
+const retainedEpochs = 2
+
+// maxNextEpochForks is the maximum number of forks whose next epoch data or next config data
+// is kept in memory for an epoch, the data of the forks pruned being removed once it is reached.
+const maxNextEpochForks = 64
+
+// addFork adds the data announced by the block of the hash given for the epoch given, and
+// returns the hashes of the blocks of the pruned forks whose data is removed to make room for
+// it. It returns false if the data of maxNextEpochForks forks not pruned is already kept.
+func (nem nextEpochMap[T]) addFork(blockState *BlockState, epoch uint64, hash common.Hash, data T) (
+	removed []common.Hash, added bool, err error) {
+	forks, has := nem[epoch]
+	if !has {
+		forks = make(map[common.Hash]T)
+		nem[epoch] = forks
+	}
+
+	_, has = forks[hash]
+	if !has && len(forks) >= maxNextEpochForks {
+		for forkHash := range forks {
+			has, err := blockState.HasHeader(forkHash)
+			if err != nil {
+				return removed, false, fmt.Errorf("checking header of block %s: %w", forkHash, err)
+			}
+			if has {
+				continue
+			}
+
+			delete(forks, forkHash)
+			removed = append(removed, forkHash)
+		}
+
+		if len(forks) >= maxNextEpochForks {
+			return removed, false, nil
+		}
+	}
+
+	forks[hash] = data
+	return removed, true, nil
+}
+
+// deleteEpochsUpTo removes the data of the epochs up to the epoch given from the map and from
+// the database under the prefix given.
+func (nem nextEpochMap[T]) deleteEpochsUpTo(db database.Table, prefix []byte, epoch uint64) error {
+	for e := range nem {
+		if e > epoch {
+			continue
+		}
+
+		delete(nem, e)
+		err := deleteDataFromDisk[T](db, e, prefix)
+		if err != nil {
+			return fmt.Errorf("deleting data of epoch %d: %w", e, err)
+		}
+	}
+	return nil
+}
+
+// pruneEpochDefinitions deletes the epoch data and the config data of the epochs older than
+// retainedEpochs epochs before the epoch of the highest finalised block given, keeping the
+// config data of the most recent of these epochs since it applies to the following epochs
+// until it is changed.
+func (s *EpochState) pruneEpochDefinitions(finalisedEpoch uint64) error {
+	if finalisedEpoch <= retainedEpochs {
+		return nil
+	}
+	horizon := finalisedEpoch - retainedEpochs
+	if horizon <= s.prunedEpochsHorizon {
+		return nil
+	}
+
+	epochs, err := s.storedEpochs(epochDataPrefix)
+	if err != nil {
+		return fmt.Errorf("getting epochs of the epoch data: %w", err)
+	}
+	configEpochs, err := s.storedEpochs(configDataPrefix)
+	if err != nil {
+		return fmt.Errorf("getting epochs of the config data: %w", err)
+	}
+
+	var latestConfigEpoch uint64
+	for _, epoch := range configEpochs {
+		if epoch <= horizon && epoch > latestConfigEpoch {
+			latestConfigEpoch = epoch
+		}
+	}
+
+	batch := s.db.NewBatch()
+	defer batch.Close()
+
+	pruned := 0
+	for _, epoch := range epochs {
+		if epoch >= horizon {
+			continue
+		}
+		err = batch.Del(epochDataKey(epoch))
+		if err != nil {
+			return err
+		}
+		pruned++
+	}
+	for _, epoch := range configEpochs {
+		if epoch >= latestConfigEpoch {
+			continue
+		}
+		err = batch.Del(configDataKey(epoch))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return fmt.Errorf("deleting epoch definitions: %w", err)
+	}
+
+	s.prunedEpochsHorizon = horizon
+	logger.Debugf("pruned the data of %d epochs before epoch %d", pruned, horizon)
+	return nil
+}
+
+// storedEpochs returns the epochs of the epoch definitions stored under the prefix given.
+func (s *EpochState) storedEpochs(prefix []byte) (epochs []uint64, err error) {
+	iter, err := s.db.NewPrefixIterator(prefix)
+	if err != nil {
+		return nil, fmt.Errorf("creating iterator: %w", err)
+	}
+	defer iter.Release()
+
+	// the iterator keys are prefixed with the table prefix
+	keyLength := len(epochPrefix) + len(prefix) + 8
+	for valid := iter.First(); valid; valid = iter.Next() {
+		key := iter.Key()
+		if len(key) != keyLength {
+			continue
+		}
+		epochs = append(epochs, binary.LittleEndian.Uint64(key[keyLength-8:]))
+	}
+	return epochs, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEpochState_pruneEpochDefinitions(t *testing.T) {
+	t.Parallel()
+
+	epochState := newTestEpochStateFromGenesis(t)
+	for epoch := uint64(1); epoch <= 10; epoch++ {
+		err := epochState.SetEpochDataRaw(epoch, &types.EpochDataRaw{Randomness: [32]byte{byte(epoch)}})
+		require.NoError(t, err)
+	}
+	for _, epoch := range []uint64{2, 5, 9} {
+		err := epochState.StoreConfigData(epoch, &types.ConfigData{C1: epoch})
+		require.NoError(t, err)
+	}
+
+	err := epochState.pruneEpochDefinitions(9)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), epochState.prunedEpochsHorizon)
+
+	for epoch := uint64(1); epoch <= 10; epoch++ {
+		_, err := epochState.db.Get(epochDataKey(epoch))
+		if epoch < 7 {
+			assert.ErrorIsf(t, err, database.ErrNotFound, "epoch %d", epoch)
+		} else {
+			assert.NoErrorf(t, err, "epoch %d", epoch)
+		}
+	}
+
+	// the config data of the epoch 5 still applies to the epochs 7 and 8
+	_, err = epochState.db.Get(configDataKey(2))
+	assert.ErrorIs(t, err, database.ErrNotFound)
+	configData, err := epochState.GetConfigData(8, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), configData.C1)
+	configData, err = epochState.GetConfigData(9, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(9), configData.C1)
+}
+
+func Test_nextEpochMap_addFork(t *testing.T) {
+	t.Parallel()
+
+	blockState := newTestBlockState(t, newTriesEmpty())
+	block := AddBlockToState(t, blockState, 1, createPrimaryBABEDigest(t), testGenesisHeader.Hash())
+
+	nem := make(nextEpochMap[types.NextEpochData])
+	removed, added, err := nem.addFork(blockState, 2, block.Hash(), types.NextEpochData{})
+	require.NoError(t, err)
+	assert.True(t, added)
+	assert.Empty(t, removed)
+
+	// the data of unknown blocks fills the epoch up to the limit
+	for i := 1; i < maxNextEpochForks; i++ {
+		_, added, err = nem.addFork(blockState, 2, common.Hash{byte(i)}, types.NextEpochData{})
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+
+	// the data of the pruned forks is removed once the limit is reached
+	removed, added, err = nem.addFork(blockState, 2, common.Hash{0xff}, types.NextEpochData{})
+	require.NoError(t, err)
+	assert.True(t, added)
+	assert.Len(t, removed, maxNextEpochForks-1)
+	assert.Len(t, nem[2], 2)
+
+	// the data is dropped once the limit is reached by forks not pruned
+	nem = make(nextEpochMap[types.NextEpochData])
+	nem[3] = map[common.Hash]types.NextEpochData{block.Hash(): {}}
+	for i := 1; i < maxNextEpochForks; i++ {
+		nem[3][common.Hash{byte(i)}] = types.NextEpochData{}
+	}
+	err = blockState.db.Put(headerKey(common.Hash{0xfe}), []byte{1})
+	require.NoError(t, err)
+	for i := 1; i < maxNextEpochForks; i++ {
+		err = blockState.db.Put(headerKey(common.Hash{byte(i)}), []byte{1})
+		require.NoError(t, err)
+	}
+	removed, added, err = nem.addFork(blockState, 3, common.Hash{0xfe}, types.NextEpochData{})
+	require.NoError(t, err)
+	assert.False(t, added)
+	assert.Empty(t, removed)
+	assert.Len(t, nem[3], maxNextEpochForks)
+}
+
+func Test_getDataKeysFromDisk(t *testing.T) {
+	t.Parallel()
+
+	epochState := newTestEpochStateFromGenesis(t)
+	for _, epoch := range []uint64{1, 10, 11} {
+		err := epochState.setBABENextEpochDataInDB(epoch, common.Hash{byte(epoch)}, types.NextEpochData{})
+		require.NoError(t, err)
+	}
+
+	keys, err := getDataKeysFromDisk[types.NextEpochData](epochState.db, nextEpochDataPrefix, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{string(nextEpochDataKey(1, common.Hash{1}))}, keys)
+}
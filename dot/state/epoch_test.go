@@ -71,6 +71,52 @@ func TestEpochState_EpochData(t *testing.T) {
 	}
 }
 
+func TestEpochState_SlotClaims(t *testing.T) {
+	s := newTestEpochStateFromGenesis(t)
+
+	_, err := s.GetSlotClaims()
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	keyring, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+
+	claims := &types.EpochSlotClaims{
+		Epoch:      1,
+		StartSlot:  100,
+		EndSlot:    200,
+		Randomness: [32]byte{77},
+		Authorities: []types.AuthorityRaw{{
+			Key:    keyring.Alice().Public().(*sr25519.PublicKey).AsBytes(),
+			Weight: 1,
+		}},
+		Threshold:    scale.MaxUint128,
+		AllowedSlots: types.PrimaryAndSecondaryVRFSlots,
+		Claims: []types.SlotClaim{
+			{Slot: 101, PreRuntimeDigest: *types.NewBABEPreRuntimeDigest([]byte{1, 2})},
+			{Slot: 150, PreRuntimeDigest: *types.NewBABEPreRuntimeDigest([]byte{3, 4})},
+		},
+	}
+
+	err = s.StoreSlotClaims(claims)
+	require.NoError(t, err)
+	res, err := s.GetSlotClaims()
+	require.NoError(t, err)
+	require.Equal(t, claims, res)
+
+	claims = &types.EpochSlotClaims{
+		Epoch:     2,
+		StartSlot: 200,
+		EndSlot:   300,
+		Threshold: scale.MaxUint128,
+	}
+	err = s.StoreSlotClaims(claims)
+	require.NoError(t, err)
+	res, err = s.GetSlotClaims()
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), res.Epoch)
+	require.Empty(t, res.Claims)
+}
+
 func TestEpochState_GetStartSlotForEpoch(t *testing.T) {
 	s := newTestEpochStateFromGenesis(t)
 
@@ -126,6 +172,27 @@ func TestEpochState_ConfigData(t *testing.T) {
 	ret, err := s.GetConfigData(1, nil)
 	require.NoError(t, err)
 	require.Equal(t, data, ret)
+
+	// the config data applies to the next epochs until it changes
+	ret, err = s.GetConfigData(3, nil)
+	require.NoError(t, err)
+	require.Equal(t, data, ret)
+}
+
+func TestEpochState_HandleBABEDigest_invalidAllowedSlots(t *testing.T) {
+	s := newTestEpochStateFromGenesis(t)
+
+	nextConfigData := types.NewVersionedNextConfigData()
+	err := nextConfigData.SetValue(types.NextConfigDataV1{C1: 1, C2: 4, SecondarySlots: 3})
+	require.NoError(t, err)
+	digest := types.NewBabeConsensusDigest()
+	err = digest.SetValue(nextConfigData)
+	require.NoError(t, err)
+
+	err = s.HandleBABEDigest(types.NewEmptyHeader(), digest)
+	require.ErrorIs(t, err, types.ErrInvalidAllowedSlots)
+	require.EqualError(t, err, "invalid allowed slots: 3")
+	require.Empty(t, s.nextConfigData)
 }
 
 func createAndImportBlockOne(t *testing.T, slotNumber uint64, blockState *BlockState) (blockOneHeader *types.Header) {
@@ -382,7 +449,8 @@ func TestStoreAndFinalizeBabeNextEpochData(t *testing.T) {
 
 			for _, e := range tt.inMemoryEpoch {
 				for i, hash := range e.hashes {
-					epochState.storeBABENextEpochData(e.epoch, hash, e.nextData[i])
+					err := epochState.storeBABENextEpochData(e.epoch, hash, e.nextData[i])
+					require.NoError(t, err)
 				}
 			}
 
@@ -445,6 +513,7 @@ func TestStoreAndFinalizeBabeNextConfigData(t *testing.T) {
 		finalizedHeader      *types.Header
 		inMemoryEpoch        []inMemoryBABEData[types.NextConfigDataV1]
 		finalizedEpoch       uint64
+		epochDataFinalized   bool
 		expectErr            error
 		shouldRemainInMemory int
 	}{
@@ -518,6 +587,24 @@ func TestStoreAndFinalizeBabeNextConfigData(t *testing.T) {
 				},
 			},
 		},
+		"store_after_next_epoch_data_finalized": {
+			finalizedEpoch:     2,
+			finalizedHeader:    blockNumber2,
+			epochDataFinalized: true,
+			inMemoryEpoch: []inMemoryBABEData[types.NextConfigDataV1]{
+				{
+					epoch:  2,
+					hashes: []common.Hash{blockNumber2.Hash()},
+					nextData: []types.NextConfigDataV1{
+						{
+							C1:             1,
+							C2:             4,
+							SecondarySlots: 2,
+						},
+					},
+				},
+			},
+		},
 		"cannot_finalize_hash_doesnt_exists": {
 			shouldRemainInMemory: 1,
 			finalizedEpoch:       2,
@@ -579,12 +666,18 @@ func TestStoreAndFinalizeBabeNextConfigData(t *testing.T) {
 
 			for _, e := range tt.inMemoryEpoch {
 				for i, hash := range e.hashes {
-					epochState.storeBABENextConfigData(e.epoch, hash, e.nextData[i])
+					err := epochState.storeBABENextConfigData(e.epoch, hash, e.nextData[i])
+					require.NoError(t, err)
 				}
 			}
 
 			require.Len(t, epochState.nextConfigData, len(tt.inMemoryEpoch))
 
+			if tt.epochDataFinalized {
+				err := epochState.SetEpochDataRaw(tt.finalizedEpoch, &types.EpochDataRaw{})
+				require.NoError(t, err)
+			}
+
 			// if there is no data in memory we try to finalize the next config data
 			// it should return nil since next epoch config data will not be in every epoch's first block
 			if len(tt.inMemoryEpoch) == 0 {
@@ -22,6 +22,7 @@
 	errDuplicateHashes         = errors.New("duplicated hashes")
 	errAlreadyHasForcedChange  = errors.New("already has a forced change")
 	errUnfinalizedAncestor     = errors.New("unfinalized ancestor")
+	errInvalidDisabledIndex    = errors.New("invalid disabled authority index")
 
 	ErrNoNextAuthorityChange = errors.New("no next authority change")
 )
@@ -34,6 +35,7 @@
 	pauseKey          = []byte("pause")
 	resumeKey         = []byte("resume")
 	currentSetIDKey   = []byte("setID")
+	voterStateKey     = []byte("voter")
 )
 
 // GrandpaState tracks information related to grandpa
@@ -100,18 +102,42 @@ func (s *GrandpaState) HandleGRANDPADigest(header *types.Header, digest types.Gr
 	case types.GrandpaForcedChange:
 		return s.addForcedChange(header, val)
 	case types.GrandpaOnDisabled:
-		return nil
+		return s.handleOnDisabled(val)
 	case types.GrandpaPause:
-		logger.Warn("GRANDPA Pause consensus message not implemented yet")
-		return nil
+		return s.addPause(header, val)
 	case types.GrandpaResume:
-		logger.Warn("GRANDPA Resume consensus message not implemented yet")
-		return nil
+		return s.addResume(header, val)
 	default:
 		return fmt.Errorf("not supported digest")
 	}
 }
 
+// addPause sets the next pause of the authority set at the block of the header given
+// plus the delay of the pause digest
+func (s *GrandpaState) addPause(header *types.Header, pause types.GrandpaPause) error {
+	number := header.Number + uint(pause.Delay)
+	err := s.SetNextPause(number)
+	if err != nil {
+		return fmt.Errorf("cannot set next pause: %w", err)
+	}
+
+	logger.Infof("authority set pause scheduled at block #%d by block %s", number, header.Hash())
+	return nil
+}
+
+// addResume sets the next resume of the authority set at the block of the header given
+// plus the delay of the resume digest
+func (s *GrandpaState) addResume(header *types.Header, resume types.GrandpaResume) error {
+	number := header.Number + uint(resume.Delay)
+	err := s.SetNextResume(number)
+	if err != nil {
+		return fmt.Errorf("cannot set next resume: %w", err)
+	}
+
+	logger.Infof("authority set resume scheduled at block #%d by block %s", number, header.Hash())
+	return nil
+}
+
 func (s *GrandpaState) addForcedChange(header *types.Header, fc types.GrandpaForcedChange) error {
 	auths, err := types.GrandpaAuthoritiesRawToAuthorities(fc.Auths)
 	if err != nil {
@@ -134,6 +160,29 @@ func (s *GrandpaState) addForcedChange(header *types.Header, fc types.GrandpaFor
 	return nil
 }
 
+// handleOnDisabled checks the authority disabled by the runtime is in the current authority set.
+// The disabled authority keeps voting until the next authority set change, as its votes are
+// still counted by the other nodes of the network.
+func (s *GrandpaState) handleOnDisabled(onDisabled types.GrandpaOnDisabled) error {
+	setID, err := s.GetCurrentSetID()
+	if err != nil {
+		return fmt.Errorf("cannot get current set id: %w", err)
+	}
+
+	authorities, err := s.GetAuthorities(setID)
+	if err != nil {
+		return fmt.Errorf("cannot get authorities for set id %d: %w", setID, err)
+	}
+
+	if onDisabled.ID >= uint64(len(authorities)) {
+		return fmt.Errorf("%w: %d for %d authorities in set id %d",
+			errInvalidDisabledIndex, onDisabled.ID, len(authorities), setID)
+	}
+
+	logger.Infof("authority %s of set id %d disabled", authorities[onDisabled.ID].Key.Hex(), setID)
+	return nil
+}
+
 func (s *GrandpaState) addScheduledChange(header *types.Header, sc types.GrandpaScheduledChange) error {
 	auths, err := types.GrandpaAuthoritiesRawToAuthorities(sc.Auths)
 	if err != nil {
@@ -209,9 +258,16 @@ func (s *GrandpaState) ApplyScheduledChanges(finalizedHeader *types.Header) erro
 }
 
 // ApplyForcedChanges will check for if there is a scheduled forced change relative to the
-// imported block and then apply it otherwise nothing happens
+// imported block and then apply it otherwise nothing happens. The forced changes are only
+// applied on the best chain, so a forced change announced on a fork is applied once a block
+// of the fork at or past its effective number is imported as the best block.
 func (s *GrandpaState) ApplyForcedChanges(importedBlockHeader *types.Header) error {
-	forcedChange, err := s.forcedChanges.findApplicable(importedBlockHeader.Hash(),
+	importedHash := importedBlockHeader.Hash()
+	if importedHash != s.blockState.BestBlockHash() {
+		return nil
+	}
+
+	forcedChange, err := s.forcedChanges.findApplicable(importedHash,
 		importedBlockHeader.Number, s.blockState.IsDescendantOf)
 	if err != nil {
 		return fmt.Errorf("cannot find applicable forced change: %w", err)
@@ -243,16 +299,6 @@ func (s *GrandpaState) ApplyForcedChanges(importedBlockHeader *types.Header) err
 		canonHeightString,
 	))
 
-	currentSetID, err := s.GetCurrentSetID()
-	if err != nil {
-		return fmt.Errorf("cannot get current set id: %w", err)
-	}
-
-	err = s.setChangeSetIDAtBlock(currentSetID, uint(forcedChange.bestFinalizedNumber))
-	if err != nil {
-		return fmt.Errorf("cannot set change set id at block: %w", err)
-	}
-
 	newSetID, err := s.IncrementSetID()
 	if err != nil {
 		return fmt.Errorf("cannot increment set id: %w", err)
@@ -264,9 +310,17 @@ func (s *GrandpaState) ApplyForcedChanges(importedBlockHeader *types.Header) err
 		return fmt.Errorf("cannot set authorities: %w", err)
 	}
 
-	err = s.setChangeSetIDAtBlock(newSetID, forcedChange.effectiveNumber())
+	// the new authority set finalises the blocks following the best finalised block of the
+	// forced change, the blocks up to it being finalised by the previous authority set
+	err = s.setChangeSetIDAtBlock(newSetID, uint(forcedChange.bestFinalizedNumber))
+	if err != nil {
+		return fmt.Errorf("cannot set change set id at block: %w", err)
+	}
+
+	// the forced change is how the voting resumes after a pause, the new authority set is not paused
+	err = s.deletePause()
 	if err != nil {
-		return fmt.Errorf("cannot set change set id at block")
+		return fmt.Errorf("cannot delete pause: %w", err)
 	}
 
 	logger.Debugf("Applied authority set forced change: %s", forcedChange)
@@ -532,6 +586,45 @@ func (s *GrandpaState) GetNextResume() (blockNumber uint, err error) {
 	return common.BytesToUint(value), nil
 }
 
+// IsPausedAt returns true if the authority set is paused at the given block number, from the
+// block of the next pause to the block of the next resume, if the resume follows the pause.
+func (s *GrandpaState) IsPausedAt(blockNumber uint) (bool, error) {
+	pause, err := s.GetNextPause()
+	if errors.Is(err, database.ErrNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("cannot get next pause: %w", err)
+	}
+
+	if blockNumber < pause {
+		return false, nil
+	}
+
+	resume, err := s.GetNextResume()
+	if errors.Is(err, database.ErrNotFound) {
+		return true, nil
+	} else if err != nil {
+		return false, fmt.Errorf("cannot get next resume: %w", err)
+	}
+
+	return resume <= pause || blockNumber < resume, nil
+}
+
+// deletePause deletes the next pause and resume of the authority set
+func (s *GrandpaState) deletePause() error {
+	err := s.db.Del(pauseKey)
+	if err != nil {
+		return fmt.Errorf("deleting pause: %w", err)
+	}
+
+	err = s.db.Del(resumeKey)
+	if err != nil {
+		return fmt.Errorf("deleting resume: %w", err)
+	}
+
+	return nil
+}
+
 func prevotesKey(round, setID uint64) []byte {
 	prevotesPrefix := []byte("pv")
 	k := roundAndSetIDToBytes(round, setID)
@@ -604,6 +697,33 @@ func (s *GrandpaState) GetPrecommits(round, setID uint64) ([]types.GrandpaSigned
 	return pcs, nil
 }
 
+// SetVoterState stores the round in progress of the voter and the votes it cast in it
+func (s *GrandpaState) SetVoterState(voterState *types.GrandpaVoterState) error {
+	data, err := scale.Marshal(*voterState)
+	if err != nil {
+		return fmt.Errorf("encoding voter state: %w", err)
+	}
+
+	return s.db.Put(voterStateKey, data)
+}
+
+// GetVoterState returns the round in progress of the voter and the votes it cast in it.
+// If the voter never cast a vote, the error database.ErrNotFound is returned.
+func (s *GrandpaState) GetVoterState() (*types.GrandpaVoterState, error) {
+	data, err := s.db.Get(voterStateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	voterState := new(types.GrandpaVoterState)
+	err = scale.Unmarshal(data, voterState)
+	if err != nil {
+		return nil, fmt.Errorf("decoding voter state: %w", err)
+	}
+
+	return voterState, nil
+}
+
 // GetAuthoritiesChangesFromBlock retrieves blocks numbers where authority set changes happened
 func (s *GrandpaState) GetAuthoritiesChangesFromBlock(initialBlockNumber uint) ([]uint, error) {
 	blockNumbers := make([]uint, 0)
@@ -34,7 +34,8 @@ func (p *pendingChange) effectiveNumber() uint {
 
 func (oc *orderedPendingChanges) Len() int { return len(*oc) }
 
-// findApplicable try to retrieve an applicable change from the slice of forced changes
+// findApplicable try to retrieve an applicable change from the slice of forced changes,
+// announced on the chain of the imported block and effective at or before it
 func (oc *orderedPendingChanges) findApplicable(importedHash common.Hash, importedNumber uint,
 	isDescendatOf isDescendantOfFunc) (*pendingChange, error) {
 
@@ -42,7 +43,11 @@ func (oc *orderedPendingChanges) findApplicable(importedHash common.Hash, import
 		announcingHash := forced.announcingHeader.Hash()
 		effectiveNumber := forced.effectiveNumber()
 
-		if importedHash == announcingHash && effectiveNumber == importedNumber {
+		if effectiveNumber > importedNumber {
+			return false, nil
+		}
+
+		if importedHash == announcingHash {
 			return true, nil
 		}
 
@@ -51,9 +56,8 @@ func (oc *orderedPendingChanges) findApplicable(importedHash common.Hash, import
 			return false, fmt.Errorf("cannot check ancestry: %w", err)
 		}
 
-		return isDescendant && effectiveNumber == importedNumber, nil
+		return isDescendant, nil
 	})
-
 }
 
 // lookupChangeWhere return the first pending change which satisfy the condition
@@ -124,6 +124,89 @@ func TestGrandpaState_LatestRound(t *testing.T) {
 	require.Equal(t, uint64(99), r)
 }
 
+func TestGrandpaState_VoterState(t *testing.T) {
+	db := NewInMemoryDB(t)
+	gs, err := NewGrandpaStateFromGenesis(db, nil, testAuths, nil)
+	require.NoError(t, err)
+
+	_, err = gs.GetVoterState()
+	require.ErrorIs(t, err, database.ErrNotFound)
+
+	voterState := &types.GrandpaVoterState{
+		SetID:   1,
+		Round:   99,
+		Prevote: &types.GrandpaVote{Hash: common.Hash{1}, Number: 10},
+	}
+	err = gs.SetVoterState(voterState)
+	require.NoError(t, err)
+
+	storedVoterState, err := gs.GetVoterState()
+	require.NoError(t, err)
+	require.Equal(t, voterState, storedVoterState)
+}
+
+func TestGrandpaState_IsPausedAt(t *testing.T) {
+	db := NewInMemoryDB(t)
+	gs, err := NewGrandpaStateFromGenesis(db, nil, testAuths, nil)
+	require.NoError(t, err)
+
+	handleDigest := func(number uint, value any) {
+		t.Helper()
+		digest := types.NewGrandpaConsensusDigest()
+		require.NoError(t, digest.SetValue(value))
+		header := &types.Header{Number: number}
+		require.NoError(t, gs.HandleGRANDPADigest(header, digest))
+	}
+
+	paused, err := gs.IsPausedAt(100)
+	require.NoError(t, err)
+	require.False(t, paused)
+
+	handleDigest(10, types.GrandpaPause{Delay: 5})
+	pause, err := gs.GetNextPause()
+	require.NoError(t, err)
+	require.Equal(t, uint(15), pause)
+
+	handleDigest(20, types.GrandpaResume{Delay: 10})
+	resume, err := gs.GetNextResume()
+	require.NoError(t, err)
+	require.Equal(t, uint(30), resume)
+
+	expectedPaused := map[uint]bool{14: false, 15: true, 29: true, 30: false}
+	for number, expected := range expectedPaused {
+		paused, err := gs.IsPausedAt(number)
+		require.NoError(t, err)
+		require.Equal(t, expected, paused, "block #%d", number)
+	}
+
+	// a pause following the resume is not resumed
+	handleDigest(40, types.GrandpaPause{Delay: 0})
+	paused, err = gs.IsPausedAt(100)
+	require.NoError(t, err)
+	require.True(t, paused)
+
+	err = gs.deletePause()
+	require.NoError(t, err)
+	paused, err = gs.IsPausedAt(100)
+	require.NoError(t, err)
+	require.False(t, paused)
+}
+
+func TestGrandpaState_HandleOnDisabled(t *testing.T) {
+	db := NewInMemoryDB(t)
+	gs, err := NewGrandpaStateFromGenesis(db, nil, testAuths, nil)
+	require.NoError(t, err)
+
+	digest := types.NewGrandpaConsensusDigest()
+	require.NoError(t, digest.SetValue(types.GrandpaOnDisabled{ID: 0}))
+	err = gs.HandleGRANDPADigest(&types.Header{Number: 1}, digest)
+	require.NoError(t, err)
+
+	require.NoError(t, digest.SetValue(types.GrandpaOnDisabled{ID: uint64(len(testAuths))}))
+	err = gs.HandleGRANDPADigest(&types.Header{Number: 1}, digest)
+	require.ErrorIs(t, err, errInvalidDisabledIndex)
+}
+
 func testBlockState(t *testing.T, db database.Database) *BlockState {
 	ctrl := gomock.NewController(t)
 	telemetryMock := NewMockTelemetry(ctrl)
@@ -645,7 +728,7 @@ func TestApplyForcedChanges(t *testing.T) {
 					},
 				})
 			},
-			importedHeader:  [2]int{0, 9}, // import block number 10 from fork A
+			importedHeader:  [2]int{2, 10}, // import block number 17 from fork C, the best block
 			expectedSetID:   1,
 			expectedPruning: true,
 			expectedGRANDPAAuthoritySet: []types.GrandpaAuthoritiesRaw{
@@ -657,11 +740,29 @@ func TestApplyForcedChanges(t *testing.T) {
 				ctrl := gomock.NewController(t)
 
 				telemetryMock := NewMockTelemetry(ctrl)
-				telemetryMock.EXPECT().SendMessage(gomock.Eq(&telemetry.AfgApplyingForcedAuthoritySetChange{Block: "8"}))
+				telemetryMock.EXPECT().SendMessage(gomock.Eq(&telemetry.AfgApplyingForcedAuthoritySetChange{Block: "15"}))
 
 				return telemetryMock
 			}(),
 		},
+		"forced_change_on_non_best_fork_should_do_nothing": {
+			generateForks: genericForks,
+			changes: func(gs *GrandpaState, headers [][]*types.Header) {
+				chainABlock8 := headers[0][7]
+				gs.addForcedChange(chainABlock8, types.GrandpaForcedChange{
+					Delay:              2,
+					BestFinalizedBlock: 3,
+					Auths: []types.GrandpaAuthoritiesRaw{
+						{Key: keyring.KeyCharlie.Public().(*sr25519.PublicKey).AsBytes()},
+					},
+				})
+			},
+			importedHeader:              [2]int{0, 9}, // import block number 10 from chain A, not the best block
+			expectedSetID:               0,
+			expectedPruning:             false,
+			expectedGRANDPAAuthoritySet: genesisGrandpaVoters,
+			telemetryMock:               nil,
+		},
 		"import_block_before_forced_change_should_do_nothing": {
 			generateForks: genericForks,
 			changes: func(gs *GrandpaState, headers [][]*types.Header) {
@@ -705,25 +806,14 @@ func TestApplyForcedChanges(t *testing.T) {
 		"apply_forced_change_with_pending_scheduled_changes_should_fail": {
 			generateForks: genericForks,
 			changes: func(gs *GrandpaState, headers [][]*types.Header) {
-				chainBBlock6 := headers[1][3]
-				gs.addScheduledChange(chainBBlock6, types.GrandpaScheduledChange{
+				chainABlock4 := headers[0][3]
+				gs.addScheduledChange(chainABlock4, types.GrandpaScheduledChange{
 					Delay: 0,
 					Auths: []types.GrandpaAuthoritiesRaw{
 						{Key: keyring.KeyDave.Public().(*sr25519.PublicKey).AsBytes()},
 					},
 				})
 
-				chainCBlock9 := headers[2][2]
-				gs.addForcedChange(chainCBlock9, types.GrandpaForcedChange{
-					Delay:              3,
-					BestFinalizedBlock: 3,
-					Auths: []types.GrandpaAuthoritiesRaw{
-						{Key: keyring.KeyCharlie.Public().(*sr25519.PublicKey).AsBytes()},
-						{Key: keyring.KeyBob.Public().(*sr25519.PublicKey).AsBytes()},
-						{Key: keyring.KeyDave.Public().(*sr25519.PublicKey).AsBytes()},
-					},
-				})
-
 				chainBBlock9 := headers[1][6]
 				gs.addForcedChange(chainBBlock9, types.GrandpaForcedChange{
 					Delay:              2,
@@ -734,8 +824,19 @@ func TestApplyForcedChanges(t *testing.T) {
 						{Key: keyring.KeyEve.Public().(*sr25519.PublicKey).AsBytes()},
 					},
 				})
+
+				chainCBlock9 := headers[2][2]
+				gs.addForcedChange(chainCBlock9, types.GrandpaForcedChange{
+					Delay:              7,
+					BestFinalizedBlock: 6,
+					Auths: []types.GrandpaAuthoritiesRaw{
+						{Key: keyring.KeyCharlie.Public().(*sr25519.PublicKey).AsBytes()},
+						{Key: keyring.KeyBob.Public().(*sr25519.PublicKey).AsBytes()},
+						{Key: keyring.KeyDave.Public().(*sr25519.PublicKey).AsBytes()},
+					},
+				})
 			},
-			importedHeader:              [2]int{1, 8}, // block number 11 imported
+			importedHeader:              [2]int{2, 10}, // block number 17 imported
 			wantErr:                     errPendingScheduledChanges,
 			expectedGRANDPAAuthoritySet: genesisGrandpaVoters,
 			expectedSetID:               0,
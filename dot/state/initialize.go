@@ -9,6 +9,7 @@
 
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/aura"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/genesis"
 	"github.com/ChainSafe/gossamer/lib/runtime"
@@ -56,9 +57,17 @@ func (s *Service) Initialise(gen *genesis.Genesis, header *types.Header, t trie.
 	}
 	defer rt.Stop()
 
-	babeCfg, err := s.loadBabeConfigurationFromRuntime(rt)
-	if err != nil {
-		return err
+	var babeCfg *types.BabeConfiguration
+	if gen.ConsensusEngine == aura.EngineName {
+		babeCfg, err = aura.GenesisConfiguration(rt)
+		if err != nil {
+			return fmt.Errorf("failed to fetch genesis aura configuration: %w", err)
+		}
+	} else {
+		babeCfg, err = s.loadBabeConfigurationFromRuntime(rt)
+		if err != nil {
+			return err
+		}
 	}
 
 	// write initial genesis values to database
@@ -76,7 +85,7 @@ func (s *Service) Initialise(gen *genesis.Genesis, header *types.Header, t trie.
 	}
 
 	// create storage state from genesis trie
-	storageState, err := NewStorageState(db, blockState, tries)
+	storageState, err := NewStorageState(db, blockState, tries, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create storage state from trie: %s", err)
 	}
@@ -14,6 +14,7 @@
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/runtime/storage"
 	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/cache"
 	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
 	"github.com/ChainSafe/gossamer/pkg/trie/inmemory/proof"
 )
@@ -35,6 +36,15 @@ type InmemoryStorageState struct {
 	tries      *Tries
 
 	db GetterPutterNewBatcher
+	// trieCache is the database caching the trie nodes and storage values, nil if
+	// the trie cache is disabled
+	trieCache *trieCacheDatabase
+	// light is true if the node is a light node, only storing the runtime code
+	light bool
+	// changes is the index of the blocks each storage key changed at, nil if it is not maintained
+	changes *StorageChanges
+	// snapshot is the flat snapshot of the state of the best block, nil if it is not maintained
+	snapshot *StateSnapshot
 	sync.RWMutex
 
 	// change notifiers
@@ -44,23 +54,47 @@ type InmemoryStorageState struct {
 }
 
 // NewStorageState creates a new StorageState backed by the given block state
-// and database located at basePath.
+// and database located at basePath. The trie nodes and storage values read from
+// the database are cached in the given trie cache, if it is not nil.
 func NewStorageState(db database.Database, blockState *BlockState,
-	tries *Tries) (*InmemoryStorageState, error) {
-	storageTable := database.NewTable(db, storagePrefix)
-
-	return &InmemoryStorageState{
+	tries *Tries, trieCache cache.TrieCache) (*InmemoryStorageState, error) {
+	storageState := &InmemoryStorageState{
 		blockState:   blockState,
 		tries:        tries,
-		db:           storageTable,
+		db:           database.NewTable(db, storagePrefix),
 		observerList: []Observer{},
 		pruner:       &pruner.ArchiveNode{},
-	}, nil
+	}
+
+	if trieCache != nil {
+		storageState.trieCache = newTrieCacheDatabase(storageState.db, trieCache)
+		storageState.db = storageState.trieCache
+	}
+
+	return storageState, nil
+}
+
+// TrieCacheStats returns the statistics of the trie cache, and false if the trie cache is disabled.
+func (s *InmemoryStorageState) TrieCacheStats() (stats TrieCacheStats, enabled bool) {
+	if s.trieCache == nil {
+		return stats, false
+	}
+	return s.trieCache.stats(), true
 }
 
 // StoreTrie stores the given trie in the StorageState and writes it to the database
 func (s *InmemoryStorageState) StoreTrie(ts *storage.TrieState, header *types.Header) error {
 	root := ts.Trie().MustHash()
+	if s.light {
+		err := s.storeLightCode(ts.LoadCode())
+		if err != nil {
+			return fmt.Errorf("storing runtime code: %w", err)
+		}
+
+		go s.notifyAll(root)
+		return nil
+	}
+
 	s.tries.softSet(root, ts.Trie())
 
 	if header != nil {
@@ -74,6 +108,20 @@ func (s *InmemoryStorageState) StoreTrie(ts *storage.TrieState, header *types.He
 		if err != nil {
 			return fmt.Errorf("storing journal record: %w", err)
 		}
+
+		if s.changes != nil {
+			err = s.changes.Store(header, ts.WrittenKeys())
+			if err != nil {
+				return fmt.Errorf("storing storage changes for block hash %s: %w", header.Hash(), err)
+			}
+		}
+
+		if s.snapshot != nil {
+			err = s.snapshot.Store(header, root, ts)
+			if err != nil {
+				return fmt.Errorf("storing state snapshot changes for block hash %s: %w", header.Hash(), err)
+			}
+		}
 	}
 
 	logger.Tracef("cached trie in storage state: %s", root)
@@ -102,7 +150,13 @@ func (s *InmemoryStorageState) TrieState(root *common.Hash) (*storage.TrieState,
 	}
 
 	t := s.tries.get(*root)
-	if t == nil {
+	if t == nil && s.light {
+		var err error
+		t, err = s.lightTrie()
+		if err != nil {
+			return nil, fmt.Errorf("while loading light trie: %w", err)
+		}
+	} else if t == nil {
 		var err error
 		t, err = s.LoadFromDB(*root)
 		if err != nil {
@@ -118,6 +172,9 @@ func (s *InmemoryStorageState) TrieState(root *common.Hash) (*storage.TrieState,
 	// the modifications?
 	nextTrie := t.(*inmemory_trie.InMemoryTrie).Snapshot()
 	next := storage.NewTrieState(nextTrie)
+	if s.snapshot != nil {
+		next.SetFlatReader(s.snapshot.Reader(*root))
+	}
 
 	logger.Tracef("returning trie with root %s to be modified", root)
 	return next, nil
@@ -149,6 +206,10 @@ func (s *InmemoryStorageState) loadTrie(root *common.Hash) (trie.Trie, error) {
 		return t, nil
 	}
 
+	if s.light {
+		return nil, fmt.Errorf("%w: trie at root %s", ErrStateNotStored, *root)
+	}
+
 	tr, err := s.LoadFromDB(*root)
 	if err != nil {
 		return nil, fmt.Errorf("trie does not exist at root %s: %w", *root, err)
@@ -181,7 +242,32 @@ func (s *InmemoryStorageState) GetStorage(root *common.Hash, key []byte) ([]byte
 		return val, nil
 	}
 
-	return inmemory_trie.GetFromDB(s.db, *root, key)
+	if s.light {
+		return s.getLightStorage(*root, key)
+	}
+
+	if s.trieCache != nil {
+		value := s.trieCache.getValue(*root, key)
+		if value != nil {
+			return value, nil
+		}
+	}
+
+	value, err := inmemory_trie.GetFromDB(s.db, *root, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if value != nil && s.trieCache != nil {
+		s.trieCache.setValue(*root, key, value)
+	}
+	return value, nil
+}
+
+// GetStorageFromDB gets the value at the given key in the trie with the given root, reading
+// it from the database rather than from the tries held in memory.
+func (s *InmemoryStorageState) GetStorageFromDB(root common.Hash, key []byte) ([]byte, error) {
+	return inmemory_trie.GetFromDB(s.db, root, key)
 }
 
 // GetStorageByBlockHash returns the value at the given key at the given block hash
@@ -208,6 +294,27 @@ func (s *InmemoryStorageState) GetStorageByBlockHash(bhash *common.Hash, key []b
 	return s.GetStorage(&root, key)
 }
 
+// GetChangedBlocks returns the hashes of the blocks numbered from start to end included, on any
+// fork, at which the value of the key given may have changed. It returns false if the changes
+// of these blocks are not all indexed, in which case the hashes returned are nil.
+func (s *InmemoryStorageState) GetChangedBlocks(key []byte, start, end uint) (
+	hashes []common.Hash, indexed bool, err error) {
+	if s.changes == nil {
+		return nil, false, nil
+	}
+
+	indexed, err = s.changes.Covers(start)
+	if err != nil || !indexed {
+		return nil, false, err
+	}
+
+	hashes, err = s.changes.ChangedBlocks(key, start, end)
+	if err != nil {
+		return nil, false, err
+	}
+	return hashes, true, nil
+}
+
 // GetStateRootFromBlock returns the state root hash of a given block hash
 func (s *InmemoryStorageState) GetStateRootFromBlock(bhash *common.Hash) (*common.Hash, error) {
 	if bhash == nil {
@@ -249,6 +356,28 @@ func (s *InmemoryStorageState) GetKeysWithPrefix(root *common.Hash, prefix []byt
 	return tr.GetKeysWithPrefix(prefix), nil
 }
 
+// GetKeysPaged returns at most limit keys matching the given prefix and strictly greater
+// than the given start key for the given hash (or best block state root if hash is nil),
+// in lexicographic order. Unlike GetKeysWithPrefix, the keys following the page are not
+// listed, so large key sets can be paginated with the last key of the previous page.
+func (s *InmemoryStorageState) GetKeysPaged(root *common.Hash, prefix, startKey []byte,
+	limit uint32) ([][]byte, error) {
+	tr, err := s.loadTrie(root)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([][]byte, 0)
+	for key := range tr.PrefixedKeysFrom(prefix, startKey) {
+		if uint32(len(keys)) >= limit { //nolint:gosec
+			break
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
 // GetStorageChild returns a child trie, if it exists
 func (s *InmemoryStorageState) GetStorageChild(root *common.Hash, keyToChild []byte) (trie.Trie, error) {
 	tr, err := s.loadTrie(root)
@@ -289,3 +418,10 @@ func (s *InmemoryStorageState) GenerateTrieProof(stateRoot common.Hash, keys [][
 	encodedProofNodes [][]byte, err error) {
 	return proof.Generate(stateRoot[:], keys, s.db)
 }
+
+// GenerateWitness returns the trie nodes on the path of the keys and of the child trie keys
+// given on the state root trie, keys absent from the trie being proven absent by these nodes.
+func (s *InmemoryStorageState) GenerateWitness(stateRoot common.Hash, keys [][]byte,
+	childKeys map[string][][]byte) (encodedProofNodes [][]byte, err error) {
+	return proof.GenerateWitness(stateRoot[:], keys, childKeys, s.db)
+}
@@ -24,7 +24,7 @@ func newTestStorageState(t *testing.T) *InmemoryStorageState {
 	tries := newTriesEmpty()
 	bs := newTestBlockState(t, tries)
 
-	s, err := NewStorageState(db, bs, tries)
+	s, err := NewStorageState(db, bs, tries, nil)
 	require.NoError(t, err)
 	return s
 }
@@ -191,7 +191,7 @@ func TestGetStorageChildAndGetStorageFromChild(t *testing.T) {
 	blockState, err := NewBlockStateFromGenesis(db, tries, &genHeader, telemetryMock)
 	require.NoError(t, err)
 
-	storage, err := NewStorageState(db, blockState, tries)
+	storage, err := NewStorageState(db, blockState, tries, nil)
 	require.NoError(t, err)
 
 	trieState := runtime.NewTrieState(genTrie)
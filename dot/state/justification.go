@@ -0,0 +1,289 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// justificationCommit is the round and the commit of an encoded GRANDPA justification,
+// followed by the vote ancestry of the justification if it has one.
+type justificationCommit struct {
+	Round        uint64
+	TargetHash   common.Hash
+	TargetNumber uint32
+	Precommits   []justificationPrecommit
+}
+
+type justificationPrecommit struct {
+	TargetHash   common.Hash
+	TargetNumber uint32
+	Signature    [64]byte
+	AuthorityID  [32]byte
+}
+
+// compactAncestor is a header of the vote ancestry of a stored justification. The headers
+// of the finalised chain are only referenced by their hash, the other headers being encoded
+// since the blocks of the pruned forks are not stored.
+type compactAncestor struct {
+	Hash common.Hash
+	// Encoded is the encoded header, empty if the header is stored with the finalised blocks
+	Encoded []byte
+}
+
+// HasJustification returns if the db contains a Justification at the given hash
+func (bs *BlockState) HasJustification(hash common.Hash) (bool, error) {
+	return bs.db.Has(prefixKey(hash, justificationPrefix))
+}
+
+// SetJustification sets a Justification in the database. The justification previously
+// stored for the highest block has its vote ancestry compacted, and is pruned if the
+// justifications are pruned and its block does not change the GRANDPA authority set.
+// The justifications of blocks lower than the highest block justified are stored the same.
+func (bs *BlockState) SetJustification(hash common.Hash, data []byte) error {
+	bs.justificationLock.Lock()
+	defer bs.justificationLock.Unlock()
+
+	batch := bs.db.NewBatch()
+	err := batch.Put(prefixKey(hash, justificationPrefix), data)
+	if err != nil {
+		return err
+	}
+	err = batch.Del(prefixKey(hash, justificationAncestryPrefix))
+	if err != nil {
+		return err
+	}
+
+	latestHash, err := bs.db.Get(latestJustificationKey)
+	if errors.Is(err, database.ErrNotFound) {
+		err = batch.Put(latestJustificationKey, hash.ToBytes())
+		if err != nil {
+			return err
+		}
+		return batch.Flush()
+	} else if err != nil {
+		return fmt.Errorf("getting latest justification: %w", err)
+	}
+	latest := common.NewHash(latestHash)
+	if latest == hash {
+		return batch.Flush()
+	}
+
+	header, err := bs.GetHeader(hash)
+	if errors.Is(err, database.ErrNotFound) {
+		// the justification of an unknown block is stored as is
+		return batch.Flush()
+	} else if err != nil {
+		return fmt.Errorf("getting header of block %s: %w", hash, err)
+	}
+	latestHeader, err := bs.GetHeader(latest)
+	if errors.Is(err, database.ErrNotFound) {
+		// the block of the latest justification was reverted
+		err = batch.Put(latestJustificationKey, hash.ToBytes())
+		if err != nil {
+			return err
+		}
+		return batch.Flush()
+	} else if err != nil {
+		return fmt.Errorf("getting header of block %s: %w", latest, err)
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return err
+	}
+
+	if header.Number < latestHeader.Number {
+		return bs.retireJustification(header)
+	}
+
+	err = bs.db.Put(latestJustificationKey, hash.ToBytes())
+	if err != nil {
+		return err
+	}
+	return bs.retireJustification(latestHeader)
+}
+
+// retireJustification prunes the justification of the block of the header given if the
+// justifications are pruned and the block does not change the authority set, and compacts
+// its vote ancestry otherwise.
+func (bs *BlockState) retireJustification(header *types.Header) error {
+	hash := header.Hash()
+	if bs.pruneJustifications {
+		changesAuthorities, err := changesAuthoritySet(header)
+		if err != nil {
+			return fmt.Errorf("checking authority set change of block %s: %w", hash, err)
+		}
+
+		if !changesAuthorities {
+			batch := bs.db.NewBatch()
+			err = batch.Del(prefixKey(hash, justificationPrefix))
+			if err != nil {
+				return err
+			}
+			err = batch.Del(prefixKey(hash, justificationAncestryPrefix))
+			if err != nil {
+				return err
+			}
+
+			logger.Tracef("pruned justification of block #%d (%s)", header.Number, hash)
+			return batch.Flush()
+		}
+	}
+
+	err := bs.compactJustification(hash)
+	if err != nil {
+		return fmt.Errorf("compacting justification of block %s: %w", hash, err)
+	}
+	return nil
+}
+
+// compactJustification replaces the headers of the finalised chain in the vote ancestry of
+// the justification stored for the given block by their hash.
+func (bs *BlockState) compactJustification(hash common.Hash) error {
+	data, err := bs.db.Get(prefixKey(hash, justificationPrefix))
+	if err != nil {
+		return err
+	}
+
+	reader := bytes.NewReader(data)
+	err = scale.NewDecoder(reader).Decode(new(justificationCommit))
+	if err != nil {
+		return fmt.Errorf("decoding commit: %w", err)
+	}
+	commitLength := len(data) - reader.Len()
+
+	var ancestry []types.Header
+	if reader.Len() > 0 {
+		err = scale.Unmarshal(data[commitLength:], &ancestry)
+		if err != nil {
+			return fmt.Errorf("decoding vote ancestry: %w", err)
+		}
+	}
+
+	compacted := false
+	ancestors := make([]compactAncestor, len(ancestry))
+	for i := range ancestry {
+		ancestorHash := ancestry[i].Hash()
+		canonicalHash, err := bs.db.Get(headerHashKey(uint64(ancestry[i].Number)))
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return fmt.Errorf("getting hash of block %d: %w", ancestry[i].Number, err)
+		}
+		if bytes.Equal(canonicalHash, ancestorHash.ToBytes()) {
+			ancestors[i] = compactAncestor{Hash: ancestorHash}
+			compacted = true
+			continue
+		}
+
+		encoded, err := scale.Marshal(ancestry[i])
+		if err != nil {
+			return fmt.Errorf("encoding header %s: %w", ancestorHash, err)
+		}
+		ancestors[i] = compactAncestor{Hash: ancestorHash, Encoded: encoded}
+	}
+
+	if !compacted {
+		return nil
+	}
+
+	encodedAncestors, err := scale.Marshal(ancestors)
+	if err != nil {
+		return fmt.Errorf("encoding compact vote ancestry: %w", err)
+	}
+
+	batch := bs.db.NewBatch()
+	err = batch.Put(prefixKey(hash, justificationPrefix), data[:commitLength])
+	if err != nil {
+		return err
+	}
+	err = batch.Put(prefixKey(hash, justificationAncestryPrefix), encodedAncestors)
+	if err != nil {
+		return err
+	}
+	return batch.Flush()
+}
+
+// GetJustification retrieves a Justification from the database
+func (bs *BlockState) GetJustification(hash common.Hash) ([]byte, error) {
+	data, err := bs.db.Get(prefixKey(hash, justificationPrefix))
+	if err != nil {
+		return nil, err
+	}
+
+	encodedAncestors, err := bs.db.Get(prefixKey(hash, justificationAncestryPrefix))
+	if errors.Is(err, database.ErrNotFound) {
+		return data, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("getting compact vote ancestry: %w", err)
+	}
+
+	var ancestors []compactAncestor
+	err = scale.Unmarshal(encodedAncestors, &ancestors)
+	if err != nil {
+		return nil, fmt.Errorf("decoding compact vote ancestry: %w", err)
+	}
+
+	ancestry := make([]types.Header, len(ancestors))
+	for i, ancestor := range ancestors {
+		if len(ancestor.Encoded) > 0 {
+			err = scale.Unmarshal(ancestor.Encoded, &ancestry[i])
+			if err != nil {
+				return nil, fmt.Errorf("decoding header %s: %w", ancestor.Hash, err)
+			}
+			continue
+		}
+
+		header, err := bs.loadHeaderFromDatabase(ancestor.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("loading vote ancestry header %s: %w", ancestor.Hash, err)
+		}
+		ancestry[i] = *header
+	}
+
+	encodedAncestry, err := scale.Marshal(ancestry)
+	if err != nil {
+		return nil, fmt.Errorf("encoding vote ancestry: %w", err)
+	}
+	return bytes.Join([][]byte{data, encodedAncestry}, nil), nil
+}
+
+// changesAuthoritySet returns true if the header given has a GRANDPA consensus digest
+// scheduling or forcing a change of the authority set.
+func changesAuthoritySet(header *types.Header) (bool, error) {
+	for _, item := range header.Digest {
+		value, err := item.Value()
+		if err != nil {
+			return false, fmt.Errorf("getting digest value: %w", err)
+		}
+
+		consensusDigest, ok := value.(types.ConsensusDigest)
+		if !ok || consensusDigest.ConsensusEngineID != types.GrandpaEngineID {
+			continue
+		}
+
+		grandpaDigest := types.GrandpaConsensusDigest{}
+		err = scale.Unmarshal(consensusDigest.Data, &grandpaDigest)
+		if err != nil {
+			return false, fmt.Errorf("decoding grandpa consensus digest: %w", err)
+		}
+
+		grandpaValue, err := grandpaDigest.Value()
+		if err != nil {
+			return false, fmt.Errorf("getting grandpa consensus digest value: %w", err)
+		}
+
+		switch grandpaValue.(type) {
+		case types.GrandpaScheduledChange, types.GrandpaForcedChange:
+			return true, nil
+		}
+	}
+	return false, nil
+}
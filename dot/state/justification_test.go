@@ -0,0 +1,183 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func addScheduledChange(t *testing.T, digest types.Digest) types.Digest {
+	t.Helper()
+
+	grandpaDigest := types.NewGrandpaConsensusDigest()
+	err := grandpaDigest.SetValue(types.GrandpaScheduledChange{})
+	require.NoError(t, err)
+	data, err := scale.Marshal(grandpaDigest)
+	require.NoError(t, err)
+
+	err = digest.Add(types.ConsensusDigest{
+		ConsensusEngineID: types.GrandpaEngineID,
+		Data:              data,
+	})
+	require.NoError(t, err)
+	return digest
+}
+
+func newEncodedJustification(t *testing.T, header *types.Header, ancestry ...types.Header) []byte {
+	t.Helper()
+
+	commit := justificationCommit{
+		Round:        1,
+		TargetHash:   header.Hash(),
+		TargetNumber: uint32(header.Number),
+		Precommits: []justificationPrecommit{{
+			TargetHash:   header.Hash(),
+			TargetNumber: uint32(header.Number),
+			Signature:    [64]byte{1},
+			AuthorityID:  [32]byte{2},
+		}},
+	}
+	encoded, err := scale.Marshal(commit)
+	require.NoError(t, err)
+	if ancestry == nil {
+		return encoded
+	}
+
+	encodedAncestry, err := scale.Marshal(ancestry)
+	require.NoError(t, err)
+	return append(encoded, encodedAncestry...)
+}
+
+func TestBlockState_SetJustification_pruning(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		pruneJustifications bool
+		kept                []bool
+	}{
+		"archive": {
+			kept: []bool{true, true, true, true},
+		},
+		"pruning": {
+			pruneJustifications: true,
+			// the justification of the block 2 changing the authority set and the latest
+			// justification are kept
+			kept: []bool{false, true, false, true},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			bs := newTestBlockState(t, newTriesEmpty())
+			bs.pruneJustifications = testCase.pruneJustifications
+
+			var headers []*types.Header
+			parentHash := testGenesisHeader.Hash()
+			for number := uint(1); number <= 4; number++ {
+				digest := createPrimaryBABEDigest(t)
+				if number == 2 {
+					digest = addScheduledChange(t, digest)
+				}
+				header := AddBlockToState(t, bs, number, digest, parentHash)
+				headers = append(headers, header)
+				parentHash = header.Hash()
+			}
+			err := bs.SetFinalisedHash(headers[3].Hash(), 1, 0)
+			require.NoError(t, err)
+
+			for _, header := range []*types.Header{headers[0], headers[1], headers[3], headers[2]} {
+				err = bs.SetJustification(header.Hash(), newEncodedJustification(t, header))
+				require.NoError(t, err)
+			}
+
+			for i, header := range headers {
+				has, err := bs.HasJustification(header.Hash())
+				require.NoError(t, err)
+				assert.Equalf(t, testCase.kept[i], has, "justification of block %d", header.Number)
+			}
+
+			justification, err := bs.GetJustification(headers[3].Hash())
+			require.NoError(t, err)
+			assert.Equal(t, newEncodedJustification(t, headers[3]), justification)
+		})
+	}
+}
+
+func TestBlockState_SetJustification_compactAncestry(t *testing.T) {
+	t.Parallel()
+
+	bs := newTestBlockState(t, newTriesEmpty())
+
+	header1 := AddBlockToState(t, bs, 1, createPrimaryBABEDigest(t), testGenesisHeader.Hash())
+	header2 := AddBlockToState(t, bs, 2, createPrimaryBABEDigest(t), header1.Hash())
+	header3 := AddBlockToState(t, bs, 3, createPrimaryBABEDigest(t), header2.Hash())
+	forkPreDigest, err := types.NewBabePrimaryPreDigest(1, 2, [32]byte{}, [64]byte{}).ToPreRuntimeDigest()
+	require.NoError(t, err)
+	forkDigest := types.NewDigest()
+	err = forkDigest.Add(*forkPreDigest)
+	require.NoError(t, err)
+	forkHeader := AddBlockToState(t, bs, 2, forkDigest, header1.Hash())
+
+	justification := newEncodedJustification(t, header1, *header2, *forkHeader)
+	err = bs.SetJustification(header1.Hash(), justification)
+	require.NoError(t, err)
+
+	// finalising the block 3 prunes the fork and stores the block 2 as finalised
+	err = bs.SetFinalisedHash(header3.Hash(), 1, 0)
+	require.NoError(t, err)
+	err = bs.SetJustification(header3.Hash(), newEncodedJustification(t, header3))
+	require.NoError(t, err)
+
+	stored, err := bs.db.Get(prefixKey(header1.Hash(), justificationPrefix))
+	require.NoError(t, err)
+	assert.Equal(t, newEncodedJustification(t, header1), stored)
+
+	encodedAncestors, err := bs.db.Get(prefixKey(header1.Hash(), justificationAncestryPrefix))
+	require.NoError(t, err)
+	var ancestors []compactAncestor
+	err = scale.Unmarshal(encodedAncestors, &ancestors)
+	require.NoError(t, err)
+	require.Len(t, ancestors, 2)
+	assert.Equal(t, header2.Hash(), ancestors[0].Hash)
+	assert.Empty(t, ancestors[0].Encoded)
+	assert.Equal(t, forkHeader.Hash(), ancestors[1].Hash)
+	assert.NotEmpty(t, ancestors[1].Encoded)
+
+	restored, err := bs.GetJustification(header1.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, justification, restored)
+
+	// the justification of a block lower than the latest one justified is compacted right away
+	err = bs.SetJustification(header1.Hash(), justification)
+	require.NoError(t, err)
+	stored, err = bs.db.Get(prefixKey(header1.Hash(), justificationPrefix))
+	require.NoError(t, err)
+	assert.Equal(t, newEncodedJustification(t, header1), stored)
+	restored, err = bs.GetJustification(header1.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, justification, restored)
+}
+
+func Test_changesAuthoritySet(t *testing.T) {
+	t.Parallel()
+
+	header := types.NewHeader(common.Hash{}, common.Hash{}, common.Hash{}, 1, addScheduledChange(t, types.NewDigest()))
+	changes, err := changesAuthoritySet(header)
+	require.NoError(t, err)
+	assert.True(t, changes)
+
+	header = types.NewHeader(common.Hash{}, common.Hash{}, common.Hash{}, 1, types.NewDigest())
+	changes, err = changesAuthoritySet(header)
+	require.NoError(t, err)
+	assert.False(t, changes)
+}
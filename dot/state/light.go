@@ -0,0 +1,102 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+)
+
+// ErrStateNotStored is returned by a light node when reading the state of a block,
+// since it only stores the block headers, the justifications and the runtime code.
+// The other storage values are read from the peers with the remote read requests
+// of the light protocol, see the ReadRemoteStorage method of the network service.
+var ErrStateNotStored = errors.New("state is not stored by a light node")
+
+// lightCodeKey is the key of the runtime code stored by a light node. It is not
+// a node hash, so it does not collide with the trie nodes of the genesis state.
+var lightCodeKey = []byte("light:code")
+
+// SetLightMode sets the storage state to only store the runtime code of the
+// tries stored, instead of their nodes.
+func (s *InmemoryStorageState) SetLightMode() {
+	s.light = true
+}
+
+// storeLightCode stores the latest runtime code known by the light node, read from
+// the peers when an imported header signals the runtime environment was updated.
+func (s *InmemoryStorageState) storeLightCode(code []byte) error {
+	if len(code) == 0 {
+		return nil
+	}
+
+	stored, err := s.db.Get(lightCodeKey)
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return fmt.Errorf("getting stored runtime code: %w", err)
+	}
+
+	if bytes.Equal(stored, code) {
+		return nil
+	}
+
+	return s.db.Put(lightCodeKey, code)
+}
+
+// initLightCode stores the given genesis runtime code if no runtime code is stored yet.
+func (s *InmemoryStorageState) initLightCode(genesisCode []byte) error {
+	_, err := s.lightCode()
+	if err == nil {
+		return nil
+	} else if !errors.Is(err, ErrStateNotStored) {
+		return err
+	}
+
+	return s.storeLightCode(genesisCode)
+}
+
+// lightCode returns the latest runtime code known by the light node.
+func (s *InmemoryStorageState) lightCode() ([]byte, error) {
+	code, err := s.db.Get(lightCodeKey)
+	if err != nil {
+		if errors.Is(err, database.ErrNotFound) {
+			return nil, fmt.Errorf("%w: runtime code not found", ErrStateNotStored)
+		}
+		return nil, fmt.Errorf("getting stored runtime code: %w", err)
+	}
+
+	return code, nil
+}
+
+// lightTrie returns a trie holding only the latest runtime code known by the light node,
+// which is enough to instantiate the runtime at any block.
+func (s *InmemoryStorageState) lightTrie() (trie.Trie, error) {
+	code, err := s.lightCode()
+	if err != nil {
+		return nil, err
+	}
+
+	t := inmemory_trie.NewEmptyTrie()
+	err = t.Put(codeKey, code)
+	if err != nil {
+		return nil, fmt.Errorf("putting runtime code in trie: %w", err)
+	}
+
+	return t, nil
+}
+
+// getLightStorage returns the value at the given key in the state with the given root,
+// which a light node only knows for the runtime code.
+func (s *InmemoryStorageState) getLightStorage(root common.Hash, key []byte) ([]byte, error) {
+	if !bytes.Equal(key, codeKey) {
+		return nil, fmt.Errorf("%w: reading key 0x%x at state root %s", ErrStateNotStored, key, root)
+	}
+
+	return s.lightCode()
+}
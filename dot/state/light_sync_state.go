@@ -0,0 +1,288 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/genesis"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+var errNoLightSyncState = errors.New("no light sync state before the first block is finalised")
+
+// LightSyncState returns the light sync state of the highest finalised block, embedded in the
+// chain specs generated by sync_state_genSyncSpec for light clients to start syncing from it.
+// Its fields are encoded as the LightSyncState of substrate's sc-sync-state-rpc, with the BABE
+// epoch changes only holding the epochs of the highest finalised block and the next one.
+func (s *Service) LightSyncState() (*genesis.LightSyncState, error) {
+	finalised, err := s.Block.GetHighestFinalisedHeader()
+	if err != nil {
+		return nil, fmt.Errorf("getting highest finalised header: %w", err)
+	}
+
+	if finalised.Number == 0 {
+		return nil, errNoLightSyncState
+	}
+
+	encodedHeader, err := scale.Marshal(*finalised)
+	if err != nil {
+		return nil, fmt.Errorf("encoding highest finalised header: %w", err)
+	}
+
+	epochChanges, err := s.babeEpochChanges(finalised)
+	if err != nil {
+		return nil, fmt.Errorf("getting BABE epoch changes: %w", err)
+	}
+
+	authoritySet, err := s.grandpaAuthoritySet()
+	if err != nil {
+		return nil, fmt.Errorf("getting GRANDPA authority set: %w", err)
+	}
+
+	return &genesis.LightSyncState{
+		FinalizedBlockHeader: common.BytesToHex(encodedHeader),
+		BabeEpochChanges:     common.BytesToHex(epochChanges),
+		// the weight of the blocks, their number of primary slots, is not tracked
+		BabeFinalizedBlockWeight: 0,
+		GrandpaAuthoritySet:      common.BytesToHex(authoritySet),
+	}, nil
+}
+
+// babeEpoch is the sc_consensus_babe::Epoch of substrate
+type babeEpoch struct {
+	EpochIndex  uint64
+	StartSlot   uint64
+	Duration    uint64
+	Authorities []types.AuthorityRaw
+	Randomness  [types.RandomnessLength]byte
+	C1          uint64
+	C2          uint64
+	// AllowedSlots is the AllowedSlots enum index, matching the secondary slots value
+	AllowedSlots byte
+}
+
+// epochChangeNode is a node of the epoch changes fork tree, keyed by the block announcing
+// its epochs. It holds both the epochs 0 and 1 if it is announced by the block 1.
+type epochChangeNode struct {
+	hash   common.Hash
+	number uint
+	epochs []babeEpoch
+}
+
+// babeEpochChanges returns the SCALE encoded EpochChanges of substrate, holding the epoch of the
+// finalised header given and the next epoch, each keyed by the block announcing it: the block 1
+// for the epochs 0 and 1, and the first block of the previous epoch for the other ones.
+func (s *Service) babeEpochChanges(finalised *types.Header) ([]byte, error) {
+	current, err := s.Epoch.GetEpochForBlock(finalised)
+	if err != nil {
+		return nil, fmt.Errorf("getting epoch of highest finalised block: %w", err)
+	}
+
+	epochs := make(map[uint64]babeEpoch, 3)
+	for epoch := current - min(current, 1); epoch <= current+1; epoch++ {
+		epochs[epoch], err = s.babeEpoch(epoch, finalised)
+		if err != nil {
+			return nil, fmt.Errorf("getting epoch %d: %w", epoch, err)
+		}
+	}
+
+	var nodes []epochChangeNode
+	if current <= 1 {
+		firstBlock, err := s.Block.GetHeaderByNumber(1)
+		if err != nil {
+			return nil, fmt.Errorf("getting block 1: %w", err)
+		}
+		nodes = append(nodes, epochChangeNode{
+			hash:   firstBlock.Hash(),
+			number: 1,
+			epochs: []babeEpoch{epochs[0], epochs[1]},
+		})
+	}
+
+	for epoch := max(current, 2); epoch <= current+1; epoch++ {
+		announcing, err := s.firstBlockOfEpoch(epochs[epoch-1].StartSlot, finalised)
+		if err != nil {
+			return nil, fmt.Errorf("getting first block of epoch %d: %w", epoch-1, err)
+		}
+		nodes = append(nodes, epochChangeNode{
+			hash:   announcing.Hash(),
+			number: announcing.Number,
+			epochs: []babeEpoch{epochs[epoch]},
+		})
+	}
+
+	return encodeEpochChanges(nodes, finalised.Number), nil
+}
+
+func (s *Service) babeEpoch(epoch uint64, finalised *types.Header) (babeEpoch, error) {
+	epochData, err := s.Epoch.GetEpochDataRaw(epoch, finalised)
+	if err != nil {
+		return babeEpoch{}, fmt.Errorf("getting epoch data: %w", err)
+	}
+
+	configData, err := s.Epoch.GetConfigData(epoch, finalised)
+	if err != nil {
+		return babeEpoch{}, fmt.Errorf("getting config data: %w", err)
+	}
+
+	startSlot, err := s.Epoch.GetStartSlotForEpoch(epoch, finalised.Hash())
+	if err != nil {
+		return babeEpoch{}, fmt.Errorf("getting start slot: %w", err)
+	}
+
+	return babeEpoch{
+		EpochIndex:   epoch,
+		StartSlot:    startSlot,
+		Duration:     s.Epoch.GetEpochLength(),
+		Authorities:  epochData.Authorities,
+		Randomness:   epochData.Randomness,
+		C1:           configData.C1,
+		C2:           configData.C2,
+		AllowedSlots: configData.SecondarySlots,
+	}, nil
+}
+
+// firstBlockOfEpoch returns the first block of the chain of the finalised header
+// given with a slot greater or equal to the start slot given.
+func (s *Service) firstBlockOfEpoch(startSlot uint64, finalised *types.Header) (*types.Header, error) {
+	low, high := uint(1), finalised.Number
+	for low < high {
+		middle := low + (high-low)/2
+		header, err := s.Block.GetHeaderByNumber(middle)
+		if err != nil {
+			return nil, err
+		}
+
+		slot, err := header.SlotNumber()
+		if err != nil {
+			return nil, fmt.Errorf("getting slot of block %d: %w", middle, err)
+		}
+
+		if slot >= startSlot {
+			high = middle
+		} else {
+			low = middle + 1
+		}
+	}
+
+	return s.Block.GetHeaderByNumber(low)
+}
+
+// encodeEpochChanges encodes the EpochChanges of substrate with a fork tree made of the
+// nodes given, each node being the parent of the next one.
+func encodeEpochChanges(nodes []epochChangeNode, finalisedNumber uint) []byte {
+	buffer := bytes.NewBuffer(nil)
+
+	// inner fork tree of the epoch headers
+	roots := uint(0)
+	if len(nodes) > 0 {
+		roots = 1
+	}
+	writeEncoded(buffer, roots)
+	for i, node := range nodes {
+		buffer.Write(node.hash.ToBytes())
+		writeEncoded(buffer, uint32(node.number)) //nolint:gosec
+		encodePersistedEpoch(buffer, node.epochs, true)
+		children := uint(0)
+		if i < len(nodes)-1 {
+			children = 1
+		}
+		writeEncoded(buffer, children)
+	}
+	bestFinalisedNumber := uint32(finalisedNumber) //nolint:gosec
+	writeEncoded(buffer, &bestFinalisedNumber)
+
+	// epochs map sorted by key
+	sortedNodes := slices.Clone(nodes)
+	slices.SortFunc(sortedNodes, func(a, b epochChangeNode) int {
+		if compared := bytes.Compare(a.hash.ToBytes(), b.hash.ToBytes()); compared != 0 {
+			return compared
+		}
+		return int(a.number) - int(b.number) //nolint:gosec
+	})
+	writeEncoded(buffer, uint(len(sortedNodes)))
+	for _, node := range sortedNodes {
+		buffer.Write(node.hash.ToBytes())
+		writeEncoded(buffer, uint32(node.number)) //nolint:gosec
+		encodePersistedEpoch(buffer, node.epochs, false)
+	}
+
+	return buffer.Bytes()
+}
+
+// encodePersistedEpoch encodes the PersistedEpochHeader of the epochs given if headerOnly
+// is true, and their PersistedEpoch otherwise, as the Genesis variant if there are two epochs.
+func encodePersistedEpoch(buffer *bytes.Buffer, epochs []babeEpoch, headerOnly bool) {
+	const genesisVariant, regularVariant = 0, 1
+	if len(epochs) == 2 {
+		buffer.WriteByte(genesisVariant)
+	} else {
+		buffer.WriteByte(regularVariant)
+	}
+
+	for _, epoch := range epochs {
+		if headerOnly {
+			writeEncoded(buffer, epoch.StartSlot)
+			writeEncoded(buffer, epoch.StartSlot+epoch.Duration)
+			continue
+		}
+		writeEncoded(buffer, epoch)
+	}
+}
+
+// grandpaAuthoritySet returns the SCALE encoded AuthoritySet of substrate for the current set,
+// without pending changes.
+func (s *Service) grandpaAuthoritySet() ([]byte, error) {
+	setID, err := s.Grandpa.GetCurrentSetID()
+	if err != nil {
+		return nil, fmt.Errorf("getting current set id: %w", err)
+	}
+
+	voters, err := s.Grandpa.GetAuthorities(setID)
+	if err != nil {
+		return nil, fmt.Errorf("getting authorities of set id %d: %w", setID, err)
+	}
+
+	authorities := make([]types.GrandpaAuthoritiesRaw, len(voters))
+	for i, voter := range voters {
+		authorities[i] = types.GrandpaAuthoritiesRaw{Key: voter.PublicKeyBytes(), ID: voter.ID}
+	}
+
+	// the last block of each previous set, the set id changing after it
+	type authoritySetChange struct {
+		SetID       uint64
+		BlockNumber uint32
+	}
+	changes := make([]authoritySetChange, setID)
+	for id := range changes {
+		blockNumber, err := s.Grandpa.GetSetIDChange(uint64(id) + 1)
+		if err != nil {
+			return nil, fmt.Errorf("getting change of set id %d: %w", id+1, err)
+		}
+		changes[id] = authoritySetChange{SetID: uint64(id), BlockNumber: uint32(blockNumber)} //nolint:gosec
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	writeEncoded(buffer, authorities)
+	writeEncoded(buffer, setID)
+	// empty fork tree of the pending standard changes
+	writeEncoded(buffer, uint(0))
+	writeEncoded(buffer, (*uint32)(nil))
+	// no pending forced changes
+	writeEncoded(buffer, uint(0))
+	writeEncoded(buffer, changes)
+	return buffer.Bytes(), nil
+}
+
+// writeEncoded writes the SCALE encoding of the value given to the buffer,
+// the values given being always encodable.
+func writeEncoded(buffer *bytes.Buffer, value any) {
+	buffer.Write(scale.MustMarshal(value))
+}
@@ -0,0 +1,66 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_encodeEpochChanges(t *testing.T) {
+	t.Parallel()
+
+	epoch := babeEpoch{EpochIndex: 2, StartSlot: 10, Duration: 5}
+
+	testCases := map[string]struct {
+		nodes           []epochChangeNode
+		finalisedNumber uint
+		encoded         []byte
+	}{
+		"no_node": {
+			finalisedNumber: 1,
+			encoded: []byte{
+				0,             // no root
+				1, 1, 0, 0, 0, // best finalised number
+				0, // empty epochs map
+			},
+		},
+		"regular_epoch": {
+			nodes:           []epochChangeNode{{hash: common.Hash{1}, number: 2, epochs: []babeEpoch{epoch}}},
+			finalisedNumber: 3,
+			encoded: bytes.Join([][]byte{
+				{4}, // one root
+				common.Hash{1}.ToBytes(),
+				{2, 0, 0, 0},              // block number
+				{1},                       // regular variant
+				{10, 0, 0, 0, 0, 0, 0, 0}, // start slot
+				{15, 0, 0, 0, 0, 0, 0, 0}, // end slot
+				{0},                       // no child
+				{1, 3, 0, 0, 0},           // best finalised number
+				{4},                       // one epoch
+				common.Hash{1}.ToBytes(),
+				{2, 0, 0, 0},              // block number
+				{1},                       // regular variant
+				{2, 0, 0, 0, 0, 0, 0, 0},  // epoch index
+				{10, 0, 0, 0, 0, 0, 0, 0}, // start slot
+				{5, 0, 0, 0, 0, 0, 0, 0},  // duration
+				{0},                       // no authority
+				make([]byte, 32+8+8+1),    // randomness, c1, c2 and allowed slots
+			}, nil),
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			encoded := encodeEpochChanges(testCase.nodes, testCase.finalisedNumber)
+			assert.Equal(t, testCase.encoded, encoded)
+		})
+	}
+}
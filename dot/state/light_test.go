@@ -0,0 +1,63 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorage_LightMode(t *testing.T) {
+	db := NewInMemoryDB(t)
+	tries := newTriesEmpty()
+	blockState := newTestBlockState(t, tries)
+
+	storage, err := NewStorageState(db, blockState, tries, nil)
+	require.NoError(t, err)
+	storage.SetLightMode()
+
+	_, err = storage.TrieState(&common.Hash{1})
+	require.ErrorIs(t, err, ErrStateNotStored)
+
+	genesisCode := []byte("genesis code")
+	err = storage.initLightCode(genesisCode)
+	require.NoError(t, err)
+
+	// the trie state of any block only holds the runtime code
+	ts, err := storage.TrieState(&common.Hash{1})
+	require.NoError(t, err)
+	assert.Equal(t, genesisCode, ts.LoadCode())
+
+	key := []byte("key")
+	err = ts.Put(key, []byte("value"))
+	require.NoError(t, err)
+	newCode := []byte("new code")
+	err = ts.Put(codeKey, newCode)
+	require.NoError(t, err)
+
+	// only the runtime code of the stored trie is stored
+	err = storage.StoreTrie(ts, nil)
+	require.NoError(t, err)
+
+	root := ts.Trie().MustHash()
+	assert.Nil(t, storage.tries.get(root))
+
+	code, err := storage.GetStorage(&root, codeKey)
+	require.NoError(t, err)
+	assert.Equal(t, newCode, code)
+
+	_, err = storage.GetStorage(&root, key)
+	require.ErrorIs(t, err, ErrStateNotStored)
+
+	// the genesis code does not replace the stored runtime code
+	err = storage.initLightCode(genesisCode)
+	require.NoError(t, err)
+
+	code, err = storage.LoadCode(&root)
+	require.NoError(t, err)
+	assert.Equal(t, newCode, code)
+}
@@ -70,7 +70,7 @@ func NewOfflinePruner(inputDBPath string,
 	}
 
 	// load storage state
-	storageState, err := NewStorageState(db, blockState, tries)
+	storageState, err := NewStorageState(db, blockState, tries, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new storage state %w", err)
 	}
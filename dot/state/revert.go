@@ -0,0 +1,158 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+var (
+	errRevertTooManyBlocks = errors.New("cannot revert more blocks than the chain has")
+	errRevertNotOnChain    = errors.New("block is not on the chain")
+)
+
+// RevertBlocks reverts the chain stored in the database by the given number of blocks,
+// and returns the header of the new head of the chain. It must be called before Start.
+func (s *Service) RevertBlocks(blocks uint) (header *types.Header, err error) {
+	bs := s.newConsistencyBlockState()
+	head, err := bs.GetHighestFinalisedHeader()
+	if err != nil {
+		return nil, fmt.Errorf("getting head of the chain: %w", err)
+	}
+
+	if blocks > head.Number {
+		return nil, fmt.Errorf("%w: %d blocks to revert for head number %d",
+			errRevertTooManyBlocks, blocks, head.Number)
+	}
+
+	hash, err := bs.db.Get(headerHashKey(uint64(head.Number - blocks)))
+	if err != nil {
+		return nil, fmt.Errorf("getting hash of block %d: %w", head.Number-blocks, err)
+	}
+
+	return s.RevertTo(common.NewHash(hash))
+}
+
+// RevertTo reverts the chain stored in the database to the block with the given hash,
+// and returns its header. The headers, bodies, justifications and other data of the blocks
+// reverted are deleted, and the block with the given hash becomes the highest finalised block.
+// The state trie nodes only used by the reverted blocks are left to be removed by the
+// prune-state command. It must be called before Start.
+func (s *Service) RevertTo(hash common.Hash) (header *types.Header, err error) {
+	bs := s.newConsistencyBlockState()
+	head, err := bs.GetHighestFinalisedHeader()
+	if err != nil {
+		return nil, fmt.Errorf("getting head of the chain: %w", err)
+	}
+
+	header, err = bs.GetHeader(hash)
+	if err != nil {
+		return nil, fmt.Errorf("getting header of block %s: %w", hash, err)
+	}
+
+	canonicalHash, err := bs.db.Get(headerHashKey(uint64(header.Number)))
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %s", errRevertNotOnChain, hash)
+	} else if err != nil {
+		return nil, fmt.Errorf("getting hash of block %d: %w", header.Number, err)
+	}
+	if !bytes.Equal(canonicalHash, hash.ToBytes()) || header.Number > head.Number {
+		return nil, fmt.Errorf("%w: %s", errRevertNotOnChain, hash)
+	}
+
+	grandpa := NewGrandpaState(s.db, nil, nil)
+	previousSetID, err := grandpa.GetCurrentSetID()
+	if err != nil {
+		return nil, fmt.Errorf("getting current set id: %w", err)
+	}
+
+	setID, err := grandpa.GetSetIDByBlockNumber(header.Number)
+	if err != nil {
+		return nil, fmt.Errorf("getting set id of block %d: %w", header.Number, err)
+	}
+
+	batch := bs.db.NewBatch()
+	for number := head.Number; number > header.Number; number-- {
+		err = deleteCanonicalBlock(bs.db, batch, number)
+		if err != nil {
+			return nil, fmt.Errorf("deleting block %d: %w", number, err)
+		}
+	}
+
+	// the justification of the new head of the chain becomes the latest justification
+	// once the next justification is stored
+	err = batch.Del(latestJustificationKey)
+	if err != nil {
+		return nil, fmt.Errorf("deleting latest justification: %w", err)
+	}
+
+	err = batch.Put(finalisedHashKey(0, setID), hash.ToBytes())
+	if err != nil {
+		return nil, fmt.Errorf("setting finalised hash: %w", err)
+	}
+
+	err = batch.Put(highestRoundAndSetIDKey, roundAndSetIDToBytes(0, setID))
+	if err != nil {
+		return nil, fmt.Errorf("setting highest round and set id: %w", err)
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return nil, fmt.Errorf("writing reverted blocks: %w", err)
+	}
+
+	// remove the set id changes of the blocks reverted, up to previousSetID+1
+	// in case of a scheduled change
+	if setID != previousSetID {
+		err = grandpa.setCurrentSetID(setID)
+		if err != nil {
+			return nil, fmt.Errorf("setting current set id: %w", err)
+		}
+	}
+
+	for id := setID + 1; id <= previousSetID+1; id++ {
+		err = grandpa.db.Del(setIDChangeKey(id))
+		if err != nil {
+			return nil, fmt.Errorf("deleting change of set id %d: %w", id, err)
+		}
+	}
+
+	logger.Infof("reverted chain from number %d to number %d and hash %s",
+		head.Number, header.Number, hash)
+	return header, nil
+}
+
+// deleteCanonicalBlock deletes the data of the block stored for the given number.
+func deleteCanonicalBlock(db database.Reader, batch database.Batch, number uint) error {
+	hashBytes, err := db.Get(headerHashKey(uint64(number)))
+	if err != nil {
+		return fmt.Errorf("getting hash: %w", err)
+	}
+	hash := common.NewHash(hashBytes)
+
+	keys := [][]byte{
+		headerHashKey(uint64(number)),
+		headerKey(hash),
+		blockBodyKey(hash),
+		arrivalTimeKey(hash),
+		prefixKey(hash, receiptPrefix),
+		prefixKey(hash, messageQueuePrefix),
+		prefixKey(hash, justificationPrefix),
+		prefixKey(hash, justificationAncestryPrefix),
+	}
+	for _, key := range keys {
+		err = batch.Del(key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
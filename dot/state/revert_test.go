@@ -0,0 +1,93 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// newTestRevertService returns a service with a database storing the genesis block
+// and the given number of blocks on top of it, the highest one being finalised.
+func newTestRevertService(t *testing.T, blocks uint) (service *Service, headers []*types.Header) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	telemetryMock := NewMockTelemetry(ctrl)
+	telemetryMock.EXPECT().SendMessage(gomock.Any()).AnyTimes()
+
+	db := NewInMemoryDB(t)
+	bs, err := NewBlockStateFromGenesis(db, newTriesEmpty(), testGenesisHeader, telemetryMock)
+	require.NoError(t, err)
+
+	grandpa := NewGrandpaState(db, nil, nil)
+	require.NoError(t, grandpa.setCurrentSetID(0))
+	require.NoError(t, grandpa.setChangeSetIDAtBlock(0, 0))
+
+	headers = []*types.Header{testGenesisHeader}
+	for number := uint(1); number <= blocks; number++ {
+		header := types.NewHeader(headers[number-1].Hash(), common.Hash{},
+			common.Hash{}, number, types.NewDigest())
+		require.NoError(t, bs.SetHeader(header))
+		require.NoError(t, bs.SetBlockBody(header.Hash(), types.NewBody(nil)))
+		require.NoError(t, bs.db.Put(headerHashKey(uint64(number)), header.Hash().ToBytes()))
+		headers = append(headers, header)
+	}
+	require.NoError(t, bs.db.Put(finalisedHashKey(0, 0), headers[blocks].Hash().ToBytes()))
+
+	return &Service{db: db}, headers
+}
+
+func Test_Service_RevertBlocks(t *testing.T) {
+	t.Parallel()
+
+	service, headers := newTestRevertService(t, 3)
+
+	header, err := service.RevertBlocks(2)
+	require.NoError(t, err)
+	assert.Equal(t, headers[1].Hash(), header.Hash())
+
+	bs := service.newConsistencyBlockState()
+	finalisedHash, err := bs.GetHighestFinalisedHash()
+	require.NoError(t, err)
+	assert.Equal(t, headers[1].Hash(), finalisedHash)
+
+	for _, reverted := range headers[2:] {
+		has, err := bs.HasHeader(reverted.Hash())
+		require.NoError(t, err)
+		assert.False(t, has)
+
+		has, err = bs.db.Has(headerHashKey(uint64(reverted.Number)))
+		require.NoError(t, err)
+		assert.False(t, has)
+	}
+
+	_, err = service.RevertBlocks(2)
+	assert.ErrorIs(t, err, errRevertTooManyBlocks)
+}
+
+func Test_Service_RevertTo(t *testing.T) {
+	t.Parallel()
+
+	service, headers := newTestRevertService(t, 2)
+
+	_, err := service.RevertTo(common.Hash{1})
+	assert.ErrorIs(t, err, database.ErrNotFound)
+
+	header, err := service.RevertTo(headers[0].Hash())
+	require.NoError(t, err)
+	assert.Equal(t, headers[0].Hash(), header.Hash())
+
+	// the block reverted is not on the chain anymore
+	bs := service.newConsistencyBlockState()
+	require.NoError(t, bs.SetHeader(headers[1]))
+	_, err = service.RevertTo(headers[1].Hash())
+	assert.ErrorIs(t, err, errRevertNotOnChain)
+}
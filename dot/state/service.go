@@ -4,16 +4,21 @@
 package state
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
+	"time"
 
 	"github.com/ChainSafe/gossamer/dot/state/pruner"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/chaos"
 	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/internal/metrics"
 	"github.com/ChainSafe/gossamer/lib/blocktree"
 	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/cache"
+	inmemory_cache "github.com/ChainSafe/gossamer/pkg/trie/cache/inmemory"
 	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
 )
 
@@ -23,19 +28,32 @@
 
 // Service is the struct that holds storage, block and network states
 type Service struct {
-	dbPath            string
-	logLvl            log.Level
-	db                database.Database
-	isMemDB           bool // set to true if using an in-memory database; only used for testing.
-	Base              *BaseState
-	Storage           *InmemoryStorageState
-	Block             *BlockState
-	Transaction       *TransactionState
-	Epoch             *EpochState
-	Grandpa           *GrandpaState
-	Slot              *SlotState
-	closeCh           chan interface{}
-	genesisBABEConfig *types.BabeConfiguration
+	dbPath              string
+	logLvl              log.Level
+	db                  database.Database
+	isMemDB             bool // set to true if using an in-memory database; only used for testing.
+	Base                *BaseState
+	Storage             *InmemoryStorageState
+	Block               *BlockState
+	Transaction         *TransactionState
+	Epoch               *EpochState
+	Grandpa             *GrandpaState
+	Slot                *SlotState
+	closeCh             chan interface{}
+	genesisBABEConfig   *types.BabeConfiguration
+	trieCacheSize       uint
+	light               bool
+	snapshot            bool
+	chaos               *chaos.Injector
+	txPoolLimits        TransactionPoolLimits
+	txBanDuration       time.Duration
+	pruneJustifications bool
+	pruneEpochs         bool
+	pruneBlockBodies    bool
+	retainedBlockBodies uint
+	// backfillDone is closed once the storage changes index backfill returns,
+	// nil if no backfill was started
+	backfillDone chan struct{}
 
 	PrunerCfg pruner.Config
 	Telemetry Telemetry
@@ -58,6 +76,32 @@ type Config struct {
 	Telemetry         Telemetry
 	Metrics           metrics.IntervalConfig
 	GenesisBABEConfig *types.BabeConfiguration
+	// TrieCacheSize is the size in bytes of the cache of the trie nodes and
+	// storage values read from the database, 0 disabling the cache
+	TrieCacheSize uint
+	// Light is set to only store the block headers, the justifications and the
+	// runtime code, the state of the blocks following the genesis not being stored
+	Light bool
+	// Snapshot is set to maintain a flat snapshot of the state of the best block, serving
+	// the storage reads of the block executions without traversing the state trie
+	Snapshot bool
+	// Chaos fails some of the database writes if it is set, for testing
+	Chaos *chaos.Injector
+	// TransactionPoolLimits are the limits of the transactions held in the transaction state
+	TransactionPoolLimits TransactionPoolLimits
+	// TransactionBanDuration is the duration the extrinsics failing repeatedly are
+	// banned from the transaction pool for, 0 disabling banning
+	TransactionBanDuration time.Duration
+	// PruneJustifications is set to only keep the justifications of the blocks changing the
+	// GRANDPA authority set and the justification of the highest block justified
+	PruneJustifications bool
+	// PruneEpochs is set to prune the BABE epoch data and config data of the epochs older
+	// than a few epochs before the epoch of the highest finalised block
+	PruneEpochs bool
+	// PruneBlockBodies is set to prune the bodies of the finalised blocks older than the
+	// RetainedBlockBodies highest finalised blocks, their headers being kept
+	PruneBlockBodies    bool
+	RetainedBlockBodies uint
 }
 
 // NewService create a new instance of Service
@@ -65,16 +109,26 @@ func NewService(config Config) *Service {
 	logger.Patch(log.SetLevel(config.LogLevel))
 
 	return &Service{
-		dbPath:            config.Path,
-		logLvl:            config.LogLevel,
-		db:                nil,
-		isMemDB:           false,
-		Storage:           nil,
-		Block:             nil,
-		closeCh:           make(chan interface{}),
-		PrunerCfg:         config.PrunerCfg,
-		Telemetry:         config.Telemetry,
-		genesisBABEConfig: config.GenesisBABEConfig,
+		dbPath:              config.Path,
+		logLvl:              config.LogLevel,
+		db:                  nil,
+		isMemDB:             false,
+		Storage:             nil,
+		Block:               nil,
+		closeCh:             make(chan interface{}),
+		PrunerCfg:           config.PrunerCfg,
+		Telemetry:           config.Telemetry,
+		genesisBABEConfig:   config.GenesisBABEConfig,
+		trieCacheSize:       config.TrieCacheSize,
+		light:               config.Light,
+		chaos:               config.Chaos,
+		txPoolLimits:        config.TransactionPoolLimits,
+		txBanDuration:       config.TransactionBanDuration,
+		snapshot:            config.Snapshot,
+		pruneJustifications: config.PruneJustifications,
+		pruneEpochs:         config.PruneEpochs,
+		pruneBlockBodies:    config.PruneBlockBodies,
+		retainedBlockBodies: config.RetainedBlockBodies,
 	}
 }
 
@@ -108,8 +162,8 @@ func (s *Service) SetupBase() error {
 		return err
 	}
 
-	s.db = db
-	s.Base = NewBaseState(db)
+	s.db = chaos.NewDatabase(db, s.chaos)
+	s.Base = NewBaseState(s.db)
 
 	return nil
 }
@@ -128,6 +182,9 @@ func (s *Service) Start() (err error) {
 	if err != nil {
 		return fmt.Errorf("failed to create block state: %w", err)
 	}
+	s.Block.pruneJustifications = s.pruneJustifications
+	s.Block.blockBodiesPruning = s.pruneBlockBodies
+	s.Block.retainedBlockBodies = s.retainedBlockBodies
 
 	// retrieve latest header
 	bestHeader, err := s.Block.GetHighestFinalisedHeader()
@@ -136,22 +193,57 @@ func (s *Service) Start() (err error) {
 	}
 
 	stateRoot := bestHeader.StateRoot
+	if s.light {
+		// a light node only stores the genesis state
+		genesisHeader, err := s.Block.GetHeader(s.Block.GenesisHash())
+		if err != nil {
+			return fmt.Errorf("failed to get genesis header: %w", err)
+		}
+		stateRoot = genesisHeader.StateRoot
+	}
 	logger.Debugf("start with latest state root: %s", stateRoot)
 
-	// create storage state
-	s.Storage, err = NewStorageState(s.db, s.Block, tries)
+	// create storage state, sharing the trie cache across block executions and rpc queries
+	var trieCache cache.TrieCache
+	if s.trieCacheSize > 0 {
+		trieCache = inmemory_cache.NewTrieInMemoryCacheWithSize(int64(s.trieCacheSize)) //nolint:gosec
+	}
+
+	s.Storage, err = NewStorageState(s.db, s.Block, tries, trieCache)
 	if err != nil {
 		return fmt.Errorf("failed to create storage state: %w", err)
 	}
 
 	// load current storage state trie into memory
-	_, err = s.Storage.LoadFromDB(stateRoot)
+	stateTrie, err := s.Storage.LoadFromDB(stateRoot)
 	if err != nil {
 		return fmt.Errorf("failed to load storage trie from database: %w", err)
 	}
 
+	if s.light {
+		s.Storage.SetLightMode()
+		err = s.Storage.initLightCode(stateTrie.Get(codeKey))
+		if err != nil {
+			return fmt.Errorf("failed to store runtime code: %w", err)
+		}
+	} else {
+		err = s.startStorageChanges()
+		if err != nil {
+			return fmt.Errorf("starting storage changes index: %w", err)
+		}
+
+		if s.snapshot {
+			err = s.startStateSnapshot()
+			if err != nil {
+				return fmt.Errorf("starting state snapshot: %w", err)
+			}
+		}
+	}
+
 	// create transaction queue
 	s.Transaction = NewTransactionState(s.Telemetry)
+	s.Transaction.SetLimits(s.txPoolLimits)
+	s.Transaction.SetBanDuration(s.txBanDuration)
 
 	// create epoch and slot state
 	s.Slot = NewSlotState(s.db)
@@ -160,6 +252,7 @@ func (s *Service) Start() (err error) {
 	if err != nil {
 		return fmt.Errorf("failed to create epoch state: %w", err)
 	}
+	s.Epoch.pruneEpochs = s.pruneEpochs
 
 	s.Grandpa = NewGrandpaState(s.db, s.Block, s.Telemetry)
 	num, _ := s.Block.BestBlockNumber()
@@ -170,6 +263,57 @@ func (s *Service) Start() (err error) {
 	return nil
 }
 
+// startStorageChanges maintains the storage changes index from the blocks following the
+// best block, and backfills the index of the blocks imported before in the background.
+func (s *Service) startStorageChanges() error {
+	changes := NewStorageChanges(s.db, s.Block)
+	bestNumber, err := s.Block.BestBlockNumber()
+	if err != nil {
+		return fmt.Errorf("getting best block number: %w", err)
+	}
+
+	err = changes.Init(bestNumber)
+	if err != nil {
+		return err
+	}
+	s.Storage.changes = changes
+
+	s.backfillDone = make(chan struct{})
+	go func() {
+		defer close(s.backfillDone)
+		err := changes.Backfill(s.Storage, s.closeCh)
+		if err != nil {
+			logger.Errorf("backfilling storage changes index: %s", err)
+		}
+	}()
+
+	return nil
+}
+
+// startStateSnapshot maintains the flat snapshot of the state of the best block, regenerating
+// it if the snapshot stored is not at the best block.
+func (s *Service) startStateSnapshot() error {
+	snapshot := NewStateSnapshot(s.db, s.Block, s.Storage, s.closeCh)
+	err := snapshot.load()
+	if err != nil {
+		return err
+	}
+
+	err = snapshot.Follow(s.Block.BestBlockHash())
+	if err != nil {
+		return fmt.Errorf("moving state snapshot to best block: %w", err)
+	}
+	s.Storage.snapshot = snapshot
+
+	snapshot.wg.Add(1)
+	go func() {
+		defer snapshot.wg.Done()
+		snapshot.followBestBlock()
+	}()
+
+	return nil
+}
+
 // Rewind rewinds the chain to the given block number.
 // If the given number of blocks is greater than the chain height, it will rewind to genesis.
 func (s *Service) Rewind(toBlock uint) error {
@@ -249,19 +393,32 @@ func (s *Service) Rewind(toBlock uint) error {
 // Stop closes each state database
 func (s *Service) Stop() error {
 	close(s.closeCh)
+	if s.backfillDone != nil {
+		<-s.backfillDone
+	}
+	if s.Storage != nil && s.Storage.snapshot != nil {
+		s.Storage.snapshot.wg.Wait()
+	}
 
 	hash, err := s.Block.GetHighestFinalisedHash()
 	if err != nil {
-		return err
+		// still flush and close the database so it is not left corrupted
+		logger.Errorf("cannot get highest finalised hash: %s", err)
+	} else {
+		logger.Debugf("stop with best finalised hash %s", hash)
 	}
 
-	logger.Debugf("stop with best finalised hash %s", hash)
+	flushErr := s.db.Flush()
+	if flushErr != nil {
+		flushErr = fmt.Errorf("flushing database: %w", flushErr)
+	}
 
-	if err = s.db.Flush(); err != nil {
-		return err
+	closeErr := s.db.Close()
+	if closeErr != nil {
+		closeErr = fmt.Errorf("closing database: %w", closeErr)
 	}
 
-	return s.db.Close()
+	return errors.Join(flushErr, closeErr)
 }
 
 // Import imports the given state corresponding to the given header and sets the head of the chain
@@ -0,0 +1,489 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const stateSnapshotPrefix = "snapshot"
+
+var (
+	// stateSnapshotHeadKey holds the hash and the state root of the block the snapshot is at
+	stateSnapshotHeadKey = []byte("head")
+	// stateSnapshotEntryPrefix + storage key -> storage value
+	stateSnapshotEntryPrefix = []byte("entry")
+)
+
+// maxStateSnapshotDiffs is the number of the most recently imported blocks whose storage
+// changes are kept in memory, to move the snapshot across forks without regenerating it.
+const maxStateSnapshotDiffs = 256
+
+// stateSnapshotBatchSize is the number of entries of the batches regenerating the snapshot.
+const stateSnapshotBatchSize = 10000
+
+var (
+	stateSnapshotHitsCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "gossamer_state_snapshot",
+		Name:      "hits_total",
+		Help:      "total number of storage reads served by the state snapshot",
+	})
+	stateSnapshotMissesCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "gossamer_state_snapshot",
+		Name:      "misses_total",
+		Help:      "total number of storage reads of a state the snapshot is not at",
+	})
+	stateSnapshotRegenerationsCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "gossamer_state_snapshot",
+		Name:      "regenerations_total",
+		Help:      "total number of regenerations of the state snapshot from the state trie",
+	})
+)
+
+// StateSnapshot is a flat key-value copy of the state of a single block, following the best
+// block, so the storage reads of that state do not traverse the state trie.
+// The storage changes of each block imported on top of the snapshot block are applied to the
+// snapshot as the block is stored. On a reorg, the changes of the retracted blocks are undone
+// and the changes of the enacted blocks are applied, the snapshot being regenerated from the
+// state trie of the best block if the changes of one of these blocks are no longer in memory.
+// The snapshot serves no read while it is regenerated.
+type StateSnapshot struct {
+	db           database.Table
+	blockState   *BlockState
+	storageState *InmemoryStorageState
+	done         <-chan interface{}
+	// wg waits for the goroutines regenerating the snapshot and following the best block
+	wg sync.WaitGroup
+
+	mu sync.RWMutex
+	// hash and root are the hash and the state root of the block the snapshot is at
+	hash common.Hash
+	root common.Hash
+	// generating is set while the snapshot is regenerated
+	generating bool
+	// diffs holds the storage changes of the most recently imported blocks, by block hash,
+	// and diffsOrder their hashes in import order
+	diffs      map[common.Hash]*stateSnapshotDiff
+	diffsOrder []common.Hash
+}
+
+// stateSnapshotDiff is the storage changes of a block.
+type stateSnapshotDiff struct {
+	parentHash common.Hash
+	root       common.Hash
+	// changes holds the values of the storage keys changed by the block, nil for the keys deleted
+	changes map[string][]byte
+	// undo holds the values of the storage keys changed before the block was applied to the
+	// snapshot, nil if the block is not applied
+	undo map[string][]byte
+}
+
+// NewStateSnapshot returns the state snapshot stored in the database given. The goroutines
+// of the snapshot return once the channel given is closed.
+func NewStateSnapshot(db database.Database, blockState *BlockState,
+	storageState *InmemoryStorageState, done <-chan interface{}) *StateSnapshot {
+	return &StateSnapshot{
+		db:           database.NewTable(db, stateSnapshotPrefix),
+		blockState:   blockState,
+		storageState: storageState,
+		done:         done,
+		diffs:        make(map[common.Hash]*stateSnapshotDiff),
+	}
+}
+
+func stateSnapshotEntryKey(key []byte) []byte {
+	return bytes.Join([][]byte{stateSnapshotEntryPrefix, key}, nil)
+}
+
+// load loads the block the snapshot stored is at, the snapshot being empty if there is none.
+func (ss *StateSnapshot) load() error {
+	data, err := ss.db.Get(stateSnapshotHeadKey)
+	if errors.Is(err, database.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("getting state snapshot head: %w", err)
+	}
+
+	ss.hash = common.NewHash(data[:common.HashLength])
+	ss.root = common.NewHash(data[common.HashLength:])
+	return nil
+}
+
+// Get returns the value of the storage key given in the state of the root given, nil if the
+// key is not in the state, and false if the snapshot is not at that state.
+func (ss *StateSnapshot) Get(root common.Hash, key []byte) (value []byte, ok bool) {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+
+	if ss.generating || ss.root != root {
+		stateSnapshotMissesCounter.Inc()
+		return nil, false
+	}
+
+	value, err := ss.getEntry(key)
+	if err != nil {
+		logger.Warnf("failed to read state snapshot entry 0x%x: %s", key, err)
+		return nil, false
+	}
+	stateSnapshotHitsCounter.Inc()
+	return value, true
+}
+
+func (ss *StateSnapshot) getEntry(key []byte) ([]byte, error) {
+	value, err := ss.db.Get(stateSnapshotEntryKey(key))
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	return value, err
+}
+
+// Reader returns the reader of the state of the root given, the reads missing once the
+// snapshot is no longer at that state.
+func (ss *StateSnapshot) Reader(root common.Hash) storage.FlatReader {
+	return &stateSnapshotReader{snapshot: ss, root: root}
+}
+
+type stateSnapshotReader struct {
+	snapshot *StateSnapshot
+	root     common.Hash
+}
+
+func (r *stateSnapshotReader) Get(key []byte) (value []byte, ok bool) {
+	return r.snapshot.Get(r.root, key)
+}
+
+// Store keeps the storage changes of the block of the header given, written to the trie state
+// given of the root given, and applies them to the snapshot if it is at the parent block.
+func (ss *StateSnapshot) Store(header *types.Header, root common.Hash, ts *storage.TrieState) error {
+	t := ts.Trie()
+	diff := &stateSnapshotDiff{
+		parentHash: header.ParentHash,
+		root:       root,
+		changes:    make(map[string][]byte),
+	}
+	for _, key := range ts.WrittenKeys() {
+		diff.changes[string(key)] = t.Get(key)
+	}
+
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	hash := header.Hash()
+	if _, ok := ss.diffs[hash]; ok {
+		return nil
+	}
+	ss.diffs[hash] = diff
+	ss.diffsOrder = append(ss.diffsOrder, hash)
+	for len(ss.diffsOrder) > maxStateSnapshotDiffs {
+		delete(ss.diffs, ss.diffsOrder[0])
+		ss.diffsOrder = ss.diffsOrder[1:]
+	}
+
+	if ss.generating || header.ParentHash != ss.hash {
+		return nil
+	}
+	return ss.apply(hash, diff)
+}
+
+// apply applies the storage changes of the block of the hash given to the snapshot.
+func (ss *StateSnapshot) apply(hash common.Hash, diff *stateSnapshotDiff) error {
+	undo := make(map[string][]byte, len(diff.changes))
+	for key := range diff.changes {
+		value, err := ss.getEntry([]byte(key))
+		if err != nil {
+			return fmt.Errorf("getting state snapshot entry 0x%x: %w", key, err)
+		}
+		undo[key] = value
+	}
+
+	err := ss.write(diff.changes, hash, diff.root)
+	if err != nil {
+		return fmt.Errorf("applying block %s: %w", hash, err)
+	}
+	diff.undo = undo
+	return nil
+}
+
+// revert undoes the storage changes of the block the snapshot is at, moving it to the parent block.
+func (ss *StateSnapshot) revert(diff *stateSnapshotDiff) error {
+	parent, err := ss.blockState.GetHeader(diff.parentHash)
+	if err != nil {
+		return fmt.Errorf("getting parent header of block %s: %w", ss.hash, err)
+	}
+
+	err = ss.write(diff.undo, diff.parentHash, parent.StateRoot)
+	if err != nil {
+		return fmt.Errorf("reverting block %s: %w", ss.hash, err)
+	}
+	diff.undo = nil
+	return nil
+}
+
+// write writes the storage values given, deleting the nil ones, and moves the snapshot to
+// the block of the hash and state root given, atomically.
+func (ss *StateSnapshot) write(values map[string][]byte, hash, root common.Hash) error {
+	batch := ss.db.NewBatch()
+	defer batch.Close()
+
+	for key, value := range values {
+		var err error
+		if value == nil {
+			err = batch.Del(stateSnapshotEntryKey([]byte(key)))
+		} else {
+			err = batch.Put(stateSnapshotEntryKey([]byte(key)), value)
+		}
+		if err != nil {
+			return fmt.Errorf("writing state snapshot entry 0x%x: %w", key, err)
+		}
+	}
+
+	err := batch.Put(stateSnapshotHeadKey, bytes.Join([][]byte{hash.ToBytes(), root.ToBytes()}, nil))
+	if err != nil {
+		return fmt.Errorf("putting state snapshot head: %w", err)
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return err
+	}
+	ss.hash, ss.root = hash, root
+	return nil
+}
+
+// Follow moves the snapshot to the best block of the hash given, undoing the changes of the
+// blocks retracted and applying the changes of the blocks enacted. The snapshot is regenerated
+// in the background if the changes of one of these blocks are not in memory.
+func (ss *StateSnapshot) Follow(bestHash common.Hash) error {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	if ss.generating || ss.hash == bestHash {
+		return nil
+	}
+
+	ancestor, err := ss.blockState.LowestCommonAncestor(ss.hash, bestHash)
+	if err != nil {
+		// the snapshot block may be stored before being added to the block tree
+		if ss.isAhead(bestHash) {
+			return nil
+		}
+		return ss.regenerate(bestHash)
+	}
+	if ancestor == bestHash {
+		// the snapshot is at a descendant of the best block
+		return nil
+	}
+
+	var retracted []*stateSnapshotDiff
+	for hash := ss.hash; hash != ancestor; {
+		diff, ok := ss.diffs[hash]
+		if !ok || diff.undo == nil {
+			return ss.regenerate(bestHash)
+		}
+		retracted = append(retracted, diff)
+		hash = diff.parentHash
+	}
+
+	var enacted []common.Hash
+	for hash := bestHash; hash != ancestor; {
+		diff, ok := ss.diffs[hash]
+		if !ok {
+			return ss.regenerate(bestHash)
+		}
+		enacted = append(enacted, hash)
+		hash = diff.parentHash
+	}
+
+	for _, diff := range retracted {
+		err = ss.revert(diff)
+		if err != nil {
+			return err
+		}
+	}
+	for i := len(enacted) - 1; i >= 0; i-- {
+		err = ss.apply(enacted[i], ss.diffs[enacted[i]])
+		if err != nil {
+			return err
+		}
+	}
+
+	logger.Debugf("moved state snapshot to block %s, reverting %d blocks and applying %d blocks",
+		bestHash, len(retracted), len(enacted))
+	return nil
+}
+
+// isAhead returns true if the snapshot is at a descendant of the block of the hash given,
+// following the blocks whose changes are in memory.
+func (ss *StateSnapshot) isAhead(hash common.Hash) bool {
+	for current := ss.hash; ; {
+		diff, ok := ss.diffs[current]
+		if !ok {
+			return false
+		}
+		if diff.parentHash == hash {
+			return true
+		}
+		current = diff.parentHash
+	}
+}
+
+// regenerate regenerates the snapshot in the background from the state trie of the block
+// of the hash given. The snapshot serves no read until it is regenerated.
+func (ss *StateSnapshot) regenerate(hash common.Hash) error {
+	header, err := ss.blockState.GetHeader(hash)
+	if err != nil {
+		return fmt.Errorf("getting header of block %s: %w", hash, err)
+	}
+
+	ss.generating = true
+	ss.hash, ss.root = common.EmptyHash, common.EmptyHash
+	for _, diff := range ss.diffs {
+		diff.undo = nil
+	}
+
+	ss.wg.Add(1)
+	go func() {
+		defer ss.wg.Done()
+
+		start := time.Now()
+		logger.Infof("regenerating state snapshot at block %s", hash)
+		entries, err := ss.generate(hash, header.StateRoot)
+		if entries < 0 {
+			return
+		}
+
+		ss.mu.Lock()
+		ss.generating = false
+		if err != nil {
+			// the regeneration is retried at the next block imported
+			ss.mu.Unlock()
+			logger.Errorf("failed to regenerate state snapshot at block %s: %s", hash, err)
+			return
+		}
+		ss.hash, ss.root = hash, header.StateRoot
+		ss.mu.Unlock()
+
+		stateSnapshotRegenerationsCounter.Inc()
+		logger.Infof("regenerated state snapshot at block %s with %d entries in %s",
+			hash, entries, time.Since(start))
+
+		// catch up with the blocks imported while regenerating
+		err = ss.Follow(ss.blockState.BestBlockHash())
+		if err != nil {
+			logger.Errorf("failed to move state snapshot to best block: %s", err)
+		}
+	}()
+	return nil
+}
+
+// generate clears the snapshot stored and writes the entries of the state trie of the block
+// of the hash and state root given. It returns the number of entries written, -1 if the
+// snapshot is closed meanwhile.
+func (ss *StateSnapshot) generate(hash, root common.Hash) (entries int, err error) {
+	err = ss.db.Del(stateSnapshotHeadKey)
+	if err != nil {
+		return 0, fmt.Errorf("deleting state snapshot head: %w", err)
+	}
+
+	err = ss.clear()
+	if err != nil {
+		return 0, fmt.Errorf("clearing state snapshot: %w", err)
+	}
+
+	t, err := ss.storageState.loadTrie(&root)
+	if err != nil {
+		return 0, fmt.Errorf("loading state trie: %w", err)
+	}
+
+	batch := ss.db.NewBatch()
+	defer batch.Close()
+	for key := range t.PrefixedKeys(nil) {
+		err = batch.Put(stateSnapshotEntryKey(key), t.Get(key))
+		if err != nil {
+			return 0, fmt.Errorf("putting state snapshot entry 0x%x: %w", key, err)
+		}
+		entries++
+
+		if batch.ValueSize() < stateSnapshotBatchSize {
+			continue
+		}
+		err = batch.Flush()
+		if err != nil {
+			return 0, fmt.Errorf("writing state snapshot entries: %w", err)
+		}
+		batch.Reset()
+
+		select {
+		case <-ss.done:
+			return -1, nil
+		default:
+		}
+	}
+
+	// the head is written last, so a snapshot partially written is regenerated on restart
+	err = batch.Put(stateSnapshotHeadKey, bytes.Join([][]byte{hash.ToBytes(), root.ToBytes()}, nil))
+	if err != nil {
+		return 0, fmt.Errorf("putting state snapshot head: %w", err)
+	}
+	return entries, batch.Flush()
+}
+
+// clear deletes the entries of the snapshot stored.
+func (ss *StateSnapshot) clear() error {
+	iter, err := ss.db.NewPrefixIterator(stateSnapshotEntryPrefix)
+	if err != nil {
+		return fmt.Errorf("creating iterator: %w", err)
+	}
+	defer iter.Release()
+
+	batch := ss.db.NewBatch()
+	defer batch.Close()
+
+	// the iterator keys are prefixed with the table prefix
+	for valid := iter.First(); valid; valid = iter.Next() {
+		err = batch.Del(iter.Key()[len(stateSnapshotPrefix):])
+		if err != nil {
+			return err
+		}
+		if batch.ValueSize() >= stateSnapshotBatchSize {
+			err = batch.Flush()
+			if err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+	return batch.Flush()
+}
+
+// followBestBlock moves the snapshot to the best block as blocks are imported, until the
+// snapshot is closed.
+func (ss *StateSnapshot) followBestBlock() {
+	imported := ss.blockState.GetImportedBlockNotifierChannel()
+	defer ss.blockState.FreeImportedBlockNotifierChannel(imported)
+
+	for {
+		select {
+		case <-ss.done:
+			return
+		case <-imported:
+		}
+
+		err := ss.Follow(ss.blockState.BestBlockHash())
+		if err != nil {
+			logger.Errorf("failed to move state snapshot to best block: %s", err)
+		}
+	}
+}
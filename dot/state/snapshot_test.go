@@ -0,0 +1,123 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStateSnapshot(t *testing.T, db database.Database, storageState *InmemoryStorageState) *StateSnapshot {
+	t.Helper()
+
+	done := make(chan interface{})
+	snapshot := NewStateSnapshot(db, storageState.blockState, storageState, done)
+	storageState.snapshot = snapshot
+	t.Cleanup(func() {
+		close(done)
+		snapshot.wg.Wait()
+	})
+	return snapshot
+}
+
+// storeTestBlock stores and adds the block on top of the parent given, writing the
+// storage values given, nil values deleting their key.
+func storeTestBlock(t *testing.T, storageState *InmemoryStorageState,
+	parent *types.Header, values map[string][]byte) *types.Header {
+	t.Helper()
+
+	ts, err := storageState.TrieState(&parent.StateRoot)
+	require.NoError(t, err)
+	for key, value := range values {
+		if value == nil {
+			require.NoError(t, ts.Delete([]byte(key)))
+		} else {
+			require.NoError(t, ts.Put([]byte(key), value))
+		}
+	}
+
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     parent.Number + 1,
+		StateRoot:  ts.Trie().MustHash(),
+		Digest:     createPrimaryBABEDigest(t),
+	}
+	require.NoError(t, storageState.StoreTrie(ts, header))
+	require.NoError(t, storageState.blockState.AddBlock(&types.Block{Header: *header, Body: types.Body{}}))
+	return header
+}
+
+func TestStateSnapshot(t *testing.T) {
+	db := NewInMemoryDB(t)
+	storageState := newTestStorageState(t)
+	snapshot := newTestStateSnapshot(t, db, storageState)
+
+	genesis, err := storageState.blockState.GetHeader(storageState.blockState.GenesisHash())
+	require.NoError(t, err)
+
+	// the snapshot is generated from the genesis state
+	require.NoError(t, snapshot.Follow(genesis.Hash()))
+	require.Eventually(t, func() bool {
+		_, ok := snapshot.Get(genesis.StateRoot, []byte("key"))
+		return ok
+	}, time.Second, 10*time.Millisecond)
+
+	block1 := storeTestBlock(t, storageState, genesis, map[string][]byte{
+		"key":   []byte("value1"),
+		"other": []byte("other"),
+	})
+	fork1 := storeTestBlock(t, storageState, genesis, map[string][]byte{"key": []byte("fork1")})
+	block2 := storeTestBlock(t, storageState, block1, map[string][]byte{"other": nil})
+
+	// the blocks on top of the snapshot block are applied as they are stored
+	value, ok := snapshot.Get(block2.StateRoot, []byte("key"))
+	require.True(t, ok)
+	assert.Equal(t, []byte("value1"), value)
+	value, ok = snapshot.Get(block2.StateRoot, []byte("other"))
+	require.True(t, ok)
+	assert.Nil(t, value)
+	_, ok = snapshot.Get(block1.StateRoot, []byte("key"))
+	assert.False(t, ok)
+
+	// the snapshot moves across forks
+	require.NoError(t, snapshot.Follow(fork1.Hash()))
+	value, ok = snapshot.Get(fork1.StateRoot, []byte("key"))
+	require.True(t, ok)
+	assert.Equal(t, []byte("fork1"), value)
+	value, ok = snapshot.Get(fork1.StateRoot, []byte("other"))
+	require.True(t, ok)
+	assert.Nil(t, value)
+
+	require.NoError(t, snapshot.Follow(block2.Hash()))
+	value, ok = snapshot.Get(block2.StateRoot, []byte("key"))
+	require.True(t, ok)
+	assert.Equal(t, []byte("value1"), value)
+
+	// the trie states of the snapshot state read from the snapshot
+	ts, err := storageState.TrieState(&block2.StateRoot)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value1"), ts.Get([]byte("key")))
+
+	// the snapshot stored is loaded at its block
+	loaded := newTestStateSnapshot(t, db, storageState)
+	require.NoError(t, loaded.load())
+	assert.Equal(t, block2.Hash(), loaded.hash)
+	assert.Equal(t, block2.StateRoot, loaded.root)
+
+	// the snapshot is regenerated when the changes of a block are not in memory, the fork
+	// becoming the best chain so the regenerated snapshot stays at its head
+	fork2 := storeTestBlock(t, storageState, fork1, nil)
+	fork3 := storeTestBlock(t, storageState, fork2, nil)
+	require.Equal(t, fork3.Hash(), storageState.blockState.BestBlockHash())
+	require.NoError(t, loaded.Follow(fork3.Hash()))
+	require.Eventually(t, func() bool {
+		value, ok := loaded.Get(fork3.StateRoot, []byte("key"))
+		return ok && string(value) == "fork1"
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,235 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+)
+
+const storageChangesPrefix = "changes"
+
+var (
+	// storageChangesIndexedFromKey holds the number of the lowest block indexed, the blocks
+	// imported from it being all indexed
+	storageChangesIndexedFromKey = []byte("indexedfrom")
+	// storageChangesKeyPrefix + blake2b(storage key) + number (uint64 big endian) + hash -> nil
+	storageChangesKeyPrefix = []byte("key")
+)
+
+// StorageChanges indexes the blocks at which each storage key changed, so the queries of
+// a storage key over a range of blocks only read the storage at the blocks it changed at.
+// The blocks of every fork are indexed as they are imported. The blocks imported before the
+// index existed are indexed by Backfill.
+// The blocks of a light node are not indexed, their state not being stored.
+type StorageChanges struct {
+	db         database.Table
+	blockState *BlockState
+}
+
+// NewStorageChanges returns the storage changes index stored in the database given.
+func NewStorageChanges(db database.Database, blockState *BlockState) *StorageChanges {
+	return &StorageChanges{
+		db:         database.NewTable(db, storageChangesPrefix),
+		blockState: blockState,
+	}
+}
+
+func storageChangesKeyPrefixFor(key []byte) []byte {
+	keyHash := common.MustBlake2bHash(key)
+	return bytes.Join([][]byte{storageChangesKeyPrefix, keyHash.ToBytes()}, nil)
+}
+
+func storageChangeKey(key []byte, number uint, hash common.Hash) []byte {
+	return bytes.Join([][]byte{
+		storageChangesKeyPrefixFor(key),
+		encodeBlockNumber(uint64(number)),
+		hash.ToBytes(),
+	}, nil)
+}
+
+// Store indexes the storage keys given as changed at the block of the header given.
+func (sc *StorageChanges) Store(header *types.Header, keys [][]byte) error {
+	batch := sc.db.NewBatch()
+	defer batch.Close()
+
+	hash := header.Hash()
+	for _, key := range keys {
+		err := batch.Put(storageChangeKey(key, header.Number, hash), nil)
+		if err != nil {
+			return fmt.Errorf("putting storage change of key 0x%x: %w", key, err)
+		}
+	}
+
+	return batch.Flush()
+}
+
+// Init marks the blocks following the best block number given as indexed, if no block is
+// indexed yet, so the blocks imported before are indexed by Backfill.
+func (sc *StorageChanges) Init(bestNumber uint) error {
+	_, indexed, err := sc.IndexedFrom()
+	if err != nil {
+		return err
+	}
+	if indexed {
+		return nil
+	}
+
+	err = sc.db.Put(storageChangesIndexedFromKey, encodeBlockNumber(uint64(bestNumber+1)))
+	if err != nil {
+		return fmt.Errorf("putting lowest block indexed: %w", err)
+	}
+	return nil
+}
+
+// IndexedFrom returns the number of the lowest block indexed, the blocks following it being
+// all indexed. It returns false if no block is indexed yet.
+func (sc *StorageChanges) IndexedFrom() (number uint, indexed bool, err error) {
+	data, err := sc.db.Get(storageChangesIndexedFromKey)
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, fmt.Errorf("getting lowest block indexed: %w", err)
+	}
+	return uint(binary.BigEndian.Uint64(data)), true, nil
+}
+
+// Covers returns true if the changes of the blocks from the number given onwards are indexed.
+func (sc *StorageChanges) Covers(number uint) (bool, error) {
+	indexedFrom, indexed, err := sc.IndexedFrom()
+	if err != nil {
+		return false, err
+	}
+	return indexed && number >= indexedFrom, nil
+}
+
+// ChangedBlocks returns the hashes of the blocks numbered from start to end included, on any
+// fork, at which the storage key given changed, by increasing block number.
+func (sc *StorageChanges) ChangedBlocks(key []byte, start, end uint) (hashes []common.Hash, err error) {
+	prefix := storageChangesKeyPrefixFor(key)
+	iter, err := sc.db.NewPrefixIterator(prefix)
+	if err != nil {
+		return nil, fmt.Errorf("creating iterator: %w", err)
+	}
+	defer iter.Release()
+
+	// the iterator keys are prefixed with the table prefix
+	fullPrefix := bytes.Join([][]byte{[]byte(storageChangesPrefix), prefix}, nil)
+	seek := bytes.Join([][]byte{fullPrefix, encodeBlockNumber(uint64(start))}, nil)
+	for valid := iter.SeekGE(seek); valid; valid = iter.Next() {
+		suffix := iter.Key()[len(fullPrefix):]
+		number := uint(binary.BigEndian.Uint64(suffix[:8]))
+		if number > end {
+			break
+		}
+		hashes = append(hashes, common.NewHash(suffix[8:]))
+	}
+
+	return hashes, nil
+}
+
+// Backfill indexes the blocks of the canonical chain imported before the index existed,
+// from the lowest block indexed down to the first block whose parent state is no longer
+// stored. The changes of each block are found by comparing its state with the state of its
+// parent. It returns when all the blocks are indexed or when the channel given is closed.
+func (sc *StorageChanges) Backfill(storageState *InmemoryStorageState, done <-chan interface{}) error {
+	indexedFrom, indexed, err := sc.IndexedFrom()
+	if err != nil {
+		return err
+	}
+	if !indexed || indexedFrom <= 1 {
+		return nil
+	}
+
+	logger.Infof("backfilling storage changes index from block %d", indexedFrom-1)
+
+	header, err := sc.blockState.GetHeaderByNumber(indexedFrom - 1)
+	if err != nil {
+		return fmt.Errorf("getting header of block %d: %w", indexedFrom-1, err)
+	}
+
+	entries, err := storageState.loadEntries(header.StateRoot)
+	if err != nil {
+		logger.Infof("not backfilling storage changes index: state of block %d is not stored: %s",
+			header.Number, err)
+		return nil
+	}
+
+	for header.Number > 0 {
+		select {
+		case <-done:
+			return nil
+		default:
+		}
+
+		parent, err := sc.blockState.GetHeader(header.ParentHash)
+		if err != nil {
+			return fmt.Errorf("getting parent header of block %d: %w", header.Number, err)
+		}
+
+		parentEntries, err := storageState.loadEntries(parent.StateRoot)
+		if err != nil {
+			logger.Infof("stopped backfilling storage changes index at block %d, "+
+				"state of block %d is not stored: %s", header.Number, parent.Number, err)
+			return nil
+		}
+
+		err = sc.Store(header, changedKeys(parentEntries, entries))
+		if err != nil {
+			return fmt.Errorf("storing changes of block %d: %w", header.Number, err)
+		}
+
+		err = sc.db.Put(storageChangesIndexedFromKey, encodeBlockNumber(uint64(header.Number)))
+		if err != nil {
+			return fmt.Errorf("putting lowest block indexed: %w", err)
+		}
+
+		if header.Number%1000 == 0 {
+			logger.Infof("backfilled storage changes index down to block %d", header.Number)
+		}
+
+		header, entries = parent, parentEntries
+	}
+
+	logger.Info("backfilled storage changes index")
+	return nil
+}
+
+// changedKeys returns the keys whose value differs between the two states given.
+func changedKeys(before, after map[string][]byte) (keys [][]byte) {
+	for key, value := range after {
+		previous, ok := before[key]
+		if !ok || !bytes.Equal(previous, value) {
+			keys = append(keys, []byte(key))
+		}
+	}
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			keys = append(keys, []byte(key))
+		}
+	}
+	return keys
+}
+
+// loadEntries returns the entries of the state trie of the root given, loaded from the
+// database without caching the trie.
+func (s *InmemoryStorageState) loadEntries(root common.Hash) (map[string][]byte, error) {
+	if t := s.tries.get(root); t != nil {
+		return t.Entries(), nil
+	}
+
+	t := inmemory_trie.NewTrie(nil, s.db)
+	err := t.Load(s.db, root)
+	if err != nil {
+		return nil, err
+	}
+	return t.Entries(), nil
+}
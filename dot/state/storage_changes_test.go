@@ -0,0 +1,99 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageChanges(t *testing.T) {
+	t.Parallel()
+
+	db, err := database.NewPebble(t.TempDir(), true)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+
+	changes := NewStorageChanges(db, nil)
+
+	_, indexed, err := changes.IndexedFrom()
+	require.NoError(t, err)
+	assert.False(t, indexed)
+
+	err = changes.Init(9)
+	require.NoError(t, err)
+	// the lowest block indexed is kept once set
+	err = changes.Init(20)
+	require.NoError(t, err)
+
+	indexedFrom, indexed, err := changes.IndexedFrom()
+	require.NoError(t, err)
+	assert.True(t, indexed)
+	assert.Equal(t, uint(10), indexedFrom)
+
+	covered, err := changes.Covers(9)
+	require.NoError(t, err)
+	assert.False(t, covered)
+	covered, err = changes.Covers(10)
+	require.NoError(t, err)
+	assert.True(t, covered)
+
+	headers := make([]*types.Header, 3)
+	for i := range headers {
+		headers[i] = &types.Header{Number: uint(10 + i), Digest: types.NewDigest()}
+	}
+	fork := &types.Header{Number: 11, ParentHash: common.Hash{1}, Digest: types.NewDigest()}
+
+	keyA := []byte("a")
+	keyB := []byte("b")
+	// keyAB shares its prefix with keyA
+	keyAB := []byte("ab")
+
+	require.NoError(t, changes.Store(headers[0], [][]byte{keyA, keyB}))
+	require.NoError(t, changes.Store(headers[1], [][]byte{keyAB}))
+	require.NoError(t, changes.Store(fork, [][]byte{keyA}))
+	require.NoError(t, changes.Store(headers[2], [][]byte{keyA}))
+
+	hashes, err := changes.ChangedBlocks(keyA, 10, 12)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []common.Hash{headers[0].Hash(), fork.Hash(), headers[2].Hash()}, hashes)
+
+	hashes, err = changes.ChangedBlocks(keyA, 11, 11)
+	require.NoError(t, err)
+	assert.Equal(t, []common.Hash{fork.Hash()}, hashes)
+
+	hashes, err = changes.ChangedBlocks(keyB, 11, 12)
+	require.NoError(t, err)
+	assert.Empty(t, hashes)
+
+	hashes, err = changes.ChangedBlocks(keyAB, 0, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []common.Hash{headers[1].Hash()}, hashes)
+}
+
+func Test_changedKeys(t *testing.T) {
+	t.Parallel()
+
+	before := map[string][]byte{
+		"unchanged": {1},
+		"modified":  {1},
+		"deleted":   {1},
+	}
+	after := map[string][]byte{
+		"unchanged": {1},
+		"modified":  {2},
+		"inserted":  {1},
+	}
+
+	keys := changedKeys(before, after)
+
+	assert.ElementsMatch(t, [][]byte{[]byte("modified"), []byte("deleted"), []byte("inserted")}, keys)
+}
@@ -4,6 +4,7 @@
 package state
 
 import (
+	"sort"
 	"sync"
 	"time"
 
@@ -14,11 +15,30 @@
 	"github.com/ChainSafe/gossamer/lib/transaction"
 )
 
+// TransactionPoolLimits are the limits of the transactions held in the queue and pool together,
+// the transactions of lowest priority being dropped when a transaction added exceeds them.
+// A zero value disables the limit.
+type TransactionPoolLimits struct {
+	// Count is the maximum number of transactions
+	Count uint
+	// Bytes is the maximum total size of the transactions
+	Bytes uint
+}
+
+func (l TransactionPoolLimits) exceeded(count, bytes uint) bool {
+	return (l.Count > 0 && count > l.Count) || (l.Bytes > 0 && bytes > l.Bytes)
+}
+
 // TransactionState represents the queue of transactions
 type TransactionState struct {
 	queue *transaction.PriorityQueue
 	pool  *transaction.Pool
 
+	limits     TransactionPoolLimits
+	limitsLock sync.Mutex
+
+	bans *transaction.BanList
+
 	// notifierChannels are used to notify transaction status. It maps a channel to
 	// hex string of the extrinsic it is supposed to notify about.
 	notifierChannels map[chan transaction.Status]string
@@ -32,6 +52,7 @@ func NewTransactionState(telemetry Telemetry) *TransactionState {
 	return &TransactionState{
 		queue:            transaction.NewPriorityQueue(),
 		pool:             transaction.NewPool(),
+		bans:             transaction.NewBanList(transaction.DefaultBanDuration, transaction.DefaultBanStrikes),
 		notifierChannels: make(map[chan transaction.Status]string),
 		telemetry:        telemetry,
 	}
@@ -64,6 +85,11 @@ func (s *TransactionState) Pending() []*transaction.ValidTransaction {
 	return append(s.queue.Pending(), s.pool.Transactions()...)
 }
 
+// PendingInQueue returns the current transactions in the queue
+func (s *TransactionState) PendingInQueue() []*transaction.ValidTransaction {
+	return s.queue.Pending()
+}
+
 // PendingInPool returns the current transactions in the pool
 func (s *TransactionState) PendingInPool() []*transaction.ValidTransaction {
 	return s.pool.Transactions()
@@ -91,6 +117,7 @@ func (s *TransactionState) AddToPool(vt *transaction.ValidTransaction) common.Ha
 	s.notifyStatus(vt.Extrinsic, transaction.Future)
 
 	hash := s.pool.Insert(vt)
+	s.dropLowestPriority(hash)
 
 	s.telemetry.SendMessage(
 		telemetry.NewTxpoolImport(uint(s.queue.Len()), uint(s.pool.Len())), //nolint:gosec
@@ -99,6 +126,116 @@ func (s *TransactionState) AddToPool(vt *transaction.ValidTransaction) common.Ha
 	return hash
 }
 
+// SetLimits sets the limits of the transactions held, enforced when adding transactions to the pool.
+func (s *TransactionState) SetLimits(limits TransactionPoolLimits) {
+	s.limitsLock.Lock()
+	defer s.limitsLock.Unlock()
+
+	s.limits = limits
+}
+
+// dropLowestPriority removes the transactions of lowest priority from the queue and pool while
+// they exceed the limits. The transaction just added is dropped first among the transactions of
+// its priority, so it does not replace a transaction of the same priority.
+func (s *TransactionState) dropLowestPriority(added common.Hash) {
+	s.limitsLock.Lock()
+	defer s.limitsLock.Unlock()
+
+	if s.limits.Count == 0 && s.limits.Bytes == 0 {
+		return
+	}
+
+	type pendingTransaction struct {
+		*transaction.ValidTransaction
+		hash common.Hash
+	}
+
+	var count, bytes uint
+	var pending []pendingTransaction
+	for _, tx := range s.Pending() {
+		pending = append(pending, pendingTransaction{ValidTransaction: tx, hash: tx.Extrinsic.Hash()})
+		count++
+		bytes += uint(len(tx.Extrinsic))
+	}
+
+	if !s.limits.exceeded(count, bytes) {
+		return
+	}
+
+	sort.Slice(pending, func(i, j int) bool {
+		priorityI, priorityJ := priority(pending[i].ValidTransaction), priority(pending[j].ValidTransaction)
+		if priorityI != priorityJ {
+			return priorityI < priorityJ
+		}
+		return pending[i].hash == added
+	})
+
+	for _, tx := range pending {
+		if !s.limits.exceeded(count, bytes) {
+			break
+		}
+
+		s.RemoveExtrinsic(tx.Extrinsic)
+		s.notifyStatus(tx.Extrinsic, transaction.Dropped)
+		logger.Debugf("dropped transaction %s of priority %d, the transaction pool being full",
+			tx.hash, priority(tx.ValidTransaction))
+
+		count--
+		bytes -= uint(len(tx.Extrinsic))
+	}
+}
+
+// RemoveExpired removes the transactions from the queue and pool whose mortality window
+// expired at the best block number given, and returns their hashes.
+func (s *TransactionState) RemoveExpired(bestNumber uint) (removed []common.Hash) {
+	for _, tx := range s.Pending() {
+		if !tx.Expired(bestNumber) {
+			continue
+		}
+
+		hash := tx.Extrinsic.Hash()
+		s.RemoveExtrinsic(tx.Extrinsic)
+		s.notifyStatus(tx.Extrinsic, transaction.Invalid)
+		logger.Debugf("removed transaction %s expired at block %d", hash, tx.ValidTill)
+		removed = append(removed, hash)
+	}
+	return removed
+}
+
+// SetBanDuration sets the duration the extrinsics failing repeatedly are banned for.
+// A zero duration disables banning.
+func (s *TransactionState) SetBanDuration(duration time.Duration) {
+	s.bans.SetDuration(duration)
+}
+
+// ReportInvalid records a validation or block building failure of the extrinsic, banning it
+// from the pool once it failed repeatedly. It returns true if the extrinsic is banned.
+func (s *TransactionState) ReportInvalid(ext types.Extrinsic) (banned bool) {
+	hash := ext.Hash()
+	banned = s.bans.Strike(hash)
+	if banned {
+		logger.Debugf("banned transaction %s after repeated failures", hash)
+	}
+	return banned
+}
+
+// BanExtrinsic bans the extrinsic from the pool, such as when removed by the operator.
+func (s *TransactionState) BanExtrinsic(ext types.Extrinsic) {
+	s.bans.Ban(ext.Hash())
+}
+
+// IsBanned returns true if the extrinsic is banned from the pool
+func (s *TransactionState) IsBanned(ext types.Extrinsic) bool {
+	return s.bans.IsBanned(ext.Hash())
+}
+
+func priority(tx *transaction.ValidTransaction) uint64 {
+	if tx.Validity == nil {
+		return 0
+	}
+	return tx.Validity.Priority
+}
+
 // GetStatusNotifierChannel creates and returns a status notifier channel.
 func (s *TransactionState) GetStatusNotifierChannel(ext types.Extrinsic) chan transaction.Status {
 	s.notifierLock.Lock()
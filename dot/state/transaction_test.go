@@ -123,3 +123,102 @@ func TestTransactionState_NotifierChannels(t *testing.T) {
 	require.Equal(t, expectedFutureCount, futureCount)
 	require.Equal(t, expectedReadyCount, readyCount)
 }
+
+func TestTransactionState_SetLimits(t *testing.T) {
+	t.Parallel()
+
+	newTx := func(extrinsic string, priority uint64) *transaction.ValidTransaction {
+		return &transaction.ValidTransaction{
+			Extrinsic: []byte(extrinsic),
+			Validity:  &transaction.Validity{Priority: priority},
+		}
+	}
+
+	testCases := map[string]struct {
+		limits   TransactionPoolLimits
+		queued   []*transaction.ValidTransaction
+		added    []*transaction.ValidTransaction
+		expected []string
+	}{
+		"no_limits": {
+			queued:   []*transaction.ValidTransaction{newTx("a", 1)},
+			added:    []*transaction.ValidTransaction{newTx("b", 2), newTx("c", 3)},
+			expected: []string{"a", "b", "c"},
+		},
+		"count_drops_lowest_priority_of_queue": {
+			limits:   TransactionPoolLimits{Count: 2},
+			queued:   []*transaction.ValidTransaction{newTx("a", 1)},
+			added:    []*transaction.ValidTransaction{newTx("b", 2), newTx("c", 3)},
+			expected: []string{"b", "c"},
+		},
+		"count_drops_added_of_same_priority": {
+			limits:   TransactionPoolLimits{Count: 2},
+			added:    []*transaction.ValidTransaction{newTx("a", 1), newTx("b", 1), newTx("c", 1)},
+			expected: []string{"a", "b"},
+		},
+		"bytes": {
+			limits:   TransactionPoolLimits{Bytes: 5},
+			added:    []*transaction.ValidTransaction{newTx("aa", 3), newTx("bb", 1), newTx("cc", 2)},
+			expected: []string{"aa", "cc"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			telemetryMock := NewMockTelemetry(ctrl)
+			telemetryMock.EXPECT().SendMessage(gomock.Any()).AnyTimes()
+
+			ts := NewTransactionState(telemetryMock)
+			ts.SetLimits(testCase.limits)
+
+			for _, tx := range testCase.queued {
+				_, err := ts.Push(tx)
+				require.NoError(t, err)
+			}
+			for _, tx := range testCase.added {
+				ts.AddToPool(tx)
+			}
+
+			var pending []string
+			for _, tx := range ts.Pending() {
+				pending = append(pending, string(tx.Extrinsic))
+			}
+			sort.Strings(pending)
+			require.Equal(t, testCase.expected, pending)
+		})
+	}
+}
+
+func TestTransactionState_RemoveExpired(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	telemetryMock := NewMockTelemetry(ctrl)
+	telemetryMock.EXPECT().SendMessage(gomock.Any()).AnyTimes()
+
+	ts := NewTransactionState(telemetryMock)
+
+	immortal := &transaction.ValidTransaction{Extrinsic: []byte("a"), Validity: &transaction.Validity{}}
+	expiring := &transaction.ValidTransaction{Extrinsic: []byte("b"), Validity: &transaction.Validity{}, ValidTill: 10}
+	queued := &transaction.ValidTransaction{Extrinsic: []byte("c"), Validity: &transaction.Validity{}, ValidTill: 12}
+
+	ts.AddToPool(immortal)
+	ts.AddToPool(expiring)
+	_, err := ts.Push(queued)
+	require.NoError(t, err)
+
+	removed := ts.RemoveExpired(9)
+	require.Empty(t, removed)
+
+	removed = ts.RemoveExpired(10)
+	require.Equal(t, []common.Hash{expiring.Extrinsic.Hash()}, removed)
+
+	removed = ts.RemoveExpired(20)
+	require.Equal(t, []common.Hash{queued.Extrinsic.Hash()}, removed)
+
+	require.Equal(t, []*transaction.ValidTransaction{immortal}, ts.Pending())
+}
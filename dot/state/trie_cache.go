@@ -0,0 +1,159 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"sync/atomic"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/trie/cache"
+	inmemory_cache "github.com/ChainSafe/gossamer/pkg/trie/cache/inmemory"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var trieCacheCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "gossamer_storage_trie_cache",
+	Name:      "lookups_total",
+	Help:      "total number of trie cache lookups by cache and result",
+}, []string{"cache", "result"})
+
+// trieCacheMetrics counts the hits and misses of the node and value caches,
+// the hit rate being computed from these counters
+type trieCacheMetrics struct {
+	nodeHits    prometheus.Counter
+	nodeMisses  prometheus.Counter
+	valueHits   prometheus.Counter
+	valueMisses prometheus.Counter
+
+	// lookups counts the lookups of this cache only, the prometheus counters
+	// being shared by all the caches
+	lookups struct {
+		nodeHits, nodeMisses, valueHits, valueMisses atomic.Uint64
+	}
+}
+
+func newTrieCacheMetrics() *trieCacheMetrics {
+	return &trieCacheMetrics{
+		nodeHits:    trieCacheCounter.WithLabelValues("node", "hit"),
+		nodeMisses:  trieCacheCounter.WithLabelValues("node", "miss"),
+		valueHits:   trieCacheCounter.WithLabelValues("value", "hit"),
+		valueMisses: trieCacheCounter.WithLabelValues("value", "miss"),
+	}
+}
+
+func (m *trieCacheMetrics) nodeHit() {
+	m.nodeHits.Inc()
+	m.lookups.nodeHits.Add(1)
+}
+
+func (m *trieCacheMetrics) nodeMiss() {
+	m.nodeMisses.Inc()
+	m.lookups.nodeMisses.Add(1)
+}
+
+func (m *trieCacheMetrics) valueHit() {
+	m.valueHits.Inc()
+	m.lookups.valueHits.Add(1)
+}
+
+func (m *trieCacheMetrics) valueMiss() {
+	m.valueMisses.Inc()
+	m.lookups.valueMisses.Add(1)
+}
+
+// TrieCacheStats are the statistics of the trie cache, exposed for diagnostics.
+type TrieCacheStats struct {
+	NodeHits    uint64 `json:"nodeHits"`
+	NodeMisses  uint64 `json:"nodeMisses"`
+	ValueHits   uint64 `json:"valueHits"`
+	ValueMisses uint64 `json:"valueMisses"`
+	// Usage is the number of entries and the size of the caches, nil if the
+	// cache implementation does not report it
+	Usage *inmemory_cache.Stats `json:"usage,omitempty"`
+}
+
+// trieCacheDatabase is the storage database reading the encoded trie nodes
+// through the trie node cache. The nodes being keyed by their hash, a cached
+// node never needs to be invalidated, and the cache can be shared by all the
+// tries loaded from the database, across block executions and RPC queries.
+type trieCacheDatabase struct {
+	GetterPutterNewBatcher
+	cache   cache.TrieCache
+	metrics *trieCacheMetrics
+}
+
+func newTrieCacheDatabase(db GetterPutterNewBatcher, trieCache cache.TrieCache) *trieCacheDatabase {
+	return &trieCacheDatabase{
+		GetterPutterNewBatcher: db,
+		cache:                  trieCache,
+		metrics:                newTrieCacheMetrics(),
+	}
+}
+
+// Get returns the value at the given key, from the node cache if the key is a node hash
+func (db *trieCacheDatabase) Get(key []byte) (value []byte, err error) {
+	if len(key) != common.HashLength {
+		return db.GetterPutterNewBatcher.Get(key)
+	}
+
+	value = db.cache.GetNode(key)
+	if value != nil {
+		db.metrics.nodeHit()
+		return value, nil
+	}
+	db.metrics.nodeMiss()
+
+	value, err = db.GetterPutterNewBatcher.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	db.cache.SetNode(key, value)
+	return value, nil
+}
+
+// getValue returns the cached storage value at the given key in the trie with the given
+// root, or nil if it is not cached.
+func (db *trieCacheDatabase) getValue(root common.Hash, key []byte) []byte {
+	value := db.cache.GetValue(valueCacheKey(root, key))
+	if value != nil {
+		db.metrics.valueHit()
+		return value
+	}
+
+	db.metrics.valueMiss()
+	return nil
+}
+
+// setValue caches the storage value at the given key in the trie with the given root.
+func (db *trieCacheDatabase) setValue(root common.Hash, key, value []byte) {
+	db.cache.SetValue(valueCacheKey(root, key), value)
+}
+
+// stats returns the lookup counts of the cache, and the size of the cache if it reports it.
+func (db *trieCacheDatabase) stats() TrieCacheStats {
+	lookups := &db.metrics.lookups
+	stats := TrieCacheStats{
+		NodeHits:    lookups.nodeHits.Load(),
+		NodeMisses:  lookups.nodeMisses.Load(),
+		ValueHits:   lookups.valueHits.Load(),
+		ValueMisses: lookups.valueMisses.Load(),
+	}
+
+	sized, ok := db.cache.(interface{ Stats() inmemory_cache.Stats })
+	if ok {
+		usage := sized.Stats()
+		stats.Usage = &usage
+	}
+	return stats
+}
+
+// valueCacheKey returns the value cache key of a storage key, which is prefixed
+// by the trie root since the value of a key differs between tries
+func valueCacheKey(root common.Hash, key []byte) []byte {
+	cacheKey := make([]byte, 0, common.HashLength+len(key))
+	cacheKey = append(cacheKey, root[:]...)
+	return append(cacheKey, key...)
+}
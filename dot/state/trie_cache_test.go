@@ -0,0 +1,101 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package state
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	inmemory_cache "github.com/ChainSafe/gossamer/pkg/trie/cache/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_trieCacheDatabase_Get(t *testing.T) {
+	t.Parallel()
+
+	storageTable := database.NewTable(NewInMemoryDB(t), storagePrefix)
+	trieCache := inmemory_cache.NewTrieInMemoryCacheWithSize(1024 * 1024)
+	db := newTrieCacheDatabase(storageTable, trieCache)
+
+	nodeHash := common.Hash{1}
+	encodedNode := []byte{1, 2, 3}
+	err := db.Put(nodeHash[:], encodedNode)
+	require.NoError(t, err)
+
+	// first read goes to the database and caches the node
+	value, err := db.Get(nodeHash[:])
+	require.NoError(t, err)
+	assert.Equal(t, encodedNode, value)
+	assert.Equal(t, encodedNode, trieCache.GetNode(nodeHash[:]))
+
+	// the node is read from the cache once removed from the database
+	err = storageTable.Del(nodeHash[:])
+	require.NoError(t, err)
+
+	value, err = db.Get(nodeHash[:])
+	require.NoError(t, err)
+	assert.Equal(t, encodedNode, value)
+
+	// keys which are not node hashes are not cached
+	key := []byte("key")
+	err = db.Put(key, encodedNode)
+	require.NoError(t, err)
+
+	value, err = db.Get(key)
+	require.NoError(t, err)
+	assert.Equal(t, encodedNode, value)
+	assert.Nil(t, trieCache.GetNode(key))
+
+	stats := db.stats()
+	assert.Equal(t, uint64(1), stats.NodeHits)
+	assert.Equal(t, uint64(1), stats.NodeMisses)
+	require.NotNil(t, stats.Usage)
+	assert.Equal(t, 1, stats.Usage.NodeEntries)
+}
+
+func TestStorage_GetStorage_TrieCache(t *testing.T) {
+	db := NewInMemoryDB(t)
+	tries := newTriesEmpty()
+	blockState := newTestBlockState(t, tries)
+
+	trieCache := inmemory_cache.NewTrieInMemoryCacheWithSize(1024 * 1024)
+	storage, err := NewStorageState(db, blockState, tries, trieCache)
+	require.NoError(t, err)
+
+	ts, err := storage.TrieState(&trie.EmptyHash)
+	require.NoError(t, err)
+
+	key := []byte("key")
+	value := []byte("value")
+	ts.Put(key, value)
+
+	root, err := ts.Trie().Hash()
+	require.NoError(t, err)
+
+	err = storage.StoreTrie(ts, nil)
+	require.NoError(t, err)
+
+	// clear the trie from memory so it is read from the database
+	storage.blockState.tries.delete(root)
+
+	data, err := storage.GetStorage(&root, key)
+	require.NoError(t, err)
+	assert.Equal(t, value, data)
+	assert.Equal(t, value, trieCache.GetValue(valueCacheKey(root, key)))
+
+	// the cached value is returned even once the trie is removed from the database
+	storage.db = database.NewTable(NewInMemoryDB(t), storagePrefix)
+
+	data, err = storage.GetStorage(&root, key)
+	require.NoError(t, err)
+	assert.Equal(t, value, data)
+
+	stats, enabled := storage.TrieCacheStats()
+	assert.True(t, enabled)
+	assert.Equal(t, uint64(1), stats.ValueHits)
+	assert.Equal(t, uint64(1), stats.ValueMisses)
+}
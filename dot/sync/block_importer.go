@@ -5,6 +5,7 @@
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,12 +14,22 @@
 	"github.com/ChainSafe/gossamer/dot/telemetry"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/internal/tracing"
 	"github.com/ChainSafe/gossamer/lib/common"
 	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const tracerName = "github.com/ChainSafe/gossamer/dot/sync"
+
+// ErrInvalidStateRoot is returned when the state root of a block does not match the state
+// after its execution
+var ErrInvalidStateRoot = errors.New("state root does not match the state after execution")
+
 var blockSizeGauge = promauto.NewGauge(prometheus.GaugeOpts{
 	Namespace: "gossamer_sync",
 	Name:      "block_size",
@@ -47,6 +58,12 @@
 		VerifyBlock(header *types.Header) error
 	}
 
+	// BatchVerifier is implemented by the block verifiers able to verify
+	// a chain of block headers at once, before the blocks are imported.
+	BatchVerifier interface {
+		VerifyBlocks(headers []*types.Header) (verified int, err error)
+	}
+
 	// FinalityGadget implements justification verification functionality
 	FinalityGadget interface {
 		VerifyBlockJustification(common.Hash, uint, []byte) (round uint64, setID uint64, err error)
@@ -56,6 +73,18 @@
 	BlockImportHandler interface {
 		HandleBlockImport(block *types.Block, state *rtstorage.TrieState, announce bool) error
 	}
+
+	// HeaderImportHandler is the interface for the handler of newly imported
+	// headers, used by light nodes which do not execute the blocks
+	HeaderImportHandler interface {
+		HandleHeaderImport(header *types.Header) error
+	}
+
+	// MisbehaviourRecorder records the blocks failing verification because of a
+	// misbehaviour of their author
+	MisbehaviourRecorder interface {
+		RecordBlockFailure(header *types.Header, err error)
+	}
 )
 
 type blockImporter struct {
@@ -63,13 +92,23 @@ type blockImporter struct {
 	storageState       StorageState
 	transactionState   TransactionState
 	babeVerifier       BabeVerifier
+	batchVerifier      BatchVerifier
 	finalityGadget     FinalityGadget
 	blockImportHandler BlockImportHandler
-	telemetry          Telemetry
+	// headerImportHandler imports the block headers
+	// without executing the blocks if the node is a light node
+	headerImportHandler HeaderImportHandler
+	telemetry           Telemetry
+	// misbehaviourRecorder records the blocks failing verification, it is nil if not set
+	misbehaviourRecorder MisbehaviourRecorder
+	// memoryBudget is the maximum size in bytes of the verified blocks waiting to be executed
+	memoryBudget uint64
+	// tracer traces the block imports
+	tracer trace.Tracer
 }
 
 func newBlockImporter(cfg *FullSyncConfig) *blockImporter {
-	return &blockImporter{
+	importer := &blockImporter{
 		blockState:         cfg.BlockState,
 		storageState:       cfg.StorageState,
 		transactionState:   cfg.TransactionState,
@@ -77,22 +116,150 @@ func newBlockImporter(cfg *FullSyncConfig) *blockImporter {
 		finalityGadget:     cfg.FinalityGadget,
 		blockImportHandler: cfg.BlockImportHandler,
 		telemetry:          cfg.Telemetry,
+		memoryBudget:       cfg.MemoryBudget,
+		tracer:             otel.Tracer(tracerName),
+
+		misbehaviourRecorder: cfg.MisbehaviourRecorder,
+	}
+
+	if batchVerifier, ok := cfg.BabeVerifier.(BatchVerifier); ok {
+		importer.batchVerifier = batchVerifier
+	}
+
+	if cfg.Light {
+		importer.headerImportHandler = cfg.HeaderImportHandler
+	}
+	return importer
+}
+
+// importBlocks imports in order the blocks received during the initial sync. The blocks are verified
+// and executed in two stages connected by a queue bounded by the memory budget, the verification
+// waiting for blocks to be executed when the queue is full. If the block verifier supports it, the
+// headers of the chains of blocks are verified in batches, otherwise the blocks are imported without
+// being verified.
+func (b *blockImporter) importBlocks(bds []*types.BlockData) (imported int, err error) {
+	queue := newImportQueue(maxQueuedBlocks, b.memoryBudget)
+	verified := make(chan error, 1)
+	go func() {
+		err := b.verifyBlocks(bds, queue)
+		queue.close()
+		verified <- err
+	}()
+
+	for {
+		bd, ok := queue.pop()
+		if !ok {
+			break
+		}
+
+		ok, err = b.importBlock(bd, networkInitialSync)
+		queue.release(bd)
+		if err != nil {
+			queue.close()
+			<-verified
+			return imported, fmt.Errorf("while handling ready block: %w", err)
+		}
+
+		if ok {
+			imported++
+		}
 	}
+
+	return imported, <-verified
+}
+
+// verifyBlocks verifies the headers of the blocks given in batches, and pushes the verified blocks
+// to the import queue. It returns without error if the queue is closed by a failed block execution.
+func (b *blockImporter) verifyBlocks(bds []*types.BlockData, queue *importQueue) error {
+	defer setQueueMetrics(stageVerification, 0, 0)
+
+	for i := 0; i < len(bds); {
+		setQueueMetrics(stageVerification, len(bds)-i, blocksSize(bds[i:]...))
+
+		verified := len(bds) - i
+		if b.batchVerifier != nil {
+			// the headers following a batch can only be verified once the blocks of the batch are
+			// imported, the epoch data of the headers being unknown before
+			if i > 0 && !queue.waitReleased() {
+				return nil
+			}
+
+			var err error
+			verified, err = b.verifyHeaders(bds[i:])
+			if err != nil {
+				return fmt.Errorf("verifying block #%d (%s) and its descendants: %w",
+					bds[i].Header.Number, bds[i].Hash, err)
+			}
+			verified = max(verified, 1)
+		}
+		setQueueMetrics(stageVerification, len(bds)-i-verified, blocksSize(bds[i+verified:]...))
+
+		for _, bd := range bds[i : i+verified] {
+			if !queue.push(bd) {
+				return nil
+			}
+		}
+		i += verified
+	}
+
+	return nil
+}
+
+// verifyHeaders batch verifies the headers of the blocks given, up to the first block without
+// a header, and returns the number of leading blocks verified.
+func (b *blockImporter) verifyHeaders(bds []*types.BlockData) (verified int, err error) {
+	headers := make([]*types.Header, 0, len(bds))
+	for _, bd := range bds {
+		if bd.Header == nil {
+			break
+		}
+		headers = append(headers, bd.Header)
+	}
+
+	if len(headers) == 0 {
+		return 0, nil
+	}
+
+	_, span := b.tracer.Start(context.Background(), "verify_headers", trace.WithAttributes(
+		attribute.Int("headers", len(headers)),
+	))
+	verified, err = b.batchVerifier.VerifyBlocks(headers)
+	span.SetAttributes(attribute.Int("verified", verified))
+	tracing.EndSpan(span, err)
+	if err != nil && verified < len(headers) {
+		b.recordFailure(headers[verified], err)
+	}
+	return verified, err
 }
 
 func (b *blockImporter) importBlock(bd *types.BlockData, origin BlockOrigin) (imported bool, err error) {
+	ctx, span := b.tracer.Start(context.Background(), "import_block", trace.WithAttributes(
+		attribute.String("hash", bd.Hash.String()),
+		attribute.Bool("initial_sync", origin == networkInitialSync),
+	))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+	if bd.Header != nil {
+		span.SetAttributes(attribute.Int64("number", int64(bd.Header.Number))) //nolint:gosec
+	}
+
 	blockAlreadyExists, err := b.blockState.HasHeader(bd.Hash)
 	if err != nil && !errors.Is(err, database.ErrNotFound) {
 		return false, err
 	}
 
 	if blockAlreadyExists {
+		span.SetAttributes(attribute.Bool("already_imported", true))
 		return false, nil
 	}
 
-	err = b.processBlockData(*bd, origin)
+	err = b.processBlockData(ctx, *bd, origin)
 	if err != nil {
 		logger.Errorf("processing block #%d (%s) failed: %s", bd.Header.Number, bd.Hash, err)
+		if bd.Header != nil {
+			b.recordFailure(bd.Header, err)
+		}
 		return false, err
 	}
 
@@ -102,7 +269,7 @@ func (b *blockImporter) importBlock(bd *types.BlockData, origin BlockOrigin) (im
 // processBlockData processes the BlockData from a BlockResponse and
 // returns the index of the last BlockData it handled on success,
 // or the index of the block data that errored on failure.
-func (b *blockImporter) processBlockData(blockData types.BlockData, origin BlockOrigin) error {
+func (b *blockImporter) processBlockData(ctx context.Context, blockData types.BlockData, origin BlockOrigin) error {
 	if blockData.Header != nil {
 		var (
 			hasJustification = blockData.Justification != nil && len(*blockData.Justification) > 0
@@ -112,15 +279,22 @@ func (b *blockImporter) processBlockData(blockData types.BlockData, origin Block
 
 		if hasJustification {
 			var err error
+			_, span := b.tracer.Start(ctx, "verify_justification")
 			round, setID, err = b.finalityGadget.VerifyBlockJustification(
 				blockData.Header.Hash(), blockData.Header.Number, *blockData.Justification)
+			tracing.EndSpan(span, err)
 			if err != nil {
 				return fmt.Errorf("verifying justification: %w", err)
 			}
 		}
 
-		if blockData.Body != nil {
-			err := b.processBlockDataWithHeaderAndBody(blockData, origin)
+		if b.headerImportHandler != nil {
+			err := b.processHeader(ctx, blockData.Header, origin)
+			if err != nil {
+				return fmt.Errorf("processing header: %w", err)
+			}
+		} else if blockData.Body != nil {
+			err := b.processBlockDataWithHeaderAndBody(ctx, blockData, origin)
 			if err != nil {
 				return fmt.Errorf("processing block data with header and body: %w", err)
 			}
@@ -148,11 +322,40 @@ func (b *blockImporter) processBlockData(blockData types.BlockData, origin Block
 	return nil
 }
 
-func (b *blockImporter) processBlockDataWithHeaderAndBody(blockData types.BlockData,
+// processHeader imports the header of a block without executing it,
+// a light node storing neither the block bodies nor the state. The headers of
+// the initial sync already batch verified are not verified again, the other
+// headers being verified whatever their origin since no block execution
+// would otherwise catch an invalid header.
+func (b *blockImporter) processHeader(ctx context.Context, header *types.Header, origin BlockOrigin) error {
+	if origin != networkInitialSync || b.batchVerifier == nil {
+		err := b.verifyBlock(ctx, header)
+		if err != nil {
+			return fmt.Errorf("babe verifying block: %w", err)
+		}
+	}
+
+	_, span := b.tracer.Start(ctx, "handle_header_import")
+	err := b.headerImportHandler.HandleHeaderImport(header)
+	tracing.EndSpan(span, err)
+	if err != nil {
+		return fmt.Errorf("handling header import: %w", err)
+	}
+
+	blockHash := header.Hash()
+	b.telemetry.SendMessage(telemetry.NewBlockImport(
+		&blockHash,
+		header.Number,
+		"NetworkInitialSync"))
+
+	return nil
+}
+
+func (b *blockImporter) processBlockDataWithHeaderAndBody(ctx context.Context, blockData types.BlockData,
 	origin BlockOrigin) (err error) {
 
 	if origin != networkInitialSync {
-		err = b.babeVerifier.VerifyBlock(blockData.Header)
+		err = b.verifyBlock(ctx, blockData.Header)
 		if err != nil {
 			return fmt.Errorf("babe verifying block: %w", err)
 		}
@@ -171,7 +374,7 @@ func (b *blockImporter) processBlockDataWithHeaderAndBody(blockData types.BlockD
 		Body:   *blockData.Body,
 	}
 
-	err = b.handleBlock(block)
+	err = b.handleBlock(ctx, block)
 	if err != nil {
 		return fmt.Errorf("handling block: %w", err)
 	}
@@ -179,8 +382,23 @@ func (b *blockImporter) processBlockDataWithHeaderAndBody(blockData types.BlockD
 	return nil
 }
 
+// recordFailure records the verification failure of the block of the header given,
+// the misbehaviour recorder ignoring the failures not caused by the block author.
+func (b *blockImporter) recordFailure(header *types.Header, err error) {
+	if b.misbehaviourRecorder != nil {
+		b.misbehaviourRecorder.RecordBlockFailure(header, err)
+	}
+}
+
+func (b *blockImporter) verifyBlock(ctx context.Context, header *types.Header) (err error) {
+	_, span := b.tracer.Start(ctx, "verify_block")
+	err = b.babeVerifier.VerifyBlock(header)
+	tracing.EndSpan(span, err)
+	return err
+}
+
 // handleBlock executes blocks and writes them to disk
-func (b *blockImporter) handleBlock(block *types.Block) error {
+func (b *blockImporter) handleBlock(ctx context.Context, block *types.Block) error {
 	parent, err := b.blockState.GetHeader(block.Header.ParentHash)
 	if err != nil {
 		return fmt.Errorf("%w: %s", errFailedToGetParent, err)
@@ -189,7 +407,10 @@ func (b *blockImporter) handleBlock(block *types.Block) error {
 	b.storageState.Lock()
 	defer b.storageState.Unlock()
 
+	// loading the trie state of the parent block reads the trie nodes not cached from the database
+	_, span := b.tracer.Start(ctx, "load_trie_state")
 	ts, err := b.storageState.TrieState(&parent.StateRoot)
+	tracing.EndSpan(span, err)
 	if err != nil {
 		return err
 	}
@@ -206,13 +427,27 @@ func (b *blockImporter) handleBlock(block *types.Block) error {
 
 	rt.SetContextStorage(ts)
 
+	_, span = b.tracer.Start(ctx, "execute_block")
 	_, err = rt.ExecuteBlock(block)
+	tracing.EndSpan(span, err)
 	if err != nil {
 		return fmt.Errorf("failed to execute block %d: %w", block.Header.Number, err)
 	}
 
+	// the runtime checks the state root of the block it executes, the check being repeated
+	// for the invalid state roots to be told apart from the other execution failures
+	stateRoot := ts.Trie().MustHash()
+	if stateRoot != block.Header.StateRoot {
+		return fmt.Errorf("%w: block %d has state root %s, expected %s",
+			ErrInvalidStateRoot, block.Header.Number, block.Header.StateRoot, stateRoot)
+	}
+
+	// handling the block import writes the block and its state to the database
 	announceImportedBlock := false
-	if err = b.blockImportHandler.HandleBlockImport(block, ts, announceImportedBlock); err != nil {
+	_, span = b.tracer.Start(ctx, "handle_block_import")
+	err = b.blockImportHandler.HandleBlockImport(block, ts, announceImportedBlock)
+	tracing.EndSpan(span, err)
+	if err != nil {
 		return err
 	}
 
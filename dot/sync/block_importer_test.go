@@ -0,0 +1,228 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_blockImporter_importBlocks(t *testing.T) {
+	t.Parallel()
+
+	bds := make([]*types.BlockData, 3)
+	headers := make([]*types.Header, 3)
+	for i := range bds {
+		headers[i] = types.NewHeader(common.Hash{byte(i)}, common.Hash{}, common.Hash{}, uint(i+1), nil)
+		bds[i] = &types.BlockData{
+			Hash:   headers[i].Hash(),
+			Header: headers[i],
+		}
+	}
+
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		setup       func(*MockBatchVerifier, *MockBlockState, *MockMisbehaviourRecorder)
+		errSentinel error
+	}{
+		"verified_in_two_batches": {
+			setup: func(verifier *MockBatchVerifier, blockState *MockBlockState, _ *MockMisbehaviourRecorder) {
+				// the last block is in an epoch whose data is not known before importing the others
+				verifier.EXPECT().VerifyBlocks(headers).Return(2, nil)
+				verifier.EXPECT().VerifyBlocks(headers[2:]).Return(1, nil)
+				for _, bd := range bds {
+					blockState.EXPECT().HasHeader(bd.Hash).Return(true, nil)
+				}
+			},
+		},
+		"verification_error": {
+			setup: func(verifier *MockBatchVerifier, blockState *MockBlockState,
+				misbehaviourRecorder *MockMisbehaviourRecorder) {
+				verifier.EXPECT().VerifyBlocks(headers).Return(1, nil)
+				blockState.EXPECT().HasHeader(bds[0].Hash).Return(true, nil)
+				verifier.EXPECT().VerifyBlocks(headers[1:]).Return(0, errTest)
+				misbehaviourRecorder.EXPECT().RecordBlockFailure(headers[1], errTest)
+			},
+			errSentinel: errTest,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			verifier := NewMockBatchVerifier(ctrl)
+			blockState := NewMockBlockState(ctrl)
+			misbehaviourRecorder := NewMockMisbehaviourRecorder(ctrl)
+			testCase.setup(verifier, blockState, misbehaviourRecorder)
+
+			importer := &blockImporter{
+				blockState:           blockState,
+				batchVerifier:        verifier,
+				misbehaviourRecorder: misbehaviourRecorder,
+				tracer:               noop.NewTracerProvider().Tracer(tracerName),
+			}
+
+			// the blocks are already imported
+			imported, err := importer.importBlocks(bds)
+			assert.ErrorIs(t, err, testCase.errSentinel)
+			assert.Zero(t, imported)
+		})
+	}
+}
+
+func Test_blockImporter_importBlock_spans(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	justification := []byte{1}
+	header := types.NewHeader(common.Hash{1}, common.Hash{}, common.Hash{}, 1, nil)
+	bd := &types.BlockData{
+		Hash:          header.Hash(),
+		Header:        header,
+		Justification: &justification,
+	}
+	errTest := errors.New("test error")
+
+	blockState := NewMockBlockState(ctrl)
+	blockState.EXPECT().HasHeader(bd.Hash).Return(false, nil)
+	finalityGadget := NewMockFinalityGadget(ctrl)
+	finalityGadget.EXPECT().VerifyBlockJustification(bd.Hash, uint(1), justification).
+		Return(uint64(0), uint64(0), errTest)
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	importer := &blockImporter{
+		blockState:     blockState,
+		finalityGadget: finalityGadget,
+		tracer:         provider.Tracer(tracerName),
+	}
+
+	imported, err := importer.importBlock(bd, networkBroadcast)
+	assert.ErrorIs(t, err, errTest)
+	assert.False(t, imported)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "verify_justification", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+
+	assert.Equal(t, "import_block", spans[1].Name())
+	assert.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+}
+
+func Test_blockImporter_importBlock_recordsFailure(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	header := types.NewHeader(common.Hash{1}, common.Hash{}, common.Hash{}, 1, nil)
+	body := types.Body{}
+	bd := &types.BlockData{
+		Hash:   header.Hash(),
+		Header: header,
+		Body:   &body,
+	}
+	errTest := errors.New("test error")
+
+	blockState := NewMockBlockState(ctrl)
+	blockState.EXPECT().HasHeader(bd.Hash).Return(false, nil)
+	babeVerifier := NewMockBabeVerifier(ctrl)
+	babeVerifier.EXPECT().VerifyBlock(header).Return(errTest)
+	misbehaviourRecorder := NewMockMisbehaviourRecorder(ctrl)
+	misbehaviourRecorder.EXPECT().RecordBlockFailure(header, gomock.Any()).
+		Do(func(_ *types.Header, err error) {
+			assert.ErrorIs(t, err, errTest)
+		})
+
+	importer := &blockImporter{
+		blockState:           blockState,
+		babeVerifier:         babeVerifier,
+		misbehaviourRecorder: misbehaviourRecorder,
+		tracer:               noop.NewTracerProvider().Tracer(tracerName),
+	}
+
+	imported, err := importer.importBlock(bd, networkBroadcast)
+	assert.ErrorIs(t, err, errTest)
+	assert.False(t, imported)
+}
+
+func Test_blockImporter_importBlock_headerVerification(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		origin            BlockOrigin
+		withBatchVerifier bool
+		verified          bool
+	}{
+		"initial_sync_without_batch_verifier": {
+			origin:   networkInitialSync,
+			verified: true,
+		},
+		"initial_sync_batch_verified": {
+			origin:            networkInitialSync,
+			withBatchVerifier: true,
+		},
+		"broadcast": {
+			origin:            networkBroadcast,
+			withBatchVerifier: true,
+			verified:          true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			header := types.NewHeader(common.Hash{1}, common.Hash{}, common.Hash{}, 1, nil)
+			bd := &types.BlockData{
+				Hash:   header.Hash(),
+				Header: header,
+			}
+
+			blockState := NewMockBlockState(ctrl)
+			blockState.EXPECT().HasHeader(bd.Hash).Return(false, nil)
+			blockState.EXPECT().CompareAndSetBlockData(bd).Return(nil)
+			babeVerifier := NewMockBabeVerifier(ctrl)
+			if testCase.verified {
+				babeVerifier.EXPECT().VerifyBlock(header).Return(nil)
+			}
+			headerImportHandler := NewMockHeaderImportHandler(ctrl)
+			headerImportHandler.EXPECT().HandleHeaderImport(header).Return(nil)
+			telemetry := NewMockTelemetry(ctrl)
+			telemetry.EXPECT().SendMessage(gomock.Any())
+
+			importer := &blockImporter{
+				blockState:          blockState,
+				babeVerifier:        babeVerifier,
+				headerImportHandler: headerImportHandler,
+				telemetry:           telemetry,
+				tracer:              noop.NewTracerProvider().Tracer(tracerName),
+			}
+			if testCase.withBatchVerifier {
+				importer.batchVerifier = NewMockBatchVerifier(ctrl)
+			}
+
+			imported, err := importer.importBlock(bd, testCase.origin)
+			require.NoError(t, err)
+			assert.True(t, imported)
+		})
+	}
+}
@@ -42,10 +42,20 @@ type FullSyncConfig struct {
 	BadBlocks          []string
 	NumOfTasks         int
 	RequestMaker       network.RequestMaker
+	// Light is set if the node is a light node, in which case only the block headers
+	// and justifications are requested, and the headers are imported without executing
+	// the blocks using the HeaderImportHandler.
+	Light               bool
+	HeaderImportHandler HeaderImportHandler
+	// MemoryBudget is the maximum size in bytes of the blocks buffered between their
+	// download, verification and execution, defaulting to DefaultMemoryBudget if zero.
+	MemoryBudget uint64
+	// MisbehaviourRecorder records the blocks failing verification, if set
+	MisbehaviourRecorder MisbehaviourRecorder
 }
 
 type importer interface {
-	importBlock(*types.BlockData, BlockOrigin) (imported bool, err error)
+	importBlocks([]*types.BlockData) (imported int, err error)
 }
 
 // FullSyncStrategy protocol is the "default" protocol.
@@ -62,6 +72,15 @@ type FullSyncStrategy struct {
 	startedAt     time.Time
 	syncedBlocks  int
 	blockImporter importer
+	// requestedData is the data requested for the blocks to import
+	requestedData byte
+	// memoryBudget is the maximum size in bytes of the blocks buffered by the strategy,
+	// no more blocks being requested than the budget left can hold
+	memoryBudget uint64
+	// blockSize is the moving average of the estimated size of the blocks downloaded
+	blockSize uint64
+
+	justificationRequester *justificationRequester
 }
 
 func NewFullSyncStrategy(cfg *FullSyncConfig) *FullSyncStrategy {
@@ -69,13 +88,26 @@ func NewFullSyncStrategy(cfg *FullSyncConfig) *FullSyncStrategy {
 		cfg.NumOfTasks = defaultNumOfTasks
 	}
 
+	if cfg.MemoryBudget == 0 {
+		cfg.MemoryBudget = DefaultMemoryBudget
+	}
+
+	requestedData := messages.BootstrapRequestData
+	if cfg.Light {
+		requestedData = messages.RequestedDataHeader + messages.RequestedDataJustification
+	}
+
 	return &FullSyncStrategy{
-		badBlocks:     cfg.BadBlocks,
-		reqMaker:      cfg.RequestMaker,
-		blockState:    cfg.BlockState,
-		numOfTasks:    cfg.NumOfTasks,
-		blockImporter: newBlockImporter(cfg),
-		unreadyBlocks: newUnreadyBlocks(),
+		badBlocks:              cfg.BadBlocks,
+		reqMaker:               cfg.RequestMaker,
+		blockState:             cfg.BlockState,
+		numOfTasks:             cfg.NumOfTasks,
+		blockImporter:          newBlockImporter(cfg),
+		requestedData:          requestedData,
+		memoryBudget:           cfg.MemoryBudget,
+		blockSize:              blockDataOverhead,
+		justificationRequester: newJustificationRequester(cfg),
+		unreadyBlocks:          newUnreadyBlocks(),
 		requestQueue: &requestsQueue[*messages.BlockRequestMessage]{
 			queue: list.New(),
 		},
@@ -107,6 +139,15 @@ func (f *FullSyncStrategy) NextActions() ([]*SyncTask, error) {
 		return nil, fmt.Errorf("while getting best block header")
 	}
 
+	justificationRequest, err := f.justificationRequester.nextRequest(bestBlockHeader)
+	if err != nil {
+		return nil, fmt.Errorf("creating justification request: %w", err)
+	}
+
+	if justificationRequest != nil {
+		reqsFromQueue = append(reqsFromQueue, justificationRequest)
+	}
+
 	// our best block is equal or ahead of current target.
 	// in the node's pov we are not legging behind so there's nothing to do
 	// or we didn't receive block announces, so lets ask for more blocks
@@ -114,8 +155,18 @@ func (f *FullSyncStrategy) NextActions() ([]*SyncTask, error) {
 		return f.createTasks(reqsFromQueue), nil
 	}
 
+	// apply backpressure on the download of the blocks if the blocks waiting for their
+	// missing ancestors use most of the memory budget
+	limit := f.downloadLimit()
+	if limit == 0 {
+		logger.Debugf("memory budget of %d bytes used by the unready blocks, not requesting more blocks",
+			f.memoryBudget)
+		return f.createTasks(reqsFromQueue), nil
+	}
+
 	startRequestAt := bestBlockHeader.Number + 1
 	targetBlockNumber := startRequestAt + uint(f.numOfTasks)*127 //nolint:gosec
+	targetBlockNumber = min(targetBlockNumber, startRequestAt+limit-1)
 
 	if targetBlockNumber > uint(currentTarget) {
 		targetBlockNumber = uint(currentTarget)
@@ -123,12 +174,32 @@ func (f *FullSyncStrategy) NextActions() ([]*SyncTask, error) {
 
 	ascendingBlockRequests := messages.NewAscendingBlockRequests(
 		startRequestAt, targetBlockNumber,
-		messages.BootstrapRequestData)
+		f.requestedData)
 	reqsFromQueue = append(reqsFromQueue, ascendingBlockRequests...)
 
 	return f.createTasks(reqsFromQueue), nil
 }
 
+// downloadLimit returns the number of blocks which can be requested without exceeding the
+// memory budget, given the size of the unready blocks and the average size of the blocks.
+func (f *FullSyncStrategy) downloadLimit() uint {
+	blocks, size := f.unreadyBlocks.size()
+	setQueueMetrics(stageUnready, blocks, size)
+	if size >= f.memoryBudget {
+		return 0
+	}
+	return uint((f.memoryBudget - size) / max(f.blockSize, blockDataOverhead)) //nolint:gosec
+}
+
+// updateBlockSize updates the moving average of the size of the blocks downloaded.
+func (f *FullSyncStrategy) updateBlockSize(bds []*types.BlockData) {
+	if len(bds) == 0 {
+		return
+	}
+	size := blocksSize(bds...) / uint64(len(bds))
+	f.blockSize = (3*f.blockSize + size) / 4
+}
+
 func (f *FullSyncStrategy) createTasks(requests []*messages.BlockRequestMessage) []*SyncTask {
 	tasks := make([]*SyncTask, 0, len(requests))
 	for _, req := range requests {
@@ -148,6 +219,7 @@ func (f *FullSyncStrategy) createTasks(requests []*messages.BlockRequestMessage)
 // peers to block/ban, or an error. FullSyncStrategy is intended to run as long as the node lives.
 func (f *FullSyncStrategy) Process(results []*SyncTaskResult) (
 	isFinished bool, reputations []Change, bans []peer.ID, err error) {
+	justificationRequests := f.justificationRequester.takeRequests(results)
 	repChanges, peersToIgnore, validResp := validateResults(results, f.badBlocks)
 	logger.Debugf("evaluating %d task results, %d valid responses", len(results), len(validResp))
 
@@ -159,6 +231,25 @@ func (f *FullSyncStrategy) Process(results []*SyncTaskResult) (
 
 	readyBlocks := make([][]*types.BlockData, 0, len(validResp))
 	for _, reqRespData := range validResp {
+
+		// justification requests only concern blocks we already have
+		if _, ok := justificationRequests[reqRespData.req]; ok {
+			err := f.justificationRequester.applyJustifications(reqRespData.responseData)
+			if err != nil {
+				logger.Warnf("applying justifications from %s: %s", reqRespData.who, err)
+				repChanges = append(repChanges, Change{
+					who: reqRespData.who,
+					rep: peerset.ReputationChange{
+						Value:  peerset.BadJustificationValue,
+						Reason: peerset.BadJustificationReason,
+					},
+				})
+			}
+			continue
+		}
+
+		f.updateBlockSize(reqRespData.responseData)
+
 		// if Gossamer requested the header, then the response data should contains
 		// the full blocks to be imported. If Gossamer didn't request the header,
 		// then the response should only contain the missing parts that will complete
@@ -205,15 +296,10 @@ func (f *FullSyncStrategy) Process(results []*SyncTaskResult) (
 
 	// this loop goal is to import ready blocks as well as update the highestFinalized header
 	for len(nextBlocksToImport) > 0 || len(disjointFragments) > 0 {
-		for _, blockToImport := range nextBlocksToImport {
-			imported, err := f.blockImporter.importBlock(blockToImport, networkInitialSync)
-			if err != nil {
-				return false, nil, nil, fmt.Errorf("while handling ready block: %w", err)
-			}
-
-			if imported {
-				f.syncedBlocks += 1
-			}
+		imported, err := f.blockImporter.importBlocks(nextBlocksToImport)
+		f.syncedBlocks += imported
+		if err != nil {
+			return false, nil, nil, err
 		}
 
 		nextBlocksToImport = make([]*types.BlockData, 0)
@@ -253,7 +339,7 @@ func (f *FullSyncStrategy) Process(results []*SyncTaskResult) (
 				request := messages.NewBlockRequest(
 					*messages.NewFromBlock(validFragment[0].Header.ParentHash),
 					messages.MaxBlocksInResponse,
-					messages.BootstrapRequestData, messages.Descending)
+					f.requestedData, messages.Descending)
 				f.requestQueue.PushBack(request)
 			} else {
 				// inserting them in the queue to be processed after the main chain
@@ -271,12 +357,17 @@ func (f *FullSyncStrategy) Process(results []*SyncTaskResult) (
 func (f *FullSyncStrategy) ShowMetrics() {
 	totalSyncAndImportSeconds := time.Since(f.startedAt).Seconds()
 	bps := float64(f.syncedBlocks) / totalSyncAndImportSeconds
-	logger.Infof("⛓️ synced %d blocks, tasks on queue %d, disjoint fragments %d, incomplete blocks %d, "+
+	logger.Debugf("⛓️ synced %d blocks, tasks on queue %d, disjoint fragments %d, incomplete blocks %d, "+
 		"took: %.2f seconds, bps: %.2f blocks/second, target block number #%d",
 		f.syncedBlocks, f.requestQueue.Len(), len(f.unreadyBlocks.disjointFragments), len(f.unreadyBlocks.incompleteBlocks),
 		totalSyncAndImportSeconds, bps, f.peers.getTarget())
 }
 
+// Target returns the highest block number announced by our peers
+func (f *FullSyncStrategy) Target() uint {
+	return uint(f.peers.getTarget())
+}
+
 func (f *FullSyncStrategy) OnBlockAnnounceHandshake(from peer.ID, msg *network.BlockAnnounceHandshake) error {
 	f.peers.update(from, msg.BestBlockHash, msg.BestBlockNumber)
 	return nil
@@ -355,7 +446,15 @@ func (f *FullSyncStrategy) OnBlockAnnounce(from peer.ID, msg *network.BlockAnnou
 		}
 	}
 
-	if !has {
+	if !has && f.requestedData&messages.RequestedDataBody == 0 {
+		// the light node does not need the body of the announced block,
+		// only its header and justification
+		logger.Infof("requesting announced block header #%d (%s)",
+			blockAnnounceHeader.Number, blockAnnounceHeaderHash.Short())
+		request := messages.NewBlockRequest(*messages.NewFromBlock(blockAnnounceHeaderHash),
+			1, f.requestedData, messages.Ascending)
+		f.requestQueue.PushBack(request)
+	} else if !has {
 		f.unreadyBlocks.newIncompleteBlock(blockAnnounceHeader)
 		logger.Infof("requesting announced block body #%d (%s)", blockAnnounceHeader.Number, blockAnnounceHeaderHash.Short())
 		request := messages.NewBlockRequest(*messages.NewFromBlock(blockAnnounceHeaderHash),
@@ -391,6 +490,7 @@ func (f *FullSyncStrategy) IsSynced() bool {
 }
 
 type RequestResponseData struct {
+	who          peer.ID
 	req          *messages.BlockRequestMessage
 	responseData []*types.BlockData
 }
@@ -468,6 +568,7 @@ func validateResults(results []*SyncTaskResult, badBlocks []string) (repChanges
 		}
 
 		validRes = append(validRes, RequestResponseData{
+			who:          result.who,
 			req:          request,
 			responseData: response.BlockData,
 		})
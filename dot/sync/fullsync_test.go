@@ -75,6 +75,41 @@ func TestFullSyncNextActions(t *testing.T) {
 		require.Equal(t, uint32(128), *request.Max)
 	})
 
+	t.Run("memory_budget_used_by_unready_blocks", func(t *testing.T) {
+		mockBlockState := NewMockBlockState(gomock.NewController(t))
+		mockBlockState.EXPECT().BestBlockHeader().Return(
+			types.NewEmptyHeader(), nil).Times(2)
+
+		fs := NewFullSyncStrategy(&FullSyncConfig{
+			BlockState:   mockBlockState,
+			MemoryBudget: 4 * blockDataOverhead,
+		})
+		err := fs.OnBlockAnnounceHandshake(peer.ID("peer-A"), &network.BlockAnnounceHandshake{
+			Roles:           1,
+			BestBlockNumber: 1024,
+			BestBlockHash:   common.BytesToHash([]byte{0x01, 0x02}),
+			GenesisHash:     common.BytesToHash([]byte{0x00, 0x01}),
+		})
+		require.NoError(t, err)
+
+		// only one more block fits in the memory budget
+		fs.unreadyBlocks.newDisjointFragment([]*types.BlockData{{}, {}, {}})
+
+		task, err := fs.NextActions()
+		require.NoError(t, err)
+		require.Len(t, task, 1)
+		request := task[0].request.(*messages.BlockRequestMessage)
+		require.Equal(t, uint(1), request.StartingBlock.RawValue())
+		require.Equal(t, uint32(1), *request.Max)
+
+		// no block is requested once the memory budget is used
+		fs.unreadyBlocks.newDisjointFragment([]*types.BlockData{{}})
+
+		task, err = fs.NextActions()
+		require.NoError(t, err)
+		require.Empty(t, task)
+	})
+
 	t.Run("having_requests_in_the_queue", func(t *testing.T) {
 		refTo := func(v uint32) *uint32 {
 			return &v
@@ -234,11 +269,15 @@ func TestFullSyncProcess(t *testing.T) {
 			Return(false, nil).
 			Times(2)
 
+		importedBlocks := 0
 		mockImporter := NewMockimporter(ctrl)
 		mockImporter.EXPECT().
-			importBlock(gomock.AssignableToTypeOf(&types.BlockData{}), networkInitialSync).
-			Return(true, nil).
-			Times(10 + 128 + 128)
+			importBlocks(gomock.AssignableToTypeOf([]*types.BlockData{})).
+			DoAndReturn(func(bds []*types.BlockData) (int, error) {
+				importedBlocks += len(bds)
+				return len(bds), nil
+			}).
+			AnyTimes()
 
 		cfg := &FullSyncConfig{
 			BlockState: mockBlockState,
@@ -289,6 +328,81 @@ func TestFullSyncProcess(t *testing.T) {
 		require.Equal(t, fs.requestQueue.Len(), 0)
 		require.Len(t, fs.unreadyBlocks.incompleteBlocks, 0)
 		require.Len(t, fs.unreadyBlocks.disjointFragments, 0)
+		require.Equal(t, 10+128+128, importedBlocks)
+	})
+
+	t.Run("light_sync_imports_headers", func(t *testing.T) {
+		// the light sync requests the same data as the justification requests
+		lightRequest := messages.NewBlockRequest(*messages.NewFromBlock(uint(1)), 127,
+			messages.RequestedDataHeader+messages.RequestedDataJustification, messages.Ascending)
+		justificationRequest := messages.NewBlockRequest(*messages.NewFromBlock(uint(1)), 127,
+			messages.RequestedDataHeader+messages.RequestedDataJustification, messages.Ascending)
+
+		headers := make([]*types.BlockData, len(fstTaskBlockResponse.BlockData))
+		for i, bd := range fstTaskBlockResponse.BlockData {
+			headers[i] = &types.BlockData{Hash: bd.Hash, Header: bd.Header}
+		}
+
+		syncTaskResults := []*SyncTaskResult{
+			{
+				who:       peer.ID("peerA"),
+				request:   lightRequest,
+				completed: true,
+				response:  &messages.BlockResponseMessage{BlockData: headers},
+			},
+			{
+				who:       peer.ID("peerB"),
+				request:   justificationRequest,
+				completed: true,
+				response:  &messages.BlockResponseMessage{},
+			},
+		}
+
+		genesisHeader := types.NewHeader(headers[0].Header.ParentHash,
+			common.Hash{}, common.Hash{}, 0, types.NewDigest())
+
+		ctrl := gomock.NewController(t)
+		mockBlockState := NewMockBlockState(ctrl)
+		mockBlockState.EXPECT().GetHighestFinalisedHeader().
+			Return(genesisHeader, nil).
+			Times(2)
+		mockBlockState.EXPECT().HasHeader(headers[0].Header.ParentHash).Return(true, nil)
+
+		mockBabeVerifier := NewMockBabeVerifier(ctrl)
+		mockHeaderImportHandler := NewMockHeaderImportHandler(ctrl)
+		mockTelemetry := NewMockTelemetry(ctrl)
+
+		var storedHeaders []*types.Header
+		for _, bd := range headers {
+			mockBlockState.EXPECT().HasHeader(bd.Hash).Return(false, nil)
+			mockBabeVerifier.EXPECT().VerifyBlock(bd.Header).Return(nil)
+			mockHeaderImportHandler.EXPECT().HandleHeaderImport(bd.Header).Return(nil)
+			mockTelemetry.EXPECT().SendMessage(gomock.Any())
+			mockBlockState.EXPECT().CompareAndSetBlockData(gomock.Any()).
+				DoAndReturn(func(bd *types.BlockData) error {
+					storedHeaders = append(storedHeaders, bd.Header)
+					return nil
+				})
+		}
+
+		fs := NewFullSyncStrategy(&FullSyncConfig{
+			BlockState:          mockBlockState,
+			BabeVerifier:        mockBabeVerifier,
+			Telemetry:           mockTelemetry,
+			Light:               true,
+			HeaderImportHandler: mockHeaderImportHandler,
+		})
+		fs.justificationRequester.requests[justificationRequest] = struct{}{}
+
+		done, _, _, err := fs.Process(syncTaskResults)
+		require.NoError(t, err)
+		require.False(t, done)
+
+		require.Len(t, storedHeaders, len(headers))
+		for i, bd := range headers {
+			require.Equal(t, bd.Header, storedHeaders[i])
+		}
+		require.Empty(t, fs.justificationRequester.requests)
 	})
 }
 
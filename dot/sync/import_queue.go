@@ -0,0 +1,166 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"sync"
+
+	"github.com/ChainSafe/gossamer/dot/network/messages"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// DefaultMemoryBudget is the default memory budget in bytes of the blocks
+// buffered between the download, verification and execution of the blocks.
+const DefaultMemoryBudget = 256 * 1024 * 1024
+
+const (
+	// blockDataOverhead is the estimated memory used by a block data besides
+	// its downloaded parts, for its decoded digest and hashes
+	blockDataOverhead = 512
+
+	// maxQueuedBlocks is the maximum number of verified blocks waiting to be executed
+	maxQueuedBlocks = messages.MaxBlocksInResponse
+)
+
+// the stages of the sync, the queue metrics being labelled by the stage consuming the blocks
+const (
+	// stageUnready is the stage of the blocks waiting for their missing ancestors or bodies
+	stageUnready      = "unready"
+	stageVerification = "verification"
+	stageExecution    = "execution"
+)
+
+var (
+	queueDepthGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "gossamer_sync",
+		Name:      "queue_depth_blocks",
+		Help:      "number of blocks buffered by the sync, by stage waiting to process them",
+	}, []string{"stage"})
+
+	queueSizeGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "gossamer_sync",
+		Name:      "queue_size_bytes",
+		Help:      "estimated size in bytes of the blocks buffered by the sync, by stage waiting to process them",
+	}, []string{"stage"})
+)
+
+// blocksSize returns the estimated memory used by the blocks data, their downloaded parts and
+// the overhead of each block data.
+func blocksSize(bds ...*types.BlockData) (size uint64) {
+	for _, bd := range bds {
+		size += blockDataOverhead + blockDataSize(bd)
+	}
+	return size
+}
+
+// setQueueMetrics sets the number and size of the blocks waiting for the stage given.
+func setQueueMetrics(stage string, blocks int, size uint64) {
+	queueDepthGauge.WithLabelValues(stage).Set(float64(blocks))
+	queueSizeGauge.WithLabelValues(stage).Set(float64(size))
+}
+
+// importQueue is the queue of the verified blocks waiting to be executed, bounded by
+// a number of blocks and a memory budget. Pushing a block to a full queue waits for
+// blocks to be executed, applying backpressure on the verification of the blocks.
+type importQueue struct {
+	mutex   sync.Mutex
+	changed *sync.Cond
+	blocks  []*types.BlockData
+	// pending is the number of blocks pushed and not released yet, the blocks
+	// being executed counting towards the limits until they are released
+	pending   int
+	size      uint64
+	maxBlocks int
+	maxSize   uint64
+	closed    bool
+}
+
+func newImportQueue(maxBlocks int, maxSize uint64) *importQueue {
+	queue := &importQueue{
+		maxBlocks: maxBlocks,
+		maxSize:   maxSize,
+	}
+	queue.changed = sync.NewCond(&queue.mutex)
+	return queue
+}
+
+// push appends the block to the queue, waiting for the queue to have room for it.
+// A block exceeding the limits on its own is accepted once the queue is empty.
+// It returns false if the queue is closed.
+func (q *importQueue) push(bd *types.BlockData) (ok bool) {
+	size := blocksSize(bd)
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for !q.closed && q.pending > 0 &&
+		(q.pending >= q.maxBlocks || q.size+size > q.maxSize) {
+		q.changed.Wait()
+	}
+	if q.closed {
+		return false
+	}
+
+	q.blocks = append(q.blocks, bd)
+	q.pending++
+	q.size += size
+	setQueueMetrics(stageExecution, len(q.blocks), q.size)
+	q.changed.Broadcast()
+	return true
+}
+
+// pop removes the first block of the queue, waiting for a block to be pushed.
+// It returns false once the queue is closed and empty.
+func (q *importQueue) pop() (bd *types.BlockData, ok bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for !q.closed && len(q.blocks) == 0 {
+		q.changed.Wait()
+	}
+	if len(q.blocks) == 0 {
+		return nil, false
+	}
+
+	bd = q.blocks[0]
+	q.blocks[0] = nil
+	q.blocks = q.blocks[1:]
+	setQueueMetrics(stageExecution, len(q.blocks), q.size)
+	return bd, true
+}
+
+// release releases the room of a block popped from the queue, once it is executed.
+func (q *importQueue) release(bd *types.BlockData) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.pending--
+	q.size -= blocksSize(bd)
+	setQueueMetrics(stageExecution, len(q.blocks), q.size)
+	q.changed.Broadcast()
+}
+
+// waitReleased waits for all the blocks pushed to be released, and returns
+// false if the queue is closed first.
+func (q *importQueue) waitReleased() (ok bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for !q.closed && q.pending > 0 {
+		q.changed.Wait()
+	}
+	return !q.closed
+}
+
+// close closes the queue, stopping the pushes, the blocks left in the queue
+// still being popped. It can be called more than once.
+func (q *importQueue) close() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.closed = true
+	q.changed.Broadcast()
+}
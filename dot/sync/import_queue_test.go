@@ -0,0 +1,133 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_blocksSize(t *testing.T) {
+	t.Parallel()
+
+	justification := []byte{1, 2}
+	bd := &types.BlockData{
+		Body:          types.NewBody([]types.Extrinsic{{1, 2, 3}, {4}}),
+		Justification: &justification,
+	}
+
+	assert.Equal(t, uint64(blockDataOverhead+6), blocksSize(bd))
+	assert.Equal(t, uint64(blockDataOverhead), blocksSize(&types.BlockData{}))
+	assert.Equal(t, uint64(2*blockDataOverhead+6), blocksSize(bd, &types.BlockData{}))
+}
+
+func Test_importQueue_backpressure(t *testing.T) {
+	t.Parallel()
+
+	queue := newImportQueue(2, 10*blockDataOverhead)
+	first, second, third := &types.BlockData{}, &types.BlockData{}, &types.BlockData{}
+
+	require.True(t, queue.push(first))
+	require.True(t, queue.push(second))
+
+	pushed := make(chan bool)
+	go func() {
+		pushed <- queue.push(third)
+	}()
+
+	select {
+	case <-pushed:
+		t.Fatal("block pushed to a full queue")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	// popping a block does not make room for another until it is released
+	bd, ok := queue.pop()
+	require.True(t, ok)
+	assert.Same(t, first, bd)
+
+	select {
+	case <-pushed:
+		t.Fatal("block pushed before a block is released")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	queue.release(bd)
+	assert.True(t, <-pushed)
+
+	queue.close()
+	assert.False(t, queue.push(&types.BlockData{}))
+
+	// the blocks left are popped once the queue is closed
+	bd, ok = queue.pop()
+	require.True(t, ok)
+	assert.Same(t, second, bd)
+	bd, ok = queue.pop()
+	require.True(t, ok)
+	assert.Same(t, third, bd)
+	_, ok = queue.pop()
+	assert.False(t, ok)
+}
+
+func Test_importQueue_memoryBudget(t *testing.T) {
+	t.Parallel()
+
+	body := types.NewBody([]types.Extrinsic{make([]byte, 1000)})
+	large := &types.BlockData{Body: body}
+	queue := newImportQueue(maxQueuedBlocks, 1000)
+
+	// a block larger than the budget is accepted in an empty queue
+	require.True(t, queue.push(large))
+
+	pushed := make(chan bool)
+	go func() {
+		pushed <- queue.push(&types.BlockData{})
+	}()
+
+	select {
+	case <-pushed:
+		t.Fatal("block pushed over the memory budget")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	bd, ok := queue.pop()
+	require.True(t, ok)
+	queue.release(bd)
+	assert.True(t, <-pushed)
+}
+
+func Test_importQueue_waitReleased(t *testing.T) {
+	t.Parallel()
+
+	queue := newImportQueue(maxQueuedBlocks, DefaultMemoryBudget)
+	require.True(t, queue.push(&types.BlockData{}))
+
+	released := make(chan bool)
+	go func() {
+		released <- queue.waitReleased()
+	}()
+
+	bd, ok := queue.pop()
+	require.True(t, ok)
+
+	select {
+	case <-released:
+		t.Fatal("popped block released before its execution")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	queue.release(bd)
+	assert.True(t, <-released)
+
+	require.True(t, queue.push(&types.BlockData{}))
+	go func() {
+		released <- queue.waitReleased()
+	}()
+	queue.close()
+	assert.False(t, <-released)
+}
@@ -0,0 +1,163 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/network/messages"
+	"github.com/ChainSafe/gossamer/dot/telemetry"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// justificationRequestLag is the number of blocks the best block should be
+	// ahead of the highest finalised block before we consider finality stalled
+	// and request justifications from our peers
+	justificationRequestLag = messages.MaxBlocksInResponse
+
+	// justificationRequestInterval is the minimum amount of time between two
+	// justification requests, giving time to the previous request to be answered
+	justificationRequestInterval = 30 * time.Second
+
+	// justificationRequestData is the data requested by a justification request, the
+	// header being needed to check the response forms a chain from our finalised block
+	justificationRequestData = messages.RequestedDataHeader + messages.RequestedDataJustification
+)
+
+var justificationsAppliedCounter = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: "gossamer_sync",
+	Name:      "justifications_applied_total",
+	Help:      "number of justifications requested to peers and applied because of a finality lag",
+})
+
+// justificationRequester requests the justifications of the blocks following
+// our highest finalised block when the finality is lagging behind the best block,
+// which happens when we missed the GRANDPA commit messages for those blocks
+type justificationRequester struct {
+	blockState     BlockState
+	finalityGadget FinalityGadget
+	telemetry      Telemetry
+	lastRequestAt  time.Time
+	// requests are the justification requests submitted and not processed yet, the
+	// light sync requesting the same data for the blocks to import
+	requests map[*messages.BlockRequestMessage]struct{}
+}
+
+func newJustificationRequester(cfg *FullSyncConfig) *justificationRequester {
+	return &justificationRequester{
+		blockState:     cfg.BlockState,
+		finalityGadget: cfg.FinalityGadget,
+		telemetry:      cfg.Telemetry,
+		requests:       make(map[*messages.BlockRequestMessage]struct{}),
+	}
+}
+
+// nextRequest returns a block request for the justifications of the blocks following
+// the highest finalised block, or nil if the finality is not lagging behind the best block
+// or a justification request was recently made.
+func (j *justificationRequester) nextRequest(bestBlockHeader *types.Header) (
+	*messages.BlockRequestMessage, error) {
+	if bestBlockHeader.Number < justificationRequestLag ||
+		time.Since(j.lastRequestAt) < justificationRequestInterval {
+		return nil, nil //nolint:nilnil
+	}
+
+	highestFinalized, err := j.blockState.GetHighestFinalisedHeader()
+	if err != nil {
+		return nil, fmt.Errorf("getting highest finalised header: %w", err)
+	}
+
+	if highestFinalized.Number+justificationRequestLag > bestBlockHeader.Number {
+		return nil, nil //nolint:nilnil
+	}
+
+	startHash, err := j.blockState.GetHashByNumber(highestFinalized.Number + 1)
+	if err != nil {
+		return nil, fmt.Errorf("getting hash of block #%d: %w", highestFinalized.Number+1, err)
+	}
+
+	logger.Infof("finality is lagging %d blocks behind best block #%d, requesting justifications from #%d (%s)",
+		bestBlockHeader.Number-highestFinalized.Number, bestBlockHeader.Number,
+		highestFinalized.Number+1, startHash.Short())
+
+	j.lastRequestAt = time.Now()
+	request := messages.NewBlockRequest(*messages.NewFromBlock(startHash),
+		messages.MaxBlocksInResponse, justificationRequestData, messages.Ascending)
+	j.requests[request] = struct{}{}
+	return request, nil
+}
+
+// takeRequests returns the requests of the results given which were created by the
+// justificationRequester, and forgets them since each submitted request has one result.
+func (j *justificationRequester) takeRequests(results []*SyncTaskResult) map[*messages.BlockRequestMessage]struct{} {
+	requests := make(map[*messages.BlockRequestMessage]struct{})
+	for _, result := range results {
+		request, ok := result.request.(*messages.BlockRequestMessage)
+		if !ok {
+			continue
+		}
+
+		if _, ok := j.requests[request]; ok {
+			delete(j.requests, request)
+			requests[request] = struct{}{}
+		}
+	}
+	return requests
+}
+
+// applyJustifications verifies and applies the highest justification found in the
+// response, finalising every block up to it. It returns an error if a justification
+// is invalid, meaning the peer should be punished.
+func (j *justificationRequester) applyJustifications(blocks []*types.BlockData) error {
+	for idx := len(blocks) - 1; idx >= 0; idx-- {
+		blockData := blocks[idx]
+		if blockData.Header == nil || blockData.Justification == nil || len(*blockData.Justification) == 0 {
+			continue
+		}
+
+		has, err := j.blockState.HasHeader(blockData.Hash)
+		if err != nil && !errors.Is(err, database.ErrNotFound) {
+			return fmt.Errorf("checking if block #%d (%s) is known: %w",
+				blockData.Header.Number, blockData.Hash, err)
+		}
+
+		// we cannot finalise a block we have not imported yet
+		if !has {
+			continue
+		}
+
+		round, setID, err := j.finalityGadget.VerifyBlockJustification(
+			blockData.Hash, blockData.Header.Number, *blockData.Justification)
+		if err != nil {
+			return fmt.Errorf("verifying justification of block #%d (%s): %w",
+				blockData.Header.Number, blockData.Hash, err)
+		}
+
+		err = j.blockState.SetFinalisedHash(blockData.Hash, round, setID)
+		if err != nil {
+			return fmt.Errorf("setting finalised hash: %w", err)
+		}
+
+		err = j.blockState.SetJustification(blockData.Hash, *blockData.Justification)
+		if err != nil {
+			return fmt.Errorf("setting justification for block number %d: %w", blockData.Header.Number, err)
+		}
+
+		logger.Infof("finalised block #%d (%s) using a requested justification",
+			blockData.Header.Number, blockData.Hash.Short())
+
+		justificationsAppliedCounter.Inc()
+		j.telemetry.SendMessage(telemetry.NewAfgFinalizedBlocksUpTo(
+			blockData.Hash, fmt.Sprint(blockData.Header.Number)))
+		return nil
+	}
+
+	return nil
+}
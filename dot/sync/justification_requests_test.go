@@ -0,0 +1,163 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/network/messages"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_justificationRequester_nextRequest(t *testing.T) {
+	t.Parallel()
+
+	startHash := common.Hash{1}
+
+	testCases := map[string]struct {
+		bestNumber      uint
+		finalisedNumber uint
+		lastRequestAt   time.Time
+		expectFinalised bool
+		expectStartHash bool
+		expectedRequest *messages.BlockRequestMessage
+	}{
+		"best_block_below_lag": {
+			bestNumber: justificationRequestLag - 1,
+		},
+		"recent_request": {
+			bestNumber:    2 * justificationRequestLag,
+			lastRequestAt: time.Now(),
+		},
+		"finality_not_lagging": {
+			bestNumber:      2 * justificationRequestLag,
+			finalisedNumber: justificationRequestLag + 1,
+			expectFinalised: true,
+		},
+		"finality_lagging": {
+			bestNumber:      2 * justificationRequestLag,
+			finalisedNumber: 10,
+			lastRequestAt:   time.Now().Add(-justificationRequestInterval),
+			expectFinalised: true,
+			expectStartHash: true,
+			expectedRequest: messages.NewBlockRequest(*messages.NewFromBlock(startHash),
+				messages.MaxBlocksInResponse, justificationRequestData, messages.Ascending),
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			blockState := NewMockBlockState(ctrl)
+			if testCase.expectFinalised {
+				blockState.EXPECT().GetHighestFinalisedHeader().
+					Return(&types.Header{Number: testCase.finalisedNumber}, nil)
+			}
+			if testCase.expectStartHash {
+				blockState.EXPECT().GetHashByNumber(testCase.finalisedNumber+1).Return(startHash, nil)
+			}
+
+			requester := newJustificationRequester(&FullSyncConfig{BlockState: blockState})
+			requester.lastRequestAt = testCase.lastRequestAt
+
+			request, err := requester.nextRequest(&types.Header{Number: testCase.bestNumber})
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedRequest, request)
+			if request != nil {
+				assert.Contains(t, requester.requests, request)
+			}
+		})
+	}
+}
+
+func Test_justificationRequester_applyJustifications(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+	justification := []byte{1, 2, 3}
+
+	newBlockData := func(number uint, withJustification bool) *types.BlockData {
+		header := &types.Header{Number: number}
+		blockData := &types.BlockData{Hash: header.Hash(), Header: header}
+		if withJustification {
+			blockData.Justification = &justification
+		}
+		return blockData
+	}
+
+	blocks := []*types.BlockData{
+		newBlockData(1, true),
+		newBlockData(2, true),
+		newBlockData(3, false),
+	}
+
+	testCases := map[string]struct {
+		blocks     []*types.BlockData
+		setupMocks func(*MockBlockState, *MockFinalityGadget, *MockTelemetry)
+		errWrapped error
+	}{
+		"no_justification": {
+			blocks:     []*types.BlockData{newBlockData(1, false)},
+			setupMocks: func(*MockBlockState, *MockFinalityGadget, *MockTelemetry) {},
+		},
+		"unknown_block": {
+			blocks: []*types.BlockData{newBlockData(1, true)},
+			setupMocks: func(blockState *MockBlockState, _ *MockFinalityGadget, _ *MockTelemetry) {
+				blockState.EXPECT().HasHeader(blocks[0].Hash).Return(false, nil)
+			},
+		},
+		"invalid_justification": {
+			blocks: blocks,
+			setupMocks: func(blockState *MockBlockState, finalityGadget *MockFinalityGadget, _ *MockTelemetry) {
+				blockState.EXPECT().HasHeader(blocks[1].Hash).Return(true, nil)
+				finalityGadget.EXPECT().VerifyBlockJustification(blocks[1].Hash, uint(2), justification).
+					Return(uint64(0), uint64(0), errTest)
+			},
+			errWrapped: errTest,
+		},
+		"highest_justification_applied": {
+			blocks: blocks,
+			setupMocks: func(blockState *MockBlockState, finalityGadget *MockFinalityGadget,
+				telemetry *MockTelemetry) {
+				blockState.EXPECT().HasHeader(blocks[1].Hash).Return(true, nil)
+				finalityGadget.EXPECT().VerifyBlockJustification(blocks[1].Hash, uint(2), justification).
+					Return(uint64(3), uint64(1), nil)
+				blockState.EXPECT().SetFinalisedHash(blocks[1].Hash, uint64(3), uint64(1)).Return(nil)
+				blockState.EXPECT().SetJustification(blocks[1].Hash, justification).Return(nil)
+				telemetry.EXPECT().SendMessage(gomock.Any())
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			blockState := NewMockBlockState(ctrl)
+			finalityGadget := NewMockFinalityGadget(ctrl)
+			telemetry := NewMockTelemetry(ctrl)
+			testCase.setupMocks(blockState, finalityGadget, telemetry)
+
+			requester := newJustificationRequester(&FullSyncConfig{
+				BlockState:     blockState,
+				FinalityGadget: finalityGadget,
+				Telemetry:      telemetry,
+			})
+
+			err := requester.applyJustifications(testCase.blocks)
+			require.ErrorIs(t, err, testCase.errWrapped)
+		})
+	}
+}
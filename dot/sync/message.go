@@ -5,12 +5,14 @@
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"slices"
 
 	"github.com/ChainSafe/gossamer/dot/network/messages"
 	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -27,8 +29,9 @@
 	errFailedToGetDescendant   = errors.New("failed to find descendant block")
 )
 
-// CreateBlockResponse creates a block response message from a block request message
-func (s *SyncService) CreateBlockResponse(from peer.ID, req *messages.BlockRequestMessage) (
+// CreateBlockResponse creates a block response message from a block request message,
+// giving up once the context is done
+func (s *SyncService) CreateBlockResponse(ctx context.Context, from peer.ID, req *messages.BlockRequestMessage) (
 	*messages.BlockResponseMessage, error) {
 	logger.Debugf("sync request from %s: %s", from, req.String())
 
@@ -63,15 +66,15 @@ func (s *SyncService) CreateBlockResponse(from peer.ID, req *messages.BlockReque
 
 	switch req.Direction {
 	case messages.Ascending:
-		return s.handleAscendingRequest(req)
+		return s.handleAscendingRequest(ctx, req)
 	case messages.Descending:
-		return s.handleDescendingRequest(req)
+		return s.handleDescendingRequest(ctx, req)
 	default:
 		return nil, fmt.Errorf("%w: %v", errInvalidRequestDirection, req.Direction)
 	}
 }
 
-func (s *SyncService) handleAscendingRequest(req *messages.BlockRequestMessage) (
+func (s *SyncService) handleAscendingRequest(ctx context.Context, req *messages.BlockRequestMessage) (
 	*messages.BlockResponseMessage, error) {
 	var (
 		max         uint = messages.MaxBlocksInResponse
@@ -137,7 +140,7 @@ func (s *SyncService) handleAscendingRequest(req *messages.BlockRequestMessage)
 			"end block number: %d",
 			req.Direction, startNumber, endNumber)
 
-		return s.handleAscendingByNumber(startNumber, endNumber, req.RequestedData)
+		return s.handleAscendingByNumber(ctx, startNumber, endNumber, req.RequestedData)
 	}
 
 	logger.Debugf("handling block request: direction %s, "+
@@ -145,10 +148,10 @@ func (s *SyncService) handleAscendingRequest(req *messages.BlockRequestMessage)
 		"end block hash: %s",
 		req.Direction, *startHash, *endHash)
 
-	return s.handleChainByHash(*startHash, *endHash, max, req.RequestedData, req.Direction)
+	return s.handleChainByHash(ctx, *startHash, *endHash, max, req.RequestedData, req.Direction)
 }
 
-func (s *SyncService) handleDescendingRequest(req *messages.BlockRequestMessage) (
+func (s *SyncService) handleDescendingRequest(ctx context.Context, req *messages.BlockRequestMessage) (
 	*messages.BlockResponseMessage, error) {
 	var (
 		startHash   *common.Hash
@@ -211,13 +214,13 @@ func (s *SyncService) handleDescendingRequest(req *messages.BlockRequestMessage)
 		logger.Infof("handling block request message with direction %s "+
 			"from number %d to number %d\n",
 			req.Direction.String(), startNumber, endNumber)
-		return s.handleDescendingByNumber(startNumber, endNumber, req.RequestedData)
+		return s.handleDescendingByNumber(ctx, startNumber, endNumber, req.RequestedData)
 	}
 
 	logger.Infof("handling block request message with direction %s "+
 		"from hash %s to end block with hash %s",
 		req.Direction.String(), *startHash, *endHash)
-	return s.handleChainByHash(*endHash, *startHash, max, req.RequestedData, req.Direction)
+	return s.handleChainByHash(ctx, *endHash, *startHash, max, req.RequestedData, req.Direction)
 }
 
 // checkOrGetDescendantHash checks if the provided `descendant` is
@@ -300,15 +303,22 @@ func (s *SyncService) checkOrGetDescendantHash(ancestor common.Hash,
 	return *descendant, nil
 }
 
-func (s *SyncService) handleAscendingByNumber(start, end uint,
+func (s *SyncService) handleAscendingByNumber(ctx context.Context, start, end uint,
 	requestedData byte) (*messages.BlockResponseMessage, error) {
 	var err error
 	data := make([]*types.BlockData, (end-start)+1)
 
 	for i := uint(0); start+i <= end; i++ {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
 		blockNumber := start + i
 		data[i], err = s.getBlockDataByNumber(blockNumber, requestedData)
-		if err != nil {
+		if errors.Is(err, state.ErrBlockBodyPruned) && i > 0 {
+			data = data[:i]
+			break
+		} else if err != nil {
 			return nil, err
 		}
 	}
@@ -318,7 +328,7 @@ func (s *SyncService) handleAscendingByNumber(start, end uint,
 	}, nil
 }
 
-func (s *SyncService) handleDescendingByNumber(start, end uint,
+func (s *SyncService) handleDescendingByNumber(ctx context.Context, start, end uint,
 	requestedData byte) (*messages.BlockResponseMessage, error) {
 	var err error
 
@@ -327,9 +337,16 @@ func (s *SyncService) handleDescendingByNumber(start, end uint,
 	}
 
 	for i := uint(0); start-i >= end; i++ {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
 		blockNumber := start - i
 		response.BlockData[i], err = s.getBlockDataByNumber(blockNumber, requestedData)
-		if err != nil {
+		if errors.Is(err, state.ErrBlockBodyPruned) && i > 0 {
+			response.BlockData = response.BlockData[:i]
+			break
+		} else if err != nil {
 			return nil, err
 		}
 	}
@@ -337,7 +354,7 @@ func (s *SyncService) handleDescendingByNumber(start, end uint,
 	return response, nil
 }
 
-func (s *SyncService) handleChainByHash(ancestor, descendant common.Hash,
+func (s *SyncService) handleChainByHash(ctx context.Context, ancestor, descendant common.Hash,
 	max uint, requestedData byte, direction messages.SyncDirection) (
 	*messages.BlockResponseMessage, error) {
 	subchain, err := s.blockState.Range(ancestor, descendant)
@@ -355,22 +372,29 @@ func (s *SyncService) handleChainByHash(ancestor, descendant common.Hash,
 		}
 	}
 
+	// reverse the subchain, if descending request
+	if direction == messages.Descending {
+		slices.Reverse(subchain)
+	}
+
 	response := &messages.BlockResponseMessage{
 		BlockData: make([]*types.BlockData, len(subchain)),
 	}
 
 	for i, hash := range subchain {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
 		response.BlockData[i], err = s.getBlockData(hash, requestedData)
-		if err != nil {
+		if errors.Is(err, state.ErrBlockBodyPruned) && i > 0 {
+			response.BlockData = response.BlockData[:i]
+			break
+		} else if err != nil {
 			return nil, err
 		}
 	}
 
-	// reverse BlockData, if descending request
-	if direction == messages.Descending {
-		slices.Reverse(response.BlockData)
-	}
-
 	return response, nil
 }
 
@@ -398,7 +422,10 @@ func (s *SyncService) getBlockData(hash common.Hash, requestedData byte) (*types
 
 	if (requestedData&messages.RequestedDataBody)>>1 == 1 {
 		blockData.Body, err = s.blockState.GetBlockBody(hash)
-		if err != nil {
+		if errors.Is(err, state.ErrBlockBodyPruned) {
+			// the response is limited to the blocks whose body is kept
+			return nil, err
+		} else if err != nil {
 			logger.Debugf("failed to get body for block with hash %s: %s", hash, err)
 		}
 	}
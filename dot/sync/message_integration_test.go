@@ -6,6 +6,7 @@
 package sync
 
 import (
+	"context"
 	"path/filepath"
 	"testing"
 	"time"
@@ -203,7 +204,7 @@ func TestService_CreateBlockResponse_MaxSize(t *testing.T) {
 		Max:           nil,
 	}
 
-	resp, err := s.CreateBlockResponse(peer.ID("alice"), req)
+	resp, err := s.CreateBlockResponse(context.Background(), peer.ID("alice"), req)
 	require.NoError(t, err)
 	require.Equal(t, int(messages.MaxBlocksInResponse), len(resp.BlockData))
 	require.Equal(t, uint(1), resp.BlockData[0].Number())
@@ -217,7 +218,7 @@ func TestService_CreateBlockResponse_MaxSize(t *testing.T) {
 		Max:           &max,
 	}
 
-	resp, err = s.CreateBlockResponse(peer.ID("alice"), req)
+	resp, err = s.CreateBlockResponse(context.Background(), peer.ID("alice"), req)
 	require.NoError(t, err)
 	require.Equal(t, int(messages.MaxBlocksInResponse), len(resp.BlockData))
 	require.Equal(t, uint(1), resp.BlockData[0].Number())
@@ -231,7 +232,7 @@ func TestService_CreateBlockResponse_MaxSize(t *testing.T) {
 		Max:           &max,
 	}
 
-	resp, err = s.CreateBlockResponse(peer.ID("alice"), req)
+	resp, err = s.CreateBlockResponse(context.Background(), peer.ID("alice"), req)
 	require.NoError(t, err)
 	require.Equal(t, int(max), len(resp.BlockData))
 	require.Equal(t, uint(1), resp.BlockData[0].Number())
@@ -246,7 +247,7 @@ func TestService_CreateBlockResponse_MaxSize(t *testing.T) {
 		Max:           nil,
 	}
 
-	resp, err = s.CreateBlockResponse(peer.ID("alice"), req)
+	resp, err = s.CreateBlockResponse(context.Background(), peer.ID("alice"), req)
 	require.NoError(t, err)
 	require.Equal(t, int(messages.MaxBlocksInResponse), len(resp.BlockData))
 	require.Equal(t, uint(128), resp.BlockData[0].Number())
@@ -262,7 +263,7 @@ func TestService_CreateBlockResponse_MaxSize(t *testing.T) {
 		Max:           &max,
 	}
 
-	resp, err = s.CreateBlockResponse(peer.ID("alice"), req)
+	resp, err = s.CreateBlockResponse(context.Background(), peer.ID("alice"), req)
 	require.NoError(t, err)
 	require.Equal(t, int(messages.MaxBlocksInResponse), len(resp.BlockData))
 	require.Equal(t, uint(256), resp.BlockData[0].Number())
@@ -276,7 +277,7 @@ func TestService_CreateBlockResponse_MaxSize(t *testing.T) {
 		Max:           &max,
 	}
 
-	resp, err = s.CreateBlockResponse(peer.ID("alice"), req)
+	resp, err = s.CreateBlockResponse(context.Background(), peer.ID("alice"), req)
 	require.NoError(t, err)
 	require.Equal(t, int(max), len(resp.BlockData))
 	require.Equal(t, uint(256), resp.BlockData[0].Number())
@@ -298,7 +299,7 @@ func TestService_CreateBlockResponse_StartHash(t *testing.T) {
 		Max:           nil,
 	}
 
-	resp, err := s.CreateBlockResponse(peer.ID("alice"), req)
+	resp, err := s.CreateBlockResponse(context.Background(), peer.ID("alice"), req)
 	require.NoError(t, err)
 	require.Equal(t, int(messages.MaxBlocksInResponse), len(resp.BlockData))
 	require.Equal(t, uint(1), resp.BlockData[0].Number())
@@ -317,7 +318,7 @@ func TestService_CreateBlockResponse_StartHash(t *testing.T) {
 		Max:           nil,
 	}
 
-	resp, err = s.CreateBlockResponse(peer.ID("alice"), req)
+	resp, err = s.CreateBlockResponse(context.Background(), peer.ID("alice"), req)
 	require.NoError(t, err)
 	require.Equal(t, int(16), len(resp.BlockData))
 	require.Equal(t, uint(16), resp.BlockData[0].Number())
@@ -330,7 +331,7 @@ func TestService_CreateBlockResponse_StartHash(t *testing.T) {
 		Max:           nil,
 	}
 
-	resp, err = s.CreateBlockResponse(peer.ID("alice"), req)
+	resp, err = s.CreateBlockResponse(context.Background(), peer.ID("alice"), req)
 	require.NoError(t, err)
 	require.Equal(t, int(16), len(resp.BlockData))
 	require.Equal(t, uint(16), resp.BlockData[0].Number())
@@ -349,7 +350,7 @@ func TestService_CreateBlockResponse_StartHash(t *testing.T) {
 		Max:           nil,
 	}
 
-	resp, err = s.CreateBlockResponse(peer.ID("alice"), req)
+	resp, err = s.CreateBlockResponse(context.Background(), peer.ID("alice"), req)
 	require.NoError(t, err)
 	require.Equal(t, int(messages.MaxBlocksInResponse), len(resp.BlockData))
 	require.Equal(t, uint(256), resp.BlockData[0].Number())
@@ -367,7 +368,7 @@ func TestService_CreateBlockResponse_StartHash(t *testing.T) {
 		Max:           nil,
 	}
 
-	resp, err = s.CreateBlockResponse(peer.ID("alice"), req)
+	resp, err = s.CreateBlockResponse(context.Background(), peer.ID("alice"), req)
 	require.NoError(t, err)
 	require.Equal(t, messages.MaxBlocksInResponse, len(resp.BlockData))
 	require.Equal(t, uint(128), resp.BlockData[0].Number())
@@ -575,7 +576,7 @@ func TestService_CreateBlockResponse_Fields(t *testing.T) {
 
 	for _, test := range testCases {
 		t.Run(test.description, func(t *testing.T) {
-			resp, err := s.CreateBlockResponse(peer.ID("alice"), test.value)
+			resp, err := s.CreateBlockResponse(context.Background(), peer.ID("alice"), test.value)
 			require.NoError(t, err)
 			require.Len(t, resp.BlockData, 2)
 			require.Equal(t, test.expectedMsgValue.BlockData[0].Hash, bestHash)
@@ -4,11 +4,13 @@
 package sync
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
 
 	"github.com/ChainSafe/gossamer/dot/network/messages"
+	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	lrucache "github.com/ChainSafe/gossamer/lib/utils/lru-cache"
@@ -186,6 +188,42 @@ type args struct {
 				}),
 			}}},
 		},
+		"descending_request_pruned_bodies": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				mockBlockState := NewMockBlockState(ctrl)
+				mockBlockState.EXPECT().BestBlockNumber().Return(uint(2), nil)
+				mockBlockState.EXPECT().GetHashByNumber(uint(2)).Return(common.Hash{2}, nil)
+				mockBlockState.EXPECT().GetBlockBody(common.Hash{2}).
+					Return(types.NewBody([]types.Extrinsic{{2}}), nil)
+				mockBlockState.EXPECT().GetHashByNumber(uint(1)).Return(common.Hash{1}, nil)
+				mockBlockState.EXPECT().GetBlockBody(common.Hash{1}).Return(nil, state.ErrBlockBodyPruned)
+				return mockBlockState
+			},
+			args: args{req: &messages.BlockRequestMessage{
+				RequestedData: messages.RequestedDataBody,
+				StartingBlock: *messages.NewFromBlock(uint(2)),
+				Direction:     messages.Descending,
+			}},
+			want: &messages.BlockResponseMessage{BlockData: []*types.BlockData{{
+				Hash: common.Hash{2},
+				Body: types.NewBody([]types.Extrinsic{{2}}),
+			}}},
+		},
+		"ascending_request_pruned_start_body": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				mockBlockState := NewMockBlockState(ctrl)
+				mockBlockState.EXPECT().BestBlockNumber().Return(uint(2), nil)
+				mockBlockState.EXPECT().GetHashByNumber(uint(1)).Return(common.Hash{1}, nil)
+				mockBlockState.EXPECT().GetBlockBody(common.Hash{1}).Return(nil, state.ErrBlockBodyPruned)
+				return mockBlockState
+			},
+			args: args{req: &messages.BlockRequestMessage{
+				RequestedData: messages.RequestedDataBody,
+				StartingBlock: *messages.NewFromBlock(uint(1)),
+				Direction:     messages.Ascending,
+			}},
+			err: state.ErrBlockBodyPruned,
+		},
 		"invalid_direction": {
 			blockStateBuilder: func(_ *gomock.Controller) BlockState {
 				return nil
@@ -208,7 +246,7 @@ type args struct {
 				blockState:            tt.blockStateBuilder(ctrl),
 				seenBlockSyncRequests: lrucache.NewLRUCache[common.Hash, uint](100),
 			}
-			got, err := s.CreateBlockResponse(peer.ID("alice"), tt.args.req)
+			got, err := s.CreateBlockResponse(context.Background(), peer.ID("alice"), tt.args.req)
 			if tt.err != nil {
 				assert.EqualError(t, err, tt.err.Error())
 			} else {
@@ -356,6 +394,18 @@ type args struct {
 				Hash: common.Hash{},
 			},
 		},
+		"requestedData_RequestedDataBody_pruned": {
+			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
+				mockBlockState := NewMockBlockState(ctrl)
+				mockBlockState.EXPECT().GetBlockBody(common.Hash{1}).Return(nil, state.ErrBlockBodyPruned)
+				return mockBlockState
+			},
+			args: args{
+				hash:          common.Hash{1},
+				requestedData: messages.RequestedDataBody,
+			},
+			err: state.ErrBlockBodyPruned,
+		},
 		"requestedData_RequestedDataBody": {
 			blockStateBuilder: func(ctrl *gomock.Controller) BlockState {
 				mockBlockState := NewMockBlockState(ctrl)
@@ -39,17 +39,17 @@ func (m *Mockimporter) EXPECT() *MockimporterMockRecorder {
 	return m.recorder
 }
 
-// importBlock mocks base method.
-func (m *Mockimporter) importBlock(arg0 *types.BlockData, arg1 BlockOrigin) (bool, error) {
+// importBlocks mocks base method.
+func (m *Mockimporter) importBlocks(arg0 []*types.BlockData) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "importBlock", arg0, arg1)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "importBlocks", arg0)
+	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// importBlock indicates an expected call of importBlock.
-func (mr *MockimporterMockRecorder) importBlock(arg0, arg1 any) *gomock.Call {
+// importBlocks indicates an expected call of importBlocks.
+func (mr *MockimporterMockRecorder) importBlocks(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "importBlock", reflect.TypeOf((*Mockimporter)(nil).importBlock), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "importBlocks", reflect.TypeOf((*Mockimporter)(nil).importBlocks), arg0)
 }
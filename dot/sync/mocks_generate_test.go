@@ -3,6 +3,7 @@
 
 package sync
 
-//go:generate mockgen -destination=mocks_test.go -package=$GOPACKAGE . Telemetry,BlockState,StorageState,TransactionState,BabeVerifier,FinalityGadget,BlockImportHandler,Network
+//go:generate mockgen -destination=mocks_test.go -package=$GOPACKAGE . Telemetry,BlockState,StorageState,TransactionState,BabeVerifier,BatchVerifier,FinalityGadget,BlockImportHandler,HeaderImportHandler,MisbehaviourRecorder,Network
 //go:generate mockgen -destination=mock_request_maker.go -package $GOPACKAGE github.com/ChainSafe/gossamer/dot/network RequestMaker
+//go:generate mockgen -destination=mock_state_response_test.go -package=$GOPACKAGE . StateResponseBlockState,StateResponseStorageState
 //go:generate mockgen -destination=mock_importer.go -source=fullsync.go -package=sync
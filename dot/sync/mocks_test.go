@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/ChainSafe/gossamer/dot/sync (interfaces: Telemetry,BlockState,StorageState,TransactionState,BabeVerifier,FinalityGadget,BlockImportHandler,Network)
+// Source: github.com/ChainSafe/gossamer/dot/sync (interfaces: Telemetry,BlockState,StorageState,TransactionState,BabeVerifier,BatchVerifier,FinalityGadget,BlockImportHandler,HeaderImportHandler,MisbehaviourRecorder,Network)
 //
 // Generated by this command:
 //
-//	mockgen -destination=mocks_test.go -package=sync . Telemetry,BlockState,StorageState,TransactionState,BabeVerifier,FinalityGadget,BlockImportHandler,Network
+//	mockgen -destination=mocks_test.go -package=sync . Telemetry,BlockState,StorageState,TransactionState,BabeVerifier,BatchVerifier,FinalityGadget,BlockImportHandler,HeaderImportHandler,MisbehaviourRecorder,Network
 //
 
 // Package sync is a generated GoMock package.
@@ -567,6 +567,44 @@ func (mr *MockBabeVerifierMockRecorder) VerifyBlock(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyBlock", reflect.TypeOf((*MockBabeVerifier)(nil).VerifyBlock), arg0)
 }
 
+// MockBatchVerifier is a mock of BatchVerifier interface.
+type MockBatchVerifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockBatchVerifierMockRecorder
+}
+
+// MockBatchVerifierMockRecorder is the mock recorder for MockBatchVerifier.
+type MockBatchVerifierMockRecorder struct {
+	mock *MockBatchVerifier
+}
+
+// NewMockBatchVerifier creates a new mock instance.
+func NewMockBatchVerifier(ctrl *gomock.Controller) *MockBatchVerifier {
+	mock := &MockBatchVerifier{ctrl: ctrl}
+	mock.recorder = &MockBatchVerifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBatchVerifier) EXPECT() *MockBatchVerifierMockRecorder {
+	return m.recorder
+}
+
+// VerifyBlocks mocks base method.
+func (m *MockBatchVerifier) VerifyBlocks(arg0 []*types.Header) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyBlocks", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyBlocks indicates an expected call of VerifyBlocks.
+func (mr *MockBatchVerifierMockRecorder) VerifyBlocks(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyBlocks", reflect.TypeOf((*MockBatchVerifier)(nil).VerifyBlocks), arg0)
+}
+
 // MockFinalityGadget is a mock of FinalityGadget interface.
 type MockFinalityGadget struct {
 	ctrl     *gomock.Controller
@@ -643,6 +681,78 @@ func (mr *MockBlockImportHandlerMockRecorder) HandleBlockImport(arg0, arg1, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleBlockImport", reflect.TypeOf((*MockBlockImportHandler)(nil).HandleBlockImport), arg0, arg1, arg2)
 }
 
+// MockHeaderImportHandler is a mock of HeaderImportHandler interface.
+type MockHeaderImportHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockHeaderImportHandlerMockRecorder
+}
+
+// MockHeaderImportHandlerMockRecorder is the mock recorder for MockHeaderImportHandler.
+type MockHeaderImportHandlerMockRecorder struct {
+	mock *MockHeaderImportHandler
+}
+
+// NewMockHeaderImportHandler creates a new mock instance.
+func NewMockHeaderImportHandler(ctrl *gomock.Controller) *MockHeaderImportHandler {
+	mock := &MockHeaderImportHandler{ctrl: ctrl}
+	mock.recorder = &MockHeaderImportHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHeaderImportHandler) EXPECT() *MockHeaderImportHandlerMockRecorder {
+	return m.recorder
+}
+
+// HandleHeaderImport mocks base method.
+func (m *MockHeaderImportHandler) HandleHeaderImport(arg0 *types.Header) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleHeaderImport", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleHeaderImport indicates an expected call of HandleHeaderImport.
+func (mr *MockHeaderImportHandlerMockRecorder) HandleHeaderImport(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleHeaderImport", reflect.TypeOf((*MockHeaderImportHandler)(nil).HandleHeaderImport), arg0)
+}
+
+// MockMisbehaviourRecorder is a mock of MisbehaviourRecorder interface.
+type MockMisbehaviourRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockMisbehaviourRecorderMockRecorder
+}
+
+// MockMisbehaviourRecorderMockRecorder is the mock recorder for MockMisbehaviourRecorder.
+type MockMisbehaviourRecorderMockRecorder struct {
+	mock *MockMisbehaviourRecorder
+}
+
+// NewMockMisbehaviourRecorder creates a new mock instance.
+func NewMockMisbehaviourRecorder(ctrl *gomock.Controller) *MockMisbehaviourRecorder {
+	mock := &MockMisbehaviourRecorder{ctrl: ctrl}
+	mock.recorder = &MockMisbehaviourRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMisbehaviourRecorder) EXPECT() *MockMisbehaviourRecorderMockRecorder {
+	return m.recorder
+}
+
+// RecordBlockFailure mocks base method.
+func (m *MockMisbehaviourRecorder) RecordBlockFailure(arg0 *types.Header, arg1 error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordBlockFailure", arg0, arg1)
+}
+
+// RecordBlockFailure indicates an expected call of RecordBlockFailure.
+func (mr *MockMisbehaviourRecorderMockRecorder) RecordBlockFailure(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordBlockFailure", reflect.TypeOf((*MockMisbehaviourRecorder)(nil).RecordBlockFailure), arg0, arg1)
+}
+
 // MockNetwork is a mock of Network interface.
 type MockNetwork struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,250 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/network/messages"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// progressShortWindow is the sliding window of the current sync rates
+	progressShortWindow = time.Minute
+	// progressLongWindow is the sliding window of the average sync rates
+	progressLongWindow = 10 * time.Minute
+)
+
+var (
+	blocksPerSecondGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "gossamer_network_syncer",
+		Name:      "blocks_per_second",
+		Help:      "number of blocks imported per second over a sliding window",
+	}, []string{"window"})
+
+	estimatedSecondsToTargetGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "gossamer_network_syncer",
+		Name:      "estimated_seconds_to_target",
+		Help:      "estimated number of seconds to reach the target block at the current import rate",
+	})
+
+	downloadedBytesCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "gossamer_network_syncer",
+		Name:      "downloaded_bytes_total",
+		Help:      "number of bytes of block data downloaded from peers",
+	})
+)
+
+type progressSample struct {
+	at        time.Time
+	bestBlock uint
+	// downloaded is the total number of bytes downloaded at the time of the sample
+	downloaded uint64
+}
+
+type peerContribution struct {
+	blocks uint64
+	bytes  uint64
+}
+
+// progressReporter measures the sync progress using samples of the best block
+// taken at every sync engine iteration, and the block responses received.
+type progressReporter struct {
+	mu         sync.Mutex
+	samples    []progressSample
+	downloaded uint64
+	target     uint
+	peers      map[peer.ID]*peerContribution
+}
+
+func newProgressReporter() *progressReporter {
+	return &progressReporter{
+		peers: make(map[peer.ID]*peerContribution),
+	}
+}
+
+// addResults accounts the blocks downloaded by the completed sync tasks
+func (p *progressReporter) addResults(results []*SyncTaskResult) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, result := range results {
+		if !result.completed {
+			continue
+		}
+
+		response, ok := result.response.(*messages.BlockResponseMessage)
+		if !ok {
+			continue
+		}
+
+		var size uint64
+		for _, blockData := range response.BlockData {
+			size += blockDataSize(blockData)
+		}
+
+		contribution, ok := p.peers[result.who]
+		if !ok {
+			contribution = new(peerContribution)
+			p.peers[result.who] = contribution
+		}
+		contribution.blocks += uint64(len(response.BlockData))
+		contribution.bytes += size
+
+		p.downloaded += size
+		downloadedBytesCounter.Add(float64(size))
+	}
+}
+
+// removePeer forgets the contribution of a disconnected peer
+func (p *progressReporter) removePeer(who peer.ID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.peers, who)
+}
+
+// addSample records the best block and target at the given time,
+// and discards the samples older than the long window.
+func (p *progressReporter) addSample(now time.Time, bestBlock, target uint) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.target = target
+	p.samples = append(p.samples, progressSample{
+		at:         now,
+		bestBlock:  bestBlock,
+		downloaded: p.downloaded,
+	})
+
+	oldest := 0
+	for oldest < len(p.samples)-1 && now.Sub(p.samples[oldest].at) > progressLongWindow {
+		oldest++
+	}
+	p.samples = p.samples[oldest:]
+}
+
+// rates returns the number of blocks imported and bytes downloaded per
+// second between the oldest sample in the window and the latest sample.
+func (p *progressReporter) rates(window time.Duration) (blocksPerSecond, bytesPerSecond float64) {
+	if len(p.samples) < 2 {
+		return 0, 0
+	}
+
+	last := p.samples[len(p.samples)-1]
+	first := last
+	for _, sample := range p.samples {
+		if last.at.Sub(sample.at) <= window {
+			first = sample
+			break
+		}
+	}
+
+	elapsed := last.at.Sub(first.at).Seconds()
+	if elapsed <= 0 {
+		return 0, 0
+	}
+
+	if last.bestBlock > first.bestBlock {
+		blocksPerSecond = float64(last.bestBlock-first.bestBlock) / elapsed
+	}
+	bytesPerSecond = float64(last.downloaded-first.downloaded) / elapsed
+	return blocksPerSecond, bytesPerSecond
+}
+
+// progress returns the current sync progress
+func (p *progressReporter) progress() common.SyncProgress {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	progress := common.SyncProgress{
+		TargetBlock: p.target,
+		Peers:       make([]common.PeerSyncContribution, 0, len(p.peers)),
+	}
+
+	if len(p.samples) > 0 {
+		progress.BestBlock = p.samples[len(p.samples)-1].bestBlock
+	}
+
+	progress.BlocksPerSecond, progress.BytesPerSecond = p.rates(progressShortWindow)
+	progress.AverageBlocksPerSecond, _ = p.rates(progressLongWindow)
+
+	if progress.BlocksPerSecond > 0 && progress.TargetBlock > progress.BestBlock {
+		remaining := float64(progress.TargetBlock - progress.BestBlock)
+		progress.EstimatedTimeToTarget = time.Duration(remaining / progress.BlocksPerSecond * float64(time.Second))
+	}
+
+	for who, contribution := range p.peers {
+		progress.Peers = append(progress.Peers, common.PeerSyncContribution{
+			PeerID: who.String(),
+			Blocks: contribution.blocks,
+			Bytes:  contribution.bytes,
+		})
+	}
+
+	sort.Slice(progress.Peers, func(i, j int) bool {
+		return progress.Peers[i].Blocks > progress.Peers[j].Blocks
+	})
+
+	return progress
+}
+
+// report logs the sync progress and updates the sync metrics
+func (p *progressReporter) report(connectedPeers int, finalised *types.Header) {
+	progress := p.progress()
+
+	blocksPerSecondGauge.WithLabelValues(progressShortWindow.String()).Set(progress.BlocksPerSecond)
+	blocksPerSecondGauge.WithLabelValues(progressLongWindow.String()).Set(progress.AverageBlocksPerSecond)
+	estimatedSecondsToTargetGauge.Set(progress.EstimatedTimeToTarget.Seconds())
+
+	logger.Infof(
+		"🚣 syncing with %d peers, finalized #%d (%s), best #%d, target #%d, "+
+			"%.2f blocks/second (%.2f over %s), %.2f KiB/second, estimated time to target %s",
+		connectedPeers,
+		finalised.Number,
+		finalised.Hash().Short(),
+		progress.BestBlock,
+		progress.TargetBlock,
+		progress.BlocksPerSecond,
+		progress.AverageBlocksPerSecond,
+		progressLongWindow,
+		progress.BytesPerSecond/1024,
+		progress.EstimatedTimeToTarget.Round(time.Second),
+	)
+
+	for _, contribution := range progress.Peers {
+		logger.Debugf("peer %s contributed %d blocks (%d bytes)",
+			contribution.PeerID, contribution.Blocks, contribution.Bytes)
+	}
+}
+
+// blockDataSize returns the size of the downloaded parts of the block data
+func blockDataSize(blockData *types.BlockData) (size uint64) {
+	if blockData.Header != nil {
+		encodedHeader, err := scale.Marshal(*blockData.Header)
+		if err == nil {
+			size += uint64(len(encodedHeader))
+		}
+	}
+
+	if blockData.Body != nil {
+		for _, extrinsic := range *blockData.Body {
+			size += uint64(len(extrinsic))
+		}
+	}
+
+	if blockData.Justification != nil {
+		size += uint64(len(*blockData.Justification))
+	}
+
+	return size
+}
@@ -0,0 +1,156 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/network/messages"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_progressReporter_progress(t *testing.T) {
+	t.Parallel()
+
+	start := time.Unix(1000, 0)
+
+	testCases := map[string]struct {
+		samples          []progressSample
+		target           uint
+		expectedProgress common.SyncProgress
+	}{
+		"no_samples": {
+			expectedProgress: common.SyncProgress{
+				Peers: []common.PeerSyncContribution{},
+			},
+		},
+		"single_sample": {
+			samples: []progressSample{{at: start, bestBlock: 10}},
+			target:  100,
+			expectedProgress: common.SyncProgress{
+				BestBlock:   10,
+				TargetBlock: 100,
+				Peers:       []common.PeerSyncContribution{},
+			},
+		},
+		"short_and_long_windows": {
+			samples: []progressSample{
+				{at: start, bestBlock: 0, downloaded: 0},
+				{at: start.Add(9 * time.Minute), bestBlock: 540, downloaded: 5400},
+				{at: start.Add(10 * time.Minute), bestBlock: 1200, downloaded: 12000},
+			},
+			target: 2520,
+			expectedProgress: common.SyncProgress{
+				BestBlock:              1200,
+				TargetBlock:            2520,
+				BlocksPerSecond:        11,
+				AverageBlocksPerSecond: 2,
+				BytesPerSecond:         110,
+				EstimatedTimeToTarget:  2 * time.Minute,
+				Peers:                  []common.PeerSyncContribution{},
+			},
+		},
+		"target_reached": {
+			samples: []progressSample{
+				{at: start, bestBlock: 0},
+				{at: start.Add(10 * time.Second), bestBlock: 100},
+			},
+			target: 100,
+			expectedProgress: common.SyncProgress{
+				BestBlock:              100,
+				TargetBlock:            100,
+				BlocksPerSecond:        10,
+				AverageBlocksPerSecond: 10,
+				Peers:                  []common.PeerSyncContribution{},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			reporter := newProgressReporter()
+			reporter.samples = testCase.samples
+			reporter.target = testCase.target
+
+			assert.Equal(t, testCase.expectedProgress, reporter.progress())
+		})
+	}
+}
+
+func Test_progressReporter_addSample(t *testing.T) {
+	t.Parallel()
+
+	start := time.Unix(1000, 0)
+	reporter := newProgressReporter()
+
+	reporter.addSample(start, 1, 10)
+	reporter.addSample(start.Add(progressLongWindow), 2, 10)
+	reporter.addSample(start.Add(progressLongWindow+time.Second), 3, 20)
+
+	expectedSamples := []progressSample{
+		{at: start.Add(progressLongWindow), bestBlock: 2},
+		{at: start.Add(progressLongWindow + time.Second), bestBlock: 3},
+	}
+	assert.Equal(t, expectedSamples, reporter.samples)
+	assert.Equal(t, uint(20), reporter.target)
+}
+
+func Test_progressReporter_addResults(t *testing.T) {
+	t.Parallel()
+
+	justification := []byte{1, 2, 3, 4}
+	body := types.Body{{1, 2}, {3}}
+	header := types.NewEmptyHeader()
+
+	alice := peer.ID("alice")
+	bob := peer.ID("bob")
+
+	reporter := newProgressReporter()
+	reporter.addResults([]*SyncTaskResult{
+		{
+			who:       alice,
+			completed: true,
+			response: &messages.BlockResponseMessage{BlockData: []*types.BlockData{
+				{Body: &body},
+				{Body: &body, Justification: &justification},
+			}},
+		},
+		{
+			who:       bob,
+			completed: true,
+			response: &messages.BlockResponseMessage{BlockData: []*types.BlockData{
+				{Header: header},
+			}},
+		},
+		{
+			who:       bob,
+			completed: false,
+			response: &messages.BlockResponseMessage{BlockData: []*types.BlockData{
+				{Body: &body},
+			}},
+		},
+	})
+
+	headerSize := blockDataSize(&types.BlockData{Header: header})
+	assert.NotZero(t, headerSize)
+
+	expectedPeers := map[peer.ID]*peerContribution{
+		alice: {blocks: 2, bytes: 10},
+		bob:   {blocks: 1, bytes: headerSize},
+	}
+	assert.Equal(t, expectedPeers, reporter.peers)
+	assert.Equal(t, 10+headerSize, reporter.downloaded)
+
+	reporter.removePeer(alice)
+	assert.Equal(t, []common.PeerSyncContribution{
+		{PeerID: bob.String(), Blocks: 1, Bytes: headerSize},
+	}, reporter.progress().Peers)
+}
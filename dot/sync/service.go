@@ -7,14 +7,17 @@
 	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/network"
 	"github.com/ChainSafe/gossamer/dot/peerset"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/internal/watchdog"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/services"
 	lrucache "github.com/ChainSafe/gossamer/lib/utils/lru-cache"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/prometheus/client_golang/prometheus"
@@ -26,6 +29,8 @@
 	minPeersDefault         = 1
 )
 
+var errSyncServiceStopped = errors.New("sync service stopped")
+
 var (
 	isSyncedGauge = promauto.NewGauge(prometheus.GaugeOpts{
 		Namespace: "gossamer_network_syncer",
@@ -33,6 +38,12 @@
 		Help:      "bool representing whether the node is synced to the head of the chain",
 	})
 
+	finalityLagGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "gossamer_network_syncer",
+		Name:      "finality_lag",
+		Help:      "number of blocks the best block is ahead of the highest finalised block",
+	})
+
 	logger = log.NewFromGlobal(log.AddContext("pkg", "sync"))
 )
 
@@ -92,6 +103,7 @@ type Strategy interface {
 	Process(results []*SyncTaskResult) (done bool, repChanges []Change, blocks []peer.ID, err error)
 	ShowMetrics()
 	IsSynced() bool
+	Target() uint
 }
 
 type SyncService struct {
@@ -109,8 +121,18 @@ type SyncService struct {
 	slotDuration      time.Duration
 
 	seenBlockSyncRequests *lrucache.LRUCache[common.Hash, uint]
-
-	stopCh chan struct{}
+	progress              *progressReporter
+
+	// heartbeat records the strategy runs of the sync engine, for the watchdog
+	heartbeat watchdog.Heartbeat
+	// waitingWorkers is true while the sync engine waits for the minimum number of workers
+	waitingWorkers atomic.Bool
+
+	stopCh    chan struct{}
+	restartCh chan struct{}
+	// engineDone is closed once the last sync engine started returned
+	engineDone  chan struct{}
+	engineMutex sync.Mutex
 }
 
 func NewSyncService(cfgs ...ServiceConfig) *SyncService {
@@ -118,7 +140,9 @@ func NewSyncService(cfgs ...ServiceConfig) *SyncService {
 		minPeers:              minPeersDefault,
 		waitPeersDuration:     waitPeersDefaultTimeout,
 		stopCh:                make(chan struct{}),
+		restartCh:             make(chan struct{}),
 		seenBlockSyncRequests: lrucache.NewLRUCache[common.Hash, uint](100),
+		progress:              newProgressReporter(),
 	}
 
 	for _, cfg := range cfgs {
@@ -129,6 +153,9 @@ func NewSyncService(cfgs ...ServiceConfig) *SyncService {
 }
 
 func (s *SyncService) waitWorkers() {
+	s.waitingWorkers.Store(true)
+	defer s.waitingWorkers.Store(false)
+
 	bestBlockHeader, err := s.blockState.BestBlockHeader()
 	if err != nil {
 		panic(fmt.Sprintf("failed to get highest finalised header: %v", err))
@@ -158,11 +185,26 @@ func (s *SyncService) waitWorkers() {
 }
 
 func (s *SyncService) Start() error {
-	s.wg.Add(1)
-	go s.runSyncEngine()
+	s.startEngine()
 	return nil
 }
 
+// startEngine starts the sync engine in a new goroutine, closing engineDone once it returns
+func (s *SyncService) startEngine() {
+	engineDone := make(chan struct{})
+	s.engineMutex.Lock()
+	s.engineDone = engineDone
+	s.engineMutex.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer close(engineDone)
+		defer services.Recover(s)
+		s.runSyncEngine()
+	}()
+}
+
 func (s *SyncService) Stop() error {
 	close(s.stopCh)
 	s.wg.Wait()
@@ -200,6 +242,7 @@ func (s *SyncService) HandleBlockAnnounce(from peer.ID, msg *network.BlockAnnoun
 func (s *SyncService) OnConnectionClosed(who peer.ID) {
 	logger.Tracef("removing peer worker: %s", who.String())
 	s.workerPool.removeWorker(who)
+	s.progress.removePeer(who)
 }
 
 func (s *SyncService) IsSynced() bool {
@@ -218,8 +261,49 @@ func (s *SyncService) HighestBlock() uint {
 	return highestBlock
 }
 
+// SyncProgress returns the current sync progress
+func (s *SyncService) SyncProgress() common.SyncProgress {
+	return s.progress.progress()
+}
+
+// Progress returns the progress of the sync engine, which is idle while it waits for the
+// minimum number of workers.
+func (s *SyncService) Progress() watchdog.Progress {
+	progress := s.heartbeat.Progress()
+	progress.Idle = s.waitingWorkers.Load()
+	return progress
+}
+
+// Restart restarts the sync engine from the default strategy, once its current strategy
+// run returned, the engine waiting for the minimum number of workers again. The engine is
+// started again if it returned, such as after a panic.
+func (s *SyncService) Restart() error {
+	select {
+	case <-s.stopCh:
+		return errSyncServiceStopped
+	default:
+	}
+
+	s.engineMutex.Lock()
+	engineDone := s.engineDone
+	s.engineMutex.Unlock()
+
+	select {
+	case s.restartCh <- struct{}{}:
+		return nil
+	case <-engineDone:
+		logger.Warnf("starting the sync engine which returned with strategy: %T", s.defaultStrategy)
+		s.mu.Lock()
+		s.currentStrategy = s.defaultStrategy
+		s.mu.Unlock()
+		s.startEngine()
+		return nil
+	case <-s.stopCh:
+		return errSyncServiceStopped
+	}
+}
+
 func (s *SyncService) runSyncEngine() {
-	defer s.wg.Done()
 	s.waitWorkers()
 
 	logger.Infof("starting sync engine with strategy: %T", s.currentStrategy)
@@ -228,6 +312,14 @@ func (s *SyncService) runSyncEngine() {
 		select {
 		case <-s.stopCh:
 			return
+		case <-s.restartCh:
+			s.mu.Lock()
+			s.currentStrategy = s.defaultStrategy
+			s.mu.Unlock()
+
+			logger.Warnf("restarting sync engine with strategy: %T", s.defaultStrategy)
+			s.waitWorkers()
+			continue
 		case <-time.After(s.slotDuration):
 		}
 
@@ -250,41 +342,48 @@ func (s *SyncService) runStrategy() {
 	finalisedHeader, err := s.blockState.GetHighestFinalisedHeader()
 	if err != nil {
 		logger.Criticalf("getting highest finalized header: %w", err)
+		s.heartbeat.Fail(fmt.Errorf("getting highest finalized header: %w", err))
 		return
 	}
 
 	bestBlockHeader, err := s.blockState.BestBlockHeader()
 	if err != nil {
 		logger.Criticalf("getting best block header: %w", err)
+		s.heartbeat.Fail(fmt.Errorf("getting best block header: %w", err))
 		return
 	}
 
-	logger.Infof(
-		"🚣 currently syncing, %d peers connected, finalized #%d (%s), best #%d (%s)",
-		len(s.network.AllConnectedPeersIDs()),
-		finalisedHeader.Number,
-		finalisedHeader.Hash().Short(),
-		bestBlockHeader.Number,
-		bestBlockHeader.Hash().Short(),
-	)
+	s.progress.addSample(time.Now(), bestBlockHeader.Number, s.currentStrategy.Target())
+	s.progress.report(len(s.network.AllConnectedPeersIDs()), finalisedHeader)
+
+	if bestBlockHeader.Number > finalisedHeader.Number {
+		finalityLagGauge.Set(float64(bestBlockHeader.Number - finalisedHeader.Number))
+	} else {
+		finalityLagGauge.Set(0)
+	}
 
 	tasks, err := s.currentStrategy.NextActions()
 	if err != nil {
 		logger.Criticalf("current sync strategy next actions failed with: %s", err.Error())
+		s.heartbeat.Fail(fmt.Errorf("getting next actions: %w", err))
 		return
 	}
 
 	logger.Tracef("amount of tasks to process: %d", len(tasks))
 	if len(tasks) == 0 {
+		s.heartbeat.Beat()
 		return
 	}
 
 	results := s.workerPool.submitRequests(tasks)
+	s.progress.addResults(results)
 	done, repChanges, peersToIgnore, err := s.currentStrategy.Process(results)
 	if err != nil {
 		logger.Criticalf("current sync strategy failed with: %s", err.Error())
+		s.heartbeat.Fail(fmt.Errorf("processing results: %w", err))
 		return
 	}
+	s.heartbeat.Beat()
 
 	for _, change := range repChanges {
 		s.network.ReportPeer(change.rep, change.who)
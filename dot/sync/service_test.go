@@ -0,0 +1,41 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncService_Progress(t *testing.T) {
+	t.Parallel()
+
+	s := NewSyncService()
+	assert.False(t, s.Progress().Idle)
+
+	s.heartbeat.Beat()
+	assert.False(t, s.Progress().Last.IsZero())
+
+	s.waitingWorkers.Store(true)
+	assert.True(t, s.Progress().Idle)
+}
+
+func TestSyncService_Restart(t *testing.T) {
+	t.Parallel()
+
+	s := NewSyncService()
+
+	// the restarts are received by the sync engine loop
+	go func() {
+		<-s.restartCh
+	}()
+	err := s.Restart()
+	require.NoError(t, err)
+
+	close(s.stopCh)
+	err = s.Restart()
+	assert.EqualError(t, err, "sync service stopped")
+}
@@ -0,0 +1,245 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/network/messages"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// maxStateResponseBytes is the soft limit of the size of the keys and values
+// returned in a single state response, the remaining entries being requested
+// by the peer using the last returned key as continuation cursor.
+const maxStateResponseBytes = 2 * 1024 * 1024
+
+var errInvalidStateRequest = errors.New("invalid state request")
+
+type (
+	// StateResponseBlockState is the block state used to answer state requests
+	StateResponseBlockState interface {
+		GetHeader(common.Hash) (*types.Header, error)
+	}
+
+	// StateResponseStorageState is the storage state used to answer state requests
+	StateResponseStorageState interface {
+		TrieState(root *common.Hash) (*rtstorage.TrieState, error)
+		GenerateTrieProof(stateRoot common.Hash, keys [][]byte) (encodedProofNodes [][]byte, err error)
+	}
+)
+
+// StateResponseProvider answers the state requests of peers fast syncing from us
+type StateResponseProvider struct {
+	blockState   StateResponseBlockState
+	storageState StateResponseStorageState
+	maxBytes     int
+}
+
+// NewStateResponseProvider creates a new StateResponseProvider
+func NewStateResponseProvider(blockState StateResponseBlockState,
+	storageState StateResponseStorageState) *StateResponseProvider {
+	return &StateResponseProvider{
+		blockState:   blockState,
+		storageState: storageState,
+		maxBytes:     maxStateResponseBytes,
+	}
+}
+
+// CreateStateResponse returns the state entries of the requested block, starting
+// after the keys given in the request. The first entry of the response always
+// contains the top trie entries, followed by an entry for each child trie met.
+// The request start contains either the last top trie key received, or the
+// last child storage key and the last key received from that child trie.
+// Collecting the entries is given up once the context is done.
+func (p *StateResponseProvider) CreateStateResponse(ctx context.Context, from peer.ID, req *messages.StateRequest) (
+	*messages.StateResponse, error) {
+	logger.Debugf("state request from %s: %s", from, req)
+
+	if len(req.Start) > 2 {
+		return nil, fmt.Errorf("%w: expected at most 2 start keys, got %d", errInvalidStateRequest, len(req.Start))
+	}
+
+	header, err := p.blockState.GetHeader(req.Block)
+	if err != nil {
+		return nil, fmt.Errorf("getting header of block %s: %w", req.Block, err)
+	}
+
+	trieState, err := p.storageState.TrieState(&header.StateRoot)
+	if err != nil {
+		return nil, fmt.Errorf("getting trie state at root %s: %w", header.StateRoot, err)
+	}
+	topTrie := trieState.Trie()
+
+	collector := &stateEntriesCollector{maxBytes: p.maxBytes}
+	topEntry := messages.KeyValueStateEntry{}
+	childEntries := make([]messages.KeyValueStateEntry, 0)
+	// childStarts holds the key after which the entries of each child entry start
+	childStarts := make([][]byte, 0)
+
+	var topStart []byte
+	if len(req.Start) > 0 {
+		topStart = req.Start[0]
+	}
+
+	// resume the child trie that was not completely sent in a previous response
+	if len(req.Start) == 2 {
+		childEntry, err := collector.collectChild(ctx, topTrie, topStart, req.Start[1])
+		if err != nil {
+			return nil, err
+		}
+		childEntries = append(childEntries, childEntry)
+		childStarts = append(childStarts, req.Start[1])
+	}
+
+	if !collector.full() {
+		topEntry.Complete = true
+		for key := range topTrie.KeysFrom(topStart) {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			collector.add(&topEntry, key, topTrie.Get(key))
+
+			if bytes.HasPrefix(key, inmemory.ChildStorageKeyPrefix) {
+				childEntry, err := collector.collectChild(ctx, topTrie, key, nil)
+				if err != nil {
+					return nil, err
+				}
+				childEntries = append(childEntries, childEntry)
+				childStarts = append(childStarts, nil)
+			}
+
+			if collector.full() {
+				topEntry.Complete = false
+				break
+			}
+		}
+	}
+
+	resp := &messages.StateResponse{
+		Entries: append([]messages.KeyValueStateEntry{topEntry}, childEntries...),
+	}
+
+	if !req.NoProof {
+		resp.Proof, err = p.stateProof(header.StateRoot, topStart, topEntry, childStarts, childEntries)
+		if err != nil {
+			return nil, fmt.Errorf("generating state proof: %w", err)
+		}
+	}
+
+	return resp, nil
+}
+
+// stateProof returns the SCALE encoded proof nodes of the keys returned from the
+// top trie and from each child trie, the child trie nodes being proven against
+// the child trie roots themselves proven by the top trie nodes.
+func (p *StateResponseProvider) stateProof(stateRoot common.Hash, topStart []byte,
+	topEntry messages.KeyValueStateEntry, childStarts [][]byte, childEntries []messages.KeyValueStateEntry) (
+	[]byte, error) {
+	proofNodes, err := p.trieProof(stateRoot, topStart, topEntry.StateEntries)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{}, len(proofNodes))
+	for _, proofNode := range proofNodes {
+		seen[string(proofNode)] = struct{}{}
+	}
+
+	for i, childEntry := range childEntries {
+		childProofNodes, err := p.trieProof(childEntry.StateRoot, childStarts[i], childEntry.StateEntries)
+		if err != nil {
+			return nil, fmt.Errorf("child trie with root %s: %w", childEntry.StateRoot, err)
+		}
+
+		for _, proofNode := range childProofNodes {
+			if _, ok := seen[string(proofNode)]; ok {
+				continue
+			}
+			seen[string(proofNode)] = struct{}{}
+			proofNodes = append(proofNodes, proofNode)
+		}
+	}
+
+	return scale.Marshal(proofNodes)
+}
+
+// trieProof returns the proof nodes of the trie keys returned, including the
+// start key to prove the range has no gap.
+func (p *StateResponseProvider) trieProof(root common.Hash, start []byte,
+	entries trie.Entries) ([][]byte, error) {
+	keys := make([][]byte, 0, len(entries)+1)
+	if len(start) > 0 {
+		keys = append(keys, start)
+	}
+	for _, entry := range entries {
+		keys = append(keys, entry.Key)
+	}
+
+	return p.storageState.GenerateTrieProof(root, keys)
+}
+
+// stateEntriesCollector accumulates state entries until the size limit is reached
+type stateEntriesCollector struct {
+	maxBytes int
+	size     int
+}
+
+func (c *stateEntriesCollector) add(entry *messages.KeyValueStateEntry, key, value []byte) {
+	entry.StateEntries = append(entry.StateEntries, trie.Entry{Key: key, Value: value})
+	c.size += len(key) + len(value)
+}
+
+func (c *stateEntriesCollector) full() bool {
+	return c.size >= c.maxBytes
+}
+
+// collectChild collects the entries of the child trie stored at the given top
+// trie key, starting after the given child key.
+func (c *stateEntriesCollector) collectChild(ctx context.Context, topTrie trie.Trie, childStorageKey, start []byte) (
+	messages.KeyValueStateEntry, error) {
+	if !bytes.HasPrefix(childStorageKey, inmemory.ChildStorageKeyPrefix) {
+		return messages.KeyValueStateEntry{}, fmt.Errorf("%w: 0x%x is not a child storage key",
+			errInvalidStateRequest, childStorageKey)
+	}
+
+	childTrie, err := topTrie.GetChild(childStorageKey[len(inmemory.ChildStorageKeyPrefix):])
+	if err != nil {
+		return messages.KeyValueStateEntry{}, fmt.Errorf("getting child trie at 0x%x: %w", childStorageKey, err)
+	}
+
+	childRoot, err := childTrie.Hash()
+	if err != nil {
+		return messages.KeyValueStateEntry{}, fmt.Errorf("hashing child trie at 0x%x: %w", childStorageKey, err)
+	}
+
+	entry := messages.KeyValueStateEntry{
+		StateRoot: childRoot,
+		Complete:  true,
+	}
+
+	for key := range childTrie.KeysFrom(start) {
+		if ctx.Err() != nil {
+			return messages.KeyValueStateEntry{}, ctx.Err()
+		}
+
+		c.add(&entry, key, childTrie.Get(key))
+		if c.full() {
+			entry.Complete = false
+			break
+		}
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,205 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/network/messages"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newStateResponseTestTrie(t *testing.T) trie.Trie {
+	t.Helper()
+
+	tr := inmemory.NewEmptyTrie()
+	for _, key := range []string{"a", "b", "c"} {
+		require.NoError(t, tr.Put([]byte(key), []byte{1}))
+	}
+	require.NoError(t, tr.PutIntoChild([]byte("x"), []byte("d"), []byte{2}))
+
+	return tr
+}
+
+func TestStateResponseProvider_CreateStateResponse(t *testing.T) {
+	t.Parallel()
+
+	blockHash := common.Hash{1}
+	stateRoot := common.Hash{2}
+	childStorageKey := append(append([]byte{}, inmemory.ChildStorageKeyPrefix...), 'x')
+
+	testCases := map[string]struct {
+		maxBytes int
+		request  *messages.StateRequest
+		// expectedKeys are the keys expected for each entry of the response
+		expectedKeys     [][]string
+		expectedComplete []bool
+		errWrapped       error
+	}{
+		"too_many_start_keys": {
+			maxBytes:   maxStateResponseBytes,
+			request:    &messages.StateRequest{Block: blockHash, Start: [][]byte{{1}, {2}, {3}}, NoProof: true},
+			errWrapped: errInvalidStateRequest,
+		},
+		"full_state": {
+			maxBytes:         maxStateResponseBytes,
+			request:          &messages.StateRequest{Block: blockHash, NoProof: true},
+			expectedKeys:     [][]string{{":child_storage:default:x", "a", "b", "c"}, {"d"}},
+			expectedComplete: []bool{true, true},
+		},
+		"first_page": {
+			maxBytes:         4,
+			request:          &messages.StateRequest{Block: blockHash, Start: [][]byte{[]byte("a")}, NoProof: true},
+			expectedKeys:     [][]string{{"b", "c"}},
+			expectedComplete: []bool{false},
+		},
+		"last_page": {
+			maxBytes:         4,
+			request:          &messages.StateRequest{Block: blockHash, Start: [][]byte{[]byte("b")}, NoProof: true},
+			expectedKeys:     [][]string{{"c"}},
+			expectedComplete: []bool{true},
+		},
+		"resume_child_trie": {
+			maxBytes: maxStateResponseBytes,
+			request: &messages.StateRequest{
+				Block:   blockHash,
+				Start:   [][]byte{childStorageKey, nil},
+				NoProof: true,
+			},
+			expectedKeys:     [][]string{{"a", "b", "c"}, {"d"}},
+			expectedComplete: []bool{true, true},
+		},
+		"resume_invalid_child_trie": {
+			maxBytes: maxStateResponseBytes,
+			request: &messages.StateRequest{
+				Block:   blockHash,
+				Start:   [][]byte{[]byte("a"), nil},
+				NoProof: true,
+			},
+			errWrapped: errInvalidStateRequest,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			blockState := NewMockStateResponseBlockState(ctrl)
+			storageState := NewMockStateResponseStorageState(ctrl)
+			if testCase.request != nil && len(testCase.request.Start) <= 2 {
+				blockState.EXPECT().GetHeader(blockHash).Return(&types.Header{StateRoot: stateRoot}, nil)
+				storageState.EXPECT().TrieState(&stateRoot).
+					Return(rtstorage.NewTrieState(newStateResponseTestTrie(t)), nil)
+			}
+
+			provider := NewStateResponseProvider(blockState, storageState)
+			provider.maxBytes = testCase.maxBytes
+
+			resp, err := provider.CreateStateResponse(context.Background(), peer.ID("peer"), testCase.request)
+			require.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				return
+			}
+
+			// the top trie entry always comes first
+			require.Len(t, resp.Entries, len(testCase.expectedKeys))
+			assert.Equal(t, common.Hash{}, resp.Entries[0].StateRoot)
+			for idx, entry := range resp.Entries {
+				keys := make([]string, len(entry.StateEntries))
+				for keyIdx, stateEntry := range entry.StateEntries {
+					keys[keyIdx] = string(stateEntry.Key)
+				}
+				assert.Equal(t, testCase.expectedKeys[idx], keys)
+				assert.Equal(t, testCase.expectedComplete[idx], entry.Complete)
+			}
+			assert.Empty(t, resp.Proof)
+		})
+	}
+}
+
+func TestStateResponseProvider_CreateStateResponse_proof(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	blockHash := common.Hash{1}
+	stateRoot := common.Hash{2}
+	proofNodes := [][]byte{{1, 2}, {3}}
+
+	blockState := NewMockStateResponseBlockState(ctrl)
+	blockState.EXPECT().GetHeader(blockHash).Return(&types.Header{StateRoot: stateRoot}, nil)
+	storageState := NewMockStateResponseStorageState(ctrl)
+	storageState.EXPECT().TrieState(&stateRoot).
+		Return(rtstorage.NewTrieState(newStateResponseTestTrie(t)), nil)
+	storageState.EXPECT().
+		GenerateTrieProof(stateRoot, [][]byte{[]byte("b"), []byte("c")}).
+		Return(proofNodes, nil)
+
+	provider := NewStateResponseProvider(blockState, storageState)
+	resp, err := provider.CreateStateResponse(context.Background(), peer.ID("peer"), &messages.StateRequest{
+		Block: blockHash,
+		Start: [][]byte{[]byte("b")},
+	})
+	require.NoError(t, err)
+
+	expectedProof, err := scale.Marshal(proofNodes)
+	require.NoError(t, err)
+	assert.Equal(t, expectedProof, resp.Proof)
+
+	// the response survives a network round trip
+	encoded, err := resp.Encode()
+	require.NoError(t, err)
+	decoded := new(messages.StateResponse)
+	require.NoError(t, decoded.Decode(encoded))
+	assert.Equal(t, resp.Proof, decoded.Proof)
+	assert.Equal(t, resp.Entries[0].StateEntries, decoded.Entries[0].StateEntries)
+}
+
+func TestStateResponseProvider_CreateStateResponse_childTrieProof(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	blockHash := common.Hash{1}
+	stateRoot := common.Hash{2}
+	childStorageKey := append(append([]byte{}, inmemory.ChildStorageKeyPrefix...), 'x')
+
+	testTrie := newStateResponseTestTrie(t)
+	childTrie, err := testTrie.GetChild([]byte("x"))
+	require.NoError(t, err)
+	childRoot, err := childTrie.Hash()
+	require.NoError(t, err)
+
+	blockState := NewMockStateResponseBlockState(ctrl)
+	blockState.EXPECT().GetHeader(blockHash).Return(&types.Header{StateRoot: stateRoot}, nil)
+	storageState := NewMockStateResponseStorageState(ctrl)
+	storageState.EXPECT().TrieState(&stateRoot).Return(rtstorage.NewTrieState(testTrie), nil)
+	storageState.EXPECT().
+		GenerateTrieProof(stateRoot, [][]byte{childStorageKey, []byte("a"), []byte("b"), []byte("c")}).
+		Return([][]byte{{1, 2}, {3}}, nil)
+	// the node shared with the top trie proof is only sent once
+	storageState.EXPECT().
+		GenerateTrieProof(childRoot, [][]byte{[]byte("d")}).
+		Return([][]byte{{3}, {4}}, nil)
+
+	provider := NewStateResponseProvider(blockState, storageState)
+	resp, err := provider.CreateStateResponse(context.Background(), peer.ID("peer"), &messages.StateRequest{
+		Block: blockHash,
+	})
+	require.NoError(t, err)
+
+	expectedProof, err := scale.Marshal([][]byte{{1, 2}, {3}, {4}})
+	require.NoError(t, err)
+	assert.Equal(t, expectedProof, resp.Proof)
+}
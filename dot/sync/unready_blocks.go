@@ -161,3 +161,17 @@ func (u *unreadyBlocks) removeIrrelevantFragments(finalisedNumber uint) {
 
 	u.disjointFragments = u.disjointFragments[:fragmentIdx]
 }
+
+// size returns the number of unready blocks and their estimated size in bytes.
+func (u *unreadyBlocks) size() (blocks int, size uint64) {
+	u.mtx.RLock()
+	defer u.mtx.RUnlock()
+
+	for _, fragment := range u.disjointFragments {
+		blocks += len(fragment)
+		size += blocksSize(fragment...)
+	}
+	blocks += len(u.incompleteBlocks)
+	size += uint64(len(u.incompleteBlocks)) * blockDataOverhead
+	return blocks, size
+}
@@ -0,0 +1,51 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package telemetry
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+type blockMisbehaviourTM BlockMisbehaviour
+
+var _ json.Marshaler = (*BlockMisbehaviour)(nil)
+
+// BlockMisbehaviour struct to hold the telemetry messages of the blocks failing
+// verification because of a misbehaviour of their author
+type BlockMisbehaviour struct {
+	Hash           common.Hash `json:"hash"`
+	Height         uint        `json:"height"`
+	Kind           string      `json:"kind"`
+	AuthorityIndex *uint32     `json:"authority_index"`
+	Reason         string      `json:"reason"`
+}
+
+// NewBlockMisbehaviour function to create new Block Misbehaviour Telemetry Message
+func NewBlockMisbehaviour(hash common.Hash, height uint, kind string, authorityIndex *uint32,
+	reason string) *BlockMisbehaviour {
+	return &BlockMisbehaviour{
+		Hash:           hash,
+		Height:         height,
+		Kind:           kind,
+		AuthorityIndex: authorityIndex,
+		Reason:         reason,
+	}
+}
+
+func (bm BlockMisbehaviour) MarshalJSON() ([]byte, error) {
+	telemetryData := struct {
+		blockMisbehaviourTM
+		MessageType string    `json:"msg"`
+		Timestamp   time.Time `json:"ts"`
+	}{
+		Timestamp:           time.Now(),
+		MessageType:         blockMisbehaviourMsg,
+		blockMisbehaviourTM: blockMisbehaviourTM(bm),
+	}
+
+	return json.Marshal(telemetryData)
+}
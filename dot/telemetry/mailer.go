@@ -39,7 +39,42 @@ func BootstrapMailer(ctx context.Context, conns []*genesis.TelemetryEndpoint, lo
 		logger: logger,
 	}
 
-	for _, v := range conns {
+	mailer.connections, err = mailer.dial(ctx, conns)
+	if err != nil {
+		return nil, err
+	}
+
+	return mailer, nil
+}
+
+// SetEndpoints connects to the telemetry endpoints given and replaces
+// the current connections with them, closing the current connections.
+func (m *Mailer) SetEndpoints(ctx context.Context, endpoints []*genesis.TelemetryEndpoint) error {
+	connections, err := m.dial(ctx, endpoints)
+	if err != nil {
+		return err
+	}
+
+	m.mutex.Lock()
+	oldConnections := m.connections
+	m.connections = connections
+	m.mutex.Unlock()
+
+	for _, conn := range oldConnections {
+		conn.Lock()
+		err = conn.wsconn.Close()
+		conn.Unlock()
+		if err != nil {
+			m.logger.Debugf("cannot close telemetry connection: %s", err)
+		}
+	}
+
+	return nil
+}
+
+func (m *Mailer) dial(ctx context.Context, endpoints []*genesis.TelemetryEndpoint) (
+	connections []*telemetryConnection, err error) {
+	for _, v := range endpoints {
 		const maxRetries = 3
 
 		for connAttempts := 0; connAttempts < maxRetries; connAttempts++ {
@@ -48,7 +83,7 @@ func BootstrapMailer(ctx context.Context, conns []*genesis.TelemetryEndpoint, lo
 			conn, response, err := websocket.DefaultDialer.DialContext(dialCtx, v.Endpoint, nil)
 			dialCancel()
 			if err != nil {
-				mailer.logger.Debugf("cannot dial telemetry endpoint %s (try %d of %d): %s",
+				m.logger.Debugf("cannot dial telemetry endpoint %s (try %d of %d): %s",
 					v.Endpoint, connAttempts+1, maxRetries, err)
 
 				if ctxErr := ctx.Err(); ctxErr != nil {
@@ -60,10 +95,10 @@ func BootstrapMailer(ctx context.Context, conns []*genesis.TelemetryEndpoint, lo
 
 			err = response.Body.Close()
 			if err != nil {
-				mailer.logger.Warnf("cannot close body of response from %s: %s", v.Endpoint, err)
+				m.logger.Warnf("cannot close body of response from %s: %s", v.Endpoint, err)
 			}
 
-			mailer.connections = append(mailer.connections, &telemetryConnection{
+			connections = append(connections, &telemetryConnection{
 				wsconn:    conn,
 				verbosity: v.Verbosity,
 			})
@@ -71,7 +106,7 @@ func BootstrapMailer(ctx context.Context, conns []*genesis.TelemetryEndpoint, lo
 		}
 	}
 
-	return mailer, nil
+	return connections, nil
 }
 
 // SendMessage sends Message to connected telemetry listeners through messageReceiver
@@ -79,17 +114,17 @@ func (m *Mailer) SendMessage(msg json.Marshaler) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
-	go m.shipTelemetryMessage(msg)
+	go m.shipTelemetryMessage(m.connections, msg)
 }
 
-func (m *Mailer) shipTelemetryMessage(msg json.Marshaler) {
+func (m *Mailer) shipTelemetryMessage(connections []*telemetryConnection, msg json.Marshaler) {
 	msgBytes, err := json.Marshal(msg)
 	if err != nil {
 		m.logger.Debugf("issue encoding %T telemetry message: %s", msg, err)
 		return
 	}
 
-	for _, conn := range m.connections {
+	for _, conn := range connections {
 		conn.Lock()
 		defer conn.Unlock()
 
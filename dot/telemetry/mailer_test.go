@@ -284,6 +284,29 @@ func TestTelemetryMarshalMessage(t *testing.T) {
 				`"msg":"block.import","ts":"[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}:` +
 				`[0-9]{2}.[0-9]+Z|([+-][0-9]{2}:[0-9]{2})"}$`,
 		},
+		"BlockMisbehaviour_marshal": {
+			message: &BlockMisbehaviour{
+				Hash:   common.Hash{},
+				Height: 1,
+				Kind:   "bad_seal",
+				Reason: "could not verify signature",
+			},
+			expected: `^{"hash":"0x[0]{64}","height":1,"kind":"bad_seal","authority_index":null,` +
+				`"reason":"could not verify signature",` +
+				`"msg":"block.misbehaviour","ts":"[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}:` +
+				`[0-9]{2}.[0-9]+Z|([+-][0-9]{2}:[0-9]{2})"}$`,
+		},
+		"SystemServiceCrash_marshal": {
+			message: &SystemServiceCrash{
+				Service: "babe.Service",
+				Panic:   "runtime error: index out of range [1] with length 1",
+				Action:  "restart",
+			},
+			expected: `^{"service":"babe.Service","panic":"runtime error: index out of range \[1\] with length 1",` +
+				`"action":"restart",` +
+				`"msg":"system.service_crash","ts":"[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}:` +
+				`[0-9]{2}.[0-9]+Z|([+-][0-9]{2}:[0-9]{2})"}$`,
+		},
 		"NotifyFinalized_marshal": {
 			message: &NotifyFinalized{
 				Best:   common.Hash{},
@@ -0,0 +1,45 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package telemetry
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type systemServiceCrashTM SystemServiceCrash
+
+var _ json.Marshaler = (*SystemServiceCrash)(nil)
+
+// SystemServiceCrash struct to hold the telemetry messages of the panics recovered in the
+// goroutines of the node services
+type SystemServiceCrash struct {
+	Service string `json:"service"`
+	Panic   string `json:"panic"`
+	// Action is the action taken after the panic, "restart" or "shutdown"
+	Action string `json:"action"`
+}
+
+// NewSystemServiceCrash function to create new System Service Crash Telemetry Message
+func NewSystemServiceCrash(service, panicValue, action string) *SystemServiceCrash {
+	return &SystemServiceCrash{
+		Service: service,
+		Panic:   panicValue,
+		Action:  action,
+	}
+}
+
+func (sc SystemServiceCrash) MarshalJSON() ([]byte, error) {
+	telemetryData := struct {
+		systemServiceCrashTM
+		MessageType string    `json:"msg"`
+		Timestamp   time.Time `json:"ts"`
+	}{
+		Timestamp:            time.Now(),
+		MessageType:          systemServiceCrashMsg,
+		systemServiceCrashTM: systemServiceCrashTM(sc),
+	}
+
+	return json.Marshal(telemetryData)
+}
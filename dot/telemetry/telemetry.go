@@ -15,14 +15,16 @@
 	afgApplyingScheduledAuthoritySetChangeMsg = "afg.applying_scheduled_authority_set_change"
 	afgApplyingForcedAuthoritySetChangeMsg    = "afg.applying_forced_authority_set_change"
 
-	blockImportMsg = "block.import"
+	blockImportMsg       = "block.import"
+	blockMisbehaviourMsg = "block.misbehaviour"
 
 	notifyFinalizedMsg = "notify.finalized"
 
 	preparedBlockForProposingMsg = "prepared_block_for_proposing"
 
-	systemConnectedMsg = "system.connected"
-	systemIntervalMsg  = "system.interval"
+	systemConnectedMsg    = "system.connected"
+	systemIntervalMsg     = "system.interval"
+	systemServiceCrashMsg = "system.service_crash"
 
 	txPoolImportMsg = "txpool.import"
 )
@@ -0,0 +1,300 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+)
+
+// TryRuntimeMode is the runtime function executed by TryRuntime
+type TryRuntimeMode string
+
+const (
+	// TryRuntimeOnRuntimeUpgrade runs the runtime migrations on the state of the block
+	TryRuntimeOnRuntimeUpgrade TryRuntimeMode = "on-runtime-upgrade"
+	// TryRuntimeExecuteBlock executes the block on the state of its parent
+	TryRuntimeExecuteBlock TryRuntimeMode = "execute-block"
+)
+
+// UpgradeCheckSelect selects the checks run by TryRuntime_on_runtime_upgrade
+type UpgradeCheckSelect byte
+
+const (
+	// UpgradeCheckNone runs no check
+	UpgradeCheckNone UpgradeCheckSelect = iota
+	// UpgradeCheckAll runs the pre and post upgrade hooks and the try-state checks
+	UpgradeCheckAll
+	// UpgradeCheckPreAndPost runs the pre and post upgrade hooks
+	UpgradeCheckPreAndPost
+	// UpgradeCheckTryState runs the try-state checks
+	UpgradeCheckTryState
+)
+
+const tryRuntimeOnRuntimeUpgrade = "TryRuntime_on_runtime_upgrade"
+
+var errInvalidTryRuntimeMode = errors.New("invalid try-runtime mode")
+
+// TryRuntimeConfig is the configuration of a try-runtime execution
+type TryRuntimeConfig struct {
+	// RuntimeFile is the path to the WASM runtime to try
+	RuntimeFile string
+	Mode        TryRuntimeMode
+	Checks      UpgradeCheckSelect
+	// URI is the HTTP RPC endpoint of the node to fetch the state from.
+	// If it is empty, the state is read from the database at BasePath.
+	URI      string
+	BasePath string
+	// Block is the block to run the runtime at, the best block if nil
+	Block        *common.Hash
+	StateVersion trie.TrieLayout
+}
+
+// StorageDiff is a storage entry modified by the runtime, with
+// a nil Before value if it was added and a nil After value if it was deleted.
+type StorageDiff struct {
+	Key    []byte
+	Before []byte
+	After  []byte
+}
+
+// TryRuntimeResult is the result of a try-runtime execution
+type TryRuntimeResult struct {
+	Block  common.Hash
+	Output []byte
+	Diffs  []StorageDiff
+}
+
+// tryRuntimeSource is the source of the blocks and states a runtime is tried against
+type tryRuntimeSource interface {
+	block(hash *common.Hash) (*types.Block, error)
+	state(blockHash common.Hash, header *types.Header) (trie.Trie, error)
+	close() error
+}
+
+// TryRuntime executes the runtime given against the state of a live chain, read
+// either from a remote node or from the local database, without persisting anything.
+// It returns the output of the runtime function executed and the storage entries it modified.
+func TryRuntime(config TryRuntimeConfig) (*TryRuntimeResult, error) {
+	code, err := os.ReadFile(filepath.Clean(config.RuntimeFile))
+	if err != nil {
+		return nil, fmt.Errorf("reading runtime file: %w", err)
+	}
+
+	var source tryRuntimeSource
+	if config.URI != "" {
+		source = &remoteTryRuntimeSource{node: &remoteNode{uri: config.URI}, stateVersion: config.StateVersion}
+	} else {
+		source, err = newLocalTryRuntimeSource(config.BasePath)
+		if err != nil {
+			return nil, err
+		}
+	}
+	defer func() {
+		closeErr := source.close()
+		if closeErr != nil {
+			logger.Errorf("closing try-runtime state source: %s", closeErr)
+		}
+	}()
+
+	block, err := source.block(config.Block)
+	if err != nil {
+		return nil, fmt.Errorf("getting block: %w", err)
+	}
+	blockHash := block.Header.Hash()
+
+	var stateTrie trie.Trie
+	switch config.Mode {
+	case TryRuntimeOnRuntimeUpgrade:
+		stateTrie, err = source.state(blockHash, &block.Header)
+	case TryRuntimeExecuteBlock:
+		var parent *types.Block
+		parent, err = source.block(&block.Header.ParentHash)
+		if err != nil {
+			return nil, fmt.Errorf("getting parent block: %w", err)
+		}
+		stateTrie, err = source.state(block.Header.ParentHash, &parent.Header)
+	default:
+		return nil, fmt.Errorf("%w: %s", errInvalidTryRuntimeMode, config.Mode)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting state: %w", err)
+	}
+
+	before := stateTrie.Entries()
+	trieState := rtstorage.NewTrieState(stateTrie)
+	if config.Mode == TryRuntimeOnRuntimeUpgrade {
+		// the upgraded runtime code is stored before its migrations run, whereas a
+		// block is executed on the unmodified parent state to keep its state root valid.
+		err = trieState.Put(common.CodeKey, code)
+		if err != nil {
+			return nil, fmt.Errorf("putting runtime code in state: %w", err)
+		}
+	}
+
+	instance, err := newTryRuntimeInstance(code, trieState)
+	if err != nil {
+		return nil, fmt.Errorf("creating runtime instance: %w", err)
+	}
+	defer instance.Stop()
+
+	var output []byte
+	switch config.Mode {
+	case TryRuntimeOnRuntimeUpgrade:
+		output, err = instance.Exec(tryRuntimeOnRuntimeUpgrade, []byte{byte(config.Checks)})
+	case TryRuntimeExecuteBlock:
+		output, err = instance.ExecuteBlock(block)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("executing %s at block %s: %w", config.Mode, blockHash, err)
+	}
+
+	return &TryRuntimeResult{
+		Block:  blockHash,
+		Output: output,
+		Diffs:  storageDiffs(before, trieState.Trie().Entries()),
+	}, nil
+}
+
+func newTryRuntimeInstance(code []byte, storage runtime.Storage) (*wazero_runtime.Instance, error) {
+	localStorage, err := newInMemoryDB()
+	if err != nil {
+		return nil, err
+	}
+
+	persistentStorage, err := newInMemoryDB()
+	if err != nil {
+		return nil, err
+	}
+
+	baseDB, err := newInMemoryDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return wazero_runtime.NewInstance(code, wazero_runtime.Config{
+		Storage:  storage,
+		Keystore: keystore.NewGlobalKeystore(),
+		LogLvl:   log.Info,
+		Role:     common.NoNetworkRole,
+		NodeStorage: runtime.NodeStorage{
+			LocalStorage:      localStorage,
+			PersistentStorage: persistentStorage,
+			BaseDB:            baseDB,
+		},
+	})
+}
+
+// storageDiffs returns the entries differing between the two key value maps given, sorted by key.
+func storageDiffs(before, after map[string][]byte) (diffs []StorageDiff) {
+	for key, beforeValue := range before {
+		afterValue, ok := after[key]
+		if ok && bytes.Equal(beforeValue, afterValue) {
+			continue
+		}
+		diffs = append(diffs, StorageDiff{Key: []byte(key), Before: beforeValue, After: afterValue})
+	}
+
+	for key, afterValue := range after {
+		_, ok := before[key]
+		if ok {
+			continue
+		}
+		diffs = append(diffs, StorageDiff{Key: []byte(key), After: afterValue})
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return bytes.Compare(diffs[i].Key, diffs[j].Key) < 0
+	})
+	return diffs
+}
+
+// localTryRuntimeSource reads the blocks and states from the node database
+type localTryRuntimeSource struct {
+	stateSrvc *state.Service
+}
+
+func newLocalTryRuntimeSource(basePath string) (*localTryRuntimeSource, error) {
+	stateSrvc, err := newOfflineStateService(basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &localTryRuntimeSource{stateSrvc: stateSrvc}, nil
+}
+
+func (s *localTryRuntimeSource) block(hash *common.Hash) (*types.Block, error) {
+	if hash == nil {
+		bestBlockHash := s.stateSrvc.Block.BestBlockHash()
+		hash = &bestBlockHash
+	}
+
+	return s.stateSrvc.Block.GetBlockByHash(*hash)
+}
+
+func (s *localTryRuntimeSource) state(_ common.Hash, header *types.Header) (trie.Trie, error) {
+	trieState, err := s.stateSrvc.Storage.TrieState(&header.StateRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	return trieState.Trie(), nil
+}
+
+func (s *localTryRuntimeSource) close() error {
+	return s.stateSrvc.Stop()
+}
+
+// remoteTryRuntimeSource fetches the blocks and states from a node using its HTTP RPC endpoint
+type remoteTryRuntimeSource struct {
+	node         *remoteNode
+	stateVersion trie.TrieLayout
+}
+
+func (s *remoteTryRuntimeSource) block(hash *common.Hash) (*types.Block, error) {
+	return s.node.block(hash)
+}
+
+func (s *remoteTryRuntimeSource) state(blockHash common.Hash, header *types.Header) (trie.Trie, error) {
+	entries, err := s.node.storageEntries(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	stateTrie, err := inmemory_trie.LoadFromMap(entries, s.stateVersion)
+	if err != nil {
+		return nil, fmt.Errorf("loading trie: %w", err)
+	}
+
+	stateRoot, err := stateTrie.Hash()
+	if err != nil {
+		return nil, fmt.Errorf("hashing trie: %w", err)
+	}
+
+	if stateRoot != header.StateRoot {
+		return nil, fmt.Errorf("state root %s of fetched state does not match state root %s of block %s",
+			stateRoot, header.StateRoot, blockHash)
+	}
+
+	return stateTrie, nil
+}
+
+func (*remoteTryRuntimeSource) close() error {
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_storageDiffs(t *testing.T) {
+	t.Parallel()
+
+	before := map[string][]byte{
+		"deleted":   {1},
+		"modified":  {2},
+		"unchanged": {3},
+	}
+	after := map[string][]byte{
+		"added":     {4},
+		"modified":  {5},
+		"unchanged": {3},
+	}
+
+	expected := []StorageDiff{
+		{Key: []byte("added"), After: []byte{4}},
+		{Key: []byte("deleted"), Before: []byte{1}},
+		{Key: []byte("modified"), Before: []byte{2}, After: []byte{5}},
+	}
+	assert.Equal(t, expected, storageDiffs(before, after))
+}
+
+func Test_remoteTryRuntimeSource_state(t *testing.T) {
+	t.Parallel()
+
+	entries := map[string]string{
+		"0x01": "0xaa",
+		"0x02": "0xbb",
+	}
+	expectedTrie, err := inmemory_trie.LoadFromMap(entries, trie.V0)
+	require.NoError(t, err)
+	stateRoot, err := expectedTrie.Hash()
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Method string `json:"method"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&request)
+		require.NoError(t, err)
+
+		var result string
+		switch request.Method {
+		case "state_getKeysPaged":
+			result = `["0x01","0x02"]`
+		case "state_queryStorageAt":
+			result = `[{"block":"0x00","changes":[["0x01","0xaa"],["0x02","0xbb"]]}]`
+		default:
+			t.Errorf("unexpected method %s", request.Method)
+		}
+
+		_, err = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":` + result + `}`))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	source := &remoteTryRuntimeSource{node: &remoteNode{uri: server.URL}, stateVersion: trie.V0}
+
+	stateTrie, err := source.state(common.Hash{1}, &types.Header{StateRoot: stateRoot})
+	require.NoError(t, err)
+	assert.Equal(t, expectedTrie.Entries(), stateTrie.Entries())
+
+	_, err = source.state(common.Hash{1}, &types.Header{StateRoot: common.Hash{2}})
+	assert.ErrorContains(t, err, "does not match state root")
+}
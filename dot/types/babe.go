@@ -6,6 +6,8 @@
 import (
 	"errors"
 	"fmt"
+
+	"github.com/ChainSafe/gossamer/pkg/scale"
 )
 
 var ErrNoFirstPreDigest = errors.New("first digest item is not pre-digest")
@@ -29,6 +31,7 @@
 var (
 	ErrChainHeadMissingDigest = errors.New("chain head missing digest")
 	ErrGenesisHeader          = errors.New("genesis header doesn't have a slot")
+	ErrInvalidAllowedSlots    = errors.New("invalid allowed slots")
 )
 
 // BabeConfiguration contains the genesis data for BABE
@@ -106,6 +109,27 @@ type ConfigData struct {
 	SecondarySlots byte
 }
 
+// SlotClaim is a slot won by the local BABE authority, with the pre-runtime digest
+// of the block to author in it
+type SlotClaim struct {
+	Slot             uint64
+	PreRuntimeDigest PreRuntimeDigest
+}
+
+// EpochSlotClaims are the slots won by the local BABE authority in an epoch, along with
+// the descriptor of the epoch the slot lottery was run for
+type EpochSlotClaims struct {
+	Epoch          uint64
+	StartSlot      uint64
+	EndSlot        uint64
+	Randomness     [RandomnessLength]byte
+	Authorities    []AuthorityRaw
+	AuthorityIndex uint32
+	Threshold      *scale.Uint128
+	AllowedSlots   AllowedSlots
+	Claims         []SlotClaim
+}
+
 // GetSlotFromHeader returns the BABE slot from the given header
 func GetSlotFromHeader(header *Header) (uint64, error) {
 	if header.Number == 0 {
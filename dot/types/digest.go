@@ -143,6 +143,12 @@ func (h ConsensusEngineID) String() string {
 // GrandpaEngineID is the hard-coded grandpa ID
 var GrandpaEngineID = ConsensusEngineID{'F', 'R', 'N', 'K'}
 
+// AuraEngineID is the hard-coded aura ID
+var AuraEngineID = ConsensusEngineID{'a', 'u', 'r', 'a'}
+
+// BeefyEngineID is the hard-coded beefy ID
+var BeefyEngineID = ConsensusEngineID{'B', 'E', 'E', 'F'}
+
 // PreRuntimeDigest contains messages from the consensus engine to the runtime.
 type PreRuntimeDigest digestItem
 
@@ -154,6 +160,14 @@ func NewBABEPreRuntimeDigest(data []byte) *PreRuntimeDigest {
 	}
 }
 
+// NewAuraPreRuntimeDigest returns a PreRuntimeDigest with the Aura consensus ID
+func NewAuraPreRuntimeDigest(data []byte) *PreRuntimeDigest {
+	return &PreRuntimeDigest{
+		ConsensusEngineID: AuraEngineID,
+		Data:              data,
+	}
+}
+
 // String returns the digest as a string
 func (d PreRuntimeDigest) String() string {
 	return fmt.Sprintf("PreRuntimeDigest ConsensusEngineID=%s Data=0x%x", d.ConsensusEngineID.ToBytes(), d.Data)
@@ -197,6 +197,16 @@ func (v GrandpaVote) String() string {
 	return fmt.Sprintf("hash=%s number=%d", v.Hash, v.Number)
 }
 
+// GrandpaVoterState is the round in progress of a GRANDPA voter with the votes it cast in it,
+// a nil vote being a vote not cast yet
+type GrandpaVoterState struct {
+	SetID           uint64
+	Round           uint64
+	PrimaryProposal *GrandpaVote
+	Prevote         *GrandpaVote
+	Precommit       *GrandpaVote
+}
+
 // GrandpaEquivocation is used to create a proof of equivocation
 // https://github.com/paritytech/finality-grandpa/blob/19d251d0b0105d51a79d3c4532a9aae75a5035bd/src/lib.rs#L213 //nolint:lll
 type GrandpaEquivocation struct {
@@ -27,6 +27,10 @@
 	Parachn0
 	// Newheads is an inherent key for new minimally-attested parachain heads.
 	Newheads
+	// Auraslot is the Aura inherent identifier.
+	Auraslot
+	// Sysi1337 is the inherent key for the parachain system validation data inherent.
+	Sysi1337
 )
 
 // Bytes returns a byte array of given inherent identifier.
@@ -44,6 +48,10 @@ func (ii InherentIdentifier) Bytes() [8]byte {
 		copy(kb[:], []byte("parachn0"))
 	case Newheads:
 		copy(kb[:], []byte("newheads"))
+	case Auraslot:
+		copy(kb[:], []byte("auraslot"))
+	case Sysi1337:
+		copy(kb[:], []byte("sysi1337"))
 	default:
 		panic("invalid inherent identifier")
 	}
@@ -0,0 +1,310 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/genesis"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+)
+
+// SpecIssueSeverity is the severity of a chain spec issue
+type SpecIssueSeverity string
+
+const (
+	// SpecIssueError is an issue preventing the node from initialising with the chain spec
+	SpecIssueError SpecIssueSeverity = "error"
+	// SpecIssueWarning is an issue the node can initialise with, but likely not what was intended
+	SpecIssueWarning SpecIssueSeverity = "warning"
+)
+
+// SpecIssue is an issue found in a chain spec
+type SpecIssue struct {
+	Severity SpecIssueSeverity
+	// Location is the field, storage key or file position the issue is at
+	Location string
+	Message  string
+}
+
+func (i SpecIssue) String() string {
+	return fmt.Sprintf("%s: %s: %s", i.Severity, i.Location, i.Message)
+}
+
+// SpecReport is the result of the validation of a chain spec
+type SpecReport struct {
+	Name string
+	ID   string
+	// Raw is true if the chain spec file is a raw chain spec
+	Raw bool
+	// Entries is the number of top level genesis storage entries
+	Entries        int
+	RuntimeVersion *runtime.Version
+	// StateRoot is the genesis state root, as computed when initialising the node
+	StateRoot   common.Hash
+	GenesisHash common.Hash
+	Issues      []SpecIssue
+}
+
+// Valid returns true if no error was found in the chain spec
+func (r *SpecReport) Valid() bool {
+	for _, issue := range r.Issues {
+		if issue.Severity == SpecIssueError {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *SpecReport) addIssue(severity SpecIssueSeverity, location, format string, args ...any) {
+	r.Issues = append(r.Issues, SpecIssue{
+		Severity: severity,
+		Location: location,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// ValidateSpecConfig is the configuration of a chain spec validation
+type ValidateSpecConfig struct {
+	File string
+	// GenesisHash is the expected genesis block hash, not checked if nil
+	GenesisHash *common.Hash
+}
+
+// ValidateSpec parses the plain or raw chain spec file given and checks the consistency of
+// its genesis storage: the authorities must be decodable, the runtime code must be a valid
+// WASM runtime and the genesis block hash must match the expected one, if any.
+// The issues found are listed in the report returned, and an error is only returned
+// if the file cannot be read.
+func ValidateSpec(config ValidateSpecConfig) (*SpecReport, error) {
+	data, err := os.ReadFile(filepath.Clean(config.File))
+	if err != nil {
+		return nil, fmt.Errorf("reading chain spec file: %w", err)
+	}
+
+	report := &SpecReport{}
+
+	gen := new(genesis.Genesis)
+	err = json.Unmarshal(data, gen)
+	if err != nil {
+		report.addIssue(SpecIssueError, jsonErrorLocation(data, err), "invalid JSON: %s", err)
+		return report, nil
+	}
+
+	report.Name = gen.Name
+	report.ID = gen.ID
+	report.Raw = gen.IsRaw()
+	if gen.Name == "" {
+		report.addIssue(SpecIssueError, "name", "chain name is empty")
+	}
+	if gen.ID == "" {
+		report.addIssue(SpecIssueError, "id", "chain id is empty, it is used to name the node base path")
+	}
+	if gen.ProtocolID == "" {
+		report.addIssue(SpecIssueWarning, "protocolId", "protocol id is empty, the default protocol id is used")
+	}
+
+	if !report.Raw {
+		err = wazero_runtime.GenesisToRaw(gen)
+		if err != nil {
+			report.addIssue(SpecIssueError, "genesis.runtime",
+				"cannot convert the plain genesis to raw storage: %s", err)
+			return report, nil
+		}
+	}
+
+	top, ok := gen.Genesis.Raw["top"]
+	if !ok {
+		report.addIssue(SpecIssueError, "genesis.raw.top", "top level genesis storage is missing")
+		return report, nil
+	}
+	report.Entries = len(top)
+
+	storage := validateSpecStorage(report, top)
+	validateSpecAuthorities(report, storage)
+
+	stateVersion := trie.V0
+	code, ok := storage[common.BytesToHex(common.CodeKey)]
+	if !ok {
+		report.addIssue(SpecIssueError, "genesis.raw.top[:code]", "runtime code is missing")
+	} else {
+		version, err := wazero_runtime.GetRuntimeVersion(code)
+		if err != nil {
+			report.addIssue(SpecIssueError, "genesis.raw.top[:code]",
+				"runtime code is not a valid WASM runtime: %s", err)
+		} else {
+			report.RuntimeVersion = &version
+			stateVersion, err = trie.ParseVersion(version.StateVersion)
+			if err != nil {
+				report.addIssue(SpecIssueError, "genesis.raw.top[:code]",
+					"runtime has an invalid state version: %s", err)
+			}
+		}
+	}
+
+	if !report.Valid() {
+		return report, nil
+	}
+
+	validateSpecStateRoot(report, *gen, stateVersion, config.GenesisHash)
+	return report, nil
+}
+
+// validateSpecStorage checks the genesis storage keys and values are hex encoded,
+// and returns the decoded values by key of the valid entries.
+func validateSpecStorage(report *SpecReport, top map[string]string) (storage map[string][]byte) {
+	keys := make([]string, 0, len(top))
+	for key := range top {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	storage = make(map[string][]byte, len(top))
+	for _, key := range keys {
+		location := fmt.Sprintf("genesis.raw.top[%s]", key)
+		keyBytes, err := common.HexToBytes(key)
+		if err != nil {
+			report.addIssue(SpecIssueError, location, "storage key is not hex encoded: %s", err)
+			continue
+		}
+
+		value, err := common.HexToBytes(top[key])
+		if err != nil {
+			report.addIssue(SpecIssueError, location, "storage value is not hex encoded: %s", err)
+			continue
+		}
+
+		storage[common.BytesToHex(keyBytes)] = value
+	}
+
+	return storage
+}
+
+// validateSpecAuthorities checks the BABE and GRANDPA genesis authorities, if any, are decodable.
+func validateSpecAuthorities(report *SpecReport, storage map[string][]byte) {
+	babeAuthorities, ok := storage[genesis.BABEAuthoritiesKeyHex]
+	if ok {
+		err := decodeSpecAuthorities(babeAuthorities, crypto.Sr25519Type)
+		if err != nil {
+			report.addIssue(SpecIssueError, "genesis.raw.top[Babe.Authorities]",
+				"cannot decode BABE authorities: %s", err)
+		}
+	}
+
+	grandpaAuthorities, ok := storage[genesis.GrandpaAuthoritiesKeyHex]
+	if ok {
+		// the GRANDPA authorities are prefixed with the version of their encoding.
+		const grandpaAuthoritiesVersion = 1
+		switch {
+		case len(grandpaAuthorities) == 0 || grandpaAuthorities[0] != grandpaAuthoritiesVersion:
+			report.addIssue(SpecIssueError, "genesis.raw.top[:grandpa_authorities]",
+				"GRANDPA authorities must start with the version byte 0x01")
+		default:
+			err := decodeSpecAuthorities(grandpaAuthorities[1:], crypto.Ed25519Type)
+			if err != nil {
+				report.addIssue(SpecIssueError, "genesis.raw.top[:grandpa_authorities]",
+					"cannot decode GRANDPA authorities: %s", err)
+			}
+		}
+	}
+
+	if len(babeAuthorities) == 0 && len(grandpaAuthorities) == 0 {
+		report.addIssue(SpecIssueWarning, "genesis.raw.top",
+			"no BABE nor GRANDPA authorities in genesis storage")
+	}
+}
+
+func decodeSpecAuthorities(encoded []byte, keyType crypto.KeyType) error {
+	var authorities []types.AuthorityRaw
+	err := scale.Unmarshal(encoded, &authorities)
+	if err != nil {
+		return err
+	}
+
+	if len(authorities) == 0 {
+		return errors.New("authority set is empty")
+	}
+
+	_, err = types.AuthoritiesRawToAuthorityAsAddress(authorities, keyType)
+	return err
+}
+
+// validateSpecStateRoot computes the genesis state root and block hash as the node does when
+// initialising, and compares the block hash with the expected genesis hash, if any.
+func validateSpecStateRoot(report *SpecReport, gen genesis.Genesis,
+	runtimeStateVersion trie.TrieLayout, expectedGenesisHash *common.Hash) {
+	genesisTrie, err := runtime.NewTrieFromGenesis(gen)
+	if err != nil {
+		report.addIssue(SpecIssueError, "genesis.raw.top", "cannot build genesis trie: %s", err)
+		return
+	}
+
+	header, err := runtime.GenesisBlockFromTrie(genesisTrie)
+	if err != nil {
+		report.addIssue(SpecIssueError, "genesis.raw.top", "cannot build genesis block: %s", err)
+		return
+	}
+	report.StateRoot = header.StateRoot
+	report.GenesisHash = header.Hash()
+
+	if runtimeStateVersion != trie.V0 {
+		versionedTrie, err := inmemory_trie.LoadFromMap(gen.Genesis.Raw["top"], runtimeStateVersion)
+		if err != nil {
+			report.addIssue(SpecIssueError, "genesis.raw.top", "cannot build genesis trie: %s", err)
+			return
+		}
+
+		versionedRoot, err := versionedTrie.Hash()
+		if err != nil {
+			report.addIssue(SpecIssueError, "genesis.raw.top", "cannot hash genesis trie: %s", err)
+			return
+		}
+
+		if versionedRoot != report.StateRoot {
+			report.addIssue(SpecIssueWarning, "genesis.raw.top",
+				"genesis state root %s differs from state root %s with the runtime state version %s",
+				report.StateRoot, versionedRoot, runtimeStateVersion)
+		}
+	}
+
+	if expectedGenesisHash != nil && *expectedGenesisHash != report.GenesisHash {
+		report.addIssue(SpecIssueError, "genesis",
+			"genesis hash %s does not match expected genesis hash %s, the chain spec is not the one of the chain",
+			report.GenesisHash, *expectedGenesisHash)
+	}
+}
+
+// jsonErrorLocation returns the line and column of the JSON decoding error given, if known.
+func jsonErrorLocation(data []byte, err error) string {
+	var offset int64
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	default:
+		return "file"
+	}
+
+	// the offset is the one of the byte following the error.
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := len(before) - bytes.LastIndexByte(before, '\n') - 1
+	return fmt.Sprintf("line %d, column %d", line, column)
+}
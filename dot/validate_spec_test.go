@@ -0,0 +1,103 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/genesis"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSpec(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		spec           string
+		expectedIssues []SpecIssue
+	}{
+		"invalid_json": {
+			spec: "{\n\"name\": \"test\",\n\"id\" 1\n}",
+			expectedIssues: []SpecIssue{{
+				Severity: SpecIssueError,
+				Location: "line 3, column 6",
+				Message:  "invalid JSON: invalid character '1' after object key",
+			}},
+		},
+		"missing_top": {
+			spec: `{"name": "test", "id": "test", "protocolId": "test", "genesis": {"raw": {}}}`,
+			expectedIssues: []SpecIssue{{
+				Severity: SpecIssueError,
+				Location: "genesis.raw.top",
+				Message:  "top level genesis storage is missing",
+			}},
+		},
+		"invalid_storage": {
+			spec: `{"name": "test", "id": "", "protocolId": "test", "genesis": {"raw": {"top": {
+				"0x3a636f6465": "0xzz",
+				"` + genesis.GrandpaAuthoritiesKeyHex + `": "0x00"
+			}}}}`,
+			expectedIssues: []SpecIssue{
+				{
+					Severity: SpecIssueError,
+					Location: "id",
+					Message:  "chain id is empty, it is used to name the node base path",
+				},
+				{
+					Severity: SpecIssueError,
+					Location: "genesis.raw.top[0x3a636f6465]",
+					Message:  "storage value is not hex encoded: encoding/hex: invalid byte: U+007A 'z': 0xzz",
+				},
+				{
+					Severity: SpecIssueError,
+					Location: "genesis.raw.top[:grandpa_authorities]",
+					Message:  "GRANDPA authorities must start with the version byte 0x01",
+				},
+				{
+					Severity: SpecIssueError,
+					Location: "genesis.raw.top[:code]",
+					Message:  "runtime code is missing",
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			file := filepath.Join(t.TempDir(), "spec.json")
+			err := os.WriteFile(file, []byte(testCase.spec), os.ModePerm)
+			require.NoError(t, err)
+
+			report, err := ValidateSpec(ValidateSpecConfig{File: file})
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedIssues, report.Issues)
+			assert.False(t, report.Valid())
+		})
+	}
+}
+
+func TestValidateSpec_Polkadot(t *testing.T) {
+	t.Parallel()
+
+	genesisHash := common.MustHexToHash("0x91b171bb158e2d3848fa23a9f1c25182fb8e20313b2c1eb49219da7a70ce90c3")
+	report, err := ValidateSpec(ValidateSpecConfig{
+		File:        utils.GetPolkadotGenesisPath(t),
+		GenesisHash: &genesisHash,
+	})
+	require.NoError(t, err)
+
+	assert.Empty(t, report.Issues)
+	assert.True(t, report.Raw)
+	assert.Equal(t, genesisHash, report.GenesisHash)
+	require.NotNil(t, report.RuntimeVersion)
+	assert.Equal(t, "polkadot", string(report.RuntimeVersion.SpecName))
+}
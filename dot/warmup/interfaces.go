@@ -0,0 +1,26 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package warmup
+
+import (
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+)
+
+// BlockState is the interface for the block state methods
+type BlockState interface {
+	BestBlockHeader() (*types.Header, error)
+	GetHeader(hash common.Hash) (*types.Header, error)
+	GetBlockByHash(hash common.Hash) (*types.Block, error)
+	GetRuntime(blockHash common.Hash) (instance runtime.Instance, err error)
+}
+
+// StorageState is the interface for the storage state methods
+type StorageState interface {
+	TrieState(root *common.Hash) (*rtstorage.TrieState, error)
+	Lock()
+	Unlock()
+}
@@ -0,0 +1,199 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package warmup warms the caches of a restarted node in the background, by replaying the
+// storage reads of its most recent blocks, so the first blocks it imports or authors do not
+// wait on the database.
+package warmup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/lib/services"
+)
+
+var logger = log.NewFromGlobal(log.AddContext("pkg", "warmup"))
+
+var errStateRootMismatch = errors.New("state root mismatch")
+
+// Service warms the caches once started. It loads the state of the best block, for the next
+// block to be executed or authored on top of it, and replays the most recent blocks, executing
+// them again with the runtime of the best block from the state of the parent of the oldest
+// one. Loading the states from the database fills the trie node cache, and the executions run
+// the runtime on the storage the next blocks read.
+type Service struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	blockState BlockState
+	storage    StorageState
+	blocks     uint
+}
+
+// NewService returns a service warming the caches with the number of most recent blocks given.
+func NewService(blockState BlockState, storage StorageState, blocks uint) *Service {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Service{
+		ctx:        ctx,
+		cancel:     cancel,
+		blockState: blockState,
+		storage:    storage,
+		blocks:     blocks,
+	}
+}
+
+// Start starts warming the caches in the background
+func (s *Service) Start() error {
+	s.wg.Add(1)
+	services.Go(s, func() {
+		defer s.wg.Done()
+		start := time.Now()
+		replayed, err := s.warm()
+		if err != nil {
+			logger.Warnf("warming caches after replaying %d blocks: %s", replayed, err)
+			return
+		}
+		logger.Infof("🔥 warmed caches replaying the last %d blocks in %s", replayed, time.Since(start))
+	})
+	return nil
+}
+
+// Stop stops warming the caches
+func (s *Service) Stop() error {
+	s.cancel()
+	s.wg.Wait()
+	return nil
+}
+
+// warm loads the state of the best block and replays the most recent blocks, and returns the
+// number of blocks replayed.
+func (s *Service) warm() (replayed uint, err error) {
+	best, err := s.blockState.BestBlockHeader()
+	if err != nil {
+		return 0, fmt.Errorf("getting best block header: %w", err)
+	}
+
+	// the state of the best block is loaded first, being read by the next block imported or authored
+	bestState, err := s.loadState(best.StateRoot)
+	if err != nil {
+		return 0, fmt.Errorf("loading state of best block: %w", err)
+	}
+	codeHash, err := common.Blake2bHash(bestState.LoadCode())
+	if err != nil {
+		return 0, fmt.Errorf("hashing runtime code: %w", err)
+	}
+
+	headers, err := s.recentHeaders(best)
+	if err != nil {
+		return 0, err
+	}
+	if len(headers) == 0 {
+		return 0, nil
+	}
+	return s.replay(best.Hash(), codeHash, headers)
+}
+
+// recentHeaders returns the headers of the most recent blocks up to the best block given, from
+// the oldest to the best block, the genesis block having no parent to be replayed on.
+func (s *Service) recentHeaders(best *types.Header) ([]*types.Header, error) {
+	var headers []*types.Header
+	for header := best; header.Number > 0 && uint(len(headers)) < s.blocks; {
+		headers = append(headers, header)
+
+		parent, err := s.blockState.GetHeader(header.ParentHash)
+		if err != nil {
+			return nil, fmt.Errorf("getting parent header of block #%d: %w", header.Number, err)
+		}
+		header = parent
+	}
+	slices.Reverse(headers)
+	return headers, nil
+}
+
+// replay executes the blocks of the headers given one after the other, each block on the state
+// left by the execution of its parent, with the runtime of the best block of the hash given.
+// The blocks are not replayed if the runtime code of the parent of the oldest block is not the
+// code of the hash given, the runtime having been upgraded since.
+func (s *Service) replay(bestHash, codeHash common.Hash, headers []*types.Header) (replayed uint, err error) {
+	oldest := headers[0]
+	parent, err := s.blockState.GetHeader(oldest.ParentHash)
+	if err != nil {
+		return 0, fmt.Errorf("getting parent header of block #%d: %w", oldest.Number, err)
+	}
+	state, err := s.loadState(parent.StateRoot)
+	if err != nil {
+		return 0, fmt.Errorf("loading state of parent of block #%d: %w", oldest.Number, err)
+	}
+
+	parentCodeHash, err := common.Blake2bHash(state.LoadCode())
+	if err != nil {
+		return 0, fmt.Errorf("hashing runtime code: %w", err)
+	}
+	if parentCodeHash != codeHash {
+		logger.Debugf("not replaying the blocks from block #%d, the runtime code changed since", oldest.Number)
+		return 0, nil
+	}
+
+	instance, err := s.blockState.GetRuntime(bestHash)
+	if err != nil {
+		return 0, fmt.Errorf("getting runtime of best block: %w", err)
+	}
+
+	for _, header := range headers {
+		if s.ctx.Err() != nil {
+			return replayed, s.ctx.Err()
+		}
+
+		err = s.execute(instance, state, header)
+		if err != nil {
+			return replayed, fmt.Errorf("replaying block #%d (%s): %w", header.Number, header.Hash(), err)
+		}
+		replayed++
+	}
+	return replayed, nil
+}
+
+// loadState returns the state of the root given, loaded from the database through the trie
+// node cache if it is not already in memory.
+func (s *Service) loadState(root common.Hash) (*rtstorage.TrieState, error) {
+	s.storage.Lock()
+	defer s.storage.Unlock()
+	return s.storage.TrieState(&root)
+}
+
+// execute executes the block of the header given on the state given, which is left with the
+// state of the block.
+func (s *Service) execute(instance runtime.Instance, state *rtstorage.TrieState, header *types.Header) error {
+	block, err := s.blockState.GetBlockByHash(header.Hash())
+	if err != nil {
+		return fmt.Errorf("getting block: %w", err)
+	}
+
+	// the runtime instance is shared with the block import, which sets its storage while
+	// holding the storage state lock
+	s.storage.Lock()
+	defer s.storage.Unlock()
+
+	instance.SetContextStorage(state)
+	_, err = instance.ExecuteBlock(block)
+	if err != nil {
+		return fmt.Errorf("executing block: %w", err)
+	}
+
+	stateRoot := state.Trie().MustHash()
+	if stateRoot != header.StateRoot {
+		return fmt.Errorf("%w: expected %s, got %s", errStateRootMismatch, header.StateRoot, stateRoot)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package warmup
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/runtime/mocks"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var testKey = []byte("key")
+
+// testChain is the block state and storage state of a chain whose blocks set the test key
+// to their number
+type testChain struct {
+	sync.Mutex
+	headers  []*types.Header
+	tries    map[common.Hash]*inmemory_trie.InMemoryTrie
+	instance runtime.Instance
+	// loaded are the state roots of the states loaded
+	loaded []common.Hash
+}
+
+// newTestChain returns a chain of the number of blocks given following the genesis block,
+// the block of the upgrade number given setting another runtime code if it is not zero.
+func newTestChain(t *testing.T, blocks, upgrade uint) *testChain {
+	t.Helper()
+
+	chain := &testChain{tries: make(map[common.Hash]*inmemory_trie.InMemoryTrie)}
+	trie := inmemory_trie.NewEmptyTrie()
+	require.NoError(t, trie.Put(common.CodeKey, []byte{1}))
+	var parentHash common.Hash
+	for number := uint(0); number <= blocks; number++ {
+		trie = trie.Snapshot()
+		require.NoError(t, trie.Put(testKey, []byte{byte(number)}))
+		if number == upgrade && upgrade != 0 {
+			require.NoError(t, trie.Put(common.CodeKey, []byte{2}))
+		}
+		header := &types.Header{
+			ParentHash: parentHash,
+			Number:     number,
+			StateRoot:  trie.MustHash(),
+			Digest:     types.NewDigest(),
+		}
+		chain.headers = append(chain.headers, header)
+		chain.tries[header.StateRoot] = trie
+		parentHash = header.Hash()
+	}
+	return chain
+}
+
+func (c *testChain) BestBlockHeader() (*types.Header, error) {
+	return c.headers[len(c.headers)-1], nil
+}
+
+func (c *testChain) GetHeader(hash common.Hash) (*types.Header, error) {
+	for _, header := range c.headers {
+		if header.Hash() == hash {
+			return header, nil
+		}
+	}
+	return nil, errors.New("header not found")
+}
+
+func (c *testChain) GetBlockByHash(hash common.Hash) (*types.Block, error) {
+	header, err := c.GetHeader(hash)
+	if err != nil {
+		return nil, err
+	}
+	return &types.Block{Header: *header}, nil
+}
+
+func (c *testChain) GetRuntime(common.Hash) (runtime.Instance, error) {
+	return c.instance, nil
+}
+
+func (c *testChain) TrieState(root *common.Hash) (*rtstorage.TrieState, error) {
+	c.loaded = append(c.loaded, *root)
+	return rtstorage.NewTrieState(c.tries[*root].Snapshot()), nil
+}
+
+// newTestInstance returns a runtime instance executing the number of blocks given, each block
+// setting the test key of its storage to its number.
+func newTestInstance(ctrl *gomock.Controller, blocks int) runtime.Instance {
+	instance := mocks.NewMockInstance(ctrl)
+	var storage runtime.Storage
+	instance.EXPECT().SetContextStorage(gomock.Any()).Times(blocks).Do(func(s runtime.Storage) {
+		storage = s
+	})
+	instance.EXPECT().ExecuteBlock(gomock.Any()).Times(blocks).DoAndReturn(func(block *types.Block) ([]byte, error) {
+		return nil, storage.Put(testKey, []byte{byte(block.Header.Number)})
+	})
+	return instance
+}
+
+func TestService_warm(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		blocks           uint
+		upgrade          uint
+		expectedReplayed uint
+		// expectedLoaded are the numbers of the blocks whose state is loaded
+		expectedLoaded []uint
+	}{
+		"no_replay": {
+			expectedLoaded: []uint{3},
+		},
+		"replay": {
+			blocks:           2,
+			expectedReplayed: 2,
+			expectedLoaded:   []uint{3, 1},
+		},
+		"replay_from_genesis": {
+			blocks:           10,
+			expectedReplayed: 3,
+			expectedLoaded:   []uint{3, 0},
+		},
+		"runtime_upgraded": {
+			blocks:         2,
+			upgrade:        2,
+			expectedLoaded: []uint{3, 1},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			chain := newTestChain(t, 3, testCase.upgrade)
+			chain.instance = newTestInstance(ctrl, int(testCase.expectedReplayed))
+			s := NewService(chain, chain, testCase.blocks)
+
+			replayed, err := s.warm()
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expectedReplayed, replayed)
+
+			expectedLoaded := make([]common.Hash, len(testCase.expectedLoaded))
+			for i, number := range testCase.expectedLoaded {
+				expectedLoaded[i] = chain.headers[number].StateRoot
+			}
+			assert.Equal(t, expectedLoaded, chain.loaded)
+		})
+	}
+}
+
+func TestService_warm_stateRootMismatch(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	chain := newTestChain(t, 3, 0)
+	// the execution of the blocks leaves the state unchanged
+	instance := mocks.NewMockInstance(ctrl)
+	instance.EXPECT().SetContextStorage(gomock.Any())
+	instance.EXPECT().ExecuteBlock(gomock.Any())
+	chain.instance = instance
+	s := NewService(chain, chain, 3)
+
+	replayed, err := s.warm()
+	assert.ErrorIs(t, err, errStateRootMismatch)
+	assert.Equal(t, uint(0), replayed)
+}
+
+func TestService(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestChain(t, 3, 0)
+	s := NewService(chain, chain, 0)
+
+	err := s.Start()
+	require.NoError(t, err)
+	// stopping the service waits for the warming to stop
+	err = s.Stop()
+	require.NoError(t, err)
+	assert.Equal(t, []common.Hash{chain.headers[3].StateRoot}, chain.loaded)
+}
@@ -0,0 +1,164 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package chaos injects faults in the node services, dropping network messages, delaying
+// the GRANDPA finalisations and failing database writes, to exercise the robustness of the
+// consensus and sync code. The faults are drawn from pseudo-random sources seeded from the
+// configuration, so a run can be reproduced with the same seed.
+package chaos
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInjectedDiskFailure is returned by the database writes failed on purpose
+var ErrInjectedDiskFailure = errors.New("injected disk write failure")
+
+var (
+	errUnknownSetting = errors.New("unknown chaos setting")
+	errProbability    = errors.New("probability must be between 0 and 1")
+	errNegativeDelay  = errors.New("delay cannot be negative")
+)
+
+// Config is the configuration of the faults injected
+type Config struct {
+	// Seed seeds the pseudo-random sources the faults are drawn from
+	Seed int64
+	// DropMessages is the probability of dropping a notification message received
+	DropMessages float64
+	// FinalityDelay is the maximum delay added before finalising a GRANDPA round, the
+	// delay of each round being drawn uniformly between 0 and FinalityDelay
+	FinalityDelay time.Duration
+	// DiskWriteFailures is the probability of failing a database write
+	DiskWriteFailures float64
+}
+
+// ParseConfig parses the comma separated list of settings given to the --chaos flag, such as
+// seed=42,drop=0.1,finality-delay=2s,disk-failure=0.01. An empty string returns the zero
+// configuration, injecting no fault.
+func ParseConfig(s string) (config Config, err error) {
+	if s == "" {
+		return config, nil
+	}
+
+	for _, setting := range strings.Split(s, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(setting), "=")
+		switch key {
+		case "seed":
+			config.Seed, err = strconv.ParseInt(value, 10, 64)
+		case "drop":
+			config.DropMessages, err = parseProbability(value)
+		case "finality-delay":
+			config.FinalityDelay, err = time.ParseDuration(value)
+			if err == nil && config.FinalityDelay < 0 {
+				err = errNegativeDelay
+			}
+		case "disk-failure":
+			config.DiskWriteFailures, err = parseProbability(value)
+		default:
+			return config, fmt.Errorf("%w: %q", errUnknownSetting, key)
+		}
+
+		if err != nil {
+			return config, fmt.Errorf("parsing %s: %w", key, err)
+		}
+	}
+
+	return config, nil
+}
+
+func parseProbability(s string) (probability float64, err error) {
+	probability, err = strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	if probability < 0 || probability > 1 {
+		return 0, fmt.Errorf("%w: %s", errProbability, s)
+	}
+	return probability, nil
+}
+
+// Enabled returns true if the configuration injects at least one kind of fault
+func (c Config) Enabled() bool {
+	return c.DropMessages > 0 || c.FinalityDelay > 0 || c.DiskWriteFailures > 0
+}
+
+// source is a pseudo-random source safe for concurrent use
+type source struct {
+	mutex sync.Mutex
+	rand  *rand.Rand
+}
+
+func newSource(seed int64) *source {
+	return &source{rand: rand.New(rand.NewSource(seed))} //nolint:gosec
+}
+
+func (s *source) float64() float64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.rand.Float64()
+}
+
+func (s *source) int63n(n int64) int64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.rand.Int63n(n)
+}
+
+// Injector decides which operations fail. Each kind of fault has its own pseudo-random
+// source, so the faults of one kind do not depend on the number of operations of the
+// other kinds. A nil Injector injects no fault.
+type Injector struct {
+	config   Config
+	messages *source
+	finality *source
+	disk     *source
+}
+
+// New returns an injector of the faults configured, or nil if the configuration injects
+// no fault.
+func New(config Config) *Injector {
+	if !config.Enabled() {
+		return nil
+	}
+
+	return &Injector{
+		config:   config,
+		messages: newSource(config.Seed),
+		finality: newSource(config.Seed + 1),
+		disk:     newSource(config.Seed + 2),
+	}
+}
+
+// DropMessage returns true if the next network message should be dropped.
+func (i *Injector) DropMessage() bool {
+	if i == nil || i.config.DropMessages == 0 {
+		return false
+	}
+	return i.messages.float64() < i.config.DropMessages
+}
+
+// FinalityDelay returns the delay to wait before the next finalisation.
+func (i *Injector) FinalityDelay() time.Duration {
+	if i == nil || i.config.FinalityDelay == 0 {
+		return 0
+	}
+	return time.Duration(i.finality.int63n(int64(i.config.FinalityDelay) + 1))
+}
+
+// FailDiskWrite returns ErrInjectedDiskFailure if the next database write should fail.
+func (i *Injector) FailDiskWrite() error {
+	if i == nil || i.config.DiskWriteFailures == 0 {
+		return nil
+	}
+	if i.disk.float64() < i.config.DiskWriteFailures {
+		return ErrInjectedDiskFailure
+	}
+	return nil
+}
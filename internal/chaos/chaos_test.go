@@ -0,0 +1,152 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package chaos
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConfig(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		s              string
+		expectedConfig Config
+		expectedErr    error
+		errMessage     string
+	}{
+		"empty": {},
+		"all_settings": {
+			s: "seed=42, drop=0.1,finality-delay=2s,disk-failure=0.01",
+			expectedConfig: Config{
+				Seed:              42,
+				DropMessages:      0.1,
+				FinalityDelay:     2 * time.Second,
+				DiskWriteFailures: 0.01,
+			},
+		},
+		"unknown_setting": {
+			s:           "seed=1,corrupt=0.5",
+			expectedErr: errUnknownSetting,
+			errMessage:  `unknown chaos setting: "corrupt"`,
+		},
+		"probability_above_one": {
+			s:           "drop=1.5",
+			expectedErr: errProbability,
+			errMessage:  "parsing drop: probability must be between 0 and 1: 1.5",
+		},
+		"negative_delay": {
+			s:           "finality-delay=-1s",
+			expectedErr: errNegativeDelay,
+			errMessage:  "parsing finality-delay: delay cannot be negative",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			config, err := ParseConfig(testCase.s)
+
+			assert.ErrorIs(t, err, testCase.expectedErr)
+			if testCase.expectedErr != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+				return
+			}
+			assert.Equal(t, testCase.expectedConfig, config)
+		})
+	}
+}
+
+func TestNew_disabled(t *testing.T) {
+	t.Parallel()
+
+	injector := New(Config{Seed: 1})
+	require.Nil(t, injector)
+
+	assert.False(t, injector.DropMessage())
+	assert.Zero(t, injector.FinalityDelay())
+	assert.NoError(t, injector.FailDiskWrite())
+}
+
+func TestInjector_deterministic(t *testing.T) {
+	t.Parallel()
+
+	config := Config{
+		Seed:              7,
+		DropMessages:      0.5,
+		FinalityDelay:     time.Second,
+		DiskWriteFailures: 0.5,
+	}
+
+	type faults struct {
+		drops  []bool
+		delays []time.Duration
+		disk   []error
+	}
+
+	run := func(diskWritesFirst bool) (f faults) {
+		injector := New(config)
+		if diskWritesFirst {
+			for i := 0; i < 20; i++ {
+				f.disk = append(f.disk, injector.FailDiskWrite())
+			}
+		}
+		for i := 0; i < 20; i++ {
+			f.drops = append(f.drops, injector.DropMessage())
+			f.delays = append(f.delays, injector.FinalityDelay())
+		}
+		if !diskWritesFirst {
+			for i := 0; i < 20; i++ {
+				f.disk = append(f.disk, injector.FailDiskWrite())
+			}
+		}
+		return f
+	}
+
+	first := run(false)
+	second := run(true)
+	assert.Equal(t, first, second)
+
+	assert.Contains(t, first.drops, true)
+	assert.Contains(t, first.drops, false)
+	assert.Contains(t, first.disk, ErrInjectedDiskFailure)
+	for _, delay := range first.delays {
+		assert.LessOrEqual(t, delay, time.Second)
+	}
+}
+
+func TestDatabase(t *testing.T) {
+	t.Parallel()
+
+	db, err := database.NewPebble(t.TempDir(), true)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+
+	assert.Same(t, db, NewDatabase(db, nil))
+
+	failing := NewDatabase(db, New(Config{DiskWriteFailures: 1}))
+
+	err = failing.Put([]byte("key"), []byte("value"))
+	assert.ErrorIs(t, err, ErrInjectedDiskFailure)
+	err = failing.Del([]byte("key"))
+	assert.ErrorIs(t, err, ErrInjectedDiskFailure)
+
+	batch := failing.NewBatch()
+	require.NoError(t, batch.Put([]byte("key"), []byte("value")))
+	err = batch.Flush()
+	assert.ErrorIs(t, err, ErrInjectedDiskFailure)
+
+	has, err := failing.Has([]byte("key"))
+	require.NoError(t, err)
+	assert.False(t, has)
+}
@@ -0,0 +1,57 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package chaos
+
+import (
+	"github.com/ChainSafe/gossamer/internal/database"
+)
+
+// Database wraps a database to fail some of its writes. Batches fail when flushed, their
+// writes being lost as a whole.
+type Database struct {
+	database.Database
+	injector *Injector
+}
+
+// NewDatabase returns the database given wrapped to fail the writes decided by the injector,
+// or the database itself if the injector is nil.
+func NewDatabase(db database.Database, injector *Injector) database.Database {
+	if injector == nil {
+		return db
+	}
+	return &Database{Database: db, injector: injector}
+}
+
+// Put sets the value of the key, unless the write is failed.
+func (db *Database) Put(key, value []byte) error {
+	if err := db.injector.FailDiskWrite(); err != nil {
+		return err
+	}
+	return db.Database.Put(key, value)
+}
+
+// Del deletes the key, unless the write is failed.
+func (db *Database) Del(key []byte) error {
+	if err := db.injector.FailDiskWrite(); err != nil {
+		return err
+	}
+	return db.Database.Del(key)
+}
+
+// NewBatch returns a batch failing when flushed if the write is failed.
+func (db *Database) NewBatch() database.Batch {
+	return &batch{Batch: db.Database.NewBatch(), injector: db.injector}
+}
+
+type batch struct {
+	database.Batch
+	injector *Injector
+}
+
+func (b *batch) Flush() error {
+	if err := b.injector.FailDiskWrite(); err != nil {
+		return err
+	}
+	return b.Batch.Flush()
+}
@@ -0,0 +1,205 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package clock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/internal/log"
+)
+
+var logger = log.NewFromGlobal(log.AddContext("pkg", "clock"))
+
+const (
+	// sampleSize is the number of block arrivals the drift is estimated from
+	sampleSize = 32
+	// minSamples is the number of block arrivals required to estimate the drift
+	minSamples = 5
+	// maxSampleSlots is the distance in slots from the local slot above which blocks
+	// are not sampled, being blocks synced rather than blocks just authored
+	maxSampleSlots = 10
+	// ntpInterval is the interval between two queries of the NTP server
+	ntpInterval = 10 * time.Minute
+)
+
+// ErrClockAhead is returned when authoring a block while the local clock is ahead of the
+// network time, the peers rejecting the blocks of slots which did not start for them yet
+var ErrClockAhead = errors.New("local clock is ahead of the network time")
+
+// Guard estimates the drift of the local clock from the network time, from the arrival
+// times of the network blocks compared to the start times of their slots and optionally
+// from an NTP server, and warns when it exceeds the maximum drift.
+type Guard struct {
+	slotDuration time.Duration
+	maxDrift     time.Duration
+	ntpServer    string
+	now          func() time.Time
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mutex sync.Mutex
+	// offsets are the durations between the start of the slots of the last blocks
+	// sampled and their arrival, in a ring buffer
+	offsets   []time.Duration
+	nextIndex int
+	// ntpOffset is the offset measured by the last successful query of the NTP server
+	ntpOffset *time.Duration
+	drifting  bool
+}
+
+// NewGuard returns a new clock guard for slots of the duration given, which queries the
+// NTP server given periodically if it is not empty.
+func NewGuard(slotDuration, maxDrift time.Duration, ntpServer string) *Guard {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Guard{
+		slotDuration: slotDuration,
+		maxDrift:     maxDrift,
+		ntpServer:    ntpServer,
+		now:          time.Now,
+		ctx:          ctx,
+		cancel:       cancel,
+		offsets:      make([]time.Duration, 0, sampleSize),
+	}
+}
+
+// Start starts querying the NTP server if one is configured
+func (g *Guard) Start() error {
+	if g.ntpServer == "" {
+		return nil
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		g.runNTP()
+	}()
+	return nil
+}
+
+// Stop stops querying the NTP server
+func (g *Guard) Stop() error {
+	g.cancel()
+	g.wg.Wait()
+	return nil
+}
+
+// ObserveBlock samples the arrival of a network block of the slot given
+func (g *Guard) ObserveBlock(slot uint64) {
+	slotStart := time.Unix(0, int64(slot)*g.slotDuration.Nanoseconds()) //nolint:gosec
+	offset := g.now().Sub(slotStart)
+
+	maxOffset := maxSampleSlots * g.slotDuration
+	if offset > maxOffset || offset < -maxOffset {
+		return
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if len(g.offsets) < sampleSize {
+		g.offsets = append(g.offsets, offset)
+	} else {
+		g.offsets[g.nextIndex] = offset
+	}
+	g.nextIndex = (g.nextIndex + 1) % sampleSize
+
+	g.checkDrift()
+}
+
+// Drift returns the estimated drift of the local clock from the network time,
+// positive when the local clock is ahead
+func (g *Guard) Drift() time.Duration {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.drift()
+}
+
+// CheckAuthoring returns an error wrapping ErrClockAhead if the local clock is ahead
+// of the network time by more than the maximum drift
+func (g *Guard) CheckAuthoring() error {
+	drift := g.Drift()
+	if drift > g.maxDrift {
+		return fmt.Errorf("%w by %s", ErrClockAhead, drift)
+	}
+	return nil
+}
+
+// drift returns the estimated drift, the NTP offset having precedence over the block arrivals.
+// The blocks of a slot being authored at its start and imported during it, the local clock
+// is behind when the blocks arrive before their slot started, and ahead when they arrive
+// after their slot ended.
+func (g *Guard) drift() time.Duration {
+	if g.ntpOffset != nil {
+		return *g.ntpOffset
+	}
+
+	if len(g.offsets) < minSamples {
+		return 0
+	}
+
+	sorted := slices.Clone(g.offsets)
+	slices.Sort(sorted)
+	median := sorted[len(sorted)/2]
+
+	switch {
+	case median < 0:
+		return median
+	case median > g.slotDuration:
+		return median - g.slotDuration
+	default:
+		return 0
+	}
+}
+
+// checkDrift logs a warning when the drift exceeds the maximum drift,
+// and when it is back below it.
+func (g *Guard) checkDrift() {
+	drift := g.drift()
+	exceeded := drift > g.maxDrift || drift < -g.maxDrift
+
+	switch {
+	case exceeded && !g.drifting:
+		direction := "ahead of"
+		if drift < 0 {
+			direction, drift = "behind", -drift
+		}
+		logger.Warnf("local clock is %s %s the network time, above the maximum drift of %s: "+
+			"check the synchronisation of the system clock", drift, direction, g.maxDrift)
+	case !exceeded && g.drifting:
+		logger.Infof("local clock is back in sync with the network time, drifting by %s", drift)
+	}
+
+	g.drifting = exceeded
+}
+
+func (g *Guard) runNTP() {
+	ticker := time.NewTicker(ntpInterval)
+	defer ticker.Stop()
+
+	for {
+		offset, err := QueryNTP(g.ctx, g.ntpServer)
+		if err != nil {
+			logger.Warnf("cannot query NTP server %s: %s", g.ntpServer, err)
+		} else {
+			logger.Debugf("local clock offset from NTP server %s: %s", g.ntpServer, offset)
+			g.mutex.Lock()
+			g.ntpOffset = &offset
+			g.checkDrift()
+			g.mutex.Unlock()
+		}
+
+		select {
+		case <-g.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
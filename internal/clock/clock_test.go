@@ -0,0 +1,87 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuard_ObserveBlock(t *testing.T) {
+	t.Parallel()
+
+	const slotDuration = 6 * time.Second
+	const slot = uint64(1_000_000)
+	slotStart := time.Unix(0, int64(slot)*slotDuration.Nanoseconds())
+
+	testCases := map[string]struct {
+		arrivalOffset time.Duration
+		samples       int
+		expectedDrift time.Duration
+		expectedErr   error
+	}{
+		"not_enough_samples": {
+			arrivalOffset: -3 * time.Second,
+			samples:       minSamples - 1,
+		},
+		"arrival_during_slot": {
+			arrivalOffset: 2 * time.Second,
+			samples:       sampleSize,
+		},
+		"arrival_before_slot": {
+			arrivalOffset: -3 * time.Second,
+			samples:       minSamples,
+			expectedDrift: -3 * time.Second,
+		},
+		"arrival_after_slot": {
+			arrivalOffset: slotDuration + 3*time.Second,
+			samples:       sampleSize + 1,
+			expectedDrift: 3 * time.Second,
+			expectedErr:   ErrClockAhead,
+		},
+		"synced_blocks_ignored": {
+			arrivalOffset: (maxSampleSlots + 1) * slotDuration,
+			samples:       sampleSize,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			guard := NewGuard(slotDuration, time.Second, "")
+			guard.now = func() time.Time { return slotStart.Add(testCase.arrivalOffset) }
+
+			for i := 0; i < testCase.samples; i++ {
+				guard.ObserveBlock(slot)
+			}
+
+			assert.Equal(t, testCase.expectedDrift, guard.Drift())
+			assert.LessOrEqual(t, len(guard.offsets), sampleSize)
+
+			err := guard.CheckAuthoring()
+			assert.ErrorIs(t, err, testCase.expectedErr)
+		})
+	}
+}
+
+func TestGuard_Drift_NTPPrecedence(t *testing.T) {
+	t.Parallel()
+
+	guard := NewGuard(6*time.Second, time.Second, "")
+	guard.now = func() time.Time { return time.Unix(0, 0).Add(-3 * time.Second) }
+	for i := 0; i < minSamples; i++ {
+		guard.ObserveBlock(0)
+	}
+	require.Equal(t, -3*time.Second, guard.Drift())
+
+	ntpOffset := 2 * time.Second
+	guard.ntpOffset = &ntpOffset
+	require.Equal(t, ntpOffset, guard.Drift())
+	require.ErrorIs(t, guard.CheckAuthoring(), ErrClockAhead)
+}
@@ -0,0 +1,101 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package clock
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+const (
+	ntpPort       = "123"
+	ntpPacketSize = 48
+	ntpTimeout    = 5 * time.Second
+	// ntpEpochOffset is the number of seconds between the NTP epoch (1900) and the unix epoch (1970)
+	ntpEpochOffset = 2208988800
+	// ntpClientHeader is the first byte of a request, with no leap indicator, version 4 and client mode
+	ntpClientHeader = 0b00_100_011
+	ntpServerMode   = 4
+)
+
+var errInvalidNTPResponse = errors.New("invalid NTP response")
+
+// QueryNTP queries the time of the NTP server given and returns the offset of the
+// local clock from it, positive when the local clock is ahead, as described in RFC 5905.
+func QueryNTP(ctx context.Context, server string) (offset time.Duration, err error) {
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, ntpPort)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ntpTimeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", server)
+	if err != nil {
+		return 0, fmt.Errorf("dialing: %w", err)
+	}
+	defer conn.Close() //nolint:errcheck
+
+	deadline, _ := ctx.Deadline()
+	err = conn.SetDeadline(deadline)
+	if err != nil {
+		return 0, fmt.Errorf("setting deadline: %w", err)
+	}
+
+	request := make([]byte, ntpPacketSize)
+	request[0] = ntpClientHeader
+
+	sent := time.Now()
+	_, err = conn.Write(request)
+	if err != nil {
+		return 0, fmt.Errorf("sending request: %w", err)
+	}
+
+	response := make([]byte, ntpPacketSize)
+	n, err := conn.Read(response)
+	if err != nil {
+		return 0, fmt.Errorf("reading response: %w", err)
+	}
+	received := time.Now()
+
+	return ntpOffset(response[:n], sent, received)
+}
+
+// ntpOffset returns the offset of the local clock from the server time of the response
+// given, received at the time given for a request sent at the time given.
+func ntpOffset(response []byte, sent, received time.Time) (time.Duration, error) {
+	if len(response) < ntpPacketSize {
+		return 0, fmt.Errorf("%w: %d bytes long", errInvalidNTPResponse, len(response))
+	}
+
+	if mode := response[0] & 0b111; mode != ntpServerMode {
+		return 0, fmt.Errorf("%w: mode %d", errInvalidNTPResponse, mode)
+	}
+
+	// a stratum of 0 is a kiss-o'-death packet, the server refusing to serve the request
+	if stratum := response[1]; stratum == 0 {
+		return 0, fmt.Errorf("%w: kiss-o'-death %q", errInvalidNTPResponse, response[12:16])
+	}
+
+	serverReceived := ntpTime(response[32:40])
+	serverSent := ntpTime(response[40:48])
+
+	// the offset of the server from the local clock is ((t2 - t1) + (t3 - t4)) / 2
+	serverOffset := (serverReceived.Sub(sent) + serverSent.Sub(received)) / 2
+	return -serverOffset, nil
+}
+
+// ntpTime returns the time of the NTP timestamp given, the seconds since the NTP epoch
+// followed by the fraction of second, both big endian 32 bits unsigned integers.
+func ntpTime(timestamp []byte) time.Time {
+	seconds := int64(binary.BigEndian.Uint32(timestamp[:4])) - ntpEpochOffset
+	fraction := int64(binary.BigEndian.Uint32(timestamp[4:]))
+	nanoseconds := (fraction * int64(time.Second)) >> 32
+	return time.Unix(seconds, nanoseconds)
+}
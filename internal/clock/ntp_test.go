@@ -0,0 +1,107 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package clock
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ntpResponse returns a NTP server response with the receive and transmit timestamps given
+func ntpResponse(stratum byte, serverReceived, serverSent time.Time) []byte {
+	response := make([]byte, ntpPacketSize)
+	response[0] = 0b00_100_000 | ntpServerMode
+	response[1] = stratum
+	putNTPTime(response[32:40], serverReceived)
+	putNTPTime(response[40:48], serverSent)
+	return response
+}
+
+func putNTPTime(b []byte, t time.Time) {
+	binary.BigEndian.PutUint32(b[:4], uint32(t.Unix()+ntpEpochOffset))
+	binary.BigEndian.PutUint32(b[4:], uint32((int64(t.Nanosecond())<<32)/int64(time.Second)))
+}
+
+func Test_ntpOffset(t *testing.T) {
+	t.Parallel()
+
+	sent := time.Unix(1_700_000_000, 0)
+	received := sent.Add(100 * time.Millisecond)
+
+	testCases := map[string]struct {
+		response       []byte
+		expectedOffset time.Duration
+		errMessage     string
+	}{
+		"short_response": {
+			response:   make([]byte, 10),
+			errMessage: "invalid NTP response: 10 bytes long",
+		},
+		"client_mode": {
+			response:   make([]byte, ntpPacketSize),
+			errMessage: "invalid NTP response: mode 0",
+		},
+		"kiss_of_death": {
+			response:   ntpResponse(0, sent, sent),
+			errMessage: "invalid NTP response: kiss-o'-death \"\\x00\\x00\\x00\\x00\"",
+		},
+		"server_behind": {
+			// the server clock is 2s behind, the request and the response taking 50ms each
+			response: ntpResponse(1,
+				sent.Add(50*time.Millisecond-2*time.Second),
+				sent.Add(50*time.Millisecond-2*time.Second)),
+			expectedOffset: 2 * time.Second,
+		},
+		"server_ahead": {
+			response: ntpResponse(2,
+				sent.Add(50*time.Millisecond+time.Second),
+				sent.Add(50*time.Millisecond+time.Second)),
+			expectedOffset: -time.Second,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			offset, err := ntpOffset(testCase.response, sent, received)
+			if testCase.errMessage != "" {
+				assert.ErrorIs(t, err, errInvalidNTPResponse)
+				assert.EqualError(t, err, testCase.errMessage)
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, testCase.expectedOffset, offset, float64(time.Microsecond))
+		})
+	}
+}
+
+func TestQueryNTP(t *testing.T) {
+	t.Parallel()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go func() {
+		request := make([]byte, ntpPacketSize)
+		_, addr, err := conn.ReadFrom(request)
+		if err != nil {
+			return
+		}
+		now := time.Now().Add(-time.Hour)
+		_, _ = conn.WriteTo(ntpResponse(1, now, now), addr)
+	}()
+
+	offset, err := QueryNTP(context.Background(), conn.LocalAddr().String())
+	require.NoError(t, err)
+	assert.InDelta(t, time.Hour, offset, float64(time.Second))
+}
@@ -0,0 +1,200 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package console
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+var errClientClosed = errors.New("connection closed")
+
+// rpcError is the error of a JSON-RPC response
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// rpcMessage is a JSON-RPC message received from the node, either the response to a request
+// or the notification of a subscription.
+type rpcMessage struct {
+	ID     *uint64         `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+	Method string          `json:"method"`
+	Params struct {
+		Result       json.RawMessage `json:"result"`
+		Subscription json.RawMessage `json:"subscription"`
+	} `json:"params"`
+}
+
+// client is a JSON-RPC client of the websocket endpoint of a node, supporting subscriptions
+type client struct {
+	conn       *websocket.Conn
+	writeMutex sync.Mutex
+
+	mutex         sync.Mutex
+	nextID        uint64
+	pending       map[uint64]chan rpcMessage
+	subscriptions map[string]chan<- json.RawMessage
+
+	done chan struct{}
+	err  error
+}
+
+func dial(ctx context.Context, uri string) (*client, error) {
+	conn, response, err := websocket.DefaultDialer.DialContext(ctx, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to %s: %w", uri, err)
+	}
+	_ = response.Body.Close()
+
+	c := &client{
+		conn:          conn,
+		pending:       make(map[uint64]chan rpcMessage),
+		subscriptions: make(map[string]chan<- json.RawMessage),
+		done:          make(chan struct{}),
+	}
+	go c.readLoop()
+	return c, nil
+}
+
+func (c *client) readLoop() {
+	defer close(c.done)
+
+	for {
+		var message rpcMessage
+		err := c.conn.ReadJSON(&message)
+		if err != nil {
+			c.mutex.Lock()
+			c.err = err
+			c.mutex.Unlock()
+			return
+		}
+
+		c.mutex.Lock()
+		if message.ID != nil {
+			responses, ok := c.pending[*message.ID]
+			delete(c.pending, *message.ID)
+			if ok {
+				responses <- message
+			}
+		} else if message.Method != "" {
+			notifications, ok := c.subscriptions[subscriptionID(message.Params.Subscription)]
+			if ok {
+				select {
+				case notifications <- message.Params.Result:
+				default:
+					// the notification is dropped rather than blocking the other responses
+				}
+			}
+		}
+		c.mutex.Unlock()
+	}
+}
+
+// call calls the RPC method given and decodes its result in the result given, if not nil.
+func (c *client) call(ctx context.Context, method string, params []interface{}, result interface{}) error {
+	if params == nil {
+		params = []interface{}{}
+	}
+
+	responses := make(chan rpcMessage, 1)
+	c.mutex.Lock()
+	if c.err != nil {
+		c.mutex.Unlock()
+		return fmt.Errorf("%w: %s", errClientClosed, c.err)
+	}
+	c.nextID++
+	id := c.nextID
+	c.pending[id] = responses
+	c.mutex.Unlock()
+
+	defer func() {
+		c.mutex.Lock()
+		delete(c.pending, id)
+		c.mutex.Unlock()
+	}()
+
+	c.writeMutex.Lock()
+	err := c.conn.WriteJSON(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"method":  method,
+		"params":  params,
+	})
+	c.writeMutex.Unlock()
+	if err != nil {
+		return fmt.Errorf("sending %s request: %w", method, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.done:
+		return fmt.Errorf("%w: %s", errClientClosed, c.err)
+	case response := <-responses:
+		if response.Error != nil {
+			return fmt.Errorf("%s returned error %d: %s", method, response.Error.Code, response.Error.Message)
+		}
+		if result == nil {
+			return nil
+		}
+		err = json.Unmarshal(response.Result, result)
+		if err != nil {
+			return fmt.Errorf("decoding %s result: %w", method, err)
+		}
+		return nil
+	}
+}
+
+// subscribe calls the subscription RPC method given, the notifications of the subscription
+// being sent to the channel given. It returns the subscription ID.
+func (c *client) subscribe(ctx context.Context, method string, params []interface{},
+	notifications chan<- json.RawMessage) (string, error) {
+	var result json.RawMessage
+	err := c.call(ctx, method, params, &result)
+	if err != nil {
+		return "", err
+	}
+
+	id := subscriptionID(result)
+	c.mutex.Lock()
+	c.subscriptions[id] = notifications
+	c.mutex.Unlock()
+	return id, nil
+}
+
+// unsubscribe stops the subscription of the ID given with the unsubscription RPC method given.
+func (c *client) unsubscribe(ctx context.Context, method, id string) error {
+	c.mutex.Lock()
+	delete(c.subscriptions, id)
+	c.mutex.Unlock()
+
+	var param interface{} = id
+	var number json.Number
+	if json.Unmarshal([]byte(id), &number) == nil {
+		// gossamer subscription IDs are numbers
+		param = number
+	}
+	return c.call(ctx, method, []interface{}{param}, nil)
+}
+
+func (c *client) close() error {
+	err := c.conn.Close()
+	<-c.done
+	return err
+}
+
+// subscriptionID returns the subscription ID of the JSON value given, which is a number for
+// gossamer and a string for substrate nodes.
+func subscriptionID(raw json.RawMessage) string {
+	return string(bytes.Trim(raw, `"`))
+}
@@ -0,0 +1,444 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package console
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/scale"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+var (
+	errInvalidArgument = errors.New("invalid argument")
+	errTrailingBytes   = errors.New("trailing bytes")
+)
+
+// devAccounts are the names of the development accounts, derived from the development
+// secret phrase with the //Name hard junction.
+var devAccounts = []string{"alice", "bob", "charlie", "dave", "eve", "ferdie"}
+
+// decode decodes the SCALE encoded value of the type given into a value printable as JSON.
+// The byte arrays and sequences are hex encoded, the variants without fields are their name
+// and the other variants an object of a single key, their name.
+func (m *metadata) decode(id ctypes.Si1LookupTypeID, encoded []byte) (interface{}, error) {
+	reader := bytes.NewReader(encoded)
+	value, err := m.decodeValue(id, scale.NewDecoder(reader))
+	if err != nil {
+		return nil, err
+	}
+	if reader.Len() > 0 {
+		return nil, fmt.Errorf("%w: %d bytes left decoding %s", errTrailingBytes, reader.Len(), m.typeName(id))
+	}
+	return value, nil
+}
+
+func (m *metadata) decodeValue(id ctypes.Si1LookupTypeID, decoder *scale.Decoder) (interface{}, error) {
+	typ, err := m.lookup(id)
+	if err != nil {
+		return nil, err
+	}
+	def := typ.Def
+
+	switch {
+	case def.IsComposite:
+		return m.decodeFields(def.Composite.Fields, decoder)
+	case def.IsVariant:
+		index, err := decoder.ReadOneByte()
+		if err != nil {
+			return nil, err
+		}
+		for _, variant := range def.Variant.Variants {
+			if byte(variant.Index) != index {
+				continue
+			}
+			if len(variant.Fields) == 0 {
+				return string(variant.Name), nil
+			}
+			fields, err := m.decodeFields(variant.Fields, decoder)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{string(variant.Name): fields}, nil
+		}
+		return nil, fmt.Errorf("unknown variant index %d of %s", index, m.typeName(id))
+	case def.IsSequence:
+		length, err := decoder.DecodeUintCompact()
+		if err != nil {
+			return nil, err
+		}
+		return m.decodeElements(def.Sequence.Type, length.Uint64(), decoder)
+	case def.IsArray:
+		return m.decodeElements(def.Array.Type, uint64(def.Array.Len), decoder)
+	case def.IsTuple:
+		if len(def.Tuple) == 0 {
+			return nil, nil
+		}
+		elements := make([]interface{}, len(def.Tuple))
+		for i, element := range def.Tuple {
+			elements[i], err = m.decodeValue(element, decoder)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return elements, nil
+	case def.IsPrimitive:
+		return decodePrimitive(def.Primitive.Si0TypeDefPrimitive, decoder)
+	case def.IsCompact:
+		return decoder.DecodeUintCompact()
+	case def.IsBitSequence:
+		bits, err := decoder.DecodeUintCompact()
+		if err != nil {
+			return nil, err
+		}
+		data := make([]byte, (bits.Uint64()+7)/8)
+		err = decoder.Read(data)
+		if err != nil {
+			return nil, err
+		}
+		return common.BytesToHex(data), nil
+	default:
+		return nil, fmt.Errorf("%w: %d has no supported definition", errUnknownType, id.Int64())
+	}
+}
+
+// decodeFields decodes the fields given, into an object if they are named, into the value
+// of the field if there is a single unnamed field and into an array otherwise.
+func (m *metadata) decodeFields(fields []ctypes.Si1Field, decoder *scale.Decoder) (interface{}, error) {
+	if len(fields) == 1 && !fields[0].HasName {
+		return m.decodeValue(fields[0].Type, decoder)
+	}
+
+	if len(fields) > 0 && fields[0].HasName {
+		object := make(map[string]interface{}, len(fields))
+		for _, field := range fields {
+			value, err := m.decodeValue(field.Type, decoder)
+			if err != nil {
+				return nil, fmt.Errorf("decoding field %s: %w", field.Name, err)
+			}
+			object[string(field.Name)] = value
+		}
+		return object, nil
+	}
+
+	values := make([]interface{}, len(fields))
+	for i, field := range fields {
+		value, err := m.decodeValue(field.Type, decoder)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// decodeElements decodes the elements of a sequence or an array, the bytes being hex encoded
+func (m *metadata) decodeElements(id ctypes.Si1LookupTypeID, length uint64,
+	decoder *scale.Decoder) (interface{}, error) {
+	if m.isByte(id) {
+		data := make([]byte, length)
+		err := decoder.Read(data)
+		if err != nil {
+			return nil, err
+		}
+		return common.BytesToHex(data), nil
+	}
+
+	elements := make([]interface{}, 0, length)
+	for i := uint64(0); i < length; i++ {
+		element, err := m.decodeValue(id, decoder)
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, element)
+	}
+	return elements, nil
+}
+
+func (m *metadata) isByte(id ctypes.Si1LookupTypeID) bool {
+	typ, err := m.lookup(id)
+	return err == nil && typ.Def.IsPrimitive && typ.Def.Primitive.Si0TypeDefPrimitive == ctypes.IsU8
+}
+
+// primitiveSizes are the sizes in bytes of the fixed size integer primitives
+var primitiveSizes = map[ctypes.Si0TypeDefPrimitive]int{
+	ctypes.IsU8: 1, ctypes.IsU16: 2, ctypes.IsU32: 4, ctypes.IsU64: 8, ctypes.IsU128: 16, ctypes.IsU256: 32,
+	ctypes.IsI8: 1, ctypes.IsI16: 2, ctypes.IsI32: 4, ctypes.IsI64: 8, ctypes.IsI128: 16, ctypes.IsI256: 32,
+}
+
+func isSigned(primitive ctypes.Si0TypeDefPrimitive) bool {
+	return primitive >= ctypes.IsI8
+}
+
+func decodePrimitive(primitive ctypes.Si0TypeDefPrimitive, decoder *scale.Decoder) (interface{}, error) {
+	switch primitive {
+	case ctypes.IsBool:
+		b, err := decoder.ReadOneByte()
+		return b == 1, err
+	case ctypes.IsChar:
+		data := make([]byte, 4)
+		err := decoder.Read(data)
+		return string(rune(binary.LittleEndian.Uint32(data))), err
+	case ctypes.IsStr:
+		length, err := decoder.DecodeUintCompact()
+		if err != nil {
+			return nil, err
+		}
+		data := make([]byte, length.Uint64())
+		err = decoder.Read(data)
+		return string(data), err
+	}
+
+	size, ok := primitiveSizes[primitive]
+	if !ok {
+		return nil, fmt.Errorf("%w: primitive %d", errUnknownType, primitive)
+	}
+	data := make([]byte, size)
+	err := decoder.Read(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// the integers are little endian
+	bigEndian := make([]byte, size)
+	for i := range data {
+		bigEndian[size-1-i] = data[i]
+	}
+	value := new(big.Int).SetBytes(bigEndian)
+	if isSigned(primitive) && data[size-1]&0x80 != 0 {
+		value.Sub(value, new(big.Int).Lsh(big.NewInt(1), uint(size*8)))
+	}
+	return value, nil
+}
+
+// encode SCALE encodes the value of the type given from its text representation. A hex string
+// is the value itself for the byte arrays and sequences, and the SCALE encoded value for the
+// other types. The accounts are given by development account name, SS58 address or hex, the
+// variants by name, followed by :value for the variants with a field.
+func (m *metadata) encode(id ctypes.Si1LookupTypeID, arg string) ([]byte, error) {
+	typ, err := m.lookup(id)
+	if err != nil {
+		return nil, err
+	}
+	def := typ.Def
+
+	isBytes := (def.IsSequence && m.isByte(def.Sequence.Type)) || (def.IsArray && m.isByte(def.Array.Type))
+	if strings.HasPrefix(arg, "0x") && !isBytes && !m.isBytesComposite(typ) {
+		return common.HexToBytes(arg)
+	}
+
+	switch {
+	case def.IsComposite:
+		if len(def.Composite.Fields) != 1 {
+			return nil, fmt.Errorf("%w: %s must be given as SCALE encoded hex", errInvalidArgument, m.typeName(id))
+		}
+		return m.encode(def.Composite.Fields[0].Type, arg)
+	case def.IsVariant:
+		return m.encodeVariant(id, def.Variant, arg)
+	case def.IsSequence && isBytes:
+		data, err := decodeBytesArg(arg)
+		if err != nil {
+			return nil, err
+		}
+		length, err := encodeCompact(new(big.Int).SetUint64(uint64(len(data))))
+		if err != nil {
+			return nil, err
+		}
+		return append(length, data...), nil
+	case def.IsArray && isBytes:
+		data, err := decodeBytesArg(arg)
+		if err != nil {
+			return nil, err
+		}
+		if uint32(len(data)) != uint32(def.Array.Len) {
+			if def.Array.Len == 32 {
+				return accountID(arg)
+			}
+			return nil, fmt.Errorf("%w: %s is %d bytes long instead of %d", errInvalidArgument, arg,
+				len(data), def.Array.Len)
+		}
+		return data, nil
+	case def.IsPrimitive:
+		return encodePrimitive(def.Primitive.Si0TypeDefPrimitive, arg)
+	case def.IsCompact:
+		value, ok := new(big.Int).SetString(arg, 10)
+		if !ok || value.Sign() < 0 {
+			return nil, fmt.Errorf("%w: %s is not an unsigned integer", errInvalidArgument, arg)
+		}
+		return encodeCompact(value)
+	default:
+		return nil, fmt.Errorf("%w: %s must be given as SCALE encoded hex", errInvalidArgument, m.typeName(id))
+	}
+}
+
+// isBytesComposite returns true if the type is a composite wrapping bytes, such as AccountId32,
+// whose hex representation is the bytes themselves.
+func (m *metadata) isBytesComposite(typ *ctypes.Si1Type) bool {
+	for typ.Def.IsComposite && len(typ.Def.Composite.Fields) == 1 {
+		inner, err := m.lookup(typ.Def.Composite.Fields[0].Type)
+		if err != nil {
+			return false
+		}
+		typ = inner
+	}
+	return (typ.Def.IsArray && m.isByte(typ.Def.Array.Type)) ||
+		(typ.Def.IsSequence && m.isByte(typ.Def.Sequence.Type))
+}
+
+func (m *metadata) encodeVariant(id ctypes.Si1LookupTypeID, def ctypes.Si1TypeDefVariant,
+	arg string) ([]byte, error) {
+	name, value, hasValue := strings.Cut(arg, ":")
+	for _, variant := range def.Variants {
+		if !strings.EqualFold(string(variant.Name), name) {
+			continue
+		}
+		if !hasValue {
+			if len(variant.Fields) != 0 {
+				return nil, fmt.Errorf("%w: variant %s of %s has fields", errInvalidArgument, name, m.typeName(id))
+			}
+			return []byte{byte(variant.Index)}, nil
+		}
+		if len(variant.Fields) != 1 {
+			return nil, fmt.Errorf("%w: variant %s of %s does not have a single field", errInvalidArgument,
+				name, m.typeName(id))
+		}
+		encoded, err := m.encode(variant.Fields[0].Type, value)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte{byte(variant.Index)}, encoded...), nil
+	}
+
+	// addresses are given as accounts, encoded as the Id variant of MultiAddress
+	for _, variant := range def.Variants {
+		if variant.Name == "Id" && len(variant.Fields) == 1 {
+			encoded, err := m.encode(variant.Fields[0].Type, arg)
+			if err != nil {
+				return nil, err
+			}
+			return append([]byte{byte(variant.Index)}, encoded...), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s is not a variant of %s", errInvalidArgument, arg, m.typeName(id))
+}
+
+func decodeBytesArg(arg string) ([]byte, error) {
+	if strings.HasPrefix(arg, "0x") {
+		return common.HexToBytes(arg)
+	}
+	return []byte(arg), nil
+}
+
+// accountID returns the account ID of the development account name, SS58 address or hex
+// public key given.
+func accountID(arg string) ([]byte, error) {
+	if strings.HasPrefix(arg, "0x") {
+		data, err := common.HexToBytes(arg)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) != 32 {
+			return nil, fmt.Errorf("%w: account %s is not 32 bytes long", errInvalidArgument, arg)
+		}
+		return data, nil
+	}
+
+	for _, name := range devAccounts {
+		if strings.EqualFold(arg, name) {
+			pair, err := devKeyringPair(name)
+			if err != nil {
+				return nil, err
+			}
+			return pair.PublicKey, nil
+		}
+	}
+
+	_, id, err := crypto.DecodeSS58(common.Address(arg))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s is not a development account, SS58 address or hex account ID: %s",
+			errInvalidArgument, arg, err)
+	}
+	return id, nil
+}
+
+// devKeyringPair returns the sr25519 keyring pair of the development account of the name given
+func devKeyringPair(name string) (signature.KeyringPair, error) {
+	uri := "//" + strings.ToUpper(name[:1]) + strings.ToLower(name[1:])
+	return signature.KeyringPairFromSecret(uri, 42)
+}
+
+func encodePrimitive(primitive ctypes.Si0TypeDefPrimitive, arg string) ([]byte, error) {
+	switch primitive {
+	case ctypes.IsBool:
+		value, err := strconv.ParseBool(arg)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errInvalidArgument, err)
+		}
+		if value {
+			return []byte{1}, nil
+		}
+		return []byte{0}, nil
+	case ctypes.IsChar:
+		r, size := utf8.DecodeRuneInString(arg)
+		if size == 0 || size != len(arg) {
+			return nil, fmt.Errorf("%w: %s is not a single character", errInvalidArgument, arg)
+		}
+		return binary.LittleEndian.AppendUint32(nil, uint32(r)), nil
+	case ctypes.IsStr:
+		length, err := encodeCompact(new(big.Int).SetUint64(uint64(len(arg))))
+		if err != nil {
+			return nil, err
+		}
+		return append(length, arg...), nil
+	}
+
+	size, ok := primitiveSizes[primitive]
+	if !ok {
+		return nil, fmt.Errorf("%w: primitive %d", errUnknownType, primitive)
+	}
+	value, ok := new(big.Int).SetString(arg, 10)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s is not an integer", errInvalidArgument, arg)
+	}
+
+	bits := uint(size * 8)
+	limit := new(big.Int).Lsh(big.NewInt(1), bits)
+	if isSigned(primitive) {
+		half := new(big.Int).Rsh(limit, 1)
+		if value.Cmp(half) >= 0 || value.Cmp(new(big.Int).Neg(half)) < 0 {
+			return nil, fmt.Errorf("%w: %s overflows %s", errInvalidArgument, arg, primitiveNames[primitive])
+		}
+		if value.Sign() < 0 {
+			value = new(big.Int).Add(value, limit)
+		}
+	} else if value.Sign() < 0 || value.Cmp(limit) >= 0 {
+		return nil, fmt.Errorf("%w: %s overflows %s", errInvalidArgument, arg, primitiveNames[primitive])
+	}
+
+	bigEndian := value.FillBytes(make([]byte, size))
+	littleEndian := make([]byte, size)
+	for i := range bigEndian {
+		littleEndian[size-1-i] = bigEndian[i]
+	}
+	return littleEndian, nil
+}
+
+func encodeCompact(value *big.Int) ([]byte, error) {
+	buffer := bytes.NewBuffer(nil)
+	err := scale.NewEncoder(buffer).EncodeUintCompact(*value)
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package console
+
+import (
+	"strings"
+
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+)
+
+// Complete returns the candidates completing the last word of the line given, which is
+// empty if the line ends with a space.
+func (c *Console) Complete(line string) []string {
+	fields := strings.Fields(line)
+	if len(fields) == 0 || strings.HasSuffix(line, " ") {
+		fields = append(fields, "")
+	}
+	position, word := len(fields)-1, fields[len(fields)-1]
+
+	var options []string
+	switch {
+	case position == 0:
+		for _, command := range commands {
+			options = append(options, command.name)
+		}
+	case fields[0] == "storage":
+		options = c.completeStorage(fields, position)
+	case fields[0] == "constant":
+		switch position {
+		case 1:
+			options = c.metadata.palletNames(func(pallet *ctypes.PalletMetadataV14) bool {
+				return len(pallet.Constants) > 0
+			})
+		case 2:
+			options = c.metadata.constantNames(fields[1])
+		}
+	case fields[0] == "tx":
+		switch position {
+		case 1:
+			options = devAccounts
+		case 2:
+			options = c.metadata.palletNames(func(pallet *ctypes.PalletMetadataV14) bool {
+				return pallet.HasCalls
+			})
+		case 3:
+			options = c.metadata.callNames(fields[2])
+		default:
+			options = devAccounts
+		}
+	case fields[0] == "head" && position == 1:
+		options = []string{"on", "off"}
+	case fields[0] == "rpc" && position == 1:
+		options = c.rpcMethods
+	}
+
+	var candidates []string
+	for _, option := range options {
+		if strings.HasPrefix(strings.ToLower(option), strings.ToLower(word)) {
+			candidates = append(candidates, option)
+		}
+	}
+	return candidates
+}
+
+func (c *Console) completeStorage(fields []string, position int) []string {
+	switch position {
+	case 1:
+		return c.metadata.palletNames(func(pallet *ctypes.PalletMetadataV14) bool {
+			return pallet.HasStorage
+		})
+	case 2:
+		return c.metadata.storageNames(fields[1])
+	default:
+		return devAccounts
+	}
+}
+
+// AutoComplete completes the word before the cursor position of the line given with the
+// longest common prefix of its candidates, as an auto complete callback of a terminal.
+func (c *Console) AutoComplete(line string, pos int, key rune) (newLine string, newPos int, ok bool) {
+	if key != '\t' || pos != len(line) {
+		return "", 0, false
+	}
+
+	candidates := c.Complete(line)
+	if len(candidates) == 0 {
+		return "", 0, false
+	}
+
+	completion := candidates[0]
+	for _, candidate := range candidates[1:] {
+		completion = commonPrefix(completion, candidate)
+	}
+	if len(candidates) == 1 {
+		completion += " "
+	}
+
+	start := strings.LastIndexAny(line, " \t") + 1
+	if len(completion) < len(line)-start {
+		return "", 0, false
+	}
+	newLine = line[:start] + completion
+	return newLine, len(newLine), true
+}
+
+func commonPrefix(a, b string) string {
+	i := 0
+	for i < len(a) && i < len(b) && strings.EqualFold(a[i:i+1], b[i:i+1]) {
+		i++
+	}
+	return a[:i]
+}
@@ -0,0 +1,555 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package console implements an interactive console connected to the websocket RPC endpoint
+// of a node, to query its storage decoded with the runtime metadata, submit extrinsics signed
+// by the development accounts and watch the new blocks with their events.
+package console
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+// keysPageSize is the number of entries listed when querying a storage map without all its keys
+const keysPageSize = 100
+
+var (
+	errUnknownCommand = errors.New("unknown command")
+	errUsage          = errors.New("usage")
+)
+
+// ErrExit is returned by the exit command to leave the console
+var ErrExit = errors.New("exit")
+
+// commands are the console commands with their usage
+var commands = []struct {
+	name  string
+	usage string
+}{
+	{"help", "help: list the commands"},
+	{"pallets", "pallets: list the pallets of the runtime"},
+	{"storage", "storage <pallet> <item> [keys...]: query a storage item, listing the entries " +
+		"of a map if not all its keys are given"},
+	{"constant", "constant <pallet> <name>: print a constant of a pallet"},
+	{"tx", "tx <account> <pallet> <call> [args...]: submit a call signed by a development account"},
+	{"head", "head [on|off]: print the new blocks with their events, or stop printing them"},
+	{"rpc", "rpc <method> [params...]: call an RPC method, the params being JSON values or strings"},
+	{"exit", "exit: leave the console"},
+}
+
+// Console is an interactive console connected to a node
+type Console struct {
+	client     *client
+	metadata   *metadata
+	genesis    common.Hash
+	rpcMethods []string
+
+	outMutex sync.Mutex
+	out      io.Writer
+
+	headMutex        sync.Mutex
+	headSubscription string
+	headDone         chan struct{}
+}
+
+// New connects to the websocket RPC endpoint of the node at the URI given, and fetches the
+// metadata of its runtime. The outputs of the console are written to the writer given.
+func New(ctx context.Context, uri string, out io.Writer) (*Console, error) {
+	c, err := dial(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	console := &Console{client: c, out: out}
+	err = console.load(ctx)
+	if err != nil {
+		_ = c.close()
+		return nil, err
+	}
+	return console, nil
+}
+
+func (c *Console) load(ctx context.Context) error {
+	var encodedMetadata string
+	err := c.client.call(ctx, "state_getMetadata", nil, &encodedMetadata)
+	if err != nil {
+		return err
+	}
+	c.metadata, err = newMetadata(encodedMetadata)
+	if err != nil {
+		return err
+	}
+
+	var genesisHash string
+	err = c.client.call(ctx, "chain_getBlockHash", []interface{}{0}, &genesisHash)
+	if err != nil {
+		return err
+	}
+	c.genesis, err = common.HexToHash(genesisHash)
+	if err != nil {
+		return fmt.Errorf("decoding genesis hash: %w", err)
+	}
+
+	var methods struct {
+		Methods []string `json:"methods"`
+	}
+	// the RPC methods are only used to complete the rpc command
+	if c.client.call(ctx, "rpc_methods", nil, &methods) == nil {
+		c.rpcMethods = methods.Methods
+	}
+	return nil
+}
+
+// Close stops watching the new blocks and closes the connection to the node
+func (c *Console) Close() error {
+	c.stopHeads(context.Background())
+	return c.client.close()
+}
+
+// SetOutput sets the writer the outputs of the console are written to
+func (c *Console) SetOutput(out io.Writer) {
+	c.outMutex.Lock()
+	defer c.outMutex.Unlock()
+	c.out = out
+}
+
+func (c *Console) printf(format string, args ...interface{}) {
+	c.outMutex.Lock()
+	defer c.outMutex.Unlock()
+	_, _ = fmt.Fprintf(c.out, format, args...)
+}
+
+// Run executes the commands read line by line from the reader given, until its end or the
+// exit command. The errors of the commands are printed.
+func (c *Console) Run(ctx context.Context, in io.Reader) error {
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		err := c.Execute(ctx, scanner.Text())
+		if errors.Is(err, ErrExit) {
+			return nil
+		} else if err != nil {
+			c.printf("error: %s\n", err)
+		}
+	}
+	return scanner.Err()
+}
+
+// Execute executes the command line given
+func (c *Console) Execute(ctx context.Context, line string) error {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil
+	}
+	command, args := fields[0], fields[1:]
+
+	switch command {
+	case "help":
+		for _, command := range commands {
+			c.printf("%s\n", command.usage)
+		}
+		return nil
+	case "pallets":
+		for _, name := range c.metadata.palletNames(func(*ctypes.PalletMetadataV14) bool { return true }) {
+			c.printf("%s\n", name)
+		}
+		return nil
+	case "storage":
+		return c.storage(ctx, args)
+	case "constant":
+		return c.constant(args)
+	case "tx":
+		return c.submit(ctx, args)
+	case "head":
+		return c.head(ctx, args)
+	case "rpc":
+		return c.rpc(ctx, args)
+	case "exit", "quit":
+		return ErrExit
+	default:
+		return fmt.Errorf("%w: %s, see help", errUnknownCommand, command)
+	}
+}
+
+func usage(command string) error {
+	for _, c := range commands {
+		if c.name == command {
+			return fmt.Errorf("%w: %s", errUsage, c.usage)
+		}
+	}
+	return errUsage
+}
+
+func (c *Console) storage(ctx context.Context, args []string) error {
+	if len(args) < 1 {
+		return usage("storage")
+	}
+	if len(args) == 1 {
+		names := c.metadata.storageNames(args[0])
+		if names == nil {
+			return fmt.Errorf("%w: %s", errUnknownStorage, args[0])
+		}
+		c.printf("%s\n", strings.Join(names, " "))
+		return nil
+	}
+
+	_, _, keyTypes, err := c.metadata.storageEntry(args[0], args[1])
+	if err != nil {
+		return err
+	}
+	key, entry, err := c.metadata.storageKey(args[0], args[1], args[2:])
+	if err != nil {
+		return err
+	}
+	valueType := storageValueType(entry)
+
+	if len(args[2:]) == len(keyTypes) {
+		var value *string
+		err = c.client.call(ctx, "state_getStorage", []interface{}{common.BytesToHex(key)}, &value)
+		if err != nil {
+			return err
+		}
+
+		var encoded []byte
+		if value != nil {
+			encoded, err = common.HexToBytes(*value)
+			if err != nil {
+				return err
+			}
+		} else if entry.Modifier.IsDefault {
+			encoded = entry.Fallback
+		} else {
+			c.printf("null\n")
+			return nil
+		}
+		return c.printValue(valueType, encoded)
+	}
+
+	var keys []string
+	err = c.client.call(ctx, "state_getKeysPaged", []interface{}{common.BytesToHex(key), keysPageSize, nil}, &keys)
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		c.printf("no entries\n")
+		return nil
+	}
+
+	var changeSets []struct {
+		Changes [][2]*string `json:"changes"`
+	}
+	err = c.client.call(ctx, "state_queryStorageAt", []interface{}{keys}, &changeSets)
+	if err != nil {
+		return err
+	}
+	for _, changeSet := range changeSets {
+		for _, change := range changeSet.Changes {
+			if change[0] == nil || change[1] == nil {
+				continue
+			}
+			encoded, err := common.HexToBytes(*change[1])
+			if err != nil {
+				return err
+			}
+			c.printf("%s: ", *change[0])
+			err = c.printValue(valueType, encoded)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if len(keys) == keysPageSize {
+		c.printf("first %d entries listed, give more keys to narrow the query\n", keysPageSize)
+	}
+	return nil
+}
+
+func (c *Console) constant(args []string) error {
+	if len(args) == 1 {
+		names := c.metadata.constantNames(args[0])
+		c.printf("%s\n", strings.Join(names, " "))
+		return nil
+	}
+	if len(args) != 2 {
+		return usage("constant")
+	}
+
+	constant, err := c.metadata.constant(args[0], args[1])
+	if err != nil {
+		return err
+	}
+	return c.printValue(constant.Type, constant.Value)
+}
+
+func (c *Console) printValue(id ctypes.Si1LookupTypeID, encoded []byte) error {
+	value, err := c.metadata.decode(id, encoded)
+	if err != nil {
+		return fmt.Errorf("decoding %s: %w", c.metadata.typeName(id), err)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	c.printf("%s\n", data)
+	return nil
+}
+
+func (c *Console) submit(ctx context.Context, args []string) error {
+	if len(args) < 2 {
+		return usage("tx")
+	}
+	if len(args) == 2 {
+		names := c.metadata.callNames(args[1])
+		if names == nil {
+			return fmt.Errorf("%w: %s", errUnknownCall, args[1])
+		}
+		c.printf("%s\n", strings.Join(names, " "))
+		return nil
+	}
+
+	pair, err := devKeyringPair(args[0])
+	if err != nil {
+		return fmt.Errorf("deriving key of %s: %w", args[0], err)
+	}
+
+	pallet, call, err := c.metadata.call(args[1], args[2])
+	if err != nil {
+		return err
+	}
+	callArgs := args[3:]
+	if len(callArgs) != len(call.Fields) {
+		params := make([]string, len(call.Fields))
+		for i, field := range call.Fields {
+			params[i] = fmt.Sprintf("%s: %s", field.Name, c.metadata.typeName(field.Type))
+		}
+		return fmt.Errorf("%w: %s.%s(%s)", errUsage, pallet.Name, call.Name, strings.Join(params, ", "))
+	}
+
+	var encodedArgs []byte
+	for i, field := range call.Fields {
+		encoded, err := c.metadata.encode(field.Type, callArgs[i])
+		if err != nil {
+			return fmt.Errorf("encoding %s: %w", field.Name, err)
+		}
+		encodedArgs = append(encodedArgs, encoded...)
+	}
+
+	var nonce uint64
+	err = c.client.call(ctx, "system_accountNextIndex", []interface{}{pair.Address}, &nonce)
+	if err != nil {
+		return err
+	}
+
+	var version struct {
+		SpecVersion        uint32 `json:"specVersion"`
+		TransactionVersion uint32 `json:"transactionVersion"`
+	}
+	err = c.client.call(ctx, "state_getRuntimeVersion", nil, &version)
+	if err != nil {
+		return err
+	}
+
+	extrinsic := ctypes.NewExtrinsic(ctypes.Call{
+		CallIndex: ctypes.CallIndex{SectionIndex: uint8(pallet.Index), MethodIndex: uint8(call.Index)},
+		Args:      encodedArgs,
+	})
+	err = extrinsic.Sign(pair, ctypes.SignatureOptions{
+		Era:                ctypes.ExtrinsicEra{IsImmortalEra: true},
+		Nonce:              ctypes.NewUCompactFromUInt(nonce),
+		Tip:                ctypes.NewUCompactFromUInt(0),
+		SpecVersion:        ctypes.U32(version.SpecVersion),
+		GenesisHash:        ctypes.Hash(c.genesis),
+		BlockHash:          ctypes.Hash(c.genesis),
+		TransactionVersion: ctypes.U32(version.TransactionVersion),
+	})
+	if err != nil {
+		return fmt.Errorf("signing extrinsic: %w", err)
+	}
+
+	encoded, err := codec.EncodeToHex(extrinsic)
+	if err != nil {
+		return fmt.Errorf("encoding extrinsic: %w", err)
+	}
+
+	var hash string
+	err = c.client.call(ctx, "author_submitExtrinsic", []interface{}{encoded}, &hash)
+	if err != nil {
+		return err
+	}
+	c.printf("submitted %s.%s from %s with nonce %d: %s\n", pallet.Name, call.Name, args[0], nonce, hash)
+	return nil
+}
+
+func (c *Console) rpc(ctx context.Context, args []string) error {
+	if len(args) < 1 {
+		return usage("rpc")
+	}
+
+	params := make([]interface{}, len(args)-1)
+	for i, arg := range args[1:] {
+		var param interface{}
+		if json.Unmarshal([]byte(arg), &param) != nil {
+			param = arg
+		}
+		params[i] = param
+	}
+
+	var result json.RawMessage
+	err := c.client.call(ctx, args[0], params, &result)
+	if err != nil {
+		return err
+	}
+	c.printf("%s\n", result)
+	return nil
+}
+
+func (c *Console) head(ctx context.Context, args []string) error {
+	switch {
+	case len(args) == 0 || args[0] == "on":
+		return c.watchHeads(ctx)
+	case args[0] == "off":
+		c.stopHeads(ctx)
+		return nil
+	default:
+		return usage("head")
+	}
+}
+
+// watchHeads subscribes to the new heads of the node, printing each new block with its events
+func (c *Console) watchHeads(ctx context.Context) error {
+	c.headMutex.Lock()
+	defer c.headMutex.Unlock()
+	if c.headDone != nil {
+		return nil
+	}
+
+	eventsKey, eventsEntry, err := c.metadata.storageKey("System", "Events", nil)
+	if err != nil {
+		return err
+	}
+	eventsType := storageValueType(eventsEntry)
+
+	notifications := make(chan json.RawMessage, 16)
+	id, err := c.client.subscribe(ctx, "chain_subscribeNewHeads", nil, notifications)
+	if err != nil {
+		return err
+	}
+	c.headSubscription = id
+	done := make(chan struct{})
+	c.headDone = done
+
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-c.client.done:
+				return
+			case notification := <-notifications:
+				err := c.printHead(notification, eventsKey, eventsType)
+				if err != nil {
+					c.printf("error: %s\n", err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+func (c *Console) stopHeads(ctx context.Context) {
+	c.headMutex.Lock()
+	defer c.headMutex.Unlock()
+	if c.headDone == nil {
+		return
+	}
+
+	close(c.headDone)
+	c.headDone = nil
+	err := c.client.unsubscribe(ctx, "chain_unsubscribeNewHeads", c.headSubscription)
+	if err != nil && !errors.Is(err, errClientClosed) {
+		c.printf("error: %s\n", err)
+	}
+}
+
+func (c *Console) printHead(notification json.RawMessage, eventsKey []byte,
+	eventsType ctypes.Si1LookupTypeID) error {
+	var header struct {
+		Number string `json:"number"`
+	}
+	err := json.Unmarshal(notification, &header)
+	if err != nil {
+		return fmt.Errorf("decoding header: %w", err)
+	}
+	number, err := strconv.ParseUint(strings.TrimPrefix(header.Number, "0x"), 16, 64)
+	if err != nil {
+		return fmt.Errorf("decoding block number: %w", err)
+	}
+
+	ctx := context.Background()
+	var hash string
+	err = c.client.call(ctx, "chain_getBlockHash", []interface{}{number}, &hash)
+	if err != nil {
+		return err
+	}
+
+	var value *string
+	err = c.client.call(ctx, "state_getStorage", []interface{}{common.BytesToHex(eventsKey), hash}, &value)
+	if err != nil {
+		return err
+	}
+	var records []interface{}
+	if value != nil {
+		encoded, err := common.HexToBytes(*value)
+		if err != nil {
+			return err
+		}
+		decoded, err := c.metadata.decode(eventsType, encoded)
+		if err != nil {
+			return fmt.Errorf("decoding events: %w", err)
+		}
+		records, _ = decoded.([]interface{})
+	}
+
+	c.printf("#%d %s, %d events\n", number, hash, len(records))
+	for _, record := range records {
+		fields, ok := record.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		c.printf("  %s\n", formatEvent(fields["event"]))
+	}
+	return nil
+}
+
+// formatEvent formats a decoded runtime event as Pallet.Event followed by its fields
+func formatEvent(event interface{}) string {
+	pallet, ok := event.(map[string]interface{})
+	if !ok || len(pallet) != 1 {
+		data, _ := json.Marshal(event)
+		return string(data)
+	}
+
+	for palletName, palletEvent := range pallet {
+		switch palletEvent := palletEvent.(type) {
+		case string:
+			return palletName + "." + palletEvent
+		case map[string]interface{}:
+			for eventName, fields := range palletEvent {
+				data, _ := json.Marshal(fields)
+				return palletName + "." + eventName + " " + string(data)
+			}
+		}
+	}
+	return ""
+}
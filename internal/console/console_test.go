@@ -0,0 +1,377 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package console
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const alicePublicKey = "0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d"
+
+func typeID(id uint64) ctypes.Si1LookupTypeID {
+	return ctypes.NewSi1LookupTypeIDFromUInt(id)
+}
+
+func primitive(p ctypes.Si0TypeDefPrimitive) *ctypes.Si1Type {
+	return &ctypes.Si1Type{Def: ctypes.Si1TypeDef{
+		IsPrimitive: true, Primitive: ctypes.Si1TypeDefPrimitive{Si0TypeDefPrimitive: p}}}
+}
+
+func namedField(name string, id uint64) ctypes.Si1Field {
+	return ctypes.Si1Field{HasName: true, Name: ctypes.Text(name), Type: typeID(id)}
+}
+
+// newTestMetadata returns a metadata with a System pallet storing the accounts data and the
+// block number, and a Balances pallet with a transfer call and a constant.
+func newTestMetadata() *metadata {
+	lookup := map[int64]*ctypes.Si1Type{
+		0: primitive(ctypes.IsU8),
+		1: primitive(ctypes.IsU32),
+		2: {Def: ctypes.Si1TypeDef{IsArray: true, Array: ctypes.Si1TypeDefArray{Len: 32, Type: typeID(0)}}},
+		3: {
+			Path: ctypes.Si1Path{"sp_core", "crypto", "AccountId32"},
+			Def: ctypes.Si1TypeDef{IsComposite: true, Composite: ctypes.Si1TypeDefComposite{
+				Fields: []ctypes.Si1Field{{Type: typeID(2)}}}},
+		},
+		4: primitive(ctypes.IsU128),
+		5: {Def: ctypes.Si1TypeDef{IsCompact: true, Compact: ctypes.Si1TypeDefCompact{Type: typeID(4)}}},
+		6: {Def: ctypes.Si1TypeDef{IsSequence: true, Sequence: ctypes.Si1TypeDefSequence{Type: typeID(0)}}},
+		7: {
+			Path: ctypes.Si1Path{"sp_runtime", "multiaddress", "MultiAddress"},
+			Def: ctypes.Si1TypeDef{IsVariant: true, Variant: ctypes.Si1TypeDefVariant{Variants: []ctypes.Si1Variant{
+				{Name: "Id", Index: 0, Fields: []ctypes.Si1Field{{Type: typeID(3)}}},
+				{Name: "Raw", Index: 3, Fields: []ctypes.Si1Field{{Type: typeID(6)}}},
+			}}},
+		},
+		8: {
+			Path: ctypes.Si1Path{"pallet_balances", "AccountData"},
+			Def: ctypes.Si1TypeDef{IsComposite: true, Composite: ctypes.Si1TypeDefComposite{
+				Fields: []ctypes.Si1Field{namedField("free", 4), namedField("nonce", 1)}}},
+		},
+		9: {
+			Path: ctypes.Si1Path{"pallet_balances", "Call"},
+			Def: ctypes.Si1TypeDef{IsVariant: true, Variant: ctypes.Si1TypeDefVariant{Variants: []ctypes.Si1Variant{
+				{Name: "transfer", Index: 0, Fields: []ctypes.Si1Field{namedField("dest", 7), namedField("value", 5)}},
+				{Name: "force_unreserve", Index: 5, Fields: []ctypes.Si1Field{namedField("who", 7), namedField("amount", 4)}},
+			}}},
+		},
+		10: {
+			Path: ctypes.Si1Path{"pallet_balances", "Reasons"},
+			Def: ctypes.Si1TypeDef{IsVariant: true, Variant: ctypes.Si1TypeDefVariant{Variants: []ctypes.Si1Variant{
+				{Name: "Fee", Index: 0}, {Name: "Misc", Index: 1}, {Name: "All", Index: 2},
+			}}},
+		},
+		11: primitive(ctypes.IsBool),
+		12: primitive(ctypes.IsI32),
+		13: primitive(ctypes.IsStr),
+		14: {Def: ctypes.Si1TypeDef{IsTuple: true, Tuple: ctypes.Si1TypeDefTuple{typeID(1), typeID(11)}}},
+	}
+
+	blake2128Concat := ctypes.StorageHasherV10{IsBlake2_128Concat: true}
+	return &metadata{v14: &ctypes.MetadataV14{
+		EfficientLookup: lookup,
+		Pallets: []ctypes.PalletMetadataV14{
+			{
+				Name:       "System",
+				Index:      0,
+				HasStorage: true,
+				Storage: ctypes.StorageMetadataV14{Prefix: "System", Items: []ctypes.StorageEntryMetadataV14{
+					{
+						Name:     "Account",
+						Modifier: ctypes.StorageFunctionModifierV0{IsDefault: true},
+						Type: ctypes.StorageEntryTypeV14{IsMap: true, AsMap: ctypes.MapTypeV14{
+							Hashers: []ctypes.StorageHasherV10{blake2128Concat},
+							Key:     typeID(3),
+							Value:   typeID(8),
+						}},
+					},
+					{
+						Name:     "Number",
+						Modifier: ctypes.StorageFunctionModifierV0{IsDefault: true},
+						Type:     ctypes.StorageEntryTypeV14{IsPlainType: true, AsPlainType: typeID(1)},
+						Fallback: ctypes.Bytes{0, 0, 0, 0},
+					},
+				}},
+				Constants: []ctypes.ConstantMetadataV14{
+					{Name: "SS58Prefix", Type: typeID(1), Value: ctypes.Bytes{42, 0, 0, 0}},
+				},
+			},
+			{
+				Name:     "Balances",
+				Index:    5,
+				HasCalls: true,
+				Calls:    ctypes.FunctionMetadataV14{Type: typeID(9)},
+				Constants: []ctypes.ConstantMetadataV14{
+					{Name: "ExistentialDeposit", Type: typeID(4), Value: common.MustHexToBytes(
+						"0x00e87648170000000000000000000000")},
+				},
+			},
+		},
+	}}
+}
+
+func Test_metadata_encode(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		id         uint64
+		arg        string
+		encoded    string
+		errWrapped error
+	}{
+		"u32": {id: 1, arg: "258", encoded: "0x02010000"},
+		"u32_overflow": {id: 1, arg: "4294967296",
+			errWrapped: errInvalidArgument},
+		"negative_i32":     {id: 12, arg: "-2", encoded: "0xfeffffff"},
+		"bool":             {id: 11, arg: "true", encoded: "0x01"},
+		"str":              {id: 13, arg: "abc", encoded: "0x0c616263"},
+		"compact":          {id: 5, arg: "1000000000000", encoded: "0x070010a5d4e8"},
+		"bytes":            {id: 6, arg: "0x0102", encoded: "0x080102"},
+		"bytes_text":       {id: 6, arg: "ab", encoded: "0x086162"},
+		"account_dev_name": {id: 3, arg: "Alice", encoded: alicePublicKey},
+		"account_ss58": {id: 3, arg: "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY",
+			encoded: alicePublicKey},
+		"account_hex":           {id: 3, arg: alicePublicKey, encoded: alicePublicKey},
+		"account_invalid":       {id: 3, arg: "mallory", errWrapped: errInvalidArgument},
+		"variant_without_field": {id: 10, arg: "misc", encoded: "0x01"},
+		"variant_with_field":    {id: 7, arg: "Raw:0x01", encoded: "0x030401"},
+		"address_as_account":    {id: 7, arg: "alice", encoded: "0x00" + alicePublicKey[2:]},
+		"unknown_variant":       {id: 10, arg: "None", errWrapped: errInvalidArgument},
+		"scale_encoded_hex":     {id: 8, arg: "0x0102", encoded: "0x0102"},
+		"composite_of_fields":   {id: 8, arg: "1", errWrapped: errInvalidArgument},
+		"unknown_type":          {id: 99, arg: "1", errWrapped: errUnknownType},
+	}
+
+	m := newTestMetadata()
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			encoded, err := m.encode(typeID(testCase.id), testCase.arg)
+			if testCase.errWrapped != nil {
+				assert.ErrorIs(t, err, testCase.errWrapped)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.encoded, common.BytesToHex(encoded))
+		})
+	}
+}
+
+func Test_metadata_decode(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		id         uint64
+		encoded    string
+		json       string
+		errWrapped error
+	}{
+		"u32":                {id: 1, encoded: "0x02010000", json: "258"},
+		"i32":                {id: 12, encoded: "0xfeffffff", json: "-2"},
+		"u128":               {id: 4, encoded: "0x00e87648170000000000000000000000", json: "100000000000"},
+		"compact":            {id: 5, encoded: "0x070010a5d4e8", json: "1000000000000"},
+		"bytes":              {id: 6, encoded: "0x080102", json: `"0x0102"`},
+		"account":            {id: 3, encoded: alicePublicKey, json: `"` + alicePublicKey + `"`},
+		"str":                {id: 13, encoded: "0x0c616263", json: `"abc"`},
+		"tuple":              {id: 14, encoded: "0x0700000001", json: "[7,true]"},
+		"variant":            {id: 10, encoded: "0x02", json: `"All"`},
+		"variant_with_field": {id: 7, encoded: "0x030401", json: `{"Raw":"0x01"}`},
+		"composite":          {id: 8, encoded: "0x0a00000000000000000000000000000001000000", json: `{"free":10,"nonce":1}`},
+		"call": {id: 9, encoded: "0x0003040128",
+			json: `{"transfer":{"dest":{"Raw":"0x01"},"value":10}}`},
+		"trailing_bytes":  {id: 1, encoded: "0x0201000000", errWrapped: errTrailingBytes},
+		"unknown_variant": {id: 10, encoded: "0x03"},
+	}
+
+	m := newTestMetadata()
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			value, err := m.decode(typeID(testCase.id), common.MustHexToBytes(testCase.encoded))
+			if testCase.json == "" {
+				if testCase.errWrapped != nil {
+					assert.ErrorIs(t, err, testCase.errWrapped)
+				} else {
+					assert.Error(t, err)
+				}
+				return
+			}
+			require.NoError(t, err)
+			data, err := json.Marshal(value)
+			require.NoError(t, err)
+			assert.JSONEq(t, testCase.json, string(data))
+		})
+	}
+}
+
+func Test_metadata_storageKey(t *testing.T) {
+	t.Parallel()
+
+	m := newTestMetadata()
+
+	key, entry, err := m.storageKey("system", "account", []string{"alice"})
+	require.NoError(t, err)
+	assert.Equal(t, "0x26aa394eea5630e07c48ae0c9558cef7b99d880ec681799c0cf30e8886371da9"+
+		"de1e86a9a8c739864cf3cc5ec2bea59f"+alicePublicKey[2:], common.BytesToHex(key))
+	assert.Equal(t, typeID(8), storageValueType(entry))
+
+	prefix, _, err := m.storageKey("System", "Account", nil)
+	require.NoError(t, err)
+	assert.Equal(t, key[:32], prefix)
+
+	_, _, err = m.storageKey("System", "Number", []string{"1"})
+	assert.ErrorIs(t, err, errInvalidArgument)
+
+	_, _, err = m.storageKey("System", "Unknown", nil)
+	assert.ErrorIs(t, err, errUnknownStorage)
+
+	_, _, err = m.storageKey("Balances", "Account", nil)
+	assert.ErrorIs(t, err, errUnknownStorage)
+}
+
+func Test_metadata_typeName(t *testing.T) {
+	t.Parallel()
+
+	m := newTestMetadata()
+	assert.Equal(t, "AccountId32", m.typeName(typeID(3)))
+	assert.Equal(t, "Compact<u128>", m.typeName(typeID(5)))
+	assert.Equal(t, "Vec<u8>", m.typeName(typeID(6)))
+	assert.Equal(t, "[u8; 32]", m.typeName(typeID(2)))
+	assert.Equal(t, "(u32, bool)", m.typeName(typeID(14)))
+	assert.Equal(t, "?", m.typeName(typeID(99)))
+}
+
+func TestConsole_Execute(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		line       string
+		output     string
+		errWrapped error
+		errMessage string
+	}{
+		"empty":          {line: "  "},
+		"pallets":        {line: "pallets", output: "Balances\nSystem\n"},
+		"storage_items":  {line: "storage system", output: "Account Number\n"},
+		"constant":       {line: "constant Balances ExistentialDeposit", output: "100000000000\n"},
+		"constant_names": {line: "constant System", output: "SS58Prefix\n"},
+		"unknown_constant": {line: "constant System Unknown", errWrapped: errUnknownConstant,
+			errMessage: "unknown constant: System.Unknown"},
+		"tx_calls": {line: "tx alice Balances", output: "force_unreserve transfer\n"},
+		"tx_args_usage": {line: "tx alice Balances transfer bob", errWrapped: errUsage,
+			errMessage: "usage: Balances.transfer(dest: MultiAddress, value: Compact<u128>)"},
+		"storage_usage":   {line: "storage", errWrapped: errUsage},
+		"unknown_command": {line: "foo", errWrapped: errUnknownCommand, errMessage: "unknown command: foo, see help"},
+		"exit":            {line: "exit", errWrapped: ErrExit},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			output := bytes.NewBuffer(nil)
+			console := &Console{metadata: newTestMetadata(), out: output}
+
+			err := console.Execute(context.Background(), testCase.line)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errMessage != "" {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.output, output.String())
+		})
+	}
+}
+
+func TestConsole_Run(t *testing.T) {
+	t.Parallel()
+
+	output := bytes.NewBuffer(nil)
+	console := &Console{metadata: newTestMetadata(), out: output}
+
+	input := bytes.NewBufferString("constant System SS58Prefix\nfoo\nexit\npallets\n")
+	err := console.Run(context.Background(), input)
+	require.NoError(t, err)
+	assert.Equal(t, "42\nerror: unknown command: foo, see help\n", output.String())
+}
+
+func TestConsole_Complete(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		line       string
+		candidates []string
+	}{
+		"commands": {line: "",
+			candidates: []string{"help", "pallets", "storage", "constant", "tx", "head", "rpc", "exit"}},
+		"command_prefix":  {line: "h", candidates: []string{"help", "head"}},
+		"storage_pallets": {line: "storage ", candidates: []string{"System"}},
+		"storage_items":   {line: "storage system a", candidates: []string{"Account"}},
+		"storage_keys":    {line: "storage System Account b", candidates: []string{"bob"}},
+		"constant_names":  {line: "constant Balances e", candidates: []string{"ExistentialDeposit"}},
+		"tx_accounts":     {line: "tx ch", candidates: []string{"charlie"}},
+		"tx_pallets":      {line: "tx alice ", candidates: []string{"Balances"}},
+		"tx_calls":        {line: "tx alice Balances t", candidates: []string{"transfer"}},
+		"head":            {line: "head o", candidates: []string{"on", "off"}},
+		"no_candidates":   {line: "storage Unknown ", candidates: nil},
+	}
+
+	console := &Console{metadata: newTestMetadata()}
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.candidates, console.Complete(testCase.line))
+		})
+	}
+}
+
+func TestConsole_AutoComplete(t *testing.T) {
+	t.Parallel()
+
+	console := &Console{metadata: newTestMetadata()}
+
+	line, pos, ok := console.AutoComplete("storage sys", 11, '\t')
+	assert.True(t, ok)
+	assert.Equal(t, "storage System ", line)
+	assert.Equal(t, len(line), pos)
+
+	line, pos, ok = console.AutoComplete("head o", 6, '\t')
+	assert.True(t, ok)
+	assert.Equal(t, "head o", line)
+	assert.Equal(t, 6, pos)
+
+	_, _, ok = console.AutoComplete("storage sys", 11, 'a')
+	assert.False(t, ok)
+
+	_, _, ok = console.AutoComplete("storage sys", 3, '\t')
+	assert.False(t, ok)
+}
+
+func Test_formatEvent(t *testing.T) {
+	t.Parallel()
+
+	event := map[string]interface{}{"Balances": map[string]interface{}{
+		"Deposit": map[string]interface{}{"who": alicePublicKey},
+	}}
+	assert.Equal(t, `Balances.Deposit {"who":"`+alicePublicKey+`"}`, formatEvent(event))
+
+	event = map[string]interface{}{"System": "CodeUpdated"}
+	assert.Equal(t, "System.CodeUpdated", formatEvent(event))
+
+	assert.Equal(t, `"unexpected"`, formatEvent("unexpected"))
+}
@@ -0,0 +1,296 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package console
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+)
+
+var (
+	errMetadataVersion = errors.New("unsupported metadata version")
+	errUnknownPallet   = errors.New("unknown pallet")
+	errUnknownStorage  = errors.New("unknown storage item")
+	errUnknownCall     = errors.New("unknown call")
+	errUnknownConstant = errors.New("unknown constant")
+	errUnknownType     = errors.New("unknown type")
+)
+
+// metadata is the runtime metadata of the node, used to build the storage keys and the calls,
+// to decode the storage values and the events, and to complete the console commands.
+type metadata struct {
+	v14 *ctypes.MetadataV14
+}
+
+// newMetadata decodes the hex encoded metadata returned by state_getMetadata
+func newMetadata(encoded string) (*metadata, error) {
+	raw := &ctypes.Metadata{}
+	err := codec.DecodeFromHex(encoded, raw)
+	if err != nil {
+		return nil, fmt.Errorf("decoding metadata: %w", err)
+	}
+	if raw.Version != 14 {
+		return nil, fmt.Errorf("%w: %d", errMetadataVersion, raw.Version)
+	}
+	return &metadata{v14: &raw.AsMetadataV14}, nil
+}
+
+func (m *metadata) pallet(name string) (*ctypes.PalletMetadataV14, error) {
+	for i := range m.v14.Pallets {
+		if strings.EqualFold(string(m.v14.Pallets[i].Name), name) {
+			return &m.v14.Pallets[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", errUnknownPallet, name)
+}
+
+func (m *metadata) lookup(id ctypes.Si1LookupTypeID) (*ctypes.Si1Type, error) {
+	typ, ok := m.v14.EfficientLookup[id.Int64()]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", errUnknownType, id.Int64())
+	}
+	return typ, nil
+}
+
+// storageEntry returns the storage entry of the pallet given, and the type IDs of its keys.
+func (m *metadata) storageEntry(palletName, item string) (
+	pallet *ctypes.PalletMetadataV14, entry *ctypes.StorageEntryMetadataV14,
+	keys []ctypes.Si1LookupTypeID, err error) {
+	pallet, err = m.pallet(palletName)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if !pallet.HasStorage {
+		return nil, nil, nil, fmt.Errorf("%w: %s has no storage", errUnknownStorage, pallet.Name)
+	}
+
+	for i := range pallet.Storage.Items {
+		if !strings.EqualFold(string(pallet.Storage.Items[i].Name), item) {
+			continue
+		}
+		entry = &pallet.Storage.Items[i]
+		if !entry.Type.IsMap {
+			return pallet, entry, nil, nil
+		}
+
+		keyType, err := m.lookup(entry.Type.AsMap.Key)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if len(entry.Type.AsMap.Hashers) > 1 && keyType.Def.IsTuple {
+			keys = keyType.Def.Tuple
+		} else {
+			keys = []ctypes.Si1LookupTypeID{entry.Type.AsMap.Key}
+		}
+		return pallet, entry, keys, nil
+	}
+	return nil, nil, nil, fmt.Errorf("%w: %s.%s", errUnknownStorage, pallet.Name, item)
+}
+
+// call returns the pallet given and its call variant of the name given
+func (m *metadata) call(palletName, name string) (*ctypes.PalletMetadataV14, *ctypes.Si1Variant, error) {
+	pallet, err := m.pallet(palletName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !pallet.HasCalls {
+		return nil, nil, fmt.Errorf("%w: %s has no calls", errUnknownCall, pallet.Name)
+	}
+
+	calls, err := m.lookup(pallet.Calls.Type)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range calls.Def.Variant.Variants {
+		if strings.EqualFold(string(calls.Def.Variant.Variants[i].Name), name) {
+			return pallet, &calls.Def.Variant.Variants[i], nil
+		}
+	}
+	return nil, nil, fmt.Errorf("%w: %s.%s", errUnknownCall, pallet.Name, name)
+}
+
+// constant returns the constant of the pallet given
+func (m *metadata) constant(palletName, name string) (*ctypes.ConstantMetadataV14, error) {
+	pallet, err := m.pallet(palletName)
+	if err != nil {
+		return nil, err
+	}
+	for i := range pallet.Constants {
+		if strings.EqualFold(string(pallet.Constants[i].Name), name) {
+			return &pallet.Constants[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s.%s", errUnknownConstant, pallet.Name, name)
+}
+
+// palletNames returns the sorted names of the pallets for which the filter given is true
+func (m *metadata) palletNames(filter func(pallet *ctypes.PalletMetadataV14) bool) []string {
+	var names []string
+	for i := range m.v14.Pallets {
+		if filter(&m.v14.Pallets[i]) {
+			names = append(names, string(m.v14.Pallets[i].Name))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// storageNames returns the sorted names of the storage items of the pallet given
+func (m *metadata) storageNames(palletName string) []string {
+	pallet, err := m.pallet(palletName)
+	if err != nil || !pallet.HasStorage {
+		return nil
+	}
+	names := make([]string, len(pallet.Storage.Items))
+	for i, item := range pallet.Storage.Items {
+		names[i] = string(item.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// callNames returns the sorted names of the calls of the pallet given
+func (m *metadata) callNames(palletName string) []string {
+	pallet, err := m.pallet(palletName)
+	if err != nil || !pallet.HasCalls {
+		return nil
+	}
+	calls, err := m.lookup(pallet.Calls.Type)
+	if err != nil {
+		return nil
+	}
+	names := make([]string, len(calls.Def.Variant.Variants))
+	for i, variant := range calls.Def.Variant.Variants {
+		names[i] = string(variant.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// constantNames returns the sorted names of the constants of the pallet given
+func (m *metadata) constantNames(palletName string) []string {
+	pallet, err := m.pallet(palletName)
+	if err != nil {
+		return nil
+	}
+	names := make([]string, len(pallet.Constants))
+	for i, constant := range pallet.Constants {
+		names[i] = string(constant.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// typeName returns a readable name of the type given, for the command usages
+func (m *metadata) typeName(id ctypes.Si1LookupTypeID) string {
+	typ, err := m.lookup(id)
+	if err != nil {
+		return "?"
+	}
+
+	switch {
+	case len(typ.Path) > 0:
+		return string(typ.Path[len(typ.Path)-1])
+	case typ.Def.IsPrimitive:
+		return primitiveNames[typ.Def.Primitive.Si0TypeDefPrimitive]
+	case typ.Def.IsCompact:
+		return "Compact<" + m.typeName(typ.Def.Compact.Type) + ">"
+	case typ.Def.IsSequence:
+		return "Vec<" + m.typeName(typ.Def.Sequence.Type) + ">"
+	case typ.Def.IsArray:
+		return fmt.Sprintf("[%s; %d]", m.typeName(typ.Def.Array.Type), typ.Def.Array.Len)
+	case typ.Def.IsTuple:
+		names := make([]string, len(typ.Def.Tuple))
+		for i, element := range typ.Def.Tuple {
+			names[i] = m.typeName(element)
+		}
+		return "(" + strings.Join(names, ", ") + ")"
+	default:
+		return "?"
+	}
+}
+
+var primitiveNames = map[ctypes.Si0TypeDefPrimitive]string{
+	ctypes.IsBool: "bool", ctypes.IsChar: "char", ctypes.IsStr: "str",
+	ctypes.IsU8: "u8", ctypes.IsU16: "u16", ctypes.IsU32: "u32", ctypes.IsU64: "u64",
+	ctypes.IsU128: "u128", ctypes.IsU256: "u256",
+	ctypes.IsI8: "i8", ctypes.IsI16: "i16", ctypes.IsI32: "i32", ctypes.IsI64: "i64",
+	ctypes.IsI128: "i128", ctypes.IsI256: "i256",
+}
+
+// storageKey returns the storage key of the storage item given, the prefix of its entries if
+// fewer keys than the map keys are given. The keys are given in their text representation.
+func (m *metadata) storageKey(palletName, item string, args []string) (
+	key []byte, entry *ctypes.StorageEntryMetadataV14, err error) {
+	pallet, entry, keyTypes, err := m.storageEntry(palletName, item)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(args) > len(keyTypes) {
+		return nil, nil, fmt.Errorf("%w: %s.%s has %d keys, %d given", errInvalidArgument,
+			pallet.Name, entry.Name, len(keyTypes), len(args))
+	}
+
+	palletHash, err := common.Twox128Hash([]byte(pallet.Storage.Prefix))
+	if err != nil {
+		return nil, nil, err
+	}
+	itemHash, err := common.Twox128Hash([]byte(entry.Name))
+	if err != nil {
+		return nil, nil, err
+	}
+	key = append(palletHash, itemHash...)
+
+	for i, arg := range args {
+		encoded, err := m.encode(keyTypes[i], arg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("encoding key %d: %w", i+1, err)
+		}
+		hashed, err := hashStorageKey(entry.Type.AsMap.Hashers[i], encoded)
+		if err != nil {
+			return nil, nil, err
+		}
+		key = append(key, hashed...)
+	}
+	return key, entry, nil
+}
+
+func hashStorageKey(hasher ctypes.StorageHasherV10, encoded []byte) ([]byte, error) {
+	switch {
+	case hasher.IsBlake2_128:
+		return common.Blake2b128(encoded)
+	case hasher.IsBlake2_256:
+		hash, err := common.Blake2bHash(encoded)
+		return hash[:], err
+	case hasher.IsBlake2_128Concat:
+		hash, err := common.Blake2b128(encoded)
+		return append(hash, encoded...), err
+	case hasher.IsTwox128:
+		return common.Twox128Hash(encoded)
+	case hasher.IsTwox256:
+		hash, err := common.Twox256(encoded)
+		return hash[:], err
+	case hasher.IsTwox64Concat:
+		hash, err := common.Twox64(encoded)
+		return append(hash, encoded...), err
+	case hasher.IsIdentity:
+		return encoded, nil
+	default:
+		return nil, errors.New("unknown storage hasher")
+	}
+}
+
+// storageValueType returns the type of the values of the storage entry given
+func storageValueType(entry *ctypes.StorageEntryMetadataV14) ctypes.Si1LookupTypeID {
+	if entry.Type.IsMap {
+		return entry.Type.AsMap.Value
+	}
+	return entry.Type.AsPlainType
+}
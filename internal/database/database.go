@@ -54,6 +54,23 @@ type Database interface {
 	NewPrefixIterator(prefix []byte) (Iterator, error)
 }
 
+// Stats are statistics of the database, such as its disk usage and the activity of
+// its block cache, exposed for diagnostics.
+type Stats struct {
+	DiskSpaceUsage    uint64 `json:"diskSpaceUsage"`
+	ReadAmplification int    `json:"readAmplification"`
+	MemTableSize      uint64 `json:"memTableSize"`
+	MemTableCount     int64  `json:"memTableCount"`
+	WALSize           uint64 `json:"walSize"`
+	FlushCount        int64  `json:"flushCount"`
+	CompactionCount   int64  `json:"compactionCount"`
+	CompactionDebt    uint64 `json:"compactionDebt"`
+	BlockCacheSize    int64  `json:"blockCacheSize"`
+	BlockCacheHits    int64  `json:"blockCacheHits"`
+	BlockCacheMisses  int64  `json:"blockCacheMisses"`
+	Snapshots         int    `json:"snapshots"`
+}
+
 type Table interface {
 	Reader
 	Writer
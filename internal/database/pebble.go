@@ -4,6 +4,7 @@
 package database
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"os"
@@ -110,6 +111,53 @@ func (p *PebbleDB) Flush() error {
 	return nil
 }
 
+// Stats returns a snapshot of the statistics of the database.
+func (p *PebbleDB) Stats() Stats {
+	metrics := p.db.Metrics()
+	return Stats{
+		DiskSpaceUsage:    metrics.DiskSpaceUsage(),
+		ReadAmplification: metrics.ReadAmp(),
+		MemTableSize:      metrics.MemTable.Size,
+		MemTableCount:     metrics.MemTable.Count,
+		WALSize:           metrics.WAL.Size,
+		FlushCount:        metrics.Flush.Count,
+		CompactionCount:   metrics.Compact.Count,
+		CompactionDebt:    metrics.Compact.EstimatedDebt,
+		BlockCacheSize:    metrics.BlockCache.Size,
+		BlockCacheHits:    metrics.BlockCache.Hits,
+		BlockCacheMisses:  metrics.BlockCache.Misses,
+		Snapshots:         metrics.Snapshots.Count,
+	}
+}
+
+// Compact compacts the whole key range of the database, reclaiming the disk space
+// of the deleted and overwritten keys. It blocks until the compaction is done.
+func (p *PebbleDB) Compact() error {
+	iter, err := p.db.NewIter(nil)
+	if err != nil {
+		return fmt.Errorf("creating iterator: %w", err)
+	}
+
+	if !iter.First() {
+		return iter.Close()
+	}
+	start := bytes.Clone(iter.Key())
+	iter.Last()
+	// the end of the range is exclusive
+	end := append(bytes.Clone(iter.Key()), 0)
+
+	err = iter.Close()
+	if err != nil {
+		return fmt.Errorf("closing iterator: %w", err)
+	}
+
+	err = p.db.Compact(start, end, true)
+	if err != nil {
+		return fmt.Errorf("compacting database: %w", err)
+	}
+	return nil
+}
+
 // NewBatch returns an implementation of Batch interface using the
 // internal database
 func (p *PebbleDB) NewBatch() Batch {
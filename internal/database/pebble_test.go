@@ -64,6 +64,46 @@ func TestPebbleDBIterator(t *testing.T) {
 	testSeekKeyValueIterator(t, db)
 }
 
+func TestPebbleDBStats(t *testing.T) {
+	db := testNewPebble(t).(*PebbleDB)
+
+	err := db.Put([]byte("key"), []byte("value"))
+	require.NoError(t, err)
+	err = db.Flush()
+	require.NoError(t, err)
+
+	stats := db.Stats()
+	require.Equal(t, int64(1), stats.FlushCount)
+	require.NotZero(t, stats.DiskSpaceUsage)
+}
+
+func TestPebbleDBCompact(t *testing.T) {
+	db := testNewPebble(t).(*PebbleDB)
+
+	// compacting an empty database is a no-op
+	err := db.Compact()
+	require.NoError(t, err)
+
+	for _, key := range []string{"a", "b", "c"} {
+		err = db.Put([]byte(key), []byte("value"))
+		require.NoError(t, err)
+	}
+	err = db.Del([]byte("b"))
+	require.NoError(t, err)
+
+	err = db.Compact()
+	require.NoError(t, err)
+
+	stats := db.Stats()
+	require.NotZero(t, stats.CompactionCount)
+
+	value, err := db.Get([]byte("c"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+	_, err = db.Get([]byte("b"))
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
 func testPutGetter(t *testing.T, db Database) {
 	tests := testSetup()
 	for _, v := range tests {
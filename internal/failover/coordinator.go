@@ -0,0 +1,193 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package failover
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/internal/log"
+)
+
+var logger = log.NewFromGlobal(log.AddContext("pkg", "failover"))
+
+// Role switches the node between the authority role and the full node role
+type Role interface {
+	SetAuthoring(authoring bool) error
+}
+
+// Coordinator coordinates the nodes sharing the same authority keys through a lease, so at
+// most one of them authors blocks and votes at a time and the authority does not equivocate
+// during a failover. The node holding the lease is switched to the authority role and the
+// other nodes stay in the full node role, taking over once the lease expired.
+//
+// The lease is renewed each third of its duration, and the node switches to the full node
+// role if it could not renew the lease for two thirds of its duration, so it stops authoring
+// a third of the lease duration before another node can acquire it.
+type Coordinator struct {
+	lease    Lease
+	holder   string
+	ttl      time.Duration
+	interval time.Duration
+	role     Role
+	now      func() time.Time
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mutex sync.Mutex
+	// held is true if the node holds the lease and is in the authority role
+	held bool
+	// renewed is the time the lease was last acquired or renewed at
+	renewed time.Time
+	// steppedDown is true if the node does not compete for the lease
+	steppedDown bool
+}
+
+// NewCoordinator returns a coordinator of the role given competing for the lease given as
+// the holder given, for leases of the duration given. The node does not compete for the
+// lease until switched to the authority role if standby is true.
+func NewCoordinator(lease Lease, holder string, ttl time.Duration, role Role, standby bool) *Coordinator {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Coordinator{
+		lease:       lease,
+		holder:      holder,
+		ttl:         ttl,
+		interval:    ttl / 3,
+		role:        role,
+		now:         time.Now,
+		ctx:         ctx,
+		cancel:      cancel,
+		steppedDown: standby,
+	}
+}
+
+// Start starts competing for the lease
+func (c *Coordinator) Start() error {
+	logger.Infof("competing for the authoring lease as %s", c.holder)
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.run()
+	}()
+	return nil
+}
+
+// Stop stops competing for the lease, releasing it if held so another node takes over
+func (c *Coordinator) Stop() error {
+	c.cancel()
+	c.wg.Wait()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.stepDown()
+	return nil
+}
+
+// SetAuthoring makes the node compete for the lease if authoring is true, the node being
+// switched to the authority role once it acquired the lease. Otherwise, the node releases
+// the lease if held, switches to the full node role and stops competing for the lease.
+func (c *Coordinator) SetAuthoring(authoring bool) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.steppedDown = !authoring
+	if !authoring {
+		c.stepDown()
+	}
+	return nil
+}
+
+// IsAuthoring returns true if the node holds the lease and is in the authority role.
+func (c *Coordinator) IsAuthoring() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.held
+}
+
+func (c *Coordinator) run() {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		c.tick()
+
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tick acquires or renews the lease, switching the node to the authority role when it
+// acquired the lease, and to the full node role when it lost it.
+func (c *Coordinator) tick() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.steppedDown {
+		return
+	}
+
+	// the lease expiry is counted from before the lease is acquired
+	attempted := c.now()
+	acquired, err := c.lease.Acquire(c.holder, c.ttl)
+	switch {
+	case err != nil:
+		logger.Warnf("cannot acquire lease: %s", err)
+		if c.held && c.now().Sub(c.renewed) >= c.ttl-c.interval {
+			logger.Errorf("lease not renewed for %s, switching to the full node role", c.now().Sub(c.renewed))
+			c.demote()
+		}
+	case !acquired:
+		if c.held {
+			logger.Errorf("lease acquired by another node, switching to the full node role")
+			c.demote()
+		}
+	default:
+		c.renewed = attempted
+		if c.held {
+			return
+		}
+
+		logger.Info("lease acquired, switching to the authority role")
+		err = c.role.SetAuthoring(true)
+		if err != nil {
+			logger.Errorf("cannot switch to the authority role: %s", err)
+			c.release()
+			return
+		}
+		c.held = true
+	}
+}
+
+// stepDown switches the node to the full node role and releases the lease if held.
+func (c *Coordinator) stepDown() {
+	if !c.held {
+		return
+	}
+	c.demote()
+	c.release()
+}
+
+// demote switches the node to the full node role.
+func (c *Coordinator) demote() {
+	c.held = false
+	err := c.role.SetAuthoring(false)
+	if err != nil {
+		logger.Criticalf("cannot switch to the full node role: %s", err)
+	}
+}
+
+// release releases the lease.
+func (c *Coordinator) release() {
+	err := c.lease.Release(c.holder)
+	if err != nil {
+		logger.Warnf("cannot release lease: %s", err)
+	}
+}
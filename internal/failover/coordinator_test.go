@@ -0,0 +1,164 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package failover
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCoordinator_tick(t *testing.T) {
+	t.Parallel()
+
+	const ttl = 9 * time.Second
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		held          bool
+		sinceRenewal  time.Duration
+		steppedDown   bool
+		acquired      bool
+		acquireErr    error
+		setAuthoring  *bool
+		setRoleErr    error
+		release       bool
+		expectedHeld  bool
+		expectRenewal bool
+	}{
+		"stepped_down": {
+			steppedDown: true,
+		},
+		"lease_acquired": {
+			acquired:      true,
+			setAuthoring:  ptrTo(true),
+			expectedHeld:  true,
+			expectRenewal: true,
+		},
+		"lease_renewed": {
+			held:          true,
+			acquired:      true,
+			expectedHeld:  true,
+			expectRenewal: true,
+		},
+		"authority_role_error": {
+			acquired:      true,
+			setAuthoring:  ptrTo(true),
+			setRoleErr:    errTest,
+			release:       true,
+			expectRenewal: true,
+		},
+		"lease_held_by_other_node": {
+			acquired: false,
+		},
+		"lease_lost": {
+			held:         true,
+			acquired:     false,
+			setAuthoring: ptrTo(false),
+		},
+		"renewal_error": {
+			held:         true,
+			sinceRenewal: ttl / 3,
+			acquireErr:   errTest,
+			expectedHeld: true,
+		},
+		"renewal_error_past_deadline": {
+			held:         true,
+			sinceRenewal: 2 * ttl / 3,
+			acquireErr:   errTest,
+			setAuthoring: ptrTo(false),
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			now := time.Unix(1_700_000_000, 0)
+			lease := NewMockLease(ctrl)
+			role := NewMockRole(ctrl)
+			coordinator := NewCoordinator(lease, "primary", ttl, role, testCase.steppedDown)
+			coordinator.now = func() time.Time { return now }
+			coordinator.held = testCase.held
+			coordinator.renewed = now.Add(-testCase.sinceRenewal)
+
+			if !testCase.steppedDown {
+				lease.EXPECT().Acquire("primary", ttl).Return(testCase.acquired, testCase.acquireErr)
+			}
+			if testCase.setAuthoring != nil {
+				role.EXPECT().SetAuthoring(*testCase.setAuthoring).Return(testCase.setRoleErr)
+			}
+			if testCase.release {
+				lease.EXPECT().Release("primary").Return(nil)
+			}
+
+			coordinator.tick()
+
+			assert.Equal(t, testCase.expectedHeld, coordinator.held)
+			if testCase.expectRenewal {
+				assert.Equal(t, now, coordinator.renewed)
+			}
+		})
+	}
+}
+
+func TestCoordinator_SetAuthoring(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	lease := NewMockLease(ctrl)
+	role := NewMockRole(ctrl)
+	coordinator := NewCoordinator(lease, "primary", time.Minute, role, false)
+	coordinator.held = true
+
+	// stepping down releases the lease held
+	role.EXPECT().SetAuthoring(false).Return(nil)
+	lease.EXPECT().Release("primary").Return(nil)
+	err := coordinator.SetAuthoring(false)
+	assert.NoError(t, err)
+	assert.False(t, coordinator.IsAuthoring())
+
+	// the node stepped down does not compete for the lease
+	coordinator.tick()
+
+	err = coordinator.SetAuthoring(true)
+	assert.NoError(t, err)
+	assert.False(t, coordinator.IsAuthoring())
+
+	lease.EXPECT().Acquire("primary", time.Minute).Return(true, nil)
+	role.EXPECT().SetAuthoring(true).Return(nil)
+	coordinator.tick()
+	assert.True(t, coordinator.IsAuthoring())
+}
+
+func TestCoordinator_Stop(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	lease := NewMockLease(ctrl)
+	role := NewMockRole(ctrl)
+	coordinator := NewCoordinator(lease, "primary", time.Minute, role, false)
+
+	lease.EXPECT().Acquire("primary", time.Minute).Return(true, nil)
+	role.EXPECT().SetAuthoring(true).Return(nil)
+	role.EXPECT().SetAuthoring(false).Return(nil)
+	lease.EXPECT().Release("primary").Return(nil)
+
+	err := coordinator.Start()
+	assert.NoError(t, err)
+	assert.Eventually(t, coordinator.IsAuthoring, time.Second, 10*time.Millisecond)
+
+	err = coordinator.Stop()
+	assert.NoError(t, err)
+	assert.False(t, coordinator.IsAuthoring())
+}
+
+func ptrTo[T any](value T) *T {
+	return &value
+}
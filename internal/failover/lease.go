@@ -0,0 +1,204 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package failover
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// lockAttempts is the number of attempts to create the lock file of a file lease
+	lockAttempts = 10
+	// lockRetryDelay is the delay between two attempts to create the lock file
+	lockRetryDelay = 50 * time.Millisecond
+)
+
+// ErrLeaseLocked is returned when the lock file of a file lease cannot be created,
+// another node updating the lease
+var ErrLeaseLocked = errors.New("lease is locked by another node")
+
+// Lease is a lease held by at most one holder at a time, until it expires unless renewed
+type Lease interface {
+	// Acquire acquires the lease for the holder given, or renews it if the holder already
+	// holds it, for the duration given. It returns false if another holder holds the lease
+	// and the lease did not expire.
+	Acquire(holder string, ttl time.Duration) (acquired bool, err error)
+	// Release releases the lease if the holder given holds it.
+	Release(holder string) error
+}
+
+// leaseRecord is the content of the file of a file lease
+type leaseRecord struct {
+	Holder string    `json:"holder"`
+	Expiry time.Time `json:"expiry"`
+}
+
+// FileLease is a lease stored in a file of a filesystem shared by the nodes competing for
+// it, such as a network filesystem. The lease file is updated under a lock file created
+// exclusively, and replaced atomically.
+type FileLease struct {
+	path string
+	now  func() time.Time
+}
+
+// NewFileLease returns a lease stored in the file of the path given.
+func NewFileLease(path string) *FileLease {
+	return &FileLease{
+		path: path,
+		now:  time.Now,
+	}
+}
+
+// Acquire acquires the lease for the holder given for the duration given, if the lease is
+// not held by another holder or expired.
+func (l *FileLease) Acquire(holder string, ttl time.Duration) (acquired bool, err error) {
+	unlock, err := l.lock(ttl)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		unlockErr := unlock()
+		if unlockErr != nil {
+			err = errors.Join(err, unlockErr)
+		}
+	}()
+
+	record, err := l.read()
+	if err != nil {
+		return false, err
+	}
+
+	now := l.now()
+	if record.Holder != "" && record.Holder != holder && now.Before(record.Expiry) {
+		return false, nil
+	}
+
+	err = l.write(leaseRecord{Holder: holder, Expiry: now.Add(ttl)})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Release removes the lease file if the holder given holds the lease.
+func (l *FileLease) Release(holder string) (err error) {
+	unlock, err := l.lock(0)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		unlockErr := unlock()
+		if unlockErr != nil {
+			err = errors.Join(err, unlockErr)
+		}
+	}()
+
+	record, err := l.read()
+	if err != nil {
+		return err
+	}
+
+	if record.Holder != holder {
+		return nil
+	}
+
+	err = os.Remove(l.path)
+	if err != nil {
+		return fmt.Errorf("removing lease file: %w", err)
+	}
+	return nil
+}
+
+// lock creates the lock file of the lease, returning the function removing it. A lock file
+// older than the duration given, left by a node stopped while updating the lease, is removed.
+func (l *FileLease) lock(staleAfter time.Duration) (unlock func() error, err error) {
+	lockPath := l.path + ".lock"
+	for attempt := 1; ; attempt++ {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			err = file.Close()
+			if err != nil {
+				return nil, fmt.Errorf("closing lock file: %w", err)
+			}
+			return func() error {
+				err := os.Remove(lockPath)
+				if err != nil {
+					return fmt.Errorf("removing lock file: %w", err)
+				}
+				return nil
+			}, nil
+		} else if !errors.Is(err, fs.ErrExist) {
+			return nil, fmt.Errorf("creating lock file: %w", err)
+		}
+
+		info, err := os.Stat(lockPath)
+		if err == nil && staleAfter > 0 && l.now().Sub(info.ModTime()) > staleAfter {
+			logger.Warnf("removing stale lock file %s", lockPath)
+			_ = os.Remove(lockPath)
+			continue
+		}
+
+		if attempt == lockAttempts {
+			return nil, ErrLeaseLocked
+		}
+		time.Sleep(lockRetryDelay)
+	}
+}
+
+// read returns the lease record of the lease file, empty if the lease file does not exist.
+func (l *FileLease) read() (record leaseRecord, err error) {
+	data, err := os.ReadFile(l.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return record, nil
+	} else if err != nil {
+		return record, fmt.Errorf("reading lease file: %w", err)
+	}
+
+	err = json.Unmarshal(data, &record)
+	if err != nil {
+		return record, fmt.Errorf("decoding lease file: %w", err)
+	}
+	return record, nil
+}
+
+// write replaces the lease file with the lease record given, writing it to a temporary
+// file renamed to the lease file.
+func (l *FileLease) write(record leaseRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("encoding lease record: %w", err)
+	}
+
+	tempFile, err := os.CreateTemp(filepath.Dir(l.path), filepath.Base(l.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating temporary lease file: %w", err)
+	}
+	tempPath := tempFile.Name()
+
+	_, err = tempFile.Write(data)
+	if err == nil {
+		err = tempFile.Sync()
+	}
+	closeErr := tempFile.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("writing temporary lease file: %w", err)
+	}
+
+	err = os.Rename(tempPath, l.path)
+	if err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("renaming temporary lease file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package failover
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileLease_Acquire(t *testing.T) {
+	t.Parallel()
+
+	const ttl = 10 * time.Second
+	path := filepath.Join(t.TempDir(), "lease")
+	now := time.Unix(1_700_000_000, 0)
+	lease := NewFileLease(path)
+	lease.now = func() time.Time { return now }
+
+	acquired, err := lease.Acquire("primary", ttl)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	// the lease held by another holder cannot be acquired until it expires
+	now = now.Add(ttl - time.Second)
+	acquired, err = lease.Acquire("backup", ttl)
+	require.NoError(t, err)
+	assert.False(t, acquired)
+
+	// the holder renews its lease
+	acquired, err = lease.Acquire("primary", ttl)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	now = now.Add(ttl)
+	acquired, err = lease.Acquire("backup", ttl)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	record, err := lease.read()
+	require.NoError(t, err)
+	assert.Equal(t, "backup", record.Holder)
+	assert.True(t, now.Add(ttl).Equal(record.Expiry))
+
+	_, err = os.Stat(path + ".lock")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestFileLease_Release(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "lease")
+	lease := NewFileLease(path)
+
+	acquired, err := lease.Acquire("primary", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	// the lease is not released by another holder
+	err = lease.Release("backup")
+	require.NoError(t, err)
+	acquired, err = lease.Acquire("backup", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, acquired)
+
+	err = lease.Release("primary")
+	require.NoError(t, err)
+	acquired, err = lease.Acquire("backup", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+}
+
+func TestFileLease_lock(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "lease")
+	lease := NewFileLease(path)
+
+	unlock, err := lease.lock(time.Minute)
+	require.NoError(t, err)
+
+	_, err = lease.lock(time.Minute)
+	assert.ErrorIs(t, err, ErrLeaseLocked)
+
+	// the lock file left by a stopped node is removed once stale
+	lease.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	staleUnlock, err := lease.lock(time.Minute)
+	require.NoError(t, err)
+	err = staleUnlock()
+	require.NoError(t, err)
+
+	err = unlock()
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
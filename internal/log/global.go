@@ -16,6 +16,12 @@ func Patch(options ...Option) {
 	globalLogger.Patch(options...)
 }
 
+// PatchContext patches the global child loggers having
+// the value given for the context key given.
+func PatchContext(key, value string, options ...Option) {
+	globalLogger.PatchContext(key, value, options...)
+}
+
 // Errorf using the global logger, only used in test
 // main runners initialisation error.
 func Errorf(s string, args ...interface{}) {
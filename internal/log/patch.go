@@ -22,3 +22,23 @@ func (l *Logger) patchWithoutLocking(options ...Option) {
 	updatedSettings.mergeWith(newSettings(options))
 	l.settings = updatedSettings
 }
+
+// PatchContext patches the settings of the logger and of its child
+// loggers, recursively, having the value given for the context key given.
+// This is thread safe.
+func (l *Logger) PatchContext(key, value string, options ...Option) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.patchContextWithoutLocking(key, value, options...)
+}
+
+func (l *Logger) patchContextWithoutLocking(key, value string, options ...Option) {
+	if l.settings.hasContext(key, value) {
+		l.patchWithoutLocking(options...)
+	}
+
+	for _, child := range l.childs {
+		child.patchContextWithoutLocking(key, value, options...)
+	}
+}
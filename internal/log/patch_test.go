@@ -175,3 +175,19 @@ func Test_Logger_patchWithoutLocking(t *testing.T) {
 		})
 	}
 }
+
+func Test_Logger_PatchContext(t *testing.T) {
+	t.Parallel()
+
+	parent := New(SetWriter(io.Discard), SetLevel(Info))
+	core := parent.New(AddContext("pkg", "core"))
+	coreChild := core.New(AddContext("module", "child"))
+	network := parent.New(AddContext("pkg", "network"))
+
+	parent.PatchContext("pkg", "core", SetLevel(Debug))
+
+	assert.Equal(t, levelPtr(Info), parent.settings.level)
+	assert.Equal(t, levelPtr(Debug), core.settings.level)
+	assert.Equal(t, levelPtr(Debug), coreChild.settings.level)
+	assert.Equal(t, levelPtr(Info), network.settings.level)
+}
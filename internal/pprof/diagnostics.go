@@ -0,0 +1,92 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package pprof
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const diagnosticsPath = "/debug/gossamer/"
+
+// Diagnostic returns a snapshot of the state of a part of the node,
+// such as the database statistics, which is served encoded as JSON.
+type Diagnostic func() (any, error)
+
+// Diagnostics is the registry of the diagnostics served at /debug/gossamer/<name>.
+// Its methods are safe for concurrent use.
+type Diagnostics struct {
+	mutex       sync.RWMutex
+	diagnostics map[string]Diagnostic
+}
+
+// NewDiagnostics creates an empty diagnostics registry.
+func NewDiagnostics() *Diagnostics {
+	return &Diagnostics{
+		diagnostics: make(map[string]Diagnostic),
+	}
+}
+
+// Set sets the diagnostic served at /debug/gossamer/<name>, replacing
+// the diagnostic previously set with this name.
+func (d *Diagnostics) Set(name string, diagnostic Diagnostic) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.diagnostics[name] = diagnostic
+}
+
+// names returns the sorted names of the diagnostics.
+func (d *Diagnostics) names() (names []string) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	names = make([]string, 0, len(d.diagnostics))
+	for name := range d.diagnostics {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func (d *Diagnostics) get(name string) (diagnostic Diagnostic, ok bool) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	diagnostic, ok = d.diagnostics[name]
+	return diagnostic, ok
+}
+
+// ServeHTTP serves the diagnostic named by the last element of the path as JSON,
+// or the names of the diagnostics for the /debug/gossamer/ path.
+func (d *Diagnostics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, diagnosticsPath)
+	if name == "" {
+		writeJSON(w, d.names())
+		return
+	}
+
+	diagnostic, ok := d.get(name)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	snapshot, err := diagnostic()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, snapshot)
+}
+
+func writeJSON(w http.ResponseWriter, value any) {
+	body, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,69 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package pprof
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Diagnostics_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	diagnostics := NewDiagnostics()
+	diagnostics.Set("database", func() (any, error) {
+		return struct {
+			Size int `json:"size"`
+		}{Size: 10}, nil
+	})
+	diagnostics.Set("network", func() (any, error) {
+		return nil, errors.New("test error")
+	})
+
+	testCases := map[string]struct {
+		path   string
+		status int
+		body   string
+	}{
+		"names": {
+			path:   "/debug/gossamer/",
+			status: http.StatusOK,
+			body:   "[\n  \"database\",\n  \"network\"\n]",
+		},
+		"diagnostic": {
+			path:   "/debug/gossamer/database",
+			status: http.StatusOK,
+			body:   "{\n  \"size\": 10\n}",
+		},
+		"diagnostic_error": {
+			path:   "/debug/gossamer/network",
+			status: http.StatusInternalServerError,
+			body:   "test error\n",
+		},
+		"unknown_diagnostic": {
+			path:   "/debug/gossamer/unknown",
+			status: http.StatusNotFound,
+			body:   "404 page not found\n",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			request := httptest.NewRequest(http.MethodGet, testCase.path, http.NoBody)
+			recorder := httptest.NewRecorder()
+
+			diagnostics.ServeHTTP(recorder, request)
+
+			assert.Equal(t, testCase.status, recorder.Code)
+			assert.Equal(t, testCase.body, recorder.Body.String())
+		})
+	}
+}
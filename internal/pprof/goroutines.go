@@ -0,0 +1,139 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package pprof
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"runtime/pprof"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const labelsPrefix = "# labels: "
+
+// goroutineRecord is a record of the goroutine profile in its text format,
+// grouping the goroutines sharing the same stack and labels.
+type goroutineRecord struct {
+	count int
+	// labels is the labels set formatted as {"key":"value", ...}, or empty
+	// if the goroutines have no label.
+	labels string
+	text   string
+}
+
+// goroutinesHandler serves the goroutines grouped by the labels of the services which
+// started them. Without query parameters, it writes the number of goroutines for each
+// labels set. Otherwise, it writes the goroutine profile in its text format, keeping
+// only the goroutines having all the labels given as query parameters, for example
+// ?service=sync.Service
+func goroutinesHandler(w http.ResponseWriter, r *http.Request) {
+	records, err := goroutineRecords()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+
+	query := r.URL.Query()
+	if len(query) == 0 {
+		writeGoroutineCounts(w, records)
+		return
+	}
+
+	var filtered []goroutineRecord
+	total := 0
+	for _, record := range records {
+		if !hasLabels(record.labels, query) {
+			continue
+		}
+		filtered = append(filtered, record)
+		total += record.count
+	}
+
+	_, _ = fmt.Fprintf(w, "goroutine profile: total %d\n", total)
+	for _, record := range filtered {
+		_, _ = fmt.Fprintf(w, "%s\n\n", record.text)
+	}
+}
+
+// goroutineRecords returns the records of the goroutine profile.
+func goroutineRecords() (records []goroutineRecord, err error) {
+	var profile bytes.Buffer
+	err = pprof.Lookup("goroutine").WriteTo(&profile, 1)
+	if err != nil {
+		return nil, fmt.Errorf("writing goroutine profile: %w", err)
+	}
+
+	// the first line is the goroutines total
+	_, body, _ := strings.Cut(profile.String(), "\n")
+	for _, text := range strings.Split(body, "\n\n") {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+
+		countField, _, _ := strings.Cut(text, " ")
+		count, err := strconv.Atoi(countField)
+		if err != nil {
+			return nil, fmt.Errorf("parsing goroutine count of record: %w", err)
+		}
+
+		record := goroutineRecord{count: count, text: text}
+		for _, line := range strings.Split(text, "\n") {
+			if labels, ok := strings.CutPrefix(line, labelsPrefix); ok {
+				record.labels = labels
+				break
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// hasLabels returns true if the labels set contains all the labels given.
+func hasLabels(labels string, query map[string][]string) bool {
+	for key, values := range query {
+		for _, value := range values {
+			if !strings.Contains(labels, fmt.Sprintf("%q:%q", key, value)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// writeGoroutineCounts writes the number of goroutines of each labels set, by
+// decreasing number of goroutines.
+func writeGoroutineCounts(w http.ResponseWriter, records []goroutineRecord) {
+	counts := make(map[string]int)
+	total := 0
+	for _, record := range records {
+		counts[record.labels] += record.count
+		total += record.count
+	}
+
+	labelsSets := make([]string, 0, len(counts))
+	for labels := range counts {
+		labelsSets = append(labelsSets, labels)
+	}
+	slices.SortFunc(labelsSets, func(a, b string) int {
+		if counts[a] != counts[b] {
+			return counts[b] - counts[a]
+		}
+		return strings.Compare(a, b)
+	})
+
+	_, _ = fmt.Fprintf(w, "goroutine profile: total %d\n", total)
+	for _, labels := range labelsSets {
+		name := labels
+		if name == "" {
+			name = "(no labels)"
+		}
+		_, _ = fmt.Fprintf(w, "%d %s\n", counts[labels], name)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package pprof
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"runtime/pprof"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_goroutinesHandler(t *testing.T) {
+	stop := make(chan struct{})
+	defer close(stop)
+	pprof.Do(context.Background(), pprof.Labels("service", "test.Service"), func(context.Context) {
+		go func() { <-stop }()
+		go func() { <-stop }()
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/debug/gossamer/goroutines", http.NoBody)
+	recorder := httptest.NewRecorder()
+	goroutinesHandler(recorder, request)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "\n2 {\"service\":\"test.Service\"}\n")
+
+	request = httptest.NewRequest(http.MethodGet, "/debug/gossamer/goroutines?service=test.Service", http.NoBody)
+	recorder = httptest.NewRecorder()
+	goroutinesHandler(recorder, request)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	body := recorder.Body.String()
+	assert.Contains(t, body, "goroutine profile: total 2\n")
+	assert.Contains(t, body, "Test_goroutinesHandler")
+	assert.NotContains(t, body, "goroutinesHandler+")
+}
+
+func Test_hasLabels(t *testing.T) {
+	t.Parallel()
+
+	const labels = `{"service":"sync.Service", "worker":"1"}`
+
+	assert.True(t, hasLabels(labels, map[string][]string{"service": {"sync.Service"}}))
+	assert.True(t, hasLabels(labels, map[string][]string{"service": {"sync.Service"}, "worker": {"1"}}))
+	assert.False(t, hasLabels(labels, map[string][]string{"service": {"sync"}}))
+	assert.False(t, hasLabels("", map[string][]string{"service": {"sync.Service"}}))
+}
@@ -11,8 +11,10 @@
 )
 
 // NewServer creates a new Pprof server which will listen at
-// the address specified.
-func NewServer(address string, logger Logger,
+// the address specified. Next to the pprof handlers, it serves the
+// goroutines grouped by service at /debug/gossamer/goroutines and
+// the diagnostics given at /debug/gossamer/<name>.
+func NewServer(address string, logger Logger, diagnostics *Diagnostics,
 	options ...httpserver.Option) *httpserver.Server {
 	handler := http.NewServeMux()
 	handler.HandleFunc("/debug/pprof/", pprof.Index)
@@ -24,5 +26,7 @@ func NewServer(address string, logger Logger,
 	handler.Handle("/debug/pprof/goroutine", pprof.Handler("goroutine"))
 	handler.Handle("/debug/pprof/heap", pprof.Handler("heap"))
 	handler.Handle("/debug/pprof/threadcreate", pprof.Handler("threadcreate"))
+	handler.HandleFunc(diagnosticsPath+"goroutines", goroutinesHandler)
+	handler.Handle(diagnosticsPath, diagnostics)
 	return httpserver.New("pprof", address, handler, logger, options...)
 }
@@ -27,7 +27,9 @@ func Test_Server(t *testing.T) {
 	logger.EXPECT().Warn("pprof http server shutting down: context canceled")
 
 	const httpServerShutdownTimeout = 10 * time.Second // 10s in case test worker is slow
-	server := NewServer(address, logger,
+	diagnostics := NewDiagnostics()
+	diagnostics.Set("test", func() (any, error) { return map[string]int{"value": 1}, nil })
+	server := NewServer(address, logger, diagnostics,
 		httpserver.ShutdownTimeout(httpServerShutdownTimeout))
 	require.NotNil(t, server)
 
@@ -58,6 +60,9 @@ func Test_Server(t *testing.T) {
 		"debug/pprof/goroutine",
 		"debug/pprof/heap",
 		"debug/pprof/threadcreate",
+		"debug/gossamer/",
+		"debug/gossamer/goroutines",
+		"debug/gossamer/test",
 	}
 
 	type httpResult struct {
@@ -14,10 +14,11 @@
 // Service is a pprof http server service compatible with the
 // dot/service.go interface.
 type Service struct {
-	settings Settings
-	server   Runner
-	cancel   context.CancelFunc
-	done     chan error
+	settings    Settings
+	diagnostics *Diagnostics
+	server      Runner
+	cancel      context.CancelFunc
+	done        chan error
 }
 
 // NewService creates a pprof server service compatible with the
@@ -29,13 +30,21 @@ func NewService(config cfg.PprofConfig, logger Logger) *Service {
 		MutexProfileRate: config.MutexProfileRate,
 	}
 
+	diagnostics := NewDiagnostics()
 	return &Service{
-		settings: settings,
-		server:   NewServer(settings.ListeningAddress, logger),
-		done:     make(chan error),
+		settings:    settings,
+		diagnostics: diagnostics,
+		server:      NewServer(settings.ListeningAddress, logger, diagnostics),
+		done:        make(chan error),
 	}
 }
 
+// SetDiagnostic sets the diagnostic served at /debug/gossamer/<name>.
+// It can be called before or after the service is started.
+func (s *Service) SetDiagnostic(name string, diagnostic Diagnostic) {
+	s.diagnostics.Set(name, diagnostic)
+}
+
 var ErrServerDoneBeforeReady = errors.New("server terminated before being ready")
 
 // Start starts the pprof server service.
@@ -27,6 +27,7 @@ func Test_NewService(t *testing.T) {
 		ListeningAddress: pprofConfig.ListeningAddress,
 	}
 	assert.Equal(t, expectedSettings, service.settings)
+	assert.NotNil(t, service.diagnostics)
 	assert.NotNil(t, service.server)
 	assert.NotNil(t, service.done)
 }
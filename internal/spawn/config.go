@@ -0,0 +1,216 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package spawn
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+var (
+	errNoNodes         = errors.New("network has no nodes")
+	errNoChain         = errors.New("network has no chain")
+	errNoName          = errors.New("node has no name")
+	errDuplicateName   = errors.New("duplicate node name")
+	errNoValidatorKey  = errors.New("validator node has no key")
+	errUnknownKey      = errors.New("unknown built-in key")
+	errDuplicatePort   = errors.New("port used by several nodes")
+	errParachains      = errors.New("parachains are not supported")
+	errUnknownProvider = errors.New("unsupported zombienet provider")
+)
+
+// testKeys are the names of the built-in keys a node can be started with
+var testKeys = map[string]struct{}{
+	"alice": {}, "bob": {}, "charlie": {}, "dave": {}, "eve": {},
+	"ferdie": {}, "george": {}, "heather": {}, "ian": {},
+}
+
+// NodeConfig is the description of a node of the network
+type NodeConfig struct {
+	// Name is the name of the node, used as its key if it is the name of a built-in key
+	Name string `mapstructure:"name"`
+	// Key is the name of the built-in key of the node, required for a validator
+	Key string `mapstructure:"key"`
+	// Validator is true if the node authors blocks with BABE and votes with GRANDPA
+	Validator bool `mapstructure:"validator"`
+	// Command is the gossamer binary running the node, defaults to the one of the network
+	Command string `mapstructure:"command"`
+	// Args are the flags added to the ones of the network for this node
+	Args []string `mapstructure:"args"`
+	// Port is the p2p port of the node, assigned automatically if 0
+	Port uint16 `mapstructure:"port"`
+	// RPCPort is the HTTP RPC port of the node, assigned automatically if 0
+	RPCPort uint32 `mapstructure:"rpc-port"`
+	// WSPort is the websocket RPC port of the node, assigned automatically if 0
+	WSPort uint32 `mapstructure:"ws-port"`
+	// PrometheusPort is the prometheus port of the node, assigned automatically if 0
+	PrometheusPort uint32 `mapstructure:"prometheus-port"`
+}
+
+// Config is the description of a local network
+type Config struct {
+	// Chain is the name of a built-in chain or the path of the chain spec of the network
+	Chain string `mapstructure:"chain"`
+	// Command is the gossamer binary running the nodes
+	Command string `mapstructure:"command"`
+	// Args are the flags added to the command of every node
+	Args []string `mapstructure:"args"`
+	// Nodes are the nodes of the network
+	Nodes []NodeConfig `mapstructure:"nodes"`
+}
+
+// zombienetNode is the node description of a zombienet network definition
+type zombienetNode struct {
+	Name           string   `mapstructure:"name"`
+	Command        string   `mapstructure:"command"`
+	Validator      *bool    `mapstructure:"validator"`
+	Args           []string `mapstructure:"args"`
+	P2PPort        uint16   `mapstructure:"p2p_port"`
+	RPCPort        uint32   `mapstructure:"rpc_port"`
+	WSPort         uint32   `mapstructure:"ws_port"`
+	PrometheusPort uint32   `mapstructure:"prometheus_port"`
+}
+
+// zombienetNetwork is the zombienet network definition, only its relay chain being used
+type zombienetNetwork struct {
+	Settings struct {
+		Provider string `mapstructure:"provider"`
+	} `mapstructure:"settings"`
+	Relaychain struct {
+		Chain          string          `mapstructure:"chain"`
+		ChainSpecPath  string          `mapstructure:"chain_spec_path"`
+		DefaultCommand string          `mapstructure:"default_command"`
+		DefaultArgs    []string        `mapstructure:"default_args"`
+		Nodes          []zombienetNode `mapstructure:"nodes"`
+	} `mapstructure:"relaychain"`
+	Parachains []interface{} `mapstructure:"parachains"`
+}
+
+// ParseConfig reads the network description of the TOML file at the path given. The file is
+// either a gossamer network description, or a zombienet network definition whose relay chain
+// nodes are run by gossamer, detected by its relaychain table.
+func ParseConfig(path string) (*Config, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("toml")
+	err := v.ReadInConfig()
+	if err != nil {
+		return nil, fmt.Errorf("reading network description: %w", err)
+	}
+
+	config := &Config{}
+	if v.IsSet("relaychain") {
+		var network zombienetNetwork
+		err = v.Unmarshal(&network)
+		if err != nil {
+			return nil, fmt.Errorf("decoding zombienet network definition: %w", err)
+		}
+		config, err = network.toConfig()
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		err = v.Unmarshal(config)
+		if err != nil {
+			return nil, fmt.Errorf("decoding network description: %w", err)
+		}
+	}
+
+	err = config.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// toConfig converts the zombienet network definition to a network description. The
+// arguments are split on whitespaces since zombienet accepts flags and values in one string.
+func (z zombienetNetwork) toConfig() (*Config, error) {
+	if len(z.Parachains) > 0 {
+		return nil, errParachains
+	}
+	switch z.Settings.Provider {
+	case "", "native":
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownProvider, z.Settings.Provider)
+	}
+
+	config := &Config{
+		Chain:   z.Relaychain.ChainSpecPath,
+		Command: z.Relaychain.DefaultCommand,
+		Args:    splitArgs(z.Relaychain.DefaultArgs),
+	}
+	if config.Chain == "" {
+		config.Chain = z.Relaychain.Chain
+	}
+
+	for _, node := range z.Relaychain.Nodes {
+		// zombienet nodes are validators unless stated otherwise
+		validator := node.Validator == nil || *node.Validator
+		config.Nodes = append(config.Nodes, NodeConfig{
+			Name:           node.Name,
+			Validator:      validator,
+			Command:        node.Command,
+			Args:           splitArgs(node.Args),
+			Port:           node.P2PPort,
+			RPCPort:        node.RPCPort,
+			WSPort:         node.WSPort,
+			PrometheusPort: node.PrometheusPort,
+		})
+	}
+	return config, nil
+}
+
+func splitArgs(args []string) (split []string) {
+	for _, arg := range args {
+		split = append(split, strings.Fields(arg)...)
+	}
+	return split
+}
+
+// Validate checks the network description is valid
+func (c *Config) Validate() error {
+	if c.Chain == "" {
+		return errNoChain
+	}
+	if len(c.Nodes) == 0 {
+		return errNoNodes
+	}
+
+	names := make(map[string]struct{}, len(c.Nodes))
+	for _, node := range c.Nodes {
+		if node.Name == "" {
+			return errNoName
+		}
+		if _, ok := names[node.Name]; ok {
+			return fmt.Errorf("%w: %s", errDuplicateName, node.Name)
+		}
+		names[node.Name] = struct{}{}
+
+		key := node.key()
+		if key == "" && node.Validator {
+			return fmt.Errorf("%w: %s", errNoValidatorKey, node.Name)
+		}
+		if _, ok := testKeys[key]; key != "" && !ok {
+			return fmt.Errorf("%w: %s for node %s", errUnknownKey, key, node.Name)
+		}
+	}
+	return nil
+}
+
+// key returns the name of the built-in key of the node, which is its name if it is the name
+// of a built-in key and no key is set.
+func (n NodeConfig) key() string {
+	if n.Key != "" {
+		return strings.ToLower(n.Key)
+	}
+	name := strings.ToLower(n.Name)
+	if _, ok := testKeys[name]; ok {
+		return name
+	}
+	return ""
+}
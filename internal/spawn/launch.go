@@ -0,0 +1,199 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package spawn
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/ChainSafe/gossamer/internal/log"
+)
+
+var logger = log.NewFromGlobal(log.AddContext("pkg", "spawn"))
+
+// NetworkFile is the name of the file written in the base path of the network once its nodes
+// are ready, listing the nodes with their endpoints.
+const NetworkFile = "network.json"
+
+const (
+	// readinessPollInterval is the interval between two checks of the RPC port of a node
+	readinessPollInterval = 250 * time.Millisecond
+	// stopTimeout is the time given to a node to stop once interrupted, before it is killed
+	stopTimeout = 30 * time.Second
+)
+
+var errNodeExited = errors.New("node exited")
+
+// Network is a network of gossamer nodes running as child processes
+type Network struct {
+	Nodes    []*Node `json:"nodes"`
+	basePath string
+	procs    []*process
+}
+
+type process struct {
+	node *Node
+	cmd  *exec.Cmd
+	done chan struct{}
+	err  error
+}
+
+// Launch starts the nodes given as child processes, their output being written to their
+// log file, and waits for their RPC port to accept connections for at most the ready
+// timeout given. It then writes the network file in the base path. The nodes already started
+// are stopped if a node fails to start or to be ready.
+func Launch(ctx context.Context, nodes []*Node, basePath string, readyTimeout time.Duration) (
+	_ *Network, err error) {
+	nw := &Network{Nodes: nodes, basePath: basePath}
+	defer func() {
+		if err != nil {
+			nw.Stop()
+		}
+	}()
+
+	for _, node := range nodes {
+		var proc *process
+		proc, err = startProcess(node)
+		if err != nil {
+			return nil, fmt.Errorf("starting node %s: %w", node.Name, err)
+		}
+		nw.procs = append(nw.procs, proc)
+		logger.Infof("started node %s with pid %d, logging to %s", node.Name, proc.cmd.Process.Pid, node.LogFile)
+	}
+
+	readyCtx, cancel := context.WithTimeout(ctx, readyTimeout)
+	defer cancel()
+	for _, proc := range nw.procs {
+		err = proc.waitReady(readyCtx)
+		if err != nil {
+			return nil, fmt.Errorf("waiting for node %s: %w", proc.node.Name, err)
+		}
+		logger.Infof("node %s ready, peer id %s, rpc %s, ws %s",
+			proc.node.Name, proc.node.PeerID, proc.node.RPCURI, proc.node.WSURI)
+	}
+
+	data, err := json.MarshalIndent(nw, "", "\t")
+	if err != nil {
+		return nil, fmt.Errorf("encoding network file: %w", err)
+	}
+	err = os.WriteFile(filepath.Join(basePath, NetworkFile), data, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("writing network file: %w", err)
+	}
+
+	return nw, nil
+}
+
+func startProcess(node *Node) (*process, error) {
+	err := os.MkdirAll(node.BasePath, 0o700)
+	if err != nil {
+		return nil, fmt.Errorf("creating base path: %w", err)
+	}
+
+	logFile, err := os.Create(node.LogFile)
+	if err != nil {
+		return nil, fmt.Errorf("creating log file: %w", err)
+	}
+
+	cmd := exec.Command(node.Command, node.Args...) //nolint:gosec
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	err = cmd.Start()
+	if err != nil {
+		_ = logFile.Close()
+		return nil, err
+	}
+
+	proc := &process{node: node, cmd: cmd, done: make(chan struct{})}
+	go func() {
+		defer close(proc.done)
+		proc.err = cmd.Wait()
+		_ = logFile.Close()
+	}()
+	return proc, nil
+}
+
+// waitReady waits for the RPC port of the node to accept connections
+func (p *process) waitReady(ctx context.Context) error {
+	address := fmt.Sprintf("127.0.0.1:%d", p.node.RPCPort)
+	ticker := time.NewTicker(readinessPollInterval)
+	defer ticker.Stop()
+
+	for {
+		conn, err := net.DialTimeout("tcp", address, readinessPollInterval)
+		if err == nil {
+			return conn.Close()
+		}
+
+		select {
+		case <-p.done:
+			return fmt.Errorf("%w: %v, see %s", errNodeExited, p.err, p.node.LogFile)
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Wait waits for the context to be done or for a node to exit, and returns an error if a
+// node exited. The nodes are not stopped.
+func (nw *Network) Wait(ctx context.Context) error {
+	exited := make(chan *process, len(nw.procs))
+	for _, proc := range nw.procs {
+		go func(proc *process) {
+			select {
+			case <-proc.done:
+				exited <- proc
+			case <-ctx.Done():
+			}
+		}(proc)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case proc := <-exited:
+		return fmt.Errorf("%w: %s: %v, see %s", errNodeExited, proc.node.Name, proc.err, proc.node.LogFile)
+	}
+}
+
+// Stop interrupts every node and waits for it to stop, killing the nodes not stopped in time.
+// The network file is removed.
+func (nw *Network) Stop() {
+	for _, proc := range nw.procs {
+		select {
+		case <-proc.done:
+			continue
+		default:
+		}
+		err := proc.cmd.Process.Signal(os.Interrupt)
+		if err != nil {
+			logger.Warnf("interrupting node %s: %s", proc.node.Name, err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), stopTimeout)
+	defer cancel()
+	for _, proc := range nw.procs {
+		select {
+		case <-proc.done:
+		case <-ctx.Done():
+			logger.Warnf("killing node %s not stopped after %s", proc.node.Name, stopTimeout)
+			_ = proc.cmd.Process.Kill()
+			<-proc.done
+		}
+	}
+
+	err := os.Remove(filepath.Join(nw.basePath, NetworkFile))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		logger.Warnf("removing network file: %s", err)
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package spawn
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+// The ports of the nodes not given in the network description are assigned from these ones,
+// following the ports of the westend local nodes.
+const (
+	firstPort           = uint16(7001)
+	firstRPCPort        = uint32(8545)
+	firstWSPort         = uint32(8546)
+	firstPrometheusPort = uint32(9856)
+	portStep            = 10
+)
+
+// Node is a node of the network ready to be launched
+type Node struct {
+	Name           string   `json:"name"`
+	Validator      bool     `json:"validator"`
+	PeerID         string   `json:"peerId"`
+	MultiAddress   string   `json:"multiAddress"`
+	BasePath       string   `json:"basePath"`
+	LogFile        string   `json:"logFile"`
+	Port           uint16   `json:"port"`
+	RPCPort        uint32   `json:"rpcPort"`
+	WSPort         uint32   `json:"wsPort"`
+	PrometheusPort uint32   `json:"prometheusPort"`
+	RPCURI         string   `json:"rpcUri"`
+	WSURI          string   `json:"wsUri"`
+	PrometheusURI  string   `json:"prometheusUri"`
+	Command        string   `json:"command"`
+	Args           []string `json:"args"`
+}
+
+// Plan returns the nodes of the network described, each of them in its own directory of the
+// base path given. The node keys are derived from the node names so the peer IDs are known
+// before starting the nodes, and every node uses the other nodes as bootnodes.
+func Plan(config *Config, basePath, defaultCommand string) ([]*Node, error) {
+	nodes := make([]*Node, len(config.Nodes))
+	nodeKeys := make([]string, len(config.Nodes))
+	usedPorts := make(map[uint32]string)
+	usePort := func(node string, port uint32) error {
+		if other, ok := usedPorts[port]; ok {
+			return fmt.Errorf("%w: %d by %s and %s", errDuplicatePort, port, other, node)
+		}
+		usedPorts[port] = node
+		return nil
+	}
+
+	for i, nodeConfig := range config.Nodes {
+		seed, err := common.Blake2bHash([]byte(nodeConfig.Name))
+		if err != nil {
+			return nil, fmt.Errorf("deriving node key of %s: %w", nodeConfig.Name, err)
+		}
+		_, peerID, err := network.NodeKeyFromSeed(seed[:])
+		if err != nil {
+			return nil, fmt.Errorf("deriving peer ID of %s: %w", nodeConfig.Name, err)
+		}
+		nodeKeys[i] = common.BytesToHex(seed[:])[2:]
+
+		node := &Node{
+			Name:           nodeConfig.Name,
+			Validator:      nodeConfig.Validator,
+			PeerID:         peerID.String(),
+			BasePath:       filepath.Join(basePath, nodeConfig.Name),
+			Port:           nodeConfig.Port,
+			RPCPort:        nodeConfig.RPCPort,
+			WSPort:         nodeConfig.WSPort,
+			PrometheusPort: nodeConfig.PrometheusPort,
+			Command:        nodeConfig.Command,
+		}
+		node.LogFile = filepath.Join(node.BasePath, "gossamer.log")
+
+		step := uint32(i * portStep) //nolint:gosec
+		if node.Port == 0 {
+			node.Port = firstPort + uint16(step) //nolint:gosec
+		}
+		if node.RPCPort == 0 {
+			node.RPCPort = firstRPCPort + step
+		}
+		if node.WSPort == 0 {
+			node.WSPort = firstWSPort + step
+		}
+		if node.PrometheusPort == 0 {
+			node.PrometheusPort = firstPrometheusPort + step
+		}
+		for _, port := range []uint32{uint32(node.Port), node.RPCPort, node.WSPort, node.PrometheusPort} {
+			err = usePort(node.Name, port)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		node.MultiAddress = fmt.Sprintf("/ip4/127.0.0.1/tcp/%d/p2p/%s", node.Port, node.PeerID)
+		node.RPCURI = fmt.Sprintf("http://127.0.0.1:%d", node.RPCPort)
+		node.WSURI = fmt.Sprintf("ws://127.0.0.1:%d", node.WSPort)
+		node.PrometheusURI = fmt.Sprintf("http://127.0.0.1:%d/metrics", node.PrometheusPort)
+
+		if node.Command == "" {
+			node.Command = config.Command
+		}
+		if node.Command == "" {
+			node.Command = defaultCommand
+		}
+		nodes[i] = node
+	}
+
+	for i, node := range nodes {
+		var bootnodes []string
+		for j, other := range nodes {
+			if j != i {
+				bootnodes = append(bootnodes, other.MultiAddress)
+			}
+		}
+
+		node.Args = []string{
+			"--chain", config.Chain,
+			"--base-path", node.BasePath,
+			"--name", node.Name,
+			"--node-key", nodeKeys[i],
+			"--port", strconv.Itoa(int(node.Port)),
+			"--rpc-port", strconv.FormatUint(uint64(node.RPCPort), 10),
+			"--ws-port", strconv.FormatUint(uint64(node.WSPort), 10),
+			"--prometheus-port", strconv.FormatUint(uint64(node.PrometheusPort), 10),
+			"--public-ip", "127.0.0.1",
+			"--no-mdns",
+			"--no-telemetry",
+		}
+		if len(bootnodes) > 0 {
+			node.Args = append(node.Args, "--bootnodes", strings.Join(bootnodes, ","))
+		}
+		if key := config.Nodes[i].key(); key != "" {
+			node.Args = append(node.Args, "--key", key)
+		}
+		if node.Validator {
+			node.Args = append(node.Args, "--validator")
+		}
+		node.Args = append(node.Args, config.Args...)
+		node.Args = append(node.Args, config.Nodes[i].Args...)
+	}
+
+	return nodes, nil
+}
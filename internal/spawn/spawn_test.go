@@ -0,0 +1,219 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package spawn
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeNetwork(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "network.toml")
+	err := os.WriteFile(path, []byte(content), 0o600)
+	require.NoError(t, err)
+	return path
+}
+
+func TestParseConfig(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		content  string
+		expected *Config
+		errWrap  error
+	}{
+		"gossamer_network": {
+			content: `
+chain = "westend-local"
+args = ["--log", "info"]
+
+[[nodes]]
+name = "alice"
+validator = true
+port = 7500
+
+[[nodes]]
+name = "full"
+args = ["--state-pruning", "archive"]
+`,
+			expected: &Config{
+				Chain: "westend-local",
+				Args:  []string{"--log", "info"},
+				Nodes: []NodeConfig{
+					{Name: "alice", Validator: true, Port: 7500},
+					{Name: "full", Args: []string{"--state-pruning", "archive"}},
+				},
+			},
+		},
+		"zombienet_network": {
+			content: `
+[settings]
+provider = "native"
+
+[relaychain]
+chain_spec_path = "chain/westend-local/westend-local-spec-raw.json"
+chain = "westend-local"
+default_command = "gossamer"
+
+[[relaychain.nodes]]
+name = "alice"
+args = ["--key alice", "--min-peers 0"]
+rpc_port = 9000
+
+[[relaychain.nodes]]
+name = "bob"
+validator = false
+`,
+			expected: &Config{
+				Chain:   "chain/westend-local/westend-local-spec-raw.json",
+				Command: "gossamer",
+				Nodes: []NodeConfig{
+					{Name: "alice", Validator: true, Args: []string{"--key", "alice", "--min-peers", "0"}, RPCPort: 9000},
+					{Name: "bob"},
+				},
+			},
+		},
+		"zombienet_parachains": {
+			content: `
+[relaychain]
+chain = "westend-local"
+
+[[relaychain.nodes]]
+name = "alice"
+
+[[parachains]]
+id = 100
+`,
+			errWrap: errParachains,
+		},
+		"zombienet_kubernetes_provider": {
+			content: `
+[settings]
+provider = "kubernetes"
+
+[relaychain]
+chain = "westend-local"
+
+[[relaychain.nodes]]
+name = "alice"
+`,
+			errWrap: errUnknownProvider,
+		},
+		"no_chain": {
+			content: `
+[[nodes]]
+name = "alice"
+`,
+			errWrap: errNoChain,
+		},
+		"no_nodes": {
+			content: `chain = "westend-local"`,
+			errWrap: errNoNodes,
+		},
+		"duplicate_name": {
+			content: `
+chain = "westend-local"
+[[nodes]]
+name = "alice"
+[[nodes]]
+name = "alice"
+`,
+			errWrap: errDuplicateName,
+		},
+		"validator_without_key": {
+			content: `
+chain = "westend-local"
+[[nodes]]
+name = "validator"
+validator = true
+`,
+			errWrap: errNoValidatorKey,
+		},
+		"unknown_key": {
+			content: `
+chain = "westend-local"
+[[nodes]]
+name = "validator"
+key = "zoe"
+`,
+			errWrap: errUnknownKey,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			config, err := ParseConfig(writeNetwork(t, testCase.content))
+			assert.ErrorIs(t, err, testCase.errWrap)
+			assert.Equal(t, testCase.expected, config)
+		})
+	}
+}
+
+func TestPlan(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{
+		Chain: "westend-local",
+		Args:  []string{"--log", "info"},
+		Nodes: []NodeConfig{
+			{Name: "alice", Validator: true},
+			{Name: "bob", Validator: true, Port: 7100},
+			{Name: "full", Command: "/usr/bin/gossamer", Args: []string{"--min-peers", "1"}},
+		},
+	}
+
+	nodes, err := Plan(config, "/tmp/network", "gossamer")
+	require.NoError(t, err)
+	require.Len(t, nodes, 3)
+
+	alice, bob, full := nodes[0], nodes[1], nodes[2]
+	assert.Equal(t, uint16(7001), alice.Port)
+	assert.Equal(t, uint32(8545), alice.RPCPort)
+	assert.Equal(t, uint32(8546), alice.WSPort)
+	assert.Equal(t, uint32(9856), alice.PrometheusPort)
+	assert.Equal(t, uint16(7100), bob.Port)
+	assert.Equal(t, uint32(8555), bob.RPCPort)
+	assert.Equal(t, uint16(7021), full.Port)
+
+	assert.Equal(t, "gossamer", alice.Command)
+	assert.Equal(t, "/usr/bin/gossamer", full.Command)
+	assert.Equal(t, filepath.Join("/tmp/network", "bob"), bob.BasePath)
+	assert.Equal(t, "/ip4/127.0.0.1/tcp/7100/p2p/"+bob.PeerID, bob.MultiAddress)
+
+	// the peer IDs are derived from the node names
+	replanned, err := Plan(config, "/tmp/other", "gossamer")
+	require.NoError(t, err)
+	assert.Equal(t, alice.PeerID, replanned[0].PeerID)
+	assert.NotEqual(t, alice.PeerID, bob.PeerID)
+
+	assert.Subset(t, alice.Args, []string{"--key", "alice", "--validator", "--log", "info",
+		"--bootnodes", bob.MultiAddress + "," + full.MultiAddress})
+	assert.NotContains(t, full.Args, "--key")
+	assert.NotContains(t, full.Args, "--validator")
+	assert.Equal(t, []string{"--log", "info", "--min-peers", "1"}, full.Args[len(full.Args)-4:])
+}
+
+func TestPlan_duplicatePort(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{
+		Chain: "westend-local",
+		Nodes: []NodeConfig{
+			{Name: "alice"},
+			{Name: "bob", RPCPort: 8545},
+		},
+	}
+
+	_, err := Plan(config, "/tmp/network", "gossamer")
+	assert.ErrorIs(t, err, errDuplicatePort)
+}
@@ -0,0 +1,330 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package testnet runs networks of gossamer nodes in the test process, with their real
+// BABE and GRANDPA services, for the integration tests of multi-node behaviours.
+package testnet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	cfg "github.com/ChainSafe/gossamer/config"
+	"github.com/ChainSafe/gossamer/dot"
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/genesis"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+// pollInterval is the interval between two checks of the nodes state when waiting
+const pollInterval = 100 * time.Millisecond
+
+var errHashMismatch = errors.New("block hash differs between nodes")
+
+// NodeConfig is the configuration of a node of the test network
+type NodeConfig struct {
+	// Key is the keyring name of the node keys, such as alice, bob or charlie
+	Key string
+	// Authority is true if the node authors blocks with BABE and votes with GRANDPA,
+	// its key must then be an authority of the chain spec.
+	Authority bool
+}
+
+// Config is the configuration of a test network
+type Config struct {
+	// ChainSpec is the path of the raw chain spec, defaults to the westend local chain spec
+	// whose authorities are alice and bob.
+	ChainSpec string
+	// Nodes are the nodes of the network
+	Nodes []NodeConfig
+	// LogLevel is the log level of the nodes, defaults to error.
+	LogLevel log.Level
+	// Chaos are the faults injected in every node, formatted as the --chaos flag value,
+	// such as seed=1,drop=0.05. The nodes are seeded the same.
+	Chaos string
+}
+
+// Network is a network of gossamer nodes running in the test process, connected to
+// each other over the loopback interface.
+type Network struct {
+	Nodes []*Node
+}
+
+// Node is a node of the test network
+type Node struct {
+	Name   string
+	PeerID peer.ID
+	node   *dot.Node
+	done   chan struct{}
+}
+
+type nodeIdentity struct {
+	nodeKey  string
+	peerID   peer.ID
+	port     uint16
+	bootnode string
+}
+
+// New initialises and starts the nodes of a test network. The nodes are stopped at the end
+// of the test.
+func New(t *testing.T, config Config) *Network {
+	t.Helper()
+
+	if config.ChainSpec == "" {
+		config.ChainSpec = utils.GetWestendLocalRawGenesisPath(t)
+	}
+	if config.LogLevel == 0 {
+		config.LogLevel = log.Error
+	}
+
+	gen, err := genesis.NewGenesisFromJSONRaw(config.ChainSpec)
+	require.NoError(t, err)
+
+	identities := make([]nodeIdentity, len(config.Nodes))
+	for i, nodeConfig := range config.Nodes {
+		identities[i] = newNodeIdentity(t, nodeConfig.Key)
+	}
+
+	nw := &Network{}
+	for i, nodeConfig := range config.Nodes {
+		var bootnodes []string
+		for j, identity := range identities {
+			if j != i {
+				bootnodes = append(bootnodes, identity.bootnode)
+			}
+		}
+
+		nodeCfg := newNodeConfig(t, config, gen, nodeConfig, identities[i], bootnodes)
+		node := startNode(t, nodeCfg, nodeConfig.Key)
+		node.PeerID = identities[i].peerID
+		nw.Nodes = append(nw.Nodes, node)
+	}
+
+	return nw
+}
+
+// newNodeIdentity returns the p2p identity of the node, derived from its key name, and
+// reserves a loopback port for it.
+func newNodeIdentity(t *testing.T, key string) nodeIdentity {
+	t.Helper()
+
+	seed, err := common.Blake2bHash([]byte(key))
+	require.NoError(t, err)
+
+	_, peerID, err := network.NodeKeyFromSeed(seed[:])
+	require.NoError(t, err)
+
+	port := availablePort(t)
+	return nodeIdentity{
+		nodeKey:  common.BytesToHex(seed[:])[2:],
+		peerID:   peerID,
+		port:     port,
+		bootnode: fmt.Sprintf("/ip4/127.0.0.1/tcp/%d/p2p/%s", port, peerID),
+	}
+}
+
+func newNodeConfig(t *testing.T, config Config, gen *genesis.Genesis, nodeConfig NodeConfig,
+	identity nodeIdentity, bootnodes []string) *cfg.Config {
+	t.Helper()
+
+	nodeCfg := cfg.DefaultConfig()
+	nodeCfg.Name = nodeConfig.Key
+	nodeCfg.ID = gen.ID
+	nodeCfg.BasePath = filepath.Join(t.TempDir(), nodeConfig.Key)
+	nodeCfg.ChainSpec = config.ChainSpec
+	nodeCfg.NoTelemetry = true
+	nodeCfg.Chaos = config.Chaos
+
+	level := config.LogLevel.String()
+	nodeCfg.LogLevel = level
+	nodeCfg.Log = &cfg.LogConfig{
+		Core:    level,
+		Digest:  level,
+		Sync:    level,
+		Network: level,
+		RPC:     level,
+		State:   level,
+		Runtime: level,
+		Babe:    level,
+		Grandpa: level,
+		Wasmer:  level,
+	}
+
+	nodeCfg.Core.Role = common.FullNodeRole
+	nodeCfg.Core.BabeAuthority = nodeConfig.Authority
+	nodeCfg.Core.GrandpaAuthority = nodeConfig.Authority
+	if nodeConfig.Authority {
+		nodeCfg.Core.Role = common.AuthorityRole
+	}
+	// the clock guard queries an NTP server, and all the nodes share the same clock
+	nodeCfg.Core.MaxClockDrift = 0
+
+	nodeCfg.Network.Port = identity.port
+	nodeCfg.Network.ListenAddresses = []string{fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", identity.port)}
+	nodeCfg.Network.PublicIP = "127.0.0.1"
+	nodeCfg.Network.NodeKey = identity.nodeKey
+	nodeCfg.Network.ProtocolID = gen.ProtocolID
+	nodeCfg.Network.Bootnodes = bootnodes
+	nodeCfg.Network.NoMDNS = true
+	nodeCfg.Network.MinPeers = len(bootnodes)
+
+	return nodeCfg
+}
+
+func startNode(t *testing.T, nodeCfg *cfg.Config, key string) *Node {
+	t.Helper()
+
+	ks := keystore.NewGlobalKeystore()
+	sr25519KeyRing, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+	ed25519KeyRing, err := keystore.NewEd25519Keyring()
+	require.NoError(t, err)
+
+	require.NoError(t, keystore.LoadKeystore(key, ks.Acco, sr25519KeyRing))
+	require.NoError(t, keystore.LoadKeystore(key, ks.Babe, sr25519KeyRing))
+	require.NoError(t, keystore.LoadKeystore(key, ks.Gran, ed25519KeyRing))
+
+	err = dot.InitNode(nodeCfg)
+	require.NoError(t, err)
+
+	dotNode, err := dot.NewNode(nodeCfg, ks)
+	require.NoError(t, err)
+
+	node := &Node{
+		Name: key,
+		node: dotNode,
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		defer close(node.done)
+		err := dotNode.Start()
+		if err != nil {
+			t.Errorf("node %s stopped: %s", key, err)
+		}
+	}()
+
+	t.Cleanup(node.Stop)
+	select {
+	case <-dotNode.Started():
+	case <-node.done:
+		t.Fatalf("node %s stopped before starting", key)
+	}
+
+	return node
+}
+
+// Stop stops the node, it does nothing if the node is already stopped.
+func (n *Node) Stop() {
+	select {
+	case <-n.done:
+		return
+	default:
+	}
+
+	n.node.Stop()
+	<-n.done
+}
+
+// BlockState returns the block state of the node
+func (n *Node) BlockState() *state.BlockState {
+	return n.node.ServiceRegistry.Get(&state.Service{}).(*state.Service).Block
+}
+
+// Network returns the network service of the node
+func (n *Node) Network() *network.Service {
+	return n.node.ServiceRegistry.Get(&network.Service{}).(*network.Service)
+}
+
+// WaitForBestBlock waits for the best block of every node to reach the given number.
+func (nw *Network) WaitForBestBlock(ctx context.Context, number uint) error {
+	return nw.waitFor(ctx, func(node *Node) (bool, error) {
+		bestNumber, err := node.BlockState().BestBlockNumber()
+		if err != nil {
+			return false, fmt.Errorf("getting best block number of %s: %w", node.Name, err)
+		}
+		return bestNumber >= number, nil
+	})
+}
+
+// WaitForFinalisedBlock waits for every node to finalise the block at the given number, and
+// returns its hash once checked to be the same on every node.
+func (nw *Network) WaitForFinalisedBlock(ctx context.Context, number uint) (hash common.Hash, err error) {
+	err = nw.waitFor(ctx, func(node *Node) (bool, error) {
+		header, err := node.BlockState().GetHighestFinalisedHeader()
+		if err != nil {
+			return false, fmt.Errorf("getting highest finalised header of %s: %w", node.Name, err)
+		}
+		return header.Number >= number, nil
+	})
+	if err != nil {
+		return hash, err
+	}
+
+	for i, node := range nw.Nodes {
+		nodeHash, err := node.BlockState().GetHashByNumber(number)
+		if err != nil {
+			return hash, fmt.Errorf("getting hash of block %d of %s: %w", number, node.Name, err)
+		}
+
+		if i == 0 {
+			hash = nodeHash
+		} else if nodeHash != hash {
+			return hash, fmt.Errorf("%w: block %d is %s on %s and %s on %s", errHashMismatch,
+				number, hash, nw.Nodes[0].Name, nodeHash, node.Name)
+		}
+	}
+
+	return hash, nil
+}
+
+// waitFor waits for the condition to be true for every node, or for the context to be done.
+func (nw *Network) waitFor(ctx context.Context, condition func(node *Node) (bool, error)) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		met := true
+		for _, node := range nw.Nodes {
+			nodeMet, err := condition(node)
+			if err != nil {
+				return err
+			}
+			met = met && nodeMet
+		}
+
+		if met {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// availablePort returns a free tcp port of the loopback interface
+func availablePort(t *testing.T) uint16 {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, listener.Close())
+	}()
+
+	return uint16(listener.Addr().(*net.TCPAddr).Port) //nolint:gosec
+}
@@ -0,0 +1,54 @@
+//go:build integration
+
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package testnet
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetwork_Finality(t *testing.T) {
+	nw := New(t, Config{
+		Nodes: []NodeConfig{
+			{Key: "alice", Authority: true},
+			{Key: "bob", Authority: true},
+			{Key: "charlie"},
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+
+	err := nw.WaitForBestBlock(ctx, 3)
+	require.NoError(t, err)
+
+	_, err = nw.WaitForFinalisedBlock(ctx, 2)
+	require.NoError(t, err)
+
+	for _, node := range nw.Nodes {
+		peerCount := node.Network().Peers()
+		require.Len(t, peerCount, len(nw.Nodes)-1, node.Name)
+	}
+}
+
+func TestNetwork_Chaos(t *testing.T) {
+	nw := New(t, Config{
+		Nodes: []NodeConfig{
+			{Key: "alice", Authority: true},
+			{Key: "bob", Authority: true},
+		},
+		Chaos: "seed=1,drop=0.05,finality-delay=1s",
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	_, err := nw.WaitForFinalisedBlock(ctx, 2)
+	require.NoError(t, err)
+}
@@ -0,0 +1,126 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package tracing exports the OpenTelemetry spans of the node.
+// The services start their spans with a tracer obtained with otel.Tracer, which records
+// nothing until the global tracer provider is set by NewExporter, so tracing costs
+// close to nothing when it is disabled.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const shutdownTimeout = 10 * time.Second
+
+// Settings are the destinations the trace spans are exported to, spans are exported
+// to all the destinations set.
+type Settings struct {
+	// File is the file the spans are exported to as JSON.
+	File string
+	// OTLPEndpoint is the address of the collector the spans are exported to with OTLP over gRPC,
+	// such as localhost:4317.
+	OTLPEndpoint string
+	// OTLPInsecure disables the TLS of the connection to the collector.
+	OTLPInsecure bool
+}
+
+// Enabled returns true if the spans are exported to at least one destination.
+func (s Settings) Enabled() bool {
+	return s.File != "" || s.OTLPEndpoint != ""
+}
+
+// Exporter exports the OpenTelemetry spans of the node.
+type Exporter struct {
+	file     *os.File
+	provider *sdktrace.TracerProvider
+}
+
+// NewExporter sets the global tracer provider to export the spans to the destinations
+// of the settings given. The traces file is appended to if it already exists.
+func NewExporter(settings Settings) (exporter *Exporter, err error) {
+	exporter = &Exporter{}
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", "gossamer"),
+		)),
+	}
+
+	if settings.File != "" {
+		exporter.file, err = os.OpenFile(settings.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("opening traces file: %w", err)
+		}
+
+		fileExporter, err := stdouttrace.New(stdouttrace.WithWriter(exporter.file))
+		if err != nil {
+			_ = exporter.file.Close()
+			return nil, fmt.Errorf("creating file span exporter: %w", err)
+		}
+		options = append(options, sdktrace.WithBatcher(fileExporter))
+	}
+
+	if settings.OTLPEndpoint != "" {
+		otlpOptions := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(settings.OTLPEndpoint)}
+		if settings.OTLPInsecure {
+			otlpOptions = append(otlpOptions, otlptracegrpc.WithInsecure())
+		}
+
+		// the connection to the collector is established in the background
+		otlpExporter, err := otlptracegrpc.New(context.Background(), otlpOptions...)
+		if err != nil {
+			if exporter.file != nil {
+				_ = exporter.file.Close()
+			}
+			return nil, fmt.Errorf("creating OTLP span exporter: %w", err)
+		}
+		options = append(options, sdktrace.WithBatcher(otlpExporter))
+	}
+
+	exporter.provider = sdktrace.NewTracerProvider(options...)
+	otel.SetTracerProvider(exporter.provider)
+
+	return exporter, nil
+}
+
+// Stop flushes the spans not exported yet and closes the traces file.
+func (e *Exporter) Stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	var errs []error
+	err := e.provider.Shutdown(ctx)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("shutting down tracer provider: %w", err))
+	}
+
+	if e.file != nil {
+		err = e.file.Close()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("closing traces file: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// EndSpan ends the span given, recording the error the traced operation failed with if it is not nil.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
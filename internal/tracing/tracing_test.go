@@ -0,0 +1,85 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func Test_Settings_Enabled(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, Settings{OTLPInsecure: true}.Enabled())
+	assert.True(t, Settings{File: "traces.json"}.Enabled())
+	assert.True(t, Settings{OTLPEndpoint: "localhost:4317"}.Enabled())
+}
+
+func Test_Exporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.json")
+
+	exporter, err := NewExporter(Settings{File: path})
+	require.NoError(t, err)
+
+	_, span := otel.Tracer("test").Start(context.Background(), "test-span")
+	span.End()
+
+	err = exporter.Stop()
+	require.NoError(t, err)
+
+	traces, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(traces), `"Name":"test-span"`)
+	assert.Contains(t, string(traces), `"Value":"gossamer"`)
+}
+
+func Test_NewExporter_otlp(t *testing.T) {
+	// the connection to the collector is not established before spans are exported
+	exporter, err := NewExporter(Settings{
+		OTLPEndpoint: "127.0.0.1:4317",
+		OTLPInsecure: true,
+	})
+	require.NoError(t, err)
+
+	err = exporter.Stop()
+	assert.NoError(t, err)
+}
+
+func Test_NewExporter_invalidPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "traces.json")
+
+	_, err := NewExporter(Settings{File: path})
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func Test_EndSpan(t *testing.T) {
+	t.Parallel()
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	_, span := tracer.Start(context.Background(), "ok")
+	EndSpan(span, nil)
+	_, span = tracer.Start(context.Background(), "failed")
+	EndSpan(span, errors.New("test error"))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Empty(t, spans[0].Events())
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, "test error", spans[1].Status().Description)
+	require.Len(t, spans[1].Events(), 1)
+	assert.Equal(t, "exception", spans[1].Events()[0].Name)
+}
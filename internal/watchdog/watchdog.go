@@ -0,0 +1,262 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package watchdog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"runtime/pprof"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/services"
+)
+
+var logger = log.NewFromGlobal(log.AddContext("pkg", "watchdog"))
+
+const (
+	// restartTimeout is the duration a service is given to restart before the restart
+	// is reported as failed, the restart carrying on in the background
+	restartTimeout = 30 * time.Second
+	// minInterval is the minimum interval between two checks of the services
+	minInterval = time.Second
+)
+
+// Progress is the progress of a watched service
+type Progress struct {
+	// Last is the time the service last made progress at, zero if it never did
+	Last time.Time
+	// Err is the last error encountered by the service, nil if none
+	Err error
+	// Idle is true if the service is not expected to make progress, such as a paused service
+	Idle bool
+}
+
+// Heartbeat records the progress of the loop of a service and the last error it encountered.
+// It is safe for concurrent use, and its zero value is ready to use.
+type Heartbeat struct {
+	mutex sync.Mutex
+	last  time.Time
+	err   error
+}
+
+// Beat records the loop made progress
+func (h *Heartbeat) Beat() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.last = time.Now()
+}
+
+// Fail records the error given as the last error of the loop
+func (h *Heartbeat) Fail(err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.err = err
+}
+
+// Progress returns the progress recorded
+func (h *Heartbeat) Progress() Progress {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return Progress{Last: h.last, Err: h.err}
+}
+
+// Service is a service watched by the watchdog
+type Service interface {
+	Progress() Progress
+	Restart() error
+}
+
+// Target is a service watched by the watchdog, restarted if it does not make progress
+// within its window.
+type Target struct {
+	// Name is the name of the service, being the value of the services.ServiceLabel pprof
+	// label of its goroutines, such as babe.Service
+	Name    string
+	Service Service
+	Window  time.Duration
+}
+
+// target is a watched service and its watch state
+type target struct {
+	Target
+	// since is the time the watch of the service started at, the service being considered
+	// making progress at that time. It is reset when the service is idle and restarted.
+	since time.Time
+	// restarting is closed once the last restart of the service returned
+	restarting chan struct{}
+}
+
+// Watchdog detects the services not making progress within their window, such as a stalled
+// slot loop, and attempts to restart them in-process after logging their goroutines and
+// their last error.
+type Watchdog struct {
+	targets  []*target
+	interval time.Duration
+	now      func() time.Time
+	// goroutines returns the stacks of the goroutines of the service of the name given
+	goroutines func(name string) string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewWatchdog returns a watchdog of the targets given, checking them four times per window
+// of the target of the smallest window.
+func NewWatchdog(targets ...Target) *Watchdog {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &Watchdog{
+		interval:   minInterval,
+		now:        time.Now,
+		goroutines: serviceGoroutines,
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+
+	for i, t := range targets {
+		if i == 0 || t.Window/4 < w.interval {
+			w.interval = max(t.Window/4, minInterval)
+		}
+		w.targets = append(w.targets, &target{Target: t})
+	}
+	return w
+}
+
+// Start starts watching the services
+func (w *Watchdog) Start() error {
+	now := w.now()
+	for _, t := range w.targets {
+		logger.Infof("watching %s with a window of %s", t.Name, t.Window)
+		t.since = now
+	}
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		w.run()
+	}()
+	return nil
+}
+
+// Stop stops watching the services, without waiting for the restarts in progress
+func (w *Watchdog) Stop() error {
+	w.cancel()
+	w.wg.Wait()
+	return nil
+}
+
+func (w *Watchdog) run() {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for _, t := range w.targets {
+			w.check(t)
+		}
+	}
+}
+
+// check restarts the service of the target given if it did not make progress within its
+// window, unless the previous restart of the service did not return yet.
+func (w *Watchdog) check(t *target) {
+	now := w.now()
+	progress := t.Service.Progress()
+	if progress.Idle {
+		t.since = now
+		return
+	}
+
+	last := t.since
+	if progress.Last.After(last) {
+		last = progress.Last
+	}
+	stalled := now.Sub(last)
+	if stalled < t.Window {
+		return
+	}
+
+	t.since = now
+	if t.restarting != nil {
+		select {
+		case <-t.restarting:
+		default:
+			logger.Errorf("%s made no progress for %s and is still restarting", t.Name, stalled.Truncate(time.Second))
+			return
+		}
+	}
+
+	logger.Errorf("%s made no progress for %s, last error: %v, goroutines:\n%s",
+		t.Name, stalled.Truncate(time.Second), progress.Err, w.goroutines(t.Name))
+	t.restarting = w.restart(t)
+}
+
+// restart restarts the service of the target given in the background, labelling the
+// goroutines started with the name of the service, and returns a channel closed once
+// the restart returned.
+func (w *Watchdog) restart(t *target) (restarting chan struct{}) {
+	logger.Warnf("restarting %s", t.Name)
+
+	restarting = make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		defer close(restarting)
+		labels := pprof.Labels(services.ServiceLabel, t.Name)
+		pprof.Do(context.Background(), labels, func(context.Context) {
+			done <- t.Service.Restart()
+		})
+	}()
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		timer := time.NewTimer(restartTimeout)
+		defer timer.Stop()
+
+		select {
+		case err := <-done:
+			if err != nil {
+				logger.Errorf("cannot restart %s: %s", t.Name, err)
+				return
+			}
+			logger.Infof("restarted %s", t.Name)
+		case <-timer.C:
+			logger.Errorf("cannot restart %s: timed out after %s", t.Name, restartTimeout)
+		case <-w.ctx.Done():
+		}
+	}()
+	return restarting
+}
+
+// serviceGoroutines returns the stacks of the goroutines labelled with the service name
+// given, grouped by identical stacks, or the stacks of all the goroutines if none is
+// labelled with it.
+func serviceGoroutines(name string) string {
+	var buffer bytes.Buffer
+	err := pprof.Lookup("goroutine").WriteTo(&buffer, 1)
+	if err != nil {
+		return fmt.Sprintf("cannot dump goroutines: %s", err)
+	}
+
+	label := fmt.Sprintf("%q:%q", services.ServiceLabel, name)
+	var stacks []string
+	for _, record := range strings.Split(buffer.String(), "\n\n") {
+		if strings.Contains(record, label) {
+			stacks = append(stacks, record)
+		}
+	}
+	if len(stacks) == 0 {
+		return buffer.String()
+	}
+	return strings.Join(stacks, "\n\n")
+}
@@ -0,0 +1,213 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package watchdog
+
+import (
+	"context"
+	"errors"
+	"runtime/pprof"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testService is a watched service whose progress is set by the test
+type testService struct {
+	mutex    sync.Mutex
+	progress Progress
+	restarts int
+	// restart is received from before returning from Restart, if set
+	restart chan error
+}
+
+func (s *testService) Progress() Progress {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.progress
+}
+
+func (s *testService) Restart() error {
+	s.mutex.Lock()
+	s.restarts++
+	restart := s.restart
+	s.mutex.Unlock()
+
+	if restart == nil {
+		return nil
+	}
+	return <-restart
+}
+
+func (s *testService) restartCount() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.restarts
+}
+
+func TestHeartbeat(t *testing.T) {
+	t.Parallel()
+
+	var heartbeat Heartbeat
+	assert.Equal(t, Progress{}, heartbeat.Progress())
+
+	errTest := errors.New("test error")
+	heartbeat.Fail(errTest)
+	before := time.Now()
+	heartbeat.Beat()
+
+	progress := heartbeat.Progress()
+	assert.False(t, progress.Last.Before(before))
+	assert.Equal(t, errTest, progress.Err)
+	assert.False(t, progress.Idle)
+}
+
+func TestNewWatchdog(t *testing.T) {
+	t.Parallel()
+
+	w := NewWatchdog(
+		Target{Name: "babe.Service", Window: time.Minute},
+		Target{Name: "grandpa.Service", Window: 20 * time.Second},
+	)
+	assert.Equal(t, 5*time.Second, w.interval)
+
+	w = NewWatchdog(Target{Name: "babe.Service", Window: 2 * time.Second})
+	assert.Equal(t, minInterval, w.interval)
+}
+
+func TestWatchdog_check(t *testing.T) {
+	t.Parallel()
+
+	const window = time.Minute
+	start := time.Unix(1_000_000, 0)
+
+	testCases := map[string]struct {
+		progress        Progress
+		elapsed         time.Duration
+		restarting      bool
+		expectedSince   time.Time
+		expectedRestart bool
+	}{
+		"within_window": {
+			elapsed:       window - time.Second,
+			expectedSince: start,
+		},
+		"progress_within_window": {
+			progress:      Progress{Last: start.Add(window)},
+			elapsed:       window + time.Second,
+			expectedSince: start,
+		},
+		"idle": {
+			progress:      Progress{Idle: true},
+			elapsed:       2 * window,
+			expectedSince: start.Add(2 * window),
+		},
+		"stalled": {
+			progress:        Progress{Last: start.Add(time.Second), Err: errors.New("test error")},
+			elapsed:         window + time.Second,
+			expectedSince:   start.Add(window + time.Second),
+			expectedRestart: true,
+		},
+		"stalled_while_restarting": {
+			elapsed:       window,
+			restarting:    true,
+			expectedSince: start.Add(window),
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			service := &testService{progress: testCase.progress}
+			w := NewWatchdog(Target{Name: "babe.Service", Service: service, Window: window})
+			w.now = func() time.Time { return start.Add(testCase.elapsed) }
+			var goroutinesLogged bool
+			w.goroutines = func(name string) string {
+				goroutinesLogged = true
+				assert.Equal(t, "babe.Service", name)
+				return ""
+			}
+
+			target := w.targets[0]
+			target.since = start
+			if testCase.restarting {
+				target.restarting = make(chan struct{})
+			}
+
+			w.check(target)
+			w.cancel()
+			w.wg.Wait()
+
+			assert.Equal(t, testCase.expectedSince, target.since)
+			assert.Equal(t, testCase.expectedRestart, goroutinesLogged)
+			if testCase.expectedRestart {
+				<-target.restarting
+				assert.Equal(t, 1, service.restartCount())
+			} else {
+				assert.Zero(t, service.restartCount())
+			}
+		})
+	}
+}
+
+func TestWatchdog_restart(t *testing.T) {
+	t.Parallel()
+
+	service := &testService{restart: make(chan error)}
+	w := NewWatchdog(Target{Name: "babe.Service", Service: service, Window: time.Minute})
+	w.goroutines = func(string) string { return "" }
+	start := time.Unix(1_000_000, 0)
+	now := start.Add(time.Minute)
+	w.now = func() time.Time { return now }
+	target := w.targets[0]
+	target.since = start
+
+	w.check(target)
+	require.NotNil(t, target.restarting)
+
+	// the service is not restarted again while its restart did not return
+	now = now.Add(time.Minute)
+	w.check(target)
+	service.restart <- errors.New("test error")
+	<-target.restarting
+	assert.Equal(t, 1, service.restartCount())
+
+	now = now.Add(time.Minute)
+	w.check(target)
+	service.restart <- nil
+	<-target.restarting
+	assert.Equal(t, 2, service.restartCount())
+
+	err := w.Stop()
+	require.NoError(t, err)
+}
+
+func TestServiceGoroutines(t *testing.T) {
+	t.Parallel()
+
+	stop := make(chan struct{})
+	started := make(chan struct{})
+	labels := pprof.Labels(services.ServiceLabel, "watchdog.testService")
+	pprof.Do(context.Background(), labels, func(context.Context) {
+		go func() {
+			close(started)
+			<-stop
+		}()
+	})
+	<-started
+	defer close(stop)
+
+	goroutines := serviceGoroutines("watchdog.testService")
+	assert.Contains(t, goroutines, `# labels: {"service":"watchdog.testService"}`)
+	assert.Equal(t, 1, strings.Count(goroutines, "# labels:"))
+
+	goroutines = serviceGoroutines("watchdog.unknownService")
+	assert.Contains(t, goroutines, "goroutine profile: total")
+}
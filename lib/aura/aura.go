@@ -0,0 +1,459 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package aura
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/telemetry"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/lib/transaction"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+var logger = log.NewFromGlobal(log.AddContext("pkg", "aura"))
+
+// invalidExhaustsResources is the result of applying an extrinsic which
+// would exhaust the resources of the block, Err(Invalid(ExhaustsResources)).
+var invalidExhaustsResources = []byte{1, 0, 6}
+
+// Service authors a block at each slot for which the node is the Aura authority
+type Service struct {
+	ctx          context.Context
+	cancel       context.CancelFunc
+	authority    bool
+	slotDuration time.Duration
+
+	blockState         BlockState
+	storageState       StorageState
+	transactionState   TransactionState
+	blockImportHandler BlockImportHandler
+
+	keypair *sr25519.Keypair
+
+	sync.RWMutex
+	pause chan struct{}
+
+	telemetry Telemetry
+	wg        sync.WaitGroup
+}
+
+// ServiceConfig represents an Aura configuration
+type ServiceConfig struct {
+	LogLvl             log.Level
+	BlockState         BlockState
+	StorageState       StorageState
+	TransactionState   TransactionState
+	BlockImportHandler BlockImportHandler
+	Keypair            *sr25519.Keypair
+	Authority          bool
+	SlotDuration       time.Duration
+	Telemetry          Telemetry
+}
+
+// NewService returns a new Aura service
+func NewService(cfg *ServiceConfig) (*Service, error) {
+	if cfg.Keypair == nil && cfg.Authority {
+		return nil, errNoKeypairProvided
+	}
+
+	logger.Patch(log.SetLevel(cfg.LogLvl))
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	auraService := &Service{
+		ctx:                ctx,
+		cancel:             cancel,
+		authority:          cfg.Authority,
+		slotDuration:       cfg.SlotDuration,
+		blockState:         cfg.BlockState,
+		storageState:       cfg.StorageState,
+		transactionState:   cfg.TransactionState,
+		blockImportHandler: cfg.BlockImportHandler,
+		keypair:            cfg.Keypair,
+		pause:              make(chan struct{}),
+		telemetry:          cfg.Telemetry,
+	}
+
+	logger.Debugf("created service with block producer ID=%v and slot duration %s",
+		cfg.Authority, cfg.SlotDuration)
+
+	return auraService, nil
+}
+
+// Start starts Aura block authoring. A service paused before being started starts
+// authoring once resumed.
+func (s *Service) Start() error {
+	s.RLock()
+	defer s.RUnlock()
+
+	if !s.authority || s.IsPaused() {
+		return nil
+	}
+
+	s.wg.Add(1)
+	go func() {
+		s.run()
+		s.wg.Done()
+	}()
+	return nil
+}
+
+// Stop stops the service. If stop is called, it cannot be resumed.
+func (s *Service) Stop() error {
+	if !s.authority {
+		return nil
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	if s.ctx.Err() != nil {
+		return errors.New("service already stopped")
+	}
+
+	s.cancel()
+	s.wg.Wait()
+	return nil
+}
+
+// Pause pauses the service ie. halts block production
+func (s *Service) Pause() error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.IsPaused() {
+		return nil
+	}
+
+	close(s.pause)
+	return nil
+}
+
+// Resume resumes the service ie. resumes block production
+func (s *Service) Resume() error {
+	s.Lock()
+	defer s.Unlock()
+
+	if !s.IsPaused() {
+		return nil
+	}
+
+	s.pause = make(chan struct{})
+	s.wg.Add(1)
+	go func() {
+		s.run()
+		s.wg.Done()
+	}()
+	logger.Debug("service resumed")
+	return nil
+}
+
+// IsPaused returns if the service is paused or not (ie. producing blocks)
+func (s *Service) IsPaused() bool {
+	select {
+	case <-s.pause:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsStopped returns true if the service is stopped (ie not producing blocks)
+func (s *Service) IsStopped() bool {
+	return s.ctx.Err() != nil
+}
+
+// SlotDuration returns the slot duration in milliseconds
+func (s *Service) SlotDuration() uint64 {
+	return uint64(s.slotDuration.Milliseconds()) //nolint:gosec
+}
+
+// EpochLength returns the epoch length in slots, Aura has no epochs so each slot is an epoch.
+func (*Service) EpochLength() uint64 {
+	return 1
+}
+
+// SealBlock is not supported by Aura, whose blocks are only authored at their slot.
+func (*Service) SealBlock() (*types.Block, error) {
+	return nil, errSealNotSupported
+}
+
+func (s *Service) run() {
+	for {
+		slot := getCurrentSlot(s.slotDuration) + 1
+
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-s.pause:
+			return
+		case <-time.After(time.Until(getSlotStartTime(slot, s.slotDuration))):
+		}
+
+		err := s.handleSlot(slot)
+		if err != nil {
+			logger.Warnf("failed to handle slot %d: %s", slot, err)
+		}
+	}
+}
+
+// handleSlot builds and imports a block on top of the best block if the node
+// is the authority expected to author at the slot given.
+func (s *Service) handleSlot(slot uint64) error {
+	parent, err := s.blockState.BestBlockHeader()
+	if err != nil {
+		return fmt.Errorf("getting best block header: %w", err)
+	}
+
+	block, ts, err := s.Propose(parent, slot, types.NewInherentData(), false)
+	switch {
+	case errors.Is(err, ErrSlotNotClaimed):
+		logger.Tracef("not our turn to author at slot %d", slot)
+		return nil
+	case errors.Is(err, errSlotNotIncreasing):
+		logger.Debugf("skipping slot %d: %s", slot, err)
+		return nil
+	case err != nil:
+		return err
+	}
+
+	s.telemetry.SendMessage(
+		telemetry.NewPreparedBlockForProposing(
+			block.Header.Hash(),
+			fmt.Sprint(block.Header.Number),
+		),
+	)
+
+	err = s.blockImportHandler.HandleBlockProduced(block, ts)
+	if err != nil {
+		return fmt.Errorf("importing built block: %w", err)
+	}
+
+	return nil
+}
+
+// Propose builds and seals a block on top of the parent given at the slot given, provided the
+// node is the authority of the slot, otherwise ErrSlotNotClaimed is returned. The inherents of
+// the inherent data given are applied with the timestamp and slot inherents. If recordProof is
+// true, the parent state keys accessed while building the block are recorded in the returned
+// trie state, so a storage proof of the block can be generated.
+func (s *Service) Propose(parent *types.Header, slot uint64, inherentData *types.InherentData,
+	recordProof bool) (*types.Block, *rtstorage.TrieState, error) {
+	if s.keypair == nil {
+		return nil, nil, ErrNotAuthority
+	}
+
+	if parent.Number != 0 {
+		parentSlot, err := slotFromHeader(parent)
+		if err != nil {
+			return nil, nil, fmt.Errorf("getting parent slot: %w", err)
+		}
+
+		if parentSlot >= slot {
+			return nil, nil, fmt.Errorf("%w: slot %d, parent slot %d", errSlotNotIncreasing, slot, parentSlot)
+		}
+	}
+
+	authorities, err := getAuthorities(s.storageState, parent.StateRoot)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !bytes.Equal(slotAuthor(slot, authorities).Encode(), s.keypair.Public().Encode()) {
+		return nil, nil, fmt.Errorf("%w: %d", ErrSlotNotClaimed, slot)
+	}
+
+	s.storageState.Lock()
+	defer s.storageState.Unlock()
+
+	ts, err := s.storageState.TrieState(&parent.StateRoot)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting parent trie with state root %s: %w", parent.StateRoot, err)
+	}
+
+	if recordProof {
+		ts.EnableProofRecording()
+	}
+
+	rt, err := s.blockState.GetRuntime(parent.Hash())
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting runtime: %w", err)
+	}
+
+	rt.SetContextStorage(ts)
+
+	block, err := s.buildBlock(parent, slot, rt, inherentData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("building block: %w", err)
+	}
+
+	logger.Infof("built block %d with hash %s, state root %s and slot %d",
+		block.Header.Number, block.Header.Hash(), block.Header.StateRoot, slot)
+
+	return block, ts, nil
+}
+
+// buildBlock constructs and seals a block for the slot given with the given parent.
+func (s *Service) buildBlock(parent *types.Header, slot uint64, rt runtime.Instance,
+	inherentData *types.InherentData) (*types.Block, error) {
+	encodedSlot, err := scale.Marshal(slot)
+	if err != nil {
+		return nil, fmt.Errorf("encoding slot: %w", err)
+	}
+
+	digest := types.NewDigest()
+	err = digest.Add(*types.NewAuraPreRuntimeDigest(encodedSlot))
+	if err != nil {
+		return nil, fmt.Errorf("adding pre-runtime digest: %w", err)
+	}
+
+	header := types.NewHeader(parent.Hash(), common.Hash{}, common.Hash{}, parent.Number+1, digest)
+	err = rt.InitializeBlock(header)
+	if err != nil {
+		return nil, fmt.Errorf("initialising block: %w", err)
+	}
+
+	inherents, err := s.applyInherents(slot, rt, inherentData)
+	if err != nil {
+		return nil, fmt.Errorf("applying inherents: %w", err)
+	}
+
+	included := s.applyExtrinsics(slot, rt)
+
+	header, err = rt.FinalizeBlock()
+	if err != nil {
+		for _, tx := range included {
+			_, _ = s.transactionState.Push(tx)
+		}
+		return nil, fmt.Errorf("finalising block: %w", err)
+	}
+
+	hash, err := common.Blake2bHash(scale.MustMarshal(*header))
+	if err != nil {
+		return nil, fmt.Errorf("hashing header: %w", err)
+	}
+
+	signature, err := s.keypair.Sign(hash[:])
+	if err != nil {
+		return nil, fmt.Errorf("signing header: %w", err)
+	}
+
+	err = header.Digest.Add(types.SealDigest{
+		ConsensusEngineID: types.AuraEngineID,
+		Data:              signature,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("adding seal: %w", err)
+	}
+
+	extrinsics := types.BytesArrayToExtrinsics(inherents)
+	for _, tx := range included {
+		var extrinsic []byte
+		err = scale.Unmarshal(tx.Extrinsic, &extrinsic)
+		if err != nil {
+			return nil, fmt.Errorf("decoding extrinsic: %w", err)
+		}
+		extrinsics = append(extrinsics, extrinsic)
+	}
+
+	return &types.Block{
+		Header: *header,
+		Body:   types.Body(extrinsics),
+	}, nil
+}
+
+// applyInherents applies the inherents of the inherent data given with the timestamp
+// and slot inherents, and returns the inherent extrinsics.
+func (s *Service) applyInherents(slot uint64, rt runtime.Instance,
+	inherentData *types.InherentData) ([][]byte, error) {
+	timestamp := getSlotStartTime(slot, s.slotDuration).UnixMilli()
+
+	err := inherentData.SetInherent(types.Timstap0, uint64(timestamp)) //nolint:gosec
+	if err != nil {
+		return nil, err
+	}
+
+	err = inherentData.SetInherent(types.Auraslot, slot)
+	if err != nil {
+		return nil, err
+	}
+
+	encodedInherentData, err := inherentData.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	encodedExtrinsics, err := rt.InherentExtrinsics(encodedInherentData)
+	if err != nil {
+		return nil, err
+	}
+
+	var extrinsics [][]byte
+	err = scale.Unmarshal(encodedExtrinsics, &extrinsics)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, extrinsic := range extrinsics {
+		ret, err := rt.ApplyExtrinsic(scale.MustMarshal(extrinsic))
+		if err != nil {
+			return nil, err
+		}
+
+		if !bytes.Equal(ret, []byte{0, 0}) {
+			return nil, fmt.Errorf("applying inherent failed with result 0x%x", ret)
+		}
+	}
+
+	return extrinsics, nil
+}
+
+// applyExtrinsics applies the queued extrinsics until two thirds of the slot
+// have elapsed, leaving the rest of the slot for the block finalisation.
+func (s *Service) applyExtrinsics(slot uint64, rt runtime.Instance) []*transaction.ValidTransaction {
+	slotEnd := getSlotStartTime(slot, s.slotDuration).Add(s.slotDuration * 2 / 3)
+	timer := time.NewTimer(time.Until(slotEnd))
+	defer timer.Stop()
+
+	var included []*transaction.ValidTransaction
+	for {
+		tx := s.transactionState.PopWithTimer(timer.C)
+		if tx == nil {
+			return included
+		}
+
+		ret, err := rt.ApplyExtrinsic(tx.Extrinsic)
+		if err != nil {
+			logger.Warnf("applying extrinsic %s: %s", tx.Extrinsic, err)
+			s.transactionState.ReportInvalid(tx.Extrinsic)
+			continue
+		}
+
+		switch {
+		case len(ret) > 0 && ret[0] == 0:
+			// the extrinsic is included even if its dispatch failed
+			included = append(included, tx)
+		case bytes.HasPrefix(ret, invalidExhaustsResources):
+			hash, err := s.transactionState.Push(tx)
+			if err != nil {
+				logger.Debugf("failed to re-add transaction with hash %s to queue: %s", hash, err)
+			}
+			return included
+		default:
+			logger.Debugf("dropping invalid extrinsic %s with result 0x%x", tx.Extrinsic, ret)
+			s.transactionState.ReportInvalid(tx.Extrinsic)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package aura
+
+import "errors"
+
+var (
+	// ErrBadSignature is returned when the seal of a block is not signed by the expected author
+	ErrBadSignature = errors.New("could not verify signature")
+
+	// ErrNotAuthority is returned when trying to perform authority functions when not an authority
+	ErrNotAuthority = errors.New("node is not an authority")
+
+	// ErrSlotNotClaimed is returned when proposing a block at a slot of another authority
+	ErrSlotNotClaimed = errors.New("slot is not claimed by the node")
+
+	errNoAuthorities             = errors.New("no aura authorities")
+	errNoPreRuntimeDigest        = errors.New("no aura pre-runtime digest")
+	errMultiplePreRuntimeDigests = errors.New("multiple aura pre-runtime digests")
+	errLastDigestItemNotSeal     = errors.New("last digest item is not an aura seal")
+	errFutureSlot                = errors.New("slot is in the future")
+	errSlotNotIncreasing         = errors.New("slot is not greater than the slot of the parent block")
+	errSealNotSupported          = errors.New("blocks cannot be sealed on demand with aura")
+	errNoKeypairProvided         = errors.New("cannot create aura service as authority; no keypair provided")
+)
@@ -0,0 +1,6 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package aura
+
+//go:generate mockgen -destination=mock_state_test.go -package $GOPACKAGE . BlockState,StorageState
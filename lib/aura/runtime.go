@@ -0,0 +1,99 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package aura
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// EngineName is the consensus engine name of the chain spec selecting Aura
+const EngineName = "aura"
+
+// authoritiesKey is the storage key of the Aura authorities, twox128("Aura") ++ twox128("Authorities")
+var authoritiesKey = common.MustHexToBytes("0x57f8dc2f5ab09467896f47300f0424385e0621c4869aa60c02be9adcc98a0d1d")
+
+// Executor executes runtime calls
+type Executor interface {
+	Exec(function string, data []byte) ([]byte, error)
+}
+
+// GenesisConfiguration returns the BABE configuration equivalent to the Aura configuration of
+// the runtime given, which is stored by the epoch state to provide the slot duration of Aura
+// chains. Aura has no epochs nor slot lottery, so every slot is an epoch of its own.
+func GenesisConfiguration(rt Executor) (*types.BabeConfiguration, error) {
+	encodedSlotDuration, err := rt.Exec(runtime.AuraAPISlotDuration, []byte{})
+	if err != nil {
+		return nil, fmt.Errorf("getting slot duration: %w", err)
+	}
+
+	var slotDuration uint64
+	err = scale.Unmarshal(encodedSlotDuration, &slotDuration)
+	if err != nil {
+		return nil, fmt.Errorf("decoding slot duration: %w", err)
+	}
+
+	encodedAuthorities, err := rt.Exec(runtime.AuraAPIAuthorities, []byte{})
+	if err != nil {
+		return nil, fmt.Errorf("getting authorities: %w", err)
+	}
+
+	var authorities [][sr25519.PublicKeyLength]byte
+	err = scale.Unmarshal(encodedAuthorities, &authorities)
+	if err != nil {
+		return nil, fmt.Errorf("decoding authorities: %w", err)
+	}
+
+	genesisAuthorities := make([]types.AuthorityRaw, len(authorities))
+	for i, authority := range authorities {
+		genesisAuthorities[i] = types.AuthorityRaw{Key: authority, Weight: 1}
+	}
+
+	return &types.BabeConfiguration{
+		SlotDuration:       slotDuration,
+		EpochLength:        1,
+		C1:                 1,
+		C2:                 1,
+		GenesisAuthorities: genesisAuthorities,
+	}, nil
+}
+
+// getAuthorities returns the Aura authorities stored in the state with the root given.
+func getAuthorities(storageState StorageState, stateRoot common.Hash) ([]*sr25519.PublicKey, error) {
+	encodedAuthorities, err := storageState.GetStorage(&stateRoot, authoritiesKey)
+	if err != nil {
+		return nil, fmt.Errorf("getting authorities from storage: %w", err)
+	}
+
+	var authorities [][sr25519.PublicKeyLength]byte
+	err = scale.Unmarshal(encodedAuthorities, &authorities)
+	if err != nil {
+		return nil, fmt.Errorf("decoding authorities: %w", err)
+	}
+
+	if len(authorities) == 0 {
+		return nil, errNoAuthorities
+	}
+
+	keys := make([]*sr25519.PublicKey, len(authorities))
+	for i, authority := range authorities {
+		keys[i], err = sr25519.NewPublicKey(authority[:])
+		if err != nil {
+			return nil, fmt.Errorf("decoding authority %d public key: %w", i, err)
+		}
+	}
+
+	return keys, nil
+}
+
+// slotAuthor returns the authority expected to author a block at the slot given,
+// the authorities taking turns in a round robin fashion.
+func slotAuthor(slot uint64, authorities []*sr25519.PublicKey) *sr25519.PublicKey {
+	return authorities[slot%uint64(len(authorities))]
+}
@@ -0,0 +1,57 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package aura
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testExecutor map[string][]byte
+
+func (e testExecutor) Exec(function string, _ []byte) ([]byte, error) {
+	ret, ok := e[function]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %s", function)
+	}
+	return ret, nil
+}
+
+func TestGenesisConfiguration(t *testing.T) {
+	t.Parallel()
+
+	_, encodedAuthorities := newTestAuthorities(t, 2)
+	var authorities [][32]byte
+	err := scale.Unmarshal(encodedAuthorities, &authorities)
+	require.NoError(t, err)
+
+	executor := testExecutor{
+		runtime.AuraAPISlotDuration: scale.MustMarshal(uint64(6000)),
+		runtime.AuraAPIAuthorities:  encodedAuthorities,
+	}
+
+	babeConfig, err := GenesisConfiguration(executor)
+	require.NoError(t, err)
+
+	expected := &types.BabeConfiguration{
+		SlotDuration: 6000,
+		EpochLength:  1,
+		C1:           1,
+		C2:           1,
+		GenesisAuthorities: []types.AuthorityRaw{
+			{Key: authorities[0], Weight: 1},
+			{Key: authorities[1], Weight: 1},
+		},
+	}
+	assert.Equal(t, expected, babeConfig)
+
+	_, err = GenesisConfiguration(testExecutor{})
+	assert.ErrorContains(t, err, "getting slot duration")
+}
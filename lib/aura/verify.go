@@ -0,0 +1,162 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package aura
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// maxSlotDrift is the number of slots a block is allowed to be ahead of our
+// current slot, to account for the clock drift between the nodes.
+const maxSlotDrift = 1
+
+// Verifier verifies that blocks are authored by the Aura authority of their slot
+type Verifier struct {
+	blockState   BlockState
+	storageState StorageState
+	slotDuration time.Duration
+}
+
+// NewVerifier returns a new Aura block verifier
+func NewVerifier(blockState BlockState, storageState StorageState, slotDuration time.Duration) *Verifier {
+	return &Verifier{
+		blockState:   blockState,
+		storageState: storageState,
+		slotDuration: slotDuration,
+	}
+}
+
+// VerifyBlock verifies that the block header given is sealed by the authority whose turn it
+// is to author at the slot of the block, using the authorities of the state of its parent.
+func (v *Verifier) VerifyBlock(header *types.Header) error {
+	slot, err := slotFromHeader(header)
+	if err != nil {
+		return err
+	}
+
+	currentSlot := getCurrentSlot(v.slotDuration)
+	if slot > currentSlot+maxSlotDrift {
+		return fmt.Errorf("%w: slot %d, current slot %d", errFutureSlot, slot, currentSlot)
+	}
+
+	parent, err := v.blockState.GetHeader(header.ParentHash)
+	if err != nil {
+		return fmt.Errorf("getting parent header: %w", err)
+	}
+
+	if parent.Number != 0 {
+		parentSlot, err := slotFromHeader(parent)
+		if err != nil {
+			return fmt.Errorf("getting parent slot: %w", err)
+		}
+
+		if slot <= parentSlot {
+			return fmt.Errorf("%w: slot %d, parent slot %d", errSlotNotIncreasing, slot, parentSlot)
+		}
+	}
+
+	authorities, err := getAuthorities(v.storageState, parent.StateRoot)
+	if err != nil {
+		return err
+	}
+
+	if len(header.Digest) == 0 {
+		return errLastDigestItemNotSeal
+	}
+
+	sealItemValue, err := header.Digest[len(header.Digest)-1].Value()
+	if err != nil {
+		return fmt.Errorf("getting seal item value: %w", err)
+	}
+	seal, ok := sealItemValue.(types.SealDigest)
+	if !ok || seal.ConsensusEngineID != types.AuraEngineID {
+		return fmt.Errorf("%w: got %s", errLastDigestItemNotSeal, sealItemValue)
+	}
+
+	hash, err := unsealedHash(header)
+	if err != nil {
+		return err
+	}
+
+	author := slotAuthor(slot, authorities)
+	ok, err = author.Verify(hash[:], seal.Data)
+	if err != nil {
+		return fmt.Errorf("verifying seal: %w", err)
+	}
+
+	if !ok {
+		return fmt.Errorf("%w: expected author %s at slot %d", ErrBadSignature, author.Hex(), slot)
+	}
+
+	return nil
+}
+
+// slotFromHeader returns the slot of the Aura pre-runtime digest of the header.
+func slotFromHeader(header *types.Header) (slot uint64, err error) {
+	var found bool
+	for _, item := range header.Digest {
+		value, err := item.Value()
+		if err != nil {
+			return 0, fmt.Errorf("getting digest item value: %w", err)
+		}
+
+		preRuntimeDigest, ok := value.(types.PreRuntimeDigest)
+		if !ok || preRuntimeDigest.ConsensusEngineID != types.AuraEngineID {
+			continue
+		}
+
+		if found {
+			return 0, errMultiplePreRuntimeDigests
+		}
+		found = true
+
+		err = scale.Unmarshal(preRuntimeDigest.Data, &slot)
+		if err != nil {
+			return 0, fmt.Errorf("decoding slot: %w", err)
+		}
+	}
+
+	if !found {
+		return 0, errNoPreRuntimeDigest
+	}
+
+	return slot, nil
+}
+
+// unsealedHash returns the hash of the header given without its last digest item, the seal.
+func unsealedHash(header *types.Header) (common.Hash, error) {
+	digest := types.NewDigest()
+	for _, item := range header.Digest[:len(header.Digest)-1] {
+		value, err := item.Value()
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("getting digest item value: %w", err)
+		}
+
+		err = digest.Add(value)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("adding digest item: %w", err)
+		}
+	}
+
+	unsealed := *types.NewHeader(header.ParentHash, header.StateRoot, header.ExtrinsicsRoot, header.Number, digest)
+	encodedHeader, err := scale.Marshal(unsealed)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("encoding header: %w", err)
+	}
+
+	return common.Blake2bHash(encodedHeader)
+}
+
+func getCurrentSlot(slotDuration time.Duration) uint64 {
+	return uint64(time.Now().UnixNano()) / uint64(slotDuration.Nanoseconds()) //nolint:gosec
+}
+
+func getSlotStartTime(slot uint64, slotDuration time.Duration) time.Time {
+	return time.Unix(0, int64(slot)*slotDuration.Nanoseconds()) //nolint:gosec
+}
@@ -0,0 +1,143 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package aura
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const testSlotDuration = 6 * time.Second
+
+func newTestAuthorities(t *testing.T, n int) ([]*sr25519.Keypair, []byte) {
+	t.Helper()
+
+	keypairs := make([]*sr25519.Keypair, n)
+	authorities := make([][sr25519.PublicKeyLength]byte, n)
+	for i := range keypairs {
+		kp, err := sr25519.GenerateKeypair()
+		require.NoError(t, err)
+		keypairs[i] = kp
+		copy(authorities[i][:], kp.Public().Encode())
+	}
+
+	return keypairs, scale.MustMarshal(authorities)
+}
+
+// newSealedHeader returns a header at the slot given sealed with the keypair given.
+func newSealedHeader(t *testing.T, parent *types.Header, slot uint64, kp *sr25519.Keypair) *types.Header {
+	t.Helper()
+
+	digest := types.NewDigest()
+	err := digest.Add(*types.NewAuraPreRuntimeDigest(scale.MustMarshal(slot)))
+	require.NoError(t, err)
+
+	header := types.NewHeader(parent.Hash(), common.Hash{1}, common.Hash{2}, parent.Number+1, digest)
+	hash, err := common.Blake2bHash(scale.MustMarshal(*header))
+	require.NoError(t, err)
+
+	signature, err := kp.Sign(hash[:])
+	require.NoError(t, err)
+
+	err = header.Digest.Add(types.SealDigest{
+		ConsensusEngineID: types.AuraEngineID,
+		Data:              signature,
+	})
+	require.NoError(t, err)
+
+	return header
+}
+
+func Test_slotAuthor(t *testing.T) {
+	t.Parallel()
+
+	keypairs, _ := newTestAuthorities(t, 3)
+	authorities := make([]*sr25519.PublicKey, len(keypairs))
+	for i, kp := range keypairs {
+		authorities[i] = kp.Public().(*sr25519.PublicKey)
+	}
+
+	assert.Equal(t, authorities[0], slotAuthor(3, authorities))
+	assert.Equal(t, authorities[1], slotAuthor(4, authorities))
+	assert.Equal(t, authorities[2], slotAuthor(5, authorities))
+}
+
+func TestVerifier_VerifyBlock(t *testing.T) {
+	t.Parallel()
+
+	keypairs, encodedAuthorities := newTestAuthorities(t, 2)
+	currentSlot := getCurrentSlot(testSlotDuration)
+	genesis := types.NewHeader(common.Hash{}, common.Hash{3}, common.Hash{}, 0, types.NewDigest())
+	parent := newSealedHeader(t, genesis, currentSlot-2, keypairs[currentSlot%2])
+
+	testCases := map[string]struct {
+		header      *types.Header
+		parent      *types.Header
+		noStorage   bool
+		errSentinel error
+	}{
+		"no_pre_runtime_digest": {
+			header:      types.NewHeader(parent.Hash(), common.Hash{}, common.Hash{}, 2, types.NewDigest()),
+			errSentinel: errNoPreRuntimeDigest,
+		},
+		"future_slot": {
+			header:      newSealedHeader(t, parent, currentSlot+3, keypairs[(currentSlot+3)%2]),
+			errSentinel: errFutureSlot,
+		},
+		"slot_not_increasing": {
+			header:      newSealedHeader(t, parent, currentSlot-2, keypairs[currentSlot%2]),
+			parent:      parent,
+			noStorage:   true,
+			errSentinel: errSlotNotIncreasing,
+		},
+		"wrong_author": {
+			header:      newSealedHeader(t, parent, currentSlot, keypairs[(currentSlot+1)%2]),
+			parent:      parent,
+			errSentinel: ErrBadSignature,
+		},
+		"valid_block": {
+			header: newSealedHeader(t, parent, currentSlot, keypairs[currentSlot%2]),
+			parent: parent,
+		},
+		"valid_block_on_genesis": {
+			header: newSealedHeader(t, genesis, currentSlot, keypairs[currentSlot%2]),
+			parent: genesis,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			blockState := NewMockBlockState(ctrl)
+			storageState := NewMockStorageState(ctrl)
+			if testCase.parent != nil {
+				blockState.EXPECT().GetHeader(testCase.parent.Hash()).Return(testCase.parent, nil)
+				if !testCase.noStorage {
+					storageState.EXPECT().GetStorage(&testCase.parent.StateRoot, authoritiesKey).
+						Return(encodedAuthorities, nil)
+				}
+			}
+
+			verifier := NewVerifier(blockState, storageState, testSlotDuration)
+			err := verifier.VerifyBlock(testCase.header)
+			if testCase.errSentinel == nil {
+				require.NoError(t, err)
+				return
+			}
+			assert.True(t, errors.Is(err, testCase.errSentinel), err)
+		})
+	}
+}
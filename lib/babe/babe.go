@@ -8,13 +8,17 @@
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/telemetry"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/internal/watchdog"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/services"
 
 	ethmetrics "github.com/ethereum/go-ethereum/metrics"
 )
@@ -27,6 +31,8 @@ type Service struct {
 	cancel       context.CancelFunc
 	authority    bool
 	dev          bool
+	sealMode     SealMode
+	dryRun       bool // discards the blocks built instead of importing and broadcasting them
 	constants    constants
 	epochHandler *epochHandler
 
@@ -40,10 +46,24 @@ type Service struct {
 
 	// BABE authority keypair
 	keypair *sr25519.Keypair // TODO: change to BABE keystore (#1864)
+	// keystore the authority keypair is switched from when the session keys change, if set
+	keystore Keystore
+
+	// clockGuard prevents authoring blocks while the local clock is ahead of the network, if set
+	clockGuard ClockGuard
+
+	// blockLimits holds the block limits of the runtime the blocks are built with
+	blockLimits blockLimitsCache
 
 	// State variables
 	sync.RWMutex
 	pause chan struct{}
+	// sealLock serialises the blocks sealed on demand
+	sealLock sync.Mutex
+	// engineDone is closed once the last block production engine started returned
+	engineDone chan struct{}
+	// heartbeat records the slots handled by the slot loop, for the watchdog
+	heartbeat watchdog.Heartbeat
 
 	telemetry Telemetry
 	wg        sync.WaitGroup
@@ -58,8 +78,11 @@ type ServiceConfig struct {
 	EpochState         EpochState
 	BlockImportHandler BlockImportHandler
 	Keypair            *sr25519.Keypair
+	Keystore           Keystore
 	AuthData           []types.Authority
 	IsDev              bool
+	SealMode           SealMode
+	AuthoringDryRun    bool
 	Authority          bool
 	Telemetry          Telemetry
 }
@@ -70,6 +93,10 @@ func (sc *ServiceConfig) Validate() error {
 		return errNoBABEAuthorityKeyProvided
 	}
 
+	if !sc.SealMode.IsValid() {
+		return fmt.Errorf("%w: %s", errInvalidSealMode, sc.SealMode)
+	}
+
 	return nil
 }
 
@@ -98,10 +125,13 @@ func (Builder) NewServiceIFace(cfg *ServiceConfig) (service *Service, err error)
 		storageState:       cfg.StorageState,
 		epochState:         cfg.EpochState,
 		keypair:            cfg.Keypair,
+		keystore:           cfg.Keystore,
 		transactionState:   cfg.TransactionState,
 		pause:              make(chan struct{}),
 		authority:          cfg.Authority,
 		dev:                cfg.IsDev,
+		sealMode:           cfg.SealMode,
+		dryRun:             cfg.AuthoringDryRun,
 		blockImportHandler: cfg.BlockImportHandler,
 		constants: constants{
 			slotDuration: slotDuration,
@@ -140,10 +170,13 @@ func NewService(cfg *ServiceConfig) (*Service, error) {
 		storageState:       cfg.StorageState,
 		epochState:         cfg.EpochState,
 		keypair:            cfg.Keypair,
+		keystore:           cfg.Keystore,
 		transactionState:   cfg.TransactionState,
 		pause:              make(chan struct{}),
 		authority:          cfg.Authority,
 		dev:                cfg.IsDev,
+		sealMode:           cfg.SealMode,
+		dryRun:             cfg.AuthoringDryRun,
 		blockImportHandler: cfg.BlockImportHandler,
 		constants: constants{
 			slotDuration: slotDuration,
@@ -160,18 +193,36 @@ func NewService(cfg *ServiceConfig) (*Service, error) {
 	return babeService, nil
 }
 
-// Start starts BABE block authoring
+// Start starts BABE block authoring. A service paused before being started starts
+// authoring once resumed.
 func (b *Service) Start() error {
-	if !b.authority {
+	b.Lock()
+	defer b.Unlock()
+
+	if !b.authority || b.IsPaused() {
 		return nil
 	}
 
+	b.startEngine()
+	return nil
+}
+
+// startEngine starts the block production engine, which must be called with the lock held
+func (b *Service) startEngine() {
+	engineDone := make(chan struct{})
+	b.engineDone = engineDone
 	b.wg.Add(1)
 	go func() {
+		defer b.wg.Done()
+		defer close(engineDone)
+		defer services.Recover(b)
 		b.initiate()
-		b.wg.Done()
 	}()
-	return nil
+}
+
+// SetClockGuard sets the clock guard checked before authoring a block
+func (b *Service) SetClockGuard(clockGuard ClockGuard) {
+	b.clockGuard = clockGuard
 }
 
 // SlotDuration returns the current service slot duration in milliseconds
@@ -207,15 +258,46 @@ func (b *Service) Resume() error {
 	}
 
 	b.pause = make(chan struct{})
-	b.wg.Add(1)
-	go func() {
-		b.initiate()
-		b.wg.Done()
-	}()
+	b.startEngine()
 	logger.Debug("service resumed")
 	return nil
 }
 
+// Progress returns the progress of the slot loop, which is idle if the service does not
+// author blocks at claimed slots or is paused.
+func (b *Service) Progress() watchdog.Progress {
+	b.RLock()
+	defer b.RUnlock()
+
+	progress := b.heartbeat.Progress()
+	progress.Idle = !b.authority || b.sealMode != SlotSeal || b.IsPaused() || b.IsStopped()
+	return progress
+}
+
+// Restart restarts the slot loop, such as after a panic, waiting for the running loop to
+// return before starting a new one so that no slot is handled twice. It does nothing if the
+// service is paused.
+func (b *Service) Restart() error {
+	b.Lock()
+	if b.IsPaused() {
+		b.Unlock()
+		return nil
+	}
+	close(b.pause)
+	engineDone := b.engineDone
+	b.Unlock()
+
+	if engineDone != nil {
+		select {
+		case <-engineDone:
+		case <-b.ctx.Done():
+			return b.ctx.Err()
+		}
+	}
+
+	return b.Resume()
+}
+
 // IsPaused returns if the service is paused or not (ie. producing blocks)
 func (b *Service) IsPaused() bool {
 	select {
@@ -257,11 +339,44 @@ func (b *Service) IsStopped() bool {
 	return b.ctx.Err() != nil
 }
 
+// selectKeypair switches the authority keypair to a keypair of the keystore found in the
+// authorities given, if the current keypair is not one of them, so that the session keys
+// rotated and set on chain are used from the session they are set for.
+func (b *Service) selectKeypair(authorities []types.AuthorityRaw) {
+	if b.keystore == nil {
+		return
+	}
+
+	isAuthority := func(kp *sr25519.Keypair) bool {
+		return slices.ContainsFunc(authorities, func(auth types.AuthorityRaw) bool {
+			return bytes.Equal(auth.Key[:], kp.Public().Encode())
+		})
+	}
+
+	if b.keypair != nil && isAuthority(b.keypair) {
+		return
+	}
+
+	for _, kp := range b.keystore.Keypairs() {
+		keypair, ok := kp.(*sr25519.Keypair)
+		if !ok || !isAuthority(keypair) {
+			continue
+		}
+
+		if b.keypair != nil {
+			logger.Infof("switching BABE authority key from %s to %s", b.keypair.Public().Hex(), keypair.Public().Hex())
+		}
+		b.keypair = keypair
+		return
+	}
+}
+
 func (b *Service) getAuthorityIndex(authorities []types.AuthorityRaw) (uint32, error) {
 	if !b.authority {
 		return 0, ErrNotAuthority
 	}
 
+	b.selectKeypair(authorities)
 	pub := b.keypair.Public()
 
 	for i, auth := range authorities {
@@ -274,15 +389,34 @@ func (b *Service) getAuthorityIndex(authorities []types.AuthorityRaw) (uint32, e
 }
 
 func (b *Service) initiate() {
+	switch b.sealMode {
+	case InstantSeal:
+		logger.Info("sealing a block for each transaction received")
+		b.runInstantSeal()
+		return
+	case ManualSeal:
+		logger.Info("sealing blocks on demand only")
+		return
+	}
+
 	// we should consider better error handling for this - we should
 	// retry to run the engine at some point (maybe the next epoch) if
 	// there's an error.
 	if err := b.runEngine(); err != nil {
+		b.heartbeat.Fail(err)
 		logger.Criticalf("failed to run block production engine: %s", err)
 	}
 }
 
 func (b *Service) initiateAndGetEpochHandler(epoch uint64) (*epochHandler, error) {
+	handler, err := b.loadEpochHandler(epoch)
+	if err == nil {
+		logger.Infof("resuming epoch %d with %d slot claims persisted", epoch, len(handler.slotToPreRuntimeDigest))
+		return handler, nil
+	} else if !errors.Is(err, database.ErrNotFound) && !errors.Is(err, errStaleSlotClaims) {
+		logger.Warnf("cannot load slot claims of epoch %d: %s", epoch, err)
+	}
+
 	epochDescriptor, err := b.initiateEpoch(epoch)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initiate epoch: %w", err)
@@ -291,12 +425,49 @@ func (b *Service) initiateAndGetEpochHandler(epoch uint64) (*epochHandler, error
 	logger.Debugf("initiated epoch with threshold %s, randomness 0x%x and authorities %v",
 		epochDescriptor.data.threshold, epochDescriptor.data.randomness[:], epochDescriptor.data.authorities)
 
-	return newEpochHandler(
+	handler, err = newEpochHandler(
 		epochDescriptor,
 		b.constants,
 		b.handleSlot,
 		b.keypair,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	// the slot claims are persisted so a restart in the middle of the epoch does not run
+	// the lottery again, a failure only costing the lottery to be run again after a restart
+	err = b.epochState.StoreSlotClaims(handler.slotClaims())
+	if err != nil {
+		logger.Warnf("cannot persist slot claims of epoch %d: %s", epoch, err)
+	}
+
+	return handler, nil
+}
+
+// loadEpochHandler returns the epoch handler of the slot claims persisted for the given epoch,
+// if they were claimed with the authority key of the service
+func (b *Service) loadEpochHandler(epoch uint64) (*epochHandler, error) {
+	claims, err := b.epochState.GetSlotClaims()
+	if err != nil {
+		return nil, fmt.Errorf("getting slot claims: %w", err)
+	}
+
+	if claims.Epoch != epoch {
+		return nil, fmt.Errorf("%w: claimed for epoch %d", errStaleSlotClaims, claims.Epoch)
+	}
+
+	if getCurrentSlot(b.constants.slotDuration) >= claims.EndSlot {
+		return nil, fmt.Errorf("%w: epoch ended at slot %d", errStaleSlotClaims, claims.EndSlot)
+	}
+
+	b.selectKeypair(claims.Authorities)
+	if int(claims.AuthorityIndex) >= len(claims.Authorities) ||
+		!bytes.Equal(claims.Authorities[claims.AuthorityIndex].Key[:], b.keypair.Public().Encode()) {
+		return nil, fmt.Errorf("%w: claimed with another authority key", errStaleSlotClaims)
+	}
+
+	return newEpochHandlerFromSlotClaims(claims, b.constants, b.handleSlot), nil
 }
 
 func (b *Service) runEngine() error {
@@ -334,6 +505,7 @@ func (b *Service) handleEpoch(epoch uint64) (next uint64, err error) {
 	if err != nil {
 		return 0, fmt.Errorf("cannot initiate and get epoch handler: %w", err)
 	}
+	b.epochHandler.heartbeat = &b.heartbeat
 
 	nextEpochStarts := b.epochHandler.descriptor.endSlot
 	nextEpochStartTime := getSlotStartTime(nextEpochStarts, b.constants.slotDuration)
@@ -423,10 +595,27 @@ func (b *Service) handleSlot(epoch uint64, slot Slot,
 	authorityIndex uint32,
 	preRuntimeDigest *types.PreRuntimeDigest,
 ) error {
+	if b.clockGuard != nil {
+		err := b.clockGuard.CheckAuthoring()
+		if err != nil {
+			return fmt.Errorf("not authoring in slot %d: %w", slot.number, err)
+		}
+	}
+
 	parent, err := b.getParentForBlockAuthoring(slot.number)
 	if err != nil {
 		return fmt.Errorf("could not get parent for claiming slot %d: %w", slot.number, err)
 	}
+
+	_, err = b.produceBlock(epoch, parent, slot, authorityIndex, preRuntimeDigest)
+	return err
+}
+
+// produceBlock builds a block on top of the parent given at the slot given and imports it.
+func (b *Service) produceBlock(epoch uint64, parent *types.Header, slot Slot,
+	authorityIndex uint32,
+	preRuntimeDigest *types.PreRuntimeDigest,
+) (*types.Block, error) {
 	b.storageState.Lock()
 	defer b.storageState.Unlock()
 
@@ -435,19 +624,19 @@ func (b *Service) handleSlot(epoch uint64, slot Slot,
 	ts, err := b.storageState.TrieState(&parent.StateRoot)
 	if err != nil || ts == nil {
 		logger.Errorf("failed to get parent trie with parent state root %s: %s", parent.StateRoot, err)
-		return err
+		return nil, err
 	}
 
 	rt, err := b.blockState.GetRuntime(parent.Hash())
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	rt.SetContextStorage(ts)
 
 	block, err := b.buildBlock(parent, slot, rt, authorityIndex, preRuntimeDigest)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	logger.Infof(
@@ -457,6 +646,13 @@ func (b *Service) handleSlot(epoch uint64, slot Slot,
 		"built block with parent hash %s, header %s and body %s",
 		parent.Hash(), block.Header.String(), block.Body)
 
+	if b.dryRun {
+		logger.Infof(
+			"dry run: would have authored block %d with hash %s and %d extrinsics on parent %s in slot %d",
+			block.Header.Number, block.Header.Hash(), len(block.Body), parent.Hash(), slot.number)
+		return block, nil
+	}
+
 	b.telemetry.SendMessage(
 		telemetry.NewPreparedBlockForProposing(
 			block.Header.Hash(),
@@ -466,10 +662,10 @@ func (b *Service) handleSlot(epoch uint64, slot Slot,
 
 	if err := b.blockImportHandler.HandleBlockProduced(block, ts); err != nil {
 		logger.Warnf("failed to import built block: %s", err)
-		return err
+		return nil, err
 	}
 
-	return nil
+	return block, nil
 }
 
 func getCurrentSlot(slotDuration time.Duration) uint64 {
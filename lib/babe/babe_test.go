@@ -0,0 +1,76 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package babe
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Progress(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	b := &Service{
+		ctx:       ctx,
+		cancel:    cancel,
+		authority: true,
+		pause:     make(chan struct{}),
+	}
+	assert.False(t, b.Progress().Idle)
+
+	errTest := errors.New("test error")
+	b.heartbeat.Fail(errTest)
+	b.heartbeat.Beat()
+	progress := b.Progress()
+	assert.False(t, progress.Last.IsZero())
+	assert.Equal(t, errTest, progress.Err)
+
+	b.sealMode = ManualSeal
+	assert.True(t, b.Progress().Idle)
+
+	b.sealMode = SlotSeal
+	err := b.Pause()
+	require.NoError(t, err)
+	assert.True(t, b.Progress().Idle)
+}
+
+func TestService_Restart(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// the engine of the manual seal mode returns right away
+	b := &Service{
+		ctx:       ctx,
+		cancel:    cancel,
+		authority: true,
+		sealMode:  ManualSeal,
+		pause:     make(chan struct{}),
+	}
+
+	err := b.Start()
+	require.NoError(t, err)
+	firstEngine := b.engineDone
+
+	err = b.Restart()
+	require.NoError(t, err)
+	assert.False(t, b.IsPaused())
+	assert.NotEqual(t, firstEngine, b.engineDone)
+	<-b.engineDone
+
+	// a paused service is not restarted
+	err = b.Pause()
+	require.NoError(t, err)
+	secondEngine := b.engineDone
+	err = b.Restart()
+	require.NoError(t, err)
+	assert.True(t, b.IsPaused())
+	assert.Equal(t, secondEngine, b.engineDone)
+}
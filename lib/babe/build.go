@@ -5,17 +5,24 @@
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/tracing"
 	"github.com/ChainSafe/gossamer/lib/babe/inherents"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 	"github.com/ChainSafe/gossamer/lib/transaction"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 	ethmetrics "github.com/ethereum/go-ethereum/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -23,6 +30,33 @@
 	buildBlockErrors = "gossamer/proposer/block/constructed/errors"
 )
 
+const tracerName = "github.com/ChainSafe/gossamer/lib/babe"
+
+// phases of the block building, used as label of the phase duration metric and as name
+// of the phase trace spans
+const (
+	phaseInitialize = "initialize"
+	phaseInherents  = "inherents"
+	phaseExtrinsics = "extrinsics"
+	phaseFinalize   = "finalize"
+	phaseSeal       = "seal"
+)
+
+var (
+	buildBlockPhaseDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "gossamer_babe",
+		Name:      "block_build_phase_duration_seconds",
+		Help:      "duration of each phase of the block building",
+		Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 14), // 0.5ms to ~4s
+	}, []string{"phase"})
+	applyExtrinsicDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "gossamer_babe",
+		Name:      "block_build_apply_extrinsic_duration_seconds",
+		Help:      "duration of the application of each extrinsic of the built blocks",
+		Buckets:   prometheus.ExponentialBuckets(0.00005, 2, 16), // 50µs to ~1.6s
+	})
+)
+
 // construct a block for this slot with the given parent
 func (b *Service) buildBlock(parent *types.Header, slot Slot, rt Runtime,
 	authorityIndex uint32, preRuntimeDigest *types.PreRuntimeDigest) (*types.Block, error) {
@@ -33,6 +67,8 @@ func (b *Service) buildBlock(parent *types.Header, slot Slot, rt Runtime,
 		authorityIndex,
 		preRuntimeDigest,
 	)
+	builder.requeue = b.dryRun
+	builder.limits = b.blockLimits.get(rt)
 
 	// is necessary to enable ethmetrics to be possible register values
 	ethmetrics.Enabled = true
@@ -57,6 +93,14 @@ type BlockBuilder struct {
 	blockState            BlockState
 	currentAuthorityIndex uint32
 	preRuntimeDigest      *types.PreRuntimeDigest
+	// tracer traces the phases of the block building
+	tracer trace.Tracer
+	// requeue pushes the transactions included back to the queue once the block is built,
+	// for blocks which are not imported
+	requeue bool
+	// limits are the block limits of the runtime the extrinsics included are checked
+	// against, nil to rely on the runtime rejecting the extrinsics not fitting
+	limits *blockLimits
 }
 
 // NewBlockBuilder creates a new block builder.
@@ -73,23 +117,34 @@ func NewBlockBuilder(
 		blockState:            bs,
 		currentAuthorityIndex: authidx,
 		preRuntimeDigest:      preRuntimeDigest,
+		tracer:                otel.Tracer(tracerName),
 	}
 }
 
-func (b *BlockBuilder) buildBlock(parent *types.Header, slot Slot, rt Runtime) (*types.Block, error) {
+func (b *BlockBuilder) buildBlock(parent *types.Header, slot Slot, rt Runtime) (block *types.Block, err error) {
 	logger.Tracef("build block with parent %s and slot: %s", parent, slot)
 
-	// create new block header
 	number := parent.Number + 1
+	ctx, span := b.tracer.Start(context.Background(), "build_block", trace.WithAttributes(
+		attribute.Int64("slot", int64(slot.number)), //nolint:gosec
+		attribute.Int64("number", int64(number)),    //nolint:gosec
+	))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
+	// create new block header
 	digest := types.NewDigest()
-	err := digest.Add(*b.preRuntimeDigest)
+	err = digest.Add(*b.preRuntimeDigest)
 	if err != nil {
 		return nil, err
 	}
 	header := types.NewHeader(parent.Hash(), common.Hash{}, common.Hash{}, number, digest)
 
 	// initialise block header
+	endPhase := b.startPhase(ctx, phaseInitialize)
 	err = rt.InitializeBlock(header)
+	endPhase(err)
 	if err != nil {
 		return nil, err
 	}
@@ -97,20 +152,34 @@ func (b *BlockBuilder) buildBlock(parent *types.Header, slot Slot, rt Runtime) (
 	logger.Trace("initialised block")
 
 	// add block inherents
+	endPhase = b.startPhase(ctx, phaseInherents)
 	inherents, err := buildBlockInherents(slot, rt, parent)
+	endPhase(err)
 	if err != nil {
 		return nil, fmt.Errorf("cannot build inherents: %s", err)
 	}
 
 	logger.Tracef("built block encoded inherents: %v", inherents)
 
+	var length uint32
+	for _, inherent := range inherents {
+		length += uint32(len(scale.MustMarshal(inherent))) //nolint:gosec
+	}
+
 	// add block extrinsics
-	included := b.buildBlockExtrinsics(slot, rt)
+	phaseCtx, phaseSpan := b.tracer.Start(ctx, phaseExtrinsics)
+	start := time.Now()
+	included := b.buildBlockExtrinsics(phaseCtx, slot, rt, length)
+	buildBlockPhaseDuration.WithLabelValues(phaseExtrinsics).Observe(time.Since(start).Seconds())
+	phaseSpan.SetAttributes(attribute.Int("included", len(included)))
+	phaseSpan.End()
 
 	logger.Trace("built block extrinsics")
 
 	// finalise block
+	endPhase = b.startPhase(ctx, phaseFinalize)
 	header, err = rt.FinalizeBlock()
+	endPhase(err)
 	if err != nil {
 		b.addToQueue(included)
 		return nil, fmt.Errorf("cannot finalise block: %s", err)
@@ -119,12 +188,9 @@ func (b *BlockBuilder) buildBlock(parent *types.Header, slot Slot, rt Runtime) (
 	logger.Trace("finalised block")
 
 	// create seal and add to digest
-	seal, err := b.buildBlockSeal(header)
-	if err != nil {
-		return nil, err
-	}
-
-	err = header.Digest.Add(*seal)
+	endPhase = b.startPhase(ctx, phaseSeal)
+	err = b.sealHeader(header)
+	endPhase(err)
 	if err != nil {
 		return nil, err
 	}
@@ -136,14 +202,39 @@ func (b *BlockBuilder) buildBlock(parent *types.Header, slot Slot, rt Runtime) (
 		return nil, err
 	}
 
-	block := &types.Block{
+	block = &types.Block{
 		Header: *header,
 		Body:   body,
 	}
 
+	if b.requeue {
+		b.addToQueue(included)
+	}
+
 	return block, nil
 }
 
+// startPhase starts the span of a block building phase. The returned function ends the span
+// and observes the duration of the phase, it is given the error the phase failed with.
+func (b *BlockBuilder) startPhase(ctx context.Context, phase string) (endPhase func(err error)) {
+	_, span := b.tracer.Start(ctx, phase)
+	start := time.Now()
+	return func(err error) {
+		buildBlockPhaseDuration.WithLabelValues(phase).Observe(time.Since(start).Seconds())
+		tracing.EndSpan(span, err)
+	}
+}
+
+// sealHeader adds the seal digest to the finalised block header.
+func (b *BlockBuilder) sealHeader(header *types.Header) error {
+	seal, err := b.buildBlockSeal(header)
+	if err != nil {
+		return err
+	}
+
+	return header.Digest.Add(*seal)
+}
+
 // buildBlockSeal creates the seal for the block header.
 // the seal consists of the ConsensusEngineID and a signature of the encoded block header.
 func (b *BlockBuilder) buildBlockSeal(header *types.Header) (*types.SealDigest, error) {
@@ -171,8 +262,15 @@ func (b *BlockBuilder) buildBlockSeal(header *types.Header) (*types.SealDigest,
 // buildBlockExtrinsics applies extrinsics to the block. it returns an array of included extrinsics.
 // for each extrinsic in queue, add it to the block, until the slot ends or the block is full.
 // if any extrinsic fails, it returns an empty array and an error.
-func (b *BlockBuilder) buildBlockExtrinsics(slot Slot, rt ExtrinsicHandler) []*transaction.ValidTransaction {
-	var included []*transaction.ValidTransaction
+// blockLength is the encoded length of the extrinsics already in the block, the extrinsics
+// exceeding the block length limit being left in the queue. The dispatch class of the queued
+// extrinsics is not known, so they are checked against the limit of the normal class.
+func (b *BlockBuilder) buildBlockExtrinsics(ctx context.Context, slot Slot,
+	rt ExtrinsicHandler, blockLength uint32) []*transaction.ValidTransaction {
+	var included, skipped []*transaction.ValidTransaction
+	defer func() {
+		b.addToQueue(skipped)
+	}()
 
 	slotEnd := slot.start.Add(slot.duration * 2 / 3) // reserve last 1/3 of slot for block finalisation
 	timeout := slotEnd.Sub(slot.start)               // timeout relative to the slot start
@@ -186,21 +284,45 @@ func (b *BlockBuilder) buildBlockExtrinsics(slot Slot, rt ExtrinsicHandler) []*t
 		}
 
 		extrinsic := txn.Extrinsic
+		length := uint32(len(extrinsic)) //nolint:gosec
+		if !b.limits.fits(dispatchClassNormal, blockLength, length) {
+			logger.Debugf("build block, skipping extrinsic %s exceeding the block length limit", extrinsic)
+			skipped = append(skipped, txn)
+			if len(skipped) >= maxSkippedExtrinsics {
+				logger.Debug("build block, block is full")
+				break
+			}
+			continue
+		}
+
 		logger.Tracef("build block, applying extrinsic %s", extrinsic)
 
+		_, span := b.tracer.Start(ctx, "apply_extrinsic", trace.WithAttributes(
+			attribute.Int("size", len(extrinsic)),
+		))
+		if span.IsRecording() {
+			span.SetAttributes(attribute.String("hash", extrinsic.Hash().String()))
+		}
+
+		start := time.Now()
 		ret, err := rt.ApplyExtrinsic(extrinsic)
+		applyExtrinsicDuration.Observe(time.Since(start).Seconds())
 		if err != nil {
+			tracing.EndSpan(span, err)
 			logger.Warnf("determining apply extrinsic call error: %s", err)
+			b.transactionState.ReportInvalid(extrinsic)
 			continue
 		}
 
 		err = determineErr(ret)
+		tracing.EndSpan(span, err)
 		if err != nil {
 			logger.Warnf("error when applying extrinsic %s: %s", extrinsic, err)
 
 			// Failure of the module call dispatching doesn't invalidate the extrinsic.
 			// It is included in the block.
 			if _, ok := err.(*DispatchOutcomeError); !ok {
+				b.transactionState.ReportInvalid(extrinsic)
 				continue
 			}
 
@@ -221,6 +343,7 @@ func (b *BlockBuilder) buildBlockExtrinsics(slot Slot, rt ExtrinsicHandler) []*t
 
 		logger.Debugf("build block applied extrinsic %s", extrinsic)
 		included = append(included, txn)
+		blockLength += length
 	}
 
 	return included
@@ -6,21 +6,18 @@
 package babe
 
 import (
-	"bytes"
+	"context"
 	"testing"
 
+	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	"github.com/ChainSafe/gossamer/lib/transaction"
+	"github.com/ChainSafe/gossamer/pkg/extrinsic"
 	"github.com/ChainSafe/gossamer/pkg/scale"
-
-	cscale "github.com/centrifuge/go-substrate-rpc-client/v4/scale"
-	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
-	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
-	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/stretchr/testify/require"
 )
@@ -54,6 +51,9 @@ func TestSeal(t *testing.T) {
 }
 
 func TestBuildBlock_ok(t *testing.T) {
+	keyRing, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+
 	genesis, genesisTrie, genesisHeader := newWestendDevGenesisWithTrieAndHeader(t)
 	babeService := createTestService(t, ServiceConfig{}, genesis, genesisTrie, genesisHeader, AuthorOnEverySlotBABEConfig)
 
@@ -70,7 +70,7 @@ func TestBuildBlock_ok(t *testing.T) {
 		duration: babeService.constants.slotDuration,
 		number:   epochDescriptor.startSlot,
 	}
-	extrinsic := runtime.NewTestExtrinsic(t, rt, parentHash, parentHash, 0, signature.TestKeyringPairAlice,
+	extrinsic := runtime.NewTestExtrinsic(t, rt, parentHash, parentHash, 0, keyRing.Alice(),
 		"System.remark", []byte{0xab, 0xcd})
 	block := createTestBlockWithSlot(t, babeService, &genesisHeader, [][]byte{common.MustHexToBytes(extrinsic)},
 		epochDescriptor, slot)
@@ -91,7 +91,43 @@ func TestBuildBlock_ok(t *testing.T) {
 	require.Equal(t, expectedSecondExtrinsic, common.BytesToHex(extsBytes[1]))
 }
 
+func TestBuildBlock_dryRun(t *testing.T) {
+	keyRing, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+
+	genesis, genesisTrie, genesisHeader := newWestendDevGenesisWithTrieAndHeader(t)
+	babeService := createTestService(t, ServiceConfig{AuthoringDryRun: true}, genesis, genesisTrie,
+		genesisHeader, AuthorOnEverySlotBABEConfig)
+
+	parentHash := genesisHeader.Hash()
+	rt, err := babeService.blockState.GetRuntime(parentHash)
+	require.NoError(t, err)
+
+	const epoch = 0
+	epochDescriptor, err := babeService.initiateEpoch(epoch)
+	require.NoError(t, err)
+
+	slot := Slot{
+		start:    getSlotStartTime(epochDescriptor.startSlot, babeService.constants.slotDuration),
+		duration: babeService.constants.slotDuration,
+		number:   epochDescriptor.startSlot,
+	}
+	extrinsic := runtime.NewTestExtrinsic(t, rt, parentHash, parentHash, 0, keyRing.Alice(),
+		"System.remark", []byte{0xab, 0xcd})
+	block := createTestBlockWithSlot(t, babeService, &genesisHeader, [][]byte{common.MustHexToBytes(extrinsic)},
+		epochDescriptor, slot)
+	require.Equal(t, 3, len(block.Body))
+
+	// the transaction included is back in the queue, the block built being discarded
+	pending := babeService.transactionState.(*state.TransactionState).Pending()
+	require.Len(t, pending, 1)
+	require.Equal(t, types.Extrinsic(common.MustHexToBytes(extrinsic)), pending[0].Extrinsic)
+}
+
 func TestApplyExtrinsicAfterFirstBlockFinalized(t *testing.T) {
+	keyRing, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+
 	genesis, genesisTrie, genesisHeader := newWestendDevGenesisWithTrieAndHeader(t)
 	babeService := createTestService(t, ServiceConfig{}, genesis, genesisTrie, genesisHeader, AuthorOnEverySlotBABEConfig)
 	const authorityIndex = 0
@@ -133,7 +169,7 @@ func TestApplyExtrinsicAfterFirstBlockFinalized(t *testing.T) {
 	_, err = buildBlockInherents(slot, rt, parentHeader)
 	require.NoError(t, err)
 
-	ext := runtime.NewTestExtrinsic(t, rt, emptyHash, parentHeader.Hash(), 0, signature.TestKeyringPairAlice,
+	ext := runtime.NewTestExtrinsic(t, rt, emptyHash, parentHeader.Hash(), 0, keyRing.Alice(),
 		"System.remark", []byte{0xab, 0xcd})
 	_, err = rt.ApplyExtrinsic(common.MustHexToBytes(ext))
 	require.NoError(t, err)
@@ -142,13 +178,13 @@ func TestApplyExtrinsicAfterFirstBlockFinalized(t *testing.T) {
 	require.NoError(t, err)
 
 	ext2 := runtime.NewTestExtrinsic(t, rt, parentHeader.Hash(), parentHeader.Hash(), 0,
-		signature.TestKeyringPairAlice, "System.remark",
+		keyRing.Alice(), "System.remark",
 		[]byte{0xab, 0xcd})
 
 	validExt := []byte{byte(types.TxnExternal)}
 	validExt = append(validExt, common.MustHexToBytes(ext2)...)
 	validExt = append(validExt, babeService.blockState.BestBlockHash().ToBytes()...)
-	_, err = rt.ValidateTransaction(validExt)
+	_, err = rt.ValidateTransaction(context.Background(), validExt)
 	require.NoError(t, err)
 
 	// Add 7 seconds to allow slot to be claimed at appropriate time, Westend has 6 second slot times
@@ -201,55 +237,40 @@ func TestBuildAndApplyExtrinsic(t *testing.T) {
 	err = scale.Unmarshal(rawMeta, &metadataBytes)
 	require.NoError(t, err)
 
-	meta := &ctypes.Metadata{}
-	err = codec.Decode(metadataBytes, meta)
-	require.NoError(t, err)
-
-	runtimeVersion, err := rt.Version()
+	registry, err := scale.NewRegistry(metadataBytes)
 	require.NoError(t, err)
-
-	charlie, err := ctypes.NewMultiAddressFromHexAccountID(
-		keyRing.KeyCharlie.Public().Hex())
+	extrinsicBuilder, err := extrinsic.NewBuilder(registry)
 	require.NoError(t, err)
 
-	call, err := ctypes.NewCall(meta, "Balances.transfer", charlie, ctypes.NewUCompactFromUInt(12345))
+	runtimeVersion, err := rt.Version()
 	require.NoError(t, err)
 
-	// Create the extrinsic
-	extrinsic := ctypes.NewExtrinsic(call)
-	genesisHash, err := ctypes.NewHashFromHexString(genesisHeader.Hash().String())
+	charlie := extrinsic.NewMultiAddressID(keyRing.KeyCharlie.Public())
+	call, err := extrinsicBuilder.NewCall("Balances.transfer", charlie, scale.NewCompact(uint64(12345)))
 	require.NoError(t, err)
 
-	so := ctypes.SignatureOptions{
-		BlockHash:          genesisHash,
-		Era:                ctypes.ExtrinsicEra{IsImmortalEra: true},
-		GenesisHash:        genesisHash,
-		Nonce:              ctypes.NewUCompactFromUInt(uint64(0)),
-		SpecVersion:        ctypes.U32(runtimeVersion.SpecVersion),
-		Tip:                ctypes.NewUCompactFromUInt(0),
-		TransactionVersion: ctypes.U32(runtimeVersion.TransactionVersion),
+	options := extrinsic.Options{
+		SpecVersion:        runtimeVersion.SpecVersion,
+		TransactionVersion: runtimeVersion.TransactionVersion,
+		GenesisHash:        genesisHeader.Hash(),
+		BlockHash:          genesisHeader.Hash(),
 	}
 
 	// Sign the transaction using Alice's default account
-	err = extrinsic.Sign(signature.TestKeyringPairAlice, so)
-	require.NoError(t, err)
-
-	extEnc := bytes.NewBuffer(nil)
-	encoder := cscale.NewEncoder(extEnc)
-	err = extrinsic.Encode(*encoder)
+	extEnc, err := extrinsicBuilder.Signed(call, keyRing.Alice(), options)
 	require.NoError(t, err)
 
-	externalExtrinsic := buildLocalTransaction(t, rt, extEnc.Bytes(), bestBlockHash)
+	externalExtrinsic := buildLocalTransaction(t, rt, extEnc, bestBlockHash)
 
-	txVal, err := rt.ValidateTransaction(externalExtrinsic)
+	txVal, err := rt.ValidateTransaction(context.Background(), externalExtrinsic)
 	require.NoError(t, err)
 
-	validTransaction := transaction.NewValidTransaction(extEnc.Bytes(), txVal)
+	validTransaction := transaction.NewValidTransaction(extEnc, txVal)
 	_, err = babeService.transactionState.Push(validTransaction)
 	require.NoError(t, err)
 
 	// apply extrinsic
-	res, err := rt.ApplyExtrinsic(extEnc.Bytes())
+	res, err := rt.ApplyExtrinsic(extEnc)
 	require.NoError(t, err)
 	// Expected result for valid ApplyExtrinsic is 0, 0
 	require.Equal(t, []byte{0, 0}, res)
@@ -276,43 +297,30 @@ func TestBuildAndApplyExtrinsic_InvalidPayment(t *testing.T) {
 	err = scale.Unmarshal(rawMeta, &metadataBytes)
 	require.NoError(t, err)
 
-	meta := &ctypes.Metadata{}
-	err = codec.Decode(metadataBytes, meta)
+	registry, err := scale.NewRegistry(metadataBytes)
 	require.NoError(t, err)
-
-	runtimeVersion, err := rt.Version()
+	extrinsicBuilder, err := extrinsic.NewBuilder(registry)
 	require.NoError(t, err)
 
-	charlie, err := ctypes.NewMultiAddressFromHexAccountID(
-		keyRing.KeyCharlie.Public().Hex())
-	require.NoError(t, err)
-
-	call, err := ctypes.NewCall(meta, "Balances.transfer", charlie, ctypes.NewUCompactFromUInt(^uint64(0)))
+	runtimeVersion, err := rt.Version()
 	require.NoError(t, err)
 
-	extrinsic := ctypes.NewExtrinsic(call)
-	genesisHash, err := ctypes.NewHashFromHexString(genesisHeader.Hash().String())
+	charlie := extrinsic.NewMultiAddressID(keyRing.KeyCharlie.Public())
+	call, err := extrinsicBuilder.NewCall("Balances.transfer", charlie, scale.NewCompact(^uint64(0)))
 	require.NoError(t, err)
 
-	so := ctypes.SignatureOptions{
-		BlockHash:          genesisHash,
-		Era:                ctypes.ExtrinsicEra{IsImmortalEra: true},
-		GenesisHash:        genesisHash,
-		Nonce:              ctypes.NewUCompactFromUInt(uint64(0)),
-		SpecVersion:        ctypes.U32(runtimeVersion.SpecVersion),
-		Tip:                ctypes.NewUCompactFromUInt(^uint64(0)),
-		TransactionVersion: ctypes.U32(runtimeVersion.TransactionVersion),
+	options := extrinsic.Options{
+		Tip:                ^uint64(0),
+		SpecVersion:        runtimeVersion.SpecVersion,
+		TransactionVersion: runtimeVersion.TransactionVersion,
+		GenesisHash:        genesisHeader.Hash(),
+		BlockHash:          genesisHeader.Hash(),
 	}
 
-	err = extrinsic.Sign(signature.TestKeyringPairAlice, so)
-	require.NoError(t, err)
-
-	extEnc := bytes.NewBuffer(nil)
-	encoder := cscale.NewEncoder(extEnc)
-	err = extrinsic.Encode(*encoder)
+	extEnc, err := extrinsicBuilder.Signed(call, keyRing.Alice(), options)
 	require.NoError(t, err)
 
-	res, err := rt.ApplyExtrinsic(extEnc.Bytes())
+	res, err := rt.ApplyExtrinsic(extEnc)
 	require.NoError(t, err)
 
 	err = determineErr(res)
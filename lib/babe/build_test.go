@@ -0,0 +1,132 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package babe
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/babe/mocks"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/transaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_BlockBuilder_buildBlockExtrinsics_spans(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	validTxn := &transaction.ValidTransaction{Extrinsic: types.Extrinsic{1}}
+	invalidTxn := &transaction.ValidTransaction{Extrinsic: types.Extrinsic{2}}
+	errTest := errors.New("test error")
+
+	transactionState := NewMockTransactionState(ctrl)
+	gomock.InOrder(
+		transactionState.EXPECT().PopWithTimer(gomock.Any()).Return(validTxn),
+		transactionState.EXPECT().PopWithTimer(gomock.Any()).Return(invalidTxn),
+		transactionState.EXPECT().PopWithTimer(gomock.Any()).Return(nil),
+	)
+	transactionState.EXPECT().ReportInvalid(invalidTxn.Extrinsic).Return(false)
+
+	runtime := mocks.NewMockInstance(ctrl)
+	runtime.EXPECT().ApplyExtrinsic(validTxn.Extrinsic).Return([]byte{0, 0}, nil)
+	runtime.EXPECT().ApplyExtrinsic(invalidTxn.Extrinsic).Return(nil, errTest)
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	builder := &BlockBuilder{
+		transactionState: transactionState,
+		tracer:           provider.Tracer(tracerName),
+	}
+
+	slot := Slot{start: time.Now(), duration: time.Second}
+	included := builder.buildBlockExtrinsics(context.Background(), slot, runtime, 0)
+	assert.Equal(t, []*transaction.ValidTransaction{validTxn}, included)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "apply_extrinsic", spans[0].Name())
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Contains(t, spans[0].Attributes(), attribute.String("hash", validTxn.Extrinsic.Hash().String()))
+
+	assert.Equal(t, "apply_extrinsic", spans[1].Name())
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, errTest.Error(), spans[1].Status().Description)
+}
+
+func Test_BlockBuilder_buildBlockExtrinsics_blockLength(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	newTransaction := func(length int) *transaction.ValidTransaction {
+		return &transaction.ValidTransaction{Extrinsic: make(types.Extrinsic, length)}
+	}
+	fitting, tooLong, last := newTransaction(30), newTransaction(31), newTransaction(10)
+	skipped := make([]*transaction.ValidTransaction, maxSkippedExtrinsics-1)
+	for i := range skipped {
+		skipped[i] = newTransaction(100 + i)
+	}
+
+	// the extrinsics exceeding the block length are left in the queue, and the block is full
+	// once too many extrinsics are skipped
+	transactionState := NewMockTransactionState(ctrl)
+	calls := []any{
+		transactionState.EXPECT().PopWithTimer(gomock.Any()).Return(fitting),
+		transactionState.EXPECT().PopWithTimer(gomock.Any()).Return(tooLong),
+		transactionState.EXPECT().PopWithTimer(gomock.Any()).Return(last),
+	}
+	for _, txn := range skipped {
+		calls = append(calls, transactionState.EXPECT().PopWithTimer(gomock.Any()).Return(txn))
+	}
+	gomock.InOrder(calls...)
+	for _, txn := range append([]*transaction.ValidTransaction{tooLong}, skipped...) {
+		transactionState.EXPECT().Push(txn).Return(common.Hash{}, nil)
+	}
+
+	runtime := mocks.NewMockInstance(ctrl)
+	runtime.EXPECT().ApplyExtrinsic(fitting.Extrinsic).Return([]byte{0, 0}, nil)
+	runtime.EXPECT().ApplyExtrinsic(last.Extrinsic).Return([]byte{0, 0}, nil)
+
+	builder := &BlockBuilder{
+		transactionState: transactionState,
+		tracer:           noop.NewTracerProvider().Tracer(tracerName),
+		limits:           &blockLimits{maxLength: [3]uint32{100, 200, 200}},
+	}
+
+	slot := Slot{start: time.Now(), duration: time.Second}
+	included := builder.buildBlockExtrinsics(context.Background(), slot, runtime, 60)
+	assert.Equal(t, []*transaction.ValidTransaction{fitting, last}, included)
+}
+
+func Test_BlockBuilder_startPhase(t *testing.T) {
+	t.Parallel()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	builder := &BlockBuilder{tracer: provider.Tracer(tracerName)}
+
+	ctx, parent := provider.Tracer(tracerName).Start(context.Background(), "build_block")
+	endPhase := builder.startPhase(ctx, phaseFinalize)
+	endPhase(errors.New("test error"))
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, phaseFinalize, spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	require.Len(t, spans[0].Events(), 1)
+	assert.Equal(t, "exception", spans[0].Events()[0].Name)
+}
@@ -7,8 +7,11 @@
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/watchdog"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 )
 
@@ -25,6 +28,8 @@ type epochHandler struct {
 	slotToPreRuntimeDigest map[uint64]*types.PreRuntimeDigest
 
 	handleSlot handleSlotFunc
+	// heartbeat records the slots handled, if set
+	heartbeat *watchdog.Heartbeat
 }
 
 func newEpochHandler(epochDescriptor *epochDescriptor, constants constants,
@@ -55,6 +60,59 @@ func newEpochHandler(epochDescriptor *epochDescriptor, constants constants,
 	}, nil
 }
 
+// newEpochHandlerFromSlotClaims returns the epoch handler of slot claims persisted by a previous
+// run of the service, without running the slot lottery again
+func newEpochHandlerFromSlotClaims(claims *types.EpochSlotClaims, constants constants,
+	handleSlot handleSlotFunc) *epochHandler {
+	slotToPreRuntimeDigest := make(map[uint64]*types.PreRuntimeDigest, len(claims.Claims))
+	for i := range claims.Claims {
+		slotToPreRuntimeDigest[claims.Claims[i].Slot] = &claims.Claims[i].PreRuntimeDigest
+	}
+
+	return &epochHandler{
+		slotHandler: newSlotHandler(constants.slotDuration),
+		descriptor: &epochDescriptor{
+			data: &epochData{
+				randomness:     claims.Randomness,
+				authorityIndex: claims.AuthorityIndex,
+				authorities:    claims.Authorities,
+				threshold:      claims.Threshold,
+				allowedSlots:   claims.AllowedSlots,
+			},
+			epoch:     claims.Epoch,
+			startSlot: claims.StartSlot,
+			endSlot:   claims.EndSlot,
+		},
+		constants:              constants,
+		handleSlot:             handleSlot,
+		slotToPreRuntimeDigest: slotToPreRuntimeDigest,
+	}
+}
+
+// slotClaims returns the slot claims of the epoch handler, ordered by slot
+func (h *epochHandler) slotClaims() *types.EpochSlotClaims {
+	claims := &types.EpochSlotClaims{
+		Epoch:          h.descriptor.epoch,
+		StartSlot:      h.descriptor.startSlot,
+		EndSlot:        h.descriptor.endSlot,
+		Randomness:     h.descriptor.data.randomness,
+		Authorities:    h.descriptor.data.authorities,
+		AuthorityIndex: h.descriptor.data.authorityIndex,
+		Threshold:      h.descriptor.data.threshold,
+		AllowedSlots:   h.descriptor.data.allowedSlots,
+		Claims:         make([]types.SlotClaim, 0, len(h.slotToPreRuntimeDigest)),
+	}
+
+	for _, slot := range slices.Sorted(maps.Keys(h.slotToPreRuntimeDigest)) {
+		claims.Claims = append(claims.Claims, types.SlotClaim{
+			Slot:             slot,
+			PreRuntimeDigest: *h.slotToPreRuntimeDigest[slot],
+		})
+	}
+
+	return claims
+}
+
 // run executes the block production for each available successfully claimed slot
 // it is important to note that any error will be transmitted through errCh
 func (h *epochHandler) run(ctx context.Context, errCh chan<- error) {
@@ -79,6 +137,9 @@ func (h *epochHandler) run(ctx context.Context, errCh chan<- error) {
 			errCh <- err
 			return
 		}
+		if h.heartbeat != nil {
+			h.heartbeat.Beat()
+		}
 
 		// check if the slot is an authoring slot otherwise wait for the next slot
 		preRuntimeDigest, has := h.slotToPreRuntimeDigest[currentSlot.number]
@@ -93,6 +154,9 @@ func (h *epochHandler) run(ctx context.Context, errCh chan<- error) {
 			preRuntimeDigest)
 		if err != nil {
 			logger.Warnf("failed to handle slot %d: %s", currentSlot.number, err)
+			if h.heartbeat != nil {
+				h.heartbeat.Fail(fmt.Errorf("handling slot %d: %w", currentSlot.number, err))
+			}
 		}
 	}
 }
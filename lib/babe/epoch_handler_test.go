@@ -52,3 +52,50 @@ func TestNewEpochHandler(t *testing.T) {
 	require.Equal(t, epochData, epochHandler.descriptor.data)
 	require.NotNil(t, epochHandler.handleSlot)
 }
+
+func TestEpochHandler_slotClaims(t *testing.T) {
+	testHandleSlotFunc := func(epoch uint64, slot Slot, authorityIndex uint32,
+		preRuntimeDigest *types.PreRuntimeDigest,
+	) error {
+		return nil
+	}
+
+	testConstants := constants{
+		slotDuration: 6 * time.Second,
+		epochLength:  200,
+	}
+
+	keypair := keyring.Alice().(*sr25519.Keypair)
+	epochDescriptor := &epochDescriptor{
+		data: &epochData{
+			randomness:   Randomness{1},
+			authorities:  []types.AuthorityRaw{{Key: keypair.Public().(*sr25519.PublicKey).AsBytes(), Weight: 1}},
+			threshold:    scale.MaxUint128,
+			allowedSlots: types.PrimaryAndSecondaryPlainSlots,
+		},
+		epoch:     2,
+		startSlot: 400,
+		endSlot:   600,
+	}
+
+	epochHandler, err := newEpochHandler(epochDescriptor, testConstants, testHandleSlotFunc, keypair)
+	require.NoError(t, err)
+
+	claims := epochHandler.slotClaims()
+	require.Len(t, claims.Claims, 200)
+	require.Equal(t, uint64(400), claims.Claims[0].Slot)
+	require.Equal(t, uint64(599), claims.Claims[199].Slot)
+
+	// the slot claims are persisted encoded, so they are decoded before being resumed
+	encoded, err := scale.Marshal(*claims)
+	require.NoError(t, err)
+	decoded := new(types.EpochSlotClaims)
+	err = scale.Unmarshal(encoded, decoded)
+	require.NoError(t, err)
+
+	resumed := newEpochHandlerFromSlotClaims(decoded, testConstants, testHandleSlotFunc)
+	require.Equal(t, epochHandler.descriptor, resumed.descriptor)
+	require.Equal(t, epochHandler.slotToPreRuntimeDigest, resumed.slotToPreRuntimeDigest)
+	require.Equal(t, testConstants, resumed.constants)
+	require.NotNil(t, resumed.handleSlot)
+}
@@ -7,6 +7,7 @@
 	"testing"
 
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/crypto"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 
@@ -141,3 +142,52 @@ func TestBabeService_getEpochDataAndStartSlot(t *testing.T) {
 		})
 	}
 }
+
+func TestService_selectKeypair(t *testing.T) {
+	t.Parallel()
+
+	alice := keyring.Alice().(*sr25519.Keypair)
+	bob := keyring.Bob().(*sr25519.Keypair)
+
+	ks := keystore.NewBasicKeystore(keystore.BabeName, crypto.Sr25519Type)
+	require.NoError(t, ks.Insert(alice))
+	require.NoError(t, ks.Insert(bob))
+
+	testCases := map[string]struct {
+		authorities []types.AuthorityRaw
+		expected    *sr25519.Keypair
+	}{
+		"current_keypair_is_authority": {
+			authorities: []types.AuthorityRaw{
+				*types.NewAuthority(bob.Public(), 1).ToRaw(),
+				*types.NewAuthority(alice.Public(), 1).ToRaw(),
+			},
+			expected: alice,
+		},
+		"switch_to_keystore_keypair": {
+			authorities: []types.AuthorityRaw{*types.NewAuthority(bob.Public(), 1).ToRaw()},
+			expected:    bob,
+		},
+		"no_keystore_keypair_is_authority": {
+			authorities: []types.AuthorityRaw{
+				*types.NewAuthority(keyring.Charlie().Public(), 1).ToRaw(),
+			},
+			expected: alice,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			service := &Service{
+				keypair:  alice,
+				keystore: ks,
+			}
+
+			service.selectKeypair(testCase.authorities)
+			require.Equal(t, testCase.expected, service.keypair)
+		})
+	}
+}
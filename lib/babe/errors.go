@@ -69,11 +69,14 @@
 	errNotOurTurnToPropose        = errors.New("cannot claim slot, not our turn to propose a block")
 	errMissingDigestItems         = errors.New("block header is missing digest items")
 	errServicePaused              = errors.New("service paused")
+	errInvalidSealMode            = errors.New("invalid seal mode")
 	errInvalidSlotTechnique       = errors.New("invalid slot claiming technique")
 	errNoBABEAuthorityKeyProvided = errors.New("cannot create BABE service as authority; no keypair provided")
 	errLastDigestItemNotSeal      = errors.New("last digest item is not seal")
 	errLaggingSlot                = errors.New("current slot is smaller than slot of best block")
 	errNoDigest                   = errors.New("no digest provided")
+	errStaleSlotClaims            = errors.New("slot claims are stale")
+	errInvalidBlockLimits         = errors.New("invalid block limits")
 )
 
 // A DispatchOutcomeError is outcome of dispatching the extrinsic
@@ -403,7 +403,7 @@ type committedCandidateReceipt struct {
 type uncheckedSignedAvailabilityBitfield struct {
 	// The payload is part of the signed data. The rest is the signing context,
 	// which is known both at signing and at validation.
-	Payload []byte `scale:"1"`
+	Payload scale.BitVec `scale:"1"`
 	// The index of the validator signing this statement.
 	ValidatorIndex uint32 `scale:"2"`
 	// The signature by the validator of the signed payload.
@@ -417,7 +417,7 @@ type backedCandidate struct {
 	// The validity votes themselves, expressed as signatures.
 	ValidityVotes []validityAttestation `scale:"2"`
 	// The indices of the validators within the group, expressed as a bitfield.
-	ValidatorIndices []byte `scale:"3"`
+	ValidatorIndices scale.BitVec `scale:"3"`
 }
 
 // multiDisputeStatementSet is a set of dispute statements.
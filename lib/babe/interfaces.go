@@ -13,6 +13,7 @@
 type Runtime interface {
 	BlockHandler
 	ExtrinsicHandler
+	MetadataHandler
 }
 
 // BlockHandler handles block initialisation and finalisation.
@@ -27,6 +28,11 @@ type ExtrinsicHandler interface {
 	ApplyExtrinsic(data types.Extrinsic) ([]byte, error)
 }
 
+// MetadataHandler returns the runtime metadata.
+type MetadataHandler interface {
+	Metadata() ([]byte, error)
+}
+
 // Telemetry is the telemetry client to send telemetry messages.
 type Telemetry interface {
 	SendMessage(msg json.Marshaler)
@@ -0,0 +1,119 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package babe
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// maxSkippedExtrinsics is the number of extrinsics not fitting in the block skipped before
+// the block is considered full, the next extrinsics of the queue being likely too long too
+const maxSkippedExtrinsics = 8
+
+// dispatch classes of the extrinsics, indexing the limits of each class
+const (
+	dispatchClassNormal = iota
+	dispatchClassOperational
+	dispatchClassMandatory
+)
+
+// blockLimits are the limits the runtime enforces on the blocks, read from the
+// frame_system limits constants of the runtime metadata.
+type blockLimits struct {
+	// maxLength is the maximum length of all the encoded extrinsics of a block when
+	// including an extrinsic of each dispatch class
+	maxLength [3]uint32
+}
+
+// newBlockLimits reads the block limits from the System.BlockLength constant of the
+// metadata of the runtime given. The constant is decoded with the types of the metadata,
+// so the limits are read whatever the runtime version.
+func newBlockLimits(rt MetadataHandler) (*blockLimits, error) {
+	encodedMetadata, err := rt.Metadata()
+	if err != nil {
+		return nil, fmt.Errorf("getting metadata: %w", err)
+	}
+
+	var metadata []byte
+	err = scale.Unmarshal(encodedMetadata, &metadata)
+	if err != nil {
+		return nil, fmt.Errorf("decoding metadata: %w", err)
+	}
+
+	registry, err := scale.NewRegistry(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("parsing metadata: %w", err)
+	}
+
+	constant, err := registry.Constant("System", "BlockLength")
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := registry.Decode(constant.Type, constant.Value)
+	if err != nil {
+		return nil, fmt.Errorf("decoding block length: %w", err)
+	}
+
+	blockLength, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: block length %v", errInvalidBlockLimits, value)
+	}
+	perClass, ok := blockLength["max"].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: max block length %v", errInvalidBlockLimits, blockLength["max"])
+	}
+
+	limits := &blockLimits{}
+	for class, name := range map[int]string{
+		dispatchClassNormal:      "normal",
+		dispatchClassOperational: "operational",
+		dispatchClassMandatory:   "mandatory",
+	} {
+		limits.maxLength[class], ok = perClass[name].(uint32)
+		if !ok {
+			return nil, fmt.Errorf("%w: max %s block length %v", errInvalidBlockLimits, name, perClass[name])
+		}
+	}
+	return limits, nil
+}
+
+// fits returns true if an extrinsic of the dispatch class and encoded length given can be
+// included in a block whose extrinsics have the encoded length given. Nil limits are no limits.
+func (bl *blockLimits) fits(class int, blockLength, length uint32) bool {
+	if bl == nil {
+		return true
+	}
+	return uint64(blockLength)+uint64(length) <= uint64(bl.maxLength[class])
+}
+
+// blockLimitsCache holds the block limits of the runtime the last block was built with,
+// since the runtime rarely changes between the blocks built.
+type blockLimitsCache struct {
+	mu      sync.Mutex
+	runtime Runtime
+	limits  *blockLimits
+}
+
+// get returns the block limits of the runtime given, or nil if they cannot be read, in
+// which case blocks are built relying on the runtime rejecting the extrinsics not fitting.
+func (c *blockLimitsCache) get(rt Runtime) *blockLimits {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.runtime == rt {
+		return c.limits
+	}
+
+	limits, err := newBlockLimits(rt)
+	if err != nil {
+		logger.Warnf("cannot read the block limits of the runtime, building blocks without them: %s", err)
+	}
+	c.runtime = rt
+	c.limits = limits
+	return limits
+}
@@ -0,0 +1,112 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package babe
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/babe/mocks"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// testBlockLengthMetadata is a SCALE encoded runtime metadata V15 with a System pallet,
+// whose BlockLength constant has a max length of 3932160 bytes for the normal class and
+// 5242880 bytes for the operational and mandatory classes
+const testBlockLengthMetadata = "0x" +
+	"6d6574610f0c000000050500040c346672616d655f737570706f72742064697370617463684050657244697370617463" +
+	"68436c61737300000c01186e6f726d616c000000012c6f7065726174696f6e616c00000001246d616e6461746f727900" +
+	"000000080c306672616d655f73797374656d186c696d6974732c426c6f636b4c656e677468000004010c6d6178040000" +
+	"00041853797374656d000000042c426c6f636b4c656e677468083000003c000000500000005000000000000400000000" +
+	"0000"
+
+func Test_newBlockLimits(t *testing.T) {
+	t.Parallel()
+
+	encodedMetadata, err := scale.Marshal(common.MustHexToBytes(testBlockLengthMetadata))
+	require.NoError(t, err)
+	errTest := errors.New("test error")
+
+	testCases := map[string]struct {
+		metadata    []byte
+		metadataErr error
+		limits      *blockLimits
+		errWrapped  error
+		errMessage  string
+	}{
+		"block_length": {
+			metadata: encodedMetadata,
+			limits:   &blockLimits{maxLength: [3]uint32{3932160, 5242880, 5242880}},
+		},
+		"metadata_error": {
+			metadataErr: errTest,
+			errWrapped:  errTest,
+			errMessage:  "getting metadata: test error",
+		},
+		"metadata_version_not_supported": {
+			metadata:   scale.MustMarshal([]byte("meta\x0d")),
+			errWrapped: scale.ErrMetadataVersion,
+			errMessage: "parsing metadata: metadata version not supported: 13",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			runtime := mocks.NewMockInstance(ctrl)
+			runtime.EXPECT().Metadata().Return(testCase.metadata, testCase.metadataErr)
+
+			limits, err := newBlockLimits(runtime)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.limits, limits)
+		})
+	}
+}
+
+func Test_blockLimits_fits(t *testing.T) {
+	t.Parallel()
+
+	var noLimits *blockLimits
+	assert.True(t, noLimits.fits(dispatchClassNormal, 1000, 1000))
+
+	limits := &blockLimits{maxLength: [3]uint32{100, 200, 300}}
+	assert.True(t, limits.fits(dispatchClassNormal, 60, 40))
+	assert.False(t, limits.fits(dispatchClassNormal, 60, 41))
+	assert.True(t, limits.fits(dispatchClassOperational, 60, 140))
+	assert.False(t, limits.fits(dispatchClassMandatory, 1<<31, 1<<31))
+}
+
+func Test_blockLimitsCache_get(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	encodedMetadata, err := scale.Marshal(common.MustHexToBytes(testBlockLengthMetadata))
+	require.NoError(t, err)
+
+	cache := &blockLimitsCache{}
+
+	// the limits are read once for the runtime
+	runtime := mocks.NewMockInstance(ctrl)
+	runtime.EXPECT().Metadata().Return(encodedMetadata, nil)
+	limits := cache.get(runtime)
+	expected := &blockLimits{maxLength: [3]uint32{3932160, 5242880, 5242880}}
+	assert.Equal(t, expected, limits)
+	assert.Same(t, limits, cache.get(runtime))
+
+	// the blocks are built without limits if the limits of the runtime cannot be read
+	otherRuntime := mocks.NewMockInstance(ctrl)
+	otherRuntime.EXPECT().Metadata().Return(nil, errors.New("test error"))
+	assert.Nil(t, cache.get(otherRuntime))
+	assert.Nil(t, cache.get(otherRuntime))
+}
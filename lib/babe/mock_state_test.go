@@ -422,6 +422,20 @@ func (mr *MockTransactionStateMockRecorder) Push(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockTransactionState)(nil).Push), arg0)
 }
 
+// ReportInvalid mocks base method.
+func (m *MockTransactionState) ReportInvalid(arg0 types.Extrinsic) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportInvalid", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// ReportInvalid indicates an expected call of ReportInvalid.
+func (mr *MockTransactionStateMockRecorder) ReportInvalid(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportInvalid", reflect.TypeOf((*MockTransactionState)(nil).ReportInvalid), arg0)
+}
+
 // MockEpochState is a mock of EpochState interface.
 type MockEpochState struct {
 	ctrl     *gomock.Controller
@@ -549,6 +563,21 @@ func (mr *MockEpochStateMockRecorder) GetSkippedEpochDataRaw(arg0, arg1, arg2 an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSkippedEpochDataRaw", reflect.TypeOf((*MockEpochState)(nil).GetSkippedEpochDataRaw), arg0, arg1, arg2)
 }
 
+// GetSlotClaims mocks base method.
+func (m *MockEpochState) GetSlotClaims() (*types.EpochSlotClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSlotClaims")
+	ret0, _ := ret[0].(*types.EpochSlotClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSlotClaims indicates an expected call of GetSlotClaims.
+func (mr *MockEpochStateMockRecorder) GetSlotClaims() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSlotClaims", reflect.TypeOf((*MockEpochState)(nil).GetSlotClaims))
+}
+
 // GetSlotDuration mocks base method.
 func (m *MockEpochState) GetSlotDuration() (time.Duration, error) {
 	m.ctrl.T.Helper()
@@ -608,6 +637,20 @@ func (mr *MockEpochStateMockRecorder) StoreCurrentEpoch(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreCurrentEpoch", reflect.TypeOf((*MockEpochState)(nil).StoreCurrentEpoch), arg0)
 }
 
+// StoreSlotClaims mocks base method.
+func (m *MockEpochState) StoreSlotClaims(arg0 *types.EpochSlotClaims) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StoreSlotClaims", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StoreSlotClaims indicates an expected call of StoreSlotClaims.
+func (mr *MockEpochStateMockRecorder) StoreSlotClaims(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreSlotClaims", reflect.TypeOf((*MockEpochState)(nil).StoreSlotClaims), arg0)
+}
+
 // MockBlockImportHandler is a mock of BlockImportHandler interface.
 type MockBlockImportHandler struct {
 	ctrl     *gomock.Controller
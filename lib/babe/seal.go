@@ -0,0 +1,157 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package babe
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+)
+
+// SealMode is the development authoring mode of the service, replacing the
+// authoring of blocks at the slots claimed in each epoch.
+type SealMode string
+
+const (
+	// SlotSeal authors blocks at the slots claimed in each epoch, which is the default
+	SlotSeal SealMode = ""
+	// InstantSeal authors a block as soon as a transaction enters the transaction queue
+	InstantSeal SealMode = "instant"
+	// ManualSeal authors a block only when SealBlock is called
+	ManualSeal SealMode = "manual"
+)
+
+const (
+	// sealSlotDuration is the slot duration given to the block builder when sealing a
+	// block, so the transactions of the queue are applied without waiting for a whole slot.
+	sealSlotDuration = 150 * time.Millisecond
+	// instantSealPollInterval is the interval at which the instant seal mode checks
+	// the service is still running while waiting for transactions.
+	instantSealPollInterval = 500 * time.Millisecond
+)
+
+var errSlotSealMode = errors.New("blocks are only authored at claimed slots")
+
+// IsValid returns true if the seal mode is known
+func (m SealMode) IsValid() bool {
+	switch m {
+	case SlotSeal, InstantSeal, ManualSeal:
+		return true
+	default:
+		return false
+	}
+}
+
+// runInstantSeal seals a block each time a transaction enters the transaction
+// queue, until the service is paused or stopped.
+func (b *Service) runInstantSeal() {
+	for {
+		select {
+		case <-b.ctx.Done():
+			return
+		case <-b.pause:
+			return
+		default:
+		}
+
+		txn := b.transactionState.PopWithTimer(time.After(instantSealPollInterval))
+		if txn == nil {
+			continue
+		}
+
+		// the transaction is pushed back so it is applied when building the block
+		_, err := b.transactionState.Push(txn)
+		if err != nil {
+			logger.Warnf("failed to push back transaction to seal: %s", err)
+			continue
+		}
+
+		_, err = b.SealBlock()
+		if err != nil {
+			logger.Errorf("failed to seal block: %s", err)
+
+			select {
+			case <-b.ctx.Done():
+				return
+			case <-time.After(instantSealPollInterval):
+			}
+		}
+	}
+}
+
+// SealBlock authors a block on top of the best block with the transactions of
+// the transaction queue, at the first slot we can claim after both the current
+// slot and the slot of the best block. It is only available in the instant and
+// manual seal modes, which author blocks on demand instead of at claimed slots.
+func (b *Service) SealBlock() (*types.Block, error) {
+	if !b.authority {
+		return nil, ErrNotAuthority
+	}
+	if b.sealMode == SlotSeal {
+		return nil, errSlotSealMode
+	}
+	if b.IsPaused() {
+		return nil, errServicePaused
+	}
+
+	b.sealLock.Lock()
+	defer b.sealLock.Unlock()
+
+	bestBlockHeader, err := b.blockState.BestBlockHeader()
+	if err != nil {
+		return nil, fmt.Errorf("getting best block header: %w", err)
+	}
+
+	parent, err := bestBlockHeader.DeepCopy()
+	if err != nil {
+		return nil, fmt.Errorf("copying best block header: %w", err)
+	}
+
+	startSlot := getCurrentSlot(b.constants.slotDuration)
+	if parent.Hash() != b.blockState.GenesisHash() {
+		parentSlot, err := b.blockState.GetSlotForBlock(parent.Hash())
+		if err != nil {
+			return nil, fmt.Errorf("getting slot for best block: %w", err)
+		}
+
+		if parentSlot >= startSlot {
+			startSlot = parentSlot + 1
+		}
+	}
+
+	epoch, err := b.epochState.GetEpochForBlock(parent)
+	if err != nil {
+		return nil, fmt.Errorf("getting epoch for best block: %w", err)
+	}
+
+	descriptor, err := b.initiateEpoch(epoch)
+	if err != nil {
+		return nil, fmt.Errorf("initiating epoch %d: %w", epoch, err)
+	}
+
+	for slotNumber := startSlot; slotNumber < startSlot+b.constants.epochLength; slotNumber++ {
+		for slotNumber >= descriptor.endSlot {
+			epoch++
+			descriptor, err = b.initiateEpoch(epoch)
+			if err != nil {
+				return nil, fmt.Errorf("initiating epoch %d: %w", epoch, err)
+			}
+		}
+
+		preRuntimeDigest, err := claimSlot(epoch, slotNumber, descriptor.data, b.keypair)
+		if errors.Is(err, errOverPrimarySlotThreshold) || errors.Is(err, errNotOurTurnToPropose) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("claiming slot %d: %w", slotNumber, err)
+		}
+
+		slot := NewSlot(getSlotStartTime(slotNumber, b.constants.slotDuration), sealSlotDuration, slotNumber)
+		return b.produceBlock(epoch, parent, *slot, descriptor.data.authorityIndex, preRuntimeDigest)
+	}
+
+	return nil, fmt.Errorf("%w: in the %d slots after slot %d",
+		errNotOurTurnToPropose, b.constants.epochLength, startSlot)
+}
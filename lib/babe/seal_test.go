@@ -0,0 +1,61 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package babe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSealMode_IsValid(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, SlotSeal.IsValid())
+	assert.True(t, InstantSeal.IsValid())
+	assert.True(t, ManualSeal.IsValid())
+	assert.False(t, SealMode("other").IsValid())
+}
+
+func TestService_SealBlock(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		service    *Service
+		errWrapped error
+	}{
+		"not_authority": {
+			service:    &Service{sealMode: ManualSeal},
+			errWrapped: ErrNotAuthority,
+		},
+		"slot_seal_mode": {
+			service:    &Service{authority: true},
+			errWrapped: errSlotSealMode,
+		},
+		"paused": {
+			service: &Service{
+				authority: true,
+				sealMode:  ManualSeal,
+				pause: func() chan struct{} {
+					pause := make(chan struct{})
+					close(pause)
+					return pause
+				}(),
+			},
+			errWrapped: errServicePaused,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			block, err := testCase.service.SealBlock()
+			require.ErrorIs(t, err, testCase.errWrapped)
+			assert.Nil(t, block)
+		})
+	}
+}
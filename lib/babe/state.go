@@ -9,6 +9,7 @@
 
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	"github.com/ChainSafe/gossamer/lib/transaction"
@@ -53,6 +54,7 @@ type StorageState interface {
 type TransactionState interface {
 	Push(vt *transaction.ValidTransaction) (common.Hash, error)
 	PopWithTimer(timerCh <-chan time.Time) (tx *transaction.ValidTransaction)
+	ReportInvalid(ext types.Extrinsic) (banned bool)
 }
 
 // EpochState is the interface for epoch methods
@@ -73,9 +75,23 @@ type EpochState interface {
 	GetStartSlotForEpoch(epoch uint64, bestBlockHash common.Hash) (uint64, error)
 	GetEpochForBlock(header *types.Header) (uint64, error)
 	SkipVerify(*types.Header) (bool, error)
+
+	StoreSlotClaims(claims *types.EpochSlotClaims) error
+	GetSlotClaims() (*types.EpochSlotClaims, error)
 }
 
 // BlockImportHandler is the interface for the handler of new blocks
 type BlockImportHandler interface {
 	HandleBlockProduced(block *types.Block, state *rtstorage.TrieState) error
 }
+
+// ClockGuard is the interface of the guard of the local clock against the network time
+type ClockGuard interface {
+	ObserveBlock(slot uint64)
+	CheckAuthoring() error
+}
+
+// Keystore is the interface of the keystore holding the BABE authority keys
+type Keystore interface {
+	Keypairs() []keystore.KeyPair
+}
@@ -6,10 +6,12 @@
 import (
 	"errors"
 	"fmt"
+	goruntime "runtime"
 	"sync"
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 	"github.com/ChainSafe/gossamer/pkg/scale"
@@ -17,13 +19,25 @@
 
 var errEmptyKeyOwnershipProof = errors.New("key ownership proof is nil")
 
+// verifierInfoCacheEpochs is the number of epochs, before the latest one,
+// for which the verifier info is kept in the cache
+const verifierInfoCacheEpochs = 2
+
 // verifierInfo contains the information needed to verify blocks
 // it remains the same for an epoch
 type verifierInfo struct {
-	authorities    []types.AuthorityRaw
-	randomness     Randomness
-	threshold      *scale.Uint128
-	secondarySlots bool
+	authorities  []types.AuthorityRaw
+	randomness   Randomness
+	threshold    *scale.Uint128
+	allowedSlots types.AllowedSlots
+}
+
+// forkVerifierInfo is the verifier info of an epoch on the fork of the chain descending from
+// the anchor block. The data of the epoch is announced by an ancestor of the anchor block, so
+// the verifier info is the same for every block descending from it.
+type forkVerifierInfo struct {
+	anchor common.Hash
+	info   *verifierInfo
 }
 
 // onDisabledInfo contains information about an authority that's been disabled at a certain
@@ -41,11 +55,16 @@ type VerificationManager struct {
 	blockState BlockState
 	slotState  SlotState
 	epochState EpochState
-	epochInfo  map[uint64]*verifierInfo // map of epoch number -> info needed for verification
+	// the epoch data may differ between the branches of the chain,
+	// so we keep track of the info of each branch verified.
+	// map of epoch number -> info needed for verification on each branch
+	epochInfo map[uint64][]*forkVerifierInfo
 	// there may be different OnDisabled digests on different
 	// branches of the chain, so we need to keep track of all of them.
 	// map of epoch number -> block producer index -> block number and hash
 	onDisabled map[uint64]map[uint32][]*onDisabledInfo
+	// clockGuard samples the arrival of the blocks verified, if set
+	clockGuard ClockGuard
 }
 
 // NewVerificationManager returns a new NewVerificationManager
@@ -54,11 +73,16 @@ func NewVerificationManager(blockState BlockState, slotState SlotState, epochSta
 		epochState: epochState,
 		slotState:  slotState,
 		blockState: blockState,
-		epochInfo:  make(map[uint64]*verifierInfo),
+		epochInfo:  make(map[uint64][]*forkVerifierInfo),
 		onDisabled: make(map[uint64]map[uint32][]*onDisabledInfo),
 	}
 }
 
+// SetClockGuard sets the clock guard sampling the arrival of the blocks verified
+func (v *VerificationManager) SetClockGuard(clockGuard ClockGuard) {
+	v.clockGuard = clockGuard
+}
+
 // SetOnDisabled sets the BABE authority with the given index as disabled for the rest of the epoch
 func (v *VerificationManager) SetOnDisabled(index uint32, header *types.Header) error {
 	epoch, err := v.epochState.GetEpochForBlock(header)
@@ -69,17 +93,13 @@ func (v *VerificationManager) SetOnDisabled(index uint32, header *types.Header)
 	v.lock.Lock()
 	defer v.lock.Unlock()
 
-	if _, has := v.epochInfo[epoch]; !has {
-		info, err := v.getVerifierInfo(epoch, header)
-		if err != nil {
-			return err
-		}
-
-		v.epochInfo[epoch] = info
+	info, err := v.cachedVerifierInfo(epoch, header.ParentHash, header)
+	if err != nil {
+		return err
 	}
 
 	// check that index is valid
-	if index >= uint32(len(v.epochInfo[epoch].authorities)) { //nolint:gosec
+	if index >= uint32(len(info.authorities)) { //nolint:gosec
 		return ErrInvalidBlockProducerIndex
 	}
 
@@ -145,13 +165,9 @@ func (v *VerificationManager) VerifyBlock(header *types.Header) error {
 			return fmt.Errorf("getting epoch for parent header: %w", err)
 		}
 
-		if parentEpoch > currentBlockEpoch {
-			return fmt.Errorf("%w: expected epoch greater than parent block epoch %d, got: %d",
-				errEpochLowerThanExpected, parentEpoch, currentBlockEpoch)
-		}
-
-		if currentBlockEpoch > (parentEpoch + 1) {
-			epochWhereDataDescriptorIs = parentEpoch + 1
+		epochWhereDataDescriptorIs, err = dataDescriptorEpoch(currentBlockEpoch, parentEpoch)
+		if err != nil {
+			return err
 		}
 	}
 
@@ -160,13 +176,204 @@ func (v *VerificationManager) VerifyBlock(header *types.Header) error {
 		return fmt.Errorf("getting current slot duration: %w", err)
 	}
 
-	info, err := v.getVerifierInfo(epochWhereDataDescriptorIs, header)
+	v.lock.Lock()
+	info, err := v.cachedVerifierInfo(epochWhereDataDescriptorIs, header.ParentHash, header)
+	v.lock.Unlock()
 	if err != nil {
 		return fmt.Errorf("getting verifier info: %w", err)
 	}
 
 	verifier := newVerifier(v.blockState, v.slotState, currentBlockEpoch, info, slotDuration)
-	return verifier.verifyAuthorshipRight(header)
+	err = verifier.verifyAuthorshipRight(header)
+	if err != nil {
+		return err
+	}
+
+	if v.clockGuard != nil {
+		slot, err := header.SlotNumber()
+		if err != nil {
+			return fmt.Errorf("getting slot number: %w", err)
+		}
+		v.clockGuard.ObserveBlock(slot)
+	}
+
+	return nil
+}
+
+// VerifyBlocks verifies the authorship right of the longest prefix of the headers given forming
+// a chain on top of an imported block, and for which the epoch data is known before importing them,
+// that is the headers up to the end of the epoch following the epoch of the imported block.
+// The verifiers of the headers are resolved in order, then their seals and slot claims are verified
+// in parallel, and finally they are checked for equivocations in order.
+// It returns the number of headers verified, which is at least one if no error is returned.
+func (v *VerificationManager) VerifyBlocks(headers []*types.Header) (verified int, err error) {
+	if len(headers) == 0 {
+		return 0, nil
+	}
+
+	verifiers, err := v.batchVerifiers(headers)
+	if err != nil {
+		return 0, err
+	}
+	headers = headers[:len(verifiers)]
+
+	errs := make([]error, len(headers))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(goruntime.NumCPU(), len(headers)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				errs[i] = verifiers[i].verifySeal(headers[i])
+			}
+		}()
+	}
+
+	for i := range headers {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for i, header := range headers {
+		if errs[i] != nil {
+			return i, fmt.Errorf("verifying block #%d (%s): %w", header.Number, header.Hash(), errs[i])
+		}
+
+		err = verifiers[i].verifyEquivocation(header)
+		if err != nil {
+			return i, fmt.Errorf("verifying block #%d (%s): %w", header.Number, header.Hash(), err)
+		}
+	}
+
+	return len(headers), nil
+}
+
+// batchVerifiers returns the verifiers of the longest prefix of the headers given
+// which can be verified in a batch, as described in VerifyBlocks.
+func (v *VerificationManager) batchVerifiers(headers []*types.Header) ([]*verifier, error) {
+	anchor, err := v.blockState.GetHeader(headers[0].ParentHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting header: %w", err)
+	}
+
+	var anchorEpoch uint64
+	if anchor.Hash() != v.blockState.GenesisHash() {
+		anchorEpoch, err = v.epochState.GetEpochForBlock(anchor)
+		if err != nil {
+			return nil, fmt.Errorf("getting epoch for parent header: %w", err)
+		}
+	}
+
+	slotDuration, err := v.epochState.GetSlotDuration()
+	if err != nil {
+		return nil, fmt.Errorf("getting current slot duration: %w", err)
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	verifiers := make([]*verifier, 0, len(headers))
+	parentEpoch := anchorEpoch
+	for i, header := range headers {
+		if i > 0 && header.ParentHash != headers[i-1].Hash() {
+			break
+		}
+
+		epoch, err := v.epochState.GetEpochForBlock(header)
+		if err != nil {
+			if i > 0 {
+				// the header is verified again as the first header of the next batch
+				break
+			}
+			return nil, fmt.Errorf("getting epoch for block header: %w", err)
+		}
+
+		epochWhereDataDescriptorIs := epoch
+		if i > 0 || anchor.Hash() != v.blockState.GenesisHash() {
+			epochWhereDataDescriptorIs, err = dataDescriptorEpoch(epoch, parentEpoch)
+			if err != nil {
+				return nil, fmt.Errorf("block #%d (%s): %w", header.Number, header.Hash(), err)
+			}
+		}
+
+		// the epoch data is announced by a block of the previous epoch,
+		// which may be part of the batch and not imported yet
+		if i > 0 && epochWhereDataDescriptorIs > anchorEpoch+1 {
+			break
+		}
+
+		info, err := v.cachedVerifierInfo(epochWhereDataDescriptorIs, anchor.Hash(), anchor)
+		if err != nil {
+			return nil, fmt.Errorf("getting verifier info: %w", err)
+		}
+
+		verifiers = append(verifiers, newVerifier(v.blockState, v.slotState, epoch, info, slotDuration))
+		parentEpoch = epoch
+	}
+
+	return verifiers, nil
+}
+
+// dataDescriptorEpoch returns the epoch whose data is used to verify a block of the epoch given,
+// with its parent block in the parent epoch given. If epochs were skipped between the blocks,
+// the data of the epoch following the parent epoch is used.
+func dataDescriptorEpoch(epoch, parentEpoch uint64) (uint64, error) {
+	if parentEpoch > epoch {
+		return 0, fmt.Errorf("%w: expected epoch greater than parent block epoch %d, got: %d",
+			errEpochLowerThanExpected, parentEpoch, epoch)
+	}
+
+	if epoch > (parentEpoch + 1) {
+		return parentEpoch + 1, nil
+	}
+
+	return epoch, nil
+}
+
+// cachedVerifierInfo returns the verifier info of the epoch given on the fork of the chain descending
+// from the anchor block given, which must be a block of the previous epoch or a later one.
+// If it is not cached, it is retrieved using the header given and cached.
+// The verification manager lock must be held by the caller.
+func (v *VerificationManager) cachedVerifierInfo(epoch uint64, anchor common.Hash,
+	header *types.Header) (*verifierInfo, error) {
+	for _, forkInfo := range v.epochInfo[epoch] {
+		if forkInfo.anchor == anchor {
+			return forkInfo.info, nil
+		}
+
+		isDescendant, err := v.blockState.IsDescendantOf(forkInfo.anchor, anchor)
+		if err != nil {
+			// the anchor may belong to a pruned branch
+			if errors.Is(err, database.ErrNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("checking ancestry: %w", err)
+		}
+
+		if isDescendant {
+			return forkInfo.info, nil
+		}
+	}
+
+	info, err := v.getVerifierInfo(epoch, header)
+	if err != nil {
+		return nil, err
+	}
+
+	v.epochInfo[epoch] = append(v.epochInfo[epoch], &forkVerifierInfo{
+		anchor: anchor,
+		info:   info,
+	})
+
+	for cachedEpoch := range v.epochInfo {
+		if cachedEpoch+verifierInfoCacheEpochs < epoch {
+			delete(v.epochInfo, cachedEpoch)
+		}
+	}
+
+	return info, nil
 }
 
 func (v *VerificationManager) getVerifierInfo(epoch uint64, header *types.Header) (*verifierInfo, error) {
@@ -186,42 +393,68 @@ func (v *VerificationManager) getVerifierInfo(epoch uint64, header *types.Header
 	}
 
 	return &verifierInfo{
-		authorities:    epochData.Authorities,
-		randomness:     epochData.Randomness,
-		threshold:      threshold,
-		secondarySlots: configData.SecondarySlots > 0,
+		authorities:  epochData.Authorities,
+		randomness:   epochData.Randomness,
+		threshold:    threshold,
+		allowedSlots: types.AllowedSlots(configData.SecondarySlots),
 	}, nil
 }
 
 // verifier is a BABE verifier for a specific authority set, randomness, and threshold
 type verifier struct {
-	blockState     BlockState
-	slotState      SlotState
-	epoch          uint64
-	authorities    []types.AuthorityRaw
-	randomness     Randomness
-	threshold      *scale.Uint128
-	secondarySlots bool
-	slotDuration   time.Duration
+	blockState   BlockState
+	slotState    SlotState
+	epoch        uint64
+	authorities  []types.AuthorityRaw
+	randomness   Randomness
+	threshold    *scale.Uint128
+	allowedSlots types.AllowedSlots
+	slotDuration time.Duration
 }
 
 // newVerifier returns a Verifier for the epoch described by the given descriptor
 func newVerifier(blockState BlockState, slotState SlotState,
 	epoch uint64, info *verifierInfo, slotDuration time.Duration) *verifier {
 	return &verifier{
-		blockState:     blockState,
-		slotState:      slotState,
-		epoch:          epoch,
-		authorities:    info.authorities,
-		randomness:     info.randomness,
-		threshold:      info.threshold,
-		secondarySlots: info.secondarySlots,
-		slotDuration:   slotDuration,
+		blockState:   blockState,
+		slotState:    slotState,
+		epoch:        epoch,
+		authorities:  info.authorities,
+		randomness:   info.randomness,
+		threshold:    info.threshold,
+		allowedSlots: info.allowedSlots,
+		slotDuration: slotDuration,
 	}
 }
 
 // verifyAuthorshipRight verifies that the authority that produced a block was authorized to produce it.
 func (b *verifier) verifyAuthorshipRight(header *types.Header) error {
+	err := b.verifySeal(header)
+	if err != nil {
+		return err
+	}
+
+	return b.verifyEquivocation(header)
+}
+
+// verifyEquivocation returns an error if the author of the block equivocated,
+// in which case the equivocation is reported.
+func (b *verifier) verifyEquivocation(header *types.Header) error {
+	equivocated, err := b.verifyBlockEquivocation(header)
+	if err != nil {
+		return fmt.Errorf("could not verify block equivocation: %w", err)
+	}
+
+	if equivocated {
+		return fmt.Errorf("%w for block header %s", ErrProducerEquivocated, header.Hash())
+	}
+
+	return nil
+}
+
+// verifySeal verifies the slot claim of the pre-runtime digest of the block
+// and the seal of the block signed by the authority claiming the slot.
+func (b *verifier) verifySeal(header *types.Header) error {
 	// header should have 2 digest items (possibly more in the future)
 	// first item should be pre-digest, second should be seal
 	if len(header.Digest) < 2 {
@@ -319,15 +552,6 @@ func (b *verifier) verifyAuthorshipRight(header *types.Header) error {
 		return ErrBadSignature
 	}
 
-	equivocated, err := b.verifyBlockEquivocation(header)
-	if err != nil {
-		return fmt.Errorf("could not verify block equivocation: %w", err)
-	}
-
-	if equivocated {
-		return fmt.Errorf("%w for block header %s", ErrProducerEquivocated, header.Hash())
-	}
-
 	return nil
 }
 
@@ -424,7 +648,7 @@ func (b *verifier) verifyPreRuntimeDigest(digest *types.PreRuntimeDigest) (any,
 	case types.BabePrimaryPreDigest:
 		ok, err = b.verifyPrimarySlotWinner(d.AuthorityIndex, d.SlotNumber, d.VRFOutput, d.VRFProof)
 	case types.BabeSecondaryVRFPreDigest:
-		if !b.secondarySlots {
+		if b.allowedSlots != types.PrimaryAndSecondaryVRFSlots {
 			ok = false
 			break
 		}
@@ -440,7 +664,7 @@ func (b *verifier) verifyPreRuntimeDigest(digest *types.PreRuntimeDigest) (any,
 		}
 
 	case types.BabeSecondaryPlainPreDigest:
-		if !b.secondarySlots {
+		if b.allowedSlots != types.PrimaryAndSecondaryPlainSlots {
 			ok = false
 			break
 		}
@@ -61,11 +61,14 @@ func TestVerificationManager_OnDisabled_NewDigest(t *testing.T) {
 	epochDescriptor, err := babeService.initiateEpoch(testEpochIndex)
 	require.NoError(t, err)
 
-	vm.epochInfo[testEpochIndex] = &verifierInfo{
-		authorities: epochDescriptor.data.authorities,
-		threshold:   epochDescriptor.data.threshold,
-		randomness:  epochDescriptor.data.randomness,
-	}
+	vm.epochInfo[testEpochIndex] = []*forkVerifierInfo{{
+		anchor: genesisHeader.Hash(),
+		info: &verifierInfo{
+			authorities: epochDescriptor.data.authorities,
+			threshold:   epochDescriptor.data.threshold,
+			randomness:  epochDescriptor.data.randomness,
+		},
+	}}
 
 	slot := Slot{
 		start:    getSlotStartTime(epochDescriptor.startSlot, babeService.constants.slotDuration),
@@ -103,11 +106,14 @@ func TestVerificationManager_OnDisabled_DuplicateDigest(t *testing.T) {
 	slotState := state.NewSlotState(db)
 	vm := NewVerificationManager(babeService.blockState, slotState, babeService.epochState)
 
-	vm.epochInfo[testEpochIndex] = &verifierInfo{
-		authorities: epochDescriptor.data.authorities,
-		threshold:   epochDescriptor.data.threshold,
-		randomness:  epochDescriptor.data.randomness,
-	}
+	vm.epochInfo[testEpochIndex] = []*forkVerifierInfo{{
+		anchor: genesisHeader.Hash(),
+		info: &verifierInfo{
+			authorities: epochDescriptor.data.authorities,
+			threshold:   epochDescriptor.data.threshold,
+			randomness:  epochDescriptor.data.randomness,
+		},
+	}}
 
 	slot := Slot{
 		start:    getSlotStartTime(epochDescriptor.startSlot, babeService.constants.slotDuration),
@@ -646,9 +652,10 @@ func TestVerifyForkBlocksWithRespectiveEpochData(t *testing.T) {
 	epochState, err := state.NewEpochStateFromGenesis(inMemoryDB, stateService.Block, epochBABEConfig)
 	require.NoError(t, err)
 
-	onBlockImportDigestHandler := digest.NewBlockImportHandler(epochState, stateService.Grandpa)
+	digestRegistry := digest.NewDefaultEngineRegistry(epochState, stateService.Grandpa)
+	onBlockImportDigestHandler := digest.NewBlockImportHandler(digestRegistry)
 
-	digestHandler, err := digest.NewHandler(stateService.Block, epochState, stateService.Grandpa)
+	digestHandler, err := digest.NewHandler(stateService.Block, digestRegistry)
 	require.NoError(t, err)
 
 	digestHandler.Start()
@@ -720,7 +727,7 @@ func TestVerifyForkBlocksWithRespectiveEpochData(t *testing.T) {
 		require.Equal(t, len(authorities[3:]), len(verifierInfo.authorities))
 		require.ElementsMatch(t, authorities[3:], verifierInfo.authorities)
 
-		require.True(t, verifierInfo.secondarySlots)
+		require.Equal(t, types.PrimaryAndSecondaryPlainSlots, verifierInfo.allowedSlots)
 		require.Equal(t, expectedThreshold, verifierInfo.threshold)
 	}
 
@@ -738,7 +745,7 @@ func TestVerifyForkBlocksWithRespectiveEpochData(t *testing.T) {
 		// should keep the original authorities
 		require.ElementsMatch(t, authorities[6:], verifierInfo.authorities)
 
-		require.True(t, verifierInfo.secondarySlots)
+		require.Equal(t, types.PrimaryAndSecondaryPlainSlots, verifierInfo.allowedSlots)
 		require.Equal(t, expectedThreshold, verifierInfo.threshold)
 	}
 
@@ -766,7 +773,7 @@ func TestVerifyForkBlocksWithRespectiveEpochData(t *testing.T) {
 	// should keep the original authorities
 	require.ElementsMatch(t, authorities[6:], verifierInfo.authorities)
 
-	require.True(t, verifierInfo.secondarySlots)
+	require.Equal(t, types.PrimaryAndSecondaryPlainSlots, verifierInfo.allowedSlots)
 	require.Equal(t, expectedThreshold, verifierInfo.threshold)
 }
 
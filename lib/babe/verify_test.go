@@ -72,13 +72,13 @@ func encodeAndHashHeader(t *testing.T, header *types.Header) common.Hash {
 }
 
 func newTestVerifier(kp *sr25519.Keypair, blockState BlockState, slotState SlotState,
-	threshold *scale.Uint128, secSlots bool) *verifier {
+	threshold *scale.Uint128, allowedSlots types.AllowedSlots) *verifier {
 	authority := types.NewAuthority(kp.Public(), uint64(1))
 	info := &verifierInfo{
-		authorities:    []types.AuthorityRaw{*authority.ToRaw(), *authority.ToRaw()},
-		randomness:     Randomness{},
-		threshold:      threshold,
-		secondarySlots: secSlots,
+		authorities:  []types.AuthorityRaw{*authority.ToRaw(), *authority.ToRaw()},
+		randomness:   Randomness{},
+		threshold:    threshold,
+		allowedSlots: allowedSlots,
 	}
 	return newVerifier(blockState, slotState, 1, info, testSlotDuration)
 }
@@ -357,9 +357,9 @@ func Test_verifier_verifyPreRuntimeDigest(t *testing.T) {
 	}
 
 	viVRFSec2 := &verifierInfo{
-		authorities:    []types.AuthorityRaw{*authVRFSec.ToRaw(), *authVRFSec.ToRaw()},
-		threshold:      scale.MaxUint128,
-		secondarySlots: true,
+		authorities:  []types.AuthorityRaw{*authVRFSec.ToRaw(), *authVRFSec.ToRaw()},
+		threshold:    scale.MaxUint128,
+		allowedSlots: types.PrimaryAndSecondaryVRFSlots,
 	}
 
 	// BabeSecondaryPlainPreDigest case
@@ -374,9 +374,9 @@ func Test_verifier_verifyPreRuntimeDigest(t *testing.T) {
 	}
 
 	viSec2 := &verifierInfo{
-		authorities:    []types.AuthorityRaw{*authSec.ToRaw(), *authSec.ToRaw()},
-		threshold:      scale.MaxUint128,
-		secondarySlots: true,
+		authorities:  []types.AuthorityRaw{*authSec.ToRaw(), *authSec.ToRaw()},
+		threshold:    scale.MaxUint128,
+		allowedSlots: types.PrimaryAndSecondaryPlainSlots,
 	}
 
 	type args struct {
@@ -442,6 +442,18 @@ type args struct {
 			args:     args{babePRD},
 			expErr:   ErrBadSlotClaim,
 		},
+		{
+			name:     "BabeSecondaryPlainPreDigest secondary VRF slots allowed",
+			verifier: *newVerifier(mockBlockState, mockSlotState, 1, viVRFSec2, testSlotDuration),
+			args:     args{prd},
+			expErr:   ErrBadSlotClaim,
+		},
+		{
+			name:     "BabeSecondaryVRFPreDigest secondary plain slots allowed",
+			verifier: *newVerifier(mockBlockState, mockSlotState, 1, viSec2, testSlotDuration),
+			args:     args{babePRD},
+			expErr:   ErrBadSlotClaim,
+		},
 		{
 			name:     "BabeSecondaryVRFPreDigest invalid claim",
 			verifier: *newVerifier(mockBlockState, mockSlotState, 1, viVRFSec2, testSlotDuration),
@@ -549,28 +561,30 @@ func Test_verifier_verifyAuthorshipRight(t *testing.T) {
 	babePrd, err := testBabePrimaryPreDigest.ToPreRuntimeDigest()
 	assert.NoError(t, err)
 	header3 := newTestHeader(t, *babePrd, testInvalidSeal)
-	babeVerifier := newTestVerifier(kp, mockBlockState, mockSlotStateNoOp, scale.MaxUint128, false)
+	babeVerifier := newTestVerifier(kp, mockBlockState, mockSlotStateNoOp, scale.MaxUint128, types.PrimarySlots)
 
 	// Case 4: Invalid signature - BabePrimaryPreDigest
 	babePrd2, err := testBabePrimaryPreDigest.ToPreRuntimeDigest()
 	assert.NoError(t, err)
 	header4 := newTestHeader(t, *babePrd2)
 	signAndAddSeal(t, kp, header4, []byte{1})
-	babeVerifier2 := newTestVerifier(kp, mockBlockState, mockSlotStateNoOp, scale.MaxUint128, false)
+	babeVerifier2 := newTestVerifier(kp, mockBlockState, mockSlotStateNoOp, scale.MaxUint128, types.PrimarySlots)
 
 	// Case 5: Invalid signature - BabeSecondaryPlainPreDigest
 	babeSecPlainPrd, err := testBabeSecondaryPlainPreDigest.ToPreRuntimeDigest()
 	assert.NoError(t, err)
 	header5 := newTestHeader(t, *babeSecPlainPrd)
 	signAndAddSeal(t, kp, header5, []byte{1})
-	babeVerifier3 := newTestVerifier(kp, mockBlockState, mockSlotStateNoOp, scale.MaxUint128, true)
+	babeVerifier3 := newTestVerifier(kp, mockBlockState, mockSlotStateNoOp, scale.MaxUint128,
+		types.PrimaryAndSecondaryPlainSlots)
 
 	// Case 6: Invalid signature - BabeSecondaryVrfPreDigest
 	encSecVrfDigest := newEncodedBabeDigest(t, testBabeSecondaryVRFPreDigest)
 	assert.NoError(t, err)
 	header6 := newTestHeader(t, *types.NewBABEPreRuntimeDigest(encSecVrfDigest))
 	signAndAddSeal(t, kp, header6, []byte{1})
-	babeVerifier4 := newTestVerifier(kp, mockBlockState, mockSlotStateNoOp, scale.MaxUint128, true)
+	babeVerifier4 := newTestVerifier(kp, mockBlockState, mockSlotStateNoOp, scale.MaxUint128,
+		types.PrimaryAndSecondaryVRFSlots)
 
 	// Case 7: GetAuthorityIndex Err
 	babeParentPrd, err := testBabePrimaryPreDigest.ToPreRuntimeDigest()
@@ -595,7 +609,7 @@ func Test_verifier_verifyAuthorshipRight(t *testing.T) {
 	mockSlotState.EXPECT().CheckEquivocation(gomock.Any(),
 		testBabePrimaryPreDigest.SlotNumber, header7, signerAuthID).Return(nil, slotStateMockErr)
 
-	babeVerifier5 := newTestVerifier(kp, mockBlockState, mockSlotState, scale.MaxUint128, false)
+	babeVerifier5 := newTestVerifier(kp, mockBlockState, mockSlotState, scale.MaxUint128, types.PrimarySlots)
 
 	tests := []struct {
 		name     string
@@ -1131,10 +1145,10 @@ func Test_verifier_verifyAuthorshipRightEquivocatory(t *testing.T) {
 				mockBlockState.EXPECT().GetRuntime(header.Hash()).Return(mockRuntime, nil)
 				auth := types.NewAuthority(kp.Public(), uint64(1))
 				info := &verifierInfo{
-					authorities:    []types.AuthorityRaw{*auth.ToRaw(), *auth.ToRaw()},
-					threshold:      scale.MaxUint128,
-					secondarySlots: true,
-					randomness:     Randomness{},
+					authorities:  []types.AuthorityRaw{*auth.ToRaw(), *auth.ToRaw()},
+					threshold:    scale.MaxUint128,
+					allowedSlots: types.PrimaryAndSecondaryPlainSlots,
+					randomness:   Randomness{},
 				}
 
 				return newVerifier(mockBlockState, mockSlotState, 1, info, testSlotDuration)
@@ -1202,10 +1216,10 @@ func Test_verifier_verifyAuthorshipRightEquivocatory(t *testing.T) {
 
 				auth := types.NewAuthority(kp.Public(), uint64(1))
 				info := &verifierInfo{
-					authorities:    []types.AuthorityRaw{*auth.ToRaw(), *auth.ToRaw()},
-					threshold:      scale.MaxUint128,
-					secondarySlots: true,
-					randomness:     Randomness{},
+					authorities:  []types.AuthorityRaw{*auth.ToRaw(), *auth.ToRaw()},
+					threshold:    scale.MaxUint128,
+					allowedSlots: types.PrimaryAndSecondaryVRFSlots,
+					randomness:   Randomness{},
 				}
 
 				return newVerifier(mockBlockState, mockSlotState, 1, info, testSlotDuration)
@@ -1484,6 +1498,115 @@ func TestVerificationManager_VerifyBlock(t *testing.T) {
 	}
 }
 
+func TestVerificationManager_VerifyBlocks(t *testing.T) {
+	kp, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	parentHeader := types.NewEmptyHeader()
+	authorities := []types.AuthorityRaw{
+		{
+			Key:    [32]byte(kp.Public().Encode()),
+			Weight: 1,
+		},
+	}
+	configData := &types.ConfigData{
+		C1:             1,
+		C2:             1,
+		SecondarySlots: 1,
+	}
+
+	threshold, err := CalculateThreshold(configData.C1, configData.C2, len(authorities))
+	require.NoError(t, err)
+
+	epochData := &epochData{
+		authorities: authorities,
+		threshold:   threshold,
+	}
+
+	// the last header is in the epoch following the one of its parent, so
+	// its epoch data is not known until the headers before it are imported
+	newHeaders := func(t *testing.T, sealKeypair *sr25519.Keypair) []*types.Header {
+		headers := make([]*types.Header, 3)
+		parentHash := parentHeader.Hash()
+		for i := range headers {
+			preRuntimeDigest, err := claimSlot(1, uint64(i), epochData, kp)
+			require.NoError(t, err)
+
+			digest := types.NewDigest()
+			err = digest.Add(*preRuntimeDigest)
+			require.NoError(t, err)
+
+			header := &types.Header{
+				ParentHash: parentHash,
+				Number:     uint(i + 1),
+				Digest:     digest,
+			}
+			seal := buildSealDigest(t, header, sealKeypair)
+			err = header.Digest.Add(*seal)
+			require.NoError(t, err)
+
+			headers[i] = header
+			parentHash = header.Hash()
+		}
+		return headers
+	}
+
+	otherKeypair, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		headers        []*types.Header
+		equivocations  int
+		expectedResult int
+		errSentinel    error
+	}{
+		"batch_verified_up_to_next_epoch": {
+			headers:        newHeaders(t, kp),
+			equivocations:  2,
+			expectedResult: 2,
+		},
+		"bad_signature": {
+			headers:     newHeaders(t, otherKeypair),
+			errSentinel: ErrBadSignature,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			headers := testCase.headers
+
+			mockBlockState := NewMockBlockState(ctrl)
+			mockBlockState.EXPECT().GetHeader(headers[0].ParentHash).Return(parentHeader, nil)
+			mockBlockState.EXPECT().GenesisHash().Return(parentHeader.Hash()).AnyTimes()
+
+			mockEpochState := NewMockEpochState(ctrl)
+			mockEpochState.EXPECT().GetSlotDuration().Return(6*time.Second, nil)
+			mockEpochState.EXPECT().GetEpochForBlock(headers[0]).Return(uint64(1), nil)
+			mockEpochState.EXPECT().GetEpochForBlock(headers[1]).Return(uint64(1), nil)
+			mockEpochState.EXPECT().GetEpochForBlock(headers[2]).Return(uint64(2), nil)
+			// the verifier info is retrieved once for the whole batch
+			mockEpochState.EXPECT().GetEpochDataRaw(uint64(1), parentHeader).
+				Return(&types.EpochDataRaw{Authorities: authorities}, nil)
+			mockEpochState.EXPECT().GetConfigData(uint64(1), parentHeader).Return(configData, nil)
+
+			mockSlotState := NewMockSlotState(ctrl)
+			mockSlotState.EXPECT().
+				CheckEquivocation(gomock.Any(), gomock.Any(), gomock.Any(), [32]byte(kp.Public().Encode())).
+				Return(nil, nil).
+				Times(testCase.equivocations)
+
+			vm := NewVerificationManager(mockBlockState, mockSlotState, mockEpochState)
+			verified, err := vm.VerifyBlocks(headers)
+			assert.ErrorIs(t, err, testCase.errSentinel)
+			assert.Equal(t, testCase.expectedResult, verified)
+			assert.Len(t, vm.epochInfo[1], 1)
+		})
+	}
+}
+
 func buildSealDigest(t *testing.T, header *types.Header, kp *sr25519.Keypair) *types.SealDigest {
 	t.Helper()
 
@@ -1551,9 +1674,9 @@ func TestVerificationManager_SetOnDisabled(t *testing.T) {
 
 	authority := types.NewAuthority(kp.Public(), uint64(1))
 	info := &verifierInfo{
-		authorities:    []types.AuthorityRaw{*authority.ToRaw(), *authority.ToRaw()},
-		threshold:      scale.MaxUint128,
-		secondarySlots: true,
+		authorities:  []types.AuthorityRaw{*authority.ToRaw(), *authority.ToRaw()},
+		threshold:    scale.MaxUint128,
+		allowedSlots: types.PrimaryAndSecondaryVRFSlots,
 	}
 
 	disabledInfo := []*onDisabledInfo{
@@ -1564,26 +1687,26 @@ func TestVerificationManager_SetOnDisabled(t *testing.T) {
 
 	vm0 := NewVerificationManager(mockBlockStateEmpty, mockSlotState, mockEpochStateGetEpochErr)
 	vm1 := NewVerificationManager(mockBlockStateEmpty, mockSlotState, mockEpochStateGetEpochDataErr)
-	vm1.epochInfo[1] = info
+	vm1.epochInfo[1] = []*forkVerifierInfo{{info: info}}
 
 	vm2 := NewVerificationManager(mockBlockStateEmpty, mockSlotState, mockEpochStateIndexLenErr)
-	vm2.epochInfo[2] = info
+	vm2.epochInfo[2] = []*forkVerifierInfo{{info: info}}
 
 	vm3 := NewVerificationManager(mockBlockStateEmpty, mockSlotState, mockEpochStateSetDisabledProd)
-	vm3.epochInfo[2] = info
+	vm3.epochInfo[2] = []*forkVerifierInfo{{info: info}}
 
 	vm4 := NewVerificationManager(mockBlockStateIsDescendantErr, mockSlotState, mockEpochStateOk)
-	vm4.epochInfo[2] = info
+	vm4.epochInfo[2] = []*forkVerifierInfo{{info: info}}
 	vm4.onDisabled[2] = map[uint32][]*onDisabledInfo{}
 	vm4.onDisabled[2][0] = disabledInfo
 
 	vm5 := NewVerificationManager(mockBlockStateAuthorityDisabled, mockSlotState, mockEpochStateOk2)
-	vm5.epochInfo[2] = info
+	vm5.epochInfo[2] = []*forkVerifierInfo{{info: info}}
 	vm5.onDisabled[2] = map[uint32][]*onDisabledInfo{}
 	vm5.onDisabled[2][0] = disabledInfo
 
 	vm6 := NewVerificationManager(mockBlockStateOk, mockSlotState, mockEpochStateOk3)
-	vm6.epochInfo[2] = info
+	vm6.epochInfo[2] = []*forkVerifierInfo{{info: info}}
 	vm6.onDisabled[2] = map[uint32][]*onDisabledInfo{}
 	vm6.onDisabled[2][0] = disabledInfo
 
@@ -10,6 +10,7 @@
 package blocktree
 
 import (
+	context "context"
 	reflect "reflect"
 
 	types "github.com/ChainSafe/gossamer/dot/types"
@@ -115,6 +116,20 @@ func (mr *MockInstanceMockRecorder) CheckInherents() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckInherents", reflect.TypeOf((*MockInstance)(nil).CheckInherents))
 }
 
+// Config mocks base method.
+func (m *MockInstance) Config() runtime.InstanceConfig {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Config")
+	ret0, _ := ret[0].(runtime.InstanceConfig)
+	return ret0
+}
+
+// Config indicates an expected call of Config.
+func (mr *MockInstanceMockRecorder) Config() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Config", reflect.TypeOf((*MockInstance)(nil).Config))
+}
+
 // DecodeSessionKeys mocks base method.
 func (m *MockInstance) DecodeSessionKeys(arg0 []byte) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -145,6 +160,21 @@ func (mr *MockInstanceMockRecorder) Exec(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exec", reflect.TypeOf((*MockInstance)(nil).Exec), arg0, arg1)
 }
 
+// ExecContext mocks base method.
+func (m *MockInstance) ExecContext(arg0 context.Context, arg1 string, arg2 []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecContext", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecContext indicates an expected call of ExecContext.
+func (mr *MockInstanceMockRecorder) ExecContext(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecContext", reflect.TypeOf((*MockInstance)(nil).ExecContext), arg0, arg1, arg2)
+}
+
 // ExecuteBlock mocks base method.
 func (m *MockInstance) ExecuteBlock(arg0 *types.Block) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -303,6 +333,36 @@ func (mr *MockInstanceMockRecorder) Metadata() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Metadata", reflect.TypeOf((*MockInstance)(nil).Metadata))
 }
 
+// MetadataAtVersion mocks base method.
+func (m *MockInstance) MetadataAtVersion(arg0 uint32) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MetadataAtVersion", arg0)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MetadataAtVersion indicates an expected call of MetadataAtVersion.
+func (mr *MockInstanceMockRecorder) MetadataAtVersion(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MetadataAtVersion", reflect.TypeOf((*MockInstance)(nil).MetadataAtVersion), arg0)
+}
+
+// MetadataVersions mocks base method.
+func (m *MockInstance) MetadataVersions() ([]uint32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MetadataVersions")
+	ret0, _ := ret[0].([]uint32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MetadataVersions indicates an expected call of MetadataVersions.
+func (mr *MockInstanceMockRecorder) MetadataVersions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MetadataVersions", reflect.TypeOf((*MockInstance)(nil).MetadataVersions))
+}
+
 // NetworkService mocks base method.
 func (m *MockInstance) NetworkService() runtime.BasicNetwork {
 	m.ctrl.T.Helper()
@@ -332,15 +392,18 @@ func (mr *MockInstanceMockRecorder) NodeStorage() *gomock.Call {
 }
 
 // OffchainWorker mocks base method.
-func (m *MockInstance) OffchainWorker() {
+func (m *MockInstance) OffchainWorker(arg0 *types.Header) ([]types.Extrinsic, error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "OffchainWorker")
+	ret := m.ctrl.Call(m, "OffchainWorker", arg0)
+	ret0, _ := ret[0].([]types.Extrinsic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // OffchainWorker indicates an expected call of OffchainWorker.
-func (mr *MockInstanceMockRecorder) OffchainWorker() *gomock.Call {
+func (mr *MockInstanceMockRecorder) OffchainWorker(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OffchainWorker", reflect.TypeOf((*MockInstance)(nil).OffchainWorker))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OffchainWorker", reflect.TypeOf((*MockInstance)(nil).OffchainWorker), arg0)
 }
 
 // PaymentQueryInfo mocks base method.
@@ -395,18 +458,18 @@ func (mr *MockInstanceMockRecorder) Stop() *gomock.Call {
 }
 
 // ValidateTransaction mocks base method.
-func (m *MockInstance) ValidateTransaction(arg0 types.Extrinsic) (*transaction.Validity, error) {
+func (m *MockInstance) ValidateTransaction(arg0 context.Context, arg1 types.Extrinsic) (*transaction.Validity, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ValidateTransaction", arg0)
+	ret := m.ctrl.Call(m, "ValidateTransaction", arg0, arg1)
 	ret0, _ := ret[0].(*transaction.Validity)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ValidateTransaction indicates an expected call of ValidateTransaction.
-func (mr *MockInstanceMockRecorder) ValidateTransaction(arg0 any) *gomock.Call {
+func (mr *MockInstanceMockRecorder) ValidateTransaction(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateTransaction", reflect.TypeOf((*MockInstance)(nil).ValidateTransaction), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateTransaction", reflect.TypeOf((*MockInstance)(nil).ValidateTransaction), arg0, arg1)
 }
 
 // Validator mocks base method.
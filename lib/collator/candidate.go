@@ -0,0 +1,109 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package collator
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/erasure"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+var errNoValidationCode = errors.New("parachain has no validation code at relay parent")
+
+// candidateReceipt returns the candidate receipt of the collation given, built against the validation
+// data at the relay parent given, with the erasure root of its available data for the validators of
+// the relay parent, and signed with the collator key.
+func (s *Service) candidateReceipt(relayParent common.Hash, validationData *PersistedValidationData,
+	collation *Collation) (*CandidateReceipt, error) {
+	validators, err := s.relayChain.Validators(relayParent)
+	if err != nil {
+		return nil, fmt.Errorf("getting validators: %w", err)
+	}
+
+	codeHash, err := s.relayChain.ValidationCodeHash(relayParent, s.paraID, Included)
+	if err != nil {
+		return nil, fmt.Errorf("getting validation code hash: %w", err)
+	}
+
+	if codeHash == nil {
+		return nil, fmt.Errorf("%w: para id %d, relay parent %s", errNoValidationCode, s.paraID, relayParent)
+	}
+
+	availableData, err := scale.Marshal(AvailableData{PoV: collation.PoV, ValidationData: *validationData})
+	if err != nil {
+		return nil, fmt.Errorf("encoding available data: %w", err)
+	}
+
+	chunks, err := erasure.ObtainChunks(len(validators), availableData)
+	if err != nil {
+		return nil, fmt.Errorf("erasure coding available data: %w", err)
+	}
+
+	erasureRoot, err := erasure.ChunksRoot(chunks)
+	if err != nil {
+		return nil, fmt.Errorf("computing erasure root: %w", err)
+	}
+
+	validationDataHash, err := hashEncoded(*validationData)
+	if err != nil {
+		return nil, fmt.Errorf("hashing persisted validation data: %w", err)
+	}
+
+	povHash, err := hashEncoded(collation.PoV)
+	if err != nil {
+		return nil, fmt.Errorf("hashing proof of validity: %w", err)
+	}
+
+	paraHead, err := common.Blake2bHash(collation.HeadData)
+	if err != nil {
+		return nil, fmt.Errorf("hashing head data: %w", err)
+	}
+
+	commitmentsHash, err := hashEncoded(CandidateCommitments{
+		UpwardMessages:            collation.UpwardMessages,
+		HorizontalMessages:        collation.HorizontalMessages,
+		NewValidationCode:         collation.NewValidationCode,
+		HeadData:                  collation.HeadData,
+		ProcessedDownwardMessages: collation.ProcessedDownwardMessages,
+		HrmpWatermark:             collation.HrmpWatermark,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("hashing candidate commitments: %w", err)
+	}
+
+	descriptor := CandidateDescriptor{
+		ParaID:                      s.paraID,
+		RelayParent:                 relayParent,
+		Collator:                    CollatorID(s.key.Public().Encode()),
+		PersistedValidationDataHash: validationDataHash,
+		PoVHash:                     povHash,
+		ErasureRoot:                 erasureRoot,
+		ParaHead:                    paraHead,
+		ValidationCodeHash:          *codeHash,
+	}
+
+	signature, err := s.key.Sign(descriptor.signaturePayload())
+	if err != nil {
+		return nil, fmt.Errorf("signing candidate descriptor: %w", err)
+	}
+	copy(descriptor.Signature[:], signature)
+
+	return &CandidateReceipt{
+		Descriptor:      descriptor,
+		CommitmentsHash: commitmentsHash,
+	}, nil
+}
+
+// hashEncoded returns the blake2b hash of the SCALE encoding of the value given
+func hashEncoded(value any) (common.Hash, error) {
+	encoded, err := scale.Marshal(value)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return common.Blake2bHash(encoded)
+}
@@ -0,0 +1,299 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package collator builds parachain blocks against the relay chain and produces
+// their collations, the candidates submitted to the relay chain validators.
+package collator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/aura"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory/proof"
+)
+
+var logger = log.NewFromGlobal(log.AddContext("pkg", "collator"))
+
+// relayPollInterval is the interval at which the best relay chain block is checked
+const relayPollInterval = time.Second
+
+var (
+	errNoValidationData = errors.New("parachain has no validation data at relay parent")
+	errPoVTooLarge      = errors.New("proof of validity is larger than the maximum size")
+)
+
+// BlockState is the block state of the parachain
+type BlockState interface {
+	GetHeader(hash common.Hash) (*types.Header, error)
+}
+
+// StorageState is the storage state of the parachain
+type StorageState interface {
+	GenerateWitness(stateRoot common.Hash, keys [][]byte, childKeys map[string][][]byte) ([][]byte, error)
+}
+
+// Proposer builds and seals parachain blocks
+type Proposer interface {
+	Propose(parent *types.Header, slot uint64, inherentData *types.InherentData,
+		recordProof bool) (*types.Block, *rtstorage.TrieState, error)
+	SlotDuration() uint64
+}
+
+// BlockImportHandler is the interface for the handler of newly produced blocks
+type BlockImportHandler interface {
+	HandleBlockProduced(block *types.Block, state *rtstorage.TrieState) error
+}
+
+// CollationDistributor submits collations to the relay chain validators assigned to the parachain
+type CollationDistributor interface {
+	DistributeCollation(receipt *CandidateReceipt, pov *PoV) error
+}
+
+// Config is the collator configuration
+type Config struct {
+	LogLvl             log.Level
+	ParaID             ParaID
+	Key                *sr25519.Keypair
+	RelayChain         RelayChainInterface
+	BlockState         BlockState
+	StorageState       StorageState
+	Proposer           Proposer
+	BlockImportHandler BlockImportHandler
+	Distributor        CollationDistributor
+}
+
+// Service builds a parachain block on top of the parachain head included in each new
+// best relay chain block, and distributes its collation to the relay chain validators.
+type Service struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	paraID             ParaID
+	key                *sr25519.Keypair
+	relayChain         RelayChainInterface
+	blockState         BlockState
+	storageState       StorageState
+	proposer           Proposer
+	blockImportHandler BlockImportHandler
+	distributor        CollationDistributor
+}
+
+// NewService returns a new collator service
+func NewService(cfg *Config) *Service {
+	logger.Patch(log.SetLevel(cfg.LogLvl))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Service{
+		ctx:                ctx,
+		cancel:             cancel,
+		paraID:             cfg.ParaID,
+		key:                cfg.Key,
+		relayChain:         cfg.RelayChain,
+		blockState:         cfg.BlockState,
+		storageState:       cfg.StorageState,
+		proposer:           cfg.Proposer,
+		blockImportHandler: cfg.BlockImportHandler,
+		distributor:        cfg.Distributor,
+	}
+}
+
+// Start starts collating
+func (s *Service) Start() error {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.run()
+	}()
+	return nil
+}
+
+// Stop stops collating
+func (s *Service) Stop() error {
+	s.cancel()
+	s.wg.Wait()
+	return nil
+}
+
+func (s *Service) run() {
+	ticker := time.NewTicker(relayPollInterval)
+	defer ticker.Stop()
+
+	var lastRelayParent common.Hash
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		relayParent := s.relayChain.BestBlockHash()
+		if relayParent == lastRelayParent {
+			continue
+		}
+		lastRelayParent = relayParent
+
+		err := s.collate(relayParent)
+		if err != nil {
+			logger.Warnf("failed to collate at relay parent %s: %s", relayParent, err)
+		}
+	}
+}
+
+// collate builds a parachain block against the relay parent given if the node is the
+// parachain authority of the current slot, and distributes its collation.
+func (s *Service) collate(relayParent common.Hash) error {
+	collation, validationData, err := s.produceCollation(relayParent)
+	switch {
+	case errors.Is(err, aura.ErrSlotNotClaimed):
+		logger.Tracef("not our turn to collate at relay parent %s", relayParent)
+		return nil
+	case err != nil:
+		return err
+	}
+
+	receipt, err := s.candidateReceipt(relayParent, validationData, collation)
+	if err != nil {
+		return fmt.Errorf("building candidate receipt: %w", err)
+	}
+
+	err = s.distributor.DistributeCollation(receipt, &collation.PoV)
+	if err != nil {
+		return fmt.Errorf("distributing collation: %w", err)
+	}
+
+	return nil
+}
+
+// produceCollation builds, imports and returns the collation of a parachain block built on top
+// of the parachain head of the validation data at the relay parent given, with the validation data.
+func (s *Service) produceCollation(relayParent common.Hash) (*Collation, *PersistedValidationData, error) {
+	validationData, err := s.relayChain.PersistedValidationData(relayParent, s.paraID, Included)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting persisted validation data: %w", err)
+	}
+
+	if validationData == nil {
+		return nil, nil, fmt.Errorf("%w: para id %d, relay parent %s", errNoValidationData, s.paraID, relayParent)
+	}
+
+	parentHead := types.NewEmptyHeader()
+	err = scale.Unmarshal(validationData.ParentHead, parentHead)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decoding parent head: %w", err)
+	}
+
+	parent, err := s.blockState.GetHeader(parentHead.Hash())
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting parent header: %w", err)
+	}
+
+	inherentData, err := s.parachainInherentData(relayParent, validationData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	slotDuration := time.Duration(s.proposer.SlotDuration()) * time.Millisecond //nolint:gosec
+	slot := uint64(time.Now().UnixNano()) / uint64(slotDuration.Nanoseconds())  //nolint:gosec
+
+	block, ts, err := s.proposer.Propose(parent, slot, inherentData, true)
+	if err != nil {
+		return nil, nil, fmt.Errorf("proposing block: %w", err)
+	}
+
+	pov, err := s.proofOfValidity(parent, block, ts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if uint64(len(pov.BlockData)) > uint64(validationData.MaxPovSize) {
+		return nil, nil, fmt.Errorf("%w: %d bytes, maximum %d bytes",
+			errPoVTooLarge, len(pov.BlockData), validationData.MaxPovSize)
+	}
+
+	err = s.blockImportHandler.HandleBlockProduced(block, ts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("importing block: %w", err)
+	}
+
+	headData, err := scale.Marshal(block.Header)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encoding head data: %w", err)
+	}
+
+	logger.Infof("collated block %d with hash %s at relay parent %s, proof of validity size %d bytes",
+		block.Header.Number, block.Header.Hash(), relayParent, len(pov.BlockData))
+
+	return &Collation{
+		HeadData:      headData,
+		PoV:           *pov,
+		HrmpWatermark: validationData.RelayParentNumber,
+	}, validationData, nil
+}
+
+// parachainInherentData returns the inherent data with the parachain system inherent,
+// carrying the validation data and the relay chain state proof at the relay parent given.
+func (s *Service) parachainInherentData(relayParent common.Hash,
+	validationData *PersistedValidationData) (*types.InherentData, error) {
+	keys, err := relayStateProofKeys(s.paraID)
+	if err != nil {
+		return nil, fmt.Errorf("getting relay state proof keys: %w", err)
+	}
+
+	relayChainState, err := s.relayChain.ProveRead(relayParent, keys)
+	if err != nil {
+		return nil, fmt.Errorf("proving relay chain state: %w", err)
+	}
+
+	inherentData := types.NewInherentData()
+	err = inherentData.SetInherent(types.Sysi1337, ParachainInherentData{
+		ValidationData:  *validationData,
+		RelayChainState: relayChainState,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("setting parachain inherent: %w", err)
+	}
+
+	return inherentData, nil
+}
+
+// proofOfValidity returns the proof of validity of the block given, built on top of the parent given,
+// with the compact storage proof of the parent state keys recorded in the trie state given.
+func (s *Service) proofOfValidity(parent *types.Header, block *types.Block,
+	ts *rtstorage.TrieState) (*PoV, error) {
+	storageProof, err := s.storageState.GenerateWitness(parent.StateRoot, ts.RecordedKeys(), ts.RecordedChildKeys())
+	if err != nil {
+		return nil, fmt.Errorf("generating storage proof: %w", err)
+	}
+
+	compactProof, err := proof.EncodeCompact(parent.StateRoot.ToBytes(), storageProof)
+	if err != nil {
+		return nil, fmt.Errorf("encoding compact storage proof: %w", err)
+	}
+
+	extrinsics := make([][]byte, len(block.Body))
+	for i, extrinsic := range block.Body {
+		extrinsics[i] = extrinsic
+	}
+
+	blockData, err := scale.Marshal(ParachainBlockData{
+		Header:       block.Header,
+		Extrinsics:   extrinsics,
+		StorageProof: CompactProof{EncodedNodes: compactProof},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encoding parachain block data: %w", err)
+	}
+
+	return &PoV{BlockData: blockData}, nil
+}
@@ -0,0 +1,227 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package collator
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/lib/aura"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/erasure"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory/proof"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const testParaID ParaID = 2000
+
+func Test_relayStateProofKeys(t *testing.T) {
+	t.Parallel()
+
+	keys, err := relayStateProofKeys(testParaID)
+	require.NoError(t, err)
+	require.Len(t, keys, 7)
+
+	// twox128("Babe") ++ twox128("CurrentSlot")
+	assert.Equal(t, common.MustHexToBytes("0x1cb6f36e027abb2091cfb5110ab5087f06155b3cd9a8c9e5e9a23fd5dc13a5ed"), keys[0])
+	// the keys of the parachain storage items end with the twox64 concat hash of the para id
+	for _, key := range keys[2:] {
+		assert.Equal(t, scale.MustMarshal(testParaID), key[len(key)-4:])
+	}
+}
+
+func newTestParentState(t *testing.T) (*types.Header, *rtstorage.TrieState, [][]byte) {
+	t.Helper()
+
+	tr := inmemory.NewEmptyTrie()
+	for i := 0; i < 32; i++ {
+		tr.Put([]byte(fmt.Sprintf("key-%d", i)), []byte(fmt.Sprintf("value-%d", i)))
+	}
+
+	root, err := trie.V0.Hash(tr)
+	require.NoError(t, err)
+
+	db, err := database.NewPebble("", true)
+	require.NoError(t, err)
+	err = tr.WriteDirty(db)
+	require.NoError(t, err)
+
+	ts := rtstorage.NewTrieState(tr)
+	ts.EnableProofRecording()
+	ts.Get([]byte("key-7"))
+
+	storageProof, err := proof.GenerateWitness(root.ToBytes(), ts.RecordedKeys(), ts.RecordedChildKeys(), db)
+	require.NoError(t, err)
+
+	parent := types.NewHeader(common.Hash{1}, root, common.Hash{}, 1, types.NewDigest())
+	return parent, ts, storageProof
+}
+
+func TestService_collate(t *testing.T) {
+	t.Parallel()
+
+	relayParent := common.Hash{0xaa}
+	parent, ts, storageProof := newTestParentState(t)
+	block := &types.Block{
+		Header: *types.NewHeader(parent.Hash(), common.Hash{2}, common.Hash{3}, 2, types.NewDigest()),
+		Body:   types.Body{{1, 2, 3}},
+	}
+	validationData := &PersistedValidationData{
+		ParentHead:        scale.MustMarshal(*parent),
+		RelayParentNumber: 100,
+		MaxPovSize:        5 * 1024 * 1024,
+	}
+	validationCodeHash := ValidationCodeHash{0xcc}
+
+	key, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		setup       func(*MockRelayChainInterface, *MockBlockState, *MockProposer)
+		imported    bool
+		distributed bool
+		errSentinel error
+	}{
+		"no_validation_data": {
+			setup: func(relayChain *MockRelayChainInterface, _ *MockBlockState, _ *MockProposer) {
+				relayChain.EXPECT().PersistedValidationData(relayParent, testParaID, Included).Return(nil, nil)
+			},
+			errSentinel: errNoValidationData,
+		},
+		"slot_not_claimed": {
+			setup: func(relayChain *MockRelayChainInterface, blockState *MockBlockState, proposer *MockProposer) {
+				relayChain.EXPECT().PersistedValidationData(relayParent, testParaID, Included).
+					Return(validationData, nil)
+				relayChain.EXPECT().ProveRead(relayParent, gomock.Len(7)).Return([][]byte{{1}}, nil)
+				blockState.EXPECT().GetHeader(parent.Hash()).Return(parent, nil)
+				proposer.EXPECT().SlotDuration().Return(uint64(6000))
+				proposer.EXPECT().Propose(parent, gomock.Any(), gomock.Any(), true).
+					Return(nil, nil, aura.ErrSlotNotClaimed)
+			},
+		},
+		"no_validation_code": {
+			setup: func(relayChain *MockRelayChainInterface, blockState *MockBlockState, proposer *MockProposer) {
+				relayChain.EXPECT().PersistedValidationData(relayParent, testParaID, Included).
+					Return(validationData, nil)
+				relayChain.EXPECT().ProveRead(relayParent, gomock.Len(7)).Return([][]byte{{1}}, nil)
+				relayChain.EXPECT().Validators(relayParent).Return(make([]ValidatorID, 10), nil)
+				relayChain.EXPECT().ValidationCodeHash(relayParent, testParaID, Included).Return(nil, nil)
+				blockState.EXPECT().GetHeader(parent.Hash()).Return(parent, nil)
+				proposer.EXPECT().SlotDuration().Return(uint64(6000))
+				proposer.EXPECT().Propose(parent, gomock.Any(), gomock.Any(), true).
+					Return(block, ts, nil)
+			},
+			imported:    true,
+			errSentinel: errNoValidationCode,
+		},
+		"collation_distributed": {
+			setup: func(relayChain *MockRelayChainInterface, blockState *MockBlockState, proposer *MockProposer) {
+				relayChain.EXPECT().PersistedValidationData(relayParent, testParaID, Included).
+					Return(validationData, nil)
+				relayChain.EXPECT().ProveRead(relayParent, gomock.Len(7)).Return([][]byte{{1}}, nil)
+				relayChain.EXPECT().Validators(relayParent).Return(make([]ValidatorID, 10), nil)
+				relayChain.EXPECT().ValidationCodeHash(relayParent, testParaID, Included).
+					Return(&validationCodeHash, nil)
+				blockState.EXPECT().GetHeader(parent.Hash()).Return(parent, nil)
+				proposer.EXPECT().SlotDuration().Return(uint64(6000))
+				proposer.EXPECT().Propose(parent, gomock.Any(), gomock.Any(), true).
+					Return(block, ts, nil)
+			},
+			imported:    true,
+			distributed: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			relayChain := NewMockRelayChainInterface(ctrl)
+			blockState := NewMockBlockState(ctrl)
+			storageState := NewMockStorageState(ctrl)
+			proposer := NewMockProposer(ctrl)
+			blockImportHandler := NewMockBlockImportHandler(ctrl)
+			distributor := NewMockCollationDistributor(ctrl)
+			testCase.setup(relayChain, blockState, proposer)
+
+			if testCase.imported {
+				storageState.EXPECT().GenerateWitness(parent.StateRoot, ts.RecordedKeys(), ts.RecordedChildKeys()).
+					Return(storageProof, nil)
+				blockImportHandler.EXPECT().HandleBlockProduced(block, ts).Return(nil)
+			}
+
+			if testCase.distributed {
+				distributor.EXPECT().DistributeCollation(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(receipt *CandidateReceipt, pov *PoV) error {
+						blockData := ParachainBlockData{Header: *types.NewEmptyHeader()}
+						err := scale.Unmarshal(pov.BlockData, &blockData)
+						require.NoError(t, err)
+						assert.Equal(t, block.Header.Hash(), blockData.Header.Hash())
+						assert.Equal(t, [][]byte{{1, 2, 3}}, blockData.Extrinsics)
+						assert.Len(t, blockData.StorageProof.EncodedNodes, len(storageProof))
+
+						descriptor := receipt.Descriptor
+						assert.Equal(t, testParaID, descriptor.ParaID)
+						assert.Equal(t, relayParent, descriptor.RelayParent)
+						assert.Equal(t, CollatorID(key.Public().Encode()), descriptor.Collator)
+						assert.Equal(t, validationCodeHash, descriptor.ValidationCodeHash)
+						assert.Equal(t, common.MustBlake2bHash(scale.MustMarshal(*validationData)),
+							descriptor.PersistedValidationDataHash)
+						assert.Equal(t, common.MustBlake2bHash(scale.MustMarshal(*pov)), descriptor.PoVHash)
+
+						headData := scale.MustMarshal(block.Header)
+						assert.Equal(t, common.MustBlake2bHash(headData), descriptor.ParaHead)
+						assert.Equal(t, common.MustBlake2bHash(scale.MustMarshal(CandidateCommitments{
+							HeadData:      headData,
+							HrmpWatermark: 100,
+						})), receipt.CommitmentsHash)
+
+						chunks, err := erasure.ObtainChunks(10, scale.MustMarshal(AvailableData{
+							PoV:            *pov,
+							ValidationData: *validationData,
+						}))
+						require.NoError(t, err)
+						erasureRoot, err := erasure.ChunksRoot(chunks)
+						require.NoError(t, err)
+						assert.Equal(t, erasureRoot, descriptor.ErasureRoot)
+
+						ok, err := key.Public().Verify(descriptor.signaturePayload(), descriptor.Signature[:])
+						require.NoError(t, err)
+						assert.True(t, ok)
+						return nil
+					})
+			}
+
+			service := NewService(&Config{
+				ParaID:             testParaID,
+				Key:                key,
+				RelayChain:         relayChain,
+				BlockState:         blockState,
+				StorageState:       storageState,
+				Proposer:           proposer,
+				BlockImportHandler: blockImportHandler,
+				Distributor:        distributor,
+			})
+
+			err := service.collate(relayParent)
+			if testCase.errSentinel == nil {
+				require.NoError(t, err)
+				return
+			}
+			assert.True(t, errors.Is(err, testCase.errSentinel), err)
+		})
+	}
+}
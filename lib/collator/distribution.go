@@ -0,0 +1,261 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package collator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/network/messages"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+// maxDistributedCollations is the number of the latest collations kept to answer the
+// collation fetching requests of the validators.
+const maxDistributedCollations = 8
+
+var (
+	errUnexpectedRequest = errors.New("unexpected request")
+	errUnknownCollation  = errors.New("no collation at relay parent")
+)
+
+// Network is the network of the embedded relay chain node
+type Network interface {
+	RegisterNotificationsProtocol(sub protocol.ID,
+		fallbackSubs []protocol.ID,
+		messageID network.MessageType,
+		handshakeGetter network.HandshakeGetter,
+		handshakeDecoder network.HandshakeDecoder,
+		handshakeValidator network.HandshakeValidator,
+		messageDecoder network.MessageDecoder,
+		messageHandler network.NotificationsMessageHandler,
+		batchHandler network.NotificationsMessageBatchHandler,
+		maxSize uint64,
+	) error
+	RegisterRequestResponseProtocol(cfg network.RequestResponseConfig) error
+	SendMessage(to peer.ID, msg network.NotificationsMessage) error
+	AllConnectedPeersIDs() []peer.ID
+}
+
+// distributedCollation is a collation advertised to the validators
+type distributedCollation struct {
+	receipt *CandidateReceipt
+	pov     *PoV
+}
+
+// Distributor distributes the collations to the relay chain validators over the collation protocol
+// of the embedded relay chain node. The collator is declared to each connected relay chain peer before
+// advertising its collations, which the validators assigned to the parachain fetch with the collation
+// fetching protocol. Since the validators are not discovered, the collations are advertised to every
+// connected peer, so the relay chain node should be connected to the validators of the parachain.
+type Distributor struct {
+	network   Network
+	key       *sr25519.Keypair
+	localPeer peer.ID
+	paraID    ParaID
+
+	mu sync.Mutex
+	// collations are the latest collations distributed, oldest first
+	collations []distributedCollation
+	// declared are the peers the collator is declared to
+	declared map[peer.ID]struct{}
+}
+
+var _ CollationDistributor = (*Distributor)(nil)
+
+// NewDistributor returns a collation distributor declaring the collator of the key given as collator
+// of the parachain given, and registers the collation protocols on the relay chain network given.
+func NewDistributor(net Network, genesisHash common.Hash, localPeer peer.ID, key *sr25519.Keypair,
+	paraID ParaID) (*Distributor, error) {
+	d := &Distributor{
+		network:   net,
+		key:       key,
+		localPeer: localPeer,
+		paraID:    paraID,
+		declared:  make(map[peer.ID]struct{}),
+	}
+
+	genesis := strings.TrimPrefix(genesisHash.String(), "0x")
+	err := net.RegisterNotificationsProtocol(
+		protocol.ID(fmt.Sprintf("/%s/%s", genesis, collationID1)),
+		[]protocol.ID{legacyCollationID1},
+		network.CollationMsgType,
+		d.getHandshake,
+		d.decodeHandshake,
+		d.validateHandshake,
+		d.decodeMessage,
+		d.handleMessage,
+		nil,
+		maxCollationNotificationSize,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("registering collation protocol: %w", err)
+	}
+
+	err = net.RegisterRequestResponseProtocol(network.RequestResponseConfig{
+		ProtocolID:          protocol.ID(fmt.Sprintf("/%s/%s", genesis, reqCollationID1)),
+		FallbackProtocolIDs: []protocol.ID{legacyReqCollationID1},
+		MaxRequestSize:      maxCollationRequestSize,
+		MaxResponseSize:     maxCollationResponseSize,
+		DecodeRequest:       d.decodeRequest,
+		HandleRequest:       d.handleRequest,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("registering collation fetching protocol: %w", err)
+	}
+
+	return d, nil
+}
+
+// DistributeCollation keeps the collation of the candidate given to be fetched by the validators,
+// and advertises it to the connected relay chain peers, declaring the collator to the new peers.
+func (d *Distributor) DistributeCollation(receipt *CandidateReceipt, pov *PoV) error {
+	relayParent := receipt.Descriptor.RelayParent
+	peers := d.network.AllConnectedPeersIDs()
+
+	d.mu.Lock()
+	d.collations = append(d.collations, distributedCollation{receipt: receipt, pov: pov})
+	if len(d.collations) > maxDistributedCollations {
+		d.collations = d.collations[len(d.collations)-maxDistributedCollations:]
+	}
+
+	connected := make(map[peer.ID]struct{}, len(peers))
+	for _, to := range peers {
+		connected[to] = struct{}{}
+	}
+	for declared := range d.declared {
+		if _, ok := connected[declared]; !ok {
+			delete(d.declared, declared)
+		}
+	}
+
+	undeclared := make(map[peer.ID]struct{})
+	for _, to := range peers {
+		if _, ok := d.declared[to]; !ok {
+			undeclared[to] = struct{}{}
+			d.declared[to] = struct{}{}
+		}
+	}
+	d.mu.Unlock()
+
+	// the declaration is signed once, since the key cannot be used concurrently
+	var declaration *collationMessage
+	if len(undeclared) > 0 {
+		var err error
+		declaration, err = newDeclareMessage(d.key, d.localPeer, d.paraID)
+		if err != nil {
+			return err
+		}
+	}
+
+	advertisement := newAdvertiseCollationMessage(relayParent)
+	for _, to := range peers {
+		if _, ok := undeclared[to]; ok {
+			go d.advertise(to, declaration, advertisement)
+		} else {
+			go d.advertise(to, nil, advertisement)
+		}
+	}
+
+	logger.Debugf("advertising collation of candidate with para head %s at relay parent %s to %d peers",
+		receipt.Descriptor.ParaHead, relayParent, len(peers))
+	return nil
+}
+
+// advertise sends the advertisement given to the peer given, sending the
+// declaration of the collator first if it is not nil.
+func (d *Distributor) advertise(to peer.ID, declaration, advertisement *collationMessage) {
+	if declaration != nil {
+		err := d.network.SendMessage(to, declaration)
+		if err != nil {
+			logger.Debugf("failed to declare collator to peer %s: %s", to, err)
+
+			d.mu.Lock()
+			delete(d.declared, to)
+			d.mu.Unlock()
+			return
+		}
+	}
+
+	err := d.network.SendMessage(to, advertisement)
+	if err != nil {
+		logger.Debugf("failed to advertise collation to peer %s: %s", to, err)
+	}
+}
+
+func (*Distributor) getHandshake() (network.Handshake, error) {
+	return &collationHandshake{}, nil
+}
+
+func (*Distributor) decodeHandshake(_ []byte) (network.Handshake, error) {
+	return &collationHandshake{}, nil
+}
+
+func (*Distributor) validateHandshake(_ peer.ID, _ network.Handshake) error {
+	return nil
+}
+
+func (*Distributor) decodeMessage(in []byte) (network.NotificationsMessage, error) {
+	msg := new(collationMessage)
+	err := msg.Decode(in)
+	return msg, err
+}
+
+// handleMessage logs the collations seconded by the validators, the other messages
+// being meant for the validators.
+func (*Distributor) handleMessage(from peer.ID, msg network.NotificationsMessage) (bool, error) {
+	cm, ok := msg.(*collationMessage)
+	if !ok {
+		return false, nil
+	}
+
+	relayParent, ok := cm.secondedRelayParent()
+	if ok {
+		logger.Infof("collation at relay parent %s seconded by validator peer %s", relayParent, from)
+	}
+
+	return false, nil
+}
+
+func (*Distributor) decodeRequest(in []byte) (messages.P2PMessage, error) {
+	req := new(collationFetchingRequest)
+	err := req.Decode(in)
+	return req, err
+}
+
+// handleRequest answers the collation fetching request of a validator with the collation
+// distributed at the relay parent requested.
+func (d *Distributor) handleRequest(_ context.Context, from peer.ID,
+	msg messages.P2PMessage) (messages.P2PMessage, error) {
+	req, ok := msg.(*collationFetchingRequest)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", errUnexpectedRequest, msg)
+	}
+
+	if req.ParaID != d.paraID {
+		return nil, fmt.Errorf("%w: para id %d, collating for para id %d", errUnexpectedRequest, req.ParaID, d.paraID)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for i := len(d.collations) - 1; i >= 0; i-- {
+		collation := d.collations[i]
+		if collation.receipt.Descriptor.RelayParent != req.RelayParent {
+			continue
+		}
+
+		logger.Debugf("sending collation at relay parent %s to peer %s", req.RelayParent, from)
+		return &collationFetchingResponse{Receipt: *collation.receipt, PoV: *collation.pov}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", errUnknownCollation, req.RelayParent)
+}
@@ -0,0 +1,205 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package collator
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestDistributor(t *testing.T, net *MockNetwork) (*Distributor, *sr25519.Keypair) {
+	t.Helper()
+
+	key, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	genesisHash := common.Hash{0xab}
+	net.EXPECT().RegisterNotificationsProtocol(
+		protocol.ID("/ab00000000000000000000000000000000000000000000000000000000000000/collation/1"),
+		[]protocol.ID{"/polkadot/collation/1"}, network.CollationMsgType,
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+		uint64(maxCollationNotificationSize)).Return(nil)
+	net.EXPECT().RegisterRequestResponseProtocol(gomock.Any()).
+		DoAndReturn(func(cfg network.RequestResponseConfig) error {
+			assert.Equal(t,
+				protocol.ID("/ab00000000000000000000000000000000000000000000000000000000000000/req_collation/1"),
+				cfg.ProtocolID)
+			assert.Equal(t, []protocol.ID{"/polkadot/req_collation/1"}, cfg.FallbackProtocolIDs)
+			return nil
+		})
+
+	distributor, err := NewDistributor(net, genesisHash, peer.ID("local"), key, testParaID)
+	require.NoError(t, err)
+	return distributor, key
+}
+
+func newTestReceipt(relayParent common.Hash) *CandidateReceipt {
+	return &CandidateReceipt{
+		Descriptor: CandidateDescriptor{
+			ParaID:      testParaID,
+			RelayParent: relayParent,
+		},
+		CommitmentsHash: common.Hash{0xcc},
+	}
+}
+
+func TestDistributor_DistributeCollation(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	net := NewMockNetwork(ctrl)
+	distributor, key := newTestDistributor(t, net)
+
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		sent = make(map[peer.ID][]*collationMessage)
+	)
+	net.EXPECT().SendMessage(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(to peer.ID, msg network.NotificationsMessage) error {
+			defer wg.Done()
+			mu.Lock()
+			defer mu.Unlock()
+			sent[to] = append(sent[to], msg.(*collationMessage))
+			return nil
+		}).Times(6)
+
+	// the collator is declared to each peer before its first advertisement
+	net.EXPECT().AllConnectedPeersIDs().Return([]peer.ID{"a", "b"})
+	wg.Add(4)
+	err := distributor.DistributeCollation(newTestReceipt(common.Hash{1}), &PoV{BlockData: []byte{1}})
+	require.NoError(t, err)
+	wg.Wait()
+
+	net.EXPECT().AllConnectedPeersIDs().Return([]peer.ID{"a", "b"})
+	wg.Add(2)
+	err = distributor.DistributeCollation(newTestReceipt(common.Hash{2}), &PoV{BlockData: []byte{2}})
+	require.NoError(t, err)
+	wg.Wait()
+
+	for _, to := range []peer.ID{"a", "b"} {
+		messages := sent[to]
+		require.Len(t, messages, 3)
+
+		declaration := messages[0].Data
+		require.Len(t, declaration, 2+32+4+64)
+		assert.Equal(t, []byte{collatorProtocolIndex, declareIndex}, declaration[:2])
+		assert.Equal(t, key.Public().Encode(), declaration[2:34])
+		assert.Equal(t, scale.MustMarshal(testParaID), declaration[34:38])
+		ok, err := key.Public().Verify(append([]byte("local"), []byte("COLL")...), declaration[38:])
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		assert.Equal(t, newAdvertiseCollationMessage(common.Hash{1}), messages[1])
+		assert.Equal(t, newAdvertiseCollationMessage(common.Hash{2}), messages[2])
+	}
+}
+
+func TestDistributor_handleRequest(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+
+	net := NewMockNetwork(ctrl)
+	net.EXPECT().AllConnectedPeersIDs().Return(nil).AnyTimes()
+	distributor, _ := newTestDistributor(t, net)
+
+	for i := 0; i <= maxDistributedCollations; i++ {
+		err := distributor.DistributeCollation(newTestReceipt(common.Hash{byte(i)}), &PoV{BlockData: []byte{byte(i)}})
+		require.NoError(t, err)
+	}
+
+	testCases := map[string]struct {
+		request     *collationFetchingRequest
+		pov         []byte
+		errSentinel error
+	}{
+		"other_para_id": {
+			request:     &collationFetchingRequest{RelayParent: common.Hash{1}, ParaID: testParaID + 1},
+			errSentinel: errUnexpectedRequest,
+		},
+		"pruned_collation": {
+			request:     &collationFetchingRequest{RelayParent: common.Hash{0}, ParaID: testParaID},
+			errSentinel: errUnknownCollation,
+		},
+		"collation": {
+			request: &collationFetchingRequest{RelayParent: common.Hash{3}, ParaID: testParaID},
+			pov:     []byte{3},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			encodedRequest, err := testCase.request.Encode()
+			require.NoError(t, err)
+			request, err := distributor.decodeRequest(encodedRequest)
+			require.NoError(t, err)
+
+			response, err := distributor.handleRequest(context.Background(), peer.ID("validator"), request)
+			if testCase.errSentinel != nil {
+				assert.True(t, errors.Is(err, testCase.errSentinel), err)
+				return
+			}
+			require.NoError(t, err)
+
+			encodedResponse, err := response.Encode()
+			require.NoError(t, err)
+			assert.Equal(t, byte(0), encodedResponse[0])
+
+			var decoded collationFetchingResponse
+			err = decoded.Decode(encodedResponse)
+			require.NoError(t, err)
+			assert.Equal(t, *newTestReceipt(testCase.request.RelayParent), decoded.Receipt)
+			assert.Equal(t, testCase.pov, decoded.PoV.BlockData)
+		})
+	}
+}
+
+func Test_collationMessage_secondedRelayParent(t *testing.T) {
+	t.Parallel()
+
+	seconded := &collationMessage{Data: append([]byte{collatorProtocolIndex, collationSecondedIndex},
+		append(common.Hash{7}.ToBytes(), 1, 2, 3)...)}
+	relayParent, ok := seconded.secondedRelayParent()
+	assert.True(t, ok)
+	assert.Equal(t, common.Hash{7}, relayParent)
+
+	_, ok = newAdvertiseCollationMessage(common.Hash{7}).secondedRelayParent()
+	assert.False(t, ok)
+}
+
+func TestCandidateDescriptor_signaturePayload(t *testing.T) {
+	t.Parallel()
+
+	descriptor := CandidateDescriptor{
+		ParaID:                      testParaID,
+		RelayParent:                 common.Hash{1},
+		PersistedValidationDataHash: common.Hash{2},
+		PoVHash:                     common.Hash{3},
+		ValidationCodeHash:          ValidationCodeHash{4},
+		ErasureRoot:                 common.Hash{5},
+	}
+
+	payload := descriptor.signaturePayload()
+	require.Len(t, payload, 132)
+	assert.Equal(t, byte(1), payload[0])
+	assert.Equal(t, []byte{0xd0, 0x07, 0, 0}, payload[32:36])
+	assert.Equal(t, byte(2), payload[36])
+	assert.Equal(t, byte(3), payload[68])
+	assert.Equal(t, byte(4), payload[100])
+}
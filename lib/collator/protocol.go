@@ -0,0 +1,186 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package collator
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	collationID1          = "collation/1"
+	legacyCollationID1    = "/polkadot/collation/1"
+	reqCollationID1       = "req_collation/1"
+	legacyReqCollationID1 = "/polkadot/req_collation/1"
+
+	// maxCollationNotificationSize is the maximum size of a collation protocol message
+	maxCollationNotificationSize = 100 * 1024
+	// maxCollationRequestSize is the maximum size of a collation fetching request
+	maxCollationRequestSize = 1000
+	// maxCollationResponseSize is the maximum size of a collation fetching response,
+	// bounding the size of the proof of validity of the collations distributed.
+	maxCollationResponseSize = 16*1024*1024 + 10_000
+)
+
+// The variant indexes of the collation protocol messages. The collation protocol carries
+// the collator protocol messages, which are the declaration of a collator, the advertisement
+// of a collation to the validators and the notification of the collation seconded.
+const (
+	collatorProtocolIndex   byte = 0
+	declareIndex            byte = 0
+	advertiseCollationIndex byte = 1
+	collationSecondedIndex  byte = 4
+)
+
+var errUnexpectedResponseVariant = errors.New("unexpected collation fetching response variant")
+
+var (
+	_ network.NotificationsMessage = (*collationMessage)(nil)
+	_ network.Handshake            = (*collationHandshake)(nil)
+)
+
+// collationMessage is a message of the collation protocol, carrying an encoded collator
+// protocol message prefixed with the variant indexes of the message.
+type collationMessage struct {
+	Data []byte
+}
+
+// newDeclareMessage returns the message declaring the collator of the key given as collator of
+// the parachain given, signing the peer id of the node sending it.
+func newDeclareMessage(key *sr25519.Keypair, localPeer peer.ID, paraID ParaID) (*collationMessage, error) {
+	payload := append([]byte(localPeer), []byte("COLL")...)
+	signature, err := key.Sign(payload)
+	if err != nil {
+		return nil, fmt.Errorf("signing declaration: %w", err)
+	}
+
+	data := append([]byte{collatorProtocolIndex, declareIndex}, key.Public().Encode()...)
+	data = append(data, scale.MustMarshal(paraID)...)
+	return &collationMessage{Data: append(data, signature...)}, nil
+}
+
+// newAdvertiseCollationMessage returns the message advertising a collation at the relay parent given
+func newAdvertiseCollationMessage(relayParent common.Hash) *collationMessage {
+	data := append([]byte{collatorProtocolIndex, advertiseCollationIndex}, relayParent[:]...)
+	return &collationMessage{Data: data}
+}
+
+// Type returns CollationMsgType
+func (*collationMessage) Type() network.MessageType {
+	return network.CollationMsgType
+}
+
+// String formats a collationMessage as a string
+func (m *collationMessage) String() string {
+	return fmt.Sprintf("collationMessage Data=0x%x", m.Data)
+}
+
+// Encode returns the encoded message
+func (m *collationMessage) Encode() ([]byte, error) {
+	return m.Data, nil
+}
+
+// Decode the message into a collationMessage
+func (m *collationMessage) Decode(in []byte) error {
+	m.Data = in
+	return nil
+}
+
+// Hash returns the blake2b hash of the message
+func (m *collationMessage) Hash() (common.Hash, error) {
+	return common.Blake2bHash(m.Data)
+}
+
+// secondedRelayParent returns the relay parent of the collation seconded notified by the message,
+// and false if the message is not a collation seconded notification.
+func (m *collationMessage) secondedRelayParent() (common.Hash, bool) {
+	if len(m.Data) < 2+common.HashLength ||
+		m.Data[0] != collatorProtocolIndex || m.Data[1] != collationSecondedIndex {
+		return common.Hash{}, false
+	}
+
+	return common.NewHash(m.Data[2 : 2+common.HashLength]), true
+}
+
+// collationHandshake is the empty handshake of the collation protocol
+type collationHandshake struct{}
+
+// String formats a collationHandshake as a string
+func (*collationHandshake) String() string {
+	return "collationHandshake"
+}
+
+// Encode encodes a collationHandshake, which is empty
+func (*collationHandshake) Encode() ([]byte, error) {
+	return []byte{}, nil
+}
+
+// Decode the message into a collationHandshake
+func (*collationHandshake) Decode(_ []byte) error {
+	return nil
+}
+
+// IsValid returns true
+func (*collationHandshake) IsValid() bool {
+	return true
+}
+
+// collationFetchingRequest is the request of a validator fetching the collation
+// of a parachain advertised at a relay parent.
+type collationFetchingRequest struct {
+	RelayParent common.Hash
+	ParaID      ParaID
+}
+
+// String formats a collationFetchingRequest as a string
+func (r *collationFetchingRequest) String() string {
+	return fmt.Sprintf("collationFetchingRequest RelayParent=%s ParaID=%d", r.RelayParent, r.ParaID)
+}
+
+// Encode encodes a collationFetchingRequest using SCALE
+func (r *collationFetchingRequest) Encode() ([]byte, error) {
+	return scale.Marshal(*r)
+}
+
+// Decode the message into a collationFetchingRequest
+func (r *collationFetchingRequest) Decode(in []byte) error {
+	return scale.Unmarshal(in, r)
+}
+
+// collationFetchingResponse is the collation variant of the response to a collation fetching request
+type collationFetchingResponse struct {
+	Receipt CandidateReceipt
+	PoV     PoV
+}
+
+// String formats a collationFetchingResponse as a string
+func (r *collationFetchingResponse) String() string {
+	return fmt.Sprintf("collationFetchingResponse ParaID=%d RelayParent=%s PoVHash=%s",
+		r.Receipt.Descriptor.ParaID, r.Receipt.Descriptor.RelayParent, r.Receipt.Descriptor.PoVHash)
+}
+
+// Encode encodes a collationFetchingResponse using SCALE, prefixed with its variant index
+func (r *collationFetchingResponse) Encode() ([]byte, error) {
+	encoded, err := scale.Marshal(*r)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{0}, encoded...), nil
+}
+
+// Decode the message into a collationFetchingResponse
+func (r *collationFetchingResponse) Decode(in []byte) error {
+	if len(in) == 0 || in[0] != 0 {
+		return errUnexpectedResponseVariant
+	}
+
+	return scale.Unmarshal(in[1:], r)
+}
@@ -0,0 +1,228 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package collator
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// RelayChainInterface is the interface to the relay chain validating the parachain
+type RelayChainInterface interface {
+	BestBlockHash() common.Hash
+	GetHeader(hash common.Hash) (*types.Header, error)
+	PersistedValidationData(relayParent common.Hash, paraID ParaID,
+		assumption OccupiedCoreAssumption) (*PersistedValidationData, error)
+	Validators(relayParent common.Hash) ([]ValidatorID, error)
+	ValidationCodeHash(relayParent common.Hash, paraID ParaID,
+		assumption OccupiedCoreAssumption) (*ValidationCodeHash, error)
+	ProveRead(relayParent common.Hash, keys [][]byte) ([][]byte, error)
+}
+
+// RelayBlockState is the block state of the embedded relay chain node
+type RelayBlockState interface {
+	BestBlockHash() common.Hash
+	GetHeader(hash common.Hash) (*types.Header, error)
+	GetRuntime(blockHash common.Hash) (runtime.Instance, error)
+}
+
+// RelayStorageState is the storage state of the embedded relay chain node
+type RelayStorageState interface {
+	TrieState(root *common.Hash) (*rtstorage.TrieState, error)
+	GenerateWitness(stateRoot common.Hash, keys [][]byte, childKeys map[string][][]byte) ([][]byte, error)
+	sync.Locker
+}
+
+// RelayChain is the interface to the relay chain backed by the state of a relay chain
+// full node embedded in the collator process.
+type RelayChain struct {
+	blockState   RelayBlockState
+	storageState RelayStorageState
+}
+
+var _ RelayChainInterface = (*RelayChain)(nil)
+
+// NewRelayChain returns a relay chain interface using the state of the embedded relay chain node given.
+func NewRelayChain(blockState RelayBlockState, storageState RelayStorageState) *RelayChain {
+	return &RelayChain{
+		blockState:   blockState,
+		storageState: storageState,
+	}
+}
+
+// BestBlockHash returns the hash of the best relay chain block
+func (r *RelayChain) BestBlockHash() common.Hash {
+	return r.blockState.BestBlockHash()
+}
+
+// GetHeader returns the header of the relay chain block with the hash given
+func (r *RelayChain) GetHeader(hash common.Hash) (*types.Header, error) {
+	return r.blockState.GetHeader(hash)
+}
+
+// PersistedValidationData returns the validation data of the parachain at the relay parent given,
+// calling the parachain host runtime API of the relay chain. It returns a nil validation data
+// if the parachain is not registered or its core is occupied under the assumption given.
+func (r *RelayChain) PersistedValidationData(relayParent common.Hash, paraID ParaID,
+	assumption OccupiedCoreAssumption) (*PersistedValidationData, error) {
+	var validationData *PersistedValidationData
+	err := r.call(relayParent, runtime.ParachainHostPersistedValidationData,
+		paraAssumption{paraID, assumption}, &validationData)
+	if err != nil {
+		return nil, err
+	}
+
+	return validationData, nil
+}
+
+// Validators returns the validators of the current session at the relay parent given
+func (r *RelayChain) Validators(relayParent common.Hash) ([]ValidatorID, error) {
+	var validators []ValidatorID
+	err := r.call(relayParent, runtime.ParachainHostValidators, nil, &validators)
+	if err != nil {
+		return nil, err
+	}
+
+	return validators, nil
+}
+
+// ValidationCodeHash returns the hash of the validation code of the parachain at the relay parent
+// given. It returns a nil hash if the parachain is not registered or its core is occupied under
+// the assumption given.
+func (r *RelayChain) ValidationCodeHash(relayParent common.Hash, paraID ParaID,
+	assumption OccupiedCoreAssumption) (*ValidationCodeHash, error) {
+	var codeHash *ValidationCodeHash
+	err := r.call(relayParent, runtime.ParachainHostValidationCodeHash,
+		paraAssumption{paraID, assumption}, &codeHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return codeHash, nil
+}
+
+// paraAssumption are the parameters of the parachain host runtime API calls
+// taking a para id and an occupied core assumption.
+type paraAssumption struct {
+	ParaID     ParaID
+	Assumption OccupiedCoreAssumption
+}
+
+// call executes the runtime API call given in the state of the relay parent given, with
+// the SCALE encoded parameters given, decoding its result in the destination given.
+// The parameters are empty if they are nil.
+func (r *RelayChain) call(relayParent common.Hash, function string, params, dst any) error {
+	header, err := r.blockState.GetHeader(relayParent)
+	if err != nil {
+		return fmt.Errorf("getting relay parent header: %w", err)
+	}
+
+	var encodedParams []byte
+	if params != nil {
+		encodedParams, err = scale.Marshal(params)
+		if err != nil {
+			return fmt.Errorf("encoding parameters: %w", err)
+		}
+	}
+
+	r.storageState.Lock()
+	defer r.storageState.Unlock()
+
+	ts, err := r.storageState.TrieState(&header.StateRoot)
+	if err != nil {
+		return fmt.Errorf("getting relay parent state: %w", err)
+	}
+
+	rt, err := r.blockState.GetRuntime(relayParent)
+	if err != nil {
+		return fmt.Errorf("getting relay parent runtime: %w", err)
+	}
+
+	rt.SetContextStorage(ts)
+
+	encodedResult, err := rt.Exec(function, encodedParams)
+	if err != nil {
+		return fmt.Errorf("executing %s: %w", function, err)
+	}
+
+	err = scale.Unmarshal(encodedResult, dst)
+	if err != nil {
+		return fmt.Errorf("decoding result of %s: %w", function, err)
+	}
+
+	return nil
+}
+
+// ProveRead returns the storage proof of the keys given in the state of the relay parent given.
+func (r *RelayChain) ProveRead(relayParent common.Hash, keys [][]byte) ([][]byte, error) {
+	header, err := r.blockState.GetHeader(relayParent)
+	if err != nil {
+		return nil, fmt.Errorf("getting relay parent header: %w", err)
+	}
+
+	return r.storageState.GenerateWitness(header.StateRoot, keys, nil)
+}
+
+// relayStateProofKeys returns the keys of the relay chain state read by the parachain
+// runtime from the relay chain state proof of the parachain system inherent.
+func relayStateProofKeys(paraID ParaID) (keys [][]byte, err error) {
+	encodedParaID := scale.MustMarshal(paraID)
+
+	globalKeys := [][2]string{
+		{"Babe", "CurrentSlot"},
+		{"Configuration", "ActiveConfig"},
+	}
+	for _, names := range globalKeys {
+		key, err := storagePrefix(names[0], names[1])
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	paraKeys := [][2]string{
+		{"Dmp", "DownwardMessageQueueHeads"},
+		{"Hrmp", "HrmpIngressChannelsIndex"},
+		{"Hrmp", "HrmpEgressChannelsIndex"},
+		{"Paras", "UpgradeGoAheadSignal"},
+		{"Paras", "UpgradeRestrictionSignal"},
+	}
+	for _, names := range paraKeys {
+		prefix, err := storagePrefix(names[0], names[1])
+		if err != nil {
+			return nil, err
+		}
+
+		hashedParaID, err := common.Twox64(encodedParaID)
+		if err != nil {
+			return nil, fmt.Errorf("hashing para id: %w", err)
+		}
+
+		key := append(prefix, hashedParaID...)
+		keys = append(keys, append(key, encodedParaID...))
+	}
+
+	return keys, nil
+}
+
+// storagePrefix returns the storage key prefix of the storage item of the pallet given
+func storagePrefix(pallet, item string) ([]byte, error) {
+	palletHash, err := common.Twox128Hash([]byte(pallet))
+	if err != nil {
+		return nil, fmt.Errorf("hashing pallet name: %w", err)
+	}
+
+	itemHash, err := common.Twox128Hash([]byte(item))
+	if err != nil {
+		return nil, fmt.Errorf("hashing storage item name: %w", err)
+	}
+
+	return append(palletHash, itemHash...), nil
+}
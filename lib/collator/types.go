@@ -0,0 +1,169 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package collator
+
+import (
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// ParaID is the identifier of a parachain on the relay chain
+type ParaID uint32
+
+// HeadData is the SCALE encoded header of a parachain block, as stored by the relay chain
+type HeadData []byte
+
+// OccupiedCoreAssumption is the assumption made about the availability core of the
+// parachain when computing its validation data at a relay chain block.
+type OccupiedCoreAssumption byte
+
+const (
+	// Included assumes the candidate occupying the core is included
+	Included OccupiedCoreAssumption = iota
+	// TimedOut assumes the candidate occupying the core timed out
+	TimedOut
+	// Free assumes the core is free
+	Free
+)
+
+// PersistedValidationData is the validation data of the parachain at a relay chain block,
+// which the parachain block built against the relay chain block must be valid for.
+type PersistedValidationData struct {
+	ParentHead             HeadData
+	RelayParentNumber      uint32
+	RelayParentStorageRoot common.Hash
+	MaxPovSize             uint32
+}
+
+// InboundDownwardMessage is a message sent by the relay chain to the parachain
+type InboundDownwardMessage struct {
+	SentAt uint32
+	Msg    []byte
+}
+
+// InboundHrmpMessage is a message sent by another parachain to the parachain
+type InboundHrmpMessage struct {
+	SentAt uint32
+	Data   []byte
+}
+
+// InboundHrmpMessages are the messages received from a sender parachain.
+// A slice of it, ordered by sender, has the encoding of the map of messages by sender.
+type InboundHrmpMessages struct {
+	Sender   ParaID
+	Messages []InboundHrmpMessage
+}
+
+// ParachainInherentData is the data of the parachain system inherent, giving the parachain
+// runtime its validation data, the relay chain state proof and the inbound messages.
+type ParachainInherentData struct {
+	ValidationData PersistedValidationData
+	// RelayChainState is the storage proof of the relay chain state read by the runtime,
+	// against the relay parent storage root of the validation data.
+	RelayChainState    [][]byte
+	DownwardMessages   []InboundDownwardMessage
+	HorizontalMessages []InboundHrmpMessages
+}
+
+// CompactProof is a storage proof encoded in its compact form
+type CompactProof struct {
+	EncodedNodes [][]byte
+}
+
+// ParachainBlockData is the block data of the proof of validity of a parachain block,
+// which is everything needed by the relay chain validators to execute the block.
+type ParachainBlockData struct {
+	Header       types.Header
+	Extrinsics   [][]byte
+	StorageProof CompactProof
+}
+
+// PoV is the proof of validity of a parachain block
+type PoV struct {
+	BlockData []byte
+}
+
+// OutboundHrmpMessage is a message sent by the parachain to another parachain
+type OutboundHrmpMessage struct {
+	Recipient ParaID
+	Data      []byte
+}
+
+// Collation is a parachain block candidate with its proof of validity,
+// submitted to the relay chain validators to be backed.
+type Collation struct {
+	UpwardMessages            [][]byte
+	HorizontalMessages        []OutboundHrmpMessage
+	NewValidationCode         *[]byte
+	HeadData                  HeadData
+	PoV                       PoV
+	ProcessedDownwardMessages uint32
+	HrmpWatermark             uint32
+}
+
+// ValidatorID is the sr25519 public key of a relay chain validator
+type ValidatorID [32]byte
+
+// CollatorID is the sr25519 public key of a collator, the collators of a cumulus
+// parachain using a key generated at startup.
+type CollatorID [32]byte
+
+// CollatorSignature is the sr25519 signature of a collator
+type CollatorSignature [64]byte
+
+// ValidationCodeHash is the blake2b hash of the validation code of a parachain
+type ValidationCodeHash common.Hash
+
+// CandidateCommitments are the outputs of the validation of a parachain block candidate
+type CandidateCommitments struct {
+	UpwardMessages            [][]byte
+	HorizontalMessages        []OutboundHrmpMessage
+	NewValidationCode         *[]byte
+	HeadData                  HeadData
+	ProcessedDownwardMessages uint32
+	HrmpWatermark             uint32
+}
+
+// CandidateDescriptor describes a parachain block candidate and the context it is valid in,
+// signed by its collator.
+type CandidateDescriptor struct {
+	ParaID      ParaID
+	RelayParent common.Hash
+	Collator    CollatorID
+	// PersistedValidationDataHash is the blake2b hash of the encoded persisted validation data
+	PersistedValidationDataHash common.Hash
+	// PoVHash is the blake2b hash of the encoded proof of validity
+	PoVHash common.Hash
+	// ErasureRoot is the root of the erasure coding chunks of the available data of the candidate
+	ErasureRoot common.Hash
+	// Signature is the signature of the collator on the payload returned by signaturePayload
+	Signature CollatorSignature
+	// ParaHead is the blake2b hash of the head data of the candidate
+	ParaHead           common.Hash
+	ValidationCodeHash ValidationCodeHash
+}
+
+// signaturePayload returns the payload of the collator signature of the descriptor
+func (d *CandidateDescriptor) signaturePayload() []byte {
+	payload := make([]byte, 0, 132)
+	payload = append(payload, d.RelayParent[:]...)
+	payload = append(payload, scale.MustMarshal(d.ParaID)...)
+	payload = append(payload, d.PersistedValidationDataHash[:]...)
+	payload = append(payload, d.PoVHash[:]...)
+	return append(payload, d.ValidationCodeHash[:]...)
+}
+
+// CandidateReceipt is a parachain block candidate with the hash of its commitments
+type CandidateReceipt struct {
+	Descriptor      CandidateDescriptor
+	CommitmentsHash common.Hash
+}
+
+// AvailableData is the data kept available by the relay chain validators for a backed candidate,
+// which is erasure coded in a chunk for each validator.
+type AvailableData struct {
+	PoV            PoV
+	ValidationData PersistedValidationData
+}
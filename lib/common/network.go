@@ -20,6 +20,22 @@ type NetworkState struct {
 	Multiaddrs []ma.Multiaddr
 }
 
+// NetworkTraffic is the network traffic of the host needed for the rpc server
+type NetworkTraffic struct {
+	TotalBytesInbound  uint64
+	TotalBytesOutbound uint64
+	// Peers is the traffic of each connected peer, keyed by peer ID
+	Peers map[string]Traffic
+	// Protocols is the traffic of each sub-protocol, keyed by protocol ID
+	Protocols map[string]Traffic
+}
+
+// Traffic is the number of bytes received and sent
+type Traffic struct {
+	BytesInbound  uint64
+	BytesOutbound uint64
+}
+
 // PeerInfo is network information about peers needed for the rpc server
 type PeerInfo struct {
 	PeerID     string
@@ -0,0 +1,27 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package common
+
+import "time"
+
+// SyncProgress is information about the sync progress needed for the rpc server
+type SyncProgress struct {
+	BestBlock   uint
+	TargetBlock uint
+	// BlocksPerSecond is the import rate over the last minute
+	BlocksPerSecond float64
+	// AverageBlocksPerSecond is the import rate over the last ten minutes
+	AverageBlocksPerSecond float64
+	// BytesPerSecond is the block data download rate over the last minute
+	BytesPerSecond        float64
+	EstimatedTimeToTarget time.Duration
+	Peers                 []PeerSyncContribution
+}
+
+// PeerSyncContribution is the amount of block data downloaded from a peer while syncing
+type PeerSyncContribution struct {
+	PeerID string
+	Blocks uint64
+	Bytes  uint64
+}
@@ -132,6 +132,20 @@ func NewKeypairFromMnenomic(mnemonic, password string) (*Keypair, error) {
 	return NewKeypairFromSeed(seed[:32])
 }
 
+// Derive returns the keypair derived from the keypair along the given hard derivation junctions
+func (kp *Keypair) Derive(junctions []crypto.DeriveJunction) (*Keypair, error) {
+	seed := ed25519.PrivateKey(*kp.private).Seed()
+	for _, junction := range junctions {
+		if !junction.Hard {
+			return nil, fmt.Errorf("%w: for ed25519 keys", crypto.ErrSoftDerivationNotSupported)
+		}
+		derived := crypto.HardDeriveSeed("Ed25519HDKD", seed, junction.ChainCode)
+		seed = derived[:]
+	}
+
+	return NewKeypairFromSeed(seed)
+}
+
 // GenerateKeypair returns a new ed25519 keypair
 func GenerateKeypair() (*Keypair, error) {
 	buf := make([]byte, SeedLength)
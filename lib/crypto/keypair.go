@@ -4,11 +4,12 @@
 package crypto
 
 import (
+	"fmt"
+
 	"github.com/ChainSafe/gossamer/lib/common"
 
 	"github.com/btcsuite/btcutil/base58"
 	bip39 "github.com/cosmos/go-bip39"
-	"golang.org/x/crypto/blake2b"
 )
 
 // KeyType str
@@ -55,16 +56,11 @@ func PublicKeyToAddress(pub PublicKey) common.Address {
 }
 
 func publicKeyBytesToAddress(b []byte) common.Address {
-	hasher, err := blake2b.New(64, nil)
-	if err != nil {
-		return ""
-	}
-	_, err = hasher.Write(append(ss58Prefix, b...))
+	checksum, err := ss58Checksum(b)
 	if err != nil {
 		return ""
 	}
-	checksum := hasher.Sum(nil)
-	return common.Address(base58.Encode(append(b, checksum[:2]...)))
+	return common.Address(base58.Encode(append(b, checksum...)))
 }
 
 // PublicAddressToByteArray returns []byte address for given PublicKey Address
@@ -78,7 +74,18 @@ func PublicAddressToByteArray(add common.Address) []byte {
 
 // NewBIP39Mnemonic returns a new BIP39-compatible mnemonic
 func NewBIP39Mnemonic() (string, error) {
-	entropy, err := bip39.NewEntropy(128)
+	return NewBIP39MnemonicOfLength(12)
+}
+
+// NewBIP39MnemonicOfLength returns a new BIP39-compatible mnemonic of the given
+// number of words, which must be 12, 15, 18, 21 or 24
+func NewBIP39MnemonicOfLength(words int) (string, error) {
+	if words < 12 || words > 24 || words%3 != 0 {
+		return "", fmt.Errorf("invalid number of words: %d", words)
+	}
+
+	// each word encodes 11 bits, 32 bits of entropy having a checksum of 1 bit
+	entropy, err := bip39.NewEntropy(words * 32 / 3)
 	if err != nil {
 		return "", err
 	}
@@ -112,6 +112,16 @@ func NewPrivateKey(in []byte) (*PrivateKey, error) {
 	return priv, err
 }
 
+// NewKeypairFromSeed returns a Keypair given a 32 bytes seed, used as private key
+func NewKeypairFromSeed(seed []byte) (*Keypair, error) {
+	priv, err := NewPrivateKey(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKeypairFromPrivate(priv)
+}
+
 // NewKeypairFromPrivateKeyString returns a Keypair given a 0x prefixed private key string
 func NewKeypairFromPrivateKeyString(in string) (*Keypair, error) {
 	privBytes, err := common.HexToBytes(in)
@@ -127,6 +137,20 @@ func NewKeypairFromPrivateKeyString(in string) (*Keypair, error) {
 	return NewKeypairFromPrivate(priv)
 }
 
+// Derive returns the keypair derived from the keypair along the given hard derivation junctions
+func (kp *Keypair) Derive(junctions []crypto.DeriveJunction) (*Keypair, error) {
+	seed := kp.private.Encode()
+	for _, junction := range junctions {
+		if !junction.Hard {
+			return nil, fmt.Errorf("%w: for secp256k1 keys", crypto.ErrSoftDerivationNotSupported)
+		}
+		derived := crypto.HardDeriveSeed("Secp256k1HDKD", seed, junction.ChainCode)
+		seed = derived[:]
+	}
+
+	return NewKeypairFromSeed(seed)
+}
+
 // GenerateKeypair will generate a Keypair
 func GenerateKeypair() (*Keypair, error) {
 	priv, err := secp256k1.GenerateKey()
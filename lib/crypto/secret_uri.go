@@ -0,0 +1,139 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package crypto
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+
+	"github.com/ChainSafe/go-schnorrkel"
+	"golang.org/x/crypto/blake2b"
+)
+
+// DevPhrase is the secret phrase of the substrate development accounts, used by
+// the secret URIs starting with a derivation path, such as //Alice
+const DevPhrase = "bottom drive obey lake curtain smoke basket hold race lonely fit walk"
+
+// SeedLength is the length of the seeds the keypairs are created from
+const SeedLength = 32
+
+// ChainCodeLength is the length of the chain code of a derivation junction
+const ChainCodeLength = 32
+
+var (
+	errInvalidSecretURI = errors.New("invalid secret URI")
+	// ErrSoftDerivationNotSupported is returned when deriving a key with a soft junction
+	// with a scheme only supporting hard derivation
+	ErrSoftDerivationNotSupported = errors.New("soft derivation not supported")
+)
+
+// DeriveJunction is a junction of a derivation path, hard junctions
+// being written //name and soft junctions /name
+type DeriveJunction struct {
+	ChainCode [ChainCodeLength]byte
+	Hard      bool
+}
+
+// NewDeriveJunction returns the junction of the given name. As in substrate, the name
+// is encoded as an u64 if it is a number and as a string otherwise, its chain code being
+// the encoding padded with zeroes, or its blake2b hash if it is longer than 32 bytes.
+func NewDeriveJunction(name string, hard bool) DeriveJunction {
+	var encoded []byte
+	if index, err := strconv.ParseUint(name, 10, 64); err == nil {
+		encoded = binary.LittleEndian.AppendUint64(nil, index)
+	} else {
+		encoded = scale.MustMarshal(name)
+	}
+
+	junction := DeriveJunction{Hard: hard}
+	if len(encoded) > ChainCodeLength {
+		junction.ChainCode = blake2b.Sum256(encoded)
+	} else {
+		copy(junction.ChainCode[:], encoded)
+	}
+	return junction
+}
+
+// SecretURI is a parsed secret URI of the form <phrase>//hard/soft///password, the phrase
+// being either a BIP39 mnemonic or a 0x prefixed hex encoded seed
+type SecretURI struct {
+	Phrase    string
+	Junctions []DeriveJunction
+	Password  string
+}
+
+// ParseSecretURI parses the secret URI given, using DevPhrase if it has no phrase
+func ParseSecretURI(uri string) (*SecretURI, error) {
+	secretURI := &SecretURI{}
+
+	rest, password, hasPassword := strings.Cut(uri, "///")
+	if hasPassword {
+		secretURI.Password = password
+	}
+
+	phrase, path, _ := strings.Cut(rest, "/")
+	secretURI.Phrase = strings.TrimSpace(phrase)
+	if secretURI.Phrase == "" {
+		secretURI.Phrase = DevPhrase
+	}
+
+	if path == "" && strings.HasSuffix(rest, "/") {
+		return nil, fmt.Errorf("%w: empty derivation junction", errInvalidSecretURI)
+	}
+
+	// the path has its leading slash removed, a hard junction starting with the second slash
+	for path != "" {
+		hard := strings.HasPrefix(path, "/")
+		if hard {
+			path = path[1:]
+		}
+
+		var name string
+		name, path, _ = strings.Cut(path, "/")
+		if name == "" {
+			return nil, fmt.Errorf("%w: empty derivation junction", errInvalidSecretURI)
+		}
+		secretURI.Junctions = append(secretURI.Junctions, NewDeriveJunction(name, hard))
+	}
+
+	return secretURI, nil
+}
+
+// Seed returns the seed of the phrase of the secret URI, the phrase itself if it is a hex
+// encoded seed, or the mini secret key derived from the BIP39 mnemonic and the password
+// as in substrate otherwise.
+func (u *SecretURI) Seed() ([]byte, error) {
+	if strings.HasPrefix(u.Phrase, "0x") {
+		seed, err := common.HexToBytes(u.Phrase)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errInvalidSecretURI, err)
+		}
+		if len(seed) != SeedLength {
+			return nil, fmt.Errorf("%w: seed is not %d bytes long", errInvalidSecretURI, SeedLength)
+		}
+		return seed, nil
+	}
+
+	seed, err := schnorrkel.SeedFromMnemonic(u.Phrase, u.Password)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidSecretURI, err)
+	}
+	return seed[:SeedLength], nil
+}
+
+// HardDeriveSeed returns the seed derived from the seed given with the chain code given, as the
+// blake2b hash of the encoded tuple of the context, the seed and the chain code. It is the hard
+// derivation of the ed25519 and secp256k1 keys, which do not support soft derivation.
+func HardDeriveSeed(context string, seed []byte, chainCode [ChainCodeLength]byte) [SeedLength]byte {
+	encoded := scale.MustMarshal(context)
+	encoded = append(encoded, seed...)
+	encoded = append(encoded, chainCode[:]...)
+	return blake2b.Sum256(encoded)
+}
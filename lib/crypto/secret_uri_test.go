@@ -0,0 +1,90 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSecretURI(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		uri        string
+		secretURI  *SecretURI
+		errWrapped error
+		errMessage string
+	}{
+		"phrase_only": {
+			uri:       "0x0123",
+			secretURI: &SecretURI{Phrase: "0x0123"},
+		},
+		"dev_phrase": {
+			uri: "//Alice/1///password",
+			secretURI: &SecretURI{
+				Phrase: DevPhrase,
+				Junctions: []DeriveJunction{
+					NewDeriveJunction("Alice", true),
+					NewDeriveJunction("1", false),
+				},
+				Password: "password",
+			},
+		},
+		"empty_junction": {
+			uri:        "phrase//",
+			errWrapped: errInvalidSecretURI,
+			errMessage: "invalid secret URI: empty derivation junction",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			secretURI, err := ParseSecretURI(testCase.uri)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+			assert.Equal(t, testCase.secretURI, secretURI)
+		})
+	}
+}
+
+func TestNewDeriveJunction(t *testing.T) {
+	t.Parallel()
+
+	// numbers are encoded as little endian u64
+	junction := NewDeriveJunction("1", false)
+	assert.Equal(t, [ChainCodeLength]byte{1}, junction.ChainCode)
+	assert.False(t, junction.Hard)
+
+	// strings are encoded with their compact length prefix
+	junction = NewDeriveJunction("Alice", true)
+	assert.Equal(t, [ChainCodeLength]byte{20, 'A', 'l', 'i', 'c', 'e'}, junction.ChainCode)
+	assert.True(t, junction.Hard)
+
+	// chain codes of encodings longer than 32 bytes are their blake2b hash
+	junction = NewDeriveJunction("a junction name longer than thirty two bytes", true)
+	assert.NotEqual(t, byte('a'), junction.ChainCode[1])
+}
+
+func TestSecretURI_Seed(t *testing.T) {
+	t.Parallel()
+
+	seed, err := (&SecretURI{Phrase: DevPhrase}).Seed()
+	require.NoError(t, err)
+	assert.Len(t, seed, SeedLength)
+
+	withPassword, err := (&SecretURI{Phrase: DevPhrase, Password: "password"}).Seed()
+	require.NoError(t, err)
+	assert.NotEqual(t, seed, withPassword)
+
+	_, err = (&SecretURI{Phrase: "0x0123"}).Seed()
+	assert.ErrorIs(t, err, errInvalidSecretURI)
+}
@@ -5,8 +5,9 @@
 
 import (
 	"errors"
+	"runtime"
 	"sync"
-	"time"
+	"sync/atomic"
 )
 
 var ErrSignatureVerificationFailed = errors.New("failed to verify signature")
@@ -22,16 +23,19 @@ type SignatureInfo struct {
 	VerifyFunc SigVerifyFunc
 }
 
-// SignatureVerifier ...
+// SignatureVerifier verifies batches of signatures in the background,
+// using a pool of workers verifying the signatures in parallel.
 type SignatureVerifier struct {
-	batch   []*SignatureInfo
-	init    bool // Indicates whether the batch processing is started.
-	invalid bool // Set to true if any signature verification fails.
 	logger  Erroer
-	closeCh chan struct{}
-	sync.RWMutex
-	sync.Once
-	sync.WaitGroup
+	workers int
+
+	lock    sync.Mutex
+	started bool                // Indicates whether the batch processing is started.
+	pending []*SignatureInfo    // Signatures added before the batch processing is started.
+	queue   chan *SignatureInfo // Signatures to be verified by the workers.
+	wg      sync.WaitGroup
+
+	invalid atomic.Bool // Set to true if any signature verification fails.
 }
 
 // NewSignatureVerifier initialises SignatureVerifier which does background verification of signatures.
@@ -40,114 +44,108 @@ type SignatureVerifier struct {
 // Signatures can be added to the batch using Add().
 func NewSignatureVerifier(logger Erroer) *SignatureVerifier {
 	return &SignatureVerifier{
-		batch:   make([]*SignatureInfo, 0),
-		init:    false,
-		invalid: false,
 		logger:  logger,
-		RWMutex: sync.RWMutex{},
-		closeCh: make(chan struct{}),
+		workers: runtime.NumCPU(),
 	}
 }
 
-// Start signature verification in batch.
+// Start signature verification in batch, spawning the workers verifying the signatures
+// added to the batch. It does nothing if the batch processing is already started.
 func (sv *SignatureVerifier) Start() {
-	// Update the init state.
-	sv.Lock()
-	sv.init = true
-	sv.Unlock()
-
-	sv.WaitGroup.Add(1)
-
-	go func() {
-		defer sv.Done()
-		for {
-			select {
-			case <-sv.closeCh:
-				return
-			default:
-				signature := sv.Remove()
-				if signature == nil {
+	sv.lock.Lock()
+	if sv.started {
+		sv.lock.Unlock()
+		return
+	}
+
+	sv.started = true
+	sv.queue = make(chan *SignatureInfo, sv.workers)
+	queue := sv.queue
+	pending := sv.pending
+	sv.pending = nil
+	sv.lock.Unlock()
+
+	sv.wg.Add(sv.workers)
+	for i := 0; i < sv.workers; i++ {
+		go func() {
+			defer sv.wg.Done()
+			for signature := range queue {
+				// the batch is invalid, drain the remaining signatures
+				if sv.IsInvalid() {
 					continue
 				}
+
 				err := signature.VerifyFunc(signature.PubKey, signature.Sign, signature.Msg)
 				if err != nil {
 					sv.logger.Errorf("[ext_crypto_start_batch_verify_version_1]: %s", err)
 					sv.Invalid()
-					return
 				}
 			}
-		}
-	}()
+		}()
+	}
+
+	for _, signature := range pending {
+		queue <- signature
+	}
 }
 
-// IsStarted ...
+// IsStarted returns true if the batch processing is started.
 func (sv *SignatureVerifier) IsStarted() bool {
-	sv.RLock()
-	defer sv.RUnlock()
-	return sv.init
+	sv.lock.Lock()
+	defer sv.lock.Unlock()
+	return sv.started
 }
 
-// IsInvalid ...
+// IsInvalid returns true if a signature of the batch failed to verify.
 func (sv *SignatureVerifier) IsInvalid() bool {
-	sv.RLock()
-	defer sv.RUnlock()
-	return sv.invalid
+	return sv.invalid.Load()
 }
 
-// Invalid ...
+// Invalid marks the batch as invalid.
 func (sv *SignatureVerifier) Invalid() {
-	sv.RLock()
-	defer sv.RUnlock()
-	sv.invalid = true
+	sv.invalid.Store(true)
 }
 
-// Add ...
+// Add adds the signature given to the batch. If the batch processing is started,
+// the signature is verified by the next available worker.
 func (sv *SignatureVerifier) Add(s *SignatureInfo) {
 	if sv.IsInvalid() {
 		return
 	}
 
-	sv.Lock()
-	defer sv.Unlock()
-	sv.batch = append(sv.batch, s)
-}
-
-// Remove returns the first signature from the batch. Returns nil if batch is empty.
-func (sv *SignatureVerifier) Remove() *SignatureInfo {
-	sv.Lock()
-	defer sv.Unlock()
-	if len(sv.batch) == 0 {
-		return nil
+	sv.lock.Lock()
+	if !sv.started {
+		sv.pending = append(sv.pending, s)
+		sv.lock.Unlock()
+		return
 	}
-	sign := sv.batch[0]
-	sv.batch = sv.batch[1:len(sv.batch)]
-	return sign
+	queue := sv.queue
+	sv.lock.Unlock()
+
+	queue <- s
 }
 
 // Reset reset the signature verifier for reuse.
 func (sv *SignatureVerifier) Reset() {
-	sv.Lock()
-	defer sv.Unlock()
-	sv.init = false
-	sv.batch = make([]*SignatureInfo, 0)
-	sv.invalid = false
-	sv.closeCh = make(chan struct{})
+	sv.lock.Lock()
+	defer sv.lock.Unlock()
+	sv.started = false
+	sv.pending = nil
+	sv.queue = nil
+	sv.invalid.Store(false)
 }
 
 // Finish waits till batch is finished. Returns true if all the signatures are valid, Otherwise returns false.
 func (sv *SignatureVerifier) Finish() bool {
-	for {
-		time.Sleep(100 * time.Millisecond)
-		sv.Lock()
-		if sv.invalid || len(sv.batch) == 0 {
-			close(sv.closeCh)
-			sv.Unlock()
-			break
-		}
-		sv.RUnlock()
-	}
-	// Wait till start function to finish and then reset it.
-	sv.Wait()
+	// the signatures added before the batch is started are verified too
+	sv.Start()
+
+	sv.lock.Lock()
+	close(sv.queue)
+	sv.lock.Unlock()
+
+	// Wait till the workers verified the batch and then reset it.
+	sv.wg.Wait()
 	isInvalid := sv.IsInvalid()
 	sv.Reset()
 	return !isInvalid
@@ -122,5 +122,45 @@ func TestVerifySignature(t *testing.T) {
 			require.Equal(t, testCase.expect, ok)
 		})
 	}
+}
+
+func TestSignatureVerifier_parallelBatch(t *testing.T) {
+	t.Parallel()
+
+	keypair, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	signatures := make([]*crypto.SignatureInfo, 64)
+	for i := range signatures {
+		message := []byte{byte(i)}
+		sign, err := keypair.Sign(message)
+		require.NoError(t, err)
+
+		signatures[i] = &crypto.SignatureInfo{
+			PubKey:     keypair.Public().Encode(),
+			Sign:       sign,
+			Msg:        message,
+			VerifyFunc: sr25519.VerifySignature,
+		}
+	}
+
+	signVerify := crypto.NewSignatureVerifier(log.New(log.SetWriter(io.Discard)))
+
+	signVerify.Start()
+	for _, sig := range signatures {
+		signVerify.Add(sig)
+	}
+	require.True(t, signVerify.Finish())
+	require.False(t, signVerify.IsStarted())
+
+	// the verifier is reused for a batch with an invalid signature
+	invalid := *signatures[32]
+	invalid.Msg = []byte("invalid")
+	signatures[32] = &invalid
 
+	signVerify.Start()
+	for _, sig := range signatures {
+		signVerify.Add(sig)
+	}
+	require.False(t, signVerify.Finish())
 }
@@ -159,6 +159,29 @@ func NewKeypairFromMnenomic(mnemonic, password string) (*Keypair, error) {
 	}, nil
 }
 
+// Derive returns the keypair derived from the keypair along the given derivation junctions
+func (kp *Keypair) Derive(junctions []crypto.DeriveJunction) (*Keypair, error) {
+	secret := kp.private.key
+	for _, junction := range junctions {
+		derive := sr25519.DeriveKeySoft
+		if junction.Hard {
+			derive = sr25519.DeriveKeyHard
+		}
+
+		extended, err := derive(secret, nil, junction.ChainCode)
+		if err != nil {
+			return nil, fmt.Errorf("deriving key: %w", err)
+		}
+
+		secret, err = extended.Secret()
+		if err != nil {
+			return nil, fmt.Errorf("getting derived secret key: %w", err)
+		}
+	}
+
+	return NewKeypair(secret)
+}
+
 // NewPrivateKey creates a new private key using the input bytes
 func NewPrivateKey(in []byte) (*PrivateKey, error) {
 	if len(in) != PrivateKeyLength {
@@ -9,6 +9,7 @@
 	"fmt"
 	"testing"
 
+	sr25519 "github.com/ChainSafe/go-schnorrkel"
 	"github.com/ChainSafe/gossamer/lib/crypto"
 	bip39 "github.com/cosmos/go-bip39"
 	"github.com/gtank/merlin"
@@ -182,3 +183,45 @@ func TestVerifySignature(t *testing.T) {
 	}
 
 }
+
+func TestKeypair_Derive(t *testing.T) {
+	seed, err := (&crypto.SecretURI{Phrase: crypto.DevPhrase}).Seed()
+	require.NoError(t, err)
+	root, err := NewKeypairFromSeed(seed)
+	require.NoError(t, err)
+
+	alice, err := root.Derive([]crypto.DeriveJunction{crypto.NewDeriveJunction("Alice", true)})
+	require.NoError(t, err)
+	require.Equal(t, "0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d", alice.Public().Hex())
+
+	// the soft derived keys are the keys derived from the public key of their parent
+	testCases := map[string]struct {
+		hard []crypto.DeriveJunction
+		soft []crypto.DeriveJunction
+	}{
+		"soft": {
+			soft: []crypto.DeriveJunction{crypto.NewDeriveJunction("soft", false)},
+		},
+		"mixed": {
+			hard: []crypto.DeriveJunction{crypto.NewDeriveJunction("stash", true)},
+			soft: []crypto.DeriveJunction{crypto.NewDeriveJunction("0", false), crypto.NewDeriveJunction("1", false)},
+		},
+	}
+
+	for name, testCase := range testCases {
+		derived, err := alice.Derive(append(testCase.hard, testCase.soft...))
+		require.NoError(t, err, name)
+
+		parent, err := alice.Derive(testCase.hard)
+		require.NoError(t, err, name)
+
+		public := parent.public.key
+		for _, junction := range testCase.soft {
+			extended, err := sr25519.DeriveKeySoft(public, nil, junction.ChainCode)
+			require.NoError(t, err, name)
+			public, err = extended.Public()
+			require.NoError(t, err, name)
+		}
+		require.Equal(t, public.Encode(), derived.public.key.Encode(), name)
+	}
+}
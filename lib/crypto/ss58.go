@@ -0,0 +1,139 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package crypto
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+
+	"github.com/btcsuite/btcutil/base58"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// SubstrateSS58Prefix is the SS58 prefix of the generic substrate addresses
+	SubstrateSS58Prefix uint16 = 42
+
+	ss58ChecksumLength = 2
+	maxSS58Prefix      = 1<<14 - 1
+)
+
+var (
+	errInvalidSS58Address  = errors.New("invalid SS58 address")
+	errInvalidSS58Prefix   = errors.New("invalid SS58 prefix")
+	errInvalidSS58Checksum = errors.New("invalid SS58 checksum")
+)
+
+// ss58Networks are the SS58 prefixes of the well known networks, by name
+var ss58Networks = map[string]uint16{
+	"polkadot":  0,
+	"kusama":    2,
+	"westend":   42,
+	"substrate": SubstrateSS58Prefix,
+}
+
+// ParseSS58Network returns the SS58 prefix given a network name, or a prefix number
+func ParseSS58Network(network string) (uint16, error) {
+	if prefix, ok := ss58Networks[network]; ok {
+		return prefix, nil
+	}
+
+	prefix, err := strconv.ParseUint(network, 10, 16)
+	if err != nil || prefix > maxSS58Prefix {
+		return 0, fmt.Errorf("%w: unknown network %s", errInvalidSS58Prefix, network)
+	}
+
+	return uint16(prefix), nil
+}
+
+// EncodeSS58 returns the SS58 address of the account id given with the network prefix given
+// see: https://docs.substrate.io/reference/address-formats/
+func EncodeSS58(prefix uint16, accountID []byte) (common.Address, error) {
+	var encoded []byte
+	switch {
+	case prefix < 64:
+		encoded = []byte{byte(prefix)}
+	case prefix <= maxSS58Prefix:
+		// the lower six bits of the first byte are the bits 2 to 7 of the prefix, the second byte
+		// holds the bits 0 and 1 of the prefix in its upper bits and the bits 8 to 13 in its lower bits
+		encoded = []byte{
+			byte((prefix&0b1111_1100)>>2) | 0b0100_0000,
+			byte(prefix>>8) | byte((prefix&0b11)<<6),
+		}
+	default:
+		return "", fmt.Errorf("%w: %d", errInvalidSS58Prefix, prefix)
+	}
+
+	encoded = append(encoded, accountID...)
+	checksum, err := ss58Checksum(encoded)
+	if err != nil {
+		return "", err
+	}
+
+	return common.Address(base58.Encode(append(encoded, checksum...))), nil
+}
+
+// DecodeSS58 returns the network prefix and the account id of the SS58 address given
+func DecodeSS58(address common.Address) (prefix uint16, accountID []byte, err error) {
+	decoded := base58.Decode(string(address))
+	if len(decoded) < 1 {
+		return 0, nil, fmt.Errorf("%w: %s", errInvalidSS58Address, address)
+	}
+
+	prefixLength := 1
+	switch {
+	case decoded[0] < 64:
+		prefix = uint16(decoded[0])
+	case decoded[0] < 128:
+		if len(decoded) < 2 {
+			return 0, nil, fmt.Errorf("%w: %s", errInvalidSS58Address, address)
+		}
+		lower := (decoded[0] << 2) | (decoded[1] >> 6)
+		upper := decoded[1] & 0b0011_1111
+		prefix = uint16(lower) | uint16(upper)<<8
+		prefixLength = 2
+	default:
+		return 0, nil, fmt.Errorf("%w: first byte %d", errInvalidSS58Prefix, decoded[0])
+	}
+
+	if len(decoded) <= prefixLength+ss58ChecksumLength {
+		return 0, nil, fmt.Errorf("%w: %s", errInvalidSS58Address, address)
+	}
+
+	body := decoded[:len(decoded)-ss58ChecksumLength]
+	checksum, err := ss58Checksum(body)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if !bytes.Equal(checksum, decoded[len(body):]) {
+		return 0, nil, fmt.Errorf("%w: %s", errInvalidSS58Checksum, address)
+	}
+
+	return prefix, body[prefixLength:], nil
+}
+
+// ss58Checksum returns the checksum of the prefixed account id given
+func ss58Checksum(b []byte) ([]byte, error) {
+	hasher, err := blake2b.New(64, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = hasher.Write(ss58Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = hasher.Write(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return hasher.Sum(nil)[:ss58ChecksumLength], nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package crypto
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeSS58(t *testing.T) {
+	t.Parallel()
+
+	alice := common.MustHexToBytes("0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d")
+
+	testCases := map[string]struct {
+		network string
+		address common.Address
+	}{
+		"polkadot":  {network: "polkadot", address: "15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5"},
+		"kusama":    {network: "kusama", address: "HNZata7iMYWmk5RvZRTiAsSDhV8366zq2YGb3tLH5Upf74F"},
+		"substrate": {network: "42", address: "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			prefix, err := ParseSS58Network(testCase.network)
+			require.NoError(t, err)
+
+			address, err := EncodeSS58(prefix, alice)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.address, address)
+
+			decodedPrefix, accountID, err := DecodeSS58(address)
+			require.NoError(t, err)
+			assert.Equal(t, prefix, decodedPrefix)
+			assert.Equal(t, alice, accountID)
+		})
+	}
+}
+
+func TestDecodeSS58(t *testing.T) {
+	t.Parallel()
+
+	accountID := make([]byte, 32)
+
+	// two bytes prefixes
+	for _, prefix := range []uint16{64, 1000, maxSS58Prefix} {
+		address, err := EncodeSS58(prefix, accountID)
+		require.NoError(t, err)
+
+		decodedPrefix, decodedAccountID, err := DecodeSS58(address)
+		require.NoError(t, err)
+		assert.Equal(t, prefix, decodedPrefix)
+		assert.Equal(t, accountID, decodedAccountID)
+	}
+
+	_, err := EncodeSS58(maxSS58Prefix+1, accountID)
+	assert.ErrorIs(t, err, errInvalidSS58Prefix)
+
+	_, _, err = DecodeSS58("5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQZ")
+	assert.ErrorIs(t, err, errInvalidSS58Checksum)
+
+	_, _, err = DecodeSS58("")
+	assert.ErrorIs(t, err, errInvalidSS58Address)
+
+	_, err = ParseSS58Network("unknown")
+	assert.ErrorIs(t, err, errInvalidSS58Prefix)
+}
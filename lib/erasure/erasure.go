@@ -0,0 +1,246 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package erasure implements the erasure coding of the availability data of the parachain
+// candidates, compatible with the Reed-Solomon code of Polkadot over GF(2^16). The data
+// is split in a chunk for each validator, any third of the chunks recovering the data.
+package erasure
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+)
+
+// MaxValidators is the maximum number of validators the data can be erasure coded for
+const MaxValidators = fieldSize
+
+var (
+	errNotEnoughValidators = errors.New("not enough validators")
+	errTooManyValidators   = errors.New("too many validators")
+	errEmptyData           = errors.New("data is empty")
+	errNotEnoughChunks     = errors.New("not enough chunks")
+	errInvalidChunk        = errors.New("invalid chunk")
+)
+
+// RecoveryThreshold returns the number of chunks needed to recover the data erasure
+// coded for the number of validators given, which is a bit more than a third of them.
+func RecoveryThreshold(nValidators int) (int, error) {
+	switch {
+	case nValidators > MaxValidators:
+		return 0, fmt.Errorf("%w: %d, maximum %d", errTooManyValidators, nValidators, MaxValidators)
+	case nValidators <= 1:
+		return 0, fmt.Errorf("%w: %d, minimum 2", errNotEnoughValidators, nValidators)
+	}
+
+	return (nValidators-1)/3 + 1, nil
+}
+
+// codeParams returns the number of data symbols in each run of the code, which is the
+// recovery threshold lowered to a power of two.
+func codeParams(nValidators int) (k int, err error) {
+	threshold, err := RecoveryThreshold(nValidators)
+	if err != nil {
+		return 0, err
+	}
+
+	k = 1
+	for k*2 <= threshold {
+		k *= 2
+	}
+	return k, nil
+}
+
+// ObtainChunks erasure codes the data given, returning the chunk of each validator. The data
+// is split in runs of k symbols of two bytes, k being the recovery threshold lowered to a power
+// of two, each run being the values at the first k points of the polynomial of degree lower
+// than k it is interpolated to, and the symbol of the run in the chunk of validator i the value
+// of the polynomial at the point i. The chunks of the first k validators are the data itself.
+func ObtainChunks(nValidators int, data []byte) ([][]byte, error) {
+	k, err := codeParams(nValidators)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return nil, errEmptyData
+	}
+
+	runs := (len(data) + 2*k - 1) / (2 * k)
+	chunks := make([][]byte, nValidators)
+	for i := range chunks {
+		chunks[i] = make([]byte, 2*runs)
+	}
+
+	// the value of the Lagrange polynomial of the data point i at the point j is
+	// W(j) / (D * (j + i)), W vanishing on the data points and D being the product
+	// of the non zero data points, so the coefficients only depend on j + i.
+	logDenominator := logProduct(1, k, func(t int) uint16 { return uint16(t) })
+	logCoefficients := make([][]uint16, nValidators-k)
+	for j := k; j < nValidators; j++ {
+		logVanishing := logProduct(0, k, func(m int) uint16 { return uint16(j ^ m) })
+		logScale := addLogs(logVanishing, negLog(logDenominator))
+
+		coefficients := make([]uint16, k)
+		for i := range coefficients {
+			coefficients[i] = addLogs(logScale, negLog(logTable[j^i]))
+		}
+		logCoefficients[j-k] = coefficients
+	}
+
+	run := make([]uint16, k)
+	logRun := make([]uint16, k)
+	for r := 0; r < runs; r++ {
+		for i := range run {
+			run[i] = symbolAt(data, 2*(r*k+i))
+			logRun[i] = logTable[run[i]]
+			binary.BigEndian.PutUint16(chunks[i][2*r:], run[i])
+		}
+
+		for j := k; j < nValidators; j++ {
+			var value uint16
+			for i, coefficient := range logCoefficients[j-k] {
+				if run[i] != 0 {
+					value ^= expTable[addLogs(logRun[i], coefficient)]
+				}
+			}
+			binary.BigEndian.PutUint16(chunks[j][2*r:], value)
+		}
+	}
+
+	return chunks, nil
+}
+
+// Reconstruct recovers the data erasure coded for the number of validators given from the
+// chunks given by validator index, at least as many as the recovery threshold. The data
+// recovered is padded with zeros up to the size of the runs of the code.
+func Reconstruct(nValidators int, chunks map[int][]byte) ([]byte, error) {
+	k, err := codeParams(nValidators)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(chunks) < k {
+		return nil, fmt.Errorf("%w: %d chunks, %d needed", errNotEnoughChunks, len(chunks), k)
+	}
+
+	indices := make([]int, 0, len(chunks))
+	for index, chunk := range chunks {
+		if index < 0 || index >= nValidators {
+			return nil, fmt.Errorf("%w: index %d out of range", errInvalidChunk, index)
+		}
+		if len(chunk) == 0 || len(chunk)%2 != 0 {
+			return nil, fmt.Errorf("%w: chunk %d has size %d", errInvalidChunk, index, len(chunk))
+		}
+		indices = append(indices, index)
+	}
+	sort.Ints(indices)
+	indices = indices[:k]
+
+	chunkSize := len(chunks[indices[0]])
+	for _, index := range indices {
+		if len(chunks[index]) != chunkSize {
+			return nil, fmt.Errorf("%w: chunk %d has size %d, expected %d",
+				errInvalidChunk, index, len(chunks[index]), chunkSize)
+		}
+	}
+
+	// the data symbol t is the value at the point t of the polynomial interpolated
+	// from the values at the points of the chunks, the Lagrange polynomial of the
+	// point a being the product of (t + b) / (a + b) for the other points b.
+	logDenominators := make([]uint16, k)
+	for a, pointA := range indices {
+		logDenominators[a] = logProduct(0, k, func(b int) uint16 {
+			if b == a {
+				return 1
+			}
+			return uint16(pointA ^ indices[b])
+		})
+	}
+
+	logWeights := make([][]uint16, k)
+	known := make([]int, k)
+	for t := range logWeights {
+		known[t] = sort.SearchInts(indices, t)
+		if known[t] < k && indices[known[t]] == t {
+			continue
+		}
+		known[t] = -1
+
+		logNumerator := logProduct(0, k, func(b int) uint16 { return uint16(t ^ indices[b]) })
+		weights := make([]uint16, k)
+		for a, pointA := range indices {
+			logWeight := addLogs(logNumerator, negLog(logTable[t^pointA]))
+			weights[a] = addLogs(logWeight, negLog(logDenominators[a]))
+		}
+		logWeights[t] = weights
+	}
+
+	runs := chunkSize / 2
+	data := make([]byte, 2*k*runs)
+	values := make([]uint16, k)
+	for r := 0; r < runs; r++ {
+		for a, index := range indices {
+			values[a] = binary.BigEndian.Uint16(chunks[index][2*r:])
+		}
+
+		for t := 0; t < k; t++ {
+			var value uint16
+			if known[t] >= 0 {
+				value = values[known[t]]
+			} else {
+				for a, weight := range logWeights[t] {
+					value ^= mulLog(values[a], weight)
+				}
+			}
+			binary.BigEndian.PutUint16(data[2*(r*k+t):], value)
+		}
+	}
+
+	return data, nil
+}
+
+// ChunksRoot returns the erasure root of the chunks given, the root of the trie mapping the
+// little endian index of each chunk to the blake2b hash of the chunk.
+func ChunksRoot(chunks [][]byte) (common.Hash, error) {
+	entries := make(trie.Entries, len(chunks))
+	for i, chunk := range chunks {
+		chunkHash, err := common.Blake2bHash(chunk)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("hashing chunk %d: %w", i, err)
+		}
+
+		entries[i] = trie.Entry{
+			Key:   scale.MustMarshal(uint32(i)), //nolint:gosec
+			Value: chunkHash.ToBytes(),
+		}
+	}
+
+	return trie.V0.Root(inmemory.NewEmptyTrie(), entries)
+}
+
+// symbolAt returns the big endian symbol of the data at the offset given,
+// the data being padded with zeros.
+func symbolAt(data []byte, offset int) uint16 {
+	var symbol [2]byte
+	if offset < len(data) {
+		copy(symbol[:], data[offset:])
+	}
+	return binary.BigEndian.Uint16(symbol[:])
+}
+
+// logProduct returns the logarithm of the product of the elements returned by
+// the function given for the values in [start, end), which must not be zero.
+func logProduct(start, end int, element func(int) uint16) uint16 {
+	var sum uint64
+	for i := start; i < end; i++ {
+		sum += uint64(logTable[element(i)])
+	}
+	return uint16(sum % fieldModulus)
+}
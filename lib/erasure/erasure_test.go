@@ -0,0 +1,180 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package erasure
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_fieldTables(t *testing.T) {
+	t.Parallel()
+
+	seen := make(map[uint16]struct{}, fieldModulus)
+	for element := 1; element < fieldSize; element++ {
+		log := logTable[element]
+		require.Less(t, log, uint16(fieldModulus))
+		require.Equal(t, uint16(element), expTable[log])
+		seen[log] = struct{}{}
+	}
+	assert.Len(t, seen, fieldModulus)
+
+	// the first element of the Cantor basis is one, the other elements
+	// having their inverse as product with the element
+	assert.Equal(t, uint16(0), logTable[1])
+	for _, element := range []uint16{2, 3, 0x1234, 0xffff} {
+		inverse := expTable[negLog(logTable[element])]
+		assert.Equal(t, uint16(1), mulLog(element, logTable[inverse]))
+	}
+}
+
+func TestRecoveryThreshold(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[int]int{2: 1, 3: 1, 4: 2, 10: 4, 100: 34, 297: 99, MaxValidators: 21846}
+	for nValidators, threshold := range testCases {
+		actual, err := RecoveryThreshold(nValidators)
+		require.NoError(t, err)
+		assert.Equal(t, threshold, actual, "validators %d", nValidators)
+	}
+
+	_, err := RecoveryThreshold(1)
+	assert.True(t, errors.Is(err, errNotEnoughValidators), err)
+
+	_, err = RecoveryThreshold(MaxValidators + 1)
+	assert.True(t, errors.Is(err, errTooManyValidators), err)
+}
+
+func testData(size int) []byte {
+	data := make([]byte, size)
+	for i := range data {
+		data[i] = byte(i*7 + 3)
+	}
+	return data
+}
+
+func TestObtainChunks(t *testing.T) {
+	t.Parallel()
+
+	data := testData(1000)
+	chunks, err := ObtainChunks(10, data)
+	require.NoError(t, err)
+	require.Len(t, chunks, 10)
+
+	// the recovery threshold of 10 validators is 4, so each run has 4 symbols
+	// and the chunks of the first 4 validators are the data itself
+	for _, chunk := range chunks {
+		assert.Len(t, chunk, 2*((len(data)+7)/8))
+	}
+	assert.Equal(t, data[:2], chunks[0][:2])
+	assert.Equal(t, data[6:8], chunks[3][:2])
+	assert.Equal(t, data[8:10], chunks[0][2:4])
+
+	_, err = ObtainChunks(1, data)
+	assert.True(t, errors.Is(err, errNotEnoughValidators), err)
+
+	_, err = ObtainChunks(10, nil)
+	assert.True(t, errors.Is(err, errEmptyData), err)
+}
+
+func TestReconstruct(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		nValidators int
+		dataSize    int
+		indices     []int
+		errSentinel error
+	}{
+		"systematic_chunks": {
+			nValidators: 10,
+			dataSize:    1000,
+			indices:     []int{0, 1, 2, 3},
+		},
+		"parity_chunks": {
+			nValidators: 10,
+			dataSize:    1000,
+			indices:     []int{6, 7, 8, 9},
+		},
+		"mixed_chunks": {
+			nValidators: 100,
+			dataSize:    4099,
+			indices: []int{1, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37, 41, 43, 47, 53,
+				59, 61, 67, 71, 73, 79, 83, 89, 97, 98, 99, 0, 2, 4, 6, 8},
+		},
+		"two_validators": {
+			nValidators: 2,
+			dataSize:    33,
+			indices:     []int{1},
+		},
+		"not_enough_chunks": {
+			nValidators: 10,
+			dataSize:    1000,
+			indices:     []int{2, 5, 9},
+			errSentinel: errNotEnoughChunks,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			data := testData(testCase.dataSize)
+			chunks, err := ObtainChunks(testCase.nValidators, data)
+			require.NoError(t, err)
+
+			available := make(map[int][]byte, len(testCase.indices))
+			for _, index := range testCase.indices {
+				available[index] = chunks[index]
+			}
+
+			recovered, err := Reconstruct(testCase.nValidators, available)
+			if testCase.errSentinel != nil {
+				assert.True(t, errors.Is(err, testCase.errSentinel), err)
+				return
+			}
+			require.NoError(t, err)
+
+			require.GreaterOrEqual(t, len(recovered), len(data))
+			assert.Equal(t, data, recovered[:len(data)])
+			assert.Equal(t, make([]byte, len(recovered)-len(data)), recovered[len(data):])
+		})
+	}
+}
+
+func TestReconstruct_invalidChunks(t *testing.T) {
+	t.Parallel()
+
+	chunks, err := ObtainChunks(4, testData(100))
+	require.NoError(t, err)
+
+	_, err = Reconstruct(4, map[int][]byte{1: chunks[1], 4: chunks[3]})
+	assert.True(t, errors.Is(err, errInvalidChunk), err)
+
+	_, err = Reconstruct(4, map[int][]byte{1: chunks[1], 2: chunks[2][:4]})
+	assert.True(t, errors.Is(err, errInvalidChunk), err)
+}
+
+func TestChunksRoot(t *testing.T) {
+	t.Parallel()
+
+	chunks, err := ObtainChunks(10, testData(1000))
+	require.NoError(t, err)
+
+	root, err := ChunksRoot(chunks)
+	require.NoError(t, err)
+
+	sameRoot, err := ChunksRoot(chunks)
+	require.NoError(t, err)
+	assert.Equal(t, root, sameRoot)
+
+	chunks[9][0] ^= 1
+	otherRoot, err := ChunksRoot(chunks)
+	require.NoError(t, err)
+	assert.NotEqual(t, root, otherRoot)
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package erasure
+
+// The erasure code works over the field GF(2^16), whose elements are represented in the
+// Cantor basis, as the additive representation of the novel polynomial basis codec used by
+// Polkadot. In this representation the field addition is the exclusive or, so the subspace
+// spanned by the first elements of the basis is the set of the indices lower than its size.
+const (
+	fieldBits = 16
+	fieldSize = 1 << fieldBits
+	// fieldModulus is the order of the multiplicative group of the field
+	fieldModulus = fieldSize - 1
+	// fieldPolynomial is the irreducible polynomial x^16 + x^5 + x^3 + x^2 + 1 defining the field
+	fieldPolynomial = 0x1002D
+)
+
+// cantorBasis is the Cantor basis of the field, in the polynomial representation
+var cantorBasis = [fieldBits]uint16{
+	1, 44234, 15374, 5694, 50562, 60718, 37196, 16402,
+	27800, 4312, 27250, 47360, 64952, 64308, 65336, 39198,
+}
+
+var (
+	// logTable maps an element to its discrete logarithm, zero having the logarithm fieldModulus
+	logTable [fieldSize]uint16
+	// expTable maps a discrete logarithm to its element, the logarithm fieldModulus mapping to one
+	expTable [fieldSize]uint16
+)
+
+func init() {
+	// the discrete logarithms of the elements in the polynomial representation
+	state := uint32(1)
+	for i := uint32(0); i < fieldModulus; i++ {
+		expTable[state] = uint16(i)
+		state <<= 1
+		if state >= fieldSize {
+			state ^= fieldPolynomial
+		}
+	}
+	expTable[0] = fieldModulus
+
+	// the polynomial representation of the elements in the Cantor basis
+	logTable[0] = 0
+	for i := 0; i < fieldBits; i++ {
+		for j := 0; j < 1<<i; j++ {
+			logTable[j+1<<i] = logTable[j] ^ cantorBasis[i]
+		}
+	}
+
+	for i := range logTable {
+		logTable[i] = expTable[logTable[i]]
+	}
+
+	for i := range logTable {
+		expTable[logTable[i]] = uint16(i)
+	}
+	expTable[fieldModulus] = expTable[0]
+}
+
+// addLogs returns the sum modulo fieldModulus of the logarithms given
+func addLogs(a, b uint16) uint16 {
+	sum := uint32(a) + uint32(b)
+	return uint16((sum & fieldModulus) + (sum >> fieldBits))
+}
+
+// negLog returns the logarithm of the inverse of the element of the logarithm given
+func negLog(a uint16) uint16 {
+	return fieldModulus - a
+}
+
+// mulLog returns the product of the element a and of the element of logarithm logB
+func mulLog(a, logB uint16) uint16 {
+	if a == 0 {
+		return 0
+	}
+	return expTable[addLogs(logTable[a], logB)]
+}
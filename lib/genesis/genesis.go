@@ -5,11 +5,16 @@
 
 import (
 	"encoding/json"
+	"errors"
 
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 )
 
+// ErrRuntimeGenesis is returned when converting to raw a genesis whose storage is built by the
+// GenesisBuilder runtime API.
+var ErrRuntimeGenesis = errors.New("genesis storage is built by the runtime")
+
 // Genesis stores the data parsed from the genesis configuration file
 type Genesis struct {
 	Name               string                 `json:"name"`
@@ -24,6 +29,17 @@ type Genesis struct {
 	BadBlocks          []string               `json:"badBlocks"`
 	ConsensusEngine    string                 `json:"consensusEngine"`
 	CodeSubstitutes    map[string]string      `json:"codeSubstitutes"`
+	LightSyncState     *LightSyncState        `json:"lightSyncState,omitempty"`
+}
+
+// LightSyncState is the state of the highest finalised block embedded in a chain spec,
+// for light clients to start syncing from it instead of the genesis block.
+// Its hex fields are SCALE encoded.
+type LightSyncState struct {
+	FinalizedBlockHeader     string `json:"finalizedBlockHeader"`
+	BabeEpochChanges         string `json:"babeEpochChanges"`
+	BabeFinalizedBlockWeight uint32 `json:"babeFinalizedBlockWeight"`
+	GrandpaAuthoritySet      string `json:"grandpaAuthoritySet"`
 }
 
 // Data defines the genesis file data formatted for trie storage
@@ -49,8 +65,18 @@ type TelemetryEndpoint struct {
 
 // Fields stores genesis raw data, and human readable runtime data
 type Fields struct {
-	Raw     map[string]map[string]string `json:"raw,omitempty"`
-	Runtime *Runtime                     `json:"runtime,omitempty"`
+	Raw            map[string]map[string]string `json:"raw,omitempty"`
+	Runtime        *Runtime                     `json:"runtime,omitempty"`
+	RuntimeGenesis *RuntimeGenesis              `json:"runtimeGenesis,omitempty"`
+}
+
+// RuntimeGenesis is the genesis of a runtime exposing the GenesisBuilder runtime API, whose
+// storage is built by the runtime from the JSON genesis config given, or from its default
+// genesis config patched with the JSON patch given.
+type RuntimeGenesis struct {
+	Code   string          `json:"code"`
+	Config json.RawMessage `json:"config,omitempty"`
+	Patch  json.RawMessage `json:"patch,omitempty"`
 }
 
 // Runtime is the structure of the genesis runtime field.
@@ -226,14 +252,18 @@ func (g *Genesis) GenesisFields() Fields {
 
 // IsRaw returns whether the genesis is raw or not
 func (g *Genesis) IsRaw() bool {
-	return g.Genesis.Raw != nil || g.Genesis.Runtime == nil
+	return g.Genesis.Raw != nil || (g.Genesis.Runtime == nil && g.Genesis.RuntimeGenesis == nil)
 }
 
-// ToRaw converts a non-raw genesis to a raw genesis
+// ToRaw converts a non-raw genesis to a raw genesis. It returns ErrRuntimeGenesis if the
+// genesis storage has to be built by the runtime.
 func (g *Genesis) ToRaw() error {
 	if g.IsRaw() {
 		return nil
 	}
+	if g.Genesis.Runtime == nil {
+		return ErrRuntimeGenesis
+	}
 
 	grt := g.Genesis.Runtime
 	res, err := buildRawMap(*grt)
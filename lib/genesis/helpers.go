@@ -81,6 +81,11 @@ func NewGenesisFromJSON(file string, authCount int) (*Genesis, error) {
 		trimGenesisAuthority(g, authCount)
 	}
 
+	if g.Genesis.Runtime == nil && g.Genesis.RuntimeGenesis != nil {
+		// the raw genesis is built by the runtime
+		return g, nil
+	}
+
 	g.Genesis.Raw = make(map[string]map[string]string)
 	grt := g.Genesis.Runtime
 	if grt == nil {
@@ -0,0 +1,98 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package genesis
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+var errNoRuntimeGenesisCode = errors.New("runtime genesis code is empty")
+
+// DecodeCode returns the runtime code of the runtime genesis.
+func (r *RuntimeGenesis) DecodeCode() ([]byte, error) {
+	if r.Code == "" {
+		return nil, errNoRuntimeGenesisCode
+	}
+
+	code, err := common.HexToBytes(r.Code)
+	if err != nil {
+		return nil, fmt.Errorf("decoding runtime genesis code: %w", err)
+	}
+	return code, nil
+}
+
+// BuildConfig returns the JSON genesis config the runtime storage is built from, which is the
+// config of the runtime genesis if it is set, and otherwise the default config returned by the
+// function given patched with the patch of the runtime genesis.
+func (r *RuntimeGenesis) BuildConfig(defaultConfig func() ([]byte, error)) ([]byte, error) {
+	if len(r.Config) > 0 {
+		if len(r.Patch) > 0 {
+			return nil, errors.New("runtime genesis cannot have both a config and a patch")
+		}
+		return r.Config, nil
+	}
+
+	config, err := defaultConfig()
+	if err != nil {
+		return nil, fmt.Errorf("getting default genesis config: %w", err)
+	}
+	if len(r.Patch) == 0 {
+		return config, nil
+	}
+
+	patched, err := MergeConfigPatch(config, r.Patch)
+	if err != nil {
+		return nil, fmt.Errorf("patching default genesis config: %w", err)
+	}
+	return patched, nil
+}
+
+// MergeConfigPatch merges the JSON patch given into the JSON genesis config given, the
+// objects being merged recursively, a null value removing the key and any other value
+// replacing the value of the config. The numbers are kept as they are so the balances
+// larger than 2^53 are not rounded.
+func MergeConfigPatch(config, patch []byte) ([]byte, error) {
+	decodedConfig, err := decodeJSON(config)
+	if err != nil {
+		return nil, fmt.Errorf("decoding config: %w", err)
+	}
+	decodedPatch, err := decodeJSON(patch)
+	if err != nil {
+		return nil, fmt.Errorf("decoding patch: %w", err)
+	}
+
+	return json.Marshal(mergeJSON(decodedConfig, decodedPatch))
+}
+
+func decodeJSON(data []byte) (value interface{}, err error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	err = decoder.Decode(&value)
+	return value, err
+}
+
+func mergeJSON(base, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	baseObject, ok := base.(map[string]interface{})
+	if !ok {
+		baseObject = make(map[string]interface{}, len(patchObject))
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(baseObject, key)
+			continue
+		}
+		baseObject[key] = mergeJSON(baseObject[key], value)
+	}
+	return baseObject
+}
@@ -0,0 +1,88 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package genesis
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeConfigPatch(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		config   string
+		patch    string
+		expected string
+	}{
+		"nested_objects_merged": {
+			config:   `{"balances":{"balances":[]},"sudo":{"key":null}}`,
+			patch:    `{"sudo":{"key":"5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"}}`,
+			expected: `{"balances":{"balances":[]},"sudo":{"key":"5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"}}`,
+		},
+		"arrays_replaced": {
+			config:   `{"babe":{"authorities":[["a",1],["b",1]],"epochConfig":{"c":[1,4]}}}`,
+			patch:    `{"babe":{"authorities":[["c",1]]}}`,
+			expected: `{"babe":{"authorities":[["c",1]],"epochConfig":{"c":[1,4]}}}`,
+		},
+		"null_removes_key": {
+			config:   `{"sudo":{"key":"a"},"system":{}}`,
+			patch:    `{"sudo":null}`,
+			expected: `{"system":{}}`,
+		},
+		"large_numbers_kept": {
+			config:   `{"balances":{"balances":[]}}`,
+			patch:    `{"balances":{"balances":[["a",1000000000000000000000]]}}`,
+			expected: `{"balances":{"balances":[["a",1000000000000000000000]]}}`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			merged, err := MergeConfigPatch([]byte(testCase.config), []byte(testCase.patch))
+			require.NoError(t, err)
+			assert.JSONEq(t, testCase.expected, string(merged))
+		})
+	}
+}
+
+func TestRuntimeGenesis_BuildConfig(t *testing.T) {
+	t.Parallel()
+
+	defaultConfig := func() ([]byte, error) {
+		return []byte(`{"sudo":{"key":null}}`), nil
+	}
+
+	config, err := (&RuntimeGenesis{}).BuildConfig(defaultConfig)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"sudo":{"key":null}}`, string(config))
+
+	config, err = (&RuntimeGenesis{Patch: json.RawMessage(`{"sudo":{"key":"a"}}`)}).BuildConfig(defaultConfig)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"sudo":{"key":"a"}}`, string(config))
+
+	errDefaultConfig := errors.New("test error")
+	config, err = (&RuntimeGenesis{Config: json.RawMessage(`{"system":{}}`)}).BuildConfig(
+		func() ([]byte, error) { return nil, errDefaultConfig })
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"system":{}}`, string(config))
+
+	_, err = (&RuntimeGenesis{}).BuildConfig(func() ([]byte, error) { return nil, errDefaultConfig })
+	assert.ErrorIs(t, err, errDefaultConfig)
+}
+
+func TestGenesis_ToRaw_runtimeGenesis(t *testing.T) {
+	t.Parallel()
+
+	gen := &Genesis{Genesis: Fields{RuntimeGenesis: &RuntimeGenesis{Code: "0x00"}}}
+	assert.False(t, gen.IsRaw())
+	assert.ErrorIs(t, gen.ToRaw(), ErrRuntimeGenesis)
+}
@@ -63,7 +63,7 @@
 	ErrInvalidCatchUpRound = errors.New("catch up request is for future round")
 
 	// ErrInvalidCatchUpResponseRound is returned when a catch-up response is received with an invalid round
-	ErrInvalidCatchUpResponseRound = errors.New("catch up response is not for previous round")
+	ErrInvalidCatchUpResponseRound = errors.New("catch up response is for a past round")
 
 	// ErrGHOSTlessCatchUp is returned when a catch up response
 	// does not contain a valid grandpa-GHOST (ie. finalised block)
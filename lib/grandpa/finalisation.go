@@ -234,6 +234,14 @@ func (h *votingRoundHandler) Run() (err error) {
 		case action := <-h.finalisationEngineCh:
 			switch action {
 			case determinePrevote:
+				paused, err := h.grandpaService.isVotingPaused()
+				if err != nil {
+					return fmt.Errorf("checking if voting is paused: %w", err)
+				} else if paused {
+					logger.Infof("voting paused, not pre-voting in round %d", h.grandpaService.state.round)
+					continue
+				}
+
 				isPrimary, err := h.grandpaService.handleIsPrimary()
 				if err != nil {
 					return fmt.Errorf("handling primary: %w", err)
@@ -244,6 +252,16 @@ func (h *votingRoundHandler) Run() (err error) {
 					return fmt.Errorf("determining pre-vote: %w", err)
 				}
 
+				preVote, err = h.grandpaService.restrictVote(preVote)
+				if err != nil {
+					return fmt.Errorf("restricting pre-vote: %w", err)
+				}
+
+				preVote, err = h.grandpaService.castVote(preVote, prevote)
+				if err != nil {
+					return fmt.Errorf("casting pre-vote: %w", err)
+				}
+
 				signedpreVote, prevoteMessage, err :=
 					h.grandpaService.createSignedVoteAndVoteMessage(preVote, prevote)
 				if err != nil {
@@ -261,11 +279,24 @@ func (h *votingRoundHandler) Run() (err error) {
 				}
 
 			case determinePrecommit:
+				paused, err := h.grandpaService.isVotingPaused()
+				if err != nil {
+					return fmt.Errorf("checking if voting is paused: %w", err)
+				} else if paused {
+					logger.Infof("voting paused, not pre-committing in round %d", h.grandpaService.state.round)
+					continue
+				}
+
 				preCommit, err := h.grandpaService.determinePreCommit()
 				if err != nil {
 					return fmt.Errorf("determining pre-commit: %w", err)
 				}
 
+				preCommit, err = h.grandpaService.castVote(preCommit, precommit)
+				if err != nil {
+					return fmt.Errorf("casting pre-commit: %w", err)
+				}
+
 				signedPreCommit, precommitMessage, err :=
 					h.grandpaService.createSignedVoteAndVoteMessage(preCommit, precommit)
 				if err != nil {
@@ -277,6 +308,7 @@ func (h *votingRoundHandler) Run() (err error) {
 				err = h.grandpaService.sendPrecommitMessage(precommitMessage)
 				if err != nil {
 					logger.Errorf("sending pre-commit message: %s", err)
+					h.grandpaService.heartbeat.Fail(fmt.Errorf("sending pre-commit message: %w", err))
 				}
 
 			case finalize:
@@ -9,6 +9,7 @@
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -16,11 +17,14 @@
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/telemetry"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/chaos"
 	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/internal/watchdog"
 	"github.com/ChainSafe/gossamer/lib/blocktree"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/services"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/prometheus/client_golang/prometheus"
@@ -51,15 +55,26 @@ type Service struct {
 	blockState     BlockState
 	grandpaState   GrandpaState
 	keypair        *ed25519.Keypair // TODO: change to grandpa keystore (#1870)
+	keystore       Keystore         // keystore the keypair is switched from when the voters change, if set
 	mapLock        sync.Mutex
 	chanLock       sync.Mutex
 	roundLock      sync.Mutex
 	authority      bool          // run the service as an authority (ie participate in voting)
 	paused         atomic.Value  // the service will be paused if it is waiting for catch up responses
 	resumed        chan struct{} // this channel will be closed when the service resumes
+	votingPaused   atomic.Bool   // the service does not vote while paused, see PauseVoting
+	votingPause    *VotingPause  // the voting pause set from the command line, if any
 	messageHandler *MessageHandler
 	network        Network
 	interval       time.Duration
+	chaos          *chaos.Injector // delays the finalisations if set, for testing
+	// restartVoter receives the restarts of the voter, with the channel its result is sent on
+	restartVoter chan chan error
+	// voterDone is closed once the last voter started returned
+	voterDone  chan struct{}
+	voterMutex sync.Mutex
+	// heartbeat records the rounds started by the voter, for the watchdog
+	heartbeat watchdog.Heartbeat
 
 	// current state information
 	state *State // current state
@@ -93,9 +108,12 @@ type Config struct {
 	Network      Network
 	Voters       []Voter
 	Keypair      *ed25519.Keypair
+	Keystore     Keystore
 	Authority    bool
 	Interval     time.Duration
 	Telemetry    Telemetry
+	VotingPause  *VotingPause
+	Chaos        *chaos.Injector
 }
 
 // NewService returns a new GRANDPA Service instance.
@@ -143,6 +161,7 @@ func NewService(cfg *Config) (*Service, error) {
 		blockState:         cfg.BlockState,
 		grandpaState:       cfg.GrandpaState,
 		keypair:            cfg.Keypair,
+		keystore:           cfg.Keystore,
 		authority:          cfg.Authority,
 		prevotes:           new(sync.Map),
 		precommits:         new(sync.Map),
@@ -157,9 +176,17 @@ func NewService(cfg *Config) (*Service, error) {
 		interval:           cfg.Interval,
 		telemetry:          cfg.Telemetry,
 		neighborMsgChan:    neighborMsgChan,
+		votingPause:        cfg.VotingPause,
+		chaos:              cfg.Chaos,
+		restartVoter:       make(chan chan error),
+	}
+
+	if s.authority {
+		s.selectKeypair()
 	}
 
 	s.neighborTracker = newNeighborTracker(s, neighborMsgChan)
+	s.neighborTracker.updateState(setID, round, uint32(head.Number)) //nolint:gosec
 
 	if err := s.registerProtocol(); err != nil {
 		return nil, err
@@ -182,15 +209,25 @@ func (s *Service) Start() error {
 	}
 
 	s.tracker.start()
+	s.startVoter()
+	return nil
+}
+
+// startVoter starts the voter in a new goroutine, closing voterDone once it returns
+func (s *Service) startVoter() {
+	voterDone := make(chan struct{})
+	s.voterMutex.Lock()
+	s.voterDone = voterDone
+	s.voterMutex.Unlock()
 
 	go func() {
+		defer close(voterDone)
+		defer services.Recover(s)
 		err := s.initiate()
 		if err != nil {
 			panic(fmt.Sprintf("running grandpa service: %s", err))
 		}
 	}()
-
-	return nil
 }
 
 // Stop stops the GRANDPA finality service
@@ -247,6 +284,7 @@ func (s *Service) updateAuthorities() error {
 
 	s.state.voters = nextAuthorities
 	s.state.setID = currSetID
+	s.selectKeypair()
 	// round resets to 1 after a set ID change,
 	// setting to 0 before incrementing indicates
 	// the setID has been increased
@@ -258,6 +296,39 @@ func (s *Service) updateAuthorities() error {
 	return nil
 }
 
+// selectKeypair switches the keypair to a keypair of the keystore found in the voter set,
+// if the current keypair is not in it, so that the session keys rotated and set on chain
+// are used from the voter set they are part of.
+func (s *Service) selectKeypair() {
+	if s.keystore == nil {
+		return
+	}
+
+	isVoter := func(kp *ed25519.Keypair) bool {
+		return slices.ContainsFunc(s.state.voters, func(voter types.GrandpaVoter) bool {
+			return bytes.Equal(voter.Key.Encode(), kp.Public().Encode())
+		})
+	}
+
+	if s.keypair != nil && isVoter(s.keypair) {
+		return
+	}
+
+	for _, kp := range s.keystore.Keypairs() {
+		keypair, ok := kp.(*ed25519.Keypair)
+		if !ok || !isVoter(keypair) {
+			continue
+		}
+
+		if s.keypair != nil {
+			logger.Infof("switching GRANDPA authority key from %s to %s for set id %d",
+				s.keypair.Public().Hex(), keypair.Public().Hex(), s.state.setID)
+		}
+		s.keypair = keypair
+		return
+	}
+}
+
 func (s *Service) publicKeyBytes() ed25519.PublicKeyBytes {
 	return s.keypair.Public().(*ed25519.PublicKey).AsBytes()
 }
@@ -341,6 +412,7 @@ func (s *Service) initiateRound() error {
 	s.pvEquivocations = make(map[ed25519.PublicKeyBytes][]*SignedVote)
 	s.pcEquivocations = make(map[ed25519.PublicKeyBytes][]*SignedVote)
 
+	s.heartbeat.Beat()
 	return nil
 }
 
@@ -357,11 +429,66 @@ func (s *Service) initiate() error {
 		case <-s.ctx.Done():
 			return finalisationHandler.Stop()
 		case err := <-errorCh:
+			s.heartbeat.Fail(err)
 			return err
+		case done := <-s.restartVoter:
+			// the round in progress is abandoned, the voter resuming from the next round
+			err = finalisationHandler.Stop()
+			if err != nil {
+				logger.Warnf("stopping finalisation handler: %s", err)
+			}
+
+			finalisationHandler = newFinalisationHandler(s)
+			errorCh, err = finalisationHandler.Start()
+			done <- err
+			if err != nil {
+				return fmt.Errorf("restarting finalisation handler: %w", err)
+			}
 		}
 	}
 }
 
+// Progress returns the progress of the voter, which is idle if the service is not an
+// authority or its voting is paused.
+func (s *Service) Progress() watchdog.Progress {
+	progress := s.heartbeat.Progress()
+	progress.Idle = !s.authority || s.IsVotingPaused() || s.ctx.Err() != nil
+	return progress
+}
+
+// Restart restarts the voter, abandoning the round in progress to vote from the next round.
+// The voter is started again if it returned, such as after a panic.
+func (s *Service) Restart() error {
+	if !s.authority {
+		return nil
+	}
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+
+	s.voterMutex.Lock()
+	voterDone := s.voterDone
+	s.voterMutex.Unlock()
+
+	done := make(chan error, 1)
+	select {
+	case s.restartVoter <- done:
+	case <-voterDone:
+		logger.Warn("starting the voter which returned")
+		s.startVoter()
+		return nil
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+
+	select {
+	case err := <-done:
+		return err
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
 func (s *Service) handleIsPrimary() (bool, error) {
 	// derive primary
 	primary := s.derivePrimary()
@@ -386,6 +513,11 @@ func (s *Service) handleIsPrimary() (bool, error) {
 		Number: uint32(best.Number), //nolint:gosec
 	}
 
+	pv, err = s.castVote(pv, primaryProposal)
+	if err != nil {
+		return false, fmt.Errorf("casting primary proposal: %w", err)
+	}
+
 	// send primary prevote message to network
 	spv, primProposal, err := s.createSignedVoteAndVoteMessage(pv, primaryProposal)
 	if err != nil {
@@ -626,8 +758,64 @@ func (s *Service) determinePreCommit() (*Vote, error) {
 	return &pvb, nil
 }
 
+// castVote persists the vote given as the vote of the stage given cast in the current round,
+// before it is broadcast. If a vote of the stage was already cast in the round, before the
+// node restarted, that vote is returned instead, so that the voter does not equivocate.
+func (s *Service) castVote(vote *Vote, stage Subround) (*Vote, error) {
+	voterState, err := s.grandpaState.GetVoterState()
+	if err != nil && !errors.Is(err, database.ErrNotFound) {
+		return nil, fmt.Errorf("getting voter state: %w", err)
+	}
+
+	if voterState == nil || voterState.SetID != s.state.setID || voterState.Round != s.state.round {
+		voterState = &types.GrandpaVoterState{
+			SetID: s.state.setID,
+			Round: s.state.round,
+		}
+	}
+
+	var cast **Vote
+	switch stage {
+	case primaryProposal:
+		cast = &voterState.PrimaryProposal
+	case prevote:
+		cast = &voterState.Prevote
+	case precommit:
+		cast = &voterState.Precommit
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedSubround, stage)
+	}
+
+	if *cast != nil {
+		if **cast != *vote {
+			logger.Infof("keeping %s vote %s cast before restarting in round %d and set id %d, instead of %s",
+				stage, *cast, s.state.round, s.state.setID, vote)
+		}
+		return *cast, nil
+	}
+
+	*cast = vote
+	err = s.grandpaState.SetVoterState(voterState)
+	if err != nil {
+		return nil, fmt.Errorf("setting voter state: %w", err)
+	}
+
+	return vote, nil
+}
+
 // finalise finalises the round by setting the best final candidate for this round
 func (s *Service) finalise() error {
+	if delay := s.chaos.FinalityDelay(); delay > 0 {
+		logger.Debugf("chaos: delaying the finalisation of round %d by %s", s.state.round, delay)
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-s.ctx.Done():
+			timer.Stop()
+			return s.ctx.Err()
+		}
+	}
+
 	// get best final candidate
 	bfc, err := s.getBestFinalCandidate()
 	if err != nil {
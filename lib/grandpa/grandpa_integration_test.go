@@ -987,6 +987,86 @@ func TestDeterminePreVote_WithInvalidPrimaryPreVote(t *testing.T) {
 	require.Equal(t, gs.head.Hash(), pv.Hash)
 }
 
+func TestCastVote_RestartMidRound(t *testing.T) {
+	t.Parallel()
+
+	kr, err := keystore.NewEd25519Keyring()
+	require.NoError(t, err)
+	aliceKeyPair := kr.Alice().(*ed25519.Keypair)
+
+	gs, st := newTestService(t, aliceKeyPair)
+	state.AddBlocksToState(t, st.Block, 3, false)
+
+	// restart stops the voter and starts a new one on the same state, resuming in the same round
+	restart := func(gs *Service) *Service {
+		t.Helper()
+		require.NoError(t, gs.Stop())
+
+		restarted, err := NewService(&Config{
+			BlockState:   st.Block,
+			GrandpaState: st.Grandpa,
+			Voters:       gs.state.voters,
+			Authority:    true,
+			Network:      gs.network,
+			Interval:     gs.interval,
+			Telemetry:    gs.telemetry,
+			Keypair:      aliceKeyPair,
+		})
+		require.NoError(t, err)
+		restarted.state.round = gs.state.round
+		return restarted
+	}
+
+	gs.state.round = 1
+	header, err := st.Block.BestBlockHeader()
+	require.NoError(t, err)
+	firstVote := NewVoteFromHeader(header)
+
+	pv, err := gs.castVote(firstVote, prevote)
+	require.NoError(t, err)
+	require.Equal(t, firstVote, pv)
+
+	// the node restarts after prevoting, with a new best block
+	gs = restart(gs)
+	state.AddBlocksToState(t, st.Block, 1, false)
+	header, err = st.Block.BestBlockHeader()
+	require.NoError(t, err)
+	secondVote := NewVoteFromHeader(header)
+
+	pv, err = gs.castVote(secondVote, prevote)
+	require.NoError(t, err)
+	require.Equal(t, firstVote, pv)
+
+	pc, err := gs.castVote(secondVote, precommit)
+	require.NoError(t, err)
+	require.Equal(t, secondVote, pc)
+
+	// the node restarts after precommitting
+	gs = restart(gs)
+	pv, err = gs.castVote(secondVote, prevote)
+	require.NoError(t, err)
+	require.Equal(t, firstVote, pv)
+
+	pc, err = gs.castVote(firstVote, precommit)
+	require.NoError(t, err)
+	require.Equal(t, secondVote, pc)
+
+	// the votes of a previous round do not prevent voting in the next round
+	gs.state.round = 2
+	pv, err = gs.castVote(secondVote, prevote)
+	require.NoError(t, err)
+	require.Equal(t, secondVote, pv)
+
+	voterState, err := st.Grandpa.GetVoterState()
+	require.NoError(t, err)
+	expected := &types.GrandpaVoterState{
+		SetID:   0,
+		Round:   2,
+		Prevote: secondVote,
+	}
+	require.Equal(t, expected, voterState)
+}
+
 func TestGetGrandpaGHOST_CommonAncestor(t *testing.T) {
 	t.Parallel()
 
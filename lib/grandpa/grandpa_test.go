@@ -0,0 +1,207 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestService_selectKeypair(t *testing.T) {
+	t.Parallel()
+
+	kr, err := keystore.NewEd25519Keyring()
+	require.NoError(t, err)
+
+	alice := kr.Alice().(*ed25519.Keypair)
+	bob := kr.Bob().(*ed25519.Keypair)
+
+	ks := keystore.NewBasicKeystore(keystore.GranName, crypto.Ed25519Type)
+	require.NoError(t, ks.Insert(alice))
+	require.NoError(t, ks.Insert(bob))
+
+	voter := func(kp keystore.KeyPair, id uint64) Voter {
+		return Voter{Key: *kp.Public().(*ed25519.PublicKey), ID: id}
+	}
+
+	testCases := map[string]struct {
+		voters   []Voter
+		expected *ed25519.Keypair
+	}{
+		"current_keypair_is_voter": {
+			voters:   []Voter{voter(bob, 0), voter(alice, 1)},
+			expected: alice,
+		},
+		"switch_to_keystore_keypair": {
+			voters:   []Voter{voter(bob, 0)},
+			expected: bob,
+		},
+		"no_keystore_keypair_is_voter": {
+			voters:   []Voter{voter(kr.Charlie(), 0)},
+			expected: alice,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			service := &Service{
+				keypair:  alice,
+				keystore: ks,
+				state:    &State{voters: testCase.voters},
+			}
+
+			service.selectKeypair()
+			require.Equal(t, testCase.expected, service.keypair)
+		})
+	}
+}
+
+func TestService_restrictVote(t *testing.T) {
+	t.Parallel()
+
+	// chain of headers 0 <- 1 <- ... <- 5
+	headers := make([]*types.Header, 6)
+	for number := range headers {
+		headers[number] = &types.Header{Number: uint(number)}
+		if number > 0 {
+			headers[number].ParentHash = headers[number-1].Hash()
+		}
+	}
+	vote := NewVoteFromHeader(headers[5])
+
+	testCases := map[string]struct {
+		votingPause *VotingPause
+		bestNumber  uint
+		expected    *Vote
+	}{
+		"no_voting_pause": {
+			expected: vote,
+		},
+		"vote_before_pause": {
+			votingPause: &VotingPause{Block: 5, Delay: 10},
+			expected:    vote,
+		},
+		"best_block_past_delay": {
+			votingPause: &VotingPause{Block: 2, Delay: 2},
+			bestNumber:  5,
+			expected:    vote,
+		},
+		"vote_restricted_to_pause_block": {
+			votingPause: &VotingPause{Block: 2, Delay: 3},
+			bestNumber:  5,
+			expected:    NewVoteFromHeader(headers[2]),
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			blockState := NewMockBlockState(ctrl)
+			blockState.EXPECT().BestBlockHeader().
+				Return(&types.Header{Number: testCase.bestNumber}, nil).AnyTimes()
+			for _, header := range headers {
+				blockState.EXPECT().GetHeader(header.Hash()).Return(header, nil).AnyTimes()
+			}
+
+			service := &Service{
+				blockState:  blockState,
+				votingPause: testCase.votingPause,
+			}
+
+			restricted, err := service.restrictVote(vote)
+			require.NoError(t, err)
+			require.Equal(t, testCase.expected, restricted)
+		})
+	}
+}
+
+func TestService_isVotingPaused(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	grandpaState := NewMockGrandpaState(ctrl)
+	service := &Service{
+		grandpaState: grandpaState,
+		head:         &types.Header{Number: 10},
+	}
+
+	grandpaState.EXPECT().IsPausedAt(uint(10)).Return(false, nil)
+	paused, err := service.isVotingPaused()
+	require.NoError(t, err)
+	require.False(t, paused)
+
+	grandpaState.EXPECT().IsPausedAt(uint(10)).Return(true, nil)
+	paused, err = service.isVotingPaused()
+	require.NoError(t, err)
+	require.True(t, paused)
+
+	service.PauseVoting()
+	paused, err = service.isVotingPaused()
+	require.NoError(t, err)
+	require.True(t, paused)
+
+	service.ResumeVoting()
+	require.False(t, service.IsVotingPaused())
+}
+
+func TestService_Progress(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Service{ctx: ctx, cancel: cancel}
+	assert.True(t, s.Progress().Idle)
+
+	s.authority = true
+	s.heartbeat.Beat()
+	progress := s.Progress()
+	assert.False(t, progress.Idle)
+	assert.False(t, progress.Last.IsZero())
+
+	s.PauseVoting()
+	assert.True(t, s.Progress().Idle)
+	s.ResumeVoting()
+
+	cancel()
+	assert.True(t, s.Progress().Idle)
+}
+
+func TestService_Restart(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Service{
+		ctx:          ctx,
+		cancel:       cancel,
+		authority:    true,
+		restartVoter: make(chan chan error),
+	}
+
+	// the voter restarts are received by the voting loop
+	errTest := errors.New("test error")
+	go func() {
+		done := <-s.restartVoter
+		done <- errTest
+	}()
+	err := s.Restart()
+	assert.ErrorIs(t, err, errTest)
+
+	cancel()
+	err = s.Restart()
+	assert.ErrorIs(t, err, context.Canceled)
+}
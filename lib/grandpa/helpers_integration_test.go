@@ -84,6 +84,7 @@ func (n *testNetwork) SendJustificationRequest(to peer.ID, num uint32) {
 
 func (*testNetwork) RegisterNotificationsProtocol(
 	_ protocol.ID,
+	_ []protocol.ID,
 	_ network.MessageType,
 	_ network.HandshakeGetter,
 	_ network.HandshakeDecoder,
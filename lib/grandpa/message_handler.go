@@ -133,22 +133,20 @@ func (h *MessageHandler) handleCatchUpResponse(msg *CatchUpResponse) error {
 		return err
 	}
 
-	// TODO: re-add catch-up logic (#1531)
-	if true {
-		return nil
-	}
-
 	// if we aren't currently expecting a catch up response, return
 	if !h.grandpa.paused.Load().(bool) {
 		logger.Debug("not currently paused, ignoring catch up response")
 		return nil
 	}
 
+	// the response ends the catch up request, whether it is valid or not
+	defer h.grandpa.paused.Store(false)
+
 	if msg.SetID != h.grandpa.state.setID {
 		return ErrSetIDMismatch
 	}
 
-	if msg.Round != h.grandpa.state.round-1 {
+	if msg.Round < h.grandpa.state.round {
 		return ErrInvalidCatchUpResponseRound
 	}
 
@@ -178,18 +176,23 @@ func (h *MessageHandler) handleCatchUpResponse(msg *CatchUpResponse) error {
 		return err
 	}
 
-	// update state and signal to grandpa we are ready to initiate
-	head, err := h.grandpa.blockState.GetHeader(msg.Hash)
+	// the catch up round is completed, finalising its block lets the
+	// current voting round complete and the next one start after it
+	has, err := h.grandpa.blockState.HasFinalisedBlock(msg.Round, msg.SetID)
 	if err != nil {
-		return err
+		return fmt.Errorf("checking for a finalised block in the block state: %w", err)
+	}
+
+	if !has {
+		err = h.grandpa.blockState.SetFinalisedHash(msg.Hash, msg.Round, msg.SetID)
+		if err != nil {
+			return fmt.Errorf("setting finalised hash: %w", err)
+		}
 	}
 
-	h.grandpa.head = head
-	h.grandpa.state.round = msg.Round
 	close(h.grandpa.resumed)
 	h.grandpa.resumed = make(chan struct{})
-	h.grandpa.paused.Store(false)
-	logger.Debugf("caught up to round; unpaused service and grandpa state round is %d", h.grandpa.state.round)
+	logger.Debugf("caught up to round %d with set id %d", msg.Round, msg.SetID)
 	return nil
 }
 
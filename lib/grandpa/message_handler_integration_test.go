@@ -715,6 +715,96 @@ func TestMessageHandler_HandleCatchUpResponse(t *testing.T) {
 	require.Equal(t, round+1, gs.state.round)
 }
 
+func TestMessageHandler_HandleCatchUpResponse_Paused(t *testing.T) {
+	t.Parallel()
+
+	kr, err := keystore.NewEd25519Keyring()
+	require.NoError(t, err)
+	aliceKeyPair := kr.Alice().(*ed25519.Keypair)
+
+	gs, st := newTestService(t, aliceKeyPair)
+
+	ctrl := gomock.NewController(t)
+	telemetryMock := NewMockTelemetry(ctrl)
+	h := NewMessageHandler(gs, st.Block, telemetryMock)
+
+	const previousBlocksToAdd = 7
+	now := time.Unix(1000, 0)
+	caughtUpBlock := addBlocksAndReturnTheLastOne(t, st.Block, previousBlocksToAdd, now)
+	vote := types.GrandpaVote{
+		Hash:   caughtUpBlock.Header.Hash(),
+		Number: uint32(caughtUpBlock.Header.Number),
+	}
+
+	// the peer catching us up is a few rounds ahead
+	round := gs.state.round + 3
+	prevotes := make([]SignedVote, len(kr.Keys))
+	precommits := make([]SignedVote, len(kr.Keys))
+	for idx, key := range kr.Keys {
+		authorityID := key.Public().(*ed25519.PublicKey).AsBytes()
+		prevotes[idx] = SignedVote{
+			Vote:        vote,
+			Signature:   signFakeFullVote(t, key, prevote, vote, round, gs.state.setID),
+			AuthorityID: authorityID,
+		}
+		precommits[idx] = SignedVote{
+			Vote:        vote,
+			Signature:   signFakeFullVote(t, key, precommit, vote, round, gs.state.setID),
+			AuthorityID: authorityID,
+		}
+	}
+
+	msg := &CatchUpResponse{
+		Round:                  round,
+		SetID:                  gs.state.setID,
+		PreVoteJustification:   prevotes,
+		PreCommitJustification: precommits,
+		Hash:                   vote.Hash,
+		Number:                 vote.Number,
+	}
+
+	gs.paused.Store(true)
+	out, err := h.handleMessage("", msg)
+	require.NoError(t, err)
+	require.Nil(t, out)
+	require.False(t, gs.paused.Load().(bool))
+
+	has, err := st.Block.HasFinalisedBlock(round, gs.state.setID)
+	require.NoError(t, err)
+	require.True(t, has)
+
+	// the voting round completes, jumping to the caught up round
+	completable, err := gs.checkRoundCompletable()
+	require.NoError(t, err)
+	require.True(t, completable)
+}
+
+func TestMessageHandler_HandleCatchUpResponse_InvalidResumes(t *testing.T) {
+	t.Parallel()
+
+	kr, err := keystore.NewEd25519Keyring()
+	require.NoError(t, err)
+	aliceKeyPair := kr.Alice().(*ed25519.Keypair)
+
+	gs, st := newTestService(t, aliceKeyPair)
+
+	ctrl := gomock.NewController(t)
+	telemetryMock := NewMockTelemetry(ctrl)
+	h := NewMessageHandler(gs, st.Block, telemetryMock)
+
+	msg := &CatchUpResponse{
+		Round:  gs.state.round,
+		SetID:  gs.state.setID + 1,
+		Hash:   testGenesisHeader.Hash(),
+		Number: 0,
+	}
+
+	gs.paused.Store(true)
+	_, err = h.handleMessage("", msg)
+	require.ErrorIs(t, err, ErrSetIDMismatch)
+	require.False(t, gs.paused.Load().(bool))
+}
+
 func Test_getEquivocatoryVoters(t *testing.T) {
 	t.Parallel()
 
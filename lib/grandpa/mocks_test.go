@@ -506,6 +506,36 @@ func (mr *MockGrandpaStateMockRecorder) GetSetIDByBlockNumber(arg0 any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSetIDByBlockNumber", reflect.TypeOf((*MockGrandpaState)(nil).GetSetIDByBlockNumber), arg0)
 }
 
+// GetVoterState mocks base method.
+func (m *MockGrandpaState) GetVoterState() (*types.GrandpaVoterState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVoterState")
+	ret0, _ := ret[0].(*types.GrandpaVoterState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVoterState indicates an expected call of GetVoterState.
+func (mr *MockGrandpaStateMockRecorder) GetVoterState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVoterState", reflect.TypeOf((*MockGrandpaState)(nil).GetVoterState))
+}
+
+// IsPausedAt mocks base method.
+func (m *MockGrandpaState) IsPausedAt(arg0 uint) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsPausedAt", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsPausedAt indicates an expected call of IsPausedAt.
+func (mr *MockGrandpaStateMockRecorder) IsPausedAt(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsPausedAt", reflect.TypeOf((*MockGrandpaState)(nil).IsPausedAt), arg0)
+}
+
 // NextGrandpaAuthorityChange mocks base method.
 func (m *MockGrandpaState) NextGrandpaAuthorityChange(arg0 common.Hash, arg1 uint) (uint, error) {
 	m.ctrl.T.Helper()
@@ -563,6 +593,20 @@ func (mr *MockGrandpaStateMockRecorder) SetPrevotes(arg0, arg1, arg2 any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrevotes", reflect.TypeOf((*MockGrandpaState)(nil).SetPrevotes), arg0, arg1, arg2)
 }
 
+// SetVoterState mocks base method.
+func (m *MockGrandpaState) SetVoterState(arg0 *types.GrandpaVoterState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetVoterState", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetVoterState indicates an expected call of SetVoterState.
+func (mr *MockGrandpaStateMockRecorder) SetVoterState(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetVoterState", reflect.TypeOf((*MockGrandpaState)(nil).SetVoterState), arg0)
+}
+
 // MockNetwork is a mock of Network interface.
 type MockNetwork struct {
 	ctrl     *gomock.Controller
@@ -599,17 +643,17 @@ func (mr *MockNetworkMockRecorder) GossipMessage(arg0 any) *gomock.Call {
 }
 
 // RegisterNotificationsProtocol mocks base method.
-func (m *MockNetwork) RegisterNotificationsProtocol(arg0 protocol.ID, arg1 network.MessageType, arg2 func() (network.Handshake, error), arg3 func([]byte) (network.Handshake, error), arg4 func(peer.ID, network.Handshake) error, arg5 func([]byte) (network.NotificationsMessage, error), arg6 func(peer.ID, network.NotificationsMessage) (bool, error), arg7 func(peer.ID, network.NotificationsMessage), arg8 uint64) error {
+func (m *MockNetwork) RegisterNotificationsProtocol(arg0 protocol.ID, arg1 []protocol.ID, arg2 network.MessageType, arg3 func() (network.Handshake, error), arg4 func([]byte) (network.Handshake, error), arg5 func(peer.ID, network.Handshake) error, arg6 func([]byte) (network.NotificationsMessage, error), arg7 func(peer.ID, network.NotificationsMessage) (bool, error), arg8 func(peer.ID, network.NotificationsMessage), arg9 uint64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RegisterNotificationsProtocol", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+	ret := m.ctrl.Call(m, "RegisterNotificationsProtocol", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RegisterNotificationsProtocol indicates an expected call of RegisterNotificationsProtocol.
-func (mr *MockNetworkMockRecorder) RegisterNotificationsProtocol(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8 any) *gomock.Call {
+func (mr *MockNetworkMockRecorder) RegisterNotificationsProtocol(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterNotificationsProtocol", reflect.TypeOf((*MockNetwork)(nil).RegisterNotificationsProtocol), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterNotificationsProtocol", reflect.TypeOf((*MockNetwork)(nil).RegisterNotificationsProtocol), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9)
 }
 
 // SendMessage mocks base method.
@@ -12,8 +12,18 @@
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
-// How often neighbour messages should be rebroadcast in the case where no new packets are created
-const neighbourBroadcastPeriod = time.Minute * 2
+const (
+	// How often neighbour messages should be rebroadcast in the case where no new packets are created
+	neighbourBroadcastPeriod = time.Minute * 2
+
+	// catchUpThreshold is the number of rounds a peer must be ahead of us
+	// before we request it to catch us up
+	catchUpThreshold = 2
+
+	// catchUpRequestTimeout is how long we wait for a catch up response
+	// before sending a new catch up request
+	catchUpRequestTimeout = time.Second * 30
+)
 
 type neighborData struct {
 	peer        peer.ID
@@ -35,6 +45,9 @@ type neighborTracker struct {
 	currentRound     uint64
 	highestFinalized uint32
 
+	// lastCatchUpRequest is when the last catch up request was sent
+	lastCatchUpRequest time.Time
+
 	finalizationCha chan *types.FinalisationInfo
 	neighborMsgChan chan neighborData
 	stoppedNeighbor chan struct{}
@@ -90,13 +103,16 @@ func (nt *neighborTracker) run() {
 				ticker.Reset(neighbourBroadcastPeriod)
 			}
 		case neighborData := <-nt.neighborMsgChan:
-			if neighborData.neighborMsg.Number > nt.peerview[neighborData.peer].highestFinalized {
-				nt.updatePeer(
-					neighborData.peer,
-					neighborData.neighborMsg.SetID,
-					neighborData.neighborMsg.Round,
-					neighborData.neighborMsg.Number,
-				)
+			nt.updatePeer(
+				neighborData.peer,
+				neighborData.neighborMsg.SetID,
+				neighborData.neighborMsg.Round,
+				neighborData.neighborMsg.Number,
+			)
+
+			err := nt.maybeRequestCatchUp(neighborData.peer)
+			if err != nil {
+				logger.Warnf("requesting catch up from peer %s: %s", neighborData.peer, err)
 			}
 		case <-nt.stoppedNeighbor:
 			logger.Info("stopping neighbour tracker")
@@ -127,6 +143,63 @@ func (nt *neighborTracker) getPeer(p peer.ID) neighborState {
 	return nt.peerview[p]
 }
 
+// maybeRequestCatchUp sends a catch up request to the given peer if it is
+// voting in our set and at least catchUpThreshold rounds ahead of us.
+// Only one catch up request is in flight at any given time.
+func (nt *neighborTracker) maybeRequestCatchUp(p peer.ID) error {
+	if !nt.grandpa.authority {
+		return nil
+	}
+
+	nt.Lock()
+	peerState := nt.peerview[p]
+	if peerState.setID != nt.currentSetID ||
+		peerState.round < nt.currentRound+catchUpThreshold ||
+		time.Since(nt.lastCatchUpRequest) < catchUpRequestTimeout {
+		nt.Unlock()
+		return nil
+	}
+	requestTime := time.Now()
+	nt.lastCatchUpRequest = requestTime
+	nt.Unlock()
+
+	// the peer is voting in its current round, the last completed round is the previous one
+	req := newCatchUpRequest(peerState.round-1, peerState.setID)
+	cm, err := req.ToConsensusMessage()
+	if err != nil {
+		return fmt.Errorf("converting catch up request to network message: %w", err)
+	}
+
+	logger.Debugf("sending catch up request for round %d and set id %d to peer %s",
+		req.Round, req.SetID, p)
+
+	nt.grandpa.paused.Store(true)
+	err = nt.grandpa.network.SendMessage(p, cm)
+	if err != nil {
+		nt.grandpa.paused.Store(false)
+		return fmt.Errorf("sending catch up request: %w", err)
+	}
+
+	time.AfterFunc(catchUpRequestTimeout, func() {
+		nt.catchUpRequestTimedOut(requestTime)
+	})
+	return nil
+}
+
+// catchUpRequestTimedOut resumes the service if it is still waiting for the response
+// to the catch up request sent at the time given, letting a new request be sent.
+func (nt *neighborTracker) catchUpRequestTimedOut(requestTime time.Time) {
+	nt.Lock()
+	defer nt.Unlock()
+
+	if !nt.lastCatchUpRequest.Equal(requestTime) || !nt.grandpa.paused.Load().(bool) {
+		return
+	}
+
+	logger.Debugf("catch up request sent at %s timed out", requestTime)
+	nt.grandpa.paused.Store(false)
+}
+
 func (nt *neighborTracker) BroadcastNeighborMsg() error {
 	packet := NeighbourPacketV1{
 		Round:  nt.currentRound,
@@ -4,6 +4,7 @@
 package grandpa
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -242,3 +243,128 @@ func TestNeighbourTracker_UpdatePeer_viaChannel(t *testing.T) {
 
 	nt.Stop()
 }
+
+func TestNeighbourTracker_catchUpRequestTimedOut(t *testing.T) {
+	t.Parallel()
+
+	requestTime := time.Now()
+	testCases := map[string]struct {
+		lastCatchUpRequest time.Time
+		paused             bool
+		expectedPaused     bool
+	}{
+		"not_paused": {
+			lastCatchUpRequest: requestTime,
+		},
+		"newer_request_in_flight": {
+			lastCatchUpRequest: requestTime.Add(time.Second),
+			paused:             true,
+			expectedPaused:     true,
+		},
+		"request_timed_out": {
+			lastCatchUpRequest: requestTime,
+			paused:             true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			grandpaService := &Service{}
+			grandpaService.paused.Store(testCase.paused)
+
+			nt := &neighborTracker{
+				grandpa:            grandpaService,
+				lastCatchUpRequest: testCase.lastCatchUpRequest,
+			}
+
+			nt.catchUpRequestTimedOut(requestTime)
+			require.Equal(t, testCase.expectedPaused, grandpaService.paused.Load().(bool))
+		})
+	}
+}
+
+func TestNeighbourTracker_maybeRequestCatchUp(t *testing.T) {
+	t.Parallel()
+
+	catchUpRequest, err := newCatchUpRequest(7, 5).ToConsensusMessage()
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		authority          bool
+		peerState          neighborState
+		lastCatchUpRequest time.Time
+		sendErr            error
+		expectRequest      bool
+		errMessage         string
+		expectedPaused     bool
+	}{
+		"not_authority": {
+			peerState: neighborState{setID: 5, round: 8},
+		},
+		"different_set_id": {
+			authority: true,
+			peerState: neighborState{setID: 6, round: 8},
+		},
+		"round_below_threshold": {
+			authority: true,
+			peerState: neighborState{setID: 5, round: 6},
+		},
+		"request_in_flight": {
+			authority:          true,
+			peerState:          neighborState{setID: 5, round: 8},
+			lastCatchUpRequest: time.Now(),
+		},
+		"send_error": {
+			authority:     true,
+			peerState:     neighborState{setID: 5, round: 8},
+			sendErr:       errors.New("test error"),
+			expectRequest: true,
+			errMessage:    "sending catch up request: test error",
+		},
+		"request_sent": {
+			authority:          true,
+			peerState:          neighborState{setID: 5, round: 8},
+			lastCatchUpRequest: time.Now().Add(-catchUpRequestTimeout),
+			expectRequest:      true,
+			expectedPaused:     true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+
+			network := NewMockNetwork(ctrl)
+			if testCase.expectRequest {
+				network.EXPECT().SendMessage(peer.ID("testPeer"), catchUpRequest).Return(testCase.sendErr)
+			}
+
+			grandpaService := &Service{
+				network:   network,
+				authority: testCase.authority,
+			}
+			grandpaService.paused.Store(false)
+
+			nt := &neighborTracker{
+				grandpa:            grandpaService,
+				peerview:           map[peer.ID]neighborState{"testPeer": testCase.peerState},
+				currentSetID:       5,
+				currentRound:       6,
+				lastCatchUpRequest: testCase.lastCatchUpRequest,
+			}
+
+			err := nt.maybeRequestCatchUp("testPeer")
+			if testCase.errMessage != "" {
+				require.EqualError(t, err, testCase.errMessage)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, testCase.expectedPaused, grandpaService.paused.Load().(bool))
+		})
+	}
+}
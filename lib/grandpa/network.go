@@ -17,6 +17,9 @@
 
 const grandpaID1 = "grandpa/1"
 
+// legacyGrandpaProtocolID is the protocol id used by the nodes not deriving it from the genesis hash
+const legacyGrandpaProtocolID = "/paritytech/grandpa/1"
+
 // NotificationsMessage is an alias for network.NotificationsMessage
 type NotificationsMessage = network.NotificationsMessage
 
@@ -60,6 +63,7 @@ func (s *Service) registerProtocol() error {
 
 	return s.network.RegisterNotificationsProtocol(
 		protocol.ID(grandpaProtocolID),
+		[]protocol.ID{legacyGrandpaProtocolID},
 		network.ConsensusMsgType,
 		s.getHandshake,
 		s.decodeHandshake,
@@ -127,9 +131,20 @@ func (s *Service) handleNetworkMessage(from peer.ID, msg NotificationsMessage) (
 
 	switch r := resp.(type) {
 	case *ConsensusMessage:
-		if r != nil {
-			s.network.GossipMessage(resp)
+		if r == nil {
+			break
+		}
+
+		// catch up responses are only meant for the requesting peer
+		if _, ok := m.(*CatchUpRequest); ok {
+			err = s.network.SendMessage(from, r)
+			if err != nil {
+				return false, fmt.Errorf("sending catch up response: %w", err)
+			}
+			break
 		}
+
+		s.network.GossipMessage(resp)
 	case nil:
 	default:
 		logger.Warnf(
@@ -138,7 +153,7 @@ func (s *Service) handleNetworkMessage(from peer.ID, msg NotificationsMessage) (
 	}
 
 	switch m.(type) {
-	case *NeighbourPacketV1, *CatchUpResponse:
+	case *NeighbourPacketV1, *CatchUpRequest, *CatchUpResponse:
 		return false, nil
 	}
 
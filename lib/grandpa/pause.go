@@ -0,0 +1,80 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"fmt"
+)
+
+// VotingPause pauses the voting at a block for a number of blocks: while the best block is
+// less than delay blocks past the pause block, the votes do not go past the pause block.
+type VotingPause struct {
+	Block uint32
+	Delay uint32
+}
+
+// PauseVoting pauses the voting of the service, the rounds in progress being
+// finalised with the votes of the other voters only, until ResumeVoting is called.
+func (s *Service) PauseVoting() {
+	logger.Warn("pausing voting")
+	s.votingPaused.Store(true)
+}
+
+// ResumeVoting resumes the voting of the service paused with PauseVoting
+func (s *Service) ResumeVoting() {
+	logger.Info("resuming voting")
+	s.votingPaused.Store(false)
+}
+
+// IsVotingPaused returns true if the voting was paused with PauseVoting
+func (s *Service) IsVotingPaused() bool {
+	return s.votingPaused.Load()
+}
+
+// isVotingPaused returns true if the voting is paused with PauseVoting, or if the authority
+// set is paused at the last finalised block by a pause digest.
+func (s *Service) isVotingPaused() (bool, error) {
+	if s.IsVotingPaused() {
+		return true, nil
+	}
+
+	paused, err := s.grandpaState.IsPausedAt(s.head.Number)
+	if err != nil {
+		return false, fmt.Errorf("checking if authority set is paused: %w", err)
+	}
+
+	return paused, nil
+}
+
+// restrictVote returns the ancestor of the vote at the block of the voting pause if the vote
+// goes past it and the best block is within the delay of the pause, and the vote otherwise.
+func (s *Service) restrictVote(vote *Vote) (*Vote, error) {
+	if s.votingPause == nil || vote.Number <= s.votingPause.Block {
+		return vote, nil
+	}
+
+	best, err := s.blockState.BestBlockHeader()
+	if err != nil {
+		return nil, fmt.Errorf("getting best block header: %w", err)
+	}
+
+	if best.Number > uint(s.votingPause.Block)+uint(s.votingPause.Delay) {
+		return vote, nil
+	}
+
+	header, err := s.blockState.GetHeader(vote.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("getting header of vote: %w", err)
+	}
+
+	for header.Number > uint(s.votingPause.Block) {
+		header, err = s.blockState.GetHeader(header.ParentHash)
+		if err != nil {
+			return nil, fmt.Errorf("getting parent header: %w", err)
+		}
+	}
+
+	logger.Debugf("restricting vote for block #%d to paused block #%d", vote.Number, header.Number)
+	return NewVoteFromHeader(header), nil
+}
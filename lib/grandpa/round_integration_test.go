@@ -18,6 +18,7 @@
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/telemetry"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 	"github.com/ChainSafe/gossamer/lib/keystore"
@@ -576,6 +577,19 @@ func TestSendingVotesInRightStage(t *testing.T) {
 	mockedGrandpaState.EXPECT().
 		SetLatestRound(uint64(1)).
 		Return(nil)
+	mockedGrandpaState.EXPECT().
+		IsPausedAt(testGenesisHeader.Number).
+		Return(false, nil).
+		Times(2)
+	// bob is the primary of round 1 so it casts a primary proposal, a prevote and a precommit
+	mockedGrandpaState.EXPECT().
+		GetVoterState().
+		Return(nil, database.ErrNotFound).
+		Times(3)
+	mockedGrandpaState.EXPECT().
+		SetVoterState(gomock.AssignableToTypeOf(&types.GrandpaVoterState{})).
+		Return(nil).
+		Times(3)
 	mockedGrandpaState.EXPECT().
 		GetPrecommits(uint64(1), uint64(0)).
 		Return([]types.GrandpaSignedVote{}, nil)
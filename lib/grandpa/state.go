@@ -10,6 +10,7 @@
 	"github.com/ChainSafe/gossamer/dot/network"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime"
 )
 
@@ -53,6 +54,14 @@ type GrandpaState interface {
 	GetPrecommits(round, setID uint64) ([]SignedVote, error)
 	NextGrandpaAuthorityChange(bestBlockHash common.Hash, bestBlockNumber uint) (blockHeight uint, err error)
 	GetAuthoritiesChangesFromBlock(blockNumber uint) ([]uint, error)
+	SetVoterState(voterState *types.GrandpaVoterState) error
+	GetVoterState() (*types.GrandpaVoterState, error)
+	IsPausedAt(blockNumber uint) (bool, error)
+}
+
+// Keystore is the interface of the keystore holding the GRANDPA authority keys
+type Keystore interface {
+	Keypairs() []keystore.KeyPair
 }
 
 // Network is the interface required by GRANDPA for the network
@@ -60,6 +69,7 @@ type Network interface {
 	GossipMessage(msg network.NotificationsMessage)
 	SendMessage(to peer.ID, msg NotificationsMessage) error
 	RegisterNotificationsProtocol(sub protocol.ID,
+		fallbackSubs []protocol.ID,
 		messageID network.MessageType,
 		handshakeGetter network.HandshakeGetter,
 		handshakeDecoder network.HandshakeDecoder,
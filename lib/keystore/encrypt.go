@@ -18,22 +18,26 @@
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 	"github.com/ChainSafe/gossamer/lib/crypto/secp256k1"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
-
-	"golang.org/x/crypto/blake2b"
 )
 
-// EncryptedKeystore holds Type PublicKey and Ciphertext
+// EncryptedKeystore holds Type PublicKey and Ciphertext, encrypted with the symmetric key
+// derived from the password with KDF
 type EncryptedKeystore struct {
 	Type       string
 	PublicKey  string
 	Ciphertext []byte
+	KDF        *KDFParams `json:",omitempty"`
 }
 
-// gcmFromPassphrase creates a symmetric AES key given a password
-func gcmFromPassphrase(password []byte) (cipher.AEAD, error) {
-	hash := blake2b.Sum256(password)
+// gcmFromPassphrase creates a symmetric AES key given a password and the parameters of
+// the key derivation function, the key being the blake2b hash of the password if they are nil
+func gcmFromPassphrase(password []byte, kdf *KDFParams) (cipher.AEAD, error) {
+	key, err := kdf.deriveKey(password)
+	if err != nil {
+		return nil, err
+	}
 
-	block, err := aes.NewCipher(hash[:])
+	block, err := aes.NewCipher(key)
 	if err != nil {
 		return nil, err
 	}
@@ -48,7 +52,13 @@ func gcmFromPassphrase(password []byte) (cipher.AEAD, error) {
 
 // Encrypt uses AES to encrypt `msg` with the symmetric key deterministically created from `password`
 func Encrypt(msg, password []byte) ([]byte, error) {
-	gcm, err := gcmFromPassphrase(password)
+	return EncryptWithKDF(msg, password, nil)
+}
+
+// EncryptWithKDF uses AES to encrypt `msg` with the symmetric key derived from `password`
+// with the key derivation function of `kdf`
+func EncryptWithKDF(msg, password []byte, kdf *KDFParams) ([]byte, error) {
+	gcm, err := gcmFromPassphrase(password, kdf)
 	if err != nil {
 		return nil, err
 	}
@@ -64,12 +74,21 @@ func Encrypt(msg, password []byte) ([]byte, error) {
 
 // Decrypt uses AES to decrypt ciphertext with the symmetric key deterministically created from `password`
 func Decrypt(data, password []byte) ([]byte, error) {
-	gcm, err := gcmFromPassphrase(password)
+	return DecryptWithKDF(data, password, nil)
+}
+
+// DecryptWithKDF uses AES to decrypt ciphertext with the symmetric key derived from `password`
+// with the key derivation function of `kdf`
+func DecryptWithKDF(data, password []byte, kdf *KDFParams) ([]byte, error) {
+	gcm, err := gcmFromPassphrase(password, kdf)
 	if err != nil {
 		return nil, err
 	}
 
 	nonceSize := gcm.NonceSize()
+	if len(data) < nonceSize {
+		return nil, errors.New("ciphertext too short")
+	}
 	nonce, ciphertext := data[:nonceSize], data[nonceSize:]
 	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
 	if err != nil {
@@ -97,9 +116,20 @@ func DecryptPrivateKey(data, password []byte, keytype string) (crypto.PrivateKey
 	return DecodePrivateKey(pk, keytype)
 }
 
-// EncryptAndWriteToFile encrypts the `crypto.PrivateKey` using the password and saves it to the specified file
-func EncryptAndWriteToFile(path string, pk crypto.PrivateKey, password []byte) error {
-	ciphertext, err := EncryptPrivateKey(pk, password)
+// EncryptAndWriteToFile encrypts the `crypto.PrivateKey` using the password and saves it to the specified file,
+// the symmetric key being derived from the password with the key derivation function named `kdf`,
+// or DefaultKDF if it is empty
+func EncryptAndWriteToFile(path string, pk crypto.PrivateKey, password []byte, kdf string) error {
+	if kdf == "" {
+		kdf = DefaultKDF
+	}
+
+	kdfParams, err := NewKDFParams(kdf)
+	if err != nil {
+		return err
+	}
+
+	ciphertext, err := EncryptWithKDF(pk.Encode(), password, kdfParams)
 	if err != nil {
 		return err
 	}
@@ -130,6 +160,7 @@ func EncryptAndWriteToFile(path string, pk crypto.PrivateKey, password []byte) e
 		Type:       keytype,
 		PublicKey:  pub.Hex(),
 		Ciphertext: ciphertext,
+		KDF:        kdfParams,
 	}
 
 	data, err := json.MarshalIndent(keydata, "", "\t")
@@ -137,7 +168,14 @@ func EncryptAndWriteToFile(path string, pk crypto.PrivateKey, password []byte) e
 		return err
 	}
 
-	err = os.WriteFile(filepath.Clean(path), append(data, byte('\n')), 0600)
+	// write to a temporary file first so an interrupted write does not corrupt an existing key file
+	tmpPath := filepath.Clean(path) + ".tmp"
+	err = os.WriteFile(tmpPath, append(data, byte('\n')), 0600)
+	if err != nil {
+		return fmt.Errorf("cannot write to destination file: %w", err)
+	}
+
+	err = os.Rename(tmpPath, filepath.Clean(path))
 	if err != nil {
 		return fmt.Errorf("cannot write to destination file: %w", err)
 	}
@@ -163,5 +201,22 @@ func ReadFromFileAndDecrypt(filename string, password []byte) (crypto.PrivateKey
 		return nil, err
 	}
 
-	return DecryptPrivateKey(keydata.Ciphertext, password, keydata.Type)
+	pk, err := DecryptWithKDF(keydata.Ciphertext, password, keydata.KDF)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodePrivateKey(pk, keydata.Type)
+}
+
+// ChangePassword decrypts the key file with its current password and encrypts it again with the new
+// password, deriving the new symmetric key with the key derivation function named `kdf`, or DefaultKDF
+// if it is empty. It upgrades the key files without key derivation function parameters.
+func ChangePassword(filename string, password, newPassword []byte, kdf string) error {
+	pk, err := ReadFromFileAndDecrypt(filename, password)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt key file: %w", err)
+	}
+
+	return EncryptAndWriteToFile(filename, pk, newPassword, kdf)
 }
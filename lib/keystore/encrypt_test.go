@@ -6,6 +6,8 @@
 import (
 	"bytes"
 	"crypto/rand"
+	"encoding/json"
+	"os"
 	"path/filepath"
 	"reflect"
 	"testing"
@@ -13,6 +15,8 @@
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 	"github.com/ChainSafe/gossamer/lib/crypto/secp256k1"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEncryptAndDecrypt(t *testing.T) {
@@ -74,7 +78,7 @@ func TestEncryptAndDecryptFromFile_Ed25519(t *testing.T) {
 	}
 	priv := kp.Private()
 
-	err = EncryptAndWriteToFile(path, priv, password)
+	err = EncryptAndWriteToFile(path, priv, password, "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -99,7 +103,7 @@ func TestEncryptAndDecryptFromFile_Sr25519(t *testing.T) {
 	}
 	priv := kp.Private()
 
-	err = EncryptAndWriteToFile(path, priv, password)
+	err = EncryptAndWriteToFile(path, priv, password, "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -124,7 +128,7 @@ func TestEncryptAndDecryptFromFile_Secp256k1(t *testing.T) {
 	}
 	priv := kp.Private()
 
-	err = EncryptAndWriteToFile(path, priv, password)
+	err = EncryptAndWriteToFile(path, priv, password, "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -138,3 +142,61 @@ func TestEncryptAndDecryptFromFile_Secp256k1(t *testing.T) {
 		t.Fatalf("Fail: got %v expected %v", res, priv)
 	}
 }
+
+func TestEncryptAndDecryptFromFile_Argon2id(t *testing.T) {
+	password := []byte("noot")
+	path := filepath.Join(t.TempDir(), "test_key")
+
+	kp, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	err = EncryptAndWriteToFile(path, kp.Private(), password, KDFArgon2id)
+	require.NoError(t, err)
+
+	res, err := ReadFromFileAndDecrypt(path, password)
+	require.NoError(t, err)
+	assert.Equal(t, kp.Private().Encode(), res.Encode())
+
+	_, err = ReadFromFileAndDecrypt(path, []byte("wrong"))
+	assert.Error(t, err)
+}
+
+func TestChangePassword(t *testing.T) {
+	password := []byte("noot")
+	newPassword := []byte("noot2")
+	path := filepath.Join(t.TempDir(), "test_key")
+
+	kp, err := ed25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	// key file written before the key derivation function parameters were introduced
+	ciphertext, err := EncryptPrivateKey(kp.Private(), password)
+	require.NoError(t, err)
+	data, err := json.Marshal(EncryptedKeystore{
+		Type:       "ed25519",
+		PublicKey:  kp.Public().Hex(),
+		Ciphertext: ciphertext,
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0600))
+
+	err = ChangePassword(path, newPassword, password, KDFArgon2id)
+	assert.ErrorContains(t, err, "failed to decrypt key file")
+
+	err = ChangePassword(path, password, newPassword, KDFArgon2id)
+	require.NoError(t, err)
+
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	keydata := new(EncryptedKeystore)
+	require.NoError(t, json.Unmarshal(data, keydata))
+	require.NotNil(t, keydata.KDF)
+	assert.Equal(t, KDFArgon2id, keydata.KDF.Name)
+
+	_, err = ReadFromFileAndDecrypt(path, password)
+	assert.Error(t, err)
+
+	res, err := ReadFromFileAndDecrypt(path, newPassword)
+	require.NoError(t, err)
+	assert.Equal(t, kp.Private().Encode(), res.Encode())
+}
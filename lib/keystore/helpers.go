@@ -64,10 +64,52 @@ func DecodeKeyPairFromHex(keystr []byte, keytype crypto.KeyType) (kp KeyPair, er
 	return kp, err
 }
 
+// DecodeKeyPairFromSecretURI returns the keypair of the given type derived along the derivation
+// path of the secret URI from its seed
+func DecodeKeyPairFromSecretURI(uri string, keytype crypto.KeyType) (kp KeyPair, err error) {
+	secretURI, err := crypto.ParseSecretURI(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	seed, err := secretURI.Seed()
+	if err != nil {
+		return nil, err
+	}
+
+	switch keytype {
+	case crypto.Sr25519Type:
+		var root *sr25519.Keypair
+		root, err = sr25519.NewKeypairFromSeed(seed)
+		if err == nil {
+			kp, err = root.Derive(secretURI.Junctions)
+		}
+	case crypto.Ed25519Type:
+		var root *ed25519.Keypair
+		root, err = ed25519.NewKeypairFromSeed(seed)
+		if err == nil {
+			kp, err = root.Derive(secretURI.Junctions)
+		}
+	case crypto.Secp256k1Type:
+		var root *secp256k1.Keypair
+		root, err = secp256k1.NewKeypairFromSeed(seed)
+		if err == nil {
+			kp, err = root.Derive(secretURI.Junctions)
+		}
+	default:
+		return nil, errors.New("cannot decode key: invalid key type")
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("deriving key: %w", err)
+	}
+	return kp, nil
+}
+
 // GenerateKeypair create a new keypair with the corresponding type and saves
 // it to basepath/keystore/[public key].key in json format encrypted using the
-// specified password and returns the resulting filepath of the new key
-func GenerateKeypair(keytype string, kp PublicPrivater, basepath string, password []byte) (string, error) {
+// specified password and key derivation function, and returns the resulting filepath of the new key
+func GenerateKeypair(keytype string, kp PublicPrivater, basepath string, password []byte, kdf string) (string, error) {
 	if keytype == "" {
 		keytype = crypto.Sr25519Type
 	}
@@ -103,7 +145,7 @@ func GenerateKeypair(keytype string, kp PublicPrivater, basepath string, passwor
 		return "", fmt.Errorf("failed to create absolute filepath: %s", err)
 	}
 
-	err = EncryptAndWriteToFile(fp, kp.Private(), password)
+	err = EncryptAndWriteToFile(fp, kp.Private(), password, kdf)
 	if err != nil {
 		return "", fmt.Errorf("failed to write key to file: %s", err)
 	}
@@ -187,7 +229,7 @@ func ImportKeypair(fp, dir string) (string, error) {
 }
 
 // ImportRawPrivateKey imports a raw private key and saves it to the keystore directory
-func ImportRawPrivateKey(key, keytype, basepath string, password []byte) (string, error) {
+func ImportRawPrivateKey(key, keytype, basepath string, password []byte, kdf string) (string, error) {
 	var kp PublicPrivater
 	var err error
 
@@ -212,14 +254,25 @@ func ImportRawPrivateKey(key, keytype, basepath string, password []byte) (string
 		}
 	}
 
-	return GenerateKeypair(keytype, kp, basepath, password)
+	return GenerateKeypair(keytype, kp, basepath, password, kdf)
 }
 
 // UnlockKeys unlocks keys specified by the --unlock flag with the passwords given by --password
 // and places them into the keystore
 func UnlockKeys(ks Inserter, dir, unlock, password string) error {
-	var indices []int
 	var passwords []string
+	if password != "" {
+		// passwords corresponding to the keys
+		passwords = strings.Split(password, ",")
+	}
+
+	return UnlockKeysWithPasswords(ks, dir, unlock, passwords)
+}
+
+// UnlockKeysWithPasswords unlocks keys specified by the --unlock flag, each with its own password,
+// and places them into the keystore
+func UnlockKeysWithPasswords(ks Inserter, dir, unlock string, passwords []string) error {
+	var indices []int
 	var err error
 
 	keyDir, err := utils.KeystoreDir(dir)
@@ -235,11 +288,6 @@ func UnlockKeys(ks Inserter, dir, unlock, password string) error {
 		}
 	}
 
-	if password != "" {
-		// passwords corresponding to the keys
-		passwords = strings.Split(password, ",")
-	}
-
 	if len(passwords) != len(indices) {
 		return fmt.Errorf("number of passwords given does not match number of keys to unlock")
 	}
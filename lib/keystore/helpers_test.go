@@ -64,7 +64,7 @@ func TestDetermineKeyType(t *testing.T) {
 func TestGenerateKey_Sr25519(t *testing.T) {
 	testdir := t.TempDir()
 
-	keyfile, err := GenerateKeypair("sr25519", nil, testdir, testPassword)
+	keyfile, err := GenerateKeypair("sr25519", nil, testdir, testPassword, "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -86,7 +86,7 @@ func TestGenerateKey_Sr25519(t *testing.T) {
 func TestGenerateKey_Ed25519(t *testing.T) {
 	testdir := t.TempDir()
 
-	keyfile, err := GenerateKeypair("ed25519", nil, testdir, testPassword)
+	keyfile, err := GenerateKeypair("ed25519", nil, testdir, testPassword, "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -123,7 +123,7 @@ func TestGenerateKey_Ed25519(t *testing.T) {
 func TestGenerateKey_Secp256k1(t *testing.T) {
 	testdir := t.TempDir()
 
-	keyfile, err := GenerateKeypair("secp256k1", nil, testdir, testPassword)
+	keyfile, err := GenerateKeypair("secp256k1", nil, testdir, testPassword, "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -160,7 +160,7 @@ func TestGenerateKey_Secp256k1(t *testing.T) {
 func TestGenerateKey_NoType(t *testing.T) {
 	testdir := t.TempDir()
 
-	keyfile, err := GenerateKeypair("", nil, testdir, testPassword)
+	keyfile, err := GenerateKeypair("", nil, testdir, testPassword, "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -195,7 +195,7 @@ func TestImportKey(t *testing.T) {
 
 	keypath := basePath
 
-	importkeyfile, err := GenerateKeypair("sr25519", nil, keypath, testPassword)
+	importkeyfile, err := GenerateKeypair("sr25519", nil, keypath, testPassword, "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -230,12 +230,12 @@ func TestListKeys(t *testing.T) {
 		var err error
 		var keyfile string
 		if i%2 == 0 {
-			keyfile, err = GenerateKeypair("sr25519", nil, testdir, testPassword)
+			keyfile, err = GenerateKeypair("sr25519", nil, testdir, testPassword, "")
 			if err != nil {
 				t.Fatal(err)
 			}
 		} else {
-			keyfile, err = GenerateKeypair("ed25519", nil, testdir, testPassword)
+			keyfile, err = GenerateKeypair("ed25519", nil, testdir, testPassword, "")
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -265,7 +265,7 @@ func TestListKeys(t *testing.T) {
 func TestUnlockKeys(t *testing.T) {
 	testdir := t.TempDir()
 
-	keyfile, err := GenerateKeypair("sr25519", nil, testdir, testPassword)
+	keyfile, err := GenerateKeypair("sr25519", nil, testdir, testPassword, "")
 	require.NoError(t, err)
 
 	ks := NewBasicKeystore("test", crypto.Sr25519Type)
@@ -294,7 +294,7 @@ func TestImportRawPrivateKey_NoType(t *testing.T) {
 
 	keyfile, err := ImportRawPrivateKey(
 		"0x33a6f3093f158a7109f679410bef1a0c54168145e0cecb4df006c1c2fffb1f09",
-		"", testdir, testPassword)
+		"", testdir, testPassword, "")
 	require.NoError(t, err)
 
 	contents, err := os.ReadFile(keyfile)
@@ -314,7 +314,7 @@ func TestImportRawPrivateKey_Sr25519(t *testing.T) {
 
 	keyfile, err := ImportRawPrivateKey(
 		"0x33a6f3093f158a7109f679410bef1a0c54168145e0cecb4df006c1c2fffb1f09",
-		"sr25519", testdir, testPassword)
+		"sr25519", testdir, testPassword, "")
 	require.NoError(t, err)
 
 	contents, err := os.ReadFile(keyfile)
@@ -334,7 +334,7 @@ func TestImportRawPrivateKey_Ed25519(t *testing.T) {
 
 	keyfile, err := ImportRawPrivateKey(
 		"0x33a6f3093f158a7109f679410bef1a0c54168145e0cecb4df006c1c2fffb1f09",
-		"ed25519", testdir, testPassword)
+		"ed25519", testdir, testPassword, "")
 	require.NoError(t, err)
 
 	contents, err := os.ReadFile(keyfile)
@@ -354,7 +354,7 @@ func TestImportRawPrivateKey_Secp256k1(t *testing.T) {
 
 	keyfile, err := ImportRawPrivateKey(
 		"0x33a6f3093f158a7109f679410bef1a0c54168145e0cecb4df006c1c2fffb1f09",
-		"secp256k1", testdir, testPassword)
+		"secp256k1", testdir, testPassword, "")
 	require.NoError(t, err)
 
 	contents, err := os.ReadFile(keyfile)
@@ -397,3 +397,79 @@ func TestDecodeKeyPairFromHex(t *testing.T) {
 	_, err = DecodeKeyPairFromHex(nil, "")
 	require.Error(t, err, "cannot decode key: invalid key type")
 }
+
+func TestDecodeKeyPairFromSecretURI(t *testing.T) {
+	testCases := []struct {
+		uri            string
+		keytype        crypto.KeyType
+		expectedPublic string
+		expectedErr    string
+	}{
+		{
+			uri:            crypto.DevPhrase,
+			keytype:        crypto.Sr25519Type,
+			expectedPublic: "0x46ebddef8cd9bb167dc30878d7113b7e168e6f0646beffd77d69d39bad76b47a",
+		},
+		{
+			uri:            "//Alice",
+			keytype:        crypto.Sr25519Type,
+			expectedPublic: "0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d",
+		},
+		{
+			uri:            crypto.DevPhrase + "//Alice//stash",
+			keytype:        crypto.Sr25519Type,
+			expectedPublic: "0xbe5ddb1579b72e84524fc29e78609e3caf42e85aa118ebfe0b0ad404b5bdd25f",
+		},
+		{
+			// known soft derived key of substrate
+			uri:            crypto.DevPhrase + "/Alice",
+			keytype:        crypto.Sr25519Type,
+			expectedPublic: "0xd6c71059dbbe9ad2b0ed3f289738b800836eb425544ce694825285b958ca755e",
+		},
+		{
+			uri:            "//Alice/soft",
+			keytype:        crypto.Sr25519Type,
+			expectedPublic: "0x02cfd83074aefc9955af4034d19b3780d47a52e158ababec8ec012b2295f1c5b",
+		},
+		{
+			uri:            "//Alice//stash/0",
+			keytype:        crypto.Sr25519Type,
+			expectedPublic: "0x6ec1d52d09ea2136574aa8b32d6c2dc7cea3999ce6857ac7fe92bfbe8a163e15",
+		},
+		{
+			uri:            "//Alice/soft//hard",
+			keytype:        crypto.Sr25519Type,
+			expectedPublic: "0x568a4209957aa77918aabb4f51a81f443878ff27ad0b118106273b25253d5e2e",
+		},
+		{
+			uri:            "//Alice",
+			keytype:        crypto.Ed25519Type,
+			expectedPublic: "0x88dc3417d5058ec4b4503e0c12ea1a0a89be200fe98922423d4334014fa6b0ee",
+		},
+		{
+			uri:            "//Alice",
+			keytype:        crypto.Secp256k1Type,
+			expectedPublic: "0x020a1091341fe5664bfa1782d5e04779689068c916b04cb365ec3153755684d9a1",
+		},
+		{
+			uri:         "//Alice/soft",
+			keytype:     crypto.Ed25519Type,
+			expectedErr: "deriving key: soft derivation not supported: for ed25519 keys",
+		},
+		{
+			uri:         "//Alice/soft",
+			keytype:     crypto.Secp256k1Type,
+			expectedErr: "deriving key: soft derivation not supported: for secp256k1 keys",
+		},
+	}
+
+	for _, testCase := range testCases {
+		kp, err := DecodeKeyPairFromSecretURI(testCase.uri, testCase.keytype)
+		if testCase.expectedErr != "" {
+			require.EqualError(t, err, testCase.expectedErr)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, testCase.expectedPublic, kp.Public().Hex(), testCase.uri)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// KDFScrypt is the name of the scrypt key derivation function
+	KDFScrypt = "scrypt"
+	// KDFArgon2id is the name of the argon2id key derivation function
+	KDFArgon2id = "argon2id"
+	// DefaultKDF is the key derivation function the key files are encrypted with by default
+	DefaultKDF = KDFScrypt
+)
+
+const (
+	kdfKeyLength  = 32
+	kdfSaltLength = 32
+
+	// scrypt parameters used by the substrate and polkadot-js key files
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+
+	// argon2id parameters recommended by RFC 9106 for memory constrained environments
+	argon2idTime    = 3
+	argon2idMemory  = 64 * 1024
+	argon2idThreads = 4
+)
+
+var (
+	errUnknownKDF       = errors.New("unknown key derivation function")
+	errInvalidKDFParams = errors.New("invalid key derivation function parameters")
+)
+
+// KDFParams are the parameters of the key derivation function deriving the symmetric
+// key of a key file from its password. Key files written before their introduction
+// do not have them, their symmetric key being the blake2b hash of the password.
+type KDFParams struct {
+	Name string `json:"name"`
+	Salt []byte `json:"salt"`
+	// N, R and P are the scrypt cost parameters
+	N int `json:"n,omitempty"`
+	R int `json:"r,omitempty"`
+	P int `json:"p,omitempty"`
+	// Time, Memory in KiB and Threads are the argon2id cost parameters
+	Time    uint32 `json:"time,omitempty"`
+	Memory  uint32 `json:"memory,omitempty"`
+	Threads uint8  `json:"threads,omitempty"`
+}
+
+// NewKDFParams returns the default parameters of the key derivation function
+// with the given name, with a random salt.
+func NewKDFParams(name string) (*KDFParams, error) {
+	salt := make([]byte, kdfSaltLength)
+	_, err := io.ReadFull(rand.Reader, salt)
+	if err != nil {
+		return nil, fmt.Errorf("generating salt: %w", err)
+	}
+
+	switch name {
+	case KDFScrypt:
+		return &KDFParams{Name: name, Salt: salt, N: scryptN, R: scryptR, P: scryptP}, nil
+	case KDFArgon2id:
+		return &KDFParams{
+			Name:    name,
+			Salt:    salt,
+			Time:    argon2idTime,
+			Memory:  argon2idMemory,
+			Threads: argon2idThreads,
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownKDF, name)
+	}
+}
+
+// deriveKey derives the symmetric key from the password with the key derivation function
+// of the parameters, or with blake2b if the parameters are nil.
+func (p *KDFParams) deriveKey(password []byte) ([]byte, error) {
+	if p == nil {
+		hash := blake2b.Sum256(password)
+		return hash[:], nil
+	}
+
+	switch p.Name {
+	case KDFScrypt:
+		key, err := scrypt.Key(password, p.Salt, p.N, p.R, p.P, kdfKeyLength)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errInvalidKDFParams, err)
+		}
+		return key, nil
+	case KDFArgon2id:
+		if p.Time == 0 || p.Memory == 0 || p.Threads == 0 {
+			return nil, fmt.Errorf("%w: argon2id time, memory and threads must be positive", errInvalidKDFParams)
+		}
+		return argon2.IDKey(password, p.Salt, p.Time, p.Memory, p.Threads, kdfKeyLength), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownKDF, p.Name)
+	}
+}
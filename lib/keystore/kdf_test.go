@@ -0,0 +1,46 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_KDFParams_deriveKey(t *testing.T) {
+	t.Parallel()
+
+	password := []byte("noot")
+
+	for _, name := range []string{KDFScrypt, KDFArgon2id} {
+		params, err := NewKDFParams(name)
+		require.NoError(t, err)
+
+		key, err := params.deriveKey(password)
+		require.NoError(t, err)
+		assert.Len(t, key, kdfKeyLength)
+
+		// the key is derived deterministically from the password and the salt
+		sameKey, err := params.deriveKey(password)
+		require.NoError(t, err)
+		assert.Equal(t, key, sameKey)
+
+		otherParams, err := NewKDFParams(name)
+		require.NoError(t, err)
+		otherKey, err := otherParams.deriveKey(password)
+		require.NoError(t, err)
+		assert.NotEqual(t, key, otherKey)
+	}
+
+	_, err := NewKDFParams("pbkdf2")
+	assert.ErrorIs(t, err, errUnknownKDF)
+
+	_, err = (&KDFParams{Name: KDFScrypt, N: 3, R: 8, P: 1}).deriveKey(password)
+	assert.ErrorIs(t, err, errInvalidKDFParams)
+
+	_, err = (&KDFParams{Name: KDFArgon2id}).deriveKey(password)
+	assert.ErrorIs(t, err, errInvalidKDFParams)
+}
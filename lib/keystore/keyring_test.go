@@ -45,12 +45,25 @@ func TestNewSr25519Keyring(t *testing.T) {
 }
 
 func TestNewEd25519Keyring(t *testing.T) {
+	// private keys generated using `subkey inspect --scheme ed25519 //Name`
+	privateKeys := []string{
+		"0xabf8e5bdbe30c65656c0a3cbd181ff8a56294a69dfedd27982aace4a76909115",
+		"0x3b7b60af2abcd57ba401ab398f84f4ca54bd6b2140d2503fbcf3286535fe3ff1",
+		"0x072c02fa1409dc37e03a4ed01703d4a9e6bba9c228a49a00366e9630a97cba7c",
+		"0x771f47d3caf8a2ee40b0719e1c1ecbc01d73ada220cf08df12a00453ab703738",
+		"0xbef5a3cd63dd36ab9792364536140e5a0cce6925969940c431934de056398556",
+		"0x1441e38eb309b66e9286867a5cd05902b05413eb9723a685d4d77753d73d0a1d",
+		"0x583b887078cbae4b6ac6fbee324c3d2c16f3a1f8bf18f0d234de3ac33baa4470",
+		"0xb8f3de627932e28914f3bc4bc3d7d2fc95c1f95c7915343d79df68d8250de180",
+		"0xfd9f15cac5ffd14ed08914c200b1744ab00bdddf45e86cd13ccf9585ffa0e3ce",
+	}
+
 	kr, err := NewEd25519Keyring()
 	require.NoError(t, err)
 
 	v := reflect.ValueOf(kr).Elem()
 	for i := 0; i < v.NumField()-1; i++ {
 		key := v.Field(i).Interface().(*ed25519.Keypair).Private().Hex()
-		require.Equal(t, ed25519PrivateKeys[i], key[:66])
+		require.Equal(t, privateKeys[i], key[:66])
 	}
 }
@@ -42,23 +42,23 @@
 )
 
 var (
-	bytesAllocatedSumGauge = promauto.NewGauge(prometheus.GaugeOpts{
+	bytesAllocatedSumGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: "gossamer_allocator",
 		Name:      "bytes_allocated_sum",
-		Help:      "the sum of every allocation ever made this increases every time a new allocation is made",
-	})
-	bytesAllocatedPeakGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Help:      "the sum of every allocation made by the last call of the runtime function",
+	}, []string{"function"})
+	bytesAllocatedPeakGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: "gossamer_allocator",
 		Name:      "bytes_allocated_peak",
-		Help: "the peak number of bytes ever allocated this is the maximum " +
-			"the `bytes_allocated_sum` ever reached",
-	})
-	addressSpaceUsedGague = promauto.NewGauge(prometheus.GaugeOpts{
+		Help: "the peak number of bytes allocated by the last call of the runtime function, " +
+			"including the allocation headers",
+	}, []string{"function"})
+	addressSpaceUsedGague = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: "gossamer_allocator",
 		Name:      "address_space_used",
-		Help: "the amount of address space (in bytes) used by the allocator this is calculated as " +
-			"the difference between the allocator's bumper and the heap base.",
-	})
+		Help: "the amount of address space (in bytes) used by the last call of the runtime function, " +
+			"calculated as the difference between the allocator's bumper and the heap base.",
+	}, []string{"function"})
 
 	logger = log.NewFromGlobal(
 		log.AddContext("pkg", "runtime-allocator"),
@@ -74,7 +74,7 @@
 	ErrAllocatorOutOfSpace          = errors.New("allocator out of space")
 	ErrCannotGrowLinearMemory       = errors.New("cannot grow linear memory")
 	ErrInvalidPointerForDealocation = errors.New("invalid pointer for deallocation")
-	ErrEmptyHeader                  = errors.New("allocation points to an empty header")
+	ErrDoubleFree                   = errors.New("double free, allocation points to a free header")
 	ErrAllocatorPoisoned            = errors.New("allocator poisoned")
 	ErrMemoryShrunk                 = errors.New("memory shrunk")
 )
@@ -301,33 +301,33 @@ func (f *FreeLists) replace(order Order, newLink Link) (old Link) {
 
 // AllocationStats gather stats during the lifetime of the allocator
 type AllocationStats struct {
-	// the current number of bytes allocated
+	// BytesAllocated is the current number of bytes allocated
 	// this represents how many bytes are allocated *right now*
-	bytesAllocated uint32
+	BytesAllocated uint32
 
-	// the peak number of bytes ever allocated
-	// this is the maximum the `bytesAllocated` ever reached
-	bytesAllocatedPeak uint32
+	// BytesAllocatedPeak is the peak number of bytes ever allocated
+	// this is the maximum the `BytesAllocated` ever reached
+	BytesAllocatedPeak uint32
 
-	// the sum of every allocation ever made
+	// BytesAllocatedSum is the sum of every allocation ever made
 	// this increases every time a new allocation is made
-	bytesAllocatedSum *big.Int
+	BytesAllocatedSum *big.Int
 
-	// the amount of address space (in bytes) used by the allocator
+	// AddressSpaceUsed is the amount of address space (in bytes) used by the allocator
 	// this is calculated as the difference between the allocator's
 	// bumper and the heap base.
 	//
 	// currently the bumper's only ever incremented, so this is
 	// simultaneously the current value as well as the peak value.
-	addressSpaceUsed uint32
+	AddressSpaceUsed uint32
 }
 
-// collect exports the allocations stats through prometheus metrics
-// under `gossamer_allocator` namespace
-func (a AllocationStats) collect() {
-	bytesAllocatedSumGauge.Set(float64(a.bytesAllocatedSum.Uint64()))
-	bytesAllocatedPeakGauge.Set(float64(a.bytesAllocatedPeak))
-	addressSpaceUsedGague.Set(float64(a.addressSpaceUsed))
+// Collect exports the allocations stats of a call of the runtime function given
+// through prometheus metrics under `gossamer_allocator` namespace
+func (a AllocationStats) Collect(function string) {
+	bytesAllocatedSumGauge.WithLabelValues(function).Set(float64(a.BytesAllocatedSum.Uint64()))
+	bytesAllocatedPeakGauge.WithLabelValues(function).Set(float64(a.BytesAllocatedPeak))
+	addressSpaceUsedGague.WithLabelValues(function).Set(float64(a.AddressSpaceUsed))
 }
 
 var _ runtime.Allocator = (*FreeingBumpHeapAllocator)(nil)
@@ -335,29 +335,40 @@ func (a AllocationStats) collect() {
 type FreeingBumpHeapAllocator struct {
 	originalHeapBase       uint32
 	bumper                 uint32
+	maxPages               uint32
 	freeLists              *FreeLists
 	poisoned               bool
 	lastObservedMemorySize uint64
 	stats                  AllocationStats
 }
 
-func NewFreeingBumpHeapAllocator(heapBase uint32) *FreeingBumpHeapAllocator {
+// NewFreeingBumpHeapAllocator creates an allocator of the heap starting at the heap base given,
+// which never grows the linear memory beyond the given maximum number of pages, capped to MaxWasmPages.
+func NewFreeingBumpHeapAllocator(heapBase, maxPages uint32) *FreeingBumpHeapAllocator {
 	alignedHeapBase := (heapBase + Aligment - 1) / Aligment * Aligment
 	return &FreeingBumpHeapAllocator{
 		originalHeapBase:       alignedHeapBase,
 		bumper:                 alignedHeapBase,
+		maxPages:               min(maxPages, MaxWasmPages),
 		freeLists:              NewFreeLists(),
 		poisoned:               false,
 		lastObservedMemorySize: 0,
 		stats: AllocationStats{
-			bytesAllocated:     0,
-			bytesAllocatedPeak: 0,
-			bytesAllocatedSum:  big.NewInt(0),
-			addressSpaceUsed:   0,
+			BytesAllocated:     0,
+			BytesAllocatedPeak: 0,
+			BytesAllocatedSum:  big.NewInt(0),
+			AddressSpaceUsed:   0,
 		},
 	}
 }
 
+// Stats returns the stats of the allocations made since the allocator was created.
+func (f *FreeingBumpHeapAllocator) Stats() AllocationStats {
+	stats := f.stats
+	stats.BytesAllocatedSum = new(big.Int).Set(f.stats.BytesAllocatedSum)
+	return stats
+}
+
 // Allocate gets the requested number of bytes to allocate and returns a pointer.
 // The maximum size which can be allocated is 32MiB.
 // There is no minimum size, but whatever size is passed into this function is rounded
@@ -418,7 +429,7 @@ func (f *FreeingBumpHeapAllocator) Allocate(mem runtime.Memory, size uint32) (pt
 		headerPtr = value.headerPtr
 	case Nil:
 		// Corresponding free list is empty. Allocate a new item
-		newPtr, err := bump(&f.bumper, order.size()+HeaderSize, mem)
+		newPtr, err := bump(&f.bumper, order.size()+HeaderSize, f.maxPages, mem)
 		if err != nil {
 			return 0, fmt.Errorf("bumping: %w", err)
 		}
@@ -433,18 +444,17 @@ func (f *FreeingBumpHeapAllocator) Allocate(mem runtime.Memory, size uint32) (pt
 		return 0, fmt.Errorf("writing header into: %w", err)
 	}
 
-	f.stats.bytesAllocated += order.size() + HeaderSize
+	f.stats.BytesAllocated += order.size() + HeaderSize
 
-	// f.stats.bytesAllocatedSum += order.size() + HeaderSize
-	// but since bytesAllocatedSum is a big.NewInt we should
+	// f.stats.BytesAllocatedSum += order.size() + HeaderSize
+	// but since BytesAllocatedSum is a big.NewInt we should
 	// use the method `.Add` to perform the operations
-	f.stats.bytesAllocatedSum = big.NewInt(0).
-		Add(f.stats.bytesAllocatedSum,
+	f.stats.BytesAllocatedSum = big.NewInt(0).
+		Add(f.stats.BytesAllocatedSum,
 			big.NewInt(0).
 				Add(big.NewInt(int64(order.size())), big.NewInt(HeaderSize)))
-	f.stats.bytesAllocatedPeak = max(f.stats.bytesAllocatedPeak, f.stats.bytesAllocated)
-	f.stats.addressSpaceUsed = f.bumper - f.originalHeapBase
-	f.stats.collect()
+	f.stats.BytesAllocatedPeak = max(f.stats.BytesAllocatedPeak, f.stats.BytesAllocated)
+	f.stats.AddressSpaceUsed = f.bumper - f.originalHeapBase
 
 	return headerPtr + HeaderSize, nil
 }
@@ -487,7 +497,7 @@ func (f *FreeingBumpHeapAllocator) Deallocate(mem runtime.Memory, ptr uint32) (e
 
 	order, ok := header.intoOccupied()
 	if !ok {
-		return ErrEmptyHeader
+		return fmt.Errorf("%w: pointer: %d", ErrDoubleFree, ptr)
 	}
 
 	// update the just freed header and knit it back to the free list
@@ -497,17 +507,18 @@ func (f *FreeingBumpHeapAllocator) Deallocate(mem runtime.Memory, ptr uint32) (e
 		return fmt.Errorf("writing header into: %w", err)
 	}
 
-	newBytesAllocated, ok := checkedSub(f.stats.bytesAllocated, order.size()+HeaderSize)
+	newBytesAllocated, ok := checkedSub(f.stats.BytesAllocated, order.size()+HeaderSize)
 	if !ok {
 		return fmt.Errorf("underflow of the current allocated bytes count")
 	}
 
-	f.stats.bytesAllocated = newBytesAllocated
-	f.stats.collect()
+	f.stats.BytesAllocated = newBytesAllocated
 	return nil
 }
 
-func bump(bumper *uint32, size uint32, mem runtime.Memory) (uint32, error) {
+// bump increments the bumper by the size given and returns its previous value, growing
+// the linear memory if needed, up to the maximum number of pages given.
+func bump(bumper *uint32, size, maxPages uint32, mem runtime.Memory) (uint32, error) {
 	requiredSize := uint64(*bumper) + uint64(size)
 
 	if requiredSize > mem.Size() {
@@ -521,26 +532,26 @@ func bump(bumper *uint32, size uint32, mem runtime.Memory) (uint32, error) {
 			panic(fmt.Sprintf("page size cannot fit into uint32, current memory size: %d", mem.Size()))
 		}
 
-		if currentPages >= MaxWasmPages {
-			return 0, fmt.Errorf("%w: current pages %d greater than max wasm pages %d",
-				ErrAllocatorOutOfSpace, currentPages, MaxWasmPages)
+		if currentPages >= maxPages {
+			return 0, fmt.Errorf("%w: current pages %d greater than max pages %d",
+				ErrAllocatorOutOfSpace, currentPages, maxPages)
 		}
 
-		if requiredPages > MaxWasmPages {
-			return 0, fmt.Errorf("%w: required pages %d greater than max wasm pages %d",
-				ErrAllocatorOutOfSpace, requiredPages, MaxWasmPages)
+		if requiredPages > maxPages {
+			return 0, fmt.Errorf("%w: required pages %d greater than max pages %d",
+				ErrAllocatorOutOfSpace, requiredPages, maxPages)
 		}
 
 		// ideally we want to double our current number of pages,
 		// as long as it's less than the double absolute max we can have
-		nextPages := min(currentPages*2, MaxWasmPages)
+		nextPages := min(currentPages*2, maxPages)
 		// ... but if even more pages are required then try to allocate that many
 		nextPages = max(nextPages, requiredPages)
 
 		_, ok = mem.Grow(nextPages - currentPages)
 		if !ok {
-			return 0, fmt.Errorf("%w: from %d pages to %d pages",
-				ErrCannotGrowLinearMemory, currentPages, nextPages)
+			return 0, fmt.Errorf("%w: %w: from %d pages to %d pages",
+				ErrAllocatorOutOfSpace, ErrCannotGrowLinearMemory, currentPages, nextPages)
 		}
 
 		pagesIncrease := (mem.Size() / PageSize) == uint64(nextPages)
@@ -34,7 +34,7 @@ func TestPagesFromSize(t *testing.T) {
 
 func TestShouldAllocatePropertly(t *testing.T) {
 	mem := NewMemoryInstanceWithPages(t, 1)
-	heap := NewFreeingBumpHeapAllocator(0)
+	heap := NewFreeingBumpHeapAllocator(0, MaxWasmPages)
 
 	ptr, err := heap.Allocate(mem, 1)
 	require.NoError(t, err)
@@ -43,7 +43,7 @@ func TestShouldAllocatePropertly(t *testing.T) {
 
 func TestShouldAlwaysAlignPointerToMultiplesOf8(t *testing.T) {
 	mem := NewMemoryInstanceWithPages(t, 1)
-	heap := NewFreeingBumpHeapAllocator(13)
+	heap := NewFreeingBumpHeapAllocator(13, MaxWasmPages)
 
 	ptr, err := heap.Allocate(mem, 1)
 	require.NoError(t, err)
@@ -55,7 +55,7 @@ func TestShouldAlwaysAlignPointerToMultiplesOf8(t *testing.T) {
 
 func TestShouldIncrementPointersProperly(t *testing.T) {
 	mem := NewMemoryInstanceWithPages(t, 1)
-	heap := NewFreeingBumpHeapAllocator(0)
+	heap := NewFreeingBumpHeapAllocator(0, MaxWasmPages)
 
 	ptr1, err := heap.Allocate(mem, 1)
 	require.NoError(t, err)
@@ -79,7 +79,7 @@ func TestShouldIncrementPointersProperly(t *testing.T) {
 
 func TestShouldFreeProperly(t *testing.T) {
 	mem := NewMemoryInstanceWithPages(t, 1)
-	heap := NewFreeingBumpHeapAllocator(0)
+	heap := NewFreeingBumpHeapAllocator(0, MaxWasmPages)
 
 	ptr1, err := heap.Allocate(mem, 1)
 	require.NoError(t, err)
@@ -105,7 +105,7 @@ func TestShouldFreeProperly(t *testing.T) {
 func TestShouldDeallocateAndReallocateProperly(t *testing.T) {
 	const paddedOffset = 16
 	mem := NewMemoryInstanceWithPages(t, 1)
-	heap := NewFreeingBumpHeapAllocator(13)
+	heap := NewFreeingBumpHeapAllocator(13, MaxWasmPages)
 
 	ptr1, err := heap.Allocate(mem, 1)
 	require.NoError(t, err)
@@ -138,7 +138,7 @@ func TestShouldDeallocateAndReallocateProperly(t *testing.T) {
 
 func TestShouldBuildLinkedListOfFreeAreasProperly(t *testing.T) {
 	mem := NewMemoryInstanceWithPages(t, 1)
-	heap := NewFreeingBumpHeapAllocator(0)
+	heap := NewFreeingBumpHeapAllocator(0, MaxWasmPages)
 
 	// given
 	ptr1, err := heap.Allocate(mem, 8)
@@ -175,7 +175,7 @@ func TestShouldNotAllocIfTooLarge(t *testing.T) {
 	mem := NewMemoryInstanceWithPages(t, 1)
 	mem.setMaxWasmPages(1)
 
-	heap := NewFreeingBumpHeapAllocator(13)
+	heap := NewFreeingBumpHeapAllocator(13, MaxWasmPages)
 
 	ptr, err := heap.Allocate(mem, PageSize-13)
 	require.Zero(t, ptr)
@@ -185,7 +185,7 @@ func TestShouldNotAllocIfTooLarge(t *testing.T) {
 func TestShouldNotAllocateIfFull(t *testing.T) {
 	mem := NewMemoryInstanceWithPages(t, 1)
 	mem.setMaxWasmPages(1)
-	heap := NewFreeingBumpHeapAllocator(0)
+	heap := NewFreeingBumpHeapAllocator(0, MaxWasmPages)
 
 	ptr1, err := heap.Allocate(mem, (PageSize/2)-HeaderSize)
 	require.NoError(t, err)
@@ -199,7 +199,7 @@ func TestShouldNotAllocateIfFull(t *testing.T) {
 
 func TestShouldAllocateMaxPossibleAllocationSize(t *testing.T) {
 	mem := NewMemoryInstanceWithPages(t, 1)
-	heap := NewFreeingBumpHeapAllocator(0)
+	heap := NewFreeingBumpHeapAllocator(0, MaxWasmPages)
 
 	ptr, err := heap.Allocate(mem, MaxPossibleAllocations)
 	require.NoError(t, err)
@@ -208,7 +208,7 @@ func TestShouldAllocateMaxPossibleAllocationSize(t *testing.T) {
 
 func TestShouldNotAllocateIfRequestedSizeIsTooLarge(t *testing.T) {
 	mem := NewMemoryInstanceWithPages(t, 1)
-	heap := NewFreeingBumpHeapAllocator(0)
+	heap := NewFreeingBumpHeapAllocator(0, MaxWasmPages)
 
 	ptr, err := heap.Allocate(mem, MaxPossibleAllocations+1)
 	require.Zero(t, ptr)
@@ -218,7 +218,7 @@ func TestShouldNotAllocateIfRequestedSizeIsTooLarge(t *testing.T) {
 func TestShouldReturnErrorWhenBumperGreaterThanHeapSize(t *testing.T) {
 	mem := NewMemoryInstanceWithPages(t, 1)
 	mem.setMaxWasmPages(1)
-	heap := NewFreeingBumpHeapAllocator(0)
+	heap := NewFreeingBumpHeapAllocator(0, MaxWasmPages)
 
 	ptrs := make([]uint32, 0)
 	for idx := 0; idx < (PageSize / 40); idx++ {
@@ -228,7 +228,7 @@ func TestShouldReturnErrorWhenBumperGreaterThanHeapSize(t *testing.T) {
 		ptrs = append(ptrs, ptr)
 	}
 
-	require.Equal(t, uint32(PageSize-16), heap.stats.bytesAllocated)
+	require.Equal(t, uint32(PageSize-16), heap.stats.BytesAllocated)
 	require.Equal(t, uint32(PageSize-16), heap.bumper)
 
 	for _, ptr := range ptrs {
@@ -236,8 +236,8 @@ func TestShouldReturnErrorWhenBumperGreaterThanHeapSize(t *testing.T) {
 		require.NoError(t, err)
 	}
 
-	require.Zero(t, heap.stats.bytesAllocated)
-	require.Equal(t, uint32(PageSize-16), heap.stats.bytesAllocatedPeak)
+	require.Zero(t, heap.stats.BytesAllocated)
+	require.Equal(t, uint32(PageSize-16), heap.stats.BytesAllocatedPeak)
 	require.Equal(t, uint32(PageSize-16), heap.bumper)
 
 	// Allocate another 8 byte to use the full heap
@@ -257,18 +257,18 @@ func TestShouldReturnErrorWhenBumperGreaterThanHeapSize(t *testing.T) {
 
 func TestShouldIncludePrefixesInTotalHeapSize(t *testing.T) {
 	mem := NewMemoryInstanceWithPages(t, 1)
-	heap := NewFreeingBumpHeapAllocator(1)
+	heap := NewFreeingBumpHeapAllocator(1, MaxWasmPages)
 
 	ptr, err := heap.Allocate(mem, 9)
 	require.NoError(t, err)
 	require.NotZero(t, ptr)
 
-	require.Equal(t, uint32(HeaderSize+16), heap.stats.bytesAllocated)
+	require.Equal(t, uint32(HeaderSize+16), heap.stats.BytesAllocated)
 }
 
 func TestShouldCalculateTotalHeapSizeToZero(t *testing.T) {
 	mem := NewMemoryInstanceWithPages(t, 1)
-	heap := NewFreeingBumpHeapAllocator(13)
+	heap := NewFreeingBumpHeapAllocator(13, MaxWasmPages)
 
 	ptr, err := heap.Allocate(mem, 42)
 	require.NoError(t, err)
@@ -277,12 +277,12 @@ func TestShouldCalculateTotalHeapSizeToZero(t *testing.T) {
 	err = heap.Deallocate(mem, ptr)
 	require.NoError(t, err)
 
-	require.Zero(t, heap.stats.bytesAllocated)
+	require.Zero(t, heap.stats.BytesAllocated)
 }
 
 func TestShouldCalculateTotalSizeOfZero(t *testing.T) {
 	mem := NewMemoryInstanceWithPages(t, 1)
-	heap := NewFreeingBumpHeapAllocator(19)
+	heap := NewFreeingBumpHeapAllocator(19, MaxWasmPages)
 
 	for idx := 1; idx < 10; idx++ {
 		ptr, err := heap.Allocate(mem, 42)
@@ -293,7 +293,7 @@ func TestShouldCalculateTotalSizeOfZero(t *testing.T) {
 		require.NoError(t, err)
 	}
 
-	require.Zero(t, heap.stats.bytesAllocated)
+	require.Zero(t, heap.stats.BytesAllocated)
 }
 
 func TestShouldGetItemSizeFromOrder(t *testing.T) {
@@ -312,7 +312,7 @@ func TestShouldGetMaxItemSizeFromIndex(t *testing.T) {
 
 func TestDeallocateNeedsToMaintainLinkedList(t *testing.T) {
 	mem := NewMemoryInstanceWithPages(t, 1)
-	heap := NewFreeingBumpHeapAllocator(0)
+	heap := NewFreeingBumpHeapAllocator(0, MaxWasmPages)
 
 	// allocate and free some pointers
 	ptrs := make([]uint32, 4)
@@ -346,7 +346,7 @@ func TestPoisonOOM(t *testing.T) {
 	mem := NewMemoryInstanceWithPages(t, 1)
 	mem.setMaxWasmPages(1)
 
-	heap := NewFreeingBumpHeapAllocator(0)
+	heap := NewFreeingBumpHeapAllocator(0, MaxWasmPages)
 
 	alloc_ptr, err := heap.Allocate(mem, PageSize/2)
 	require.NoError(t, err)
@@ -362,6 +362,60 @@ func TestPoisonOOM(t *testing.T) {
 	require.Error(t, err, ErrAllocatorPoisoned)
 }
 
+func TestPoisonDoubleFree(t *testing.T) {
+	mem := NewMemoryInstanceWithPages(t, 1)
+	heap := NewFreeingBumpHeapAllocator(0, MaxWasmPages)
+
+	ptr, err := heap.Allocate(mem, 8)
+	require.NoError(t, err)
+
+	err = heap.Deallocate(mem, ptr)
+	require.NoError(t, err)
+
+	err = heap.Deallocate(mem, ptr)
+	require.ErrorIs(t, err, ErrDoubleFree)
+	require.True(t, heap.poisoned)
+
+	ptr, err = heap.Allocate(mem, 8)
+	require.Zero(t, ptr)
+	require.ErrorIs(t, err, ErrAllocatorPoisoned)
+}
+
+func TestShouldNotGrowMemoryBeyondMaxPages(t *testing.T) {
+	mem := NewMemoryInstanceWithPages(t, 1)
+	heap := NewFreeingBumpHeapAllocator(0, 2)
+
+	// the memory is grown to the max pages instead of being doubled
+	ptr, err := heap.Allocate(mem, PageSize)
+	require.NoError(t, err)
+	require.NotZero(t, ptr)
+	require.Equal(t, uint32(2), mem.pages())
+
+	ptr, err = heap.Allocate(mem, PageSize)
+	require.Zero(t, ptr)
+	require.ErrorIs(t, err, ErrAllocatorOutOfSpace)
+	require.Equal(t, uint32(2), mem.pages())
+	require.True(t, heap.poisoned)
+}
+
+func TestStats(t *testing.T) {
+	mem := NewMemoryInstanceWithPages(t, 1)
+	heap := NewFreeingBumpHeapAllocator(0, MaxWasmPages)
+
+	ptr, err := heap.Allocate(mem, 8)
+	require.NoError(t, err)
+	_, err = heap.Allocate(mem, 16)
+	require.NoError(t, err)
+	err = heap.Deallocate(mem, ptr)
+	require.NoError(t, err)
+
+	stats := heap.Stats()
+	require.Equal(t, uint32(16+HeaderSize), stats.BytesAllocated)
+	require.Equal(t, uint32(8+16+2*HeaderSize), stats.BytesAllocatedPeak)
+	require.Equal(t, uint64(8+16+2*HeaderSize), stats.BytesAllocatedSum.Uint64())
+	require.Equal(t, uint32(8+16+2*HeaderSize), stats.AddressSpaceUsed)
+}
+
 func TestNOrders(t *testing.T) {
 	// Test that N_ORDERS is consistent with min and max possible allocation.
 	require.Equal(t,
@@ -371,7 +425,7 @@ func TestNOrders(t *testing.T) {
 
 func TestAcceptsGrowingMemory(t *testing.T) {
 	mem := NewMemoryInstanceWithPages(t, 1)
-	heap := NewFreeingBumpHeapAllocator(0)
+	heap := NewFreeingBumpHeapAllocator(0, MaxWasmPages)
 
 	ptr1, err := heap.Allocate(mem, PageSize/2)
 	require.NoError(t, err)
@@ -391,7 +445,7 @@ func TestAcceptsGrowingMemory(t *testing.T) {
 
 func TestDoesNotAcceptShrinkingMemory(t *testing.T) {
 	mem := NewMemoryInstanceWithPages(t, 2)
-	heap := NewFreeingBumpHeapAllocator(0)
+	heap := NewFreeingBumpHeapAllocator(0, MaxWasmPages)
 	ptr, err := heap.Allocate(mem, PageSize/2)
 	require.NoError(t, err)
 	require.NotZero(t, ptr)
@@ -407,7 +461,7 @@ func TestDoesNotAcceptShrinkingMemory(t *testing.T) {
 
 func TestShouldGrowMemoryWhenRunningOutOfSpace(t *testing.T) {
 	mem := NewMemoryInstanceWithPages(t, 1)
-	heap := NewFreeingBumpHeapAllocator(0)
+	heap := NewFreeingBumpHeapAllocator(0, MaxWasmPages)
 
 	require.Equal(t, uint32(1), mem.pages())
 	ptr, err := heap.Allocate(mem, PageSize*2)
@@ -418,7 +472,7 @@ func TestShouldGrowMemoryWhenRunningOutOfSpace(t *testing.T) {
 
 func TestModifyingHeaderLeadsToAnError(t *testing.T) {
 	mem := NewMemoryInstanceWithPages(t, 1)
-	heap := NewFreeingBumpHeapAllocator(0)
+	heap := NewFreeingBumpHeapAllocator(0, MaxWasmPages)
 	ptr, err := heap.Allocate(mem, 5)
 	require.NoError(t, err)
 	require.NotZero(t, ptr)
@@ -28,6 +28,12 @@
 	WESTEND_RUNTIME_V0912_FP  = "westend_runtime-v9111.compact.wasm"
 	WESTEND_RUNTIME_V0912_URL = "https://github.com/paritytech/polkadot/releases/download/v0.9.11/" +
 		"westend_runtime-v9111.compact.compressed.wasm?raw=true"
+
+	// v1.8.0 contracts rococo parachain, used to test the child tries of the contracts pallet
+	CONTRACTS_ROCOCO_RUNTIME_v180     = "contracts-rococo_runtime-v1008000"
+	CONTRACTS_ROCOCO_RUNTIME_V180_FP  = "contracts-rococo_runtime-v1008000.compact.wasm"
+	CONTRACTS_ROCOCO_RUNTIME_V180_URL = "https://github.com/paritytech/polkadot-sdk/releases/download/polkadot-v1.8.0/" +
+		"contracts-rococo_runtime-v1008000.compact.compressed.wasm?raw=true"
 )
 
 const (
@@ -39,6 +45,10 @@
 	CoreExecuteBlock = "Core_execute_block"
 	// Metadata is the runtime API call Metadata_metadata
 	Metadata = "Metadata_metadata"
+	// MetadataAtVersion is the runtime API call Metadata_metadata_at_version
+	MetadataAtVersion = "Metadata_metadata_at_version"
+	// MetadataVersions is the runtime API call Metadata_metadata_versions
+	MetadataVersions = "Metadata_metadata_versions"
 	// TaggedTransactionQueueValidateTransaction is the runtime API call TaggedTransactionQueue_validate_transaction
 	TaggedTransactionQueueValidateTransaction = "TaggedTransactionQueue_validate_transaction"
 	// GrandpaAuthorities is the runtime API call GrandpaApi_grandpa_authorities
@@ -54,12 +64,35 @@
 	GrandpaGenerateKeyOwnershipProof = "GrandpaApi_generate_key_ownership_proof"
 	// BabeAPIConfiguration is the runtime API call BabeApi_configuration
 	BabeAPIConfiguration = "BabeApi_configuration"
+	// AuraAPISlotDuration is the runtime API call AuraApi_slot_duration
+	AuraAPISlotDuration = "AuraApi_slot_duration"
+	// AuraAPIAuthorities is the runtime API call AuraApi_authorities
+	AuraAPIAuthorities = "AuraApi_authorities"
+	// SassafrasAPIRingContext is the runtime API call SassafrasApi_ring_context
+	SassafrasAPIRingContext = "SassafrasApi_ring_context"
+	// SassafrasAPISubmitTicketsUnsignedExtrinsic is the runtime API call
+	// SassafrasApi_submit_tickets_unsigned_extrinsic
+	SassafrasAPISubmitTicketsUnsignedExtrinsic = "SassafrasApi_submit_tickets_unsigned_extrinsic"
+	// SassafrasAPISlotTicket is the runtime API call SassafrasApi_slot_ticket
+	SassafrasAPISlotTicket = "SassafrasApi_slot_ticket"
+	// SassafrasAPICurrentEpoch is the runtime API call SassafrasApi_current_epoch
+	SassafrasAPICurrentEpoch = "SassafrasApi_current_epoch"
+	// SassafrasAPINextEpoch is the runtime API call SassafrasApi_next_epoch
+	SassafrasAPINextEpoch = "SassafrasApi_next_epoch"
+	// ParachainHostPersistedValidationData is the runtime API call ParachainHost_persisted_validation_data
+	ParachainHostPersistedValidationData = "ParachainHost_persisted_validation_data"
+	// ParachainHostValidators is the runtime API call ParachainHost_validators
+	ParachainHostValidators = "ParachainHost_validators"
+	// ParachainHostValidationCodeHash is the runtime API call ParachainHost_validation_code_hash
+	ParachainHostValidationCodeHash = "ParachainHost_validation_code_hash"
 	// BlockBuilderInherentExtrinsics is the runtime API call BlockBuilder_inherent_extrinsics
 	BlockBuilderInherentExtrinsics = "BlockBuilder_inherent_extrinsics"
 	// BlockBuilderApplyExtrinsic is the runtime API call BlockBuilder_apply_extrinsic
 	BlockBuilderApplyExtrinsic = "BlockBuilder_apply_extrinsic"
 	// BlockBuilderFinalizeBlock is the runtime API call BlockBuilder_finalize_block
 	BlockBuilderFinalizeBlock = "BlockBuilder_finalize_block"
+	// OffchainWorkerAPIOffchainWorker is the runtime API call OffchainWorkerApi_offchain_worker
+	OffchainWorkerAPIOffchainWorker = "OffchainWorkerApi_offchain_worker"
 	// DecodeSessionKeys is the runtime API call SessionKeys_decode_session_keys
 	DecodeSessionKeys = "SessionKeys_decode_session_keys"
 	// TransactionPaymentAPIQueryInfo returns information of a given extrinsic
@@ -68,4 +101,10 @@
 	TransactionPaymentCallAPIQueryCallInfo = "TransactionPaymentCallApi_query_call_info"
 	// TransactionPaymentCallAPIQueryCallFeeDetails returns call query call fee details
 	TransactionPaymentCallAPIQueryCallFeeDetails = "TransactionPaymentCallApi_query_call_fee_details"
+	// ContractsAPIInstantiate is the runtime API call ContractsApi_instantiate
+	ContractsAPIInstantiate = "ContractsApi_instantiate"
+	// GenesisBuilderCreateDefaultConfig returns the default JSON genesis config of the runtime
+	GenesisBuilderCreateDefaultConfig = "GenesisBuilder_create_default_config"
+	// GenesisBuilderBuildConfig builds the genesis storage from a JSON genesis config
+	GenesisBuilderBuildConfig = "GenesisBuilder_build_config"
 )
@@ -4,6 +4,9 @@
 package runtime
 
 import (
+	"context"
+	"errors"
+
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
@@ -11,6 +14,10 @@
 	"github.com/ChainSafe/gossamer/lib/transaction"
 )
 
+// ErrMetadataVersionNotSupported is returned when the runtime does not provide the
+// metadata at the version requested.
+var ErrMetadataVersionNotSupported = errors.New("metadata version not supported")
+
 // Instance for runtime methods
 type Instance interface {
 	Stop()
@@ -18,14 +25,18 @@ type Instance interface {
 	NetworkService() BasicNetwork
 	Keystore() *keystore.GlobalKeystore
 	Validator() bool
+	Config() InstanceConfig
 	Exec(function string, data []byte) ([]byte, error)
+	ExecContext(ctx context.Context, function string, data []byte) ([]byte, error)
 	SetContextStorage(s Storage)
 	GetCodeHash() common.Hash
 	Version() (Version, error)
 	Metadata() (metadata []byte, err error)
+	MetadataAtVersion(version uint32) (metadata []byte, err error)
+	MetadataVersions() (versions []uint32, err error)
 	BabeConfiguration() (*types.BabeConfiguration, error)
 	GrandpaAuthorities() ([]types.Authority, error)
-	ValidateTransaction(e types.Extrinsic) (*transaction.Validity, error)
+	ValidateTransaction(ctx context.Context, e types.Extrinsic) (*transaction.Validity, error)
 	InitializeBlock(header *types.Header) error
 	InherentExtrinsics(data []byte) ([]byte, error)
 	ApplyExtrinsic(data types.Extrinsic) ([]byte, error)
@@ -41,7 +52,7 @@ type Instance interface {
 		keyOwnershipProof types.OpaqueKeyOwnershipProof,
 	) error
 	RandomSeed()
-	OffchainWorker()
+	OffchainWorker(header *types.Header) ([]types.Extrinsic, error)
 	GenerateSessionKeys()
 	GrandpaGenerateKeyOwnershipProof(authSetID uint64, authorityID ed25519.PublicKeyBytes) (
 		types.GrandpaOpaqueKeyOwnershipProof, error)
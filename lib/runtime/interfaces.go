@@ -5,6 +5,7 @@
 
 import (
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime/storage"
 	"github.com/ChainSafe/gossamer/lib/transaction"
 	"github.com/ChainSafe/gossamer/pkg/trie"
 )
@@ -19,6 +20,8 @@ type Trie interface {
 	ClearPrefix(prefix []byte) (err error)
 	ClearPrefixLimit(prefix []byte, limit uint32) (
 		deleted uint32, allDeleted bool, err error)
+	ClearPrefixFrom(prefix []byte, limit *uint32, cursor []byte) (
+		results storage.RemovalResults, err error)
 }
 
 // ChildTrie storage interface.S
@@ -32,6 +35,8 @@ type ChildTrie interface {
 	ClearChildStorage(keyToChild, key []byte) error
 	ClearPrefixInChild(keyToChild, prefix []byte) error
 	ClearPrefixInChildWithLimit(keyToChild, prefix []byte, limit uint32) (uint32, bool, error)
+	ClearPrefixInChildFrom(keyToChild, prefix []byte, limit *uint32, cursor []byte) (
+		results storage.RemovalResults, err error)
 	GetChildNextKey(keyToChild, key []byte) ([]byte, error)
 }
 
@@ -352,9 +352,27 @@ func (_m *Instance) NodeStorage() runtime.NodeStorage {
 	return r0
 }
 
-// OffchainWorker provides a mock function with given fields:
-func (_m *Instance) OffchainWorker() {
-	_m.Called()
+// OffchainWorker provides a mock function with given fields: header
+func (_m *Instance) OffchainWorker(header *types.Header) ([]types.Extrinsic, error) {
+	ret := _m.Called(header)
+
+	var r0 []types.Extrinsic
+	if rf, ok := ret.Get(0).(func(*types.Header) []types.Extrinsic); ok {
+		r0 = rf(header)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]types.Extrinsic)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*types.Header) error); ok {
+		r1 = rf(header)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // PaymentQueryInfo provides a mock function with given fields: ext
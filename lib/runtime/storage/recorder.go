@@ -0,0 +1,98 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package storage
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+)
+
+// keyRecorder records the keys of the state trie accessed during a runtime
+// call, so a storage proof of every node touched by the call can be generated
+// from the trie the call started from.
+type keyRecorder struct {
+	mu   sync.Mutex
+	keys map[string]struct{}
+	// childKeys are the keys recorded of each child trie, by key of the child trie
+	childKeys map[string]map[string]struct{}
+}
+
+func newKeyRecorder() *keyRecorder {
+	return &keyRecorder{
+		keys: make(map[string]struct{}),
+	}
+}
+
+func (r *keyRecorder) record(keys ...[]byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, key := range keys {
+		if key == nil {
+			continue
+		}
+		r.keys[string(key)] = struct{}{}
+	}
+}
+
+// recordChild records the key of the main trie holding the root of a child trie,
+// and the keys of the child trie given.
+func (r *keyRecorder) recordChild(keyToChild []byte, keys ...[]byte) {
+	key := make([]byte, 0, len(inmemory.ChildStorageKeyPrefix)+len(keyToChild))
+	key = append(key, inmemory.ChildStorageKeyPrefix...)
+	r.record(append(key, keyToChild...))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, key := range keys {
+		if key == nil {
+			continue
+		}
+		if r.childKeys == nil {
+			r.childKeys = make(map[string]map[string]struct{})
+		}
+		childKeys, ok := r.childKeys[string(keyToChild)]
+		if !ok {
+			childKeys = make(map[string]struct{})
+			r.childKeys[string(keyToChild)] = childKeys
+		}
+		childKeys[string(key)] = struct{}{}
+	}
+}
+
+// recordedKeys returns the recorded keys in lexicographical order.
+func (r *keyRecorder) recordedKeys() [][]byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return sortedKeys(r.keys)
+}
+
+// recordedChildKeys returns the recorded keys of each child trie in lexicographical
+// order, by key of the child trie.
+func (r *keyRecorder) recordedChildKeys() map[string][][]byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	childKeys := make(map[string][][]byte, len(r.childKeys))
+	for keyToChild, keys := range r.childKeys {
+		childKeys[keyToChild] = sortedKeys(keys)
+	}
+	return childKeys
+}
+
+func sortedKeys(set map[string]struct{}) [][]byte {
+	keys := make([][]byte, 0, len(set))
+	for key := range set {
+		keys = append(keys, []byte(key))
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return string(keys[i]) < string(keys[j])
+	})
+	return keys
+}
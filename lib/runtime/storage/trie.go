@@ -9,11 +9,14 @@
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"math"
 	"sort"
+	"strings"
 	"sync"
 
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
 	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 )
@@ -26,6 +29,19 @@ type TrieState struct {
 	mtx          sync.RWMutex
 	state        trie.Trie
 	transactions *list.List
+	recorder     *keyRecorder
+	// written records the keys of the state trie written, see WrittenKeys
+	written *keyRecorder
+	// flat serves the reads of the initial state trie, nil once the state trie is written
+	flat FlatReader
+}
+
+// FlatReader reads the storage values of a state trie from a flat key-value store,
+// without traversing the trie.
+type FlatReader interface {
+	// Get returns the value of the key given, nil if the key is not in the state, and
+	// false if the value cannot be read from the flat store.
+	Get(key []byte) (value []byte, ok bool)
 }
 
 // NewTrieState initialises and returns a new TrieState instance
@@ -34,6 +50,7 @@ func NewTrieState(initialState trie.Trie) *TrieState {
 	return &TrieState{
 		transactions: transactions,
 		state:        initialState,
+		written:      newKeyRecorder(),
 	}
 }
 
@@ -93,6 +110,137 @@ func (t *TrieState) CommitTransaction() {
 		// This is the last transaction so we apply all the changes to our state
 		tx := t.transactions.Remove(t.transactions.Back()).(*storageDiff)
 		tx.applyToTrie(t.state)
+		t.flat = nil
+	}
+}
+
+// EnableProofRecording starts recording the keys of the state trie accessed
+// through the TrieState, see RecordedKeys.
+func (t *TrieState) EnableProofRecording() {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	t.recorder = newKeyRecorder()
+}
+
+// RecordedKeys returns the keys of the state trie accessed since the proof
+// recording was enabled, in lexicographical order. Generating the proof of
+// these keys on the initial state trie produces the storage proof needed to
+// replay the calls made on the TrieState. It returns nil if the proof
+// recording is not enabled.
+func (t *TrieState) RecordedKeys() [][]byte {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+
+	if t.recorder == nil {
+		return nil
+	}
+	return t.recorder.recordedKeys()
+}
+
+func (t *TrieState) record(keys ...[]byte) {
+	if t.recorder != nil {
+		t.recorder.record(keys...)
+	}
+}
+
+// RecordedChildKeys returns the keys of each child trie accessed since the proof recording
+// was enabled, in lexicographical order, by key of the child trie. The keys of the main trie
+// holding the roots of the child tries accessed are returned by RecordedKeys. It returns nil
+// if the proof recording is not enabled.
+func (t *TrieState) RecordedChildKeys() map[string][][]byte {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+
+	if t.recorder == nil {
+		return nil
+	}
+	return t.recorder.recordedChildKeys()
+}
+
+func (t *TrieState) recordChild(keyToChild []byte, keys ...[]byte) {
+	if t.recorder != nil {
+		t.recorder.recordChild(keyToChild, keys...)
+	}
+}
+
+// recordChildPrefix records the prefix given and the keys starting with it of the child
+// trie in the state trie.
+func (t *TrieState) recordChildPrefix(keyToChild, prefix []byte) {
+	if t.recorder == nil {
+		return
+	}
+
+	t.recorder.recordChild(keyToChild, prefix)
+	child, err := t.state.GetChild(keyToChild)
+	if err != nil || child == nil {
+		return
+	}
+	for key := range child.PrefixedKeys(prefix) {
+		t.recorder.recordChild(keyToChild, key)
+	}
+}
+
+// recordChildNextKey records the key given and the key following it of the child trie
+// in the state trie.
+func (t *TrieState) recordChildNextKey(keyToChild, key []byte) {
+	if t.recorder == nil {
+		return
+	}
+
+	t.recorder.recordChild(keyToChild, key)
+	child, err := t.state.GetChild(keyToChild)
+	if err != nil || child == nil {
+		return
+	}
+	t.recorder.recordChild(keyToChild, child.NextKey(key))
+}
+
+// WrittenKeys returns the keys of the state trie written through the TrieState, in
+// lexicographical order, the writes to a child trie being recorded as a write of the key
+// holding its root. The keys written in a storage transaction rolled back or written with
+// their current value are included, so the keys whose value changed are a subset of the
+// keys returned.
+func (t *TrieState) WrittenKeys() [][]byte {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+
+	if t.written == nil {
+		return nil
+	}
+	return t.written.recordedKeys()
+}
+
+func (t *TrieState) recordWrite(keys ...[]byte) {
+	if t.written == nil {
+		t.written = newKeyRecorder()
+	}
+	t.written.record(keys...)
+	t.invalidateFlat()
+}
+
+func (t *TrieState) recordChildWrite(keyToChild []byte) {
+	if t.written == nil {
+		t.written = newKeyRecorder()
+	}
+	t.written.recordChild(keyToChild)
+	t.invalidateFlat()
+}
+
+// SetFlatReader sets the flat store of the initial state trie, serving the reads of the
+// keys not written since. It must hold the same state as the trie.
+func (t *TrieState) SetFlatReader(flat FlatReader) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	t.flat = flat
+}
+
+// invalidateFlat stops reading from the flat store once the state trie is written
+// directly, outside of a storage transaction.
+func (t *TrieState) invalidateFlat() {
+	if t.getCurrentTransaction() == nil {
+		t.flat = nil
 	}
 }
 
@@ -109,6 +257,9 @@ func (t *TrieState) Put(key, value []byte) (err error) {
 	t.mtx.Lock()
 	defer t.mtx.Unlock()
 
+	t.record(key)
+	t.recordWrite(key)
+
 	// If we have running transactions we apply the change there,
 	// if not, we apply the changes directly on our state trie
 	if t.getCurrentTransaction() != nil {
@@ -133,6 +284,12 @@ func (t *TrieState) Get(key []byte) []byte {
 	}
 
 	// If we didn't find the key in the latest transactions lookup from state
+	t.record(key)
+	if t.flat != nil {
+		if value, ok := t.flat.Get(key); ok {
+			return value
+		}
+	}
 	return t.state.Get(key)
 }
 
@@ -161,6 +318,9 @@ func (t *TrieState) Delete(key []byte) (err error) {
 	t.mtx.Lock()
 	defer t.mtx.Unlock()
 
+	t.record(key)
+	t.recordWrite(key)
+
 	if currentTx := t.getCurrentTransaction(); currentTx != nil {
 		t.getCurrentTransaction().delete(string(key))
 		return nil
@@ -195,6 +355,7 @@ func (t *TrieState) NextKey(key []byte) []byte {
 				break
 			}
 		}
+		t.record(key, nextKeyOnState)
 
 		if nextKeyOnState == nil {
 			return nextKey
@@ -207,7 +368,9 @@ func (t *TrieState) NextKey(key []byte) []byte {
 		return nextKey
 	}
 
-	return t.state.NextKey(key)
+	nextKey := t.state.NextKey(key)
+	t.record(key, nextKey)
+	return nextKey
 }
 
 // ClearPrefix deletes all key-value pairs from the trie where the key starts with the given prefix
@@ -220,12 +383,19 @@ func (t *TrieState) ClearPrefix(prefix []byte) error {
 
 		for key := range t.state.PrefixedKeys(prefix) {
 			keysOnState = append(keysOnState, string(key))
+			t.record(key)
+			t.recordWrite(key)
 		}
 
 		currentTx.clearPrefix(prefix, keysOnState, -1)
 		return nil
 	}
 
+	for key := range t.state.PrefixedKeys(prefix) {
+		t.record(key)
+		t.recordWrite(key)
+	}
+
 	return t.state.ClearPrefix(prefix)
 }
 
@@ -240,12 +410,19 @@ func (t *TrieState) ClearPrefixLimit(prefix []byte, limit uint32) (
 
 		for key := range t.state.PrefixedKeys(prefix) {
 			keysOnState = append(keysOnState, string(key))
+			t.record(key)
+			t.recordWrite(key)
 		}
 
 		deleted, allDeleted = currentTx.clearPrefix(prefix, keysOnState, int(limit))
 		return deleted, allDeleted, nil
 	}
 
+	for key := range t.state.PrefixedKeys(prefix) {
+		t.record(key)
+		t.recordWrite(key)
+	}
+
 	return t.state.ClearPrefixLimit(prefix, limit)
 }
 
@@ -277,8 +454,25 @@ func (t *TrieState) SetChildStorage(keyToChild, key, value []byte) error {
 	t.mtx.Lock()
 	defer t.mtx.Unlock()
 
+	t.recordChild(keyToChild, key)
+	t.recordChildWrite(keyToChild)
+
 	if currentTx := t.getCurrentTransaction(); currentTx != nil {
 		keyToChildStr := string(keyToChild)
+		if currentTx.deletes[keyToChildStr] {
+			// The child trie deleted in the transaction is created again,
+			// without the keys it has in the state trie.
+			child, err := t.state.GetChild(keyToChild)
+			if err != nil && !errors.Is(err, trie.ErrChildTrieDoesNotExist) {
+				return err
+			}
+			if child != nil {
+				for childKey := range child.PrefixedKeys(nil) {
+					currentTx.deleteFromChild(keyToChildStr, string(childKey))
+				}
+			}
+		}
+
 		keyString := string(key)
 		currentTx.upsertChild(keyToChildStr, keyString, value)
 		return nil
@@ -291,11 +485,47 @@ func (t *TrieState) GetChildRoot(keyToChild []byte) (common.Hash, error) {
 	t.mtx.RLock()
 	defer t.mtx.RUnlock()
 
-	child, err := t.state.GetChild(keyToChild)
+	t.recordChild(keyToChild)
+
+	currentTx := t.getCurrentTransaction()
+	if currentTx != nil && currentTx.deletes[string(keyToChild)] {
+		return common.EmptyHash, fmt.Errorf("%w at key 0x%x%x",
+			trie.ErrChildTrieDoesNotExist, inmemory.ChildStorageKeyPrefix, keyToChild)
+	}
+
+	var childChanges *storageDiff
+	if currentTx != nil {
+		childChanges = currentTx.childChangeSet[string(keyToChild)]
+	}
+	if childChanges == nil {
+		child, err := t.state.GetChild(keyToChild)
+		if err != nil {
+			return common.EmptyHash, err
+		}
+		return child.Hash()
+	}
+
+	// the root of the child trie includes the changes of the running transactions
+	state, ok := t.state.(*inmemory.InMemoryTrie)
+	if !ok {
+		return common.EmptyHash, fmt.Errorf("cannot compute child root of %T with pending changes", t.state)
+	}
+	child, err := state.ChildSnapshot(keyToChild)
 	if err != nil {
 		return common.EmptyHash, err
 	}
-
+	for key, value := range childChanges.upserts {
+		err = child.Put([]byte(key), value)
+		if err != nil {
+			return common.EmptyHash, fmt.Errorf("putting pending change in child trie: %w", err)
+		}
+	}
+	for key := range childChanges.deletes {
+		err = child.Delete([]byte(key))
+		if err != nil {
+			return common.EmptyHash, fmt.Errorf("deleting pending change from child trie: %w", err)
+		}
+	}
 	return child.Hash()
 }
 
@@ -304,6 +534,8 @@ func (t *TrieState) GetChildStorage(keyToChild, key []byte) ([]byte, error) {
 	t.mtx.RLock()
 	defer t.mtx.RUnlock()
 
+	t.recordChild(keyToChild, key)
+
 	if currentTx := t.getCurrentTransaction(); currentTx != nil {
 		val, deleted := currentTx.getFromChild(string(keyToChild), string(key))
 		if val != nil || deleted {
@@ -320,6 +552,9 @@ func (t *TrieState) DeleteChild(keyToChild []byte) error {
 	t.mtx.Lock()
 	defer t.mtx.Unlock()
 
+	t.recordChild(keyToChild)
+	t.recordChildWrite(keyToChild)
+
 	if currentTx := t.getCurrentTransaction(); currentTx != nil {
 		currentTx.delete(string(keyToChild))
 		return nil
@@ -334,6 +569,9 @@ func (t *TrieState) DeleteChildLimit(key []byte, limit *[]byte) (
 	t.mtx.Lock()
 	defer t.mtx.Unlock()
 
+	t.recordChildPrefix(key, nil)
+	t.recordChildWrite(key)
+
 	if currentTx := t.getCurrentTransaction(); currentTx != nil {
 		deleteLimit := -1
 		if limit != nil {
@@ -382,7 +620,8 @@ func (t *TrieState) DeleteChildLimit(key []byte, limit *[]byte) (
 		// a bad intermediary state. Take also care of the caching of deleted Merkle
 		// values within the tries, which is used for online pruning.
 		// See https://github.com/ChainSafe/gossamer/issues/3032
-		err = child.Delete([]byte(k))
+		// Clearing through the main trie updates the child trie root it holds.
+		err = t.state.ClearFromChild(key, []byte(k))
 		if err != nil {
 			return deleted, allDeleted, fmt.Errorf("deleting from child trie located at key 0x%x: %w", key, err)
 		}
@@ -401,6 +640,9 @@ func (t *TrieState) ClearChildStorage(keyToChild, key []byte) error {
 	t.mtx.Lock()
 	defer t.mtx.Unlock()
 
+	t.recordChild(keyToChild, key)
+	t.recordChildWrite(keyToChild)
+
 	if currentTx := t.getCurrentTransaction(); currentTx != nil {
 		keyToChildStr := string(keyToChild)
 		keyStr := string(key)
@@ -421,6 +663,9 @@ func (t *TrieState) ClearPrefixInChild(keyToChild, prefix []byte) error {
 	t.mtx.Lock()
 	defer t.mtx.Unlock()
 
+	t.recordChildPrefix(keyToChild, prefix)
+	t.recordChildWrite(keyToChild)
+
 	if currentTx := t.getCurrentTransaction(); currentTx != nil {
 		child, err := t.state.GetChild(keyToChild)
 		if err != nil {
@@ -445,17 +690,39 @@ func (t *TrieState) ClearPrefixInChild(keyToChild, prefix []byte) error {
 		return err
 	}
 
-	err = child.ClearPrefix(prefix)
-	if err != nil {
-		return fmt.Errorf("clearing prefix in child trie located at key 0x%x: %w", keyToChild, err)
+	_, _, err = t.clearPrefixInChild(keyToChild, child, prefix, math.MaxUint32)
+	return err
+}
+
+// clearPrefixInChild deletes up to limit keys starting with the prefix given from the child
+// trie, through the main trie so the child trie root it holds is updated.
+func (t *TrieState) clearPrefixInChild(keyToChild []byte, child trie.Trie, prefix []byte, limit uint32) (
+	deleted uint32, allDeleted bool, err error) {
+	var keys [][]byte
+	for key := range child.PrefixedKeys(prefix) {
+		keys = append(keys, key)
 	}
-	return nil
+
+	for _, key := range keys {
+		if deleted == limit {
+			return deleted, false, nil
+		}
+		err = t.state.ClearFromChild(keyToChild, key)
+		if err != nil {
+			return deleted, false, fmt.Errorf("clearing prefix in child trie located at key 0x%x: %w", keyToChild, err)
+		}
+		deleted++
+	}
+	return deleted, true, nil
 }
 
 func (t *TrieState) ClearPrefixInChildWithLimit(keyToChild, prefix []byte, limit uint32) (uint32, bool, error) {
 	t.mtx.Lock()
 	defer t.mtx.Unlock()
 
+	t.recordChildPrefix(keyToChild, prefix)
+	t.recordChildWrite(keyToChild)
+
 	if currentTx := t.getCurrentTransaction(); currentTx != nil {
 		child, err := t.state.GetChild(keyToChild)
 		if err != nil {
@@ -480,7 +747,151 @@ func (t *TrieState) ClearPrefixInChildWithLimit(keyToChild, prefix []byte, limit
 		return 0, false, err
 	}
 
-	return child.ClearPrefixLimit(prefix, limit)
+	return t.clearPrefixInChild(keyToChild, child, prefix, limit)
+}
+
+// RemovalResults are the results of a removal of keys bounded by a limit.
+type RemovalResults struct {
+	// Cursor is the key to continue the removal from, nil if all the keys were removed.
+	Cursor []byte
+	// Backend is the number of keys removed from the state trie.
+	Backend uint32
+	// Unique is the number of keys removed, from the state trie and the running transaction.
+	Unique uint32
+	// Loops is the number of keys of the state trie iterated over.
+	Loops uint32
+}
+
+// ClearPrefixFrom deletes the keys starting with the prefix given, iterating over up to limit
+// keys of the state trie in lexicographical order from the cursor given. The keys written in
+// the running transaction are all deleted and do not count toward the limit.
+func (t *TrieState) ClearPrefixFrom(prefix []byte, limit *uint32, cursor []byte) (
+	results RemovalResults, err error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	currentTx := t.getCurrentTransaction()
+	if currentTx != nil {
+		for _, key := range slices.Clone(currentTx.sortedKeys) {
+			if strings.HasPrefix(key, string(prefix)) {
+				t.recordWrite([]byte(key))
+				currentTx.delete(key)
+				results.Unique++
+			}
+		}
+	}
+
+	for _, key := range prefixedKeysFrom(t.state, prefix, cursor, limit) {
+		if limit != nil && results.Loops == *limit {
+			results.Cursor = key
+			return results, nil
+		}
+		results.Loops++
+
+		t.record(key)
+		if currentTx != nil {
+			if currentTx.deletes[string(key)] {
+				continue
+			}
+			t.recordWrite(key)
+			currentTx.delete(string(key))
+		} else {
+			t.recordWrite(key)
+			err = t.state.Delete(key)
+			if err != nil {
+				return results, fmt.Errorf("deleting key 0x%x: %w", key, err)
+			}
+		}
+		results.Backend++
+		results.Unique++
+	}
+
+	return results, nil
+}
+
+// ClearPrefixInChildFrom deletes the keys starting with the prefix given from the child trie,
+// iterating over up to limit keys of the child trie in the state trie in lexicographical order
+// from the cursor given. The keys written in the running transaction are all deleted and do not
+// count toward the limit.
+func (t *TrieState) ClearPrefixInChildFrom(keyToChild, prefix []byte, limit *uint32, cursor []byte) (
+	results RemovalResults, err error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	t.recordChild(keyToChild)
+	t.recordChildWrite(keyToChild)
+
+	var stateKeys [][]byte
+	currentTx := t.getCurrentTransaction()
+	if currentTx == nil || !currentTx.deletes[string(keyToChild)] {
+		child, err := t.state.GetChild(keyToChild)
+		if err != nil && !errors.Is(err, trie.ErrChildTrieDoesNotExist) {
+			return results, err
+		}
+		if child != nil {
+			stateKeys = prefixedKeysFrom(child, prefix, cursor, limit)
+		}
+		t.recordChild(keyToChild, append([][]byte{prefix, cursor}, stateKeys...)...)
+	}
+
+	var childChanges *storageDiff
+	if currentTx != nil {
+		childChanges = currentTx.childChangeSet[string(keyToChild)]
+		if childChanges != nil {
+			for _, key := range slices.Clone(childChanges.sortedKeys) {
+				if strings.HasPrefix(key, string(prefix)) {
+					childChanges.delete(key)
+					results.Unique++
+				}
+			}
+		}
+	}
+
+	for _, key := range stateKeys {
+		if limit != nil && results.Loops == *limit {
+			results.Cursor = key
+			return results, nil
+		}
+		results.Loops++
+
+		if currentTx != nil {
+			if childChanges != nil && childChanges.deletes[string(key)] {
+				continue
+			}
+			currentTx.deleteFromChild(string(keyToChild), string(key))
+		} else {
+			// Clearing through the main trie updates the child trie root it holds.
+			err = t.state.ClearFromChild(keyToChild, key)
+			if err != nil {
+				return results, fmt.Errorf("deleting from child trie located at key 0x%x: %w", keyToChild, err)
+			}
+		}
+		results.Backend++
+		results.Unique++
+	}
+
+	return results, nil
+}
+
+// prefixedKeysFrom returns the keys of the trie given starting with the prefix given, in
+// lexicographical order from the cursor given included. Up to limit keys are returned, plus the
+// key following them, if any.
+func prefixedKeysFrom(t trie.TrieRead, prefix, cursor []byte, limit *uint32) (keys [][]byte) {
+	keysSeq := t.PrefixedKeys(prefix)
+	if bytes.Compare(cursor, prefix) > 0 {
+		if bytes.HasPrefix(cursor, prefix) && t.Get(cursor) != nil {
+			keys = append(keys, cursor)
+		}
+		keysSeq = t.PrefixedKeysFrom(prefix, cursor)
+	}
+
+	for key := range keysSeq {
+		if limit != nil && uint64(len(keys)) > uint64(*limit) {
+			break
+		}
+		keys = append(keys, key)
+	}
+	return keys
 }
 
 // GetChildNextKey returns the next lexicographical larger key from child storage. If it does not exist, it returns nil.
@@ -488,6 +899,8 @@ func (t *TrieState) GetChildNextKey(keyToChild, key []byte) ([]byte, error) {
 	t.mtx.RLock()
 	defer t.mtx.RUnlock()
 
+	t.recordChildNextKey(keyToChild, key)
+
 	if currentTx := t.getCurrentTransaction(); currentTx != nil {
 		// If we are going to delete this child we return error
 		if currentTx.deletes[string(keyToChild)] {
@@ -549,6 +962,8 @@ func (t *TrieState) GetKeysWithPrefixFromChild(keyToChild, prefix []byte) ([][]b
 	t.mtx.RLock()
 	defer t.mtx.RUnlock()
 
+	t.recordChildPrefix(keyToChild, prefix)
+
 	if currentTx := t.getCurrentTransaction(); currentTx != nil {
 		// If we are going to delete this child we return error
 		if currentTx.deletes[string(keyToChild)] {
@@ -556,28 +971,34 @@ func (t *TrieState) GetKeysWithPrefixFromChild(keyToChild, prefix []byte) ([][]b
 		}
 
 		if childChanges := currentTx.childChangeSet[string(keyToChild)]; childChanges != nil {
-			allEntries := make(map[string][]byte)
-
-			maps.Copy(allEntries, childChanges.upserts)
+			keys := make(map[string]struct{})
 			child, err := t.state.GetChild(keyToChild)
 			if err != nil {
 				// Child trie does not exists and won't exists in the future
-				if len(allEntries) == 0 {
+				if len(childChanges.upserts) == 0 {
 					return nil, err
 				}
 			} else {
-				allEntries = child.Entries()
+				for key := range child.PrefixedKeys(prefix) {
+					keys[string(key)] = struct{}{}
+				}
 			}
-			keys := maps.Keys(allEntries)
-
-			values := make([][]byte, 0)
 
-			for _, k := range keys {
-				if bytes.HasPrefix([]byte(k), prefix) {
-					values = append(values, []byte(k))
+			for key := range childChanges.upserts {
+				if strings.HasPrefix(key, string(prefix)) {
+					keys[key] = struct{}{}
 				}
 			}
+			for key := range childChanges.deletes {
+				delete(keys, key)
+			}
 
+			sortedKeys := maps.Keys(keys)
+			sort.Strings(sortedKeys)
+			values := make([][]byte, len(sortedKeys))
+			for i, key := range sortedKeys {
+				values[i] = []byte(key)
+			}
 			return values, nil
 		}
 	}
@@ -682,6 +682,54 @@ func TestTrieState_NestedTransactions(t *testing.T) {
 				require.Equal(t, 0, ts.transactions.Len())
 			},
 		},
+		"rollback_child_trie_deletion_on_nested_transaction": {
+			createTrieState: func() *TrieState {
+				state := inmemory_trie.NewEmptyTrie()
+				state.PutIntoChild([]byte("child"), []byte("key-1"), []byte("value-1"))
+				ts := NewTrieState(state)
+				{
+					ts.StartTransaction()
+					ts.SetChildStorage([]byte("child"), []byte("key-2"), []byte("value-2"))
+					{
+						ts.StartTransaction()
+						ts.DeleteChild([]byte("child"))
+						ts.RollbackTransaction()
+					}
+					ts.CommitTransaction()
+				}
+				return ts
+			},
+			assert: func(t *testing.T, ts *TrieState) {
+				keys, err := ts.GetKeysWithPrefixFromChild([]byte("child"), nil)
+				require.NoError(t, err)
+				require.Equal(t, [][]byte{[]byte("key-1"), []byte("key-2")}, keys)
+				require.Equal(t, 0, ts.transactions.Len())
+			},
+		},
+		"child_trie_created_again_on_nested_transaction": {
+			createTrieState: func() *TrieState {
+				state := inmemory_trie.NewEmptyTrie()
+				state.PutIntoChild([]byte("child"), []byte("key-1"), []byte("value-1"))
+				ts := NewTrieState(state)
+				{
+					ts.StartTransaction()
+					ts.DeleteChild([]byte("child"))
+					{
+						ts.StartTransaction()
+						ts.SetChildStorage([]byte("child"), []byte("key-2"), []byte("value-2"))
+						ts.CommitTransaction()
+					}
+					ts.CommitTransaction()
+				}
+				return ts
+			},
+			assert: func(t *testing.T, ts *TrieState) {
+				expected := inmemory_trie.NewEmptyTrie()
+				require.NoError(t, expected.PutIntoChild([]byte("child"), []byte("key-2"), []byte("value-2")))
+				require.Equal(t, expected.MustHash(), ts.Trie().MustHash())
+				require.Equal(t, 0, ts.transactions.Len())
+			},
+		},
 		"rollback_without_transaction_should_panic": {
 			createTrieState: func() *TrieState {
 				return NewTrieState(inmemory_trie.NewEmptyTrie())
@@ -745,3 +793,314 @@ func BenchmarkNextKey(b *testing.B) {
 		}
 	}
 }
+
+func TestTrieState_RecordedKeys(t *testing.T) {
+	ts := NewTrieState(inmemory_trie.NewEmptyTrie())
+	require.Nil(t, ts.RecordedKeys())
+
+	ts.Put([]byte("key1"), []byte("value1"))
+	ts.Put([]byte("key3"), []byte("value3"))
+
+	ts.EnableProofRecording()
+	require.Empty(t, ts.RecordedKeys())
+
+	ts.StartTransaction()
+	ts.Put([]byte("key4"), []byte("value4"))
+	// reading a key written in the transaction does not access the state trie
+	require.Equal(t, []byte("value4"), ts.Get([]byte("key4")))
+	require.Nil(t, ts.Get([]byte("key2")))
+	require.Equal(t, []byte("key3"), ts.NextKey([]byte("key1")))
+	ts.CommitTransaction()
+
+	err := ts.SetChildStorage([]byte("child"), []byte("key"), []byte("value"))
+	require.NoError(t, err)
+	value, err := ts.GetChildStorage([]byte("child"), []byte("absent"))
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	expectedKeys := [][]byte{
+		[]byte(":child_storage:default:child"),
+		[]byte("key1"),
+		[]byte("key2"),
+		[]byte("key3"),
+		[]byte("key4"),
+	}
+	require.Equal(t, expectedKeys, ts.RecordedKeys())
+
+	expectedChildKeys := map[string][][]byte{
+		"child": {[]byte("absent"), []byte("key")},
+	}
+	require.Equal(t, expectedChildKeys, ts.RecordedChildKeys())
+}
+
+func TestTrieState_WrittenKeys(t *testing.T) {
+	state := inmemory_trie.NewEmptyTrie()
+	require.NoError(t, state.Put([]byte("prefix1"), []byte("value")))
+	require.NoError(t, state.Put([]byte("prefix2"), []byte("value")))
+	ts := NewTrieState(state)
+
+	ts.StartTransaction()
+	require.NoError(t, ts.Put([]byte("key1"), []byte("value1")))
+	require.Nil(t, ts.Get([]byte("key2")))
+	require.NoError(t, ts.ClearPrefix([]byte("prefix")))
+	ts.StartTransaction()
+	require.NoError(t, ts.Delete([]byte("key3")))
+	ts.RollbackTransaction()
+	ts.CommitTransaction()
+
+	err := ts.SetChildStorage([]byte("child"), []byte("key"), []byte("value"))
+	require.NoError(t, err)
+
+	expectedKeys := [][]byte{
+		[]byte(":child_storage:default:child"),
+		[]byte("key1"),
+		[]byte("key3"),
+		[]byte("prefix1"),
+		[]byte("prefix2"),
+	}
+	require.Equal(t, expectedKeys, ts.WrittenKeys())
+}
+
+type mapFlatReader map[string][]byte
+
+func (m mapFlatReader) Get(key []byte) (value []byte, ok bool) {
+	return m[string(key)], true
+}
+
+func TestTrieState_FlatReader(t *testing.T) {
+	state := inmemory_trie.NewEmptyTrie()
+	require.NoError(t, state.Put([]byte("key1"), []byte("value1")))
+	require.NoError(t, state.Put([]byte("key2"), []byte("value2")))
+	ts := NewTrieState(state)
+	// the flat store values differ from the trie values to tell where they are read from
+	ts.SetFlatReader(mapFlatReader{"key1": []byte("flat1"), "key2": []byte("flat2")})
+
+	ts.StartTransaction()
+	require.NoError(t, ts.Put([]byte("key1"), []byte("written1")))
+	require.Equal(t, []byte("written1"), ts.Get([]byte("key1")))
+	require.Equal(t, []byte("flat2"), ts.Get([]byte("key2")))
+	ts.CommitTransaction()
+
+	// the flat store no longer holds the state once the transaction is applied to the trie
+	require.Equal(t, []byte("written1"), ts.Get([]byte("key1")))
+	require.Equal(t, []byte("value2"), ts.Get([]byte("key2")))
+
+	ts = NewTrieState(state)
+	ts.SetFlatReader(mapFlatReader{"key2": []byte("flat2")})
+	require.NoError(t, ts.Put([]byte("key3"), []byte("value3")))
+	require.Equal(t, []byte("value2"), ts.Get([]byte("key2")))
+}
+
+// TestTrieState_ChildTrieLifecycle follows the lifecycle of the child trie of a contract of
+// the contracts pallet: instantiated in a block, its storage iterated in the following block,
+// and lazily deleted a few keys per block once terminated.
+func TestTrieState_ChildTrieLifecycle(t *testing.T) {
+	state := inmemory_trie.NewEmptyTrie()
+	require.NoError(t, state.Put([]byte("key"), []byte("value")))
+	emptyRoot := state.MustHash()
+
+	trieID := []byte("contract-trie-id")
+	entries := map[string][]byte{
+		"balance": []byte("100"),
+		"counter": []byte("1"),
+		"owner":   []byte("alice"),
+	}
+
+	// instantiation
+	ts := NewTrieState(state)
+	ts.StartTransaction()
+	for key, value := range entries {
+		require.NoError(t, ts.SetChildStorage(trieID, []byte(key), value))
+	}
+	pendingRoot, err := ts.GetChildRoot(trieID)
+	require.NoError(t, err)
+	root, err := ts.Root()
+	require.NoError(t, err)
+	require.NotEqual(t, emptyRoot, root)
+
+	childRoot, err := ts.GetChildRoot(trieID)
+	require.NoError(t, err)
+	require.Equal(t, childRoot, pendingRoot)
+	require.Equal(t, childRoot.ToBytes(), ts.Get(append(inmemory_trie.ChildStorageKeyPrefix, trieID...)))
+
+	// iteration in the following block, with pending changes
+	ts = NewTrieState(ts.Trie().(*inmemory_trie.InMemoryTrie).Snapshot())
+	ts.StartTransaction()
+	require.NoError(t, ts.SetChildStorage(trieID, []byte("code"), []byte("wasm")))
+	require.NoError(t, ts.ClearChildStorage(trieID, []byte("counter")))
+	keys, err := ts.GetKeysWithPrefixFromChild(trieID, []byte("c"))
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte("code")}, keys)
+	nextKey, err := ts.GetChildNextKey(trieID, []byte("balance"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("code"), nextKey)
+	ts.RollbackTransaction()
+
+	// lazy deletion once terminated
+	ts.StartTransaction()
+	deleted, allDeleted, err := ts.DeleteChildLimit(trieID, uint32Bytes(2))
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), deleted)
+	require.False(t, allDeleted)
+	pendingRoot, err = ts.GetChildRoot(trieID)
+	require.NoError(t, err)
+	require.NotEqual(t, childRoot, pendingRoot)
+	root, err = ts.Root()
+	require.NoError(t, err)
+	require.NotEqual(t, emptyRoot, root)
+
+	ts.StartTransaction()
+	deleted, allDeleted, err = ts.DeleteChildLimit(trieID, uint32Bytes(2))
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), deleted)
+	require.True(t, allDeleted)
+	root, err = ts.Root()
+	require.NoError(t, err)
+	require.Equal(t, emptyRoot, root)
+
+	_, err = ts.GetChildRoot(trieID)
+	require.ErrorIs(t, err, trie.ErrChildTrieDoesNotExist)
+}
+
+// TestTrieState_ChildTrieRootWithoutTransaction checks the changes of the child tries made
+// without storage transaction are reflected in the root of the main trie.
+func TestTrieState_ChildTrieRootWithoutTransaction(t *testing.T) {
+	keyToChild := []byte("child")
+	expected := inmemory_trie.NewEmptyTrie()
+	require.NoError(t, expected.PutIntoChild(keyToChild, []byte("other"), []byte("value")))
+
+	ts := NewTrieState(inmemory_trie.NewEmptyTrie())
+	for _, key := range []string{"prefix1", "prefix2", "prefix3", "other"} {
+		require.NoError(t, ts.SetChildStorage(keyToChild, []byte(key), []byte("value")))
+	}
+
+	deleted, allDeleted, err := ts.ClearPrefixInChildWithLimit(keyToChild, []byte("prefix"), 2)
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), deleted)
+	require.False(t, allDeleted)
+	require.NoError(t, ts.ClearPrefixInChild(keyToChild, []byte("prefix")))
+	require.Equal(t, expected.MustHash(), ts.Trie().MustHash())
+
+	deleted, allDeleted, err = ts.DeleteChildLimit(keyToChild, uint32Bytes(1))
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), deleted)
+	require.True(t, allDeleted)
+	require.Equal(t, trie.EmptyHash, ts.Trie().MustHash())
+}
+
+func uint32Bytes(value uint32) *[]byte {
+	encoded := make([]byte, 4)
+	binary.LittleEndian.PutUint32(encoded, value)
+	return &encoded
+}
+
+func TestTrieState_ClearPrefixFrom(t *testing.T) {
+	keys := []string{"prefix1", "prefix2", "prefix3", "other"}
+	limit := uint32(2)
+
+	t.Run("without_transaction", func(t *testing.T) {
+		ts := NewTrieState(inmemory_trie.NewEmptyTrie())
+		for _, key := range keys {
+			require.NoError(t, ts.Put([]byte(key), []byte("value")))
+		}
+
+		results, err := ts.ClearPrefixFrom([]byte("prefix"), &limit, nil)
+		require.NoError(t, err)
+		require.Equal(t, RemovalResults{Cursor: []byte("prefix3"), Backend: 2, Unique: 2, Loops: 2}, results)
+		require.Nil(t, ts.Get([]byte("prefix2")))
+		require.NotNil(t, ts.Get([]byte("prefix3")))
+
+		results, err = ts.ClearPrefixFrom([]byte("prefix"), &limit, results.Cursor)
+		require.NoError(t, err)
+		require.Equal(t, RemovalResults{Backend: 1, Unique: 1, Loops: 1}, results)
+		require.Nil(t, ts.Get([]byte("prefix3")))
+		require.NotNil(t, ts.Get([]byte("other")))
+	})
+
+	t.Run("with_transaction", func(t *testing.T) {
+		state := inmemory_trie.NewEmptyTrie()
+		for _, key := range keys {
+			require.NoError(t, state.Put([]byte(key), []byte("value")))
+		}
+		ts := NewTrieState(state)
+		ts.StartTransaction()
+		require.NoError(t, ts.Put([]byte("prefix0"), []byte("value")))
+		require.NoError(t, ts.Delete([]byte("prefix1")))
+
+		// the keys written in the transaction do not count toward the limit
+		results, err := ts.ClearPrefixFrom([]byte("prefix"), &limit, nil)
+		require.NoError(t, err)
+		require.Equal(t, RemovalResults{Cursor: []byte("prefix3"), Backend: 1, Unique: 2, Loops: 2}, results)
+		require.Nil(t, ts.Get([]byte("prefix0")))
+		require.Nil(t, ts.Get([]byte("prefix2")))
+		require.NotNil(t, ts.Get([]byte("prefix3")))
+
+		results, err = ts.ClearPrefixFrom([]byte("prefix"), nil, results.Cursor)
+		require.NoError(t, err)
+		require.Equal(t, RemovalResults{Backend: 1, Unique: 1, Loops: 1}, results)
+
+		ts.CommitTransaction()
+		require.Equal(t, map[string][]byte{"other": []byte("value")}, ts.TrieEntries())
+	})
+}
+
+func TestTrieState_ClearPrefixInChildFrom(t *testing.T) {
+	keyToChild := []byte("child")
+	keys := []string{"prefix1", "prefix2", "prefix3", "other"}
+	limit := uint32(2)
+
+	t.Run("without_transaction", func(t *testing.T) {
+		ts := NewTrieState(inmemory_trie.NewEmptyTrie())
+		for _, key := range keys {
+			require.NoError(t, ts.SetChildStorage(keyToChild, []byte(key), []byte("value")))
+		}
+
+		results, err := ts.ClearPrefixInChildFrom(keyToChild, []byte("prefix"), &limit, nil)
+		require.NoError(t, err)
+		require.Equal(t, RemovalResults{Cursor: []byte("prefix3"), Backend: 2, Unique: 2, Loops: 2}, results)
+
+		results, err = ts.ClearPrefixInChildFrom(keyToChild, nil, &limit, results.Cursor)
+		require.NoError(t, err)
+		require.Equal(t, RemovalResults{Backend: 1, Unique: 1, Loops: 1}, results)
+
+		expected := inmemory_trie.NewEmptyTrie()
+		require.NoError(t, expected.PutIntoChild(keyToChild, []byte("other"), []byte("value")))
+		require.Equal(t, expected.MustHash(), ts.Trie().MustHash())
+	})
+
+	t.Run("with_transaction", func(t *testing.T) {
+		state := inmemory_trie.NewEmptyTrie()
+		for _, key := range keys {
+			require.NoError(t, state.PutIntoChild(keyToChild, []byte(key), []byte("value")))
+		}
+		ts := NewTrieState(state)
+		ts.StartTransaction()
+		require.NoError(t, ts.SetChildStorage(keyToChild, []byte("prefix0"), []byte("value")))
+
+		results, err := ts.ClearPrefixInChildFrom(keyToChild, []byte("prefix"), &limit, nil)
+		require.NoError(t, err)
+		require.Equal(t, RemovalResults{Cursor: []byte("prefix3"), Backend: 2, Unique: 3, Loops: 2}, results)
+
+		value, err := ts.GetChildStorage(keyToChild, []byte("prefix3"))
+		require.NoError(t, err)
+		require.NotNil(t, value)
+		value, err = ts.GetChildStorage(keyToChild, []byte("prefix0"))
+		require.NoError(t, err)
+		require.Nil(t, value)
+
+		results, err = ts.ClearPrefixInChildFrom(keyToChild, []byte("prefix"), &limit, results.Cursor)
+		require.NoError(t, err)
+		require.Equal(t, RemovalResults{Backend: 1, Unique: 1, Loops: 1}, results)
+
+		keys, err := ts.GetKeysWithPrefixFromChild(keyToChild, nil)
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{[]byte("other")}, keys)
+	})
+
+	t.Run("child_trie_does_not_exist", func(t *testing.T) {
+		ts := NewTrieState(inmemory_trie.NewEmptyTrie())
+		results, err := ts.ClearPrefixInChildFrom(keyToChild, nil, &limit, nil)
+		require.NoError(t, err)
+		require.Equal(t, RemovalResults{}, results)
+	})
+}
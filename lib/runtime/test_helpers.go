@@ -22,10 +22,8 @@
 	"github.com/ChainSafe/gossamer/lib/crypto"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/ChainSafe/gossamer/pkg/extrinsic"
 	"github.com/ChainSafe/gossamer/pkg/scale"
-	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
-	ctypes "github.com/centrifuge/go-substrate-rpc-client/v4/types"
-	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/stretchr/testify/require"
 )
@@ -82,6 +80,10 @@ func GetRuntime(ctx context.Context, runtime string) (
 	case WESTEND_RUNTIME_v0912:
 		runtimeFilename = WESTEND_RUNTIME_V0912_FP
 		url = WESTEND_RUNTIME_V0912_URL
+	// only used for TestInstance_ContractsChildTrie
+	case CONTRACTS_ROCOCO_RUNTIME_v180:
+		runtimeFilename = CONTRACTS_ROCOCO_RUNTIME_V180_FP
+		url = CONTRACTS_ROCOCO_RUNTIME_V180_URL
 	default:
 		return "", fmt.Errorf("%w: %s", ErrRuntimeUnknown, runtime)
 	}
@@ -198,9 +200,10 @@ type MetadataVersioner interface {
 	Versioner
 }
 
-// NewTestExtrinsic builds a new extrinsic using centrifuge pkg
+// NewTestExtrinsic builds a new extrinsic of the call given signed by the signer given, with
+// the immortal era and no tip, and returns it hex encoded
 func NewTestExtrinsic(t *testing.T, rt MetadataVersioner, genHash, blockHash common.Hash,
-	nonce uint64, keyRingPair signature.KeyringPair, call string, args ...interface{}) string {
+	nonce uint64, signer extrinsic.Signer, call string, args ...interface{}) string {
 	t.Helper()
 
 	rawMeta, err := rt.Metadata()
@@ -210,35 +213,29 @@ func NewTestExtrinsic(t *testing.T, rt MetadataVersioner, genHash, blockHash com
 	err = scale.Unmarshal(rawMeta, &decoded)
 	require.NoError(t, err)
 
-	meta := &ctypes.Metadata{}
-	err = codec.Decode(decoded, meta)
+	registry, err := scale.NewRegistry(decoded)
 	require.NoError(t, err)
 
-	rv, err := rt.Version()
+	builder, err := extrinsic.NewBuilder(registry)
 	require.NoError(t, err)
 
-	c, err := ctypes.NewCall(meta, call, args...)
+	rv, err := rt.Version()
 	require.NoError(t, err)
 
-	ext := ctypes.NewExtrinsic(c)
-	o := ctypes.SignatureOptions{
-		BlockHash:          ctypes.Hash(blockHash),
-		Era:                ctypes.ExtrinsicEra{IsImmortalEra: false},
-		GenesisHash:        ctypes.Hash(genHash),
-		Nonce:              ctypes.NewUCompactFromUInt(nonce),
-		SpecVersion:        ctypes.U32(rv.SpecVersion),
-		Tip:                ctypes.NewUCompactFromUInt(0),
-		TransactionVersion: ctypes.U32(rv.TransactionVersion),
-	}
-
-	// Sign the transaction using Alice's key
-	err = ext.Sign(keyRingPair, o)
+	c, err := builder.NewCall(call, args...)
 	require.NoError(t, err)
 
-	extEnc, err := codec.EncodeToHex(ext)
+	options := extrinsic.Options{
+		Nonce:              nonce,
+		SpecVersion:        rv.SpecVersion,
+		TransactionVersion: rv.TransactionVersion,
+		GenesisHash:        genHash,
+		BlockHash:          blockHash,
+	}
+	ext, err := builder.Signed(c, signer, options)
 	require.NoError(t, err)
 
-	return extEnc
+	return common.BytesToHex(ext)
 }
 
 // Versioner returns the version from the runtime.
@@ -63,3 +63,22 @@ type Context struct {
 	OffchainHTTPSet *offchain.HTTPSet
 	Version         *Version
 }
+
+// InstanceConfig is the configuration of a runtime instance, shared by the
+// instances created from it for the upgraded runtime code.
+type InstanceConfig struct {
+	// StubMissingHostFunctions links the host functions imported by the runtime and not
+	// implemented to stubs failing the runtime calls using them, instead of failing to
+	// instantiate the runtime.
+	StubMissingHostFunctions bool
+	// MaxHeapPages is the maximum number of pages the memory of the runtime grows to
+	// when allocating, defaults to allocator.MaxWasmPages if zero.
+	MaxHeapPages uint32
+	// CompilationCacheDir is the directory persisting the compiled runtimes,
+	// the runtimes are only cached in memory if empty.
+	CompilationCacheDir string
+	// Metering instruments the runtime code to count the fuel consumed by each call,
+	// one unit per wasm instruction executed, reported through the metering hook.
+	// The runtime is not metered if its code uses instructions the metering does not support.
+	Metering bool
+}
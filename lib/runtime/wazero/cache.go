@@ -0,0 +1,64 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package wazero_runtime
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tetratelabs/wazero"
+)
+
+// compilationCacheDirPrefix prefixes the name of the directories created by gossamer to cache
+// the compiled runtimes, the other entries of the cache directory being left untouched.
+const compilationCacheDirPrefix = "gossamer-wazero-"
+
+// PrepareCompilationCacheDir returns the directory caching the compiled runtimes for the given
+// gossamer version, created in the directory given. The caches of the other gossamer versions
+// are removed, since a runtime compiled by another version may not run the same way.
+func PrepareCompilationCacheDir(dir, version string) (versionDir string, err error) {
+	err = os.MkdirAll(dir, 0o700)
+	if err != nil {
+		return "", fmt.Errorf("creating cache directory: %w", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("reading cache directory: %w", err)
+	}
+
+	versionDirName := compilationCacheDirPrefix + version
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == versionDirName ||
+			!strings.HasPrefix(entry.Name(), compilationCacheDirPrefix) {
+			continue
+		}
+
+		logger.Debugf("removing compiled runtimes cache %s", entry.Name())
+		err = os.RemoveAll(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return "", fmt.Errorf("removing stale cache: %w", err)
+		}
+	}
+
+	versionDir = filepath.Join(dir, versionDirName)
+	err = os.MkdirAll(versionDir, 0o700)
+	if err != nil {
+		return "", fmt.Errorf("creating version cache directory: %w", err)
+	}
+
+	return versionDir, nil
+}
+
+// newCompilationCache returns the cache of the compiled runtimes, persisted in the directory
+// given and keyed by the hash of the runtime code, or kept in memory if the directory is empty.
+func newCompilationCache(dir string) (wazero.CompilationCache, error) {
+	if dir == "" {
+		return wazero.NewCompilationCache(), nil
+	}
+
+	return wazero.NewCompilationCacheWithDir(dir)
+}
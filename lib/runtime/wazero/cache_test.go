@@ -0,0 +1,47 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package wazero_runtime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepareCompilationCacheDir(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	staleDir := filepath.Join(dir, "gossamer-wazero-0.8.0")
+	err := os.MkdirAll(staleDir, 0o700)
+	require.NoError(t, err)
+
+	// the entries not created by gossamer are left untouched
+	unrelatedDir := filepath.Join(dir, "0.8.0")
+	err = os.MkdirAll(unrelatedDir, 0o700)
+	require.NoError(t, err)
+	unrelatedFile := filepath.Join(dir, "gossamer-wazero-notes")
+	err = os.WriteFile(unrelatedFile, []byte{1}, 0o600)
+	require.NoError(t, err)
+
+	versionDir, err := PrepareCompilationCacheDir(dir, "0.9.0")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "gossamer-wazero-0.9.0"), versionDir)
+	assert.DirExists(t, versionDir)
+	assert.NoDirExists(t, staleDir)
+	assert.DirExists(t, unrelatedDir)
+	assert.FileExists(t, unrelatedFile)
+
+	// the cache of the current version is kept
+	cachedFile := filepath.Join(versionDir, "compiled")
+	err = os.WriteFile(cachedFile, []byte{1}, 0o600)
+	require.NoError(t, err)
+
+	_, err = PrepareCompilationCacheDir(dir, "0.9.0")
+	require.NoError(t, err)
+	assert.FileExists(t, cachedFile)
+}
@@ -0,0 +1,137 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package wazero_runtime
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/genesis"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+)
+
+// ErrGenesisConfigRejected is returned when the runtime fails to build the genesis storage
+// from the JSON genesis config given.
+var ErrGenesisConfigRejected = errors.New("genesis config rejected by the runtime")
+
+// GenesisToRaw converts a non-raw genesis to a raw genesis, building the genesis storage with
+// the GenesisBuilder runtime API of the runtime code of the genesis if it is a runtime genesis.
+func GenesisToRaw(gen *genesis.Genesis) error {
+	if gen.IsRaw() {
+		return nil
+	}
+
+	runtimeGenesis := gen.Genesis.RuntimeGenesis
+	if runtimeGenesis == nil {
+		return gen.ToRaw()
+	}
+
+	code, err := runtimeGenesis.DecodeCode()
+	if err != nil {
+		return err
+	}
+
+	top, err := BuildGenesisStorage(code, runtimeGenesis)
+	if err != nil {
+		return fmt.Errorf("building genesis storage: %w", err)
+	}
+
+	gen.Genesis.Raw = map[string]map[string]string{"top": top}
+	return nil
+}
+
+// BuildGenesisStorage instantiates the runtime code given in an empty state, and builds the
+// genesis storage of the runtime genesis given by calling GenesisBuilder_build_config with its
+// JSON genesis config, which is built from the output of GenesisBuilder_create_default_config
+// if the runtime genesis only has a patch. It returns the hex encoded genesis storage entries,
+// the runtime code included.
+func BuildGenesisStorage(code []byte, runtimeGenesis *genesis.RuntimeGenesis) (map[string]string, error) {
+	state := storage.NewTrieState(inmemory_trie.NewEmptyTrie())
+	instance, err := NewInstance(code, Config{
+		Storage:  state,
+		Keystore: keystore.NewGlobalKeystore(),
+		LogLvl:   log.Error,
+		Role:     common.NoNetworkRole,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("creating runtime instance: %w", err)
+	}
+	defer instance.Stop()
+
+	config, err := runtimeGenesis.BuildConfig(instance.genesisBuilderDefaultConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	err = instance.genesisBuilderBuildConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	err = state.Put(common.CodeKey, code)
+	if err != nil {
+		return nil, fmt.Errorf("setting runtime code: %w", err)
+	}
+
+	entries := state.TrieEntries()
+	top := make(map[string]string, len(entries))
+	for key, value := range entries {
+		top[common.BytesToHex([]byte(key))] = common.BytesToHex(value)
+	}
+	return top, nil
+}
+
+// genesisBuilderDefaultConfig calls runtime function GenesisBuilder_create_default_config
+// and returns the default JSON genesis config of the runtime.
+func (in *Instance) genesisBuilderDefaultConfig() ([]byte, error) {
+	ret, err := in.Exec(runtime.GenesisBuilderCreateDefaultConfig, []byte{})
+	if err != nil {
+		return nil, err
+	}
+
+	var config []byte
+	err = scale.Unmarshal(ret, &config)
+	if err != nil {
+		return nil, fmt.Errorf("decoding default genesis config: %w", err)
+	}
+	return config, nil
+}
+
+// genesisBuilderBuildConfig calls runtime function GenesisBuilder_build_config with the
+// JSON genesis config given, the genesis storage being written to the instance storage.
+func (in *Instance) genesisBuilderBuildConfig(config []byte) error {
+	encodedConfig, err := scale.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("encoding genesis config: %w", err)
+	}
+
+	ret, err := in.Exec(runtime.GenesisBuilderBuildConfig, encodedConfig)
+	if err != nil {
+		return err
+	}
+
+	// the runtime returns a Result<(), RuntimeString>
+	if len(ret) == 0 {
+		return errors.New("empty response")
+	}
+	switch ret[0] {
+	case 0:
+		return nil
+	case 1:
+		var message string
+		err = scale.Unmarshal(ret[1:], &message)
+		if err != nil {
+			return fmt.Errorf("decoding build config error: %w", err)
+		}
+		return fmt.Errorf("%w: %s", ErrGenesisConfigRejected, message)
+	default:
+		return fmt.Errorf("invalid result byte: %d", ret[0])
+	}
+}
@@ -8,12 +8,14 @@
 	"context"
 	"crypto/rand"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
 	"reflect"
 	"time"
 
+	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
@@ -21,6 +23,7 @@
 	"github.com/ChainSafe/gossamer/lib/crypto/secp256k1"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/runtime/storage"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 	"github.com/ChainSafe/gossamer/pkg/trie"
 	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
@@ -668,8 +671,6 @@ func ext_crypto_sr25519_verify_version_1(ctx context.Context, m api.Module, sig
 		panic("nil runtime context")
 	}
 
-	sigVerifier := rtCtx.SigVerifier
-
 	message := read(m, msg)
 	signature, ok := m.Memory().Read(sig, 64)
 	if !ok {
@@ -690,17 +691,8 @@ func ext_crypto_sr25519_verify_version_1(ctx context.Context, m api.Module, sig
 		"pub=%s message=0x%x signature=0x%x",
 		pub.Hex(), message, signature)
 
-	if sigVerifier.IsStarted() {
-		signature := crypto.SignatureInfo{
-			PubKey:     pub.Encode(),
-			Sign:       signature,
-			Msg:        message,
-			VerifyFunc: sr25519.VerifySignature,
-		}
-		sigVerifier.Add(&signature)
-		return 1
-	}
-
+	// the deprecated signatures are verified inline even if a batch verification is
+	// started, their verification failure being tolerated and not failing the batch
 	ok, err = pub.VerifyDeprecated(message, signature)
 	if err != nil || !ok {
 		message := validateSignatureFail
@@ -777,14 +769,51 @@ func ext_crypto_sr25519_verify_version_2(ctx context.Context, m api.Module, sig
 	return 1
 }
 
-func ext_crypto_start_batch_verify_version_1(_ context.Context, _ api.Module) {
-	// TODO: fix and re-enable signature verification (#1405)
-	// beginBatchVerify(context)
+// the verify host functions already queue the signature verifications in the batch
+// if one is started, so the batch verify host functions share their implementation
+func ext_crypto_sr25519_batch_verify_version_1(ctx context.Context, m api.Module,
+	sig uint32, msg uint64, key uint32) uint32 {
+	return ext_crypto_sr25519_verify_version_2(ctx, m, sig, msg, key)
+}
+
+func ext_crypto_ed25519_batch_verify_version_1(ctx context.Context, m api.Module,
+	sig uint32, msg uint64, key uint32) uint32 {
+	return ext_crypto_ed25519_verify_version_1(ctx, m, sig, msg, key)
+}
+
+func ext_crypto_ecdsa_batch_verify_version_1(ctx context.Context, m api.Module,
+	sig uint32, msg uint64, key uint32) uint32 {
+	return ext_crypto_ecdsa_verify_version_2(ctx, m, sig, msg, key)
+}
+
+func ext_crypto_start_batch_verify_version_1(ctx context.Context, _ api.Module) {
+	rtCtx := ctx.Value(runtimeContextKey).(*runtime.Context)
+	if rtCtx == nil {
+		panic("nil runtime context")
+	}
+
+	if rtCtx.SigVerifier.IsStarted() {
+		panic("batch verification already started")
+	}
+
+	rtCtx.SigVerifier.Start()
 }
 
-func ext_crypto_finish_batch_verify_version_1(_ context.Context, _ api.Module) uint32 {
-	// TODO: fix and re-enable signature verification (#1405)
-	// return finishBatchVerify(context)
+func ext_crypto_finish_batch_verify_version_1(ctx context.Context, _ api.Module) uint32 {
+	rtCtx := ctx.Value(runtimeContextKey).(*runtime.Context)
+	if rtCtx == nil {
+		panic("nil runtime context")
+	}
+
+	if !rtCtx.SigVerifier.IsStarted() {
+		panic("batch verification not started")
+	}
+
+	if !rtCtx.SigVerifier.Finish() {
+		logger.Debug("batch signature verification failed")
+		return 0
+	}
+
 	return 1
 }
 
@@ -1035,18 +1064,30 @@ func ext_default_child_storage_read_version_1(
 		return mustWrite(m, rtCtx.Allocator, noneEncoded)
 	}
 
-	valueBuf, _ := splitPointerSize(valueOut)
-	ok := m.Memory().Write(valueBuf, value[offset:])
+	if value == nil {
+		return mustWrite(m, rtCtx.Allocator, noneEncoded)
+	}
+
+	var data []byte
+	if offset <= uint32(len(value)) { //nolint:gosec
+		data = value[offset:]
+	}
+
+	// only the part of the value fitting in the buffer is written
+	valueOutPtr, valueOutSize := splitPointerSize(valueOut)
+	written := uint64(len(data))
+	if written > valueOutSize {
+		written = valueOutSize
+	}
+
+	ok := m.Memory().Write(valueOutPtr, data[:written])
 	if !ok {
 		panic("write overflow")
 	}
 
-	size := uint32(len(value[offset:])) //nolint:gosec
-	sizeBuf := make([]byte, 4)
-	binary.LittleEndian.PutUint32(sizeBuf, size)
-
-	// this is expected to be Option(Vec<u8>)
-	return mustWrite(m, rtCtx.Allocator, scale.MustMarshal(&sizeBuf))
+	// the size of the value from the offset, as Option<u32>
+	size := uint32(len(data)) //nolint:gosec
+	return mustWrite(m, rtCtx.Allocator, scale.MustMarshal(&size))
 }
 
 func ext_default_child_storage_set_version_1(
@@ -1171,6 +1212,74 @@ func ext_default_child_storage_clear_prefix_version_2(ctx context.Context, m api
 	return resultSpan
 }
 
+// multiRemovalResults is the result of a removal of keys bounded by a limit,
+// returned by the host functions taking a cursor to continue the removal from.
+type multiRemovalResults struct {
+	// Cursor is the cursor to continue the removal from, None if all the keys were removed.
+	Cursor *[]byte
+	// Backend is the number of keys removed from the backend.
+	Backend uint32
+	// Unique is the number of unique keys removed, in the backend and the overlay.
+	Unique uint32
+	// Loops is the number of iterations done over the backend keys.
+	Loops uint32
+}
+
+func newMultiRemovalResults(results storage.RemovalResults) multiRemovalResults {
+	encoded := multiRemovalResults{
+		Backend: results.Backend,
+		Unique:  results.Unique,
+		Loops:   results.Loops,
+	}
+	if results.Cursor != nil {
+		encoded.Cursor = &results.Cursor
+	}
+	return encoded
+}
+
+// readLimitAndCursor reads the optional limit and cursor of a removal of keys.
+func readLimitAndCursor(m api.Module, limitSpan, cursorSpan uint64) (limit *uint32, cursor []byte) {
+	err := scale.Unmarshal(read(m, limitSpan), &limit)
+	if err != nil {
+		logger.Warnf("failed scale decoding limit: %s", err)
+		panic(err)
+	}
+
+	var cursorPtr *[]byte
+	err = scale.Unmarshal(read(m, cursorSpan), &cursorPtr)
+	if err != nil {
+		logger.Warnf("failed scale decoding cursor: %s", err)
+		panic(err)
+	}
+	if cursorPtr != nil {
+		cursor = *cursorPtr
+	}
+
+	return limit, cursor
+}
+
+func ext_default_child_storage_clear_prefix_version_3(ctx context.Context, m api.Module,
+	childStorageKey, prefixSpan, limitSpan, cursorSpan uint64) uint64 {
+	rtCtx := ctx.Value(runtimeContextKey).(*runtime.Context)
+	if rtCtx == nil {
+		panic("nil runtime context")
+	}
+	storage := rtCtx.Storage
+
+	keyToChild := read(m, childStorageKey)
+	prefix := read(m, prefixSpan)
+	limit, cursor := readLimitAndCursor(m, limitSpan, cursorSpan)
+
+	results, err := storage.ClearPrefixInChildFrom(keyToChild, prefix, limit, cursor)
+	if err != nil {
+		logger.Errorf("failed to clear prefix in child with limit: %s", err)
+		panic(err)
+	}
+
+	encodedResults := newMultiRemovalResults(results)
+	return mustWrite(m, rtCtx.Allocator, scale.MustMarshal(encodedResults))
+}
+
 func ext_default_child_storage_exists_version_1(ctx context.Context, m api.Module, childStorageKey, key uint64) uint32 {
 	rtCtx := ctx.Value(runtimeContextKey).(*runtime.Context)
 	if rtCtx == nil {
@@ -1236,6 +1345,16 @@ func ext_default_child_storage_next_key_version_1(
 	return mustWrite(m, rtCtx.Allocator, scale.MustMarshal(&childNextKey))
 }
 
+// getChildRoot returns the root of the child trie at the key given, the root of an empty
+// trie if the child trie does not exist.
+func getChildRoot(storage runtime.Storage, keyToChild []byte) (common.Hash, error) {
+	childRoot, err := storage.GetChildRoot(keyToChild)
+	if errors.Is(err, trie.ErrChildTrieDoesNotExist) {
+		return trie.EmptyHash, nil
+	}
+	return childRoot, err
+}
+
 func ext_default_child_storage_root_version_1(
 	ctx context.Context, m api.Module, childStorageKey uint64) (ptrSize uint64) {
 	rtCtx := ctx.Value(runtimeContextKey).(*runtime.Context)
@@ -1243,7 +1362,7 @@ func ext_default_child_storage_root_version_1(
 		panic("nil runtime context")
 	}
 	storage := rtCtx.Storage
-	childRoot, err := storage.GetChildRoot(read(m, childStorageKey))
+	childRoot, err := getChildRoot(storage, read(m, childStorageKey))
 	if err != nil {
 		logger.Errorf("failed to encode child root: %s", err)
 		return 0
@@ -1268,7 +1387,7 @@ func ext_default_child_storage_root_version_2(ctx context.Context, m api.Module,
 	storage := rtCtx.Storage
 	key := read(m, childStorageKey)
 
-	childRoot, err := storage.GetChildRoot(key)
+	childRoot, err := getChildRoot(storage, key)
 	if err != nil {
 		logger.Errorf("failed to encode child root: %s", err)
 		return mustWrite(m, rtCtx.Allocator, emptyByteVectorEncoded)
@@ -1445,6 +1564,36 @@ func ext_default_child_storage_storage_kill_version_3(
 	return ret
 }
 
+func ext_default_child_storage_storage_kill_version_4(
+	ctx context.Context, m api.Module, childStorageKeySpan, limitSpan, cursorSpan uint64) uint64 {
+	rtCtx := ctx.Value(runtimeContextKey).(*runtime.Context)
+	if rtCtx == nil {
+		panic("nil runtime context")
+	}
+	storage := rtCtx.Storage
+
+	childStorageKey := read(m, childStorageKeySpan)
+	limit, cursor := readLimitAndCursor(m, limitSpan, cursorSpan)
+
+	results, err := storage.ClearPrefixInChildFrom(childStorageKey, nil, limit, cursor)
+	if err != nil {
+		logger.Errorf("failed to kill child storage: %s", err)
+		panic(err)
+	}
+
+	if results.Cursor == nil {
+		// every key is removed, the child trie itself is removed
+		err = storage.DeleteChild(childStorageKey)
+		if err != nil {
+			logger.Errorf("failed to delete child trie: %s", err)
+			panic(err)
+		}
+	}
+
+	encodedResults := newMultiRemovalResults(results)
+	return mustWrite(m, rtCtx.Allocator, scale.MustMarshal(encodedResults))
+}
+
 func ext_hashing_blake2_128_version_1(ctx context.Context, m api.Module, dataSpan uint64) uint32 {
 	rtCtx := ctx.Value(runtimeContextKey).(*runtime.Context)
 	if rtCtx == nil {
@@ -1832,15 +1981,39 @@ func ext_offchain_random_seed_version_1(ctx context.Context, m api.Module) uint3
 	return ptr
 }
 
-// TODO Investigate via https://github.com/ChainSafe/gossamer/issues/3986
 func ext_offchain_submit_transaction_version_1(ctx context.Context, m api.Module, data uint64) uint64 {
 	rtCtx := ctx.Value(runtimeContextKey).(*runtime.Context)
 	if rtCtx == nil {
 		panic("nil runtime context")
 	}
 
-	// OK case
-	ret, err := write(m, rtCtx.Allocator, []byte{0})
+	resultMode := scale.OK
+
+	// the transactions are collected for the caller of the offchain worker,
+	// which validates them and adds them to the transaction pool
+	submitted, ok := ctx.Value(offchainTransactionsKey).(*[]types.Extrinsic)
+	if ok {
+		extrinsic := read(m, data)
+		cp := make(types.Extrinsic, len(extrinsic))
+		copy(cp, extrinsic)
+		*submitted = append(*submitted, cp)
+	} else {
+		logger.Warn("cannot submit transaction outside of an offchain worker call")
+		resultMode = scale.Err
+	}
+
+	result := scale.NewResult(nil, nil)
+	err := result.Set(resultMode, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	enc, err := scale.Marshal(result)
+	if err != nil {
+		panic(err)
+	}
+
+	ret, err := write(m, rtCtx.Allocator, enc)
 	if err != nil {
 		panic(err)
 	}
@@ -2155,6 +2328,34 @@ func ext_storage_clear_prefix_version_2(ctx context.Context, m api.Module, prefi
 	return valueSpan
 }
 
+func ext_storage_clear_prefix_version_3(ctx context.Context, m api.Module,
+	prefixSpan, limitSpan, cursorSpan uint64) uint64 {
+	rtCtx := ctx.Value(runtimeContextKey).(*runtime.Context)
+	if rtCtx == nil {
+		panic("nil runtime context")
+	}
+	storage := rtCtx.Storage
+
+	prefix := read(m, prefixSpan)
+	logger.Debugf("prefix: 0x%x", prefix)
+
+	limit, cursor := readLimitAndCursor(m, limitSpan, cursorSpan)
+
+	if bytes.HasPrefix(prefix, childStorageKeyPrefix) {
+		logger.Warnf("cannot clear child prefix: 0x%x", prefix)
+		return mustWrite(m, rtCtx.Allocator, scale.MustMarshal(multiRemovalResults{}))
+	}
+
+	results, err := storage.ClearPrefixFrom(prefix, limit, cursor)
+	if err != nil {
+		logger.Errorf("failed to clear prefix limit: %s", err)
+		panic(err)
+	}
+
+	encodedResults := newMultiRemovalResults(results)
+	return mustWrite(m, rtCtx.Allocator, scale.MustMarshal(encodedResults))
+}
+
 func ext_storage_exists_version_1(ctx context.Context, m api.Module, keySpan uint64) uint32 {
 	rtCtx := ctx.Value(runtimeContextKey).(*runtime.Context)
 	if rtCtx == nil {
@@ -2334,6 +2535,24 @@ func ext_storage_start_transaction_version_1(ctx context.Context, _ api.Module)
 		panic("nil runtime context")
 	}
 	rtCtx.Storage.StartTransaction()
+
+	if transactions, ok := ctx.Value(storageTransactionsKey).(*uint); ok {
+		*transactions++
+	}
+}
+
+// closeStorageTransaction accounts for the closing of a storage transaction open by the runtime,
+// panicking if the runtime has no transaction open: the transactions open by the node for the
+// call cannot be closed by the runtime.
+func closeStorageTransaction(ctx context.Context) {
+	transactions, ok := ctx.Value(storageTransactionsKey).(*uint)
+	if !ok {
+		return
+	}
+	if *transactions == 0 {
+		panic("no storage transaction open by the runtime")
+	}
+	*transactions--
 }
 
 func ext_storage_rollback_transaction_version_1(ctx context.Context, _ api.Module) {
@@ -2341,6 +2560,7 @@ func ext_storage_rollback_transaction_version_1(ctx context.Context, _ api.Modul
 	if rtCtx == nil {
 		panic("nil runtime context")
 	}
+	closeStorageTransaction(ctx)
 	rtCtx.Storage.RollbackTransaction()
 }
 
@@ -2349,6 +2569,7 @@ func ext_storage_commit_transaction_version_1(ctx context.Context, _ api.Module)
 	if rtCtx == nil {
 		panic("nil runtime context")
 	}
+	closeStorageTransaction(ctx)
 	rtCtx.Storage.CommitTransaction()
 }
 
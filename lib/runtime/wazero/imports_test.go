@@ -14,6 +14,7 @@
 	"testing"
 	"time"
 
+	gosstypes "github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/common/types"
@@ -31,6 +32,7 @@
 	"github.com/ChainSafe/gossamer/pkg/trie/inmemory/proof"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tetratelabs/wazero/api"
 )
 
 var DefaultVersion = &runtime.Version{
@@ -559,6 +561,226 @@ func Test_ext_crypto_sr25519_verify_version_2(t *testing.T) {
 	require.NotNil(t, read)
 }
 
+// batchSignature is a signature verified by one of the batch verify host functions
+type batchSignature struct {
+	verify    func(ctx context.Context, m api.Module, sig uint32, msg uint64, key uint32) uint32
+	signature []byte
+	message   []byte
+	publicKey []byte
+}
+
+func newSr25519BatchSignature(t *testing.T, message []byte) batchSignature {
+	t.Helper()
+
+	kp, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+	signature, err := kp.Private().Sign(message)
+	require.NoError(t, err)
+
+	return batchSignature{
+		verify:    ext_crypto_sr25519_batch_verify_version_1,
+		signature: signature,
+		message:   message,
+		publicKey: kp.Public().Encode(),
+	}
+}
+
+func newEd25519BatchSignature(t *testing.T, message []byte) batchSignature {
+	t.Helper()
+
+	kp, err := ed25519.GenerateKeypair()
+	require.NoError(t, err)
+	signature, err := kp.Private().Sign(message)
+	require.NoError(t, err)
+
+	return batchSignature{
+		verify:    ext_crypto_ed25519_batch_verify_version_1,
+		signature: signature,
+		message:   message,
+		publicKey: kp.Public().Encode(),
+	}
+}
+
+func newEcdsaBatchSignature(t *testing.T, message []byte) batchSignature {
+	t.Helper()
+
+	kp, err := secp256k1.GenerateKeypair()
+	require.NoError(t, err)
+	messageHash, err := common.Blake2bHash(message)
+	require.NoError(t, err)
+	signature, err := kp.Private().Sign(messageHash[:])
+	require.NoError(t, err)
+
+	return batchSignature{
+		verify:    ext_crypto_ecdsa_batch_verify_version_1,
+		signature: signature,
+		message:   message,
+		publicKey: kp.Public().Encode(),
+	}
+}
+
+// newBatchVerifyContext returns the context of the host functions calls and sets the
+// allocator of the instance, which is otherwise only set by its Exec method.
+func newBatchVerifyContext(t *testing.T, inst *Instance) context.Context {
+	t.Helper()
+
+	heapBase := api.DecodeU32(inst.Module.ExportedGlobal("__heap_base").Get())
+	inst.Context.Allocator = allocator.NewFreeingBumpHeapAllocator(heapBase, allocator.MaxWasmPages)
+	return context.WithValue(context.Background(), runtimeContextKey, inst.Context)
+}
+
+func Test_ext_crypto_batch_verify_version_1(t *testing.T) {
+	message := []byte("Hello world!")
+	badSr25519Signature := newSr25519BatchSignature(t, message)
+	badSr25519Signature.message = []byte("Goodbye world!")
+	badEd25519Signature := newEd25519BatchSignature(t, message)
+	badEd25519Signature.message = []byte("Goodbye world!")
+	badEcdsaSignature := newEcdsaBatchSignature(t, message)
+	badEcdsaSignature.message = []byte("Goodbye world!")
+
+	testCases := map[string]struct {
+		signatures []batchSignature
+		valid      bool
+	}{
+		"empty_batch": {
+			valid: true,
+		},
+		"sr25519": {
+			signatures: []batchSignature{
+				newSr25519BatchSignature(t, message),
+				newSr25519BatchSignature(t, message),
+			},
+			valid: true,
+		},
+		"ed25519": {
+			signatures: []batchSignature{
+				newEd25519BatchSignature(t, message),
+				newEd25519BatchSignature(t, message),
+			},
+			valid: true,
+		},
+		"ecdsa": {
+			signatures: []batchSignature{
+				newEcdsaBatchSignature(t, message),
+				newEcdsaBatchSignature(t, message),
+			},
+			valid: true,
+		},
+		"mixed": {
+			signatures: []batchSignature{
+				newSr25519BatchSignature(t, message),
+				newEd25519BatchSignature(t, message),
+				newEcdsaBatchSignature(t, message),
+			},
+			valid: true,
+		},
+		"bad_sr25519_version_1_signature": {
+			// the deprecated signatures verification failure is tolerated
+			signatures: []batchSignature{
+				newSr25519BatchSignature(t, message),
+				{
+					verify:    ext_crypto_sr25519_verify_version_1,
+					signature: badSr25519Signature.signature,
+					message:   badSr25519Signature.message,
+					publicKey: badSr25519Signature.publicKey,
+				},
+			},
+			valid: true,
+		},
+		"bad_sr25519_zero_public_key_signature": {
+			// the zero public key signatures are verified as deprecated signatures
+			signatures: []batchSignature{
+				newSr25519BatchSignature(t, message),
+				{
+					verify:    ext_crypto_sr25519_batch_verify_version_1,
+					signature: badSr25519Signature.signature,
+					message:   message,
+					publicKey: make([]byte, 32),
+				},
+			},
+			valid: true,
+		},
+		"bad_sr25519_signature": {
+			signatures: []batchSignature{
+				newSr25519BatchSignature(t, message),
+				badSr25519Signature,
+				newEd25519BatchSignature(t, message),
+			},
+		},
+		"bad_ed25519_signature": {
+			signatures: []batchSignature{
+				newEd25519BatchSignature(t, message),
+				badEd25519Signature,
+				newEcdsaBatchSignature(t, message),
+			},
+		},
+		"bad_ecdsa_signature": {
+			signatures: []batchSignature{
+				newEcdsaBatchSignature(t, message),
+				badEcdsaSignature,
+				newSr25519BatchSignature(t, message),
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			inst := NewTestInstance(t, runtime.HOST_API_TEST_RUNTIME, TestWithVersion(DefaultVersion))
+			ctx := newBatchVerifyContext(t, inst)
+
+			ext_crypto_start_batch_verify_version_1(ctx, inst.Module)
+			require.True(t, inst.Context.SigVerifier.IsStarted())
+
+			for _, signature := range testCase.signatures {
+				sigSpan, err := write(inst.Module, inst.Context.Allocator, signature.signature)
+				require.NoError(t, err)
+				msgSpan, err := write(inst.Module, inst.Context.Allocator, signature.message)
+				require.NoError(t, err)
+				keySpan, err := write(inst.Module, inst.Context.Allocator, signature.publicKey)
+				require.NoError(t, err)
+				sigPtr, _ := splitPointerSize(sigSpan)
+				keyPtr, _ := splitPointerSize(keySpan)
+
+				// the verification is queued in the batch, its result is only known once finished
+				ret := signature.verify(ctx, inst.Module, sigPtr, msgSpan, keyPtr)
+				require.Equal(t, uint32(1), ret)
+			}
+
+			ret := ext_crypto_finish_batch_verify_version_1(ctx, inst.Module)
+			var expected uint32
+			if testCase.valid {
+				expected = 1
+			}
+			require.Equal(t, expected, ret)
+			require.False(t, inst.Context.SigVerifier.IsStarted())
+		})
+	}
+}
+
+func Test_ext_crypto_start_batch_verify_version_1_alreadyStarted(t *testing.T) {
+	inst := NewTestInstance(t, runtime.HOST_API_TEST_RUNTIME, TestWithVersion(DefaultVersion))
+	ctx := newBatchVerifyContext(t, inst)
+
+	ext_crypto_start_batch_verify_version_1(ctx, inst.Module)
+	require.PanicsWithValue(t, "batch verification already started", func() {
+		ext_crypto_start_batch_verify_version_1(ctx, inst.Module)
+	})
+
+	ret := ext_crypto_finish_batch_verify_version_1(ctx, inst.Module)
+	require.Equal(t, uint32(1), ret)
+}
+
+func Test_ext_crypto_finish_batch_verify_version_1_notStarted(t *testing.T) {
+	inst := NewTestInstance(t, runtime.HOST_API_TEST_RUNTIME, TestWithVersion(DefaultVersion))
+	ctx := newBatchVerifyContext(t, inst)
+
+	require.PanicsWithValue(t, "batch verification not started", func() {
+		ext_crypto_finish_batch_verify_version_1(ctx, inst.Module)
+	})
+	require.False(t, inst.Context.SigVerifier.IsStarted())
+}
+
 func Test_ext_trie_blake2_256_root_version_1(t *testing.T) {
 	inst := NewTestInstance(t, runtime.HOST_API_TEST_RUNTIME, TestWithVersion(DefaultVersion))
 
@@ -875,7 +1097,7 @@ func Test_ext_misc_runtime_version_version_1(t *testing.T) {
 		}
 	}
 
-	allocator := allocator.NewFreeingBumpHeapAllocator(0)
+	allocator := allocator.NewFreeingBumpHeapAllocator(0, allocator.MaxWasmPages)
 	inst.Context.Allocator = allocator
 
 	data := bytes
@@ -1639,6 +1861,20 @@ func Test_ext_hashing_sha2_256_version_1(t *testing.T) {
 	require.Equal(t, expected[:], hash)
 }
 
+func Test_ext_offchain_submit_transaction_version_1(t *testing.T) {
+	inst := NewTestInstance(t, runtime.HOST_API_TEST_RUNTIME, TestWithVersion(DefaultVersion))
+
+	extrinsic := []byte{1, 2, 3}
+	enc, err := scale.Marshal(extrinsic)
+	require.NoError(t, err)
+
+	var submitted []gosstypes.Extrinsic
+	ctx := context.WithValue(context.Background(), offchainTransactionsKey, &submitted)
+	_, err = inst.ExecContext(ctx, "rtm_ext_offchain_submit_transaction_version_1", enc)
+	require.NoError(t, err)
+	require.Equal(t, []gosstypes.Extrinsic{extrinsic}, submitted)
+}
+
 func Test_ext_offchain_timestamp_version_1(t *testing.T) {
 	inst := NewTestInstance(t, runtime.HOST_API_TEST_RUNTIME, TestWithVersion(DefaultVersion))
 
@@ -2213,6 +2449,32 @@ func Test_ext_storage_root_version_2(t *testing.T) {
 	require.Equal(t, expected[:], hash)
 }
 
+func Test_ext_storage_transactions_version_1(t *testing.T) {
+	ts := storage.NewTrieState(inmemory_trie.NewEmptyTrie())
+	var transactions uint
+	ctx := context.WithValue(context.Background(), runtimeContextKey, &runtime.Context{Storage: ts})
+	ctx = context.WithValue(ctx, storageTransactionsKey, &transactions)
+
+	// the transaction open by the node cannot be closed by the runtime
+	ts.StartTransaction()
+	require.PanicsWithValue(t, "no storage transaction open by the runtime", func() {
+		ext_storage_commit_transaction_version_1(ctx, nil)
+	})
+
+	ext_storage_start_transaction_version_1(ctx, nil)
+	require.NoError(t, ts.Put([]byte("key"), []byte("value")))
+	ext_storage_start_transaction_version_1(ctx, nil)
+	require.NoError(t, ts.Put([]byte("other"), []byte("value")))
+	require.Equal(t, uint(2), transactions)
+
+	ext_storage_rollback_transaction_version_1(ctx, nil)
+	ext_storage_commit_transaction_version_1(ctx, nil)
+	require.Equal(t, uint(0), transactions)
+
+	require.Equal(t, []byte("value"), ts.Get([]byte("key")))
+	require.Nil(t, ts.Get([]byte("other")))
+}
+
 func Test_ext_storage_set_version_1(t *testing.T) {
 	inst := NewTestInstance(t, runtime.HOST_API_TEST_RUNTIME, TestWithVersion(DefaultVersion))
 
@@ -8,10 +8,12 @@
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/internal/tracing"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
@@ -25,6 +27,9 @@
 	"github.com/klauspost/compress/zstd"
 	"github.com/tetratelabs/wazero"
 	"github.com/tetratelabs/wazero/api"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Name represents the name of the interpreter
@@ -33,10 +38,45 @@
 	MemoryMinPages uint32 = 2070
 )
 
+// hostModuleName is the name of the module of the host functions imported by the runtimes
+const hostModuleName = "env"
+
+const tracerName = "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+
+// ErrMissingHostFunction is returned when the runtime calls a host function not implemented
+var ErrMissingHostFunction = errors.New("host function not implemented")
+
+// OutOfMemoryError is returned when a runtime call exhausts the heap of the runtime.
+type OutOfMemoryError struct {
+	Function string
+	Stats    allocator.AllocationStats
+	Err      error
+}
+
+func (e *OutOfMemoryError) Error() string {
+	return fmt.Sprintf("runtime function %s out of memory, %d bytes allocated (peak of %d bytes, %d bytes "+
+		"of address space used): %s", e.Function, e.Stats.BytesAllocated, e.Stats.BytesAllocatedPeak,
+		e.Stats.AddressSpaceUsed, e.Err)
+}
+
+func (e *OutOfMemoryError) Unwrap() error {
+	return e.Err
+}
+
 type runtimeContextKeyType struct{}
 
 var runtimeContextKey = runtimeContextKeyType{}
 
+type offchainTransactionsKeyType struct{}
+
+// offchainTransactionsKey is the context key of the transactions submitted during an offchain worker call
+var offchainTransactionsKey = offchainTransactionsKeyType{}
+
+type storageTransactionsKeyType struct{}
+
+// storageTransactionsKey is the context key of the number of storage transactions open by the runtime during a call
+var storageTransactionsKey = storageTransactionsKeyType{}
+
 var _ runtime.Instance = (*Instance)(nil)
 
 type wazeroMeta struct {
@@ -53,6 +93,9 @@ type Instance struct {
 	wasmByteCode []byte
 	codeHash     common.Hash
 	metadata     wazeroMeta
+	config       runtime.InstanceConfig
+	// tracer traces the runtime calls
+	tracer trace.Tracer
 	sync.Mutex
 }
 
@@ -67,6 +110,7 @@ type Config struct {
 	Transaction    runtime.TransactionState
 	CodeHash       common.Hash
 	DefaultVersion *runtime.Version
+	runtime.InstanceConfig
 }
 
 func decompressWasm(code []byte) ([]byte, error) {
@@ -110,15 +154,12 @@ func NewInstanceFromTrie(t trie.Trie, cfg Config) (*Instance, error) {
 	return NewInstance(code, cfg)
 }
 
-func newRuntime(ctx context.Context,
-	code []byte,
-	config wazero.RuntimeConfig,
-) (api.Module, wazero.Runtime, wazero.CompiledModule, error) {
-	rt := wazero.NewRuntimeWithConfig(ctx, config)
-
+// newHostModuleBuilder returns the builder of the host module exporting
+// the host functions implemented by gossamer to the runtimes.
+func newHostModuleBuilder(rt wazero.Runtime) wazero.HostModuleBuilder {
 	const i32, i64 = api.ValueTypeI32, api.ValueTypeI64
 
-	hostCompiledModule, err := rt.NewHostModuleBuilder("env").
+	return rt.NewHostModuleBuilder(hostModuleName).
 		// values from newer kusama/polkadot runtimes
 		ExportMemory("memory", MemoryMinPages).
 		NewFunctionBuilder().
@@ -263,6 +304,24 @@ func newRuntime(ctx context.Context,
 		).
 		Export("ext_crypto_start_batch_verify_version_1").
 		NewFunctionBuilder().
+		WithGoModuleFunction(
+			tripleArgWithReturnFn(ext_crypto_sr25519_batch_verify_version_1),
+			[]api.ValueType{i32, i64, i32}, []api.ValueType{i32},
+		).
+		Export("ext_crypto_sr25519_batch_verify_version_1").
+		NewFunctionBuilder().
+		WithGoModuleFunction(
+			tripleArgWithReturnFn(ext_crypto_ed25519_batch_verify_version_1),
+			[]api.ValueType{i32, i64, i32}, []api.ValueType{i32},
+		).
+		Export("ext_crypto_ed25519_batch_verify_version_1").
+		NewFunctionBuilder().
+		WithGoModuleFunction(
+			tripleArgWithReturnFn(ext_crypto_ecdsa_batch_verify_version_1),
+			[]api.ValueType{i32, i64, i32}, []api.ValueType{i32},
+		).
+		Export("ext_crypto_ecdsa_batch_verify_version_1").
+		NewFunctionBuilder().
 		WithGoModuleFunction(
 			noArgWithReturn(ext_crypto_finish_batch_verify_version_1),
 			[]api.ValueType{}, []api.ValueType{i32},
@@ -359,6 +418,12 @@ func newRuntime(ctx context.Context,
 		).
 		Export("ext_default_child_storage_clear_prefix_version_2").
 		NewFunctionBuilder().
+		WithGoModuleFunction(
+			quadArgWithReturnFn(ext_default_child_storage_clear_prefix_version_3),
+			[]api.ValueType{i64, i64, i64, i64}, []api.ValueType{i64},
+		).
+		Export("ext_default_child_storage_clear_prefix_version_3").
+		NewFunctionBuilder().
 		WithGoModuleFunction(
 			doubleArgWithReturnFn(ext_default_child_storage_exists_version_1),
 			[]api.ValueType{i64, i64}, []api.ValueType{i32},
@@ -407,6 +472,12 @@ func newRuntime(ctx context.Context,
 		).
 		Export("ext_default_child_storage_storage_kill_version_3").
 		NewFunctionBuilder().
+		WithGoModuleFunction(
+			tripleArgWithReturnFn(ext_default_child_storage_storage_kill_version_4),
+			[]api.ValueType{i64, i64, i64}, []api.ValueType{i64},
+		).
+		Export("ext_default_child_storage_storage_kill_version_4").
+		NewFunctionBuilder().
 		WithGoModuleFunction(
 			singleArgFn(ext_allocator_free_version_1),
 			[]api.ValueType{i32}, []api.ValueType{},
@@ -575,6 +646,12 @@ func newRuntime(ctx context.Context,
 		).
 		Export("ext_storage_clear_prefix_version_2").
 		NewFunctionBuilder().
+		WithGoModuleFunction(
+			tripleArgWithReturnFn(ext_storage_clear_prefix_version_3),
+			[]api.ValueType{i64, i64, i64}, []api.ValueType{i64},
+		).
+		Export("ext_storage_clear_prefix_version_3").
+		NewFunctionBuilder().
 		WithGoModuleFunction(
 			singleArgWithReturnFn(ext_storage_exists_version_1),
 			[]api.ValueType{i64}, []api.ValueType{i32},
@@ -639,27 +716,72 @@ func newRuntime(ctx context.Context,
 			doubleArgWithReturnFn(ext_crypto_ecdsa_generate_version_1),
 			[]api.ValueType{i32, i64}, []api.ValueType{i32},
 		).
-		Export("ext_crypto_ecdsa_generate_version_1").
-		Compile(ctx)
+		Export("ext_crypto_ecdsa_generate_version_1")
+}
+
+func newRuntime(ctx context.Context,
+	code []byte,
+	config wazero.RuntimeConfig,
+	stubMissingHostFunctions bool,
+) (api.Module, wazero.Runtime, wazero.CompiledModule, error) {
+	rt := wazero.NewRuntimeWithConfig(ctx, config)
 
+	code, err := decompressWasm(code)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	_, err = rt.InstantiateModule(ctx, hostCompiledModule, wazero.NewModuleConfig())
+	guestCompiledModule, err := rt.CompileModule(ctx, code)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	code, err = decompressWasm(code)
+	hostModuleBuilder := newHostModuleBuilder(rt)
+	hostCompiledModule, err := hostModuleBuilder.Compile(ctx)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	guestCompiledModule, err := rt.CompileModule(ctx, code)
+	if stubMissingHostFunctions {
+		// the stubs are exported by a host module of the name of the module they are imported from
+		moduleBuilders := map[string]wazero.HostModuleBuilder{hostModuleName: hostModuleBuilder}
+		_, missing := splitHostFunctionImports(guestCompiledModule, hostCompiledModule)
+		for _, definition := range missing {
+			moduleName, name, _ := definition.Import()
+			logger.Warnf("runtime imports missing host function %s from module %s, linking a stub", name, moduleName)
+			moduleBuilder, ok := moduleBuilders[moduleName]
+			if !ok {
+				moduleBuilder = rt.NewHostModuleBuilder(moduleName)
+				moduleBuilders[moduleName] = moduleBuilder
+			}
+			moduleBuilder.
+				NewFunctionBuilder().
+				WithGoModuleFunction(missingHostFunctionStub(name),
+					definition.ParamTypes(), definition.ResultTypes()).
+				Export(name)
+		}
+
+		hostCompiledModule, err = hostModuleBuilder.Compile(ctx)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		for moduleName, moduleBuilder := range moduleBuilders {
+			if moduleName == hostModuleName {
+				continue
+			}
+			_, err = moduleBuilder.Instantiate(ctx)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("instantiating stubs module %s: %w", moduleName, err)
+			}
+		}
+	}
+
+	_, err = rt.InstantiateModule(ctx, hostCompiledModule, wazero.NewModuleConfig())
 	if err != nil {
 		return nil, nil, nil, err
 	}
+
 	mod, err := rt.Instantiate(ctx, code)
 	if err != nil {
 		return nil, nil, nil, err
@@ -668,6 +790,76 @@ func newRuntime(ctx context.Context,
 	return mod, rt, guestCompiledModule, nil
 }
 
+// splitHostFunctionImports returns the host functions imported by the guest module given, split
+// between the ones exported by the host module given and the missing ones.
+func splitHostFunctionImports(guestModule, hostModule wazero.CompiledModule) (
+	implemented, missing []api.FunctionDefinition) {
+	exported := hostModule.ExportedFunctions()
+	for _, definition := range guestModule.ImportedFunctions() {
+		moduleName, name, _ := definition.Import()
+		if _, ok := exported[name]; ok && moduleName == hostModuleName {
+			implemented = append(implemented, definition)
+			continue
+		}
+		missing = append(missing, definition)
+	}
+
+	return implemented, missing
+}
+
+// missingHostFunctionStub returns the stub of the missing host function with the name given,
+// which logs the call of the host function the first time, and fails the runtime call
+// with the ErrMissingHostFunction error.
+func missingHostFunctionStub(name string) api.GoModuleFunction {
+	var once sync.Once
+	return api.GoModuleFunc(func(_ context.Context, _ api.Module, _ []uint64) {
+		once.Do(func() {
+			logger.Errorf("runtime called missing host function %s", name)
+		})
+		panic(fmt.Errorf("%w: %s", ErrMissingHostFunction, name))
+	})
+}
+
+// HostFunctionImports returns the names of the host functions imported by the runtime code given,
+// split between the ones implemented by gossamer and the missing ones, sorted by name.
+func HostFunctionImports(code []byte) (implemented, missing []string, err error) {
+	ctx := context.Background()
+	rt := wazero.NewRuntime(ctx)
+	defer rt.Close(ctx) //nolint:errcheck
+
+	code, err = decompressWasm(code)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decompressing runtime code: %w", err)
+	}
+
+	guestCompiledModule, err := rt.CompileModule(ctx, code)
+	if err != nil {
+		return nil, nil, fmt.Errorf("compiling runtime code: %w", err)
+	}
+
+	hostCompiledModule, err := newHostModuleBuilder(rt).Compile(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("compiling host module: %w", err)
+	}
+
+	implementedDefinitions, missingDefinitions := splitHostFunctionImports(guestCompiledModule, hostCompiledModule)
+	for _, definition := range implementedDefinitions {
+		_, name, _ := definition.Import()
+		implemented = append(implemented, name)
+	}
+	for _, definition := range missingDefinitions {
+		moduleName, name, _ := definition.Import()
+		if moduleName != hostModuleName {
+			name = moduleName + "." + name
+		}
+		missing = append(missing, name)
+	}
+
+	slices.Sort(implemented)
+	slices.Sort(missing)
+	return implemented, missing, nil
+}
+
 // NewInstance instantiates a runtime from raw wasm bytecode
 func NewInstance(code []byte, cfg Config) (instance *Instance, err error) {
 	logger.Debug("instantiating a runtime!")
@@ -675,9 +867,24 @@ func NewInstance(code []byte, cfg Config) (instance *Instance, err error) {
 
 	// Prepare a cache directory.
 	ctx := context.Background()
-	cache := wazero.NewCompilationCache()
-	config := wazero.NewRuntimeConfig().WithCompilationCache(cache)
-	mod, rt, guestCompiledModule, err := newRuntime(ctx, code, config)
+	cache, err := newCompilationCache(cfg.CompilationCacheDir)
+	if err != nil {
+		return nil, fmt.Errorf("creating compilation cache: %w", err)
+	}
+
+	// the runtime calls are aborted once their context is done
+	config := wazero.NewRuntimeConfig().
+		WithCompilationCache(cache).
+		WithCloseOnContextDone(true)
+	runtimeCode := code
+	if cfg.Metering {
+		runtimeCode, err = meteredCode(code)
+		if err != nil {
+			logger.Warnf("runtime of code hash %s is not metered: %s", cfg.CodeHash, err)
+			runtimeCode = code
+		}
+	}
+	mod, rt, guestCompiledModule, err := newRuntime(ctx, runtimeCode, config, cfg.StubMissingHostFunctions)
 	if err != nil {
 		return nil, fmt.Errorf("creating runtime instance: %w", err)
 	}
@@ -696,6 +903,8 @@ func NewInstance(code []byte, cfg Config) (instance *Instance, err error) {
 		},
 		Module:   mod,
 		codeHash: cfg.CodeHash,
+		config:   cfg.InstanceConfig,
+		tracer:   otel.Tracer(tracerName),
 		metadata: wazeroMeta{
 			config:      config,
 			cache:       cache,
@@ -721,11 +930,37 @@ func NewInstance(code []byte, cfg Config) (instance *Instance, err error) {
 
 var ErrExportFunctionNotFound = errors.New("export function not found")
 
+// Exec calls the runtime function given with the SCALE encoded data given as argument.
 func (i *Instance) Exec(function string, data []byte) ([]byte, error) {
+	return i.ExecContext(context.Background(), function, data)
+}
+
+// ExecContext calls the runtime function given with the SCALE encoded data given as argument,
+// aborting the call once the context given is done. The guest module instance of an aborted
+// call is discarded so the runtime instance remains usable for the next calls.
+// The call is traced as a child span of the span of the context given, if any.
+func (i *Instance) ExecContext(ctx context.Context, function string, data []byte) ([]byte, error) {
+	ctx, span := i.tracer.Start(ctx, function, trace.WithAttributes(
+		attribute.Int("input_size", len(data)),
+	))
+	if span.IsRecording() {
+		span.SetAttributes(attribute.String("code_hash", i.codeHash.String()))
+	}
+
+	result, err := i.execContext(ctx, function, data)
+	span.SetAttributes(attribute.Int("output_size", len(result)))
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+func (i *Instance) execContext(ctx context.Context, function string, data []byte) ([]byte, error) {
 	i.Lock()
 	defer i.Unlock()
 
-	mod, err := i.Runtime.InstantiateModule(context.Background(), i.metadata.guestModule, wazero.NewModuleConfig())
+	mod, err := i.Runtime.InstantiateModule(ctx, i.metadata.guestModule, wazero.NewModuleConfig())
+	if err != nil && ctx.Err() != nil {
+		return nil, fmt.Errorf("instantiate guest module: %w", ctx.Err())
+	}
 	if mod == nil {
 		return nil, fmt.Errorf("instantiate guest module: nil")
 	}
@@ -740,13 +975,32 @@ func (i *Instance) Exec(function string, data []byte) ([]byte, error) {
 		}
 	}()
 
+	// the fuel is read before the guest module is closed
+	if fuel := mod.ExportedGlobal(fuelGlobalName); i.config.Metering && fuel != nil {
+		defer func() {
+			trace.SpanFromContext(ctx).SetAttributes(attribute.Int64("fuel", int64(fuel.Get()))) //nolint:gosec
+			MeteringReport{Function: function, CodeHash: i.codeHash, Fuel: fuel.Get()}.report()
+		}()
+	}
+
 	encodedHeapBase := mod.ExportedGlobal("__heap_base")
 	if encodedHeapBase == nil {
 		return nil, fmt.Errorf("wazero error: nil global for __heap_base")
 	}
 
 	heapBase := api.DecodeU32(encodedHeapBase.Get())
-	i.Context.Allocator = allocator.NewFreeingBumpHeapAllocator(heapBase)
+	maxHeapPages := i.config.MaxHeapPages
+	if maxHeapPages == 0 {
+		maxHeapPages = allocator.MaxWasmPages
+	}
+	heapAllocator := allocator.NewFreeingBumpHeapAllocator(heapBase, maxHeapPages)
+	i.Context.Allocator = heapAllocator
+	defer func() {
+		stats := heapAllocator.Stats()
+		stats.Collect(function)
+		logger.Tracef("runtime function %s allocated a peak of %d bytes, using %d bytes of address space",
+			function, stats.BytesAllocatedPeak, stats.AddressSpaceUsed)
+	}()
 
 	memory := mod.Memory()
 	if memory == nil {
@@ -756,7 +1010,8 @@ func (i *Instance) Exec(function string, data []byte) ([]byte, error) {
 	dataLength := uint32(len(data)) //nolint:gosec
 	inputPtr, err := i.Context.Allocator.Allocate(memory, dataLength)
 	if err != nil {
-		return nil, fmt.Errorf("allocating input memory: %w", err)
+		return nil, outOfMemoryError(function, heapAllocator,
+			fmt.Errorf("allocating input memory: %w", err))
 	}
 
 	ok := memory.Write(inputPtr, data)
@@ -769,10 +1024,34 @@ func (i *Instance) Exec(function string, data []byte) ([]byte, error) {
 		return nil, fmt.Errorf("%w: %s", ErrExportFunctionNotFound, function)
 	}
 
-	ctx := context.WithValue(context.Background(), runtimeContextKey, i.Context)
-	values, err := runtimeFunc.Call(ctx, api.EncodeU32(inputPtr), api.EncodeU32(dataLength))
+	// a batch verification left unfinished by a failed call is discarded
+	defer func() {
+		if i.Context.SigVerifier.IsStarted() {
+			i.Context.SigVerifier.Finish()
+		}
+	}()
+
+	// the storage transactions left open by the runtime are rolled back
+	var storageTransactions uint
+	defer func() {
+		if storageTransactions > 0 {
+			logger.Warnf("runtime function %s left %d storage transactions open, rolling them back",
+				function, storageTransactions)
+		}
+		for ; storageTransactions > 0; storageTransactions-- {
+			i.Context.Storage.RollbackTransaction()
+		}
+	}()
+
+	callCtx := context.WithValue(ctx, runtimeContextKey, i.Context)
+	callCtx = context.WithValue(callCtx, storageTransactionsKey, &storageTransactions)
+	values, err := runtimeFunc.Call(callCtx, api.EncodeU32(inputPtr), api.EncodeU32(dataLength))
 	if err != nil {
-		return nil, fmt.Errorf("running runtime function: %w", err)
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("running runtime function %s: %w", function, ctx.Err())
+		}
+		return nil, outOfMemoryError(function, heapAllocator,
+			fmt.Errorf("running runtime function: %w", err))
 	}
 	if len(values) == 0 {
 		return nil, fmt.Errorf("no returned values from runtime function: %s", function)
@@ -787,6 +1066,20 @@ func (i *Instance) Exec(function string, data []byte) ([]byte, error) {
 	return result, nil
 }
 
+// outOfMemoryError returns an OutOfMemoryError wrapping the error given if the runtime function
+// exhausted the heap of the allocator given, and the error unchanged otherwise.
+func outOfMemoryError(function string, heapAllocator *allocator.FreeingBumpHeapAllocator, err error) error {
+	if !errors.Is(err, allocator.ErrAllocatorOutOfSpace) {
+		return err
+	}
+
+	return &OutOfMemoryError{
+		Function: function,
+		Stats:    heapAllocator.Stats(),
+		Err:      err,
+	}
+}
+
 // Version returns the instance version.
 // This is cheap to call since the instance version is cached.
 // Note the instance version is set at creation and on code update.
@@ -823,9 +1116,9 @@ func (in *Instance) version() error { //skipcq: RVV-B0001
 // ValidateTransaction runs the extrinsic through the runtime function
 // TaggedTransactionQueue_validate_transaction and returns *transaction.Validity. The error can
 // be a VDT of either transaction.InvalidTransaction or transaction.UnknownTransaction, or can represent
-// a normal error i.e. unmarshalling error
-func (in *Instance) ValidateTransaction(e types.Extrinsic) (*transaction.Validity, error) {
-	ret, err := in.Exec(runtime.TaggedTransactionQueueValidateTransaction, e)
+// a normal error i.e. unmarshalling error. The runtime call is aborted once the context given is done.
+func (in *Instance) ValidateTransaction(ctx context.Context, e types.Extrinsic) (*transaction.Validity, error) {
+	ret, err := in.ExecContext(ctx, runtime.TaggedTransactionQueueValidateTransaction, e)
 	if err != nil {
 		return nil, err
 	}
@@ -838,7 +1131,56 @@ func (in *Instance) Metadata() ([]byte, error) {
 	return in.Exec(runtime.Metadata, []byte{})
 }
 
-// BabeConfiguration gets the configuration data for BABE from the runtime
+// MetadataAtVersion calls runtime function Metadata_metadata_at_version, and returns
+// the metadata at the version given, encoded like the metadata returned by Metadata.
+// It returns runtime.ErrMetadataVersionNotSupported if the runtime does not provide
+// the metadata at this version.
+func (in *Instance) MetadataAtVersion(version uint32) ([]byte, error) {
+	encodedVersion, err := scale.Marshal(version)
+	if err != nil {
+		return nil, fmt.Errorf("encoding version: %w", err)
+	}
+
+	ret, err := in.Exec(runtime.MetadataAtVersion, encodedVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	// the runtime returns an Option<OpaqueMetadata>, so strip the option byte
+	// to get the encoded OpaqueMetadata
+	if len(ret) == 0 {
+		return nil, errors.New("empty response")
+	}
+	switch ret[0] {
+	case 0:
+		return nil, fmt.Errorf("%w: version %d", runtime.ErrMetadataVersionNotSupported, version)
+	case 1:
+		return ret[1:], nil
+	default:
+		return nil, fmt.Errorf("invalid option byte: %d", ret[0])
+	}
+}
+
+// MetadataVersions calls runtime function Metadata_metadata_versions, and returns
+// the metadata versions supported by the runtime.
+func (in *Instance) MetadataVersions() ([]uint32, error) {
+	ret, err := in.Exec(runtime.MetadataVersions, []byte{})
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []uint32
+	err = scale.Unmarshal(ret, &versions)
+	if err != nil {
+		return nil, fmt.Errorf("decoding versions: %w", err)
+	}
+
+	return versions, nil
+}
+
+// BabeConfiguration gets the configuration data for BABE from the runtime.
+// The runtimes before the version 2 of the BABE API return the secondary slots
+// as a boolean, encoded as the allowed slots PrimarySlots or PrimaryAndSecondaryPlainSlots.
 func (in *Instance) BabeConfiguration() (*types.BabeConfiguration, error) {
 	data, err := in.Exec(runtime.BabeAPIConfiguration, []byte{})
 	if err != nil {
@@ -851,6 +1193,10 @@ func (in *Instance) BabeConfiguration() (*types.BabeConfiguration, error) {
 		return nil, err
 	}
 
+	if types.AllowedSlots(bc.SecondarySlots) > types.PrimaryAndSecondaryVRFSlots {
+		return nil, fmt.Errorf("%w: %d", types.ErrInvalidAllowedSlots, bc.SecondarySlots)
+	}
+
 	return bc, nil
 }
 
@@ -1125,9 +1471,25 @@ func (in *Instance) GrandpaSubmitReportEquivocationUnsignedExtrinsic(
 func (*Instance) RandomSeed() {
 	panic("unimplemented")
 }
-func (*Instance) OffchainWorker() {
-	panic("unimplemented")
+
+// OffchainWorker runs the offchain worker of the runtime for the block header given and returns
+// the transactions it submitted, which are neither validated nor added to the transaction pool.
+func (in *Instance) OffchainWorker(header *types.Header) ([]types.Extrinsic, error) {
+	encodedHeader, err := scale.Marshal(*header)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode header: %w", err)
+	}
+
+	var submitted []types.Extrinsic
+	ctx := context.WithValue(context.Background(), offchainTransactionsKey, &submitted)
+	_, err = in.ExecContext(ctx, runtime.OffchainWorkerAPIOffchainWorker, encodedHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	return submitted, nil
 }
+
 func (*Instance) GenerateSessionKeys() {
 	panic("unimplemented")
 }
@@ -1157,6 +1519,11 @@ func (in *Instance) Validator() bool {
 	return in.Context.Validator
 }
 
+// Config returns the configuration of the instance
+func (in *Instance) Config() runtime.InstanceConfig {
+	return in.config
+}
+
 // SetContextStorage sets the runtime's storage.
 func (in *Instance) SetContextStorage(s runtime.Storage) {
 	in.Lock()
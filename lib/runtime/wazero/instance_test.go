@@ -5,7 +5,11 @@
 
 import (
 	"bytes"
+	"context"
+	"encoding/binary"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"math/big"
 	"os"
 	"path/filepath"
@@ -16,19 +20,23 @@
 	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 	"github.com/ChainSafe/gossamer/lib/genesis"
+	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/runtime/allocator"
 	"github.com/ChainSafe/gossamer/lib/runtime/storage"
 	"github.com/ChainSafe/gossamer/lib/runtime/wazero/testdata"
 	"github.com/ChainSafe/gossamer/lib/utils"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 	"github.com/ChainSafe/gossamer/pkg/trie"
 	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
-	"github.com/centrifuge/go-substrate-rpc-client/v4/signature"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tetratelabs/wazero"
 )
 
 func mustHexTo64BArray(t *testing.T, inputHex string) (outputArray [64]byte) {
@@ -256,8 +264,10 @@ func TestWestendRuntime_ValidateTransaction(t *testing.T) {
 		StateRoot: trie.V0.MustHash(genTrie), // Get right state version from runtime
 	}
 
+	keyRing, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
 	extHex := runtime.NewTestExtrinsic(t, rt, genesisHeader.Hash(), genesisHeader.Hash(),
-		0, signature.TestKeyringPairAlice, "System.remark", []byte{0xab, 0xcd})
+		0, keyRing.Alice(), "System.remark", []byte{0xab, 0xcd})
 
 	genesisHashBytes := genesisHeader.Hash().ToBytes()
 
@@ -269,7 +279,7 @@ func TestWestendRuntime_ValidateTransaction(t *testing.T) {
 	extrinsicsBytes := bytes.Join(validateTransactionArguments, nil)
 
 	runtime.InitializeRuntimeToTest(t, rt, genesisHeader)
-	_, err = rt.ValidateTransaction(extrinsicsBytes)
+	_, err = rt.ValidateTransaction(context.Background(), extrinsicsBytes)
 	require.NoError(t, err)
 }
 
@@ -538,10 +548,8 @@ func TestInstance_BadSignature_WestendBlock8077850(t *testing.T) {
 				require.Equal(t, ret, []byte{0, 0})
 			}
 
-			keyring, err := signature.KeyringPairFromSecret(
-				"0x00000000000000000000000000000000000000000000000000000"+
-					"00000000000000000000000000000000000000000000000000000"+
-					"0000000000000000000000", 42)
+			// the zero secret key has the zero account as public key
+			keyring, err := sr25519.NewKeypairFromPrivateKeyBytes(make([]byte, 32))
 			require.NoError(t, err)
 
 			extHex := runtime.NewTestExtrinsic(t, instance, header.ParentHash, header.ParentHash,
@@ -668,8 +676,10 @@ func TestInstance_ApplyExtrinsic_WestendRuntime(t *testing.T) {
 	err = instance.InitializeBlock(header)
 	require.NoError(t, err)
 
+	keyRing, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
 	extHex := runtime.NewTestExtrinsic(t, instance, genesisHeader.Hash(), genesisHeader.Hash(),
-		0, signature.TestKeyringPairAlice, "System.remark", []byte{0xab, 0xcd})
+		0, keyRing.Alice(), "System.remark", []byte{0xab, 0xcd})
 
 	res, err := instance.ApplyExtrinsic(common.MustHexToBytes(extHex))
 	require.NoError(t, err)
@@ -1339,3 +1349,229 @@ func TestInstance_GrandpaSubmitReportEquivocationUnsignedExtrinsic(t *testing.T)
 	err = runtime.GrandpaSubmitReportEquivocationUnsignedExtrinsic(equivocationProof, opaqueKeyOwnershipProof)
 	require.NoError(t, err)
 }
+
+func Test_outOfMemoryError(t *testing.T) {
+	t.Parallel()
+
+	heapAllocator := allocator.NewFreeingBumpHeapAllocator(0, allocator.MaxWasmPages)
+	errTest := errors.New("test error")
+
+	err := outOfMemoryError(runtime.CoreVersion, heapAllocator, errTest)
+	assert.Equal(t, errTest, err)
+
+	errOutOfSpace := fmt.Errorf("running runtime function: %w", allocator.ErrAllocatorOutOfSpace)
+	err = outOfMemoryError(runtime.CoreVersion, heapAllocator, errOutOfSpace)
+	assert.ErrorIs(t, err, allocator.ErrAllocatorOutOfSpace)
+
+	var outOfMemoryErr *OutOfMemoryError
+	require.ErrorAs(t, err, &outOfMemoryErr)
+	assert.Equal(t, runtime.CoreVersion, outOfMemoryErr.Function)
+}
+
+func TestInstance_ExecContext_Canceled(t *testing.T) {
+	instance := NewTestInstance(t, runtime.WESTEND_RUNTIME_v0929)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := instance.ExecContext(ctx, runtime.CoreVersion, []byte{})
+	require.ErrorIs(t, err, context.Canceled)
+
+	// the runtime instance remains usable after an aborted call
+	_, err = instance.Exec(runtime.CoreVersion, []byte{})
+	require.NoError(t, err)
+}
+
+func TestInstance_Metering(t *testing.T) {
+	reports := make(chan MeteringReport, 2)
+	instance := NewTestInstance(t, runtime.WESTEND_RUNTIME_v0929, func(cfg *Config) {
+		cfg.Metering = true
+	})
+	require.True(t, instance.Config().Metering)
+
+	SetMeteringHook(func(report MeteringReport) { reports <- report })
+	defer SetMeteringHook(nil)
+
+	// the fuel consumed by a call is deterministic
+	for range 2 {
+		_, err := instance.Exec(runtime.CoreVersion, []byte{})
+		require.NoError(t, err)
+	}
+	first, second := <-reports, <-reports
+	assert.Equal(t, runtime.CoreVersion, first.Function)
+	assert.NotZero(t, first.Fuel)
+	assert.Equal(t, first, second)
+}
+
+func Test_newRuntime_stubMissingHostFunctions(t *testing.T) {
+	t.Parallel()
+
+	// wasm module importing the functions env.missing and other.missing,
+	// both of type () -> ()
+	code := []byte{
+		0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00, // magic and version
+		0x01, 0x04, 0x01, 0x60, 0x00, 0x00, // type section
+		0x02, 0x1f, 0x02, // import section
+		0x03, 'e', 'n', 'v', 0x07, 'm', 'i', 's', 's', 'i', 'n', 'g', 0x00, 0x00,
+		0x05, 'o', 't', 'h', 'e', 'r', 0x07, 'm', 'i', 's', 's', 'i', 'n', 'g', 0x00, 0x00,
+	}
+
+	ctx := context.Background()
+	config := wazero.NewRuntimeConfig()
+
+	_, _, _, err := newRuntime(ctx, code, config, false)
+	require.Error(t, err)
+
+	_, rt, _, err := newRuntime(ctx, code, config, true)
+	require.NoError(t, err)
+	defer rt.Close(ctx) //nolint:errcheck
+
+	for _, moduleName := range []string{hostModuleName, "other"} {
+		module := rt.Module(moduleName)
+		require.NotNil(t, module)
+		assert.NotNil(t, module.ExportedFunction("missing"))
+	}
+}
+
+// TestInstance_ContractsChildTrie instantiates a contract of the contracts pallet writing three
+// storage entries, and follows the lifecycle of its child trie: its root in the main trie, its
+// iteration, the migration of its root to the state version 1 and its deletion with a limit.
+func TestInstance_ContractsChildTrie(t *testing.T) {
+	runtimePath, err := runtime.GetRuntime(context.Background(), runtime.CONTRACTS_ROCOCO_RUNTIME_v180)
+	require.NoError(t, err)
+	code, err := os.ReadFile(filepath.Clean(runtimePath))
+	require.NoError(t, err)
+
+	kr, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+	alice := kr.Alice().Public()
+
+	patch := fmt.Sprintf(`{"balances":{"balances":[["%s",1000000000000000000]]}}`,
+		crypto.PublicKeyToAddress(alice))
+	top, err := BuildGenesisStorage(code, &genesis.RuntimeGenesis{Patch: json.RawMessage(patch)})
+	require.NoError(t, err)
+
+	// the state starts with the state version 0 to be migrated to the state version 1
+	state, err := inmemory_trie.LoadFromMap(top, trie.V0)
+	require.NoError(t, err)
+	ts := storage.NewTrieState(state)
+	instance := NewTestInstance(t, runtime.CONTRACTS_ROCOCO_RUNTIME_v180, func(cfg *Config) {
+		cfg.Storage = ts
+	})
+
+	// contract importing seal0.set_storage and env.memory, its deploy function setting
+	// the value of 40 bytes at offset 96 at the keys of 32 bytes at offsets 0, 32 and 64
+	value := bytes.Repeat([]byte{0xff}, 40)
+	contract := []byte{
+		0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00, // magic and version
+		0x01, 0x0a, 0x02, 0x60, 0x03, 0x7f, 0x7f, 0x7f, 0x00, 0x60, 0x00, 0x00, // type section
+		0x02, 0x24, 0x02, // import section
+		0x05, 's', 'e', 'a', 'l', '0', 0x0b, 's', 'e', 't', '_', 's', 't', 'o', 'r', 'a', 'g', 'e', 0x00, 0x00,
+		0x03, 'e', 'n', 'v', 0x06, 'm', 'e', 'm', 'o', 'r', 'y', 0x02, 0x01, 0x01, 0x01,
+		0x03, 0x03, 0x02, 0x01, 0x01, // function section
+		0x07, 0x11, 0x02, // export section
+		0x06, 'd', 'e', 'p', 'l', 'o', 'y', 0x00, 0x01,
+		0x04, 'c', 'a', 'l', 'l', 0x00, 0x02,
+		0x0a, 0x23, 0x02, // code section
+		0x1e, 0x00,
+		0x41, 0x00, 0x41, 0xe0, 0x00, 0x41, 0x28, 0x10, 0x00,
+		0x41, 0x20, 0x41, 0xe0, 0x00, 0x41, 0x28, 0x10, 0x00,
+		0x41, 0xc0, 0x00, 0x41, 0xe0, 0x00, 0x41, 0x28, 0x10, 0x00,
+		0x0b,
+		0x02, 0x00, 0x0b,
+		0x0b, 0x8f, 0x01, 0x01, 0x00, 0x41, 0x00, 0x0b, 0x88, 0x01, // data section
+	}
+	for _, keyByte := range []byte{1, 2, 3} {
+		contract = append(contract, bytes.Repeat([]byte{keyByte}, 32)...)
+	}
+	contract = append(contract, value...)
+
+	encodedContract, err := scale.Marshal(contract)
+	require.NoError(t, err)
+
+	// origin, zero value, no gas limit, no storage deposit limit, uploaded code, empty data and salt
+	args := append(alice.Encode(), make([]byte, 16)...)
+	args = append(args, 0, 0, 0)
+	args = append(args, encodedContract...)
+	args = append(args, 0, 0)
+
+	ret, err := instance.Exec(runtime.ContractsAPIInstantiate, args)
+	require.NoError(t, err)
+
+	// creation
+	childStorageKey := ts.NextKey(inmemory_trie.ChildStorageKeyPrefix)
+	require.Truef(t, bytes.HasPrefix(childStorageKey, inmemory_trie.ChildStorageKeyPrefix),
+		"no contract child trie, instantiation result 0x%x", ret)
+	trieID := childStorageKey[len(inmemory_trie.ChildStorageKeyPrefix):]
+
+	// iteration
+	keys, err := ts.GetKeysWithPrefixFromChild(trieID, nil)
+	require.NoError(t, err)
+	require.Len(t, keys, 3)
+
+	expected := inmemory_trie.NewEmptyTrie()
+	var key []byte
+	for _, expectedKey := range keys {
+		key, err = ts.GetChildNextKey(trieID, key)
+		require.NoError(t, err)
+		require.Equal(t, expectedKey, key)
+
+		childValue, err := ts.GetChildStorage(trieID, key)
+		require.NoError(t, err)
+		require.Equal(t, value, childValue)
+		require.NoError(t, expected.Put(key, childValue))
+	}
+	key, err = ts.GetChildNextKey(trieID, key)
+	require.NoError(t, err)
+	require.Nil(t, key)
+
+	// root calculation into the main trie
+	childRoot, err := ts.GetChildRoot(trieID)
+	require.NoError(t, err)
+	require.Equal(t, expected.MustHash(), childRoot)
+	_, err = ts.Trie().Hash()
+	require.NoError(t, err)
+	require.Equal(t, childRoot.ToBytes(), ts.Get(childStorageKey))
+
+	// migration to the state version 1, the values longer than 32 bytes being hashed in the
+	// trie nodes once written again, as done by the state trie migration
+	ts.SetVersion(trie.V1)
+	unmigratedRoot, err := ts.GetChildRoot(trieID)
+	require.NoError(t, err)
+	require.Equal(t, childRoot, unmigratedRoot)
+
+	expected.SetVersion(trie.V1)
+	for _, key := range keys {
+		require.NoError(t, ts.SetChildStorage(trieID, key, value))
+		require.NoError(t, expected.Put(key, value))
+	}
+	migratedRoot, err := ts.GetChildRoot(trieID)
+	require.NoError(t, err)
+	require.NotEqual(t, childRoot, migratedRoot)
+	require.Equal(t, expected.MustHash(), migratedRoot)
+	_, err = ts.Trie().Hash()
+	require.NoError(t, err)
+	require.Equal(t, migratedRoot.ToBytes(), ts.Get(childStorageKey))
+
+	// deletion with a limit, as done by the lazy deletion of the terminated contracts
+	limit := make([]byte, 4)
+	binary.LittleEndian.PutUint32(limit, 2)
+	deleted, allDeleted, err := ts.DeleteChildLimit(trieID, &limit)
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), deleted)
+	require.False(t, allDeleted)
+	_, err = ts.Trie().Hash()
+	require.NoError(t, err)
+	require.NotNil(t, ts.Get(childStorageKey))
+
+	deleted, allDeleted, err = ts.DeleteChildLimit(trieID, &limit)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), deleted)
+	require.True(t, allDeleted)
+	_, err = ts.Trie().Hash()
+	require.NoError(t, err)
+	require.Nil(t, ts.Get(childStorageKey))
+
+	_, err = ts.GetChildRoot(trieID)
+	require.ErrorIs(t, err, trie.ErrChildTrieDoesNotExist)
+}
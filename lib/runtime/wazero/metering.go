@@ -0,0 +1,543 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package wazero_runtime
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync/atomic"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// fuelGlobalName is the name of the global exported by the metered runtimes holding the fuel
+// consumed by the runtime call
+const fuelGlobalName = "gossamer_fuel"
+
+var (
+	errUnsupportedInstruction = errors.New("instruction not supported by the metering")
+	errUnexpectedEnd          = errors.New("unexpected end of runtime code")
+	errInvalidLEB128          = errors.New("invalid LEB128 integer")
+)
+
+var fuelGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: "gossamer_runtime",
+	Name:      "fuel",
+	Help:      "fuel consumed by the last call of the runtime function",
+}, []string{"function"})
+
+// MeteringReport is the fuel consumed by a call of a metered runtime, one unit of fuel being
+// consumed by each wasm instruction executed. The fuel is deterministic, the same call on the
+// same state consuming the same fuel whatever the node and its hardware. The fuel of a failed
+// call includes the instructions following the trap up to the next control flow instruction.
+type MeteringReport struct {
+	Function string
+	CodeHash common.Hash
+	Fuel     uint64
+}
+
+// MeteringHook receives the report of each call of the metered runtimes
+type MeteringHook func(report MeteringReport)
+
+var meteringHook atomic.Pointer[MeteringHook]
+
+// SetMeteringHook sets the hook receiving the report of each call of the metered runtimes, the
+// reports being only logged and exported as metrics if it is nil. The hook is called before the
+// runtime call returns, so it must not block.
+func SetMeteringHook(hook MeteringHook) {
+	if hook == nil {
+		meteringHook.Store(nil)
+		return
+	}
+	meteringHook.Store(&hook)
+}
+
+// report logs the report given, exports it as a metric and passes it to the metering hook.
+func (r MeteringReport) report() {
+	logger.Debugf("runtime function %s of code hash %s consumed %d fuel", r.Function, r.CodeHash, r.Fuel)
+	fuelGauge.WithLabelValues(r.Function).Set(float64(r.Fuel))
+	hook := meteringHook.Load()
+	if hook != nil {
+		(*hook)(r)
+	}
+}
+
+// wasm section ids
+const (
+	importSectionID = 2
+	globalSectionID = 6
+	exportSectionID = 7
+	codeSectionID   = 10
+)
+
+// sectionOrder is the order of the known sections in a wasm module, custom sections being
+// allowed anywhere
+var sectionOrder = []byte{1, 2, 3, 4, 5, 13, 6, 7, 8, 9, 12, 10, 11}
+
+// section is a section of a wasm module
+type section struct {
+	id      byte
+	content []byte
+}
+
+// instrumentMetering returns the uncompressed runtime code given, instrumented to count the
+// fuel consumed by each call in a mutable i64 global exported as gossamer_fuel.
+// Each segment of instructions running up to a control flow instruction increments the
+// global by its number of instructions when it is entered, so the fuel only depends on the
+// instructions executed.
+func instrumentMetering(code []byte) ([]byte, error) {
+	if len(code) < 8 {
+		return nil, errUnexpectedEnd
+	}
+	sections, err := parseSections(code[8:])
+	if err != nil {
+		return nil, fmt.Errorf("parsing sections: %w", err)
+	}
+
+	importedGlobals := uint32(0)
+	if i := findSection(sections, importSectionID); i >= 0 {
+		importedGlobals, err = countImportedGlobals(sections[i].content)
+		if err != nil {
+			return nil, fmt.Errorf("parsing import section: %w", err)
+		}
+	}
+
+	// the fuel global is added after the existing globals, leaving their indices unchanged
+	var globals []byte
+	globalsCount := uint32(0)
+	if i := findSection(sections, globalSectionID); i >= 0 {
+		r := &reader{data: sections[i].content}
+		globalsCount, err = r.u32()
+		if err != nil {
+			return nil, fmt.Errorf("parsing global section: %w", err)
+		}
+		globals = slices.Clone(r.data[r.offset:])
+	}
+	fuelGlobal := importedGlobals + globalsCount
+	// mutable i64 global initialised with i64.const 0
+	globals = append(globals, 0x7e, 0x01, 0x42, 0x00, 0x0b)
+	sections = setSection(sections, globalSectionID, append(appendU32(nil, globalsCount+1), globals...))
+
+	var exports []byte
+	exportsCount := uint32(0)
+	if i := findSection(sections, exportSectionID); i >= 0 {
+		r := &reader{data: sections[i].content}
+		exportsCount, err = r.u32()
+		if err != nil {
+			return nil, fmt.Errorf("parsing export section: %w", err)
+		}
+		exports = slices.Clone(r.data[r.offset:])
+	}
+	exports = appendU32(exports, uint32(len(fuelGlobalName)))
+	exports = append(exports, fuelGlobalName...)
+	exports = append(exports, 0x03) // global export
+	exports = appendU32(exports, fuelGlobal)
+	sections = setSection(sections, exportSectionID, append(appendU32(nil, exportsCount+1), exports...))
+
+	if i := findSection(sections, codeSectionID); i >= 0 {
+		sections[i].content, err = instrumentCode(sections[i].content, fuelGlobal)
+		if err != nil {
+			return nil, fmt.Errorf("instrumenting code section: %w", err)
+		}
+	}
+
+	instrumented := slices.Clone(code[:8])
+	for _, s := range sections {
+		instrumented = append(instrumented, s.id)
+		instrumented = appendU32(instrumented, uint32(len(s.content))) //nolint:gosec
+		instrumented = append(instrumented, s.content...)
+	}
+	return instrumented, nil
+}
+
+// meteredCode returns the runtime code given, compressed or not, decompressed and
+// instrumented for metering.
+func meteredCode(code []byte) ([]byte, error) {
+	code, err := decompressWasm(code)
+	if err != nil {
+		return nil, fmt.Errorf("decompressing runtime code: %w", err)
+	}
+	return instrumentMetering(code)
+}
+
+// parseSections returns the sections of the wasm module given without its header.
+func parseSections(module []byte) ([]section, error) {
+	var sections []section
+	r := &reader{data: module}
+	for r.offset < len(r.data) {
+		id, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		content, err := r.vector()
+		if err != nil {
+			return nil, fmt.Errorf("section %d: %w", id, err)
+		}
+		sections = append(sections, section{id: id, content: content})
+	}
+	return sections, nil
+}
+
+// findSection returns the index of the section of the id given, -1 if the module has no
+// such section.
+func findSection(sections []section, id byte) int {
+	return slices.IndexFunc(sections, func(s section) bool { return s.id == id })
+}
+
+// setSection sets the content of the section of the id given, inserting the section at its
+// place in the module if it does not exist.
+func setSection(sections []section, id byte, content []byte) []section {
+	if i := findSection(sections, id); i >= 0 {
+		sections[i].content = content
+		return sections
+	}
+
+	order := slices.Index(sectionOrder, id)
+	i := slices.IndexFunc(sections, func(s section) bool {
+		return slices.Index(sectionOrder, s.id) > order
+	})
+	if i < 0 {
+		i = len(sections)
+	}
+	return slices.Insert(sections, i, section{id: id, content: content})
+}
+
+// countImportedGlobals returns the number of globals imported by the import section given.
+func countImportedGlobals(imports []byte) (globals uint32, err error) {
+	r := &reader{data: imports}
+	count, err := r.u32()
+	if err != nil {
+		return 0, err
+	}
+	for ; count > 0; count-- {
+		// module and field names
+		for range 2 {
+			_, err = r.vector()
+			if err != nil {
+				return 0, err
+			}
+		}
+		kind, err := r.byte()
+		if err != nil {
+			return 0, err
+		}
+		switch kind {
+		case 0x00: // function type index
+			_, err = r.u32()
+		case 0x01: // table reference type and limits
+			_, err = r.byte()
+			if err == nil {
+				err = r.skipLimits()
+			}
+		case 0x02: // memory limits
+			err = r.skipLimits()
+		case 0x03: // global value type and mutability
+			globals++
+			_, err = r.bytes(2)
+		default:
+			err = fmt.Errorf("unknown import kind 0x%x", kind)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	return globals, nil
+}
+
+// instrumentCode returns the code section given with each function body instrumented to
+// increment the fuel global of the index given.
+func instrumentCode(code []byte, fuelGlobal uint32) ([]byte, error) {
+	r := &reader{data: code}
+	count, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+
+	instrumented := appendU32(nil, count)
+	for i := uint32(0); i < count; i++ {
+		body, err := r.vector()
+		if err != nil {
+			return nil, fmt.Errorf("function %d: %w", i, err)
+		}
+		body, err = instrumentBody(body, fuelGlobal)
+		if err != nil {
+			return nil, fmt.Errorf("function %d: %w", i, err)
+		}
+		instrumented = appendU32(instrumented, uint32(len(body))) //nolint:gosec
+		instrumented = append(instrumented, body...)
+	}
+	return instrumented, nil
+}
+
+// instrumentBody returns the function body given with the fuel of each segment of its
+// instructions charged at the start of the segment.
+func instrumentBody(body []byte, fuelGlobal uint32) ([]byte, error) {
+	r := &reader{data: body}
+	locals, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+	for ; locals > 0; locals-- {
+		// number and value type of the locals
+		_, err = r.u32()
+		if err == nil {
+			_, err = r.byte()
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	instrumented := slices.Clone(body[:r.offset])
+
+	segmentStart, fuel := r.offset, int64(0)
+	for r.offset < len(r.data) {
+		endsSegment, err := r.skipInstruction()
+		if err != nil {
+			return nil, err
+		}
+		fuel++
+		if !endsSegment && r.offset < len(r.data) {
+			continue
+		}
+
+		instrumented = appendCharge(instrumented, fuelGlobal, fuel)
+		instrumented = append(instrumented, r.data[segmentStart:r.offset]...)
+		segmentStart, fuel = r.offset, 0
+	}
+	return instrumented, nil
+}
+
+// appendCharge appends the instructions adding the fuel given to the fuel global, which leave
+// the stack unchanged.
+func appendCharge(code []byte, fuelGlobal uint32, fuel int64) []byte {
+	code = append(code, 0x23) // global.get
+	code = appendU32(code, fuelGlobal)
+	code = append(code, 0x42) // i64.const
+	code = appendS64(code, fuel)
+	code = append(code, 0x7c) // i64.add
+	code = append(code, 0x24) // global.set
+	return appendU32(code, fuelGlobal)
+}
+
+// reader reads the wasm binary format
+type reader struct {
+	data   []byte
+	offset int
+}
+
+func (r *reader) byte() (byte, error) {
+	if r.offset >= len(r.data) {
+		return 0, errUnexpectedEnd
+	}
+	b := r.data[r.offset]
+	r.offset++
+	return b, nil
+}
+
+func (r *reader) bytes(n int) ([]byte, error) {
+	if n < 0 || r.offset+n > len(r.data) {
+		return nil, errUnexpectedEnd
+	}
+	b := r.data[r.offset : r.offset+n]
+	r.offset += n
+	return b, nil
+}
+
+// vector reads bytes prefixed with their length.
+func (r *reader) vector() ([]byte, error) {
+	length, err := r.u32()
+	if err != nil {
+		return nil, err
+	}
+	return r.bytes(int(length))
+}
+
+// u32 reads an unsigned LEB128 integer of at most 32 bits.
+func (r *reader) u32() (uint32, error) {
+	var value uint32
+	for shift := 0; shift < 35; shift += 7 {
+		b, err := r.byte()
+		if err != nil {
+			return 0, err
+		}
+		value |= uint32(b&0x7f) << shift
+		if b&0x80 == 0 {
+			return value, nil
+		}
+	}
+	return 0, errInvalidLEB128
+}
+
+// skipLEB128 skips a LEB128 integer of at most the number of bits given.
+func (r *reader) skipLEB128(bits int) error {
+	for shift := 0; shift < bits+7; shift += 7 {
+		b, err := r.byte()
+		if err != nil {
+			return err
+		}
+		if b&0x80 == 0 {
+			return nil
+		}
+	}
+	return errInvalidLEB128
+}
+
+// skipU32s skips the number of LEB128 integers of at most 32 bits given.
+func (r *reader) skipU32s(n int) error {
+	for ; n > 0; n-- {
+		err := r.skipLEB128(32)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// skipLimits skips the limits of a table or memory.
+func (r *reader) skipLimits() error {
+	flags, err := r.byte()
+	if err != nil {
+		return err
+	}
+	// the maximum follows the minimum if the first bit is set
+	return r.skipU32s(1 + int(flags&0x01))
+}
+
+// skipInstruction skips the next instruction with its immediates, and returns true if the
+// instruction ends a segment of instructions, being a control flow instruction.
+func (r *reader) skipInstruction() (endsSegment bool, err error) {
+	opcode, err := r.byte()
+	if err != nil {
+		return false, err
+	}
+
+	switch {
+	case opcode == 0x02 || opcode == 0x03 || opcode == 0x04: // block, loop, if
+		return true, r.skipBlockType()
+	case opcode == 0x00 || opcode == 0x05 || opcode == 0x0b || opcode == 0x0f: // unreachable, else, end, return
+		return true, nil
+	case opcode == 0x0c || opcode == 0x0d: // br, br_if
+		return true, r.skipU32s(1)
+	case opcode == 0x0e: // br_table
+		targets, err := r.u32()
+		if err != nil {
+			return false, err
+		}
+		return true, r.skipU32s(int(targets) + 1)
+	case opcode == 0x01 || opcode == 0x1a || opcode == 0x1b || opcode == 0xd1: // nop, drop, select, ref.is_null
+		return false, nil
+	case opcode == 0x10: // call
+		return false, r.skipU32s(1)
+	case opcode == 0x11: // call_indirect
+		return false, r.skipU32s(2)
+	case opcode == 0x1c: // typed select
+		types, err := r.u32()
+		if err != nil {
+			return false, err
+		}
+		_, err = r.bytes(int(types))
+		return false, err
+	case opcode >= 0x20 && opcode <= 0x26: // local, global and table accesses
+		return false, r.skipU32s(1)
+	case opcode >= 0x28 && opcode <= 0x3e: // memory loads and stores with their alignment and offset
+		return false, r.skipU32s(2)
+	case opcode == 0x3f || opcode == 0x40: // memory.size, memory.grow
+		_, err = r.byte()
+		return false, err
+	case opcode == 0x41: // i32.const
+		return false, r.skipLEB128(32)
+	case opcode == 0x42: // i64.const
+		return false, r.skipLEB128(64)
+	case opcode == 0x43: // f32.const
+		_, err = r.bytes(4)
+		return false, err
+	case opcode == 0x44: // f64.const
+		_, err = r.bytes(8)
+		return false, err
+	case opcode >= 0x45 && opcode <= 0xc4: // numeric instructions, including sign extension
+		return false, nil
+	case opcode == 0xd0: // ref.null
+		_, err = r.byte()
+		return false, err
+	case opcode == 0xd2: // ref.func
+		return false, r.skipU32s(1)
+	case opcode == 0xfc:
+		return false, r.skipMiscInstruction()
+	default:
+		return false, fmt.Errorf("%w: opcode 0x%x", errUnsupportedInstruction, opcode)
+	}
+}
+
+// skipBlockType skips the type of a block, being either empty, a value type or a type index.
+func (r *reader) skipBlockType() error {
+	if r.offset >= len(r.data) {
+		return errUnexpectedEnd
+	}
+	switch r.data[r.offset] {
+	case 0x40, 0x7f, 0x7e, 0x7d, 0x7c, 0x7b, 0x70, 0x6f:
+		r.offset++
+		return nil
+	default:
+		return r.skipLEB128(33)
+	}
+}
+
+// skipMiscInstruction skips the instruction following the 0xfc prefix, being a saturating
+// truncation, bulk memory or table instruction.
+func (r *reader) skipMiscInstruction() error {
+	opcode, err := r.u32()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case opcode <= 7: // saturating truncations
+		return nil
+	case opcode == 8: // memory.init
+		err = r.skipU32s(1)
+		if err == nil {
+			_, err = r.byte()
+		}
+		return err
+	case opcode == 10: // memory.copy
+		_, err = r.bytes(2)
+		return err
+	case opcode == 11: // memory.fill
+		_, err = r.byte()
+		return err
+	case opcode == 12 || opcode == 14: // table.init, table.copy
+		return r.skipU32s(2)
+	case opcode == 9 || opcode == 13 || (opcode >= 15 && opcode <= 17): // data.drop, elem.drop, table.grow/size/fill
+		return r.skipU32s(1)
+	default:
+		return fmt.Errorf("%w: opcode 0xfc %d", errUnsupportedInstruction, opcode)
+	}
+}
+
+// appendU32 appends the value given encoded as an unsigned LEB128 integer.
+func appendU32(b []byte, value uint32) []byte {
+	for {
+		c := byte(value & 0x7f)
+		value >>= 7
+		if value == 0 {
+			return append(b, c)
+		}
+		b = append(b, c|0x80)
+	}
+}
+
+// appendS64 appends the value given encoded as a signed LEB128 integer.
+func appendS64(b []byte, value int64) []byte {
+	for {
+		c := byte(value & 0x7f)
+		value >>= 7
+		if (value == 0 && c&0x40 == 0) || (value == -1 && c&0x40 != 0) {
+			return append(b, c)
+		}
+		b = append(b, c|0x80)
+	}
+}
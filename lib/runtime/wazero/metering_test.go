@@ -0,0 +1,130 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package wazero_runtime
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tetratelabs/wazero"
+)
+
+// countdownModule returns a wasm module exporting the function run, which counts down from its
+// i32 parameter to zero in a loop and returns 7 as i64, with the function body given.
+func countdownModule(body []byte) []byte {
+	module := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	// type section: (param i32) (result i64)
+	module = append(module, 0x01, 0x06, 0x01, 0x60, 0x01, 0x7f, 0x01, 0x7e)
+	// function section
+	module = append(module, 0x03, 0x02, 0x01, 0x00)
+	// export section
+	module = append(module, 0x07, 0x07, 0x01, 0x03, 'r', 'u', 'n', 0x00, 0x00)
+	// code section
+	code := appendU32([]byte{0x01}, uint32(len(body)))
+	code = append(code, body...)
+	module = append(module, 0x0a)
+	module = appendU32(module, uint32(len(code)))
+	return append(module, code...)
+}
+
+var countdownBody = []byte{
+	0x00,       // no locals
+	0x03, 0x40, // loop
+	0x20, 0x00, // local.get 0
+	0x41, 0x01, // i32.const 1
+	0x6b,       // i32.sub
+	0x22, 0x00, // local.tee 0
+	0x0d, 0x00, // br_if 0
+	0x0b,       // end
+	0x42, 0x07, // i64.const 7
+	0x0b, // end
+}
+
+func Test_instrumentMetering(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		iterations   uint64
+		expectedFuel uint64
+	}{
+		// the loop instruction, 5 instructions per iteration, and the 3 instructions following the loop
+		"single_iteration": {
+			iterations:   1,
+			expectedFuel: 9,
+		},
+		"several_iterations": {
+			iterations:   3,
+			expectedFuel: 19,
+		},
+	}
+
+	code, err := instrumentMetering(countdownModule(countdownBody))
+	require.NoError(t, err)
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			rt := wazero.NewRuntime(ctx)
+			defer rt.Close(ctx) //nolint:errcheck
+			mod, err := rt.Instantiate(ctx, code)
+			require.NoError(t, err)
+
+			results, err := mod.ExportedFunction("run").Call(ctx, testCase.iterations)
+			require.NoError(t, err)
+			assert.Equal(t, []uint64{7}, results)
+
+			fuel := mod.ExportedGlobal(fuelGlobalName)
+			require.NotNil(t, fuel)
+			assert.Equal(t, testCase.expectedFuel, fuel.Get())
+		})
+	}
+}
+
+func Test_instrumentMetering_errors(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		code        []byte
+		expectedErr string
+	}{
+		"truncated_header": {
+			code:        []byte{0x00, 0x61, 0x73},
+			expectedErr: "unexpected end of runtime code",
+		},
+		"truncated_section": {
+			code:        []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00, 0x01, 0x06, 0x01},
+			expectedErr: "parsing sections: section 1: unexpected end of runtime code",
+		},
+		"unsupported_instruction": {
+			// v128.const
+			code: countdownModule([]byte{0x00, 0xfd, 0x0c, 0x0b}),
+			expectedErr: "instrumenting code section: function 0: " +
+				"instruction not supported by the metering: opcode 0xfd",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := instrumentMetering(testCase.code)
+			assert.EqualError(t, err, testCase.expectedErr)
+		})
+	}
+}
+
+func Test_appendS64(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []byte{0x07}, appendS64(nil, 7))
+	assert.Equal(t, []byte{0xc0, 0x00}, appendS64(nil, 64))
+	assert.Equal(t, []byte{0xe5, 0x8e, 0x26}, appendS64(nil, 624485))
+	assert.Equal(t, []byte{0x7f}, appendS64(nil, -1))
+}
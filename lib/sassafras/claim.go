@@ -0,0 +1,177 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sassafras
+
+import (
+	"encoding/binary"
+	"fmt"
+	"slices"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+)
+
+// SelectTickets returns the ticket ids given winning the slots of an epoch of the length
+// given, the lowest ones in ascending order.
+func SelectTickets(ticketIDs []*TicketID, epochLength uint32) []*TicketID {
+	sorted := slices.SortedFunc(slices.Values(ticketIDs), func(a, b *TicketID) int {
+		return a.Compare(b)
+	})
+	if len(sorted) > int(epochLength) {
+		sorted = sorted[:epochLength]
+	}
+	return sorted
+}
+
+// SlotTicketIndex returns the index of the ticket among the tickets selected for an epoch
+// winning the slot of the index given in the epoch. The tickets are assigned outside-in,
+// the lowest ticket to the first slot, the second lowest to the last slot of the tickets,
+// and so on, so that the slots following the tickets are left to the fallback authorities.
+func SlotTicketIndex(slotIndex, ticketsCount uint32) (ticketIndex uint32, ok bool) {
+	if slotIndex >= ticketsCount {
+		return 0, false
+	}
+
+	if slotIndex%2 == 1 {
+		return ticketsCount - 1 - slotIndex/2, true
+	}
+	return slotIndex / 2, true
+}
+
+// FallbackAuthorityIndex returns the index of the authority claiming the slot given when
+// no ticket won it, picked from the epoch randomness and the slot.
+func FallbackAuthorityIndex(randomness [32]byte, slot uint64, authoritiesCount uint32) (uint32, error) {
+	if authoritiesCount == 0 {
+		return 0, fmt.Errorf("%w: no authorities", errInvalidAuthorityIndex)
+	}
+
+	hash, err := common.Blake2bHash(slotData(randomness, slot))
+	if err != nil {
+		return 0, fmt.Errorf("hashing slot data: %w", err)
+	}
+
+	return binary.LittleEndian.Uint32(hash[:4]) % authoritiesCount, nil
+}
+
+// ClaimSlot claims the slot given of the epoch given for the keypair given, with the ticket
+// given if the slot was won by a ticket, which must be one of the tickets owned given,
+// or as the fallback authority of the slot if the slot ticket is nil.
+func ClaimSlot(epoch *Epoch, slot uint64, slotTicket *TicketID, owned []*OwnedTicket, keypair Keypair) (
+	*SlotClaim, error) {
+	authorityIndex := slices.Index(epoch.Authorities, keypair.Public())
+	if authorityIndex < 0 {
+		return nil, ErrNotAuthority
+	}
+
+	claim := &SlotClaim{
+		AuthorityIndex: uint32(authorityIndex), //nolint:gosec
+		Slot:           slot,
+	}
+	inputs := []VRFInput{slotClaimInput(epoch.Randomness, slot)}
+	authoritiesCount := uint32(len(epoch.Authorities)) //nolint:gosec
+
+	if slotTicket == nil {
+		fallbackIndex, err := FallbackAuthorityIndex(epoch.Randomness, slot, authoritiesCount)
+		if err != nil {
+			return nil, fmt.Errorf("getting fallback authority index: %w", err)
+		}
+
+		if fallbackIndex != claim.AuthorityIndex {
+			return nil, fmt.Errorf("%w: fallback authority index is %d", ErrSlotNotClaimed, fallbackIndex)
+		}
+	} else {
+		ticketIndex := slices.IndexFunc(owned, func(ticket *OwnedTicket) bool {
+			return ticket.ID.Compare(slotTicket) == 0
+		})
+		if ticketIndex < 0 {
+			return nil, fmt.Errorf("%w: ticket %s is not owned", ErrSlotNotClaimed, slotTicket)
+		}
+		ticket := owned[ticketIndex]
+
+		erasedSignature, err := ticket.erasedKeypair.Sign(slotData(epoch.Randomness, slot))
+		if err != nil {
+			return nil, fmt.Errorf("signing ticket claim: %w", err)
+		}
+
+		claim.TicketClaim = &TicketClaim{ErasedSignature: [ed25519.SignatureLength]byte(erasedSignature)}
+		inputs = append(inputs, revealedKeyInput(epoch.Randomness, ticket.Body.AttemptIndex))
+	}
+
+	signature, err := keypair.SignVRF(nil, inputs...)
+	if err != nil {
+		return nil, fmt.Errorf("signing slot claim: %w", err)
+	}
+	claim.Signature = signature
+
+	return claim, nil
+}
+
+// VerifySlotClaim verifies the claim given of a slot of the epoch given, won by the ticket of
+// the body given or by no ticket if the slot ticket is nil.
+func VerifySlotClaim(epoch *Epoch, claim *SlotClaim, slotTicket *TicketBody, verifier Verifier) error {
+	authoritiesCount := uint32(len(epoch.Authorities)) //nolint:gosec
+	if claim.AuthorityIndex >= authoritiesCount {
+		return fmt.Errorf("%w: %w: %d for %d authorities", ErrBadSlotClaim,
+			errInvalidAuthorityIndex, claim.AuthorityIndex, authoritiesCount)
+	}
+
+	inputs := []VRFInput{slotClaimInput(epoch.Randomness, claim.Slot)}
+
+	if slotTicket == nil {
+		if claim.TicketClaim != nil {
+			return fmt.Errorf("%w: %w", ErrBadSlotClaim, errUnexpectedTicketClaim)
+		}
+
+		fallbackIndex, err := FallbackAuthorityIndex(epoch.Randomness, claim.Slot, authoritiesCount)
+		if err != nil {
+			return fmt.Errorf("getting fallback authority index: %w", err)
+		}
+
+		if fallbackIndex != claim.AuthorityIndex {
+			return fmt.Errorf("%w: %w: expected authority %d, got %d", ErrBadSlotClaim,
+				errNotFallbackAuthority, fallbackIndex, claim.AuthorityIndex)
+		}
+	} else {
+		if claim.TicketClaim == nil {
+			return fmt.Errorf("%w: %w", ErrBadSlotClaim, errMissingTicketClaim)
+		}
+
+		err := ed25519.VerifySignature(slotTicket.ErasedPublic[:], claim.TicketClaim.ErasedSignature[:],
+			slotData(epoch.Randomness, claim.Slot))
+		if err != nil {
+			return fmt.Errorf("%w: erased signature: %w", ErrBadSlotClaim, err)
+		}
+
+		inputs = append(inputs, revealedKeyInput(epoch.Randomness, slotTicket.AttemptIndex))
+	}
+
+	if len(claim.Signature.PreOutputs) != len(inputs) {
+		return fmt.Errorf("%w: %w: %d for %d inputs", ErrBadSlotClaim,
+			errInvalidPreOutputs, len(claim.Signature.PreOutputs), len(inputs))
+	}
+
+	err := verifier.VerifyVRF(epoch.Authorities[claim.AuthorityIndex], claim.Signature, nil, inputs...)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrBadSlotClaim, err)
+	}
+
+	if slotTicket != nil {
+		revealedPublic, err := makeRevealedPublic(verifier, inputs[1], claim.Signature.PreOutputs[1])
+		if err != nil {
+			return fmt.Errorf("creating revealed keypair: %w", err)
+		}
+
+		if revealedPublic != slotTicket.RevealedPublic {
+			return fmt.Errorf("%w: %w", ErrBadSlotClaim, errRevealedKeyMismatch)
+		}
+	}
+
+	return nil
+}
+
+// slotData returns the randomness of the epoch followed by the little endian slot given,
+// the SCALE encoding of the tuple (randomness, slot).
+func slotData(randomness [32]byte, slot uint64) []byte {
+	return binary.LittleEndian.AppendUint64(slices.Clone(randomness[:]), slot)
+}
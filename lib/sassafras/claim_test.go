@@ -0,0 +1,134 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sassafras
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectTickets(t *testing.T) {
+	t.Parallel()
+
+	ticketIDs := []*TicketID{
+		scale.MustNewUint128([]byte{3}),
+		scale.MustNewUint128([]byte{1}),
+		scale.MustNewUint128([]byte{4}),
+		scale.MustNewUint128([]byte{2}),
+	}
+
+	selected := SelectTickets(ticketIDs, 3)
+
+	expected := []*TicketID{
+		scale.MustNewUint128([]byte{1}),
+		scale.MustNewUint128([]byte{2}),
+		scale.MustNewUint128([]byte{3}),
+	}
+	assert.Equal(t, expected, selected)
+}
+
+func TestSlotTicketIndex(t *testing.T) {
+	t.Parallel()
+
+	const ticketsCount = 5
+	expectedTicketIndexes := []uint32{0, 4, 1, 3, 2}
+
+	for slotIndex, expected := range expectedTicketIndexes {
+		ticketIndex, ok := SlotTicketIndex(uint32(slotIndex), ticketsCount)
+		assert.True(t, ok)
+		assert.Equal(t, expected, ticketIndex)
+	}
+
+	_, ok := SlotTicketIndex(ticketsCount, ticketsCount)
+	assert.False(t, ok)
+}
+
+func TestClaimSlot(t *testing.T) {
+	t.Parallel()
+
+	alice, bob := newFakeKeypair(t, 1), newFakeKeypair(t, 2)
+	epoch := &Epoch{
+		Length:      12,
+		Randomness:  [32]byte{1, 2, 3},
+		Authorities: []PublicKey{alice.Public(), bob.Public()},
+		Config: EpochConfiguration{
+			RedundancyFactor: 1,
+			AttemptsNumber:   8,
+		},
+	}
+
+	_, aliceTickets, err := GenerateTickets(epoch, newFakeRing(alice, bob), alice, fakeVerifier{})
+	require.NoError(t, err)
+	require.NotEmpty(t, aliceTickets)
+	ticket := aliceTickets[0]
+
+	t.Run("ticket", func(t *testing.T) {
+		t.Parallel()
+
+		const slot = 5
+		claim, err := ClaimSlot(epoch, slot, ticket.ID, aliceTickets, alice)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(0), claim.AuthorityIndex)
+		assert.NotNil(t, claim.TicketClaim)
+
+		err = VerifySlotClaim(epoch, claim, &ticket.Body, fakeVerifier{})
+		require.NoError(t, err)
+
+		_, err = ClaimSlot(epoch, slot, ticket.ID, nil, bob)
+		assert.ErrorIs(t, err, ErrSlotNotClaimed)
+
+		otherTicket := ticket.Body
+		otherTicket.RevealedPublic = [32]byte{1}
+		err = VerifySlotClaim(epoch, claim, &otherTicket, fakeVerifier{})
+		assert.ErrorIs(t, err, errRevealedKeyMismatch)
+
+		otherTicket = ticket.Body
+		otherTicket.ErasedPublic = otherTicket.RevealedPublic
+		err = VerifySlotClaim(epoch, claim, &otherTicket, fakeVerifier{})
+		assert.ErrorIs(t, err, ErrBadSlotClaim)
+
+		err = VerifySlotClaim(epoch, claim, nil, fakeVerifier{})
+		assert.ErrorIs(t, err, errUnexpectedTicketClaim)
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		t.Parallel()
+
+		const slot = 7
+		fallbackIndex, err := FallbackAuthorityIndex(epoch.Randomness, slot, 2)
+		require.NoError(t, err)
+		fallback, other := alice, bob
+		if fallbackIndex == 1 {
+			fallback, other = bob, alice
+		}
+
+		claim, err := ClaimSlot(epoch, slot, nil, nil, fallback)
+		require.NoError(t, err)
+		assert.Equal(t, fallbackIndex, claim.AuthorityIndex)
+		assert.Nil(t, claim.TicketClaim)
+
+		err = VerifySlotClaim(epoch, claim, nil, fakeVerifier{})
+		require.NoError(t, err)
+
+		_, err = ClaimSlot(epoch, slot, nil, nil, other)
+		assert.ErrorIs(t, err, ErrSlotNotClaimed)
+
+		claim.AuthorityIndex = 1 - fallbackIndex
+		err = VerifySlotClaim(epoch, claim, nil, fakeVerifier{})
+		assert.ErrorIs(t, err, errNotFallbackAuthority)
+
+		err = VerifySlotClaim(epoch, claim, &ticket.Body, fakeVerifier{})
+		assert.ErrorIs(t, err, errMissingTicketClaim)
+	})
+
+	t.Run("not_authority", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ClaimSlot(epoch, 1, nil, nil, newFakeKeypair(t, 3))
+		assert.ErrorIs(t, err, ErrNotAuthority)
+	})
+}
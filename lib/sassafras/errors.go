@@ -0,0 +1,31 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sassafras
+
+import "errors"
+
+var (
+	// ErrNotAuthority is returned when claiming a slot with a keypair which is not an authority of the epoch
+	ErrNotAuthority = errors.New("keypair is not an authority of the epoch")
+
+	// ErrSlotNotClaimed is returned when claiming a slot won by the ticket of another authority,
+	// or of which another authority is the fallback authority
+	ErrSlotNotClaimed = errors.New("slot is not claimed by the keypair")
+
+	// ErrBadTicketSignature is returned when the ring signature of a ticket cannot be verified
+	ErrBadTicketSignature = errors.New("could not verify ticket signature")
+
+	// ErrBadSlotClaim is returned when the claim of a slot cannot be verified
+	ErrBadSlotClaim = errors.New("could not verify slot claim")
+
+	errInvalidAttempt        = errors.New("invalid ticket attempt")
+	errInvalidPreOutputs     = errors.New("invalid number of VRF pre-outputs")
+	errTicketAboveThreshold  = errors.New("ticket id is above the threshold")
+	errInvalidAuthorityIndex = errors.New("invalid authority index")
+	errMissingTicketClaim    = errors.New("missing ticket claim for the slot won by a ticket")
+	errUnexpectedTicketClaim = errors.New("ticket claim for a slot won by no ticket")
+	errNotFallbackAuthority  = errors.New("authority is not the fallback authority of the slot")
+	errRevealedKeyMismatch   = errors.New("revealed key does not match the ticket")
+	errNoRingContext         = errors.New("no ring context")
+)
@@ -0,0 +1,104 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sassafras
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/require"
+)
+
+var errFakeVerification = errors.New("fake verification failed")
+
+// fakeKeypair is a stand-in for a bandersnatch keypair, whose pre-outputs are hashes of its
+// public key and the VRF input, and whose signatures are its public key.
+type fakeKeypair struct {
+	public PublicKey
+}
+
+func newFakeKeypair(t *testing.T, seed byte) *fakeKeypair {
+	t.Helper()
+	public, err := common.Blake2bHash([]byte{seed})
+	require.NoError(t, err)
+	return &fakeKeypair{public: PublicKey(public)}
+}
+
+func (kp *fakeKeypair) Public() PublicKey { return kp.public }
+
+func (kp *fakeKeypair) PreOutput(input VRFInput) (PreOutput, error) {
+	return fakePreOutput(kp.public, input), nil
+}
+
+func (kp *fakeKeypair) SignVRF(_ []byte, inputs ...VRFInput) (signature VRFSignature, err error) {
+	copy(signature.Signature[:], kp.public[:])
+	for _, input := range inputs {
+		signature.PreOutputs = append(signature.PreOutputs, fakePreOutput(kp.public, input))
+	}
+	return signature, nil
+}
+
+func (kp *fakeKeypair) SignRingVRF(_ RingContext, _ []byte, inputs ...VRFInput) (
+	signature RingVRFSignature, err error) {
+	copy(signature.Signature[:], kp.public[:])
+	for _, input := range inputs {
+		signature.PreOutputs = append(signature.PreOutputs, fakePreOutput(kp.public, input))
+	}
+	return signature, nil
+}
+
+func fakePreOutput(public PublicKey, input VRFInput) (preOutput PreOutput) {
+	hash, _ := common.Blake2bHash(slices.Concat(append([][]byte{public[:], input.Domain}, input.Data...)...))
+	copy(preOutput[:], hash[:])
+	return preOutput
+}
+
+// fakeVerifier verifies the signatures of fake keypairs, the ring context being
+// the concatenation of the public keys of the ring.
+type fakeVerifier struct{}
+
+func (fakeVerifier) VerifyVRF(public PublicKey, signature VRFSignature, _ []byte, inputs ...VRFInput) error {
+	if !bytes.Equal(signature.Signature[:PublicKeyLength], public[:]) {
+		return errFakeVerification
+	}
+	return verifyFakePreOutputs(public, signature.PreOutputs, inputs)
+}
+
+func (fakeVerifier) VerifyRingVRF(ring RingContext, signature RingVRFSignature, _ []byte,
+	inputs ...VRFInput) error {
+	public := PublicKey(signature.Signature[:PublicKeyLength])
+	for member := range slices.Chunk([]byte(ring), PublicKeyLength) {
+		if bytes.Equal(member, public[:]) {
+			return verifyFakePreOutputs(public, signature.PreOutputs, inputs)
+		}
+	}
+	return errFakeVerification
+}
+
+func (fakeVerifier) MakeBytes(label []byte, input VRFInput, preOutput PreOutput, length int) []byte {
+	hash, _ := common.Blake2bHash(slices.Concat(label, preOutput[:], input.Domain))
+	return hash[:length]
+}
+
+func verifyFakePreOutputs(public PublicKey, preOutputs []PreOutput, inputs []VRFInput) error {
+	if len(preOutputs) != len(inputs) {
+		return errFakeVerification
+	}
+	for i, input := range inputs {
+		if preOutputs[i] != fakePreOutput(public, input) {
+			return errFakeVerification
+		}
+	}
+	return nil
+}
+
+func newFakeRing(keypairs ...*fakeKeypair) (ring RingContext) {
+	for _, kp := range keypairs {
+		ring = append(ring, kp.public[:]...)
+	}
+	return ring
+}
@@ -0,0 +1,110 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sassafras
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// Executor executes runtime calls
+type Executor interface {
+	Exec(function string, data []byte) ([]byte, error)
+}
+
+// CurrentEpoch returns the current epoch of the runtime given
+func CurrentEpoch(rt Executor) (*Epoch, error) {
+	return getEpoch(rt, runtime.SassafrasAPICurrentEpoch)
+}
+
+// NextEpoch returns the next epoch of the runtime given
+func NextEpoch(rt Executor) (*Epoch, error) {
+	return getEpoch(rt, runtime.SassafrasAPINextEpoch)
+}
+
+func getEpoch(rt Executor, function string) (*Epoch, error) {
+	encodedEpoch, err := rt.Exec(function, []byte{})
+	if err != nil {
+		return nil, fmt.Errorf("calling %s: %w", function, err)
+	}
+
+	epoch := new(Epoch)
+	err = scale.Unmarshal(encodedEpoch, epoch)
+	if err != nil {
+		return nil, fmt.Errorf("decoding epoch: %w", err)
+	}
+
+	return epoch, nil
+}
+
+// GetRingContext returns the ring context of the authorities of the current epoch of the runtime given
+func GetRingContext(rt Executor) (RingContext, error) {
+	encodedRingContext, err := rt.Exec(runtime.SassafrasAPIRingContext, []byte{})
+	if err != nil {
+		return nil, fmt.Errorf("calling %s: %w", runtime.SassafrasAPIRingContext, err)
+	}
+
+	// the ring context is an optional fixed size array of the size of the ring domain,
+	// which is kept opaque rather than decoded
+	if len(encodedRingContext) == 0 || encodedRingContext[0] == 0 {
+		return nil, errNoRingContext
+	}
+
+	return RingContext(encodedRingContext[1:]), nil
+}
+
+// SubmitTickets submits the ticket envelopes given to the runtime given, which includes them
+// in an unsigned extrinsic of the transaction pool. It returns false if they were rejected.
+func SubmitTickets(rt Executor, envelopes []TicketEnvelope) (bool, error) {
+	encodedEnvelopes, err := scale.Marshal(envelopes)
+	if err != nil {
+		return false, fmt.Errorf("encoding tickets: %w", err)
+	}
+
+	encodedSubmitted, err := rt.Exec(runtime.SassafrasAPISubmitTicketsUnsignedExtrinsic, encodedEnvelopes)
+	if err != nil {
+		return false, fmt.Errorf("calling %s: %w", runtime.SassafrasAPISubmitTicketsUnsignedExtrinsic, err)
+	}
+
+	var submitted bool
+	err = scale.Unmarshal(encodedSubmitted, &submitted)
+	if err != nil {
+		return false, fmt.Errorf("decoding submission result: %w", err)
+	}
+
+	return submitted, nil
+}
+
+// slotTicket is a ticket winning a slot, as returned by the runtime
+type slotTicket struct {
+	ID   *TicketID
+	Body TicketBody
+}
+
+// SlotTicket returns the id and the body of the ticket winning the slot given according to the
+// runtime given, or nil if the slot is claimed by its fallback authority.
+func SlotTicket(rt Executor, slot uint64) (*TicketID, *TicketBody, error) {
+	encodedSlot, err := scale.Marshal(slot)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encoding slot: %w", err)
+	}
+
+	encodedTicket, err := rt.Exec(runtime.SassafrasAPISlotTicket, encodedSlot)
+	if err != nil {
+		return nil, nil, fmt.Errorf("calling %s: %w", runtime.SassafrasAPISlotTicket, err)
+	}
+
+	var ticket *slotTicket
+	err = scale.Unmarshal(encodedTicket, &ticket)
+	if err != nil {
+		return nil, nil, fmt.Errorf("decoding slot ticket: %w", err)
+	}
+
+	if ticket == nil {
+		return nil, nil, nil
+	}
+	return ticket.ID, &ticket.Body, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sassafras
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testExecutor map[string][]byte
+
+func (e testExecutor) Exec(function string, _ []byte) ([]byte, error) {
+	ret, ok := e[function]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %s", function)
+	}
+	return ret, nil
+}
+
+func TestCurrentEpoch(t *testing.T) {
+	t.Parallel()
+
+	epoch := &Epoch{
+		Start:       100,
+		Length:      600,
+		Randomness:  [32]byte{1},
+		Authorities: []PublicKey{{2}, {3}},
+		Config: EpochConfiguration{
+			RedundancyFactor: 1,
+			AttemptsNumber:   30,
+		},
+	}
+	executor := testExecutor{runtime.SassafrasAPICurrentEpoch: scale.MustMarshal(*epoch)}
+
+	currentEpoch, err := CurrentEpoch(executor)
+	require.NoError(t, err)
+	assert.Equal(t, epoch, currentEpoch)
+
+	_, err = NextEpoch(executor)
+	assert.EqualError(t, err, "calling SassafrasApi_next_epoch: unknown function SassafrasApi_next_epoch")
+}
+
+func TestGetRingContext(t *testing.T) {
+	t.Parallel()
+
+	ring, err := GetRingContext(testExecutor{runtime.SassafrasAPIRingContext: {1, 2, 3}})
+	require.NoError(t, err)
+	assert.Equal(t, RingContext{2, 3}, ring)
+
+	_, err = GetRingContext(testExecutor{runtime.SassafrasAPIRingContext: {0}})
+	assert.ErrorIs(t, err, errNoRingContext)
+}
+
+func TestSlotTicket(t *testing.T) {
+	t.Parallel()
+
+	ticket := &slotTicket{
+		ID:   scale.MustNewUint128([]byte{1, 2, 3}),
+		Body: TicketBody{AttemptIndex: 4, ErasedPublic: [32]byte{5}, RevealedPublic: [32]byte{6}},
+	}
+
+	ticketID, body, err := SlotTicket(testExecutor{runtime.SassafrasAPISlotTicket: scale.MustMarshal(ticket)}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, ticket.ID, ticketID)
+	assert.Equal(t, &ticket.Body, body)
+
+	var noTicket *slotTicket
+	ticketID, body, err = SlotTicket(testExecutor{runtime.SassafrasAPISlotTicket: scale.MustMarshal(noTicket)}, 1)
+	require.NoError(t, err)
+	assert.Nil(t, ticketID)
+	assert.Nil(t, body)
+}
+
+func TestSubmitTickets(t *testing.T) {
+	t.Parallel()
+
+	submitted, err := SubmitTickets(testExecutor{
+		runtime.SassafrasAPISubmitTicketsUnsignedExtrinsic: scale.MustMarshal(true),
+	}, []TicketEnvelope{{Body: TicketBody{AttemptIndex: 1}}})
+	require.NoError(t, err)
+	assert.True(t, submitted)
+}
@@ -0,0 +1,130 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package sassafras implements the client side of the Sassafras (SAFROLE) consensus: the
+// submission of the tickets entering the slot lottery of an epoch, their verification and
+// the claiming of the slots, as specified by sp-consensus-sassafras. The bandersnatch VRF
+// and ring VRF it relies on are not implemented by gossamer yet and are provided through
+// the Keypair and Verifier interfaces.
+package sassafras
+
+import (
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// EngineName is the consensus engine name of the chain spec selecting Sassafras
+const EngineName = "sassafras"
+
+// EngineID is the consensus engine id of the Sassafras digests
+var EngineID = [4]byte{'S', 'A', 'S', 'S'}
+
+const (
+	// PublicKeyLength is the length of a bandersnatch public key
+	PublicKeyLength = 32
+	// PreOutputLength is the length of a bandersnatch VRF pre-output
+	PreOutputLength = 33
+	// SignatureLength is the length of a bandersnatch signature
+	SignatureLength = 65
+	// RingSignatureLength is the length of a bandersnatch ring signature
+	RingSignatureLength = 755
+)
+
+// PublicKey is the bandersnatch public key of an authority
+type PublicKey [PublicKeyLength]byte
+
+// PreOutput is a bandersnatch VRF pre-output, from which the VRF output bytes are made
+type PreOutput [PreOutputLength]byte
+
+// VRFInput is the input of a bandersnatch VRF, the data given under a domain separation label
+type VRFInput struct {
+	Domain []byte
+	Data   [][]byte
+}
+
+// VRFSignature is a bandersnatch VRF signature with the pre-outputs of its inputs
+type VRFSignature struct {
+	Signature  [SignatureLength]byte
+	PreOutputs []PreOutput
+}
+
+// RingVRFSignature is a bandersnatch ring VRF signature, proving the signer is one of the keys
+// of a ring without revealing which one, with the pre-outputs of its inputs
+type RingVRFSignature struct {
+	Signature  [RingSignatureLength]byte
+	PreOutputs []PreOutput
+}
+
+// RingContext is the opaque context of the ring VRF of the authorities of an epoch,
+// as returned by the runtime
+type RingContext []byte
+
+// Keypair is the bandersnatch keypair of an authority
+type Keypair interface {
+	Public() PublicKey
+	// PreOutput returns the VRF pre-output of the input given
+	PreOutput(input VRFInput) (PreOutput, error)
+	// SignVRF signs the data given with the VRF inputs given
+	SignVRF(data []byte, inputs ...VRFInput) (VRFSignature, error)
+	// SignRingVRF signs the data given with the VRF inputs given as a member of the ring given
+	SignRingVRF(ring RingContext, data []byte, inputs ...VRFInput) (RingVRFSignature, error)
+}
+
+// Verifier verifies bandersnatch VRF signatures and makes the VRF output bytes
+type Verifier interface {
+	VerifyVRF(public PublicKey, signature VRFSignature, data []byte, inputs ...VRFInput) error
+	VerifyRingVRF(ring RingContext, signature RingVRFSignature, data []byte, inputs ...VRFInput) error
+	// MakeBytes returns the output bytes of the length given made from the pre-output of the
+	// input given, under the label given
+	MakeBytes(label []byte, input VRFInput, preOutput PreOutput, length int) []byte
+}
+
+// EpochConfiguration is the configuration of the ticket lottery of an epoch
+type EpochConfiguration struct {
+	// RedundancyFactor is the expected number of tickets submitted per slot
+	RedundancyFactor uint32
+	// AttemptsNumber is the number of tickets each authority attempts to submit
+	AttemptsNumber uint32
+}
+
+// Epoch is an epoch as returned by the runtime
+type Epoch struct {
+	Start       uint64
+	Length      uint32
+	Randomness  [32]byte
+	Authorities []PublicKey
+	Config      EpochConfiguration
+}
+
+// TicketID is the identifier of a ticket, made from its VRF output. The tickets with the
+// lowest identifiers win the slots of the epoch.
+type TicketID = scale.Uint128
+
+// TicketBody is the body of a ticket, signed anonymously by its authority
+type TicketBody struct {
+	AttemptIndex uint32
+	// ErasedPublic is the public key the claim of the slot won by the ticket is signed with
+	ErasedPublic [ed25519.PublicKeyLength]byte
+	// RevealedPublic is the public key revealed when claiming the slot won by the ticket
+	RevealedPublic [ed25519.PublicKeyLength]byte
+}
+
+// TicketEnvelope is a ticket as submitted to the runtime
+type TicketEnvelope struct {
+	Body      TicketBody
+	Signature RingVRFSignature
+}
+
+// TicketClaim proves the ownership of the ticket of the slot claimed
+type TicketClaim struct {
+	ErasedSignature [ed25519.SignatureLength]byte
+}
+
+// SlotClaim is the pre-runtime digest of a block claiming a slot, with a ticket of the slot
+// or as the fallback authority of a slot no ticket won
+type SlotClaim struct {
+	AuthorityIndex uint32
+	Slot           uint64
+	Signature      VRFSignature
+	TicketClaim    *TicketClaim
+}
@@ -0,0 +1,195 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sassafras
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// ticketIDLength is the number of output bytes a ticket id is made from
+const ticketIDLength = 16
+
+var (
+	ticketIDDomain    = []byte("sassafras-ticket-v1.0")
+	revealedKeyDomain = []byte("sassafras-revealed-v1.0")
+	slotClaimDomain   = []byte("sassafras-claim-v1.0")
+	ticketIDLabel     = []byte("ticket-id")
+	erasedSeedLabel   = []byte("erased-seed")
+	revealedSeedLabel = []byte("revealed-seed")
+)
+
+// OwnedTicket is a ticket submitted by the node, with the erased keypair claiming its slot
+type OwnedTicket struct {
+	ID            *TicketID
+	Body          TicketBody
+	erasedKeypair *ed25519.Keypair
+}
+
+// TicketIDThreshold returns the ticket id below which the tickets enter the lottery of an epoch
+// of the number of slots given, such that the number of tickets expected from all the attempts
+// of the validators is the redundancy factor times the number of slots.
+func TicketIDThreshold(redundancy, slots, attempts, validators uint32) *TicketID {
+	denominator := new(big.Int).SetUint64(uint64(attempts) * uint64(validators))
+	if denominator.Sign() == 0 {
+		return new(TicketID)
+	}
+
+	maxTicketID := new(big.Int).SetBytes(scale.MaxUint128.Bytes(binary.BigEndian))
+	threshold := new(big.Int).Div(maxTicketID, denominator)
+	threshold.Mul(threshold, new(big.Int).SetUint64(uint64(redundancy)*uint64(slots)))
+	if threshold.Cmp(maxTicketID) > 0 {
+		threshold = maxTicketID
+	}
+
+	return scale.MustNewUint128(threshold)
+}
+
+// GenerateTickets makes the tickets of the attempts of the keypair given for the epoch given,
+// keeping the ones below the ticket id threshold, and signs them as a member of the ring given.
+// It returns the envelopes to submit to the runtime and the tickets to claim the slots with.
+func GenerateTickets(epoch *Epoch, ring RingContext, keypair Keypair, verifier Verifier) (
+	envelopes []TicketEnvelope, tickets []*OwnedTicket, err error) {
+	threshold := TicketIDThreshold(epoch.Config.RedundancyFactor, epoch.Length,
+		epoch.Config.AttemptsNumber, uint32(len(epoch.Authorities))) //nolint:gosec
+
+	for attempt := uint32(0); attempt < epoch.Config.AttemptsNumber; attempt++ {
+		input := ticketIDInput(epoch.Randomness, attempt)
+		preOutput, err := keypair.PreOutput(input)
+		if err != nil {
+			return nil, nil, fmt.Errorf("computing pre-output of attempt %d: %w", attempt, err)
+		}
+
+		ticketID := makeTicketID(verifier, input, preOutput)
+		if ticketID.Compare(threshold) >= 0 {
+			continue
+		}
+
+		erasedKeypair, err := ed25519.NewKeypairFromSeed(
+			verifier.MakeBytes(erasedSeedLabel, input, preOutput, ed25519.SeedLength))
+		if err != nil {
+			return nil, nil, fmt.Errorf("creating erased keypair of attempt %d: %w", attempt, err)
+		}
+
+		revealedInput := revealedKeyInput(epoch.Randomness, attempt)
+		revealedPreOutput, err := keypair.PreOutput(revealedInput)
+		if err != nil {
+			return nil, nil, fmt.Errorf("computing revealed pre-output of attempt %d: %w", attempt, err)
+		}
+
+		revealedPublic, err := makeRevealedPublic(verifier, revealedInput, revealedPreOutput)
+		if err != nil {
+			return nil, nil, fmt.Errorf("creating revealed keypair of attempt %d: %w", attempt, err)
+		}
+
+		body := TicketBody{
+			AttemptIndex:   attempt,
+			ErasedPublic:   erasedKeypair.Public().(*ed25519.PublicKey).AsBytes(),
+			RevealedPublic: revealedPublic,
+		}
+
+		data, err := scale.Marshal(body)
+		if err != nil {
+			return nil, nil, fmt.Errorf("encoding ticket body of attempt %d: %w", attempt, err)
+		}
+
+		signature, err := keypair.SignRingVRF(ring, data, input)
+		if err != nil {
+			return nil, nil, fmt.Errorf("signing ticket of attempt %d: %w", attempt, err)
+		}
+
+		envelopes = append(envelopes, TicketEnvelope{Body: body, Signature: signature})
+		tickets = append(tickets, &OwnedTicket{
+			ID:            ticketID,
+			Body:          body,
+			erasedKeypair: erasedKeypair,
+		})
+	}
+
+	return envelopes, tickets, nil
+}
+
+// VerifyTickets verifies the ring signatures of the ticket envelopes given for the epoch given,
+// and returns their ticket ids, checking they are below the ticket id threshold.
+func VerifyTickets(epoch *Epoch, ring RingContext, envelopes []TicketEnvelope, verifier Verifier) (
+	ticketIDs []*TicketID, err error) {
+	threshold := TicketIDThreshold(epoch.Config.RedundancyFactor, epoch.Length,
+		epoch.Config.AttemptsNumber, uint32(len(epoch.Authorities))) //nolint:gosec
+
+	ticketIDs = make([]*TicketID, len(envelopes))
+	for i, envelope := range envelopes {
+		attempt := envelope.Body.AttemptIndex
+		if attempt >= epoch.Config.AttemptsNumber {
+			return nil, fmt.Errorf("%w: ticket %d has attempt %d for %d attempts",
+				errInvalidAttempt, i, attempt, epoch.Config.AttemptsNumber)
+		}
+
+		if len(envelope.Signature.PreOutputs) != 1 {
+			return nil, fmt.Errorf("%w: ticket %d has %d pre-outputs",
+				errInvalidPreOutputs, i, len(envelope.Signature.PreOutputs))
+		}
+
+		data, err := scale.Marshal(envelope.Body)
+		if err != nil {
+			return nil, fmt.Errorf("encoding body of ticket %d: %w", i, err)
+		}
+
+		input := ticketIDInput(epoch.Randomness, attempt)
+		err = verifier.VerifyRingVRF(ring, envelope.Signature, data, input)
+		if err != nil {
+			return nil, fmt.Errorf("%w: ticket %d: %w", ErrBadTicketSignature, i, err)
+		}
+
+		ticketID := makeTicketID(verifier, input, envelope.Signature.PreOutputs[0])
+		if ticketID.Compare(threshold) >= 0 {
+			return nil, fmt.Errorf("%w: ticket %d has id %s", errTicketAboveThreshold, i, ticketID)
+		}
+		ticketIDs[i] = ticketID
+	}
+
+	return ticketIDs, nil
+}
+
+// makeTicketID returns the ticket id made from the pre-output of the ticket input given,
+// the little endian unsigned integer of its first 16 output bytes.
+func makeTicketID(verifier Verifier, input VRFInput, preOutput PreOutput) *TicketID {
+	return scale.MustNewUint128(verifier.MakeBytes(ticketIDLabel, input, preOutput, ticketIDLength))
+}
+
+// makeRevealedPublic returns the public key of the keypair seeded by the pre-output of the
+// revealed key input given, which the claim of the slot won by the ticket reveals.
+func makeRevealedPublic(verifier Verifier, input VRFInput, preOutput PreOutput) (
+	public [ed25519.PublicKeyLength]byte, err error) {
+	keypair, err := ed25519.NewKeypairFromSeed(
+		verifier.MakeBytes(revealedSeedLabel, input, preOutput, ed25519.SeedLength))
+	if err != nil {
+		return public, err
+	}
+	return keypair.Public().(*ed25519.PublicKey).AsBytes(), nil
+}
+
+func ticketIDInput(randomness [32]byte, attempt uint32) VRFInput {
+	return VRFInput{
+		Domain: ticketIDDomain,
+		Data:   [][]byte{randomness[:], binary.LittleEndian.AppendUint32(nil, attempt)},
+	}
+}
+
+func revealedKeyInput(randomness [32]byte, attempt uint32) VRFInput {
+	return VRFInput{
+		Domain: revealedKeyDomain,
+		Data:   [][]byte{randomness[:], binary.LittleEndian.AppendUint32(nil, attempt)},
+	}
+}
+
+func slotClaimInput(randomness [32]byte, slot uint64) VRFInput {
+	return VRFInput{
+		Domain: slotClaimDomain,
+		Data:   [][]byte{randomness[:], binary.LittleEndian.AppendUint64(nil, slot)},
+	}
+}
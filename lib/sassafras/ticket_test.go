@@ -0,0 +1,154 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sassafras
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTicketIDThreshold(t *testing.T) {
+	t.Parallel()
+
+	maxTicketID, ok := new(big.Int).SetString("340282366920938463463374607431768211455", 10)
+	require.True(t, ok)
+
+	testCases := map[string]struct {
+		redundancy, slots, attempts, validators uint32
+		expected                                *big.Int
+	}{
+		"no_validators": {
+			redundancy: 1, slots: 600, attempts: 30, validators: 0,
+			expected: big.NewInt(0),
+		},
+		"one_ticket_per_slot": {
+			redundancy: 1, slots: 1, attempts: 1, validators: 1,
+			expected: maxTicketID,
+		},
+		"fraction_of_tickets": {
+			redundancy: 2, slots: 600, attempts: 30, validators: 100,
+			expected: new(big.Int).Mul(new(big.Int).Div(maxTicketID, big.NewInt(3000)), big.NewInt(1200)),
+		},
+		"saturated": {
+			redundancy: 4, slots: 600, attempts: 1, validators: 10,
+			expected: maxTicketID,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			threshold := TicketIDThreshold(testCase.redundancy, testCase.slots,
+				testCase.attempts, testCase.validators)
+			assert.Equal(t, scale.MustNewUint128(testCase.expected), threshold)
+		})
+	}
+}
+
+func TestGenerateTickets(t *testing.T) {
+	t.Parallel()
+
+	alice, bob, charlie := newFakeKeypair(t, 1), newFakeKeypair(t, 2), newFakeKeypair(t, 3)
+	epoch := &Epoch{
+		Start:       100,
+		Length:      12,
+		Randomness:  [32]byte{1, 2, 3},
+		Authorities: []PublicKey{alice.Public(), bob.Public(), charlie.Public()},
+		Config: EpochConfiguration{
+			RedundancyFactor: 1,
+			AttemptsNumber:   8,
+		},
+	}
+	ring := newFakeRing(alice, bob, charlie)
+
+	envelopes, tickets, err := GenerateTickets(epoch, ring, alice, fakeVerifier{})
+	require.NoError(t, err)
+	require.Len(t, tickets, len(envelopes))
+	require.NotEmpty(t, envelopes)
+	require.Less(t, len(envelopes), int(epoch.Config.AttemptsNumber))
+
+	threshold := TicketIDThreshold(1, 12, 8, 3)
+	for i, ticket := range tickets {
+		assert.Equal(t, envelopes[i].Body, ticket.Body)
+		assert.Equal(t, -1, ticket.ID.Compare(threshold))
+	}
+
+	ticketIDs, err := VerifyTickets(epoch, ring, envelopes, fakeVerifier{})
+	require.NoError(t, err)
+	for i, ticket := range tickets {
+		assert.Equal(t, ticket.ID, ticketIDs[i])
+	}
+}
+
+func TestVerifyTickets(t *testing.T) {
+	t.Parallel()
+
+	alice, bob := newFakeKeypair(t, 1), newFakeKeypair(t, 2)
+	epoch := &Epoch{
+		Length:      12,
+		Randomness:  [32]byte{1, 2, 3},
+		Authorities: []PublicKey{alice.Public(), bob.Public()},
+		Config: EpochConfiguration{
+			RedundancyFactor: 1,
+			AttemptsNumber:   8,
+		},
+	}
+
+	envelopes, _, err := GenerateTickets(epoch, newFakeRing(alice, bob), alice, fakeVerifier{})
+	require.NoError(t, err)
+	require.NotEmpty(t, envelopes)
+
+	testCases := map[string]struct {
+		ring       RingContext
+		envelope   func() TicketEnvelope
+		errWrapped error
+		errMessage string
+	}{
+		"invalid_attempt": {
+			ring: newFakeRing(alice, bob),
+			envelope: func() TicketEnvelope {
+				envelope := envelopes[0]
+				envelope.Body.AttemptIndex = 8
+				return envelope
+			},
+			errWrapped: errInvalidAttempt,
+			errMessage: "invalid ticket attempt: ticket 0 has attempt 8 for 8 attempts",
+		},
+		"no_pre_output": {
+			ring: newFakeRing(alice, bob),
+			envelope: func() TicketEnvelope {
+				envelope := envelopes[0]
+				envelope.Signature.PreOutputs = nil
+				return envelope
+			},
+			errWrapped: errInvalidPreOutputs,
+			errMessage: "invalid number of VRF pre-outputs: ticket 0 has 0 pre-outputs",
+		},
+		"not_in_ring": {
+			ring:       newFakeRing(bob),
+			envelope:   func() TicketEnvelope { return envelopes[0] },
+			errWrapped: ErrBadTicketSignature,
+			errMessage: "could not verify ticket signature: ticket 0: fake verification failed",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ticketIDs, err := VerifyTickets(epoch, testCase.ring,
+				[]TicketEnvelope{testCase.envelope()}, fakeVerifier{})
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			assert.EqualError(t, err, testCase.errMessage)
+			assert.Nil(t, ticketIDs)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"runtime/pprof"
+	"strings"
+	"sync/atomic"
+)
+
+// CrashHandler handles the panics recovered in the goroutines of the services, such as by
+// reporting them and restarting the service which panicked.
+type CrashHandler interface {
+	HandleCrash(service string, recovered any, stack []byte)
+}
+
+// crashHandler is the crash handler the panics recovered are passed to, the panics being
+// propagated if it is not set
+var crashHandler atomic.Pointer[CrashHandler]
+
+// SetCrashHandler sets the crash handler the panics recovered in the goroutines of the
+// services are passed to, the panics being propagated again if it is nil.
+func SetCrashHandler(handler CrashHandler) {
+	if handler == nil {
+		crashHandler.Store(nil)
+		return
+	}
+	crashHandler.Store(&handler)
+}
+
+// Name returns the name of the service given, being its type name such as babe.Service
+func Name(service any) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", service), "*")
+}
+
+// Recover recovers the panic of the goroutine of the service given and passes it to the
+// crash handler, and propagates the panic again if no crash handler is set. It must be
+// deferred by the function run by the goroutine.
+func Recover(service any) {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+
+	handler := crashHandler.Load()
+	if handler == nil {
+		panic(recovered)
+	}
+	(*handler).HandleCrash(Name(service), recovered, debug.Stack())
+}
+
+// Go runs the function given in a new goroutine of the service given, labelled with the
+// name of the service and recovering its panic.
+func Go(service any, fn func()) {
+	go func() {
+		defer Recover(service)
+		labels := pprof.Labels(ServiceLabel, Name(service))
+		pprof.Do(context.Background(), labels, func(context.Context) {
+			fn()
+		})
+	}()
+}
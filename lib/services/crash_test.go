@@ -0,0 +1,89 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package services
+
+import (
+	"bytes"
+	"runtime/pprof"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// crash is a crash passed to a testCrashHandler
+type crash struct {
+	service   string
+	recovered any
+	stack     []byte
+}
+
+// testCrashHandler sends the crashes it handles on its channel
+type testCrashHandler chan crash
+
+func (h testCrashHandler) HandleCrash(service string, recovered any, stack []byte) {
+	h <- crash{service: service, recovered: recovered, stack: stack}
+}
+
+func TestName(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "services.goroutineService", Name(&goroutineService{}))
+	require.Equal(t, "services.testCrashHandler", Name(testCrashHandler(nil)))
+}
+
+// the crash handler being global, the tests setting it are not run in parallel
+func TestRecover(t *testing.T) {
+	handler := make(testCrashHandler, 1)
+	SetCrashHandler(handler)
+	defer SetCrashHandler(nil)
+
+	func() {
+		defer Recover(&goroutineService{})
+		panic("test panic")
+	}()
+
+	crash := <-handler
+	assert.Equal(t, "services.goroutineService", crash.service)
+	assert.Equal(t, "test panic", crash.recovered)
+	assert.Contains(t, string(crash.stack), "TestRecover")
+
+	// no panic is not a crash
+	func() {
+		defer Recover(&goroutineService{})
+	}()
+	assert.Empty(t, handler)
+}
+
+func TestRecover_noHandler(t *testing.T) {
+	assert.PanicsWithValue(t, "test panic", func() {
+		defer Recover(&goroutineService{})
+		panic("test panic")
+	})
+}
+
+func TestGo(t *testing.T) {
+	handler := make(testCrashHandler, 1)
+	SetCrashHandler(handler)
+	defer SetCrashHandler(nil)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	Go(&goroutineService{}, func() {
+		close(started)
+		<-release
+		panic("test panic")
+	})
+	<-started
+
+	var profile bytes.Buffer
+	err := pprof.Lookup("goroutine").WriteTo(&profile, 1)
+	require.NoError(t, err)
+	assert.Contains(t, profile.String(), `# labels: {"service":"services.goroutineService"}`)
+
+	close(release)
+	crash := <-handler
+	assert.Equal(t, "services.goroutineService", crash.service)
+	assert.Equal(t, "test panic", crash.recovered)
+}
@@ -4,9 +4,22 @@
 package services
 
 import (
+	"context"
+	"fmt"
 	"reflect"
+	"runtime/pprof"
+	"strings"
+	"time"
 )
 
+// DefaultStopTimeout is the default duration a service is given to stop
+// before the registry moves on to stop the next services.
+const DefaultStopTimeout = 30 * time.Second
+
+// ServiceLabel is the pprof label set on the goroutines started by a service,
+// its value being the service type name such as sync.Service.
+const ServiceLabel = "service"
+
 // Service must be implemented by all services.
 // Defines the lifecycle methods Start and Stop for services.
 type Service interface {
@@ -25,14 +38,19 @@ type Pausable interface {
 type ServiceRegistry struct {
 	services     map[reflect.Type]Service // map of types to service instances
 	serviceTypes []reflect.Type           // all known service types, used to iterate through services
-	logger       Logger                   // Logger for logging service operations.
+	// dependencies maps a service type to the types of the services it depends on
+	dependencies map[reflect.Type][]reflect.Type
+	stopTimeout  time.Duration
+	logger       Logger // Logger for logging service operations.
 }
 
 // NewServiceRegistry creates an empty registry and return it as a pointer.
 func NewServiceRegistry(logger Logger) *ServiceRegistry {
 	return &ServiceRegistry{
-		services: make(map[reflect.Type]Service),
-		logger:   logger,
+		services:     make(map[reflect.Type]Service),
+		dependencies: make(map[reflect.Type][]reflect.Type),
+		stopTimeout:  DefaultStopTimeout,
+		logger:       logger,
 	}
 }
 
@@ -56,12 +74,17 @@ func (s *ServiceRegistry) RegisterService(service Service) {
 }
 
 // StartAll calls Service.Start() for all registered services.
-// The services are started in the order they were registered.
+// The services are started in the order they were registered, and the goroutines
+// they start are labelled with the service name to group them in goroutine profiles.
 func (s *ServiceRegistry) StartAll() {
 	s.logger.Infof("Starting services: %v", s.serviceTypes)
 	for _, typ := range s.serviceTypes {
 		s.logger.Debugf("Starting service %s", typ)
-		err := s.services[typ].Start()
+		var err error
+		labels := pprof.Labels(ServiceLabel, strings.TrimPrefix(typ.String(), "*"))
+		pprof.Do(context.Background(), labels, func(context.Context) {
+			err = s.services[typ].Start()
+		})
 		if err != nil {
 			s.logger.Errorf("Cannot start service %s: %s", typ, err)
 		}
@@ -69,6 +92,21 @@ func (s *ServiceRegistry) StartAll() {
 	s.logger.Debug("All services started.")
 }
 
+// DependsOn declares the service given depends on the dependencies given, so that it is
+// stopped before them. Nil dependencies, such as disabled services, are ignored.
+func (s *ServiceRegistry) DependsOn(service Service, dependencies ...Service) {
+	kind := reflect.TypeOf(service)
+	for _, dependency := range dependencies {
+		if dependency == nil {
+			continue
+		}
+		if value := reflect.ValueOf(dependency); value.Kind() == reflect.Ptr && value.IsNil() {
+			continue
+		}
+		s.dependencies[kind] = append(s.dependencies[kind], reflect.TypeOf(dependency))
+	}
+}
+
 // PauseServices pauses key services before shutdown to allow a graceful shutdown.
 // Only services that implement the Pausable interface will be paused.
 func (s *ServiceRegistry) PauseServices() {
@@ -90,14 +128,17 @@ func (s *ServiceRegistry) PauseServices() {
 
 // StopAll calls Service.Stop() for all registered services.
 // Before stopping, it pauses the services if they implement the Pausable interface.
+// A service is only stopped once all the services depending on it are stopped, and
+// services taking longer than the stop timeout to stop are left behind.
 func (s *ServiceRegistry) StopAll() {
-	s.logger.Infof("Stopping services: %v", s.serviceTypes)
+	stopOrder := s.stopOrder()
+	s.logger.Infof("Stopping services: %v", stopOrder)
 
 	s.PauseServices()
 
-	for _, typ := range s.serviceTypes {
+	for _, typ := range stopOrder {
 		s.logger.Debugf("Stopping service %s", typ)
-		err := s.services[typ].Stop()
+		err := s.stopService(s.services[typ])
 		if err != nil {
 			s.logger.Errorf("Error stopping service %s: %s", typ, err)
 		}
@@ -105,6 +146,79 @@ func (s *ServiceRegistry) StopAll() {
 	s.logger.Debug("All services stopped.")
 }
 
+// stopService stops the service given, returning an error if it does not stop
+// within the stop timeout.
+func (s *ServiceRegistry) stopService(service Service) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- service.Stop()
+	}()
+
+	timer := time.NewTimer(s.stopTimeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		return fmt.Errorf("timed out after %s", s.stopTimeout)
+	}
+}
+
+// stopOrder returns the registered service types in the order they should be stopped.
+// Each service is given a depth, being 0 if no service depends on it, or one more than
+// the highest depth of the services depending on it. Services are stopped by increasing
+// depth, and in registration order for services of the same depth.
+func (s *ServiceRegistry) stopOrder() (stopOrder []reflect.Type) {
+	dependents := make(map[reflect.Type][]reflect.Type, len(s.serviceTypes))
+	for _, typ := range s.serviceTypes {
+		for _, dependency := range s.dependencies[typ] {
+			dependents[dependency] = append(dependents[dependency], typ)
+		}
+	}
+
+	depths := make(map[reflect.Type]int, len(s.serviceTypes))
+	visiting := make(map[reflect.Type]bool, len(s.serviceTypes))
+	var depth func(typ reflect.Type) int
+	depth = func(typ reflect.Type) int {
+		if d, ok := depths[typ]; ok {
+			return d
+		}
+		if visiting[typ] {
+			// break dependency cycles, the registration order decides
+			return 0
+		}
+		visiting[typ] = true
+
+		d := 0
+		for _, dependent := range dependents[typ] {
+			if _, registered := s.services[dependent]; !registered {
+				continue
+			}
+			d = max(d, depth(dependent)+1)
+		}
+
+		visiting[typ] = false
+		depths[typ] = d
+		return d
+	}
+
+	maxDepth := 0
+	for _, typ := range s.serviceTypes {
+		maxDepth = max(maxDepth, depth(typ))
+	}
+
+	stopOrder = make([]reflect.Type, 0, len(s.serviceTypes))
+	for d := 0; d <= maxDepth; d++ {
+		for _, typ := range s.serviceTypes {
+			if depths[typ] == d {
+				stopOrder = append(stopOrder, typ)
+			}
+		}
+	}
+	return stopOrder
+}
+
 // Get retrieves a service and stores a reference to it in the passed in `srvc`
 func (s *ServiceRegistry) Get(srvc interface{}) Service {
 	if reflect.TypeOf(srvc).Kind() != reflect.Ptr {
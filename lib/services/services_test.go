@@ -4,8 +4,11 @@
 package services
 
 import (
+	"bytes"
 	"io"
+	"runtime/pprof"
 	"testing"
+	"time"
 
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/stretchr/testify/require"
@@ -34,6 +37,34 @@ func TestServiceRegistry_StartStopAll(t *testing.T) {
 	r.StopAll()
 }
 
+// goroutineService starts a goroutine running until the service is stopped.
+type goroutineService struct {
+	stop chan struct{}
+}
+
+func (s *goroutineService) Start() error {
+	go func() { <-s.stop }()
+	return nil
+}
+
+func (s *goroutineService) Stop() error {
+	close(s.stop)
+	return nil
+}
+
+func TestServiceRegistry_StartAll_goroutineLabels(t *testing.T) {
+	r := NewServiceRegistry(log.New(log.SetWriter(io.Discard)))
+	r.RegisterService(&goroutineService{stop: make(chan struct{})})
+
+	r.StartAll()
+	defer r.StopAll()
+
+	var profile bytes.Buffer
+	err := pprof.Lookup("goroutine").WriteTo(&profile, 1)
+	require.NoError(t, err)
+	require.Contains(t, profile.String(), `# labels: {"service":"services.goroutineService"}`)
+}
+
 func TestServiceRegistry_Get_Err(t *testing.T) {
 	r := NewServiceRegistry(log.New(log.SetWriter(io.Discard)))
 
@@ -45,3 +76,65 @@ func TestServiceRegistry_Get_Err(t *testing.T) {
 	f := struct{}{}
 	require.Nil(t, r.Get(f))
 }
+
+// recordingService records its name when stopped, each kind
+// of service being a different type for the registry.
+type recordingService[Kind any] struct {
+	name      string
+	stopped   *[]string
+	stopDelay time.Duration
+}
+
+func (*recordingService[Kind]) Start() error { return nil }
+
+func (s *recordingService[Kind]) Stop() error {
+	time.Sleep(s.stopDelay)
+	*s.stopped = append(*s.stopped, s.name)
+	return nil
+}
+
+type rpcKind struct{}
+type networkKind struct{}
+type syncKind struct{}
+type stateKind struct{}
+
+func TestServiceRegistry_StopAll_dependencyOrder(t *testing.T) {
+	r := NewServiceRegistry(log.New(log.SetWriter(io.Discard)))
+
+	var stopped []string
+	state := &recordingService[stateKind]{name: "state", stopped: &stopped}
+	network := &recordingService[networkKind]{name: "network", stopped: &stopped}
+	sync := &recordingService[syncKind]{name: "sync", stopped: &stopped}
+	rpc := &recordingService[rpcKind]{name: "rpc", stopped: &stopped}
+
+	r.RegisterService(state)
+	r.RegisterService(sync)
+	r.RegisterService(network)
+	r.RegisterService(rpc)
+
+	var disabled *recordingService[rpcKind]
+	r.DependsOn(rpc, network, sync, state, disabled)
+	r.DependsOn(network, sync, state)
+	r.DependsOn(sync, state)
+
+	r.StopAll()
+
+	require.Equal(t, []string{"rpc", "network", "sync", "state"}, stopped)
+}
+
+func TestServiceRegistry_StopAll_timeout(t *testing.T) {
+	r := NewServiceRegistry(log.New(log.SetWriter(io.Discard)))
+	r.stopTimeout = 10 * time.Millisecond
+
+	var stopped []string
+	slow := &recordingService[syncKind]{name: "slow", stopped: &stopped, stopDelay: time.Hour}
+	state := &recordingService[stateKind]{name: "state", stopped: &stopped}
+
+	r.RegisterService(slow)
+	r.RegisterService(state)
+	r.DependsOn(slow, state)
+
+	r.StopAll()
+
+	require.Equal(t, []string{"state"}, stopped)
+}
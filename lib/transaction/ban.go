@@ -0,0 +1,172 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package transaction
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+)
+
+const (
+	// DefaultBanDuration is the default duration an extrinsic stays banned
+	DefaultBanDuration = 30 * time.Minute
+	// DefaultBanStrikes is the default number of failures after which an extrinsic is banned
+	DefaultBanStrikes = 3
+
+	// maxBanEntries is the maximum number of extrinsics tracked, the expired entries being
+	// pruned and then the least struck entries evicted once it is reached
+	maxBanEntries = 4096
+)
+
+type banEntry struct {
+	strikes uint
+	banned  bool
+	// expiry is the time at which the ban is lifted, or the strikes forgotten
+	expiry time.Time
+}
+
+// BanList tracks the extrinsics failing validation or block building, and bans the
+// extrinsics failing repeatedly from re-entering the pool for a duration.
+// The failures of an extrinsic are forgotten after the same duration without failure.
+type BanList struct {
+	duration time.Duration
+	strikes  uint
+	now      func() time.Time
+
+	mutex   sync.Mutex
+	entries map[common.Hash]*banEntry
+}
+
+// NewBanList returns a ban list banning the extrinsics for the duration given once they
+// failed the number of strikes given. A zero duration disables banning.
+func NewBanList(duration time.Duration, strikes uint) *BanList {
+	if strikes == 0 {
+		strikes = 1
+	}
+	return &BanList{
+		duration: duration,
+		strikes:  strikes,
+		now:      time.Now,
+		entries:  make(map[common.Hash]*banEntry),
+	}
+}
+
+// SetDuration sets the duration of the bans and of the failures tracked.
+func (b *BanList) SetDuration(duration time.Duration) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.duration = duration
+	if duration == 0 {
+		b.entries = make(map[common.Hash]*banEntry)
+	}
+}
+
+// Strike records a failure of the extrinsic hash given, banning it once it reaches the
+// number of strikes. It returns true if the extrinsic is banned.
+func (b *BanList) Strike(hash common.Hash) (banned bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.duration == 0 {
+		return false
+	}
+
+	now := b.now()
+	entry := b.entry(hash, now)
+	if entry.banned {
+		return true
+	}
+
+	entry.strikes++
+	entry.expiry = now.Add(b.duration)
+	if entry.strikes >= b.strikes {
+		entry.banned = true
+	}
+	return entry.banned
+}
+
+// Ban bans the extrinsic hash given regardless of its failures.
+func (b *BanList) Ban(hash common.Hash) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.duration == 0 {
+		return
+	}
+
+	now := b.now()
+	entry := b.entry(hash, now)
+	entry.banned = true
+	entry.expiry = now.Add(b.duration)
+}
+
+// IsBanned returns true if the extrinsic hash given is currently banned.
+func (b *BanList) IsBanned(hash common.Hash) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	entry, ok := b.entries[hash]
+	if !ok {
+		return false
+	}
+	if !b.now().Before(entry.expiry) {
+		delete(b.entries, hash)
+		return false
+	}
+	return entry.banned
+}
+
+// entry returns the unexpired entry of the hash, creating it if needed.
+// It must be called with the mutex held.
+func (b *BanList) entry(hash common.Hash, now time.Time) *banEntry {
+	entry, ok := b.entries[hash]
+	if ok && now.Before(entry.expiry) {
+		return entry
+	}
+
+	if !ok && len(b.entries) >= maxBanEntries {
+		for h, e := range b.entries {
+			if !now.Before(e.expiry) {
+				delete(b.entries, h)
+			}
+		}
+
+		if len(b.entries) >= maxBanEntries {
+			b.evict()
+		}
+	}
+
+	entry = &banEntry{}
+	b.entries[hash] = entry
+	return entry
+}
+
+// evict removes the entry evicted first, an unbanned entry before a banned one,
+// the entry with the fewest strikes first, and the entry expiring first otherwise.
+// It must be called with the mutex held.
+func (b *BanList) evict() {
+	var evictedHash common.Hash
+	var evicted *banEntry
+	for hash, entry := range b.entries {
+		if evicted == nil || evictedBefore(entry, evicted) {
+			evictedHash, evicted = hash, entry
+		}
+	}
+	delete(b.entries, evictedHash)
+}
+
+// evictedBefore returns true if the entry a is evicted before the entry b.
+func evictedBefore(a, b *banEntry) bool {
+	switch {
+	case a.banned != b.banned:
+		return !a.banned
+	case a.strikes != b.strikes:
+		return a.strikes < b.strikes
+	default:
+		return a.expiry.Before(b.expiry)
+	}
+}
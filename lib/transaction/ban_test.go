@@ -0,0 +1,82 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package transaction
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBanList(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	bans := NewBanList(time.Minute, 2)
+	bans.now = func() time.Time { return now }
+
+	hashA := common.Hash{1}
+	hashB := common.Hash{2}
+
+	assert.False(t, bans.Strike(hashA))
+	assert.False(t, bans.IsBanned(hashA))
+	assert.True(t, bans.Strike(hashA))
+	assert.True(t, bans.IsBanned(hashA))
+	assert.False(t, bans.IsBanned(hashB))
+
+	// the ban is lifted after its duration
+	now = now.Add(time.Minute)
+	assert.False(t, bans.IsBanned(hashA))
+	assert.False(t, bans.Strike(hashA))
+
+	// the strikes are forgotten after the duration
+	now = now.Add(time.Minute)
+	assert.False(t, bans.Strike(hashA))
+	assert.False(t, bans.IsBanned(hashA))
+
+	bans.Ban(hashB)
+	assert.True(t, bans.IsBanned(hashB))
+
+	bans.SetDuration(0)
+	assert.False(t, bans.IsBanned(hashB))
+	assert.False(t, bans.Strike(hashA))
+	assert.False(t, bans.Strike(hashA))
+	bans.Ban(hashA)
+	assert.False(t, bans.IsBanned(hashA))
+}
+
+func TestBanList_maxEntries(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	bans := NewBanList(time.Minute, 2)
+	bans.now = func() time.Time { return now }
+
+	banned := common.Hash{1}
+	bans.Ban(banned)
+	struck := common.Hash{2}
+	bans.Strike(struck)
+
+	// fill the list with extrinsics struck once and expiring after the struck one
+	now = now.Add(time.Second)
+	for i := 0; len(bans.entries) < maxBanEntries; i++ {
+		bans.Strike(common.Hash{0xff, byte(i >> 8), byte(i)})
+	}
+
+	// the struck extrinsic expiring first is evicted
+	bans.Strike(common.Hash{3})
+	assert.Len(t, bans.entries, maxBanEntries)
+	assert.NotContains(t, bans.entries, struck)
+	assert.Contains(t, bans.entries, common.Hash{3})
+	assert.True(t, bans.IsBanned(banned))
+
+	// the struck extrinsics are evicted before the banned one
+	for i := 0; i < maxBanEntries; i++ {
+		bans.Strike(common.Hash{0xfe, byte(i >> 8), byte(i)})
+	}
+	assert.Len(t, bans.entries, maxBanEntries)
+	assert.True(t, bans.IsBanned(banned))
+}
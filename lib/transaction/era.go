@@ -0,0 +1,169 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package transaction
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+const (
+	extrinsicFormatVersion = 4
+	signedExtrinsicBit     = 0x80
+)
+
+var (
+	// ErrInvalidEra is returned when the era of an extrinsic has an invalid period or phase
+	ErrInvalidEra = errors.New("invalid extrinsic era")
+
+	errUnsupportedExtrinsicVersion = errors.New("unsupported extrinsic version")
+	errUnknownAddressType          = errors.New("unknown address type")
+	errUnknownSignatureType        = errors.New("unknown signature type")
+)
+
+// Era is the mortality of an extrinsic, see
+// https://github.com/paritytech/polkadot-sdk/blob/master/substrate/primitives/runtime/src/generic/era.rs
+// The zero value is the immortal era.
+type Era struct {
+	// Period is the number of blocks the extrinsic is valid for, 0 for an immortal extrinsic
+	Period uint64
+	// Phase is the block number modulo the period of the first block the extrinsic is valid at
+	Phase uint64
+}
+
+// Immortal returns true if the extrinsic never expires
+func (e Era) Immortal() bool {
+	return e.Period == 0
+}
+
+// Birth returns the number of the first block of the mortality window containing the current
+// block number given.
+func (e Era) Birth(current uint64) uint64 {
+	if e.Immortal() {
+		return 0
+	}
+	return (max(current, e.Phase)-e.Phase)/e.Period*e.Period + e.Phase
+}
+
+// Death returns the number of the first block the extrinsic is no longer valid at, for the
+// mortality window containing the current block number given.
+func (e Era) Death(current uint64) uint64 {
+	if e.Immortal() {
+		return ^uint64(0)
+	}
+	return e.Birth(current) + e.Period
+}
+
+// DecodeEra decodes the era of a SCALE encoded extrinsic of format version 4.
+// An unsigned extrinsic is immortal. The era is expected right after the signature,
+// as the first field of the signed extensions, which is the case of the Substrate based
+// runtimes using the CheckMortality extension.
+func DecodeEra(ext types.Extrinsic) (era Era, err error) {
+	reader := bytes.NewReader(ext)
+	decoder := scale.NewDecoder(reader)
+
+	var length uint
+	err = decoder.Decode(&length)
+	if err != nil {
+		return era, fmt.Errorf("decoding length: %w", err)
+	}
+
+	version, err := reader.ReadByte()
+	if err != nil {
+		return era, fmt.Errorf("reading version: %w", err)
+	}
+	if version&^signedExtrinsicBit != extrinsicFormatVersion {
+		return era, fmt.Errorf("%w: %d", errUnsupportedExtrinsicVersion, version&^signedExtrinsicBit)
+	}
+	if version&signedExtrinsicBit == 0 {
+		return era, nil
+	}
+
+	err = skipAddress(reader, decoder)
+	if err != nil {
+		return era, fmt.Errorf("decoding address: %w", err)
+	}
+
+	err = skipSignature(reader)
+	if err != nil {
+		return era, fmt.Errorf("decoding signature: %w", err)
+	}
+
+	return decodeEra(reader)
+}
+
+// skipAddress skips the MultiAddress of the signer
+func skipAddress(reader *bytes.Reader, decoder *scale.Decoder) error {
+	addressType, err := reader.ReadByte()
+	if err != nil {
+		return err
+	}
+
+	switch addressType {
+	case 0, 3: // account id, 32 bytes address
+		return skip(reader, 32)
+	case 1: // account index
+		var index uint
+		return decoder.Decode(&index)
+	case 2: // raw
+		var raw []byte
+		return decoder.Decode(&raw)
+	case 4: // 20 bytes address
+		return skip(reader, 20)
+	default:
+		return fmt.Errorf("%w: %d", errUnknownAddressType, addressType)
+	}
+}
+
+// skipSignature skips the MultiSignature of the extrinsic
+func skipSignature(reader *bytes.Reader) error {
+	signatureType, err := reader.ReadByte()
+	if err != nil {
+		return err
+	}
+
+	switch signatureType {
+	case 0, 1: // ed25519, sr25519
+		return skip(reader, 64)
+	case 2: // ecdsa
+		return skip(reader, 65)
+	default:
+		return fmt.Errorf("%w: %d", errUnknownSignatureType, signatureType)
+	}
+}
+
+func skip(reader *bytes.Reader, n int64) error {
+	_, err := io.CopyN(io.Discard, reader, n)
+	return err
+}
+
+func decodeEra(reader *bytes.Reader) (era Era, err error) {
+	first, err := reader.ReadByte()
+	if err != nil {
+		return era, fmt.Errorf("reading era: %w", err)
+	}
+	if first == 0 {
+		return era, nil
+	}
+
+	second, err := reader.ReadByte()
+	if err != nil {
+		return era, fmt.Errorf("reading era: %w", err)
+	}
+
+	encoded := uint64(first) | uint64(second)<<8
+	era.Period = 2 << (encoded % (1 << 4))
+	quantizeFactor := max(era.Period>>12, 1)
+	era.Phase = (encoded >> 4) * quantizeFactor
+	if era.Period < 4 || era.Phase >= era.Period {
+		return Era{}, fmt.Errorf("%w: period %d and phase %d", ErrInvalidEra, era.Period, era.Phase)
+	}
+
+	return era, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package transaction
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSignedExtrinsic(t *testing.T, era []byte) types.Extrinsic {
+	t.Helper()
+
+	address := append([]byte{0}, make([]byte, 32)...)
+	signature := append([]byte{1}, make([]byte, 64)...)
+	nonceAndTip := []byte{0, 0}
+	call := []byte{0, 1}
+	body := bytes.Join([][]byte{{0x84}, address, signature, era, nonceAndTip, call}, nil)
+
+	encoded, err := scale.Marshal(body)
+	require.NoError(t, err)
+	return encoded
+}
+
+func TestDecodeEra(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		ext         types.Extrinsic
+		era         Era
+		errWrapped  error
+		errMessage  string
+		expectError bool
+	}{
+		"unsigned": {
+			ext: scale.MustMarshal([]byte{0x04, 0, 1}),
+		},
+		"immortal": {
+			ext: newSignedExtrinsic(t, []byte{0}),
+		},
+		"mortal": {
+			ext: newSignedExtrinsic(t, []byte{0xa5, 0x02}),
+			era: Era{Period: 64, Phase: 42},
+		},
+		"invalid_period": {
+			ext:        newSignedExtrinsic(t, []byte{0x10, 0x00}),
+			errWrapped: ErrInvalidEra,
+			errMessage: "invalid extrinsic era: period 2 and phase 1",
+		},
+		"unsupported_version": {
+			ext:        scale.MustMarshal([]byte{0x85, 0, 1}),
+			errWrapped: errUnsupportedExtrinsicVersion,
+			errMessage: "unsupported extrinsic version: 5",
+		},
+		"truncated": {
+			ext:         scale.MustMarshal([]byte{0x84, 0}),
+			expectError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			era, err := DecodeEra(testCase.ext)
+
+			assert.Equal(t, testCase.era, era)
+			if testCase.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+			}
+		})
+	}
+}
+
+func TestEra_BirthDeath(t *testing.T) {
+	t.Parallel()
+
+	era := Era{Period: 64, Phase: 42}
+	assert.Equal(t, uint64(42), era.Birth(10))
+	assert.Equal(t, uint64(106), era.Death(10))
+	assert.Equal(t, uint64(42), era.Birth(105))
+	assert.Equal(t, uint64(106), era.Birth(106))
+	assert.Equal(t, uint64(170), era.Death(106))
+
+	immortal := Era{}
+	assert.True(t, immortal.Immortal())
+	assert.Equal(t, uint64(0), immortal.Birth(10))
+	assert.Equal(t, ^uint64(0), immortal.Death(10))
+}
@@ -32,6 +32,14 @@ func NewValidity(priority uint64, requires, provides [][]byte, longevity uint64,
 type ValidTransaction struct {
 	Extrinsic types.Extrinsic
 	Validity  *Validity
+	// ValidTill is the number of the first block the transaction is no longer valid at,
+	// its mortality window being expired, or 0 if the transaction does not expire
+	ValidTill uint
+}
+
+// Expired returns true if the transaction is no longer valid at the block number given
+func (vt *ValidTransaction) Expired(number uint) bool {
+	return vt.ValidTill != 0 && number >= vt.ValidTill
 }
 
 // NewValidTransaction returns ValidTransaction
@@ -54,8 +54,9 @@ func NewLRUCache[K comparable, V any](capacity uint) *LRUCache[K, V] {
 
 // Get retrieves the value associated with the given key from the cache.
 func (c *LRUCache[K, V]) Get(key K) V {
-	c.RLock()
-	defer c.RUnlock()
+	// moving the element to the front mutates the list, so a read lock is not enough
+	c.Lock()
+	defer c.Unlock()
 
 	if elem, exists := c.cache[key]; exists {
 		c.lruList.MoveToFront(elem)
@@ -0,0 +1,210 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package client provides a light client which can be embedded in Go applications
+// to follow a chain and read its state trustlessly, without running a full node.
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync/atomic"
+
+	cfg "github.com/ChainSafe/gossamer/config"
+	"github.com/ChainSafe/gossamer/dot"
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/genesis"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+)
+
+var ErrNotStarted = errors.New("light client is not started")
+
+// Option is a functional option to configure the light client
+type Option func(config *cfg.Config)
+
+// WithBasePath sets the directory where the light client stores the headers it synced.
+func WithBasePath(basePath string) Option {
+	return func(config *cfg.Config) {
+		config.BasePath = basePath
+	}
+}
+
+// WithBootnodes sets the bootnodes to connect to, instead of the ones of the chain spec.
+func WithBootnodes(bootnodes []string) Option {
+	return func(config *cfg.Config) {
+		config.Network.Bootnodes = bootnodes
+	}
+}
+
+// WithPort sets the network port the light client listens on.
+func WithPort(port uint16) Option {
+	return func(config *cfg.Config) {
+		config.Network.Port = port
+	}
+}
+
+// WithLogLevel sets the log level of the services of the light client.
+func WithLogLevel(level string) Option {
+	return func(config *cfg.Config) {
+		config.LogLevel = level
+		config.Log = &cfg.LogConfig{
+			Core:    level,
+			Digest:  level,
+			Sync:    level,
+			Network: level,
+			RPC:     level,
+			State:   level,
+			Runtime: level,
+			Babe:    level,
+			Grandpa: level,
+			Wasmer:  level,
+		}
+	}
+}
+
+// LightClient is a light client following the chain of the chain spec it is created
+// with. It only syncs and stores the block headers, whose finality is verified using
+// the GRANDPA justifications, and reads the state from its peers by verifying their
+// proofs against the state root of the headers.
+type LightClient struct {
+	node       *dot.Node
+	blockState *state.BlockState
+	network    *network.Service
+	started    atomic.Bool
+}
+
+// NewLightClient creates a light client for the chain described by the chain spec file given.
+// The light client state is initialised on the first run, and resumed on the following ones.
+func NewLightClient(chainSpec string, opts ...Option) (*LightClient, error) {
+	gen, err := genesis.NewGenesisFromJSONRaw(chainSpec)
+	if err != nil {
+		return nil, fmt.Errorf("loading chain spec: %w", err)
+	}
+
+	config := cfg.DefaultConfigFromSpec(gen)
+	config.ChainSpec = chainSpec
+	config.BasePath = filepath.Join(config.BasePath, gen.ID+"-light")
+	config.Account.Key = ""
+	config.Core.Role = common.LightClientRole
+	config.Core.BabeAuthority = false
+	config.Core.GrandpaAuthority = false
+	config.RPC = &cfg.RPCConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	err = cfg.EnsureRoot(config.BasePath)
+	if err != nil {
+		return nil, fmt.Errorf("creating base path: %w", err)
+	}
+
+	node, err := dot.NewNode(config, keystore.NewGlobalKeystore())
+	if err != nil {
+		return nil, fmt.Errorf("creating node: %w", err)
+	}
+
+	stateSrvc, ok := node.ServiceRegistry.Get(&state.Service{}).(*state.Service)
+	if !ok {
+		return nil, errors.New("state service not found")
+	}
+
+	networkSrvc, ok := node.ServiceRegistry.Get(&network.Service{}).(*network.Service)
+	if !ok {
+		return nil, errors.New("network service not found")
+	}
+
+	return &LightClient{
+		node:       node,
+		blockState: stateSrvc.Block,
+		network:    networkSrvc,
+	}, nil
+}
+
+// Start starts syncing the chain.
+func (c *LightClient) Start() {
+	c.node.ServiceRegistry.StartAll()
+	c.started.Store(true)
+}
+
+// Stop stops the light client services.
+func (c *LightClient) Stop() {
+	c.started.Store(false)
+	c.node.ServiceRegistry.StopAll()
+}
+
+// SubscribeFinalizedHeaders returns a channel receiving the headers of the blocks
+// finalised from now on. The channel is closed once the context given is done.
+func (c *LightClient) SubscribeFinalizedHeaders(ctx context.Context) <-chan *types.Header {
+	finalised := c.blockState.GetFinalisedNotifierChannel()
+	headers := make(chan *types.Header)
+
+	go func() {
+		defer close(headers)
+		defer c.blockState.FreeFinalisedNotifierChannel(finalised)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case info, ok := <-finalised:
+				if !ok {
+					return
+				}
+
+				header := info.Header
+				select {
+				case headers <- &header:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return headers
+}
+
+// Call calls the runtime function given with the SCALE encoded parameters given at
+// the block given, and returns its SCALE encoded result. Since the light client only
+// stores the runtime code, the runtime function must not read the storage.
+func (c *LightClient) Call(blockHash common.Hash, method string, params []byte) ([]byte, error) {
+	rt, err := c.blockState.GetRuntime(blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting runtime: %w", err)
+	}
+
+	result, err := rt.Exec(method, params)
+	if err != nil {
+		return nil, fmt.Errorf("executing %s: %w", method, err)
+	}
+
+	return result, nil
+}
+
+// GetStorageWithProof returns the value at the given key in the state of the block given,
+// and the encoded trie nodes proving it against the state root of the block header.
+// The value is read from the peers using the remote read request of the light protocol.
+// A nil value is returned if the proof shows the key is absent from the state.
+func (c *LightClient) GetStorageWithProof(blockHash common.Hash, key []byte) (
+	value []byte, encodedProofNodes [][]byte, err error) {
+	if !c.started.Load() {
+		return nil, nil, ErrNotStarted
+	}
+
+	header, err := c.blockState.GetHeader(blockHash)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting header: %w", err)
+	}
+
+	value, encodedProofNodes, err = c.network.ReadRemoteStorage(blockHash, header.StateRoot, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading remote storage: %w", err)
+	}
+
+	return value, encodedProofNodes, nil
+}
@@ -0,0 +1,254 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package extrinsic
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// AccountID is the 32 bytes identifier of an account
+type AccountID [32]byte
+
+// NewAccountID returns the account identifier of the public key given, the public key itself
+// for the sr25519 and ed25519 keys and the blake2b hash of the compressed public key for the
+// secp256k1 keys.
+func NewAccountID(public crypto.PublicKey) AccountID {
+	encoded := public.Encode()
+	if len(encoded) == len(AccountID{}) {
+		return AccountID(encoded)
+	}
+	return AccountID(common.MustBlake2bHash(encoded))
+}
+
+// Address is the Id variant of MultiAddress, an account identifier
+type Address AccountID
+
+// AddressIndex is the Index variant of MultiAddress, the index of an account in the Indices
+// pallet
+type AddressIndex uint32
+
+// MarshalSCALE encodes the account index as a compact integer
+func (a AddressIndex) MarshalSCALE() ([]byte, error) {
+	return scale.NewCompact(uint32(a)).MarshalSCALE()
+}
+
+// UnmarshalSCALE decodes the account index from a compact integer
+func (a *AddressIndex) UnmarshalSCALE(reader io.Reader) error {
+	var index scale.Compact[uint32]
+	err := index.UnmarshalSCALE(reader)
+	if err != nil {
+		return err
+	}
+	*a = AddressIndex(index.Value)
+	return nil
+}
+
+// AddressRaw is the Raw variant of MultiAddress, an address of any format
+type AddressRaw []byte
+
+// Address32 is the Address32 variant of MultiAddress, a 32 bytes address
+type Address32 [32]byte
+
+// Address20 is the Address20 variant of MultiAddress, a 20 bytes address such as an Ethereum
+// address
+type Address20 [20]byte
+
+type MultiAddressValues interface {
+	Address | AddressIndex | AddressRaw | Address32 | Address20
+}
+
+// MultiAddress is the address format of the signers of the extrinsics and of the accounts
+// given to the calls of the substrate runtimes
+type MultiAddress struct {
+	inner any
+}
+
+func setMultiAddress[Value MultiAddressValues](mvdt *MultiAddress, value Value) {
+	mvdt.inner = value
+}
+
+func (mvdt *MultiAddress) SetValue(value any) (err error) {
+	switch value := value.(type) {
+	case Address:
+		setMultiAddress(mvdt, value)
+		return
+
+	case AddressIndex:
+		setMultiAddress(mvdt, value)
+		return
+
+	case AddressRaw:
+		setMultiAddress(mvdt, value)
+		return
+
+	case Address32:
+		setMultiAddress(mvdt, value)
+		return
+
+	case Address20:
+		setMultiAddress(mvdt, value)
+		return
+
+	default:
+		return fmt.Errorf("unsupported type")
+	}
+}
+
+func (mvdt MultiAddress) IndexValue() (index uint, value any, err error) {
+	switch mvdt.inner.(type) {
+	case Address:
+		return 0, mvdt.inner, nil
+
+	case AddressIndex:
+		return 1, mvdt.inner, nil
+
+	case AddressRaw:
+		return 2, mvdt.inner, nil
+
+	case Address32:
+		return 3, mvdt.inner, nil
+
+	case Address20:
+		return 4, mvdt.inner, nil
+
+	}
+	return 0, nil, scale.ErrUnsupportedVaryingDataTypeValue
+}
+
+func (mvdt MultiAddress) Value() (value any, err error) {
+	_, value, err = mvdt.IndexValue()
+	return
+}
+
+func (mvdt MultiAddress) ValueAt(index uint) (value any, err error) {
+	switch index {
+	case 0:
+		return *new(Address), nil
+
+	case 1:
+		return *new(AddressIndex), nil
+
+	case 2:
+		return *new(AddressRaw), nil
+
+	case 3:
+		return *new(Address32), nil
+
+	case 4:
+		return *new(Address20), nil
+
+	}
+	return nil, scale.ErrUnknownVaryingDataTypeValue
+}
+
+// NewMultiAddress returns the multi address of the value given
+func NewMultiAddress[Value MultiAddressValues](value Value) MultiAddress {
+	mvdt := MultiAddress{}
+	setMultiAddress(&mvdt, value)
+	return mvdt
+}
+
+// NewMultiAddressID returns the Id multi address of the account of the public key given
+func NewMultiAddressID(public crypto.PublicKey) MultiAddress {
+	return NewMultiAddress(Address(NewAccountID(public)))
+}
+
+// Ed25519Signature is the Ed25519 variant of MultiSignature
+type Ed25519Signature [64]byte
+
+// Sr25519Signature is the Sr25519 variant of MultiSignature
+type Sr25519Signature [64]byte
+
+// EcdsaSignature is the Ecdsa variant of MultiSignature, a secp256k1 signature followed by its
+// recovery identifier
+type EcdsaSignature [65]byte
+
+type MultiSignatureValues interface {
+	Ed25519Signature | Sr25519Signature | EcdsaSignature
+}
+
+// MultiSignature is the signature format of the signed extrinsics of the substrate runtimes
+type MultiSignature struct {
+	inner any
+}
+
+func setMultiSignature[Value MultiSignatureValues](mvdt *MultiSignature, value Value) {
+	mvdt.inner = value
+}
+
+func (mvdt *MultiSignature) SetValue(value any) (err error) {
+	switch value := value.(type) {
+	case Ed25519Signature:
+		setMultiSignature(mvdt, value)
+		return
+
+	case Sr25519Signature:
+		setMultiSignature(mvdt, value)
+		return
+
+	case EcdsaSignature:
+		setMultiSignature(mvdt, value)
+		return
+
+	default:
+		return fmt.Errorf("unsupported type")
+	}
+}
+
+func (mvdt MultiSignature) IndexValue() (index uint, value any, err error) {
+	switch mvdt.inner.(type) {
+	case Ed25519Signature:
+		return 0, mvdt.inner, nil
+
+	case Sr25519Signature:
+		return 1, mvdt.inner, nil
+
+	case EcdsaSignature:
+		return 2, mvdt.inner, nil
+
+	}
+	return 0, nil, scale.ErrUnsupportedVaryingDataTypeValue
+}
+
+func (mvdt MultiSignature) Value() (value any, err error) {
+	_, value, err = mvdt.IndexValue()
+	return
+}
+
+func (mvdt MultiSignature) ValueAt(index uint) (value any, err error) {
+	switch index {
+	case 0:
+		return *new(Ed25519Signature), nil
+
+	case 1:
+		return *new(Sr25519Signature), nil
+
+	case 2:
+		return *new(EcdsaSignature), nil
+
+	}
+	return nil, scale.ErrUnknownVaryingDataTypeValue
+}
+
+// newMultiSignature returns the multi signature of the signature given, made with a key of
+// the type given.
+func newMultiSignature(keyType crypto.KeyType, signature []byte) (MultiSignature, error) {
+	mvdt := MultiSignature{}
+	switch {
+	case keyType == crypto.Ed25519Type && len(signature) == len(Ed25519Signature{}):
+		setMultiSignature(&mvdt, Ed25519Signature(signature))
+	case keyType == crypto.Sr25519Type && len(signature) == len(Sr25519Signature{}):
+		setMultiSignature(&mvdt, Sr25519Signature(signature))
+	case keyType == crypto.Secp256k1Type && len(signature) == len(EcdsaSignature{}):
+		setMultiSignature(&mvdt, EcdsaSignature(signature))
+	default:
+		return mvdt, fmt.Errorf("%w: %s signature of %d bytes", ErrUnsupportedSigner, keyType, len(signature))
+	}
+	return mvdt, nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package extrinsic
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// Call is a call of a pallet of the runtime, with its SCALE encoded arguments
+type Call struct {
+	PalletIndex uint8
+	CallIndex   uint8
+	Args        []byte
+}
+
+// MarshalSCALE encodes the call, so calls can be given as the arguments of other calls
+func (c Call) MarshalSCALE() ([]byte, error) {
+	return append([]byte{c.PalletIndex, c.CallIndex}, c.Args...), nil
+}
+
+// NewCall returns the call of the name given, in the Pallet.call format such as
+// Balances.transfer_keep_alive, with the arguments given SCALE encoded in order. The
+// indices of the pallet and of the call are looked up in the metadata.
+func (b *Builder) NewCall(name string, args ...any) (Call, error) {
+	pallet, variant, err := b.callVariant(name)
+	if err != nil {
+		return Call{}, err
+	}
+
+	if len(args) != len(variant.Fields) {
+		names := make([]string, len(variant.Fields))
+		for i, field := range variant.Fields {
+			names[i] = field.Name
+		}
+		return Call{}, fmt.Errorf("%w: %s takes %d arguments (%s), %d given", ErrInvalidArguments,
+			name, len(variant.Fields), strings.Join(names, ", "), len(args))
+	}
+
+	call := Call{PalletIndex: pallet.Index, CallIndex: variant.Index}
+	for i, arg := range args {
+		encoded, err := scale.Marshal(arg)
+		if err != nil {
+			return Call{}, fmt.Errorf("encoding argument %s of %s: %w", variant.Fields[i].Name, name, err)
+		}
+		call.Args = append(call.Args, encoded...)
+	}
+	return call, nil
+}
+
+// callVariant returns the pallet and the variant of the call type of the pallet of the call
+// of the name given, in the Pallet.call format.
+func (b *Builder) callVariant(name string) (*scale.Pallet, *scale.TypeVariant, error) {
+	palletName, callName, ok := strings.Cut(name, ".")
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s is not in the Pallet.call format", ErrCallNotFound, name)
+	}
+
+	pallet, err := b.registry.Pallet(palletName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if pallet.CallType == nil {
+		return nil, nil, fmt.Errorf("%w: pallet %s has no calls", ErrCallNotFound, palletName)
+	}
+	calls, err := b.registry.Type(*pallet.CallType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i := range calls.Variants {
+		if calls.Variants[i].Name == callName {
+			return pallet, &calls.Variants[i], nil
+		}
+	}
+	return nil, nil, fmt.Errorf("%w: %s", ErrCallNotFound, name)
+}
+
+// DecodeCall returns the call of the SCALE encoded call given, such as a call encoded by
+// another tool to wrap in a multisig or proxy call.
+func DecodeCall(encoded []byte) (Call, error) {
+	if len(encoded) < 2 {
+		return Call{}, fmt.Errorf("%w: %d bytes are too short for a call", ErrInvalidCall, len(encoded))
+	}
+	return Call{PalletIndex: encoded[0], CallIndex: encoded[1], Args: encoded[2:]}, nil
+}
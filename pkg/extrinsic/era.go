@@ -0,0 +1,119 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package extrinsic
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+)
+
+const (
+	minEraPeriod = 4
+	maxEraPeriod = 1 << 16
+)
+
+// Era is the period of blocks an extrinsic is valid for, starting at the block it is born
+// at. The zero value is the immortal era, an extrinsic of the immortal era being valid for
+// ever.
+type Era struct {
+	// Period is the number of blocks the extrinsic is valid for, a power of two between 4
+	// and 65536, zero for the immortal era
+	Period uint64
+	// Phase is the number of the block the extrinsic is born at modulo the period
+	Phase uint64
+}
+
+// NewMortalEra returns the mortal era of the extrinsics born at the current block number
+// given and valid for at least the period given, rounded up to the next power of two.
+func NewMortalEra(current, period uint64) Era {
+	switch {
+	case period > maxEraPeriod/2:
+		period = maxEraPeriod
+	case period < minEraPeriod:
+		period = minEraPeriod
+	default:
+		period = 1 << bits.Len64(period-1)
+	}
+
+	quantizeFactor := eraQuantizeFactor(period)
+	phase := current % period / quantizeFactor * quantizeFactor
+	return Era{Period: period, Phase: phase}
+}
+
+// eraQuantizeFactor is the factor the phases of the eras of long periods are a multiple of,
+// so their phase can be encoded in 12 bits
+func eraQuantizeFactor(period uint64) uint64 {
+	return max(period>>12, 1)
+}
+
+// IsImmortal returns true if the era is the immortal era
+func (e Era) IsImmortal() bool {
+	return e.Period == 0
+}
+
+// Birth returns the number of the block the extrinsics of the era are born at, for the
+// current block number given. The extrinsics of the immortal era are born at the genesis.
+func (e Era) Birth(current uint64) uint64 {
+	if e.IsImmortal() {
+		return 0
+	}
+	return (max(current, e.Phase)-e.Phase)/e.Period*e.Period + e.Phase
+}
+
+// Death returns the number of the first block the extrinsics of the era are no longer valid
+// at, for the current block number given.
+func (e Era) Death(current uint64) uint64 {
+	if e.IsImmortal() {
+		return math.MaxUint64
+	}
+	return e.Birth(current) + e.Period
+}
+
+// MarshalSCALE encodes the era, in one byte for the immortal era and in two bytes holding
+// the period and the quantized phase for the mortal eras.
+func (e Era) MarshalSCALE() ([]byte, error) {
+	if e.IsImmortal() {
+		return []byte{0}, nil
+	}
+	if e.Period < minEraPeriod || e.Period > maxEraPeriod || bits.OnesCount64(e.Period) != 1 ||
+		e.Phase >= e.Period {
+		return nil, fmt.Errorf("%w: period %d and phase %d", ErrInvalidEra, e.Period, e.Phase)
+	}
+
+	quantizeFactor := eraQuantizeFactor(e.Period)
+	encoded := uint16(min(max(bits.TrailingZeros64(e.Period)-1, 1), 15)) |
+		uint16(e.Phase/quantizeFactor)<<4
+	return binary.LittleEndian.AppendUint16(nil, encoded), nil
+}
+
+// UnmarshalSCALE decodes an encoded era
+func (e *Era) UnmarshalSCALE(reader io.Reader) error {
+	first := make([]byte, 1)
+	_, err := io.ReadFull(reader, first)
+	if err != nil {
+		return err
+	}
+	if first[0] == 0 {
+		*e = Era{}
+		return nil
+	}
+
+	second := make([]byte, 1)
+	_, err = io.ReadFull(reader, second)
+	if err != nil {
+		return err
+	}
+	encoded := uint64(binary.LittleEndian.Uint16([]byte{first[0], second[0]}))
+
+	period := uint64(2) << (encoded % 16)
+	phase := (encoded >> 4) * eraQuantizeFactor(period)
+	if period < minEraPeriod || phase >= period {
+		return fmt.Errorf("%w: 0x%04x", ErrInvalidEra, encoded)
+	}
+	*e = Era{Period: period, Phase: phase}
+	return nil
+}
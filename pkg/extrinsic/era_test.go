@@ -0,0 +1,110 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package extrinsic
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMortalEra(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		current uint64
+		period  uint64
+		era     Era
+		encoded []byte
+	}{
+		"period_64": {
+			current: 42,
+			period:  64,
+			era:     Era{Period: 64, Phase: 42},
+			encoded: []byte{0xa5, 0x02},
+		},
+		"period_rounded_up": {
+			current: 100,
+			period:  50,
+			era:     Era{Period: 64, Phase: 36},
+			encoded: []byte{0x45, 0x02},
+		},
+		"minimum_period": {
+			current: 6,
+			period:  1,
+			era:     Era{Period: 4, Phase: 2},
+			encoded: []byte{0x21, 0x00},
+		},
+		"quantized_phase": {
+			current: 20000,
+			period:  32768,
+			era:     Era{Period: 32768, Phase: 20000},
+			encoded: []byte{0x4e, 0x9c},
+		},
+		"maximum_period": {
+			current: 70001,
+			period:  100000,
+			era:     Era{Period: 65536, Phase: 4464},
+			encoded: []byte{0x7f, 0x11},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			era := NewMortalEra(testCase.current, testCase.period)
+			assert.Equal(t, testCase.era, era)
+
+			encoded, err := scale.Marshal(era)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.encoded, encoded)
+
+			var decoded Era
+			err = scale.Unmarshal(encoded, &decoded)
+			require.NoError(t, err)
+			assert.Equal(t, era, decoded)
+		})
+	}
+}
+
+func TestEra_lifetime(t *testing.T) {
+	t.Parallel()
+
+	era := NewMortalEra(6, 4)
+	for current := uint64(6); current < 10; current++ {
+		assert.Equal(t, uint64(6), era.Birth(current))
+		assert.Equal(t, uint64(10), era.Death(current))
+	}
+	assert.Equal(t, uint64(10), era.Birth(10))
+	assert.Equal(t, uint64(2), era.Birth(0))
+
+	immortal := Era{}
+	assert.True(t, immortal.IsImmortal())
+	assert.Equal(t, uint64(0), immortal.Birth(100))
+	assert.Equal(t, uint64(math.MaxUint64), immortal.Death(100))
+
+	encoded, err := scale.Marshal(immortal)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0}, encoded)
+}
+
+func TestEra_invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := scale.Marshal(Era{Period: 48, Phase: 1})
+	assert.ErrorIs(t, err, ErrInvalidEra)
+
+	_, err = scale.Marshal(Era{Period: 64, Phase: 64})
+	assert.ErrorIs(t, err, ErrInvalidEra)
+
+	var era Era
+	// period 4 and phase 5
+	err = scale.Unmarshal([]byte{0x51, 0x00}, &era)
+	assert.ErrorIs(t, err, ErrInvalidEra)
+}
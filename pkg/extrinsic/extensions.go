@@ -0,0 +1,316 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package extrinsic
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// metadataHashMode is the mode of the CheckMetadataHash signed extension
+type metadataHashMode uint8
+
+const (
+	metadataHashDisabled metadataHashMode = iota
+	metadataHashEnabled
+)
+
+// The names of the values of the signed extensions in their configuration
+const (
+	// ValueEra is the era of the options
+	ValueEra = "era"
+	// ValueNonce is the compact encoded nonce of the options
+	ValueNonce = "nonce"
+	// ValueTip is the compact encoded tip of the options
+	ValueTip = "tip"
+	// ValueSpecVersion is the runtime specification version of the options
+	ValueSpecVersion = "spec_version"
+	// ValueTransactionVersion is the transaction version of the options
+	ValueTransactionVersion = "transaction_version"
+	// ValueGenesisHash is the genesis hash of the options
+	ValueGenesisHash = "genesis_hash"
+	// ValueBlockHash is the hash of the block the era of the options is born at
+	ValueBlockHash = "block_hash"
+	// ValueMetadataHashMode is the mode of the metadata hash check, enabled if the options
+	// have a metadata hash
+	ValueMetadataHashMode = "metadata_hash_mode"
+	// ValueMetadataHash is the optional metadata hash of the options
+	ValueMetadataHash = "metadata_hash"
+	// ValueNone is the None option, such as the asset identifier of the fees paid in the
+	// native asset
+	ValueNone = "none"
+	// ValueCustomPrefix prefixes the names of the custom values of the options, the value
+	// custom.asset_id being the value asset_id of the custom values
+	ValueCustomPrefix = "custom."
+)
+
+// SignedExtension returns the values of the extra data and of the additional signed data of
+// a signed extension, SCALE encoded one after the other.
+type SignedExtension interface {
+	Values(options Options) (extra, additionalSigned []any, err error)
+}
+
+// SignedExtensionFunc is a function implementing the SignedExtension interface
+type SignedExtensionFunc func(options Options) (extra, additionalSigned []any, err error)
+
+// Values returns the values of the extra data and of the additional signed data of the
+// signed extension.
+func (f SignedExtensionFunc) Values(options Options) (extra, additionalSigned []any, err error) {
+	return f(options)
+}
+
+// ExtensionConfig is the configuration of a signed extension, declaring the values of its
+// extra data and of its additional signed data by name, or the registered extension it is
+// encoded as.
+type ExtensionConfig struct {
+	// Identifier is the identifier of the signed extension in the metadata
+	Identifier string `mapstructure:"identifier"`
+	// As is the identifier of the registered signed extension the extension is encoded as,
+	// for the extensions renamed by a chain. The values are ignored if it is set.
+	As string `mapstructure:"as"`
+	// Extra are the names of the values of the extra data
+	Extra []string `mapstructure:"extra"`
+	// AdditionalSigned are the names of the values of the additional signed data
+	AdditionalSigned []string `mapstructure:"additional-signed"`
+}
+
+// defaultExtensions are the signed extensions of the substrate frame pallets
+var defaultExtensions = []ExtensionConfig{
+	{Identifier: "CheckNonZeroSender"},
+	{Identifier: "CheckWeight"},
+	{Identifier: "PrevalidateAttests"},
+	{Identifier: "StorageWeightReclaim"},
+	{Identifier: "CheckSpecVersion", AdditionalSigned: []string{ValueSpecVersion}},
+	{Identifier: "CheckTxVersion", AdditionalSigned: []string{ValueTransactionVersion}},
+	{Identifier: "CheckGenesis", AdditionalSigned: []string{ValueGenesisHash}},
+	{Identifier: "CheckMortality", Extra: []string{ValueEra}, AdditionalSigned: []string{ValueBlockHash}},
+	{Identifier: "CheckEra", As: "CheckMortality"},
+	{Identifier: "CheckNonce", Extra: []string{ValueNonce}},
+	{Identifier: "ChargeTransactionPayment", Extra: []string{ValueTip}},
+	// the fees are paid in the native asset, the asset identifier being None
+	{Identifier: "ChargeAssetTxPayment", Extra: []string{ValueTip, ValueNone}},
+	{Identifier: "CheckMetadataHash", Extra: []string{ValueMetadataHashMode},
+		AdditionalSigned: []string{ValueMetadataHash}},
+}
+
+// Extensions is the registry of the signed extensions known to a builder, by their
+// identifier in the metadata.
+type Extensions struct {
+	extensions map[string]SignedExtension
+}
+
+// NewExtensions returns a registry of the signed extensions of the substrate frame pallets,
+// to which the extensions of a chain can be added.
+func NewExtensions() *Extensions {
+	extensions := &Extensions{extensions: make(map[string]SignedExtension)}
+	err := extensions.Configure(defaultExtensions...)
+	if err != nil {
+		panic(fmt.Sprintf("configuring default signed extensions: %s", err))
+	}
+	return extensions
+}
+
+// Register registers the signed extension of the identifier given, replacing the extension
+// registered before with the same identifier.
+func (e *Extensions) Register(identifier string, extension SignedExtension) {
+	e.extensions[identifier] = extension
+}
+
+// Lookup returns the signed extension of the identifier given, and false if it is not
+// registered.
+func (e *Extensions) Lookup(identifier string) (extension SignedExtension, ok bool) {
+	extension, ok = e.extensions[identifier]
+	return extension, ok
+}
+
+// Identifiers returns the sorted identifiers of the registered signed extensions
+func (e *Extensions) Identifiers() []string {
+	identifiers := make([]string, 0, len(e.extensions))
+	for identifier := range e.extensions {
+		identifiers = append(identifiers, identifier)
+	}
+	sort.Strings(identifiers)
+	return identifiers
+}
+
+// Configure registers the signed extensions of the configurations given, in order, so a
+// configuration can refer to the extensions configured before it.
+func (e *Extensions) Configure(configs ...ExtensionConfig) error {
+	for _, config := range configs {
+		if config.Identifier == "" {
+			return fmt.Errorf("%w: empty identifier", ErrInvalidExtensionConfig)
+		}
+
+		if config.As != "" {
+			extension, ok := e.Lookup(config.As)
+			if !ok {
+				return fmt.Errorf("%w: %s is encoded as %s which is not registered",
+					ErrInvalidExtensionConfig, config.Identifier, config.As)
+			}
+			e.Register(config.Identifier, extension)
+			continue
+		}
+
+		for _, names := range [][]string{config.Extra, config.AdditionalSigned} {
+			for _, name := range names {
+				if !isValueName(name) {
+					return fmt.Errorf("%w: unknown value %q of %s", ErrInvalidExtensionConfig, name, config.Identifier)
+				}
+			}
+		}
+		e.Register(config.Identifier, configuredExtension(config))
+	}
+	return nil
+}
+
+// configuredExtension is a signed extension declared by its configuration
+type configuredExtension ExtensionConfig
+
+// Values returns the values of the extra data and of the additional signed data named by the
+// configuration of the extension.
+func (c configuredExtension) Values(options Options) (extra, additionalSigned []any, err error) {
+	extra, err = namedValues(c.Identifier, c.Extra, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	additionalSigned, err = namedValues(c.Identifier, c.AdditionalSigned, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	return extra, additionalSigned, nil
+}
+
+// isValueName returns true if the name given is the name of a value of the options
+func isValueName(name string) bool {
+	if custom, ok := strings.CutPrefix(name, ValueCustomPrefix); ok {
+		return custom != ""
+	}
+	switch name {
+	case ValueEra, ValueNonce, ValueTip, ValueSpecVersion, ValueTransactionVersion, ValueGenesisHash,
+		ValueBlockHash, ValueMetadataHashMode, ValueMetadataHash, ValueNone:
+		return true
+	default:
+		return false
+	}
+}
+
+// namedValues returns the values of the options of the names given
+func namedValues(identifier string, names []string, options Options) (values []any, err error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	values = make([]any, len(names))
+	for i, name := range names {
+		switch name {
+		case ValueEra:
+			values[i] = options.Era
+		case ValueNonce:
+			values[i] = scale.NewCompact(options.Nonce)
+		case ValueTip:
+			values[i] = scale.NewCompact(options.Tip)
+		case ValueSpecVersion:
+			values[i] = options.SpecVersion
+		case ValueTransactionVersion:
+			values[i] = options.TransactionVersion
+		case ValueGenesisHash:
+			values[i] = options.GenesisHash
+		case ValueBlockHash:
+			values[i] = options.BlockHash
+		case ValueMetadataHashMode:
+			values[i] = metadataHashDisabled
+			if options.MetadataHash != nil {
+				values[i] = metadataHashEnabled
+			}
+		case ValueMetadataHash:
+			values[i] = options.MetadataHash
+		case ValueNone:
+			// the None option is encoded as the zero byte whatever the type of its value
+			values[i] = (*uint32)(nil)
+		default:
+			custom := strings.TrimPrefix(name, ValueCustomPrefix)
+			value, ok := options.Custom[custom]
+			if !ok {
+				return nil, fmt.Errorf("%w: custom value %s of %s", ErrMissingExtensionValue, custom, identifier)
+			}
+			values[i] = value
+		}
+	}
+	return values, nil
+}
+
+// encodeExtensions returns the SCALE encoded extra data and additional signed data of the
+// signed extensions of the runtime, in their order in the metadata. The extensions which
+// are not registered are only supported if both their extra and additional signed data are
+// empty.
+func (b *Builder) encodeExtensions(options Options) (extra, additionalSigned []byte, err error) {
+	for _, extension := range b.registry.SignedExtensions() {
+		signedExtension, ok := b.extensions.Lookup(extension.Identifier)
+		if !ok {
+			if !b.isEmptyType(extension.Type, 0) || !b.isEmptyType(extension.AdditionalSigned, 0) {
+				return nil, nil, fmt.Errorf("%w: %s", ErrUnsupportedSignedExtension, extension.Identifier)
+			}
+			continue
+		}
+
+		extraValues, additionalValues, err := signedExtension.Values(options)
+		if err != nil {
+			return nil, nil, fmt.Errorf("getting values of %s: %w", extension.Identifier, err)
+		}
+		for _, value := range extraValues {
+			encoded, err := scale.Marshal(value)
+			if err != nil {
+				return nil, nil, fmt.Errorf("encoding extra data of %s: %w", extension.Identifier, err)
+			}
+			extra = append(extra, encoded...)
+		}
+		for _, value := range additionalValues {
+			encoded, err := scale.Marshal(value)
+			if err != nil {
+				return nil, nil, fmt.Errorf("encoding additional signed data of %s: %w", extension.Identifier, err)
+			}
+			additionalSigned = append(additionalSigned, encoded...)
+		}
+	}
+	return extra, additionalSigned, nil
+}
+
+// maxTypeDepth bounds the nesting of the types checked by isEmptyType
+const maxTypeDepth = 16
+
+// isEmptyType returns true if the values of the type given are encoded as zero bytes, such
+// as the unit type () or a struct without fields.
+func (b *Builder) isEmptyType(typeID uint32, depth int) bool {
+	if depth > maxTypeDepth {
+		return false
+	}
+	typeInfo, err := b.registry.Type(typeID)
+	if err != nil {
+		return false
+	}
+
+	switch typeInfo.Kind {
+	case scale.TypeDefComposite:
+		for _, field := range typeInfo.Fields {
+			if !b.isEmptyType(field.Type, depth+1) {
+				return false
+			}
+		}
+		return true
+	case scale.TypeDefTuple:
+		for _, elem := range typeInfo.Tuple {
+			if !b.isEmptyType(elem, depth+1) {
+				return false
+			}
+		}
+		return true
+	case scale.TypeDefArray:
+		return typeInfo.Len == 0 || b.isEmptyType(typeInfo.Elem, depth+1)
+	default:
+		return false
+	}
+}
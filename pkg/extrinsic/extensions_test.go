@@ -0,0 +1,169 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package extrinsic
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtensions_Configure(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		configs    []ExtensionConfig
+		identifier string
+		extra      []any
+		additional []any
+		errWrapped error
+		errMessage string
+	}{
+		"alias": {
+			configs:    []ExtensionConfig{{Identifier: "CheckNonceWithSender", As: "CheckNonce"}},
+			identifier: "CheckNonceWithSender",
+			extra:      []any{scale.NewCompact(uint64(5))},
+		},
+		"values": {
+			configs: []ExtensionConfig{{
+				Identifier:       "ChargeAssetTxPayment",
+				Extra:            []string{ValueTip, ValueCustomPrefix + "asset_id"},
+				AdditionalSigned: []string{ValueGenesisHash},
+			}},
+			identifier: "ChargeAssetTxPayment",
+			extra:      []any{scale.NewCompact(uint64(1000)), uint32(1984)},
+			additional: []any{common.Hash{1}},
+		},
+		"alias_of_configured": {
+			configs: []ExtensionConfig{
+				{Identifier: "CheckCustom", Extra: []string{ValueSpecVersion}},
+				{Identifier: "CheckCustomRenamed", As: "CheckCustom"},
+			},
+			identifier: "CheckCustomRenamed",
+			extra:      []any{uint32(9430)},
+		},
+		"empty_identifier": {
+			configs:    []ExtensionConfig{{Extra: []string{ValueNonce}}},
+			errWrapped: ErrInvalidExtensionConfig,
+			errMessage: "invalid signed extension configuration: empty identifier",
+		},
+		"unknown_alias": {
+			configs:    []ExtensionConfig{{Identifier: "CheckCustom", As: "CheckUnknown"}},
+			errWrapped: ErrInvalidExtensionConfig,
+			errMessage: "invalid signed extension configuration: " +
+				"CheckCustom is encoded as CheckUnknown which is not registered",
+		},
+		"unknown_value": {
+			configs:    []ExtensionConfig{{Identifier: "CheckCustom", AdditionalSigned: []string{"weight"}}},
+			errWrapped: ErrInvalidExtensionConfig,
+			errMessage: "invalid signed extension configuration: unknown value \"weight\" of CheckCustom",
+		},
+		"empty_custom_value": {
+			configs:    []ExtensionConfig{{Identifier: "CheckCustom", Extra: []string{ValueCustomPrefix}}},
+			errWrapped: ErrInvalidExtensionConfig,
+			errMessage: "invalid signed extension configuration: unknown value \"custom.\" of CheckCustom",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			extensions := NewExtensions()
+			err := extensions.Configure(testCase.configs...)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			if testCase.errWrapped != nil {
+				assert.EqualError(t, err, testCase.errMessage)
+				return
+			}
+
+			extension, ok := extensions.Lookup(testCase.identifier)
+			require.True(t, ok)
+			options := testOptions()
+			options.Custom = map[string]any{"asset_id": uint32(1984)}
+			extra, additional, err := extension.Values(options)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.extra, extra)
+			assert.Equal(t, testCase.additional, additional)
+		})
+	}
+}
+
+func TestExtensions_Identifiers(t *testing.T) {
+	t.Parallel()
+
+	extensions := NewExtensions()
+	extensions.Register("CheckCustom", SignedExtensionFunc(func(Options) (extra, additional []any, err error) {
+		return nil, nil, nil
+	}))
+
+	assert.Equal(t, []string{
+		"ChargeAssetTxPayment",
+		"ChargeTransactionPayment",
+		"CheckCustom",
+		"CheckEra",
+		"CheckGenesis",
+		"CheckMetadataHash",
+		"CheckMortality",
+		"CheckNonZeroSender",
+		"CheckNonce",
+		"CheckSpecVersion",
+		"CheckTxVersion",
+		"CheckWeight",
+		"PrevalidateAttests",
+		"StorageWeightReclaim",
+	}, extensions.Identifiers())
+}
+
+func TestBuilder_Signed_customExtension(t *testing.T) {
+	t.Parallel()
+	b := testMetadataBuilder{t: t}
+
+	metadata := newTestMetadata(t,
+		b.extension("CheckNonce", testTypeCompactU32, testTypeEmptyTuple),
+		b.extension("CheckCustom", testTypeU32, testTypeEmptyTuple),
+		b.extension("CheckFailing", testTypeU32, testTypeEmptyTuple),
+	)
+	registry, err := scale.NewRegistry(metadata)
+	require.NoError(t, err)
+
+	call := Call{PalletIndex: 0, CallIndex: 0, Args: []byte{0}}
+	keypair, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+	options := testOptions()
+	options.Custom = map[string]any{"value": uint32(7)}
+
+	extensions := NewExtensions()
+	err = extensions.Configure(ExtensionConfig{Identifier: "CheckCustom", Extra: []string{"custom.value"}})
+	require.NoError(t, err)
+	errTest := errors.New("test error")
+	extensions.Register("CheckFailing", SignedExtensionFunc(func(Options) (extra, additional []any, err error) {
+		return nil, nil, errTest
+	}))
+
+	builder, err := NewBuilder(registry, WithExtensions(extensions))
+	require.NoError(t, err)
+	_, err = builder.Signed(call, keypair, options)
+	assert.ErrorIs(t, err, errTest)
+	assert.EqualError(t, err, "getting values of CheckFailing: test error")
+
+	extensions.Register("CheckFailing", SignedExtensionFunc(func(options Options) (extra, additional []any, err error) {
+		return []any{options.SpecVersion}, nil, nil
+	}))
+	payload, err := builder.Payload(call, options)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 0, 0, 5 << 2, 7, 0, 0, 0, 0xd6, 0x24, 0, 0}, payload)
+
+	delete(options.Custom, "value")
+	_, err = builder.Payload(call, options)
+	assert.ErrorIs(t, err, ErrMissingExtensionValue)
+	assert.EqualError(t, err, "getting values of CheckCustom: missing signed extension value: "+
+		"custom value value of CheckCustom")
+}
@@ -0,0 +1,182 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package extrinsic builds the extrinsics of the substrate runtimes from their metadata: the
+// calls are indexed with the pallets and calls of the metadata, and the signed extensions
+// are encoded in the order of the metadata by the signed extensions registered to the
+// builder, which the chains with custom extensions can add to.
+package extrinsic
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// extrinsicVersion is the version of the extrinsics format supported
+const extrinsicVersion = 4
+
+// signedBit is set in the version byte of the signed extrinsics
+const signedBit = 0x80
+
+// maxUnhashedPayloadLength is the length above which the signing payload is hashed
+const maxUnhashedPayloadLength = 256
+
+var (
+	ErrUnsupportedExtrinsicVersion = errors.New("unsupported extrinsic version")
+	ErrUnsupportedSignedExtension  = errors.New("unsupported signed extension")
+	ErrUnsupportedSigner           = errors.New("unsupported signer")
+	ErrCallNotFound                = errors.New("call not found in metadata")
+	ErrInvalidArguments            = errors.New("invalid call arguments")
+	ErrInvalidCall                 = errors.New("invalid call")
+	ErrInvalidThreshold            = errors.New("invalid multisig threshold")
+	ErrDuplicateSignatory          = errors.New("duplicate multisig signatory")
+	ErrSenderNotSignatory          = errors.New("sender is not a multisig signatory")
+	ErrInvalidEra                  = errors.New("invalid era")
+	ErrInvalidExtensionConfig      = errors.New("invalid signed extension configuration")
+	ErrMissingExtensionValue       = errors.New("missing signed extension value")
+)
+
+// Signer signs the extrinsics, such as the sr25519, ed25519 and secp256k1 keypairs
+type Signer interface {
+	Sign(msg []byte) ([]byte, error)
+	Public() crypto.PublicKey
+	Type() crypto.KeyType
+}
+
+// Options are the values of the signed extensions of a signed extrinsic
+type Options struct {
+	Era                Era
+	Nonce              uint64
+	Tip                uint64
+	SpecVersion        uint32
+	TransactionVersion uint32
+	GenesisHash        common.Hash
+	// BlockHash is the hash of the block the era is born at, the genesis hash for the
+	// immortal era
+	BlockHash common.Hash
+	// MetadataHash is the merkleized metadata hash checked by the CheckMetadataHash signed
+	// extension, the check being disabled if it is nil
+	MetadataHash *common.Hash
+	// Custom are the values of the custom signed extensions, such as an asset identifier,
+	// named custom.<name> in the configuration of the extensions
+	Custom map[string]any
+}
+
+// Builder builds the extrinsics of a runtime from the type registry of its metadata
+type Builder struct {
+	registry   *scale.Registry
+	extensions *Extensions
+}
+
+// BuilderOption is an option of the builder
+type BuilderOption func(b *Builder)
+
+// WithExtensions sets the registry of the signed extensions of the builder, for the chains
+// with custom signed extensions. It defaults to the signed extensions of the substrate frame
+// pallets.
+func WithExtensions(extensions *Extensions) BuilderOption {
+	return func(b *Builder) {
+		b.extensions = extensions
+	}
+}
+
+// NewBuilder returns a builder of the extrinsics of the runtime of the type registry given
+func NewBuilder(registry *scale.Registry, options ...BuilderOption) (*Builder, error) {
+	if registry.ExtrinsicVersion() != extrinsicVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedExtrinsicVersion, registry.ExtrinsicVersion())
+	}
+
+	builder := &Builder{registry: registry}
+	for _, option := range options {
+		option(builder)
+	}
+	if builder.extensions == nil {
+		builder.extensions = NewExtensions()
+	}
+	return builder, nil
+}
+
+// Payload returns the payload signed by the signer of a signed extrinsic: the call followed
+// by the extra data and the additional signed data of the signed extensions, hashed if it is
+// longer than 256 bytes.
+func (b *Builder) Payload(call Call, options Options) ([]byte, error) {
+	_, _, payload, err := b.payload(call, options)
+	return payload, err
+}
+
+// payload returns the encoded call and extra data of the signed extensions, with the
+// signing payload.
+func (b *Builder) payload(call Call, options Options) (encodedCall, extra, payload []byte, err error) {
+	extra, additionalSigned, err := b.encodeExtensions(options)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	encodedCall, err = call.MarshalSCALE()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	payload = make([]byte, 0, len(encodedCall)+len(extra)+len(additionalSigned))
+	payload = append(payload, encodedCall...)
+	payload = append(payload, extra...)
+	payload = append(payload, additionalSigned...)
+	if len(payload) > maxUnhashedPayloadLength {
+		hash := common.MustBlake2bHash(payload)
+		payload = hash[:]
+	}
+	return encodedCall, extra, payload, nil
+}
+
+// Signed returns the SCALE encoded extrinsic of the call given, signed by the signer given
+// with the signed extensions of the options given. The signer is given as the Id address of
+// its account.
+func (b *Builder) Signed(call Call, signer Signer, options Options) ([]byte, error) {
+	encodedCall, extra, msg, err := b.payload(call, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if signer.Type() == crypto.Secp256k1Type {
+		// the secp256k1 keys sign the hash of the payload
+		hash := common.MustBlake2bHash(msg)
+		msg = hash[:]
+	}
+	signature, err := signer.Sign(msg)
+	if err != nil {
+		return nil, fmt.Errorf("signing payload: %w", err)
+	}
+	multiSignature, err := newMultiSignature(signer.Type(), signature)
+	if err != nil {
+		return nil, err
+	}
+
+	address, err := scale.Marshal(NewMultiAddressID(signer.Public()))
+	if err != nil {
+		return nil, fmt.Errorf("encoding address: %w", err)
+	}
+	encodedSignature, err := scale.Marshal(multiSignature)
+	if err != nil {
+		return nil, fmt.Errorf("encoding signature: %w", err)
+	}
+
+	body := []byte{extrinsicVersion | signedBit}
+	body = append(body, address...)
+	body = append(body, encodedSignature...)
+	body = append(body, extra...)
+	body = append(body, encodedCall...)
+	return scale.Marshal(body)
+}
+
+// Unsigned returns the SCALE encoded unsigned extrinsic of the call given, such as the
+// inherents and the calls validated by the runtime without signature.
+func (*Builder) Unsigned(call Call) ([]byte, error) {
+	encodedCall, err := call.MarshalSCALE()
+	if err != nil {
+		return nil, err
+	}
+	return scale.Marshal(append([]byte{extrinsicVersion}, encodedCall...))
+}
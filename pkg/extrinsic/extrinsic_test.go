@@ -0,0 +1,467 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package extrinsic
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/crypto/secp256k1"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Type identifiers of the test metadata built by newTestMetadata
+const (
+	testTypeU8 uint32 = iota
+	testTypeU32
+	testTypeU16
+	testTypeBytes32
+	testTypeAccountID
+	testTypeU128
+	testTypeCompactU128
+	testTypeVecU8
+	testTypeMultiAddress
+	testTypeSystemCall
+	testTypeBalancesCall
+	testTypeEmptyTuple
+	testTypeH256
+	testTypeCompactU32
+	testTypeMode
+	testTypeOptionBytes32
+	testTypeUncheckedExtrinsic
+	testTypeEmptyStruct
+	testTypeMultisigCall
+	testTypeProxyCall
+)
+
+func testMarshal(t *testing.T, value any) []byte {
+	t.Helper()
+	encoded, err := scale.Marshal(value)
+	require.NoError(t, err)
+	return encoded
+}
+
+func testCompact(t *testing.T, value uint32) []byte {
+	t.Helper()
+	return testMarshal(t, scale.NewCompact(value))
+}
+
+func testConcat(parts ...[]byte) []byte {
+	return bytes.Join(parts, nil)
+}
+
+// testMetadataBuilder SCALE encodes the parts of the runtime metadata
+type testMetadataBuilder struct {
+	t *testing.T
+}
+
+func (b testMetadataBuilder) list(items ...[]byte) []byte {
+	return testConcat(testCompact(b.t, uint32(len(items))), testConcat(items...))
+}
+
+func (b testMetadataBuilder) field(name string, typeID uint32) []byte {
+	optionalName := []byte{0}
+	if name != "" {
+		optionalName = testConcat([]byte{1}, testMarshal(b.t, name))
+	}
+	return testConcat(optionalName, testCompact(b.t, typeID), []byte{0}, b.list())
+}
+
+func (b testMetadataBuilder) variant(name string, index uint8, fields ...[]byte) []byte {
+	return testConcat(testMarshal(b.t, name), b.list(fields...), []byte{index}, b.list())
+}
+
+func (b testMetadataBuilder) typ(id uint32, path []string, def []byte) []byte {
+	return testConcat(testCompact(b.t, id), testMarshal(b.t, path), b.list(), def, b.list())
+}
+
+func (b testMetadataBuilder) composite(fields ...[]byte) []byte {
+	return testConcat([]byte{byte(scale.TypeDefComposite)}, b.list(fields...))
+}
+
+func (b testMetadataBuilder) variants(variants ...[]byte) []byte {
+	return testConcat([]byte{byte(scale.TypeDefVariant)}, b.list(variants...))
+}
+
+func (b testMetadataBuilder) primitive(primitive scale.Primitive) []byte {
+	return []byte{byte(scale.TypeDefPrimitive), byte(primitive)}
+}
+
+func (b testMetadataBuilder) extension(identifier string, extra, additionalSigned uint32) []byte {
+	return testConcat(testMarshal(b.t, identifier), testCompact(b.t, extra), testCompact(b.t, additionalSigned))
+}
+
+// newTestMetadata returns a SCALE encoded runtime metadata of the version 14, with a System
+// pallet with a remark call, a Balances pallet with a transfer_keep_alive call, the Proxy
+// and Multisig pallets with their proxy and as_multi calls, and the signed extensions given.
+func newTestMetadata(t *testing.T, extensions ...[]byte) []byte {
+	t.Helper()
+	b := testMetadataBuilder{t: t}
+
+	types := [][]byte{
+		b.typ(testTypeU8, nil, b.primitive(scale.PrimitiveU8)),
+		b.typ(testTypeU32, nil, b.primitive(scale.PrimitiveU32)),
+		b.typ(testTypeU16, nil, b.primitive(scale.PrimitiveU16)),
+		b.typ(testTypeBytes32, nil, testConcat([]byte{byte(scale.TypeDefArray)},
+			binary.LittleEndian.AppendUint32(nil, 32), testCompact(t, testTypeU8))),
+		b.typ(testTypeAccountID, []string{"sp_core", "crypto", "AccountId32"},
+			b.composite(b.field("", testTypeBytes32))),
+		b.typ(testTypeU128, nil, b.primitive(scale.PrimitiveU128)),
+		b.typ(testTypeCompactU128, nil, testConcat([]byte{byte(scale.TypeDefCompact)},
+			testCompact(t, testTypeU128))),
+		b.typ(testTypeVecU8, nil, testConcat([]byte{byte(scale.TypeDefSequence)}, testCompact(t, testTypeU8))),
+		b.typ(testTypeMultiAddress, []string{"sp_runtime", "multiaddress", "MultiAddress"}, b.variants(
+			b.variant("Id", 0, b.field("", testTypeAccountID)),
+		)),
+		b.typ(testTypeSystemCall, []string{"frame_system", "pallet", "Call"}, b.variants(
+			b.variant("remark", 0, b.field("remark", testTypeVecU8)),
+		)),
+		b.typ(testTypeBalancesCall, []string{"pallet_balances", "pallet", "Call"}, b.variants(
+			b.variant("transfer_keep_alive", 3,
+				b.field("dest", testTypeMultiAddress),
+				b.field("value", testTypeCompactU128)),
+		)),
+		b.typ(testTypeEmptyTuple, nil, testConcat([]byte{byte(scale.TypeDefTuple)}, b.list())),
+		b.typ(testTypeH256, []string{"primitive_types", "H256"}, b.composite(b.field("", testTypeBytes32))),
+		b.typ(testTypeCompactU32, nil, testConcat([]byte{byte(scale.TypeDefCompact)}, testCompact(t, testTypeU32))),
+		b.typ(testTypeMode, []string{"frame_metadata_hash_extension", "Mode"}, b.variants(
+			b.variant("Disabled", 0),
+			b.variant("Enabled", 1),
+		)),
+		b.typ(testTypeOptionBytes32, []string{"Option"}, b.variants(
+			b.variant("None", 0),
+			b.variant("Some", 1, b.field("", testTypeBytes32)),
+		)),
+		b.typ(testTypeUncheckedExtrinsic, []string{"sp_runtime", "generic", "UncheckedExtrinsic"},
+			b.composite(b.field("", testTypeVecU8))),
+		b.typ(testTypeEmptyStruct, []string{"pallet", "CheckEmpty"},
+			b.composite(b.field("", testTypeEmptyTuple))),
+		// the types of the arguments of the calls of the multisig pallet are not checked by the builder
+		b.typ(testTypeMultisigCall, []string{"pallet_multisig", "pallet", "Call"}, b.variants(
+			b.variant("as_multi_threshold_1", 0,
+				b.field("other_signatories", testTypeVecU8),
+				b.field("call", testTypeVecU8)),
+			b.variant("as_multi", 1,
+				b.field("threshold", testTypeU16),
+				b.field("other_signatories", testTypeVecU8),
+				b.field("maybe_timepoint", testTypeVecU8),
+				b.field("call", testTypeVecU8),
+				b.field("max_weight", testTypeVecU8)),
+		)),
+		b.typ(testTypeProxyCall, []string{"pallet_proxy", "pallet", "Call"}, b.variants(
+			b.variant("proxy", 0,
+				b.field("real", testTypeMultiAddress),
+				b.field("force_proxy_type", testTypeVecU8),
+				b.field("call", testTypeVecU8)),
+		)),
+	}
+
+	pallets := [][]byte{
+		testConcat(testMarshal(t, "System"), []byte{0}, []byte{1}, testCompact(t, testTypeSystemCall),
+			[]byte{0}, b.list(), []byte{0}, []byte{0}),
+		testConcat(testMarshal(t, "Balances"), []byte{0}, []byte{1}, testCompact(t, testTypeBalancesCall),
+			[]byte{0}, b.list(), []byte{0}, []byte{5}),
+		testConcat(testMarshal(t, "Timestamp"), []byte{0}, []byte{0}, []byte{0}, b.list(), []byte{0}, []byte{3}),
+		testConcat(testMarshal(t, "Proxy"), []byte{0}, []byte{1}, testCompact(t, testTypeProxyCall),
+			[]byte{0}, b.list(), []byte{0}, []byte{29}),
+		testConcat(testMarshal(t, "Multisig"), []byte{0}, []byte{1}, testCompact(t, testTypeMultisigCall),
+			[]byte{0}, b.list(), []byte{0}, []byte{30}),
+	}
+
+	extrinsic := testConcat(testCompact(t, testTypeUncheckedExtrinsic), []byte{4}, b.list(extensions...))
+	return testConcat([]byte("meta"), []byte{14}, b.list(types...), b.list(pallets...), extrinsic,
+		testCompact(t, testTypeEmptyTuple))
+}
+
+// newTestBuilder returns a builder of the test metadata with the signed extensions of the
+// polkadot runtime.
+func newTestBuilder(t *testing.T) *Builder {
+	t.Helper()
+	b := testMetadataBuilder{t: t}
+
+	metadata := newTestMetadata(t,
+		b.extension("CheckNonZeroSender", testTypeEmptyTuple, testTypeEmptyTuple),
+		b.extension("CheckSpecVersion", testTypeEmptyTuple, testTypeU32),
+		b.extension("CheckTxVersion", testTypeEmptyTuple, testTypeU32),
+		b.extension("CheckGenesis", testTypeEmptyTuple, testTypeH256),
+		b.extension("CheckMortality", testTypeU16, testTypeH256),
+		b.extension("CheckNonce", testTypeCompactU32, testTypeEmptyTuple),
+		b.extension("CheckWeight", testTypeEmptyTuple, testTypeEmptyTuple),
+		b.extension("ChargeTransactionPayment", testTypeCompactU128, testTypeEmptyTuple),
+		b.extension("CheckEmpty", testTypeEmptyStruct, testTypeEmptyTuple),
+		b.extension("CheckMetadataHash", testTypeMode, testTypeOptionBytes32),
+	)
+	registry, err := scale.NewRegistry(metadata)
+	require.NoError(t, err)
+	builder, err := NewBuilder(registry)
+	require.NoError(t, err)
+	return builder
+}
+
+func testOptions() Options {
+	return Options{
+		Era:                NewMortalEra(42, 64),
+		Nonce:              5,
+		Tip:                1000,
+		SpecVersion:        9430,
+		TransactionVersion: 24,
+		GenesisHash:        common.Hash{1},
+		BlockHash:          common.Hash{2},
+	}
+}
+
+func TestBuilder_NewCall(t *testing.T) {
+	t.Parallel()
+	builder := newTestBuilder(t)
+
+	call, err := builder.NewCall("System.remark", []byte{0xab, 0xcd})
+	require.NoError(t, err)
+	assert.Equal(t, Call{PalletIndex: 0, CallIndex: 0, Args: []byte{8, 0xab, 0xcd}}, call)
+
+	dest := NewMultiAddress(Address{1, 2, 3})
+	call, err = builder.NewCall("Balances.transfer_keep_alive", dest, scale.NewCompact(uint64(12345)))
+	require.NoError(t, err)
+	assert.Equal(t, uint8(5), call.PalletIndex)
+	assert.Equal(t, uint8(3), call.CallIndex)
+	expectedArgs := testConcat([]byte{0, 1, 2, 3}, make([]byte, 29), testMarshal(t, scale.NewCompact(uint64(12345))))
+	assert.Equal(t, expectedArgs, call.Args)
+
+	encoded, err := scale.Marshal(call)
+	require.NoError(t, err)
+	assert.Equal(t, testConcat([]byte{5, 3}, expectedArgs), encoded)
+
+	_, err = builder.NewCall("Balances.transfer_keep_alive", dest)
+	assert.ErrorIs(t, err, ErrInvalidArguments)
+	assert.ErrorContains(t, err, "takes 2 arguments (dest, value), 1 given")
+
+	_, err = builder.NewCall("Balances.transfer")
+	assert.ErrorIs(t, err, ErrCallNotFound)
+
+	_, err = builder.NewCall("Timestamp.set", uint64(1))
+	assert.ErrorIs(t, err, ErrCallNotFound)
+
+	_, err = builder.NewCall("Staking.bond")
+	assert.ErrorIs(t, err, scale.ErrPalletNotFound)
+
+	_, err = builder.NewCall("remark")
+	assert.ErrorIs(t, err, ErrCallNotFound)
+}
+
+func TestBuilder_Signed(t *testing.T) {
+	t.Parallel()
+	builder := newTestBuilder(t)
+
+	keyring, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+	alice := keyring.Alice()
+
+	call, err := builder.NewCall("System.remark", []byte{0xab, 0xcd})
+	require.NoError(t, err)
+	options := testOptions()
+
+	encoded, err := builder.Signed(call, alice, options)
+	require.NoError(t, err)
+
+	var body []byte
+	err = scale.Unmarshal(encoded, &body)
+	require.NoError(t, err)
+
+	extra := testConcat(
+		[]byte{0xa5, 0x02}, // era of period 64 and phase 42
+		[]byte{5 << 2},     // nonce
+		[]byte{0xa1, 0x0f}, // tip
+		[]byte{0},          // metadata hash check disabled
+	)
+	additionalSigned := testConcat(
+		binary.LittleEndian.AppendUint32(nil, 9430),
+		binary.LittleEndian.AppendUint32(nil, 24),
+		options.GenesisHash.ToBytes(),
+		options.BlockHash.ToBytes(),
+		[]byte{0}, // no metadata hash
+	)
+	encodedCall := []byte{0, 0, 8, 0xab, 0xcd}
+
+	require.Len(t, body, 1+33+65+len(extra)+len(encodedCall))
+	assert.Equal(t, byte(0x84), body[0])
+	assert.Equal(t, testConcat([]byte{0}, alice.Public().Encode()), body[1:34])
+	assert.Equal(t, byte(1), body[34])
+	assert.Equal(t, extra, body[99:99+len(extra)])
+	assert.Equal(t, encodedCall, body[99+len(extra):])
+
+	payload := testConcat(encodedCall, extra, additionalSigned)
+	ok, err := alice.Public().Verify(payload, body[35:99])
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	builtPayload, err := builder.Payload(call, options)
+	require.NoError(t, err)
+	assert.Equal(t, payload, builtPayload)
+}
+
+func TestBuilder_Payload(t *testing.T) {
+	t.Parallel()
+	builder := newTestBuilder(t)
+
+	options := testOptions()
+	metadataHash := common.Hash{3}
+	options.MetadataHash = &metadataHash
+
+	call, err := builder.NewCall("System.remark", []byte{})
+	require.NoError(t, err)
+	payload, err := builder.Payload(call, options)
+	require.NoError(t, err)
+	assert.Equal(t, testConcat(
+		[]byte{0, 0, 0},
+		[]byte{0xa5, 0x02, 5 << 2, 0xa1, 0x0f},
+		[]byte{1}, // metadata hash check enabled
+		binary.LittleEndian.AppendUint32(nil, 9430),
+		binary.LittleEndian.AppendUint32(nil, 24),
+		options.GenesisHash.ToBytes(),
+		options.BlockHash.ToBytes(),
+		[]byte{1}, metadataHash.ToBytes(),
+	), payload)
+
+	// the payloads longer than 256 bytes are hashed
+	remark := make([]byte, 300)
+	call, err = builder.NewCall("System.remark", remark)
+	require.NoError(t, err)
+	payload, err = builder.Payload(call, options)
+	require.NoError(t, err)
+	assert.Len(t, payload, 32)
+}
+
+func TestBuilder_Signed_keyTypes(t *testing.T) {
+	t.Parallel()
+	builder := newTestBuilder(t)
+
+	call, err := builder.NewCall("System.remark", []byte{0xab, 0xcd})
+	require.NoError(t, err)
+	options := testOptions()
+	payload, err := builder.Payload(call, options)
+	require.NoError(t, err)
+
+	ed25519Keypair, err := ed25519.GenerateKeypair()
+	require.NoError(t, err)
+	secp256k1Keypair, err := secp256k1.GenerateKeypair()
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		signer         Signer
+		signatureIndex byte
+		signatureLen   int
+		signedMessage  []byte
+	}{
+		"ed25519": {
+			signer:         ed25519Keypair,
+			signatureIndex: 0,
+			signatureLen:   64,
+			signedMessage:  payload,
+		},
+		"secp256k1": {
+			signer:         secp256k1Keypair,
+			signatureIndex: 2,
+			signatureLen:   65,
+			signedMessage:  common.MustBlake2bHash(payload).ToBytes(),
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			encoded, err := builder.Signed(call, testCase.signer, options)
+			require.NoError(t, err)
+			var body []byte
+			err = scale.Unmarshal(encoded, &body)
+			require.NoError(t, err)
+
+			accountID := NewAccountID(testCase.signer.Public())
+			assert.Equal(t, testConcat([]byte{0x84, 0}, accountID[:]), body[:34])
+			assert.Equal(t, testCase.signatureIndex, body[34])
+
+			signature := body[35 : 35+testCase.signatureLen]
+			if testCase.signer.Type() == crypto.Secp256k1Type {
+				// the recovery identifier is not verified
+				signature = signature[:64]
+			}
+			ok, err := testCase.signer.Public().Verify(testCase.signedMessage, signature)
+			require.NoError(t, err)
+			assert.True(t, ok)
+		})
+	}
+}
+
+func TestBuilder_Signed_unsupportedExtension(t *testing.T) {
+	t.Parallel()
+	b := testMetadataBuilder{t: t}
+
+	metadata := newTestMetadata(t,
+		b.extension("CheckNonce", testTypeCompactU32, testTypeEmptyTuple),
+		b.extension("CheckCustom", testTypeU32, testTypeEmptyTuple),
+	)
+	registry, err := scale.NewRegistry(metadata)
+	require.NoError(t, err)
+	builder, err := NewBuilder(registry)
+	require.NoError(t, err)
+
+	call, err := builder.NewCall("System.remark", []byte{})
+	require.NoError(t, err)
+	keypair, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	_, err = builder.Signed(call, keypair, testOptions())
+	assert.ErrorIs(t, err, ErrUnsupportedSignedExtension)
+	assert.ErrorContains(t, err, "CheckCustom")
+}
+
+func TestBuilder_Unsigned(t *testing.T) {
+	t.Parallel()
+	builder := newTestBuilder(t)
+
+	call, err := builder.NewCall("System.remark", []byte{0xab})
+	require.NoError(t, err)
+	encoded, err := builder.Unsigned(call)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{5 << 2, 4, 0, 0, 4, 0xab}, encoded)
+}
+
+func TestMultiAddress(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		address MultiAddress
+		encoded []byte
+	}{
+		"id":        {address: NewMultiAddress(Address{1}), encoded: testConcat([]byte{0, 1}, make([]byte, 31))},
+		"index":     {address: NewMultiAddress(AddressIndex(64)), encoded: []byte{1, 1, 1}},
+		"raw":       {address: NewMultiAddress(AddressRaw{1, 2}), encoded: []byte{2, 8, 1, 2}},
+		"address32": {address: NewMultiAddress(Address32{1}), encoded: testConcat([]byte{3, 1}, make([]byte, 31))},
+		"address20": {address: NewMultiAddress(Address20{1}), encoded: testConcat([]byte{4, 1}, make([]byte, 19))},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			encoded, err := scale.Marshal(testCase.address)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.encoded, encoded)
+
+			decoded := MultiAddress{}
+			err = scale.Unmarshal(encoded, &decoded)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.address, decoded)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package extrinsic
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"slices"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// multisigAccountPrefix prefixes the preimage of the account identifiers of the multisigs
+var multisigAccountPrefix = []byte("modlpy/utilisuba")
+
+// Timepoint is the block number and the index in its block of the extrinsic of the first
+// approval of a multisig operation
+type Timepoint struct {
+	Height uint32
+	Index  uint32
+}
+
+// Weight is the maximum weight of a call, its computation time and its proof size
+type Weight struct {
+	RefTime   uint64
+	ProofSize uint64
+}
+
+// MarshalSCALE encodes the weight as two compact integers
+func (w Weight) MarshalSCALE() ([]byte, error) {
+	refTime, err := scale.NewCompact(w.RefTime).MarshalSCALE()
+	if err != nil {
+		return nil, err
+	}
+	proofSize, err := scale.NewCompact(w.ProofSize).MarshalSCALE()
+	if err != nil {
+		return nil, err
+	}
+	return append(refTime, proofSize...), nil
+}
+
+// sortSignatories returns the signatories given sorted, as expected by the multisig pallet,
+// checking the threshold given is between one and the number of signatories.
+func sortSignatories(signatories []AccountID, threshold uint16) ([]AccountID, error) {
+	if threshold == 0 || int(threshold) > len(signatories) {
+		return nil, fmt.Errorf("%w: %d of %d signatories", ErrInvalidThreshold, threshold, len(signatories))
+	}
+
+	sorted := slices.Clone(signatories)
+	slices.SortFunc(sorted, func(a, b AccountID) int {
+		return bytes.Compare(a[:], b[:])
+	})
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i] == sorted[i-1] {
+			return nil, fmt.Errorf("%w: 0x%x", ErrDuplicateSignatory, sorted[i])
+		}
+	}
+	return sorted, nil
+}
+
+// NewMultisigAccountID returns the account identifier of the multisig of the signatories and
+// of the threshold given, as computed by the multi_account_id function of the multisig
+// pallet: the hash of the sorted signatories and of the threshold.
+func NewMultisigAccountID(signatories []AccountID, threshold uint16) (AccountID, error) {
+	sorted, err := sortSignatories(signatories, threshold)
+	if err != nil {
+		return AccountID{}, err
+	}
+
+	encodedSignatories, err := scale.Marshal(sorted)
+	if err != nil {
+		return AccountID{}, fmt.Errorf("encoding signatories: %w", err)
+	}
+
+	preimage := slices.Concat(multisigAccountPrefix, encodedSignatories)
+	preimage = binary.LittleEndian.AppendUint16(preimage, threshold)
+	return AccountID(common.MustBlake2bHash(preimage)), nil
+}
+
+// NewAsMultiCall returns the call of the multisig pallet approving the call given as the
+// sender given, one of the signatories of the multisig, and dispatching it once the
+// threshold is reached. The timepoint is the one of the first approval, nil for the first
+// approval itself. The as_multi_threshold_1 call dispatching the call right away is returned
+// for the threshold of one.
+func (b *Builder) NewAsMultiCall(threshold uint16, signatories []AccountID, sender AccountID,
+	timepoint *Timepoint, call Call, maxWeight Weight) (Call, error) {
+	sorted, err := sortSignatories(signatories, threshold)
+	if err != nil {
+		return Call{}, err
+	}
+
+	otherSignatories := slices.DeleteFunc(sorted, func(signatory AccountID) bool {
+		return signatory == sender
+	})
+	if len(otherSignatories) == len(signatories) {
+		return Call{}, fmt.Errorf("%w: 0x%x", ErrSenderNotSignatory, sender)
+	}
+
+	if threshold == 1 {
+		return b.NewCall("Multisig.as_multi_threshold_1", otherSignatories, call)
+	}
+	return b.NewCall("Multisig.as_multi", threshold, otherSignatories, timepoint, call, maxWeight)
+}
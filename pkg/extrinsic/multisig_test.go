@@ -0,0 +1,126 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package extrinsic
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testAlice   = AccountID(common.MustHexToBytes("0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d"))
+	testBob     = AccountID(common.MustHexToBytes("0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48"))
+	testCharlie = AccountID(common.MustHexToBytes("0x90b5ab205c6974c9ea841be688864633dc9ca8a357843eeacf2314649965fe22"))
+)
+
+func TestNewMultisigAccountID(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		signatories []AccountID
+		threshold   uint16
+		accountID   AccountID
+		errWrapped  error
+	}{
+		"alice_bob_charlie": {
+			// 5DjYJStmdZ2rcqXbXGX7TW85JsrW6uG4y9MUcLq2BoPMpRA7
+			signatories: []AccountID{testAlice, testBob, testCharlie},
+			threshold:   2,
+			accountID: AccountID(common.MustHexToBytes(
+				"0x49daa32c7287890f38b7e1a8cd2961723d36d20baa0bf3b82e0c4bdda93b1c0a")),
+		},
+		"unsorted_signatories": {
+			signatories: []AccountID{testCharlie, testAlice, testBob},
+			threshold:   2,
+			accountID: AccountID(common.MustHexToBytes(
+				"0x49daa32c7287890f38b7e1a8cd2961723d36d20baa0bf3b82e0c4bdda93b1c0a")),
+		},
+		"zero_threshold": {
+			signatories: []AccountID{testAlice, testBob},
+			errWrapped:  ErrInvalidThreshold,
+		},
+		"threshold_above_signatories": {
+			signatories: []AccountID{testAlice, testBob},
+			threshold:   3,
+			errWrapped:  ErrInvalidThreshold,
+		},
+		"duplicate_signatory": {
+			signatories: []AccountID{testAlice, testBob, testAlice},
+			threshold:   2,
+			errWrapped:  ErrDuplicateSignatory,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			accountID, err := NewMultisigAccountID(testCase.signatories, testCase.threshold)
+			assert.ErrorIs(t, err, testCase.errWrapped)
+			assert.Equal(t, testCase.accountID, accountID)
+		})
+	}
+}
+
+func TestBuilder_NewAsMultiCall(t *testing.T) {
+	t.Parallel()
+	builder := newTestBuilder(t)
+
+	call := Call{PalletIndex: 0, CallIndex: 0, Args: []byte{4, 0xab}}
+	signatories := []AccountID{testAlice, testBob, testCharlie}
+
+	asMulti, err := builder.NewAsMultiCall(2, signatories, testAlice, nil, call, Weight{RefTime: 1, ProofSize: 2})
+	require.NoError(t, err)
+	assert.Equal(t, Call{
+		PalletIndex: 30,
+		CallIndex:   1,
+		Args: testConcat(
+			[]byte{2, 0},
+			[]byte{2 << 2}, testBob[:], testCharlie[:],
+			[]byte{0},
+			[]byte{0, 0, 4, 0xab},
+			[]byte{1 << 2, 2 << 2},
+		),
+	}, asMulti)
+
+	timepoint := &Timepoint{Height: 1, Index: 2}
+	asMulti, err = builder.NewAsMultiCall(3, signatories, testCharlie, timepoint, call, Weight{})
+	require.NoError(t, err)
+	assert.Equal(t, testConcat(
+		[]byte{3, 0},
+		[]byte{2 << 2}, testBob[:], testAlice[:],
+		[]byte{1, 1, 0, 0, 0, 2, 0, 0, 0},
+		[]byte{0, 0, 4, 0xab},
+		[]byte{0, 0},
+	), asMulti.Args)
+
+	asMulti, err = builder.NewAsMultiCall(1, signatories, testBob, nil, call, Weight{})
+	require.NoError(t, err)
+	assert.Equal(t, Call{
+		PalletIndex: 30,
+		CallIndex:   0,
+		Args:        testConcat([]byte{2 << 2}, testCharlie[:], testAlice[:], []byte{0, 0, 4, 0xab}),
+	}, asMulti)
+
+	_, err = builder.NewAsMultiCall(2, []AccountID{testBob, testCharlie}, testAlice, nil, call, Weight{})
+	assert.ErrorIs(t, err, ErrSenderNotSignatory)
+
+	_, err = builder.NewAsMultiCall(4, signatories, testAlice, nil, call, Weight{})
+	assert.ErrorIs(t, err, ErrInvalidThreshold)
+}
+
+func TestDecodeCall(t *testing.T) {
+	t.Parallel()
+
+	call, err := DecodeCall([]byte{5, 3, 1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, Call{PalletIndex: 5, CallIndex: 3, Args: []byte{1, 2}}, call)
+
+	_, err = DecodeCall([]byte{5})
+	assert.ErrorIs(t, err, ErrInvalidCall)
+}
@@ -0,0 +1,55 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package extrinsic
+
+import (
+	"encoding/binary"
+	"slices"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// pureProxyAccountPrefix prefixes the preimage of the account identifiers of the pure proxies
+var pureProxyAccountPrefix = []byte("modlpy/proxy____")
+
+// NewPureProxyAccountID returns the account identifier of the pure proxy created by the
+// spawner given with the create_pure call of the proxy pallet, with the index of the proxy
+// type and the disambiguation index given, in the extrinsic of the index given of the block
+// of the height given. It is computed as by the pure_account function of the proxy pallet.
+func NewPureProxyAccountID(spawner AccountID, proxyType uint8, index uint16,
+	height, extrinsicIndex uint32) AccountID {
+	preimage := slices.Concat(pureProxyAccountPrefix, spawner[:])
+	preimage = binary.LittleEndian.AppendUint32(preimage, height)
+	preimage = binary.LittleEndian.AppendUint32(preimage, extrinsicIndex)
+	preimage = append(preimage, proxyType)
+	preimage = binary.LittleEndian.AppendUint16(preimage, index)
+	return AccountID(common.MustBlake2bHash(preimage))
+}
+
+// NewProxyCall returns the call of the proxy pallet dispatching the call given on behalf of
+// the real account given, as a proxy of the proxy type of the index given, or of any proxy
+// type allowed to dispatch the call if it is nil.
+func (b *Builder) NewProxyCall(real AccountID, forceProxyType *uint8, call Call) (Call, error) {
+	const name = "Proxy.proxy"
+	_, variant, err := b.callVariant(name)
+	if err != nil {
+		return Call{}, err
+	}
+
+	// the real account is given as a multi address by the runtimes looking up the accounts,
+	// and as an account identifier by the other runtimes
+	var realArg any = real
+	if len(variant.Fields) > 0 {
+		realType, err := b.registry.Type(variant.Fields[0].Type)
+		if err != nil {
+			return Call{}, err
+		}
+		if realType.Kind == scale.TypeDefVariant {
+			realArg = NewMultiAddress(Address(real))
+		}
+	}
+
+	return b.NewCall(name, realArg, forceProxyType, call)
+}
@@ -0,0 +1,45 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package extrinsic
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPureProxyAccountID(t *testing.T) {
+	t.Parallel()
+
+	// 5FyHcmKVnpK2r6FY4F4wmyrWGwoi55hTqj3zPNHk62Sv5PY1
+	accountID := NewPureProxyAccountID(testAlice, 0, 0, 0, 0)
+	assert.Equal(t, AccountID(common.MustHexToBytes(
+		"0xaccf1bce3b5daf6735e65a8d3bbf1266b5f6893771ab4ab790c7ffb64aab92df")), accountID)
+
+	assert.NotEqual(t, accountID, NewPureProxyAccountID(testAlice, 1, 0, 0, 0))
+	assert.NotEqual(t, accountID, NewPureProxyAccountID(testAlice, 0, 1, 0, 0))
+	assert.NotEqual(t, accountID, NewPureProxyAccountID(testAlice, 0, 0, 1, 0))
+	assert.NotEqual(t, accountID, NewPureProxyAccountID(testAlice, 0, 0, 0, 1))
+}
+
+func TestBuilder_NewProxyCall(t *testing.T) {
+	t.Parallel()
+	builder := newTestBuilder(t)
+
+	call := Call{PalletIndex: 0, CallIndex: 0, Args: []byte{4, 0xab}}
+	proxyCall, err := builder.NewProxyCall(testAlice, nil, call)
+	require.NoError(t, err)
+	assert.Equal(t, Call{
+		PalletIndex: 29,
+		CallIndex:   0,
+		Args:        testConcat([]byte{0}, testAlice[:], []byte{0}, []byte{0, 0, 4, 0xab}),
+	}, proxyCall)
+
+	proxyType := uint8(3)
+	proxyCall, err = builder.NewProxyCall(testAlice, &proxyType, call)
+	require.NoError(t, err)
+	assert.Equal(t, testConcat([]byte{0}, testAlice[:], []byte{1, 3}, []byte{0, 0, 4, 0xab}), proxyCall.Args)
+}
@@ -0,0 +1,48 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package scale
+
+import "fmt"
+
+// BitVec is a vector of bits, SCALE encoded like a BitVec<u8, Lsb0> in Rust: the number
+// of bits compact encoded, followed by the bits packed in bytes, starting with the least
+// significant bit of the first byte.
+type BitVec []bool
+
+// NewBitVec returns the bit vector of the first bits given, packed in bytes starting
+// with the least significant bit of the first byte.
+func NewBitVec(bytes []byte, length uint) (BitVec, error) {
+	if length > uint(len(bytes))*8 {
+		return nil, fmt.Errorf("%w: %d bits in %d bytes", errBitVecTooShort, length, len(bytes))
+	}
+
+	bitVec := make(BitVec, length)
+	for i := range bitVec {
+		bitVec[i] = bytes[i/8]&(1<<(i%8)) != 0
+	}
+	return bitVec, nil
+}
+
+// Bytes returns the bits packed in bytes, starting with the least significant bit of
+// the first byte. The unused bits of the last byte are zero.
+func (bv BitVec) Bytes() []byte {
+	bytes := make([]byte, (len(bv)+7)/8)
+	for i, bit := range bv {
+		if bit {
+			bytes[i/8] |= 1 << (i % 8)
+		}
+	}
+	return bytes
+}
+
+// Ones returns the indices of the bits set, in increasing order.
+func (bv BitVec) Ones() []uint {
+	var ones []uint
+	for i, bit := range bv {
+		if bit {
+			ones = append(ones, uint(i))
+		}
+	}
+	return ones
+}
@@ -0,0 +1,77 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package scale
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitVec_Marshal(t *testing.T) {
+	tests := map[string]struct {
+		in      BitVec
+		encoded []byte
+	}{
+		"empty": {
+			in:      BitVec{},
+			encoded: []byte{0},
+		},
+		"single_bit": {
+			in:      BitVec{true},
+			encoded: []byte{1 << 2, 0b1},
+		},
+		"partial_byte": {
+			in:      BitVec{true, false, true},
+			encoded: []byte{3 << 2, 0b101},
+		},
+		"full_byte": {
+			in:      BitVec{false, false, false, false, false, false, false, true},
+			encoded: []byte{8 << 2, 0b1000_0000},
+		},
+		"two_bytes": {
+			in:      BitVec{true, true, false, false, false, false, false, false, false, true},
+			encoded: []byte{10 << 2, 0b11, 0b10},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			encoded, err := Marshal(tt.in)
+			require.NoError(t, err)
+			assert.Equal(t, tt.encoded, encoded)
+
+			var decoded BitVec
+			err = Unmarshal(encoded, &decoded)
+			require.NoError(t, err)
+			assert.Equal(t, tt.in, decoded)
+		})
+	}
+}
+
+func TestBitVec_Unmarshal_errors(t *testing.T) {
+	var bitVec BitVec
+	err := Unmarshal([]byte{9 << 2, 0xff}, &bitVec)
+	require.Error(t, err)
+
+	type withBitVec struct {
+		Bits *BitVec
+	}
+	var decoded withBitVec
+	err = Unmarshal([]byte{1, 2 << 2, 0b10}, &decoded)
+	require.NoError(t, err)
+	assert.Equal(t, withBitVec{Bits: &BitVec{false, true}}, decoded)
+}
+
+func TestNewBitVec(t *testing.T) {
+	bitVec, err := NewBitVec([]byte{0b1010_0001, 0b1}, 9)
+	require.NoError(t, err)
+	assert.Equal(t, BitVec{true, false, false, false, false, true, false, true, true}, bitVec)
+	assert.Equal(t, []uint{0, 5, 7, 8}, bitVec.Ones())
+	assert.Equal(t, []byte{0b1010_0001, 0b1}, bitVec.Bytes())
+
+	_, err = NewBitVec([]byte{0xff}, 9)
+	assert.ErrorIs(t, err, errBitVecTooShort)
+}
@@ -0,0 +1,137 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package scale
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+	"reflect"
+)
+
+// CompactInteger is the constraint of the integer types which can be compact encoded
+type CompactInteger interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | *big.Int | *Uint128 | *Uint256
+}
+
+// Compact holds an unsigned integer SCALE encoded in the compact format, like a
+// Compact<T> in Rust. Struct fields can also be compact encoded with the compact option
+// of the scale struct tag, for example `scale:"1,compact"` or `scale:",compact"`.
+type Compact[T CompactInteger] struct {
+	Value T
+}
+
+// NewCompact returns the integer given as a Compact
+func NewCompact[T CompactInteger](value T) Compact[T] {
+	return Compact[T]{Value: value}
+}
+
+// MarshalSCALE compact encodes the integer
+func (c Compact[T]) MarshalSCALE() ([]byte, error) {
+	buffer := bytes.NewBuffer(nil)
+	es := encodeState{
+		Writer:                 buffer,
+		fieldScaleIndicesCache: cache,
+	}
+	err := es.encodeCompact(reflect.ValueOf(c.Value))
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalSCALE decodes a compact encoded integer
+func (c *Compact[T]) UnmarshalSCALE(reader io.Reader) error {
+	ds := decodeState{Reader: reader}
+	return ds.decodeCompact(reflect.ValueOf(&c.Value).Elem())
+}
+
+// encodeCompact compact encodes the unsigned integer value given
+func (es *encodeState) encodeCompact(v reflect.Value) (err error) {
+	i, err := compactToBigInt(v)
+	if err != nil {
+		return err
+	}
+	return es.encodeBigInt(i)
+}
+
+// decodeCompact decodes a compact encoded unsigned integer into the destination given
+func (ds *decodeState) decodeCompact(dstv reflect.Value) (err error) {
+	temp := reflect.New(reflect.TypeOf((*big.Int)(nil))).Elem()
+	err = ds.decodeBigInt(temp)
+	if err != nil {
+		return err
+	}
+	return setFromBigInt(dstv, temp.Interface().(*big.Int))
+}
+
+// compactToBigInt returns the unsigned integer value given as a big integer
+func compactToBigInt(v reflect.Value) (*big.Int, error) {
+	switch in := v.Interface().(type) {
+	case *big.Int:
+		if in == nil {
+			return nil, fmt.Errorf("%w", errBigIntIsNil)
+		}
+		if in.Sign() < 0 {
+			return nil, fmt.Errorf("%w: %s", errCompactOutOfRange, in)
+		}
+		return in, nil
+	case *Uint128:
+		if in == nil {
+			return nil, fmt.Errorf("%w", errUint128IsNil)
+		}
+		return new(big.Int).SetBytes(in.Bytes(binary.BigEndian)), nil
+	case *Uint256:
+		if in == nil {
+			return nil, fmt.Errorf("%w", errUint256IsNil)
+		}
+		return in.BigInt(), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(v.Uint()), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCompactType, v.Type())
+	}
+}
+
+// setFromBigInt sets the unsigned integer destination given to the big integer given
+func setFromBigInt(dstv reflect.Value, i *big.Int) error {
+	switch dstv.Interface().(type) {
+	case *big.Int:
+		dstv.Set(reflect.ValueOf(i))
+		return nil
+	case *Uint128:
+		if i.BitLen() > 128 {
+			return fmt.Errorf("%w: %s does not fit in 128 bits", errCompactOutOfRange, i)
+		}
+		u, err := NewUint128(i)
+		if err != nil {
+			return err
+		}
+		dstv.Set(reflect.ValueOf(u))
+		return nil
+	case *Uint256:
+		u, err := NewUint256(i)
+		if err != nil {
+			return err
+		}
+		dstv.Set(reflect.ValueOf(u))
+		return nil
+	}
+
+	switch dstv.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if !i.IsUint64() || dstv.OverflowUint(i.Uint64()) {
+			return fmt.Errorf("%w: %s does not fit in %s", errCompactOutOfRange, i, dstv.Type())
+		}
+		dstv.SetUint(i.Uint64())
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedCompactType, dstv.Type())
+	}
+}
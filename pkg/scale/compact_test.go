@@ -0,0 +1,119 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package scale
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompact(t *testing.T) {
+	t.Run("uint32", func(t *testing.T) {
+		encoded, err := Marshal(NewCompact(uint32(69)))
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0x15, 0x01}, encoded)
+
+		var decoded Compact[uint32]
+		err = Unmarshal(encoded, &decoded)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(69), decoded.Value)
+	})
+
+	t.Run("uint128", func(t *testing.T) {
+		value := MustNewUint128(new(big.Int).Lsh(big.NewInt(1), 100))
+		encoded, err := Marshal(NewCompact(value))
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0x27, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x10}, encoded)
+
+		var decoded Compact[*Uint128]
+		err = Unmarshal(encoded, &decoded)
+		require.NoError(t, err)
+		assert.Equal(t, value, decoded.Value)
+	})
+
+	t.Run("uint256", func(t *testing.T) {
+		value := MustNewUint256(big.NewInt(1 << 20))
+		encoded, err := Marshal(NewCompact(value))
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0x02, 0x00, 0x40, 0x00}, encoded)
+
+		var decoded Compact[*Uint256]
+		err = Unmarshal(encoded, &decoded)
+		require.NoError(t, err)
+		assert.Equal(t, value, decoded.Value)
+	})
+
+	t.Run("slice", func(t *testing.T) {
+		values := []Compact[uint64]{NewCompact(uint64(1)), NewCompact(uint64(1 << 40))}
+		encoded, err := Marshal(values)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0x08, 0x04, 0x0b, 0, 0, 0, 0, 0, 0x01}, encoded)
+
+		var decoded []Compact[uint64]
+		err = Unmarshal(encoded, &decoded)
+		require.NoError(t, err)
+		assert.Equal(t, values, decoded)
+	})
+
+	t.Run("out_of_range", func(t *testing.T) {
+		encoded, err := Marshal(NewCompact(uint32(1 << 16)))
+		require.NoError(t, err)
+
+		var decoded Compact[uint8]
+		err = Unmarshal(encoded, &decoded)
+		assert.ErrorIs(t, err, errCompactOutOfRange)
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		_, err := Marshal(NewCompact(big.NewInt(-1)))
+		assert.ErrorIs(t, err, errCompactOutOfRange)
+	})
+}
+
+func TestCompactStructTag(t *testing.T) {
+	type compactFields struct {
+		Index   uint32   `scale:"2,compact"`
+		Balance *Uint128 `scale:"1,compact"`
+		Nonce   uint64   `scale:",compact"`
+		Fixed   uint16
+		Ignored uint8 `scale:"-"`
+	}
+
+	value := compactFields{
+		Index:   1,
+		Balance: MustNewUint128(big.NewInt(64)),
+		Nonce:   2,
+		Fixed:   3,
+	}
+
+	encoded, err := Marshal(value)
+	require.NoError(t, err)
+	expected := []byte{
+		0x01, 0x01, // Balance
+		0x04,       // Index
+		0x08,       // Nonce
+		0x03, 0x00, // Fixed
+	}
+	assert.Equal(t, expected, encoded)
+
+	var decoded compactFields
+	err = Unmarshal(encoded, &decoded)
+	require.NoError(t, err)
+	assert.Equal(t, value, decoded)
+
+	type unsupported struct {
+		Value int32 `scale:",compact"`
+	}
+	_, err = Marshal(unsupported{})
+	assert.ErrorIs(t, err, ErrUnsupportedCompactType)
+
+	type invalidOption struct {
+		Value uint32 `scale:",compacted"`
+	}
+	_, err = Marshal(invalidOption{})
+	assert.ErrorIs(t, err, ErrInvalidScaleTagOption)
+}
@@ -141,6 +141,10 @@ func (ds *decodeState) unmarshal(dstv reflect.Value) (err error) {
 		err = ds.decodeBigInt(dstv)
 	case *Uint128:
 		err = ds.decodeUint128(dstv)
+	case *Uint256:
+		err = ds.decodeUint256(dstv)
+	case BitVec:
+		err = ds.decodeBitVec(dstv)
 	case int, uint:
 		err = ds.decodeUint(dstv)
 	case int8, uint8, int16, uint16, int32, uint32, int64, uint64:
@@ -447,6 +451,13 @@ func (ds *decodeState) decodeStruct(dstv reflect.Value) (err error) {
 		if inv.Field(i.fieldIndex).IsValid() && !inv.Field(i.fieldIndex).IsZero() {
 			field.Set(inv.Field(i.fieldIndex))
 		}
+		if i.compact {
+			err = ds.decodeCompact(field)
+			if err != nil {
+				return fmt.Errorf("decoding struct: decoding compact field at index %d: %w", i.fieldIndex, err)
+			}
+			continue
+		}
 		err = ds.unmarshal(field)
 		if err != nil {
 			return fmt.Errorf("decoding struct: unmarshalling field at index %d: %w", i.fieldIndex, err)
@@ -747,3 +758,46 @@ func (ds *decodeState) decodeUint128(dstv reflect.Value) (err error) {
 	dstv.Set(reflect.ValueOf(ui128))
 	return
 }
+
+// decodeUint256 accepts a byte array representing a SCALE encoded
+// Uint256 and performs SCALE decoding of the Uint256
+func (ds *decodeState) decodeUint256(dstv reflect.Value) (err error) {
+	buf := make([]byte, uint256Length)
+	_, err = io.ReadFull(ds, buf)
+	if err != nil {
+		return
+	}
+	ui256, err := NewUint256(buf)
+	if err != nil {
+		return
+	}
+	dstv.Set(reflect.ValueOf(ui256))
+	return
+}
+
+// decodeBitVec decodes the number of bits compact encoded, followed by the bits
+// packed in bytes, into a BitVec
+func (ds *decodeState) decodeBitVec(dstv reflect.Value) (err error) {
+	length, err := ds.decodeLength()
+	if err != nil {
+		return
+	}
+
+	// the number of bits is encoded as Compact<u32>
+	if length > math.MaxUint32 {
+		return fmt.Errorf("%w: %d", errBitVecTooLong, length)
+	}
+
+	buf := make([]byte, (length+7)/8)
+	_, err = io.ReadFull(ds, buf)
+	if err != nil {
+		return fmt.Errorf("reading bytes: %w", err)
+	}
+
+	bitVec, err := NewBitVec(buf, length)
+	if err != nil {
+		return
+	}
+	dstv.Set(reflect.ValueOf(bitVec).Convert(dstv.Type()))
+	return
+}
@@ -0,0 +1,632 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package scale
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxDecodingDepth is the maximum nesting of the values decoded from a type registry,
+// protecting against recursive types and malicious input.
+const maxDecodingDepth = 128
+
+// Variant is an enum value decoded from a type registry
+type Variant struct {
+	Name  string
+	Index uint8
+	// Value is the value of the fields of the variant, nil if it has no field
+	Value any
+}
+
+// MarshalJSON marshals the variant as its name if it has no field, else as an object
+// with its name as key and the value of its fields as value.
+func (v Variant) MarshalJSON() ([]byte, error) {
+	if v.Value == nil {
+		return json.Marshal(v.Name)
+	}
+	return json.Marshal(map[string]any{v.Name: v.Value})
+}
+
+// Bytes is a byte sequence or byte array decoded from a type registry
+type Bytes []byte
+
+// MarshalJSON marshals the bytes as a 0x prefixed hex string.
+func (b Bytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal("0x" + hex.EncodeToString(b))
+}
+
+// Decode decodes the SCALE encoded value of the type given. Structs are decoded as
+// map[string]any keyed by field name, tuples and tuple structs as []any, enums as
+// Variant, byte sequences as Bytes and primitive types as the Go types they are
+// decoded to by Unmarshal. It is an error if the data is not entirely consumed.
+func (r *Registry) Decode(typeID uint32, data []byte) (any, error) {
+	reader := bytes.NewReader(data)
+	value, err := r.DecodeFrom(typeID, reader)
+	if err != nil {
+		return nil, err
+	}
+	if reader.Len() > 0 {
+		return nil, fmt.Errorf("%w: %d bytes", ErrTrailingBytes, reader.Len())
+	}
+	return value, nil
+}
+
+// DecodeFrom decodes the SCALE encoded value of the type given from the reader given.
+func (r *Registry) DecodeFrom(typeID uint32, reader io.Reader) (any, error) {
+	dd := &dynamicDecoder{
+		registry:    r,
+		decodeState: decodeState{Reader: reader},
+	}
+	return dd.decode(typeID, 0)
+}
+
+// dynamicDecoder decodes values using the types of a type registry
+type dynamicDecoder struct {
+	registry *Registry
+	decodeState
+}
+
+func (dd *dynamicDecoder) decode(typeID uint32, depth int) (any, error) {
+	if depth > maxDecodingDepth {
+		return nil, fmt.Errorf("%w: decoding type %d", ErrMaxDecodingDepth, typeID)
+	}
+
+	typeInfo, err := dd.registry.Type(typeID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch typeInfo.Kind {
+	case TypeDefComposite:
+		return dd.decodeFields(typeInfo.Fields, depth)
+	case TypeDefVariant:
+		return dd.decodeVariant(typeInfo, depth)
+	case TypeDefSequence:
+		length, err := dd.decodeLength()
+		if err != nil {
+			return nil, fmt.Errorf("decoding sequence length: %w", err)
+		}
+		if length > math.MaxUint32 {
+			return nil, fmt.Errorf("sequence length %d exceeds max value of uint32", length)
+		}
+		return dd.decodeElements(typeInfo.Elem, uint32(length), depth)
+	case TypeDefArray:
+		return dd.decodeElements(typeInfo.Elem, typeInfo.Len, depth)
+	case TypeDefTuple:
+		if len(typeInfo.Tuple) == 0 {
+			return nil, nil
+		}
+		values := make([]any, len(typeInfo.Tuple))
+		for i, elemType := range typeInfo.Tuple {
+			values[i], err = dd.decode(elemType, depth+1)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	case TypeDefPrimitive:
+		return dd.decodePrimitive(typeInfo.Primitive)
+	case TypeDefCompact:
+		return dd.decodeCompactType(typeInfo.Elem, depth)
+	case TypeDefBitSequence:
+		return dd.decodeBitSequence(typeInfo)
+	default:
+		return nil, fmt.Errorf("%w: kind %d of type %d", ErrInvalidTypeDefinition, typeInfo.Kind, typeID)
+	}
+}
+
+// decodeFields decodes the fields of a struct or enum variant. Named fields are decoded
+// as a map, a single unnamed field as its value and several unnamed fields as a slice.
+func (dd *dynamicDecoder) decodeFields(fields []Field, depth int) (any, error) {
+	switch {
+	case len(fields) == 0:
+		return nil, nil
+	case fields[0].Name == "":
+		values := make([]any, len(fields))
+		for i, field := range fields {
+			value, err := dd.decode(field.Type, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+		if len(values) == 1 {
+			return values[0], nil
+		}
+		return values, nil
+	default:
+		values := make(map[string]any, len(fields))
+		for _, field := range fields {
+			value, err := dd.decode(field.Type, depth+1)
+			if err != nil {
+				return nil, fmt.Errorf("decoding field %s: %w", field.Name, err)
+			}
+			values[field.Name] = value
+		}
+		return values, nil
+	}
+}
+
+func (dd *dynamicDecoder) decodeVariant(typeInfo *TypeInfo, depth int) (any, error) {
+	index, err := dd.ReadByte()
+	if err != nil {
+		return nil, fmt.Errorf("reading variant index: %w", err)
+	}
+
+	variant, err := typeInfo.variant(index)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := dd.decodeFields(variant.Fields, depth)
+	if err != nil {
+		return nil, fmt.Errorf("decoding variant %s: %w", variant.Name, err)
+	}
+	return Variant{Name: variant.Name, Index: index, Value: value}, nil
+}
+
+// decodeElements decodes the elements of a sequence or array, as Bytes for u8 elements.
+func (dd *dynamicDecoder) decodeElements(elemType uint32, length uint32, depth int) (any, error) {
+	elemInfo, err := dd.registry.Type(elemType)
+	if err != nil {
+		return nil, err
+	}
+
+	if elemInfo.Kind == TypeDefPrimitive && elemInfo.Primitive == PrimitiveU8 {
+		buf, err := dd.readFull(uint(length))
+		if err != nil {
+			return nil, err
+		}
+		return Bytes(buf), nil
+	}
+
+	// the elements are allocated as they are decoded since the length is untrusted
+	var values []any
+	for i := uint32(0); i < length; i++ {
+		value, err := dd.decode(elemType, depth+1)
+		if err != nil {
+			return nil, fmt.Errorf("decoding element %d: %w", i, err)
+		}
+		values = append(values, value)
+	}
+	if values == nil {
+		values = []any{}
+	}
+	return values, nil
+}
+
+func (dd *dynamicDecoder) decodePrimitive(primitive Primitive) (any, error) {
+	switch primitive {
+	case PrimitiveBool:
+		b, err := dd.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		switch b {
+		case 0:
+			return false, nil
+		case 1:
+			return true, nil
+		default:
+			return nil, fmt.Errorf("%w: %d", errDecodeBool, b)
+		}
+	case PrimitiveChar:
+		buf, err := dd.readFull(4)
+		if err != nil {
+			return nil, err
+		}
+		r := rune(binary.LittleEndian.Uint32(buf)) //nolint:gosec
+		if !utf8.ValidRune(r) {
+			return nil, fmt.Errorf("%w: invalid char %d", ErrInvalidTypeDefinition, r)
+		}
+		return string(r), nil
+	case PrimitiveStr:
+		length, err := dd.decodeLength()
+		if err != nil {
+			return nil, fmt.Errorf("decoding string length: %w", err)
+		}
+		if length > math.MaxUint32 {
+			return nil, fmt.Errorf("string length %d exceeds max value of uint32", length)
+		}
+		buf, err := dd.readFull(length)
+		return string(buf), err
+	case PrimitiveU8:
+		return dd.ReadByte()
+	case PrimitiveU16:
+		buf, err := dd.readFull(2)
+		if err != nil {
+			return nil, err
+		}
+		return binary.LittleEndian.Uint16(buf), nil
+	case PrimitiveU32:
+		buf, err := dd.readFull(4)
+		if err != nil {
+			return nil, err
+		}
+		return binary.LittleEndian.Uint32(buf), nil
+	case PrimitiveU64:
+		buf, err := dd.readFull(8)
+		if err != nil {
+			return nil, err
+		}
+		return binary.LittleEndian.Uint64(buf), nil
+	case PrimitiveU128:
+		buf, err := dd.readFull(16)
+		if err != nil {
+			return nil, err
+		}
+		return NewUint128(buf)
+	case PrimitiveU256:
+		buf, err := dd.readFull(uint256Length)
+		if err != nil {
+			return nil, err
+		}
+		return NewUint256(buf)
+	case PrimitiveI8:
+		b, err := dd.ReadByte()
+		return int8(b), err //nolint:gosec
+	case PrimitiveI16:
+		buf, err := dd.readFull(2)
+		if err != nil {
+			return nil, err
+		}
+		return int16(binary.LittleEndian.Uint16(buf)), nil //nolint:gosec
+	case PrimitiveI32:
+		buf, err := dd.readFull(4)
+		if err != nil {
+			return nil, err
+		}
+		return int32(binary.LittleEndian.Uint32(buf)), nil //nolint:gosec
+	case PrimitiveI64:
+		buf, err := dd.readFull(8)
+		if err != nil {
+			return nil, err
+		}
+		return int64(binary.LittleEndian.Uint64(buf)), nil //nolint:gosec
+	case PrimitiveI128:
+		return dd.decodeSignedInt(16)
+	case PrimitiveI256:
+		return dd.decodeSignedInt(32)
+	default:
+		return nil, fmt.Errorf("%w: primitive %d", ErrInvalidTypeDefinition, primitive)
+	}
+}
+
+// readFull reads exactly the number of bytes given. The buffer grows as the bytes are
+// read since the length may be decoded from untrusted input.
+func (dd *dynamicDecoder) readFull(length uint) ([]byte, error) {
+	var buf bytes.Buffer
+	n, err := io.CopyN(&buf, dd.Reader, int64(length))
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, fmt.Errorf("reading %d bytes after %d bytes: %w", length, n, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeSignedInt decodes a little endian two's complement integer of the size given.
+func (dd *dynamicDecoder) decodeSignedInt(size int) (*big.Int, error) {
+	buf, err := dd.readFull(uint(size))
+	if err != nil {
+		return nil, err
+	}
+
+	i := new(big.Int).SetBytes(reverseBytes(buf))
+	if buf[0]&0x80 != 0 {
+		i.Sub(i, new(big.Int).Lsh(big.NewInt(1), uint(size*8)))
+	}
+	return i, nil
+}
+
+// decodeCompactType decodes a compact encoded integer into the Go type of the
+// primitive integer type given, or of the single field struct wrapping it.
+func (dd *dynamicDecoder) decodeCompactType(typeID uint32, depth int) (any, error) {
+	if depth > maxDecodingDepth {
+		return nil, fmt.Errorf("%w: decoding compact type %d", ErrMaxDecodingDepth, typeID)
+	}
+
+	typeInfo, err := dd.registry.Type(typeID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch typeInfo.Kind {
+	case TypeDefComposite:
+		// compact encoding of the single field of a struct, for example Compact<Perbill>
+		if len(typeInfo.Fields) > 1 {
+			return nil, fmt.Errorf("%w: compact struct %d with %d fields",
+				ErrInvalidTypeDefinition, typeID, len(typeInfo.Fields))
+		}
+		if len(typeInfo.Fields) == 0 {
+			return nil, nil
+		}
+		value, err := dd.decodeCompactType(typeInfo.Fields[0].Type, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		if typeInfo.Fields[0].Name != "" {
+			return map[string]any{typeInfo.Fields[0].Name: value}, nil
+		}
+		return value, nil
+	case TypeDefTuple:
+		if len(typeInfo.Tuple) == 0 {
+			return nil, nil
+		}
+	case TypeDefPrimitive:
+		return dd.decodeCompactPrimitive(typeInfo.Primitive)
+	}
+	return nil, fmt.Errorf("%w: compact of type %d", ErrUnsupportedCompactType, typeID)
+}
+
+func (dd *dynamicDecoder) decodeCompactPrimitive(primitive Primitive) (any, error) {
+	var dst any
+	switch primitive {
+	case PrimitiveU8:
+		dst = new(uint8)
+	case PrimitiveU16:
+		dst = new(uint16)
+	case PrimitiveU32:
+		dst = new(uint32)
+	case PrimitiveU64:
+		dst = new(uint64)
+	case PrimitiveU128:
+		dst = new(*Uint128)
+	case PrimitiveU256:
+		dst = new(*Uint256)
+	default:
+		return nil, fmt.Errorf("%w: compact of primitive %d", ErrUnsupportedCompactType, primitive)
+	}
+
+	dstv := reflect.ValueOf(dst).Elem()
+	err := dd.decodeCompact(dstv)
+	if err != nil {
+		return nil, err
+	}
+	return dstv.Interface(), nil
+}
+
+// decodeBitSequence decodes a bit sequence with a u8, u16, u32 or u64 store and a Lsb0
+// or Msb0 order into a BitVec.
+func (dd *dynamicDecoder) decodeBitSequence(typeInfo *TypeInfo) (any, error) {
+	storeInfo, err := dd.registry.Type(typeInfo.BitStore)
+	if err != nil {
+		return nil, err
+	}
+	var storeSize uint
+	switch {
+	case storeInfo.Kind != TypeDefPrimitive:
+	case storeInfo.Primitive == PrimitiveU8:
+		storeSize = 1
+	case storeInfo.Primitive == PrimitiveU16:
+		storeSize = 2
+	case storeInfo.Primitive == PrimitiveU32:
+		storeSize = 4
+	case storeInfo.Primitive == PrimitiveU64:
+		storeSize = 8
+	}
+	if storeSize == 0 {
+		return nil, fmt.Errorf("%w: bit sequence store type %d", ErrInvalidTypeDefinition, typeInfo.BitStore)
+	}
+
+	orderInfo, err := dd.registry.Type(typeInfo.BitOrder)
+	if err != nil {
+		return nil, err
+	}
+	var msb0 bool
+	if len(orderInfo.Path) > 0 {
+		switch orderInfo.Path[len(orderInfo.Path)-1] {
+		case "Lsb0":
+		case "Msb0":
+			msb0 = true
+		default:
+			return nil, fmt.Errorf("%w: bit sequence order %s",
+				ErrInvalidTypeDefinition, strings.Join(orderInfo.Path, "::"))
+		}
+	}
+
+	length, err := dd.decodeLength()
+	if err != nil {
+		return nil, fmt.Errorf("decoding bit sequence length: %w", err)
+	}
+	if length > math.MaxUint32 {
+		return nil, fmt.Errorf("%w: %d", errBitVecTooLong, length)
+	}
+
+	numStores := (length + storeSize*8 - 1) / (storeSize * 8)
+	buf, err := dd.readFull(numStores * storeSize)
+	if err != nil {
+		return nil, err
+	}
+
+	bitVec := make(BitVec, length)
+	for i := range bitVec {
+		store := readStore(buf[uint(i)/(storeSize*8)*storeSize:], storeSize)
+		bit := uint(i) % (storeSize * 8)
+		if msb0 {
+			bit = storeSize*8 - 1 - bit
+		}
+		bitVec[i] = store&(1<<bit) != 0
+	}
+	return bitVec, nil
+}
+
+// readStore reads the little endian store of a bit sequence of the size given.
+func readStore(buf []byte, size uint) uint64 {
+	switch size {
+	case 1:
+		return uint64(buf[0])
+	case 2:
+		return uint64(binary.LittleEndian.Uint16(buf))
+	case 4:
+		return uint64(binary.LittleEndian.Uint32(buf))
+	default:
+		return binary.LittleEndian.Uint64(buf)
+	}
+}
+
+// EventRecord is an event of the System.Events storage value decoded from a type registry
+type EventRecord struct {
+	// Phase is the phase of the block the event was emitted in
+	Phase  any    `json:"phase"`
+	Pallet string `json:"pallet"`
+	Name   string `json:"name"`
+	// Fields is the value of the fields of the event, nil if it has no field
+	Fields any   `json:"fields"`
+	Topics []any `json:"topics"`
+}
+
+// DecodeStorage decodes the SCALE encoded value of the storage entry given.
+func (r *Registry) DecodeStorage(pallet, entry string, value []byte) (any, error) {
+	storageEntry, err := r.StorageEntry(pallet, entry)
+	if err != nil {
+		return nil, err
+	}
+	return r.Decode(storageEntry.ValueType, value)
+}
+
+// DecodeEvents decodes the SCALE encoded value of the System.Events storage entry.
+func (r *Registry) DecodeEvents(data []byte) ([]EventRecord, error) {
+	value, err := r.DecodeStorage("System", "Events", data)
+	if err != nil {
+		return nil, fmt.Errorf("decoding events: %w", err)
+	}
+
+	records, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: events are %T", ErrInvalidTypeDefinition, value)
+	}
+
+	events := make([]EventRecord, len(records))
+	for i, record := range records {
+		fields, ok := record.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%w: event record is %T", ErrInvalidTypeDefinition, record)
+		}
+
+		// the outer enum variant is the pallet, and the inner enum variant the event
+		runtimeEvent, ok := fields["event"].(Variant)
+		if !ok {
+			return nil, fmt.Errorf("%w: runtime event is %T", ErrInvalidTypeDefinition, fields["event"])
+		}
+		event, ok := runtimeEvent.Value.(Variant)
+		if !ok {
+			return nil, fmt.Errorf("%w: pallet %s event is %T",
+				ErrInvalidTypeDefinition, runtimeEvent.Name, runtimeEvent.Value)
+		}
+
+		topics, _ := fields["topics"].([]any)
+		events[i] = EventRecord{
+			Phase:  fields["phase"],
+			Pallet: runtimeEvent.Name,
+			Name:   event.Name,
+			Fields: event.Value,
+			Topics: topics,
+		}
+	}
+	return events, nil
+}
+
+// Extrinsic is an extrinsic decoded from a type registry
+type Extrinsic struct {
+	Version uint8 `json:"version"`
+	Signed  bool  `json:"signed"`
+	// Address, Signature and Extra are the values of the signature of a signed extrinsic
+	Address   any `json:"address,omitempty"`
+	Signature any `json:"signature,omitempty"`
+	Extra     any `json:"extra,omitempty"`
+	// Pallet and Name are the names of the pallet and of the call
+	Pallet string `json:"pallet"`
+	Name   string `json:"name"`
+	// Fields is the value of the arguments of the call, nil if it has no argument
+	Fields any `json:"fields"`
+}
+
+// extrinsicSignedBit is the bit of the version byte of an extrinsic set if it is signed
+const extrinsicSignedBit = 0b1000_0000
+
+// DecodeExtrinsic decodes the SCALE encoded extrinsic given, prefixed with its length
+// like in the block bodies.
+func (r *Registry) DecodeExtrinsic(data []byte) (*Extrinsic, error) {
+	if !r.extrinsic.known {
+		return nil, fmt.Errorf("%w: unknown extrinsic types", ErrUnsupportedExtrinsicFormat)
+	}
+
+	reader := bytes.NewReader(data)
+	dd := &dynamicDecoder{
+		registry:    r,
+		decodeState: decodeState{Reader: reader},
+	}
+
+	length, err := dd.decodeLength()
+	if err != nil {
+		return nil, fmt.Errorf("decoding extrinsic length: %w", err)
+	}
+	if length != uint(reader.Len()) {
+		return nil, fmt.Errorf("%w: length %d but %d bytes left",
+			ErrUnsupportedExtrinsicFormat, length, reader.Len())
+	}
+
+	versionByte, err := dd.ReadByte()
+	if err != nil {
+		return nil, fmt.Errorf("reading extrinsic version: %w", err)
+	}
+	extrinsic := &Extrinsic{
+		Version: versionByte &^ extrinsicSignedBit,
+		Signed:  versionByte&extrinsicSignedBit != 0,
+	}
+	if extrinsic.Version != r.extrinsic.version {
+		return nil, fmt.Errorf("%w: version %d, expected %d",
+			ErrUnsupportedExtrinsicFormat, extrinsic.Version, r.extrinsic.version)
+	}
+
+	if extrinsic.Signed {
+		extrinsic.Address, err = dd.decode(r.extrinsic.address, 0)
+		if err != nil {
+			return nil, fmt.Errorf("decoding address: %w", err)
+		}
+		extrinsic.Signature, err = dd.decode(r.extrinsic.signature, 0)
+		if err != nil {
+			return nil, fmt.Errorf("decoding signature: %w", err)
+		}
+		extrinsic.Extra, err = dd.decode(r.extrinsic.extra, 0)
+		if err != nil {
+			return nil, fmt.Errorf("decoding extra: %w", err)
+		}
+	}
+
+	value, err := dd.decode(r.extrinsic.call, 0)
+	if err != nil {
+		return nil, fmt.Errorf("decoding call: %w", err)
+	}
+	if reader.Len() > 0 {
+		return nil, fmt.Errorf("%w: %d bytes", ErrTrailingBytes, reader.Len())
+	}
+
+	// the outer enum variant is the pallet, and the inner enum variant the call
+	runtimeCall, ok := value.(Variant)
+	if !ok {
+		return nil, fmt.Errorf("%w: runtime call is %T", ErrInvalidTypeDefinition, value)
+	}
+	call, ok := runtimeCall.Value.(Variant)
+	if !ok {
+		return nil, fmt.Errorf("%w: pallet %s call is %T",
+			ErrInvalidTypeDefinition, runtimeCall.Name, runtimeCall.Value)
+	}
+	extrinsic.Pallet = runtimeCall.Name
+	extrinsic.Name = call.Name
+	extrinsic.Fields = call.Value
+	return extrinsic, nil
+}
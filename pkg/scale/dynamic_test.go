@@ -0,0 +1,315 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package scale
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRegistry(t *testing.T) *Registry {
+	t.Helper()
+	registry, err := NewRegistry(newTestMetadata(t, 14))
+	require.NoError(t, err)
+	return registry
+}
+
+func TestRegistry_Decode(t *testing.T) {
+	registry := newTestRegistry(t)
+	alice := bytes.Repeat([]byte{0xaa}, 32)
+
+	tests := map[string]struct {
+		typeID  uint32
+		encoded []byte
+		value   any
+	}{
+		"u8": {
+			typeID:  testTypeU8,
+			encoded: []byte{7},
+			value:   uint8(7),
+		},
+		"u64": {
+			typeID:  testTypeU64,
+			encoded: []byte{1, 0, 0, 0, 0, 0, 0, 1},
+			value:   uint64(1<<56 + 1),
+		},
+		"u128": {
+			typeID:  testTypeU128,
+			encoded: append([]byte{1, 2}, make([]byte, 14)...),
+			value:   MustNewUint128(big.NewInt(0x0201)),
+		},
+		"i128_negative": {
+			typeID:  testTypeI128,
+			encoded: bytes.Repeat([]byte{0xff}, 16),
+			value:   big.NewInt(-1),
+		},
+		"i8": {
+			typeID:  testTypeI8,
+			encoded: []byte{0xfe},
+			value:   int8(-2),
+		},
+		"bool": {
+			typeID:  testTypeBool,
+			encoded: []byte{1},
+			value:   true,
+		},
+		"str": {
+			typeID:  testTypeStr,
+			encoded: []byte{2 << 2, 'h', 'i'},
+			value:   "hi",
+		},
+		"char": {
+			typeID:  testTypeChar,
+			encoded: []byte{0xac, 0x20, 0, 0},
+			value:   "€",
+		},
+		"byte_sequence": {
+			typeID:  testTypeVecU8,
+			encoded: []byte{3 << 2, 1, 2, 3},
+			value:   Bytes{1, 2, 3},
+		},
+		"single_field_struct": {
+			typeID:  testTypeAccountID,
+			encoded: alice,
+			value:   Bytes(alice),
+		},
+		"sequence": {
+			typeID:  testTypeVecH256,
+			encoded: append([]byte{1 << 2}, alice...),
+			value:   []any{Bytes(alice)},
+		},
+		"empty_sequence": {
+			typeID:  testTypeVecH256,
+			encoded: []byte{0},
+			value:   []any{},
+		},
+		"compact": {
+			typeID:  testTypeCompactU128,
+			encoded: []byte{0x91, 0x01},
+			value:   MustNewUint128(big.NewInt(100)),
+		},
+		"compact_struct": {
+			typeID:  testTypeCompactPerbill,
+			encoded: []byte{0x91, 0x01},
+			value:   uint32(100),
+		},
+		"tuple": {
+			typeID:  testTypeTuple,
+			encoded: []byte{9, 0},
+			value:   []any{uint8(9), false},
+		},
+		"empty_tuple": {
+			typeID:  testTypeEmptyTuple,
+			encoded: []byte{},
+			value:   nil,
+		},
+		"variant_without_fields": {
+			typeID:  testTypePhase,
+			encoded: []byte{1},
+			value:   Variant{Name: "Finalization", Index: 1},
+		},
+		"variant_with_unnamed_fields": {
+			typeID:  testTypeBalancesEvent,
+			encoded: append(append([]byte{7}, alice...), 0x91, 0x01),
+			value: Variant{Name: "Deposit", Index: 7, Value: []any{
+				Bytes(alice), MustNewUint128(big.NewInt(100)),
+			}},
+		},
+		"bit_sequence_lsb0": {
+			typeID:  testTypeBitVecLsb0,
+			encoded: []byte{10 << 2, 0b11, 0b10},
+			value:   BitVec{true, true, false, false, false, false, false, false, false, true},
+		},
+		"bit_sequence_u32_msb0": {
+			typeID:  testTypeBitVecU32Msb0,
+			encoded: []byte{3 << 2, 0, 0, 0, 0b1010_0000},
+			value:   BitVec{true, false, true},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			value, err := registry.Decode(tt.typeID, tt.encoded)
+			require.NoError(t, err)
+			assert.Equal(t, tt.value, value)
+		})
+	}
+}
+
+func TestRegistry_Decode_errors(t *testing.T) {
+	registry := newTestRegistry(t)
+
+	tests := map[string]struct {
+		typeID  uint32
+		encoded []byte
+		err     error
+	}{
+		"unknown_type": {
+			typeID: 1000,
+			err:    ErrTypeNotFound,
+		},
+		"trailing_bytes": {
+			typeID:  testTypeU8,
+			encoded: []byte{1, 2},
+			err:     ErrTrailingBytes,
+		},
+		"unknown_variant": {
+			typeID:  testTypePhase,
+			encoded: []byte{3},
+			err:     ErrVariantNotFound,
+		},
+		"invalid_bool": {
+			typeID:  testTypeBool,
+			encoded: []byte{2},
+			err:     errDecodeBool,
+		},
+		"truncated_integer": {
+			typeID:  testTypeU64,
+			encoded: []byte{1, 2, 3},
+			err:     io.ErrUnexpectedEOF,
+		},
+		"truncated_bytes": {
+			typeID:  testTypeVecU8,
+			encoded: []byte{0xfe, 0xff, 0xff, 0xff, 1},
+			err:     io.ErrUnexpectedEOF,
+		},
+		"truncated_sequence": {
+			typeID:  testTypeVecH256,
+			encoded: []byte{0xfe, 0xff, 0xff, 0xff, 1},
+			err:     io.ErrUnexpectedEOF,
+		},
+		"recursive_type": {
+			typeID:  testTypeRecursive,
+			encoded: []byte{},
+			err:     ErrMaxDecodingDepth,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := registry.Decode(tt.typeID, tt.encoded)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestRegistry_DecodeEvents(t *testing.T) {
+	registry := newTestRegistry(t)
+	alice := bytes.Repeat([]byte{0xaa}, 32)
+	bob := bytes.Repeat([]byte{0xbb}, 32)
+	topic := bytes.Repeat([]byte{0x01}, 32)
+
+	encoded := testConcat(
+		[]byte{2 << 2},
+		// phase, event and topics of the first event
+		[]byte{0, 1, 0, 0, 0}, []byte{5, 2}, alice, bob, append([]byte{100}, make([]byte, 15)...),
+		[]byte{1 << 2}, topic,
+		// phase, event and topics of the second event
+		[]byte{1}, []byte{0, 0}, []byte{0},
+	)
+
+	events, err := registry.DecodeEvents(encoded)
+	require.NoError(t, err)
+	expected := []EventRecord{
+		{
+			Phase:  Variant{Name: "ApplyExtrinsic", Index: 0, Value: uint32(1)},
+			Pallet: "Balances",
+			Name:   "Transfer",
+			Fields: map[string]any{
+				"from":   Bytes(alice),
+				"to":     Bytes(bob),
+				"amount": MustNewUint128(big.NewInt(100)),
+			},
+			Topics: []any{Bytes(topic)},
+		},
+		{
+			Phase:  Variant{Name: "Finalization", Index: 1},
+			Pallet: "System",
+			Name:   "ExtrinsicSuccess",
+			Topics: []any{},
+		},
+	}
+	assert.Equal(t, expected, events)
+
+	encodedJSON, err := json.Marshal(events)
+	require.NoError(t, err)
+	const expectedJSON = `[{"phase":{"ApplyExtrinsic":1},"pallet":"Balances","name":"Transfer",` +
+		`"fields":{"amount":100,` +
+		`"from":"0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",` +
+		`"to":"0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"},` +
+		`"topics":["0x0101010101010101010101010101010101010101010101010101010101010101"]},` +
+		`{"phase":"Finalization","pallet":"System","name":"ExtrinsicSuccess","fields":null,"topics":[]}]`
+	assert.JSONEq(t, expectedJSON, string(encodedJSON))
+
+	_, err = registry.DecodeEvents([]byte{1 << 2, 0, 1, 0, 0, 0, 5, 9})
+	assert.ErrorIs(t, err, ErrVariantNotFound)
+}
+
+func TestRegistry_DecodeStorage(t *testing.T) {
+	registry := newTestRegistry(t)
+	hash := bytes.Repeat([]byte{0x02}, 32)
+
+	value, err := registry.DecodeStorage("System", "BlockHash", hash)
+	require.NoError(t, err)
+	assert.Equal(t, Bytes(hash), value)
+
+	_, err = registry.DecodeStorage("Staking", "Ledger", nil)
+	assert.ErrorIs(t, err, ErrPalletNotFound)
+}
+
+func TestRegistry_DecodeExtrinsic(t *testing.T) {
+	alice := bytes.Repeat([]byte{0xaa}, 32)
+	signature := bytes.Repeat([]byte{0x55}, 64)
+	call := testConcat([]byte{5, 0}, []byte{0}, alice, []byte{0x91, 0x01})
+
+	withLength := func(extrinsic []byte) []byte {
+		length, err := NewCompact(uint32(len(extrinsic))).MarshalSCALE()
+		require.NoError(t, err)
+		return append(length, extrinsic...)
+	}
+	expectedCall := map[string]any{
+		"dest":  Variant{Name: "Id", Index: 0, Value: Bytes(alice)},
+		"value": MustNewUint128(big.NewInt(100)),
+	}
+
+	for _, version := range []byte{14, 15} {
+		registry, err := NewRegistry(newTestMetadata(t, version))
+		require.NoError(t, err)
+
+		extrinsic, err := registry.DecodeExtrinsic(withLength(append([]byte{4}, call...)))
+		require.NoError(t, err)
+		assert.Equal(t, &Extrinsic{
+			Version: 4,
+			Pallet:  "Balances",
+			Name:    "transfer",
+			Fields:  expectedCall,
+		}, extrinsic)
+
+		signed := testConcat([]byte{0x84}, []byte{0}, alice, []byte{1}, signature, []byte{3 << 2}, call)
+		extrinsic, err = registry.DecodeExtrinsic(withLength(signed))
+		require.NoError(t, err)
+		assert.Equal(t, &Extrinsic{
+			Version:   4,
+			Signed:    true,
+			Address:   Variant{Name: "Id", Index: 0, Value: Bytes(alice)},
+			Signature: Variant{Name: "Sr25519", Index: 1, Value: Bytes(signature)},
+			Extra:     []any{uint32(3)},
+			Pallet:    "Balances",
+			Name:      "transfer",
+			Fields:    expectedCall,
+		}, extrinsic)
+
+		_, err = registry.DecodeExtrinsic(withLength(append([]byte{5}, call...)))
+		assert.ErrorIs(t, err, ErrUnsupportedExtrinsicFormat)
+
+		_, err = registry.DecodeExtrinsic(append([]byte{4}, call...))
+		assert.ErrorIs(t, err, ErrUnsupportedExtrinsicFormat)
+	}
+}
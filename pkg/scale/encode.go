@@ -95,6 +95,10 @@ func (es *encodeState) marshal(in interface{}) (err error) {
 		err = es.encodeBigInt(in)
 	case *Uint128:
 		err = es.encodeUint128(in)
+	case *Uint256:
+		err = es.encodeUint256(in)
+	case BitVec:
+		err = es.encodeBitVec(in)
 	case []byte:
 		err = es.encodeBytes(in)
 	case string:
@@ -367,6 +371,13 @@ func (es *encodeState) encodeStruct(in interface{}) (err error) {
 		if !field.CanInterface() {
 			continue
 		}
+		if i.compact {
+			err = es.encodeCompact(field)
+			if err != nil {
+				return fmt.Errorf("encoding compact field at index %d: %w", i.fieldIndex, err)
+			}
+			continue
+		}
 		err = es.marshal(field.Interface())
 		if err != nil {
 			return
@@ -430,3 +441,24 @@ func (es *encodeState) encodeUint128(i *Uint128) (err error) {
 	err = binary.Write(es, binary.LittleEndian, padBytes(i.Bytes(), binary.LittleEndian))
 	return
 }
+
+// encodeUint256 encodes a Uint256
+func (es *encodeState) encodeUint256(i *Uint256) (err error) {
+	if i == nil {
+		err = fmt.Errorf("%w", errUint256IsNil)
+		return
+	}
+	_, err = es.Write(i.fixedBytes(binary.LittleEndian))
+	return
+}
+
+// encodeBitVec encodes a BitVec as the number of bits compact encoded, followed by
+// the bits packed in bytes
+func (es *encodeState) encodeBitVec(bv BitVec) (err error) {
+	err = es.encodeLength(len(bv))
+	if err != nil {
+		return
+	}
+	_, err = es.Write(bv.Bytes())
+	return
+}
@@ -21,4 +21,22 @@
 	ErrVaryingDataTypeNotSet           = errors.New("varying data type not set")
 	ErrUnsupportedCustomPrimitive      = errors.New("unsupported type for custom primitive")
 	ErrInvalidScaleIndex               = errors.New("invalid scale index")
+	ErrInvalidScaleTagOption           = errors.New("invalid scale tag option")
+	ErrUnsupportedCompactType          = errors.New("unsupported type for compact encoding")
+	errCompactOutOfRange               = errors.New("compact integer out of range")
+	errUint256IsNil                    = errors.New("uint256 is nil")
+	errUint256OutOfRange               = errors.New("uint256 out of range")
+	errBitVecTooShort                  = errors.New("not enough bytes for bit vector")
+	errBitVecTooLong                   = errors.New("bit vector length exceeds max value of uint32")
+	ErrMetadataMagicNumber             = errors.New("metadata does not start with the magic number")
+	ErrMetadataVersion                 = errors.New("metadata version not supported")
+	ErrTypeNotFound                    = errors.New("type not found in registry")
+	ErrPalletNotFound                  = errors.New("pallet not found in metadata")
+	ErrStorageEntryNotFound            = errors.New("storage entry not found in metadata")
+	ErrConstantNotFound                = errors.New("constant not found in metadata")
+	ErrVariantNotFound                 = errors.New("variant not found")
+	ErrInvalidTypeDefinition           = errors.New("invalid type definition")
+	ErrMaxDecodingDepth                = errors.New("max decoding depth reached")
+	ErrTrailingBytes                   = errors.New("trailing bytes after decoded value")
+	ErrUnsupportedExtrinsicFormat      = errors.New("unsupported extrinsic format")
 )
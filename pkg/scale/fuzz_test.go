@@ -0,0 +1,89 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package scale
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The seed corpus of the fuzz tests is in testdata/fuzz, and runs with the unit tests.
+
+func Fuzz_BitVec_RoundTrip(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var bitVec BitVec
+		err := Unmarshal(data, &bitVec)
+		if err != nil {
+			return
+		}
+
+		encoded, err := Marshal(bitVec)
+		require.NoError(t, err)
+
+		var decoded BitVec
+		err = Unmarshal(encoded, &decoded)
+		require.NoError(t, err)
+		assert.Equal(t, bitVec, decoded)
+	})
+}
+
+func Fuzz_Compact_RoundTrip(f *testing.F) {
+	f.Fuzz(func(t *testing.T, value uint64) {
+		encoded, err := Marshal(NewCompact(value))
+		require.NoError(t, err)
+
+		var uintEncoded []byte
+		uintEncoded, err = Marshal(uint(value))
+		require.NoError(t, err)
+		assert.Equal(t, uintEncoded, encoded)
+
+		var decoded Compact[uint64]
+		err = Unmarshal(encoded, &decoded)
+		require.NoError(t, err)
+		assert.Equal(t, value, decoded.Value)
+	})
+}
+
+func Fuzz_Compact_Unmarshal(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var decoded Compact[*Uint256]
+		err := Unmarshal(data, &decoded)
+		if err != nil {
+			return
+		}
+
+		encoded, err := Marshal(decoded)
+		require.NoError(t, err)
+
+		var reDecoded Compact[*Uint256]
+		err = Unmarshal(encoded, &reDecoded)
+		require.NoError(t, err)
+		assert.Equal(t, decoded, reDecoded)
+	})
+}
+
+func Fuzz_Uint256_RoundTrip(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if len(data) > uint256Length {
+			data = data[:uint256Length]
+		}
+		value := new(big.Int).SetBytes(data)
+
+		u, err := NewUint256(value)
+		require.NoError(t, err)
+		assert.Zero(t, value.Cmp(u.BigInt()))
+
+		encoded, err := Marshal(u)
+		require.NoError(t, err)
+		require.Len(t, encoded, uint256Length)
+
+		var decoded *Uint256
+		err = Unmarshal(encoded, &decoded)
+		require.NoError(t, err)
+		assert.Equal(t, u, decoded)
+	})
+}
@@ -0,0 +1,819 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package scale
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// metadataMagicNumber is the magic number prefixing the runtime metadata, "meta" in ASCII
+var metadataMagicNumber = []byte("meta")
+
+// TypeDefKind is the kind of the definition of a type of a type registry
+type TypeDefKind uint8
+
+const (
+	// TypeDefComposite is a struct or a tuple struct
+	TypeDefComposite TypeDefKind = iota
+	// TypeDefVariant is an enum
+	TypeDefVariant
+	// TypeDefSequence is a vector of elements of the same type
+	TypeDefSequence
+	// TypeDefArray is a fixed length array of elements of the same type
+	TypeDefArray
+	// TypeDefTuple is a tuple
+	TypeDefTuple
+	// TypeDefPrimitive is a primitive type
+	TypeDefPrimitive
+	// TypeDefCompact is a compact encoded integer
+	TypeDefCompact
+	// TypeDefBitSequence is a bit vector
+	TypeDefBitSequence
+)
+
+// Primitive is a primitive type of a type registry
+type Primitive uint8
+
+const (
+	PrimitiveBool Primitive = iota
+	PrimitiveChar
+	PrimitiveStr
+	PrimitiveU8
+	PrimitiveU16
+	PrimitiveU32
+	PrimitiveU64
+	PrimitiveU128
+	PrimitiveU256
+	PrimitiveI8
+	PrimitiveI16
+	PrimitiveI32
+	PrimitiveI64
+	PrimitiveI128
+	PrimitiveI256
+)
+
+// TypeParam is a generic type parameter of a type of a type registry
+type TypeParam struct {
+	Name string
+	// Type is the type identifier of the parameter, or nil if it is not used
+	Type *uint32
+}
+
+// Field is a field of a struct or of an enum variant of a type registry
+type Field struct {
+	// Name is the name of the field, empty for the fields of tuple structs
+	Name     string
+	Type     uint32
+	TypeName string
+}
+
+// TypeVariant is a variant of an enum of a type registry
+type TypeVariant struct {
+	Name   string
+	Index  uint8
+	Fields []Field
+}
+
+// TypeInfo is a type of a type registry, as described by the scale-info Rust crate
+type TypeInfo struct {
+	ID     uint32
+	Path   []string
+	Params []TypeParam
+	Kind   TypeDefKind
+
+	// Fields are the fields of a composite
+	Fields []Field
+	// Variants are the variants of a variant
+	Variants []TypeVariant
+	// Elem is the type of the elements of a sequence or array, or of the compact integer
+	Elem uint32
+	// Len is the length of an array
+	Len uint32
+	// Tuple holds the types of the elements of a tuple
+	Tuple []uint32
+	// Primitive is the primitive type of a primitive
+	Primitive Primitive
+	// BitStore and BitOrder are the types of the store and of the order of a bit sequence
+	BitStore uint32
+	BitOrder uint32
+}
+
+// variant returns the variant of the index given.
+func (ti *TypeInfo) variant(index uint8) (*TypeVariant, error) {
+	for i := range ti.Variants {
+		if ti.Variants[i].Index == index {
+			return &ti.Variants[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: index %d of type %d", ErrVariantNotFound, index, ti.ID)
+}
+
+// param returns the type of the type parameter of the name given.
+func (ti *TypeInfo) param(name string) (typeID uint32, ok bool) {
+	for _, param := range ti.Params {
+		if param.Name == name && param.Type != nil {
+			return *param.Type, true
+		}
+	}
+	return 0, false
+}
+
+// StorageEntry is a storage entry of a pallet
+type StorageEntry struct {
+	Name string
+	// KeyType is the type of the keys of a storage map, or nil for a plain storage value
+	KeyType *uint32
+	// ValueType is the type of the values of the storage entry
+	ValueType uint32
+}
+
+// Constant is a constant of a pallet
+type Constant struct {
+	Name string
+	Type uint32
+	// Value is the SCALE encoded value of the constant
+	Value []byte
+}
+
+// Pallet is a pallet of the runtime metadata
+type Pallet struct {
+	Name      string
+	Index     uint8
+	Storage   []StorageEntry
+	Constants []Constant
+	// CallType, EventType and ErrorType are the types of the calls, events and errors
+	// of the pallet, nil if the pallet has none
+	CallType  *uint32
+	EventType *uint32
+	ErrorType *uint32
+}
+
+// SignedExtension is a signed extension of the extrinsics, whose extra data is included in
+// the signed extrinsics and whose additional signed data is only included in the signed payload
+type SignedExtension struct {
+	Identifier string
+	// Type is the type of the extra data
+	Type uint32
+	// AdditionalSigned is the type of the additional signed data
+	AdditionalSigned uint32
+}
+
+// extrinsicTypes holds the types of the parts of the extrinsics
+type extrinsicTypes struct {
+	// known is set if the types of the parts of the extrinsics are known
+	known     bool
+	version   uint8
+	address   uint32
+	call      uint32
+	signature uint32
+	extra     uint32
+
+	signedExtensions []SignedExtension
+}
+
+// Registry holds the types of the portable type registry of the runtime metadata, from
+// the version 14, to decode SCALE encoded values without Go types.
+type Registry struct {
+	types     map[uint32]*TypeInfo
+	pallets   []Pallet
+	extrinsic extrinsicTypes
+}
+
+// NewRegistry parses the runtime metadata given, starting with the metadata magic
+// number, and returns its type registry. Metadata versions 14 and 15 are supported.
+func NewRegistry(metadata []byte) (*Registry, error) {
+	if !bytes.HasPrefix(metadata, metadataMagicNumber) {
+		return nil, ErrMetadataMagicNumber
+	}
+
+	mr := &metadataReader{decodeState{Reader: bytes.NewReader(metadata[len(metadataMagicNumber):])}}
+	version, err := mr.ReadByte()
+	if err != nil {
+		return nil, fmt.Errorf("reading metadata version: %w", err)
+	}
+	if version != 14 && version != 15 {
+		return nil, fmt.Errorf("%w: %d", ErrMetadataVersion, version)
+	}
+
+	registry := &Registry{}
+	registry.types, err = mr.readTypes()
+	if err != nil {
+		return nil, fmt.Errorf("reading types: %w", err)
+	}
+
+	registry.pallets, err = mr.readPallets(version)
+	if err != nil {
+		return nil, fmt.Errorf("reading pallets: %w", err)
+	}
+
+	if version == 14 {
+		registry.extrinsic, err = mr.readExtrinsicV14(registry.types)
+	} else {
+		registry.extrinsic, err = mr.readExtrinsicV15()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading extrinsic metadata: %w", err)
+	}
+
+	return registry, nil
+}
+
+// Type returns the type of the identifier given.
+func (r *Registry) Type(typeID uint32) (*TypeInfo, error) {
+	typeInfo, ok := r.types[typeID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrTypeNotFound, typeID)
+	}
+	return typeInfo, nil
+}
+
+// ExtrinsicVersion returns the version of the extrinsics format of the runtime metadata.
+func (r *Registry) ExtrinsicVersion() uint8 {
+	return r.extrinsic.version
+}
+
+// SignedExtensions returns the signed extensions of the extrinsics, in their order in the
+// extrinsics.
+func (r *Registry) SignedExtensions() []SignedExtension {
+	return r.extrinsic.signedExtensions
+}
+
+// Pallets returns the pallets of the runtime metadata.
+func (r *Registry) Pallets() []Pallet {
+	return r.pallets
+}
+
+// Pallet returns the pallet of the name given.
+func (r *Registry) Pallet(name string) (*Pallet, error) {
+	for i := range r.pallets {
+		if r.pallets[i].Name == name {
+			return &r.pallets[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrPalletNotFound, name)
+}
+
+// StorageEntry returns the storage entry of the pallet and entry names given.
+func (r *Registry) StorageEntry(pallet, entry string) (*StorageEntry, error) {
+	p, err := r.Pallet(pallet)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range p.Storage {
+		if p.Storage[i].Name == entry {
+			return &p.Storage[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s.%s", ErrStorageEntryNotFound, pallet, entry)
+}
+
+// Constant returns the constant of the pallet and constant names given.
+func (r *Registry) Constant(pallet, name string) (*Constant, error) {
+	p, err := r.Pallet(pallet)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range p.Constants {
+		if p.Constants[i].Name == name {
+			return &p.Constants[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s.%s", ErrConstantNotFound, pallet, name)
+}
+
+// metadataReader reads the parts of the runtime metadata
+type metadataReader struct {
+	decodeState
+}
+
+func (mr *metadataReader) readCompact() (uint32, error) {
+	value, err := mr.decodeLength()
+	if err != nil {
+		return 0, err
+	}
+	if value > math.MaxUint32 {
+		return 0, fmt.Errorf("%w: %d does not fit in uint32", errCompactOutOfRange, value)
+	}
+	return uint32(value), nil
+}
+
+func (mr *metadataReader) readBytes() ([]byte, error) {
+	length, err := mr.readLength()
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, length)
+	_, err = io.ReadFull(mr, b)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (mr *metadataReader) readString() (string, error) {
+	b, err := mr.readBytes()
+	return string(b), err
+}
+
+func (mr *metadataReader) readBool() (bool, error) {
+	b, err := mr.ReadByte()
+	if err != nil {
+		return false, err
+	}
+	switch b {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	default:
+		return false, fmt.Errorf("%w: %d", errDecodeBool, b)
+	}
+}
+
+func (mr *metadataReader) readU32() (uint32, error) {
+	buf := make([]byte, 4)
+	_, err := io.ReadFull(mr, buf)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(buf), nil
+}
+
+// readOption reads the option byte, and returns true if a value follows.
+func (mr *metadataReader) readOption() (some bool, err error) {
+	return mr.readBool()
+}
+
+func (mr *metadataReader) readOptionalString() (string, error) {
+	some, err := mr.readOption()
+	if err != nil || !some {
+		return "", err
+	}
+	return mr.readString()
+}
+
+func (mr *metadataReader) readOptionalCompact() (*uint32, error) {
+	some, err := mr.readOption()
+	if err != nil || !some {
+		return nil, err
+	}
+	value, err := mr.readCompact()
+	if err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+// readLength reads a compact encoded length, checking there are at least as many bytes
+// left to read, each element being at least one byte long.
+func (mr *metadataReader) readLength() (uint, error) {
+	length, err := mr.decodeLength()
+	if err != nil {
+		return 0, err
+	}
+	if reader, ok := mr.Reader.(*bytes.Reader); ok && length > uint(reader.Len()) {
+		return 0, fmt.Errorf("%w: length %d exceeds remaining %d bytes",
+			io.ErrUnexpectedEOF, length, reader.Len())
+	}
+	return length, nil
+}
+
+func (mr *metadataReader) readStrings() ([]string, error) {
+	length, err := mr.readLength()
+	if err != nil {
+		return nil, err
+	}
+	strs := make([]string, length)
+	for i := range strs {
+		strs[i], err = mr.readString()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return strs, nil
+}
+
+func (mr *metadataReader) readTypes() (map[uint32]*TypeInfo, error) {
+	length, err := mr.readLength()
+	if err != nil {
+		return nil, err
+	}
+
+	types := make(map[uint32]*TypeInfo, length)
+	for i := uint(0); i < length; i++ {
+		typeInfo, err := mr.readType()
+		if err != nil {
+			return nil, fmt.Errorf("reading type %d: %w", i, err)
+		}
+		types[typeInfo.ID] = typeInfo
+	}
+	return types, nil
+}
+
+func (mr *metadataReader) readType() (typeInfo *TypeInfo, err error) {
+	typeInfo = &TypeInfo{}
+	typeInfo.ID, err = mr.readCompact()
+	if err != nil {
+		return nil, fmt.Errorf("reading id: %w", err)
+	}
+
+	typeInfo.Path, err = mr.readStrings()
+	if err != nil {
+		return nil, fmt.Errorf("reading path: %w", err)
+	}
+
+	numParams, err := mr.readLength()
+	if err != nil {
+		return nil, fmt.Errorf("reading type parameters: %w", err)
+	}
+	typeInfo.Params = make([]TypeParam, numParams)
+	for i := range typeInfo.Params {
+		typeInfo.Params[i].Name, err = mr.readString()
+		if err != nil {
+			return nil, fmt.Errorf("reading type parameter name: %w", err)
+		}
+		typeInfo.Params[i].Type, err = mr.readOptionalCompact()
+		if err != nil {
+			return nil, fmt.Errorf("reading type parameter type: %w", err)
+		}
+	}
+
+	err = mr.readTypeDef(typeInfo)
+	if err != nil {
+		return nil, fmt.Errorf("reading definition: %w", err)
+	}
+
+	_, err = mr.readStrings()
+	if err != nil {
+		return nil, fmt.Errorf("reading docs: %w", err)
+	}
+
+	return typeInfo, nil
+}
+
+func (mr *metadataReader) readTypeDef(typeInfo *TypeInfo) (err error) {
+	kind, err := mr.ReadByte()
+	if err != nil {
+		return err
+	}
+	typeInfo.Kind = TypeDefKind(kind)
+
+	switch typeInfo.Kind {
+	case TypeDefComposite:
+		typeInfo.Fields, err = mr.readFields()
+	case TypeDefVariant:
+		typeInfo.Variants, err = mr.readVariants()
+	case TypeDefSequence, TypeDefCompact:
+		typeInfo.Elem, err = mr.readCompact()
+	case TypeDefArray:
+		typeInfo.Len, err = mr.readU32()
+		if err != nil {
+			return err
+		}
+		typeInfo.Elem, err = mr.readCompact()
+	case TypeDefTuple:
+		var length uint
+		length, err = mr.readLength()
+		if err != nil {
+			return err
+		}
+		typeInfo.Tuple = make([]uint32, length)
+		for i := range typeInfo.Tuple {
+			typeInfo.Tuple[i], err = mr.readCompact()
+			if err != nil {
+				return err
+			}
+		}
+	case TypeDefPrimitive:
+		var primitive byte
+		primitive, err = mr.ReadByte()
+		if err != nil {
+			return err
+		}
+		if Primitive(primitive) > PrimitiveI256 {
+			return fmt.Errorf("%w: primitive %d", ErrInvalidTypeDefinition, primitive)
+		}
+		typeInfo.Primitive = Primitive(primitive)
+	case TypeDefBitSequence:
+		typeInfo.BitStore, err = mr.readCompact()
+		if err != nil {
+			return err
+		}
+		typeInfo.BitOrder, err = mr.readCompact()
+	default:
+		return fmt.Errorf("%w: kind %d", ErrInvalidTypeDefinition, kind)
+	}
+	return err
+}
+
+func (mr *metadataReader) readFields() ([]Field, error) {
+	length, err := mr.readLength()
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]Field, length)
+	for i := range fields {
+		fields[i].Name, err = mr.readOptionalString()
+		if err != nil {
+			return nil, fmt.Errorf("reading field name: %w", err)
+		}
+		fields[i].Type, err = mr.readCompact()
+		if err != nil {
+			return nil, fmt.Errorf("reading field type: %w", err)
+		}
+		fields[i].TypeName, err = mr.readOptionalString()
+		if err != nil {
+			return nil, fmt.Errorf("reading field type name: %w", err)
+		}
+		_, err = mr.readStrings()
+		if err != nil {
+			return nil, fmt.Errorf("reading field docs: %w", err)
+		}
+	}
+	return fields, nil
+}
+
+func (mr *metadataReader) readVariants() ([]TypeVariant, error) {
+	length, err := mr.readLength()
+	if err != nil {
+		return nil, err
+	}
+
+	variants := make([]TypeVariant, length)
+	for i := range variants {
+		variants[i].Name, err = mr.readString()
+		if err != nil {
+			return nil, fmt.Errorf("reading variant name: %w", err)
+		}
+		variants[i].Fields, err = mr.readFields()
+		if err != nil {
+			return nil, fmt.Errorf("reading variant fields: %w", err)
+		}
+		variants[i].Index, err = mr.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("reading variant index: %w", err)
+		}
+		_, err = mr.readStrings()
+		if err != nil {
+			return nil, fmt.Errorf("reading variant docs: %w", err)
+		}
+	}
+	return variants, nil
+}
+
+func (mr *metadataReader) readPallets(version byte) ([]Pallet, error) {
+	length, err := mr.readLength()
+	if err != nil {
+		return nil, err
+	}
+
+	pallets := make([]Pallet, length)
+	for i := range pallets {
+		err = mr.readPallet(&pallets[i], version)
+		if err != nil {
+			return nil, fmt.Errorf("reading pallet %d: %w", i, err)
+		}
+	}
+	return pallets, nil
+}
+
+func (mr *metadataReader) readPallet(pallet *Pallet, version byte) (err error) {
+	pallet.Name, err = mr.readString()
+	if err != nil {
+		return fmt.Errorf("reading name: %w", err)
+	}
+
+	hasStorage, err := mr.readOption()
+	if err != nil {
+		return fmt.Errorf("reading storage: %w", err)
+	}
+	if hasStorage {
+		pallet.Storage, err = mr.readStorage()
+		if err != nil {
+			return fmt.Errorf("reading storage of pallet %s: %w", pallet.Name, err)
+		}
+	}
+
+	pallet.CallType, err = mr.readOptionalCompact()
+	if err != nil {
+		return fmt.Errorf("reading calls: %w", err)
+	}
+	pallet.EventType, err = mr.readOptionalCompact()
+	if err != nil {
+		return fmt.Errorf("reading events: %w", err)
+	}
+
+	numConstants, err := mr.readLength()
+	if err != nil {
+		return fmt.Errorf("reading constants: %w", err)
+	}
+	pallet.Constants = make([]Constant, numConstants)
+	for i := range pallet.Constants {
+		constant := &pallet.Constants[i]
+		constant.Name, err = mr.readString()
+		if err != nil {
+			return fmt.Errorf("reading constant name: %w", err)
+		}
+		constant.Type, err = mr.readCompact()
+		if err != nil {
+			return fmt.Errorf("reading constant type: %w", err)
+		}
+		constant.Value, err = mr.readBytes()
+		if err != nil {
+			return fmt.Errorf("reading constant value: %w", err)
+		}
+		_, err = mr.readStrings()
+		if err != nil {
+			return fmt.Errorf("reading constant docs: %w", err)
+		}
+	}
+
+	pallet.ErrorType, err = mr.readOptionalCompact()
+	if err != nil {
+		return fmt.Errorf("reading errors: %w", err)
+	}
+
+	pallet.Index, err = mr.ReadByte()
+	if err != nil {
+		return fmt.Errorf("reading index: %w", err)
+	}
+
+	if version >= 15 {
+		_, err = mr.readStrings()
+		if err != nil {
+			return fmt.Errorf("reading docs: %w", err)
+		}
+	}
+	return nil
+}
+
+func (mr *metadataReader) readStorage() ([]StorageEntry, error) {
+	_, err := mr.readString()
+	if err != nil {
+		return nil, fmt.Errorf("reading prefix: %w", err)
+	}
+
+	length, err := mr.readLength()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]StorageEntry, length)
+	for i := range entries {
+		entries[i].Name, err = mr.readString()
+		if err != nil {
+			return nil, fmt.Errorf("reading entry name: %w", err)
+		}
+
+		// modifier
+		_, err = mr.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("reading entry modifier: %w", err)
+		}
+
+		entryType, err := mr.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("reading entry type: %w", err)
+		}
+		switch entryType {
+		case 0:
+			entries[i].ValueType, err = mr.readCompact()
+			if err != nil {
+				return nil, fmt.Errorf("reading entry value type: %w", err)
+			}
+		case 1:
+			// hashers
+			_, err = mr.readBytes()
+			if err != nil {
+				return nil, fmt.Errorf("reading entry hashers: %w", err)
+			}
+			keyType, err := mr.readCompact()
+			if err != nil {
+				return nil, fmt.Errorf("reading entry key type: %w", err)
+			}
+			entries[i].KeyType = &keyType
+			entries[i].ValueType, err = mr.readCompact()
+			if err != nil {
+				return nil, fmt.Errorf("reading entry value type: %w", err)
+			}
+		default:
+			return nil, fmt.Errorf("%w: storage entry type %d", ErrInvalidTypeDefinition, entryType)
+		}
+
+		// default value
+		_, err = mr.readBytes()
+		if err != nil {
+			return nil, fmt.Errorf("reading entry default: %w", err)
+		}
+		_, err = mr.readStrings()
+		if err != nil {
+			return nil, fmt.Errorf("reading entry docs: %w", err)
+		}
+	}
+	return entries, nil
+}
+
+// readExtrinsicV14 reads the extrinsic metadata of the metadata version 14, where the
+// types of the parts of the extrinsics are the type parameters of the extrinsic type,
+// which may be wrapped in single field structs. The extrinsic types are left unknown
+// if they cannot be found.
+func (mr *metadataReader) readExtrinsicV14(types map[uint32]*TypeInfo) (extrinsic extrinsicTypes, err error) {
+	extrinsicType, err := mr.readCompact()
+	if err != nil {
+		return extrinsic, fmt.Errorf("reading type: %w", err)
+	}
+	extrinsic.version, err = mr.ReadByte()
+	if err != nil {
+		return extrinsic, fmt.Errorf("reading version: %w", err)
+	}
+
+	extrinsic.signedExtensions, err = mr.readSignedExtensions()
+	if err != nil {
+		return extrinsic, fmt.Errorf("reading signed extensions: %w", err)
+	}
+
+	for depth := 0; depth < maxDecodingDepth; depth++ {
+		typeInfo, ok := types[extrinsicType]
+		if !ok {
+			return extrinsic, fmt.Errorf("%w: %d", ErrTypeNotFound, extrinsicType)
+		}
+
+		params := []struct {
+			name   string
+			typeID *uint32
+		}{
+			{name: "Address", typeID: &extrinsic.address},
+			{name: "Call", typeID: &extrinsic.call},
+			{name: "Signature", typeID: &extrinsic.signature},
+			{name: "Extra", typeID: &extrinsic.extra},
+		}
+		extrinsic.known = true
+		for _, param := range params {
+			typeID, ok := typeInfo.param(param.name)
+			if !ok {
+				extrinsic.known = false
+				break
+			}
+			*param.typeID = typeID
+		}
+		if extrinsic.known || typeInfo.Kind != TypeDefComposite || len(typeInfo.Fields) != 1 {
+			break
+		}
+		extrinsicType = typeInfo.Fields[0].Type
+	}
+	return extrinsic, nil
+}
+
+// readExtrinsicV15 reads the extrinsic metadata of the metadata version 15.
+func (mr *metadataReader) readExtrinsicV15() (extrinsic extrinsicTypes, err error) {
+	extrinsic.version, err = mr.ReadByte()
+	if err != nil {
+		return extrinsic, fmt.Errorf("reading version: %w", err)
+	}
+
+	for _, typeID := range []*uint32{&extrinsic.address, &extrinsic.call, &extrinsic.signature, &extrinsic.extra} {
+		*typeID, err = mr.readCompact()
+		if err != nil {
+			return extrinsic, fmt.Errorf("reading type: %w", err)
+		}
+	}
+
+	extrinsic.known = true
+
+	extrinsic.signedExtensions, err = mr.readSignedExtensions()
+	if err != nil {
+		return extrinsic, fmt.Errorf("reading signed extensions: %w", err)
+	}
+	return extrinsic, nil
+}
+
+func (mr *metadataReader) readSignedExtensions() ([]SignedExtension, error) {
+	length, err := mr.readLength()
+	if err != nil {
+		return nil, err
+	}
+	extensions := make([]SignedExtension, length)
+	for i := range extensions {
+		extensions[i].Identifier, err = mr.readString()
+		if err != nil {
+			return nil, fmt.Errorf("reading identifier: %w", err)
+		}
+		extensions[i].Type, err = mr.readCompact()
+		if err != nil {
+			return nil, fmt.Errorf("reading type: %w", err)
+		}
+		extensions[i].AdditionalSigned, err = mr.readCompact()
+		if err != nil {
+			return nil, fmt.Errorf("reading additional signed type: %w", err)
+		}
+	}
+	return extensions, nil
+}
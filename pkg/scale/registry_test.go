@@ -0,0 +1,394 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package scale
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Type identifiers of the test metadata built by newTestMetadata
+const (
+	testTypeU8 uint32 = iota
+	testTypeU32
+	testTypeU64
+	testTypeAccountID
+	testTypeBytes32
+	testTypeU128
+	testTypeCompactU128
+	testTypeVecU8
+	testTypeBool
+	testTypeStr
+	testTypeBalancesEvent
+	testTypeSystemEvent
+	testTypeH256
+	testTypeRuntimeEvent
+	testTypePhase
+	testTypeVecH256
+	testTypeEventRecord
+	testTypeVecEventRecord
+	testTypeBitVecLsb0
+	testTypeLsb0
+	testTypeMsb0
+	testTypeBitVecU32Msb0
+	testTypeI128
+	testTypeTuple
+	testTypeEmptyTuple
+	testTypeChar
+	testTypeI8
+	testTypeBalancesCall
+	testTypeMultiAddress
+	testTypeRuntimeCall
+	testTypeSignature
+	testTypeBytes64
+	testTypeExtra
+	testTypeCompactU32
+	testTypeUncheckedExtrinsic
+	testTypeRecursive
+	testTypeCompactPerbill
+	testTypePerbill
+)
+
+func testCompact(t *testing.T, value uint32) []byte {
+	t.Helper()
+	encoded, err := NewCompact(value).MarshalSCALE()
+	require.NoError(t, err)
+	return encoded
+}
+
+func testMarshal(t *testing.T, value any) []byte {
+	t.Helper()
+	encoded, err := Marshal(value)
+	require.NoError(t, err)
+	return encoded
+}
+
+func testConcat(parts ...[]byte) []byte {
+	return bytes.Join(parts, nil)
+}
+
+// testMetadataBuilder SCALE encodes the parts of the runtime metadata
+type testMetadataBuilder struct {
+	t *testing.T
+}
+
+func (b testMetadataBuilder) optionalString(s string) []byte {
+	if s == "" {
+		return []byte{0}
+	}
+	return testConcat([]byte{1}, testMarshal(b.t, s))
+}
+
+func (b testMetadataBuilder) list(items ...[]byte) []byte {
+	return testConcat(testCompact(b.t, uint32(len(items))), testConcat(items...))
+}
+
+func (b testMetadataBuilder) field(name string, typeID uint32) []byte {
+	return testConcat(b.optionalString(name), testCompact(b.t, typeID), b.optionalString(""), b.list())
+}
+
+func (b testMetadataBuilder) variant(name string, index uint8, fields ...[]byte) []byte {
+	return testConcat(testMarshal(b.t, name), b.list(fields...), []byte{index}, b.list())
+}
+
+func (b testMetadataBuilder) param(name string, typeID uint32) []byte {
+	return testConcat(testMarshal(b.t, name), []byte{1}, testCompact(b.t, typeID))
+}
+
+func (b testMetadataBuilder) typ(id uint32, path []string, params [][]byte, def []byte) []byte {
+	return testConcat(testCompact(b.t, id), testMarshal(b.t, path), b.list(params...), def,
+		b.list(testMarshal(b.t, "docs")))
+}
+
+func (b testMetadataBuilder) composite(fields ...[]byte) []byte {
+	return testConcat([]byte{byte(TypeDefComposite)}, b.list(fields...))
+}
+
+func (b testMetadataBuilder) variants(variants ...[]byte) []byte {
+	return testConcat([]byte{byte(TypeDefVariant)}, b.list(variants...))
+}
+
+func (b testMetadataBuilder) sequence(typeID uint32) []byte {
+	return testConcat([]byte{byte(TypeDefSequence)}, testCompact(b.t, typeID))
+}
+
+func (b testMetadataBuilder) array(length uint32, typeID uint32) []byte {
+	return testConcat([]byte{byte(TypeDefArray)}, binary.LittleEndian.AppendUint32(nil, length),
+		testCompact(b.t, typeID))
+}
+
+func (b testMetadataBuilder) tuple(typeIDs ...uint32) []byte {
+	elems := make([][]byte, len(typeIDs))
+	for i, typeID := range typeIDs {
+		elems[i] = testCompact(b.t, typeID)
+	}
+	return testConcat([]byte{byte(TypeDefTuple)}, b.list(elems...))
+}
+
+func (b testMetadataBuilder) primitive(primitive Primitive) []byte {
+	return []byte{byte(TypeDefPrimitive), byte(primitive)}
+}
+
+func (b testMetadataBuilder) compact(typeID uint32) []byte {
+	return testConcat([]byte{byte(TypeDefCompact)}, testCompact(b.t, typeID))
+}
+
+func (b testMetadataBuilder) bitSequence(store, order uint32) []byte {
+	return testConcat([]byte{byte(TypeDefBitSequence)}, testCompact(b.t, store), testCompact(b.t, order))
+}
+
+// newTestMetadata returns a SCALE encoded runtime metadata of the version given, with
+// a System pallet with the Events storage value and a Balances pallet.
+func newTestMetadata(t *testing.T, version byte) []byte {
+	t.Helper()
+	b := testMetadataBuilder{t: t}
+
+	types := [][]byte{
+		b.typ(testTypeU8, nil, nil, b.primitive(PrimitiveU8)),
+		b.typ(testTypeU32, nil, nil, b.primitive(PrimitiveU32)),
+		b.typ(testTypeU64, nil, nil, b.primitive(PrimitiveU64)),
+		b.typ(testTypeAccountID, []string{"sp_core", "crypto", "AccountId32"}, nil,
+			b.composite(b.field("", testTypeBytes32))),
+		b.typ(testTypeBytes32, nil, nil, b.array(32, testTypeU8)),
+		b.typ(testTypeU128, nil, nil, b.primitive(PrimitiveU128)),
+		b.typ(testTypeCompactU128, nil, nil, b.compact(testTypeU128)),
+		b.typ(testTypeVecU8, nil, nil, b.sequence(testTypeU8)),
+		b.typ(testTypeBool, nil, nil, b.primitive(PrimitiveBool)),
+		b.typ(testTypeStr, nil, nil, b.primitive(PrimitiveStr)),
+		b.typ(testTypeBalancesEvent, []string{"pallet_balances", "pallet", "Event"}, nil, b.variants(
+			b.variant("Transfer", 2,
+				b.field("from", testTypeAccountID),
+				b.field("to", testTypeAccountID),
+				b.field("amount", testTypeU128)),
+			b.variant("Deposit", 7,
+				b.field("", testTypeAccountID),
+				b.field("", testTypeCompactU128)),
+		)),
+		b.typ(testTypeSystemEvent, []string{"frame_system", "pallet", "Event"}, nil, b.variants(
+			b.variant("ExtrinsicSuccess", 0),
+			b.variant("Remarked", 1,
+				b.field("sender", testTypeAccountID),
+				b.field("hash", testTypeH256)),
+		)),
+		b.typ(testTypeH256, []string{"primitive_types", "H256"}, nil,
+			b.composite(b.field("", testTypeBytes32))),
+		b.typ(testTypeRuntimeEvent, []string{"node_runtime", "RuntimeEvent"}, nil, b.variants(
+			b.variant("System", 0, b.field("", testTypeSystemEvent)),
+			b.variant("Balances", 5, b.field("", testTypeBalancesEvent)),
+		)),
+		b.typ(testTypePhase, []string{"frame_system", "Phase"}, nil, b.variants(
+			b.variant("ApplyExtrinsic", 0, b.field("", testTypeU32)),
+			b.variant("Finalization", 1),
+			b.variant("Initialization", 2),
+		)),
+		b.typ(testTypeVecH256, nil, nil, b.sequence(testTypeH256)),
+		b.typ(testTypeEventRecord, []string{"frame_system", "EventRecord"},
+			[][]byte{b.param("E", testTypeRuntimeEvent), b.param("T", testTypeH256)},
+			b.composite(
+				b.field("phase", testTypePhase),
+				b.field("event", testTypeRuntimeEvent),
+				b.field("topics", testTypeVecH256),
+			)),
+		b.typ(testTypeVecEventRecord, nil, nil, b.sequence(testTypeEventRecord)),
+		b.typ(testTypeBitVecLsb0, nil, nil, b.bitSequence(testTypeU8, testTypeLsb0)),
+		b.typ(testTypeLsb0, []string{"bitvec", "order", "Lsb0"}, nil, b.composite()),
+		b.typ(testTypeMsb0, []string{"bitvec", "order", "Msb0"}, nil, b.composite()),
+		b.typ(testTypeBitVecU32Msb0, nil, nil, b.bitSequence(testTypeU32, testTypeMsb0)),
+		b.typ(testTypeI128, nil, nil, b.primitive(PrimitiveI128)),
+		b.typ(testTypeTuple, nil, nil, b.tuple(testTypeU8, testTypeBool)),
+		b.typ(testTypeEmptyTuple, nil, nil, b.tuple()),
+		b.typ(testTypeChar, nil, nil, b.primitive(PrimitiveChar)),
+		b.typ(testTypeI8, nil, nil, b.primitive(PrimitiveI8)),
+		b.typ(testTypeBalancesCall, []string{"pallet_balances", "pallet", "Call"}, nil, b.variants(
+			b.variant("transfer", 0,
+				b.field("dest", testTypeMultiAddress),
+				b.field("value", testTypeCompactU128)),
+		)),
+		b.typ(testTypeMultiAddress, []string{"sp_runtime", "multiaddress", "MultiAddress"}, nil, b.variants(
+			b.variant("Id", 0, b.field("", testTypeAccountID)),
+		)),
+		b.typ(testTypeRuntimeCall, []string{"node_runtime", "RuntimeCall"}, nil, b.variants(
+			b.variant("Balances", 5, b.field("", testTypeBalancesCall)),
+		)),
+		b.typ(testTypeSignature, []string{"sp_runtime", "MultiSignature"}, nil, b.variants(
+			b.variant("Sr25519", 1, b.field("", testTypeBytes64)),
+		)),
+		b.typ(testTypeBytes64, nil, nil, b.array(64, testTypeU8)),
+		b.typ(testTypeExtra, nil, nil, b.tuple(testTypeCompactU32)),
+		b.typ(testTypeCompactU32, nil, nil, b.compact(testTypeU32)),
+		b.typ(testTypeUncheckedExtrinsic, []string{"sp_runtime", "generic", "UncheckedExtrinsic"},
+			[][]byte{
+				b.param("Address", testTypeMultiAddress),
+				b.param("Call", testTypeRuntimeCall),
+				b.param("Signature", testTypeSignature),
+				b.param("Extra", testTypeExtra),
+			},
+			b.composite(b.field("", testTypeVecU8))),
+		b.typ(testTypeRecursive, nil, nil, b.composite(b.field("inner", testTypeRecursive))),
+		b.typ(testTypeCompactPerbill, nil, nil, b.compact(testTypePerbill)),
+		b.typ(testTypePerbill, []string{"sp_arithmetic", "per_things", "Perbill"}, nil,
+			b.composite(b.field("", testTypeU32))),
+	}
+
+	palletDocs := []byte{}
+	if version >= 15 {
+		palletDocs = b.list(testMarshal(t, "docs"))
+	}
+	pallets := [][]byte{
+		testConcat(
+			testMarshal(t, "System"),
+			// storage
+			[]byte{1}, testMarshal(t, "System"), b.list(
+				testConcat(testMarshal(t, "Events"), []byte{1}, []byte{0}, testCompact(t, testTypeVecEventRecord),
+					testMarshal(t, []byte{0}), b.list()),
+				testConcat(testMarshal(t, "BlockHash"), []byte{1}, []byte{1}, testMarshal(t, []byte{1}),
+					testCompact(t, testTypeU32), testCompact(t, testTypeH256),
+					testMarshal(t, make([]byte, 32)), b.list()),
+			),
+			// calls
+			[]byte{0},
+			// events
+			[]byte{1}, testCompact(t, testTypeSystemEvent),
+			// constants
+			b.list(testConcat(testMarshal(t, "SS58Prefix"), testCompact(t, testTypeU32),
+				testMarshal(t, []byte{42, 0, 0, 0}), b.list())),
+			// errors
+			[]byte{0},
+			[]byte{0},
+			palletDocs,
+		),
+		testConcat(
+			testMarshal(t, "Balances"),
+			[]byte{0},
+			[]byte{1}, testCompact(t, testTypeBalancesCall),
+			[]byte{1}, testCompact(t, testTypeBalancesEvent),
+			b.list(),
+			[]byte{1}, testCompact(t, testTypeU8),
+			[]byte{5},
+			palletDocs,
+		),
+	}
+
+	signedExtensions := b.list(testConcat(testMarshal(t, "CheckNonce"),
+		testCompact(t, testTypeCompactU32), testCompact(t, testTypeEmptyTuple)))
+	var extrinsic []byte
+	if version >= 15 {
+		extrinsic = testConcat([]byte{4},
+			testCompact(t, testTypeMultiAddress),
+			testCompact(t, testTypeRuntimeCall),
+			testCompact(t, testTypeSignature),
+			testCompact(t, testTypeExtra),
+			signedExtensions)
+	} else {
+		extrinsic = testConcat(testCompact(t, testTypeUncheckedExtrinsic), []byte{4}, signedExtensions)
+	}
+
+	return testConcat([]byte("meta"), []byte{version}, b.list(types...), b.list(pallets...), extrinsic,
+		// runtime type, ignored
+		testCompact(t, testTypeEmptyTuple))
+}
+
+func TestNewRegistry(t *testing.T) {
+	for _, version := range []byte{14, 15} {
+		registry, err := NewRegistry(newTestMetadata(t, version))
+		require.NoError(t, err)
+
+		require.Len(t, registry.Pallets(), 2)
+		system, err := registry.Pallet("System")
+		require.NoError(t, err)
+		eventType := testTypeSystemEvent
+		assert.Equal(t, &Pallet{
+			Name:  "System",
+			Index: 0,
+			Storage: []StorageEntry{
+				{Name: "Events", ValueType: testTypeVecEventRecord},
+				{Name: "BlockHash", KeyType: &[]uint32{testTypeU32}[0], ValueType: testTypeH256},
+			},
+			Constants: []Constant{
+				{Name: "SS58Prefix", Type: testTypeU32, Value: []byte{42, 0, 0, 0}},
+			},
+			EventType: &eventType,
+		}, system)
+
+		balances, err := registry.Pallet("Balances")
+		require.NoError(t, err)
+		assert.Equal(t, uint8(5), balances.Index)
+		assert.Equal(t, testTypeBalancesCall, *balances.CallType)
+		assert.Equal(t, testTypeU8, *balances.ErrorType)
+
+		typeInfo, err := registry.Type(testTypeEventRecord)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"frame_system", "EventRecord"}, typeInfo.Path)
+		assert.Equal(t, TypeDefComposite, typeInfo.Kind)
+		assert.Len(t, typeInfo.Fields, 3)
+		assert.Equal(t, "E", typeInfo.Params[0].Name)
+
+		assert.Equal(t, extrinsicTypes{
+			known:     true,
+			version:   4,
+			address:   testTypeMultiAddress,
+			call:      testTypeRuntimeCall,
+			signature: testTypeSignature,
+			extra:     testTypeExtra,
+			signedExtensions: []SignedExtension{
+				{Identifier: "CheckNonce", Type: testTypeCompactU32, AdditionalSigned: testTypeEmptyTuple},
+			},
+		}, registry.extrinsic)
+		assert.Equal(t, uint8(4), registry.ExtrinsicVersion())
+		assert.Equal(t, registry.extrinsic.signedExtensions, registry.SignedExtensions())
+	}
+}
+
+func TestNewRegistry_errors(t *testing.T) {
+	metadata := newTestMetadata(t, 14)
+
+	_, err := NewRegistry(metadata[4:])
+	assert.ErrorIs(t, err, ErrMetadataMagicNumber)
+
+	unsupported := append([]byte("meta"), 13)
+	_, err = NewRegistry(append(unsupported, metadata[5:]...))
+	assert.ErrorIs(t, err, ErrMetadataVersion)
+
+	for _, length := range []int{5, 100, len(metadata) / 2, len(metadata) - 2} {
+		_, err = NewRegistry(metadata[:length])
+		assert.Error(t, err, "length %d", length)
+	}
+
+	_, err = NewRegistry([]byte("meta\x0e\xff"))
+	assert.Error(t, err)
+}
+
+func TestRegistry_lookups(t *testing.T) {
+	registry, err := NewRegistry(newTestMetadata(t, 14))
+	require.NoError(t, err)
+
+	_, err = registry.Type(1000)
+	assert.ErrorIs(t, err, ErrTypeNotFound)
+
+	_, err = registry.Pallet("Staking")
+	assert.ErrorIs(t, err, ErrPalletNotFound)
+
+	entry, err := registry.StorageEntry("System", "BlockHash")
+	require.NoError(t, err)
+	assert.Equal(t, testTypeH256, entry.ValueType)
+
+	_, err = registry.StorageEntry("System", "Account")
+	assert.ErrorIs(t, err, ErrStorageEntryNotFound)
+	_, err = registry.StorageEntry("Balances", "TotalIssuance")
+	assert.ErrorIs(t, err, ErrStorageEntryNotFound)
+
+	constant, err := registry.Constant("System", "SS58Prefix")
+	require.NoError(t, err)
+	assert.Equal(t, testTypeU32, constant.Type)
+	assert.Equal(t, []byte{42, 0, 0, 0}, constant.Value)
+
+	_, err = registry.Constant("System", "BlockLength")
+	assert.ErrorIs(t, err, ErrConstantNotFound)
+	_, err = registry.Constant("Staking", "SessionsPerEra")
+	assert.ErrorIs(t, err, ErrPalletNotFound)
+}
@@ -21,6 +21,8 @@
 type fieldScaleIndex struct {
 	fieldIndex int
 	scaleIndex *int
+	// compact is set if the field is compact encoded
+	compact bool
 }
 type fieldScaleIndices []fieldScaleIndex
 
@@ -53,25 +55,35 @@ func (fsic *fieldScaleIndicesCache) fieldScaleIndices(in interface{}) (
 	for i := 0; i < t.NumField(); i++ {
 		field := t.Field(i)
 		tag := field.Tag.Get("scale")
-		switch strings.TrimSpace(tag) {
+		index, options, _ := strings.Cut(tag, ",")
+		fieldIndex := fieldScaleIndex{
+			fieldIndex: i,
+		}
+		for _, option := range strings.Split(options, ",") {
+			switch strings.TrimSpace(option) {
+			case "":
+			case "compact":
+				fieldIndex.compact = true
+			default:
+				err = fmt.Errorf("%w: %q for field %s", ErrInvalidScaleTagOption, option, field.Name)
+				return
+			}
+		}
+
+		switch strings.TrimSpace(index) {
 		case "":
-			indices = append(indices, fieldScaleIndex{
-				fieldIndex: i,
-			})
 		case "-":
 			// ignore this field
 			continue
 		default:
-			scaleIndex, indexErr := strconv.Atoi(tag)
+			scaleIndex, indexErr := strconv.Atoi(index)
 			if indexErr != nil {
 				err = fmt.Errorf("%w: %v", ErrInvalidScaleIndex, indexErr)
 				return
 			}
-			indices = append(indices, fieldScaleIndex{
-				fieldIndex: i,
-				scaleIndex: &scaleIndex,
-			})
+			fieldIndex.scaleIndex = &scaleIndex
 		}
+		indices = append(indices, fieldIndex)
 	}
 
 	sort.Slice(indices[:], func(i, j int) bool {
@@ -0,0 +1,142 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package scale
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+)
+
+// uint256Length is the length in bytes of a SCALE encoded Uint256
+const uint256Length = 32
+
+// Uint256 represents an unsigned 256 bit integer
+type Uint256 struct {
+	// words holds the 64 bit words of the integer, from the least significant
+	words [4]uint64
+}
+
+// MaxUint256 is the maximum uint256 value
+var MaxUint256 = &Uint256{
+	words: [4]uint64{^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0)},
+}
+
+// MustNewUint256 will panic if NewUint256 returns an error
+func MustNewUint256(in interface{}, order ...binary.ByteOrder) (u *Uint256) {
+	u, err := NewUint256(in, order...)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+// NewUint256 is constructor for Uint256 that accepts an option binary.ByteOrder
+// option is only used when inputted interface{} is of type []byte
+// by default binary.LittleEndian is used for []byte since this is SCALE
+func NewUint256(in interface{}, order ...binary.ByteOrder) (u *Uint256, err error) {
+	switch in := in.(type) {
+	case *big.Int:
+		if in.Sign() < 0 || in.BitLen() > 256 {
+			return nil, fmt.Errorf("%w: %s", errUint256OutOfRange, in)
+		}
+		return NewUint256(in.FillBytes(make([]byte, uint256Length)), binary.BigEndian)
+	case []byte:
+		var o binary.ByteOrder = binary.LittleEndian
+		if len(order) > 0 {
+			o = order[0]
+		}
+		if len(in) > uint256Length {
+			return nil, fmt.Errorf("%w: %d bytes", errUint256OutOfRange, len(in))
+		}
+
+		b := make([]byte, uint256Length)
+		u = &Uint256{}
+		switch o {
+		case binary.BigEndian:
+			copy(b[uint256Length-len(in):], in)
+			for i := range u.words {
+				end := uint256Length - 8*i
+				u.words[i] = o.Uint64(b[end-8 : end])
+			}
+		default:
+			copy(b, in)
+			for i := range u.words {
+				u.words[i] = o.Uint64(b[8*i : 8*i+8])
+			}
+		}
+	default:
+		err = fmt.Errorf("unsupported type: %T", in)
+	}
+	return
+}
+
+// Bytes returns the Uint256 in little endian format by default, without the zero
+// bytes of the most significant end. A variadic parameter order can be used to specify
+// the binary.ByteOrder used
+func (u *Uint256) Bytes(order ...binary.ByteOrder) (b []byte) {
+	b = u.BigInt().Bytes()
+	if len(order) > 0 && order[0] == binary.BigEndian {
+		return b
+	}
+	return reverseBytes(b)
+}
+
+// BigInt returns the Uint256 as a big integer
+func (u *Uint256) BigInt() *big.Int {
+	return new(big.Int).SetBytes(u.fixedBytes(binary.BigEndian))
+}
+
+// String returns the string format from the Uint256 value
+func (u *Uint256) String() string {
+	return u.BigInt().String()
+}
+
+// Compare returns 1 if the receiver is greater than other, 0 if they are equal, and -1 otherwise.
+func (u *Uint256) Compare(other *Uint256) int {
+	for i := len(u.words) - 1; i >= 0; i-- {
+		switch {
+		case u.words[i] > other.words[i]:
+			return 1
+		case u.words[i] < other.words[i]:
+			return -1
+		}
+	}
+	return 0
+}
+
+// fixedBytes returns the 32 bytes of the Uint256 in the byte order given
+func (u *Uint256) fixedBytes(order binary.ByteOrder) []byte {
+	b := make([]byte, uint256Length)
+	for i, word := range u.words {
+		switch order {
+		case binary.BigEndian:
+			end := uint256Length - 8*i
+			order.PutUint64(b[end-8:end], word)
+		default:
+			order.PutUint64(b[8*i:8*i+8], word)
+		}
+	}
+	return b
+}
+
+// UnmarshalJSON converts data to Uint256.
+func (u *Uint256) UnmarshalJSON(data []byte) error {
+	intVal, ok := big.NewInt(0).SetString(string(data), 10)
+	if !ok {
+		return fmt.Errorf("failed to unmarshal Uint256")
+	}
+
+	dec, err := NewUint256(intVal)
+	if err != nil {
+		return fmt.Errorf("creating uint256 from big integer: %w", err)
+	}
+	u.words = dec.words
+	return nil
+}
+
+// MarshalJSON converts Uint256 to []byte.
+func (u Uint256) MarshalJSON() ([]byte, error) {
+	return []byte(u.String()), nil
+}
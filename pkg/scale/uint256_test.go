@@ -0,0 +1,124 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package scale
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUint256FromBigInt(t *testing.T) {
+	bytes := []byte{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8, 1, 2}
+	bi := new(big.Int).SetBytes(bytes)
+	u, err := NewUint256(bi)
+	require.NoError(t, err)
+	require.Equal(t, bytes, u.Bytes(binary.BigEndian))
+	require.Equal(t, bi, u.BigInt())
+
+	max := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	u, err = NewUint256(max)
+	require.NoError(t, err)
+	require.Equal(t, MaxUint256, u)
+
+	_, err = NewUint256(new(big.Int).Lsh(big.NewInt(1), 256))
+	require.ErrorIs(t, err, errUint256OutOfRange)
+
+	_, err = NewUint256(big.NewInt(-1))
+	require.ErrorIs(t, err, errUint256OutOfRange)
+}
+
+func TestUint256FromLEBytes(t *testing.T) {
+	bytes := []byte{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8, 1, 2}
+	u, err := NewUint256(bytes)
+	require.NoError(t, err)
+	require.Equal(t, bytes, u.Bytes())
+
+	bytes = []byte{1, 2}
+	u, err = NewUint256(bytes)
+	require.NoError(t, err)
+	require.Equal(t, bytes, u.Bytes())
+	require.Equal(t, "513", u.String())
+
+	_, err = NewUint256(make([]byte, 33))
+	require.ErrorIs(t, err, errUint256OutOfRange)
+}
+
+func TestUint256_Cmp(t *testing.T) {
+	u0 := MustNewUint256([]byte{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8, 1, 2})
+	u1 := MustNewUint256([]byte{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8, 1, 2})
+	require.Equal(t, 0, u0.Compare(u1))
+
+	u2 := MustNewUint256([]byte{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8, 1})
+	require.Equal(t, 1, u0.Compare(u2))
+	require.Equal(t, -1, u2.Compare(u0))
+
+	require.Equal(t, 1, MaxUint256.Compare(u0))
+}
+
+func TestUint256_JSON(t *testing.T) {
+	u := MustNewUint256(new(big.Int).Lsh(big.NewInt(1), 200))
+
+	data, err := json.Marshal(u)
+	require.NoError(t, err)
+	assert.Equal(t, "1606938044258990275541962092341162602522202993782792835301376", string(data))
+
+	var decoded Uint256
+	err = json.Unmarshal(data, &decoded)
+	require.NoError(t, err)
+	assert.Equal(t, u, &decoded)
+}
+
+func TestUint256_Marshal(t *testing.T) {
+	tests := map[string]struct {
+		in      *Uint256
+		encoded []byte
+	}{
+		"zero": {
+			in:      MustNewUint256([]byte{}),
+			encoded: make([]byte, 32),
+		},
+		"one": {
+			in:      MustNewUint256(big.NewInt(1)),
+			encoded: append([]byte{1}, make([]byte, 31)...),
+		},
+		"max": {
+			in: MaxUint256,
+			encoded: []byte{
+				0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+				0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+			},
+		},
+		"words_order": {
+			in: MustNewUint256([]byte{1, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0, 4}),
+			encoded: []byte{
+				1, 0, 0, 0, 0, 0, 0, 0, 2, 0, 0, 0, 0, 0, 0, 0,
+				3, 0, 0, 0, 0, 0, 0, 0, 4, 0, 0, 0, 0, 0, 0, 0,
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			encoded, err := Marshal(tt.in)
+			require.NoError(t, err)
+			assert.Equal(t, tt.encoded, encoded)
+
+			var decoded *Uint256
+			err = Unmarshal(encoded, &decoded)
+			require.NoError(t, err)
+			assert.Equal(t, tt.in, decoded)
+		})
+	}
+}
+
+func TestUint256_Unmarshal_short(t *testing.T) {
+	var decoded *Uint256
+	err := Unmarshal(make([]byte, 31), &decoded)
+	require.Error(t, err)
+}
@@ -47,3 +47,15 @@ func (cache *maxBytesLRUCache) get(key string) []byte {
 func (cache *maxBytesLRUCache) set(key string, value []byte) {
 	cache.lru.Set(key, cacheValue(value), 0)
 }
+
+// itemCount returns the number of entries in the cache
+func (cache *maxBytesLRUCache) itemCount() int {
+	return cache.lru.ItemCount()
+}
+
+// size returns the size in bytes of the entries in the cache, overhead included,
+// once the pending insertions and promotions are processed
+func (cache *maxBytesLRUCache) size() int64 {
+	cache.lru.SyncUpdates()
+	return cache.lru.GetSize()
+}
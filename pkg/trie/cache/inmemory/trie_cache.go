@@ -4,28 +4,41 @@
 package inmemory
 
 import (
-	lrucache "github.com/ChainSafe/gossamer/lib/utils/lru-cache"
 	"github.com/ChainSafe/gossamer/pkg/trie/cache"
 )
 
 // https://github.com/paritytech/polkadot-sdk/blob/a8f4f4f00f8fc0da512a09e1450bf4cda954d70d/substrate/primitives/trie/src/cache/mod.rs#L98
-const defaultNodeCacheMaxElements = 10000
+const defaultNodeCacheMaxSize = 8 * 1024 * 1024  // 8MB
 const defaultValueCacheMaxSize = 2 * 1024 * 1024 // 2MB
 
+// nodeCacheSizePercentage is the percentage of the size of a sized cache
+// given to the node cache, the rest being given to the value cache
+const nodeCacheSizePercentage = 80
+
 // TrieInMemoryCache is an in-memory cache for trie nodes
 type TrieInMemoryCache struct {
-	nodeCache  *lrucache.LRUCache[string, []byte]
+	nodeCache  *maxBytesLRUCache
 	valueCache *maxBytesLRUCache
 }
 
 // NewTrieInMemoryCache creates a new TrieInMemoryCache
 func NewTrieInMemoryCache() *TrieInMemoryCache {
 	return &TrieInMemoryCache{
-		nodeCache:  lrucache.NewLRUCache[string, []byte](defaultNodeCacheMaxElements),
+		nodeCache:  newLruCache(defaultNodeCacheMaxSize),
 		valueCache: newLruCache(defaultValueCacheMaxSize),
 	}
 }
 
+// NewTrieInMemoryCacheWithSize creates a new TrieInMemoryCache using at most
+// maxSize bytes, shared between the node cache and the value cache
+func NewTrieInMemoryCacheWithSize(maxSize int64) *TrieInMemoryCache {
+	nodeCacheMaxSize := maxSize * nodeCacheSizePercentage / 100
+	return &TrieInMemoryCache{
+		nodeCache:  newLruCache(nodeCacheMaxSize),
+		valueCache: newLruCache(maxSize - nodeCacheMaxSize),
+	}
+}
+
 // GetValue returns the value for the given key
 func (tc *TrieInMemoryCache) GetValue(key []byte) []byte {
 	return tc.valueCache.get(string(key))
@@ -38,12 +51,30 @@ func (tc *TrieInMemoryCache) SetValue(key []byte, value []byte) {
 
 // GetNode returns the node for the given key
 func (tc *TrieInMemoryCache) GetNode(key []byte) []byte {
-	return tc.nodeCache.Get(string(key))
+	return tc.nodeCache.get(string(key))
 }
 
 // SetNode sets the node for the given key
 func (tc *TrieInMemoryCache) SetNode(key, value []byte) {
-	tc.nodeCache.Put(string(key), value)
+	tc.nodeCache.set(string(key), value)
+}
+
+// Stats are the number of entries and the size in bytes of the node and value caches
+type Stats struct {
+	NodeEntries  int   `json:"nodeEntries"`
+	NodeSize     int64 `json:"nodeSize"`
+	ValueEntries int   `json:"valueEntries"`
+	ValueSize    int64 `json:"valueSize"`
+}
+
+// Stats returns the number of entries and the size in bytes of the node and value caches
+func (tc *TrieInMemoryCache) Stats() Stats {
+	return Stats{
+		NodeEntries:  tc.nodeCache.itemCount(),
+		NodeSize:     tc.nodeCache.size(),
+		ValueEntries: tc.valueCache.itemCount(),
+		ValueSize:    tc.valueCache.size(),
+	}
 }
 
 var _ cache.TrieCache = (*TrieInMemoryCache)(nil)
@@ -27,3 +27,33 @@ func Test_TrieCache_SetAndGet(t *testing.T) {
 		assert.Nil(t, valueFromCache)
 	})
 }
+
+func Test_NewTrieInMemoryCacheWithSize(t *testing.T) {
+	const maxSize = 1024 * 1024
+	cache := NewTrieInMemoryCacheWithSize(maxSize)
+
+	key := []byte("key")
+	node := []byte("node")
+	value := []byte("value")
+
+	cache.SetNode(key, node)
+	cache.SetValue(key, value)
+
+	assert.Equal(t, node, cache.GetNode(key))
+	assert.Equal(t, value, cache.GetValue(key))
+	assert.Nil(t, cache.GetNode([]byte("missing")))
+}
+
+func Test_TrieInMemoryCache_Stats(t *testing.T) {
+	cache := NewTrieInMemoryCacheWithSize(1024 * 1024)
+
+	cache.SetNode([]byte("node"), []byte{1, 2, 3})
+	cache.SetValue([]byte("first"), []byte{1})
+	cache.SetValue([]byte("second"), []byte{2})
+
+	stats := cache.Stats()
+	assert.Equal(t, 1, stats.NodeEntries)
+	assert.Equal(t, int64(3+cacheValueOverheadSize), stats.NodeSize)
+	assert.Equal(t, 2, stats.ValueEntries)
+	assert.Equal(t, int64(2*(1+cacheValueOverheadSize)), stats.ValueSize)
+}
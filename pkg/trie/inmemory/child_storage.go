@@ -58,6 +58,23 @@ func (t *InMemoryTrie) GetChild(keyToChild []byte) (trie.Trie, error) {
 	return child, err
 }
 
+// ChildSnapshot returns a snapshot of the child trie at key :child_storage:[keyToChild], or
+// an empty child trie if it does not exist, which can be modified without modifying this trie.
+func (t *InMemoryTrie) ChildSnapshot(keyToChild []byte) (*InMemoryTrie, error) {
+	child, err := t.getInternalChildTrie(keyToChild)
+	if errors.Is(err, trie.ErrChildTrieDoesNotExist) {
+		child = NewEmptyTrie()
+		child.version = t.version
+		return child, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("getting child: %w", err)
+	}
+
+	snapshot := child.Snapshot()
+	snapshot.version = t.version
+	return snapshot, nil
+}
+
 // GetChildTries returns all child tries in this trie
 func (t *InMemoryTrie) GetChildTries() map[common.Hash]trie.Trie {
 	children := make(map[common.Hash]trie.Trie)
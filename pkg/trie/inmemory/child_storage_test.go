@@ -120,3 +120,55 @@ func TestChildTrieHashAfterClear(t *testing.T) {
 	require.Equal(t, originalEmptyHash, trie.V0.MustHash(trieThatHoldsAChildTrie))
 
 }
+
+func TestChildSnapshot(t *testing.T) {
+	parentTrie := NewEmptyTrie()
+	keyToChild := []byte("contract")
+
+	// the snapshot of a child trie not existing is empty
+	snapshot, err := parentTrie.ChildSnapshot(keyToChild)
+	require.NoError(t, err)
+	require.Equal(t, trie.EmptyHash, snapshot.MustHash())
+
+	err = parentTrie.PutIntoChild(keyToChild, []byte("key"), []byte("value"))
+	require.NoError(t, err)
+	child, err := parentTrie.GetChild(keyToChild)
+	require.NoError(t, err)
+	childHash := child.MustHash()
+
+	snapshot, err = parentTrie.ChildSnapshot(keyToChild)
+	require.NoError(t, err)
+	require.Equal(t, childHash, snapshot.MustHash())
+
+	// modifying the snapshot does not modify the child trie
+	require.NoError(t, snapshot.Put([]byte("other"), []byte("value")))
+	require.NotEqual(t, childHash, snapshot.MustHash())
+	require.Equal(t, childHash, child.MustHash())
+}
+
+func TestSetVersion_ChildTries(t *testing.T) {
+	parentTrie := NewEmptyTrie()
+	keyToChild := []byte("contract")
+	err := parentTrie.PutIntoChild(keyToChild, []byte("key"), []byte("value"))
+	require.NoError(t, err)
+
+	parentTrie.SetVersion(trie.V1)
+
+	child, err := parentTrie.getInternalChildTrie(keyToChild)
+	require.NoError(t, err)
+	assert.Equal(t, trie.V1, child.version)
+
+	// the values written once migrated are hashed following the version of the parent trie
+	largeValue := make([]byte, 64)
+	err = parentTrie.PutIntoChild(keyToChild, []byte("large"), largeValue)
+	require.NoError(t, err)
+
+	expected := NewEmptyTrie()
+	expected.SetVersion(trie.V1)
+	require.NoError(t, expected.Put([]byte("key"), []byte("value")))
+	require.NoError(t, expected.Put([]byte("large"), largeValue))
+
+	child, err = parentTrie.getInternalChildTrie(keyToChild)
+	require.NoError(t, err)
+	assert.Equal(t, expected.MustHash(), child.MustHash())
+}
@@ -303,6 +303,14 @@ func (t *InMemoryTrie) WriteDirty(db db.NewBatcher) error {
 		return err
 	}
 
+	for _, childTrie := range t.childTries {
+		err = childTrie.writeDirtyNode(batch, childTrie.root)
+		if err != nil {
+			batch.Reset()
+			return fmt.Errorf("writing dirty nodes of child trie: %w", err)
+		}
+	}
+
 	return batch.Flush()
 }
 
@@ -370,12 +378,6 @@ func (t *InMemoryTrie) writeDirtyNode(db db.DBPutter, n *node.Node) (err error)
 		}
 	}
 
-	for _, childTrie := range t.childTries {
-		if err := childTrie.writeDirtyNode(db, childTrie.root); err != nil {
-			return fmt.Errorf("writing dirty node to database: %w", err)
-		}
-	}
-
 	n.SetClean()
 
 	return nil
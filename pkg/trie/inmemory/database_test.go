@@ -333,3 +333,25 @@ func Test_Trie_PutChild_Store_Load(t *testing.T) {
 		assert.Equal(t, trie.String(), trieFromDB.String())
 	}
 }
+
+func Test_Trie_PutIntoChild_Store_Load(t *testing.T) {
+	t.Parallel()
+
+	// the root of the main trie is a leaf holding the root hash of the child trie
+	trie := NewEmptyTrie()
+	keyToChild := []byte("contract")
+	err := trie.PutIntoChild(keyToChild, []byte("key"), []byte("value"))
+	require.NoError(t, err)
+
+	db := newTestDB(t)
+	err = trie.WriteDirty(db)
+	require.NoError(t, err)
+
+	trieFromDB := NewEmptyTrie()
+	err = trieFromDB.Load(db, trie.MustHash())
+	require.NoError(t, err)
+
+	value, err := trieFromDB.GetFromChild(keyToChild, []byte("key"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+}
@@ -64,6 +64,11 @@ func (t *InMemoryTrie) SetVersion(v trie.TrieLayout) {
 	}
 
 	t.version = v
+	for _, child := range t.childTries {
+		if child.version < v {
+			child.version = v
+		}
+	}
 }
 
 // Equal is to compare one trie with other, this method will ignore the shared db instance
@@ -95,6 +100,7 @@ func (t *InMemoryTrie) Snapshot() (newTrie *InMemoryTrie) {
 		childTries[rootHash] = &InMemoryTrie{
 			generation: childTrie.generation + 1,
 			root:       childTrie.root.Copy(rootCopySettings),
+			db:         childTrie.db,
 			deltas:     tracking.New(),
 			version:    t.version,
 		}
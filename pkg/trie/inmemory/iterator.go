@@ -94,7 +94,27 @@ func (t *InMemoryTrie) PrefixedKeys(prefix []byte) iter.Seq[[]byte] {
 			return
 		}
 
-		for key := iter.NextKey(); bytes.HasPrefix(key, prefix); key = iter.NextKey() {
+		for key := iter.NextKey(); key != nil && bytes.HasPrefix(key, prefix); key = iter.NextKey() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// PrefixedKeysFrom returns an iterator over the keys in the trie that have the given prefix
+// and are strictly greater than the given start key. It allows to resume an iteration from
+// the last key returned without going over the previous keys. A nil start key iterates over
+// all the keys having the prefix.
+func (t *InMemoryTrie) PrefixedKeysFrom(prefix, startKey []byte) iter.Seq[[]byte] {
+	if startKey == nil || bytes.Compare(startKey, prefix) < 0 {
+		return t.PrefixedKeys(prefix)
+	}
+
+	iter := NewInMemoryTrieIterator(WithTrie(t), WithCursorAt(codec.KeyLEToNibbles(startKey)))
+
+	return func(yield func([]byte) bool) {
+		for key := iter.NextKey(); key != nil && bytes.HasPrefix(key, prefix); key = iter.NextKey() {
 			if !yield(key) {
 				return
 			}
@@ -84,3 +84,74 @@ func TestInMemoryIteratorGetAllKeysWithPrefixIncluded(t *testing.T) {
 
 	require.Equal(t, expectedKeys, keys)
 }
+
+func TestInMemoryIteratorPrefixedKeysFrom(t *testing.T) {
+	tt := NewEmptyTrie()
+
+	tt.Put([]byte("services_storage:serviceA:19090"), []byte("0x10"))
+	tt.Put([]byte("account_storage"), []byte("0x10"))
+	tt.Put([]byte("account_storage:ABC:AAA"), []byte("0x10"))
+	tt.Put([]byte("account_storage:ABC:CCC"), []byte("0x10"))
+	tt.Put([]byte("account_storage:ABC:DDD"), []byte("0x10"))
+	tt.Put([]byte("account_storage:JJK:EEE"), []byte("0x10"))
+
+	testCases := map[string]struct {
+		prefix       []byte
+		startKey     []byte
+		expectedKeys [][]byte
+	}{
+		"nil_start_key": {
+			prefix: []byte("account_storage:ABC"),
+			expectedKeys: [][]byte{
+				[]byte("account_storage:ABC:AAA"),
+				[]byte("account_storage:ABC:CCC"),
+				[]byte("account_storage:ABC:DDD"),
+			},
+		},
+		"start_key_is_excluded": {
+			prefix:   []byte("account_storage"),
+			startKey: []byte("account_storage:ABC:CCC"),
+			expectedKeys: [][]byte{
+				[]byte("account_storage:ABC:DDD"),
+				[]byte("account_storage:JJK:EEE"),
+			},
+		},
+		"start_key_not_in_trie": {
+			prefix:   []byte("account_storage"),
+			startKey: []byte("account_storage:ABC:BBB"),
+			expectedKeys: [][]byte{
+				[]byte("account_storage:ABC:CCC"),
+				[]byte("account_storage:ABC:DDD"),
+				[]byte("account_storage:JJK:EEE"),
+			},
+		},
+		"start_key_before_prefix": {
+			prefix:   []byte("services_storage"),
+			startKey: []byte("account_storage:ABC:AAA"),
+			expectedKeys: [][]byte{
+				[]byte("services_storage:serviceA:19090"),
+			},
+		},
+		"empty_prefix": {
+			startKey: []byte("account_storage:JJK:EEE"),
+			expectedKeys: [][]byte{
+				[]byte("services_storage:serviceA:19090"),
+			},
+		},
+		"start_key_after_prefix": {
+			prefix:   []byte("account_storage"),
+			startKey: []byte("services_storage"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var keys [][]byte
+			for key := range tt.PrefixedKeysFrom(testCase.prefix, testCase.startKey) {
+				keys = append(keys, key)
+			}
+
+			require.Equal(t, testCase.expectedKeys, keys)
+		})
+	}
+}
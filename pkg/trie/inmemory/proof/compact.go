@@ -0,0 +1,106 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package proof
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/trie/node"
+)
+
+var (
+	ErrCompactRootNotFound   = errors.New("root node not found in proof")
+	ErrCompactHashedValue    = errors.New("compact encoding of hashed storage values is not supported")
+	errCompactUnusedNodeHash = errors.New("proof node not reachable from the root")
+)
+
+// EncodeCompact encodes the storage proof given in its compact form, where the nodes
+// are ordered by a pre-order traversal of the proof trie starting from the given root
+// hash, and the hash of every child node also present in the proof is omitted from its
+// parent encoding, since it can be recomputed when decoding.
+// It is the encoding of the storage proofs of parachain blocks in their proof of validity.
+func EncodeCompact(rootHash []byte, encodedProofNodes [][]byte) (compactNodes [][]byte, err error) {
+	hashToEncoding := make(map[common.Hash][]byte, len(encodedProofNodes))
+	for _, encodedNode := range encodedProofNodes {
+		nodeHash, err := common.Blake2bHash(encodedNode)
+		if err != nil {
+			return nil, fmt.Errorf("blake2b hash: %w", err)
+		}
+		hashToEncoding[nodeHash] = encodedNode
+	}
+
+	rootEncoding, ok := hashToEncoding[common.BytesToHash(rootHash)]
+	if !ok {
+		return nil, fmt.Errorf("%w: for root hash 0x%x", ErrCompactRootNotFound, rootHash)
+	}
+
+	used := make(map[common.Hash]struct{}, len(hashToEncoding))
+	compactNodes, err = encodeCompactNode(rootEncoding, hashToEncoding, used, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(used) != len(hashToEncoding) {
+		return nil, fmt.Errorf("%w: %d nodes out of %d are reachable",
+			errCompactUnusedNodeHash, len(used), len(hashToEncoding))
+	}
+
+	return compactNodes, nil
+}
+
+// encodeCompactNode appends the compact encoding of the node with the encoding given to
+// the compact nodes given, followed by the compact encodings of its children in the proof.
+func encodeCompactNode(encoding []byte, hashToEncoding map[common.Hash][]byte,
+	used map[common.Hash]struct{}, compactNodes [][]byte) ([][]byte, error) {
+	nodeHash, err := common.Blake2bHash(encoding)
+	if err != nil {
+		return nil, fmt.Errorf("blake2b hash: %w", err)
+	}
+	used[nodeHash] = struct{}{}
+
+	decoded, err := node.Decode(bytes.NewReader(encoding))
+	if err != nil {
+		return nil, fmt.Errorf("decoding node: %w", err)
+	}
+
+	if decoded.IsHashedValue {
+		return nil, fmt.Errorf("%w: node with hash %s", ErrCompactHashedValue, nodeHash)
+	}
+
+	// reserve the position of the node before its children, since
+	// its encoding is only known once its children are processed.
+	index := len(compactNodes)
+	compactNodes = append(compactNodes, nil)
+
+	for _, child := range decoded.Children {
+		if child == nil || len(child.MerkleValue) != common.HashLength {
+			continue
+		}
+
+		childEncoding, ok := hashToEncoding[common.BytesToHash(child.MerkleValue)]
+		if !ok {
+			continue
+		}
+
+		compactNodes, err = encodeCompactNode(childEncoding, hashToEncoding, used, compactNodes)
+		if err != nil {
+			return nil, err
+		}
+
+		// an empty Merkle value marks the child as present in the proof
+		child.MerkleValue = []byte{}
+	}
+
+	buffer := bytes.NewBuffer(nil)
+	err = decoded.Encode(buffer)
+	if err != nil {
+		return nil, fmt.Errorf("encoding compact node: %w", err)
+	}
+	compactNodes[index] = buffer.Bytes()
+
+	return compactNodes, nil
+}
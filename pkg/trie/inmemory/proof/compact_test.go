@@ -0,0 +1,53 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package proof
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_EncodeCompact(t *testing.T) {
+	t.Parallel()
+
+	tr := inmemory.NewEmptyTrie()
+	for i := 0; i < 64; i++ {
+		key := []byte(fmt.Sprintf("key-%d", i))
+		tr.Put(key, bytes.Repeat([]byte{byte(i)}, 40))
+	}
+
+	rootHash, err := trie.V0.Hash(tr)
+	require.NoError(t, err)
+
+	db, err := database.NewPebble("", true)
+	require.NoError(t, err)
+	err = tr.WriteDirty(db)
+	require.NoError(t, err)
+
+	fullKeys := [][]byte{[]byte("key-1"), []byte("key-42")}
+	encodedProofNodes, err := GenerateWitness(rootHash.ToBytes(), fullKeys, nil, db)
+	require.NoError(t, err)
+
+	compactNodes, err := EncodeCompact(rootHash.ToBytes(), encodedProofNodes)
+	require.NoError(t, err)
+	require.Len(t, compactNodes, len(encodedProofNodes))
+
+	// the child hashes of the nodes present in the proof are omitted
+	var proofSize, compactSize int
+	for i := range encodedProofNodes {
+		proofSize += len(encodedProofNodes[i])
+		compactSize += len(compactNodes[i])
+	}
+	assert.Less(t, compactSize, proofSize)
+
+	_, err = EncodeCompact([]byte{1}, encodedProofNodes)
+	assert.ErrorIs(t, err, ErrCompactRootNotFound)
+}
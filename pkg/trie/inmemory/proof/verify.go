@@ -12,6 +12,7 @@
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/codec"
 	"github.com/ChainSafe/gossamer/pkg/trie/db"
 	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
 	"github.com/ChainSafe/gossamer/pkg/trie/node"
@@ -21,6 +22,7 @@
 var (
 	ErrKeyNotFoundInProofTrie = errors.New("key not found in proof trie")
 	ErrValueMismatchProofTrie = errors.New("value found in proof trie does not match")
+	ErrIncompleteProof        = errors.New("incomplete proof")
 )
 
 var logger = log.NewFromGlobal(log.AddContext("pkg", "proof"))
@@ -57,6 +59,115 @@ func Verify(encodedProofNodes [][]byte, rootHash, key, value []byte) (err error)
 	return nil
 }
 
+// Read returns the value at the given key from the encoded proof nodes given, walking
+// the path of the key from the node matching the root hash given. A nil value and a
+// nil error are returned if the proof shows the key is absent from the trie, and an
+// error wrapping ErrIncompleteProof is returned if a node on the path is missing.
+func Read(encodedProofNodes [][]byte, rootHash, key []byte) (value []byte, err error) {
+	if len(encodedProofNodes) == 0 {
+		return nil, fmt.Errorf("%w: for Merkle root hash 0x%x",
+			ErrEmptyProof, rootHash)
+	}
+
+	digestToEncoding, err := mapDigestsToEncodings(encodedProofNodes)
+	if err != nil {
+		return nil, err
+	}
+
+	rootEncoding, ok := digestToEncoding[string(rootHash)]
+	if !ok {
+		return nil, fmt.Errorf("%w: for root hash 0x%x", ErrRootNodeNotFound, rootHash)
+	}
+
+	n, err := node.Decode(bytes.NewReader(rootEncoding))
+	if err != nil {
+		return nil, fmt.Errorf("decoding root node: %w", err)
+	}
+
+	nibbles := codec.KeyLEToNibbles(key)
+	for n != nil {
+		if !bytes.HasPrefix(nibbles, n.PartialKey) {
+			return nil, nil
+		}
+		nibbles = nibbles[len(n.PartialKey):]
+
+		if len(nibbles) == 0 {
+			return readStorageValue(digestToEncoding, n)
+		}
+
+		if n.Kind() == node.Leaf || n.Children[nibbles[0]] == nil {
+			return nil, nil
+		}
+
+		n, err = readChild(digestToEncoding, n.Children[nibbles[0]])
+		if err != nil {
+			return nil, fmt.Errorf("reading key %s: %w", bytesToString(key), err)
+		}
+		nibbles = nibbles[1:]
+	}
+
+	return nil, nil
+}
+
+// mapDigestsToEncodings returns a map from the hash digest of each encoded proof
+// node to its encoding.
+func mapDigestsToEncodings(encodedProofNodes [][]byte) (digestToEncoding map[string][]byte, err error) {
+	digestToEncoding = make(map[string][]byte, len(encodedProofNodes))
+
+	buffer := pools.DigestBuffers.Get().(*bytes.Buffer)
+	defer pools.DigestBuffers.Put(buffer)
+
+	for _, encodedProofNode := range encodedProofNodes {
+		buffer.Reset()
+		err = node.MerkleValueRoot(encodedProofNode, buffer)
+		if err != nil {
+			return nil, fmt.Errorf("calculating node hash: %w", err)
+		}
+		digestToEncoding[buffer.String()] = encodedProofNode
+	}
+
+	return digestToEncoding, nil
+}
+
+// readChild returns the child node given, decoding it from the proof nodes if
+// it is referenced by its hash digest rather than inlined in its parent.
+func readChild(digestToEncoding map[string][]byte, child *node.Node) (*node.Node, error) {
+	if len(child.MerkleValue) != common.HashLength {
+		// inlined child already decoded with its parent
+		return child, nil
+	}
+
+	encoding, ok := digestToEncoding[string(child.MerkleValue)]
+	if !ok {
+		return nil, fmt.Errorf("%w: node with hash digest 0x%x not found",
+			ErrIncompleteProof, child.MerkleValue)
+	}
+
+	decoded, err := node.Decode(bytes.NewReader(encoding))
+	if err != nil {
+		return nil, fmt.Errorf("decoding child node for hash digest 0x%x: %w",
+			child.MerkleValue, err)
+	}
+
+	return decoded, nil
+}
+
+// readStorageValue returns the storage value of the node given, looking it up in
+// the proof nodes if the node only contains its hash.
+func readStorageValue(digestToEncoding map[string][]byte, n *node.Node) ([]byte, error) {
+	if !n.IsHashedValue {
+		return n.StorageValue, nil
+	}
+
+	value, ok := digestToEncoding[string(n.StorageValue)]
+	if !ok {
+		return nil, fmt.Errorf("%w: value with hash 0x%x not found",
+			ErrIncompleteProof, n.StorageValue)
+	}
+
+	return value, nil
+}
+
 var (
 	ErrEmptyProof       = errors.New("proof slice empty")
 	ErrRootNodeNotFound = errors.New("root node not found in proof")
@@ -6,6 +6,7 @@
 import (
 	"testing"
 
+	"github.com/ChainSafe/gossamer/internal/database"
 	"github.com/ChainSafe/gossamer/pkg/trie"
 	"github.com/ChainSafe/gossamer/pkg/trie/db"
 	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
@@ -117,6 +118,43 @@ func Test_Verify(t *testing.T) {
 	}
 }
 
+func Test_Read(t *testing.T) {
+	t.Parallel()
+
+	tr := inmemory.NewEmptyTrie()
+	tr.Put([]byte("cat"), []byte("meow"))
+	tr.Put([]byte("catapulta"), generateBytes(t, 40))
+	tr.Put([]byte("dog"), []byte("woof"))
+
+	rootHash, err := trie.V0.Hash(tr)
+	require.NoError(t, err)
+
+	pebble, err := database.NewPebble("", true)
+	require.NoError(t, err)
+	err = tr.WriteDirty(pebble)
+	require.NoError(t, err)
+
+	keys := [][]byte{[]byte("cat"), []byte("catapulta"), []byte("cow")}
+	witness, err := GenerateWitness(rootHash.ToBytes(), keys, nil, pebble)
+	require.NoError(t, err)
+
+	for _, key := range keys {
+		value, err := Read(witness, rootHash.ToBytes(), key)
+		require.NoError(t, err)
+		assert.Equal(t, tr.Get(key), value)
+	}
+
+	_, err = Read(witness, []byte{1}, []byte("cat"))
+	assert.ErrorIs(t, err, ErrRootNodeNotFound)
+
+	// the long catapulta leaf is referenced by its hash and missing from the
+	// proof of the cat key alone, so its absence cannot be proven
+	catWitness, err := GenerateWitness(rootHash.ToBytes(), [][]byte{[]byte("cat")}, nil, pebble)
+	require.NoError(t, err)
+	_, err = Read(catWitness, rootHash.ToBytes(), []byte("catapulta"))
+	assert.ErrorIs(t, err, ErrIncompleteProof)
+}
+
 func Test_buildTrie(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,126 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package proof
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	triepkg "github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/codec"
+	"github.com/ChainSafe/gossamer/pkg/trie/db"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/ChainSafe/gossamer/pkg/trie/node"
+	"golang.org/x/exp/maps"
+)
+
+// GenerateWitness generates and deduplicates the encoded nodes
+// on the path of each of the (Little Endian) full keys given,
+// for the trie corresponding to the root hash given, and on the
+// path of each of the child trie keys given by key of the child
+// trie, for the child tries held by the trie.
+// Contrary to Generate, a key absent from the trie does not
+// produce an error: the nodes walked until the key diverges
+// from the trie are added to the proof, proving its absence.
+// The database given is used to load the trie using the root hash given.
+func GenerateWitness(rootHash []byte, fullKeys [][]byte, childKeys map[string][][]byte,
+	database db.DBGetter) (encodedProofNodes [][]byte, err error) {
+	trie := inmemory.NewEmptyTrie()
+	if err := trie.Load(database, common.BytesToHash(rootHash)); err != nil {
+		return nil, fmt.Errorf("loading trie: %w", err)
+	}
+
+	nodeHashesSeen := make(map[common.Hash]struct{})
+	addNode := func(encodedNode []byte) error {
+		nodeHash, err := common.Blake2bHash(encodedNode)
+		if err != nil {
+			return fmt.Errorf("blake2b hash: %w", err)
+		}
+
+		_, seen := nodeHashesSeen[nodeHash]
+		if seen {
+			return nil
+		}
+		nodeHashesSeen[nodeHash] = struct{}{}
+
+		encodedProofNodes = append(encodedProofNodes, encodedNode)
+		return nil
+	}
+
+	err = walkWitnessKeys(trie.RootNode(), fullKeys, addNode)
+	if err != nil {
+		return nil, err
+	}
+
+	keysToChildren := maps.Keys(childKeys)
+	sort.Strings(keysToChildren)
+	for _, keyToChild := range keysToChildren {
+		child, err := trie.GetChild([]byte(keyToChild))
+		if errors.Is(err, triepkg.ErrChildTrieDoesNotExist) {
+			// the absence of the child trie is proven by the trie nodes
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("getting child trie at key 0x%x: %w", keyToChild, err)
+		}
+
+		err = walkWitnessKeys(child.(*inmemory.InMemoryTrie).RootNode(), childKeys[keyToChild], addNode)
+		if err != nil {
+			return nil, fmt.Errorf("child trie at key 0x%x: %w", keyToChild, err)
+		}
+	}
+
+	return encodedProofNodes, nil
+}
+
+// walkWitnessKeys calls addNode for the node encodings on the path of each of the full keys given,
+// from the root node given.
+func walkWitnessKeys(rootNode *node.Node, fullKeys [][]byte, addNode func(encodedNode []byte) error) error {
+	for _, fullKey := range fullKeys {
+		fullKeyNibbles := codec.KeyLEToNibbles(fullKey)
+		err := walkWitness(rootNode, fullKeyNibbles, true, addNode)
+		if err != nil {
+			return fmt.Errorf("walking to node at key 0x%x: %w", fullKey, err)
+		}
+	}
+	return nil
+}
+
+// walkWitness walks down the trie from the given node following the
+// full key nibbles given, and calls addNode for the root node encoding
+// and for every node encoding of at least 32 bytes walked through.
+// Smaller node encodings are inlined in their parent node encoding.
+func walkWitness(n *node.Node, fullKey []byte, isRoot bool,
+	addNode func(encodedNode []byte) error) (err error) {
+	if n == nil {
+		return nil
+	}
+
+	encodingBuffer := bytes.NewBuffer(nil)
+	err = n.Encode(encodingBuffer)
+	if err != nil {
+		return fmt.Errorf("encode node: %w", err)
+	}
+
+	if isRoot || encodingBuffer.Len() >= 32 {
+		err = addNode(encodingBuffer.Bytes())
+		if err != nil {
+			return err
+		}
+	}
+
+	if n.Kind() == node.Leaf ||
+		len(fullKey) <= len(n.PartialKey) ||
+		!bytes.HasPrefix(fullKey, n.PartialKey) {
+		// The node is the one at the full key, or the full key
+		// diverges from the trie at this node.
+		return nil
+	}
+
+	childIndex := fullKey[len(n.PartialKey)]
+	nextFullKey := fullKey[len(n.PartialKey)+1:]
+	return walkWitness(n.Children[childIndex], nextFullKey, false, addNode)
+}
@@ -0,0 +1,126 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package proof
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/pkg/trie"
+	"github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GenerateWitness(t *testing.T) {
+	t.Parallel()
+
+	keys := []string{
+		"cat",
+		"catapulta",
+		"catapora",
+		"dog",
+		"doguinho",
+	}
+
+	tr := inmemory.NewEmptyTrie()
+
+	for i, key := range keys {
+		value := fmt.Sprintf("%x-%d", key, i)
+		tr.Put([]byte(key), []byte(value))
+	}
+
+	rootHash, err := trie.V0.Hash(tr)
+	require.NoError(t, err)
+
+	db, err := database.NewPebble("", true)
+	require.NoError(t, err)
+	err = tr.WriteDirty(db)
+	require.NoError(t, err)
+
+	// the witness of present keys is the same as their proof
+	for i, key := range keys {
+		fullKeys := [][]byte{[]byte(key)}
+		witness, err := GenerateWitness(rootHash.ToBytes(), fullKeys, nil, db)
+		require.NoError(t, err)
+
+		proof, err := Generate(rootHash.ToBytes(), fullKeys, db)
+		require.NoError(t, err)
+		assert.Equal(t, proof, witness)
+
+		expectedValue := fmt.Sprintf("%x-%d", key, i)
+		err = Verify(witness, rootHash.ToBytes(), []byte(key), []byte(expectedValue))
+		require.NoError(t, err)
+	}
+
+	// absent keys produce the nodes proving their absence
+	absentKeys := [][]byte{[]byte("catapultas"), []byte("cow"), []byte("ca")}
+	_, err = Generate(rootHash.ToBytes(), absentKeys, db)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	witness, err := GenerateWitness(rootHash.ToBytes(), absentKeys, nil, db)
+	require.NoError(t, err)
+	require.NotEmpty(t, witness)
+
+	// nodes shared by the paths of several keys are deduplicated
+	allKeys := [][]byte{[]byte("catapultas"), []byte("cow"), []byte("ca"), []byte("cat"), []byte("dog")}
+	allWitness, err := GenerateWitness(rootHash.ToBytes(), allKeys, nil, db)
+	require.NoError(t, err)
+	assert.Subset(t, allWitness, witness)
+
+	seen := make(map[string]struct{}, len(allWitness))
+	for _, encodedNode := range allWitness {
+		_, duplicate := seen[string(encodedNode)]
+		assert.False(t, duplicate)
+		seen[string(encodedNode)] = struct{}{}
+	}
+}
+
+func Test_GenerateWitness_childTrie(t *testing.T) {
+	t.Parallel()
+
+	tr := inmemory.NewEmptyTrie()
+	tr.Put([]byte("key"), []byte("value"))
+
+	keyToChild := []byte("child")
+	childKeys := []string{"cat", "catapulta", "dog"}
+	for i, key := range childKeys {
+		value := fmt.Sprintf("%x-%d", key, i)
+		err := tr.PutIntoChild(keyToChild, []byte(key), []byte(value))
+		require.NoError(t, err)
+	}
+
+	rootHash, err := trie.V0.Hash(tr)
+	require.NoError(t, err)
+
+	db, err := database.NewPebble("", true)
+	require.NoError(t, err)
+	err = tr.WriteDirty(db)
+	require.NoError(t, err)
+
+	child, err := tr.GetChild(keyToChild)
+	require.NoError(t, err)
+	childRootHash, err := trie.V0.Hash(child)
+	require.NoError(t, err)
+
+	keyToChildInTrie := append([]byte(inmemory.ChildStorageKeyPrefix), keyToChild...)
+	fullKeys := [][]byte{keyToChildInTrie}
+	mainWitness, err := GenerateWitness(rootHash.ToBytes(), fullKeys, nil, db)
+	require.NoError(t, err)
+
+	witness, err := GenerateWitness(rootHash.ToBytes(), fullKeys, map[string][][]byte{
+		string(keyToChild): {[]byte("catapulta"), []byte("cow")},
+		"absent":           {[]byte("cat")},
+	}, db)
+	require.NoError(t, err)
+	assert.Subset(t, witness, mainWitness)
+	assert.Greater(t, len(witness), len(mainWitness))
+
+	// the child trie nodes added to the witness prove the child trie keys
+	err = Verify(witness, childRootHash.ToBytes(), []byte("catapulta"), []byte(fmt.Sprintf("%x-%d", "catapulta", 1)))
+	require.NoError(t, err)
+	err = Verify(witness, rootHash.ToBytes(), keyToChildInTrie, childRootHash.ToBytes())
+	require.NoError(t, err)
+}
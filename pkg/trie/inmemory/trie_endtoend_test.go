@@ -1114,3 +1114,47 @@ func Test_Trie_Descendants_Fuzz(t *testing.T) {
 
 	testDescendants(t, trie.root)
 }
+
+func Test_Trie_Hash_AfterModifications(t *testing.T) {
+	t.Parallel()
+
+	const size, modifications = 5000, 500
+
+	trie, keyValues := makeSeededTrie(t, size)
+	_, err := trie.Hash()
+	require.NoError(t, err)
+
+	// modify and delete keys of the hashed trie, such that only
+	// the modified nodes have to be hashed again.
+	modified := 0
+	for keyString := range keyValues {
+		key := []byte(keyString)
+		if modified%2 == 0 {
+			value := []byte{byte(modified)}
+			keyValues[keyString] = value
+			err = trie.Put(key, value)
+		} else {
+			delete(keyValues, keyString)
+			err = trie.Delete(key)
+		}
+		require.NoError(t, err)
+
+		modified++
+		if modified == modifications {
+			break
+		}
+	}
+
+	hash, err := trie.Hash()
+	require.NoError(t, err)
+
+	expectedTrie := NewEmptyTrie()
+	for keyString, value := range keyValues {
+		err = expectedTrie.Put([]byte(keyString), value)
+		require.NoError(t, err)
+	}
+
+	expectedHash, err := expectedTrie.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, expectedHash, hash)
+}
@@ -8,111 +8,102 @@
 	"fmt"
 	"io"
 	"runtime"
+	"sync"
 
 	"github.com/ChainSafe/gossamer/pkg/scale"
 )
 
-type encodingAsyncResult struct {
-	index  int
+type encodingResult struct {
 	buffer *bytes.Buffer
 	err    error
 }
 
-func runEncodeChild(child *Node, index int, results chan<- encodingAsyncResult, rateLimit <-chan struct{}) {
+func runEncodeChild(child *Node) (result encodingResult) {
 	buffer := bytes.NewBuffer(nil)
 	err := encodeChild(child, buffer)
-
-	results <- encodingAsyncResult{
-		index:  index,
+	return encodingResult{
 		buffer: buffer,
 		err:    err,
 	}
-	if rateLimit != nil {
-		// Only run if runEncodeChild is launched
-		// in its own goroutine.
-		<-rateLimit
-	}
 }
 
+// parallelEncodingMinDescendants is the minimum number of descendants of a branch
+// child for it to be encoded by the encoding workers, since encoding a small
+// branch costs less than handing it over to another goroutine.
+const parallelEncodingMinDescendants = 16
+
 var parallelLimit = runtime.NumCPU()
 
-var parallelEncodingRateLimit = make(chan struct{}, parallelLimit)
+// parallelEncodingWorkers limits the number of goroutines encoding branches
+// in parallel, and is shared by all the tries encoded at the same time.
+var parallelEncodingWorkers = make(chan struct{}, parallelLimit)
+
+// encodeInParallel returns true if the child is worth being encoded
+// in its own goroutine, which is the case for branches with enough
+// descendants and which Merkle value is not already calculated.
+func encodeInParallel(child *Node) bool {
+	return child.Kind() == Branch &&
+		child.MerkleValue == nil &&
+		child.Descendants >= parallelEncodingMinDescendants
+}
 
 // encodeChildrenOpportunisticParallel encodes children in parallel eventually.
-// Leaves are encoded in a blocking way, and branches are encoded in separate
-// goroutines IF they are less than the parallelLimit number of goroutines already
-// running. This is designed to limit the total number of goroutines in order to
-// avoid using too much memory on the stack.
+// Leaves, small branches and children with a cached Merkle value are encoded in
+// a blocking way, and the other branches are encoded in separate goroutines IF
+// less than parallelLimit encoding workers are already running. This is designed
+// to limit the total number of goroutines in order to avoid using too much memory
+// on the stack, while spreading the hashing of large modified subtries over the CPUs.
 func encodeChildrenOpportunisticParallel(children []*Node, buffer io.Writer) (err error) {
-	// Buffered channels since children might be encoded in this
-	// goroutine or another one.
-	resultsCh := make(chan encodingAsyncResult, ChildrenCapacity)
+	results := make([]encodingResult, len(children))
 
+	var wg sync.WaitGroup
 	for i, child := range children {
 		if child == nil {
-			resultsCh <- encodingAsyncResult{index: i}
 			continue
 		}
 
-		if child.Kind() == Leaf {
-			runEncodeChild(child, i, resultsCh, nil)
+		if !encodeInParallel(child) {
+			results[i] = runEncodeChild(child)
 			continue
 		}
 
-		// Branch child
 		select {
-		case parallelEncodingRateLimit <- struct{}{}:
-			// We have a goroutine available to encode
+		case parallelEncodingWorkers <- struct{}{}:
+			// We have a worker available to encode
 			// the branch in parallel.
-			go runEncodeChild(child, i, resultsCh, parallelEncodingRateLimit)
+			wg.Add(1)
+			go func(i int, child *Node) {
+				defer wg.Done()
+				results[i] = runEncodeChild(child)
+				<-parallelEncodingWorkers
+			}(i, child)
 		default:
-			// we reached the maximum parallel goroutines
+			// we reached the maximum parallel workers
 			// so encode this branch in this goroutine
-			runEncodeChild(child, i, resultsCh, nil)
+			results[i] = runEncodeChild(child)
 		}
 	}
 
-	currentIndex := 0
-	indexToBuffer := make(map[int]*bytes.Buffer, ChildrenCapacity)
-	for range children {
-		result := <-resultsCh
-		if result.err != nil && err == nil { // only set the first error we get
-			err = result.err
+	wg.Wait()
+
+	for i, result := range results {
+		if result.err != nil {
+			return result.err
 		}
 
-		indexToBuffer[result.index] = result.buffer
-
-		// write as many completed buffers to the result buffer.
-		for currentIndex < ChildrenCapacity {
-			resultBuffer, done := indexToBuffer[currentIndex]
-			if !done {
-				break
-			}
-
-			delete(indexToBuffer, currentIndex)
-
-			nilChildNode := resultBuffer == nil
-			if nilChildNode {
-				currentIndex++
-				continue
-			}
-
-			bufferSlice := resultBuffer.Bytes()
-			if err == nil && len(bufferSlice) > 0 {
-				// note buffer.Write copies the byte slice given as argument
-				_, writeErr := buffer.Write(bufferSlice)
-				if writeErr != nil && err == nil {
-					err = fmt.Errorf(
-						"cannot write encoding of child at index %d: %w",
-						currentIndex, writeErr)
-				}
-			}
-
-			currentIndex++
+		nilChildNode := result.buffer == nil
+		if nilChildNode {
+			continue
+		}
+
+		// note buffer.Write copies the byte slice given as argument
+		_, err = buffer.Write(result.buffer.Bytes())
+		if err != nil {
+			return fmt.Errorf("cannot write encoding of child at index %d: %w", i, err)
 		}
 	}
 
-	return err
+	return nil
 }
 
 // encodeChild computes the Merkle value of the node
@@ -178,6 +178,36 @@ func Test_encodeChildrenOpportunisticParallel(t *testing.T) {
 			0xc, 0x80, 0x0, 0x0, 0xc, 0x80, 0x0, 0x0}
 		assert.Equal(t, expectedBytes, buffer.Bytes())
 	})
+
+	t.Run("large_branches_parallel_encoding", func(t *testing.T) {
+		t.Parallel()
+
+		children := make([]*Node, ChildrenCapacity)
+		for i := range children {
+			children[i] = &Node{
+				Children:    make([]*Node, ChildrenCapacity),
+				Descendants: parallelEncodingMinDescendants,
+			}
+		}
+
+		buffer := bytes.NewBuffer(nil)
+
+		// Note this may run in parallel or not depending on other tests
+		// running in parallel.
+		err := encodeChildrenOpportunisticParallel(children, buffer)
+
+		require.NoError(t, err)
+		expectedBytes := []byte{
+			0xc, 0x80, 0x0, 0x0, 0xc, 0x80, 0x0, 0x0,
+			0xc, 0x80, 0x0, 0x0, 0xc, 0x80, 0x0, 0x0,
+			0xc, 0x80, 0x0, 0x0, 0xc, 0x80, 0x0, 0x0,
+			0xc, 0x80, 0x0, 0x0, 0xc, 0x80, 0x0, 0x0,
+			0xc, 0x80, 0x0, 0x0, 0xc, 0x80, 0x0, 0x0,
+			0xc, 0x80, 0x0, 0x0, 0xc, 0x80, 0x0, 0x0,
+			0xc, 0x80, 0x0, 0x0, 0xc, 0x80, 0x0, 0x0,
+			0xc, 0x80, 0x0, 0x0, 0xc, 0x80, 0x0, 0x0}
+		assert.Equal(t, expectedBytes, buffer.Bytes())
+	})
 }
 
 func Test_encodeChild(t *testing.T) {
@@ -55,8 +55,11 @@ func hashEncoding(encoding []byte, writer io.Writer) (err error) {
 }
 
 // CalculateMerkleValue returns the Merkle value of the non-root node.
+// The Merkle value is cached in the node until the node is modified and
+// marked as dirty, so only the nodes modified since the last calculation
+// are encoded and hashed again, even if they are not yet written to disk.
 func (n *Node) CalculateMerkleValue() (merkleValue []byte, err error) {
-	if !n.Dirty && n.MerkleValue != nil {
+	if n.MerkleValue != nil {
 		return n.MerkleValue, nil
 	}
 
@@ -71,7 +74,7 @@ func (n *Node) CalculateMerkleValue() (merkleValue []byte, err error) {
 // CalculateRootMerkleValue returns the Merkle value of the root node.
 func (n *Node) CalculateRootMerkleValue() (merkleValue []byte, err error) {
 	const rootMerkleValueLength = 32
-	if !n.Dirty && len(n.MerkleValue) == rootMerkleValueLength {
+	if len(n.MerkleValue) == rootMerkleValueLength {
 		return n.MerkleValue, nil
 	}
 
@@ -183,6 +183,15 @@ func Test_Node_CalculateMerkleValue(t *testing.T) {
 			},
 			merkleValue: []byte{1},
 		},
+		"dirty_node_with_cached_merkle_value": {
+			node: Node{
+				PartialKey:   []byte{1},
+				StorageValue: []byte{1},
+				Dirty:        true,
+				MerkleValue:  []byte{1},
+			},
+			merkleValue: []byte{1},
+		},
 		"small_encoding": {
 			node: Node{
 				PartialKey:   []byte{1},
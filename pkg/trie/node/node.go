@@ -32,6 +32,7 @@ type Node struct {
 	// from the node stored in the database.
 	Dirty bool
 	// MerkleValue is the cached Merkle value of the node.
+	// It is cleared when the node is marked as dirty.
 	MerkleValue []byte
 
 	// Descendants is the number of descendant nodes for
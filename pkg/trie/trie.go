@@ -72,6 +72,7 @@ type TrieRead interface {
 	NextKey(key []byte) []byte
 	GetKeysWithPrefix(prefix []byte) (keysLE [][]byte)
 	PrefixedKeys(prefix []byte) iter.Seq[[]byte]
+	PrefixedKeysFrom(prefix, startKey []byte) iter.Seq[[]byte]
 	KeysFrom(key []byte) iter.Seq[[]byte]
 }
 
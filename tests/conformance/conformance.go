@@ -0,0 +1,96 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package conformance runs the host API and genesis tests of the polkadot-tests
+// specification conformance suite against the gossamer host, in-process.
+package conformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Status is the outcome of a conformance test
+type Status string
+
+const (
+	// StatusPassed is the status of a test matching the specification
+	StatusPassed Status = "passed"
+	// StatusFailed is the status of a test not matching the specification
+	StatusFailed Status = "failed"
+	// StatusSkipped is the status of a test which could not be run
+	StatusSkipped Status = "skipped"
+)
+
+// Result is the result of a conformance test
+type Result struct {
+	Suite    string        `json:"suite"`
+	Name     string        `json:"name"`
+	Status   Status        `json:"status"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"durationNanoseconds"`
+}
+
+// Report is the machine-readable report of a conformance run
+type Report struct {
+	Passed  int      `json:"passed"`
+	Failed  int      `json:"failed"`
+	Skipped int      `json:"skipped"`
+	Results []Result `json:"results"`
+}
+
+// Add adds the results to the report and updates its counters
+func (r *Report) Add(results ...Result) {
+	for _, result := range results {
+		switch result.Status {
+		case StatusPassed:
+			r.Passed++
+		case StatusFailed:
+			r.Failed++
+		case StatusSkipped:
+			r.Skipped++
+		}
+		r.Results = append(r.Results, result)
+	}
+}
+
+// WriteJSON writes the report as JSON to the file at the given path
+func (r *Report) WriteJSON(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding report: %w", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("creating report directory: %w", err)
+	}
+
+	const perm = os.FileMode(0644)
+	err = os.WriteFile(path, data, perm)
+	if err != nil {
+		return fmt.Errorf("writing report: %w", err)
+	}
+
+	return nil
+}
+
+// newResult returns the result of the test from the error it failed with, if any
+func newResult(suite, name string, start time.Time, err error) Result {
+	result := Result{
+		Suite:    suite,
+		Name:     name,
+		Status:   StatusPassed,
+		Duration: time.Since(start),
+	}
+
+	if err != nil {
+		result.Status = StatusFailed
+		result.Error = err.Error()
+	}
+
+	return result
+}
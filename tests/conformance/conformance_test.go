@@ -0,0 +1,123 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/tests/utils"
+	"github.com/stretchr/testify/require"
+)
+
+// The conformance suite is configured with the following environment variables:
+// - CONFORMANCE_FIXTURES is the directory of the hostapi.json and genesis.json fixtures,
+// defaulting to the testdata directory.
+// - CONFORMANCE_RUNTIME is the path of the tester runtime wasm file, defaulting to the
+// host API tester runtime downloaded from the polkadot-spec repository.
+// - CONFORMANCE_REPORT is the path of the JSON report written, no report is written if unset.
+var (
+	fixturesDir = getEnv("CONFORMANCE_FIXTURES", "testdata")
+	runtimePath = getEnv("CONFORMANCE_RUNTIME", runtime.HOST_API_TEST_RUNTIME)
+	reportPath  = os.Getenv("CONFORMANCE_REPORT")
+)
+
+func TestMain(m *testing.M) {
+	if utils.MODE != "conformance" {
+		fmt.Println("Going to skip conformance suite tests")
+		os.Exit(0)
+	}
+
+	os.Exit(m.Run())
+}
+
+func TestConformance(t *testing.T) {
+	report := new(Report)
+
+	t.Run(HostAPISuite, func(t *testing.T) {
+		fixtures, err := LoadHostAPIFixtures(filepath.Join(fixturesDir, "hostapi.json"))
+		require.NoError(t, err)
+
+		code, err := loadTesterRuntime(runtimePath)
+		if err != nil {
+			results := skippedResults(HostAPISuite, len(fixtures), func(i int) string {
+				return fixtures[i].Name
+			}, err)
+			report.Add(results...)
+			checkResults(t, results)
+			return
+		}
+
+		results := RunHostAPI(context.Background(), code, fixtures)
+		report.Add(results...)
+		checkResults(t, results)
+	})
+
+	t.Run(GenesisSuite, func(t *testing.T) {
+		fixtures, err := LoadGenesisFixtures(filepath.Join(fixturesDir, "genesis.json"))
+		require.NoError(t, err)
+
+		results := RunGenesis(fixtures)
+		report.Add(results...)
+		checkResults(t, results)
+	})
+
+	t.Logf("conformance: %d passed, %d failed, %d skipped",
+		report.Passed, report.Failed, report.Skipped)
+
+	if reportPath != "" {
+		err := report.WriteJSON(reportPath)
+		require.NoError(t, err)
+	}
+}
+
+func loadTesterRuntime(runtimePath string) (code []byte, err error) {
+	runtimePath, err = runtime.GetRuntime(context.Background(), runtimePath)
+	if err != nil {
+		return nil, fmt.Errorf("getting tester runtime: %w", err)
+	}
+
+	return os.ReadFile(filepath.Clean(runtimePath))
+}
+
+func skippedResults(suite string, count int, name func(i int) string, err error) (results []Result) {
+	results = make([]Result, count)
+	for i := range results {
+		results[i] = Result{
+			Suite:  suite,
+			Name:   name(i),
+			Status: StatusSkipped,
+			Error:  err.Error(),
+		}
+	}
+	return results
+}
+
+func checkResults(t *testing.T, results []Result) {
+	t.Helper()
+
+	for _, result := range results {
+		result := result
+		t.Run(result.Name, func(t *testing.T) {
+			switch result.Status {
+			case StatusSkipped:
+				t.Skip(result.Error)
+			case StatusFailed:
+				t.Error(result.Error)
+			}
+		})
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package conformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// HostAPIFixture is a host API test, made of runtime calls sharing the same state.
+type HostAPIFixture struct {
+	Name  string        `json:"name"`
+	Calls []HostAPICall `json:"calls"`
+}
+
+// HostAPICall calls the tester runtime function wrapping a host API function.
+type HostAPICall struct {
+	// Function is the name of the host API function, such as ext_storage_get_version_1
+	Function string `json:"function"`
+	// Inputs are the 0x prefixed hex encoded SCALE arguments of the function
+	Inputs []string `json:"inputs"`
+	// Output is the 0x prefixed hex encoded SCALE output expected,
+	// the output is not checked if it is nil.
+	Output *string `json:"output,omitempty"`
+}
+
+// GenesisFixture is a genesis test, checking the genesis block built from a raw chain spec.
+type GenesisFixture struct {
+	Name string `json:"name"`
+	// ChainSpec is the path of the raw chain spec, relative to the fixtures file
+	ChainSpec string `json:"chainSpec"`
+	// StateRoot is the 0x prefixed hex encoded state root expected, not checked if empty
+	StateRoot string `json:"stateRoot,omitempty"`
+	// Hash is the 0x prefixed hex encoded genesis block hash expected
+	Hash string `json:"hash"`
+}
+
+// LoadHostAPIFixtures loads the host API fixtures from the JSON file at the given path
+func LoadHostAPIFixtures(path string) (fixtures []HostAPIFixture, err error) {
+	err = loadJSON(path, &fixtures)
+	if err != nil {
+		return nil, err
+	}
+	return fixtures, nil
+}
+
+// LoadGenesisFixtures loads the genesis fixtures from the JSON file at the given path
+func LoadGenesisFixtures(path string) (fixtures []GenesisFixture, err error) {
+	err = loadJSON(path, &fixtures)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range fixtures {
+		if !filepath.IsAbs(fixtures[i].ChainSpec) {
+			fixtures[i].ChainSpec = filepath.Join(filepath.Dir(path), fixtures[i].ChainSpec)
+		}
+	}
+
+	return fixtures, nil
+}
+
+func loadJSON(path string, v any) error {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return fmt.Errorf("reading fixtures: %w", err)
+	}
+
+	err = json.Unmarshal(data, v)
+	if err != nil {
+		return fmt.Errorf("decoding fixtures from %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package conformance
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/genesis"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+)
+
+// GenesisSuite is the name of the genesis test suite
+const GenesisSuite = "genesis"
+
+var (
+	errStateRootMismatch   = errors.New("state root does not match")
+	errGenesisHashMismatch = errors.New("genesis hash does not match")
+)
+
+// RunGenesis runs each genesis fixture, building the genesis block from its raw
+// chain spec the same way the node does when initialising its database.
+func RunGenesis(fixtures []GenesisFixture) (results []Result) {
+	results = make([]Result, len(fixtures))
+	for i, fixture := range fixtures {
+		start := time.Now()
+		err := runGenesisFixture(fixture)
+		results[i] = newResult(GenesisSuite, fixture.Name, start, err)
+	}
+	return results
+}
+
+func runGenesisFixture(fixture GenesisFixture) error {
+	gen, err := genesis.NewGenesisFromJSONRaw(fixture.ChainSpec)
+	if err != nil {
+		return fmt.Errorf("loading chain spec: %w", err)
+	}
+
+	genesisTrie, err := runtime.NewTrieFromGenesis(*gen)
+	if err != nil {
+		return fmt.Errorf("building genesis trie: %w", err)
+	}
+
+	header, err := runtime.GenesisBlockFromTrie(genesisTrie)
+	if err != nil {
+		return fmt.Errorf("building genesis block: %w", err)
+	}
+
+	if fixture.StateRoot != "" {
+		expected, err := common.HexToHash(fixture.StateRoot)
+		if err != nil {
+			return fmt.Errorf("decoding state root: %w", err)
+		}
+		if header.StateRoot != expected {
+			return fmt.Errorf("%w: expected %s, got %s", errStateRootMismatch, expected, header.StateRoot)
+		}
+	}
+
+	expected, err := common.HexToHash(fixture.Hash)
+	if err != nil {
+		return fmt.Errorf("decoding genesis hash: %w", err)
+	}
+	if hash := header.Hash(); hash != expected {
+		return fmt.Errorf("%w: expected %s, got %s", errGenesisHashMismatch, expected, hash)
+	}
+
+	return nil
+}
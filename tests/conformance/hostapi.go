@@ -0,0 +1,129 @@
+// Copyright 2024 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package conformance
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ChainSafe/gossamer/internal/database"
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/runtime/storage"
+	wazero_runtime "github.com/ChainSafe/gossamer/lib/runtime/wazero"
+	inmemory_trie "github.com/ChainSafe/gossamer/pkg/trie/inmemory"
+)
+
+// HostAPISuite is the name of the host API test suite
+const HostAPISuite = "host-api"
+
+var errOutputMismatch = errors.New("output does not match")
+
+// testerRuntimeVersion is the version given to the host for the tester runtime,
+// which does not export Core_version.
+var testerRuntimeVersion = &runtime.Version{
+	SpecName: []byte("polkadot-tests-tester"),
+	ImplName: []byte("polkadot-tests-tester"),
+}
+
+// RunHostAPI runs each host API fixture against a new gossamer host instantiating
+// the tester runtime code given, which exports a rtm_<function> wrapper for each
+// host API function.
+func RunHostAPI(ctx context.Context, code []byte, fixtures []HostAPIFixture) (results []Result) {
+	results = make([]Result, len(fixtures))
+	for i, fixture := range fixtures {
+		start := time.Now()
+		err := runHostAPIFixture(ctx, code, fixture)
+		results[i] = newResult(HostAPISuite, fixture.Name, start, err)
+	}
+	return results
+}
+
+func runHostAPIFixture(ctx context.Context, code []byte, fixture HostAPIFixture) (err error) {
+	nodeStorage, closeNodeStorage, err := newInMemoryNodeStorage()
+	if err != nil {
+		return fmt.Errorf("creating node storage: %w", err)
+	}
+	defer closeNodeStorage()
+
+	cfg := wazero_runtime.Config{
+		Storage:        storage.NewTrieState(inmemory_trie.NewEmptyTrie()),
+		Keystore:       keystore.NewGlobalKeystore(),
+		LogLvl:         log.Critical,
+		Role:           common.NoNetworkRole,
+		NodeStorage:    nodeStorage,
+		Network:        new(runtime.TestRuntimeNetwork),
+		DefaultVersion: testerRuntimeVersion,
+	}
+
+	instance, err := wazero_runtime.NewInstance(code, cfg)
+	if err != nil {
+		return fmt.Errorf("instantiating tester runtime: %w", err)
+	}
+	defer instance.Stop()
+
+	for i, call := range fixture.Calls {
+		var input []byte
+		for _, hexInput := range call.Inputs {
+			encoded, err := common.HexToBytes(hexInput)
+			if err != nil {
+				return fmt.Errorf("decoding input of call %d to %s: %w", i, call.Function, err)
+			}
+			input = append(input, encoded...)
+		}
+
+		output, err := instance.ExecContext(ctx, "rtm_"+call.Function, input)
+		if err != nil {
+			return fmt.Errorf("call %d to %s: %w", i, call.Function, err)
+		}
+
+		if call.Output == nil {
+			continue
+		}
+
+		expected, err := common.HexToBytes(*call.Output)
+		if err != nil {
+			return fmt.Errorf("decoding output of call %d to %s: %w", i, call.Function, err)
+		}
+
+		if !bytes.Equal(expected, output) {
+			return fmt.Errorf("%w: call %d to %s: expected %s, got %s",
+				errOutputMismatch, i, call.Function, *call.Output, common.BytesToHex(output))
+		}
+	}
+
+	return nil
+}
+
+// newInMemoryNodeStorage returns the offchain node storage of the host, along with
+// the function closing its databases.
+func newInMemoryNodeStorage() (nodeStorage runtime.NodeStorage, closeAll func(), err error) {
+	var databases []*database.PebbleDB
+	closeAll = func() {
+		for _, db := range databases {
+			_ = db.Close()
+		}
+	}
+
+	for range 3 {
+		db, err := database.NewPebble("", true)
+		if err != nil {
+			closeAll()
+			return nodeStorage, nil, err
+		}
+		databases = append(databases, db)
+	}
+
+	nodeStorage = runtime.NodeStorage{
+		LocalStorage:      databases[0],
+		PersistentStorage: databases[1],
+		BaseDB:            databases[2],
+	}
+	return nodeStorage, closeAll, nil
+}
@@ -64,9 +64,10 @@ func Default() cfg.Config {
 				"system", "author", "chain", "state", "rpc",
 				"grandpa", "offchain", "childstate", "syncstate", "payment"},
 		},
-		State:  &cfg.StateConfig{},
-		Pprof:  &cfg.PprofConfig{},
-		System: &cfg.SystemConfig{},
+		State:    &cfg.StateConfig{},
+		Pprof:    &cfg.PprofConfig{},
+		Collator: &cfg.CollatorConfig{},
+		System:   &cfg.SystemConfig{},
 	}
 }
 